@@ -28,9 +28,26 @@ import (
 type RedisClusterSpec struct {
 	runtimev1alpha1.ResourceClaimSpec `json:",inline"`
 
+	// ClassSelector selects the resource class of this claim by its labels
+	// when no class reference is set. If several classes match, the one with
+	// the highest class weight annotation is selected.
+	// +optional
+	ClassSelector *metav1.LabelSelector `json:"classSelector,omitempty"`
+
 	// EngineVersion specifies the desired Redis version.
 	// +kubebuilder:validation:Enum="2.6";"2.8";"3.2";"4.0";"5.0"
 	EngineVersion string `json:"engineVersion"`
+
+	// MemoryGB is the memory, in GB, requested of the cluster. The resource
+	// class must allow claims to request it.
+	// +optional
+	MemoryGB *int64 `json:"memoryGB,omitempty"`
+
+	// InstanceSize is the portable size of the requested cluster nodes, e.g.
+	// small or large. The resource class must allow claims to request it, and
+	// maps it to a provider specific node type.
+	// +optional
+	InstanceSize string `json:"instanceSize,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -65,6 +82,11 @@ func (rc *RedisCluster) SetConditions(c ...runtimev1alpha1.Condition) {
 	rc.Status.SetConditions(c...)
 }
 
+// GetCondition of this RedisCluster.
+func (rc *RedisCluster) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return rc.Status.GetCondition(ct)
+}
+
 // SetClassReference of this RedisCluster.
 func (rc *RedisCluster) SetClassReference(r *corev1.ObjectReference) {
 	rc.Spec.ClassReference = r
@@ -75,6 +97,11 @@ func (rc *RedisCluster) GetClassReference() *corev1.ObjectReference {
 	return rc.Spec.ClassReference
 }
 
+// GetClassSelector of this RedisCluster.
+func (rc *RedisCluster) GetClassSelector() *metav1.LabelSelector {
+	return rc.Spec.ClassSelector
+}
+
 // SetResourceReference of this RedisCluster.
 func (rc *RedisCluster) SetResourceReference(r *corev1.ObjectReference) {
 	rc.Spec.ResourceReference = r
@@ -47,6 +47,10 @@ var (
 	RedisClusterGroupVersionKind = SchemeGroupVersion.WithKind(RedisClusterKind)
 )
 
+// RedisClusterSecretDefinition is the name of the CustomSecretDefinition that
+// RedisCluster connection secrets must satisfy.
+const RedisClusterSecretDefinition = "rediscluster.cache.crossplane.io"
+
 // RedisClusterPolicy type metadata.
 var (
 	RedisClusterPolicyKind             = reflect.TypeOf(RedisClusterPolicy{}).Name()
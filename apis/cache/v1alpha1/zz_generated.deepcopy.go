@@ -21,6 +21,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -145,6 +146,16 @@ func (in *RedisClusterPolicyList) DeepCopyObject() runtime.Object {
 func (in *RedisClusterSpec) DeepCopyInto(out *RedisClusterSpec) {
 	*out = *in
 	in.ResourceClaimSpec.DeepCopyInto(&out.ResourceClaimSpec)
+	if in.ClassSelector != nil {
+		in, out := &in.ClassSelector, &out.ClassSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MemoryGB != nil {
+		in, out := &in.MemoryGB, &out.MemoryGB
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisClusterSpec.
@@ -47,6 +47,10 @@ var (
 	KubernetesClusterGroupVersionKind = SchemeGroupVersion.WithKind(KubernetesClusterKind)
 )
 
+// KubernetesClusterSecretDefinition is the name of the CustomSecretDefinition that
+// KubernetesCluster connection secrets must satisfy.
+const KubernetesClusterSecretDefinition = "kubernetescluster.compute.crossplane.io"
+
 // KubernetesClusterPolicy type metadata.
 var (
 	KubernetesClusterPolicyKind             = reflect.TypeOf(KubernetesClusterPolicy{}).Name()
@@ -28,8 +28,25 @@ import (
 type KubernetesClusterSpec struct {
 	runtimev1alpha1.ResourceClaimSpec `json:",inline"`
 
+	// ClassSelector selects the resource class of this claim by its labels
+	// when no class reference is set. If several classes match, the one with
+	// the highest class weight annotation is selected.
+	// +optional
+	ClassSelector *metav1.LabelSelector `json:"classSelector,omitempty"`
+
 	// cluster properties
 	ClusterVersion string `json:"clusterVersion,omitempty"`
+
+	// NodeCount is the number of nodes requested of the cluster. The resource
+	// class must allow claims to request it.
+	// +optional
+	NodeCount *int64 `json:"nodeCount,omitempty"`
+
+	// InstanceSize is the portable size of the requested nodes, e.g. small or
+	// large. The resource class must allow claims to request it, and maps it
+	// to a provider specific machine type.
+	// +optional
+	InstanceSize string `json:"instanceSize,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -63,6 +80,11 @@ func (kc *KubernetesCluster) SetConditions(c ...runtimev1alpha1.Condition) {
 	kc.Status.SetConditions(c...)
 }
 
+// GetCondition of this KubernetesCluster.
+func (kc *KubernetesCluster) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return kc.Status.GetCondition(ct)
+}
+
 // SetClassReference of this KubernetesCluster.
 func (kc *KubernetesCluster) SetClassReference(r *corev1.ObjectReference) {
 	kc.Spec.ClassReference = r
@@ -73,6 +95,11 @@ func (kc *KubernetesCluster) GetClassReference() *corev1.ObjectReference {
 	return kc.Spec.ClassReference
 }
 
+// GetClassSelector of this KubernetesCluster.
+func (kc *KubernetesCluster) GetClassSelector() *metav1.LabelSelector {
+	return kc.Spec.ClassSelector
+}
+
 // SetResourceReference of this KubernetesCluster.
 func (kc *KubernetesCluster) SetResourceReference(r *corev1.ObjectReference) {
 	kc.Spec.ResourceReference = r
@@ -21,6 +21,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -145,6 +146,16 @@ func (in *KubernetesClusterPolicyList) DeepCopyObject() runtime.Object {
 func (in *KubernetesClusterSpec) DeepCopyInto(out *KubernetesClusterSpec) {
 	*out = *in
 	in.ResourceClaimSpec.DeepCopyInto(&out.ResourceClaimSpec)
+	if in.ClassSelector != nil {
+		in, out := &in.ClassSelector, &out.ClassSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeCount != nil {
+		in, out := &in.NodeCount, &out.NodeCount
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesClusterSpec.
@@ -0,0 +1,163 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Error strings.
+const (
+	errFmtNotAllowed   = "resource class does not allow claims to request %s"
+	errFmtOutOfRange   = "requested %s %d is not allowed by the resource class, which requires %s"
+	errFmtUnknownSize  = "requested instance size %q is not allowed by the resource class, which allows %s"
+	errFmtNotSupported = "%s does not support claims that request %s"
+)
+
+// Claim parameter names.
+const (
+	ClaimParameterStorageGB    = "storageGB"
+	ClaimParameterNodeCount    = "nodeCount"
+	ClaimParameterMemoryGB     = "memoryGB"
+	ClaimParameterInstanceSize = "instanceSize"
+)
+
+// An Int64Range bounds an integer claim parameter. Either bound may be
+// omitted.
+type Int64Range struct {
+	// Min is the smallest allowed value, inclusive.
+	// +optional
+	Min *int64 `json:"min,omitempty"`
+
+	// Max is the largest allowed value, inclusive.
+	// +optional
+	Max *int64 `json:"max,omitempty"`
+}
+
+// Contains returns true if the supplied value falls within this range.
+func (r Int64Range) Contains(v int64) bool {
+	if r.Min != nil && v < *r.Min {
+		return false
+	}
+	if r.Max != nil && v > *r.Max {
+		return false
+	}
+	return true
+}
+
+// String describes this range.
+func (r Int64Range) String() string {
+	switch {
+	case r.Min != nil && r.Max != nil:
+		return fmt.Sprintf("between %d and %d", *r.Min, *r.Max)
+	case r.Min != nil:
+		return fmt.Sprintf("at least %d", *r.Min)
+	case r.Max != nil:
+		return fmt.Sprintf("at most %d", *r.Max)
+	default:
+		return "any value"
+	}
+}
+
+// A ClaimParameterPolicy specifies which portable parameters resource claims
+// may request of a resource class, and the values they may request. Claims
+// may not request a parameter the policy omits.
+type ClaimParameterPolicy struct {
+	// StorageGB bounds the storage, in GB, that claims may request.
+	// +optional
+	StorageGB *Int64Range `json:"storageGB,omitempty"`
+
+	// NodeCount bounds the number of nodes that claims may request.
+	// +optional
+	NodeCount *Int64Range `json:"nodeCount,omitempty"`
+
+	// MemoryGB bounds the memory, in GB, that claims may request.
+	// +optional
+	MemoryGB *Int64Range `json:"memoryGB,omitempty"`
+
+	// InstanceSizes maps each portable instance size claims may request, e.g.
+	// small or large, to the provider specific instance type, tier, or
+	// machine type that it represents.
+	// +optional
+	InstanceSizes map[string]string `json:"instanceSizes,omitempty"`
+}
+
+// AllowStorageGB returns an error unless this policy allows claims to request
+// the supplied storage.
+func (p *ClaimParameterPolicy) AllowStorageGB(v int64) error {
+	if p == nil {
+		return allow(ClaimParameterStorageGB, nil, v)
+	}
+	return allow(ClaimParameterStorageGB, p.StorageGB, v)
+}
+
+// AllowNodeCount returns an error unless this policy allows claims to request
+// the supplied number of nodes.
+func (p *ClaimParameterPolicy) AllowNodeCount(v int64) error {
+	if p == nil {
+		return allow(ClaimParameterNodeCount, nil, v)
+	}
+	return allow(ClaimParameterNodeCount, p.NodeCount, v)
+}
+
+// AllowMemoryGB returns an error unless this policy allows claims to request
+// the supplied memory.
+func (p *ClaimParameterPolicy) AllowMemoryGB(v int64) error {
+	if p == nil {
+		return allow(ClaimParameterMemoryGB, nil, v)
+	}
+	return allow(ClaimParameterMemoryGB, p.MemoryGB, v)
+}
+
+// InstanceType returns the provider specific instance type of the supplied
+// portable instance size, or an error if this policy does not allow claims to
+// request it.
+func (p *ClaimParameterPolicy) InstanceType(size string) (string, error) {
+	if p == nil || len(p.InstanceSizes) == 0 {
+		return "", errors.Errorf(errFmtNotAllowed, ClaimParameterInstanceSize)
+	}
+	t, ok := p.InstanceSizes[size]
+	if !ok {
+		sizes := make([]string, 0, len(p.InstanceSizes))
+		for s := range p.InstanceSizes {
+			sizes = append(sizes, s)
+		}
+		sort.Strings(sizes)
+		return "", errors.Errorf(errFmtUnknownSize, size, strings.Join(sizes, ", "))
+	}
+	return t, nil
+}
+
+func allow(name string, r *Int64Range, v int64) error {
+	if r == nil {
+		return errors.Errorf(errFmtNotAllowed, name)
+	}
+	if !r.Contains(v) {
+		return errors.Errorf(errFmtOutOfRange, name, v, r)
+	}
+	return nil
+}
+
+// NewNotSupportedError returns an error indicating that the supplied kind of
+// resource class cannot satisfy claims that request the supplied parameter.
+func NewNotSupportedError(classKind, parameter string) error {
+	return errors.Errorf(errFmtNotSupported, classKind, parameter)
+}
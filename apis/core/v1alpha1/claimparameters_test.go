@@ -0,0 +1,120 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+)
+
+func int64Ptr(v int64) *int64 { return &v }
+
+func TestAllowStorageGB(t *testing.T) {
+	cases := map[string]struct {
+		p    *ClaimParameterPolicy
+		v    int64
+		want error
+	}{
+		"NilPolicy": {
+			p:    nil,
+			v:    10,
+			want: errors.Errorf(errFmtNotAllowed, ClaimParameterStorageGB),
+		},
+		"ParameterOmitted": {
+			p:    &ClaimParameterPolicy{NodeCount: &Int64Range{}},
+			v:    10,
+			want: errors.Errorf(errFmtNotAllowed, ClaimParameterStorageGB),
+		},
+		"Unbounded": {
+			p: &ClaimParameterPolicy{StorageGB: &Int64Range{}},
+			v: 10,
+		},
+		"WithinRange": {
+			p: &ClaimParameterPolicy{StorageGB: &Int64Range{Min: int64Ptr(10), Max: int64Ptr(100)}},
+			v: 100,
+		},
+		"BelowMin": {
+			p:    &ClaimParameterPolicy{StorageGB: &Int64Range{Min: int64Ptr(20)}},
+			v:    10,
+			want: errors.Errorf(errFmtOutOfRange, ClaimParameterStorageGB, 10, "at least 20"),
+		},
+		"AboveMax": {
+			p:    &ClaimParameterPolicy{StorageGB: &Int64Range{Min: int64Ptr(10), Max: int64Ptr(100)}},
+			v:    200,
+			want: errors.Errorf(errFmtOutOfRange, ClaimParameterStorageGB, 200, "between 10 and 100"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := tc.p.AllowStorageGB(tc.v)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.p.AllowStorageGB(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInstanceType(t *testing.T) {
+	type want struct {
+		t   string
+		err error
+	}
+
+	cases := map[string]struct {
+		p    *ClaimParameterPolicy
+		size string
+		want want
+	}{
+		"NilPolicy": {
+			p:    nil,
+			size: "small",
+			want: want{err: errors.Errorf(errFmtNotAllowed, ClaimParameterInstanceSize)},
+		},
+		"NoInstanceSizes": {
+			p:    &ClaimParameterPolicy{},
+			size: "small",
+			want: want{err: errors.Errorf(errFmtNotAllowed, ClaimParameterInstanceSize)},
+		},
+		"KnownSize": {
+			p:    &ClaimParameterPolicy{InstanceSizes: map[string]string{"small": "db-n1-standard-1"}},
+			size: "small",
+			want: want{t: "db-n1-standard-1"},
+		},
+		"UnknownSize": {
+			p:    &ClaimParameterPolicy{InstanceSizes: map[string]string{"small": "a", "large": "b"}},
+			size: "huge",
+			want: want{err: errors.Errorf(errFmtUnknownSize, "huge", "large, small")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.p.InstanceType(tc.size)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.p.InstanceType(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.t, got); diff != "" {
+				t.Errorf("tc.p.InstanceType(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Error strings.
+const (
+	errFmtMissingKeys = "connection secret is missing required keys: %s"
+	errFmtEmptyKeys   = "connection secret has empty values for required keys: %s"
+	errFmtUnknownKeys = "connection secret has keys not declared by %s: %s"
+	errFmtWrongType   = "connection secret has type %q, but %s requires type %q"
+)
+
+// CustomSecretDefinitionNames specifies the names used to identify a kind of
+// secret.
+type CustomSecretDefinitionNames struct {
+	// Kind is the kind of secret, for example mysql.
+	Kind string `json:"kind"`
+}
+
+// SecretKeySchema describes a key of a secret.
+type SecretKeySchema struct {
+	// Type of the key's value. Secret values are always strings.
+	// +kubebuilder:validation:Enum=string
+	Type string `json:"type,omitempty"`
+
+	// Description of the key's value.
+	Description string `json:"description,omitempty"`
+}
+
+// SecretSchema describes the keys of a secret.
+type SecretSchema struct {
+	// Properties are the keys a secret may contain.
+	Properties map[string]SecretKeySchema `json:"properties,omitempty"`
+
+	// Required keys must be present, and have a non-empty value.
+	Required []string `json:"required,omitempty"`
+
+	// AdditionalProperties specifies whether a secret may contain keys that
+	// are not declared by its properties.
+	AdditionalProperties bool `json:"additionalProperties,omitempty"`
+}
+
+// CustomSecretDefinitionValidation specifies how secrets of a kind are
+// validated.
+type CustomSecretDefinitionValidation struct {
+	// OpenAPIV3Schema is the schema secrets of this kind must satisfy.
+	OpenAPIV3Schema SecretSchema `json:"openAPIV3Schema"`
+}
+
+// CustomSecretDefinitionSpec specifies a kind of secret.
+type CustomSecretDefinitionSpec struct {
+	// Group is the API group of this kind of secret, for example
+	// database.crossplane.io.
+	Group string `json:"group"`
+
+	// Names used to identify this kind of secret.
+	Names CustomSecretDefinitionNames `json:"names"`
+
+	// Version of this kind of secret, for example v1alpha1.
+	Version string `json:"version"`
+
+	// Validation specifies the schema secrets of this kind must satisfy.
+	Validation CustomSecretDefinitionValidation `json:"validation"`
+}
+
+// +kubebuilder:object:root=true
+
+// A CustomSecretDefinition declares the schema of a kind of secret, for
+// example the connection secrets of a kind of resource claim. Secrets of the
+// defined kind have the type returned by SecretType.
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="GROUP",type="string",JSONPath=".spec.group"
+// +kubebuilder:printcolumn:name="KIND",type="string",JSONPath=".spec.names.kind"
+// +kubebuilder:printcolumn:name="VERSION",type="string",JSONPath=".spec.version"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+type CustomSecretDefinition struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CustomSecretDefinitionSpec `json:"spec"`
+}
+
+// SecretType returns the type of the secrets defined by this
+// CustomSecretDefinition, for example mysql.v1alpha1.database.crossplane.io.
+func (d *CustomSecretDefinition) SecretType() corev1.SecretType {
+	return corev1.SecretType(strings.Join([]string{d.Spec.Names.Kind, d.Spec.Version, d.Spec.Group}, "."))
+}
+
+// Validate returns an error if the supplied secret does not satisfy the
+// schema of this CustomSecretDefinition. Secrets with an empty type are
+// validated as if they were of the defined type.
+func (d *CustomSecretDefinition) Validate(s *corev1.Secret) error {
+	if s.Type != "" && s.Type != d.SecretType() {
+		return errors.Errorf(errFmtWrongType, s.Type, d.GetName(), d.SecretType())
+	}
+
+	schema := d.Spec.Validation.OpenAPIV3Schema
+
+	missing, empty := []string{}, []string{}
+	for _, k := range schema.Required {
+		v, ok := s.Data[k]
+		switch {
+		case !ok:
+			missing = append(missing, k)
+		case len(v) == 0:
+			empty = append(empty, k)
+		}
+	}
+	if len(missing) > 0 {
+		return errors.Errorf(errFmtMissingKeys, strings.Join(missing, ", "))
+	}
+	if len(empty) > 0 {
+		return errors.Errorf(errFmtEmptyKeys, strings.Join(empty, ", "))
+	}
+
+	if schema.AdditionalProperties {
+		return nil
+	}
+	unknown := []string{}
+	for k := range s.Data {
+		if _, ok := schema.Properties[k]; !ok {
+			unknown = append(unknown, k)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return errors.Errorf(errFmtUnknownKeys, d.GetName(), strings.Join(unknown, ", "))
+	}
+
+	return nil
+}
+
+// +kubebuilder:object:root=true
+
+// CustomSecretDefinitionList contains a list of CustomSecretDefinitions.
+type CustomSecretDefinitionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CustomSecretDefinition `json:"items"`
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+)
+
+func TestCustomSecretDefinitionValidate(t *testing.T) {
+	d := &CustomSecretDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "mysql.database.crossplane.io"},
+		Spec: CustomSecretDefinitionSpec{
+			Group:   "database.crossplane.io",
+			Names:   CustomSecretDefinitionNames{Kind: "mysql"},
+			Version: "v1alpha1",
+			Validation: CustomSecretDefinitionValidation{
+				OpenAPIV3Schema: SecretSchema{
+					Properties: map[string]SecretKeySchema{"endpoint": {}, "username": {}, "password": {}},
+					Required:   []string{"username", "password"},
+				},
+			},
+		},
+	}
+
+	cases := map[string]struct {
+		s    *corev1.Secret
+		want error
+	}{
+		"Valid": {
+			s: &corev1.Secret{
+				Type: "mysql.v1alpha1.database.crossplane.io",
+				Data: map[string][]byte{"username": []byte("u"), "password": []byte("p")},
+			},
+		},
+		"Untyped": {
+			s: &corev1.Secret{Data: map[string][]byte{"username": []byte("u"), "password": []byte("p")}},
+		},
+		"WrongType": {
+			s:    &corev1.Secret{Type: corev1.SecretTypeOpaque},
+			want: errors.Errorf(errFmtWrongType, corev1.SecretTypeOpaque, "mysql.database.crossplane.io", "mysql.v1alpha1.database.crossplane.io"),
+		},
+		"MissingKeys": {
+			s:    &corev1.Secret{Data: map[string][]byte{"endpoint": []byte("e")}},
+			want: errors.Errorf(errFmtMissingKeys, "username, password"),
+		},
+		"EmptyKeys": {
+			s:    &corev1.Secret{Data: map[string][]byte{"username": []byte("u"), "password": {}}},
+			want: errors.Errorf(errFmtEmptyKeys, "password"),
+		},
+		"UnknownKeys": {
+			s: &corev1.Secret{Data: map[string][]byte{
+				"username": []byte("u"),
+				"password": []byte("p"),
+				"port":     []byte("3306"),
+				"cert":     []byte("c"),
+			}},
+			want: errors.Errorf(errFmtUnknownKeys, "mysql.database.crossplane.io", "cert, port"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := d.Validate(tc.s)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("d.Validate(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
@@ -47,6 +47,14 @@ var (
 	ResourceClassGroupVersionKind = SchemeGroupVersion.WithKind(ResourceClassKind)
 )
 
+// CustomSecretDefinition type metadata.
+var (
+	CustomSecretDefinitionKind             = reflect.TypeOf(CustomSecretDefinition{}).Name()
+	CustomSecretDefinitionKindAPIVersion   = CustomSecretDefinitionKind + "." + SchemeGroupVersion.String()
+	CustomSecretDefinitionGroupVersionKind = SchemeGroupVersion.WithKind(CustomSecretDefinitionKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&ResourceClass{}, &ResourceClassList{})
+	SchemeBuilder.Register(&CustomSecretDefinition{}, &CustomSecretDefinitionList{})
 }
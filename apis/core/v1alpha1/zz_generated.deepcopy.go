@@ -25,6 +25,174 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClaimParameterPolicy) DeepCopyInto(out *ClaimParameterPolicy) {
+	*out = *in
+	if in.StorageGB != nil {
+		in, out := &in.StorageGB, &out.StorageGB
+		*out = new(Int64Range)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeCount != nil {
+		in, out := &in.NodeCount, &out.NodeCount
+		*out = new(Int64Range)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MemoryGB != nil {
+		in, out := &in.MemoryGB, &out.MemoryGB
+		*out = new(Int64Range)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InstanceSizes != nil {
+		in, out := &in.InstanceSizes, &out.InstanceSizes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClaimParameterPolicy.
+func (in *ClaimParameterPolicy) DeepCopy() *ClaimParameterPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClaimParameterPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomSecretDefinition) DeepCopyInto(out *CustomSecretDefinition) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomSecretDefinition.
+func (in *CustomSecretDefinition) DeepCopy() *CustomSecretDefinition {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomSecretDefinition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CustomSecretDefinition) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomSecretDefinitionList) DeepCopyInto(out *CustomSecretDefinitionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CustomSecretDefinition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomSecretDefinitionList.
+func (in *CustomSecretDefinitionList) DeepCopy() *CustomSecretDefinitionList {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomSecretDefinitionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CustomSecretDefinitionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomSecretDefinitionNames) DeepCopyInto(out *CustomSecretDefinitionNames) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomSecretDefinitionNames.
+func (in *CustomSecretDefinitionNames) DeepCopy() *CustomSecretDefinitionNames {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomSecretDefinitionNames)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomSecretDefinitionSpec) DeepCopyInto(out *CustomSecretDefinitionSpec) {
+	*out = *in
+	out.Names = in.Names
+	in.Validation.DeepCopyInto(&out.Validation)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomSecretDefinitionSpec.
+func (in *CustomSecretDefinitionSpec) DeepCopy() *CustomSecretDefinitionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomSecretDefinitionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomSecretDefinitionValidation) DeepCopyInto(out *CustomSecretDefinitionValidation) {
+	*out = *in
+	in.OpenAPIV3Schema.DeepCopyInto(&out.OpenAPIV3Schema)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomSecretDefinitionValidation.
+func (in *CustomSecretDefinitionValidation) DeepCopy() *CustomSecretDefinitionValidation {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomSecretDefinitionValidation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Int64Range) DeepCopyInto(out *Int64Range) {
+	*out = *in
+	if in.Min != nil {
+		in, out := &in.Min, &out.Min
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Int64Range.
+func (in *Int64Range) DeepCopy() *Int64Range {
+	if in == nil {
+		return nil
+	}
+	out := new(Int64Range)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceClass) DeepCopyInto(out *ResourceClass) {
 	*out = *in
@@ -93,3 +261,45 @@ func (in *ResourceClassList) DeepCopyObject() runtime.Object {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeySchema) DeepCopyInto(out *SecretKeySchema) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeySchema.
+func (in *SecretKeySchema) DeepCopy() *SecretKeySchema {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretKeySchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretSchema) DeepCopyInto(out *SecretSchema) {
+	*out = *in
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]SecretKeySchema, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Required != nil {
+		in, out := &in.Required, &out.Required
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretSchema.
+func (in *SecretSchema) DeepCopy() *SecretSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretSchema)
+	in.DeepCopyInto(out)
+	return out
+}
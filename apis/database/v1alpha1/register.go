@@ -47,6 +47,10 @@ var (
 	MySQLInstanceGroupVersionKind = SchemeGroupVersion.WithKind(MySQLInstanceKind)
 )
 
+// MySQLInstanceSecretDefinition is the name of the CustomSecretDefinition that
+// MySQLInstance connection secrets must satisfy.
+const MySQLInstanceSecretDefinition = "mysql.database.crossplane.io"
+
 // MySQLInstancePolicy type metadata.
 var (
 	MySQLInstancePolicyKind             = reflect.TypeOf(MySQLInstancePolicy{}).Name()
@@ -68,6 +72,10 @@ var (
 	PostgreSQLInstanceGroupVersionKind = SchemeGroupVersion.WithKind(PostgreSQLInstanceKind)
 )
 
+// PostgreSQLInstanceSecretDefinition is the name of the CustomSecretDefinition that
+// PostgreSQLInstance connection secrets must satisfy.
+const PostgreSQLInstanceSecretDefinition = "postgresql.database.crossplane.io"
+
 // PostgreSQLInstancePolicy type metadata.
 var (
 	PostgreSQLInstancePolicyKind             = reflect.TypeOf(PostgreSQLInstancePolicy{}).Name()
@@ -24,13 +24,44 @@ import (
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
 )
 
+// ResourceCredentialsSecretDatabaseKey is the key inside a connection secret
+// for the name of the logical database a claim is bound to, if any.
+const ResourceCredentialsSecretDatabaseKey = "database"
+
 // MySQLInstanceSpec specifies the configuration of a MySQL instance.
 type MySQLInstanceSpec struct {
 	runtimev1alpha1.ResourceClaimSpec `json:",inline"`
 
+	// ClassSelector selects the resource class of this claim by its labels
+	// when no class reference is set. If several classes match, the one with
+	// the highest class weight annotation is selected.
+	// +optional
+	ClassSelector *metav1.LabelSelector `json:"classSelector,omitempty"`
+
 	// mysql instance properties
 	// +kubebuilder:validation:Enum="5.6";"5.7"
 	EngineVersion string `json:"engineVersion"`
+
+	// StorageGB is the storage, in GB, requested of the instance. The
+	// resource class must allow claims to request it.
+	// +optional
+	StorageGB *int64 `json:"storageGB,omitempty"`
+
+	// InstanceSize is the portable size of the requested instance, e.g.
+	// small or large. The resource class must allow claims to request it,
+	// and maps it to a provider specific instance type.
+	// +optional
+	InstanceSize string `json:"instanceSize,omitempty"`
+
+	// UserReference references a database user managed resource, for example
+	// an RDSUser, in the namespace of the resource class. When set, the
+	// connection secret of this claim contains that user's credentials rather
+	// than those of the resource this claim is bound to. The user must be
+	// created in the instance this claim is bound to, and must be labelled
+	// with this claim's namespace and name using the
+	// crossplane.io/claim-namespace and crossplane.io/claim-name labels.
+	// +optional
+	UserReference *corev1.ObjectReference `json:"userRef,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -64,6 +95,11 @@ func (i *MySQLInstance) SetConditions(c ...runtimev1alpha1.Condition) {
 	i.Status.SetConditions(c...)
 }
 
+// GetCondition of this MySQLInstance.
+func (i *MySQLInstance) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return i.Status.GetCondition(ct)
+}
+
 // SetClassReference of this MySQLInstance.
 func (i *MySQLInstance) SetClassReference(r *corev1.ObjectReference) {
 	i.Spec.ClassReference = r
@@ -74,6 +110,11 @@ func (i *MySQLInstance) GetClassReference() *corev1.ObjectReference {
 	return i.Spec.ClassReference
 }
 
+// GetClassSelector of this MySQLInstance.
+func (i *MySQLInstance) GetClassSelector() *metav1.LabelSelector {
+	return i.Spec.ClassSelector
+}
+
 // SetResourceReference of this MySQLInstance.
 func (i *MySQLInstance) SetResourceReference(r *corev1.ObjectReference) {
 	i.Spec.ResourceReference = r
@@ -84,6 +125,11 @@ func (i *MySQLInstance) GetResourceReference() *corev1.ObjectReference {
 	return i.Spec.ResourceReference
 }
 
+// GetUserReference of this MySQLInstance.
+func (i *MySQLInstance) GetUserReference() *corev1.ObjectReference {
+	return i.Spec.UserReference
+}
+
 // SetWriteConnectionSecretToReference of this MySQLInstance.
 func (i *MySQLInstance) SetWriteConnectionSecretToReference(r corev1.LocalObjectReference) {
 	i.Spec.WriteConnectionSecretToReference = r
@@ -133,9 +179,36 @@ type MySQLInstancePolicyList struct {
 type PostgreSQLInstanceSpec struct {
 	runtimev1alpha1.ResourceClaimSpec `json:",inline"`
 
+	// ClassSelector selects the resource class of this claim by its labels
+	// when no class reference is set. If several classes match, the one with
+	// the highest class weight annotation is selected.
+	// +optional
+	ClassSelector *metav1.LabelSelector `json:"classSelector,omitempty"`
+
 	// postgresql instance properties
 	// +kubebuilder:validation:Enum="9.6"
 	EngineVersion string `json:"engineVersion,omitempty"`
+
+	// StorageGB is the storage, in GB, requested of the instance. The
+	// resource class must allow claims to request it.
+	// +optional
+	StorageGB *int64 `json:"storageGB,omitempty"`
+
+	// InstanceSize is the portable size of the requested instance, e.g.
+	// small or large. The resource class must allow claims to request it,
+	// and maps it to a provider specific instance type.
+	// +optional
+	InstanceSize string `json:"instanceSize,omitempty"`
+
+	// UserReference references a database user managed resource, for example
+	// an RDSUser, in the namespace of the resource class. When set, the
+	// connection secret of this claim contains that user's credentials rather
+	// than those of the resource this claim is bound to. The user must be
+	// created in the instance this claim is bound to, and must be labelled
+	// with this claim's namespace and name using the
+	// crossplane.io/claim-namespace and crossplane.io/claim-name labels.
+	// +optional
+	UserReference *corev1.ObjectReference `json:"userRef,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -169,6 +242,11 @@ func (i *PostgreSQLInstance) SetConditions(c ...runtimev1alpha1.Condition) {
 	i.Status.SetConditions(c...)
 }
 
+// GetCondition of this PostgreSQLInstance.
+func (i *PostgreSQLInstance) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return i.Status.GetCondition(ct)
+}
+
 // SetClassReference of this PostgreSQLInstance.
 func (i *PostgreSQLInstance) SetClassReference(r *corev1.ObjectReference) {
 	i.Spec.ClassReference = r
@@ -179,6 +257,11 @@ func (i *PostgreSQLInstance) GetClassReference() *corev1.ObjectReference {
 	return i.Spec.ClassReference
 }
 
+// GetClassSelector of this PostgreSQLInstance.
+func (i *PostgreSQLInstance) GetClassSelector() *metav1.LabelSelector {
+	return i.Spec.ClassSelector
+}
+
 // SetResourceReference of this PostgreSQLInstance.
 func (i *PostgreSQLInstance) SetResourceReference(r *corev1.ObjectReference) {
 	i.Spec.ResourceReference = r
@@ -189,6 +272,11 @@ func (i *PostgreSQLInstance) GetResourceReference() *corev1.ObjectReference {
 	return i.Spec.ResourceReference
 }
 
+// GetUserReference of this PostgreSQLInstance.
+func (i *PostgreSQLInstance) GetUserReference() *corev1.ObjectReference {
+	return i.Spec.UserReference
+}
+
 // SetWriteConnectionSecretToReference of this PostgreSQLInstance.
 func (i *PostgreSQLInstance) SetWriteConnectionSecretToReference(r corev1.LocalObjectReference) {
 	i.Spec.WriteConnectionSecretToReference = r
@@ -21,6 +21,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -145,6 +147,21 @@ func (in *MySQLInstancePolicyList) DeepCopyObject() runtime.Object {
 func (in *MySQLInstanceSpec) DeepCopyInto(out *MySQLInstanceSpec) {
 	*out = *in
 	in.ResourceClaimSpec.DeepCopyInto(&out.ResourceClaimSpec)
+	if in.ClassSelector != nil {
+		in, out := &in.ClassSelector, &out.ClassSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StorageGB != nil {
+		in, out := &in.StorageGB, &out.StorageGB
+		*out = new(int64)
+		**out = **in
+	}
+	if in.UserReference != nil {
+		in, out := &in.UserReference, &out.UserReference
+		*out = new(corev1.ObjectReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MySQLInstanceSpec.
@@ -278,6 +295,21 @@ func (in *PostgreSQLInstancePolicyList) DeepCopyObject() runtime.Object {
 func (in *PostgreSQLInstanceSpec) DeepCopyInto(out *PostgreSQLInstanceSpec) {
 	*out = *in
 	in.ResourceClaimSpec.DeepCopyInto(&out.ResourceClaimSpec)
+	if in.ClassSelector != nil {
+		in, out := &in.ClassSelector, &out.ClassSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StorageGB != nil {
+		in, out := &in.StorageGB, &out.StorageGB
+		*out = new(int64)
+		**out = **in
+	}
+	if in.UserReference != nil {
+		in, out := &in.UserReference, &out.UserReference
+		*out = new(corev1.ObjectReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgreSQLInstanceSpec.
@@ -46,6 +46,16 @@ type StackRequest struct {
 	Status StackRequestStatus `json:"status,omitempty"`
 }
 
+// SetConditions of this StackRequest.
+func (r *StackRequest) SetConditions(c ...runtimev1alpha1.Condition) {
+	r.Status.SetConditions(c...)
+}
+
+// GetCondition of this StackRequest.
+func (r *StackRequest) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return r.Status.GetCondition(ct)
+}
+
 // +kubebuilder:object:root=true
 
 // StackRequestList contains a list of StackRequest
@@ -95,6 +105,16 @@ type Stack struct {
 	Status StackStatus `json:"status,omitempty"`
 }
 
+// SetConditions of this Stack.
+func (s *Stack) SetConditions(c ...runtimev1alpha1.Condition) {
+	s.Status.SetConditions(c...)
+}
+
+// GetCondition of this Stack.
+func (s *Stack) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return s.Status.GetCondition(ct)
+}
+
 // +kubebuilder:object:root=true
 
 // StackList contains a list of Stack
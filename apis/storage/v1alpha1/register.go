@@ -47,6 +47,10 @@ var (
 	BucketGroupVersionKind = SchemeGroupVersion.WithKind(BucketKind)
 )
 
+// BucketSecretDefinition is the name of the CustomSecretDefinition that
+// Bucket connection secrets must satisfy.
+const BucketSecretDefinition = "bucket.storage.crossplane.io"
+
 // BucketPolicy type metadata.
 var (
 	BucketPolicyKind             = reflect.TypeOf(BucketPolicy{}).Name()
@@ -51,6 +51,12 @@ const (
 type BucketSpec struct {
 	runtimev1alpha1.ResourceClaimSpec `json:",inline"`
 
+	// ClassSelector selects the resource class of this claim by its labels
+	// when no class reference is set. If several classes match, the one with
+	// the highest class weight annotation is selected.
+	// +optional
+	ClassSelector *metav1.LabelSelector `json:"classSelector,omitempty"`
+
 	// Name properties
 	// +kubebuilder:validation:MaxLength=63
 	// +kubebuilder:validation:MinLength=3
@@ -99,6 +105,11 @@ func (b *Bucket) SetConditions(c ...runtimev1alpha1.Condition) {
 	b.Status.SetConditions(c...)
 }
 
+// GetCondition of this Bucket.
+func (b *Bucket) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return b.Status.GetCondition(ct)
+}
+
 // SetClassReference of this Bucket.
 func (b *Bucket) SetClassReference(r *corev1.ObjectReference) {
 	b.Spec.ClassReference = r
@@ -109,6 +120,11 @@ func (b *Bucket) GetClassReference() *corev1.ObjectReference {
 	return b.Spec.ClassReference
 }
 
+// GetClassSelector of this Bucket.
+func (b *Bucket) GetClassSelector() *metav1.LabelSelector {
+	return b.Spec.ClassSelector
+}
+
 // SetResourceReference of this Bucket.
 func (b *Bucket) SetResourceReference(r *corev1.ObjectReference) {
 	b.Spec.ResourceReference = r
@@ -21,6 +21,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -145,6 +146,11 @@ func (in *BucketPolicyList) DeepCopyObject() runtime.Object {
 func (in *BucketSpec) DeepCopyInto(out *BucketSpec) {
 	*out = *in
 	in.ResourceClaimSpec.DeepCopyInto(&out.ResourceClaimSpec)
+	if in.ClassSelector != nil {
+		in, out := &in.ClassSelector, &out.ClassSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PredefinedACL != nil {
 		in, out := &in.PredefinedACL, &out.PredefinedACL
 		*out = new(PredefinedACL)
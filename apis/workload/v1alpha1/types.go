@@ -116,6 +116,16 @@ type KubernetesApplication struct {
 	Status KubernetesApplicationStatus `json:"status,omitempty"`
 }
 
+// SetConditions of this KubernetesApplication.
+func (a *KubernetesApplication) SetConditions(c ...runtimev1alpha1.Condition) {
+	a.Status.SetConditions(c...)
+}
+
+// GetCondition of this KubernetesApplication.
+func (a *KubernetesApplication) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return a.Status.GetCondition(ct)
+}
+
 // +kubebuilder:object:root=true
 
 // KubernetesApplicationList contains a list of KubernetesApplications.
@@ -211,6 +221,16 @@ type KubernetesApplicationResource struct {
 	Status KubernetesApplicationResourceStatus `json:"status,omitempty"`
 }
 
+// SetConditions of this KubernetesApplicationResource.
+func (r *KubernetesApplicationResource) SetConditions(c ...runtimev1alpha1.Condition) {
+	r.Status.SetConditions(c...)
+}
+
+// GetCondition of this KubernetesApplicationResource.
+func (r *KubernetesApplicationResource) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return r.Status.GetCondition(ct)
+}
+
 // +kubebuilder:object:root=true
 
 // KubernetesApplicationResourceList contains a list of
@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	corev1alpha1 "github.com/crossplaneio/crossplane/apis/core/v1alpha1"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -34,6 +35,18 @@ const (
 	StatusSnapshotting = "snapshotting"
 )
 
+// ReplicationGroup operations. An operation is reported in the status of a
+// replication group while ElastiCache applies the change it describes.
+const (
+	OperationModify        = "Modify"
+	OperationScaleNodeType = "ScaleNodeType"
+	OperationScaleShards   = "ScaleShards"
+	OperationUpgradeEngine = "UpgradeEngineVersion"
+
+	OperationIncreaseReplicaCount = "IncreaseReplicaCount"
+	OperationDecreaseReplicaCount = "DecreaseReplicaCount"
+)
+
 // Supported cache engines.
 const (
 	CacheEngineRedis = "redis"
@@ -169,7 +182,10 @@ type ReplicationGroupParameters struct {
 	PreferredMaintenanceWindow string `json:"preferredMaintenanceWindow,omitempty"`
 
 	// ReplicasPerNodeGroup specifies the number of replica nodes in each node
-	// group (shard). Valid values are 0 to 5.
+	// group (shard). Valid values are 0 to 5. Changing it after the
+	// replication group has been created adds or removes replicas from every
+	// node group. Replicas cannot be removed entirely by changing it to 0,
+	// which is indistinguishable from omitting it.
 	ReplicasPerNodeGroup int `json:"replicasPerNodeGroup,omitempty"`
 
 	// SecurityGroupIDs specifies one or more Amazon VPC security groups
@@ -271,6 +287,11 @@ type ReplicationGroupStatus struct {
 	// Groupname of the Replication Group.
 	GroupName string `json:"groupName,omitempty"`
 
+	// Operation is the modification most recently requested of this
+	// replication group, for example ScaleShards. It is cleared once the
+	// replication group is available again.
+	Operation string `json:"operation,omitempty"`
+
 	// TODO(negz): Support PendingModifiedValues?
 	// https://docs.aws.amazon.com/AmazonElastiCache/latest/APIReference/API_ReplicationGroupPendingModifiedValues.html
 }
@@ -281,6 +302,7 @@ type ReplicationGroupStatus struct {
 // +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.state"
 // +kubebuilder:printcolumn:name="CLASS",type="string",JSONPath=".spec.classRef.name"
 // +kubebuilder:printcolumn:name="VERSION",type="string",JSONPath=".spec.engineVersion"
+// +kubebuilder:printcolumn:name="OPERATION",type="string",JSONPath=".status.operation"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
 type ReplicationGroup struct {
@@ -301,6 +323,11 @@ func (rg *ReplicationGroup) SetConditions(c ...runtimev1alpha1.Condition) {
 	rg.Status.SetConditions(c...)
 }
 
+// GetCondition of this ReplicationGroup.
+func (rg *ReplicationGroup) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return rg.Status.GetCondition(ct)
+}
+
 // GetBindingPhase of this ReplicationGroup.
 func (rg *ReplicationGroup) GetBindingPhase() runtimev1alpha1.BindingPhase {
 	return rg.Status.GetBindingPhase()
@@ -359,6 +386,11 @@ type ReplicationGroupList struct {
 type ReplicationGroupClassSpecTemplate struct {
 	runtimev1alpha1.ResourceClassSpecTemplate `json:",inline"`
 	ReplicationGroupParameters                `json:",inline"`
+
+	// ClaimParameters specifies the portable parameters that resource claims
+	// may request of this class, and the values they may request.
+	// +optional
+	ClaimParameters *corev1alpha1.ClaimParameterPolicy `json:"claimParameters,omitempty"`
 }
 
 var _ resource.Class = &ReplicationGroupClass{}
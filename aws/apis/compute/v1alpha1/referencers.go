@@ -0,0 +1,118 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/pkg/errors"
+
+	networkv1alpha1 "github.com/crossplaneio/crossplane/aws/apis/network/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/reference"
+)
+
+const errNotEKSCluster = "managed resource is not an EKSCluster"
+
+// A VPCIDReferencerForEKSCluster assigns the ID of a referenced VPC to an
+// EKSCluster.
+type VPCIDReferencerForEKSCluster struct {
+	networkv1alpha1.VPCIDReferencer `json:",inline"`
+}
+
+// Assign the supplied VPC ID to the supplied EKSCluster.
+func (v *VPCIDReferencerForEKSCluster) Assign(res reference.CanReference, value string) error {
+	c, ok := res.(*EKSCluster)
+	if !ok {
+		return errors.New(errNotEKSCluster)
+	}
+	c.Spec.VpcID = value
+	return nil
+}
+
+// A SubnetIDReferencerForEKSCluster resolves the ID of a referenced Subnet
+// for an EKSCluster.
+type SubnetIDReferencerForEKSCluster struct {
+	networkv1alpha1.SubnetIDReferencer `json:",inline"`
+
+	// ResolvedID is the ID of the referenced Subnet. It is replaced each time
+	// the reference is resolved.
+	// +optional
+	ResolvedID string `json:"resolvedId,omitempty"`
+}
+
+// Assign the supplied Subnet ID to the reference. It is kept apart from the
+// EKSCluster's SubnetIds so that it is no longer used once the reference is
+// removed.
+func (v *SubnetIDReferencerForEKSCluster) Assign(res reference.CanReference, value string) error {
+	if _, ok := res.(*EKSCluster); !ok {
+		return errors.New(errNotEKSCluster)
+	}
+	v.ResolvedID = value
+	return nil
+}
+
+// A SecurityGroupIDReferencerForEKSCluster resolves the ID of a referenced
+// SecurityGroup for an EKSCluster.
+type SecurityGroupIDReferencerForEKSCluster struct {
+	networkv1alpha1.SecurityGroupIDReferencer `json:",inline"`
+
+	// ResolvedID is the ID of the referenced SecurityGroup. It is replaced
+	// each time the reference is resolved.
+	// +optional
+	ResolvedID string `json:"resolvedId,omitempty"`
+}
+
+// Assign the supplied SecurityGroup ID to the reference. It is kept apart
+// from the EKSCluster's SecurityGroupIds so that it is no longer used once the
+// reference is removed.
+func (v *SecurityGroupIDReferencerForEKSCluster) Assign(res reference.CanReference, value string) error {
+	if _, ok := res.(*EKSCluster); !ok {
+		return errors.New(errNotEKSCluster)
+	}
+	v.ResolvedID = value
+	return nil
+}
+
+// AllSubnetIDs returns the IDs of the subnets specified by the supplied
+// EKSClusterSpec, followed by those resolved from its references.
+func (s *EKSClusterSpec) AllSubnetIDs() []string {
+	ids := append([]string(nil), s.SubnetIds...)
+	for _, r := range s.SubnetIDRefs {
+		ids = appendIfMissing(ids, r.ResolvedID)
+	}
+	return ids
+}
+
+// AllSecurityGroupIDs returns the IDs of the security groups specified by the
+// supplied EKSClusterSpec, followed by those resolved from its references.
+func (s *EKSClusterSpec) AllSecurityGroupIDs() []string {
+	ids := append([]string(nil), s.SecurityGroupIds...)
+	for _, r := range s.SecurityGroupIDRefs {
+		ids = appendIfMissing(ids, r.ResolvedID)
+	}
+	return ids
+}
+
+func appendIfMissing(s []string, v string) []string {
+	if v == "" {
+		return s
+	}
+	for _, e := range s {
+		if e == v {
+			return s
+		}
+	}
+	return append(s, v)
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	networkv1alpha1 "github.com/crossplaneio/crossplane/aws/apis/network/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/reference"
+)
+
+var _ reference.CanReference = &EKSCluster{}
+
+func TestSubnetIDReferencerForEKSClusterAssign(t *testing.T) {
+	subnetID := "subnet-cool"
+
+	cases := map[string]struct {
+		res  reference.CanReference
+		ref  *SubnetIDReferencerForEKSCluster
+		want *SubnetIDReferencerForEKSCluster
+		err  error
+	}{
+		"NotEKSCluster": {
+			res:  &networkv1alpha1.Subnet{},
+			ref:  &SubnetIDReferencerForEKSCluster{},
+			want: &SubnetIDReferencerForEKSCluster{},
+			err:  errors.New(errNotEKSCluster),
+		},
+		"ReplacesResolvedID": {
+			res:  &EKSCluster{},
+			ref:  &SubnetIDReferencerForEKSCluster{ResolvedID: "subnet-old"},
+			want: &SubnetIDReferencerForEKSCluster{ResolvedID: subnetID},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.ref.Assign(tc.res, subnetID)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ref.Assign(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, tc.ref); diff != "" {
+				t.Errorf("ref.Assign(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSecurityGroupIDReferencerForEKSClusterAssign(t *testing.T) {
+	sgID := "sg-cool"
+
+	cases := map[string]struct {
+		res  reference.CanReference
+		ref  *SecurityGroupIDReferencerForEKSCluster
+		want *SecurityGroupIDReferencerForEKSCluster
+		err  error
+	}{
+		"NotEKSCluster": {
+			res:  &networkv1alpha1.Subnet{},
+			ref:  &SecurityGroupIDReferencerForEKSCluster{},
+			want: &SecurityGroupIDReferencerForEKSCluster{},
+			err:  errors.New(errNotEKSCluster),
+		},
+		"ReplacesResolvedID": {
+			res:  &EKSCluster{},
+			ref:  &SecurityGroupIDReferencerForEKSCluster{ResolvedID: "sg-old"},
+			want: &SecurityGroupIDReferencerForEKSCluster{ResolvedID: sgID},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.ref.Assign(tc.res, sgID)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ref.Assign(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, tc.ref); diff != "" {
+				t.Errorf("ref.Assign(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAllIDs(t *testing.T) {
+	spec := &EKSClusterSpec{EKSClusterParameters: EKSClusterParameters{
+		SubnetIds: []string{"subnet-a"},
+		SubnetIDRefs: []*SubnetIDReferencerForEKSCluster{
+			{ResolvedID: "subnet-a"},
+			{ResolvedID: "subnet-b"},
+		},
+		SecurityGroupIDRefs: []*SecurityGroupIDReferencerForEKSCluster{
+			{ResolvedID: "sg-a"},
+			{},
+		},
+	}}
+
+	if diff := cmp.Diff([]string{"subnet-a", "subnet-b"}, spec.AllSubnetIDs()); diff != "" {
+		t.Errorf("AllSubnetIDs(): -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"sg-a"}, spec.AllSecurityGroupIDs()); diff != "" {
+		t.Errorf("AllSecurityGroupIDs(): -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"subnet-a"}, spec.SubnetIds); diff != "" {
+		t.Errorf("AllSubnetIDs(): modified SubnetIds: -want, +got:\n%s", diff)
+	}
+}
@@ -19,6 +19,8 @@ package v1alpha1
 import (
 	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	corev1alpha1 "github.com/crossplaneio/crossplane/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/reference"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -76,16 +78,37 @@ type EKSClusterParameters struct {
 	// specify  up  to  5  security groups, but we recommend that you use a
 	// dedicated security group for your cluster control plane.
 	//
-	// VpcID of EKS cluster
-	VpcID string `json:"vpcId"`
+	// VpcID of EKS cluster. It is resolved from VpcIDRef when that is set.
+	// +optional
+	VpcID string `json:"vpcId,omitempty"`
+
+	// VpcIDRef references a VPC in the same namespace, from which VpcID is
+	// resolved once the VPC is available.
+	// +optional
+	VpcIDRef *VPCIDReferencerForEKSCluster `json:"vpcIdRef,omitempty"`
+
 	// SubnetIds
 	// Syntax:
 	// subnetIds=string,string,
-	SubnetIds []string `json:"subnetIds"`
+	// +optional
+	SubnetIds []string `json:"subnetIds,omitempty"`
+
+	// SubnetIDRefs reference Subnets in the same namespace, the IDs of which
+	// are used in addition to SubnetIds once they are available.
+	// +optional
+	SubnetIDRefs []*SubnetIDReferencerForEKSCluster `json:"subnetIdRefs,omitempty"`
+
 	// SecurityGroupIds
 	// Syntax:
 	// securityGroupIds=string,string,
-	SecurityGroupIds []string `json:"securityGroupIds"`
+	// +optional
+	SecurityGroupIds []string `json:"securityGroupIds,omitempty"`
+
+	// SecurityGroupIDRefs reference SecurityGroups in the same namespace, the
+	// IDs of which are used in addition to SecurityGroupIds once they are
+	// available.
+	// +optional
+	SecurityGroupIDRefs []*SecurityGroupIDReferencerForEKSCluster `json:"securityGroupIdRefs,omitempty"`
 
 	// ClientRequestToken
 	// --client-request-token (string)
@@ -251,6 +274,11 @@ func (c *EKSCluster) SetConditions(cd ...runtimev1alpha1.Condition) {
 	c.Status.SetConditions(cd...)
 }
 
+// GetCondition of this EKSCluster.
+func (c *EKSCluster) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return c.Status.GetCondition(ct)
+}
+
 // SetClaimReference of this EKSCluster.
 func (c *EKSCluster) SetClaimReference(r *corev1.ObjectReference) {
 	c.Spec.ClaimReference = r
@@ -291,6 +319,21 @@ func (c *EKSCluster) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
 	c.Spec.ReclaimPolicy = p
 }
 
+// GetReferencers of this EKSCluster.
+func (c *EKSCluster) GetReferencers() []reference.AttributeReferencer {
+	refs := make([]reference.AttributeReferencer, 0)
+	if c.Spec.VpcIDRef != nil {
+		refs = append(refs, c.Spec.VpcIDRef)
+	}
+	for _, r := range c.Spec.SubnetIDRefs {
+		refs = append(refs, r)
+	}
+	for _, r := range c.Spec.SecurityGroupIDRefs {
+		refs = append(refs, r)
+	}
+	return refs
+}
+
 // +kubebuilder:object:root=true
 
 // EKSClusterList contains a list of EKSCluster items
@@ -304,6 +347,11 @@ type EKSClusterList struct {
 type EKSClusterClassSpecTemplate struct {
 	runtimev1alpha1.ResourceClassSpecTemplate `json:",inline"`
 	EKSClusterParameters                      `json:",inline"`
+
+	// ClaimParameters specifies the portable parameters that resource claims
+	// may request of this class, and the values they may request.
+	// +optional
+	ClaimParameters *corev1alpha1.ClaimParameterPolicy `json:"claimParameters,omitempty"`
 }
 
 var _ resource.Class = &EKSClusterClass{}
@@ -22,6 +22,8 @@ package v1alpha1
 
 import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
+
+	corev1alpha1 "github.com/crossplaneio/crossplane/apis/core/v1alpha1"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -114,6 +116,11 @@ func (in *EKSClusterClassSpecTemplate) DeepCopyInto(out *EKSClusterClassSpecTemp
 	*out = *in
 	in.ResourceClassSpecTemplate.DeepCopyInto(&out.ResourceClassSpecTemplate)
 	in.EKSClusterParameters.DeepCopyInto(&out.EKSClusterParameters)
+	if in.ClaimParameters != nil {
+		in, out := &in.ClaimParameters, &out.ClaimParameters
+		*out = new(corev1alpha1.ClaimParameterPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EKSClusterClassSpecTemplate.
@@ -161,16 +168,43 @@ func (in *EKSClusterList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EKSClusterParameters) DeepCopyInto(out *EKSClusterParameters) {
 	*out = *in
+	if in.VpcIDRef != nil {
+		in, out := &in.VpcIDRef, &out.VpcIDRef
+		*out = new(VPCIDReferencerForEKSCluster)
+		**out = **in
+	}
 	if in.SubnetIds != nil {
 		in, out := &in.SubnetIds, &out.SubnetIds
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.SubnetIDRefs != nil {
+		in, out := &in.SubnetIDRefs, &out.SubnetIDRefs
+		*out = make([]*SubnetIDReferencerForEKSCluster, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(SubnetIDReferencerForEKSCluster)
+				**out = **in
+			}
+		}
+	}
 	if in.SecurityGroupIds != nil {
 		in, out := &in.SecurityGroupIds, &out.SecurityGroupIds
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.SecurityGroupIDRefs != nil {
+		in, out := &in.SecurityGroupIDRefs, &out.SecurityGroupIDRefs
+		*out = make([]*SecurityGroupIDReferencerForEKSCluster, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(SecurityGroupIDReferencerForEKSCluster)
+				**out = **in
+			}
+		}
+	}
 	in.WorkerNodes.DeepCopyInto(&out.WorkerNodes)
 	if in.MapRoles != nil {
 		in, out := &in.MapRoles, &out.MapRoles
@@ -271,6 +305,54 @@ func (in *MapUser) DeepCopy() *MapUser {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupIDReferencerForEKSCluster) DeepCopyInto(out *SecurityGroupIDReferencerForEKSCluster) {
+	*out = *in
+	out.SecurityGroupIDReferencer = in.SecurityGroupIDReferencer
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupIDReferencerForEKSCluster.
+func (in *SecurityGroupIDReferencerForEKSCluster) DeepCopy() *SecurityGroupIDReferencerForEKSCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupIDReferencerForEKSCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubnetIDReferencerForEKSCluster) DeepCopyInto(out *SubnetIDReferencerForEKSCluster) {
+	*out = *in
+	out.SubnetIDReferencer = in.SubnetIDReferencer
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetIDReferencerForEKSCluster.
+func (in *SubnetIDReferencerForEKSCluster) DeepCopy() *SubnetIDReferencerForEKSCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetIDReferencerForEKSCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCIDReferencerForEKSCluster) DeepCopyInto(out *VPCIDReferencerForEKSCluster) {
+	*out = *in
+	out.VPCIDReferencer = in.VPCIDReferencer
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCIDReferencerForEKSCluster.
+func (in *VPCIDReferencerForEKSCluster) DeepCopy() *VPCIDReferencerForEKSCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCIDReferencerForEKSCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkerNodesSpec) DeepCopyInto(out *WorkerNodesSpec) {
 	*out = *in
@@ -0,0 +1,189 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+)
+
+// RDSDatabaseParameters define the desired state of an RDSDatabase.
+type RDSDatabaseParameters struct {
+	// InstanceRef references the RDSInstance in which this database will
+	// be created. The instance must be in the same namespace as this database,
+	// and must be reachable from Crossplane. The database is created using the
+	// master credentials from the instance's connection secret.
+	InstanceRef corev1.LocalObjectReference `json:"instanceRef"`
+
+	// DatabaseName is the name of the database within the instance. Defaults
+	// to the name of the RDSDatabase.
+	DatabaseName string `json:"databaseName,omitempty"`
+}
+
+// RDSDatabaseSpec defines the desired state of an RDSDatabase.
+type RDSDatabaseSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	RDSDatabaseParameters        `json:",inline"`
+}
+
+// RDSDatabaseStatus defines the observed state of an RDSDatabase.
+type RDSDatabaseStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+
+// An RDSDatabase is a logical database within an existing RDSInstance. Many
+// RDSDatabases may share one instance. Its connection secret contains the
+// credentials of a generated user that is granted privileges only on this
+// database, the instance's endpoint, and the name of the database.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.bindingPhase"
+// +kubebuilder:printcolumn:name="INSTANCE",type="string",JSONPath=".spec.instanceRef.name"
+// +kubebuilder:printcolumn:name="CLASS",type="string",JSONPath=".spec.classRef.name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+type RDSDatabase struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RDSDatabaseSpec   `json:"spec,omitempty"`
+	Status RDSDatabaseStatus `json:"status,omitempty"`
+}
+
+// SetBindingPhase of this RDSDatabase.
+func (d *RDSDatabase) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	d.Status.SetBindingPhase(p)
+}
+
+// GetBindingPhase of this RDSDatabase.
+func (d *RDSDatabase) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return d.Status.GetBindingPhase()
+}
+
+// SetConditions of this RDSDatabase.
+func (d *RDSDatabase) SetConditions(c ...runtimev1alpha1.Condition) {
+	d.Status.SetConditions(c...)
+}
+
+// GetCondition of this RDSDatabase.
+func (d *RDSDatabase) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return d.Status.GetCondition(ct)
+}
+
+// SetClaimReference of this RDSDatabase.
+func (d *RDSDatabase) SetClaimReference(r *corev1.ObjectReference) {
+	d.Spec.ClaimReference = r
+}
+
+// GetClaimReference of this RDSDatabase.
+func (d *RDSDatabase) GetClaimReference() *corev1.ObjectReference {
+	return d.Spec.ClaimReference
+}
+
+// SetClassReference of this RDSDatabase.
+func (d *RDSDatabase) SetClassReference(r *corev1.ObjectReference) {
+	d.Spec.ClassReference = r
+}
+
+// GetClassReference of this RDSDatabase.
+func (d *RDSDatabase) GetClassReference() *corev1.ObjectReference {
+	return d.Spec.ClassReference
+}
+
+// SetWriteConnectionSecretToReference of this RDSDatabase.
+func (d *RDSDatabase) SetWriteConnectionSecretToReference(r corev1.LocalObjectReference) {
+	d.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetWriteConnectionSecretToReference of this RDSDatabase.
+func (d *RDSDatabase) GetWriteConnectionSecretToReference() corev1.LocalObjectReference {
+	return d.Spec.WriteConnectionSecretToReference
+}
+
+// GetReclaimPolicy of this RDSDatabase.
+func (d *RDSDatabase) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return d.Spec.ReclaimPolicy
+}
+
+// SetReclaimPolicy of this RDSDatabase.
+func (d *RDSDatabase) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
+	d.Spec.ReclaimPolicy = p
+}
+
+// GetDatabaseName returns the name of this RDSDatabase within its
+// instance, which defaults to its name.
+func (d *RDSDatabase) GetDatabaseName() string {
+	if d.Spec.DatabaseName != "" {
+		return d.Spec.DatabaseName
+	}
+	return d.GetName()
+}
+
+// +kubebuilder:object:root=true
+
+// RDSDatabaseList contains a list of RDSDatabase.
+type RDSDatabaseList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RDSDatabase `json:"items"`
+}
+
+// RDSDatabaseClassSpecTemplate is the Schema for the resource class
+type RDSDatabaseClassSpecTemplate struct {
+	runtimev1alpha1.ResourceClassSpecTemplate `json:",inline"`
+	RDSDatabaseParameters                     `json:",inline"`
+}
+
+var _ resource.Class = &RDSDatabaseClass{}
+
+// +kubebuilder:object:root=true
+
+// An RDSDatabaseClass is a resource class for RDSDatabases. Resource
+// claims that use it are each bound to a new database within the one
+// RDSInstance it references, rather than to a new instance.
+// +kubebuilder:printcolumn:name="INSTANCE",type="string",JSONPath=".specTemplate.instanceRef.name"
+// +kubebuilder:printcolumn:name="PROVIDER-REF",type="string",JSONPath=".specTemplate.providerRef.name"
+// +kubebuilder:printcolumn:name="RECLAIM-POLICY",type="string",JSONPath=".specTemplate.reclaimPolicy"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+type RDSDatabaseClass struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	SpecTemplate RDSDatabaseClassSpecTemplate `json:"specTemplate,omitempty"`
+}
+
+// GetReclaimPolicy of this RDSDatabaseClass.
+func (c *RDSDatabaseClass) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return c.SpecTemplate.ReclaimPolicy
+}
+
+// SetReclaimPolicy of this RDSDatabaseClass.
+func (c *RDSDatabaseClass) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
+	c.SpecTemplate.ReclaimPolicy = p
+}
+
+// +kubebuilder:object:root=true
+
+// RDSDatabaseClassList contains a list of RDSDatabaseClass.
+type RDSDatabaseClassList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RDSDatabaseClass `json:"items"`
+}
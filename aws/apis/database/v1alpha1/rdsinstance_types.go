@@ -19,6 +19,8 @@ package v1alpha1
 import (
 	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	corev1alpha1 "github.com/crossplaneio/crossplane/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/reference"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -49,6 +51,60 @@ type RDSInstanceParameters struct {
 	// 2) A RDS specific group that allows port 3306 from allowed sources (clients and instances
 	//	  that are expected to connect to the database.
 	SecurityGroups []string `json:"securityGroups,omitempty"`
+
+	// SecurityGroupRefs reference SecurityGroups in the same namespace, the
+	// IDs of which are used in addition to SecurityGroups once they are
+	// available.
+	SecurityGroupRefs []*SecurityGroupIDReferencerForRDSInstance `json:"securityGroupRefs,omitempty"`
+
+	// BackupRetentionPeriod is the number of days for which automated backups
+	// are retained. Automated backups are disabled when it is zero, which
+	// prevents point-in-time restores from this instance.
+	BackupRetentionPeriod int64 `json:"backupRetentionPeriod,omitempty"`
+
+	// RestoreFrom seeds a new instance with the data of a snapshot, or of
+	// another instance at a point in time. It is used only when the instance
+	// is created. A restored instance keeps the master username of its source,
+	// so MasterUsername must match it.
+	RestoreFrom *RDSInstanceRestoreSource `json:"restoreFrom,omitempty"`
+
+	// DeletionProtection prevents the instance from being deleted by anyone,
+	// including Crossplane, until it is disabled. When it is not set the
+	// observed setting of the instance is adopted, so that Crossplane does not
+	// disable protection that was enabled out of band. Crossplane disables
+	// deletion protection before deleting an instance whose spec does not
+	// enable it.
+	// +optional
+	DeletionProtection *bool `json:"deletionProtection,omitempty"`
+
+	// FinalSnapshot takes a snapshot of the instance before it is deleted.
+	// The snapshot is named final-<instance name>-<deletion time>, where the
+	// deletion time is in Unix seconds, and is not deleted with the instance.
+	FinalSnapshot bool `json:"finalSnapshot,omitempty"`
+}
+
+// An RDSInstanceRestoreSource specifies the data with which to seed a new
+// RDSInstance. Exactly one of its fields must be set.
+type RDSInstanceRestoreSource struct {
+	// SnapshotRef references an available RDSSnapshot in the same namespace as
+	// the instance.
+	SnapshotRef *corev1.LocalObjectReference `json:"snapshotRef,omitempty"`
+
+	// PointInTime restores the state of another RDSInstance at a point in
+	// time.
+	PointInTime *RDSInstancePointInTime `json:"pointInTime,omitempty"`
+}
+
+// An RDSInstancePointInTime identifies an RDSInstance and a point in time from
+// which it may be restored.
+type RDSInstancePointInTime struct {
+	// InstanceRef references the RDSInstance to restore. It must be in the
+	// same namespace as the new instance and have automated backups enabled.
+	InstanceRef corev1.LocalObjectReference `json:"instanceRef"`
+
+	// RestoreTime to which the instance is restored. Defaults to the latest
+	// restorable time.
+	RestoreTime *metav1.Time `json:"restoreTime,omitempty"`
 }
 
 // RDSInstanceSpec defines the desired state of RDSInstance
@@ -68,6 +124,12 @@ const (
 	RDSInstanceStateCreating RDSInstanceState = "creating"
 	// The instance is being deleted.
 	RDSInstanceStateDeleting RDSInstanceState = "deleting"
+	// The instance is being backed up. It remains accessible.
+	RDSInstanceStateBackingUp RDSInstanceState = "backing-up"
+	// The instance is being modified. It may be inaccessible.
+	RDSInstanceStateModifying RDSInstanceState = "modifying"
+	// The instance's master password is being reset.
+	RDSInstanceStateResettingMasterCredentials RDSInstanceState = "resetting-master-credentials"
 	// The instance has failed and Amazon RDS can't recover it. Perform a point-in-time restore to the latest restorable time of the instance to recover the data.
 	RDSInstanceStateFailed RDSInstanceState = "failed"
 )
@@ -81,6 +143,11 @@ type RDSInstanceStatus struct {
 	ProviderID   string `json:"providerID,omitempty"`   // the external ID to identify this resource in the cloud provider
 	InstanceName string `json:"instanceName,omitempty"` // the generated DB Instance name
 	Endpoint     string `json:"endpoint,omitempty"`     // rds instance endpoint
+
+	// MasterPasswordReset is true once an instance that was restored from a
+	// snapshot or point in time has had its master password reset to the one
+	// in its connection secret.
+	MasterPasswordReset bool `json:"masterPasswordReset,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -114,6 +181,11 @@ func (i *RDSInstance) SetConditions(c ...runtimev1alpha1.Condition) {
 	i.Status.SetConditions(c...)
 }
 
+// GetCondition of this RDSInstance.
+func (i *RDSInstance) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return i.Status.GetCondition(ct)
+}
+
 // SetClaimReference of this RDSInstance.
 func (i *RDSInstance) SetClaimReference(r *corev1.ObjectReference) {
 	i.Spec.ClaimReference = r
@@ -154,6 +226,15 @@ func (i *RDSInstance) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
 	i.Spec.ReclaimPolicy = p
 }
 
+// GetReferencers of this RDSInstance.
+func (i *RDSInstance) GetReferencers() []reference.AttributeReferencer {
+	refs := make([]reference.AttributeReferencer, 0, len(i.Spec.SecurityGroupRefs))
+	for _, r := range i.Spec.SecurityGroupRefs {
+		refs = append(refs, r)
+	}
+	return refs
+}
+
 // +kubebuilder:object:root=true
 
 // RDSInstanceList contains a list of RDSInstance
@@ -167,6 +248,11 @@ type RDSInstanceList struct {
 type RDSInstanceClassSpecTemplate struct {
 	runtimev1alpha1.ResourceClassSpecTemplate `json:",inline"`
 	RDSInstanceParameters                     `json:",inline"`
+
+	// ClaimParameters specifies the portable parameters that resource claims
+	// may request of this class, and the values they may request.
+	// +optional
+	ClaimParameters *corev1alpha1.ClaimParameterPolicy `json:"claimParameters,omitempty"`
 }
 
 var _ resource.Class = &RDSInstanceClass{}
@@ -0,0 +1,156 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+)
+
+// RDS snapshot states.
+const (
+	// The snapshot is available, and may be restored.
+	RDSSnapshotStateAvailable = "available"
+	// The snapshot is being created.
+	RDSSnapshotStateCreating = "creating"
+	// The snapshot could not be created.
+	RDSSnapshotStateFailed = "failed"
+)
+
+// RDSSnapshotParameters define the desired state of an RDSSnapshot.
+type RDSSnapshotParameters struct {
+	// InstanceRef references the RDSInstance to snapshot. The instance must be
+	// in the same namespace as the snapshot.
+	InstanceRef corev1.LocalObjectReference `json:"instanceRef"`
+}
+
+// RDSSnapshotSpec defines the desired state of an RDSSnapshot.
+type RDSSnapshotSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	RDSSnapshotParameters        `json:",inline"`
+}
+
+// RDSSnapshotStatus defines the observed state of an RDSSnapshot.
+type RDSSnapshotStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	// State of the snapshot, e.g. creating or available.
+	State string `json:"state,omitempty"`
+
+	// SnapshotIdentifier is the identifier of the DB snapshot in AWS.
+	SnapshotIdentifier string `json:"snapshotIdentifier,omitempty"`
+
+	// ProviderID is the ARN of the DB snapshot.
+	ProviderID string `json:"providerID,omitempty"`
+
+	// AllocatedStorage is the size of the snapshot in gigabytes.
+	AllocatedStorage int64 `json:"allocatedStorage,omitempty"`
+
+	// PercentProgress of the snapshot's creation.
+	PercentProgress int64 `json:"percentProgress,omitempty"`
+
+	// SnapshotCreateTime is the time at which the snapshot was taken.
+	SnapshotCreateTime *metav1.Time `json:"snapshotCreateTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An RDSSnapshot is an on-demand DB snapshot of an RDSInstance. RDSInstances
+// may be restored from available snapshots.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="INSTANCE",type="string",JSONPath=".spec.instanceRef.name"
+// +kubebuilder:printcolumn:name="SIZE",type="integer",JSONPath=".status.allocatedStorage"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+type RDSSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RDSSnapshotSpec   `json:"spec,omitempty"`
+	Status RDSSnapshotStatus `json:"status,omitempty"`
+}
+
+// SetBindingPhase of this RDSSnapshot.
+func (s *RDSSnapshot) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	s.Status.SetBindingPhase(p)
+}
+
+// GetBindingPhase of this RDSSnapshot.
+func (s *RDSSnapshot) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return s.Status.GetBindingPhase()
+}
+
+// SetConditions of this RDSSnapshot.
+func (s *RDSSnapshot) SetConditions(c ...runtimev1alpha1.Condition) {
+	s.Status.SetConditions(c...)
+}
+
+// GetCondition of this RDSSnapshot.
+func (s *RDSSnapshot) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return s.Status.GetCondition(ct)
+}
+
+// SetClaimReference of this RDSSnapshot.
+func (s *RDSSnapshot) SetClaimReference(r *corev1.ObjectReference) {
+	s.Spec.ClaimReference = r
+}
+
+// GetClaimReference of this RDSSnapshot.
+func (s *RDSSnapshot) GetClaimReference() *corev1.ObjectReference {
+	return s.Spec.ClaimReference
+}
+
+// SetClassReference of this RDSSnapshot.
+func (s *RDSSnapshot) SetClassReference(r *corev1.ObjectReference) {
+	s.Spec.ClassReference = r
+}
+
+// GetClassReference of this RDSSnapshot.
+func (s *RDSSnapshot) GetClassReference() *corev1.ObjectReference {
+	return s.Spec.ClassReference
+}
+
+// SetWriteConnectionSecretToReference of this RDSSnapshot.
+func (s *RDSSnapshot) SetWriteConnectionSecretToReference(r corev1.LocalObjectReference) {
+	s.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetWriteConnectionSecretToReference of this RDSSnapshot.
+func (s *RDSSnapshot) GetWriteConnectionSecretToReference() corev1.LocalObjectReference {
+	return s.Spec.WriteConnectionSecretToReference
+}
+
+// GetReclaimPolicy of this RDSSnapshot.
+func (s *RDSSnapshot) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return s.Spec.ReclaimPolicy
+}
+
+// SetReclaimPolicy of this RDSSnapshot.
+func (s *RDSSnapshot) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
+	s.Spec.ReclaimPolicy = p
+}
+
+// +kubebuilder:object:root=true
+
+// RDSSnapshotList contains a list of RDSSnapshot.
+type RDSSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RDSSnapshot `json:"items"`
+}
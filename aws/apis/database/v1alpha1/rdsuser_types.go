@@ -0,0 +1,156 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+)
+
+// A DatabaseGrant grants a user all privileges on a database.
+type DatabaseGrant struct {
+	// Database on which the user is granted all privileges. The database
+	// must exist in the same RDSInstance as the user, for example as an
+	// RDSDatabase.
+	Database string `json:"database"`
+}
+
+// RDSUserParameters define the desired state of an RDSUser.
+type RDSUserParameters struct {
+	// InstanceRef references the RDSInstance in which this user will be
+	// created. The instance must be in the same namespace as this user, and
+	// must be reachable from Crossplane. The user is created using the master
+	// credentials from the instance's connection secret.
+	InstanceRef corev1.LocalObjectReference `json:"instanceRef"`
+
+	// Username of this user. Defaults to the name of the RDSUser.
+	Username string `json:"username,omitempty"`
+
+	// Grants of privileges to this user. Privileges on databases that are
+	// not granted here are revoked.
+	// +optional
+	Grants []DatabaseGrant `json:"grants,omitempty"`
+}
+
+// RDSUserSpec defines the desired state of an RDSUser.
+type RDSUserSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	RDSUserParameters            `json:",inline"`
+}
+
+// RDSUserStatus defines the observed state of an RDSUser.
+type RDSUserStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+
+// An RDSUser is a login with a generated password on an existing RDSInstance.
+// Each application may use its own RDSUser rather than sharing the instance's
+// master credentials. Users are granted privileges only on the databases
+// named by their grants.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.bindingPhase"
+// +kubebuilder:printcolumn:name="INSTANCE",type="string",JSONPath=".spec.instanceRef.name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+type RDSUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RDSUserSpec   `json:"spec,omitempty"`
+	Status RDSUserStatus `json:"status,omitempty"`
+}
+
+// SetBindingPhase of this RDSUser.
+func (u *RDSUser) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	u.Status.SetBindingPhase(p)
+}
+
+// GetBindingPhase of this RDSUser.
+func (u *RDSUser) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return u.Status.GetBindingPhase()
+}
+
+// SetConditions of this RDSUser.
+func (u *RDSUser) SetConditions(c ...runtimev1alpha1.Condition) {
+	u.Status.SetConditions(c...)
+}
+
+// GetCondition of this RDSUser.
+func (u *RDSUser) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return u.Status.GetCondition(ct)
+}
+
+// SetClaimReference of this RDSUser.
+func (u *RDSUser) SetClaimReference(r *corev1.ObjectReference) {
+	u.Spec.ClaimReference = r
+}
+
+// GetClaimReference of this RDSUser.
+func (u *RDSUser) GetClaimReference() *corev1.ObjectReference {
+	return u.Spec.ClaimReference
+}
+
+// SetClassReference of this RDSUser.
+func (u *RDSUser) SetClassReference(r *corev1.ObjectReference) {
+	u.Spec.ClassReference = r
+}
+
+// GetClassReference of this RDSUser.
+func (u *RDSUser) GetClassReference() *corev1.ObjectReference {
+	return u.Spec.ClassReference
+}
+
+// SetWriteConnectionSecretToReference of this RDSUser.
+func (u *RDSUser) SetWriteConnectionSecretToReference(r corev1.LocalObjectReference) {
+	u.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetWriteConnectionSecretToReference of this RDSUser.
+func (u *RDSUser) GetWriteConnectionSecretToReference() corev1.LocalObjectReference {
+	return u.Spec.WriteConnectionSecretToReference
+}
+
+// GetReclaimPolicy of this RDSUser.
+func (u *RDSUser) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return u.Spec.ReclaimPolicy
+}
+
+// SetReclaimPolicy of this RDSUser.
+func (u *RDSUser) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
+	u.Spec.ReclaimPolicy = p
+}
+
+// GetUsername returns the username of this RDSUser, which defaults to its
+// name.
+func (u *RDSUser) GetUsername() string {
+	if u.Spec.Username != "" {
+		return u.Spec.Username
+	}
+	return u.GetName()
+}
+
+// +kubebuilder:object:root=true
+
+// RDSUserList contains a list of RDSUser.
+type RDSUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RDSUser `json:"items"`
+}
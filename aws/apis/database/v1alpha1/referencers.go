@@ -0,0 +1,70 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/pkg/errors"
+
+	networkv1alpha1 "github.com/crossplaneio/crossplane/aws/apis/network/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/reference"
+)
+
+const errNotRDSInstance = "managed resource is not an RDSInstance"
+
+// A SecurityGroupIDReferencerForRDSInstance resolves the ID of a referenced
+// SecurityGroup for an RDSInstance.
+type SecurityGroupIDReferencerForRDSInstance struct {
+	networkv1alpha1.SecurityGroupIDReferencer `json:",inline"`
+
+	// ResolvedID is the ID of the referenced SecurityGroup. It is replaced
+	// each time the reference is resolved.
+	// +optional
+	ResolvedID string `json:"resolvedId,omitempty"`
+}
+
+// Assign the supplied SecurityGroup ID to the reference. It is kept apart
+// from the RDSInstance's SecurityGroups so that it is no longer used once the
+// reference is removed.
+func (v *SecurityGroupIDReferencerForRDSInstance) Assign(res reference.CanReference, value string) error {
+	if _, ok := res.(*RDSInstance); !ok {
+		return errors.New(errNotRDSInstance)
+	}
+	v.ResolvedID = value
+	return nil
+}
+
+// AllSecurityGroups returns the IDs of the security groups specified by the
+// supplied RDSInstanceSpec, followed by those resolved from its references.
+func (s *RDSInstanceSpec) AllSecurityGroups() []string {
+	ids := append([]string(nil), s.SecurityGroups...)
+	for _, r := range s.SecurityGroupRefs {
+		ids = appendIfMissing(ids, r.ResolvedID)
+	}
+	return ids
+}
+
+func appendIfMissing(s []string, v string) []string {
+	if v == "" {
+		return s
+	}
+	for _, e := range s {
+		if e == v {
+			return s
+		}
+	}
+	return append(s, v)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	networkv1alpha1 "github.com/crossplaneio/crossplane/aws/apis/network/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/reference"
+)
+
+var _ reference.CanReference = &RDSInstance{}
+
+func TestSecurityGroupIDReferencerForRDSInstanceAssign(t *testing.T) {
+	sgID := "sg-cool"
+
+	cases := map[string]struct {
+		res  reference.CanReference
+		ref  *SecurityGroupIDReferencerForRDSInstance
+		want *SecurityGroupIDReferencerForRDSInstance
+		err  error
+	}{
+		"NotRDSInstance": {
+			res:  &networkv1alpha1.Subnet{},
+			ref:  &SecurityGroupIDReferencerForRDSInstance{},
+			want: &SecurityGroupIDReferencerForRDSInstance{},
+			err:  errors.New(errNotRDSInstance),
+		},
+		"Successful": {
+			res:  &RDSInstance{},
+			ref:  &SecurityGroupIDReferencerForRDSInstance{},
+			want: &SecurityGroupIDReferencerForRDSInstance{ResolvedID: sgID},
+		},
+		"ReplacesResolvedID": {
+			res:  &RDSInstance{},
+			ref:  &SecurityGroupIDReferencerForRDSInstance{ResolvedID: "sg-old"},
+			want: &SecurityGroupIDReferencerForRDSInstance{ResolvedID: sgID},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.ref.Assign(tc.res, sgID)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ref.Assign(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, tc.ref); diff != "" {
+				t.Errorf("ref.Assign(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAllSecurityGroups(t *testing.T) {
+	cases := map[string]struct {
+		spec *RDSInstanceSpec
+		want []string
+	}{
+		"None": {
+			spec: &RDSInstanceSpec{},
+			want: nil,
+		},
+		"SecurityGroupsOnly": {
+			spec: &RDSInstanceSpec{RDSInstanceParameters: RDSInstanceParameters{
+				SecurityGroups: []string{"sg-a"},
+			}},
+			want: []string{"sg-a"},
+		},
+		"SecurityGroupsAndRefs": {
+			spec: &RDSInstanceSpec{RDSInstanceParameters: RDSInstanceParameters{
+				SecurityGroups: []string{"sg-a", "sg-b"},
+				SecurityGroupRefs: []*SecurityGroupIDReferencerForRDSInstance{
+					{ResolvedID: "sg-b"},
+					{ResolvedID: "sg-c"},
+					{},
+				},
+			}},
+			want: []string{"sg-a", "sg-b", "sg-c"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := tc.spec.AllSecurityGroups()
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("AllSecurityGroups(): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -54,7 +54,39 @@ var (
 	RDSInstanceClassGroupVersionKind = SchemeGroupVersion.WithKind(RDSInstanceClassKind)
 )
 
+// RDSUser type metadata.
+var (
+	RDSUserKind             = reflect.TypeOf(RDSUser{}).Name()
+	RDSUserKindAPIVersion   = RDSUserKind + "." + SchemeGroupVersion.String()
+	RDSUserGroupVersionKind = SchemeGroupVersion.WithKind(RDSUserKind)
+)
+
+// RDSDatabase type metadata.
+var (
+	RDSDatabaseKind             = reflect.TypeOf(RDSDatabase{}).Name()
+	RDSDatabaseKindAPIVersion   = RDSDatabaseKind + "." + SchemeGroupVersion.String()
+	RDSDatabaseGroupVersionKind = SchemeGroupVersion.WithKind(RDSDatabaseKind)
+)
+
+// RDSDatabaseClass type metadata.
+var (
+	RDSDatabaseClassKind             = reflect.TypeOf(RDSDatabaseClass{}).Name()
+	RDSDatabaseClassKindAPIVersion   = RDSDatabaseClassKind + "." + SchemeGroupVersion.String()
+	RDSDatabaseClassGroupVersionKind = SchemeGroupVersion.WithKind(RDSDatabaseClassKind)
+)
+
+// RDSSnapshot type metadata.
+var (
+	RDSSnapshotKind             = reflect.TypeOf(RDSSnapshot{}).Name()
+	RDSSnapshotKindAPIVersion   = RDSSnapshotKind + "." + SchemeGroupVersion.String()
+	RDSSnapshotGroupVersionKind = SchemeGroupVersion.WithKind(RDSSnapshotKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&RDSInstance{}, &RDSInstanceList{})
 	SchemeBuilder.Register(&RDSInstanceClass{}, &RDSInstanceClassList{})
+	SchemeBuilder.Register(&RDSUser{}, &RDSUserList{})
+	SchemeBuilder.Register(&RDSDatabase{}, &RDSDatabaseList{})
+	SchemeBuilder.Register(&RDSDatabaseClass{}, &RDSDatabaseClassList{})
+	SchemeBuilder.Register(&RDSSnapshot{}, &RDSSnapshotList{})
 }
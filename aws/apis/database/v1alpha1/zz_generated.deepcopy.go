@@ -21,9 +21,210 @@ limitations under the License.
 package v1alpha1
 
 import (
+	v1 "k8s.io/api/core/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+
+	corev1alpha1 "github.com/crossplaneio/crossplane/apis/core/v1alpha1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseGrant) DeepCopyInto(out *DatabaseGrant) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseGrant.
+func (in *DatabaseGrant) DeepCopy() *DatabaseGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RDSDatabase) DeepCopyInto(out *RDSDatabase) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RDSDatabase.
+func (in *RDSDatabase) DeepCopy() *RDSDatabase {
+	if in == nil {
+		return nil
+	}
+	out := new(RDSDatabase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RDSDatabase) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RDSDatabaseClass) DeepCopyInto(out *RDSDatabaseClass) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.SpecTemplate.DeepCopyInto(&out.SpecTemplate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RDSDatabaseClass.
+func (in *RDSDatabaseClass) DeepCopy() *RDSDatabaseClass {
+	if in == nil {
+		return nil
+	}
+	out := new(RDSDatabaseClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RDSDatabaseClass) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RDSDatabaseClassList) DeepCopyInto(out *RDSDatabaseClassList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RDSDatabaseClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RDSDatabaseClassList.
+func (in *RDSDatabaseClassList) DeepCopy() *RDSDatabaseClassList {
+	if in == nil {
+		return nil
+	}
+	out := new(RDSDatabaseClassList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RDSDatabaseClassList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RDSDatabaseClassSpecTemplate) DeepCopyInto(out *RDSDatabaseClassSpecTemplate) {
+	*out = *in
+	in.ResourceClassSpecTemplate.DeepCopyInto(&out.ResourceClassSpecTemplate)
+	out.RDSDatabaseParameters = in.RDSDatabaseParameters
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RDSDatabaseClassSpecTemplate.
+func (in *RDSDatabaseClassSpecTemplate) DeepCopy() *RDSDatabaseClassSpecTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(RDSDatabaseClassSpecTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RDSDatabaseList) DeepCopyInto(out *RDSDatabaseList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RDSDatabase, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RDSDatabaseList.
+func (in *RDSDatabaseList) DeepCopy() *RDSDatabaseList {
+	if in == nil {
+		return nil
+	}
+	out := new(RDSDatabaseList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RDSDatabaseList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RDSDatabaseParameters) DeepCopyInto(out *RDSDatabaseParameters) {
+	*out = *in
+	out.InstanceRef = in.InstanceRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RDSDatabaseParameters.
+func (in *RDSDatabaseParameters) DeepCopy() *RDSDatabaseParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(RDSDatabaseParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RDSDatabaseSpec) DeepCopyInto(out *RDSDatabaseSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	out.RDSDatabaseParameters = in.RDSDatabaseParameters
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RDSDatabaseSpec.
+func (in *RDSDatabaseSpec) DeepCopy() *RDSDatabaseSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RDSDatabaseSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RDSDatabaseStatus) DeepCopyInto(out *RDSDatabaseStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RDSDatabaseStatus.
+func (in *RDSDatabaseStatus) DeepCopy() *RDSDatabaseStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RDSDatabaseStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RDSInstance) DeepCopyInto(out *RDSInstance) {
 	*out = *in
@@ -114,6 +315,11 @@ func (in *RDSInstanceClassSpecTemplate) DeepCopyInto(out *RDSInstanceClassSpecTe
 	*out = *in
 	in.ResourceClassSpecTemplate.DeepCopyInto(&out.ResourceClassSpecTemplate)
 	in.RDSInstanceParameters.DeepCopyInto(&out.RDSInstanceParameters)
+	if in.ClaimParameters != nil {
+		in, out := &in.ClaimParameters, &out.ClaimParameters
+		*out = new(corev1alpha1.ClaimParameterPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RDSInstanceClassSpecTemplate.
@@ -166,6 +372,27 @@ func (in *RDSInstanceParameters) DeepCopyInto(out *RDSInstanceParameters) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.SecurityGroupRefs != nil {
+		in, out := &in.SecurityGroupRefs, &out.SecurityGroupRefs
+		*out = make([]*SecurityGroupIDReferencerForRDSInstance, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(SecurityGroupIDReferencerForRDSInstance)
+				**out = **in
+			}
+		}
+	}
+	if in.RestoreFrom != nil {
+		in, out := &in.RestoreFrom, &out.RestoreFrom
+		*out = new(RDSInstanceRestoreSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeletionProtection != nil {
+		in, out := &in.DeletionProtection, &out.DeletionProtection
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RDSInstanceParameters.
@@ -178,6 +405,51 @@ func (in *RDSInstanceParameters) DeepCopy() *RDSInstanceParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RDSInstancePointInTime) DeepCopyInto(out *RDSInstancePointInTime) {
+	*out = *in
+	out.InstanceRef = in.InstanceRef
+	if in.RestoreTime != nil {
+		in, out := &in.RestoreTime, &out.RestoreTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RDSInstancePointInTime.
+func (in *RDSInstancePointInTime) DeepCopy() *RDSInstancePointInTime {
+	if in == nil {
+		return nil
+	}
+	out := new(RDSInstancePointInTime)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RDSInstanceRestoreSource) DeepCopyInto(out *RDSInstanceRestoreSource) {
+	*out = *in
+	if in.SnapshotRef != nil {
+		in, out := &in.SnapshotRef, &out.SnapshotRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.PointInTime != nil {
+		in, out := &in.PointInTime, &out.PointInTime
+		*out = new(RDSInstancePointInTime)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RDSInstanceRestoreSource.
+func (in *RDSInstanceRestoreSource) DeepCopy() *RDSInstanceRestoreSource {
+	if in == nil {
+		return nil
+	}
+	out := new(RDSInstanceRestoreSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RDSInstanceSpec) DeepCopyInto(out *RDSInstanceSpec) {
 	*out = *in
@@ -210,3 +482,244 @@ func (in *RDSInstanceStatus) DeepCopy() *RDSInstanceStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RDSSnapshot) DeepCopyInto(out *RDSSnapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RDSSnapshot.
+func (in *RDSSnapshot) DeepCopy() *RDSSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(RDSSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RDSSnapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RDSSnapshotList) DeepCopyInto(out *RDSSnapshotList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RDSSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RDSSnapshotList.
+func (in *RDSSnapshotList) DeepCopy() *RDSSnapshotList {
+	if in == nil {
+		return nil
+	}
+	out := new(RDSSnapshotList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RDSSnapshotList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RDSSnapshotParameters) DeepCopyInto(out *RDSSnapshotParameters) {
+	*out = *in
+	out.InstanceRef = in.InstanceRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RDSSnapshotParameters.
+func (in *RDSSnapshotParameters) DeepCopy() *RDSSnapshotParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(RDSSnapshotParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RDSSnapshotSpec) DeepCopyInto(out *RDSSnapshotSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	out.RDSSnapshotParameters = in.RDSSnapshotParameters
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RDSSnapshotSpec.
+func (in *RDSSnapshotSpec) DeepCopy() *RDSSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RDSSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RDSSnapshotStatus) DeepCopyInto(out *RDSSnapshotStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	if in.SnapshotCreateTime != nil {
+		in, out := &in.SnapshotCreateTime, &out.SnapshotCreateTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RDSSnapshotStatus.
+func (in *RDSSnapshotStatus) DeepCopy() *RDSSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RDSSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RDSUser) DeepCopyInto(out *RDSUser) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RDSUser.
+func (in *RDSUser) DeepCopy() *RDSUser {
+	if in == nil {
+		return nil
+	}
+	out := new(RDSUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RDSUser) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RDSUserList) DeepCopyInto(out *RDSUserList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RDSUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RDSUserList.
+func (in *RDSUserList) DeepCopy() *RDSUserList {
+	if in == nil {
+		return nil
+	}
+	out := new(RDSUserList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RDSUserList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RDSUserParameters) DeepCopyInto(out *RDSUserParameters) {
+	*out = *in
+	out.InstanceRef = in.InstanceRef
+	if in.Grants != nil {
+		in, out := &in.Grants, &out.Grants
+		*out = make([]DatabaseGrant, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RDSUserParameters.
+func (in *RDSUserParameters) DeepCopy() *RDSUserParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(RDSUserParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RDSUserSpec) DeepCopyInto(out *RDSUserSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.RDSUserParameters.DeepCopyInto(&out.RDSUserParameters)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RDSUserSpec.
+func (in *RDSUserSpec) DeepCopy() *RDSUserSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RDSUserSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RDSUserStatus) DeepCopyInto(out *RDSUserStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RDSUserStatus.
+func (in *RDSUserStatus) DeepCopy() *RDSUserStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RDSUserStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupIDReferencerForRDSInstance) DeepCopyInto(out *SecurityGroupIDReferencerForRDSInstance) {
+	*out = *in
+	out.SecurityGroupIDReferencer = in.SecurityGroupIDReferencer
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupIDReferencerForRDSInstance.
+func (in *SecurityGroupIDReferencerForRDSInstance) DeepCopy() *SecurityGroupIDReferencerForRDSInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupIDReferencerForRDSInstance)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplaneio/crossplane/pkg/reference"
+)
+
+// Error strings.
+const (
+	errNotSubnet        = "managed resource is not a Subnet"
+	errNotSecurityGroup = "managed resource is not a SecurityGroup"
+)
+
+// A VPCIDReferencer resolves the ID of a VPC in the same namespace as the
+// referencing resource.
+type VPCIDReferencer struct {
+	corev1.LocalObjectReference `json:",inline"`
+}
+
+// Build returns the ID of the referenced VPC once it is available.
+func (v *VPCIDReferencer) Build(ctx context.Context, res reference.CanReference, r client.Reader) (string, error) {
+	vpc := &VPC{}
+	if err := reference.Get(ctx, r, res, v.Name, vpc); err != nil {
+		return "", err
+	}
+	if !reference.Ready(vpc.Status.ConditionedStatus) || vpc.Status.VPCID == "" {
+		return "", reference.NewNotReadyError("referenced VPC %s is not available", v.Name)
+	}
+	return vpc.Status.VPCID, nil
+}
+
+// A SubnetIDReferencer resolves the ID of a Subnet in the same namespace as
+// the referencing resource.
+type SubnetIDReferencer struct {
+	corev1.LocalObjectReference `json:",inline"`
+}
+
+// Build returns the ID of the referenced Subnet once it is available.
+func (v *SubnetIDReferencer) Build(ctx context.Context, res reference.CanReference, r client.Reader) (string, error) {
+	s := &Subnet{}
+	if err := reference.Get(ctx, r, res, v.Name, s); err != nil {
+		return "", err
+	}
+	if !reference.Ready(s.Status.ConditionedStatus) || s.Status.SubnetID == "" {
+		return "", reference.NewNotReadyError("referenced Subnet %s is not available", v.Name)
+	}
+	return s.Status.SubnetID, nil
+}
+
+// A SecurityGroupIDReferencer resolves the ID of a SecurityGroup in the same
+// namespace as the referencing resource.
+type SecurityGroupIDReferencer struct {
+	corev1.LocalObjectReference `json:",inline"`
+}
+
+// Build returns the ID of the referenced SecurityGroup once it is available.
+func (v *SecurityGroupIDReferencer) Build(ctx context.Context, res reference.CanReference, r client.Reader) (string, error) {
+	sg := &SecurityGroup{}
+	if err := reference.Get(ctx, r, res, v.Name, sg); err != nil {
+		return "", err
+	}
+	if !reference.Ready(sg.Status.ConditionedStatus) || sg.Status.SecurityGroupID == "" {
+		return "", reference.NewNotReadyError("referenced SecurityGroup %s is not available", v.Name)
+	}
+	return sg.Status.SecurityGroupID, nil
+}
+
+// A VPCIDReferencerForSubnet assigns the ID of a referenced VPC to a Subnet.
+type VPCIDReferencerForSubnet struct {
+	VPCIDReferencer `json:",inline"`
+}
+
+// Assign the supplied VPC ID to the supplied Subnet.
+func (v *VPCIDReferencerForSubnet) Assign(res reference.CanReference, value string) error {
+	s, ok := res.(*Subnet)
+	if !ok {
+		return errors.New(errNotSubnet)
+	}
+	s.Spec.VPCID = value
+	return nil
+}
+
+// A VPCIDReferencerForSecurityGroup assigns the ID of a referenced VPC to a
+// SecurityGroup.
+type VPCIDReferencerForSecurityGroup struct {
+	VPCIDReferencer `json:",inline"`
+}
+
+// Assign the supplied VPC ID to the supplied SecurityGroup.
+func (v *VPCIDReferencerForSecurityGroup) Assign(res reference.CanReference, value string) error {
+	sg, ok := res.(*SecurityGroup)
+	if !ok {
+		return errors.New(errNotSecurityGroup)
+	}
+	sg.Spec.VPCID = value
+	return nil
+}
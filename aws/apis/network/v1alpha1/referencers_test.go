@@ -0,0 +1,136 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	"github.com/crossplaneio/crossplane/pkg/reference"
+)
+
+var _ reference.CanReference = &Subnet{}
+var _ reference.CanReference = &SecurityGroup{}
+
+func TestVPCIDReferencerBuild(t *testing.T) {
+	errBoom := errors.New("boom")
+	vpcName := "cool-vpc"
+	vpcID := "vpc-cool"
+
+	type want struct {
+		value    string
+		notReady bool
+		err      error
+	}
+
+	cases := map[string]struct {
+		kube client.Reader
+		want want
+	}{
+		"VPCNotFound": {
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, vpcName))},
+			want: want{notReady: true},
+		},
+		"GetVPCFailed": {
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			want: want{err: errors.Wrapf(errBoom, "cannot get referenced resource %s", vpcName)},
+		},
+		"VPCNotAvailable": {
+			kube: &test.MockClient{MockGet: func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+				v := obj.(*VPC)
+				v.Status.VPCID = vpcID
+				v.Status.SetConditions(runtimev1alpha1.Creating())
+				return nil
+			}},
+			want: want{notReady: true},
+		},
+		"Successful": {
+			kube: &test.MockClient{MockGet: func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+				v := obj.(*VPC)
+				v.Status.VPCID = vpcID
+				v.Status.SetConditions(runtimev1alpha1.Available())
+				return nil
+			}},
+			want: want{value: vpcID},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ref := &VPCIDReferencer{LocalObjectReference: corev1.LocalObjectReference{Name: vpcName}}
+			res := &Subnet{ObjectMeta: metav1.ObjectMeta{Namespace: namespace}}
+
+			got, err := ref.Build(context.Background(), res, tc.kube)
+			if diff := cmp.Diff(tc.want.value, got); diff != "" {
+				t.Errorf("ref.Build(...): -want, +got:\n%s", diff)
+			}
+			if tc.want.notReady {
+				if !reference.IsNotReady(err) {
+					t.Errorf("ref.Build(...): want not ready error, got %v", err)
+				}
+				return
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ref.Build(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestVPCIDReferencerForSubnetAssign(t *testing.T) {
+	vpcID := "vpc-cool"
+
+	cases := map[string]struct {
+		res  reference.CanReference
+		want reference.CanReference
+		err  error
+	}{
+		"NotSubnet": {
+			res:  &SecurityGroup{},
+			want: &SecurityGroup{},
+			err:  errors.New(errNotSubnet),
+		},
+		"Successful": {
+			res:  &Subnet{},
+			want: &Subnet{Spec: SubnetSpec{SubnetParameters: SubnetParameters{VPCID: vpcID}}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ref := &VPCIDReferencerForSubnet{}
+			err := ref.Assign(tc.res, vpcID)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ref.Assign(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, tc.res); diff != "" {
+				t.Errorf("ref.Assign(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
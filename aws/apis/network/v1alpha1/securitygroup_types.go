@@ -0,0 +1,205 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/reference"
+)
+
+// IPProtocolAll matches traffic of any protocol, on any port.
+const IPProtocolAll = "-1"
+
+// An IPPermission allows traffic to or from a set of IPv4 ranges.
+type IPPermission struct {
+	// IPProtocol is the name or number of the IP protocol to allow, e.g. tcp,
+	// udp, icmp, or -1 to allow all protocols.
+	IPProtocol string `json:"ipProtocol"`
+
+	// FromPort is the start of the allowed port range for tcp and udp, or the
+	// ICMP type for icmp.
+	// +optional
+	FromPort *int64 `json:"fromPort,omitempty"`
+
+	// ToPort is the end of the allowed port range for tcp and udp, or the ICMP
+	// code for icmp.
+	// +optional
+	ToPort *int64 `json:"toPort,omitempty"`
+
+	// CIDRBlocks from or to which traffic is allowed, e.g. 10.0.0.0/16.
+	CIDRBlocks []string `json:"cidrBlocks"`
+}
+
+// SecurityGroupParameters define the desired state of an AWS security group.
+type SecurityGroupParameters struct {
+	// VPCID is the ID of the VPC in which to create the security group. It is
+	// resolved from VPCIDRef when that is set.
+	// +optional
+	VPCID string `json:"vpcId,omitempty"`
+
+	// VPCIDRef references a VPC in the same namespace, from which VPCID is
+	// resolved once the VPC is available.
+	// +optional
+	VPCIDRef *VPCIDReferencerForSecurityGroup `json:"vpcIdRef,omitempty"`
+
+	// GroupName of the security group. Defaults to a name derived from the
+	// SecurityGroup's UID. It cannot be changed after the group is created.
+	// +optional
+	GroupName string `json:"groupName,omitempty"`
+
+	// Description of the security group. It cannot be changed after the group
+	// is created.
+	Description string `json:"description"`
+
+	// Ingress rules of the security group. Traffic that no rule allows is
+	// denied.
+	// +optional
+	Ingress []IPPermission `json:"ingress,omitempty"`
+
+	// Egress rules of the security group. AWS creates security groups with a
+	// rule that allows all outbound traffic; that rule is left in place when
+	// no egress rules are specified.
+	// +optional
+	Egress []IPPermission `json:"egress,omitempty"`
+}
+
+// SecurityGroupSpec defines the desired state of a SecurityGroup.
+type SecurityGroupSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	SecurityGroupParameters      `json:",inline"`
+}
+
+// SecurityGroupStatus defines the observed state of a SecurityGroup.
+type SecurityGroupStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	// SecurityGroupID is the ID AWS assigned to the security group.
+	SecurityGroupID string `json:"securityGroupId,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A SecurityGroup is a managed resource that represents an AWS VPC security
+// group.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="GROUPID",type="string",JSONPath=".status.securityGroupId"
+// +kubebuilder:printcolumn:name="VPCID",type="string",JSONPath=".spec.vpcId"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+type SecurityGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SecurityGroupSpec   `json:"spec,omitempty"`
+	Status SecurityGroupStatus `json:"status,omitempty"`
+}
+
+// SetBindingPhase of this SecurityGroup.
+func (g *SecurityGroup) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	g.Status.SetBindingPhase(p)
+}
+
+// GetBindingPhase of this SecurityGroup.
+func (g *SecurityGroup) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return g.Status.GetBindingPhase()
+}
+
+// SetConditions of this SecurityGroup.
+func (g *SecurityGroup) SetConditions(c ...runtimev1alpha1.Condition) {
+	g.Status.SetConditions(c...)
+}
+
+// GetCondition of this SecurityGroup.
+func (g *SecurityGroup) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return g.Status.GetCondition(ct)
+}
+
+// SetClaimReference of this SecurityGroup.
+func (g *SecurityGroup) SetClaimReference(r *corev1.ObjectReference) {
+	g.Spec.ClaimReference = r
+}
+
+// GetClaimReference of this SecurityGroup.
+func (g *SecurityGroup) GetClaimReference() *corev1.ObjectReference {
+	return g.Spec.ClaimReference
+}
+
+// SetClassReference of this SecurityGroup.
+func (g *SecurityGroup) SetClassReference(r *corev1.ObjectReference) {
+	g.Spec.ClassReference = r
+}
+
+// GetClassReference of this SecurityGroup.
+func (g *SecurityGroup) GetClassReference() *corev1.ObjectReference {
+	return g.Spec.ClassReference
+}
+
+// SetWriteConnectionSecretToReference of this SecurityGroup.
+func (g *SecurityGroup) SetWriteConnectionSecretToReference(r corev1.LocalObjectReference) {
+	g.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetWriteConnectionSecretToReference of this SecurityGroup.
+func (g *SecurityGroup) GetWriteConnectionSecretToReference() corev1.LocalObjectReference {
+	return g.Spec.WriteConnectionSecretToReference
+}
+
+// GetReclaimPolicy of this SecurityGroup.
+func (g *SecurityGroup) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return g.Spec.ReclaimPolicy
+}
+
+// SetReclaimPolicy of this SecurityGroup.
+func (g *SecurityGroup) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
+	g.Spec.ReclaimPolicy = p
+}
+
+// GetProviderReference of this SecurityGroup.
+func (g *SecurityGroup) GetProviderReference() *corev1.ObjectReference {
+	return g.Spec.ProviderReference
+}
+
+// GetReferencers of this SecurityGroup.
+func (g *SecurityGroup) GetReferencers() []reference.AttributeReferencer {
+	if g.Spec.VPCIDRef == nil {
+		return nil
+	}
+	return []reference.AttributeReferencer{g.Spec.VPCIDRef}
+}
+
+// GetGroupName returns the name of this SecurityGroup's AWS security group.
+// AWS reserves names prefixed with sg-, so the default name is prefixed with
+// the SecurityGroup's kind.
+func (g *SecurityGroup) GetGroupName() string {
+	if g.Spec.GroupName != "" {
+		return g.Spec.GroupName
+	}
+	return strings.ToLower(SecurityGroupKind) + "-" + string(g.GetUID())
+}
+
+// +kubebuilder:object:root=true
+
+// SecurityGroupList contains a list of SecurityGroup.
+type SecurityGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SecurityGroup `json:"items"`
+}
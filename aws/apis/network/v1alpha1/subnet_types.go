@@ -0,0 +1,179 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/reference"
+)
+
+// Subnet states.
+const (
+	SubnetStatePending   = "pending"
+	SubnetStateAvailable = "available"
+)
+
+// SubnetParameters define the desired state of an AWS VPC subnet.
+type SubnetParameters struct {
+	// VPCID is the ID of the VPC in which to create the subnet. It is
+	// resolved from VPCIDRef when that is set.
+	// +optional
+	VPCID string `json:"vpcId,omitempty"`
+
+	// VPCIDRef references a VPC in the same namespace, from which VPCID is
+	// resolved once the VPC is available.
+	// +optional
+	VPCIDRef *VPCIDReferencerForSubnet `json:"vpcIdRef,omitempty"`
+
+	// CIDRBlock is the IPv4 network range for the subnet, in CIDR notation,
+	// e.g. 10.0.1.0/24. It must fall within the VPC's CIDR block, and cannot be
+	// changed after the subnet is created.
+	CIDRBlock string `json:"cidrBlock"`
+
+	// AvailabilityZone in which to create the subnet, e.g. us-west-2a. AWS
+	// chooses one if it is omitted. It cannot be changed after the subnet is
+	// created.
+	// +optional
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+
+	// MapPublicIPOnLaunch indicates whether instances launched into the subnet
+	// are assigned a public IPv4 address. AWS disables it by default.
+	// +optional
+	MapPublicIPOnLaunch *bool `json:"mapPublicIPOnLaunch,omitempty"`
+}
+
+// SubnetSpec defines the desired state of a Subnet.
+type SubnetSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	SubnetParameters             `json:",inline"`
+}
+
+// SubnetStatus defines the observed state of a Subnet.
+type SubnetStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	// SubnetID is the ID AWS assigned to the subnet.
+	SubnetID string `json:"subnetId,omitempty"`
+
+	// State of the subnet, either pending or available.
+	State string `json:"state,omitempty"`
+
+	// AvailabilityZone in which the subnet was created.
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Subnet is a managed resource that represents a subnet of an AWS VPC.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="SUBNETID",type="string",JSONPath=".status.subnetId"
+// +kubebuilder:printcolumn:name="VPCID",type="string",JSONPath=".spec.vpcId"
+// +kubebuilder:printcolumn:name="CIDR",type="string",JSONPath=".spec.cidrBlock"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+type Subnet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SubnetSpec   `json:"spec,omitempty"`
+	Status SubnetStatus `json:"status,omitempty"`
+}
+
+// SetBindingPhase of this Subnet.
+func (s *Subnet) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	s.Status.SetBindingPhase(p)
+}
+
+// GetBindingPhase of this Subnet.
+func (s *Subnet) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return s.Status.GetBindingPhase()
+}
+
+// SetConditions of this Subnet.
+func (s *Subnet) SetConditions(c ...runtimev1alpha1.Condition) {
+	s.Status.SetConditions(c...)
+}
+
+// GetCondition of this Subnet.
+func (s *Subnet) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return s.Status.GetCondition(ct)
+}
+
+// SetClaimReference of this Subnet.
+func (s *Subnet) SetClaimReference(r *corev1.ObjectReference) {
+	s.Spec.ClaimReference = r
+}
+
+// GetClaimReference of this Subnet.
+func (s *Subnet) GetClaimReference() *corev1.ObjectReference {
+	return s.Spec.ClaimReference
+}
+
+// SetClassReference of this Subnet.
+func (s *Subnet) SetClassReference(r *corev1.ObjectReference) {
+	s.Spec.ClassReference = r
+}
+
+// GetClassReference of this Subnet.
+func (s *Subnet) GetClassReference() *corev1.ObjectReference {
+	return s.Spec.ClassReference
+}
+
+// SetWriteConnectionSecretToReference of this Subnet.
+func (s *Subnet) SetWriteConnectionSecretToReference(r corev1.LocalObjectReference) {
+	s.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetWriteConnectionSecretToReference of this Subnet.
+func (s *Subnet) GetWriteConnectionSecretToReference() corev1.LocalObjectReference {
+	return s.Spec.WriteConnectionSecretToReference
+}
+
+// GetReclaimPolicy of this Subnet.
+func (s *Subnet) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return s.Spec.ReclaimPolicy
+}
+
+// SetReclaimPolicy of this Subnet.
+func (s *Subnet) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
+	s.Spec.ReclaimPolicy = p
+}
+
+// GetProviderReference of this Subnet.
+func (s *Subnet) GetProviderReference() *corev1.ObjectReference {
+	return s.Spec.ProviderReference
+}
+
+// GetReferencers of this Subnet.
+func (s *Subnet) GetReferencers() []reference.AttributeReferencer {
+	if s.Spec.VPCIDRef == nil {
+		return nil
+	}
+	return []reference.AttributeReferencer{s.Spec.VPCIDRef}
+}
+
+// +kubebuilder:object:root=true
+
+// SubnetList contains a list of Subnet.
+type SubnetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Subnet `json:"items"`
+}
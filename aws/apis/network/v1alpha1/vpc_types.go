@@ -0,0 +1,160 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+)
+
+// VPC states.
+const (
+	VPCStatePending   = "pending"
+	VPCStateAvailable = "available"
+)
+
+// VPCParameters define the desired state of an AWS VPC.
+type VPCParameters struct {
+	// CIDRBlock is the IPv4 network range for the VPC, in CIDR notation, e.g.
+	// 10.0.0.0/16. It cannot be changed after the VPC is created.
+	CIDRBlock string `json:"cidrBlock"`
+
+	// InstanceTenancy of instances launched into the VPC. It cannot be changed
+	// after the VPC is created.
+	// +kubebuilder:validation:Enum=default;dedicated
+	// +optional
+	InstanceTenancy string `json:"instanceTenancy,omitempty"`
+
+	// EnableDNSSupport indicates whether the Amazon provided DNS server
+	// resolves names within the VPC. AWS enables it by default.
+	// +optional
+	EnableDNSSupport *bool `json:"enableDnsSupport,omitempty"`
+
+	// EnableDNSHostnames indicates whether instances launched into the VPC get
+	// public DNS hostnames. AWS disables it by default.
+	// +optional
+	EnableDNSHostnames *bool `json:"enableDnsHostnames,omitempty"`
+}
+
+// VPCSpec defines the desired state of a VPC.
+type VPCSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	VPCParameters                `json:",inline"`
+}
+
+// VPCStatus defines the observed state of a VPC.
+type VPCStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	// VPCID is the ID AWS assigned to the VPC.
+	VPCID string `json:"vpcId,omitempty"`
+
+	// State of the VPC, either pending or available.
+	State string `json:"state,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A VPC is a managed resource that represents an AWS Virtual Private Cloud.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="VPCID",type="string",JSONPath=".status.vpcId"
+// +kubebuilder:printcolumn:name="CIDR",type="string",JSONPath=".spec.cidrBlock"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+type VPC struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VPCSpec   `json:"spec,omitempty"`
+	Status VPCStatus `json:"status,omitempty"`
+}
+
+// SetBindingPhase of this VPC.
+func (v *VPC) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	v.Status.SetBindingPhase(p)
+}
+
+// GetBindingPhase of this VPC.
+func (v *VPC) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return v.Status.GetBindingPhase()
+}
+
+// SetConditions of this VPC.
+func (v *VPC) SetConditions(c ...runtimev1alpha1.Condition) {
+	v.Status.SetConditions(c...)
+}
+
+// GetCondition of this VPC.
+func (v *VPC) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return v.Status.GetCondition(ct)
+}
+
+// SetClaimReference of this VPC.
+func (v *VPC) SetClaimReference(r *corev1.ObjectReference) {
+	v.Spec.ClaimReference = r
+}
+
+// GetClaimReference of this VPC.
+func (v *VPC) GetClaimReference() *corev1.ObjectReference {
+	return v.Spec.ClaimReference
+}
+
+// SetClassReference of this VPC.
+func (v *VPC) SetClassReference(r *corev1.ObjectReference) {
+	v.Spec.ClassReference = r
+}
+
+// GetClassReference of this VPC.
+func (v *VPC) GetClassReference() *corev1.ObjectReference {
+	return v.Spec.ClassReference
+}
+
+// SetWriteConnectionSecretToReference of this VPC.
+func (v *VPC) SetWriteConnectionSecretToReference(r corev1.LocalObjectReference) {
+	v.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetWriteConnectionSecretToReference of this VPC.
+func (v *VPC) GetWriteConnectionSecretToReference() corev1.LocalObjectReference {
+	return v.Spec.WriteConnectionSecretToReference
+}
+
+// GetReclaimPolicy of this VPC.
+func (v *VPC) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return v.Spec.ReclaimPolicy
+}
+
+// SetReclaimPolicy of this VPC.
+func (v *VPC) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
+	v.Spec.ReclaimPolicy = p
+}
+
+// GetProviderReference of this VPC.
+func (v *VPC) GetProviderReference() *corev1.ObjectReference {
+	return v.Spec.ProviderReference
+}
+
+// +kubebuilder:object:root=true
+
+// VPCList contains a list of VPC.
+type VPCList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VPC `json:"items"`
+}
@@ -28,6 +28,7 @@ import (
 	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
 	"github.com/crossplaneio/crossplane-runtime/pkg/util"
+	"github.com/crossplaneio/crossplane/pkg/externalname"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -50,6 +51,146 @@ type S3BucketParameters struct {
 	// bucket service account that is available in a secret after provisioning.
 	// +kubebuilder:validation:Enum=Read;Write;ReadWrite
 	LocalPermission *storagev1alpha1.LocalPermissionType `json:"localPermission"`
+
+	// LifecycleRules expire objects or transition them to another storage
+	// class as they age.
+	LifecycleRules []LifecycleRule `json:"lifecycleRules,omitempty"`
+
+	// CORSRules configure the bucket's Cross-Origin Resource Sharing (CORS).
+	CORSRules []CORSRule `json:"corsRules,omitempty"`
+
+	// Encryption configures default server-side encryption of new objects.
+	Encryption *BucketEncryption `json:"encryption,omitempty"`
+
+	// Logging configures server access logging for the bucket.
+	Logging *BucketLogging `json:"logging,omitempty"`
+
+	// Website configures the bucket to host a static website.
+	Website *BucketWebsite `json:"website,omitempty"`
+
+	// Tags to apply to the bucket.
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// PublicAccessBlock limits public access to the bucket and its objects.
+	PublicAccessBlock *BucketPublicAccessBlock `json:"publicAccessBlock,omitempty"`
+}
+
+// LifecycleRule describes actions S3 takes on a bucket's objects as they age.
+type LifecycleRule struct {
+	// ID uniquely identifies the rule within the bucket.
+	ID string `json:"id"`
+
+	// Prefix limits the rule to objects with keys that begin with the prefix.
+	// The rule applies to all objects in the bucket if the prefix is omitted.
+	Prefix string `json:"prefix,omitempty"`
+
+	// Disabled rules are retained by the bucket but not applied.
+	Disabled bool `json:"disabled,omitempty"`
+
+	// ExpirationDays is the number of days after their creation at which
+	// objects expire.
+	ExpirationDays int `json:"expirationDays,omitempty"`
+
+	// NoncurrentVersionExpirationDays is the number of days after they become
+	// noncurrent at which object versions are permanently deleted. This only
+	// applies to versioned buckets.
+	NoncurrentVersionExpirationDays int `json:"noncurrentVersionExpirationDays,omitempty"`
+
+	// AbortIncompleteMultipartUploadDays is the number of days after they are
+	// initiated at which incomplete multipart uploads are aborted.
+	AbortIncompleteMultipartUploadDays int `json:"abortIncompleteMultipartUploadDays,omitempty"`
+
+	// Transitions move objects to another storage class as they age.
+	Transitions []LifecycleTransition `json:"transitions,omitempty"`
+}
+
+// LifecycleTransition moves objects to a different storage class.
+type LifecycleTransition struct {
+	// Days after their creation at which objects are transitioned.
+	Days int `json:"days"`
+
+	// StorageClass to which objects are transitioned.
+	// +kubebuilder:validation:Enum=GLACIER;STANDARD_IA;ONEZONE_IA
+	StorageClass string `json:"storageClass"`
+}
+
+// CORSRule specifies a cross-origin access rule for a bucket.
+type CORSRule struct {
+	// AllowedHeaders specifies which headers are allowed in a preflight
+	// OPTIONS request, via the Access-Control-Request-Headers header.
+	AllowedHeaders []string `json:"allowedHeaders,omitempty"`
+
+	// AllowedMethods are the HTTP methods that the origins may execute, e.g.
+	// GET, PUT, HEAD, POST or DELETE.
+	AllowedMethods []string `json:"allowedMethods"`
+
+	// AllowedOrigins are the origins from which cross-origin requests are
+	// allowed. Note that "*" means any origin.
+	AllowedOrigins []string `json:"allowedOrigins"`
+
+	// ExposeHeaders are the response headers that clients may access from
+	// their applications.
+	ExposeHeaders []string `json:"exposeHeaders,omitempty"`
+
+	// MaxAgeSeconds is the time in seconds for which a browser may cache the
+	// response to a preflight request.
+	MaxAgeSeconds int `json:"maxAgeSeconds,omitempty"`
+}
+
+// BucketEncryption is a bucket's default server-side encryption
+// configuration. It applies to objects that are stored without specifying
+// an encryption method.
+type BucketEncryption struct {
+	// Algorithm used to encrypt new objects.
+	// +kubebuilder:validation:Enum=AES256;aws:kms
+	Algorithm string `json:"algorithm"`
+
+	// KMSMasterKeyID is the ID of the AWS KMS key used to encrypt new objects
+	// when the algorithm is aws:kms. The default aws/s3 key is used if it is
+	// omitted.
+	KMSMasterKeyID string `json:"kmsMasterKeyId,omitempty"`
+}
+
+// BucketLogging is a bucket's server access logging configuration. The
+// target bucket must be in the same region as this bucket, and must grant
+// write access to the S3 log delivery group.
+type BucketLogging struct {
+	// TargetBucket in which access logs are stored.
+	TargetBucket string `json:"targetBucket"`
+
+	// TargetPrefix is prepended to the keys of all access log objects.
+	TargetPrefix string `json:"targetPrefix,omitempty"`
+}
+
+// BucketWebsite is a bucket's static website hosting configuration.
+type BucketWebsite struct {
+	// IndexDocumentSuffix is appended to requests for a directory, e.g. a
+	// suffix of index.html causes a request for images/ to return
+	// images/index.html.
+	IndexDocumentSuffix string `json:"indexDocumentSuffix"`
+
+	// ErrorDocumentKey is the key of the object returned when a 4XX error
+	// occurs.
+	ErrorDocumentKey string `json:"errorDocumentKey,omitempty"`
+}
+
+// BucketPublicAccessBlock is a bucket's public access block configuration.
+type BucketPublicAccessBlock struct {
+	// BlockPublicACLs causes S3 to reject requests that set a public ACL on
+	// the bucket or its objects.
+	BlockPublicACLs bool `json:"blockPublicAcls,omitempty"`
+
+	// IgnorePublicACLs causes S3 to ignore public ACLs on the bucket and its
+	// objects.
+	IgnorePublicACLs bool `json:"ignorePublicAcls,omitempty"`
+
+	// BlockPublicPolicy causes S3 to reject requests that set a bucket policy
+	// granting public access.
+	BlockPublicPolicy bool `json:"blockPublicPolicy,omitempty"`
+
+	// RestrictPublicBuckets limits access to a bucket with a public policy to
+	// AWS services and authorized users within the bucket owner's account.
+	RestrictPublicBuckets bool `json:"restrictPublicBuckets,omitempty"`
 }
 
 // S3BucketSpec defines the desired state of S3Bucket
@@ -99,6 +240,11 @@ func (b *S3Bucket) SetConditions(c ...runtimev1alpha1.Condition) {
 	b.Status.SetConditions(c...)
 }
 
+// GetCondition of this S3Bucket.
+func (b *S3Bucket) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return b.Status.GetCondition(ct)
+}
+
 // SetClaimReference of this S3Bucket.
 func (b *S3Bucket) SetClaimReference(r *corev1.ObjectReference) {
 	b.Spec.ClaimReference = r
@@ -188,7 +334,8 @@ type S3BucketClassList struct {
 	Items           []S3BucketClass `json:"items"`
 }
 
-// GetBucketName based on the NameFormat spec value,
+// GetBucketName returns the external name of the bucket, if it has one.
+// Otherwise the name is based on the NameFormat spec value,
 // If name format is not provided, bucket name defaults to UID
 // If name format provided with '%s' value, bucket name will result in formatted string + UID,
 //   NOTE: only single %s substitution is supported
@@ -203,7 +350,7 @@ type S3BucketClassList struct {
 //   4. NameFormat = "foo-%s", BucketName = "foo-test-uid"
 //   5. NameFormat = "foo-%s-bar-%s", BucketName = "foo-test-uid-bar-%!s(MISSING)"
 func (b *S3Bucket) GetBucketName() string {
-	return util.ConditionalStringFormat(b.Spec.NameFormat, string(b.GetUID()))
+	return externalname.Or(b, util.ConditionalStringFormat(b.Spec.NameFormat, string(b.GetUID())))
 }
 
 // SetUserPolicyVersion specifies this bucket's policy version.
@@ -19,6 +19,8 @@ package v1alpha1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
 )
 
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
@@ -30,21 +32,111 @@ type ProviderSpec struct {
 	// AWS Region
 	Region string `json:"region"`
 
-	// AWS Credentials file
-	Secret corev1.SecretKeySelector `json:"credentialsSecretRef"`
+	// CredentialsSource from which this provider obtains its base AWS
+	// credentials. Defaults to Secret.
+	// +kubebuilder:validation:Enum=Secret;File;Environment
+	// +optional
+	CredentialsSource CredentialsSource `json:"credentialsSource,omitempty"`
+
+	// AWS Credentials file. Required when the credentials source is Secret.
+	// +optional
+	Secret corev1.SecretKeySelector `json:"credentialsSecretRef,omitempty"`
+
+	// CredentialsFile is the path to an AWS credentials file mounted into the
+	// Crossplane pod. Required when the credentials source is File.
+	// +optional
+	CredentialsFile string `json:"credentialsFile,omitempty"`
+
+	// AssumeRole specifies an IAM role to assume using the base credentials.
+	// Crossplane uses the temporary credentials of the assumed role to manage
+	// AWS resources.
+	// +optional
+	AssumeRole *AssumeRoleSpec `json:"assumeRole,omitempty"`
+}
+
+// A CredentialsSource is a source from which a provider obtains credentials.
+type CredentialsSource string
+
+// Credentials sources.
+const (
+	// CredentialsSourceSecret reads an AWS credentials file from the secret
+	// referenced by the provider.
+	CredentialsSourceSecret CredentialsSource = "Secret"
+
+	// CredentialsSourceFile reads an AWS credentials file from a path in the
+	// Crossplane pod, for example a volume mounted by a secret store.
+	CredentialsSourceFile CredentialsSource = "File"
+
+	// CredentialsSourceEnvironment uses the ambient credentials of the
+	// Crossplane pod, i.e. environment variables, a shared credentials file,
+	// or the EC2 instance or ECS task role.
+	CredentialsSourceEnvironment CredentialsSource = "Environment"
+)
+
+// AssumeRoleSpec specifies an IAM role to assume via AWS STS.
+type AssumeRoleSpec struct {
+	// RoleARN is the ARN of the IAM role to assume.
+	RoleARN string `json:"roleARN"`
+
+	// ExternalID to pass when assuming the role, if the role's trust policy
+	// requires one.
+	// +optional
+	ExternalID string `json:"externalID,omitempty"`
+
+	// SessionName identifies the assumed role session in AWS CloudTrail.
+	// +optional
+	SessionName string `json:"sessionName,omitempty"`
+
+	// SessionDuration of the temporary credentials. Must be between 15 minutes
+	// and the role's maximum session duration. Defaults to 15 minutes.
+	// +optional
+	SessionDuration *metav1.Duration `json:"sessionDuration,omitempty"`
+}
+
+// ProviderStatus represents the observed state of a Provider.
+type ProviderStatus struct {
+	runtimev1alpha1.ConditionedStatus `json:",inline"`
+
+	// Identity as which the Provider's credentials authenticate, as of when
+	// they were last validated.
+	Identity string `json:"identity,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 
 // Provider is the Schema for the instances API
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="REGION",type="string",JSONPath=".spec.region"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="SECRET-NAME",type="string",JSONPath=".spec.credentialsSecretRef.name",priority=1
+// +kubebuilder:printcolumn:name="IDENTITY",type="string",JSONPath=".status.identity",priority=1
 type Provider struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec ProviderSpec `json:"spec,omitempty"`
+	Spec   ProviderSpec   `json:"spec,omitempty"`
+	Status ProviderStatus `json:"status,omitempty"`
+}
+
+// SetConditions of this Provider.
+func (p *Provider) SetConditions(c ...runtimev1alpha1.Condition) {
+	p.Status.SetConditions(c...)
+}
+
+// GetCondition of this Provider.
+func (p *Provider) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return p.Status.GetCondition(ct)
+}
+
+// SetIdentity of this Provider.
+func (p *Provider) SetIdentity(identity string) {
+	p.Status.Identity = identity
+}
+
+// GetIdentity of this Provider.
+func (p *Provider) GetIdentity() string {
+	return p.Status.Identity
 }
 
 // +kubebuilder:object:root=true
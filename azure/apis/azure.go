@@ -26,6 +26,7 @@ import (
 	cachev1alpha1 "github.com/crossplaneio/crossplane/azure/apis/cache/v1alpha1"
 	computev1alpha1 "github.com/crossplaneio/crossplane/azure/apis/compute/v1alpha1"
 	databasev1alpha1 "github.com/crossplaneio/crossplane/azure/apis/database/v1alpha1"
+	networkv1alpha1 "github.com/crossplaneio/crossplane/azure/apis/network/v1alpha1"
 	storagev1alpha1 "github.com/crossplaneio/crossplane/azure/apis/storage/v1alpha1"
 	azurev1alpha1 "github.com/crossplaneio/crossplane/azure/apis/v1alpha1"
 )
@@ -37,6 +38,7 @@ func init() {
 		cachev1alpha1.SchemeBuilder.AddToScheme,
 		computev1alpha1.SchemeBuilder.AddToScheme,
 		databasev1alpha1.SchemeBuilder.AddToScheme,
+		networkv1alpha1.SchemeBuilder.AddToScheme,
 		storagev1alpha1.SchemeBuilder.AddToScheme,
 	)
 }
@@ -51,6 +51,14 @@ const (
 	ProvisioningStateUpdating               = string(redis.Updating)
 )
 
+// Resource operations. An operation is reported in the status of a Redis
+// resource while Azure applies the change it describes.
+const (
+	OperationModify      = "Modify"
+	OperationScaleSKU    = "ScaleSKU"
+	OperationScaleShards = "ScaleShards"
+)
+
 const (
 	// SupportedRedisVersion is the only minor version of Redis currently
 	// supported by Azure Cache for Redis. The version cannot be specified at
@@ -147,6 +155,10 @@ type RedisStatus struct {
 
 	// ResourceName of the Redis cache resource.
 	ResourceName string `json:"resourceName,omitempty"`
+
+	// Operation is the update most recently requested of this Redis resource,
+	// for example ScaleSKU. It is cleared once provisioning has succeeded.
+	Operation string `json:"operation,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -155,6 +167,7 @@ type RedisStatus struct {
 // +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.state"
 // +kubebuilder:printcolumn:name="CLASS",type="string",JSONPath=".spec.classRef.name"
 // +kubebuilder:printcolumn:name="VERSION",type="string",JSONPath=".status.redisVersion"
+// +kubebuilder:printcolumn:name="OPERATION",type="string",JSONPath=".status.operation"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 type Redis struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -179,6 +192,11 @@ func (rd *Redis) SetConditions(c ...runtimev1alpha1.Condition) {
 	rd.Status.SetConditions(c...)
 }
 
+// GetCondition of this Redis.
+func (rd *Redis) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return rd.Status.GetCondition(ct)
+}
+
 // SetClaimReference of this Redis.
 func (rd *Redis) SetClaimReference(r *corev1.ObjectReference) {
 	rd.Spec.ClaimReference = r
@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	corev1alpha1 "github.com/crossplaneio/crossplane/apis/core/v1alpha1"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -131,6 +132,11 @@ func (c *AKSCluster) SetConditions(cd ...runtimev1alpha1.Condition) {
 	c.Status.SetConditions(cd...)
 }
 
+// GetCondition of this AKSCluster.
+func (c *AKSCluster) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return c.Status.GetCondition(ct)
+}
+
 // SetClaimReference of this AKSCluster.
 func (c *AKSCluster) SetClaimReference(r *corev1.ObjectReference) {
 	c.Spec.ClaimReference = r
@@ -184,6 +190,11 @@ type AKSClusterList struct {
 type AKSClusterClassSpecTemplate struct {
 	runtimev1alpha1.ResourceClassSpecTemplate `json:",inline"`
 	AKSClusterParameters                      `json:",inline"`
+
+	// ClaimParameters specifies the portable parameters that resource claims
+	// may request of this class, and the values they may request.
+	// +optional
+	ClaimParameters *corev1alpha1.ClaimParameterPolicy `json:"claimParameters,omitempty"`
 }
 
 var _ resource.Class = &AKSClusterClass{}
@@ -22,6 +22,8 @@ package v1alpha1
 
 import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
+
+	corev1alpha1 "github.com/crossplaneio/crossplane/apis/core/v1alpha1"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -114,6 +116,11 @@ func (in *AKSClusterClassSpecTemplate) DeepCopyInto(out *AKSClusterClassSpecTemp
 	*out = *in
 	in.ResourceClassSpecTemplate.DeepCopyInto(&out.ResourceClassSpecTemplate)
 	in.AKSClusterParameters.DeepCopyInto(&out.AKSClusterParameters)
+	if in.ClaimParameters != nil {
+		in, out := &in.ClaimParameters, &out.ClaimParameters
+		*out = new(corev1alpha1.ClaimParameterPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AKSClusterClassSpecTemplate.
@@ -61,8 +61,32 @@ var (
 	SQLServerClassGroupVersionKind = SchemeGroupVersion.WithKind(SQLServerClassKind)
 )
 
+// SQLServerUser type metadata.
+var (
+	SQLServerUserKind             = reflect.TypeOf(SQLServerUser{}).Name()
+	SQLServerUserKindAPIVersion   = SQLServerUserKind + "." + SchemeGroupVersion.String()
+	SQLServerUserGroupVersionKind = SchemeGroupVersion.WithKind(SQLServerUserKind)
+)
+
+// SQLServerDatabase type metadata.
+var (
+	SQLServerDatabaseKind             = reflect.TypeOf(SQLServerDatabase{}).Name()
+	SQLServerDatabaseKindAPIVersion   = SQLServerDatabaseKind + "." + SchemeGroupVersion.String()
+	SQLServerDatabaseGroupVersionKind = SchemeGroupVersion.WithKind(SQLServerDatabaseKind)
+)
+
+// SQLServerDatabaseClass type metadata.
+var (
+	SQLServerDatabaseClassKind             = reflect.TypeOf(SQLServerDatabaseClass{}).Name()
+	SQLServerDatabaseClassKindAPIVersion   = SQLServerDatabaseClassKind + "." + SchemeGroupVersion.String()
+	SQLServerDatabaseClassGroupVersionKind = SchemeGroupVersion.WithKind(SQLServerDatabaseClassKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&MysqlServer{}, &MysqlServerList{})
 	SchemeBuilder.Register(&PostgresqlServer{}, &PostgresqlServerList{})
 	SchemeBuilder.Register(&SQLServerClass{}, &SQLServerClassList{})
+	SchemeBuilder.Register(&SQLServerUser{}, &SQLServerUserList{})
+	SchemeBuilder.Register(&SQLServerDatabase{}, &SQLServerDatabaseList{})
+	SchemeBuilder.Register(&SQLServerDatabaseClass{}, &SQLServerDatabaseClassList{})
 }
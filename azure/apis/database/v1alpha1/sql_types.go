@@ -23,6 +23,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	corev1alpha1 "github.com/crossplaneio/crossplane/apis/core/v1alpha1"
 )
 
 const (
@@ -91,6 +92,11 @@ func (s *MysqlServer) SetConditions(c ...runtimev1alpha1.Condition) {
 	s.Status.SetConditions(c...)
 }
 
+// GetCondition of this MysqlServer.
+func (s *MysqlServer) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return s.Status.GetCondition(ct)
+}
+
 // SetClaimReference of this MysqlServer.
 func (s *MysqlServer) SetClaimReference(r *corev1.ObjectReference) {
 	s.Spec.ClaimReference = r
@@ -186,6 +192,11 @@ func (s *PostgresqlServer) SetConditions(c ...runtimev1alpha1.Condition) {
 	s.Status.SetConditions(c...)
 }
 
+// GetCondition of this PostgresqlServer.
+func (s *PostgresqlServer) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return s.Status.GetCondition(ct)
+}
+
 // SetClaimReference of this PostgresqlServer.
 func (s *PostgresqlServer) SetClaimReference(r *corev1.ObjectReference) {
 	s.Spec.ClaimReference = r
@@ -239,6 +250,11 @@ type PostgresqlServerList struct {
 type SQLServerClassSpecTemplate struct {
 	runtimev1alpha1.ResourceClassSpecTemplate `json:",inline"`
 	SQLServerParameters                       `json:",inline"`
+
+	// ClaimParameters specifies the portable parameters that resource claims
+	// may request of this class, and the values they may request.
+	// +optional
+	ClaimParameters *corev1alpha1.ClaimParameterPolicy `json:"claimParameters,omitempty"`
 }
 
 var _ resource.Class = &SQLServerClass{}
@@ -284,6 +300,11 @@ type SQLServerParameters struct {
 	AdminLoginName    string             `json:"adminLoginName"`
 	Version           string             `json:"version"`
 	SSLEnforced       bool               `json:"sslEnforced,omitempty"`
+
+	// RestoreFrom creates the server as a restore of another server's
+	// automated backups. Azure Database does not support on-demand snapshots
+	// of a server, so only point in time restores are possible.
+	RestoreFrom *SQLServerRestoreSource `json:"restoreFrom,omitempty"`
 }
 
 // SQLServerSpec defines the desired state of SQLServer
@@ -329,6 +350,25 @@ type StorageProfileSpec struct {
 	GeoRedundantBackup  bool `json:"geoRedundantBackup,omitempty"`
 }
 
+// SQLServerRestoreSource specifies the data a new server is restored from.
+type SQLServerRestoreSource struct {
+	// PointInTime restores the state of another server at a particular time.
+	PointInTime SQLServerPointInTime `json:"pointInTime"`
+}
+
+// SQLServerPointInTime specifies a server and a time within its backup
+// retention period.
+type SQLServerPointInTime struct {
+	// ServerRef references the server of the same kind to restore from. It
+	// must be in the same namespace as the restored server. The restored
+	// server inherits the source server's admin credentials, so its
+	// adminLoginName must match that of the source server.
+	ServerRef corev1.LocalObjectReference `json:"serverRef"`
+
+	// RestoreTime is the time to restore the source server's state as of.
+	RestoreTime metav1.Time `json:"restoreTime"`
+}
+
 // ValidMySQLVersionValues returns the valid set of engine version values.
 func ValidMySQLVersionValues() []string {
 	return []string{"5.6", "5.7"}
@@ -0,0 +1,191 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+)
+
+// SQLServerDatabaseParameters define the desired state of a SQLServerDatabase.
+type SQLServerDatabaseParameters struct {
+	// ServerRef references the SQL server in which this database will be
+	// created. The database is created using the administrator credentials
+	// from the server's connection secret.
+	ServerRef SQLServerReference `json:"serverRef"`
+
+	// DatabaseName is the name of the database within the server. Defaults to
+	// the name of the SQLServerDatabase.
+	DatabaseName string `json:"databaseName,omitempty"`
+}
+
+// SQLServerDatabaseSpec defines the desired state of a SQLServerDatabase.
+type SQLServerDatabaseSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	SQLServerDatabaseParameters  `json:",inline"`
+}
+
+// SQLServerDatabaseStatus defines the observed state of a SQLServerDatabase.
+type SQLServerDatabaseStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+
+// A SQLServerDatabase is a logical database within an existing MysqlServer or
+// PostgresqlServer. Many SQLServerDatabases may share one server. Its
+// connection secret contains the credentials of a generated user that is
+// granted privileges only on this database, the server's endpoint, and the
+// name of the database.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.bindingPhase"
+// +kubebuilder:printcolumn:name="SERVER-KIND",type="string",JSONPath=".spec.serverRef.kind"
+// +kubebuilder:printcolumn:name="SERVER",type="string",JSONPath=".spec.serverRef.name"
+// +kubebuilder:printcolumn:name="CLASS",type="string",JSONPath=".spec.classRef.name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+type SQLServerDatabase struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SQLServerDatabaseSpec   `json:"spec,omitempty"`
+	Status SQLServerDatabaseStatus `json:"status,omitempty"`
+}
+
+// SetBindingPhase of this SQLServerDatabase.
+func (d *SQLServerDatabase) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	d.Status.SetBindingPhase(p)
+}
+
+// GetBindingPhase of this SQLServerDatabase.
+func (d *SQLServerDatabase) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return d.Status.GetBindingPhase()
+}
+
+// SetConditions of this SQLServerDatabase.
+func (d *SQLServerDatabase) SetConditions(c ...runtimev1alpha1.Condition) {
+	d.Status.SetConditions(c...)
+}
+
+// GetCondition of this SQLServerDatabase.
+func (d *SQLServerDatabase) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return d.Status.GetCondition(ct)
+}
+
+// SetClaimReference of this SQLServerDatabase.
+func (d *SQLServerDatabase) SetClaimReference(r *corev1.ObjectReference) {
+	d.Spec.ClaimReference = r
+}
+
+// GetClaimReference of this SQLServerDatabase.
+func (d *SQLServerDatabase) GetClaimReference() *corev1.ObjectReference {
+	return d.Spec.ClaimReference
+}
+
+// SetClassReference of this SQLServerDatabase.
+func (d *SQLServerDatabase) SetClassReference(r *corev1.ObjectReference) {
+	d.Spec.ClassReference = r
+}
+
+// GetClassReference of this SQLServerDatabase.
+func (d *SQLServerDatabase) GetClassReference() *corev1.ObjectReference {
+	return d.Spec.ClassReference
+}
+
+// SetWriteConnectionSecretToReference of this SQLServerDatabase.
+func (d *SQLServerDatabase) SetWriteConnectionSecretToReference(r corev1.LocalObjectReference) {
+	d.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetWriteConnectionSecretToReference of this SQLServerDatabase.
+func (d *SQLServerDatabase) GetWriteConnectionSecretToReference() corev1.LocalObjectReference {
+	return d.Spec.WriteConnectionSecretToReference
+}
+
+// GetReclaimPolicy of this SQLServerDatabase.
+func (d *SQLServerDatabase) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return d.Spec.ReclaimPolicy
+}
+
+// SetReclaimPolicy of this SQLServerDatabase.
+func (d *SQLServerDatabase) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
+	d.Spec.ReclaimPolicy = p
+}
+
+// GetDatabaseName returns the name of this SQLServerDatabase within its
+// server, which defaults to its name.
+func (d *SQLServerDatabase) GetDatabaseName() string {
+	if d.Spec.DatabaseName != "" {
+		return d.Spec.DatabaseName
+	}
+	return d.GetName()
+}
+
+// +kubebuilder:object:root=true
+
+// SQLServerDatabaseList contains a list of SQLServerDatabase.
+type SQLServerDatabaseList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SQLServerDatabase `json:"items"`
+}
+
+// SQLServerDatabaseClassSpecTemplate is the Schema for the resource class
+type SQLServerDatabaseClassSpecTemplate struct {
+	runtimev1alpha1.ResourceClassSpecTemplate `json:",inline"`
+	SQLServerDatabaseParameters               `json:",inline"`
+}
+
+var _ resource.Class = &SQLServerDatabaseClass{}
+
+// +kubebuilder:object:root=true
+
+// A SQLServerDatabaseClass is a resource class for SQLServerDatabases. Each
+// resource claim that uses it is bound to a new database within the SQL server
+// it references, rather than to a new server.
+// +kubebuilder:printcolumn:name="SERVER-KIND",type="string",JSONPath=".specTemplate.serverRef.kind"
+// +kubebuilder:printcolumn:name="SERVER",type="string",JSONPath=".specTemplate.serverRef.name"
+// +kubebuilder:printcolumn:name="PROVIDER-REF",type="string",JSONPath=".specTemplate.providerRef.name"
+// +kubebuilder:printcolumn:name="RECLAIM-POLICY",type="string",JSONPath=".specTemplate.reclaimPolicy"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+type SQLServerDatabaseClass struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	SpecTemplate SQLServerDatabaseClassSpecTemplate `json:"specTemplate,omitempty"`
+}
+
+// GetReclaimPolicy of this SQLServerDatabaseClass.
+func (c *SQLServerDatabaseClass) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return c.SpecTemplate.ReclaimPolicy
+}
+
+// SetReclaimPolicy of this SQLServerDatabaseClass.
+func (c *SQLServerDatabaseClass) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
+	c.SpecTemplate.ReclaimPolicy = p
+}
+
+// +kubebuilder:object:root=true
+
+// SQLServerDatabaseClassList contains a list of SQLServerDatabaseClass.
+type SQLServerDatabaseClassList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SQLServerDatabaseClass `json:"items"`
+}
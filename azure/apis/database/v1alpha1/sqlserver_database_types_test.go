@@ -0,0 +1,68 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+)
+
+var _ resource.Managed = &SQLServerDatabase{}
+
+func TestStorageSQLServerDatabase(t *testing.T) {
+	key := types.NamespacedName{Name: name, Namespace: namespace}
+	created := &SQLServerDatabase{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: SQLServerDatabaseSpec{
+			ResourceSpec: runtimev1alpha1.ResourceSpec{
+				ProviderReference: &core.ObjectReference{},
+			},
+			SQLServerDatabaseParameters: SQLServerDatabaseParameters{
+				ServerRef: SQLServerReference{Kind: MysqlServerKind, Name: name},
+			},
+		},
+	}
+	g := gomega.NewGomegaWithT(t)
+
+	// Test Create
+	fetched := &SQLServerDatabase{}
+	g.Expect(c.Create(ctx, created)).NotTo(gomega.HaveOccurred())
+
+	g.Expect(c.Get(ctx, key, fetched)).NotTo(gomega.HaveOccurred())
+	g.Expect(fetched).To(gomega.Equal(created))
+
+	// Test Delete
+	g.Expect(c.Delete(ctx, fetched)).NotTo(gomega.HaveOccurred())
+	g.Expect(c.Get(ctx, key, fetched)).To(gomega.HaveOccurred())
+}
+
+func TestSQLServerDatabase_GetDatabaseName(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	d := &SQLServerDatabase{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	g.Expect(d.GetDatabaseName()).To(gomega.Equal(name))
+
+	d.Spec.DatabaseName = "app"
+	g.Expect(d.GetDatabaseName()).To(gomega.Equal("app"))
+}
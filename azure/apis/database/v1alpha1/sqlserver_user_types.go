@@ -0,0 +1,168 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+)
+
+// A SQLServerReference references a MysqlServer or PostgresqlServer in the
+// same namespace as the referencing resource.
+type SQLServerReference struct {
+	// Kind of the referenced SQL server.
+	// +kubebuilder:validation:Enum=MysqlServer;PostgresqlServer
+	Kind string `json:"kind"`
+
+	// Name of the referenced SQL server.
+	Name string `json:"name"`
+}
+
+// A DatabaseGrant grants a user all privileges on a database.
+type DatabaseGrant struct {
+	// Database on which the user is granted all privileges. The database
+	// must exist in the same SQL server as the user, for example as a
+	// SQLServerDatabase.
+	Database string `json:"database"`
+}
+
+// SQLServerUserParameters define the desired state of a SQLServerUser.
+type SQLServerUserParameters struct {
+	// ServerRef references the SQL server in which this user will be created.
+	// The user is created using the administrator credentials from the
+	// server's connection secret.
+	ServerRef SQLServerReference `json:"serverRef"`
+
+	// Username of this user. Defaults to the name of the SQLServerUser.
+	// Clients must connect as username@server, which is the username written
+	// to the connection secret.
+	Username string `json:"username,omitempty"`
+
+	// Grants of privileges to this user. Privileges on databases that are
+	// not granted here are revoked.
+	// +optional
+	Grants []DatabaseGrant `json:"grants,omitempty"`
+}
+
+// SQLServerUserSpec defines the desired state of a SQLServerUser.
+type SQLServerUserSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	SQLServerUserParameters      `json:",inline"`
+}
+
+// SQLServerUserStatus defines the observed state of a SQLServerUser.
+type SQLServerUserStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+
+// A SQLServerUser is a login with a generated password on an existing
+// MysqlServer or PostgresqlServer. Users are granted privileges only on the
+// databases named by their grants.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.bindingPhase"
+// +kubebuilder:printcolumn:name="SERVER-KIND",type="string",JSONPath=".spec.serverRef.kind"
+// +kubebuilder:printcolumn:name="SERVER",type="string",JSONPath=".spec.serverRef.name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+type SQLServerUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SQLServerUserSpec   `json:"spec,omitempty"`
+	Status SQLServerUserStatus `json:"status,omitempty"`
+}
+
+// SetBindingPhase of this SQLServerUser.
+func (u *SQLServerUser) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	u.Status.SetBindingPhase(p)
+}
+
+// GetBindingPhase of this SQLServerUser.
+func (u *SQLServerUser) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return u.Status.GetBindingPhase()
+}
+
+// SetConditions of this SQLServerUser.
+func (u *SQLServerUser) SetConditions(c ...runtimev1alpha1.Condition) {
+	u.Status.SetConditions(c...)
+}
+
+// GetCondition of this SQLServerUser.
+func (u *SQLServerUser) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return u.Status.GetCondition(ct)
+}
+
+// SetClaimReference of this SQLServerUser.
+func (u *SQLServerUser) SetClaimReference(r *corev1.ObjectReference) {
+	u.Spec.ClaimReference = r
+}
+
+// GetClaimReference of this SQLServerUser.
+func (u *SQLServerUser) GetClaimReference() *corev1.ObjectReference {
+	return u.Spec.ClaimReference
+}
+
+// SetClassReference of this SQLServerUser.
+func (u *SQLServerUser) SetClassReference(r *corev1.ObjectReference) {
+	u.Spec.ClassReference = r
+}
+
+// GetClassReference of this SQLServerUser.
+func (u *SQLServerUser) GetClassReference() *corev1.ObjectReference {
+	return u.Spec.ClassReference
+}
+
+// SetWriteConnectionSecretToReference of this SQLServerUser.
+func (u *SQLServerUser) SetWriteConnectionSecretToReference(r corev1.LocalObjectReference) {
+	u.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetWriteConnectionSecretToReference of this SQLServerUser.
+func (u *SQLServerUser) GetWriteConnectionSecretToReference() corev1.LocalObjectReference {
+	return u.Spec.WriteConnectionSecretToReference
+}
+
+// GetReclaimPolicy of this SQLServerUser.
+func (u *SQLServerUser) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return u.Spec.ReclaimPolicy
+}
+
+// SetReclaimPolicy of this SQLServerUser.
+func (u *SQLServerUser) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
+	u.Spec.ReclaimPolicy = p
+}
+
+// GetUsername returns the username of this SQLServerUser, which defaults to
+// its name.
+func (u *SQLServerUser) GetUsername() string {
+	if u.Spec.Username != "" {
+		return u.Spec.Username
+	}
+	return u.GetName()
+}
+
+// +kubebuilder:object:root=true
+
+// SQLServerUserList contains a list of SQLServerUser.
+type SQLServerUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SQLServerUser `json:"items"`
+}
@@ -22,8 +22,25 @@ package v1alpha1
 
 import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
+
+	corev1alpha1 "github.com/crossplaneio/crossplane/apis/core/v1alpha1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseGrant) DeepCopyInto(out *DatabaseGrant) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseGrant.
+func (in *DatabaseGrant) DeepCopy() *DatabaseGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MysqlServer) DeepCopyInto(out *MysqlServer) {
 	*out = *in
@@ -219,7 +236,12 @@ func (in *SQLServerClassList) DeepCopyObject() runtime.Object {
 func (in *SQLServerClassSpecTemplate) DeepCopyInto(out *SQLServerClassSpecTemplate) {
 	*out = *in
 	in.ResourceClassSpecTemplate.DeepCopyInto(&out.ResourceClassSpecTemplate)
-	out.SQLServerParameters = in.SQLServerParameters
+	in.SQLServerParameters.DeepCopyInto(&out.SQLServerParameters)
+	if in.ClaimParameters != nil {
+		in, out := &in.ClaimParameters, &out.ClaimParameters
+		*out = new(corev1alpha1.ClaimParameterPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLServerClassSpecTemplate.
@@ -232,11 +254,199 @@ func (in *SQLServerClassSpecTemplate) DeepCopy() *SQLServerClassSpecTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SQLServerDatabase) DeepCopyInto(out *SQLServerDatabase) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLServerDatabase.
+func (in *SQLServerDatabase) DeepCopy() *SQLServerDatabase {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLServerDatabase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SQLServerDatabase) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SQLServerDatabaseClass) DeepCopyInto(out *SQLServerDatabaseClass) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.SpecTemplate.DeepCopyInto(&out.SpecTemplate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLServerDatabaseClass.
+func (in *SQLServerDatabaseClass) DeepCopy() *SQLServerDatabaseClass {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLServerDatabaseClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SQLServerDatabaseClass) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SQLServerDatabaseClassList) DeepCopyInto(out *SQLServerDatabaseClassList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SQLServerDatabaseClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLServerDatabaseClassList.
+func (in *SQLServerDatabaseClassList) DeepCopy() *SQLServerDatabaseClassList {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLServerDatabaseClassList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SQLServerDatabaseClassList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SQLServerDatabaseClassSpecTemplate) DeepCopyInto(out *SQLServerDatabaseClassSpecTemplate) {
+	*out = *in
+	in.ResourceClassSpecTemplate.DeepCopyInto(&out.ResourceClassSpecTemplate)
+	out.SQLServerDatabaseParameters = in.SQLServerDatabaseParameters
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLServerDatabaseClassSpecTemplate.
+func (in *SQLServerDatabaseClassSpecTemplate) DeepCopy() *SQLServerDatabaseClassSpecTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLServerDatabaseClassSpecTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SQLServerDatabaseList) DeepCopyInto(out *SQLServerDatabaseList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SQLServerDatabase, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLServerDatabaseList.
+func (in *SQLServerDatabaseList) DeepCopy() *SQLServerDatabaseList {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLServerDatabaseList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SQLServerDatabaseList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SQLServerDatabaseParameters) DeepCopyInto(out *SQLServerDatabaseParameters) {
+	*out = *in
+	out.ServerRef = in.ServerRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLServerDatabaseParameters.
+func (in *SQLServerDatabaseParameters) DeepCopy() *SQLServerDatabaseParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLServerDatabaseParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SQLServerDatabaseSpec) DeepCopyInto(out *SQLServerDatabaseSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	out.SQLServerDatabaseParameters = in.SQLServerDatabaseParameters
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLServerDatabaseSpec.
+func (in *SQLServerDatabaseSpec) DeepCopy() *SQLServerDatabaseSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLServerDatabaseSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SQLServerDatabaseStatus) DeepCopyInto(out *SQLServerDatabaseStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLServerDatabaseStatus.
+func (in *SQLServerDatabaseStatus) DeepCopy() *SQLServerDatabaseStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLServerDatabaseStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SQLServerParameters) DeepCopyInto(out *SQLServerParameters) {
 	*out = *in
 	out.PricingTier = in.PricingTier
 	out.StorageProfile = in.StorageProfile
+	if in.RestoreFrom != nil {
+		in, out := &in.RestoreFrom, &out.RestoreFrom
+		*out = new(SQLServerRestoreSource)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLServerParameters.
@@ -249,11 +459,59 @@ func (in *SQLServerParameters) DeepCopy() *SQLServerParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SQLServerPointInTime) DeepCopyInto(out *SQLServerPointInTime) {
+	*out = *in
+	out.ServerRef = in.ServerRef
+	in.RestoreTime.DeepCopyInto(&out.RestoreTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLServerPointInTime.
+func (in *SQLServerPointInTime) DeepCopy() *SQLServerPointInTime {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLServerPointInTime)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SQLServerReference) DeepCopyInto(out *SQLServerReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLServerReference.
+func (in *SQLServerReference) DeepCopy() *SQLServerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLServerReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SQLServerRestoreSource) DeepCopyInto(out *SQLServerRestoreSource) {
+	*out = *in
+	in.PointInTime.DeepCopyInto(&out.PointInTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLServerRestoreSource.
+func (in *SQLServerRestoreSource) DeepCopy() *SQLServerRestoreSource {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLServerRestoreSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SQLServerSpec) DeepCopyInto(out *SQLServerSpec) {
 	*out = *in
 	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
-	out.SQLServerParameters = in.SQLServerParameters
+	in.SQLServerParameters.DeepCopyInto(&out.SQLServerParameters)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLServerSpec.
@@ -282,6 +540,119 @@ func (in *SQLServerStatus) DeepCopy() *SQLServerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SQLServerUser) DeepCopyInto(out *SQLServerUser) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLServerUser.
+func (in *SQLServerUser) DeepCopy() *SQLServerUser {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLServerUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SQLServerUser) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SQLServerUserList) DeepCopyInto(out *SQLServerUserList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SQLServerUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLServerUserList.
+func (in *SQLServerUserList) DeepCopy() *SQLServerUserList {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLServerUserList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SQLServerUserList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SQLServerUserParameters) DeepCopyInto(out *SQLServerUserParameters) {
+	*out = *in
+	out.ServerRef = in.ServerRef
+	if in.Grants != nil {
+		in, out := &in.Grants, &out.Grants
+		*out = make([]DatabaseGrant, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLServerUserParameters.
+func (in *SQLServerUserParameters) DeepCopy() *SQLServerUserParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLServerUserParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SQLServerUserSpec) DeepCopyInto(out *SQLServerUserSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.SQLServerUserParameters.DeepCopyInto(&out.SQLServerUserParameters)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLServerUserSpec.
+func (in *SQLServerUserSpec) DeepCopy() *SQLServerUserSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLServerUserSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SQLServerUserStatus) DeepCopyInto(out *SQLServerUserStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLServerUserStatus.
+func (in *SQLServerUserStatus) DeepCopy() *SQLServerUserStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLServerUserStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageProfileSpec) DeepCopyInto(out *StorageProfileSpec) {
 	*out = *in
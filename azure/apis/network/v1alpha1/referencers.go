@@ -0,0 +1,64 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplaneio/crossplane/pkg/reference"
+)
+
+const errNotSubnet = "managed resource is not a Subnet"
+
+// A VirtualNetworkNameReferencer resolves the Azure name of a VirtualNetwork
+// in the same namespace as the referencing resource.
+type VirtualNetworkNameReferencer struct {
+	corev1.LocalObjectReference `json:",inline"`
+}
+
+// Build returns the Azure name of the referenced VirtualNetwork once it is
+// available.
+func (v *VirtualNetworkNameReferencer) Build(ctx context.Context, res reference.CanReference, r client.Reader) (string, error) {
+	vn := &VirtualNetwork{}
+	if err := reference.Get(ctx, r, res, v.Name, vn); err != nil {
+		return "", err
+	}
+	if !reference.Ready(vn.Status.ConditionedStatus) || vn.Status.ResourceName == "" {
+		return "", reference.NewNotReadyError("referenced VirtualNetwork %s is not available", v.Name)
+	}
+	return vn.Status.ResourceName, nil
+}
+
+// A VirtualNetworkNameReferencerForSubnet assigns the Azure name of a
+// referenced VirtualNetwork to a Subnet.
+type VirtualNetworkNameReferencerForSubnet struct {
+	VirtualNetworkNameReferencer `json:",inline"`
+}
+
+// Assign the supplied VirtualNetwork name to the supplied Subnet.
+func (v *VirtualNetworkNameReferencerForSubnet) Assign(res reference.CanReference, value string) error {
+	s, ok := res.(*Subnet)
+	if !ok {
+		return errors.New(errNotSubnet)
+	}
+	s.Spec.VirtualNetworkName = value
+	return nil
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/util"
+	"github.com/crossplaneio/crossplane/pkg/externalname"
+	"github.com/crossplaneio/crossplane/pkg/reference"
+)
+
+// SubnetParameters define the desired state of a subnet of an Azure virtual
+// network.
+type SubnetParameters struct {
+	// NameFormat to format the subnet name passing it the object UID. If not
+	// provided, defaults to "subnet-%s", i.e. the UID prefixed with subnet-.
+	// +optional
+	NameFormat string `json:"nameFormat,omitempty"`
+
+	// ResourceGroupName of the virtual network in which to create this
+	// subnet.
+	ResourceGroupName string `json:"resourceGroupName"`
+
+	// VirtualNetworkName is the Azure name of the virtual network in which to
+	// create this subnet. It is resolved from VirtualNetworkNameRef when that
+	// is set.
+	// +optional
+	VirtualNetworkName string `json:"virtualNetworkName,omitempty"`
+
+	// VirtualNetworkNameRef references a VirtualNetwork in the same
+	// namespace, from which VirtualNetworkName is resolved once the
+	// VirtualNetwork is available.
+	// +optional
+	VirtualNetworkNameRef *VirtualNetworkNameReferencerForSubnet `json:"virtualNetworkNameRef,omitempty"`
+
+	// AddressPrefix of the subnet, in CIDR notation. It must fall within the
+	// address space of the virtual network.
+	AddressPrefix string `json:"addressPrefix"`
+
+	// ServiceEndpoints to enable on the subnet, for example Microsoft.Sql.
+	// +optional
+	ServiceEndpoints []string `json:"serviceEndpoints,omitempty"`
+}
+
+// SubnetSpec defines the desired state of a Subnet.
+type SubnetSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	SubnetParameters             `json:",inline"`
+}
+
+// SubnetStatus defines the observed state of a Subnet.
+type SubnetStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	// State is the provisioning state of the subnet.
+	State string `json:"state,omitempty"`
+
+	// ID is the fully qualified Azure resource ID of the subnet.
+	ID string `json:"id,omitempty"`
+
+	// ResourceName of the subnet in Azure.
+	ResourceName string `json:"resourceName,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Subnet is a managed resource that represents a subnet of an Azure virtual
+// network.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="VIRTUAL-NETWORK",type="string",JSONPath=".spec.virtualNetworkName"
+// +kubebuilder:printcolumn:name="PREFIX",type="string",JSONPath=".spec.addressPrefix"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+type Subnet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SubnetSpec   `json:"spec,omitempty"`
+	Status SubnetStatus `json:"status,omitempty"`
+}
+
+// SetBindingPhase of this Subnet.
+func (s *Subnet) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	s.Status.SetBindingPhase(p)
+}
+
+// GetBindingPhase of this Subnet.
+func (s *Subnet) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return s.Status.GetBindingPhase()
+}
+
+// SetConditions of this Subnet.
+func (s *Subnet) SetConditions(c ...runtimev1alpha1.Condition) {
+	s.Status.SetConditions(c...)
+}
+
+// GetCondition of this Subnet.
+func (s *Subnet) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return s.Status.GetCondition(ct)
+}
+
+// SetClaimReference of this Subnet.
+func (s *Subnet) SetClaimReference(r *corev1.ObjectReference) {
+	s.Spec.ClaimReference = r
+}
+
+// GetClaimReference of this Subnet.
+func (s *Subnet) GetClaimReference() *corev1.ObjectReference {
+	return s.Spec.ClaimReference
+}
+
+// SetClassReference of this Subnet.
+func (s *Subnet) SetClassReference(r *corev1.ObjectReference) {
+	s.Spec.ClassReference = r
+}
+
+// GetClassReference of this Subnet.
+func (s *Subnet) GetClassReference() *corev1.ObjectReference {
+	return s.Spec.ClassReference
+}
+
+// SetWriteConnectionSecretToReference of this Subnet.
+func (s *Subnet) SetWriteConnectionSecretToReference(r corev1.LocalObjectReference) {
+	s.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetWriteConnectionSecretToReference of this Subnet.
+func (s *Subnet) GetWriteConnectionSecretToReference() corev1.LocalObjectReference {
+	return s.Spec.WriteConnectionSecretToReference
+}
+
+// GetReclaimPolicy of this Subnet.
+func (s *Subnet) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return s.Spec.ReclaimPolicy
+}
+
+// SetReclaimPolicy of this Subnet.
+func (s *Subnet) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
+	s.Spec.ReclaimPolicy = p
+}
+
+// GetProviderReference of this Subnet.
+func (s *Subnet) GetProviderReference() *corev1.ObjectReference {
+	return s.Spec.ProviderReference
+}
+
+// GetReferencers of this Subnet.
+func (s *Subnet) GetReferencers() []reference.AttributeReferencer {
+	if s.Spec.VirtualNetworkNameRef == nil {
+		return nil
+	}
+	return []reference.AttributeReferencer{s.Spec.VirtualNetworkNameRef}
+}
+
+// GetResourceName returns the name of this Subnet in Azure, which is its
+// external name if it has one, or else based on its NameFormat.
+func (s *Subnet) GetResourceName() string {
+	f := s.Spec.NameFormat
+	if f == "" {
+		f = strings.ToLower(SubnetKind) + "-%s"
+	}
+	return externalname.Or(s, util.ConditionalStringFormat(f, string(s.GetUID())))
+}
+
+// +kubebuilder:object:root=true
+
+// SubnetList contains a list of Subnet.
+type SubnetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Subnet `json:"items"`
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/util"
+	"github.com/crossplaneio/crossplane/pkg/externalname"
+)
+
+// Provisioning states of Azure network resources.
+const (
+	ProvisioningStateSucceeded = "Succeeded"
+	ProvisioningStateUpdating  = "Updating"
+	ProvisioningStateDeleting  = "Deleting"
+	ProvisioningStateFailed    = "Failed"
+)
+
+// VirtualNetworkParameters define the desired state of an Azure virtual
+// network.
+type VirtualNetworkParameters struct {
+	// NameFormat to format the virtual network name passing it the object
+	// UID. If not provided, defaults to "virtualnetwork-%s", i.e. the UID
+	// prefixed with virtualnetwork-.
+	// +optional
+	NameFormat string `json:"nameFormat,omitempty"`
+
+	// ResourceGroupName in which to create this virtual network.
+	ResourceGroupName string `json:"resourceGroupName"`
+
+	// Location in which to create this virtual network.
+	Location string `json:"location"`
+
+	// AddressPrefixes of the virtual network's address space, in CIDR
+	// notation.
+	// +kubebuilder:validation:MinItems=1
+	AddressPrefixes []string `json:"addressPrefixes"`
+
+	// DNSServers used by VMs deployed in the virtual network. Azure provided
+	// DNS is used if none are specified.
+	// +optional
+	DNSServers []string `json:"dnsServers,omitempty"`
+
+	// Tags to apply to the virtual network.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// VirtualNetworkSpec defines the desired state of a VirtualNetwork.
+type VirtualNetworkSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	VirtualNetworkParameters     `json:",inline"`
+}
+
+// VirtualNetworkStatus defines the observed state of a VirtualNetwork.
+type VirtualNetworkStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	// State is the provisioning state of the virtual network.
+	State string `json:"state,omitempty"`
+
+	// ID is the fully qualified Azure resource ID of the virtual network.
+	ID string `json:"id,omitempty"`
+
+	// ResourceName of the virtual network in Azure.
+	ResourceName string `json:"resourceName,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A VirtualNetwork is a managed resource that represents an Azure virtual
+// network.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="LOCATION",type="string",JSONPath=".spec.location"
+// +kubebuilder:printcolumn:name="RESOURCE-GROUP",type="string",JSONPath=".spec.resourceGroupName"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+type VirtualNetwork struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualNetworkSpec   `json:"spec,omitempty"`
+	Status VirtualNetworkStatus `json:"status,omitempty"`
+}
+
+// SetBindingPhase of this VirtualNetwork.
+func (v *VirtualNetwork) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	v.Status.SetBindingPhase(p)
+}
+
+// GetBindingPhase of this VirtualNetwork.
+func (v *VirtualNetwork) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return v.Status.GetBindingPhase()
+}
+
+// SetConditions of this VirtualNetwork.
+func (v *VirtualNetwork) SetConditions(c ...runtimev1alpha1.Condition) {
+	v.Status.SetConditions(c...)
+}
+
+// GetCondition of this VirtualNetwork.
+func (v *VirtualNetwork) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return v.Status.GetCondition(ct)
+}
+
+// SetClaimReference of this VirtualNetwork.
+func (v *VirtualNetwork) SetClaimReference(r *corev1.ObjectReference) {
+	v.Spec.ClaimReference = r
+}
+
+// GetClaimReference of this VirtualNetwork.
+func (v *VirtualNetwork) GetClaimReference() *corev1.ObjectReference {
+	return v.Spec.ClaimReference
+}
+
+// SetClassReference of this VirtualNetwork.
+func (v *VirtualNetwork) SetClassReference(r *corev1.ObjectReference) {
+	v.Spec.ClassReference = r
+}
+
+// GetClassReference of this VirtualNetwork.
+func (v *VirtualNetwork) GetClassReference() *corev1.ObjectReference {
+	return v.Spec.ClassReference
+}
+
+// SetWriteConnectionSecretToReference of this VirtualNetwork.
+func (v *VirtualNetwork) SetWriteConnectionSecretToReference(r corev1.LocalObjectReference) {
+	v.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetWriteConnectionSecretToReference of this VirtualNetwork.
+func (v *VirtualNetwork) GetWriteConnectionSecretToReference() corev1.LocalObjectReference {
+	return v.Spec.WriteConnectionSecretToReference
+}
+
+// GetReclaimPolicy of this VirtualNetwork.
+func (v *VirtualNetwork) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return v.Spec.ReclaimPolicy
+}
+
+// SetReclaimPolicy of this VirtualNetwork.
+func (v *VirtualNetwork) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
+	v.Spec.ReclaimPolicy = p
+}
+
+// GetProviderReference of this VirtualNetwork.
+func (v *VirtualNetwork) GetProviderReference() *corev1.ObjectReference {
+	return v.Spec.ProviderReference
+}
+
+// GetResourceName returns the name of this VirtualNetwork in Azure, which is its
+// external name if it has one, or else based on its NameFormat.
+func (v *VirtualNetwork) GetResourceName() string {
+	f := v.Spec.NameFormat
+	if f == "" {
+		f = strings.ToLower(VirtualNetworkKind) + "-%s"
+	}
+	return externalname.Or(v, util.ConditionalStringFormat(f, string(v.GetUID())))
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualNetworkList contains a list of VirtualNetwork.
+type VirtualNetworkList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualNetwork `json:"items"`
+}
@@ -24,6 +24,7 @@ import (
 	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
 	"github.com/crossplaneio/crossplane-runtime/pkg/util"
+	"github.com/crossplaneio/crossplane/pkg/externalname"
 )
 
 // AccountParameters define the configuration for Account object
@@ -83,6 +84,11 @@ func (a *Account) SetConditions(c ...runtimev1alpha1.Condition) {
 	a.Status.SetConditions(c...)
 }
 
+// GetCondition of this Account.
+func (a *Account) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return a.Status.GetCondition(ct)
+}
+
 // SetClaimReference of this Account.
 func (a *Account) SetClaimReference(r *corev1.ObjectReference) {
 	a.Spec.ClaimReference = r
@@ -242,6 +248,11 @@ func (c *Container) SetConditions(cd ...runtimev1alpha1.Condition) {
 	c.Status.SetConditions(cd...)
 }
 
+// GetCondition of this Container.
+func (c *Container) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return c.Status.GetCondition(ct)
+}
+
 // SetClaimReference of this Container.
 func (c *Container) SetClaimReference(r *corev1.ObjectReference) {
 	c.Spec.ClaimReference = r
@@ -282,7 +293,8 @@ func (c *Container) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
 	c.Spec.ReclaimPolicy = p
 }
 
-// GetContainerName based on the NameFormat spec value,
+// GetContainerName returns the external name of the container if one is set,
+// or else a name based on the NameFormat spec value,
 // If name format is not provided, container name defaults to UID
 // If name format provided with '%s' value, container name will result in formatted string + UID,
 //   NOTE: only single %s substitution is supported
@@ -297,7 +309,7 @@ func (c *Container) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
 //   4. NameFormat = "foo-%s", ContainerName = "foo-test-uid"
 //   5. NameFormat = "foo-%s-bar-%s", ContainerName = "foo-test-uid-bar-%!s(MISSING)"
 func (c *Container) GetContainerName() string {
-	return util.ConditionalStringFormat(c.Spec.NameFormat, string(c.GetUID()))
+	return externalname.Or(c, util.ConditionalStringFormat(c.Spec.NameFormat, string(c.GetUID())))
 }
 
 // +kubebuilder:object:root=true
@@ -31,6 +31,7 @@ import (
 	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
 	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	"github.com/crossplaneio/crossplane/pkg/externalname"
 	localtest "github.com/crossplaneio/crossplane/pkg/test"
 )
 
@@ -150,6 +151,23 @@ func TestContainer_GetContainerName(t *testing.T) {
 			},
 			want: "foo-test-uid-bar-%!s(MISSING)",
 		},
+		{
+			name: "external name",
+			fields: fields{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   om.Namespace,
+					Name:        om.Name,
+					UID:         om.UID,
+					Annotations: map[string]string{externalname.AnnotationKey: "existing"},
+				},
+				Spec: ContainerSpec{
+					ContainerParameters: ContainerParameters{
+						NameFormat: "foo-%s",
+					},
+				},
+			},
+			want: "existing",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
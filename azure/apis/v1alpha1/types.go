@@ -29,19 +29,101 @@ import (
 type ProviderSpec struct {
 	// Important: Run "make generate" to regenerate code after modifying this file
 
-	// Azure service principal credentials json secret key reference
-	Secret corev1.SecretKeySelector `json:"credentialsSecretRef"`
+	// CredentialsSource from which this provider obtains Azure credentials.
+	// Defaults to Secret.
+	// +kubebuilder:validation:Enum=Secret;File;Environment;ManagedIdentity
+	// +optional
+	CredentialsSource CredentialsSource `json:"credentialsSource,omitempty"`
+
+	// Azure service principal credentials json secret key reference. Required
+	// when the credentials source is Secret.
+	// +optional
+	Secret corev1.SecretKeySelector `json:"credentialsSecretRef,omitempty"`
+
+	// CredentialsFile is the path to an Azure service principal credentials
+	// json file mounted into the Crossplane pod. Required when the credentials
+	// source is File.
+	// +optional
+	CredentialsFile string `json:"credentialsFile,omitempty"`
+
+	// SubscriptionID of the Azure subscription in which to manage resources.
+	// Required when the credentials source is ManagedIdentity, and overrides
+	// the subscription of the Environment credentials source.
+	// +optional
+	SubscriptionID string `json:"subscriptionID,omitempty"`
+
+	// ManagedIdentityClientID is the client ID of a user assigned managed
+	// identity. The system assigned managed identity is used if omitted.
+	// +optional
+	ManagedIdentityClientID string `json:"managedIdentityClientID,omitempty"`
+}
+
+// A CredentialsSource is a source from which a provider obtains credentials.
+type CredentialsSource string
+
+// Credentials sources.
+const (
+	// CredentialsSourceSecret reads service principal credentials json from
+	// the secret referenced by the provider.
+	CredentialsSourceSecret CredentialsSource = "Secret"
+
+	// CredentialsSourceFile reads service principal credentials json from a
+	// path in the Crossplane pod, for example a volume mounted by a secret
+	// store.
+	CredentialsSourceFile CredentialsSource = "File"
+
+	// CredentialsSourceEnvironment reads service principal credentials from
+	// the AZURE_TENANT_ID, AZURE_CLIENT_ID, AZURE_CLIENT_SECRET and
+	// AZURE_SUBSCRIPTION_ID environment variables of the Crossplane pod.
+	CredentialsSourceEnvironment CredentialsSource = "Environment"
+
+	// CredentialsSourceManagedIdentity uses the Azure managed identity of the
+	// Crossplane pod, for example one assigned by AAD Pod Identity.
+	CredentialsSourceManagedIdentity CredentialsSource = "ManagedIdentity"
+)
+
+// ProviderStatus represents the observed state of a Provider.
+type ProviderStatus struct {
+	runtimev1alpha1.ConditionedStatus `json:",inline"`
+
+	// Identity as which the Provider's credentials authenticate, as of when
+	// they were last validated.
+	Identity string `json:"identity,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 
 // Provider is the Schema for the instances API
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SECRET-NAME",type="string",JSONPath=".spec.credentialsSecretRef.name",priority=1
+// +kubebuilder:printcolumn:name="IDENTITY",type="string",JSONPath=".status.identity",priority=1
 type Provider struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec ProviderSpec `json:"spec,omitempty"`
+	Spec   ProviderSpec   `json:"spec,omitempty"`
+	Status ProviderStatus `json:"status,omitempty"`
+}
+
+// SetConditions of this Provider.
+func (p *Provider) SetConditions(c ...runtimev1alpha1.Condition) {
+	p.Status.SetConditions(c...)
+}
+
+// GetCondition of this Provider.
+func (p *Provider) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return p.Status.GetCondition(ct)
+}
+
+// SetIdentity of this Provider.
+func (p *Provider) SetIdentity(identity string) {
+	p.Status.Identity = identity
+}
+
+// GetIdentity of this Provider.
+func (p *Provider) GetIdentity() string {
+	return p.Status.Identity
 }
 
 // +kubebuilder:object:root=true
@@ -82,6 +164,16 @@ type ResourceGroup struct {
 	Status ResourceGroupStatus `json:"status,omitempty"`
 }
 
+// SetConditions of this ResourceGroup.
+func (rg *ResourceGroup) SetConditions(c ...runtimev1alpha1.Condition) {
+	rg.Status.SetConditions(c...)
+}
+
+// GetCondition of this ResourceGroup.
+func (rg *ResourceGroup) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return rg.Status.GetCondition(ct)
+}
+
 // +kubebuilder:object:root=true
 
 // ResourceGroupList contains a list of Resource Groups
@@ -17,15 +17,24 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"os"
 	"path/filepath"
+	"strings"
 
 	awsapis "github.com/crossplaneio/crossplane/aws/apis"
 	azureapis "github.com/crossplaneio/crossplane/azure/apis"
 	gcpapis "github.com/crossplaneio/crossplane/gcp/apis"
 
+	azuretracing "github.com/Azure/go-autorest/tracing"
+	"github.com/pkg/errors"
 	"gopkg.in/alecthomas/kingpin.v2"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	runtimelog "sigs.k8s.io/controller-runtime/pkg/runtime/log"
@@ -33,13 +42,22 @@ import (
 
 	"github.com/crossplaneio/crossplane-runtime/pkg/logging"
 	"github.com/crossplaneio/crossplane/apis"
+	"github.com/crossplaneio/crossplane/pkg/clients/aws/rds"
+	"github.com/crossplaneio/crossplane/pkg/clients/credentials"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
 	"github.com/crossplaneio/crossplane/pkg/controller/aws"
 	"github.com/crossplaneio/crossplane/pkg/controller/azure"
+	"github.com/crossplaneio/crossplane/pkg/controller/classselector"
 	"github.com/crossplaneio/crossplane/pkg/controller/defaultclass"
 	"github.com/crossplaneio/crossplane/pkg/controller/gcp"
+	"github.com/crossplaneio/crossplane/pkg/controller/provider"
 	stacksController "github.com/crossplaneio/crossplane/pkg/controller/stacks"
 	"github.com/crossplaneio/crossplane/pkg/controller/workload"
+	"github.com/crossplaneio/crossplane/pkg/inspect"
+	"github.com/crossplaneio/crossplane/pkg/metrics"
 	"github.com/crossplaneio/crossplane/pkg/stacks"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
+	"github.com/crossplaneio/crossplane/pkg/webhook"
 )
 
 func main() {
@@ -52,9 +70,61 @@ func main() {
 		syncPeriod = app.Flag("sync", "Controller manager sync period duration such as 300ms, 1.5h or 2h45m").
 				Short('s').Default("1h").Duration()
 
+		// leader election allows several replicas of Crossplane, or of the stack manager, to run at
+		// once. Only the elected leader runs controllers; the others wait to take over if it fails.
+		leaderElection          = app.Flag("leader-election", "Use leader election so that only one replica runs controllers at a time.").Bool()
+		leaderElectionNamespace = app.Flag("leader-election-namespace", "Namespace of the config map used for leader election. Defaults to the namespace Crossplane runs in.").String()
+		leaderElectionID        = app.Flag("leader-election-id", "Name of the config map used for leader election. Defaults to a name specific to the command being run.").String()
+		leaseDuration           = app.Flag("leader-election-lease-duration", "Duration non-leader replicas wait before trying to acquire leadership from a leader that has stopped renewing it.").
+					Default("15s").Duration()
+		renewDeadline = app.Flag("leader-election-renew-deadline", "Duration the leader retries renewing leadership before giving it up.").Default("10s").Duration()
+		retryPeriod   = app.Flag("leader-election-retry-period", "Duration replicas wait between attempts to acquire or renew leadership.").Default("2s").Duration()
+
+		metricsAddress = app.Flag("metrics-address", "Address on which to serve Prometheus metrics. Set to 0 to disable serving metrics.").Default(":8080").String()
+
+		// spans of reconciles and of the cloud API calls they make can be exported over OTLP or to an
+		// OpenCensus agent, or written to stdout or a file to debug without a collector.
+		traceExporter = app.Flag("trace-exporter", "Exporter of spans of reconciles and cloud API calls. One of "+strings.Join(tracing.Exporters, ", ")+".").
+				Default(tracing.ExporterNone).Enum(tracing.Exporters...)
+		traceAgentAddress = app.Flag("trace-agent-address", "Address of the OpenCensus agent, or OpenTelemetry Collector OpenCensus receiver, to which the agent exporter sends spans.").
+					Default(tracing.DefaultAgentAddress).String()
+		traceOTLPEndpoint = app.Flag("trace-otlp-endpoint", "URL of the OTLP/HTTP traces endpoint to which the otlp exporter sends spans.").
+					Default(tracing.DefaultOTLPEndpoint).String()
+		traceOTLPHeaders = app.Flag("trace-otlp-header", "HTTP header the otlp exporter sends with each export, for example Authorization=Bearer token. May be repeated.").
+					StringMap()
+		traceFile     = app.Flag("trace-file", "File to which the file exporter writes spans.").Default("/tmp/crossplane-spans.json").String()
+		traceSampling = app.Flag("trace-sampling-probability", "Probability with which reconciles are traced.").Default("1").Float64()
+
 		// default crossplane command and args, this is the default main entry point for Crossplane's
 		// multi-cloud control plane functionality
-		crossplaneCmd = app.Command(filepath.Base(os.Args[0]), "An open source multicloud control plane.").Default()
+		crossplaneCmd  = app.Command(filepath.Base(os.Args[0]), "An open source multicloud control plane.").Default()
+		enableWebhooks = crossplaneCmd.Flag("enable-webhooks", "Serve admission webhooks that validate and default resource claims, classes, and managed resources.").Bool()
+		webhookPort    = crossplaneCmd.Flag("webhook-port", "Port on which to serve admission webhooks.").Default("9443").Int()
+		webhookCertDir = crossplaneCmd.Flag("webhook-cert-dir", "Directory containing the tls.crt and tls.key used to serve admission webhooks.").
+				Default("/tmp/k8s-webhook-server/serving-certs").String()
+		cloudAPIQPS = crossplaneCmd.Flag("cloud-api-qps", "Average requests per second allowed to each cloud API using each provider's credentials.").
+				Default("10").Float64()
+		cloudAPIBurst  = crossplaneCmd.Flag("cloud-api-burst", "Requests allowed at once to each cloud API using each provider's credentials.").Default("20").Int()
+		cloudAPILimits = crossplaneCmd.Flag("cloud-api-limit", "Requests per second and burst allowed to a particular cloud API, for example rds=5:10. May be repeated.").
+				StringMap()
+		resourceMetricsInterval = crossplaneCmd.Flag("resource-metrics-interval", "Interval at which managed resources and resource claims are counted for metrics.").
+					Default("1m").Duration()
+		enabledProviders = crossplaneCmd.Flag("providers", "Comma separated cloud providers whose APIs and controllers are enabled. One or more of aws, azure and gcp.").
+					Default(providerNames...).Strings()
+		disabledControllers = crossplaneCmd.Flag("disable-controllers", "Comma separated sets of controllers to disable. One or more of "+strings.Join(controllerSetNames(), ", ")+".").
+					Strings()
+
+		// providers may only use the ambient credentials of the Crossplane pod, or credentials files
+		// mounted into it, when the administrator trusts their namespace. Anyone who may create a
+		// provider could otherwise act as Crossplane.
+		podCredentialsNamespaces = crossplaneCmd.Flag("pod-credentials-namespace", "Namespace whose providers may use the ambient credentials of the Crossplane pod, or read credentials files mounted into it. May be repeated.").
+						Strings()
+		credentialsFileDirs = crossplaneCmd.Flag("credentials-file-dir", "Directory from which providers may read credentials files. May be repeated.").Strings()
+
+		// RDS users and databases are managed over TLS connections to their instances, verified using
+		// the certificate authority bundle published by AWS.
+		rdsCABundle = crossplaneCmd.Flag("aws-rds-ca-bundle", "File containing the PEM encoded certificate authorities used to verify connections to RDS instances.").
+				Default(rds.CABundleFile).String()
 
 		// stacks  commands and args, these are the main entry points for Crossplane's stack manager (SM).
 		// The SM runs as a separate pod from the main Crossplane pod because in order to install stacks that
@@ -74,6 +144,17 @@ func main() {
 		// execute this command.
 		extUnpackCmd = extCmd.Command("unpack", "Unpack a stack")
 		extUnpackDir = extUnpackCmd.Flag("content-dir", "The directory that contains the stack contents").Required().String()
+
+		// inspect commands describe the resources Crossplane manages. Installed on the PATH as
+		// kubectl-crossplane, the Crossplane binary may also be run as a kubectl plugin, e.g.
+		// kubectl crossplane inspect claim mysqlinstance app-db.
+		inspectCmd          = app.Command("inspect", "Inspect the resources Crossplane manages")
+		inspectNamespace    = inspectCmd.Flag("namespace", "Namespace of the resources to inspect.").Short('n').Default("default").String()
+		inspectClaimCmd     = inspectCmd.Command("claim", "Show a resource claim's class, managed resource, provider and connection secret, and explain why it is not bound")
+		inspectClaimKind    = inspectClaimCmd.Arg("kind", "Kind of resource claim, e.g. mysqlinstance or mysqlinstances.database.crossplane.io").Required().String()
+		inspectClaimName    = inspectClaimCmd.Arg("name", "Name of the resource claim").Required().String()
+		inspectStacksCmd    = inspectCmd.Command("stacks", "List installed stacks, the CRDs they own and the health of their controllers")
+		inspectAllNamespace = inspectStacksCmd.Flag("all-namespaces", "List stacks in all namespaces.").Short('A').Bool()
 	)
 	cmd := kingpin.MustParse(app.Parse(os.Args[1:]))
 
@@ -88,23 +169,100 @@ func main() {
 
 	var setupWithManagerFunc func(manager.Manager) error
 
+	// The stack manager does not run any cloud provider's controllers, but
+	// the stacks it installs may use any cloud provider's APIs.
+	providers := providerNames
+
+	// Crossplane and the stack manager run different controllers, so they
+	// elect their leaders independently.
+	electionID := *leaderElectionID
+
+	serviceName := "crossplane"
+
 	// Determine the command being called and execute the corresponding logic
 	switch cmd {
 	case crossplaneCmd.FullCommand():
-		// the default Crossplane command is being run, add all the regular controllers to the manager
-		setupWithManagerFunc = controllerSetupWithManager
+		// the default Crossplane command is being run, add the enabled regular controllers to the manager
+		var err error
+		providers, err = parseList(*enabledProviders, providerNames)
+		kingpin.FatalIfError(err, "Cannot parse providers")
+		disabled, err := parseList(*disabledControllers, controllerSetNames())
+		kingpin.FatalIfError(err, "Cannot parse disabled controllers")
+		enabled := enabledControllerSets(providers, disabled)
+		log.Info("Enabled cloud providers", "providers", providers)
+		log.Info("Enabled controllers", "controllers", enabled)
+
+		setupWithManagerFunc = func(mgr manager.Manager) error {
+			if err := controllerSetupWithManager(mgr, enabled); err != nil {
+				return err
+			}
+			return mgr.Add(metrics.NewResourceCollector(mgr, *resourceMetricsInterval))
+		}
+		if electionID == "" {
+			electionID = "crossplane-leader-election"
+		}
+		limits := make(map[string]pool.Limit, len(*cloudAPILimits))
+		for api, l := range *cloudAPILimits {
+			lm, err := pool.ParseLimit(l)
+			kingpin.FatalIfError(err, "Cannot parse rate limit for cloud API %s", api)
+			limits[api] = lm
+		}
+		pool.Default = pool.New(pool.Limit{QPS: *cloudAPIQPS, Burst: *cloudAPIBurst}, limits)
+		credentials.Default = &credentials.Policy{Namespaces: *podCredentialsNamespaces, FileDirectories: *credentialsFileDirs}
+		rds.CABundleFile = *rdsCABundle
+		log.Info("Namespaces whose providers may use pod credentials", "namespaces", *podCredentialsNamespaces, "fileDirectories", *credentialsFileDirs)
+		if *enableWebhooks {
+			setupControllers := setupWithManagerFunc
+			setupWithManagerFunc = func(mgr manager.Manager) error {
+				if err := setupControllers(mgr); err != nil {
+					return err
+				}
+				return webhookSetupWithManager(mgr)
+			}
+		}
 	case extManageCmd.FullCommand():
 		// the "stacks manage" command is being run, the only controllers we should add to the
 		// manager are the stacks controllers
 		setupWithManagerFunc = stacksControllerSetupWithManager
+		if electionID == "" {
+			electionID = "crossplane-stack-manager-leader-election"
+		}
+		serviceName = "crossplane-stack-manager"
 	case extUnpackCmd.FullCommand():
 		// stack unpack command was called, run the stack unpacking logic
 		kingpin.FatalIfError(stacks.Unpack(*extUnpackDir), "failed to unpack stacks")
 		return
+	case inspectClaimCmd.FullCommand():
+		kingpin.FatalIfError(inspectClaim(*inspectNamespace, *inspectClaimKind, *inspectClaimName), "Cannot inspect resource claim")
+		return
+	case inspectStacksCmd.FullCommand():
+		ns := *inspectNamespace
+		if *inspectAllNamespace {
+			ns = ""
+		}
+		kingpin.FatalIfError(inspectStacks(ns), "Cannot inspect stacks")
+		return
 	default:
 		kingpin.FatalUsage("unknown command %s", cmd)
 	}
 
+	stopTracing, err := tracing.Setup(tracing.Options{
+		ServiceName:         serviceName,
+		Exporter:            *traceExporter,
+		AgentAddress:        *traceAgentAddress,
+		OTLPEndpoint:        *traceOTLPEndpoint,
+		OTLPHeaders:         *traceOTLPHeaders,
+		File:                *traceFile,
+		SamplingProbability: *traceSampling,
+	})
+	kingpin.FatalIfError(err, "Cannot set up tracing")
+	defer stopTracing()
+	if *traceExporter != tracing.ExporterNone {
+		// Azure's SDK records spans of the requests it makes, including
+		// polls of long running operations, once its tracing is enabled.
+		kingpin.FatalIfError(azuretracing.Enable(), "Cannot enable Azure SDK tracing")
+	}
+
 	// Get a config to talk to the apiserver
 	cfg, err := config.GetConfig()
 	if err != nil {
@@ -114,7 +272,18 @@ func main() {
 	log.Info("Sync period", "duration", syncPeriod.String())
 
 	// Create a new Cmd to provide shared dependencies and start components
-	mgr, err := manager.New(cfg, manager.Options{SyncPeriod: syncPeriod})
+	mgr, err := manager.New(cfg, manager.Options{
+		SyncPeriod:              syncPeriod,
+		Port:                    *webhookPort,
+		CertDir:                 *webhookCertDir,
+		LeaderElection:          *leaderElection,
+		LeaderElectionNamespace: *leaderElectionNamespace,
+		LeaderElectionID:        electionID,
+		LeaseDuration:           leaseDuration,
+		RenewDeadline:           renewDeadline,
+		RetryPeriod:             retryPeriod,
+		MetricsBindAddress:      *metricsAddress,
+	})
 	if err != nil {
 		kingpin.FatalIfError(err, "Cannot create manager")
 	}
@@ -122,7 +291,7 @@ func main() {
 	log.Info("Adding schemes")
 
 	// add all resources to the manager's runtime scheme
-	if err := addToScheme(mgr.GetScheme()); err != nil {
+	if err := addToScheme(mgr.GetScheme(), providers); err != nil {
 		kingpin.FatalIfError(err, "Cannot add APIs to scheme")
 	}
 
@@ -139,30 +308,112 @@ func main() {
 	kingpin.FatalIfError(mgr.Start(signals.SetupSignalHandler()), "Cannot start controller")
 }
 
-func controllerSetupWithManager(mgr manager.Manager) error {
-	if err := (&defaultclass.Controllers{}).SetupWithManager(mgr); err != nil {
-		return err
+// providerNames are the cloud providers supported by Crossplane. Each cloud
+// provider's set of controllers is named for it.
+var providerNames = []string{"aws", "azure", "gcp"}
+
+// A controllerSet is a named set of controllers that may be disabled.
+type controllerSet struct {
+	name        string
+	controllers interface {
+		SetupWithManager(manager.Manager) error
 	}
+}
 
-	if err := (&aws.Controllers{}).SetupWithManager(mgr); err != nil {
-		return err
+// controllerSets returns the sets of controllers run by the default command,
+// in the order they are added to the manager.
+func controllerSets() []controllerSet {
+	return []controllerSet{
+		{name: "defaultclass", controllers: &defaultclass.Controllers{}},
+		{name: "classselector", controllers: &classselector.Controllers{}},
+		{name: "provider", controllers: &provider.Controllers{}},
+		{name: "aws", controllers: &aws.Controllers{}},
+		{name: "azure", controllers: &azure.Controllers{}},
+		{name: "gcp", controllers: &gcp.Controllers{}},
+		{name: "workload", controllers: &workload.Controllers{}},
 	}
+}
 
-	if err := (&azure.Controllers{}).SetupWithManager(mgr); err != nil {
-		return err
+// controllerSetNames returns the names of all sets of controllers.
+func controllerSetNames() []string {
+	sets := controllerSets()
+	names := make([]string, len(sets))
+	for i, s := range sets {
+		names[i] = s.name
 	}
+	return names
+}
 
-	if err := (&gcp.Controllers{}).SetupWithManager(mgr); err != nil {
-		return err
+// enabledControllerSets returns the names of the sets of controllers that
+// are enabled given the supplied enabled cloud providers and disabled sets of
+// controllers.
+func enabledControllerSets(providers, disabled []string) []string {
+	off := map[string]bool{}
+	for _, p := range providerNames {
+		off[p] = true
+	}
+	for _, p := range providers {
+		off[p] = false
+	}
+	for _, d := range disabled {
+		off[d] = true
 	}
 
-	if err := (&workload.Controllers{}).SetupWithManager(mgr); err != nil {
-		return err
+	enabled := []string{}
+	for _, name := range controllerSetNames() {
+		if !off[name] {
+			enabled = append(enabled, name)
+		}
+	}
+	return enabled
+}
+
+// parseList returns the supplied values, which may each be a comma separated
+// list, as a single list. It returns an error if any value is not allowed.
+func parseList(values, allowed []string) ([]string, error) {
+	ok := map[string]bool{}
+	for _, a := range allowed {
+		ok[a] = true
+	}
+
+	list := []string{}
+	for _, v := range values {
+		for _, item := range strings.Split(v, ",") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+			if !ok[item] {
+				return nil, errors.Errorf("%q is not one of %s", item, strings.Join(allowed, ", "))
+			}
+			list = append(list, item)
+		}
+	}
+	return list, nil
+}
+
+func controllerSetupWithManager(mgr manager.Manager, enabled []string) error {
+	on := map[string]bool{}
+	for _, name := range enabled {
+		on[name] = true
+	}
+
+	for _, s := range controllerSets() {
+		if !on[s.name] {
+			continue
+		}
+		if err := s.controllers.SetupWithManager(mgr); err != nil {
+			return errors.Wrapf(err, "cannot set up %s controllers", s.name)
+		}
 	}
 
 	return nil
 }
 
+func webhookSetupWithManager(mgr manager.Manager) error {
+	return (&webhook.Webhooks{}).SetupWithManager(mgr)
+}
+
 func stacksControllerSetupWithManager(mgr manager.Manager) error {
 	if err := (&stacksController.Controllers{}).SetupWithManager(mgr); err != nil {
 		return err
@@ -170,23 +421,77 @@ func stacksControllerSetupWithManager(mgr manager.Manager) error {
 	return nil
 }
 
-// addToScheme adds all resources to the runtime scheme.
-func addToScheme(scheme *runtime.Scheme) error {
+// providerSchemes add the resources of each cloud provider to a runtime
+// scheme.
+var providerSchemes = map[string]func(*runtime.Scheme) error{
+	"aws":   awsapis.AddToScheme,
+	"azure": azureapis.AddToScheme,
+	"gcp":   gcpapis.AddToScheme,
+}
+
+// addToScheme adds Crossplane's resources, and the resources of the supplied
+// cloud providers, to the runtime scheme.
+func addToScheme(scheme *runtime.Scheme, providers []string) error {
 	if err := apis.AddToScheme(scheme); err != nil {
 		return err
 	}
 
-	if err := awsapis.AddToScheme(scheme); err != nil {
-		return err
+	for _, p := range providers {
+		if err := providerSchemes[p](scheme); err != nil {
+			return err
+		}
 	}
 
-	if err := gcpapis.AddToScheme(scheme); err != nil {
-		return err
+	return nil
+}
+
+// inspectClient returns a client that can read Crossplane's resources, and the
+// resources of any stack, from the API server.
+func inspectClient() (client.Client, meta.RESTMapper, error) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot get config")
+	}
+	s := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(s); err != nil {
+		return nil, nil, errors.Wrap(err, "cannot add Kubernetes APIs to scheme")
+	}
+	if err := apis.AddToScheme(s); err != nil {
+		return nil, nil, errors.Wrap(err, "cannot add APIs to scheme")
 	}
+	m, err := apiutil.NewDiscoveryRESTMapper(cfg)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot discover APIs")
+	}
+	c, err := client.New(cfg, client.Options{Scheme: s, Mapper: m})
+	return c, m, errors.Wrap(err, "cannot create client")
+}
 
-	if err := azureapis.AddToScheme(scheme); err != nil {
+func inspectClaim(namespace, kind, name string) error {
+	c, m, err := inspectClient()
+	if err != nil {
 		return err
 	}
-
+	gvk, err := inspect.KindFor(m, kind)
+	if err != nil {
+		return err
+	}
+	n, err := inspect.Claim(context.Background(), c, gvk, types.NamespacedName{Namespace: namespace, Name: name})
+	if err != nil {
+		return err
+	}
+	inspect.Print(os.Stdout, n)
 	return nil
 }
+
+func inspectStacks(namespace string) error {
+	c, _, err := inspectClient()
+	if err != nil {
+		return err
+	}
+	s, err := inspect.Stacks(context.Background(), c, namespace)
+	if err != nil {
+		return err
+	}
+	return inspect.PrintStacks(os.Stdout, s)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseList(t *testing.T) {
+	cases := map[string]struct {
+		values  []string
+		want    []string
+		wantErr bool
+	}{
+		"Empty": {
+			values: nil,
+			want:   []string{},
+		},
+		"Repeated": {
+			values: []string{"aws", "gcp"},
+			want:   []string{"aws", "gcp"},
+		},
+		"CommaSeparated": {
+			values: []string{"aws, gcp", "azure"},
+			want:   []string{"aws", "gcp", "azure"},
+		},
+		"EmptyItems": {
+			values: []string{",aws,,", ""},
+			want:   []string{"aws"},
+		},
+		"NotAllowed": {
+			values:  []string{"aws,alibaba"},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseList(tc.values, providerNames)
+			if diff := cmp.Diff(tc.wantErr, err != nil); diff != "" {
+				t.Fatalf("parseList(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("parseList(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEnabledControllerSets(t *testing.T) {
+	cases := map[string]struct {
+		providers []string
+		disabled  []string
+		want      []string
+	}{
+		"AllProviders": {
+			providers: providerNames,
+			want:      []string{"defaultclass", "classselector", "provider", "aws", "azure", "gcp", "workload"},
+		},
+		"SomeProviders": {
+			providers: []string{"gcp"},
+			want:      []string{"defaultclass", "classselector", "provider", "gcp", "workload"},
+		},
+		"NoProviders": {
+			want: []string{"defaultclass", "classselector", "provider", "workload"},
+		},
+		"DisabledControllers": {
+			providers: providerNames,
+			disabled:  []string{"workload", "azure"},
+			want:      []string{"defaultclass", "classselector", "provider", "aws", "gcp"},
+		},
+		"DisabledEverything": {
+			providers: providerNames,
+			disabled:  controllerSetNames(),
+			want:      []string{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := enabledControllerSets(tc.providers, tc.disabled)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("enabledControllerSets(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -23,6 +23,8 @@ import (
 
 	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	corev1alpha1 "github.com/crossplaneio/crossplane/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/reference"
 )
 
 // Cloud Memorystore instance states.
@@ -42,6 +44,13 @@ var (
 	TierStandardHA = redis.Instance_STANDARD_HA.String()
 )
 
+// Cloud Memorystore instance operations. An operation is reported in the status
+// of an instance while GCP applies the change it describes.
+const (
+	OperationScaleMemory  = "ScaleMemory"
+	OperationUpdateConfig = "UpdateConfig"
+)
+
 // CloudMemorystoreInstanceParameters define the fields required for provisioning
 // a cloud memorystore instance on GCP
 // Most fields map directly to a GCP Instance resource.
@@ -52,7 +61,8 @@ type CloudMemorystoreInstanceParameters struct {
 
 	// Tier specifies the replication level of the Redis cluster. BASIC provides
 	// a single Redis instance with no high availability. STANDARD_HA provides a
-	// cluster of two Redis instances in distinct availability zones.
+	// cluster of two Redis instances in distinct availability zones. The tier
+	// cannot be changed after the instance is created.
 	// https://cloud.google.com/memorystore/docs/redis/redis-tiers
 	// +kubebuilder:validation:Enum=BASIC;STANDARD_HA
 	Tier string `json:"tier"`
@@ -84,6 +94,10 @@ type CloudMemorystoreInstanceParameters struct {
 	// default network will be used.
 	AuthorizedNetwork string `json:"authorizedNetwork,omitempty"`
 
+	// AuthorizedNetworkRef references a Network in the same namespace, from
+	// which AuthorizedNetwork is resolved once the network is available.
+	AuthorizedNetworkRef *NetworkURIReferencerForCloudMemorystoreInstance `json:"authorizedNetworkRef,omitempty"`
+
 	// RedisVersion specifies the version of Redis software. If not provided,
 	// latest supported version will be used. Updating the version will perform
 	// an upgrade/downgrade to the new version. Currently, the supported values
@@ -133,6 +147,10 @@ type CloudMemorystoreInstanceStatus struct {
 	// project and location (region) IDs. e.g. 'foo', not
 	// 'projects/fooproj/locations/us-foo1/instances/foo'
 	InstanceName string `json:"instanceName,omitempty"`
+
+	// Operation is the update most recently requested of this instance, for
+	// example ScaleMemory. It is cleared once the instance is ready again.
+	Operation string `json:"operation,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -141,6 +159,7 @@ type CloudMemorystoreInstanceStatus struct {
 // +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.state"
 // +kubebuilder:printcolumn:name="CLASS",type="string",JSONPath=".spec.classRef.name"
 // +kubebuilder:printcolumn:name="VERSION",type="string",JSONPath=".spec.redisVersion"
+// +kubebuilder:printcolumn:name="OPERATION",type="string",JSONPath=".status.operation"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 type CloudMemorystoreInstance struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -165,6 +184,11 @@ func (i *CloudMemorystoreInstance) SetConditions(c ...runtimev1alpha1.Condition)
 	i.Status.SetConditions(c...)
 }
 
+// GetCondition of this CloudMemorystoreInstance.
+func (i *CloudMemorystoreInstance) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return i.Status.GetCondition(ct)
+}
+
 // SetClaimReference of this CloudMemorystoreInstance.
 func (i *CloudMemorystoreInstance) SetClaimReference(r *corev1.ObjectReference) {
 	i.Spec.ClaimReference = r
@@ -205,6 +229,14 @@ func (i *CloudMemorystoreInstance) SetReclaimPolicy(p runtimev1alpha1.ReclaimPol
 	i.Spec.ReclaimPolicy = p
 }
 
+// GetReferencers of this CloudMemorystoreInstance.
+func (i *CloudMemorystoreInstance) GetReferencers() []reference.AttributeReferencer {
+	if i.Spec.AuthorizedNetworkRef == nil {
+		return nil
+	}
+	return []reference.AttributeReferencer{i.Spec.AuthorizedNetworkRef}
+}
+
 // +kubebuilder:object:root=true
 
 // CloudMemorystoreInstanceList contains a list of CloudMemorystoreInstance
@@ -218,6 +250,11 @@ type CloudMemorystoreInstanceList struct {
 type CloudMemorystoreInstanceClassSpecTemplate struct {
 	runtimev1alpha1.ResourceClassSpecTemplate `json:",inline"`
 	CloudMemorystoreInstanceParameters        `json:",inline"`
+
+	// ClaimParameters specifies the portable parameters that resource claims
+	// may request of this class, and the values they may request.
+	// +optional
+	ClaimParameters *corev1alpha1.ClaimParameterPolicy `json:"claimParameters,omitempty"`
 }
 
 var _ resource.Class = &CloudMemorystoreInstanceClass{}
@@ -22,6 +22,8 @@ package v1alpha1
 
 import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
+
+	corev1alpha1 "github.com/crossplaneio/crossplane/apis/core/v1alpha1"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -114,6 +116,11 @@ func (in *CloudMemorystoreInstanceClassSpecTemplate) DeepCopyInto(out *CloudMemo
 	*out = *in
 	in.ResourceClassSpecTemplate.DeepCopyInto(&out.ResourceClassSpecTemplate)
 	in.CloudMemorystoreInstanceParameters.DeepCopyInto(&out.CloudMemorystoreInstanceParameters)
+	if in.ClaimParameters != nil {
+		in, out := &in.ClaimParameters, &out.ClaimParameters
+		*out = new(corev1alpha1.ClaimParameterPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudMemorystoreInstanceClassSpecTemplate.
@@ -161,6 +168,11 @@ func (in *CloudMemorystoreInstanceList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudMemorystoreInstanceParameters) DeepCopyInto(out *CloudMemorystoreInstanceParameters) {
 	*out = *in
+	if in.AuthorizedNetworkRef != nil {
+		in, out := &in.AuthorizedNetworkRef, &out.AuthorizedNetworkRef
+		*out = new(NetworkURIReferencerForCloudMemorystoreInstance)
+		**out = **in
+	}
 	if in.RedisConfigs != nil {
 		in, out := &in.RedisConfigs, &out.RedisConfigs
 		*out = make(map[string]string, len(*in))
@@ -212,3 +224,19 @@ func (in *CloudMemorystoreInstanceStatus) DeepCopy() *CloudMemorystoreInstanceSt
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkURIReferencerForCloudMemorystoreInstance) DeepCopyInto(out *NetworkURIReferencerForCloudMemorystoreInstance) {
+	*out = *in
+	out.NetworkURIReferencer = in.NetworkURIReferencer
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkURIReferencerForCloudMemorystoreInstance.
+func (in *NetworkURIReferencerForCloudMemorystoreInstance) DeepCopy() *NetworkURIReferencerForCloudMemorystoreInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkURIReferencerForCloudMemorystoreInstance)
+	in.DeepCopyInto(out)
+	return out
+}
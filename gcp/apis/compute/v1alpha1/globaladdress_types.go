@@ -0,0 +1,227 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/util"
+	"github.com/crossplaneio/crossplane/pkg/externalname"
+	"github.com/crossplaneio/crossplane/pkg/reference"
+)
+
+// Global address types.
+const (
+	AddressTypeInternal = "INTERNAL"
+	AddressTypeExternal = "EXTERNAL"
+)
+
+// Global address purposes.
+const (
+	AddressPurposeVPCPeering = "VPC_PEERING"
+)
+
+// Global address statuses.
+const (
+	AddressStatusReserving = "RESERVING"
+	AddressStatusReserved  = "RESERVED"
+	AddressStatusInUse     = "IN_USE"
+)
+
+// GlobalAddressParameters define the desired state of a GCP global address.
+// Global addresses cannot be changed after they are created.
+type GlobalAddressParameters struct {
+	// NameFormat to format the address name passing it the object UID. If not
+	// provided, defaults to "globaladdress-%s", i.e. the UID prefixed with
+	// globaladdress-.
+	// +optional
+	NameFormat string `json:"nameFormat,omitempty"`
+
+	// Description of the address.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Address is the first IP address of the range to reserve, e.g.
+	// 10.100.0.0. GCP chooses an unused range if it is omitted.
+	// +optional
+	Address string `json:"address,omitempty"`
+
+	// AddressType of the address, either INTERNAL or EXTERNAL. GCP defaults
+	// to EXTERNAL.
+	// +kubebuilder:validation:Enum=INTERNAL;EXTERNAL
+	// +optional
+	AddressType string `json:"addressType,omitempty"`
+
+	// Purpose of the address. A range reserved for private services access,
+	// i.e. for a service networking Connection, must use VPC_PEERING.
+	// +kubebuilder:validation:Enum=VPC_PEERING
+	// +optional
+	Purpose string `json:"purpose,omitempty"`
+
+	// PrefixLength of the reserved range, e.g. 16. It is required when the
+	// purpose is VPC_PEERING.
+	// +optional
+	PrefixLength int64 `json:"prefixLength,omitempty"`
+
+	// Network is the URL of the network in which to reserve an INTERNAL
+	// address. It is resolved from NetworkRef when that is set.
+	// +optional
+	Network string `json:"network,omitempty"`
+
+	// NetworkRef references a Network in the same namespace, from which
+	// Network is resolved once the Network is available.
+	// +optional
+	NetworkRef *NetworkURIReferencerForGlobalAddress `json:"networkRef,omitempty"`
+}
+
+// GlobalAddressSpec defines the desired state of a GlobalAddress.
+type GlobalAddressSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	GlobalAddressParameters      `json:",inline"`
+}
+
+// GlobalAddressStatus defines the observed state of a GlobalAddress.
+type GlobalAddressStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	// Name of the address in GCP.
+	Name string `json:"name,omitempty"`
+
+	// SelfLink is the URL of the address.
+	SelfLink string `json:"selfLink,omitempty"`
+
+	// Address is the first IP address of the reserved range.
+	Address string `json:"address,omitempty"`
+
+	// Status of the address, either RESERVING, RESERVED or IN_USE.
+	Status string `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A GlobalAddress is a managed resource that represents a GCP global address,
+// for example a range of internal addresses reserved for private services
+// access.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="NAME",type="string",JSONPath=".status.name"
+// +kubebuilder:printcolumn:name="ADDRESS",type="string",JSONPath=".status.address"
+// +kubebuilder:printcolumn:name="PREFIX",type="integer",JSONPath=".spec.prefixLength"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+type GlobalAddress struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GlobalAddressSpec   `json:"spec,omitempty"`
+	Status GlobalAddressStatus `json:"status,omitempty"`
+}
+
+// SetBindingPhase of this GlobalAddress.
+func (a *GlobalAddress) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	a.Status.SetBindingPhase(p)
+}
+
+// GetBindingPhase of this GlobalAddress.
+func (a *GlobalAddress) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return a.Status.GetBindingPhase()
+}
+
+// SetConditions of this GlobalAddress.
+func (a *GlobalAddress) SetConditions(c ...runtimev1alpha1.Condition) {
+	a.Status.SetConditions(c...)
+}
+
+// GetCondition of this GlobalAddress.
+func (a *GlobalAddress) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return a.Status.GetCondition(ct)
+}
+
+// SetClaimReference of this GlobalAddress.
+func (a *GlobalAddress) SetClaimReference(r *corev1.ObjectReference) {
+	a.Spec.ClaimReference = r
+}
+
+// GetClaimReference of this GlobalAddress.
+func (a *GlobalAddress) GetClaimReference() *corev1.ObjectReference {
+	return a.Spec.ClaimReference
+}
+
+// SetClassReference of this GlobalAddress.
+func (a *GlobalAddress) SetClassReference(r *corev1.ObjectReference) {
+	a.Spec.ClassReference = r
+}
+
+// GetClassReference of this GlobalAddress.
+func (a *GlobalAddress) GetClassReference() *corev1.ObjectReference {
+	return a.Spec.ClassReference
+}
+
+// SetWriteConnectionSecretToReference of this GlobalAddress.
+func (a *GlobalAddress) SetWriteConnectionSecretToReference(r corev1.LocalObjectReference) {
+	a.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetWriteConnectionSecretToReference of this GlobalAddress.
+func (a *GlobalAddress) GetWriteConnectionSecretToReference() corev1.LocalObjectReference {
+	return a.Spec.WriteConnectionSecretToReference
+}
+
+// GetReclaimPolicy of this GlobalAddress.
+func (a *GlobalAddress) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return a.Spec.ReclaimPolicy
+}
+
+// SetReclaimPolicy of this GlobalAddress.
+func (a *GlobalAddress) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
+	a.Spec.ReclaimPolicy = p
+}
+
+// GetProviderReference of this GlobalAddress.
+func (a *GlobalAddress) GetProviderReference() *corev1.ObjectReference {
+	return a.Spec.ProviderReference
+}
+
+// GetReferencers of this GlobalAddress.
+func (a *GlobalAddress) GetReferencers() []reference.AttributeReferencer {
+	if a.Spec.NetworkRef == nil {
+		return nil
+	}
+	return []reference.AttributeReferencer{a.Spec.NetworkRef}
+}
+
+// GetResourceName returns the name of this GlobalAddress in GCP, which is its
+// external name if it has one, or else based on its NameFormat.
+func (a *GlobalAddress) GetResourceName() string {
+	f := a.Spec.NameFormat
+	if f == "" {
+		f = strings.ToLower(GlobalAddressKind) + "-%s"
+	}
+	return externalname.Or(a, util.ConditionalStringFormat(f, string(a.GetUID())))
+}
+
+// +kubebuilder:object:root=true
+
+// GlobalAddressList contains a list of GlobalAddress.
+type GlobalAddressList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GlobalAddress `json:"items"`
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/util"
+	"github.com/crossplaneio/crossplane/pkg/externalname"
+)
+
+// Network routing modes.
+const (
+	RoutingModeRegional = "REGIONAL"
+	RoutingModeGlobal   = "GLOBAL"
+)
+
+// NetworkParameters define the desired state of a GCP VPC network.
+type NetworkParameters struct {
+	// NameFormat to format the network name passing it the object UID. If not
+	// provided, defaults to "network-%s", i.e. the UID prefixed with network-.
+	// +optional
+	NameFormat string `json:"nameFormat,omitempty"`
+
+	// Description of the network. It cannot be changed after the network is
+	// created.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// AutoCreateSubnetworks creates an auto mode network, with a subnetwork in
+	// each region, when true. Otherwise a custom mode network is created, and
+	// its subnetworks must be created explicitly. It cannot be changed after
+	// the network is created.
+	// +optional
+	AutoCreateSubnetworks bool `json:"autoCreateSubnetworks,omitempty"`
+
+	// RoutingMode determines whether Cloud Routers advertise the routes of
+	// only their own region, or of all regions. GCP defaults to REGIONAL.
+	// +kubebuilder:validation:Enum=REGIONAL;GLOBAL
+	// +optional
+	RoutingMode string `json:"routingMode,omitempty"`
+}
+
+// NetworkSpec defines the desired state of a Network.
+type NetworkSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	NetworkParameters            `json:",inline"`
+}
+
+// NetworkStatus defines the observed state of a Network.
+type NetworkStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	// Name of the network in GCP.
+	Name string `json:"name,omitempty"`
+
+	// SelfLink is the URL of the network.
+	SelfLink string `json:"selfLink,omitempty"`
+
+	// Subnetworks are the URLs of the network's subnetworks.
+	Subnetworks []string `json:"subnetworks,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Network is a managed resource that represents a GCP VPC network.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="NETWORK",type="string",JSONPath=".status.name"
+// +kubebuilder:printcolumn:name="ROUTING",type="string",JSONPath=".spec.routingMode"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+type Network struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NetworkSpec   `json:"spec,omitempty"`
+	Status NetworkStatus `json:"status,omitempty"`
+}
+
+// SetBindingPhase of this Network.
+func (n *Network) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	n.Status.SetBindingPhase(p)
+}
+
+// GetBindingPhase of this Network.
+func (n *Network) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return n.Status.GetBindingPhase()
+}
+
+// SetConditions of this Network.
+func (n *Network) SetConditions(c ...runtimev1alpha1.Condition) {
+	n.Status.SetConditions(c...)
+}
+
+// GetCondition of this Network.
+func (n *Network) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return n.Status.GetCondition(ct)
+}
+
+// SetClaimReference of this Network.
+func (n *Network) SetClaimReference(r *corev1.ObjectReference) {
+	n.Spec.ClaimReference = r
+}
+
+// GetClaimReference of this Network.
+func (n *Network) GetClaimReference() *corev1.ObjectReference {
+	return n.Spec.ClaimReference
+}
+
+// SetClassReference of this Network.
+func (n *Network) SetClassReference(r *corev1.ObjectReference) {
+	n.Spec.ClassReference = r
+}
+
+// GetClassReference of this Network.
+func (n *Network) GetClassReference() *corev1.ObjectReference {
+	return n.Spec.ClassReference
+}
+
+// SetWriteConnectionSecretToReference of this Network.
+func (n *Network) SetWriteConnectionSecretToReference(r corev1.LocalObjectReference) {
+	n.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetWriteConnectionSecretToReference of this Network.
+func (n *Network) GetWriteConnectionSecretToReference() corev1.LocalObjectReference {
+	return n.Spec.WriteConnectionSecretToReference
+}
+
+// GetReclaimPolicy of this Network.
+func (n *Network) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return n.Spec.ReclaimPolicy
+}
+
+// SetReclaimPolicy of this Network.
+func (n *Network) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
+	n.Spec.ReclaimPolicy = p
+}
+
+// GetProviderReference of this Network.
+func (n *Network) GetProviderReference() *corev1.ObjectReference {
+	return n.Spec.ProviderReference
+}
+
+// GetResourceName returns the name of this Network in GCP, which is its
+// external name if it has one, or else based on its NameFormat. Network names
+// must start with a letter, so the UID is prefixed with the Network's kind by
+// default.
+func (n *Network) GetResourceName() string {
+	f := n.Spec.NameFormat
+	if f == "" {
+		f = strings.ToLower(NetworkKind) + "-%s"
+	}
+	return externalname.Or(n, util.ConditionalStringFormat(f, string(n.GetUID())))
+}
+
+// +kubebuilder:object:root=true
+
+// NetworkList contains a list of Network.
+type NetworkList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Network `json:"items"`
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplaneio/crossplane/pkg/externalname"
+)
+
+var _ resource.Managed = &Network{}
+
+func TestStorageNetwork(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	key := types.NamespacedName{Name: name, Namespace: namespace}
+	created := &Network{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: NetworkSpec{
+			ResourceSpec: runtimev1alpha1.ResourceSpec{
+				ProviderReference: &core.ObjectReference{},
+			},
+			NetworkParameters: NetworkParameters{RoutingMode: RoutingModeRegional},
+		},
+	}
+
+	// Test Create
+	fetched := &Network{}
+	g.Expect(c.Create(ctx, created)).NotTo(HaveOccurred())
+
+	g.Expect(c.Get(ctx, key, fetched)).NotTo(HaveOccurred())
+	g.Expect(fetched).To(Equal(created))
+
+	// Test Delete
+	g.Expect(c.Delete(ctx, fetched)).NotTo(HaveOccurred())
+	g.Expect(c.Get(ctx, key, fetched)).To(HaveOccurred())
+}
+
+func TestNetwork_GetResourceName(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	n := &Network{ObjectMeta: metav1.ObjectMeta{UID: types.UID("test-uid")}}
+	g.Expect(n.GetResourceName()).To(Equal("network-test-uid"))
+
+	n.Spec.NameFormat = "cool-%s"
+	g.Expect(n.GetResourceName()).To(Equal("cool-test-uid"))
+
+	n.Spec.NameFormat = "cool"
+	g.Expect(n.GetResourceName()).To(Equal("cool"))
+
+	externalname.Set(n, "existing")
+	g.Expect(n.GetResourceName()).To(Equal("existing"))
+}
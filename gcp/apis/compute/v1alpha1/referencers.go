@@ -0,0 +1,113 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplaneio/crossplane/pkg/reference"
+)
+
+// Error strings.
+const (
+	errNotSubnetwork    = "managed resource is not a Subnetwork"
+	errNotGlobalAddress = "managed resource is not a GlobalAddress"
+)
+
+// A NetworkURIReferencer resolves the URL of a Network in the same namespace
+// as the referencing resource.
+type NetworkURIReferencer struct {
+	corev1.LocalObjectReference `json:",inline"`
+}
+
+// Build returns the self link of the referenced Network once it is available.
+func (v *NetworkURIReferencer) Build(ctx context.Context, res reference.CanReference, r client.Reader) (string, error) {
+	n := &Network{}
+	if err := reference.Get(ctx, r, res, v.Name, n); err != nil {
+		return "", err
+	}
+	if !reference.Ready(n.Status.ConditionedStatus) || n.Status.SelfLink == "" {
+		return "", reference.NewNotReadyError("referenced Network %s is not available", v.Name)
+	}
+	return n.Status.SelfLink, nil
+}
+
+// computeURIPrefix prefixes the self links of GCP compute resources.
+const computeURIPrefix = "https://www.googleapis.com/compute/v1/"
+
+// RelativeNetworkURI returns the supplied Network self link relative to the
+// compute API, e.g. projects/my-project/global/networks/my-network. Some GCP
+// services only accept networks in this form.
+func RelativeNetworkURI(selfLink string) string {
+	return strings.TrimPrefix(selfLink, computeURIPrefix)
+}
+
+// A GlobalAddressNameReferencer resolves the GCP name of a GlobalAddress in
+// the same namespace as the referencing resource.
+type GlobalAddressNameReferencer struct {
+	corev1.LocalObjectReference `json:",inline"`
+}
+
+// Build returns the name of the referenced GlobalAddress once it is
+// available.
+func (v *GlobalAddressNameReferencer) Build(ctx context.Context, res reference.CanReference, r client.Reader) (string, error) {
+	a := &GlobalAddress{}
+	if err := reference.Get(ctx, r, res, v.Name, a); err != nil {
+		return "", err
+	}
+	if !reference.Ready(a.Status.ConditionedStatus) || a.Status.Name == "" {
+		return "", reference.NewNotReadyError("referenced GlobalAddress %s is not available", v.Name)
+	}
+	return a.Status.Name, nil
+}
+
+// A NetworkURIReferencerForSubnetwork assigns the URL of a referenced Network
+// to a Subnetwork.
+type NetworkURIReferencerForSubnetwork struct {
+	NetworkURIReferencer `json:",inline"`
+}
+
+// Assign the supplied Network URL to the supplied Subnetwork.
+func (v *NetworkURIReferencerForSubnetwork) Assign(res reference.CanReference, value string) error {
+	s, ok := res.(*Subnetwork)
+	if !ok {
+		return errors.New(errNotSubnetwork)
+	}
+	s.Spec.Network = value
+	return nil
+}
+
+// A NetworkURIReferencerForGlobalAddress assigns the URL of a referenced
+// Network to a GlobalAddress.
+type NetworkURIReferencerForGlobalAddress struct {
+	NetworkURIReferencer `json:",inline"`
+}
+
+// Assign the supplied Network URL to the supplied GlobalAddress.
+func (v *NetworkURIReferencerForGlobalAddress) Assign(res reference.CanReference, value string) error {
+	a, ok := res.(*GlobalAddress)
+	if !ok {
+		return errors.New(errNotGlobalAddress)
+	}
+	a.Spec.Network = value
+	return nil
+}
@@ -54,7 +54,31 @@ var (
 	GKEClusterClassGroupVersionKind = SchemeGroupVersion.WithKind(GKEClusterClassKind)
 )
 
+// Network type metadata.
+var (
+	NetworkKind             = reflect.TypeOf(Network{}).Name()
+	NetworkKindAPIVersion   = NetworkKind + "." + SchemeGroupVersion.String()
+	NetworkGroupVersionKind = SchemeGroupVersion.WithKind(NetworkKind)
+)
+
+// Subnetwork type metadata.
+var (
+	SubnetworkKind             = reflect.TypeOf(Subnetwork{}).Name()
+	SubnetworkKindAPIVersion   = SubnetworkKind + "." + SchemeGroupVersion.String()
+	SubnetworkGroupVersionKind = SchemeGroupVersion.WithKind(SubnetworkKind)
+)
+
+// GlobalAddress type metadata.
+var (
+	GlobalAddressKind             = reflect.TypeOf(GlobalAddress{}).Name()
+	GlobalAddressKindAPIVersion   = GlobalAddressKind + "." + SchemeGroupVersion.String()
+	GlobalAddressGroupVersionKind = SchemeGroupVersion.WithKind(GlobalAddressKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&GKECluster{}, &GKEClusterList{})
 	SchemeBuilder.Register(&GKEClusterClass{}, &GKEClusterClassList{})
+	SchemeBuilder.Register(&Network{}, &NetworkList{})
+	SchemeBuilder.Register(&Subnetwork{}, &SubnetworkList{})
+	SchemeBuilder.Register(&GlobalAddress{}, &GlobalAddressList{})
 }
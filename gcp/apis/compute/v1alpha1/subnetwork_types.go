@@ -0,0 +1,211 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/util"
+	"github.com/crossplaneio/crossplane/pkg/externalname"
+	"github.com/crossplaneio/crossplane/pkg/reference"
+)
+
+// A SecondaryRange is an additional IP range of a subnetwork, typically used
+// for the pods and services of a GKE cluster.
+type SecondaryRange struct {
+	// RangeName identifies the range within its subnetwork.
+	RangeName string `json:"rangeName"`
+
+	// IPCIDRRange of the range, e.g. 10.4.0.0/14.
+	IPCIDRRange string `json:"ipCidrRange"`
+}
+
+// SubnetworkParameters define the desired state of a GCP VPC subnetwork.
+type SubnetworkParameters struct {
+	// NameFormat to format the subnetwork name passing it the object UID. If
+	// not provided, defaults to "subnetwork-%s", i.e. the UID prefixed with
+	// subnetwork-.
+	// +optional
+	NameFormat string `json:"nameFormat,omitempty"`
+
+	// Network is the URL of the network to which the subnetwork belongs, e.g.
+	// global/networks/my-network. It cannot be changed after the subnetwork is
+	// created. It is resolved from NetworkRef when that is set.
+	// +optional
+	Network string `json:"network,omitempty"`
+
+	// NetworkRef references a Network in the same namespace, from which
+	// Network is resolved once the Network is available.
+	// +optional
+	NetworkRef *NetworkURIReferencerForSubnetwork `json:"networkRef,omitempty"`
+
+	// Region in which to create the subnetwork, e.g. us-central1. It cannot be
+	// changed after the subnetwork is created.
+	Region string `json:"region"`
+
+	// IPCIDRRange is the primary IP range of the subnetwork, e.g.
+	// 10.0.0.0/20. It may only be expanded after the subnetwork is created.
+	IPCIDRRange string `json:"ipCidrRange"`
+
+	// Description of the subnetwork. It cannot be changed after the
+	// subnetwork is created.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// PrivateIPGoogleAccess allows VMs in the subnetwork without external IP
+	// addresses to reach Google APIs.
+	// +optional
+	PrivateIPGoogleAccess bool `json:"privateIpGoogleAccess,omitempty"`
+
+	// SecondaryIPRanges of the subnetwork.
+	// +optional
+	SecondaryIPRanges []SecondaryRange `json:"secondaryIpRanges,omitempty"`
+}
+
+// SubnetworkSpec defines the desired state of a Subnetwork.
+type SubnetworkSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	SubnetworkParameters         `json:",inline"`
+}
+
+// SubnetworkStatus defines the observed state of a Subnetwork.
+type SubnetworkStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	// Name of the subnetwork in GCP.
+	Name string `json:"name,omitempty"`
+
+	// SelfLink is the URL of the subnetwork.
+	SelfLink string `json:"selfLink,omitempty"`
+
+	// GatewayAddress is the gateway address for default routes to reach
+	// destinations outside the subnetwork.
+	GatewayAddress string `json:"gatewayAddress,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Subnetwork is a managed resource that represents a regional subnetwork of
+// a GCP VPC network.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="SUBNETWORK",type="string",JSONPath=".status.name"
+// +kubebuilder:printcolumn:name="REGION",type="string",JSONPath=".spec.region"
+// +kubebuilder:printcolumn:name="CIDR",type="string",JSONPath=".spec.ipCidrRange"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+type Subnetwork struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SubnetworkSpec   `json:"spec,omitempty"`
+	Status SubnetworkStatus `json:"status,omitempty"`
+}
+
+// SetBindingPhase of this Subnetwork.
+func (s *Subnetwork) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	s.Status.SetBindingPhase(p)
+}
+
+// GetBindingPhase of this Subnetwork.
+func (s *Subnetwork) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return s.Status.GetBindingPhase()
+}
+
+// SetConditions of this Subnetwork.
+func (s *Subnetwork) SetConditions(c ...runtimev1alpha1.Condition) {
+	s.Status.SetConditions(c...)
+}
+
+// GetCondition of this Subnetwork.
+func (s *Subnetwork) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return s.Status.GetCondition(ct)
+}
+
+// SetClaimReference of this Subnetwork.
+func (s *Subnetwork) SetClaimReference(r *corev1.ObjectReference) {
+	s.Spec.ClaimReference = r
+}
+
+// GetClaimReference of this Subnetwork.
+func (s *Subnetwork) GetClaimReference() *corev1.ObjectReference {
+	return s.Spec.ClaimReference
+}
+
+// SetClassReference of this Subnetwork.
+func (s *Subnetwork) SetClassReference(r *corev1.ObjectReference) {
+	s.Spec.ClassReference = r
+}
+
+// GetClassReference of this Subnetwork.
+func (s *Subnetwork) GetClassReference() *corev1.ObjectReference {
+	return s.Spec.ClassReference
+}
+
+// SetWriteConnectionSecretToReference of this Subnetwork.
+func (s *Subnetwork) SetWriteConnectionSecretToReference(r corev1.LocalObjectReference) {
+	s.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetWriteConnectionSecretToReference of this Subnetwork.
+func (s *Subnetwork) GetWriteConnectionSecretToReference() corev1.LocalObjectReference {
+	return s.Spec.WriteConnectionSecretToReference
+}
+
+// GetReclaimPolicy of this Subnetwork.
+func (s *Subnetwork) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return s.Spec.ReclaimPolicy
+}
+
+// SetReclaimPolicy of this Subnetwork.
+func (s *Subnetwork) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
+	s.Spec.ReclaimPolicy = p
+}
+
+// GetProviderReference of this Subnetwork.
+func (s *Subnetwork) GetProviderReference() *corev1.ObjectReference {
+	return s.Spec.ProviderReference
+}
+
+// GetReferencers of this Subnetwork.
+func (s *Subnetwork) GetReferencers() []reference.AttributeReferencer {
+	if s.Spec.NetworkRef == nil {
+		return nil
+	}
+	return []reference.AttributeReferencer{s.Spec.NetworkRef}
+}
+
+// GetResourceName returns the name of this Subnetwork in GCP, which is its
+// external name if it has one, or else based on its NameFormat.
+func (s *Subnetwork) GetResourceName() string {
+	f := s.Spec.NameFormat
+	if f == "" {
+		f = strings.ToLower(SubnetworkKind) + "-%s"
+	}
+	return externalname.Or(s, util.ConditionalStringFormat(f, string(s.GetUID())))
+}
+
+// +kubebuilder:object:root=true
+
+// SubnetworkList contains a list of Subnetwork.
+type SubnetworkList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Subnetwork `json:"items"`
+}
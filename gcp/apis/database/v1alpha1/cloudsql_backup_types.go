@@ -0,0 +1,163 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+)
+
+// CloudSQL backup run states.
+const (
+	// The backup run completed successfully, and may be restored.
+	BackupStateSuccessful = "SUCCESSFUL"
+	// The backup run failed.
+	BackupStateFailed = "FAILED"
+)
+
+// CloudsqlBackupParameters define the desired state of a CloudsqlBackup.
+type CloudsqlBackupParameters struct {
+	// InstanceRef references the CloudsqlInstance to back up. The instance must
+	// be in the same namespace as the backup.
+	InstanceRef corev1.LocalObjectReference `json:"instanceRef"`
+}
+
+// CloudsqlBackupSpec defines the desired state of a CloudsqlBackup.
+type CloudsqlBackupSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	CloudsqlBackupParameters     `json:",inline"`
+}
+
+// CloudsqlBackupStatus defines the observed state of a CloudsqlBackup.
+type CloudsqlBackupStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	// State of the backup run, e.g. RUNNING or SUCCESSFUL.
+	State string `json:"state,omitempty"`
+
+	// Instance is the name of the CloudSQL instance that was backed up.
+	Instance string `json:"instance,omitempty"`
+
+	// BackupRunID identifies the backup run within its instance.
+	BackupRunID int64 `json:"backupRunID,omitempty"`
+
+	// StartTime is the time at which the backup run started.
+	StartTime string `json:"startTime,omitempty"`
+
+	// EndTime is the time at which the backup run finished.
+	EndTime string `json:"endTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A CloudsqlBackup is an on-demand backup run of a CloudsqlInstance.
+// CloudsqlInstances may be restored from successful backups. CloudSQL does not
+// report the size of a backup.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="INSTANCE",type="string",JSONPath=".spec.instanceRef.name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+type CloudsqlBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CloudsqlBackupSpec   `json:"spec,omitempty"`
+	Status CloudsqlBackupStatus `json:"status,omitempty"`
+}
+
+// SetBindingPhase of this CloudsqlBackup.
+func (b *CloudsqlBackup) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	b.Status.SetBindingPhase(p)
+}
+
+// GetBindingPhase of this CloudsqlBackup.
+func (b *CloudsqlBackup) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return b.Status.GetBindingPhase()
+}
+
+// SetConditions of this CloudsqlBackup.
+func (b *CloudsqlBackup) SetConditions(c ...runtimev1alpha1.Condition) {
+	b.Status.SetConditions(c...)
+}
+
+// GetCondition of this CloudsqlBackup.
+func (b *CloudsqlBackup) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return b.Status.GetCondition(ct)
+}
+
+// SetClaimReference of this CloudsqlBackup.
+func (b *CloudsqlBackup) SetClaimReference(r *corev1.ObjectReference) {
+	b.Spec.ClaimReference = r
+}
+
+// GetClaimReference of this CloudsqlBackup.
+func (b *CloudsqlBackup) GetClaimReference() *corev1.ObjectReference {
+	return b.Spec.ClaimReference
+}
+
+// SetClassReference of this CloudsqlBackup.
+func (b *CloudsqlBackup) SetClassReference(r *corev1.ObjectReference) {
+	b.Spec.ClassReference = r
+}
+
+// GetClassReference of this CloudsqlBackup.
+func (b *CloudsqlBackup) GetClassReference() *corev1.ObjectReference {
+	return b.Spec.ClassReference
+}
+
+// SetWriteConnectionSecretToReference of this CloudsqlBackup.
+func (b *CloudsqlBackup) SetWriteConnectionSecretToReference(r corev1.LocalObjectReference) {
+	b.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetWriteConnectionSecretToReference of this CloudsqlBackup.
+func (b *CloudsqlBackup) GetWriteConnectionSecretToReference() corev1.LocalObjectReference {
+	return b.Spec.WriteConnectionSecretToReference
+}
+
+// GetReclaimPolicy of this CloudsqlBackup.
+func (b *CloudsqlBackup) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return b.Spec.ReclaimPolicy
+}
+
+// SetReclaimPolicy of this CloudsqlBackup.
+func (b *CloudsqlBackup) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
+	b.Spec.ReclaimPolicy = p
+}
+
+// GetProviderReference of this CloudsqlBackup.
+func (b *CloudsqlBackup) GetProviderReference() *corev1.ObjectReference {
+	return b.Spec.ProviderReference
+}
+
+// Description returns the description with which the backup run of this
+// CloudsqlBackup is created. Backup run IDs are assigned by CloudSQL, so the
+// description is used to find the run.
+func (b *CloudsqlBackup) Description() string {
+	return "crossplane-" + string(b.GetUID())
+}
+
+// +kubebuilder:object:root=true
+
+// CloudsqlBackupList contains a list of CloudsqlBackup.
+type CloudsqlBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CloudsqlBackup `json:"items"`
+}
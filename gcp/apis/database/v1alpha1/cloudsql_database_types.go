@@ -0,0 +1,204 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+)
+
+// CloudsqlDatabaseParameters define the desired state of a CloudsqlDatabase.
+type CloudsqlDatabaseParameters struct {
+	// InstanceRef references the CloudsqlInstance in which this database will
+	// be created. The instance must be in the same namespace as this database.
+	InstanceRef corev1.LocalObjectReference `json:"instanceRef"`
+
+	// DatabaseName is the name of the database within the instance. Defaults
+	// to the name of the CloudsqlDatabase.
+	DatabaseName string `json:"databaseName,omitempty"`
+
+	// Charset of the database, e.g. utf8. Defaults to the instance's default.
+	Charset string `json:"charset,omitempty"`
+
+	// Collation of the database, e.g. utf8_general_ci. Defaults to the
+	// instance's default.
+	Collation string `json:"collation,omitempty"`
+}
+
+// CloudsqlDatabaseSpec defines the desired state of a CloudsqlDatabase.
+type CloudsqlDatabaseSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	CloudsqlDatabaseParameters   `json:",inline"`
+}
+
+// CloudsqlDatabaseStatus defines the observed state of a CloudsqlDatabase.
+type CloudsqlDatabaseStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	// Instance is the name of the CloudSQL instance in which this database
+	// exists.
+	Instance string `json:"instance,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A CloudsqlDatabase is a logical database within an existing
+// CloudsqlInstance. Many CloudsqlDatabases may share one instance. Its
+// connection secret contains the credentials of a generated user that is
+// granted privileges only on this database, the instance's endpoint, and the
+// name of the database.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.bindingPhase"
+// +kubebuilder:printcolumn:name="INSTANCE",type="string",JSONPath=".spec.instanceRef.name"
+// +kubebuilder:printcolumn:name="CLASS",type="string",JSONPath=".spec.classRef.name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+type CloudsqlDatabase struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CloudsqlDatabaseSpec   `json:"spec,omitempty"`
+	Status CloudsqlDatabaseStatus `json:"status,omitempty"`
+}
+
+// SetBindingPhase of this CloudsqlDatabase.
+func (d *CloudsqlDatabase) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	d.Status.SetBindingPhase(p)
+}
+
+// GetBindingPhase of this CloudsqlDatabase.
+func (d *CloudsqlDatabase) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return d.Status.GetBindingPhase()
+}
+
+// SetConditions of this CloudsqlDatabase.
+func (d *CloudsqlDatabase) SetConditions(c ...runtimev1alpha1.Condition) {
+	d.Status.SetConditions(c...)
+}
+
+// GetCondition of this CloudsqlDatabase.
+func (d *CloudsqlDatabase) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return d.Status.GetCondition(ct)
+}
+
+// SetClaimReference of this CloudsqlDatabase.
+func (d *CloudsqlDatabase) SetClaimReference(r *corev1.ObjectReference) {
+	d.Spec.ClaimReference = r
+}
+
+// GetClaimReference of this CloudsqlDatabase.
+func (d *CloudsqlDatabase) GetClaimReference() *corev1.ObjectReference {
+	return d.Spec.ClaimReference
+}
+
+// SetClassReference of this CloudsqlDatabase.
+func (d *CloudsqlDatabase) SetClassReference(r *corev1.ObjectReference) {
+	d.Spec.ClassReference = r
+}
+
+// GetClassReference of this CloudsqlDatabase.
+func (d *CloudsqlDatabase) GetClassReference() *corev1.ObjectReference {
+	return d.Spec.ClassReference
+}
+
+// GetProviderReference of this CloudsqlDatabase.
+func (d *CloudsqlDatabase) GetProviderReference() *corev1.ObjectReference {
+	return d.Spec.ProviderReference
+}
+
+// SetWriteConnectionSecretToReference of this CloudsqlDatabase.
+func (d *CloudsqlDatabase) SetWriteConnectionSecretToReference(r corev1.LocalObjectReference) {
+	d.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetWriteConnectionSecretToReference of this CloudsqlDatabase.
+func (d *CloudsqlDatabase) GetWriteConnectionSecretToReference() corev1.LocalObjectReference {
+	return d.Spec.WriteConnectionSecretToReference
+}
+
+// GetReclaimPolicy of this CloudsqlDatabase.
+func (d *CloudsqlDatabase) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return d.Spec.ReclaimPolicy
+}
+
+// SetReclaimPolicy of this CloudsqlDatabase.
+func (d *CloudsqlDatabase) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
+	d.Spec.ReclaimPolicy = p
+}
+
+// GetDatabaseName returns the name of this CloudsqlDatabase within its
+// instance, which defaults to its name.
+func (d *CloudsqlDatabase) GetDatabaseName() string {
+	if d.Spec.DatabaseName != "" {
+		return d.Spec.DatabaseName
+	}
+	return d.GetName()
+}
+
+// +kubebuilder:object:root=true
+
+// CloudsqlDatabaseList contains a list of CloudsqlDatabase.
+type CloudsqlDatabaseList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CloudsqlDatabase `json:"items"`
+}
+
+// CloudsqlDatabaseClassSpecTemplate is the Schema for the resource class
+type CloudsqlDatabaseClassSpecTemplate struct {
+	runtimev1alpha1.ResourceClassSpecTemplate `json:",inline"`
+	CloudsqlDatabaseParameters                `json:",inline"`
+}
+
+var _ resource.Class = &CloudsqlDatabaseClass{}
+
+// +kubebuilder:object:root=true
+
+// A CloudsqlDatabaseClass is a resource class for CloudsqlDatabases. Resource
+// claims that use it are each bound to a new database within the one
+// CloudsqlInstance it references, rather than to a new instance.
+// +kubebuilder:printcolumn:name="INSTANCE",type="string",JSONPath=".specTemplate.instanceRef.name"
+// +kubebuilder:printcolumn:name="PROVIDER-REF",type="string",JSONPath=".specTemplate.providerRef.name"
+// +kubebuilder:printcolumn:name="RECLAIM-POLICY",type="string",JSONPath=".specTemplate.reclaimPolicy"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+type CloudsqlDatabaseClass struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	SpecTemplate CloudsqlDatabaseClassSpecTemplate `json:"specTemplate,omitempty"`
+}
+
+// GetReclaimPolicy of this CloudsqlDatabaseClass.
+func (c *CloudsqlDatabaseClass) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return c.SpecTemplate.ReclaimPolicy
+}
+
+// SetReclaimPolicy of this CloudsqlDatabaseClass.
+func (c *CloudsqlDatabaseClass) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
+	c.SpecTemplate.ReclaimPolicy = p
+}
+
+// +kubebuilder:object:root=true
+
+// CloudsqlDatabaseClassList contains a list of CloudsqlDatabaseClass.
+type CloudsqlDatabaseClassList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CloudsqlDatabaseClass `json:"items"`
+}
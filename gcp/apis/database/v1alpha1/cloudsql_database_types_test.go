@@ -0,0 +1,84 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+)
+
+var _ resource.Managed = &CloudsqlDatabase{}
+
+func TestStorageCloudsqlDatabase(t *testing.T) {
+	key := types.NamespacedName{Name: name, Namespace: namespace}
+	created := &CloudsqlDatabase{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: CloudsqlDatabaseSpec{
+			ResourceSpec: runtimev1alpha1.ResourceSpec{
+				ProviderReference: &core.ObjectReference{},
+			},
+			CloudsqlDatabaseParameters: CloudsqlDatabaseParameters{
+				InstanceRef: core.LocalObjectReference{Name: "cool-instance"},
+			},
+		},
+	}
+	g := gomega.NewGomegaWithT(t)
+
+	// Test Create
+	fetched := &CloudsqlDatabase{}
+	g.Expect(c.Create(ctx, created)).NotTo(gomega.HaveOccurred())
+
+	g.Expect(c.Get(ctx, key, fetched)).NotTo(gomega.HaveOccurred())
+	g.Expect(fetched).To(gomega.Equal(created))
+
+	// Test Delete
+	g.Expect(c.Delete(ctx, fetched)).NotTo(gomega.HaveOccurred())
+	g.Expect(c.Get(ctx, key, fetched)).To(gomega.HaveOccurred())
+}
+
+func TestCloudsqlDatabase_GetDatabaseName(t *testing.T) {
+	tests := map[string]struct {
+		d    *CloudsqlDatabase
+		want string
+	}{
+		"Default": {
+			d:    &CloudsqlDatabase{ObjectMeta: metav1.ObjectMeta{Name: "cool-database"}},
+			want: "cool-database",
+		},
+		"Explicit": {
+			d: &CloudsqlDatabase{
+				ObjectMeta: metav1.ObjectMeta{Name: "cool-database"},
+				Spec:       CloudsqlDatabaseSpec{CloudsqlDatabaseParameters: CloudsqlDatabaseParameters{DatabaseName: "app"}},
+			},
+			want: "app",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.d.GetDatabaseName(); got != tt.want {
+				t.Errorf("GetDatabaseName(): want %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
@@ -27,6 +27,9 @@ import (
 
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
 	"github.com/crossplaneio/crossplane-runtime/pkg/util"
+	corev1alpha1 "github.com/crossplaneio/crossplane/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/externalname"
+	"github.com/crossplaneio/crossplane/pkg/reference"
 )
 
 // CloudSQL instance states
@@ -35,6 +38,12 @@ const (
 	StateRunnable = "RUNNABLE"
 )
 
+// CloudSQL IP address types.
+const (
+	IPAddressTypePrimary = "PRIMARY"
+	IPAddressTypePrivate = "PRIVATE"
+)
+
 // CloudSQL version prefixes.
 const (
 	MysqlDBVersionPrefix = "MYSQL"
@@ -53,6 +62,25 @@ const (
 type CloudsqlInstanceParameters struct {
 	AuthorizedNetworks []string `json:"authorizedNetworks,omitempty"`
 
+	// PrivateNetwork is the resource link of a VPC network from which the
+	// instance is accessible via a private IP address, e.g.
+	// projects/my-project/global/networks/default. The network must have a
+	// service networking connection. It is resolved from PrivateNetworkRef
+	// when that is set.
+	// +optional
+	PrivateNetwork string `json:"privateNetwork,omitempty"`
+
+	// PrivateNetworkRef references a Network in the same namespace, from
+	// which PrivateNetwork is resolved once the network is available.
+	// +optional
+	PrivateNetworkRef *NetworkURIReferencerForCloudsqlInstance `json:"privateNetworkRef,omitempty"`
+
+	// IPv4Enabled specifies whether the instance is assigned a public IP
+	// address. CloudSQL assigns one by default. Instances that disable it
+	// must specify a PrivateNetwork.
+	// +optional
+	IPv4Enabled *bool `json:"ipv4Enabled,omitempty"`
+
 	// The database engine (MySQL or PostgreSQL) and its specific version to use, e.g., MYSQL_5_7 or POSTGRES_9_6.
 	DatabaseVersion string `json:"databaseVersion"`
 
@@ -74,6 +102,42 @@ type CloudsqlInstanceParameters struct {
 	// NameFormat to format resource name passing it a object UID
 	// If not provided, defaults to "%s", i.e. UID value
 	NameFormat string `json:"nameFormat,omitempty"`
+
+	// BackupStartTime enables daily automated backups, which start within
+	// four hours of this time. It is specified in UTC, in the form HH:MM.
+	BackupStartTime string `json:"backupStartTime,omitempty"`
+
+	// RestoreFrom seeds a new instance with the data of a backup. The backup
+	// is restored once, when the instance first becomes runnable.
+	RestoreFrom *CloudsqlInstanceRestoreSource `json:"restoreFrom,omitempty"`
+
+	// FinalExport exports the data of the instance to Cloud Storage before
+	// the instance is deleted. Cloud SQL deletes the backups of an instance
+	// along with it, so an export is the only way to keep its data.
+	FinalExport *CloudsqlInstanceExport `json:"finalExport,omitempty"`
+}
+
+// A CloudsqlInstanceRestoreSource specifies the data with which to seed a new
+// CloudsqlInstance.
+type CloudsqlInstanceRestoreSource struct {
+	// BackupRef references a successful CloudsqlBackup in the same namespace
+	// as the instance. The backed up instance must use the same database
+	// version as the new instance.
+	BackupRef corev1.LocalObjectReference `json:"backupRef"`
+}
+
+// A CloudsqlInstanceExport specifies where to export the data of a
+// CloudsqlInstance.
+type CloudsqlInstanceExport struct {
+	// URI of the Cloud Storage object to export to, in the form
+	// gs://bucket/object. Objects whose names end in .gz are compressed. The
+	// service account of the instance must be allowed to write to the bucket.
+	URI string `json:"uri"`
+
+	// Databases to export. All databases are exported when none are named,
+	// which only MySQL instances support; PostgreSQL instances must name
+	// exactly one.
+	Databases []string `json:"databases,omitempty"`
 }
 
 // CloudsqlInstanceSpec defines the desired state of CloudsqlInstance
@@ -88,6 +152,26 @@ type CloudsqlInstanceStatus struct {
 
 	State    string `json:"state,omitempty"`
 	Endpoint string `json:"endpoint,omitempty"`
+
+	// PublicIPAddress of the instance, if it has one.
+	PublicIPAddress string `json:"publicIpAddress,omitempty"`
+
+	// PrivateIPAddress of the instance within its PrivateNetwork, if it
+	// has one.
+	PrivateIPAddress string `json:"privateIpAddress,omitempty"`
+
+	// ServerCACertificate is the PEM encoded certificate of the authority
+	// that signs the instance's server certificate. It is used to verify
+	// SQL connections to the instance.
+	ServerCACertificate string `json:"serverCACertificate,omitempty"`
+
+	// Restored is true once the backup this instance was seeded from has
+	// been restored.
+	Restored bool `json:"restored,omitempty"`
+
+	// FinalExportOperation is the name of the Cloud SQL operation that
+	// exports the data of this instance before it is deleted.
+	FinalExportOperation string `json:"finalExportOperation,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -122,6 +206,11 @@ func (i *CloudsqlInstance) SetConditions(c ...runtimev1alpha1.Condition) {
 	i.Status.SetConditions(c...)
 }
 
+// GetCondition of this CloudsqlInstance.
+func (i *CloudsqlInstance) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return i.Status.GetCondition(ct)
+}
+
 // SetClaimReference of this CloudsqlInstance.
 func (i *CloudsqlInstance) SetClaimReference(r *corev1.ObjectReference) {
 	i.Spec.ClaimReference = r
@@ -167,6 +256,14 @@ func (i *CloudsqlInstance) GetWriteConnectionSecretToReference() corev1.LocalObj
 	return i.Spec.WriteConnectionSecretToReference
 }
 
+// GetReferencers of this CloudsqlInstance.
+func (i *CloudsqlInstance) GetReferencers() []reference.AttributeReferencer {
+	if i.Spec.PrivateNetworkRef == nil {
+		return nil
+	}
+	return []reference.AttributeReferencer{i.Spec.PrivateNetworkRef}
+}
+
 // +kubebuilder:object:root=true
 
 // CloudsqlInstanceList contains a list of CloudsqlInstance
@@ -191,20 +288,37 @@ func (i *CloudsqlInstance) DatabaseInstance(name string) *sqladmin.DatabaseInsta
 		authnets[i] = &sqladmin.AclEntry{Value: v}
 	}
 
-	return &sqladmin.DatabaseInstance{
+	ipc := &sqladmin.IpConfiguration{
+		AuthorizedNetworks: authnets,
+		PrivateNetwork:     i.Spec.PrivateNetwork,
+	}
+	if i.Spec.IPv4Enabled != nil {
+		ipc.Ipv4Enabled = *i.Spec.IPv4Enabled
+		// Ipv4Enabled would otherwise be omitted when false.
+		ipc.ForceSendFields = []string{"Ipv4Enabled"}
+	}
+
+	di := &sqladmin.DatabaseInstance{
 		Name:            name,
 		Region:          i.Spec.Region,
 		DatabaseVersion: i.Spec.DatabaseVersion,
 		Settings: &sqladmin.Settings{
-			Tier:           i.Spec.Tier,
-			DataDiskType:   i.Spec.StorageType,
-			DataDiskSizeGb: i.Spec.StorageGB,
-			IpConfiguration: &sqladmin.IpConfiguration{
-				AuthorizedNetworks: authnets,
-			},
-			UserLabels: i.Spec.Labels,
+			Tier:            i.Spec.Tier,
+			DataDiskType:    i.Spec.StorageType,
+			DataDiskSizeGb:  i.Spec.StorageGB,
+			IpConfiguration: ipc,
+			UserLabels:      i.Spec.Labels,
 		},
 	}
+
+	if i.Spec.BackupStartTime != "" {
+		di.Settings.BackupConfiguration = &sqladmin.BackupConfiguration{
+			Enabled:   true,
+			StartTime: i.Spec.BackupStartTime,
+		}
+	}
+
+	return di
 }
 
 // DatabaseUserName returns default database user name base on database version
@@ -215,7 +329,8 @@ func (i *CloudsqlInstance) DatabaseUserName() string {
 	return MysqlDefaultUser
 }
 
-// GetResourceName based on the NameFormat spec value,
+// GetResourceName returns the instance's external name, if it has one, or else
+// a name based on the NameFormat spec value,
 // If name format is not provided, resource name defaults to {{kind}}-UID
 // If name format provided with '%s' value, resource name will result in formatted string + UID,
 //   NOTE: only single %s substitution is supported
@@ -240,7 +355,7 @@ func (i *CloudsqlInstance) GetResourceName() string {
 		instanceNameFormatString = strings.ToLower(CloudsqlInstanceKind) + "-%s"
 	}
 
-	return util.ConditionalStringFormat(instanceNameFormatString, string(i.GetUID()))
+	return externalname.Or(i, util.ConditionalStringFormat(instanceNameFormatString, string(i.GetUID())))
 }
 
 // IsRunnable returns true if instance is in Runnable state
@@ -261,15 +376,41 @@ func (i *CloudsqlInstance) SetStatus(inst *sqladmin.DatabaseInstance) {
 		i.Status.SetConditions(runtimev1alpha1.Unavailable())
 	}
 
+	i.Status.PublicIPAddress = ""
+	i.Status.PrivateIPAddress = ""
+	for _, ip := range inst.IpAddresses {
+		switch ip.Type {
+		case IPAddressTypePrimary:
+			i.Status.PublicIPAddress = ip.IpAddress
+		case IPAddressTypePrivate:
+			i.Status.PrivateIPAddress = ip.IpAddress
+		}
+	}
+
 	if len(inst.IpAddresses) > 0 {
 		i.Status.Endpoint = inst.IpAddresses[0].IpAddress
 	}
+
+	if inst.ServerCaCert != nil {
+		i.Status.ServerCACertificate = inst.ServerCaCert.Cert
+	}
+
+	// Instances connected to a private network are published by their
+	// private IP address, which is reachable from within that network.
+	if i.Spec.PrivateNetwork != "" && i.Status.PrivateIPAddress != "" {
+		i.Status.Endpoint = i.Status.PrivateIPAddress
+	}
 }
 
 // CloudsqlInstanceClassSpecTemplate is the Schema for the resource class
 type CloudsqlInstanceClassSpecTemplate struct {
 	runtimev1alpha1.ResourceClassSpecTemplate `json:",inline"`
 	CloudsqlInstanceParameters                `json:",inline"`
+
+	// ClaimParameters specifies the portable parameters that resource claims
+	// may request of this class, and the values they may request.
+	// +optional
+	ClaimParameters *corev1alpha1.ClaimParameterPolicy `json:"claimParameters,omitempty"`
 }
 
 var _ resource.Class = &CloudsqlInstanceClass{}
@@ -32,6 +32,7 @@ import (
 
 	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	"github.com/crossplaneio/crossplane/pkg/externalname"
 	localtest "github.com/crossplaneio/crossplane/pkg/test"
 )
 
@@ -169,6 +170,49 @@ func TestCloudsqlInstance_DatabaseInstance(t *testing.T) {
 				},
 			},
 		},
+		"WithPrivateNetwork": {
+			fields: fields{
+				Spec: CloudsqlInstanceSpec{
+					CloudsqlInstanceParameters: CloudsqlInstanceParameters{
+						PrivateNetwork: "projects/foo/global/networks/bar",
+						IPv4Enabled:    new(bool),
+					},
+				},
+			},
+			args: args{name: "foo"},
+			want: &sqladmin.DatabaseInstance{
+				Name: "foo",
+				Settings: &sqladmin.Settings{
+					IpConfiguration: &sqladmin.IpConfiguration{
+						AuthorizedNetworks: []*sqladmin.AclEntry{},
+						PrivateNetwork:     "projects/foo/global/networks/bar",
+						ForceSendFields:    []string{"Ipv4Enabled"},
+					},
+				},
+			},
+		},
+		"WithBackups": {
+			fields: fields{
+				Spec: CloudsqlInstanceSpec{
+					CloudsqlInstanceParameters: CloudsqlInstanceParameters{
+						BackupStartTime: "03:00",
+					},
+				},
+			},
+			args: args{name: "foo"},
+			want: &sqladmin.DatabaseInstance{
+				Name: "foo",
+				Settings: &sqladmin.Settings{
+					BackupConfiguration: &sqladmin.BackupConfiguration{
+						Enabled:   true,
+						StartTime: "03:00",
+					},
+					IpConfiguration: &sqladmin.IpConfiguration{
+						AuthorizedNetworks: []*sqladmin.AclEntry{},
+					},
+				},
+			},
+		},
 	}
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -274,6 +318,22 @@ func TestCloudsqlInstance_GetResourceName(t *testing.T) {
 			},
 			want: "foo-test-uid-bar-%!s(MISSING)",
 		},
+		"ExternalName": {
+			fields: fields{
+				meta: metav1.ObjectMeta{
+					Namespace:   om.Namespace,
+					Name:        om.Name,
+					UID:         om.UID,
+					Annotations: map[string]string{externalname.AnnotationKey: "existing"},
+				},
+				spec: CloudsqlInstanceSpec{
+					CloudsqlInstanceParameters: CloudsqlInstanceParameters{
+						NameFormat: "foo-%s",
+					},
+				},
+			},
+			want: "existing",
+		},
 	}
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -307,10 +367,47 @@ func TestCloudsqlInstance_IsRunnable(t *testing.T) {
 				State: "something-else",
 			},
 		},
+		"PrivateNetwork": {
+			spec: CloudsqlInstanceSpec{
+				CloudsqlInstanceParameters: CloudsqlInstanceParameters{
+					PrivateNetwork: "projects/foo/global/networks/bar",
+				},
+			},
+			status: CloudsqlInstanceStatus{},
+			args: &sqladmin.DatabaseInstance{
+				IpAddresses: []*sqladmin.IpMapping{
+					{
+						IpAddress: "public",
+						Type:      IPAddressTypePrimary,
+					},
+					{
+						IpAddress: "private",
+						Type:      IPAddressTypePrivate,
+					},
+				},
+			},
+			want: CloudsqlInstanceStatus{
+				ResourceStatus: runtimev1alpha1.ResourceStatus{
+					ConditionedStatus: runtimev1alpha1.ConditionedStatus{
+						Conditions: []runtimev1alpha1.Condition{
+							{
+								Type:   runtimev1alpha1.TypeReady,
+								Status: "False",
+								Reason: "Managed resource is not available for use",
+							},
+						},
+					},
+				},
+				Endpoint:         "private",
+				PublicIPAddress:  "public",
+				PrivateIPAddress: "private",
+			},
+		},
 	}
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
 			c := &CloudsqlInstance{
+				Spec:   tt.spec,
 				Status: tt.status,
 			}
 			if got := c.IsRunnable(); got != tt.want {
@@ -322,6 +419,7 @@ func TestCloudsqlInstance_IsRunnable(t *testing.T) {
 
 func TestCloudsqlInstance_SetStatus(t *testing.T) {
 	tests := map[string]struct {
+		spec   CloudsqlInstanceSpec
 		status CloudsqlInstanceStatus
 		args   *sqladmin.DatabaseInstance
 		want   CloudsqlInstanceStatus
@@ -356,7 +454,8 @@ func TestCloudsqlInstance_SetStatus(t *testing.T) {
 						IpAddress: "foo",
 					},
 				},
-				State: StateRunnable,
+				ServerCaCert: &sqladmin.SslCert{Cert: "cool-ca"},
+				State:        StateRunnable,
 			},
 			want: CloudsqlInstanceStatus{
 				ResourceStatus: runtimev1alpha1.ResourceStatus{
@@ -373,8 +472,9 @@ func TestCloudsqlInstance_SetStatus(t *testing.T) {
 						Phase: runtimev1alpha1.BindingPhaseUnbound,
 					},
 				},
-				Endpoint: "foo",
-				State:    StateRunnable,
+				Endpoint:            "foo",
+				ServerCACertificate: "cool-ca",
+				State:               StateRunnable,
 			},
 		},
 	}
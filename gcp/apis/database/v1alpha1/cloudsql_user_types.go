@@ -0,0 +1,171 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+)
+
+// MysqlAnyHost allows a CloudSQL MySQL user to connect from any host.
+const MysqlAnyHost = "%"
+
+// A DatabaseGrant grants a user all privileges on a database.
+type DatabaseGrant struct {
+	// Database on which the user is granted all privileges. The database
+	// must exist in the same CloudsqlInstance as the user, for example as a
+	// CloudsqlDatabase.
+	Database string `json:"database"`
+}
+
+// CloudsqlUserParameters define the desired state of a CloudsqlUser.
+type CloudsqlUserParameters struct {
+	// InstanceRef references the CloudsqlInstance in which this user will be
+	// created. The instance must be in the same namespace as this user, and
+	// must be reachable from Crossplane. The user is created using the master
+	// credentials from the instance's connection secret.
+	InstanceRef corev1.LocalObjectReference `json:"instanceRef"`
+
+	// Username of this user. Defaults to the name of the CloudsqlUser.
+	Username string `json:"username,omitempty"`
+
+	// Host from which this user may connect. Only supported by MySQL
+	// instances, where it defaults to any host.
+	Host string `json:"host,omitempty"`
+
+	// Grants of privileges to this user. Privileges on databases that are
+	// not granted here are revoked.
+	// +optional
+	Grants []DatabaseGrant `json:"grants,omitempty"`
+}
+
+// CloudsqlUserSpec defines the desired state of a CloudsqlUser.
+type CloudsqlUserSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	CloudsqlUserParameters       `json:",inline"`
+}
+
+// CloudsqlUserStatus defines the observed state of a CloudsqlUser.
+type CloudsqlUserStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	// Instance is the name of the CloudSQL instance in which this user exists.
+	Instance string `json:"instance,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A CloudsqlUser is a login with a generated password on an existing
+// CloudsqlInstance. Each application may use its own CloudsqlUser rather than
+// sharing the instance's master credentials. Users are granted privileges only
+// on the databases named by their grants.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.bindingPhase"
+// +kubebuilder:printcolumn:name="INSTANCE",type="string",JSONPath=".spec.instanceRef.name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+type CloudsqlUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CloudsqlUserSpec   `json:"spec,omitempty"`
+	Status CloudsqlUserStatus `json:"status,omitempty"`
+}
+
+// SetBindingPhase of this CloudsqlUser.
+func (u *CloudsqlUser) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	u.Status.SetBindingPhase(p)
+}
+
+// GetBindingPhase of this CloudsqlUser.
+func (u *CloudsqlUser) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return u.Status.GetBindingPhase()
+}
+
+// SetConditions of this CloudsqlUser.
+func (u *CloudsqlUser) SetConditions(c ...runtimev1alpha1.Condition) {
+	u.Status.SetConditions(c...)
+}
+
+// GetCondition of this CloudsqlUser.
+func (u *CloudsqlUser) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return u.Status.GetCondition(ct)
+}
+
+// SetClaimReference of this CloudsqlUser.
+func (u *CloudsqlUser) SetClaimReference(r *corev1.ObjectReference) {
+	u.Spec.ClaimReference = r
+}
+
+// GetClaimReference of this CloudsqlUser.
+func (u *CloudsqlUser) GetClaimReference() *corev1.ObjectReference {
+	return u.Spec.ClaimReference
+}
+
+// SetClassReference of this CloudsqlUser.
+func (u *CloudsqlUser) SetClassReference(r *corev1.ObjectReference) {
+	u.Spec.ClassReference = r
+}
+
+// GetClassReference of this CloudsqlUser.
+func (u *CloudsqlUser) GetClassReference() *corev1.ObjectReference {
+	return u.Spec.ClassReference
+}
+
+// GetProviderReference of this CloudsqlUser.
+func (u *CloudsqlUser) GetProviderReference() *corev1.ObjectReference {
+	return u.Spec.ProviderReference
+}
+
+// GetReclaimPolicy of this CloudsqlUser.
+func (u *CloudsqlUser) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return u.Spec.ReclaimPolicy
+}
+
+// SetReclaimPolicy of this CloudsqlUser.
+func (u *CloudsqlUser) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
+	u.Spec.ReclaimPolicy = p
+}
+
+// SetWriteConnectionSecretToReference of this CloudsqlUser.
+func (u *CloudsqlUser) SetWriteConnectionSecretToReference(r corev1.LocalObjectReference) {
+	u.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetWriteConnectionSecretToReference of this CloudsqlUser.
+func (u *CloudsqlUser) GetWriteConnectionSecretToReference() corev1.LocalObjectReference {
+	return u.Spec.WriteConnectionSecretToReference
+}
+
+// GetUsername returns the username of this CloudsqlUser, which defaults to
+// its name.
+func (u *CloudsqlUser) GetUsername() string {
+	if u.Spec.Username != "" {
+		return u.Spec.Username
+	}
+	return u.GetName()
+}
+
+// +kubebuilder:object:root=true
+
+// CloudsqlUserList contains a list of CloudsqlUser.
+type CloudsqlUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CloudsqlUser `json:"items"`
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/pkg/errors"
+
+	computev1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/compute/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/reference"
+)
+
+const errNotCloudsqlInstance = "managed resource is not a CloudsqlInstance"
+
+// A NetworkURIReferencerForCloudsqlInstance assigns the URL of a referenced
+// Network to the private network of a CloudsqlInstance.
+type NetworkURIReferencerForCloudsqlInstance struct {
+	computev1alpha1.NetworkURIReferencer `json:",inline"`
+}
+
+// Assign the supplied Network URL to the supplied CloudsqlInstance.
+func (v *NetworkURIReferencerForCloudsqlInstance) Assign(res reference.CanReference, value string) error {
+	i, ok := res.(*CloudsqlInstance)
+	if !ok {
+		return errors.New(errNotCloudsqlInstance)
+	}
+	i.Spec.PrivateNetwork = computev1alpha1.RelativeNetworkURI(value)
+	return nil
+}
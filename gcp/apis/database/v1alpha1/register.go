@@ -54,7 +54,39 @@ var (
 	CloudsqlInstanceClassGroupVersionKind = SchemeGroupVersion.WithKind(CloudsqlInstanceClassKind)
 )
 
+// CloudsqlUser type metadata.
+var (
+	CloudsqlUserKind             = reflect.TypeOf(CloudsqlUser{}).Name()
+	CloudsqlUserKindAPIVersion   = CloudsqlUserKind + "." + SchemeGroupVersion.String()
+	CloudsqlUserGroupVersionKind = SchemeGroupVersion.WithKind(CloudsqlUserKind)
+)
+
+// CloudsqlDatabase type metadata.
+var (
+	CloudsqlDatabaseKind             = reflect.TypeOf(CloudsqlDatabase{}).Name()
+	CloudsqlDatabaseKindAPIVersion   = CloudsqlDatabaseKind + "." + SchemeGroupVersion.String()
+	CloudsqlDatabaseGroupVersionKind = SchemeGroupVersion.WithKind(CloudsqlDatabaseKind)
+)
+
+// CloudsqlDatabaseClass type metadata.
+var (
+	CloudsqlDatabaseClassKind             = reflect.TypeOf(CloudsqlDatabaseClass{}).Name()
+	CloudsqlDatabaseClassKindAPIVersion   = CloudsqlDatabaseClassKind + "." + SchemeGroupVersion.String()
+	CloudsqlDatabaseClassGroupVersionKind = SchemeGroupVersion.WithKind(CloudsqlDatabaseClassKind)
+)
+
+// CloudsqlBackup type metadata.
+var (
+	CloudsqlBackupKind             = reflect.TypeOf(CloudsqlBackup{}).Name()
+	CloudsqlBackupKindAPIVersion   = CloudsqlBackupKind + "." + SchemeGroupVersion.String()
+	CloudsqlBackupGroupVersionKind = SchemeGroupVersion.WithKind(CloudsqlBackupKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&CloudsqlInstance{}, &CloudsqlInstanceList{})
 	SchemeBuilder.Register(&CloudsqlInstanceClass{}, &CloudsqlInstanceClassList{})
+	SchemeBuilder.Register(&CloudsqlUser{}, &CloudsqlUserList{})
+	SchemeBuilder.Register(&CloudsqlDatabase{}, &CloudsqlDatabaseList{})
+	SchemeBuilder.Register(&CloudsqlDatabaseClass{}, &CloudsqlDatabaseClassList{})
+	SchemeBuilder.Register(&CloudsqlBackup{}, &CloudsqlBackupList{})
 }
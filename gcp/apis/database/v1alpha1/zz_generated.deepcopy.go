@@ -22,8 +22,301 @@ package v1alpha1
 
 import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
+
+	corev1alpha1 "github.com/crossplaneio/crossplane/apis/core/v1alpha1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudsqlBackup) DeepCopyInto(out *CloudsqlBackup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudsqlBackup.
+func (in *CloudsqlBackup) DeepCopy() *CloudsqlBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudsqlBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudsqlBackup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudsqlBackupList) DeepCopyInto(out *CloudsqlBackupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CloudsqlBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudsqlBackupList.
+func (in *CloudsqlBackupList) DeepCopy() *CloudsqlBackupList {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudsqlBackupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudsqlBackupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudsqlBackupParameters) DeepCopyInto(out *CloudsqlBackupParameters) {
+	*out = *in
+	out.InstanceRef = in.InstanceRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudsqlBackupParameters.
+func (in *CloudsqlBackupParameters) DeepCopy() *CloudsqlBackupParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudsqlBackupParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudsqlBackupSpec) DeepCopyInto(out *CloudsqlBackupSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	out.CloudsqlBackupParameters = in.CloudsqlBackupParameters
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudsqlBackupSpec.
+func (in *CloudsqlBackupSpec) DeepCopy() *CloudsqlBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudsqlBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudsqlBackupStatus) DeepCopyInto(out *CloudsqlBackupStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudsqlBackupStatus.
+func (in *CloudsqlBackupStatus) DeepCopy() *CloudsqlBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudsqlBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudsqlDatabase) DeepCopyInto(out *CloudsqlDatabase) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudsqlDatabase.
+func (in *CloudsqlDatabase) DeepCopy() *CloudsqlDatabase {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudsqlDatabase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudsqlDatabase) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudsqlDatabaseClass) DeepCopyInto(out *CloudsqlDatabaseClass) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.SpecTemplate.DeepCopyInto(&out.SpecTemplate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudsqlDatabaseClass.
+func (in *CloudsqlDatabaseClass) DeepCopy() *CloudsqlDatabaseClass {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudsqlDatabaseClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudsqlDatabaseClass) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudsqlDatabaseClassList) DeepCopyInto(out *CloudsqlDatabaseClassList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CloudsqlDatabaseClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudsqlDatabaseClassList.
+func (in *CloudsqlDatabaseClassList) DeepCopy() *CloudsqlDatabaseClassList {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudsqlDatabaseClassList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudsqlDatabaseClassList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudsqlDatabaseClassSpecTemplate) DeepCopyInto(out *CloudsqlDatabaseClassSpecTemplate) {
+	*out = *in
+	in.ResourceClassSpecTemplate.DeepCopyInto(&out.ResourceClassSpecTemplate)
+	out.CloudsqlDatabaseParameters = in.CloudsqlDatabaseParameters
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudsqlDatabaseClassSpecTemplate.
+func (in *CloudsqlDatabaseClassSpecTemplate) DeepCopy() *CloudsqlDatabaseClassSpecTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudsqlDatabaseClassSpecTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudsqlDatabaseList) DeepCopyInto(out *CloudsqlDatabaseList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CloudsqlDatabase, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudsqlDatabaseList.
+func (in *CloudsqlDatabaseList) DeepCopy() *CloudsqlDatabaseList {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudsqlDatabaseList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudsqlDatabaseList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudsqlDatabaseParameters) DeepCopyInto(out *CloudsqlDatabaseParameters) {
+	*out = *in
+	out.InstanceRef = in.InstanceRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudsqlDatabaseParameters.
+func (in *CloudsqlDatabaseParameters) DeepCopy() *CloudsqlDatabaseParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudsqlDatabaseParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudsqlDatabaseSpec) DeepCopyInto(out *CloudsqlDatabaseSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	out.CloudsqlDatabaseParameters = in.CloudsqlDatabaseParameters
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudsqlDatabaseSpec.
+func (in *CloudsqlDatabaseSpec) DeepCopy() *CloudsqlDatabaseSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudsqlDatabaseSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudsqlDatabaseStatus) DeepCopyInto(out *CloudsqlDatabaseStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudsqlDatabaseStatus.
+func (in *CloudsqlDatabaseStatus) DeepCopy() *CloudsqlDatabaseStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudsqlDatabaseStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudsqlInstance) DeepCopyInto(out *CloudsqlInstance) {
 	*out = *in
@@ -114,6 +407,11 @@ func (in *CloudsqlInstanceClassSpecTemplate) DeepCopyInto(out *CloudsqlInstanceC
 	*out = *in
 	in.ResourceClassSpecTemplate.DeepCopyInto(&out.ResourceClassSpecTemplate)
 	in.CloudsqlInstanceParameters.DeepCopyInto(&out.CloudsqlInstanceParameters)
+	if in.ClaimParameters != nil {
+		in, out := &in.ClaimParameters, &out.ClaimParameters
+		*out = new(corev1alpha1.ClaimParameterPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudsqlInstanceClassSpecTemplate.
@@ -126,6 +424,26 @@ func (in *CloudsqlInstanceClassSpecTemplate) DeepCopy() *CloudsqlInstanceClassSp
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudsqlInstanceExport) DeepCopyInto(out *CloudsqlInstanceExport) {
+	*out = *in
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudsqlInstanceExport.
+func (in *CloudsqlInstanceExport) DeepCopy() *CloudsqlInstanceExport {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudsqlInstanceExport)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudsqlInstanceList) DeepCopyInto(out *CloudsqlInstanceList) {
 	*out = *in
@@ -166,6 +484,16 @@ func (in *CloudsqlInstanceParameters) DeepCopyInto(out *CloudsqlInstanceParamete
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PrivateNetworkRef != nil {
+		in, out := &in.PrivateNetworkRef, &out.PrivateNetworkRef
+		*out = new(NetworkURIReferencerForCloudsqlInstance)
+		**out = **in
+	}
+	if in.IPv4Enabled != nil {
+		in, out := &in.IPv4Enabled, &out.IPv4Enabled
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Labels != nil {
 		in, out := &in.Labels, &out.Labels
 		*out = make(map[string]string, len(*in))
@@ -173,6 +501,16 @@ func (in *CloudsqlInstanceParameters) DeepCopyInto(out *CloudsqlInstanceParamete
 			(*out)[key] = val
 		}
 	}
+	if in.RestoreFrom != nil {
+		in, out := &in.RestoreFrom, &out.RestoreFrom
+		*out = new(CloudsqlInstanceRestoreSource)
+		**out = **in
+	}
+	if in.FinalExport != nil {
+		in, out := &in.FinalExport, &out.FinalExport
+		*out = new(CloudsqlInstanceExport)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudsqlInstanceParameters.
@@ -185,6 +523,22 @@ func (in *CloudsqlInstanceParameters) DeepCopy() *CloudsqlInstanceParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudsqlInstanceRestoreSource) DeepCopyInto(out *CloudsqlInstanceRestoreSource) {
+	*out = *in
+	out.BackupRef = in.BackupRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudsqlInstanceRestoreSource.
+func (in *CloudsqlInstanceRestoreSource) DeepCopy() *CloudsqlInstanceRestoreSource {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudsqlInstanceRestoreSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudsqlInstanceSpec) DeepCopyInto(out *CloudsqlInstanceSpec) {
 	*out = *in
@@ -217,3 +571,147 @@ func (in *CloudsqlInstanceStatus) DeepCopy() *CloudsqlInstanceStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudsqlUser) DeepCopyInto(out *CloudsqlUser) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudsqlUser.
+func (in *CloudsqlUser) DeepCopy() *CloudsqlUser {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudsqlUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudsqlUser) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudsqlUserList) DeepCopyInto(out *CloudsqlUserList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CloudsqlUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudsqlUserList.
+func (in *CloudsqlUserList) DeepCopy() *CloudsqlUserList {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudsqlUserList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudsqlUserList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudsqlUserParameters) DeepCopyInto(out *CloudsqlUserParameters) {
+	*out = *in
+	out.InstanceRef = in.InstanceRef
+	if in.Grants != nil {
+		in, out := &in.Grants, &out.Grants
+		*out = make([]DatabaseGrant, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudsqlUserParameters.
+func (in *CloudsqlUserParameters) DeepCopy() *CloudsqlUserParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudsqlUserParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudsqlUserSpec) DeepCopyInto(out *CloudsqlUserSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.CloudsqlUserParameters.DeepCopyInto(&out.CloudsqlUserParameters)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudsqlUserSpec.
+func (in *CloudsqlUserSpec) DeepCopy() *CloudsqlUserSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudsqlUserSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudsqlUserStatus) DeepCopyInto(out *CloudsqlUserStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudsqlUserStatus.
+func (in *CloudsqlUserStatus) DeepCopy() *CloudsqlUserStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudsqlUserStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseGrant) DeepCopyInto(out *DatabaseGrant) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseGrant.
+func (in *DatabaseGrant) DeepCopy() *DatabaseGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkURIReferencerForCloudsqlInstance) DeepCopyInto(out *NetworkURIReferencerForCloudsqlInstance) {
+	*out = *in
+	out.NetworkURIReferencer = in.NetworkURIReferencer
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkURIReferencerForCloudsqlInstance.
+func (in *NetworkURIReferencerForCloudsqlInstance) DeepCopy() *NetworkURIReferencerForCloudsqlInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkURIReferencerForCloudsqlInstance)
+	in.DeepCopyInto(out)
+	return out
+}
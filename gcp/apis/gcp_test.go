@@ -23,6 +23,7 @@ import (
 	cachev1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/cache/v1alpha1"
 	computev1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/compute/v1alpha1"
 	databasev1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/database/v1alpha1"
+	servicenetworkingv1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/servicenetworking/v1alpha1"
 	storagev1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/storage/v1alpha1"
 	gcpv1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/v1alpha1"
 
@@ -40,6 +41,7 @@ func TestAddToScheme(t *testing.T) {
 		cachev1alpha1.SchemeGroupVersion,
 		computev1alpha1.SchemeGroupVersion,
 		databasev1alpha1.SchemeGroupVersion,
+		servicenetworkingv1alpha1.SchemeGroupVersion,
 		storagev1alpha1.SchemeGroupVersion,
 	}
 	for _, gv := range gvs {
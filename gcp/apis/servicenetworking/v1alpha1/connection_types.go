@@ -0,0 +1,194 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/reference"
+)
+
+// ServiceNetworkingParent is the parent of connections to the services of
+// Google and its partners, such as Cloud SQL and Cloud Memorystore.
+const ServiceNetworkingParent = "services/servicenetworking.googleapis.com"
+
+// ConnectionParameters define the desired state of a private services access
+// connection. A connection peers a VPC network with the network of a service
+// producer, allowing the network to reach the producer's services, for example
+// Cloud SQL instances, using private IP addresses.
+type ConnectionParameters struct {
+	// Parent is the service producer, e.g.
+	// services/servicenetworking.googleapis.com.
+	// +optional
+	Parent string `json:"parent,omitempty"`
+
+	// Network is the URL of the consumer's VPC network to connect, e.g.
+	// projects/my-project/global/networks/my-network. It cannot be changed
+	// after the connection is created. It is resolved from NetworkRef when
+	// that is set.
+	// +optional
+	Network string `json:"network,omitempty"`
+
+	// NetworkRef references a Network in the same namespace, from which
+	// Network is resolved once the Network is available.
+	// +optional
+	NetworkRef *NetworkURIReferencerForConnection `json:"networkRef,omitempty"`
+
+	// ReservedPeeringRanges are the names of the global addresses, with
+	// purpose VPC_PEERING, from which the service producer allocates the
+	// subnetworks of the services that use the connection.
+	// +optional
+	ReservedPeeringRanges []string `json:"reservedPeeringRanges,omitempty"`
+
+	// ReservedPeeringRangeRefs reference GlobalAddresses in the same
+	// namespace, the names of which are added to ReservedPeeringRanges once
+	// they are available.
+	// +optional
+	ReservedPeeringRangeRefs []*GlobalAddressNameReferencerForConnection `json:"reservedPeeringRangeRefs,omitempty"`
+}
+
+// ConnectionSpec defines the desired state of a Connection.
+type ConnectionSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	ConnectionParameters         `json:",inline"`
+}
+
+// ConnectionStatus defines the observed state of a Connection.
+type ConnectionStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	// Peering is the name of the VPC network peering created for the
+	// connection.
+	Peering string `json:"peering,omitempty"`
+
+	// Service is the name of the peering service associated with the
+	// connection.
+	Service string `json:"service,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Connection is a managed resource that represents a GCP private services
+// access connection.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="PARENT",type="string",JSONPath=".spec.parent"
+// +kubebuilder:printcolumn:name="PEERING",type="string",JSONPath=".status.peering"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+type Connection struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ConnectionSpec   `json:"spec,omitempty"`
+	Status ConnectionStatus `json:"status,omitempty"`
+}
+
+// SetBindingPhase of this Connection.
+func (c *Connection) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	c.Status.SetBindingPhase(p)
+}
+
+// GetBindingPhase of this Connection.
+func (c *Connection) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return c.Status.GetBindingPhase()
+}
+
+// SetConditions of this Connection.
+func (c *Connection) SetConditions(c ...runtimev1alpha1.Condition) {
+	c.Status.SetConditions(c...)
+}
+
+// GetCondition of this Connection.
+func (c *Connection) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return c.Status.GetCondition(ct)
+}
+
+// SetClaimReference of this Connection.
+func (c *Connection) SetClaimReference(r *corev1.ObjectReference) {
+	c.Spec.ClaimReference = r
+}
+
+// GetClaimReference of this Connection.
+func (c *Connection) GetClaimReference() *corev1.ObjectReference {
+	return c.Spec.ClaimReference
+}
+
+// SetClassReference of this Connection.
+func (c *Connection) SetClassReference(r *corev1.ObjectReference) {
+	c.Spec.ClassReference = r
+}
+
+// GetClassReference of this Connection.
+func (c *Connection) GetClassReference() *corev1.ObjectReference {
+	return c.Spec.ClassReference
+}
+
+// SetWriteConnectionSecretToReference of this Connection.
+func (c *Connection) SetWriteConnectionSecretToReference(r corev1.LocalObjectReference) {
+	c.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetWriteConnectionSecretToReference of this Connection.
+func (c *Connection) GetWriteConnectionSecretToReference() corev1.LocalObjectReference {
+	return c.Spec.WriteConnectionSecretToReference
+}
+
+// GetReclaimPolicy of this Connection.
+func (c *Connection) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return c.Spec.ReclaimPolicy
+}
+
+// SetReclaimPolicy of this Connection.
+func (c *Connection) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
+	c.Spec.ReclaimPolicy = p
+}
+
+// GetProviderReference of this Connection.
+func (c *Connection) GetProviderReference() *corev1.ObjectReference {
+	return c.Spec.ProviderReference
+}
+
+// GetReferencers of this Connection.
+func (c *Connection) GetReferencers() []reference.AttributeReferencer {
+	refs := make([]reference.AttributeReferencer, 0)
+	if c.Spec.NetworkRef != nil {
+		refs = append(refs, c.Spec.NetworkRef)
+	}
+	for _, r := range c.Spec.ReservedPeeringRangeRefs {
+		refs = append(refs, r)
+	}
+	return refs
+}
+
+// GetParent returns the service producer of this Connection, defaulting to
+// Google's service networking producer.
+func (c *Connection) GetParent() string {
+	if c.Spec.Parent == "" {
+		return ServiceNetworkingParent
+	}
+	return c.Spec.Parent
+}
+
+// +kubebuilder:object:root=true
+
+// ConnectionList contains a list of Connection.
+type ConnectionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Connection `json:"items"`
+}
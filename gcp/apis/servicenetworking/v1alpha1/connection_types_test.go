@@ -0,0 +1,93 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	"github.com/crossplaneio/crossplane/pkg/reference"
+	localtest "github.com/crossplaneio/crossplane/pkg/test"
+)
+
+const (
+	namespace = "default"
+	name      = "test-connection"
+)
+
+var (
+	c   client.Client
+	ctx = context.TODO()
+)
+
+var _ resource.Managed = &Connection{}
+var _ reference.CanReference = &Connection{}
+
+func TestMain(m *testing.M) {
+	t := test.NewEnv(namespace, SchemeBuilder.SchemeBuilder, localtest.CRDs())
+	c = t.StartClient()
+	t.StopAndExit(m.Run())
+}
+
+func TestStorageConnection(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	key := types.NamespacedName{Name: name, Namespace: namespace}
+	created := &Connection{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: ConnectionSpec{
+			ResourceSpec: runtimev1alpha1.ResourceSpec{
+				ProviderReference: &core.ObjectReference{},
+			},
+			ConnectionParameters: ConnectionParameters{
+				Parent:                ServiceNetworkingParent,
+				Network:               "projects/cool-project/global/networks/cool-network",
+				ReservedPeeringRanges: []string{"cool-range"},
+			},
+		},
+	}
+
+	// Test Create
+	fetched := &Connection{}
+	g.Expect(c.Create(ctx, created)).NotTo(HaveOccurred())
+
+	g.Expect(c.Get(ctx, key, fetched)).NotTo(HaveOccurred())
+	g.Expect(fetched).To(Equal(created))
+
+	// Test Delete
+	g.Expect(c.Delete(ctx, fetched)).NotTo(HaveOccurred())
+	g.Expect(c.Get(ctx, key, fetched)).To(HaveOccurred())
+}
+
+func TestConnection_GetParent(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cn := &Connection{}
+	g.Expect(cn.GetParent()).To(Equal(ServiceNetworkingParent))
+
+	cn.Spec.Parent = "services/cool.googleapis.com"
+	g.Expect(cn.GetParent()).To(Equal("services/cool.googleapis.com"))
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/pkg/errors"
+
+	computev1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/compute/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/reference"
+)
+
+const errNotConnection = "managed resource is not a Connection"
+
+// A NetworkURIReferencerForConnection assigns the URL of a referenced Network
+// to a Connection.
+type NetworkURIReferencerForConnection struct {
+	computev1alpha1.NetworkURIReferencer `json:",inline"`
+}
+
+// Assign the supplied Network URL to the supplied Connection.
+func (v *NetworkURIReferencerForConnection) Assign(res reference.CanReference, value string) error {
+	c, ok := res.(*Connection)
+	if !ok {
+		return errors.New(errNotConnection)
+	}
+	c.Spec.Network = value
+	return nil
+}
+
+// A GlobalAddressNameReferencerForConnection adds the name of a referenced
+// GlobalAddress to the reserved peering ranges of a Connection.
+type GlobalAddressNameReferencerForConnection struct {
+	computev1alpha1.GlobalAddressNameReferencer `json:",inline"`
+}
+
+// Assign the supplied GlobalAddress name to the supplied Connection, unless it
+// is already one of its reserved peering ranges.
+func (v *GlobalAddressNameReferencerForConnection) Assign(res reference.CanReference, value string) error {
+	c, ok := res.(*Connection)
+	if !ok {
+		return errors.New(errNotConnection)
+	}
+	for _, r := range c.Spec.ReservedPeeringRanges {
+		if r == value {
+			return nil
+		}
+	}
+	c.Spec.ReservedPeeringRanges = append(c.Spec.ReservedPeeringRanges, value)
+	return nil
+}
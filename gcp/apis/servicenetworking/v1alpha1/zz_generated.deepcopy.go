@@ -0,0 +1,169 @@
+// +build !ignore_autogenerated
+
+// autogenerated by controller-gen object, do not modify manually
+
+package v1alpha1
+
+import (
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Connection) DeepCopyInto(out *Connection) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Connection.
+func (in *Connection) DeepCopy() *Connection {
+	if in == nil {
+		return nil
+	}
+	out := new(Connection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Connection) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionList) DeepCopyInto(out *ConnectionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Connection, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionList.
+func (in *ConnectionList) DeepCopy() *ConnectionList {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConnectionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionParameters) DeepCopyInto(out *ConnectionParameters) {
+	*out = *in
+	if in.NetworkRef != nil {
+		in, out := &in.NetworkRef, &out.NetworkRef
+		*out = new(NetworkURIReferencerForConnection)
+		**out = **in
+	}
+	if in.ReservedPeeringRanges != nil {
+		in, out := &in.ReservedPeeringRanges, &out.ReservedPeeringRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReservedPeeringRangeRefs != nil {
+		in, out := &in.ReservedPeeringRangeRefs, &out.ReservedPeeringRangeRefs
+		*out = make([]*GlobalAddressNameReferencerForConnection, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(GlobalAddressNameReferencerForConnection)
+				**out = **in
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionParameters.
+func (in *ConnectionParameters) DeepCopy() *ConnectionParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionSpec) DeepCopyInto(out *ConnectionSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ConnectionParameters.DeepCopyInto(&out.ConnectionParameters)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionSpec.
+func (in *ConnectionSpec) DeepCopy() *ConnectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionStatus) DeepCopyInto(out *ConnectionStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionStatus.
+func (in *ConnectionStatus) DeepCopy() *ConnectionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalAddressNameReferencerForConnection) DeepCopyInto(out *GlobalAddressNameReferencerForConnection) {
+	*out = *in
+	out.GlobalAddressNameReferencer = in.GlobalAddressNameReferencer
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalAddressNameReferencerForConnection.
+func (in *GlobalAddressNameReferencerForConnection) DeepCopy() *GlobalAddressNameReferencerForConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalAddressNameReferencerForConnection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkURIReferencerForConnection) DeepCopyInto(out *NetworkURIReferencerForConnection) {
+	*out = *in
+	out.NetworkURIReferencer = in.NetworkURIReferencer
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkURIReferencerForConnection.
+func (in *NetworkURIReferencerForConnection) DeepCopy() *NetworkURIReferencerForConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkURIReferencerForConnection)
+	in.DeepCopyInto(out)
+	return out
+}
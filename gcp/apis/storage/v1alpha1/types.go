@@ -27,6 +27,7 @@ import (
 
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
 	"github.com/crossplaneio/crossplane-runtime/pkg/util"
+	"github.com/crossplaneio/crossplane/pkg/externalname"
 )
 
 // ProjectTeam is the project team associated with the entity, if any.
@@ -824,6 +825,11 @@ func (b *Bucket) SetConditions(c ...runtimev1alpha1.Condition) {
 	b.Status.SetConditions(c...)
 }
 
+// GetCondition of this Bucket.
+func (b *Bucket) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return b.Status.GetCondition(ct)
+}
+
 // SetClaimReference of this Bucket.
 func (b *Bucket) SetClaimReference(r *corev1.ObjectReference) {
 	b.Spec.ClaimReference = r
@@ -864,7 +870,8 @@ func (b *Bucket) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
 	b.Spec.ReclaimPolicy = p
 }
 
-// GetBucketName based on the NameFormat spec value,
+// GetBucketName returns the bucket's external name when it is set, and is
+// otherwise based on the NameFormat spec value,
 // If name format is not provided, bucket name defaults to UID
 // If name format provided with '%s' value, bucket name will result in formatted string + UID,
 //   NOTE: only single %s substitution is supported
@@ -879,7 +886,7 @@ func (b *Bucket) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
 //   4. NameFormat = "foo-%s", BucketName = "foo-test-uid"
 //   5. NameFormat = "foo-%s-bar-%s", BucketName = "foo-test-uid-bar-%!s(MISSING)"
 func (b *Bucket) GetBucketName() string {
-	return util.ConditionalStringFormat(b.Spec.NameFormat, string(b.GetUID()))
+	return externalname.Or(b, util.ConditionalStringFormat(b.Spec.NameFormat, string(b.GetUID())))
 }
 
 // +kubebuilder:object:root=true
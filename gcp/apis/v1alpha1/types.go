@@ -19,14 +19,37 @@ package v1alpha1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
 )
 
 // ProviderSpec defines the desired state of Provider
 type ProviderSpec struct {
 	// Important: Run "make generate" to regenerate code after modifying this file
 
-	// GCP ServiceAccount json secret key reference
-	Secret corev1.SecretKeySelector `json:"credentialsSecretRef"`
+	// CredentialsSource from which this provider obtains its base GCP
+	// credentials. Defaults to Secret.
+	// +kubebuilder:validation:Enum=Secret;File;Environment
+	// +optional
+	CredentialsSource CredentialsSource `json:"credentialsSource,omitempty"`
+
+	// GCP ServiceAccount json secret key reference. Required when the
+	// credentials source is Secret.
+	// +optional
+	Secret corev1.SecretKeySelector `json:"credentialsSecretRef,omitempty"`
+
+	// CredentialsFile is the path to a GCP ServiceAccount json key file
+	// mounted into the Crossplane pod. Required when the credentials source is
+	// File.
+	// +optional
+	CredentialsFile string `json:"credentialsFile,omitempty"`
+
+	// ImpersonateServiceAccount is the email address of a GCP ServiceAccount
+	// to impersonate using the base credentials, which must be granted the
+	// Service Account Token Creator role on it. Crossplane uses short-lived
+	// access tokens of the impersonated ServiceAccount to manage GCP resources.
+	// +optional
+	ImpersonateServiceAccount string `json:"impersonateServiceAccount,omitempty"`
 
 	// GCP ProjectID (name)
 	ProjectID string `json:"projectID"`
@@ -35,17 +58,69 @@ type ProviderSpec struct {
 	RequiredPermissions []string `json:"requiredPermissions,omitempty"`
 }
 
+// A CredentialsSource is a source from which a provider obtains credentials.
+type CredentialsSource string
+
+// Credentials sources.
+const (
+	// CredentialsSourceSecret reads a ServiceAccount json key from the secret
+	// referenced by the provider.
+	CredentialsSourceSecret CredentialsSource = "Secret"
+
+	// CredentialsSourceFile reads a ServiceAccount json key from a path in the
+	// Crossplane pod, for example a volume mounted by a secret store.
+	CredentialsSourceFile CredentialsSource = "File"
+
+	// CredentialsSourceEnvironment uses the application default credentials of
+	// the Crossplane pod, for example those of a GKE Workload Identity or of
+	// the node's ServiceAccount.
+	CredentialsSourceEnvironment CredentialsSource = "Environment"
+)
+
+// ProviderStatus represents the observed state of a Provider.
+type ProviderStatus struct {
+	runtimev1alpha1.ConditionedStatus `json:",inline"`
+
+	// Identity as which the Provider's credentials authenticate, as of when
+	// they were last validated.
+	Identity string `json:"identity,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 
 // Provider is the Schema for the instances API
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="PROJECT-ID",type="string",JSONPath=".spec.projectID"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="SECRET-NAME",type="string",JSONPath=".spec.credentialsSecretRef.name",priority=1
+// +kubebuilder:printcolumn:name="IDENTITY",type="string",JSONPath=".status.identity",priority=1
 type Provider struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec ProviderSpec `json:"spec,omitempty"`
+	Spec   ProviderSpec   `json:"spec,omitempty"`
+	Status ProviderStatus `json:"status,omitempty"`
+}
+
+// SetConditions of this Provider.
+func (p *Provider) SetConditions(c ...runtimev1alpha1.Condition) {
+	p.Status.SetConditions(c...)
+}
+
+// GetCondition of this Provider.
+func (p *Provider) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return p.Status.GetCondition(ct)
+}
+
+// SetIdentity of this Provider.
+func (p *Provider) SetIdentity(identity string) {
+	p.Status.Identity = identity
+}
+
+// GetIdentity of this Provider.
+func (p *Provider) GetIdentity() string {
+	return p.Status.Identity
 }
 
 // +kubebuilder:object:root=true
@@ -17,19 +17,50 @@ limitations under the License.
 package aws
 
 import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
 	"github.com/aws/aws-sdk-go-v2/aws/external"
+	"github.com/aws/aws-sdk-go-v2/aws/stscreds"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/go-ini/ini"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane-runtime/pkg/util"
 	"github.com/crossplaneio/crossplane/aws/apis/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/credentials"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
+	"github.com/crossplaneio/crossplane/pkg/metrics"
 )
 
 // DefaultSection for INI files.
 const DefaultSection = ini.DefaultSection
 
+// Keys of an AWS credentials profile that describe how to obtain credentials
+// other than a static access key. These match the keys of the AWS shared
+// config file.
+const (
+	keyCredentialSource = "credential_source"
+	keyRoleARN          = "role_arn"
+	keyExternalID       = "external_id"
+	keyRoleSessionName  = "role_session_name"
+	keyDurationSeconds  = "duration_seconds"
+
+	credentialSourceEnvironment = "Environment"
+)
+
 // A FieldOption determines how common Go types are translated to the types
 // required by the Azure Go SDK.
 type FieldOption int
@@ -74,11 +105,61 @@ func CredentialsIDSecret(data []byte, profile string) (string, string, error) {
 }
 
 // LoadConfig - AWS configuration which can be used to issue requests against AWS API
+// The profile may specify credential_source = Environment to use the ambient
+// credentials of the Crossplane pod rather than a static access key, and may
+// specify a role_arn (with optional external_id, role_session_name and
+// duration_seconds) to assume using those credentials.
 func LoadConfig(data []byte, profile, region string) (*aws.Config, error) {
-	id, secret, err := CredentialsIDSecret(data, profile)
+	cfg, err := ini.InsensitiveLoad(data)
+	if err != nil {
+		return nil, err
+	}
+
+	section, err := cfg.GetSection(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := baseConfig(data, profile, region, section)
 	if err != nil {
 		return nil, err
 	}
+	config.Handlers.Send.PushFront(nameOperation)
+
+	if !section.HasKey(keyRoleARN) {
+		return &config, nil
+	}
+
+	p := stscreds.NewAssumeRoleProvider(sts.New(config), section.Key(keyRoleARN).String())
+	if id := section.Key(keyExternalID).String(); id != "" {
+		p.ExternalID = aws.String(id)
+	}
+	if name := section.Key(keyRoleSessionName).String(); name != "" {
+		p.RoleSessionName = name
+	}
+	if section.HasKey(keyDurationSeconds) {
+		d, err := section.Key(keyDurationSeconds).Int()
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot parse %s", keyDurationSeconds)
+		}
+		p.Duration = time.Duration(d) * time.Second
+	}
+	config.Credentials = p
+
+	return &config, nil
+}
+
+// baseConfig returns AWS configuration using either the static access key or
+// the ambient credentials specified by the supplied profile section.
+func baseConfig(data []byte, profile, region string, section *ini.Section) (aws.Config, error) {
+	if section.Key(keyCredentialSource).String() == credentialSourceEnvironment {
+		return external.LoadDefaultAWSConfig(external.WithRegion(region))
+	}
+
+	id, secret, err := CredentialsIDSecret(data, profile)
+	if err != nil {
+		return aws.Config{}, err
+	}
 
 	creds := aws.Credentials{
 		AccessKeyID:     id,
@@ -90,8 +171,7 @@ func LoadConfig(data []byte, profile, region string) (*aws.Config, error) {
 		Region:      region,
 	}
 
-	config, err := external.LoadDefaultAWSConfig(shared)
-	return &config, err
+	return external.LoadDefaultAWSConfig(shared)
 }
 
 // ValidateConfig - validates AWS configuration by issuing list s3 buckets request
@@ -104,7 +184,13 @@ func ValidateConfig(config *aws.Config) error {
 
 // Config - crate AWS Config based on credentials data using [default] profile
 func Config(client kubernetes.Interface, p *v1alpha1.Provider) (*aws.Config, error) {
-	data, err := util.SecretData(client, p.Namespace, p.Spec.Secret)
+	if err := ready(p); err != nil {
+		return nil, err
+	}
+
+	data, err := credentialsProfile(p, func() ([]byte, error) {
+		return util.SecretData(client, p.Namespace, p.Spec.Secret)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -112,6 +198,245 @@ func Config(client kubernetes.Interface, p *v1alpha1.Provider) (*aws.Config, err
 	return LoadConfig(data, DefaultSection, p.Spec.Region)
 }
 
+// ProviderCredentials returns the credentials of the supplied provider as an
+// AWS credentials file, suitable for use with LoadConfig and the [default]
+// profile. The credentials are obtained from the provider's credentials
+// source, and include the role the provider assumes, if any.
+// Credentials of a provider whose credentials are known to be invalid are not
+// returned.
+func ProviderCredentials(ctx context.Context, c client.Client, p *v1alpha1.Provider) ([]byte, error) {
+	if err := ready(p); err != nil {
+		return nil, err
+	}
+	return credentialsProfile(p, secretData(ctx, c, p))
+}
+
+// PooledConfig returns the AWS config of the supplied provider, for use with
+// the supplied AWS API. Configs are cached by the supplied pool until the
+// provider or its credentials secret change. Requests made using a config are
+// rate limited, and back off when any request to the same API using the same
+// provider is throttled.
+func PooledConfig(ctx context.Context, pl *pool.Pool, c client.Client, p *v1alpha1.Provider, api string) (*aws.Config, error) {
+	if err := ready(p); err != nil {
+		return nil, err
+	}
+
+	v, err := ProviderVersion(ctx, c, p)
+	if err != nil {
+		return nil, err
+	}
+
+	k := pool.Key{Provider: p.GetUID(), API: api, Kind: "config"}
+	o, err := pl.Get(k, v, func() (interface{}, error) {
+		data, err := credentialsProfile(p, secretData(ctx, c, p))
+		if err != nil {
+			return nil, err
+		}
+		cfg, err := LoadConfig(data, DefaultSection, p.Spec.Region)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot load AWS config")
+		}
+		cfg.HTTPClient = pl.HTTPClient(k, Throttled, pool.Method)
+		return cfg, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Callers may modify the config they're returned, so we return a copy.
+	cfg := o.(*aws.Config).Copy()
+	return &cfg, nil
+}
+
+// ProviderVersion returns a version of the supplied provider's configuration
+// that changes when the provider's spec or its credentials secret change.
+func ProviderVersion(ctx context.Context, c client.Client, p *v1alpha1.Provider) (string, error) {
+	secret := ""
+	if p.Spec.CredentialsSource == "" || p.Spec.CredentialsSource == v1alpha1.CredentialsSourceSecret {
+		secret = p.Spec.Secret.Name
+	}
+	return pool.Version(ctx, c, p, secret)
+}
+
+// ErrorCode returns the error code of the supplied AWS API error, if any.
+func ErrorCode(err error) string {
+	if e, ok := errors.Cause(err).(awserr.Error); ok {
+		return e.Code()
+	}
+	return ""
+}
+
+// credentialsErrorCodes are the error codes AWS APIs use to report that
+// credentials were rejected, or are not authorized to perform an operation.
+var credentialsErrorCodes = map[string]bool{
+	"AccessDenied":                true,
+	"AccessDeniedException":       true,
+	"AuthFailure":                 true,
+	"ExpiredToken":                true,
+	"ExpiredTokenException":       true,
+	"InvalidClientTokenId":        true,
+	"SignatureDoesNotMatch":       true,
+	"UnauthorizedOperation":       true,
+	"UnrecognizedClientException": true,
+}
+
+// IsErrorUnauthorized returns true if the supplied error indicates that AWS
+// rejected the credentials used to make a request, or that they are not
+// authorized to make it.
+func IsErrorUnauthorized(err error) bool {
+	return credentialsErrorCodes[ErrorCode(err)]
+}
+
+// nameOperation names the operation the supplied request calls in the context
+// of its HTTP request, so that the HTTP transport may label metrics with it.
+func nameOperation(r *aws.Request) {
+	r.HTTPRequest = r.HTTPRequest.WithContext(metrics.WithOperation(r.HTTPRequest.Context(), r.Operation.Name))
+}
+
+// throttlingErrorCodes are the error codes AWS APIs use to report that
+// requests are being throttled.
+var throttlingErrorCodes = [][]byte{
+	[]byte("Throttling"),
+	[]byte("RequestLimitExceeded"),
+	[]byte("RequestThrottled"),
+	[]byte("TooManyRequestsException"),
+	[]byte("SlowDown"),
+}
+
+// Throttled reports whether the supplied response from an AWS API indicates
+// requests are being throttled.
+func Throttled(rsp *http.Response) bool {
+	switch rsp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadRequest, http.StatusServiceUnavailable:
+	default:
+		return false
+	}
+
+	// Most AWS APIs report throttling using an error code in the body of
+	// the response, so we read it then replace it for the AWS SDK to read.
+	body, err := ioutil.ReadAll(rsp.Body)
+	rsp.Body.Close() // nolint:errcheck
+	rsp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+	for _, code := range throttlingErrorCodes {
+		if bytes.Contains(body, code) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateProvider validates the credentials of the supplied provider by
+// calling AWS STS GetCallerIdentity. It returns the ARN of the identity as
+// which the credentials authenticate.
+func ValidateProvider(ctx context.Context, c client.Client, p *v1alpha1.Provider) (string, error) {
+	data, err := credentialsProfile(p, secretData(ctx, c, p))
+	if err != nil {
+		return "", err
+	}
+
+	config, err := LoadConfig(data, DefaultSection, p.Spec.Region)
+	if err != nil {
+		return "", errors.Wrap(err, "cannot load AWS config")
+	}
+
+	// Validation is infrequent, so it is not rate limited, but its requests
+	// are still recorded as cloud API metrics.
+	config.HTTPClient = &http.Client{Transport: &pool.Transport{Throttled: Throttled, API: sts.EndpointsID, Provider: p.GetUID()}}
+
+	rsp, err := sts.New(*config).GetCallerIdentityRequest(&sts.GetCallerIdentityInput{}).Send()
+	if err != nil {
+		return "", errors.Wrap(err, "cannot get AWS caller identity")
+	}
+
+	return StringValue(rsp.Arn), nil
+}
+
+// ready returns an error if the supplied provider's credentials are known to be
+// invalid. Providers whose credentials have not been validated are assumed to
+// be ready.
+func ready(p *v1alpha1.Provider) error {
+	if c := p.GetCondition(runtimev1alpha1.TypeReady); c.Status == corev1.ConditionFalse {
+		return errors.Errorf("provider %s is not ready: %s", p.GetName(), c.Message)
+	}
+	return nil
+}
+
+// secretData returns a function that reads the AWS credentials file of the
+// supplied provider from its secret.
+func secretData(ctx context.Context, c client.Client, p *v1alpha1.Provider) func() ([]byte, error) {
+	return func() ([]byte, error) {
+		s := &corev1.Secret{}
+		n := types.NamespacedName{Namespace: p.GetNamespace(), Name: p.Spec.Secret.Name}
+		if err := c.Get(ctx, n, s); err != nil {
+			return nil, errors.Wrapf(err, "cannot get provider secret %s", n)
+		}
+		return s.Data[p.Spec.Secret.Key], nil
+	}
+}
+
+// credentialsProfile returns the AWS credentials file of the supplied provider,
+// amended to describe its credentials source and the role it assumes, if any.
+func credentialsProfile(p *v1alpha1.Provider, secret func() ([]byte, error)) ([]byte, error) {
+	if p.Spec.CredentialsSource == v1alpha1.CredentialsSourceEnvironment {
+		if err := credentials.Default.AllowAmbient(p.GetNamespace()); err != nil {
+			return nil, err
+		}
+	}
+	if p.Spec.CredentialsSource != v1alpha1.CredentialsSourceEnvironment && p.Spec.AssumeRole == nil {
+		return credentialsData(p, secret)
+	}
+
+	cfg := ini.Empty()
+	if p.Spec.CredentialsSource != v1alpha1.CredentialsSourceEnvironment {
+		data, err := credentialsData(p, secret)
+		if err != nil {
+			return nil, err
+		}
+		if cfg, err = ini.InsensitiveLoad(data); err != nil {
+			return nil, errors.Wrap(err, "cannot parse AWS credentials file")
+		}
+	}
+
+	section := cfg.Section(DefaultSection)
+	if p.Spec.CredentialsSource == v1alpha1.CredentialsSourceEnvironment {
+		section.Key(keyCredentialSource).SetValue(credentialSourceEnvironment)
+	}
+	if r := p.Spec.AssumeRole; r != nil {
+		section.Key(keyRoleARN).SetValue(r.RoleARN)
+		if r.ExternalID != "" {
+			section.Key(keyExternalID).SetValue(r.ExternalID)
+		}
+		if r.SessionName != "" {
+			section.Key(keyRoleSessionName).SetValue(r.SessionName)
+		}
+		if r.SessionDuration != nil {
+			section.Key(keyDurationSeconds).SetValue(strconv.Itoa(int(r.SessionDuration.Duration.Seconds())))
+		}
+	}
+
+	b := &bytes.Buffer{}
+	_, err := cfg.WriteTo(b)
+	return b.Bytes(), errors.Wrap(err, "cannot write AWS credentials file")
+}
+
+// credentialsData returns the AWS credentials file of the supplied provider,
+// read either from the provider's secret or from its credentials file.
+func credentialsData(p *v1alpha1.Provider, secret func() ([]byte, error)) ([]byte, error) {
+	if p.Spec.CredentialsSource == v1alpha1.CredentialsSourceFile {
+		if err := credentials.Default.AllowFile(p.GetNamespace(), p.Spec.CredentialsFile); err != nil {
+			return nil, err
+		}
+		data, err := ioutil.ReadFile(p.Spec.CredentialsFile)
+		return data, errors.Wrapf(err, "cannot read provider credentials file %s", p.Spec.CredentialsFile)
+	}
+	return secret()
+}
+
 // String converts the supplied string for use with the AWS Go SDK.
 func String(v string, o ...FieldOption) *string {
 	for _, fo := range o {
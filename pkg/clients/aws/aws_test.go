@@ -18,11 +18,24 @@ package aws
 
 import (
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	"github.com/aws/aws-sdk-go-v2/aws/stscreds"
 	"github.com/go-ini/ini"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	. "github.com/onsi/gomega"
+
+	"github.com/crossplaneio/crossplane/aws/apis/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/credentials"
 )
 
 const (
@@ -75,3 +88,124 @@ func TestValidateInvalid(t *testing.T) {
 	err = ValidateConfig(config)
 	g.Expect(err).To(HaveOccurred())
 }
+
+func TestLoadConfigAssumeRole(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	data := []byte(fmt.Sprintf(awsCredentialsFileFormat+"\nrole_arn = %s\nexternal_id = %s\nrole_session_name = %s\nduration_seconds = %d",
+		"default", "testID", "testSecret", "arn:aws:iam::123456789012:role/crossplane", "testExternalID", "crossplane", 3600))
+
+	config, err := LoadConfig(data, ini.DefaultSection, "us-west-2")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	p, ok := config.Credentials.(*stscreds.AssumeRoleProvider)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(p.RoleARN).To(Equal("arn:aws:iam::123456789012:role/crossplane"))
+	g.Expect(*p.ExternalID).To(Equal("testExternalID"))
+	g.Expect(p.RoleSessionName).To(Equal("crossplane"))
+	g.Expect(p.Duration).To(Equal(time.Hour))
+}
+
+func TestCredentialsProfile(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	secret := []byte(fmt.Sprintf(awsCredentialsFileFormat, "default", "testID", "testSecret"))
+	secretFn := func() ([]byte, error) { return secret, nil }
+
+	defer func(p *credentials.Policy) { credentials.Default = p }(credentials.Default)
+	credentials.Default = &credentials.Policy{Namespaces: []string{"crossplane-system"}, FileDirectories: []string{os.TempDir()}}
+	trusted := metav1.ObjectMeta{Namespace: "crossplane-system"}
+
+	// static credentials from a secret are passed through verbatim
+	p := &v1alpha1.Provider{}
+	data, err := credentialsProfile(p, secretFn)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(data).To(Equal(secret))
+
+	// static credentials from a file are read from the file
+	f, err := ioutil.TempFile("", "aws-credentials")
+	g.Expect(err).NotTo(HaveOccurred())
+	defer os.Remove(f.Name())
+	_, err = f.Write(secret)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(f.Close()).To(Succeed())
+
+	p = &v1alpha1.Provider{ObjectMeta: trusted, Spec: v1alpha1.ProviderSpec{
+		CredentialsSource: v1alpha1.CredentialsSourceFile,
+		CredentialsFile:   f.Name(),
+		AssumeRole:        &v1alpha1.AssumeRoleSpec{RoleARN: "arn:aws:iam::123456789012:role/crossplane"},
+	}}
+	data, err = credentialsProfile(p, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	id, key, err := CredentialsIDSecret(data, DefaultSection)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(id).To(Equal("testID"))
+	g.Expect(key).To(Equal("testSecret"))
+
+	// files may only be read by providers in trusted namespaces
+	p.SetNamespace("app-team")
+	_, err = credentialsProfile(p, nil)
+	g.Expect(err).To(HaveOccurred())
+
+	// ambient credentials are used to assume a role
+	p = &v1alpha1.Provider{ObjectMeta: trusted, Spec: v1alpha1.ProviderSpec{
+		CredentialsSource: v1alpha1.CredentialsSourceEnvironment,
+		AssumeRole: &v1alpha1.AssumeRoleSpec{
+			RoleARN:         "arn:aws:iam::123456789012:role/crossplane",
+			ExternalID:      "testExternalID",
+			SessionDuration: &metav1.Duration{Duration: 30 * time.Minute},
+		},
+	}}
+	data, err = credentialsProfile(p, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	cfg, err := ini.InsensitiveLoad(data)
+	g.Expect(err).NotTo(HaveOccurred())
+	section := cfg.Section(DefaultSection)
+	g.Expect(section.Key(keyCredentialSource).String()).To(Equal(credentialSourceEnvironment))
+	g.Expect(section.Key(keyRoleARN).String()).To(Equal("arn:aws:iam::123456789012:role/crossplane"))
+	g.Expect(section.Key(keyExternalID).String()).To(Equal("testExternalID"))
+	g.Expect(section.Key(keyDurationSeconds).String()).To(Equal("1800"))
+	g.Expect(section.HasKey(keyRoleSessionName)).To(BeFalse())
+
+	// ambient credentials may only be used by providers in trusted namespaces
+	p.SetNamespace("app-team")
+	_, err = credentialsProfile(p, nil)
+	g.Expect(err).To(HaveOccurred())
+
+	// a missing credentials file is an error
+	p = &v1alpha1.Provider{ObjectMeta: trusted, Spec: v1alpha1.ProviderSpec{
+		CredentialsSource: v1alpha1.CredentialsSourceFile,
+		CredentialsFile:   filepath.Join(os.TempDir(), "nonexistent"),
+	}}
+	_, err = credentialsProfile(p, nil)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestIsErrorUnauthorized(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(IsErrorUnauthorized(nil)).To(BeFalse())
+	g.Expect(IsErrorUnauthorized(errors.New("boom"))).To(BeFalse())
+	g.Expect(IsErrorUnauthorized(awserr.New("RequestTimeout", "boom", nil))).To(BeFalse())
+	g.Expect(IsErrorUnauthorized(awserr.New("InvalidClientTokenId", "boom", nil))).To(BeTrue())
+	g.Expect(IsErrorUnauthorized(errors.Wrap(awserr.New("AccessDenied", "boom", nil), "cannot get AWS caller identity"))).To(BeTrue())
+}
+
+func TestThrottled(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	rsp := func(status int, body string) *http.Response {
+		return &http.Response{StatusCode: status, Body: ioutil.NopCloser(strings.NewReader(body))}
+	}
+
+	g.Expect(Throttled(rsp(http.StatusTooManyRequests, ""))).To(BeTrue())
+	g.Expect(Throttled(rsp(http.StatusOK, "Throttling"))).To(BeFalse())
+	g.Expect(Throttled(rsp(http.StatusBadRequest, "<Code>InvalidParameterValue</Code>"))).To(BeFalse())
+
+	// throttling error codes are detected, and the body can still be read
+	throttled := rsp(http.StatusBadRequest, "<Error><Code>Throttling</Code></Error>")
+	g.Expect(Throttled(throttled)).To(BeTrue())
+	body, err := ioutil.ReadAll(throttled.Body)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(body)).To(Equal("<Error><Code>Throttling</Code></Error>"))
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sim provides a simulated CloudFormation API.
+package sim
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	cf "github.com/aws/aws-sdk-go-v2/service/cloudformation"
+
+	"github.com/crossplaneio/crossplane/pkg/clients/aws/cloudformation"
+	"github.com/crossplaneio/crossplane/pkg/clients/sim"
+)
+
+// Stack states.
+const (
+	stateCreateInProgress = string(cf.StackStatusCreateInProgress)
+	stateCreateComplete   = string(cf.StackStatusCreateComplete)
+	stateDeleteInProgress = string(cf.StackStatusDeleteInProgress)
+	stateDeleteComplete   = string(cf.StackStatusDeleteComplete)
+)
+
+// outputNodeInstanceRole is the output of the EKS worker node stack template
+// that the EKS client reads.
+const outputNodeInstanceRole = "NodeInstanceRole"
+
+type stack struct {
+	name       string
+	lifecycle  *sim.Lifecycle
+	parameters map[string]string
+}
+
+// A Client is a simulated CloudFormation API. Stacks are created in the
+// CREATE_IN_PROGRESS state and complete after they have been observed Polls
+// times. Deleted stacks pass through DELETE_IN_PROGRESS and, as with the real
+// API, may still be described by their ID once deletion is complete. Errors
+// may be injected into any method, keyed by its name.
+type Client struct {
+	sim.Failures
+
+	// Polls is the number of times a stack in a transitional state must be
+	// observed before it settles.
+	Polls int
+
+	mu     sync.Mutex
+	stacks map[string]*stack
+	ids    int
+}
+
+var _ cloudformation.Client = &Client{}
+
+// NewClient returns a simulated CloudFormation API with no stacks.
+func NewClient() *Client {
+	return &Client{Polls: sim.DefaultPolls, stacks: map[string]*stack{}}
+}
+
+// CreateStack creates a stack with the supplied name and parameters. The
+// template is not evaluated; a completed stack has a NodeInstanceRole output
+// like that of the EKS worker node template.
+func (c *Client) CreateStack(stackName *string, _ *string, parameters map[string]string) (*string, error) {
+	if err := c.Call("CreateStack"); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	name := aws.StringValue(stackName)
+	for _, s := range c.stacks {
+		if s.name == name && s.lifecycle.State() != stateDeleteComplete {
+			return nil, awserr.New(cf.ErrCodeAlreadyExistsException, fmt.Sprintf("Stack [%s] already exists", name), nil)
+		}
+	}
+
+	c.ids++
+	id := fmt.Sprintf("arn:aws:cloudformation:sim-region-1:000000000000:stack/%s/%d", name, c.ids)
+	params := make(map[string]string, len(parameters))
+	for k, v := range parameters {
+		params[k] = v
+	}
+	c.stacks[id] = &stack{
+		name:       name,
+		lifecycle:  sim.NewLifecycle(stateCreateInProgress).Then(stateCreateComplete, c.Polls),
+		parameters: params,
+	}
+	return aws.String(id), nil
+}
+
+// GetStack returns the stack with the supplied ID.
+func (c *Client) GetStack(stackID *string) (*cf.Stack, error) {
+	if err := c.Call("GetStack"); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := aws.StringValue(stackID)
+	s, ok := c.stacks[id]
+	if !ok {
+		return nil, stackNotFound(id)
+	}
+	s.lifecycle.Observe()
+	return view(id, s), nil
+}
+
+// DeleteStack starts deleting the stack with the supplied ID. Deleting a stack
+// that is already deleted succeeds.
+func (c *Client) DeleteStack(stackID *string) error {
+	if err := c.Call("DeleteStack"); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := aws.StringValue(stackID)
+	s, ok := c.stacks[id]
+	if !ok {
+		return stackNotFound(id)
+	}
+	switch s.lifecycle.State() {
+	case stateDeleteInProgress, stateDeleteComplete:
+		return nil
+	}
+	s.lifecycle.Set(stateDeleteInProgress).Then(stateDeleteComplete, c.Polls)
+	return nil
+}
+
+// Settle completes all pending stack transitions immediately.
+func (c *Client) Settle() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, s := range c.stacks {
+		s.lifecycle.Settle()
+	}
+}
+
+// Stacks returns the names of all stacks that have not been deleted, in
+// order.
+func (c *Client) Stacks() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	names := make([]string, 0, len(c.stacks))
+	for _, s := range c.stacks {
+		if s.lifecycle.State() != stateDeleteComplete {
+			names = append(names, s.name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func view(id string, s *stack) *cf.Stack {
+	out := &cf.Stack{
+		StackId:     aws.String(id),
+		StackName:   aws.String(s.name),
+		StackStatus: cf.StackStatus(s.lifecycle.State()),
+	}
+	keys := make([]string, 0, len(s.parameters))
+	for k := range s.parameters {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		out.Parameters = append(out.Parameters, cf.Parameter{ParameterKey: aws.String(k), ParameterValue: aws.String(s.parameters[k])})
+	}
+	if s.lifecycle.State() == stateCreateComplete {
+		out.Outputs = []cf.Output{{
+			OutputKey:   aws.String(outputNodeInstanceRole),
+			OutputValue: aws.String(fmt.Sprintf("arn:aws:iam::000000000000:role/%s-%s", s.name, outputNodeInstanceRole)),
+		}}
+	}
+	return out
+}
+
+// stackNotFound returns the error the CloudFormation client recognises as a
+// missing stack.
+func stackNotFound(id string) error {
+	return awserr.New(cf.ErrCodeStackInstanceNotFoundException, fmt.Sprintf("Stack with id %s does not exist", id), nil)
+}
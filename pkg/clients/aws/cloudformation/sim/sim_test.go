@@ -0,0 +1,80 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sim
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cf "github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplaneio/crossplane/pkg/clients/aws/cloudformation"
+)
+
+func TestStackLifecycle(t *testing.T) {
+	c := NewClient()
+
+	id, err := c.CreateStack(aws.String("cool-stack"), aws.String("template"), map[string]string{"ClusterName": "cool-cluster"})
+	if err != nil {
+		t.Fatalf("c.CreateStack(...): %s", err)
+	}
+	if _, err := c.CreateStack(aws.String("cool-stack"), aws.String("template"), nil); err == nil {
+		t.Errorf("c.CreateStack(...): want error creating a stack that exists, got nil")
+	}
+
+	want := []cf.StackStatus{cf.StackStatusCreateInProgress, cf.StackStatusCreateComplete}
+	got := []cf.StackStatus{}
+	for i := 0; i < c.Polls; i++ {
+		s, err := c.GetStack(id)
+		if err != nil {
+			t.Fatalf("c.GetStack(...): %s", err)
+		}
+		got = append(got, s.StackStatus)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("c.GetStack(...): -want states, +got states:\n%s", diff)
+	}
+
+	s, err := c.GetStack(id)
+	if err != nil {
+		t.Fatalf("c.GetStack(...): %s", err)
+	}
+	if len(s.Outputs) != 1 || aws.StringValue(s.Outputs[0].OutputKey) != outputNodeInstanceRole {
+		t.Errorf("c.GetStack(...): want %s output, got %v", outputNodeInstanceRole, s.Outputs)
+	}
+
+	if err := c.DeleteStack(id); err != nil {
+		t.Fatalf("c.DeleteStack(...): %s", err)
+	}
+	c.Settle()
+	s, err = c.GetStack(id)
+	if err != nil {
+		t.Fatalf("c.GetStack(...): deleted stack: %s", err)
+	}
+	if diff := cmp.Diff(cf.StackStatusDeleteComplete, s.StackStatus); diff != "" {
+		t.Errorf("c.GetStack(...): deleted stack: -want state, +got state:\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{}, c.Stacks()); diff != "" {
+		t.Errorf("c.Stacks(): -want, +got:\n%s", diff)
+	}
+
+	_, err = c.GetStack(aws.String("nonexistent"))
+	if !cloudformation.IsErrorNotFound(err) {
+		t.Errorf("c.GetStack(...): want not found error, got %v", err)
+	}
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/ec2iface"
+	"github.com/pkg/errors"
+
+	"github.com/crossplaneio/crossplane/aws/apis/network/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/aws"
+)
+
+// API is the name of the AWS API used by EC2 clients.
+const API = ec2.EndpointsID
+
+// EC2 error codes. The EC2 API does not model its errors, so these codes are
+// not exported by the AWS SDK.
+const (
+	errCodeVPCNotFound           = "InvalidVpcID.NotFound"
+	errCodeSubnetNotFound        = "InvalidSubnetID.NotFound"
+	errCodeSecurityGroupNotFound = "InvalidGroup.NotFound"
+)
+
+// A Client handles CRUD operations for EC2 networking resources. This interface
+// is compatible with the upstream AWS EC2 client.
+type Client ec2iface.EC2API
+
+// NewClient returns a new EC2 client. Credentials must be passed as JSON
+// encoded data. Requests are made using the supplied HTTP client, if any.
+func NewClient(credentials []byte, region string, hc *http.Client) (Client, error) {
+	cfg, err := aws.LoadConfig(credentials, aws.DefaultSection, region)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create new AWS configuration")
+	}
+	if hc != nil {
+		cfg.HTTPClient = hc
+	}
+	return ec2.New(*cfg), nil
+}
+
+// NewCreateVpcInput returns VPC creation input suitable for use with the AWS
+// API.
+func NewCreateVpcInput(v *v1alpha1.VPC) *ec2.CreateVpcInput {
+	return &ec2.CreateVpcInput{
+		CidrBlock:       aws.String(v.Spec.CIDRBlock, aws.FieldRequired),
+		InstanceTenancy: ec2.Tenancy(v.Spec.InstanceTenancy),
+	}
+}
+
+// NewCreateSubnetInput returns subnet creation input suitable for use with the
+// AWS API.
+func NewCreateSubnetInput(s *v1alpha1.Subnet) *ec2.CreateSubnetInput {
+	return &ec2.CreateSubnetInput{
+		VpcId:            aws.String(s.Spec.VPCID, aws.FieldRequired),
+		CidrBlock:        aws.String(s.Spec.CIDRBlock, aws.FieldRequired),
+		AvailabilityZone: aws.String(s.Spec.AvailabilityZone),
+	}
+}
+
+// NewCreateSecurityGroupInput returns security group creation input suitable
+// for use with the AWS API.
+func NewCreateSecurityGroupInput(g *v1alpha1.SecurityGroup) *ec2.CreateSecurityGroupInput {
+	return &ec2.CreateSecurityGroupInput{
+		VpcId:       aws.String(g.Spec.VPCID, aws.FieldRequired),
+		GroupName:   aws.String(g.GetGroupName(), aws.FieldRequired),
+		Description: aws.String(g.Spec.Description, aws.FieldRequired),
+	}
+}
+
+// BoolNeedsUpdate returns true if the supplied desired value is set and differs
+// from the supplied observed AWS attribute value. Unset desired values are not
+// managed.
+func BoolNeedsUpdate(desired *bool, observed *ec2.AttributeBooleanValue) bool {
+	if desired == nil {
+		return false
+	}
+	if observed == nil {
+		return true
+	}
+	return *desired != aws.BoolValue(observed.Value)
+}
+
+// A rule is an IPPermission that allows traffic to or from a single IPv4 range.
+type rule struct {
+	protocol string
+	from     int64
+	to       int64
+	hasPorts bool
+	cidr     string
+}
+
+func (r rule) permission() ec2.IpPermission {
+	p := ec2.IpPermission{
+		IpProtocol: aws.String(r.protocol, aws.FieldRequired),
+		IpRanges:   []ec2.IpRange{{CidrIp: aws.String(r.cidr, aws.FieldRequired)}},
+	}
+	if r.hasPorts {
+		p.FromPort = &r.from
+		p.ToPort = &r.to
+	}
+	return p
+}
+
+func newRule(protocol string, from, to *int64, cidr string) rule {
+	r := rule{protocol: protocol, cidr: cidr}
+
+	// Rules that allow all protocols allow all ports, so AWS does not report
+	// their port range.
+	if protocol == v1alpha1.IPProtocolAll || from == nil || to == nil {
+		return r
+	}
+	r.from, r.to, r.hasPorts = *from, *to, true
+	return r
+}
+
+func desiredRules(in []v1alpha1.IPPermission) map[rule]bool {
+	rules := map[rule]bool{}
+	for _, p := range in {
+		for _, cidr := range p.CIDRBlocks {
+			rules[newRule(p.IPProtocol, p.FromPort, p.ToPort, cidr)] = true
+		}
+	}
+	return rules
+}
+
+// observedRules returns the IPv4 range rules of the supplied permissions.
+// Permissions may also allow traffic from IPv6 ranges, prefix lists, and other
+// security groups; these are not managed.
+func observedRules(in []ec2.IpPermission) map[rule]bool {
+	rules := map[rule]bool{}
+	for _, p := range in {
+		for _, r := range p.IpRanges {
+			rules[newRule(aws.StringValue(p.IpProtocol), p.FromPort, p.ToPort, aws.StringValue(r.CidrIp))] = true
+		}
+	}
+	return rules
+}
+
+// subtract returns the rules of a that are not in b, in a stable order.
+func subtract(a, b map[rule]bool) []ec2.IpPermission {
+	rules := make([]rule, 0, len(a))
+	for r := range a {
+		if !b[r] {
+			rules = append(rules, r)
+		}
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].protocol != rules[j].protocol {
+			return rules[i].protocol < rules[j].protocol
+		}
+		if rules[i].from != rules[j].from {
+			return rules[i].from < rules[j].from
+		}
+		if rules[i].to != rules[j].to {
+			return rules[i].to < rules[j].to
+		}
+		return rules[i].cidr < rules[j].cidr
+	})
+
+	if len(rules) == 0 {
+		return nil
+	}
+	p := make([]ec2.IpPermission, len(rules))
+	for i, r := range rules {
+		p[i] = r.permission()
+	}
+	return p
+}
+
+// DiffPermissions returns the permissions that must be authorized and revoked
+// to make the supplied observed permissions match the desired permissions.
+// Each returned permission allows a single IPv4 range.
+func DiffPermissions(desired []v1alpha1.IPPermission, observed []ec2.IpPermission) (authorize, revoke []ec2.IpPermission) {
+	d, o := desiredRules(desired), observedRules(observed)
+	return subtract(d, o), subtract(o, d)
+}
+
+// IsVPCNotFound returns true if the supplied error indicates a VPC was not
+// found.
+func IsVPCNotFound(err error) bool {
+	return isErrorCodeEqual(errCodeVPCNotFound, err)
+}
+
+// IsSubnetNotFound returns true if the supplied error indicates a subnet was
+// not found.
+func IsSubnetNotFound(err error) bool {
+	return isErrorCodeEqual(errCodeSubnetNotFound, err)
+}
+
+// IsSecurityGroupNotFound returns true if the supplied error indicates a
+// security group was not found.
+func IsSecurityGroupNotFound(err error) bool {
+	return isErrorCodeEqual(errCodeSecurityGroupNotFound, err)
+}
+
+func isErrorCodeEqual(errorCode string, err error) bool {
+	ce, ok := err.(interface {
+		Code() string
+	})
+	if !ok {
+		return false
+	}
+
+	return ce.Code() == errorCode
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplaneio/crossplane/aws/apis/network/v1alpha1"
+)
+
+func TestBoolNeedsUpdate(t *testing.T) {
+	cases := []struct {
+		name     string
+		desired  *bool
+		observed *ec2.AttributeBooleanValue
+		want     bool
+	}{
+		{
+			name:     "Unmanaged",
+			observed: &ec2.AttributeBooleanValue{Value: aws.Bool(true)},
+			want:     false,
+		},
+		{
+			name:     "Differs",
+			desired:  aws.Bool(true),
+			observed: &ec2.AttributeBooleanValue{Value: aws.Bool(false)},
+			want:     true,
+		},
+		{
+			name:     "Matches",
+			desired:  aws.Bool(false),
+			observed: &ec2.AttributeBooleanValue{Value: aws.Bool(false)},
+			want:     false,
+		},
+		{
+			name:    "NotObserved",
+			desired: aws.Bool(false),
+			want:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := BoolNeedsUpdate(tc.desired, tc.observed)
+			if got != tc.want {
+				t.Errorf("BoolNeedsUpdate(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestDiffPermissions(t *testing.T) {
+	https := ec2.IpPermission{
+		IpProtocol: aws.String("tcp"),
+		FromPort:   aws.Int64(443),
+		ToPort:     aws.Int64(443),
+		IpRanges:   []ec2.IpRange{{CidrIp: aws.String("10.0.0.0/16")}},
+	}
+	all := ec2.IpPermission{
+		IpProtocol: aws.String(v1alpha1.IPProtocolAll),
+		IpRanges:   []ec2.IpRange{{CidrIp: aws.String("0.0.0.0/0")}},
+	}
+
+	cases := []struct {
+		name          string
+		desired       []v1alpha1.IPPermission
+		observed      []ec2.IpPermission
+		wantAuthorize []ec2.IpPermission
+		wantRevoke    []ec2.IpPermission
+	}{
+		{
+			name: "NoChanges",
+			desired: []v1alpha1.IPPermission{{
+				IPProtocol: "tcp",
+				FromPort:   aws.Int64(443),
+				ToPort:     aws.Int64(443),
+				CIDRBlocks: []string{"10.0.0.0/16"},
+			}},
+			observed: []ec2.IpPermission{https},
+		},
+		{
+			name: "AuthorizeAndRevoke",
+			desired: []v1alpha1.IPPermission{{
+				IPProtocol: "tcp",
+				FromPort:   aws.Int64(443),
+				ToPort:     aws.Int64(443),
+				CIDRBlocks: []string{"10.0.0.0/16"},
+			}},
+			observed:      []ec2.IpPermission{all},
+			wantAuthorize: []ec2.IpPermission{https},
+			wantRevoke:    []ec2.IpPermission{all},
+		},
+		{
+			name: "AllProtocolsIgnoresPorts",
+			desired: []v1alpha1.IPPermission{{
+				IPProtocol: v1alpha1.IPProtocolAll,
+				FromPort:   aws.Int64(0),
+				ToPort:     aws.Int64(65535),
+				CIDRBlocks: []string{"0.0.0.0/0"},
+			}},
+			observed: []ec2.IpPermission{all},
+		},
+		{
+			name: "UnmanagedSourcesIgnored",
+			observed: []ec2.IpPermission{{
+				IpProtocol:       aws.String("tcp"),
+				FromPort:         aws.Int64(22),
+				ToPort:           aws.Int64(22),
+				UserIdGroupPairs: []ec2.UserIdGroupPair{{GroupId: aws.String("sg-cool")}},
+			}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			authorize, revoke := DiffPermissions(tc.desired, tc.observed)
+			if diff := cmp.Diff(tc.wantAuthorize, authorize); diff != "" {
+				t.Errorf("DiffPermissions(...): -want authorize, +got authorize:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantRevoke, revoke); diff != "" {
+				t.Errorf("DiffPermissions(...): -want revoke, +got revoke:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/ec2iface"
+)
+
+var _ ec2iface.EC2API = &MockClient{}
+
+// MockClient is a fake implementation of ec2.Client.
+type MockClient struct {
+	ec2iface.EC2API
+
+	MockCreateVpcRequest                     func(*ec2.CreateVpcInput) ec2.CreateVpcRequest
+	MockDescribeVpcsRequest                  func(*ec2.DescribeVpcsInput) ec2.DescribeVpcsRequest
+	MockDescribeVpcAttributeRequest          func(*ec2.DescribeVpcAttributeInput) ec2.DescribeVpcAttributeRequest
+	MockModifyVpcAttributeRequest            func(*ec2.ModifyVpcAttributeInput) ec2.ModifyVpcAttributeRequest
+	MockDeleteVpcRequest                     func(*ec2.DeleteVpcInput) ec2.DeleteVpcRequest
+	MockCreateSubnetRequest                  func(*ec2.CreateSubnetInput) ec2.CreateSubnetRequest
+	MockDescribeSubnetsRequest               func(*ec2.DescribeSubnetsInput) ec2.DescribeSubnetsRequest
+	MockModifySubnetAttributeRequest         func(*ec2.ModifySubnetAttributeInput) ec2.ModifySubnetAttributeRequest
+	MockDeleteSubnetRequest                  func(*ec2.DeleteSubnetInput) ec2.DeleteSubnetRequest
+	MockCreateSecurityGroupRequest           func(*ec2.CreateSecurityGroupInput) ec2.CreateSecurityGroupRequest
+	MockDescribeSecurityGroupsRequest        func(*ec2.DescribeSecurityGroupsInput) ec2.DescribeSecurityGroupsRequest
+	MockAuthorizeSecurityGroupIngressRequest func(*ec2.AuthorizeSecurityGroupIngressInput) ec2.AuthorizeSecurityGroupIngressRequest
+	MockRevokeSecurityGroupIngressRequest    func(*ec2.RevokeSecurityGroupIngressInput) ec2.RevokeSecurityGroupIngressRequest
+	MockAuthorizeSecurityGroupEgressRequest  func(*ec2.AuthorizeSecurityGroupEgressInput) ec2.AuthorizeSecurityGroupEgressRequest
+	MockRevokeSecurityGroupEgressRequest     func(*ec2.RevokeSecurityGroupEgressInput) ec2.RevokeSecurityGroupEgressRequest
+	MockDeleteSecurityGroupRequest           func(*ec2.DeleteSecurityGroupInput) ec2.DeleteSecurityGroupRequest
+}
+
+// CreateVpcRequest calls the underlying
+// MockCreateVpcRequest method.
+func (c *MockClient) CreateVpcRequest(i *ec2.CreateVpcInput) ec2.CreateVpcRequest {
+	return c.MockCreateVpcRequest(i)
+}
+
+// DescribeVpcsRequest calls the underlying
+// MockDescribeVpcsRequest method.
+func (c *MockClient) DescribeVpcsRequest(i *ec2.DescribeVpcsInput) ec2.DescribeVpcsRequest {
+	return c.MockDescribeVpcsRequest(i)
+}
+
+// DescribeVpcAttributeRequest calls the underlying
+// MockDescribeVpcAttributeRequest method.
+func (c *MockClient) DescribeVpcAttributeRequest(i *ec2.DescribeVpcAttributeInput) ec2.DescribeVpcAttributeRequest {
+	return c.MockDescribeVpcAttributeRequest(i)
+}
+
+// ModifyVpcAttributeRequest calls the underlying
+// MockModifyVpcAttributeRequest method.
+func (c *MockClient) ModifyVpcAttributeRequest(i *ec2.ModifyVpcAttributeInput) ec2.ModifyVpcAttributeRequest {
+	return c.MockModifyVpcAttributeRequest(i)
+}
+
+// DeleteVpcRequest calls the underlying
+// MockDeleteVpcRequest method.
+func (c *MockClient) DeleteVpcRequest(i *ec2.DeleteVpcInput) ec2.DeleteVpcRequest {
+	return c.MockDeleteVpcRequest(i)
+}
+
+// CreateSubnetRequest calls the underlying
+// MockCreateSubnetRequest method.
+func (c *MockClient) CreateSubnetRequest(i *ec2.CreateSubnetInput) ec2.CreateSubnetRequest {
+	return c.MockCreateSubnetRequest(i)
+}
+
+// DescribeSubnetsRequest calls the underlying
+// MockDescribeSubnetsRequest method.
+func (c *MockClient) DescribeSubnetsRequest(i *ec2.DescribeSubnetsInput) ec2.DescribeSubnetsRequest {
+	return c.MockDescribeSubnetsRequest(i)
+}
+
+// ModifySubnetAttributeRequest calls the underlying
+// MockModifySubnetAttributeRequest method.
+func (c *MockClient) ModifySubnetAttributeRequest(i *ec2.ModifySubnetAttributeInput) ec2.ModifySubnetAttributeRequest {
+	return c.MockModifySubnetAttributeRequest(i)
+}
+
+// DeleteSubnetRequest calls the underlying
+// MockDeleteSubnetRequest method.
+func (c *MockClient) DeleteSubnetRequest(i *ec2.DeleteSubnetInput) ec2.DeleteSubnetRequest {
+	return c.MockDeleteSubnetRequest(i)
+}
+
+// CreateSecurityGroupRequest calls the underlying
+// MockCreateSecurityGroupRequest method.
+func (c *MockClient) CreateSecurityGroupRequest(i *ec2.CreateSecurityGroupInput) ec2.CreateSecurityGroupRequest {
+	return c.MockCreateSecurityGroupRequest(i)
+}
+
+// DescribeSecurityGroupsRequest calls the underlying
+// MockDescribeSecurityGroupsRequest method.
+func (c *MockClient) DescribeSecurityGroupsRequest(i *ec2.DescribeSecurityGroupsInput) ec2.DescribeSecurityGroupsRequest {
+	return c.MockDescribeSecurityGroupsRequest(i)
+}
+
+// AuthorizeSecurityGroupIngressRequest calls the underlying
+// MockAuthorizeSecurityGroupIngressRequest method.
+func (c *MockClient) AuthorizeSecurityGroupIngressRequest(i *ec2.AuthorizeSecurityGroupIngressInput) ec2.AuthorizeSecurityGroupIngressRequest {
+	return c.MockAuthorizeSecurityGroupIngressRequest(i)
+}
+
+// RevokeSecurityGroupIngressRequest calls the underlying
+// MockRevokeSecurityGroupIngressRequest method.
+func (c *MockClient) RevokeSecurityGroupIngressRequest(i *ec2.RevokeSecurityGroupIngressInput) ec2.RevokeSecurityGroupIngressRequest {
+	return c.MockRevokeSecurityGroupIngressRequest(i)
+}
+
+// AuthorizeSecurityGroupEgressRequest calls the underlying
+// MockAuthorizeSecurityGroupEgressRequest method.
+func (c *MockClient) AuthorizeSecurityGroupEgressRequest(i *ec2.AuthorizeSecurityGroupEgressInput) ec2.AuthorizeSecurityGroupEgressRequest {
+	return c.MockAuthorizeSecurityGroupEgressRequest(i)
+}
+
+// RevokeSecurityGroupEgressRequest calls the underlying
+// MockRevokeSecurityGroupEgressRequest method.
+func (c *MockClient) RevokeSecurityGroupEgressRequest(i *ec2.RevokeSecurityGroupEgressInput) ec2.RevokeSecurityGroupEgressRequest {
+	return c.MockRevokeSecurityGroupEgressRequest(i)
+}
+
+// DeleteSecurityGroupRequest calls the underlying
+// MockDeleteSecurityGroupRequest method.
+func (c *MockClient) DeleteSecurityGroupRequest(i *ec2.DeleteSecurityGroupInput) ec2.DeleteSecurityGroupRequest {
+	return c.MockDeleteSecurityGroupRequest(i)
+}
@@ -37,6 +37,11 @@ import (
 	cfc "github.com/crossplaneio/crossplane/pkg/clients/aws/cloudformation"
 )
 
+// API is the name of the AWS API used by EKS clients. EKS clients also call
+// the EC2, STS and CloudFormation APIs; requests to them are rate limited as
+// requests to EKS.
+const API = eks.EndpointsID
+
 const (
 	clusterIDHeader                = "x-k8s-aws-id"
 	v1Prefix                       = "k8s-aws-v1."
@@ -123,8 +128,8 @@ func (e *eksClient) Create(name string, spec awscomputev1alpha1.EKSClusterSpec)
 		Name:    aws.String(name),
 		RoleArn: aws.String(spec.RoleARN),
 		ResourcesVpcConfig: &eks.VpcConfigRequest{
-			SubnetIds:        spec.SubnetIds,
-			SecurityGroupIds: spec.SecurityGroupIds,
+			SubnetIds:        spec.AllSubnetIDs(),
+			SecurityGroupIds: spec.AllSecurityGroupIDs(),
 		},
 	}
 	if spec.ClusterVersion != "" {
@@ -146,7 +151,7 @@ func (e *eksClient) CreateWorkerNodes(name string, clusterVersion string, spec a
 		return nil, err
 	}
 
-	subnetIds := strings.Join(spec.SubnetIds, ",")
+	subnetIds := strings.Join(spec.AllSubnetIDs(), ",")
 	parameters := map[string]string{
 		"ClusterName":                      name,
 		"VpcId":                            spec.VpcID,
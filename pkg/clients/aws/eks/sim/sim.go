@@ -0,0 +1,258 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sim provides a simulated EKS API.
+package sim
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	cf "github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+
+	awscomputev1alpha1 "github.com/crossplaneio/crossplane/aws/apis/compute/v1alpha1"
+	cfsim "github.com/crossplaneio/crossplane/pkg/clients/aws/cloudformation/sim"
+	"github.com/crossplaneio/crossplane/pkg/clients/aws/eks"
+	"github.com/crossplaneio/crossplane/pkg/clients/sim"
+)
+
+// Cluster states.
+const (
+	stateCreating = string(awseks.ClusterStatusCreating)
+	stateActive   = string(awseks.ClusterStatusActive)
+	stateDeleting = string(awseks.ClusterStatusDeleting)
+)
+
+// Defaults of the simulated API.
+const (
+	defaultVersion = "1.13"
+	defaultImageID = "ami-00000000000000000"
+
+	// certificateAuthority is the base64 encoded certificate authority data
+	// of every simulated cluster.
+	certificateAuthority = "c2ltdWxhdGVkIGNlcnRpZmljYXRlIGF1dGhvcml0eQ=="
+)
+
+type cluster struct {
+	lifecycle *sim.Lifecycle
+	version   string
+	roleARN   string
+}
+
+// A Client is a simulated EKS API. Clusters are created in the CREATING state
+// and become ACTIVE after they have been observed Polls times. Worker nodes
+// are created as stacks of a simulated CloudFormation API. Errors may be
+// injected into any method, keyed by its name.
+type Client struct {
+	sim.Failures
+
+	// Polls is the number of times a cluster in a transitional state must be
+	// observed before it settles.
+	Polls int
+
+	// CloudFormation is the simulated API that manages worker node stacks.
+	CloudFormation *cfsim.Client
+
+	mu       sync.Mutex
+	clusters map[string]*cluster
+}
+
+var _ eks.Client = &Client{}
+
+// NewClient returns a simulated EKS API with no clusters.
+func NewClient() *Client {
+	return &Client{
+		Polls:          sim.DefaultPolls,
+		CloudFormation: cfsim.NewClient(),
+		clusters:       map[string]*cluster{},
+	}
+}
+
+// Create creates a cluster with the supplied name and spec.
+func (c *Client) Create(name string, spec awscomputev1alpha1.EKSClusterSpec) (*eks.Cluster, error) {
+	if err := c.Call("Create"); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.clusters[name]; ok {
+		return nil, awserr.New(awseks.ErrCodeResourceInUseException, fmt.Sprintf("Cluster already exists with name: %s", name), nil)
+	}
+	cl := &cluster{
+		lifecycle: sim.NewLifecycle(stateCreating).Then(stateActive, c.Polls),
+		version:   spec.ClusterVersion,
+		roleARN:   spec.RoleARN,
+	}
+	if cl.version == "" {
+		cl.version = defaultVersion
+	}
+	c.clusters[name] = cl
+	return view(name, cl), nil
+}
+
+// Get returns the named cluster.
+func (c *Client) Get(name string) (*eks.Cluster, error) {
+	if err := c.Call("Get"); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cl, ok := c.clusters[name]
+	if !ok {
+		return nil, clusterNotFound(name)
+	}
+	if cl.lifecycle.Observe() == sim.Deleted {
+		delete(c.clusters, name)
+		return nil, clusterNotFound(name)
+	}
+	return view(name, cl), nil
+}
+
+// Delete starts deleting the named cluster.
+func (c *Client) Delete(name string) error {
+	if err := c.Call("Delete"); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cl, ok := c.clusters[name]
+	if !ok {
+		return clusterNotFound(name)
+	}
+	if cl.lifecycle.State() != stateDeleting {
+		cl.lifecycle.Set(stateDeleting).Then(sim.Deleted, c.Polls)
+	}
+	return nil
+}
+
+// CreateWorkerNodes creates a CloudFormation stack of worker nodes for the
+// named cluster. The latest AMI is used unless the spec names one.
+func (c *Client) CreateWorkerNodes(name string, _ string, spec awscomputev1alpha1.EKSClusterSpec) (*eks.ClusterWorkers, error) {
+	if err := c.Call("CreateWorkerNodes"); err != nil {
+		return nil, err
+	}
+
+	image := spec.WorkerNodes.NodeImageID
+	if image == "" {
+		image = defaultImageID
+	}
+	params := map[string]string{
+		"ClusterName":      name,
+		"VpcId":            spec.VpcID,
+		"KeyName":          spec.WorkerNodes.KeyName,
+		"NodeImageId":      image,
+		"NodeInstanceType": spec.WorkerNodes.NodeInstanceType,
+		"NodeGroupName":    spec.WorkerNodes.NodeGroupName,
+	}
+	id, err := c.CloudFormation.CreateStack(aws.String(name), aws.String(""), params)
+	if err != nil {
+		return nil, err
+	}
+	return eks.NewClusterWorkers(aws.StringValue(id), cf.StackStatusCreateInProgress, "", ""), nil
+}
+
+// GetWorkerNodes returns the worker nodes of the supplied CloudFormation stack.
+func (c *Client) GetWorkerNodes(stackID string) (*eks.ClusterWorkers, error) {
+	if err := c.Call("GetWorkerNodes"); err != nil {
+		return nil, err
+	}
+
+	s, err := c.CloudFormation.GetStack(aws.String(stackID))
+	if err != nil {
+		return nil, err
+	}
+	arn := ""
+	for _, o := range s.Outputs {
+		if aws.StringValue(o.OutputKey) == "NodeInstanceRole" {
+			arn = aws.StringValue(o.OutputValue)
+		}
+	}
+	return eks.NewClusterWorkers(stackID, s.StackStatus, aws.StringValue(s.StackStatusReason), arn), nil
+}
+
+// DeleteWorkerNodes starts deleting the supplied CloudFormation stack.
+func (c *Client) DeleteWorkerNodes(stackID string) error {
+	if err := c.Call("DeleteWorkerNodes"); err != nil {
+		return err
+	}
+	return c.CloudFormation.DeleteStack(aws.String(stackID))
+}
+
+// ConnectionToken returns a bearer token for the named cluster. The token is
+// not a presigned STS request, but is of the same form.
+func (c *Client) ConnectionToken(name string) (string, error) {
+	if err := c.Call("ConnectionToken"); err != nil {
+		return "", err
+	}
+	return "k8s-aws-v1." + base64.RawURLEncoding.EncodeToString([]byte("sim:"+name)), nil
+}
+
+// Settle completes all pending cluster and worker node transitions
+// immediately.
+func (c *Client) Settle() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for name, cl := range c.clusters {
+		if cl.lifecycle.Settle() == sim.Deleted {
+			delete(c.clusters, name)
+		}
+	}
+	c.CloudFormation.Settle()
+}
+
+// Clusters returns the names of all clusters that exist, including those that
+// are being created or deleted, in order.
+func (c *Client) Clusters() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	names := make([]string, 0, len(c.clusters))
+	for name := range c.clusters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func view(name string, cl *cluster) *eks.Cluster {
+	out := &eks.Cluster{
+		Name:    name,
+		Version: cl.version,
+		ARN:     fmt.Sprintf("arn:aws:eks:sim-region-1:000000000000:cluster/%s", name),
+		Status:  cl.lifecycle.State(),
+	}
+	if out.Status != stateCreating {
+		out.Endpoint = fmt.Sprintf("https://%s.sim-region-1.eks.amazonaws.com", name)
+		out.CA = certificateAuthority
+	}
+	return out
+}
+
+func clusterNotFound(name string) error {
+	return awserr.New(awseks.ErrCodeResourceNotFoundException, fmt.Sprintf("No cluster found for name: %s.", name), nil)
+}
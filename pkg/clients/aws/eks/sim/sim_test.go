@@ -0,0 +1,83 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sim
+
+import (
+	"testing"
+
+	cf "github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/google/go-cmp/cmp"
+
+	awscomputev1alpha1 "github.com/crossplaneio/crossplane/aws/apis/compute/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/aws/eks"
+)
+
+const clusterName = "cool-cluster"
+
+func TestClusterLifecycle(t *testing.T) {
+	c := NewClient()
+	spec := awscomputev1alpha1.EKSClusterSpec{}
+
+	if _, err := c.Create(clusterName, spec); err != nil {
+		t.Fatalf("c.Create(...): %s", err)
+	}
+	_, err := c.Create(clusterName, spec)
+	if err == nil || !eks.IsErrorAlreadyExists(err) {
+		t.Errorf("c.Create(...): want already exists error, got %v", err)
+	}
+
+	want := []string{stateCreating, stateActive}
+	got := []string{}
+	for i := 0; i < c.Polls; i++ {
+		cl, err := c.Get(clusterName)
+		if err != nil {
+			t.Fatalf("c.Get(...): %s", err)
+		}
+		got = append(got, cl.Status)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("c.Get(...): -want states, +got states:\n%s", diff)
+	}
+
+	w, err := c.CreateWorkerNodes(clusterName, defaultVersion, spec)
+	if err != nil {
+		t.Fatalf("c.CreateWorkerNodes(...): %s", err)
+	}
+	c.Settle()
+	w, err = c.GetWorkerNodes(w.WorkerStackID)
+	if err != nil {
+		t.Fatalf("c.GetWorkerNodes(...): %s", err)
+	}
+	if w.WorkersStatus != cf.StackStatusCreateComplete || w.WorkerARN == "" {
+		t.Errorf("c.GetWorkerNodes(...): want completed workers with an ARN, got %+v", w)
+	}
+
+	if err := c.DeleteWorkerNodes(w.WorkerStackID); err != nil {
+		t.Fatalf("c.DeleteWorkerNodes(...): %s", err)
+	}
+	if err := c.Delete(clusterName); err != nil {
+		t.Fatalf("c.Delete(...): %s", err)
+	}
+	c.Settle()
+	_, err = c.Get(clusterName)
+	if err == nil || !eks.IsErrorNotFound(err) {
+		t.Errorf("c.Get(...): want not found error after deletion, got %v", err)
+	}
+	if diff := cmp.Diff([]string{}, c.CloudFormation.Stacks()); diff != "" {
+		t.Errorf("c.CloudFormation.Stacks(): -want, +got:\n%s", diff)
+	}
+}
@@ -19,6 +19,8 @@ package elasticache
 import (
 	"fmt"
 	"hash/fnv"
+	"net/http"
+	"sort"
 
 	"github.com/aws/aws-sdk-go-v2/service/elasticache"
 	"github.com/aws/aws-sdk-go-v2/service/elasticache/elasticacheiface"
@@ -27,28 +29,40 @@ import (
 
 	"github.com/crossplaneio/crossplane/aws/apis/cache/v1alpha1"
 	"github.com/crossplaneio/crossplane/pkg/clients/aws"
+	"github.com/crossplaneio/crossplane/pkg/externalname"
 )
 
 // NamePrefix is the prefix for all created ElastiCache replication groups.
 const NamePrefix = "ec"
 
+// API is the name of the AWS API used by ElastiCache clients.
+const API = elasticache.EndpointsID
+
 // A Client handles CRUD operations for ElastiCache resources. This interface is
 // compatible with the upstream AWS redis client.
 type Client elasticacheiface.ElastiCacheAPI
 
 // NewClient returns a new ElastiCache client. Credentials must be passed as
-// JSON encoded data.
-func NewClient(credentials []byte, region string) (Client, error) {
+// JSON encoded data. Requests are made using the supplied HTTP client, if any.
+func NewClient(credentials []byte, region string, hc *http.Client) (Client, error) {
 	cfg, err := aws.LoadConfig(credentials, aws.DefaultSection, region)
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot create new AWS configuration")
 	}
+	if hc != nil {
+		cfg.HTTPClient = hc
+	}
 	return elasticache.New(*cfg), nil
 }
 
 // NewReplicationGroupID returns an identifier used to identify a Replication
-// Group in the AWS API.
+// Group in the AWS API. The external name of the supplied object is used as
+// the identifier if it has one.
 func NewReplicationGroupID(o metav1.Object) string {
+	if n := externalname.Get(o); n != "" {
+		return n
+	}
+
 	/*
 		We want this ID to be deterministic and unique across time and space. We
 		should always return the same ID for a given Kubernetes ReplicationGroup
@@ -164,6 +178,58 @@ func NewModifyReplicationGroupInput(g *v1alpha1.ReplicationGroup) *elasticache.M
 	}
 }
 
+// NewModifyReplicationGroupShardConfigurationInput returns ElastiCache
+// replication group shard configuration input suitable for use with the AWS
+// API. When scaling in it removes the node groups with the highest IDs.
+func NewModifyReplicationGroupShardConfigurationInput(g *v1alpha1.ReplicationGroup, rg elasticache.ReplicationGroup) *elasticache.ModifyReplicationGroupShardConfigurationInput {
+	i := &elasticache.ModifyReplicationGroupShardConfigurationInput{
+		ReplicationGroupId: aws.String(NewReplicationGroupID(g), aws.FieldRequired),
+		NodeGroupCount:     aws.Int64(g.Spec.NumNodeGroups, aws.FieldRequired),
+
+		// ElastiCache only supports applying shard configuration changes
+		// immediately.
+		ApplyImmediately: aws.Bool(true),
+	}
+
+	remove := len(rg.NodeGroups) - g.Spec.NumNodeGroups
+	if remove <= 0 {
+		return i
+	}
+
+	ids := make([]string, 0, len(rg.NodeGroups))
+	for _, ng := range rg.NodeGroups {
+		ids = append(ids, aws.StringValue(ng.NodeGroupId))
+	}
+	sort.Strings(ids)
+	i.NodeGroupsToRemove = ids[len(ids)-remove:]
+
+	return i
+}
+
+// NewIncreaseReplicaCountInput returns ElastiCache replica count increase
+// input suitable for use with the AWS API.
+func NewIncreaseReplicaCountInput(g *v1alpha1.ReplicationGroup) *elasticache.IncreaseReplicaCountInput {
+	return &elasticache.IncreaseReplicaCountInput{
+		ReplicationGroupId: aws.String(NewReplicationGroupID(g), aws.FieldRequired),
+		NewReplicaCount:    aws.Int64(g.Spec.ReplicasPerNodeGroup, aws.FieldRequired),
+
+		// ElastiCache only supports changing replica counts immediately.
+		ApplyImmediately: aws.Bool(true),
+	}
+}
+
+// NewDecreaseReplicaCountInput returns ElastiCache replica count decrease
+// input suitable for use with the AWS API.
+func NewDecreaseReplicaCountInput(g *v1alpha1.ReplicationGroup) *elasticache.DecreaseReplicaCountInput {
+	return &elasticache.DecreaseReplicaCountInput{
+		ReplicationGroupId: aws.String(NewReplicationGroupID(g), aws.FieldRequired),
+		NewReplicaCount:    aws.Int64(g.Spec.ReplicasPerNodeGroup, aws.FieldRequired),
+
+		// ElastiCache only supports changing replica counts immediately.
+		ApplyImmediately: aws.Bool(true),
+	}
+}
+
 // NewDeleteReplicationGroupInput returns ElastiCache replication group deletion
 // input suitable for use with the AWS API.
 func NewDeleteReplicationGroupInput(g *v1alpha1.ReplicationGroup) *elasticache.DeleteReplicationGroupInput {
@@ -201,6 +267,53 @@ func ReplicationGroupNeedsUpdate(kube *v1alpha1.ReplicationGroup, rg elasticache
 	return false
 }
 
+// ReplicationGroupOperation returns the operation that modifying the supplied
+// AWS resource to match the supplied Kubernetes resource will perform.
+func ReplicationGroupOperation(kube *v1alpha1.ReplicationGroup, rg elasticache.ReplicationGroup) string {
+	if kube.Spec.CacheNodeType != aws.StringValue(rg.CacheNodeType) {
+		return v1alpha1.OperationScaleNodeType
+	}
+	return v1alpha1.OperationModify
+}
+
+// NodeGroupsNeedUpdate returns true if the supplied Kubernetes resource
+// specifies a different number of node groups (shards) than the supplied AWS
+// resource has. Only cluster mode enabled replication groups may be resharded.
+func NodeGroupsNeedUpdate(kube *v1alpha1.ReplicationGroup, rg elasticache.ReplicationGroup) bool {
+	if !aws.BoolValue(rg.ClusterEnabled) || kube.Spec.NumNodeGroups == 0 {
+		return false
+	}
+	return kube.Spec.NumNodeGroups != len(rg.NodeGroups)
+}
+
+// ReplicaCountOperation returns the operation needed to make each node group
+// (shard) of the supplied AWS resource have the number of replicas the
+// supplied Kubernetes resource specifies, or an empty string if none is needed.
+// Replicas are added if any node group has too few, and removed if any has too
+// many.
+func ReplicaCountOperation(kube *v1alpha1.ReplicationGroup, rg elasticache.ReplicationGroup) string {
+	want := kube.Spec.ReplicasPerNodeGroup
+	if want == 0 {
+		return ""
+	}
+
+	increase, decrease := false, false
+	for _, ng := range rg.NodeGroups {
+		// Each node group has one primary node; the rest are replicas.
+		replicas := len(ng.NodeGroupMembers) - 1
+		increase = increase || replicas < want
+		decrease = decrease || replicas > want
+	}
+
+	switch {
+	case increase:
+		return v1alpha1.OperationIncreaseReplicaCount
+	case decrease:
+		return v1alpha1.OperationDecreaseReplicaCount
+	}
+	return ""
+}
+
 func automaticFailoverEnabled(rg elasticache.ReplicationGroup) bool {
 	return rg.AutomaticFailover == elasticache.AutomaticFailoverStatusEnabled || rg.AutomaticFailover == elasticache.AutomaticFailoverStatusEnabling
 }
@@ -234,6 +347,15 @@ func CacheClusterNeedsUpdate(kube *v1alpha1.ReplicationGroup, cc elasticache.Cac
 	return sgIDsNeedUpdate(kube.Spec.SecurityGroupIDs, cc.SecurityGroups) || sgNamesNeedUpdate(kube.Spec.CacheSecurityGroupNames, cc.CacheSecurityGroups)
 }
 
+// CacheClusterOperation returns the operation that modifying the supplied
+// AWS resource to match the supplied Kubernetes resource will perform.
+func CacheClusterOperation(kube *v1alpha1.ReplicationGroup, cc elasticache.CacheCluster) string {
+	if v := kube.Spec.EngineVersion; v != "" && v != aws.StringValue(cc.EngineVersion) {
+		return v1alpha1.OperationUpgradeEngine
+	}
+	return v1alpha1.OperationModify
+}
+
 func sgIDsNeedUpdate(kube []string, cc []elasticache.SecurityGroupMembership) bool {
 	if len(kube) != len(cc) {
 		return true
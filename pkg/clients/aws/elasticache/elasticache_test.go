@@ -26,6 +26,7 @@ import (
 
 	"github.com/crossplaneio/crossplane/aws/apis/cache/v1alpha1"
 	"github.com/crossplaneio/crossplane/pkg/clients/aws"
+	"github.com/crossplaneio/crossplane/pkg/externalname"
 )
 
 const (
@@ -119,6 +120,14 @@ func TestNewReplicationGroupID(t *testing.T) {
 			object: replicationGroup,
 			want:   id,
 		},
+		{
+			name: "ExternalName",
+			object: &v1alpha1.ReplicationGroup{ObjectMeta: metav1.ObjectMeta{
+				UID:         uid,
+				Annotations: map[string]string{externalname.AnnotationKey: "existing"},
+			}},
+			want: "existing",
+		},
 	}
 
 	for _, tc := range cases {
@@ -296,6 +305,90 @@ func TestNewModifyReplicationGroupInput(t *testing.T) {
 	}
 }
 
+func TestNewModifyReplicationGroupShardConfigurationInput(t *testing.T) {
+	nodeGroups := func(ids ...string) []elasticache.NodeGroup {
+		ngs := make([]elasticache.NodeGroup, len(ids))
+		for i := range ids {
+			ngs[i] = elasticache.NodeGroup{NodeGroupId: aws.String(ids[i])}
+		}
+		return ngs
+	}
+
+	cases := []struct {
+		name  string
+		group *v1alpha1.ReplicationGroup
+		rg    elasticache.ReplicationGroup
+		want  *elasticache.ModifyReplicationGroupShardConfigurationInput
+	}{
+		{
+			name:  "ScaleOut",
+			group: replicationGroup,
+			rg:    elasticache.ReplicationGroup{NodeGroups: nodeGroups("0001")},
+			want: &elasticache.ModifyReplicationGroupShardConfigurationInput{
+				ReplicationGroupId: aws.String(id, aws.FieldRequired),
+				ApplyImmediately:   aws.Bool(true),
+				NodeGroupCount:     aws.Int64(numNodeGroups, aws.FieldRequired),
+			},
+		},
+		{
+			name:  "ScaleIn",
+			group: replicationGroup,
+			rg:    elasticache.ReplicationGroup{NodeGroups: nodeGroups("0004", "0001", "0003", "0002")},
+			want: &elasticache.ModifyReplicationGroupShardConfigurationInput{
+				ReplicationGroupId: aws.String(id, aws.FieldRequired),
+				ApplyImmediately:   aws.Bool(true),
+				NodeGroupCount:     aws.Int64(numNodeGroups, aws.FieldRequired),
+				NodeGroupsToRemove: []string{"0003", "0004"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := NewModifyReplicationGroupShardConfigurationInput(tc.group, tc.rg)
+
+			if err := got.Validate(); err != nil {
+				t.Errorf("NewModifyReplicationGroupShardConfigurationInput(...): invalid input: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("NewModifyReplicationGroupShardConfigurationInput(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewIncreaseReplicaCountInput(t *testing.T) {
+	want := &elasticache.IncreaseReplicaCountInput{
+		ReplicationGroupId: aws.String(id, aws.FieldRequired),
+		NewReplicaCount:    aws.Int64(replicasPerNodeGroup, aws.FieldRequired),
+		ApplyImmediately:   aws.Bool(true),
+	}
+
+	got := NewIncreaseReplicaCountInput(replicationGroup)
+	if err := got.Validate(); err != nil {
+		t.Errorf("NewIncreaseReplicaCountInput(...): invalid input: %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("NewIncreaseReplicaCountInput(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestNewDecreaseReplicaCountInput(t *testing.T) {
+	want := &elasticache.DecreaseReplicaCountInput{
+		ReplicationGroupId: aws.String(id, aws.FieldRequired),
+		NewReplicaCount:    aws.Int64(replicasPerNodeGroup, aws.FieldRequired),
+		ApplyImmediately:   aws.Bool(true),
+	}
+
+	got := NewDecreaseReplicaCountInput(replicationGroup)
+	if err := got.Validate(); err != nil {
+		t.Errorf("NewDecreaseReplicaCountInput(...): invalid input: %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("NewDecreaseReplicaCountInput(...): -want, +got:\n%s", diff)
+	}
+}
+
 func TestNewDeleteReplicationGroupInput(t *testing.T) {
 	cases := []struct {
 		name  string
@@ -453,6 +546,101 @@ func TestReplicationGroupNeedsUpdate(t *testing.T) {
 	}
 }
 
+func TestNodeGroupsNeedUpdate(t *testing.T) {
+	cases := []struct {
+		name string
+		kube *v1alpha1.ReplicationGroup
+		rg   elasticache.ReplicationGroup
+		want bool
+	}{
+		{
+			name: "NeedsMoreNodeGroups",
+			kube: replicationGroup,
+			rg: elasticache.ReplicationGroup{
+				ClusterEnabled: aws.Bool(true),
+				NodeGroups:     []elasticache.NodeGroup{{}},
+			},
+			want: true,
+		},
+		{
+			name: "NeedsNoUpdate",
+			kube: replicationGroup,
+			rg: elasticache.ReplicationGroup{
+				ClusterEnabled: aws.Bool(true),
+				NodeGroups:     []elasticache.NodeGroup{{}, {}},
+			},
+			want: false,
+		},
+		{
+			name: "ClusterModeDisabled",
+			kube: replicationGroup,
+			rg: elasticache.ReplicationGroup{
+				ClusterEnabled: aws.Bool(false),
+				NodeGroups:     []elasticache.NodeGroup{{}},
+			},
+			want: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := NodeGroupsNeedUpdate(tc.kube, tc.rg)
+			if got != tc.want {
+				t.Errorf("NodeGroupsNeedUpdate(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestReplicaCountOperation(t *testing.T) {
+	// nodeGroup returns a node group with one primary and the supplied number
+	// of replicas.
+	nodeGroup := func(replicas int) elasticache.NodeGroup {
+		return elasticache.NodeGroup{NodeGroupMembers: make([]elasticache.NodeGroupMember, replicas+1)}
+	}
+
+	cases := []struct {
+		name string
+		kube *v1alpha1.ReplicationGroup
+		rg   elasticache.ReplicationGroup
+		want string
+	}{
+		{
+			name: "NeedsMoreReplicas",
+			kube: replicationGroup,
+			rg:   elasticache.ReplicationGroup{NodeGroups: []elasticache.NodeGroup{nodeGroup(replicasPerNodeGroup), nodeGroup(1)}},
+			want: v1alpha1.OperationIncreaseReplicaCount,
+		},
+		{
+			name: "NeedsFewerReplicas",
+			kube: replicationGroup,
+			rg:   elasticache.ReplicationGroup{NodeGroups: []elasticache.NodeGroup{nodeGroup(replicasPerNodeGroup), nodeGroup(3)}},
+			want: v1alpha1.OperationDecreaseReplicaCount,
+		},
+		{
+			name: "NeedsNoUpdate",
+			kube: replicationGroup,
+			rg:   elasticache.ReplicationGroup{NodeGroups: []elasticache.NodeGroup{nodeGroup(replicasPerNodeGroup), nodeGroup(replicasPerNodeGroup)}},
+			want: "",
+		},
+		{
+			name: "ReplicasNotSpecified",
+			kube: &v1alpha1.ReplicationGroup{},
+			rg:   elasticache.ReplicationGroup{NodeGroups: []elasticache.NodeGroup{nodeGroup(1)}},
+			want: "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ReplicaCountOperation(tc.kube, tc.rg)
+			if got != tc.want {
+				t.Errorf("ReplicaCountOperation(...): want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
 func TestCacheClusterNeedsUpdate(t *testing.T) {
 	cases := []struct {
 		name string
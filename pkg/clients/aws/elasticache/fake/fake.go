@@ -32,6 +32,10 @@ type MockClient struct {
 	MockModifyReplicationGroupRequest    func(*elasticache.ModifyReplicationGroupInput) elasticache.ModifyReplicationGroupRequest
 	MockDeleteReplicationGroupRequest    func(*elasticache.DeleteReplicationGroupInput) elasticache.DeleteReplicationGroupRequest
 
+	MockModifyReplicationGroupShardConfigurationRequest func(*elasticache.ModifyReplicationGroupShardConfigurationInput) elasticache.ModifyReplicationGroupShardConfigurationRequest
+	MockIncreaseReplicaCountRequest                     func(*elasticache.IncreaseReplicaCountInput) elasticache.IncreaseReplicaCountRequest
+	MockDecreaseReplicaCountRequest                     func(*elasticache.DecreaseReplicaCountInput) elasticache.DecreaseReplicaCountRequest
+
 	MockDescribeCacheClustersRequest func(*elasticache.DescribeCacheClustersInput) elasticache.DescribeCacheClustersRequest
 }
 
@@ -59,6 +63,24 @@ func (c *MockClient) DeleteReplicationGroupRequest(i *elasticache.DeleteReplicat
 	return c.MockDeleteReplicationGroupRequest(i)
 }
 
+// ModifyReplicationGroupShardConfigurationRequest calls the underlying
+// MockModifyReplicationGroupShardConfigurationRequest method.
+func (c *MockClient) ModifyReplicationGroupShardConfigurationRequest(i *elasticache.ModifyReplicationGroupShardConfigurationInput) elasticache.ModifyReplicationGroupShardConfigurationRequest {
+	return c.MockModifyReplicationGroupShardConfigurationRequest(i)
+}
+
+// IncreaseReplicaCountRequest calls the underlying
+// MockIncreaseReplicaCountRequest method.
+func (c *MockClient) IncreaseReplicaCountRequest(i *elasticache.IncreaseReplicaCountInput) elasticache.IncreaseReplicaCountRequest {
+	return c.MockIncreaseReplicaCountRequest(i)
+}
+
+// DecreaseReplicaCountRequest calls the underlying
+// MockDecreaseReplicaCountRequest method.
+func (c *MockClient) DecreaseReplicaCountRequest(i *elasticache.DecreaseReplicaCountInput) elasticache.DecreaseReplicaCountRequest {
+	return c.MockDecreaseReplicaCountRequest(i)
+}
+
 // DescribeCacheClustersRequest calls the underlying
 // MockDescribeCacheClustersRequest method.
 func (c *MockClient) DescribeCacheClustersRequest(i *elasticache.DescribeCacheClustersInput) elasticache.DescribeCacheClustersRequest {
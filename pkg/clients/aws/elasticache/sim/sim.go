@@ -0,0 +1,504 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sim provides a simulated ElastiCache API.
+package sim
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache/elasticacheiface"
+
+	"github.com/crossplaneio/crossplane/aws/apis/cache/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/sim"
+)
+
+// Defaults ElastiCache applies to replication groups that do not specify
+// them.
+const (
+	defaultPort              = 6379
+	defaultEngineVersion     = "5.0.5"
+	defaultParameterGroup    = "default.redis5.0"
+	defaultSnapshotWindow    = "05:00-06:00"
+	defaultMaintenanceWindow = "sun:06:00-sun:07:00"
+)
+
+type replicationGroup struct {
+	lifecycle *sim.Lifecycle
+	group     elasticache.ReplicationGroup
+	clusters  map[string]elasticache.CacheCluster
+}
+
+// A Client is a simulated ElastiCache API. Replication groups are created in
+// the "creating" state and become "available" after they have been observed
+// Polls times. They are modified and deleted asynchronously in the same way.
+// Only the replication group and cache cluster operations Crossplane uses are
+// simulated; calling any other operation panics.
+//
+// The simulated API applies each request when the request is built, rather
+// than when it is sent. Errors may be injected into any request, keyed by the
+// name of the operation, for example "CreateReplicationGroup".
+type Client struct {
+	elasticacheiface.ElastiCacheAPI
+	sim.Failures
+
+	// Polls is the number of times a replication group in a transitional
+	// state must be observed before it settles.
+	Polls int
+
+	mu     sync.Mutex
+	groups map[string]*replicationGroup
+}
+
+var _ elasticacheiface.ElastiCacheAPI = &Client{}
+
+// NewClient returns a simulated ElastiCache API with no replication groups.
+func NewClient() *Client {
+	return &Client{Polls: sim.DefaultPolls, groups: map[string]*replicationGroup{}}
+}
+
+// CreateReplicationGroupRequest creates a replication group.
+func (c *Client) CreateReplicationGroupRequest(i *elasticache.CreateReplicationGroupInput) elasticache.CreateReplicationGroupRequest {
+	if err := c.Call("CreateReplicationGroup"); err != nil {
+		return elasticache.CreateReplicationGroupRequest{Request: request(nil, err), Input: i}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := aws.StringValue(i.ReplicationGroupId)
+	if _, ok := c.groups[id]; ok {
+		err := awserr.New(elasticache.ErrCodeReplicationGroupAlreadyExistsFault, fmt.Sprintf("Replication group %s already exists.", id), nil)
+		return elasticache.CreateReplicationGroupRequest{Request: request(nil, err), Input: i}
+	}
+
+	rg := newReplicationGroup(i)
+	rg.lifecycle = sim.NewLifecycle(v1alpha1.StatusCreating).Then(v1alpha1.StatusAvailable, c.Polls)
+	c.groups[id] = rg
+
+	out := &elasticache.CreateReplicationGroupOutput{ReplicationGroup: rg.view()}
+	return elasticache.CreateReplicationGroupRequest{Request: request(out, nil), Input: i}
+}
+
+// DescribeReplicationGroupsRequest observes the requested replication group.
+// Listing all replication groups is not supported.
+func (c *Client) DescribeReplicationGroupsRequest(i *elasticache.DescribeReplicationGroupsInput) elasticache.DescribeReplicationGroupsRequest {
+	if err := c.Call("DescribeReplicationGroups"); err != nil {
+		return elasticache.DescribeReplicationGroupsRequest{Request: request(nil, err), Input: i}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := aws.StringValue(i.ReplicationGroupId)
+	rg, ok := c.groups[id]
+	if !ok {
+		return elasticache.DescribeReplicationGroupsRequest{Request: request(nil, notFound(id)), Input: i}
+	}
+	if rg.lifecycle.Observe() == sim.Deleted {
+		delete(c.groups, id)
+		return elasticache.DescribeReplicationGroupsRequest{Request: request(nil, notFound(id)), Input: i}
+	}
+
+	out := &elasticache.DescribeReplicationGroupsOutput{ReplicationGroups: []elasticache.ReplicationGroup{*rg.view()}}
+	return elasticache.DescribeReplicationGroupsRequest{Request: request(out, nil), Input: i}
+}
+
+// ModifyReplicationGroupRequest modifies the requested replication group,
+// which must be available. Changes are always applied immediately.
+func (c *Client) ModifyReplicationGroupRequest(i *elasticache.ModifyReplicationGroupInput) elasticache.ModifyReplicationGroupRequest {
+	if err := c.Call("ModifyReplicationGroup"); err != nil {
+		return elasticache.ModifyReplicationGroupRequest{Request: request(nil, err), Input: i}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := aws.StringValue(i.ReplicationGroupId)
+	rg, err := c.available(id)
+	if err != nil {
+		return elasticache.ModifyReplicationGroupRequest{Request: request(nil, err), Input: i}
+	}
+
+	rg.modify(i)
+	rg.lifecycle.Set(v1alpha1.StatusModifying).Then(v1alpha1.StatusAvailable, c.Polls)
+
+	out := &elasticache.ModifyReplicationGroupOutput{ReplicationGroup: rg.view()}
+	return elasticache.ModifyReplicationGroupRequest{Request: request(out, nil), Input: i}
+}
+
+// ModifyReplicationGroupShardConfigurationRequest changes the number of node
+// groups of the requested replication group, which must be available and
+// have cluster mode enabled.
+func (c *Client) ModifyReplicationGroupShardConfigurationRequest(i *elasticache.ModifyReplicationGroupShardConfigurationInput) elasticache.ModifyReplicationGroupShardConfigurationRequest {
+	if err := c.Call("ModifyReplicationGroupShardConfiguration"); err != nil {
+		return elasticache.ModifyReplicationGroupShardConfigurationRequest{Request: request(nil, err), Input: i}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := aws.StringValue(i.ReplicationGroupId)
+	rg, err := c.available(id)
+	if err != nil {
+		return elasticache.ModifyReplicationGroupShardConfigurationRequest{Request: request(nil, err), Input: i}
+	}
+	if !aws.BoolValue(rg.group.ClusterEnabled) {
+		err := awserr.New(elasticache.ErrCodeInvalidParameterValueException, fmt.Sprintf("Replication group %s does not have cluster mode enabled.", id), nil)
+		return elasticache.ModifyReplicationGroupShardConfigurationRequest{Request: request(nil, err), Input: i}
+	}
+
+	rg.reshard(int(aws.Int64Value(i.NodeGroupCount)))
+	rg.lifecycle.Set(v1alpha1.StatusModifying).Then(v1alpha1.StatusAvailable, c.Polls)
+
+	out := &elasticache.ModifyReplicationGroupShardConfigurationOutput{ReplicationGroup: rg.view()}
+	return elasticache.ModifyReplicationGroupShardConfigurationRequest{Request: request(out, nil), Input: i}
+}
+
+// IncreaseReplicaCountRequest adds replicas to every node group of the
+// requested replication group, which must be available.
+func (c *Client) IncreaseReplicaCountRequest(i *elasticache.IncreaseReplicaCountInput) elasticache.IncreaseReplicaCountRequest {
+	if err := c.Call("IncreaseReplicaCount"); err != nil {
+		return elasticache.IncreaseReplicaCountRequest{Request: request(nil, err), Input: i}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	rg, err := c.replicate(aws.StringValue(i.ReplicationGroupId), int(aws.Int64Value(i.NewReplicaCount)), true)
+	if err != nil {
+		return elasticache.IncreaseReplicaCountRequest{Request: request(nil, err), Input: i}
+	}
+
+	out := &elasticache.IncreaseReplicaCountOutput{ReplicationGroup: rg.view()}
+	return elasticache.IncreaseReplicaCountRequest{Request: request(out, nil), Input: i}
+}
+
+// DecreaseReplicaCountRequest removes replicas from every node group of the
+// requested replication group, which must be available.
+func (c *Client) DecreaseReplicaCountRequest(i *elasticache.DecreaseReplicaCountInput) elasticache.DecreaseReplicaCountRequest {
+	if err := c.Call("DecreaseReplicaCount"); err != nil {
+		return elasticache.DecreaseReplicaCountRequest{Request: request(nil, err), Input: i}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	rg, err := c.replicate(aws.StringValue(i.ReplicationGroupId), int(aws.Int64Value(i.NewReplicaCount)), false)
+	if err != nil {
+		return elasticache.DecreaseReplicaCountRequest{Request: request(nil, err), Input: i}
+	}
+
+	out := &elasticache.DecreaseReplicaCountOutput{ReplicationGroup: rg.view()}
+	return elasticache.DecreaseReplicaCountRequest{Request: request(out, nil), Input: i}
+}
+
+// DeleteReplicationGroupRequest starts deleting the requested replication
+// group.
+func (c *Client) DeleteReplicationGroupRequest(i *elasticache.DeleteReplicationGroupInput) elasticache.DeleteReplicationGroupRequest {
+	if err := c.Call("DeleteReplicationGroup"); err != nil {
+		return elasticache.DeleteReplicationGroupRequest{Request: request(nil, err), Input: i}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := aws.StringValue(i.ReplicationGroupId)
+	rg, ok := c.groups[id]
+	if !ok {
+		return elasticache.DeleteReplicationGroupRequest{Request: request(nil, notFound(id)), Input: i}
+	}
+	if rg.lifecycle.State() == v1alpha1.StatusDeleting {
+		err := awserr.New(elasticache.ErrCodeInvalidReplicationGroupStateFault, fmt.Sprintf("Replication group %s is already being deleted.", id), nil)
+		return elasticache.DeleteReplicationGroupRequest{Request: request(nil, err), Input: i}
+	}
+
+	rg.lifecycle.Set(v1alpha1.StatusDeleting).Then(sim.Deleted, c.Polls)
+
+	out := &elasticache.DeleteReplicationGroupOutput{ReplicationGroup: rg.view()}
+	return elasticache.DeleteReplicationGroupRequest{Request: request(out, nil), Input: i}
+}
+
+// DescribeCacheClustersRequest describes the requested member cluster of a
+// replication group. Listing all cache clusters is not supported.
+func (c *Client) DescribeCacheClustersRequest(i *elasticache.DescribeCacheClustersInput) elasticache.DescribeCacheClustersRequest {
+	if err := c.Call("DescribeCacheClusters"); err != nil {
+		return elasticache.DescribeCacheClustersRequest{Request: request(nil, err), Input: i}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := aws.StringValue(i.CacheClusterId)
+	for _, rg := range c.groups {
+		if cc, ok := rg.clusters[id]; ok {
+			cc.CacheClusterStatus = aws.String(rg.lifecycle.State())
+			out := &elasticache.DescribeCacheClustersOutput{CacheClusters: []elasticache.CacheCluster{cc}}
+			return elasticache.DescribeCacheClustersRequest{Request: request(out, nil), Input: i}
+		}
+	}
+
+	err := awserr.New(elasticache.ErrCodeCacheClusterNotFoundFault, fmt.Sprintf("CacheCluster %s not found.", id), nil)
+	return elasticache.DescribeCacheClustersRequest{Request: request(nil, err), Input: i}
+}
+
+// Settle completes all pending state transitions immediately.
+func (c *Client) Settle() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for id, rg := range c.groups {
+		if rg.lifecycle.Settle() == sim.Deleted {
+			delete(c.groups, id)
+		}
+	}
+}
+
+// ReplicationGroups returns the IDs of all replication groups that exist,
+// including those that are being created or deleted, in order.
+func (c *Client) ReplicationGroups() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ids := make([]string, 0, len(c.groups))
+	for id := range c.groups {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// replicate sets the number of replicas in each node group of the available
+// replication group. The new count must be greater than the current count if
+// increase is true, and less than it otherwise.
+func (c *Client) replicate(id string, replicas int, increase bool) (*replicationGroup, error) {
+	rg, err := c.available(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each node group has one primary member; the rest are replicas.
+	current := len(rg.group.MemberClusters)/len(rg.group.NodeGroups) - 1
+	if replicas < 0 || (increase && replicas <= current) || (!increase && replicas >= current) {
+		return nil, awserr.New(elasticache.ErrCodeInvalidParameterValueException, fmt.Sprintf("Replication group %s cannot change from %d to %d replicas.", id, current, replicas), nil)
+	}
+
+	port := aws.Int64Value(rg.group.ConfigurationEndpoint.Port)
+	template := rg.clusters[rg.group.MemberClusters[0]]
+	rg.resize(len(rg.group.NodeGroups), replicas+1, template, port)
+	rg.lifecycle.Set(v1alpha1.StatusModifying).Then(v1alpha1.StatusAvailable, c.Polls)
+	return rg, nil
+}
+
+func (c *Client) available(id string) (*replicationGroup, error) {
+	rg, ok := c.groups[id]
+	if !ok {
+		return nil, notFound(id)
+	}
+	if rg.lifecycle.State() != v1alpha1.StatusAvailable {
+		return nil, awserr.New(elasticache.ErrCodeInvalidReplicationGroupStateFault, fmt.Sprintf("Replication group %s is not in available state.", id), nil)
+	}
+	return rg, nil
+}
+
+func newReplicationGroup(i *elasticache.CreateReplicationGroupInput) *replicationGroup {
+	id := aws.StringValue(i.ReplicationGroupId)
+	port := aws.Int64Value(i.Port)
+	if port == 0 {
+		port = defaultPort
+	}
+
+	rg := &replicationGroup{
+		group: elasticache.ReplicationGroup{
+			ReplicationGroupId:       aws.String(id),
+			Description:              i.ReplicationGroupDescription,
+			CacheNodeType:            i.CacheNodeType,
+			ClusterEnabled:           aws.Bool(aws.Int64Value(i.NumNodeGroups) > 0),
+			AtRestEncryptionEnabled:  i.AtRestEncryptionEnabled,
+			TransitEncryptionEnabled: i.TransitEncryptionEnabled,
+			AuthTokenEnabled:         aws.Bool(aws.StringValue(i.AuthToken) != ""),
+			SnapshotRetentionLimit:   aws.Int64(aws.Int64Value(i.SnapshotRetentionLimit)),
+			SnapshotWindow:           aws.String(orDefault(i.SnapshotWindow, defaultSnapshotWindow)),
+			AutomaticFailover:        automaticFailover(i.AutomaticFailoverEnabled),
+			ConfigurationEndpoint:    &elasticache.Endpoint{Address: aws.String(fmt.Sprintf("%s.sim.clustercfg.cache.amazonaws.com", id)), Port: aws.Int64(port)},
+		},
+		clusters: map[string]elasticache.CacheCluster{},
+	}
+
+	template := elasticache.CacheCluster{
+		CacheNodeType:              i.CacheNodeType,
+		Engine:                     aws.String(v1alpha1.CacheEngineRedis),
+		EngineVersion:              aws.String(orDefault(i.EngineVersion, defaultEngineVersion)),
+		CacheParameterGroup:        &elasticache.CacheParameterGroupStatus{CacheParameterGroupName: aws.String(orDefault(i.CacheParameterGroupName, defaultParameterGroup))},
+		PreferredMaintenanceWindow: aws.String(orDefault(i.PreferredMaintenanceWindow, defaultMaintenanceWindow)),
+		ReplicationGroupId:         aws.String(id),
+	}
+	setNotificationTopic(&template, i.NotificationTopicArn)
+	setSecurityGroups(&template, i.SecurityGroupIds, i.CacheSecurityGroupNames)
+
+	groups := int(aws.Int64Value(i.NumNodeGroups))
+	members := int(aws.Int64Value(i.ReplicasPerNodeGroup)) + 1
+	if groups == 0 {
+		groups = 1
+		members = int(aws.Int64Value(i.NumCacheClusters))
+	}
+	if members < 1 {
+		members = 1
+	}
+	rg.resize(groups, members, template, port)
+
+	return rg
+}
+
+// resize sets the number of node groups of the replication group, each with
+// the supplied number of member clusters modelled on the supplied template.
+func (rg *replicationGroup) resize(groups, members int, template elasticache.CacheCluster, port int64) {
+	id := aws.StringValue(rg.group.ReplicationGroupId)
+	rg.group.NodeGroups = make([]elasticache.NodeGroup, groups)
+	rg.group.MemberClusters = nil
+	rg.clusters = map[string]elasticache.CacheCluster{}
+
+	for g := 0; g < groups; g++ {
+		ngID := fmt.Sprintf("%04d", g+1)
+		ng := elasticache.NodeGroup{
+			NodeGroupId:     aws.String(ngID),
+			PrimaryEndpoint: &elasticache.Endpoint{Address: aws.String(fmt.Sprintf("%s-%s.sim.cache.amazonaws.com", id, ngID)), Port: aws.Int64(port)},
+		}
+		for m := 0; m < members; m++ {
+			ccID := fmt.Sprintf("%s-%s-%03d", id, ngID, m+1)
+			cc := template
+			cc.CacheClusterId = aws.String(ccID)
+			rg.clusters[ccID] = cc
+			rg.group.MemberClusters = append(rg.group.MemberClusters, ccID)
+			ng.NodeGroupMembers = append(ng.NodeGroupMembers, elasticache.NodeGroupMember{CacheClusterId: aws.String(ccID), CacheNodeId: aws.String("0001")})
+		}
+		rg.group.NodeGroups[g] = ng
+	}
+}
+
+// reshard sets the number of node groups of the replication group. Node
+// groups are added and removed at the end; the simulated API ignores the
+// specific node groups a request asks to remove.
+func (rg *replicationGroup) reshard(count int) {
+	if count < 1 {
+		return
+	}
+	port := aws.Int64Value(rg.group.ConfigurationEndpoint.Port)
+	members := len(rg.group.MemberClusters) / len(rg.group.NodeGroups)
+	template := rg.clusters[rg.group.MemberClusters[0]]
+	rg.resize(count, members, template, port)
+}
+
+func (rg *replicationGroup) modify(i *elasticache.ModifyReplicationGroupInput) {
+	if i.AutomaticFailoverEnabled != nil {
+		rg.group.AutomaticFailover = automaticFailover(i.AutomaticFailoverEnabled)
+	}
+	if v := aws.StringValue(i.CacheNodeType); v != "" {
+		rg.group.CacheNodeType = aws.String(v)
+	}
+	if i.SnapshotRetentionLimit != nil {
+		rg.group.SnapshotRetentionLimit = aws.Int64(aws.Int64Value(i.SnapshotRetentionLimit))
+	}
+	if v := aws.StringValue(i.SnapshotWindow); v != "" {
+		rg.group.SnapshotWindow = aws.String(v)
+	}
+
+	for id, cc := range rg.clusters {
+		cc.CacheNodeType = rg.group.CacheNodeType
+		if v := aws.StringValue(i.EngineVersion); v != "" {
+			cc.EngineVersion = aws.String(v)
+		}
+		if v := aws.StringValue(i.CacheParameterGroupName); v != "" {
+			cc.CacheParameterGroup = &elasticache.CacheParameterGroupStatus{CacheParameterGroupName: aws.String(v)}
+		}
+		if v := aws.StringValue(i.PreferredMaintenanceWindow); v != "" {
+			cc.PreferredMaintenanceWindow = aws.String(v)
+		}
+		setNotificationTopic(&cc, i.NotificationTopicArn)
+		setSecurityGroups(&cc, i.SecurityGroupIds, i.CacheSecurityGroupNames)
+		rg.clusters[id] = cc
+	}
+}
+
+// view returns the replication group as the ElastiCache API would describe
+// it. Endpoints are only reported once the group has been created.
+func (rg *replicationGroup) view() *elasticache.ReplicationGroup {
+	g := rg.group
+	g.Status = aws.String(rg.lifecycle.State())
+	if g.NodeGroups != nil {
+		g.NodeGroups = append([]elasticache.NodeGroup{}, g.NodeGroups...)
+	}
+	if g.MemberClusters != nil {
+		g.MemberClusters = append([]string{}, g.MemberClusters...)
+	}
+
+	if rg.lifecycle.State() == v1alpha1.StatusCreating {
+		g.ConfigurationEndpoint = nil
+		for i := range g.NodeGroups {
+			g.NodeGroups[i].PrimaryEndpoint = nil
+		}
+	}
+	if !aws.BoolValue(g.ClusterEnabled) {
+		g.ConfigurationEndpoint = nil
+	}
+	return &g
+}
+
+func automaticFailover(enabled *bool) elasticache.AutomaticFailoverStatus {
+	if aws.BoolValue(enabled) {
+		return elasticache.AutomaticFailoverStatusEnabled
+	}
+	return elasticache.AutomaticFailoverStatusDisabled
+}
+
+func setNotificationTopic(cc *elasticache.CacheCluster, arn *string) {
+	cc.NotificationConfiguration = nil
+	if v := aws.StringValue(arn); v != "" {
+		cc.NotificationConfiguration = &elasticache.NotificationConfiguration{TopicArn: aws.String(v), TopicStatus: aws.String("active")}
+	}
+}
+
+func setSecurityGroups(cc *elasticache.CacheCluster, ids, names []string) {
+	cc.SecurityGroups = make([]elasticache.SecurityGroupMembership, len(ids))
+	for i, id := range ids {
+		cc.SecurityGroups[i] = elasticache.SecurityGroupMembership{SecurityGroupId: aws.String(id), Status: aws.String("active")}
+	}
+	cc.CacheSecurityGroups = make([]elasticache.CacheSecurityGroupMembership, len(names))
+	for i, name := range names {
+		cc.CacheSecurityGroups[i] = elasticache.CacheSecurityGroupMembership{CacheSecurityGroupName: aws.String(name), Status: aws.String("active")}
+	}
+}
+
+func orDefault(v *string, d string) string {
+	if s := aws.StringValue(v); s != "" {
+		return s
+	}
+	return d
+}
+
+func request(data interface{}, err error) *aws.Request {
+	return &aws.Request{HTTPRequest: &http.Request{}, Data: data, Error: err}
+}
+
+func notFound(id string) error {
+	return awserr.New(elasticache.ErrCodeReplicationGroupNotFoundFault, fmt.Sprintf("ReplicationGroup %s not found.", id), nil)
+}
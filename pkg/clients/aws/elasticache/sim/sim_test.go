@@ -0,0 +1,273 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sim
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+
+	"github.com/crossplaneio/crossplane/aws/apis/cache/v1alpha1"
+	client "github.com/crossplaneio/crossplane/pkg/clients/aws/elasticache"
+)
+
+var errBoom = errors.New("boom")
+
+func replicationGroup(numNodeGroups int) *v1alpha1.ReplicationGroup {
+	return &v1alpha1.ReplicationGroup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "cool-namespace", Name: "cool-group", UID: "definitely-a-uuid"},
+		Spec: v1alpha1.ReplicationGroupSpec{
+			ReplicationGroupParameters: v1alpha1.ReplicationGroupParameters{
+				CacheNodeType:    "cache.t2.micro",
+				NumCacheClusters: 2,
+				NumNodeGroups:    numNodeGroups,
+			},
+		},
+	}
+}
+
+// describe observes the supplied replication group once.
+func describe(t *testing.T, c *Client, g *v1alpha1.ReplicationGroup) (elasticache.ReplicationGroup, error) {
+	t.Helper()
+	rsp, err := c.DescribeReplicationGroupsRequest(client.NewDescribeReplicationGroupsInput(g)).Send()
+	if err != nil {
+		return elasticache.ReplicationGroup{}, err
+	}
+	return rsp.ReplicationGroups[0], nil
+}
+
+func TestReplicationGroupLifecycle(t *testing.T) {
+	c := NewClient()
+	g := replicationGroup(0)
+
+	if _, err := c.CreateReplicationGroupRequest(client.NewCreateReplicationGroupInput(g, "")).Send(); err != nil {
+		t.Fatalf("CreateReplicationGroupRequest(...).Send(): %s", err)
+	}
+	_, err := c.CreateReplicationGroupRequest(client.NewCreateReplicationGroupInput(g, "")).Send()
+	if !client.IsAlreadyExists(err) {
+		t.Errorf("CreateReplicationGroupRequest(...).Send(): want already exists error, got %v", err)
+	}
+
+	rg, err := describe(t, c, g)
+	if err != nil {
+		t.Fatalf("DescribeReplicationGroupsRequest(...).Send(): %s", err)
+	}
+	if diff := cmp.Diff(v1alpha1.StatusCreating, aws.StringValue(rg.Status)); diff != "" {
+		t.Errorf("DescribeReplicationGroupsRequest(...).Send(): -want status, +got status:\n%s", diff)
+	}
+	if diff := cmp.Diff(client.Endpoint{}, client.ConnectionEndpoint(rg)); diff != "" {
+		t.Errorf("ConnectionEndpoint(...): -want, +got:\n%s", diff)
+	}
+
+	rg, err = describe(t, c, g)
+	if err != nil {
+		t.Fatalf("DescribeReplicationGroupsRequest(...).Send(): %s", err)
+	}
+	if diff := cmp.Diff(v1alpha1.StatusAvailable, aws.StringValue(rg.Status)); diff != "" {
+		t.Errorf("DescribeReplicationGroupsRequest(...).Send(): -want status, +got status:\n%s", diff)
+	}
+	if client.ConnectionEndpoint(rg).Address == "" {
+		t.Errorf("ConnectionEndpoint(...): want an address once available")
+	}
+	if diff := cmp.Diff(2, len(rg.MemberClusters)); diff != "" {
+		t.Errorf("DescribeReplicationGroupsRequest(...).Send(): -want member clusters, +got member clusters:\n%s", diff)
+	}
+
+	// A replication group that was just created from a spec should not need
+	// to be updated to match that spec.
+	if client.ReplicationGroupNeedsUpdate(g, rg) {
+		t.Errorf("ReplicationGroupNeedsUpdate(...): want false for a newly created group")
+	}
+	for _, id := range rg.MemberClusters {
+		rsp, err := c.DescribeCacheClustersRequest(client.NewDescribeCacheClustersInput(id)).Send()
+		if err != nil {
+			t.Fatalf("DescribeCacheClustersRequest(...).Send(): %s", err)
+		}
+		if client.CacheClusterNeedsUpdate(g, rsp.CacheClusters[0]) {
+			t.Errorf("CacheClusterNeedsUpdate(...): want false for a newly created group")
+		}
+	}
+
+	g.Spec.CacheNodeType = "cache.m5.large"
+	g.Spec.EngineVersion = "5.0.6"
+	if _, err := c.ModifyReplicationGroupRequest(client.NewModifyReplicationGroupInput(g)).Send(); err != nil {
+		t.Fatalf("ModifyReplicationGroupRequest(...).Send(): %s", err)
+	}
+	if _, err := c.ModifyReplicationGroupRequest(client.NewModifyReplicationGroupInput(g)).Send(); err == nil {
+		t.Errorf("ModifyReplicationGroupRequest(...).Send(): want error modifying a group that is being modified")
+	}
+	c.Settle()
+	rg, err = describe(t, c, g)
+	if err != nil {
+		t.Fatalf("DescribeReplicationGroupsRequest(...).Send(): %s", err)
+	}
+	if client.ReplicationGroupNeedsUpdate(g, rg) {
+		t.Errorf("ReplicationGroupNeedsUpdate(...): want false for a modified group")
+	}
+	rsp, err := c.DescribeCacheClustersRequest(client.NewDescribeCacheClustersInput(rg.MemberClusters[0])).Send()
+	if err != nil {
+		t.Fatalf("DescribeCacheClustersRequest(...).Send(): %s", err)
+	}
+	if client.CacheClusterNeedsUpdate(g, rsp.CacheClusters[0]) {
+		t.Errorf("CacheClusterNeedsUpdate(...): want false for a modified group")
+	}
+
+	if _, err := c.DeleteReplicationGroupRequest(client.NewDeleteReplicationGroupInput(g)).Send(); err != nil {
+		t.Fatalf("DeleteReplicationGroupRequest(...).Send(): %s", err)
+	}
+	rg, err = describe(t, c, g)
+	if err != nil {
+		t.Fatalf("DescribeReplicationGroupsRequest(...).Send(): %s", err)
+	}
+	if diff := cmp.Diff(v1alpha1.StatusDeleting, aws.StringValue(rg.Status)); diff != "" {
+		t.Errorf("DescribeReplicationGroupsRequest(...).Send(): -want status, +got status:\n%s", diff)
+	}
+	if _, err := describe(t, c, g); !client.IsNotFound(err) {
+		t.Errorf("DescribeReplicationGroupsRequest(...).Send(): want not found error, got %v", err)
+	}
+}
+
+func TestModifyReplicationGroupShardConfiguration(t *testing.T) {
+	cases := map[string]struct {
+		numNodeGroups int
+		want          int
+		wantErr       bool
+	}{
+		"ScaleOut": {
+			numNodeGroups: 2,
+			want:          3,
+		},
+		"ScaleIn": {
+			numNodeGroups: 3,
+			want:          2,
+		},
+		"ClusterModeDisabled": {
+			numNodeGroups: 0,
+			wantErr:       true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := NewClient()
+			g := replicationGroup(tc.numNodeGroups)
+			c.CreateReplicationGroupRequest(client.NewCreateReplicationGroupInput(g, "")).Send() // nolint:errcheck
+			c.Settle()
+
+			rg, err := describe(t, c, g)
+			if err != nil {
+				t.Fatalf("DescribeReplicationGroupsRequest(...).Send(): %s", err)
+			}
+			g.Spec.NumNodeGroups = tc.want
+			_, err = c.ModifyReplicationGroupShardConfigurationRequest(client.NewModifyReplicationGroupShardConfigurationInput(g, rg)).Send()
+			if diff := cmp.Diff(tc.wantErr, err != nil); diff != "" {
+				t.Fatalf("ModifyReplicationGroupShardConfigurationRequest(...).Send(): -want error, +got error:\n%s", diff)
+			}
+			if tc.wantErr {
+				return
+			}
+
+			c.Settle()
+			rg, err = describe(t, c, g)
+			if err != nil {
+				t.Fatalf("DescribeReplicationGroupsRequest(...).Send(): %s", err)
+			}
+			if client.NodeGroupsNeedUpdate(g, rg) {
+				t.Errorf("NodeGroupsNeedUpdate(...): want false for a resharded group")
+			}
+		})
+	}
+}
+
+func TestReplicaCount(t *testing.T) {
+	cases := map[string]struct {
+		replicas int
+		want     string
+	}{
+		"Increase": {
+			replicas: 3,
+			want:     v1alpha1.OperationIncreaseReplicaCount,
+		},
+		"Decrease": {
+			replicas: 1,
+			want:     v1alpha1.OperationDecreaseReplicaCount,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := NewClient()
+			g := replicationGroup(2)
+			g.Spec.ReplicasPerNodeGroup = 2
+			c.CreateReplicationGroupRequest(client.NewCreateReplicationGroupInput(g, "")).Send() // nolint:errcheck
+			c.Settle()
+
+			g.Spec.ReplicasPerNodeGroup = tc.replicas
+			rg, err := describe(t, c, g)
+			if err != nil {
+				t.Fatalf("DescribeReplicationGroupsRequest(...).Send(): %s", err)
+			}
+			op := client.ReplicaCountOperation(g, rg)
+			if diff := cmp.Diff(tc.want, op); diff != "" {
+				t.Fatalf("ReplicaCountOperation(...): -want, +got:\n%s", diff)
+			}
+
+			switch op {
+			case v1alpha1.OperationIncreaseReplicaCount:
+				_, err = c.IncreaseReplicaCountRequest(client.NewIncreaseReplicaCountInput(g)).Send()
+			case v1alpha1.OperationDecreaseReplicaCount:
+				_, err = c.DecreaseReplicaCountRequest(client.NewDecreaseReplicaCountInput(g)).Send()
+			}
+			if err != nil {
+				t.Fatalf("%sRequest(...).Send(): %s", op, err)
+			}
+
+			c.Settle()
+			rg, err = describe(t, c, g)
+			if err != nil {
+				t.Fatalf("DescribeReplicationGroupsRequest(...).Send(): %s", err)
+			}
+			if op := client.ReplicaCountOperation(g, rg); op != "" {
+				t.Errorf("ReplicaCountOperation(...): want no operation for a rescaled group, got %s", op)
+			}
+		})
+	}
+}
+
+func TestInjectedFailure(t *testing.T) {
+	c := NewClient()
+	c.Inject("DescribeReplicationGroups", errBoom, 1)
+	g := replicationGroup(0)
+
+	_, err := describe(t, c, g)
+	if diff := cmp.Diff(errBoom, err, test.EquateErrors()); diff != "" {
+		t.Errorf("DescribeReplicationGroupsRequest(...).Send(): -want error, +got error:\n%s", diff)
+	}
+	_, err = describe(t, c, g)
+	if !client.IsNotFound(err) {
+		t.Errorf("DescribeReplicationGroupsRequest(...).Send(): want not found error, got %v", err)
+	}
+	if diff := cmp.Diff(2, c.Calls("DescribeReplicationGroups")); diff != "" {
+		t.Errorf("c.Calls(...): -want, +got:\n%s", diff)
+	}
+}
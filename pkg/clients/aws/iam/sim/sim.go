@@ -0,0 +1,229 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sim provides a simulated IAM API.
+package sim
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+
+	client "github.com/crossplaneio/crossplane/pkg/clients/aws/iam"
+	"github.com/crossplaneio/crossplane/pkg/clients/sim"
+)
+
+// IAM allows each user at most two access keys.
+const maxAccessKeys = 2
+
+type user struct {
+	keys     []string
+	policies map[string]bool
+}
+
+type policy struct {
+	document string
+	version  int
+}
+
+// A Client is a simulated IAM API. IAM is eventually consistent, but its
+// operations complete synchronously, so the simulated API has no transitional
+// states. As with the real client, a policy is named after the user it is
+// created for and attached to. Errors may be injected into any method, keyed
+// by its name.
+type Client struct {
+	sim.Failures
+
+	mu       sync.Mutex
+	users    map[string]*user
+	policies map[string]*policy
+	keys     int
+}
+
+var _ client.Client = &Client{}
+
+// NewClient returns a simulated IAM API with no users or policies.
+func NewClient() *Client {
+	return &Client{users: map[string]*user{}, policies: map[string]*policy{}}
+}
+
+// CreateUser creates the named user, if it does not already exist, and a new
+// access key for the user.
+func (c *Client) CreateUser(username string) (*iam.AccessKey, error) {
+	if err := c.Call("CreateUser"); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	u, ok := c.users[username]
+	if !ok {
+		u = &user{policies: map[string]bool{}}
+		c.users[username] = u
+	}
+	if len(u.keys) >= maxAccessKeys {
+		err := awserr.New(iam.ErrCodeLimitExceededException, fmt.Sprintf("Cannot exceed quota for AccessKeysPerUser: %d", maxAccessKeys), nil)
+		return nil, fmt.Errorf("failed to create access key, %s", err)
+	}
+
+	c.keys++
+	id := fmt.Sprintf("AKIASIMULATED%07d", c.keys)
+	u.keys = append(u.keys, id)
+	return &iam.AccessKey{
+		AccessKeyId:     aws.String(id),
+		SecretAccessKey: aws.String(fmt.Sprintf("simulated-secret-%07d", c.keys)),
+		UserName:        aws.String(username),
+		Status:          iam.StatusTypeActive,
+	}, nil
+}
+
+// DeleteUser deletes the named user and its access keys. A user cannot be
+// deleted while policies are attached to it.
+func (c *Client) DeleteUser(username string) error {
+	if err := c.Call("DeleteUser"); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	u, ok := c.users[username]
+	if !ok {
+		return noSuchEntity("user", username)
+	}
+	u.keys = nil
+	if len(u.policies) > 0 {
+		return awserr.New(iam.ErrCodeDeleteConflictException, "Cannot delete entity, must detach all policies first.", nil)
+	}
+	delete(c.users, username)
+	return nil
+}
+
+// CreatePolicyAndAttach creates a policy with the supplied document, or
+// updates it if it already exists, and attaches it to the named user.
+func (c *Client) CreatePolicyAndAttach(username string, policyName string, policyDocument string) (string, error) {
+	if err := c.Call("CreatePolicyAndAttach"); err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	p, ok := c.policies[username]
+	if !ok {
+		p = &policy{}
+		c.policies[username] = p
+	}
+	p.document = policyDocument
+	p.version++
+
+	u, ok := c.users[username]
+	if !ok {
+		return "", fmt.Errorf("failed to attach policy, %s", noSuchEntity("user", username))
+	}
+	u.policies[username] = true
+	return version(p), nil
+}
+
+// GetPolicyVersion returns the default version of the named policy.
+func (c *Client) GetPolicyVersion(policyName string) (string, error) {
+	if err := c.Call("GetPolicyVersion"); err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	p, ok := c.policies[policyName]
+	if !ok {
+		return "", noSuchEntity("policy", policyName)
+	}
+	return version(p), nil
+}
+
+// UpdatePolicy creates a new default version of the named policy with the
+// supplied document.
+func (c *Client) UpdatePolicy(policyName string, policyDocument string) (string, error) {
+	if err := c.Call("UpdatePolicy"); err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	p, ok := c.policies[policyName]
+	if !ok {
+		return "", noSuchEntity("policy", policyName)
+	}
+	p.document = policyDocument
+	p.version++
+	return version(p), nil
+}
+
+// DeletePolicyAndDetach detaches the policy of the named user and deletes it.
+// Policies and users that do not exist are ignored.
+func (c *Client) DeletePolicyAndDetach(username string, policyName string) error {
+	if err := c.Call("DeletePolicyAndDetach"); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if u, ok := c.users[username]; ok {
+		delete(u.policies, username)
+	}
+	delete(c.policies, username)
+	return nil
+}
+
+// Users returns the names of all users that exist, in order.
+func (c *Client) Users() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	names := make([]string, 0, len(c.users))
+	for name := range c.users {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PolicyDocument returns the current document of the named policy.
+func (c *Client) PolicyDocument(policyName string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	p, ok := c.policies[policyName]
+	if !ok {
+		return "", false
+	}
+	return p.document, true
+}
+
+func version(p *policy) string {
+	return fmt.Sprintf("v%d", p.version)
+}
+
+func noSuchEntity(kind, name string) error {
+	return awserr.New(iam.ErrCodeNoSuchEntityException, fmt.Sprintf("The %s with name %s cannot be found.", kind, name), nil)
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sim
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/google/go-cmp/cmp"
+)
+
+const username = "cool-user"
+
+func code(err error) string {
+	if ae, ok := err.(awserr.Error); ok {
+		return ae.Code()
+	}
+	return ""
+}
+
+func TestUserLifecycle(t *testing.T) {
+	c := NewClient()
+
+	if _, err := c.CreateUser(username); err != nil {
+		t.Fatalf("c.CreateUser(...): %s", err)
+	}
+	if _, err := c.CreateUser(username); err != nil {
+		t.Fatalf("c.CreateUser(...): %s", err)
+	}
+	if _, err := c.CreateUser(username); err == nil {
+		t.Errorf("c.CreateUser(...): want error creating a third access key, got nil")
+	}
+
+	v, err := c.CreatePolicyAndAttach(username, username, "{}")
+	if err != nil {
+		t.Fatalf("c.CreatePolicyAndAttach(...): %s", err)
+	}
+	if diff := cmp.Diff("v1", v); diff != "" {
+		t.Errorf("c.CreatePolicyAndAttach(...): -want version, +got version:\n%s", diff)
+	}
+	v, err = c.UpdatePolicy(username, `{"Version":"2012-10-17"}`)
+	if err != nil {
+		t.Fatalf("c.UpdatePolicy(...): %s", err)
+	}
+	if got, _ := c.GetPolicyVersion(username); got != v {
+		t.Errorf("c.GetPolicyVersion(...): want %s, got %s", v, got)
+	}
+	if got, _ := c.PolicyDocument(username); got != `{"Version":"2012-10-17"}` {
+		t.Errorf("c.PolicyDocument(...): want updated document, got %s", got)
+	}
+
+	if err := c.DeleteUser(username); code(err) != iam.ErrCodeDeleteConflictException {
+		t.Errorf("c.DeleteUser(...): want delete conflict error, got %v", err)
+	}
+	if err := c.DeletePolicyAndDetach(username, username); err != nil {
+		t.Fatalf("c.DeletePolicyAndDetach(...): %s", err)
+	}
+	if err := c.DeleteUser(username); err != nil {
+		t.Fatalf("c.DeleteUser(...): %s", err)
+	}
+	if diff := cmp.Diff([]string{}, c.Users()); diff != "" {
+		t.Errorf("c.Users(): -want, +got:\n%s", diff)
+	}
+	if _, err := c.GetPolicyVersion(username); code(err) != iam.ErrCodeNoSuchEntityException {
+		t.Errorf("c.GetPolicyVersion(...): want no such entity error, got %v", err)
+	}
+}
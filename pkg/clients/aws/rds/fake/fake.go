@@ -23,9 +23,16 @@ import (
 
 // MockRDSClient for testing.
 type MockRDSClient struct {
-	MockGetInstance    func(string) (*rds.Instance, error)
-	MockCreateInstance func(string, string, *v1alpha1.RDSInstanceSpec) (*rds.Instance, error)
-	MockDeleteInstance func(name string) (*rds.Instance, error)
+	MockGetInstance            func(string) (*rds.Instance, error)
+	MockCreateInstance         func(string, string, *v1alpha1.RDSInstanceSpec) (*rds.Instance, error)
+	MockRestoreInstance        func(string, rds.RestoreSource, *v1alpha1.RDSInstanceSpec) (*rds.Instance, error)
+	MockModifyRestoredInstance func(name, password string, spec *v1alpha1.RDSInstanceSpec) error
+	MockSetDeletionProtection  func(name string, enabled bool) error
+	MockDeleteInstance         func(name, finalSnapshot string) (*rds.Instance, error)
+
+	MockCreateSnapshot func(instance, name string) (*rds.Snapshot, error)
+	MockGetSnapshot    func(name string) (*rds.Snapshot, error)
+	MockDeleteSnapshot func(name string) error
 }
 
 // GetInstance finds RDS Instance by name
@@ -39,6 +46,38 @@ func (m *MockRDSClient) CreateInstance(name, password string, spec *v1alpha1.RDS
 }
 
 // DeleteInstance deletes RDS Instance
-func (m *MockRDSClient) DeleteInstance(name string) (*rds.Instance, error) {
-	return m.MockDeleteInstance(name)
+func (m *MockRDSClient) DeleteInstance(name, finalSnapshot string) (*rds.Instance, error) {
+	return m.MockDeleteInstance(name, finalSnapshot)
+}
+
+// RestoreInstance creates RDS Instance from a snapshot or point in time
+func (m *MockRDSClient) RestoreInstance(name string, src rds.RestoreSource, spec *v1alpha1.RDSInstanceSpec) (*rds.Instance, error) {
+	return m.MockRestoreInstance(name, src, spec)
+}
+
+// ModifyRestoredInstance sets the master password and security groups of a
+// restored RDS Instance
+func (m *MockRDSClient) ModifyRestoredInstance(name, password string, spec *v1alpha1.RDSInstanceSpec) error {
+	return m.MockModifyRestoredInstance(name, password, spec)
+}
+
+// SetDeletionProtection enables or disables deletion protection of RDS
+// Instance
+func (m *MockRDSClient) SetDeletionProtection(name string, enabled bool) error {
+	return m.MockSetDeletionProtection(name, enabled)
+}
+
+// CreateSnapshot creates a DB snapshot of RDS Instance
+func (m *MockRDSClient) CreateSnapshot(instance, name string) (*rds.Snapshot, error) {
+	return m.MockCreateSnapshot(instance, name)
+}
+
+// GetSnapshot finds DB snapshot by name
+func (m *MockRDSClient) GetSnapshot(name string) (*rds.Snapshot, error) {
+	return m.MockGetSnapshot(name)
+}
+
+// DeleteSnapshot deletes DB snapshot
+func (m *MockRDSClient) DeleteSnapshot(name string) error {
+	return m.MockDeleteSnapshot(name)
 }
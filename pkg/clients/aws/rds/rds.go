@@ -18,21 +18,37 @@ package rds
 
 import (
 	"fmt"
+	"net/http"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
 	"github.com/aws/aws-sdk-go-v2/service/rds/rdsiface"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/crossplaneio/crossplane/aws/apis/database/v1alpha1"
+	awsClient "github.com/crossplaneio/crossplane/pkg/clients/aws"
 )
 
+// API is the name of the AWS API used by RDS clients.
+const API = rds.EndpointsID
+
+// CABundleFile is the file from which the PEM encoded certificate authorities
+// that sign RDS instances' certificates are read. Crossplane connects to RDS
+// instances via SQL to manage their users and databases, and only does so
+// over connections verified using these certificate authorities. The
+// Crossplane image includes the bundle published by AWS at this path.
+var CABundleFile = "/etc/crossplane/rds-ca-bundle.pem"
+
 // Instance crossplane representation of the to AWS DBInstance
 type Instance struct {
-	Name     string
-	ARN      string
-	Status   string
-	Endpoint string
+	Name               string
+	ARN                string
+	Status             string
+	Endpoint           string
+	DeletionProtection bool
 }
 
 // NewInstance returns new Instance structure
@@ -43,18 +59,57 @@ func NewInstance(instance *rds.DBInstance) *Instance {
 	}
 
 	return &Instance{
-		Name:     aws.StringValue(instance.DBInstanceIdentifier),
-		ARN:      aws.StringValue(instance.DBInstanceArn),
-		Status:   aws.StringValue(instance.DBInstanceStatus),
-		Endpoint: endpoint,
+		Name:               aws.StringValue(instance.DBInstanceIdentifier),
+		ARN:                aws.StringValue(instance.DBInstanceArn),
+		Status:             aws.StringValue(instance.DBInstanceStatus),
+		Endpoint:           endpoint,
+		DeletionProtection: aws.BoolValue(instance.DeletionProtection),
+	}
+}
+
+// Snapshot crossplane representation of the to AWS DBSnapshot
+type Snapshot struct {
+	Name             string
+	ARN              string
+	Status           string
+	AllocatedStorage int64
+	PercentProgress  int64
+	CreateTime       *time.Time
+}
+
+// NewSnapshot returns new Snapshot structure
+func NewSnapshot(snapshot *rds.DBSnapshot) *Snapshot {
+	return &Snapshot{
+		Name:             aws.StringValue(snapshot.DBSnapshotIdentifier),
+		ARN:              aws.StringValue(snapshot.DBSnapshotArn),
+		Status:           aws.StringValue(snapshot.Status),
+		AllocatedStorage: aws.Int64Value(snapshot.AllocatedStorage),
+		PercentProgress:  aws.Int64Value(snapshot.PercentProgress),
+		CreateTime:       snapshot.SnapshotCreateTime,
 	}
 }
 
+// A RestoreSource identifies the data with which a new instance is seeded.
+// Either SnapshotName, or SourceInstanceName and (optionally) RestoreTime must
+// be set. The latest restorable time is used when RestoreTime is nil.
+type RestoreSource struct {
+	SnapshotName       string
+	SourceInstanceName string
+	RestoreTime        *time.Time
+}
+
 // Client defines RDS RDSClient operations
 type Client interface {
 	CreateInstance(string, string, *v1alpha1.RDSInstanceSpec) (*Instance, error)
+	RestoreInstance(string, RestoreSource, *v1alpha1.RDSInstanceSpec) (*Instance, error)
+	ModifyRestoredInstance(name, password string, spec *v1alpha1.RDSInstanceSpec) error
+	SetDeletionProtection(name string, enabled bool) error
 	GetInstance(name string) (*Instance, error)
-	DeleteInstance(name string) (*Instance, error)
+	DeleteInstance(name, finalSnapshot string) (*Instance, error)
+
+	CreateSnapshot(instance, name string) (*Snapshot, error)
+	GetSnapshot(name string) (*Snapshot, error)
+	DeleteSnapshot(name string) error
 }
 
 type rdsClient struct {
@@ -66,6 +121,20 @@ func NewClient(config *aws.Config) Client {
 	return &rdsClient{rds.New(*config)}
 }
 
+// NewClientWithCredentials creates new RDS RDSClient with the supplied JSON
+// encoded AWS credentials. Requests are made using the supplied HTTP client, if
+// any.
+func NewClientWithCredentials(credentials []byte, region string, hc *http.Client) (Client, error) {
+	cfg, err := awsClient.LoadConfig(credentials, awsClient.DefaultSection, region)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create new AWS configuration")
+	}
+	if hc != nil {
+		cfg.HTTPClient = hc
+	}
+	return NewClient(cfg), nil
+}
+
 // CreateInstance creates RDS Instance with provided Specification
 func (r *rdsClient) CreateInstance(name, password string, spec *v1alpha1.RDSInstanceSpec) (*Instance, error) {
 	input := CreateDBInstanceInput(name, password, spec)
@@ -77,6 +146,45 @@ func (r *rdsClient) CreateInstance(name, password string, spec *v1alpha1.RDSInst
 	return NewInstance(output.DBInstance), nil
 }
 
+// RestoreInstance creates RDS Instance with provided Specification, seeded
+// with the data of the supplied RestoreSource
+func (r *rdsClient) RestoreInstance(name string, src RestoreSource, spec *v1alpha1.RDSInstanceSpec) (*Instance, error) {
+	if src.SnapshotName != "" {
+		output, err := r.rds.RestoreDBInstanceFromDBSnapshotRequest(RestoreDBInstanceFromDBSnapshotInput(name, src.SnapshotName, spec)).Send()
+		if err != nil {
+			return nil, err
+		}
+		return NewInstance(output.DBInstance), nil
+	}
+
+	output, err := r.rds.RestoreDBInstanceToPointInTimeRequest(RestoreDBInstanceToPointInTimeInput(name, src, spec)).Send()
+	if err != nil {
+		return nil, err
+	}
+	return NewInstance(output.DBInstance), nil
+}
+
+// ModifyRestoredInstance immediately sets the master password and VPC
+// security groups of an RDS Instance that was restored from a snapshot or
+// point in time. Restored instances keep the master password of their
+// source, and cannot be given security groups when they are restored.
+func (r *rdsClient) ModifyRestoredInstance(name, password string, spec *v1alpha1.RDSInstanceSpec) error {
+	_, err := r.rds.ModifyDBInstanceRequest(ModifyRestoredDBInstanceInput(name, password, spec)).Send()
+	return err
+}
+
+// SetDeletionProtection immediately enables or disables deletion protection
+// of RDS Instance
+func (r *rdsClient) SetDeletionProtection(name string, enabled bool) error {
+	input := rds.ModifyDBInstanceInput{
+		DBInstanceIdentifier: aws.String(name),
+		DeletionProtection:   aws.Bool(enabled),
+		ApplyImmediately:     aws.Bool(true),
+	}
+	_, err := r.rds.ModifyDBInstanceRequest(&input).Send()
+	return err
+}
+
 // GetInstance finds RDS Instance by name
 func (r *rdsClient) GetInstance(name string) (*Instance, error) {
 	input := rds.DescribeDBInstancesInput{DBInstanceIdentifier: &name}
@@ -93,12 +201,17 @@ func (r *rdsClient) GetInstance(name string) (*Instance, error) {
 	return NewInstance(&output.DBInstances[0]), nil
 }
 
-// DeleteInstance deletes RDS Instance
-func (r *rdsClient) DeleteInstance(name string) (*Instance, error) {
+// DeleteInstance deletes RDS Instance. A final DB snapshot of the supplied
+// name is taken before the instance is deleted, unless the name is empty.
+func (r *rdsClient) DeleteInstance(name, finalSnapshot string) (*Instance, error) {
 	input := rds.DeleteDBInstanceInput{
 		DBInstanceIdentifier: &name,
 		SkipFinalSnapshot:    aws.Bool(true),
 	}
+	if finalSnapshot != "" {
+		input.SkipFinalSnapshot = aws.Bool(false)
+		input.FinalDBSnapshotIdentifier = aws.String(finalSnapshot)
+	}
 	output, err := r.rds.DeleteDBInstanceRequest(&input).Send()
 	if err != nil {
 		return nil, err
@@ -107,17 +220,78 @@ func (r *rdsClient) DeleteInstance(name string) (*Instance, error) {
 	return NewInstance(output.DBInstance), nil
 }
 
+// CreateSnapshot creates a manual DB snapshot of RDS Instance
+func (r *rdsClient) CreateSnapshot(instance, name string) (*Snapshot, error) {
+	input := rds.CreateDBSnapshotInput{
+		DBInstanceIdentifier: aws.String(instance),
+		DBSnapshotIdentifier: aws.String(name),
+	}
+	output, err := r.rds.CreateDBSnapshotRequest(&input).Send()
+	if err != nil {
+		return nil, err
+	}
+	return NewSnapshot(output.DBSnapshot), nil
+}
+
+// GetSnapshot finds DB snapshot by name
+func (r *rdsClient) GetSnapshot(name string) (*Snapshot, error) {
+	input := rds.DescribeDBSnapshotsInput{DBSnapshotIdentifier: aws.String(name)}
+	output, err := r.rds.DescribeDBSnapshotsRequest(&input).Send()
+	if err != nil {
+		return nil, err
+	}
+
+	outputCount := len(output.DBSnapshots)
+	if outputCount == 0 || outputCount > 1 {
+		return nil, fmt.Errorf("rds snapshot [%s] is not found", name)
+	}
+
+	return NewSnapshot(&output.DBSnapshots[0]), nil
+}
+
+// DeleteSnapshot deletes DB snapshot
+func (r *rdsClient) DeleteSnapshot(name string) error {
+	input := rds.DeleteDBSnapshotInput{DBSnapshotIdentifier: aws.String(name)}
+	_, err := r.rds.DeleteDBSnapshotRequest(&input).Send()
+	return err
+}
+
+// NewSnapshotName returns a DB snapshot identifier for the supplied object.
+// Identifiers must begin with a letter, so the object's UID is prefixed.
+func NewSnapshotName(o metav1.Object) string {
+	return fmt.Sprintf("snapshot-%s", o.GetUID())
+}
+
+// FinalSnapshotName returns the identifier of the DB snapshot taken before
+// the supplied RDS Instance is deleted at the supplied time. The time keeps
+// the identifier unique when an instance of the same name is deleted again,
+// while retries of the same deletion use the same identifier.
+func FinalSnapshotName(instance string, deleted time.Time) string {
+	return fmt.Sprintf("final-%s-%d", instance, deleted.Unix())
+}
+
 // IsErrorAlreadyExists returns true if the supplied error indicates a cluster
 // does already exists.
 func IsErrorAlreadyExists(err error) bool {
 	return strings.Contains(err.Error(), rds.ErrCodeDBClusterAlreadyExistsFault)
 }
 
+// IsErrorSnapshotAlreadyExists returns true if the supplied error indicates a
+// DB snapshot already exists.
+func IsErrorSnapshotAlreadyExists(err error) bool {
+	return strings.Contains(err.Error(), rds.ErrCodeDBSnapshotAlreadyExistsFault)
+}
+
 // IsErrorNotFound helper function to test for ErrCodeDBInstanceNotFoundFault error
 func IsErrorNotFound(err error) bool {
 	return strings.Contains(err.Error(), rds.ErrCodeDBInstanceNotFoundFault)
 }
 
+// IsErrorSnapshotNotFound helper function to test for ErrCodeDBSnapshotNotFoundFault error
+func IsErrorSnapshotNotFound(err error) bool {
+	return strings.Contains(err.Error(), rds.ErrCodeDBSnapshotNotFoundFault)
+}
+
 // CreateDBInstanceInput from RDSInstanceSpec
 func CreateDBInstanceInput(name, password string, spec *v1alpha1.RDSInstanceSpec) *rds.CreateDBInstanceInput {
 	return &rds.CreateDBInstanceInput{
@@ -128,9 +302,49 @@ func CreateDBInstanceInput(name, password string, spec *v1alpha1.RDSInstanceSpec
 		EngineVersion:         aws.String(spec.EngineVersion),
 		MasterUsername:        aws.String(spec.MasterUsername),
 		MasterUserPassword:    aws.String(password),
-		BackupRetentionPeriod: aws.Int64(0),
-		VpcSecurityGroupIds:   spec.SecurityGroups,
+		BackupRetentionPeriod: aws.Int64(spec.BackupRetentionPeriod),
+		VpcSecurityGroupIds:   spec.AllSecurityGroups(),
 		PubliclyAccessible:    aws.Bool(true),
 		DBSubnetGroupName:     aws.String(spec.SubnetGroupName),
+		DeletionProtection:    spec.DeletionProtection,
+	}
+}
+
+// RestoreDBInstanceFromDBSnapshotInput from RDSInstanceSpec
+func RestoreDBInstanceFromDBSnapshotInput(name, snapshot string, spec *v1alpha1.RDSInstanceSpec) *rds.RestoreDBInstanceFromDBSnapshotInput {
+	return &rds.RestoreDBInstanceFromDBSnapshotInput{
+		DBInstanceIdentifier: aws.String(name),
+		DBSnapshotIdentifier: aws.String(snapshot),
+		DBInstanceClass:      aws.String(spec.Class),
+		Engine:               aws.String(spec.Engine),
+		PubliclyAccessible:   aws.Bool(true),
+		DBSubnetGroupName:    aws.String(spec.SubnetGroupName),
+		DeletionProtection:   spec.DeletionProtection,
+	}
+}
+
+// RestoreDBInstanceToPointInTimeInput from RDSInstanceSpec
+func RestoreDBInstanceToPointInTimeInput(name string, src RestoreSource, spec *v1alpha1.RDSInstanceSpec) *rds.RestoreDBInstanceToPointInTimeInput {
+	return &rds.RestoreDBInstanceToPointInTimeInput{
+		TargetDBInstanceIdentifier: aws.String(name),
+		SourceDBInstanceIdentifier: aws.String(src.SourceInstanceName),
+		RestoreTime:                src.RestoreTime,
+		UseLatestRestorableTime:    aws.Bool(src.RestoreTime == nil),
+		DBInstanceClass:            aws.String(spec.Class),
+		Engine:                     aws.String(spec.Engine),
+		PubliclyAccessible:         aws.Bool(true),
+		DBSubnetGroupName:          aws.String(spec.SubnetGroupName),
+		DeletionProtection:         spec.DeletionProtection,
+	}
+}
+
+// ModifyRestoredDBInstanceInput from RDSInstanceSpec, setting the master
+// password and the VPC security groups that cannot be set on restore
+func ModifyRestoredDBInstanceInput(name, password string, spec *v1alpha1.RDSInstanceSpec) *rds.ModifyDBInstanceInput {
+	return &rds.ModifyDBInstanceInput{
+		DBInstanceIdentifier: aws.String(name),
+		MasterUserPassword:   aws.String(password),
+		VpcSecurityGroupIds:  spec.AllSecurityGroups(),
+		ApplyImmediately:     aws.Bool(true),
 	}
 }
@@ -0,0 +1,410 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sim provides a simulated RDS API.
+package sim
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	awsrds "github.com/aws/aws-sdk-go-v2/service/rds"
+
+	"github.com/crossplaneio/crossplane/aws/apis/database/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/aws/rds"
+	"github.com/crossplaneio/crossplane/pkg/clients/sim"
+)
+
+// RDS instance and snapshot states.
+const (
+	stateCreating                   = string(v1alpha1.RDSInstanceStateCreating)
+	stateAvailable                  = string(v1alpha1.RDSInstanceStateAvailable)
+	stateModifying                  = string(v1alpha1.RDSInstanceStateModifying)
+	stateResettingMasterCredentials = string(v1alpha1.RDSInstanceStateResettingMasterCredentials)
+	stateDeleting                   = string(v1alpha1.RDSInstanceStateDeleting)
+)
+
+var errProtected = awserr.New("InvalidParameterCombination", "Cannot delete protected DB Instance, please disable deletion protection and try again.", nil)
+
+type dbInstance struct {
+	lifecycle          *sim.Lifecycle
+	spec               v1alpha1.RDSInstanceSpec
+	password           string
+	deletionProtection bool
+}
+
+type dbSnapshot struct {
+	lifecycle *sim.Lifecycle
+	instance  string
+	password  string
+	size      int64
+	created   time.Time
+}
+
+// A Client is a simulated RDS API. Instances and snapshots are created in the
+// "creating" state and become "available" after they have been observed
+// Polls times. Instances are modified and deleted asynchronously in the same
+// way. Errors may be injected into any method, keyed by its name.
+type Client struct {
+	sim.Failures
+
+	// Polls is the number of times an instance or snapshot in a transitional
+	// state must be observed before it settles.
+	Polls int
+
+	mu        sync.Mutex
+	instances map[string]*dbInstance
+	snapshots map[string]*dbSnapshot
+}
+
+var _ rds.Client = &Client{}
+
+// NewClient returns a simulated RDS API with no instances or snapshots.
+func NewClient() *Client {
+	return &Client{
+		Polls:     sim.DefaultPolls,
+		instances: map[string]*dbInstance{},
+		snapshots: map[string]*dbSnapshot{},
+	}
+}
+
+// CreateInstance creates an instance with the supplied name, master password,
+// and spec.
+func (c *Client) CreateInstance(name, password string, spec *v1alpha1.RDSInstanceSpec) (*rds.Instance, error) {
+	if err := c.Call("CreateInstance"); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.instances[name]; ok {
+		return nil, awserr.New(awsrds.ErrCodeDBInstanceAlreadyExistsFault, fmt.Sprintf("DB instance %s already exists.", name), nil)
+	}
+	i := &dbInstance{
+		lifecycle:          sim.NewLifecycle(stateCreating).Then(stateAvailable, c.Polls),
+		spec:               *spec,
+		password:           password,
+		deletionProtection: aws.BoolValue(spec.DeletionProtection),
+	}
+	c.instances[name] = i
+	return view(name, i), nil
+}
+
+// RestoreInstance creates an instance with the supplied name and spec from
+// the supplied snapshot or source instance. The new instance has the master
+// password of its source.
+func (c *Client) RestoreInstance(name string, src rds.RestoreSource, spec *v1alpha1.RDSInstanceSpec) (*rds.Instance, error) {
+	if err := c.Call("RestoreInstance"); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.instances[name]; ok {
+		return nil, awserr.New(awsrds.ErrCodeDBInstanceAlreadyExistsFault, fmt.Sprintf("DB instance %s already exists.", name), nil)
+	}
+
+	password := ""
+	switch {
+	case src.SnapshotName != "":
+		s, ok := c.snapshots[src.SnapshotName]
+		if !ok {
+			return nil, snapshotNotFound(src.SnapshotName)
+		}
+		if s.lifecycle.State() != stateAvailable {
+			return nil, awserr.New(awsrds.ErrCodeInvalidDBSnapshotStateFault, fmt.Sprintf("DB snapshot %s is not available.", src.SnapshotName), nil)
+		}
+		password = s.password
+	default:
+		s, ok := c.instances[src.SourceInstanceName]
+		if !ok {
+			return nil, instanceNotFound(src.SourceInstanceName)
+		}
+		password = s.password
+	}
+
+	i := &dbInstance{
+		lifecycle:          sim.NewLifecycle(stateCreating).Then(stateAvailable, c.Polls),
+		spec:               *spec,
+		password:           password,
+		deletionProtection: aws.BoolValue(spec.DeletionProtection),
+	}
+	c.instances[name] = i
+	return view(name, i), nil
+}
+
+// ModifyRestoredInstance sets the master password and security groups of the
+// named instance, which must be available.
+func (c *Client) ModifyRestoredInstance(name, password string, spec *v1alpha1.RDSInstanceSpec) error {
+	if err := c.Call("ModifyRestoredInstance"); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i, err := c.available(name)
+	if err != nil {
+		return err
+	}
+	i.password = password
+	i.spec.SecurityGroups = spec.SecurityGroups
+	i.lifecycle.Set(stateResettingMasterCredentials).Then(stateAvailable, c.Polls)
+	return nil
+}
+
+// SetDeletionProtection enables or disables deletion protection of the named
+// instance, which must be available.
+func (c *Client) SetDeletionProtection(name string, enabled bool) error {
+	if err := c.Call("SetDeletionProtection"); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i, err := c.available(name)
+	if err != nil {
+		return err
+	}
+	i.deletionProtection = enabled
+	i.lifecycle.Set(stateModifying).Then(stateAvailable, c.Polls)
+	return nil
+}
+
+// GetInstance observes the named instance.
+func (c *Client) GetInstance(name string) (*rds.Instance, error) {
+	if err := c.Call("GetInstance"); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i, ok := c.instances[name]
+	if !ok {
+		return nil, instanceNotFound(name)
+	}
+	if i.lifecycle.Observe() == sim.Deleted {
+		delete(c.instances, name)
+		return nil, instanceNotFound(name)
+	}
+	return view(name, i), nil
+}
+
+// DeleteInstance starts deleting the named instance. A snapshot of the
+// instance is taken first if a final snapshot name is supplied. Instances
+// with deletion protection enabled cannot be deleted.
+func (c *Client) DeleteInstance(name, finalSnapshot string) (*rds.Instance, error) {
+	if err := c.Call("DeleteInstance"); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i, ok := c.instances[name]
+	if !ok {
+		return nil, instanceNotFound(name)
+	}
+	if i.lifecycle.State() == stateDeleting {
+		return nil, awserr.New(awsrds.ErrCodeInvalidDBInstanceStateFault, fmt.Sprintf("DB instance %s is already being deleted.", name), nil)
+	}
+	if i.deletionProtection {
+		return nil, errProtected
+	}
+	if finalSnapshot != "" {
+		if _, ok := c.snapshots[finalSnapshot]; ok {
+			return nil, awserr.New(awsrds.ErrCodeDBSnapshotAlreadyExistsFault, fmt.Sprintf("DB snapshot %s already exists.", finalSnapshot), nil)
+		}
+		c.snapshots[finalSnapshot] = c.snapshotOf(name, i)
+	}
+	i.lifecycle.Set(stateDeleting).Then(sim.Deleted, c.Polls)
+	return view(name, i), nil
+}
+
+// CreateSnapshot starts taking a snapshot of the named instance, which must be
+// available.
+func (c *Client) CreateSnapshot(instance, name string) (*rds.Snapshot, error) {
+	if err := c.Call("CreateSnapshot"); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i, err := c.available(instance)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := c.snapshots[name]; ok {
+		return nil, awserr.New(awsrds.ErrCodeDBSnapshotAlreadyExistsFault, fmt.Sprintf("DB snapshot %s already exists.", name), nil)
+	}
+	s := c.snapshotOf(instance, i)
+	c.snapshots[name] = s
+	return snapshotView(name, s), nil
+}
+
+// GetSnapshot observes the named snapshot.
+func (c *Client) GetSnapshot(name string) (*rds.Snapshot, error) {
+	if err := c.Call("GetSnapshot"); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, ok := c.snapshots[name]
+	if !ok {
+		return nil, snapshotNotFound(name)
+	}
+	s.lifecycle.Observe()
+	return snapshotView(name, s), nil
+}
+
+// DeleteSnapshot deletes the named snapshot.
+func (c *Client) DeleteSnapshot(name string) error {
+	if err := c.Call("DeleteSnapshot"); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.snapshots[name]; !ok {
+		return snapshotNotFound(name)
+	}
+	delete(c.snapshots, name)
+	return nil
+}
+
+// Settle completes all pending state transitions immediately.
+func (c *Client) Settle() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for name, i := range c.instances {
+		if i.lifecycle.Settle() == sim.Deleted {
+			delete(c.instances, name)
+		}
+	}
+	for _, s := range c.snapshots {
+		s.lifecycle.Settle()
+	}
+}
+
+// Instances returns the names of all instances that exist, including those
+// that are being created or deleted, in order.
+func (c *Client) Instances() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	names := make([]string, 0, len(c.instances))
+	for name := range c.instances {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Snapshots returns the names of all snapshots that exist, in order.
+func (c *Client) Snapshots() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	names := make([]string, 0, len(c.snapshots))
+	for name := range c.snapshots {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// MasterPassword returns the master password of the named instance.
+func (c *Client) MasterPassword(name string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i, ok := c.instances[name]
+	if !ok {
+		return "", false
+	}
+	return i.password, true
+}
+
+func (c *Client) available(name string) (*dbInstance, error) {
+	i, ok := c.instances[name]
+	if !ok {
+		return nil, instanceNotFound(name)
+	}
+	if i.lifecycle.State() != stateAvailable {
+		return nil, awserr.New(awsrds.ErrCodeInvalidDBInstanceStateFault, fmt.Sprintf("DB instance %s is not in available state.", name), nil)
+	}
+	return i, nil
+}
+
+func (c *Client) snapshotOf(name string, i *dbInstance) *dbSnapshot {
+	return &dbSnapshot{
+		lifecycle: sim.NewLifecycle(stateCreating).Then(stateAvailable, c.Polls),
+		instance:  name,
+		password:  i.password,
+		size:      i.spec.Size,
+		created:   time.Now(),
+	}
+}
+
+func view(name string, i *dbInstance) *rds.Instance {
+	in := &rds.Instance{
+		Name:               name,
+		ARN:                fmt.Sprintf("arn:aws:rds:sim-region-1:000000000000:db:%s", name),
+		Status:             i.lifecycle.State(),
+		DeletionProtection: i.deletionProtection,
+	}
+	if in.Status != stateCreating {
+		in.Endpoint = fmt.Sprintf("%s.sim-region-1.rds.amazonaws.com", name)
+	}
+	return in
+}
+
+func snapshotView(name string, s *dbSnapshot) *rds.Snapshot {
+	progress := int64(0)
+	if s.lifecycle.State() == stateAvailable {
+		progress = 100
+	}
+	created := s.created
+	return &rds.Snapshot{
+		Name:             name,
+		ARN:              fmt.Sprintf("arn:aws:rds:sim-region-1:000000000000:snapshot:%s", name),
+		Status:           s.lifecycle.State(),
+		AllocatedStorage: s.size,
+		PercentProgress:  progress,
+		CreateTime:       &created,
+	}
+}
+
+func instanceNotFound(name string) error {
+	return awserr.New(awsrds.ErrCodeDBInstanceNotFoundFault, fmt.Sprintf("DBInstance %s not found.", name), nil)
+}
+
+func snapshotNotFound(name string) error {
+	return awserr.New(awsrds.ErrCodeDBSnapshotNotFoundFault, fmt.Sprintf("DBSnapshot %s not found.", name), nil)
+}
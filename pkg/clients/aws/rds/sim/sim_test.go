@@ -0,0 +1,203 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sim
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+
+	"github.com/crossplaneio/crossplane/aws/apis/database/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/aws/rds"
+)
+
+const (
+	instanceName = "cool-instance"
+	snapshotName = "cool-snapshot"
+	password     = "hunter2"
+)
+
+var errBoom = errors.New("boom")
+
+func TestInstanceLifecycle(t *testing.T) {
+	c := NewClient()
+	spec := &v1alpha1.RDSInstanceSpec{}
+
+	if _, err := c.CreateInstance(instanceName, password, spec); err != nil {
+		t.Fatalf("c.CreateInstance(...): %s", err)
+	}
+	if _, err := c.CreateInstance(instanceName, password, spec); err == nil {
+		t.Errorf("c.CreateInstance(...): want error creating existing instance, got nil")
+	}
+
+	want := []string{stateCreating, stateAvailable, stateAvailable}
+	for _, w := range want {
+		i, err := c.GetInstance(instanceName)
+		if err != nil {
+			t.Fatalf("c.GetInstance(...): %s", err)
+		}
+		if diff := cmp.Diff(w, i.Status); diff != "" {
+			t.Errorf("c.GetInstance(...): -want status, +got status:\n%s", diff)
+		}
+	}
+
+	if err := c.ModifyRestoredInstance(instanceName, "new", spec); err != nil {
+		t.Fatalf("c.ModifyRestoredInstance(...): %s", err)
+	}
+	if got, _ := c.MasterPassword(instanceName); got != "new" {
+		t.Errorf("c.MasterPassword(...): want new, got %s", got)
+	}
+	if err := c.ModifyRestoredInstance(instanceName, "newer", spec); err == nil {
+		t.Errorf("c.ModifyRestoredInstance(...): want error modifying unavailable instance, got nil")
+	}
+	c.Settle()
+
+	if _, err := c.DeleteInstance(instanceName, ""); err != nil {
+		t.Fatalf("c.DeleteInstance(...): %s", err)
+	}
+	i, err := c.GetInstance(instanceName)
+	if err != nil {
+		t.Fatalf("c.GetInstance(...): %s", err)
+	}
+	if diff := cmp.Diff(stateDeleting, i.Status); diff != "" {
+		t.Errorf("c.GetInstance(...): -want status, +got status:\n%s", diff)
+	}
+	if _, err := c.GetInstance(instanceName); !rds.IsErrorNotFound(err) {
+		t.Errorf("c.GetInstance(...): want not found error, got %v", err)
+	}
+	if diff := cmp.Diff([]string{}, c.Instances()); diff != "" {
+		t.Errorf("c.Instances(): -want, +got:\n%s", diff)
+	}
+}
+
+func TestDeleteInstance(t *testing.T) {
+	type want struct {
+		err      error
+		snapshot bool
+	}
+
+	cases := map[string]struct {
+		c             *Client
+		finalSnapshot string
+		want          want
+	}{
+		"InjectedError": {
+			c: func() *Client {
+				c := NewClient()
+				c.Inject("DeleteInstance", errBoom, 1)
+				return c
+			}(),
+			want: want{err: errBoom},
+		},
+		"NotFound": {
+			c:    NewClient(),
+			want: want{err: instanceNotFound(instanceName)},
+		},
+		"DeletionProtection": {
+			c: func() *Client {
+				c := NewClient()
+				c.CreateInstance(instanceName, password, &v1alpha1.RDSInstanceSpec{ // nolint:errcheck
+					RDSInstanceParameters: v1alpha1.RDSInstanceParameters{DeletionProtection: aws.Bool(true)},
+				})
+				return c
+			}(),
+			want: want{err: errProtected},
+		},
+		"FinalSnapshot": {
+			c: func() *Client {
+				c := NewClient()
+				c.CreateInstance(instanceName, password, &v1alpha1.RDSInstanceSpec{}) // nolint:errcheck
+				return c
+			}(),
+			finalSnapshot: snapshotName,
+			want:          want{snapshot: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.c.DeleteInstance(instanceName, tc.finalSnapshot)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("c.DeleteInstance(...): -want error, +got error:\n%s", diff)
+			}
+			_, err = tc.c.GetSnapshot(snapshotName)
+			if diff := cmp.Diff(tc.want.snapshot, err == nil); diff != "" {
+				t.Errorf("c.GetSnapshot(...): -want exists, +got exists:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRestoreInstance(t *testing.T) {
+	const restored = "restored-instance"
+
+	cases := map[string]struct {
+		src     rds.RestoreSource
+		settle  bool
+		wantErr bool
+	}{
+		"FromSnapshot": {
+			src:    rds.RestoreSource{SnapshotName: snapshotName},
+			settle: true,
+		},
+		"FromUnavailableSnapshot": {
+			src:     rds.RestoreSource{SnapshotName: snapshotName},
+			wantErr: true,
+		},
+		"FromMissingSnapshot": {
+			src:     rds.RestoreSource{SnapshotName: "missing"},
+			settle:  true,
+			wantErr: true,
+		},
+		"FromInstance": {
+			src:    rds.RestoreSource{SourceInstanceName: instanceName},
+			settle: true,
+		},
+		"FromMissingInstance": {
+			src:     rds.RestoreSource{SourceInstanceName: "missing"},
+			settle:  true,
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := NewClient()
+			c.CreateInstance(instanceName, password, &v1alpha1.RDSInstanceSpec{}) // nolint:errcheck
+			c.Settle()
+			c.CreateSnapshot(instanceName, snapshotName) // nolint:errcheck
+			if tc.settle {
+				c.Settle()
+			}
+
+			_, err := c.RestoreInstance(restored, tc.src, &v1alpha1.RDSInstanceSpec{})
+			if diff := cmp.Diff(tc.wantErr, err != nil); diff != "" {
+				t.Fatalf("c.RestoreInstance(...): -want error, +got error:\n%s", diff)
+			}
+			if tc.wantErr {
+				return
+			}
+			if got, _ := c.MasterPassword(restored); got != password {
+				t.Errorf("c.MasterPassword(...): want %s, got %s", password, got)
+			}
+		})
+	}
+}
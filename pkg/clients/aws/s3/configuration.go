@@ -0,0 +1,532 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/crossplaneio/crossplane/aws/apis/storage/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/aws/s3/operations"
+)
+
+// Error codes returned by S3 when a bucket has no configuration of a
+// particular kind.
+const (
+	errCodeNoSuchLifecycleConfiguration  = "NoSuchLifecycleConfiguration"
+	errCodeNoSuchCORSConfiguration       = "NoSuchCORSConfiguration"
+	errCodeNoSuchEncryptionConfiguration = "ServerSideEncryptionConfigurationNotFoundError"
+	errCodeNoSuchWebsiteConfiguration    = "NoSuchWebsiteConfiguration"
+	errCodeNoSuchTagSet                  = "NoSuchTagSet"
+)
+
+// UpdateLifecycleConfiguration replaces the bucket's lifecycle rules if they
+// differ from those of the supplied S3Bucket, or removes them if the
+// S3Bucket specifies none.
+func (c *Client) UpdateLifecycleConfiguration(bucket *v1alpha1.S3Bucket) error {
+	name := aws.String(bucket.GetBucketName())
+
+	var observed []s3.LifecycleRule
+	rsp, err := c.s3.GetBucketLifecycleConfigurationRequest(&s3.GetBucketLifecycleConfigurationInput{Bucket: name}).Send()
+	switch {
+	case err == nil:
+		observed = rsp.Rules
+	case !isErrorCode(err, errCodeNoSuchLifecycleConfiguration):
+		return err
+	}
+
+	desired := NewLifecycleRules(bucket.Spec.LifecycleRules)
+	if reflect.DeepEqual(GenerateLifecycleRules(desired), GenerateLifecycleRules(observed)) {
+		return nil
+	}
+
+	if len(desired) == 0 {
+		_, err := c.s3.DeleteBucketLifecycleRequest(&s3.DeleteBucketLifecycleInput{Bucket: name}).Send()
+		return err
+	}
+
+	input := &s3.PutBucketLifecycleConfigurationInput{
+		Bucket:                 name,
+		LifecycleConfiguration: &s3.BucketLifecycleConfiguration{Rules: desired},
+	}
+	_, err = c.s3.PutBucketLifecycleConfigurationRequest(input).Send()
+	return err
+}
+
+// UpdateCORS replaces the bucket's CORS rules if they differ from those of
+// the supplied S3Bucket, or removes them if the S3Bucket specifies none.
+func (c *Client) UpdateCORS(bucket *v1alpha1.S3Bucket) error {
+	name := aws.String(bucket.GetBucketName())
+
+	var observed []s3.CORSRule
+	rsp, err := c.s3.GetBucketCORSRequest(&s3.GetBucketCorsInput{Bucket: name}).Send()
+	switch {
+	case err == nil:
+		observed = rsp.CORSRules
+	case !isErrorCode(err, errCodeNoSuchCORSConfiguration):
+		return err
+	}
+
+	desired := NewCORSRules(bucket.Spec.CORSRules)
+	if reflect.DeepEqual(GenerateCORSRules(desired), GenerateCORSRules(observed)) {
+		return nil
+	}
+
+	if len(desired) == 0 {
+		_, err := c.s3.DeleteBucketCORSRequest(&s3.DeleteBucketCorsInput{Bucket: name}).Send()
+		return err
+	}
+
+	input := &s3.PutBucketCorsInput{
+		Bucket:            name,
+		CORSConfiguration: &s3.CORSConfiguration{CORSRules: desired},
+	}
+	_, err = c.s3.PutBucketCORSRequest(input).Send()
+	return err
+}
+
+// UpdateEncryption replaces the bucket's default encryption configuration if
+// it differs from that of the supplied S3Bucket, or removes it if the S3Bucket
+// specifies none.
+func (c *Client) UpdateEncryption(bucket *v1alpha1.S3Bucket) error {
+	name := aws.String(bucket.GetBucketName())
+
+	var observed *s3.ServerSideEncryptionConfiguration
+	rsp, err := c.s3.GetBucketEncryptionRequest(&s3.GetBucketEncryptionInput{Bucket: name}).Send()
+	switch {
+	case err == nil:
+		observed = rsp.ServerSideEncryptionConfiguration
+	case !isErrorCode(err, errCodeNoSuchEncryptionConfiguration):
+		return err
+	}
+
+	if reflect.DeepEqual(bucket.Spec.Encryption, GenerateBucketEncryption(observed)) {
+		return nil
+	}
+
+	if bucket.Spec.Encryption == nil {
+		_, err := c.s3.DeleteBucketEncryptionRequest(&s3.DeleteBucketEncryptionInput{Bucket: name}).Send()
+		return err
+	}
+
+	input := &s3.PutBucketEncryptionInput{
+		Bucket:                            name,
+		ServerSideEncryptionConfiguration: NewServerSideEncryptionConfiguration(bucket.Spec.Encryption),
+	}
+	_, err = c.s3.PutBucketEncryptionRequest(input).Send()
+	return err
+}
+
+// UpdateLogging enables or disables server access logging for the bucket in
+// accordance with the supplied S3Bucket.
+func (c *Client) UpdateLogging(bucket *v1alpha1.S3Bucket) error {
+	name := aws.String(bucket.GetBucketName())
+
+	rsp, err := c.s3.GetBucketLoggingRequest(&s3.GetBucketLoggingInput{Bucket: name}).Send()
+	if err != nil {
+		return err
+	}
+
+	if reflect.DeepEqual(bucket.Spec.Logging, GenerateBucketLogging(rsp.LoggingEnabled)) {
+		return nil
+	}
+
+	// Logging is disabled by supplying an empty logging status.
+	input := &s3.PutBucketLoggingInput{
+		Bucket:              name,
+		BucketLoggingStatus: &s3.BucketLoggingStatus{LoggingEnabled: NewLoggingEnabled(bucket.Spec.Logging)},
+	}
+	_, err = c.s3.PutBucketLoggingRequest(input).Send()
+	return err
+}
+
+// UpdateWebsite replaces the bucket's static website configuration if it
+// differs from that of the supplied S3Bucket, or removes it if the S3Bucket
+// specifies none.
+func (c *Client) UpdateWebsite(bucket *v1alpha1.S3Bucket) error {
+	name := aws.String(bucket.GetBucketName())
+
+	var observed *v1alpha1.BucketWebsite
+	rsp, err := c.s3.GetBucketWebsiteRequest(&s3.GetBucketWebsiteInput{Bucket: name}).Send()
+	switch {
+	case err == nil:
+		observed = GenerateBucketWebsite(rsp.IndexDocument, rsp.ErrorDocument)
+	case !isErrorCode(err, errCodeNoSuchWebsiteConfiguration):
+		return err
+	}
+
+	if reflect.DeepEqual(bucket.Spec.Website, observed) {
+		return nil
+	}
+
+	if bucket.Spec.Website == nil {
+		_, err := c.s3.DeleteBucketWebsiteRequest(&s3.DeleteBucketWebsiteInput{Bucket: name}).Send()
+		return err
+	}
+
+	input := &s3.PutBucketWebsiteInput{
+		Bucket:               name,
+		WebsiteConfiguration: NewWebsiteConfiguration(bucket.Spec.Website),
+	}
+	_, err = c.s3.PutBucketWebsiteRequest(input).Send()
+	return err
+}
+
+// UpdateTagging replaces the bucket's tags if they differ from those of the
+// supplied S3Bucket, or removes them if the S3Bucket specifies none.
+func (c *Client) UpdateTagging(bucket *v1alpha1.S3Bucket) error {
+	name := aws.String(bucket.GetBucketName())
+
+	var observed []s3.Tag
+	rsp, err := c.s3.GetBucketTaggingRequest(&s3.GetBucketTaggingInput{Bucket: name}).Send()
+	switch {
+	case err == nil:
+		observed = rsp.TagSet
+	case !isErrorCode(err, errCodeNoSuchTagSet):
+		return err
+	}
+
+	if reflect.DeepEqual(emptyMapToNil(bucket.Spec.Tags), GenerateTags(observed)) {
+		return nil
+	}
+
+	if len(bucket.Spec.Tags) == 0 {
+		_, err := c.s3.DeleteBucketTaggingRequest(&s3.DeleteBucketTaggingInput{Bucket: name}).Send()
+		return err
+	}
+
+	input := &s3.PutBucketTaggingInput{
+		Bucket:  name,
+		Tagging: &s3.Tagging{TagSet: NewTagSet(bucket.Spec.Tags)},
+	}
+	_, err = c.s3.PutBucketTaggingRequest(input).Send()
+	return err
+}
+
+// UpdatePublicAccessBlock replaces the bucket's public access block if it
+// differs from that of the supplied S3Bucket, or removes it if the S3Bucket
+// specifies none.
+func (c *Client) UpdatePublicAccessBlock(bucket *v1alpha1.S3Bucket) error {
+	name := aws.String(bucket.GetBucketName())
+
+	var observed *operations.PublicAccessBlockConfiguration
+	rsp, err := c.s3.GetPublicAccessBlockRequest(&operations.GetPublicAccessBlockInput{Bucket: name}).Send()
+	switch {
+	case err == nil:
+		observed = rsp.PublicAccessBlockConfiguration
+	case !isErrorCode(err, operations.ErrCodeNoSuchPublicAccessBlockConfiguration):
+		return err
+	}
+
+	if reflect.DeepEqual(bucket.Spec.PublicAccessBlock, GenerateBucketPublicAccessBlock(observed)) {
+		return nil
+	}
+
+	if bucket.Spec.PublicAccessBlock == nil {
+		_, err := c.s3.DeletePublicAccessBlockRequest(&operations.DeletePublicAccessBlockInput{Bucket: name}).Send()
+		return err
+	}
+
+	input := &operations.PutPublicAccessBlockInput{
+		Bucket:                         name,
+		PublicAccessBlockConfiguration: NewPublicAccessBlockConfiguration(bucket.Spec.PublicAccessBlock),
+	}
+	_, err = c.s3.PutPublicAccessBlockRequest(input).Send()
+	return err
+}
+
+// NewLifecycleRules returns lifecycle rules suitable for use with the AWS API.
+func NewLifecycleRules(rules []v1alpha1.LifecycleRule) []s3.LifecycleRule {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	out := make([]s3.LifecycleRule, len(rules))
+	for i, r := range rules {
+		lr := s3.LifecycleRule{
+			ID:     aws.String(r.ID),
+			Filter: &s3.LifecycleRuleFilter{Prefix: aws.String(r.Prefix)},
+			Status: s3.ExpirationStatusEnabled,
+		}
+		if r.Disabled {
+			lr.Status = s3.ExpirationStatusDisabled
+		}
+		if r.ExpirationDays > 0 {
+			lr.Expiration = &s3.LifecycleExpiration{Days: aws.Int64(int64(r.ExpirationDays))}
+		}
+		if r.NoncurrentVersionExpirationDays > 0 {
+			lr.NoncurrentVersionExpiration = &s3.NoncurrentVersionExpiration{NoncurrentDays: aws.Int64(int64(r.NoncurrentVersionExpirationDays))}
+		}
+		if r.AbortIncompleteMultipartUploadDays > 0 {
+			lr.AbortIncompleteMultipartUpload = &s3.AbortIncompleteMultipartUpload{DaysAfterInitiation: aws.Int64(int64(r.AbortIncompleteMultipartUploadDays))}
+		}
+		for _, t := range r.Transitions {
+			lr.Transitions = append(lr.Transitions, s3.Transition{
+				Days:         aws.Int64(int64(t.Days)),
+				StorageClass: s3.TransitionStorageClass(t.StorageClass),
+			})
+		}
+		out[i] = lr
+	}
+	return out
+}
+
+// GenerateLifecycleRules returns the lifecycle rules of an S3Bucket given the
+// supplied lifecycle rules from the AWS API.
+func GenerateLifecycleRules(rules []s3.LifecycleRule) []v1alpha1.LifecycleRule {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	out := make([]v1alpha1.LifecycleRule, len(rules))
+	for i, lr := range rules {
+		r := v1alpha1.LifecycleRule{
+			ID:       aws.StringValue(lr.ID),
+			Prefix:   aws.StringValue(lr.Prefix),
+			Disabled: lr.Status == s3.ExpirationStatusDisabled,
+		}
+		if lr.Filter != nil && lr.Filter.Prefix != nil {
+			r.Prefix = aws.StringValue(lr.Filter.Prefix)
+		}
+		if lr.Expiration != nil {
+			r.ExpirationDays = int(aws.Int64Value(lr.Expiration.Days))
+		}
+		if lr.NoncurrentVersionExpiration != nil {
+			r.NoncurrentVersionExpirationDays = int(aws.Int64Value(lr.NoncurrentVersionExpiration.NoncurrentDays))
+		}
+		if lr.AbortIncompleteMultipartUpload != nil {
+			r.AbortIncompleteMultipartUploadDays = int(aws.Int64Value(lr.AbortIncompleteMultipartUpload.DaysAfterInitiation))
+		}
+		for _, t := range lr.Transitions {
+			r.Transitions = append(r.Transitions, v1alpha1.LifecycleTransition{
+				Days:         int(aws.Int64Value(t.Days)),
+				StorageClass: string(t.StorageClass),
+			})
+		}
+		out[i] = r
+	}
+	return out
+}
+
+// NewCORSRules returns CORS rules suitable for use with the AWS API.
+func NewCORSRules(rules []v1alpha1.CORSRule) []s3.CORSRule {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	out := make([]s3.CORSRule, len(rules))
+	for i, r := range rules {
+		out[i] = s3.CORSRule{
+			AllowedHeaders: r.AllowedHeaders,
+			AllowedMethods: r.AllowedMethods,
+			AllowedOrigins: r.AllowedOrigins,
+			ExposeHeaders:  r.ExposeHeaders,
+		}
+		if r.MaxAgeSeconds > 0 {
+			out[i].MaxAgeSeconds = aws.Int64(int64(r.MaxAgeSeconds))
+		}
+	}
+	return out
+}
+
+// GenerateCORSRules returns the CORS rules of an S3Bucket given the supplied
+// CORS rules from the AWS API.
+func GenerateCORSRules(rules []s3.CORSRule) []v1alpha1.CORSRule {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	out := make([]v1alpha1.CORSRule, len(rules))
+	for i, r := range rules {
+		out[i] = v1alpha1.CORSRule{
+			AllowedHeaders: emptyToNil(r.AllowedHeaders),
+			AllowedMethods: emptyToNil(r.AllowedMethods),
+			AllowedOrigins: emptyToNil(r.AllowedOrigins),
+			ExposeHeaders:  emptyToNil(r.ExposeHeaders),
+			MaxAgeSeconds:  int(aws.Int64Value(r.MaxAgeSeconds)),
+		}
+	}
+	return out
+}
+
+// NewServerSideEncryptionConfiguration returns a default encryption
+// configuration suitable for use with the AWS API.
+func NewServerSideEncryptionConfiguration(e *v1alpha1.BucketEncryption) *s3.ServerSideEncryptionConfiguration {
+	if e == nil {
+		return nil
+	}
+
+	d := &s3.ServerSideEncryptionByDefault{SSEAlgorithm: s3.ServerSideEncryption(e.Algorithm)}
+	if e.KMSMasterKeyID != "" {
+		d.KMSMasterKeyID = aws.String(e.KMSMasterKeyID)
+	}
+	return &s3.ServerSideEncryptionConfiguration{
+		Rules: []s3.ServerSideEncryptionRule{{ApplyServerSideEncryptionByDefault: d}},
+	}
+}
+
+// GenerateBucketEncryption returns the encryption configuration of an
+// S3Bucket given the supplied configuration from the AWS API.
+func GenerateBucketEncryption(c *s3.ServerSideEncryptionConfiguration) *v1alpha1.BucketEncryption {
+	if c == nil || len(c.Rules) == 0 || c.Rules[0].ApplyServerSideEncryptionByDefault == nil {
+		return nil
+	}
+	d := c.Rules[0].ApplyServerSideEncryptionByDefault
+	return &v1alpha1.BucketEncryption{
+		Algorithm:      string(d.SSEAlgorithm),
+		KMSMasterKeyID: aws.StringValue(d.KMSMasterKeyID),
+	}
+}
+
+// NewLoggingEnabled returns a logging configuration suitable for use with the
+// AWS API.
+func NewLoggingEnabled(l *v1alpha1.BucketLogging) *s3.LoggingEnabled {
+	if l == nil {
+		return nil
+	}
+	return &s3.LoggingEnabled{
+		TargetBucket: aws.String(l.TargetBucket),
+		TargetPrefix: aws.String(l.TargetPrefix),
+	}
+}
+
+// GenerateBucketLogging returns the logging configuration of an S3Bucket
+// given the supplied configuration from the AWS API.
+func GenerateBucketLogging(l *s3.LoggingEnabled) *v1alpha1.BucketLogging {
+	if l == nil {
+		return nil
+	}
+	return &v1alpha1.BucketLogging{
+		TargetBucket: aws.StringValue(l.TargetBucket),
+		TargetPrefix: aws.StringValue(l.TargetPrefix),
+	}
+}
+
+// NewWebsiteConfiguration returns a static website configuration suitable for
+// use with the AWS API.
+func NewWebsiteConfiguration(w *v1alpha1.BucketWebsite) *s3.WebsiteConfiguration {
+	if w == nil {
+		return nil
+	}
+	c := &s3.WebsiteConfiguration{IndexDocument: &s3.IndexDocument{Suffix: aws.String(w.IndexDocumentSuffix)}}
+	if w.ErrorDocumentKey != "" {
+		c.ErrorDocument = &s3.ErrorDocument{Key: aws.String(w.ErrorDocumentKey)}
+	}
+	return c
+}
+
+// GenerateBucketWebsite returns the static website configuration of an
+// S3Bucket given the supplied index and error documents from the AWS API.
+func GenerateBucketWebsite(i *s3.IndexDocument, e *s3.ErrorDocument) *v1alpha1.BucketWebsite {
+	if i == nil {
+		return nil
+	}
+	w := &v1alpha1.BucketWebsite{IndexDocumentSuffix: aws.StringValue(i.Suffix)}
+	if e != nil {
+		w.ErrorDocumentKey = aws.StringValue(e.Key)
+	}
+	return w
+}
+
+// NewTagSet returns a tag set suitable for use with the AWS API. Tags are
+// sorted by key.
+func NewTagSet(tags map[string]string) []s3.Tag {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	out := make([]s3.Tag, len(keys))
+	for i, k := range keys {
+		out[i] = s3.Tag{Key: aws.String(k), Value: aws.String(tags[k])}
+	}
+	return out
+}
+
+// GenerateTags returns the tags of an S3Bucket given the supplied tag set
+// from the AWS API.
+func GenerateTags(tags []s3.Tag) map[string]string {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	out := make(map[string]string, len(tags))
+	for _, t := range tags {
+		out[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+	}
+	return out
+}
+
+// NewPublicAccessBlockConfiguration returns a public access block
+// configuration suitable for use with the AWS API.
+func NewPublicAccessBlockConfiguration(p *v1alpha1.BucketPublicAccessBlock) *operations.PublicAccessBlockConfiguration {
+	if p == nil {
+		return nil
+	}
+	return &operations.PublicAccessBlockConfiguration{
+		BlockPublicAcls:       aws.Bool(p.BlockPublicACLs),
+		IgnorePublicAcls:      aws.Bool(p.IgnorePublicACLs),
+		BlockPublicPolicy:     aws.Bool(p.BlockPublicPolicy),
+		RestrictPublicBuckets: aws.Bool(p.RestrictPublicBuckets),
+	}
+}
+
+// GenerateBucketPublicAccessBlock returns the public access block of an
+// S3Bucket given the supplied configuration from the AWS API.
+func GenerateBucketPublicAccessBlock(c *operations.PublicAccessBlockConfiguration) *v1alpha1.BucketPublicAccessBlock {
+	if c == nil {
+		return nil
+	}
+	return &v1alpha1.BucketPublicAccessBlock{
+		BlockPublicACLs:       aws.BoolValue(c.BlockPublicAcls),
+		IgnorePublicACLs:      aws.BoolValue(c.IgnorePublicAcls),
+		BlockPublicPolicy:     aws.BoolValue(c.BlockPublicPolicy),
+		RestrictPublicBuckets: aws.BoolValue(c.RestrictPublicBuckets),
+	}
+}
+
+// isErrorCode returns true if the supplied error is an AWS error with the
+// supplied code.
+func isErrorCode(err error, code string) bool {
+	if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == code {
+		return true
+	}
+	return false
+}
+
+func emptyToNil(s []string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+	return s
+}
+
+func emptyMapToNil(m map[string]string) map[string]string {
+	if len(m) == 0 {
+		return nil
+	}
+	return m
+}
@@ -0,0 +1,358 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/google/go-cmp/cmp"
+	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+
+	awsstorage "github.com/crossplaneio/crossplane/aws/apis/storage/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/aws/s3/operations"
+	fakeops "github.com/crossplaneio/crossplane/pkg/clients/aws/s3/operations/fake"
+)
+
+func bucketWith(p awsstorage.S3BucketParameters) *awsstorage.S3Bucket {
+	return &awsstorage.S3Bucket{Spec: awsstorage.S3BucketSpec{S3BucketParameters: p}}
+}
+
+func TestClient_UpdateLifecycleConfiguration(t *testing.T) {
+	boom := errors.New("boom")
+	notFound := awserr.New(errCodeNoSuchLifecycleConfiguration, "", nil)
+	rules := []awsstorage.LifecycleRule{{
+		ID:             "expire",
+		Prefix:         "logs/",
+		ExpirationDays: 30,
+		Transitions:    []awsstorage.LifecycleTransition{{Days: 7, StorageClass: "STANDARD_IA"}},
+	}}
+
+	tests := map[string]struct {
+		bucket     *awsstorage.S3Bucket
+		getRet     []interface{}
+		wantPut    int
+		wantDelete int
+		wantErr    error
+	}{
+		"NoRules": {
+			bucket: bucketWith(awsstorage.S3BucketParameters{}),
+			getRet: []interface{}{nil, notFound},
+		},
+		"UpToDate": {
+			bucket: bucketWith(awsstorage.S3BucketParameters{LifecycleRules: rules}),
+			getRet: []interface{}{&s3.GetBucketLifecycleConfigurationOutput{Rules: NewLifecycleRules(rules)}, nil},
+		},
+		"NeedsPut": {
+			bucket:  bucketWith(awsstorage.S3BucketParameters{LifecycleRules: rules}),
+			getRet:  []interface{}{nil, notFound},
+			wantPut: 1,
+		},
+		"NeedsDelete": {
+			bucket:     bucketWith(awsstorage.S3BucketParameters{}),
+			getRet:     []interface{}{&s3.GetBucketLifecycleConfigurationOutput{Rules: NewLifecycleRules(rules)}, nil},
+			wantDelete: 1,
+		},
+		"GetError": {
+			bucket:  bucketWith(awsstorage.S3BucketParameters{LifecycleRules: rules}),
+			getRet:  []interface{}{nil, boom},
+			wantErr: boom,
+		},
+	}
+
+	for testName, vals := range tests {
+		t.Run(testName, func(t *testing.T) {
+			// Set up mocks
+			get := new(fakeops.GetBucketLifecycleConfigurationRequest)
+			get.On("Send").Return(vals.getRet...)
+			put := new(fakeops.PutBucketLifecycleConfigurationRequest)
+			put.On("Send").Return(&s3.PutBucketLifecycleConfigurationOutput{}, nil)
+			del := new(fakeops.DeleteBucketLifecycleRequest)
+			del.On("Send").Return(&s3.DeleteBucketLifecycleOutput{}, nil)
+
+			ops := new(fakeops.Operations)
+			ops.On("GetBucketLifecycleConfigurationRequest", mock.Anything).Return(get)
+			ops.On("PutBucketLifecycleConfigurationRequest", mock.Anything).Return(put)
+			ops.On("DeleteBucketLifecycleRequest", mock.Anything).Return(del)
+
+			c := Client{s3: ops}
+
+			err := c.UpdateLifecycleConfiguration(vals.bucket)
+
+			if diff := cmp.Diff(vals.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("UpdateLifecycleConfiguration(...): -want error, +got error:\n%s", diff)
+			}
+			ops.AssertNumberOfCalls(t, "PutBucketLifecycleConfigurationRequest", vals.wantPut)
+			ops.AssertNumberOfCalls(t, "DeleteBucketLifecycleRequest", vals.wantDelete)
+		})
+	}
+}
+
+func TestClient_UpdateLogging(t *testing.T) {
+	logging := &awsstorage.BucketLogging{TargetBucket: "logs", TargetPrefix: "coolbucket/"}
+
+	tests := map[string]struct {
+		bucket  *awsstorage.S3Bucket
+		getRet  []interface{}
+		wantPut int
+	}{
+		"UpToDate": {
+			bucket: bucketWith(awsstorage.S3BucketParameters{Logging: logging}),
+			getRet: []interface{}{&s3.GetBucketLoggingOutput{LoggingEnabled: NewLoggingEnabled(logging)}, nil},
+		},
+		"NeedsEnable": {
+			bucket:  bucketWith(awsstorage.S3BucketParameters{Logging: logging}),
+			getRet:  []interface{}{&s3.GetBucketLoggingOutput{}, nil},
+			wantPut: 1,
+		},
+		"NeedsDisable": {
+			bucket:  bucketWith(awsstorage.S3BucketParameters{}),
+			getRet:  []interface{}{&s3.GetBucketLoggingOutput{LoggingEnabled: NewLoggingEnabled(logging)}, nil},
+			wantPut: 1,
+		},
+	}
+
+	for testName, vals := range tests {
+		t.Run(testName, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+
+			// Set up mocks
+			get := new(fakeops.GetBucketLoggingRequest)
+			get.On("Send").Return(vals.getRet...)
+			put := new(fakeops.PutBucketLoggingRequest)
+			put.On("Send").Return(&s3.PutBucketLoggingOutput{}, nil)
+
+			ops := new(fakeops.Operations)
+			ops.On("GetBucketLoggingRequest", mock.Anything).Return(get)
+			ops.On("PutBucketLoggingRequest", mock.Anything).Return(put)
+
+			c := Client{s3: ops}
+
+			err := c.UpdateLogging(vals.bucket)
+
+			g.Expect(err).To(gomega.BeNil())
+			ops.AssertNumberOfCalls(t, "PutBucketLoggingRequest", vals.wantPut)
+		})
+	}
+}
+
+func TestClient_UpdateTagging(t *testing.T) {
+	notFound := awserr.New(errCodeNoSuchTagSet, "", nil)
+	tags := map[string]string{"team": "cool", "env": "prod"}
+
+	tests := map[string]struct {
+		bucket     *awsstorage.S3Bucket
+		getRet     []interface{}
+		wantPut    int
+		wantDelete int
+	}{
+		"UpToDate": {
+			bucket: bucketWith(awsstorage.S3BucketParameters{Tags: tags}),
+			getRet: []interface{}{&s3.GetBucketTaggingOutput{TagSet: NewTagSet(tags)}, nil},
+		},
+		"NeedsPut": {
+			bucket:  bucketWith(awsstorage.S3BucketParameters{Tags: tags}),
+			getRet:  []interface{}{&s3.GetBucketTaggingOutput{TagSet: NewTagSet(map[string]string{"team": "cool"})}, nil},
+			wantPut: 1,
+		},
+		"NeedsDelete": {
+			bucket:     bucketWith(awsstorage.S3BucketParameters{Tags: map[string]string{}}),
+			getRet:     []interface{}{&s3.GetBucketTaggingOutput{TagSet: NewTagSet(tags)}, nil},
+			wantDelete: 1,
+		},
+		"NoTags": {
+			bucket: bucketWith(awsstorage.S3BucketParameters{}),
+			getRet: []interface{}{nil, notFound},
+		},
+	}
+
+	for testName, vals := range tests {
+		t.Run(testName, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+
+			// Set up mocks
+			get := new(fakeops.GetBucketTaggingRequest)
+			get.On("Send").Return(vals.getRet...)
+			put := new(fakeops.PutBucketTaggingRequest)
+			put.On("Send").Return(&s3.PutBucketTaggingOutput{}, nil)
+			del := new(fakeops.DeleteBucketTaggingRequest)
+			del.On("Send").Return(&s3.DeleteBucketTaggingOutput{}, nil)
+
+			ops := new(fakeops.Operations)
+			ops.On("GetBucketTaggingRequest", mock.Anything).Return(get)
+			ops.On("PutBucketTaggingRequest", mock.Anything).Return(put)
+			ops.On("DeleteBucketTaggingRequest", mock.Anything).Return(del)
+
+			c := Client{s3: ops}
+
+			err := c.UpdateTagging(vals.bucket)
+
+			g.Expect(err).To(gomega.BeNil())
+			ops.AssertNumberOfCalls(t, "PutBucketTaggingRequest", vals.wantPut)
+			ops.AssertNumberOfCalls(t, "DeleteBucketTaggingRequest", vals.wantDelete)
+		})
+	}
+}
+
+func TestClient_UpdatePublicAccessBlock(t *testing.T) {
+	boom := errors.New("boom")
+	notFound := awserr.New(operations.ErrCodeNoSuchPublicAccessBlockConfiguration, "", nil)
+	block := &awsstorage.BucketPublicAccessBlock{BlockPublicACLs: true, BlockPublicPolicy: true}
+
+	tests := map[string]struct {
+		bucket     *awsstorage.S3Bucket
+		getRet     []interface{}
+		wantPut    int
+		wantDelete int
+		wantErr    error
+	}{
+		"UpToDate": {
+			bucket: bucketWith(awsstorage.S3BucketParameters{PublicAccessBlock: block}),
+			getRet: []interface{}{&operations.GetPublicAccessBlockOutput{PublicAccessBlockConfiguration: NewPublicAccessBlockConfiguration(block)}, nil},
+		},
+		"NeedsPut": {
+			bucket:  bucketWith(awsstorage.S3BucketParameters{PublicAccessBlock: block}),
+			getRet:  []interface{}{nil, notFound},
+			wantPut: 1,
+		},
+		"NeedsDelete": {
+			bucket:     bucketWith(awsstorage.S3BucketParameters{}),
+			getRet:     []interface{}{&operations.GetPublicAccessBlockOutput{PublicAccessBlockConfiguration: NewPublicAccessBlockConfiguration(block)}, nil},
+			wantDelete: 1,
+		},
+		"NoPublicAccessBlock": {
+			bucket: bucketWith(awsstorage.S3BucketParameters{}),
+			getRet: []interface{}{nil, notFound},
+		},
+		"GetFailed": {
+			bucket:  bucketWith(awsstorage.S3BucketParameters{PublicAccessBlock: block}),
+			getRet:  []interface{}{nil, boom},
+			wantErr: boom,
+		},
+	}
+
+	for testName, vals := range tests {
+		t.Run(testName, func(t *testing.T) {
+			// Set up mocks
+			get := new(fakeops.GetPublicAccessBlockRequest)
+			get.On("Send").Return(vals.getRet...)
+			put := new(fakeops.PutPublicAccessBlockRequest)
+			put.On("Send").Return(&operations.PutPublicAccessBlockOutput{}, nil)
+			del := new(fakeops.DeletePublicAccessBlockRequest)
+			del.On("Send").Return(&operations.DeletePublicAccessBlockOutput{}, nil)
+
+			ops := new(fakeops.Operations)
+			ops.On("GetPublicAccessBlockRequest", mock.Anything).Return(get)
+			ops.On("PutPublicAccessBlockRequest", mock.Anything).Return(put)
+			ops.On("DeletePublicAccessBlockRequest", mock.Anything).Return(del)
+
+			c := Client{s3: ops}
+
+			err := c.UpdatePublicAccessBlock(vals.bucket)
+
+			if diff := cmp.Diff(vals.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("UpdatePublicAccessBlock(...): -want error, +got error:\n%s", diff)
+			}
+			ops.AssertNumberOfCalls(t, "PutPublicAccessBlockRequest", vals.wantPut)
+			ops.AssertNumberOfCalls(t, "DeletePublicAccessBlockRequest", vals.wantDelete)
+		})
+	}
+}
+
+func TestGenerateLifecycleRules(t *testing.T) {
+	// S3 may return rules that use the deprecated top level prefix rather
+	// than a filter.
+	got := GenerateLifecycleRules([]s3.LifecycleRule{{
+		ID:                             aws.String("cool"),
+		Prefix:                         aws.String("logs/"),
+		Status:                         s3.ExpirationStatusDisabled,
+		NoncurrentVersionExpiration:    &s3.NoncurrentVersionExpiration{NoncurrentDays: aws.Int64(3)},
+		AbortIncompleteMultipartUpload: &s3.AbortIncompleteMultipartUpload{DaysAfterInitiation: aws.Int64(1)},
+	}})
+	want := []awsstorage.LifecycleRule{{
+		ID:                                 "cool",
+		Prefix:                             "logs/",
+		Disabled:                           true,
+		NoncurrentVersionExpirationDays:    3,
+		AbortIncompleteMultipartUploadDays: 1,
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GenerateLifecycleRules(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestGenerateCORSRules(t *testing.T) {
+	rules := []awsstorage.CORSRule{{
+		AllowedMethods: []string{"GET"},
+		AllowedOrigins: []string{"*"},
+		ExposeHeaders:  []string{},
+		MaxAgeSeconds:  300,
+	}}
+	want := []awsstorage.CORSRule{{
+		AllowedMethods: []string{"GET"},
+		AllowedOrigins: []string{"*"},
+		MaxAgeSeconds:  300,
+	}}
+
+	got := GenerateCORSRules(NewCORSRules(rules))
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GenerateCORSRules(NewCORSRules(...)): -want, +got:\n%s", diff)
+	}
+}
+
+func TestNewServerSideEncryptionConfiguration(t *testing.T) {
+	cases := map[string]*awsstorage.BucketEncryption{
+		"AES256": {Algorithm: string(s3.ServerSideEncryptionAes256)},
+		"KMS":    {Algorithm: string(s3.ServerSideEncryptionAwsKms), KMSMasterKeyID: "coolkey"},
+		"None":   nil,
+	}
+
+	for name, e := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateBucketEncryption(NewServerSideEncryptionConfiguration(e))
+			if diff := cmp.Diff(e, got); diff != "" {
+				t.Errorf("GenerateBucketEncryption(NewServerSideEncryptionConfiguration(...)): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewWebsiteConfiguration(t *testing.T) {
+	w := &awsstorage.BucketWebsite{IndexDocumentSuffix: "index.html", ErrorDocumentKey: "404.html"}
+	c := NewWebsiteConfiguration(w)
+	got := GenerateBucketWebsite(c.IndexDocument, c.ErrorDocument)
+	if diff := cmp.Diff(w, got); diff != "" {
+		t.Errorf("GenerateBucketWebsite(NewWebsiteConfiguration(...)): -want, +got:\n%s", diff)
+	}
+}
+
+func TestNewTagSet(t *testing.T) {
+	got := NewTagSet(map[string]string{"b": "2", "a": "1"})
+	want := []s3.Tag{
+		{Key: aws.String("a"), Value: aws.String("1")},
+		{Key: aws.String("b"), Value: aws.String("2")},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("NewTagSet(...): -want, +got:\n%s", diff)
+	}
+}
@@ -32,6 +32,14 @@ type MockS3Client struct {
 	MockUpdateVersioning     func(bucket *v1alpha1.S3Bucket) error
 	MockUpdatePolicyDocument func(username string, bucket *v1alpha1.S3Bucket) (string, error)
 	MockDelete               func(bucket *v1alpha1.S3Bucket) error
+
+	MockUpdateLifecycleConfiguration func(bucket *v1alpha1.S3Bucket) error
+	MockUpdateCORS                   func(bucket *v1alpha1.S3Bucket) error
+	MockUpdateEncryption             func(bucket *v1alpha1.S3Bucket) error
+	MockUpdateLogging                func(bucket *v1alpha1.S3Bucket) error
+	MockUpdateWebsite                func(bucket *v1alpha1.S3Bucket) error
+	MockUpdateTagging                func(bucket *v1alpha1.S3Bucket) error
+	MockUpdatePublicAccessBlock      func(bucket *v1alpha1.S3Bucket) error
 }
 
 // CreateOrUpdateBucket calls the underlying MockCreateOrUpdateBucket method.
@@ -64,6 +72,43 @@ func (m *MockS3Client) UpdatePolicyDocument(username string, bucket *v1alpha1.S3
 	return m.MockUpdatePolicyDocument(username, bucket)
 }
 
+// UpdateLifecycleConfiguration calls the underlying
+// MockUpdateLifecycleConfiguration method.
+func (m *MockS3Client) UpdateLifecycleConfiguration(bucket *v1alpha1.S3Bucket) error {
+	return m.MockUpdateLifecycleConfiguration(bucket)
+}
+
+// UpdateCORS calls the underlying MockUpdateCORS method.
+func (m *MockS3Client) UpdateCORS(bucket *v1alpha1.S3Bucket) error {
+	return m.MockUpdateCORS(bucket)
+}
+
+// UpdateEncryption calls the underlying MockUpdateEncryption method.
+func (m *MockS3Client) UpdateEncryption(bucket *v1alpha1.S3Bucket) error {
+	return m.MockUpdateEncryption(bucket)
+}
+
+// UpdateLogging calls the underlying MockUpdateLogging method.
+func (m *MockS3Client) UpdateLogging(bucket *v1alpha1.S3Bucket) error {
+	return m.MockUpdateLogging(bucket)
+}
+
+// UpdateWebsite calls the underlying MockUpdateWebsite method.
+func (m *MockS3Client) UpdateWebsite(bucket *v1alpha1.S3Bucket) error {
+	return m.MockUpdateWebsite(bucket)
+}
+
+// UpdateTagging calls the underlying MockUpdateTagging method.
+func (m *MockS3Client) UpdateTagging(bucket *v1alpha1.S3Bucket) error {
+	return m.MockUpdateTagging(bucket)
+}
+
+// UpdatePublicAccessBlock calls the underlying MockUpdatePublicAccessBlock
+// method.
+func (m *MockS3Client) UpdatePublicAccessBlock(bucket *v1alpha1.S3Bucket) error {
+	return m.MockUpdatePublicAccessBlock(bucket)
+}
+
 // DeleteBucket calls the underlying MockDeleteBucket method.
 func (m *MockS3Client) DeleteBucket(bucket *v1alpha1.S3Bucket) error {
 	return m.MockDelete(bucket)
@@ -27,6 +27,54 @@ func (_m *Operations) CreateBucketRequest(_a0 *s3.CreateBucketInput) operations.
 	return r0
 }
 
+// DeleteBucketCORSRequest provides a mock function with given fields: _a0
+func (_m *Operations) DeleteBucketCORSRequest(_a0 *s3.DeleteBucketCorsInput) operations.DeleteBucketCORSRequest {
+	ret := _m.Called(_a0)
+
+	var r0 operations.DeleteBucketCORSRequest
+	if rf, ok := ret.Get(0).(func(*s3.DeleteBucketCorsInput) operations.DeleteBucketCORSRequest); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(operations.DeleteBucketCORSRequest)
+		}
+	}
+
+	return r0
+}
+
+// DeleteBucketEncryptionRequest provides a mock function with given fields: _a0
+func (_m *Operations) DeleteBucketEncryptionRequest(_a0 *s3.DeleteBucketEncryptionInput) operations.DeleteBucketEncryptionRequest {
+	ret := _m.Called(_a0)
+
+	var r0 operations.DeleteBucketEncryptionRequest
+	if rf, ok := ret.Get(0).(func(*s3.DeleteBucketEncryptionInput) operations.DeleteBucketEncryptionRequest); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(operations.DeleteBucketEncryptionRequest)
+		}
+	}
+
+	return r0
+}
+
+// DeleteBucketLifecycleRequest provides a mock function with given fields: _a0
+func (_m *Operations) DeleteBucketLifecycleRequest(_a0 *s3.DeleteBucketLifecycleInput) operations.DeleteBucketLifecycleRequest {
+	ret := _m.Called(_a0)
+
+	var r0 operations.DeleteBucketLifecycleRequest
+	if rf, ok := ret.Get(0).(func(*s3.DeleteBucketLifecycleInput) operations.DeleteBucketLifecycleRequest); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(operations.DeleteBucketLifecycleRequest)
+		}
+	}
+
+	return r0
+}
+
 // DeleteBucketRequest provides a mock function with given fields: _a0
 func (_m *Operations) DeleteBucketRequest(_a0 *s3.DeleteBucketInput) operations.DeleteBucketRequest {
 	ret := _m.Called(_a0)
@@ -43,6 +91,134 @@ func (_m *Operations) DeleteBucketRequest(_a0 *s3.DeleteBucketInput) operations.
 	return r0
 }
 
+// DeleteBucketTaggingRequest provides a mock function with given fields: _a0
+func (_m *Operations) DeleteBucketTaggingRequest(_a0 *s3.DeleteBucketTaggingInput) operations.DeleteBucketTaggingRequest {
+	ret := _m.Called(_a0)
+
+	var r0 operations.DeleteBucketTaggingRequest
+	if rf, ok := ret.Get(0).(func(*s3.DeleteBucketTaggingInput) operations.DeleteBucketTaggingRequest); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(operations.DeleteBucketTaggingRequest)
+		}
+	}
+
+	return r0
+}
+
+// DeleteBucketWebsiteRequest provides a mock function with given fields: _a0
+func (_m *Operations) DeleteBucketWebsiteRequest(_a0 *s3.DeleteBucketWebsiteInput) operations.DeleteBucketWebsiteRequest {
+	ret := _m.Called(_a0)
+
+	var r0 operations.DeleteBucketWebsiteRequest
+	if rf, ok := ret.Get(0).(func(*s3.DeleteBucketWebsiteInput) operations.DeleteBucketWebsiteRequest); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(operations.DeleteBucketWebsiteRequest)
+		}
+	}
+
+	return r0
+}
+
+// DeletePublicAccessBlockRequest provides a mock function with given fields: _a0
+func (_m *Operations) DeletePublicAccessBlockRequest(_a0 *operations.DeletePublicAccessBlockInput) operations.DeletePublicAccessBlockRequest {
+	ret := _m.Called(_a0)
+
+	var r0 operations.DeletePublicAccessBlockRequest
+	if rf, ok := ret.Get(0).(func(*operations.DeletePublicAccessBlockInput) operations.DeletePublicAccessBlockRequest); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(operations.DeletePublicAccessBlockRequest)
+		}
+	}
+
+	return r0
+}
+
+// GetBucketCORSRequest provides a mock function with given fields: _a0
+func (_m *Operations) GetBucketCORSRequest(_a0 *s3.GetBucketCorsInput) operations.GetBucketCORSRequest {
+	ret := _m.Called(_a0)
+
+	var r0 operations.GetBucketCORSRequest
+	if rf, ok := ret.Get(0).(func(*s3.GetBucketCorsInput) operations.GetBucketCORSRequest); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(operations.GetBucketCORSRequest)
+		}
+	}
+
+	return r0
+}
+
+// GetBucketEncryptionRequest provides a mock function with given fields: _a0
+func (_m *Operations) GetBucketEncryptionRequest(_a0 *s3.GetBucketEncryptionInput) operations.GetBucketEncryptionRequest {
+	ret := _m.Called(_a0)
+
+	var r0 operations.GetBucketEncryptionRequest
+	if rf, ok := ret.Get(0).(func(*s3.GetBucketEncryptionInput) operations.GetBucketEncryptionRequest); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(operations.GetBucketEncryptionRequest)
+		}
+	}
+
+	return r0
+}
+
+// GetBucketLifecycleConfigurationRequest provides a mock function with given fields: _a0
+func (_m *Operations) GetBucketLifecycleConfigurationRequest(_a0 *s3.GetBucketLifecycleConfigurationInput) operations.GetBucketLifecycleConfigurationRequest {
+	ret := _m.Called(_a0)
+
+	var r0 operations.GetBucketLifecycleConfigurationRequest
+	if rf, ok := ret.Get(0).(func(*s3.GetBucketLifecycleConfigurationInput) operations.GetBucketLifecycleConfigurationRequest); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(operations.GetBucketLifecycleConfigurationRequest)
+		}
+	}
+
+	return r0
+}
+
+// GetBucketLoggingRequest provides a mock function with given fields: _a0
+func (_m *Operations) GetBucketLoggingRequest(_a0 *s3.GetBucketLoggingInput) operations.GetBucketLoggingRequest {
+	ret := _m.Called(_a0)
+
+	var r0 operations.GetBucketLoggingRequest
+	if rf, ok := ret.Get(0).(func(*s3.GetBucketLoggingInput) operations.GetBucketLoggingRequest); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(operations.GetBucketLoggingRequest)
+		}
+	}
+
+	return r0
+}
+
+// GetBucketTaggingRequest provides a mock function with given fields: _a0
+func (_m *Operations) GetBucketTaggingRequest(_a0 *s3.GetBucketTaggingInput) operations.GetBucketTaggingRequest {
+	ret := _m.Called(_a0)
+
+	var r0 operations.GetBucketTaggingRequest
+	if rf, ok := ret.Get(0).(func(*s3.GetBucketTaggingInput) operations.GetBucketTaggingRequest); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(operations.GetBucketTaggingRequest)
+		}
+	}
+
+	return r0
+}
+
 // GetBucketVersioningRequest provides a mock function with given fields: _a0
 func (_m *Operations) GetBucketVersioningRequest(_a0 *s3.GetBucketVersioningInput) operations.GetBucketVersioningRequest {
 	ret := _m.Called(_a0)
@@ -59,6 +235,38 @@ func (_m *Operations) GetBucketVersioningRequest(_a0 *s3.GetBucketVersioningInpu
 	return r0
 }
 
+// GetBucketWebsiteRequest provides a mock function with given fields: _a0
+func (_m *Operations) GetBucketWebsiteRequest(_a0 *s3.GetBucketWebsiteInput) operations.GetBucketWebsiteRequest {
+	ret := _m.Called(_a0)
+
+	var r0 operations.GetBucketWebsiteRequest
+	if rf, ok := ret.Get(0).(func(*s3.GetBucketWebsiteInput) operations.GetBucketWebsiteRequest); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(operations.GetBucketWebsiteRequest)
+		}
+	}
+
+	return r0
+}
+
+// GetPublicAccessBlockRequest provides a mock function with given fields: _a0
+func (_m *Operations) GetPublicAccessBlockRequest(_a0 *operations.GetPublicAccessBlockInput) operations.GetPublicAccessBlockRequest {
+	ret := _m.Called(_a0)
+
+	var r0 operations.GetPublicAccessBlockRequest
+	if rf, ok := ret.Get(0).(func(*operations.GetPublicAccessBlockInput) operations.GetPublicAccessBlockRequest); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(operations.GetPublicAccessBlockRequest)
+		}
+	}
+
+	return r0
+}
+
 // PutBucketACLRequest provides a mock function with given fields: _a0
 func (_m *Operations) PutBucketACLRequest(_a0 *s3.PutBucketAclInput) operations.PutBucketACLRequest {
 	ret := _m.Called(_a0)
@@ -75,6 +283,86 @@ func (_m *Operations) PutBucketACLRequest(_a0 *s3.PutBucketAclInput) operations.
 	return r0
 }
 
+// PutBucketCORSRequest provides a mock function with given fields: _a0
+func (_m *Operations) PutBucketCORSRequest(_a0 *s3.PutBucketCorsInput) operations.PutBucketCORSRequest {
+	ret := _m.Called(_a0)
+
+	var r0 operations.PutBucketCORSRequest
+	if rf, ok := ret.Get(0).(func(*s3.PutBucketCorsInput) operations.PutBucketCORSRequest); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(operations.PutBucketCORSRequest)
+		}
+	}
+
+	return r0
+}
+
+// PutBucketEncryptionRequest provides a mock function with given fields: _a0
+func (_m *Operations) PutBucketEncryptionRequest(_a0 *s3.PutBucketEncryptionInput) operations.PutBucketEncryptionRequest {
+	ret := _m.Called(_a0)
+
+	var r0 operations.PutBucketEncryptionRequest
+	if rf, ok := ret.Get(0).(func(*s3.PutBucketEncryptionInput) operations.PutBucketEncryptionRequest); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(operations.PutBucketEncryptionRequest)
+		}
+	}
+
+	return r0
+}
+
+// PutBucketLifecycleConfigurationRequest provides a mock function with given fields: _a0
+func (_m *Operations) PutBucketLifecycleConfigurationRequest(_a0 *s3.PutBucketLifecycleConfigurationInput) operations.PutBucketLifecycleConfigurationRequest {
+	ret := _m.Called(_a0)
+
+	var r0 operations.PutBucketLifecycleConfigurationRequest
+	if rf, ok := ret.Get(0).(func(*s3.PutBucketLifecycleConfigurationInput) operations.PutBucketLifecycleConfigurationRequest); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(operations.PutBucketLifecycleConfigurationRequest)
+		}
+	}
+
+	return r0
+}
+
+// PutBucketLoggingRequest provides a mock function with given fields: _a0
+func (_m *Operations) PutBucketLoggingRequest(_a0 *s3.PutBucketLoggingInput) operations.PutBucketLoggingRequest {
+	ret := _m.Called(_a0)
+
+	var r0 operations.PutBucketLoggingRequest
+	if rf, ok := ret.Get(0).(func(*s3.PutBucketLoggingInput) operations.PutBucketLoggingRequest); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(operations.PutBucketLoggingRequest)
+		}
+	}
+
+	return r0
+}
+
+// PutBucketTaggingRequest provides a mock function with given fields: _a0
+func (_m *Operations) PutBucketTaggingRequest(_a0 *s3.PutBucketTaggingInput) operations.PutBucketTaggingRequest {
+	ret := _m.Called(_a0)
+
+	var r0 operations.PutBucketTaggingRequest
+	if rf, ok := ret.Get(0).(func(*s3.PutBucketTaggingInput) operations.PutBucketTaggingRequest); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(operations.PutBucketTaggingRequest)
+		}
+	}
+
+	return r0
+}
+
 // PutBucketVersioningRequest provides a mock function with given fields: _a0
 func (_m *Operations) PutBucketVersioningRequest(_a0 *s3.PutBucketVersioningInput) operations.PutBucketVersioningRequest {
 	ret := _m.Called(_a0)
@@ -90,3 +378,35 @@ func (_m *Operations) PutBucketVersioningRequest(_a0 *s3.PutBucketVersioningInpu
 
 	return r0
 }
+
+// PutBucketWebsiteRequest provides a mock function with given fields: _a0
+func (_m *Operations) PutBucketWebsiteRequest(_a0 *s3.PutBucketWebsiteInput) operations.PutBucketWebsiteRequest {
+	ret := _m.Called(_a0)
+
+	var r0 operations.PutBucketWebsiteRequest
+	if rf, ok := ret.Get(0).(func(*s3.PutBucketWebsiteInput) operations.PutBucketWebsiteRequest); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(operations.PutBucketWebsiteRequest)
+		}
+	}
+
+	return r0
+}
+
+// PutPublicAccessBlockRequest provides a mock function with given fields: _a0
+func (_m *Operations) PutPublicAccessBlockRequest(_a0 *operations.PutPublicAccessBlockInput) operations.PutPublicAccessBlockRequest {
+	ret := _m.Called(_a0)
+
+	var r0 operations.PutPublicAccessBlockRequest
+	if rf, ok := ret.Get(0).(func(*operations.PutPublicAccessBlockInput) operations.PutPublicAccessBlockRequest); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(operations.PutPublicAccessBlockRequest)
+		}
+	}
+
+	return r0
+}
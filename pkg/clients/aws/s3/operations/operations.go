@@ -26,4 +26,24 @@ type Operations interface {
 	PutBucketACLRequest(*s3.PutBucketAclInput) PutBucketACLRequest
 	PutBucketVersioningRequest(*s3.PutBucketVersioningInput) PutBucketVersioningRequest
 	DeleteBucketRequest(*s3.DeleteBucketInput) DeleteBucketRequest
+	GetBucketLifecycleConfigurationRequest(*s3.GetBucketLifecycleConfigurationInput) GetBucketLifecycleConfigurationRequest
+	PutBucketLifecycleConfigurationRequest(*s3.PutBucketLifecycleConfigurationInput) PutBucketLifecycleConfigurationRequest
+	DeleteBucketLifecycleRequest(*s3.DeleteBucketLifecycleInput) DeleteBucketLifecycleRequest
+	GetBucketCORSRequest(*s3.GetBucketCorsInput) GetBucketCORSRequest
+	PutBucketCORSRequest(*s3.PutBucketCorsInput) PutBucketCORSRequest
+	DeleteBucketCORSRequest(*s3.DeleteBucketCorsInput) DeleteBucketCORSRequest
+	GetBucketEncryptionRequest(*s3.GetBucketEncryptionInput) GetBucketEncryptionRequest
+	PutBucketEncryptionRequest(*s3.PutBucketEncryptionInput) PutBucketEncryptionRequest
+	DeleteBucketEncryptionRequest(*s3.DeleteBucketEncryptionInput) DeleteBucketEncryptionRequest
+	GetBucketLoggingRequest(*s3.GetBucketLoggingInput) GetBucketLoggingRequest
+	PutBucketLoggingRequest(*s3.PutBucketLoggingInput) PutBucketLoggingRequest
+	GetBucketWebsiteRequest(*s3.GetBucketWebsiteInput) GetBucketWebsiteRequest
+	PutBucketWebsiteRequest(*s3.PutBucketWebsiteInput) PutBucketWebsiteRequest
+	DeleteBucketWebsiteRequest(*s3.DeleteBucketWebsiteInput) DeleteBucketWebsiteRequest
+	GetBucketTaggingRequest(*s3.GetBucketTaggingInput) GetBucketTaggingRequest
+	PutBucketTaggingRequest(*s3.PutBucketTaggingInput) PutBucketTaggingRequest
+	DeleteBucketTaggingRequest(*s3.DeleteBucketTaggingInput) DeleteBucketTaggingRequest
+	GetPublicAccessBlockRequest(*GetPublicAccessBlockInput) GetPublicAccessBlockRequest
+	PutPublicAccessBlockRequest(*PutPublicAccessBlockInput) PutPublicAccessBlockRequest
+	DeletePublicAccessBlockRequest(*DeletePublicAccessBlockInput) DeletePublicAccessBlockRequest
 }
@@ -0,0 +1,174 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operations
+
+import (
+	"crypto/md5" // nolint:gosec
+	"encoding/base64"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	"github.com/aws/aws-sdk-go-v2/private/protocol"
+	"github.com/aws/aws-sdk-go-v2/private/protocol/restxml"
+)
+
+// The version of the AWS SDK we use predates the S3 public access block API,
+// so we describe its operations here. The SDK marshals and unmarshals these
+// types using their struct tags, exactly as it would the types it generates.
+
+// S3 public access block operations.
+const (
+	opGetPublicAccessBlock    = "GetPublicAccessBlock"
+	opPutPublicAccessBlock    = "PutPublicAccessBlock"
+	opDeletePublicAccessBlock = "DeletePublicAccessBlock"
+)
+
+// ErrCodeNoSuchPublicAccessBlockConfiguration is returned when getting the
+// public access block of a bucket that has none.
+const ErrCodeNoSuchPublicAccessBlockConfiguration = "NoSuchPublicAccessBlockConfiguration"
+
+// PublicAccessBlockConfiguration limits public access to a bucket and the
+// objects it contains.
+type PublicAccessBlockConfiguration struct {
+	_ struct{} `type:"structure"`
+
+	BlockPublicAcls       *bool `locationName:"BlockPublicAcls" type:"boolean"`
+	IgnorePublicAcls      *bool `locationName:"IgnorePublicAcls" type:"boolean"`
+	BlockPublicPolicy     *bool `locationName:"BlockPublicPolicy" type:"boolean"`
+	RestrictPublicBuckets *bool `locationName:"RestrictPublicBuckets" type:"boolean"`
+}
+
+// GetPublicAccessBlockInput is the input of the GetPublicAccessBlock API
+// operation.
+type GetPublicAccessBlockInput struct {
+	_ struct{} `type:"structure"`
+
+	Bucket *string `location:"uri" locationName:"Bucket" type:"string" required:"true"`
+}
+
+// GetPublicAccessBlockOutput is the output of the GetPublicAccessBlock API
+// operation.
+type GetPublicAccessBlockOutput struct {
+	_ struct{} `type:"structure" payload:"PublicAccessBlockConfiguration"`
+
+	PublicAccessBlockConfiguration *PublicAccessBlockConfiguration `type:"structure"`
+}
+
+// PutPublicAccessBlockInput is the input of the PutPublicAccessBlock API
+// operation.
+type PutPublicAccessBlockInput struct {
+	_ struct{} `type:"structure" payload:"PublicAccessBlockConfiguration"`
+
+	Bucket *string `location:"uri" locationName:"Bucket" type:"string" required:"true"`
+
+	PublicAccessBlockConfiguration *PublicAccessBlockConfiguration `locationName:"PublicAccessBlockConfiguration" type:"structure" required:"true" xmlURI:"http://s3.amazonaws.com/doc/2006-03-01/"`
+}
+
+// PutPublicAccessBlockOutput is the output of the PutPublicAccessBlock API
+// operation.
+type PutPublicAccessBlockOutput struct {
+	_ struct{} `type:"structure"`
+}
+
+// DeletePublicAccessBlockInput is the input of the DeletePublicAccessBlock
+// API operation.
+type DeletePublicAccessBlockInput struct {
+	_ struct{} `type:"structure"`
+
+	Bucket *string `location:"uri" locationName:"Bucket" type:"string" required:"true"`
+}
+
+// DeletePublicAccessBlockOutput is the output of the DeletePublicAccessBlock
+// API operation.
+type DeletePublicAccessBlockOutput struct {
+	_ struct{} `type:"structure"`
+}
+
+type getPublicAccessBlockRequest struct {
+	*aws.Request
+}
+
+func (r getPublicAccessBlockRequest) Send() (*GetPublicAccessBlockOutput, error) {
+	if err := r.Request.Send(); err != nil {
+		return nil, err
+	}
+	return r.Request.Data.(*GetPublicAccessBlockOutput), nil
+}
+
+type putPublicAccessBlockRequest struct {
+	*aws.Request
+}
+
+func (r putPublicAccessBlockRequest) Send() (*PutPublicAccessBlockOutput, error) {
+	if err := r.Request.Send(); err != nil {
+		return nil, err
+	}
+	return r.Request.Data.(*PutPublicAccessBlockOutput), nil
+}
+
+type deletePublicAccessBlockRequest struct {
+	*aws.Request
+}
+
+func (r deletePublicAccessBlockRequest) Send() (*DeletePublicAccessBlockOutput, error) {
+	if err := r.Request.Send(); err != nil {
+		return nil, err
+	}
+	return r.Request.Data.(*DeletePublicAccessBlockOutput), nil
+}
+
+// contentMD5 sets the Content-MD5 header S3 requires of requests that put a
+// public access block. The S3 API client adds it only to the operations it
+// knows require it.
+func contentMD5(r *aws.Request) {
+	h := md5.New() // nolint:gosec
+	if _, err := io.Copy(h, r.Body); err != nil {
+		r.Error = awserr.New("ContentMD5", "failed to read body", err)
+		return
+	}
+	if _, err := r.Body.Seek(0, io.SeekStart); err != nil {
+		r.Error = awserr.New("ContentMD5", "failed to seek body", err)
+		return
+	}
+	r.HTTPRequest.Header.Set("Content-MD5", base64.StdEncoding.EncodeToString(h.Sum(nil)))
+}
+
+// GetPublicAccessBlockRequest creates a get public access block request
+func (api *S3Operations) GetPublicAccessBlockRequest(i *GetPublicAccessBlockInput) GetPublicAccessBlockRequest {
+	op := &aws.Operation{Name: opGetPublicAccessBlock, HTTPMethod: "GET", HTTPPath: "/{Bucket}?publicAccessBlock"}
+	return getPublicAccessBlockRequest{Request: api.client.NewRequest(op, i, &GetPublicAccessBlockOutput{})}
+}
+
+// PutPublicAccessBlockRequest creates a put public access block request
+func (api *S3Operations) PutPublicAccessBlockRequest(i *PutPublicAccessBlockInput) PutPublicAccessBlockRequest {
+	op := &aws.Operation{Name: opPutPublicAccessBlock, HTTPMethod: "PUT", HTTPPath: "/{Bucket}?publicAccessBlock"}
+	req := api.client.NewRequest(op, i, &PutPublicAccessBlockOutput{})
+	req.Handlers.Build.PushBack(contentMD5)
+	req.Handlers.Unmarshal.Remove(restxml.UnmarshalHandler)
+	req.Handlers.Unmarshal.PushBackNamed(protocol.UnmarshalDiscardBodyHandler)
+	return putPublicAccessBlockRequest{Request: req}
+}
+
+// DeletePublicAccessBlockRequest creates a delete public access block request
+func (api *S3Operations) DeletePublicAccessBlockRequest(i *DeletePublicAccessBlockInput) DeletePublicAccessBlockRequest {
+	op := &aws.Operation{Name: opDeletePublicAccessBlock, HTTPMethod: "DELETE", HTTPPath: "/{Bucket}?publicAccessBlock"}
+	req := api.client.NewRequest(op, i, &DeletePublicAccessBlockOutput{})
+	req.Handlers.Unmarshal.Remove(restxml.UnmarshalHandler)
+	req.Handlers.Unmarshal.PushBackNamed(protocol.UnmarshalDiscardBodyHandler)
+	return deletePublicAccessBlockRequest{Request: req}
+}
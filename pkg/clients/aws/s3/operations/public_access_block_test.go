@@ -0,0 +1,200 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operations
+
+import (
+	"crypto/md5" // nolint:gosec
+	"encoding/base64"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	"github.com/aws/aws-sdk-go-v2/aws/defaults"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/google/go-cmp/cmp"
+)
+
+const (
+	bucket = "coolbucket"
+
+	configurationXML = `<PublicAccessBlockConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><BlockPublicAcls>true</BlockPublicAcls><IgnorePublicAcls>false</IgnorePublicAcls><BlockPublicPolicy>true</BlockPublicPolicy><RestrictPublicBuckets>false</RestrictPublicBuckets></PublicAccessBlockConfiguration>`
+	errorXML         = `<Error><Code>NoSuchPublicAccessBlockConfiguration</Code><Message>The public access block configuration was not found</Message></Error>`
+)
+
+type request struct {
+	method string
+	uri    string
+	md5    string
+	body   []byte
+}
+
+type configuration struct {
+	XMLName               xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ PublicAccessBlockConfiguration"`
+	BlockPublicAcls       bool
+	IgnorePublicAcls      bool
+	BlockPublicPolicy     bool
+	RestrictPublicBuckets bool
+}
+
+// server returns a test server that records the request it receives and
+// responds with the supplied status code and body.
+func server(t *testing.T, got *request, status int, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("cannot read request body: %s", err)
+		}
+		*got = request{method: r.Method, uri: r.URL.RequestURI(), md5: r.Header.Get("Content-MD5"), body: b}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+}
+
+func operations(url string) *S3Operations {
+	cfg := defaults.Config()
+	cfg.Region = "us-east-1"
+	cfg.Credentials = aws.NewStaticCredentialsProvider("id", "secret", "")
+	cfg.EndpointResolver = aws.ResolveWithEndpointURL(url)
+	return NewS3Operations(s3.New(cfg))
+}
+
+func TestGetPublicAccessBlockRequest(t *testing.T) {
+	type want struct {
+		req request
+		out *GetPublicAccessBlockOutput
+		err string
+	}
+
+	cases := map[string]struct {
+		status int
+		body   string
+		want   want
+	}{
+		"Successful": {
+			status: http.StatusOK,
+			body:   configurationXML,
+			want: want{
+				req: request{method: http.MethodGet, uri: "/" + bucket + "?publicAccessBlock=", body: []byte{}},
+				out: &GetPublicAccessBlockOutput{PublicAccessBlockConfiguration: &PublicAccessBlockConfiguration{
+					BlockPublicAcls:       aws.Bool(true),
+					IgnorePublicAcls:      aws.Bool(false),
+					BlockPublicPolicy:     aws.Bool(true),
+					RestrictPublicBuckets: aws.Bool(false),
+				}},
+			},
+		},
+		"NotFound": {
+			status: http.StatusNotFound,
+			body:   errorXML,
+			want: want{
+				req: request{method: http.MethodGet, uri: "/" + bucket + "?publicAccessBlock=", body: []byte{}},
+				err: ErrCodeNoSuchPublicAccessBlockConfiguration,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := request{}
+			srv := server(t, &got, tc.status, tc.body)
+			defer srv.Close()
+
+			out, err := operations(srv.URL).GetPublicAccessBlockRequest(&GetPublicAccessBlockInput{Bucket: aws.String(bucket)}).Send()
+			if diff := cmp.Diff(tc.want.err, errorCode(err)); diff != "" {
+				t.Errorf("Send(): -want error code, +got error code:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.out, out); diff != "" {
+				t.Errorf("Send(): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.req, got, cmp.AllowUnexported(request{})); diff != "" {
+				t.Errorf("Send(): -want request, +got request:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPutPublicAccessBlockRequest(t *testing.T) {
+	got := request{}
+	srv := server(t, &got, http.StatusOK, "")
+	defer srv.Close()
+
+	in := &PutPublicAccessBlockInput{
+		Bucket: aws.String(bucket),
+		PublicAccessBlockConfiguration: &PublicAccessBlockConfiguration{
+			BlockPublicAcls:       aws.Bool(true),
+			IgnorePublicAcls:      aws.Bool(false),
+			BlockPublicPolicy:     aws.Bool(true),
+			RestrictPublicBuckets: aws.Bool(false),
+		},
+	}
+	if _, err := operations(srv.URL).PutPublicAccessBlockRequest(in).Send(); err != nil {
+		t.Fatalf("Send(): %s", err)
+	}
+
+	sum := md5.Sum(got.body) // nolint:gosec
+	want := request{
+		method: http.MethodPut,
+		uri:    "/" + bucket + "?publicAccessBlock=",
+		md5:    base64.StdEncoding.EncodeToString(sum[:]),
+		body:   got.body,
+	}
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(request{})); diff != "" {
+		t.Errorf("Send(): -want request, +got request:\n%s", diff)
+	}
+
+	gotcfg := configuration{}
+	if err := xml.Unmarshal(got.body, &gotcfg); err != nil {
+		t.Fatalf("xml.Unmarshal(...): %s", err)
+	}
+	wantcfg := configuration{
+		XMLName:           xml.Name{Space: "http://s3.amazonaws.com/doc/2006-03-01/", Local: "PublicAccessBlockConfiguration"},
+		BlockPublicAcls:   true,
+		BlockPublicPolicy: true,
+	}
+	if diff := cmp.Diff(wantcfg, gotcfg); diff != "" {
+		t.Errorf("Send(): -want body, +got body:\n%s", diff)
+	}
+}
+
+func TestDeletePublicAccessBlockRequest(t *testing.T) {
+	got := request{}
+	srv := server(t, &got, http.StatusNoContent, "")
+	defer srv.Close()
+
+	if _, err := operations(srv.URL).DeletePublicAccessBlockRequest(&DeletePublicAccessBlockInput{Bucket: aws.String(bucket)}).Send(); err != nil {
+		t.Fatalf("Send(): %s", err)
+	}
+
+	want := request{method: http.MethodDelete, uri: "/" + bucket + "?publicAccessBlock=", body: []byte{}}
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(request{})); diff != "" {
+		t.Errorf("Send(): -want request, +got request:\n%s", diff)
+	}
+}
+
+func errorCode(err error) string {
+	if ae, ok := err.(awserr.Error); ok {
+		return ae.Code()
+	}
+	if err != nil {
+		return err.Error()
+	}
+	return ""
+}
@@ -44,3 +44,103 @@ type PutBucketVersioningRequest interface {
 type DeleteBucketRequest interface {
 	Send() (*s3.DeleteBucketOutput, error)
 }
+
+// GetBucketLifecycleConfigurationRequest is a API request type for the GetBucketLifecycleConfiguration API operation.
+type GetBucketLifecycleConfigurationRequest interface {
+	Send() (*s3.GetBucketLifecycleConfigurationOutput, error)
+}
+
+// PutBucketLifecycleConfigurationRequest is a API request type for the PutBucketLifecycleConfiguration API operation.
+type PutBucketLifecycleConfigurationRequest interface {
+	Send() (*s3.PutBucketLifecycleConfigurationOutput, error)
+}
+
+// DeleteBucketLifecycleRequest is a API request type for the DeleteBucketLifecycle API operation.
+type DeleteBucketLifecycleRequest interface {
+	Send() (*s3.DeleteBucketLifecycleOutput, error)
+}
+
+// GetBucketCORSRequest is a API request type for the GetBucketCors API operation.
+type GetBucketCORSRequest interface {
+	Send() (*s3.GetBucketCorsOutput, error)
+}
+
+// PutBucketCORSRequest is a API request type for the PutBucketCors API operation.
+type PutBucketCORSRequest interface {
+	Send() (*s3.PutBucketCorsOutput, error)
+}
+
+// DeleteBucketCORSRequest is a API request type for the DeleteBucketCors API operation.
+type DeleteBucketCORSRequest interface {
+	Send() (*s3.DeleteBucketCorsOutput, error)
+}
+
+// GetBucketEncryptionRequest is a API request type for the GetBucketEncryption API operation.
+type GetBucketEncryptionRequest interface {
+	Send() (*s3.GetBucketEncryptionOutput, error)
+}
+
+// PutBucketEncryptionRequest is a API request type for the PutBucketEncryption API operation.
+type PutBucketEncryptionRequest interface {
+	Send() (*s3.PutBucketEncryptionOutput, error)
+}
+
+// DeleteBucketEncryptionRequest is a API request type for the DeleteBucketEncryption API operation.
+type DeleteBucketEncryptionRequest interface {
+	Send() (*s3.DeleteBucketEncryptionOutput, error)
+}
+
+// GetBucketLoggingRequest is a API request type for the GetBucketLogging API operation.
+type GetBucketLoggingRequest interface {
+	Send() (*s3.GetBucketLoggingOutput, error)
+}
+
+// PutBucketLoggingRequest is a API request type for the PutBucketLogging API operation.
+type PutBucketLoggingRequest interface {
+	Send() (*s3.PutBucketLoggingOutput, error)
+}
+
+// GetBucketWebsiteRequest is a API request type for the GetBucketWebsite API operation.
+type GetBucketWebsiteRequest interface {
+	Send() (*s3.GetBucketWebsiteOutput, error)
+}
+
+// PutBucketWebsiteRequest is a API request type for the PutBucketWebsite API operation.
+type PutBucketWebsiteRequest interface {
+	Send() (*s3.PutBucketWebsiteOutput, error)
+}
+
+// DeleteBucketWebsiteRequest is a API request type for the DeleteBucketWebsite API operation.
+type DeleteBucketWebsiteRequest interface {
+	Send() (*s3.DeleteBucketWebsiteOutput, error)
+}
+
+// GetBucketTaggingRequest is a API request type for the GetBucketTagging API operation.
+type GetBucketTaggingRequest interface {
+	Send() (*s3.GetBucketTaggingOutput, error)
+}
+
+// PutBucketTaggingRequest is a API request type for the PutBucketTagging API operation.
+type PutBucketTaggingRequest interface {
+	Send() (*s3.PutBucketTaggingOutput, error)
+}
+
+// DeleteBucketTaggingRequest is a API request type for the DeleteBucketTagging API operation.
+type DeleteBucketTaggingRequest interface {
+	Send() (*s3.DeleteBucketTaggingOutput, error)
+}
+
+// GetPublicAccessBlockRequest is a API request type for the GetPublicAccessBlock API operation.
+type GetPublicAccessBlockRequest interface {
+	Send() (*GetPublicAccessBlockOutput, error)
+}
+
+// PutPublicAccessBlockRequest is a API request type for the PutPublicAccessBlock API operation.
+type PutPublicAccessBlockRequest interface {
+	Send() (*PutPublicAccessBlockOutput, error)
+}
+
+// DeletePublicAccessBlockRequest is a API request type for the DeletePublicAccessBlock API operation.
+type DeletePublicAccessBlockRequest interface {
+	Send() (*DeletePublicAccessBlockOutput, error)
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package operations
 
 import (
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/s3iface"
 )
@@ -24,11 +25,15 @@ import (
 // S3Operations provides methods for common S3 operations
 type S3Operations struct {
 	s3 s3iface.S3API
+
+	// client is used to build requests for operations the S3 API client does
+	// not support.
+	client *aws.Client
 }
 
 // NewS3Operations creates a new instance of S3Operations
-func NewS3Operations(s3 s3iface.S3API) *S3Operations {
-	return &S3Operations{s3: s3}
+func NewS3Operations(s3 *s3.S3) *S3Operations {
+	return &S3Operations{s3: s3, client: s3.Client}
 }
 
 // GetBucketVersioningRequest creates a get bucket versioning request
@@ -55,3 +60,88 @@ func (api *S3Operations) DeleteBucketRequest(i *s3.DeleteBucketInput) DeleteBuck
 func (api *S3Operations) CreateBucketRequest(i *s3.CreateBucketInput) CreateBucketRequest {
 	return api.s3.CreateBucketRequest(i)
 }
+
+// GetBucketLifecycleConfigurationRequest creates a get bucket lifecycle configuration request
+func (api *S3Operations) GetBucketLifecycleConfigurationRequest(i *s3.GetBucketLifecycleConfigurationInput) GetBucketLifecycleConfigurationRequest {
+	return api.s3.GetBucketLifecycleConfigurationRequest(i)
+}
+
+// PutBucketLifecycleConfigurationRequest creates a put bucket lifecycle configuration request
+func (api *S3Operations) PutBucketLifecycleConfigurationRequest(i *s3.PutBucketLifecycleConfigurationInput) PutBucketLifecycleConfigurationRequest {
+	return api.s3.PutBucketLifecycleConfigurationRequest(i)
+}
+
+// DeleteBucketLifecycleRequest creates a delete bucket lifecycle request
+func (api *S3Operations) DeleteBucketLifecycleRequest(i *s3.DeleteBucketLifecycleInput) DeleteBucketLifecycleRequest {
+	return api.s3.DeleteBucketLifecycleRequest(i)
+}
+
+// GetBucketCORSRequest creates a get bucket CORS request
+func (api *S3Operations) GetBucketCORSRequest(i *s3.GetBucketCorsInput) GetBucketCORSRequest {
+	return api.s3.GetBucketCorsRequest(i)
+}
+
+// PutBucketCORSRequest creates a put bucket CORS request
+func (api *S3Operations) PutBucketCORSRequest(i *s3.PutBucketCorsInput) PutBucketCORSRequest {
+	return api.s3.PutBucketCorsRequest(i)
+}
+
+// DeleteBucketCORSRequest creates a delete bucket CORS request
+func (api *S3Operations) DeleteBucketCORSRequest(i *s3.DeleteBucketCorsInput) DeleteBucketCORSRequest {
+	return api.s3.DeleteBucketCorsRequest(i)
+}
+
+// GetBucketEncryptionRequest creates a get bucket encryption request
+func (api *S3Operations) GetBucketEncryptionRequest(i *s3.GetBucketEncryptionInput) GetBucketEncryptionRequest {
+	return api.s3.GetBucketEncryptionRequest(i)
+}
+
+// PutBucketEncryptionRequest creates a put bucket encryption request
+func (api *S3Operations) PutBucketEncryptionRequest(i *s3.PutBucketEncryptionInput) PutBucketEncryptionRequest {
+	return api.s3.PutBucketEncryptionRequest(i)
+}
+
+// DeleteBucketEncryptionRequest creates a delete bucket encryption request
+func (api *S3Operations) DeleteBucketEncryptionRequest(i *s3.DeleteBucketEncryptionInput) DeleteBucketEncryptionRequest {
+	return api.s3.DeleteBucketEncryptionRequest(i)
+}
+
+// GetBucketLoggingRequest creates a get bucket logging request
+func (api *S3Operations) GetBucketLoggingRequest(i *s3.GetBucketLoggingInput) GetBucketLoggingRequest {
+	return api.s3.GetBucketLoggingRequest(i)
+}
+
+// PutBucketLoggingRequest creates a put bucket logging request
+func (api *S3Operations) PutBucketLoggingRequest(i *s3.PutBucketLoggingInput) PutBucketLoggingRequest {
+	return api.s3.PutBucketLoggingRequest(i)
+}
+
+// GetBucketWebsiteRequest creates a get bucket website request
+func (api *S3Operations) GetBucketWebsiteRequest(i *s3.GetBucketWebsiteInput) GetBucketWebsiteRequest {
+	return api.s3.GetBucketWebsiteRequest(i)
+}
+
+// PutBucketWebsiteRequest creates a put bucket website request
+func (api *S3Operations) PutBucketWebsiteRequest(i *s3.PutBucketWebsiteInput) PutBucketWebsiteRequest {
+	return api.s3.PutBucketWebsiteRequest(i)
+}
+
+// DeleteBucketWebsiteRequest creates a delete bucket website request
+func (api *S3Operations) DeleteBucketWebsiteRequest(i *s3.DeleteBucketWebsiteInput) DeleteBucketWebsiteRequest {
+	return api.s3.DeleteBucketWebsiteRequest(i)
+}
+
+// GetBucketTaggingRequest creates a get bucket tagging request
+func (api *S3Operations) GetBucketTaggingRequest(i *s3.GetBucketTaggingInput) GetBucketTaggingRequest {
+	return api.s3.GetBucketTaggingRequest(i)
+}
+
+// PutBucketTaggingRequest creates a put bucket tagging request
+func (api *S3Operations) PutBucketTaggingRequest(i *s3.PutBucketTaggingInput) PutBucketTaggingRequest {
+	return api.s3.PutBucketTaggingRequest(i)
+}
+
+// DeleteBucketTaggingRequest creates a delete bucket tagging request
+func (api *S3Operations) DeleteBucketTaggingRequest(i *s3.DeleteBucketTaggingInput) DeleteBucketTaggingRequest {
+	return api.s3.DeleteBucketTaggingRequest(i)
+}
@@ -33,6 +33,10 @@ import (
 	iamc "github.com/crossplaneio/crossplane/pkg/clients/aws/iam"
 )
 
+// API is the name of the AWS API used by S3 clients. S3 clients also call the
+// IAM API; requests to it are rate limited as requests to S3.
+const API = s3.EndpointsID
+
 const (
 	bucketUser           = "crossplane-bucket-%s"
 	bucketObjectARN      = "arn:aws:s3:::%s"
@@ -49,6 +53,13 @@ type Service interface {
 	UpdateBucketACL(bucket *v1alpha1.S3Bucket) error
 	UpdateVersioning(bucket *v1alpha1.S3Bucket) error
 	UpdatePolicyDocument(username string, bucket *v1alpha1.S3Bucket) (string, error)
+	UpdateLifecycleConfiguration(bucket *v1alpha1.S3Bucket) error
+	UpdateCORS(bucket *v1alpha1.S3Bucket) error
+	UpdateEncryption(bucket *v1alpha1.S3Bucket) error
+	UpdateLogging(bucket *v1alpha1.S3Bucket) error
+	UpdateWebsite(bucket *v1alpha1.S3Bucket) error
+	UpdateTagging(bucket *v1alpha1.S3Bucket) error
+	UpdatePublicAccessBlock(bucket *v1alpha1.S3Bucket) error
 	DeleteBucket(bucket *v1alpha1.S3Bucket) error
 }
 
@@ -64,6 +75,12 @@ func NewClient(config *aws.Config) Service {
 	return &Client{s3: ops, iamClient: iamc.NewClient(config)}
 }
 
+// NewClientWithOperations returns an S3 Client that uses the supplied S3
+// operations and IAM client, for example simulated ones.
+func NewClientWithOperations(ops operations.Operations, iam iamc.Client) Service {
+	return &Client{s3: ops, iamClient: iam}
+}
+
 // CreateOrUpdateBucket creates or updates the supplied S3 bucket with provided
 // specification, and returns access keys with permissions of localPermission
 func (c *Client) CreateOrUpdateBucket(bucket *v1alpha1.S3Bucket) error {
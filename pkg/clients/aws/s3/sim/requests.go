@@ -0,0 +1,251 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sim
+
+import (
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/crossplaneio/crossplane/pkg/clients/aws/s3/operations"
+)
+
+// Requests of the simulated S3 API. Each is applied when it is built, and
+// returns its outcome when it is sent.
+
+type createBucketRequest struct {
+	out *s3.CreateBucketOutput
+	err error
+}
+
+func (r createBucketRequest) Send() (*s3.CreateBucketOutput, error) {
+	return r.out, r.err
+}
+
+type getBucketVersioningRequest struct {
+	out *s3.GetBucketVersioningOutput
+	err error
+}
+
+func (r getBucketVersioningRequest) Send() (*s3.GetBucketVersioningOutput, error) {
+	return r.out, r.err
+}
+
+type putBucketACLRequest struct {
+	out *s3.PutBucketAclOutput
+	err error
+}
+
+func (r putBucketACLRequest) Send() (*s3.PutBucketAclOutput, error) {
+	return r.out, r.err
+}
+
+type putBucketVersioningRequest struct {
+	out *s3.PutBucketVersioningOutput
+	err error
+}
+
+func (r putBucketVersioningRequest) Send() (*s3.PutBucketVersioningOutput, error) {
+	return r.out, r.err
+}
+
+type deleteBucketRequest struct {
+	out *s3.DeleteBucketOutput
+	err error
+}
+
+func (r deleteBucketRequest) Send() (*s3.DeleteBucketOutput, error) {
+	return r.out, r.err
+}
+
+type getBucketLifecycleConfigurationRequest struct {
+	out *s3.GetBucketLifecycleConfigurationOutput
+	err error
+}
+
+func (r getBucketLifecycleConfigurationRequest) Send() (*s3.GetBucketLifecycleConfigurationOutput, error) {
+	return r.out, r.err
+}
+
+type putBucketLifecycleConfigurationRequest struct {
+	out *s3.PutBucketLifecycleConfigurationOutput
+	err error
+}
+
+func (r putBucketLifecycleConfigurationRequest) Send() (*s3.PutBucketLifecycleConfigurationOutput, error) {
+	return r.out, r.err
+}
+
+type deleteBucketLifecycleRequest struct {
+	out *s3.DeleteBucketLifecycleOutput
+	err error
+}
+
+func (r deleteBucketLifecycleRequest) Send() (*s3.DeleteBucketLifecycleOutput, error) {
+	return r.out, r.err
+}
+
+type getBucketCORSRequest struct {
+	out *s3.GetBucketCorsOutput
+	err error
+}
+
+func (r getBucketCORSRequest) Send() (*s3.GetBucketCorsOutput, error) {
+	return r.out, r.err
+}
+
+type putBucketCORSRequest struct {
+	out *s3.PutBucketCorsOutput
+	err error
+}
+
+func (r putBucketCORSRequest) Send() (*s3.PutBucketCorsOutput, error) {
+	return r.out, r.err
+}
+
+type deleteBucketCORSRequest struct {
+	out *s3.DeleteBucketCorsOutput
+	err error
+}
+
+func (r deleteBucketCORSRequest) Send() (*s3.DeleteBucketCorsOutput, error) {
+	return r.out, r.err
+}
+
+type getBucketEncryptionRequest struct {
+	out *s3.GetBucketEncryptionOutput
+	err error
+}
+
+func (r getBucketEncryptionRequest) Send() (*s3.GetBucketEncryptionOutput, error) {
+	return r.out, r.err
+}
+
+type putBucketEncryptionRequest struct {
+	out *s3.PutBucketEncryptionOutput
+	err error
+}
+
+func (r putBucketEncryptionRequest) Send() (*s3.PutBucketEncryptionOutput, error) {
+	return r.out, r.err
+}
+
+type deleteBucketEncryptionRequest struct {
+	out *s3.DeleteBucketEncryptionOutput
+	err error
+}
+
+func (r deleteBucketEncryptionRequest) Send() (*s3.DeleteBucketEncryptionOutput, error) {
+	return r.out, r.err
+}
+
+type getBucketLoggingRequest struct {
+	out *s3.GetBucketLoggingOutput
+	err error
+}
+
+func (r getBucketLoggingRequest) Send() (*s3.GetBucketLoggingOutput, error) {
+	return r.out, r.err
+}
+
+type putBucketLoggingRequest struct {
+	out *s3.PutBucketLoggingOutput
+	err error
+}
+
+func (r putBucketLoggingRequest) Send() (*s3.PutBucketLoggingOutput, error) {
+	return r.out, r.err
+}
+
+type getBucketWebsiteRequest struct {
+	out *s3.GetBucketWebsiteOutput
+	err error
+}
+
+func (r getBucketWebsiteRequest) Send() (*s3.GetBucketWebsiteOutput, error) {
+	return r.out, r.err
+}
+
+type putBucketWebsiteRequest struct {
+	out *s3.PutBucketWebsiteOutput
+	err error
+}
+
+func (r putBucketWebsiteRequest) Send() (*s3.PutBucketWebsiteOutput, error) {
+	return r.out, r.err
+}
+
+type deleteBucketWebsiteRequest struct {
+	out *s3.DeleteBucketWebsiteOutput
+	err error
+}
+
+func (r deleteBucketWebsiteRequest) Send() (*s3.DeleteBucketWebsiteOutput, error) {
+	return r.out, r.err
+}
+
+type getBucketTaggingRequest struct {
+	out *s3.GetBucketTaggingOutput
+	err error
+}
+
+func (r getBucketTaggingRequest) Send() (*s3.GetBucketTaggingOutput, error) {
+	return r.out, r.err
+}
+
+type putBucketTaggingRequest struct {
+	out *s3.PutBucketTaggingOutput
+	err error
+}
+
+func (r putBucketTaggingRequest) Send() (*s3.PutBucketTaggingOutput, error) {
+	return r.out, r.err
+}
+
+type deleteBucketTaggingRequest struct {
+	out *s3.DeleteBucketTaggingOutput
+	err error
+}
+
+func (r deleteBucketTaggingRequest) Send() (*s3.DeleteBucketTaggingOutput, error) {
+	return r.out, r.err
+}
+
+type getPublicAccessBlockRequest struct {
+	out *operations.GetPublicAccessBlockOutput
+	err error
+}
+
+func (r getPublicAccessBlockRequest) Send() (*operations.GetPublicAccessBlockOutput, error) {
+	return r.out, r.err
+}
+
+type putPublicAccessBlockRequest struct {
+	out *operations.PutPublicAccessBlockOutput
+	err error
+}
+
+func (r putPublicAccessBlockRequest) Send() (*operations.PutPublicAccessBlockOutput, error) {
+	return r.out, r.err
+}
+
+type deletePublicAccessBlockRequest struct {
+	out *operations.DeletePublicAccessBlockOutput
+	err error
+}
+
+func (r deletePublicAccessBlockRequest) Send() (*operations.DeletePublicAccessBlockOutput, error) {
+	return r.out, r.err
+}
@@ -0,0 +1,423 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sim provides a simulated S3 API.
+package sim
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/crossplaneio/crossplane/pkg/clients/aws/s3/operations"
+	"github.com/crossplaneio/crossplane/pkg/clients/sim"
+)
+
+// Error codes of requests to get configuration a bucket does not have.
+const (
+	errCodeNoSuchLifecycleConfiguration  = "NoSuchLifecycleConfiguration"
+	errCodeNoSuchCORSConfiguration       = "NoSuchCORSConfiguration"
+	errCodeNoSuchEncryptionConfiguration = "ServerSideEncryptionConfigurationNotFoundError"
+	errCodeNoSuchWebsiteConfiguration    = "NoSuchWebsiteConfiguration"
+	errCodeNoSuchTagSet                  = "NoSuchTagSet"
+)
+
+type bucket struct {
+	region            string
+	acl               s3.BucketCannedACL
+	versioning        s3.BucketVersioningStatus
+	lifecycle         []s3.LifecycleRule
+	cors              []s3.CORSRule
+	encryption        *s3.ServerSideEncryptionConfiguration
+	logging           *s3.LoggingEnabled
+	website           *s3.WebsiteConfiguration
+	tags              []s3.Tag
+	publicAccessBlock *operations.PublicAccessBlockConfiguration
+}
+
+// Operations is a simulated S3 API. S3 creates, configures, and deletes
+// buckets synchronously, so the simulated API has no transitional states. It
+// may be used with an S3 client, and with a simulated IAM API, by way of
+// s3.NewClientWithOperations. Errors may be injected into any request, keyed
+// by the name of the S3 API operation, for example "CreateBucket" or
+// "PutBucketCors".
+type Operations struct {
+	sim.Failures
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+var _ operations.Operations = &Operations{}
+
+// NewOperations returns a simulated S3 API with no buckets.
+func NewOperations() *Operations {
+	return &Operations{buckets: map[string]*bucket{}}
+}
+
+// CreateBucketRequest creates a bucket.
+func (o *Operations) CreateBucketRequest(i *s3.CreateBucketInput) operations.CreateBucketRequest {
+	if err := o.Call("CreateBucket"); err != nil {
+		return createBucketRequest{err: err}
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	name := aws.StringValue(i.Bucket)
+	if _, ok := o.buckets[name]; ok {
+		return createBucketRequest{err: awserr.New(s3.ErrCodeBucketAlreadyOwnedByYou, "Your previous request to create the named bucket succeeded and you already own it.", nil)}
+	}
+	b := &bucket{acl: i.ACL}
+	if i.CreateBucketConfiguration != nil {
+		b.region = string(i.CreateBucketConfiguration.LocationConstraint)
+	}
+	o.buckets[name] = b
+	return createBucketRequest{out: &s3.CreateBucketOutput{Location: aws.String("/" + name)}}
+}
+
+// DeleteBucketRequest deletes a bucket.
+func (o *Operations) DeleteBucketRequest(i *s3.DeleteBucketInput) operations.DeleteBucketRequest {
+	if err := o.Call("DeleteBucket"); err != nil {
+		return deleteBucketRequest{err: err}
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	name := aws.StringValue(i.Bucket)
+	if _, ok := o.buckets[name]; !ok {
+		return deleteBucketRequest{err: noSuchBucket(name)}
+	}
+	delete(o.buckets, name)
+	return deleteBucketRequest{out: &s3.DeleteBucketOutput{}}
+}
+
+// PutBucketACLRequest sets the canned ACL of a bucket.
+func (o *Operations) PutBucketACLRequest(i *s3.PutBucketAclInput) operations.PutBucketACLRequest {
+	if err := o.update("PutBucketAcl", i.Bucket, func(b *bucket) { b.acl = i.ACL }); err != nil {
+		return putBucketACLRequest{err: err}
+	}
+	return putBucketACLRequest{out: &s3.PutBucketAclOutput{}}
+}
+
+// GetBucketVersioningRequest returns the versioning status of a bucket.
+func (o *Operations) GetBucketVersioningRequest(i *s3.GetBucketVersioningInput) operations.GetBucketVersioningRequest {
+	out := &s3.GetBucketVersioningOutput{}
+	if err := o.read("GetBucketVersioning", i.Bucket, func(b *bucket) error {
+		out.Status = b.versioning
+		return nil
+	}); err != nil {
+		return getBucketVersioningRequest{err: err}
+	}
+	return getBucketVersioningRequest{out: out}
+}
+
+// PutBucketVersioningRequest sets the versioning status of a bucket.
+func (o *Operations) PutBucketVersioningRequest(i *s3.PutBucketVersioningInput) operations.PutBucketVersioningRequest {
+	if err := o.update("PutBucketVersioning", i.Bucket, func(b *bucket) {
+		if i.VersioningConfiguration != nil {
+			b.versioning = i.VersioningConfiguration.Status
+		}
+	}); err != nil {
+		return putBucketVersioningRequest{err: err}
+	}
+	return putBucketVersioningRequest{out: &s3.PutBucketVersioningOutput{}}
+}
+
+// GetBucketLifecycleConfigurationRequest returns the lifecycle rules of a
+// bucket.
+func (o *Operations) GetBucketLifecycleConfigurationRequest(i *s3.GetBucketLifecycleConfigurationInput) operations.GetBucketLifecycleConfigurationRequest {
+	out := &s3.GetBucketLifecycleConfigurationOutput{}
+	if err := o.read("GetBucketLifecycleConfiguration", i.Bucket, func(b *bucket) error {
+		if len(b.lifecycle) == 0 {
+			return awserr.New(errCodeNoSuchLifecycleConfiguration, "The lifecycle configuration does not exist.", nil)
+		}
+		out.Rules = b.lifecycle
+		return nil
+	}); err != nil {
+		return getBucketLifecycleConfigurationRequest{err: err}
+	}
+	return getBucketLifecycleConfigurationRequest{out: out}
+}
+
+// PutBucketLifecycleConfigurationRequest replaces the lifecycle rules of a
+// bucket.
+func (o *Operations) PutBucketLifecycleConfigurationRequest(i *s3.PutBucketLifecycleConfigurationInput) operations.PutBucketLifecycleConfigurationRequest {
+	if err := o.update("PutBucketLifecycleConfiguration", i.Bucket, func(b *bucket) {
+		b.lifecycle = nil
+		if i.LifecycleConfiguration != nil {
+			b.lifecycle = i.LifecycleConfiguration.Rules
+		}
+	}); err != nil {
+		return putBucketLifecycleConfigurationRequest{err: err}
+	}
+	return putBucketLifecycleConfigurationRequest{out: &s3.PutBucketLifecycleConfigurationOutput{}}
+}
+
+// DeleteBucketLifecycleRequest removes the lifecycle rules of a bucket.
+func (o *Operations) DeleteBucketLifecycleRequest(i *s3.DeleteBucketLifecycleInput) operations.DeleteBucketLifecycleRequest {
+	if err := o.update("DeleteBucketLifecycle", i.Bucket, func(b *bucket) { b.lifecycle = nil }); err != nil {
+		return deleteBucketLifecycleRequest{err: err}
+	}
+	return deleteBucketLifecycleRequest{out: &s3.DeleteBucketLifecycleOutput{}}
+}
+
+// GetBucketCORSRequest returns the CORS rules of a bucket.
+func (o *Operations) GetBucketCORSRequest(i *s3.GetBucketCorsInput) operations.GetBucketCORSRequest {
+	out := &s3.GetBucketCorsOutput{}
+	if err := o.read("GetBucketCors", i.Bucket, func(b *bucket) error {
+		if len(b.cors) == 0 {
+			return awserr.New(errCodeNoSuchCORSConfiguration, "The CORS configuration does not exist.", nil)
+		}
+		out.CORSRules = b.cors
+		return nil
+	}); err != nil {
+		return getBucketCORSRequest{err: err}
+	}
+	return getBucketCORSRequest{out: out}
+}
+
+// PutBucketCORSRequest replaces the CORS rules of a bucket.
+func (o *Operations) PutBucketCORSRequest(i *s3.PutBucketCorsInput) operations.PutBucketCORSRequest {
+	if err := o.update("PutBucketCors", i.Bucket, func(b *bucket) {
+		b.cors = nil
+		if i.CORSConfiguration != nil {
+			b.cors = i.CORSConfiguration.CORSRules
+		}
+	}); err != nil {
+		return putBucketCORSRequest{err: err}
+	}
+	return putBucketCORSRequest{out: &s3.PutBucketCorsOutput{}}
+}
+
+// DeleteBucketCORSRequest removes the CORS rules of a bucket.
+func (o *Operations) DeleteBucketCORSRequest(i *s3.DeleteBucketCorsInput) operations.DeleteBucketCORSRequest {
+	if err := o.update("DeleteBucketCors", i.Bucket, func(b *bucket) { b.cors = nil }); err != nil {
+		return deleteBucketCORSRequest{err: err}
+	}
+	return deleteBucketCORSRequest{out: &s3.DeleteBucketCorsOutput{}}
+}
+
+// GetBucketEncryptionRequest returns the default encryption configuration of
+// a bucket.
+func (o *Operations) GetBucketEncryptionRequest(i *s3.GetBucketEncryptionInput) operations.GetBucketEncryptionRequest {
+	out := &s3.GetBucketEncryptionOutput{}
+	if err := o.read("GetBucketEncryption", i.Bucket, func(b *bucket) error {
+		if b.encryption == nil {
+			return awserr.New(errCodeNoSuchEncryptionConfiguration, "The server side encryption configuration was not found.", nil)
+		}
+		out.ServerSideEncryptionConfiguration = b.encryption
+		return nil
+	}); err != nil {
+		return getBucketEncryptionRequest{err: err}
+	}
+	return getBucketEncryptionRequest{out: out}
+}
+
+// PutBucketEncryptionRequest replaces the default encryption configuration of
+// a bucket.
+func (o *Operations) PutBucketEncryptionRequest(i *s3.PutBucketEncryptionInput) operations.PutBucketEncryptionRequest {
+	if err := o.update("PutBucketEncryption", i.Bucket, func(b *bucket) { b.encryption = i.ServerSideEncryptionConfiguration }); err != nil {
+		return putBucketEncryptionRequest{err: err}
+	}
+	return putBucketEncryptionRequest{out: &s3.PutBucketEncryptionOutput{}}
+}
+
+// DeleteBucketEncryptionRequest removes the default encryption configuration
+// of a bucket.
+func (o *Operations) DeleteBucketEncryptionRequest(i *s3.DeleteBucketEncryptionInput) operations.DeleteBucketEncryptionRequest {
+	if err := o.update("DeleteBucketEncryption", i.Bucket, func(b *bucket) { b.encryption = nil }); err != nil {
+		return deleteBucketEncryptionRequest{err: err}
+	}
+	return deleteBucketEncryptionRequest{out: &s3.DeleteBucketEncryptionOutput{}}
+}
+
+// GetBucketLoggingRequest returns the server access logging configuration of
+// a bucket. Logging is disabled if the configuration is empty.
+func (o *Operations) GetBucketLoggingRequest(i *s3.GetBucketLoggingInput) operations.GetBucketLoggingRequest {
+	out := &s3.GetBucketLoggingOutput{}
+	if err := o.read("GetBucketLogging", i.Bucket, func(b *bucket) error {
+		out.LoggingEnabled = b.logging
+		return nil
+	}); err != nil {
+		return getBucketLoggingRequest{err: err}
+	}
+	return getBucketLoggingRequest{out: out}
+}
+
+// PutBucketLoggingRequest replaces the server access logging configuration of
+// a bucket.
+func (o *Operations) PutBucketLoggingRequest(i *s3.PutBucketLoggingInput) operations.PutBucketLoggingRequest {
+	if err := o.update("PutBucketLogging", i.Bucket, func(b *bucket) {
+		b.logging = nil
+		if i.BucketLoggingStatus != nil {
+			b.logging = i.BucketLoggingStatus.LoggingEnabled
+		}
+	}); err != nil {
+		return putBucketLoggingRequest{err: err}
+	}
+	return putBucketLoggingRequest{out: &s3.PutBucketLoggingOutput{}}
+}
+
+// GetBucketWebsiteRequest returns the static website configuration of a
+// bucket.
+func (o *Operations) GetBucketWebsiteRequest(i *s3.GetBucketWebsiteInput) operations.GetBucketWebsiteRequest {
+	out := &s3.GetBucketWebsiteOutput{}
+	if err := o.read("GetBucketWebsite", i.Bucket, func(b *bucket) error {
+		if b.website == nil {
+			return awserr.New(errCodeNoSuchWebsiteConfiguration, "The specified bucket does not have a website configuration.", nil)
+		}
+		out.IndexDocument = b.website.IndexDocument
+		out.ErrorDocument = b.website.ErrorDocument
+		out.RedirectAllRequestsTo = b.website.RedirectAllRequestsTo
+		out.RoutingRules = b.website.RoutingRules
+		return nil
+	}); err != nil {
+		return getBucketWebsiteRequest{err: err}
+	}
+	return getBucketWebsiteRequest{out: out}
+}
+
+// PutBucketWebsiteRequest replaces the static website configuration of a
+// bucket.
+func (o *Operations) PutBucketWebsiteRequest(i *s3.PutBucketWebsiteInput) operations.PutBucketWebsiteRequest {
+	if err := o.update("PutBucketWebsite", i.Bucket, func(b *bucket) { b.website = i.WebsiteConfiguration }); err != nil {
+		return putBucketWebsiteRequest{err: err}
+	}
+	return putBucketWebsiteRequest{out: &s3.PutBucketWebsiteOutput{}}
+}
+
+// DeleteBucketWebsiteRequest removes the static website configuration of a
+// bucket.
+func (o *Operations) DeleteBucketWebsiteRequest(i *s3.DeleteBucketWebsiteInput) operations.DeleteBucketWebsiteRequest {
+	if err := o.update("DeleteBucketWebsite", i.Bucket, func(b *bucket) { b.website = nil }); err != nil {
+		return deleteBucketWebsiteRequest{err: err}
+	}
+	return deleteBucketWebsiteRequest{out: &s3.DeleteBucketWebsiteOutput{}}
+}
+
+// GetBucketTaggingRequest returns the tags of a bucket.
+func (o *Operations) GetBucketTaggingRequest(i *s3.GetBucketTaggingInput) operations.GetBucketTaggingRequest {
+	out := &s3.GetBucketTaggingOutput{}
+	if err := o.read("GetBucketTagging", i.Bucket, func(b *bucket) error {
+		if len(b.tags) == 0 {
+			return awserr.New(errCodeNoSuchTagSet, "The TagSet does not exist.", nil)
+		}
+		out.TagSet = b.tags
+		return nil
+	}); err != nil {
+		return getBucketTaggingRequest{err: err}
+	}
+	return getBucketTaggingRequest{out: out}
+}
+
+// PutBucketTaggingRequest replaces the tags of a bucket.
+func (o *Operations) PutBucketTaggingRequest(i *s3.PutBucketTaggingInput) operations.PutBucketTaggingRequest {
+	if err := o.update("PutBucketTagging", i.Bucket, func(b *bucket) {
+		b.tags = nil
+		if i.Tagging != nil {
+			b.tags = i.Tagging.TagSet
+		}
+	}); err != nil {
+		return putBucketTaggingRequest{err: err}
+	}
+	return putBucketTaggingRequest{out: &s3.PutBucketTaggingOutput{}}
+}
+
+// DeleteBucketTaggingRequest removes the tags of a bucket.
+func (o *Operations) DeleteBucketTaggingRequest(i *s3.DeleteBucketTaggingInput) operations.DeleteBucketTaggingRequest {
+	if err := o.update("DeleteBucketTagging", i.Bucket, func(b *bucket) { b.tags = nil }); err != nil {
+		return deleteBucketTaggingRequest{err: err}
+	}
+	return deleteBucketTaggingRequest{out: &s3.DeleteBucketTaggingOutput{}}
+}
+
+// GetPublicAccessBlockRequest returns the public access block of a bucket.
+func (o *Operations) GetPublicAccessBlockRequest(i *operations.GetPublicAccessBlockInput) operations.GetPublicAccessBlockRequest {
+	out := &operations.GetPublicAccessBlockOutput{}
+	if err := o.read("GetPublicAccessBlock", i.Bucket, func(b *bucket) error {
+		if b.publicAccessBlock == nil {
+			return awserr.New(operations.ErrCodeNoSuchPublicAccessBlockConfiguration, "The public access block configuration was not found.", nil)
+		}
+		out.PublicAccessBlockConfiguration = b.publicAccessBlock
+		return nil
+	}); err != nil {
+		return getPublicAccessBlockRequest{err: err}
+	}
+	return getPublicAccessBlockRequest{out: out}
+}
+
+// PutPublicAccessBlockRequest replaces the public access block of a bucket.
+func (o *Operations) PutPublicAccessBlockRequest(i *operations.PutPublicAccessBlockInput) operations.PutPublicAccessBlockRequest {
+	if err := o.update("PutPublicAccessBlock", i.Bucket, func(b *bucket) { b.publicAccessBlock = i.PublicAccessBlockConfiguration }); err != nil {
+		return putPublicAccessBlockRequest{err: err}
+	}
+	return putPublicAccessBlockRequest{out: &operations.PutPublicAccessBlockOutput{}}
+}
+
+// DeletePublicAccessBlockRequest removes the public access block of a bucket.
+func (o *Operations) DeletePublicAccessBlockRequest(i *operations.DeletePublicAccessBlockInput) operations.DeletePublicAccessBlockRequest {
+	if err := o.update("DeletePublicAccessBlock", i.Bucket, func(b *bucket) { b.publicAccessBlock = nil }); err != nil {
+		return deletePublicAccessBlockRequest{err: err}
+	}
+	return deletePublicAccessBlockRequest{out: &operations.DeletePublicAccessBlockOutput{}}
+}
+
+// Buckets returns the names of all buckets that exist, in order.
+func (o *Operations) Buckets() []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	names := make([]string, 0, len(o.buckets))
+	for name := range o.buckets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// read calls the supplied function with the named bucket, if it exists.
+func (o *Operations) read(op string, name *string, fn func(b *bucket) error) error {
+	if err := o.Call(op); err != nil {
+		return err
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	b, ok := o.buckets[aws.StringValue(name)]
+	if !ok {
+		return noSuchBucket(aws.StringValue(name))
+	}
+	return fn(b)
+}
+
+// update calls the supplied function with the named bucket, if it exists.
+func (o *Operations) update(op string, name *string, fn func(b *bucket)) error {
+	return o.read(op, name, func(b *bucket) error {
+		fn(b)
+		return nil
+	})
+}
+
+func noSuchBucket(name string) error {
+	return awserr.New(s3.ErrCodeNoSuchBucket, fmt.Sprintf("The specified bucket %s does not exist.", name), nil)
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sim
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	storage "github.com/crossplaneio/crossplane/apis/storage/v1alpha1"
+	"github.com/crossplaneio/crossplane/aws/apis/storage/v1alpha1"
+	iamsim "github.com/crossplaneio/crossplane/pkg/clients/aws/iam/sim"
+	client "github.com/crossplaneio/crossplane/pkg/clients/aws/s3"
+	"github.com/crossplaneio/crossplane/pkg/clients/aws/s3/operations"
+)
+
+const bucketName = "cool-bucket"
+
+func code(err error) string {
+	if ae, ok := err.(awserr.Error); ok {
+		return ae.Code()
+	}
+	return ""
+}
+
+func TestBucketLifecycle(t *testing.T) {
+	ops := NewOperations()
+	iam := iamsim.NewClient()
+	c := client.NewClientWithOperations(ops, iam)
+
+	perm := storage.ReadWritePermission
+	b := &v1alpha1.S3Bucket{
+		ObjectMeta: metav1.ObjectMeta{Name: bucketName},
+		Spec: v1alpha1.S3BucketSpec{S3BucketParameters: v1alpha1.S3BucketParameters{
+			NameFormat:      bucketName,
+			Region:          "us-west-2",
+			Versioning:      true,
+			LocalPermission: &perm,
+			CORSRules:       []v1alpha1.CORSRule{{AllowedMethods: []string{"GET"}, AllowedOrigins: []string{"*"}}},
+			PublicAccessBlock: &v1alpha1.BucketPublicAccessBlock{
+				BlockPublicACLs:       true,
+				IgnorePublicACLs:      true,
+				BlockPublicPolicy:     true,
+				RestrictPublicBuckets: true,
+			},
+		}},
+	}
+	username := client.GenerateBucketUsername(b)
+
+	if err := c.CreateOrUpdateBucket(b); err != nil {
+		t.Fatalf("c.CreateOrUpdateBucket(...): %s", err)
+	}
+	if err := c.CreateOrUpdateBucket(b); err != nil {
+		t.Errorf("c.CreateOrUpdateBucket(...): creating an existing bucket: %s", err)
+	}
+	if _, _, err := c.CreateUser(username, b); err != nil {
+		t.Fatalf("c.CreateUser(...): %s", err)
+	}
+	if err := c.UpdateVersioning(b); err != nil {
+		t.Fatalf("c.UpdateVersioning(...): %s", err)
+	}
+
+	got, err := c.GetBucketInfo(username, b)
+	if err != nil {
+		t.Fatalf("c.GetBucketInfo(...): %s", err)
+	}
+	if diff := cmp.Diff(&client.Bucket{Versioning: true, UserPolicyVersion: "v1"}, got); diff != "" {
+		t.Errorf("c.GetBucketInfo(...): -want, +got:\n%s", diff)
+	}
+
+	_, err = ops.GetBucketCORSRequest(&s3.GetBucketCorsInput{Bucket: &b.Spec.NameFormat}).Send()
+	if diff := cmp.Diff(errCodeNoSuchCORSConfiguration, code(err)); diff != "" {
+		t.Errorf("GetBucketCORSRequest(...): before CORS was updated: -want code, +got code:\n%s", diff)
+	}
+	if err := c.UpdateCORS(b); err != nil {
+		t.Fatalf("c.UpdateCORS(...): %s", err)
+	}
+	cors, err := ops.GetBucketCORSRequest(&s3.GetBucketCorsInput{Bucket: &b.Spec.NameFormat}).Send()
+	if err != nil {
+		t.Fatalf("GetBucketCORSRequest(...): %s", err)
+	}
+	if diff := cmp.Diff(b.Spec.CORSRules, client.GenerateCORSRules(cors.CORSRules)); diff != "" {
+		t.Errorf("GetBucketCORSRequest(...): -want rules, +got rules:\n%s", diff)
+	}
+
+	if err := c.UpdatePublicAccessBlock(b); err != nil {
+		t.Fatalf("c.UpdatePublicAccessBlock(...): %s", err)
+	}
+	pab, err := ops.GetPublicAccessBlockRequest(&operations.GetPublicAccessBlockInput{Bucket: &b.Spec.NameFormat}).Send()
+	if err != nil {
+		t.Fatalf("GetPublicAccessBlockRequest(...): %s", err)
+	}
+	if diff := cmp.Diff(b.Spec.PublicAccessBlock, client.GenerateBucketPublicAccessBlock(pab.PublicAccessBlockConfiguration)); diff != "" {
+		t.Errorf("GetPublicAccessBlockRequest(...): -want, +got:\n%s", diff)
+	}
+	b.Spec.PublicAccessBlock = nil
+	if err := c.UpdatePublicAccessBlock(b); err != nil {
+		t.Fatalf("c.UpdatePublicAccessBlock(...): removing the public access block: %s", err)
+	}
+	_, err = ops.GetPublicAccessBlockRequest(&operations.GetPublicAccessBlockInput{Bucket: &b.Spec.NameFormat}).Send()
+	if diff := cmp.Diff(operations.ErrCodeNoSuchPublicAccessBlockConfiguration, code(err)); diff != "" {
+		t.Errorf("GetPublicAccessBlockRequest(...): after the public access block was removed: -want code, +got code:\n%s", diff)
+	}
+
+	b.Status.IAMUsername = username
+	if err := c.DeleteBucket(b); err != nil {
+		t.Fatalf("c.DeleteBucket(...): %s", err)
+	}
+	if diff := cmp.Diff([]string{}, ops.Buckets()); diff != "" {
+		t.Errorf("ops.Buckets(): -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{}, iam.Users()); diff != "" {
+		t.Errorf("iam.Users(): -want, +got:\n%s", diff)
+	}
+
+	_, err = ops.DeleteBucketRequest(&s3.DeleteBucketInput{Bucket: &b.Spec.NameFormat}).Send()
+	if diff := cmp.Diff(s3.ErrCodeNoSuchBucket, code(err)); diff != "" {
+		t.Errorf("DeleteBucketRequest(...): deleting a missing bucket: -want code, +got code:\n%s", diff)
+	}
+}
@@ -19,14 +19,17 @@ package azure
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2018-03-31/containerservice"
 	"github.com/Azure/go-autorest/autorest/to"
 	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	computev1alpha1 "github.com/crossplaneio/crossplane/azure/apis/compute/v1alpha1"
 	"github.com/crossplaneio/crossplane/azure/apis/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
 )
 
 const (
@@ -34,6 +37,11 @@ const (
 	// created cluster agent pool profile
 	AgentPoolProfileName = "agentpool"
 
+	// AKSAPI is the name of the Azure API used by AKS setup clients. Requests
+	// made to the Azure AD Graph API by these clients are limited as part of
+	// it.
+	AKSAPI = "Microsoft.ContainerService"
+
 	maxClusterNameLen = 31
 )
 
@@ -51,10 +59,26 @@ type AKSSetupAPIFactory interface {
 
 // AKSSetupClientFactory implements the AKSSetupAPIFactory interface by returning real clients that talk to Azure APIs
 type AKSSetupClientFactory struct {
+	// Kube is used to read provider credentials. Clients are created using
+	// the supplied clientset, and are not pooled, if it is nil.
+	Kube client.Client
+
+	// Pool caches clients and limits their requests to the Azure API.
+	Pool *pool.Pool
 }
 
 // CreateSetupClient creates and returns an AKS setup client that is ready to talk to Azure APIs
 func (f *AKSSetupClientFactory) CreateSetupClient(provider *v1alpha1.Provider, clientset kubernetes.Interface) (*AKSSetupClient, error) {
+	if f.Kube != nil {
+		c, err := PooledClient(context.Background(), f.Pool, f.Kube, provider, AKSAPI, func(c *Client, hc *http.Client) (interface{}, error) {
+			return newAKSSetupClient(c, hc)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Azure client: %+v", err)
+		}
+		return c.(*AKSSetupClient), nil
+	}
+
 	aksClusterClient, err := NewAKSClusterClient(provider, clientset)
 	if err != nil {
 		return nil, err
@@ -77,6 +101,26 @@ func (f *AKSSetupClientFactory) CreateSetupClient(provider *v1alpha1.Provider, c
 	}, nil
 }
 
+// newAKSSetupClient returns an AKS setup client that sends requests using the
+// supplied HTTP client, or the default sender if it is nil.
+func newAKSSetupClient(c *Client, hc *http.Client) (*AKSSetupClient, error) {
+	appClient, err := newApplicationClient(c, hc)
+	if err != nil {
+		return nil, err
+	}
+
+	spClient, err := newServicePrincipalClient(c, hc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AKSSetupClient{
+		AKSClusterAPI:       newAKSClusterClient(c, hc),
+		ApplicationAPI:      appClient,
+		ServicePrincipalAPI: spClient,
+	}, nil
+}
+
 // AKSClusterAPI represents the API interface for a AKS Cluster client
 type AKSClusterAPI interface {
 	Get(ctx context.Context, instance computev1alpha1.AKSCluster) (containerservice.ManagedCluster, error)
@@ -98,11 +142,18 @@ func NewAKSClusterClient(provider *v1alpha1.Provider, clientset kubernetes.Inter
 		return nil, fmt.Errorf("failed to create Azure client: %+v", err)
 	}
 
+	return newAKSClusterClient(client, nil), nil
+}
+
+func newAKSClusterClient(client *Client, hc *http.Client) *AKSClusterClient {
 	aksClustersClient := containerservice.NewManagedClustersClient(client.SubscriptionID)
 	aksClustersClient.Authorizer = client.Authorizer
 	aksClustersClient.AddToUserAgent(UserAgent)
+	if hc != nil {
+		aksClustersClient.Sender = hc
+	}
 
-	return &AKSClusterClient{aksClustersClient}, nil
+	return &AKSClusterClient{aksClustersClient}
 }
 
 // Get returns the AKS cluster details for the given instance
@@ -20,16 +20,27 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-05-01/resources"
 	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/adal"
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
 	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane-runtime/pkg/util"
 	"github.com/crossplaneio/crossplane/azure/apis/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/credentials"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
 )
 
 const (
@@ -59,6 +70,7 @@ type Client struct {
 	tenantID                       string
 	activeDirectoryEndpointURL     string
 	activeDirectoryGraphResourceID string
+	managedIdentity                bool
 }
 
 // Credentials represents the contents of a JSON encoded Azure credentials file.
@@ -72,30 +84,282 @@ type Credentials struct {
 	ActiveDirectoryEndpointURL     string `json:"activeDirectoryEndpointUrl"`
 	ResourceManagerEndpointURL     string `json:"resourceManagerEndpointUrl"`
 	ActiveDirectoryGraphResourceID string `json:"activeDirectoryGraphResourceId"`
+
+	// ManagedIdentity credentials are authorized using the Azure managed
+	// identity of the Crossplane pod rather than a service principal. The
+	// ClientID optionally identifies a user assigned managed identity.
+	ManagedIdentity bool `json:"managedIdentity,omitempty"`
+}
+
+// NewAuthorizer returns an authorizer for the Azure resource manager API using
+// the supplied credentials.
+func NewAuthorizer(c Credentials) (autorest.Authorizer, error) {
+	if c.ManagedIdentity {
+		cfg := auth.NewMSIConfig()
+		cfg.ClientID = c.ClientID
+		if c.ResourceManagerEndpointURL != "" {
+			cfg.Resource = c.ResourceManagerEndpointURL
+		}
+		a, err := cfg.Authorizer()
+		return a, errors.Wrap(err, "cannot create Azure authorizer from managed identity")
+	}
+
+	cfg := auth.NewClientCredentialsConfig(c.ClientID, c.ClientSecret, c.TenantID)
+	cfg.AADEndpoint = c.ActiveDirectoryEndpointURL
+	cfg.Resource = c.ResourceManagerEndpointURL
+	a, err := cfg.Authorizer()
+	return a, errors.Wrap(err, "cannot create Azure authorizer from credentials config")
+}
+
+// ProviderCredentials returns the JSON encoded credentials of the supplied
+// provider, obtained from its credentials source. Credentials of a provider
+// whose credentials are known to be invalid are not returned.
+func ProviderCredentials(ctx context.Context, c client.Client, p *v1alpha1.Provider) ([]byte, error) {
+	if err := ready(p); err != nil {
+		return nil, err
+	}
+	return providerCredentials(p, secretData(ctx, c, p))
+}
+
+// ProviderVersion returns a version of the supplied provider's configuration
+// that changes when the provider's spec or its credentials secret change.
+func ProviderVersion(ctx context.Context, c client.Client, p *v1alpha1.Provider) (string, error) {
+	secret := ""
+	if p.Spec.CredentialsSource == "" || p.Spec.CredentialsSource == v1alpha1.CredentialsSourceSecret {
+		secret = p.Spec.Secret.Name
+	}
+	return pool.Version(ctx, c, p, secret)
+}
+
+// Operation names the Azure Resource Manager operation the supplied request
+// calls, for example Redis.get or Redis.listKeys. The operation is inferred
+// from the request's method and resource path, which alternates between
+// resource types and names, for example
+// /subscriptions/s/resourceGroups/g/providers/Microsoft.Cache/Redis/r.
+func Operation(req *http.Request) string {
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	n := len(segments)
+	if segments[0] == "" {
+		return req.Method
+	}
+
+	switch {
+	case n%2 == 0 && req.Method == http.MethodPut:
+		return segments[n-2] + ".createOrUpdate"
+	case n%2 == 0:
+		return segments[n-2] + "." + strings.ToLower(req.Method)
+	case n == 1 || req.Method == http.MethodGet:
+		// A path with an odd number of segments that is read ends in a
+		// resource type, and lists the resources of that type.
+		return segments[n-1] + ".list"
+	}
+
+	// Otherwise it ends in an action performed on a resource.
+	return segments[n-3] + "." + segments[n-1]
+}
+
+// ActiveDirectoryAPI is the name of the Azure API used to validate
+// credentials.
+const ActiveDirectoryAPI = "ActiveDirectory"
+
+// ValidateProvider validates the credentials of the supplied provider by
+// acquiring an Azure Active Directory token for the resource manager API. It
+// returns the identity as which the credentials authenticate.
+func ValidateProvider(ctx context.Context, c client.Client, p *v1alpha1.Provider) (string, error) {
+	data, err := providerCredentials(p, secretData(ctx, c, p))
+	if err != nil {
+		return "", err
+	}
+
+	creds := Credentials{}
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return "", errors.Wrap(err, "cannot unmarshal Azure credentials")
+	}
+
+	t, err := newServicePrincipalToken(creds)
+	if err != nil {
+		return "", err
+	}
+	// Validation is infrequent, so it is not rate limited, but its requests
+	// are still recorded as cloud API metrics.
+	t.SetSender(&http.Client{Transport: &pool.Transport{API: ActiveDirectoryAPI, Provider: p.GetUID()}})
+	if err := t.RefreshWithContext(ctx); err != nil {
+		return "", errors.Wrap(err, "cannot acquire Azure token")
+	}
+
+	switch {
+	case creds.ManagedIdentity && creds.ClientID == "":
+		return "system assigned managed identity", nil
+	case creds.ManagedIdentity:
+		return fmt.Sprintf("managed identity %s", creds.ClientID), nil
+	default:
+		return fmt.Sprintf("service principal %s in tenant %s", creds.ClientID, creds.TenantID), nil
+	}
+}
+
+// newServicePrincipalToken returns a token for the resource manager API using
+// the supplied credentials.
+func newServicePrincipalToken(c Credentials) (*adal.ServicePrincipalToken, error) {
+	resource := c.ResourceManagerEndpointURL
+	if resource == "" {
+		resource = autorestazure.PublicCloud.ResourceManagerEndpoint
+	}
+
+	if c.ManagedIdentity {
+		endpoint, err := adal.GetMSIVMEndpoint()
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot get managed identity endpoint")
+		}
+		if c.ClientID == "" {
+			t, err := adal.NewServicePrincipalTokenFromMSI(endpoint, resource)
+			return t, errors.Wrap(err, "cannot create managed identity token")
+		}
+		t, err := adal.NewServicePrincipalTokenFromMSIWithUserAssignedID(endpoint, resource, c.ClientID)
+		return t, errors.Wrap(err, "cannot create managed identity token")
+	}
+
+	aad := c.ActiveDirectoryEndpointURL
+	if aad == "" {
+		aad = autorestazure.PublicCloud.ActiveDirectoryEndpoint
+	}
+	cfg, err := adal.NewOAuthConfig(aad, c.TenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create Azure OAuth config")
+	}
+	t, err := adal.NewServicePrincipalToken(*cfg, c.ClientID, c.ClientSecret, resource)
+	return t, errors.Wrap(err, "cannot create service principal token")
+}
+
+// ready returns an error if the supplied provider's credentials are known to be
+// invalid. Providers whose credentials have not been validated are assumed to
+// be ready.
+func ready(p *v1alpha1.Provider) error {
+	if c := p.GetCondition(runtimev1alpha1.TypeReady); c.Status == corev1.ConditionFalse {
+		return errors.Errorf("provider %s is not ready: %s", p.GetName(), c.Message)
+	}
+	return nil
+}
+
+// secretData returns a function that reads the JSON encoded credentials of the
+// supplied provider from its secret.
+func secretData(ctx context.Context, c client.Client, p *v1alpha1.Provider) func() ([]byte, error) {
+	return func() ([]byte, error) {
+		s := &corev1.Secret{}
+		n := types.NamespacedName{Namespace: p.GetNamespace(), Name: p.Spec.Secret.Name}
+		if err := c.Get(ctx, n, s); err != nil {
+			return nil, errors.Wrapf(err, "cannot get provider secret %s", n)
+		}
+		return s.Data[p.Spec.Secret.Key], nil
+	}
+}
+
+// providerCredentials returns the JSON encoded credentials of the supplied
+// provider, calling secret to read them from the provider's secret.
+func providerCredentials(p *v1alpha1.Provider, secret func() ([]byte, error)) ([]byte, error) {
+	switch p.Spec.CredentialsSource {
+	case v1alpha1.CredentialsSourceFile:
+		if err := credentials.Default.AllowFile(p.GetNamespace(), p.Spec.CredentialsFile); err != nil {
+			return nil, err
+		}
+		data, err := ioutil.ReadFile(p.Spec.CredentialsFile)
+		return data, errors.Wrapf(err, "cannot read provider credentials file %s", p.Spec.CredentialsFile)
+	case v1alpha1.CredentialsSourceEnvironment:
+		if err := credentials.Default.AllowAmbient(p.GetNamespace()); err != nil {
+			return nil, err
+		}
+		settings, err := auth.GetSettingsFromEnvironment()
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot get Azure settings from environment")
+		}
+		c := credentialsForEnvironment(settings.Environment)
+		c.ClientID = settings.Values[auth.ClientID]
+		c.ClientSecret = settings.Values[auth.ClientSecret]
+		c.TenantID = settings.Values[auth.TenantID]
+		c.SubscriptionID = settings.Values[auth.SubscriptionID]
+		if p.Spec.SubscriptionID != "" {
+			c.SubscriptionID = p.Spec.SubscriptionID
+		}
+		return json.Marshal(c)
+	case v1alpha1.CredentialsSourceManagedIdentity:
+		if err := credentials.Default.AllowAmbient(p.GetNamespace()); err != nil {
+			return nil, err
+		}
+		c := credentialsForEnvironment(autorestazure.PublicCloud)
+		c.ManagedIdentity = true
+		c.ClientID = p.Spec.ManagedIdentityClientID
+		c.SubscriptionID = p.Spec.SubscriptionID
+		return json.Marshal(c)
+	default:
+		return secret()
+	}
+}
+
+// credentialsForEnvironment returns credentials using the endpoints of the
+// supplied Azure environment.
+func credentialsForEnvironment(e autorestazure.Environment) Credentials {
+	return Credentials{
+		ActiveDirectoryEndpointURL:     e.ActiveDirectoryEndpoint,
+		ResourceManagerEndpointURL:     e.ResourceManagerEndpoint,
+		ActiveDirectoryGraphResourceID: e.GraphEndpoint,
+	}
 }
 
 // NewClient will look up the Azure credential information from the given provider and return a client
 // that can be used to connect to Azure services.
 func NewClient(provider *v1alpha1.Provider, clientset kubernetes.Interface) (*Client, error) {
-	// first get the secret data that should contain all the auth/creds information
-	azureSecretData, err := util.SecretData(clientset, provider.Namespace, provider.Spec.Secret)
+	if err := ready(provider); err != nil {
+		return nil, err
+	}
+
+	// first get the data that should contain all the auth/creds information
+	azureSecretData, err := providerCredentials(provider, func() ([]byte, error) {
+		return util.SecretData(clientset, provider.Namespace, provider.Spec.Secret)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get azure client secret: %+v", err)
 	}
 
+	return newClient(azureSecretData)
+}
+
+// PooledClient returns a client of the supplied Azure API for the supplied
+// provider. The client is created by calling fn with a Client and an HTTP
+// client that limits requests to the API, unless the supplied pool holds a
+// client created using the provider's current configuration.
+func PooledClient(ctx context.Context, pl *pool.Pool, c client.Client, p *v1alpha1.Provider, api string, fn func(c *Client, hc *http.Client) (interface{}, error)) (interface{}, error) {
+	if err := ready(p); err != nil {
+		return nil, err
+	}
+
+	v, err := ProviderVersion(ctx, c, p)
+	if err != nil {
+		return nil, err
+	}
+
+	k := pool.Key{Provider: p.GetUID(), API: api}
+	return pl.Get(k, v, func() (interface{}, error) {
+		data, err := providerCredentials(p, secretData(ctx, c, p))
+		if err != nil {
+			return nil, err
+		}
+		ac, err := newClient(data)
+		if err != nil {
+			return nil, err
+		}
+		return fn(ac, pl.HTTPClient(k, pool.TooManyRequests, Operation))
+	})
+}
+
+// newClient returns a client that can be used to connect to Azure services
+// using the supplied JSON encoded credentials.
+func newClient(data []byte) (*Client, error) {
 	// load Credentials from json data
 	creds := Credentials{}
-	err = json.Unmarshal(azureSecretData, &creds)
-	if err != nil {
+	if err := json.Unmarshal(data, &creds); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal azure client secret data: %+v", err)
 	}
 
-	// create a config object from the loaded credentials data
-	config := auth.NewClientCredentialsConfig(creds.ClientID, creds.ClientSecret, creds.TenantID)
-	config.AADEndpoint = creds.ActiveDirectoryEndpointURL
-	config.Resource = creds.ResourceManagerEndpointURL
-
-	authorizer, err := config.Authorizer()
+	authorizer, err := NewAuthorizer(creds)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get authorizer from config: %+v", err)
 	}
@@ -108,6 +372,7 @@ func NewClient(provider *v1alpha1.Provider, clientset kubernetes.Interface) (*Cl
 		tenantID:                       creds.TenantID,
 		activeDirectoryEndpointURL:     creds.ActiveDirectoryEndpointURL,
 		activeDirectoryGraphResourceID: creds.ActiveDirectoryGraphResourceID,
+		managedIdentity:                creds.ManagedIdentity,
 	}, nil
 }
 
@@ -122,6 +387,18 @@ func ValidateClient(client *Client) error {
 	return err
 }
 
+// IsUnauthorized returns true if the supplied error indicates that Azure
+// Active Directory rejected the credentials used to acquire a token.
+func IsUnauthorized(err error) bool {
+	e, ok := errors.Cause(err).(adal.TokenRefreshError)
+	if !ok || e.Response() == nil {
+		return false
+	}
+	// Token endpoints reject invalid credentials as a bad request.
+	code := e.Response().StatusCode
+	return code == http.StatusBadRequest || code == http.StatusUnauthorized
+}
+
 // IsNotFound returns a value indicating whether the given error represents that the resource was not found.
 func IsNotFound(err error) bool {
 	detailedError, ok := err.(autorest.DetailedError)
@@ -137,6 +414,32 @@ func IsNotFound(err error) bool {
 	return statusCode == http.StatusNotFound
 }
 
+// ErrorCode returns the error code of the supplied Azure API error, if any.
+// The HTTP status code of the error is returned if Azure did not report a
+// more specific error code.
+func ErrorCode(err error) string {
+	var de autorest.DetailedError
+	switch e := errors.Cause(err).(type) {
+	case *autorestazure.RequestError:
+		if e.ServiceError != nil && e.ServiceError.Code != "" {
+			return e.ServiceError.Code
+		}
+		de = e.DetailedError
+	case autorest.DetailedError:
+		if re, ok := e.Original.(*autorestazure.RequestError); ok && re.ServiceError != nil && re.ServiceError.Code != "" {
+			return re.ServiceError.Code
+		}
+		de = e
+	default:
+		return ""
+	}
+
+	if de.StatusCode == nil {
+		return ""
+	}
+	return fmt.Sprint(de.StatusCode)
+}
+
 // ToStringPtr converts the supplied string for use with the Azure Go SDK.
 func ToStringPtr(s string, o ...FieldOption) *string {
 	for _, fo := range o {
@@ -17,16 +17,22 @@ limitations under the License.
 package azure
 
 import (
+	"encoding/json"
+	"io/ioutil"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/onsi/gomega"
+	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
 
 	"github.com/crossplaneio/crossplane/azure/apis/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/credentials"
 )
 
 const (
@@ -86,6 +92,64 @@ func TestNewClient(t *testing.T) {
 	g.Expect(client.SubscriptionID).To(gomega.Equal("bf1b0e59-93da-42e0-82c6-5a1d94227911"))
 }
 
+func TestProviderCredentials(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	// credentials are read from a file mounted into the pod
+	f, err := ioutil.TempFile("", "azure-credentials")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	defer os.Remove(f.Name())
+	_, err = f.Write([]byte(authData))
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(f.Close()).To(gomega.Succeed())
+
+	defer func(p *credentials.Policy) { credentials.Default = p }(credentials.Default)
+	credentials.Default = &credentials.Policy{Namespaces: []string{"crossplane-system"}, FileDirectories: []string{os.TempDir()}}
+	trusted := metav1.ObjectMeta{Namespace: "crossplane-system"}
+
+	p := &v1alpha1.Provider{ObjectMeta: trusted, Spec: v1alpha1.ProviderSpec{
+		CredentialsSource: v1alpha1.CredentialsSourceFile,
+		CredentialsFile:   f.Name(),
+	}}
+	data, err := providerCredentials(p, nil)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(data).To(gomega.Equal([]byte(authData)))
+
+	// files may only be read by providers in trusted namespaces
+	p.SetNamespace("app-team")
+	_, err = providerCredentials(p, nil)
+	g.Expect(err).To(gomega.HaveOccurred())
+
+	// managed identity credentials are authorized without a client secret
+	p = &v1alpha1.Provider{ObjectMeta: trusted, Spec: v1alpha1.ProviderSpec{
+		CredentialsSource:       v1alpha1.CredentialsSourceManagedIdentity,
+		SubscriptionID:          "bf1b0e59-93da-42e0-82c6-5a1d94227911",
+		ManagedIdentityClientID: "0f32e96b-b9a4-49ce-a857-243a33b20e5c",
+	}}
+	data, err = providerCredentials(p, nil)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	c := Credentials{}
+	g.Expect(json.Unmarshal(data, &c)).To(gomega.Succeed())
+	g.Expect(c.ManagedIdentity).To(gomega.BeTrue())
+	g.Expect(c.ClientID).To(gomega.Equal("0f32e96b-b9a4-49ce-a857-243a33b20e5c"))
+	g.Expect(c.ClientSecret).To(gomega.BeEmpty())
+	g.Expect(c.SubscriptionID).To(gomega.Equal("bf1b0e59-93da-42e0-82c6-5a1d94227911"))
+	g.Expect(c.ResourceManagerEndpointURL).To(gomega.Equal("https://management.azure.com/"))
+
+	// managed identities may only be used by providers in trusted namespaces
+	p.SetNamespace("app-team")
+	_, err = providerCredentials(p, nil)
+	g.Expect(err).To(gomega.HaveOccurred())
+
+	// a missing credentials file is an error
+	p = &v1alpha1.Provider{ObjectMeta: trusted, Spec: v1alpha1.ProviderSpec{
+		CredentialsSource: v1alpha1.CredentialsSourceFile,
+		CredentialsFile:   filepath.Join(os.TempDir(), "nonexistent"),
+	}}
+	_, err = providerCredentials(p, nil)
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
 func TestIsNotFound(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 
@@ -103,3 +167,55 @@ func TestIsNotFound(t *testing.T) {
 		g.Expect(actual).To(gomega.Equal(tt.expected))
 	}
 }
+
+type refreshError struct{ rsp *http.Response }
+
+func (e refreshError) Error() string            { return "boom" }
+func (e refreshError) Response() *http.Response { return e.rsp }
+
+func TestIsUnauthorized(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cases := []struct {
+		err      error
+		expected bool
+	}{
+		{nil, false},
+		{refreshError{}, false},
+		{refreshError{rsp: &http.Response{StatusCode: http.StatusInternalServerError}}, false},
+		{refreshError{rsp: &http.Response{StatusCode: http.StatusUnauthorized}}, true},
+		{errors.Wrap(refreshError{rsp: &http.Response{StatusCode: http.StatusBadRequest}}, "cannot acquire Azure token"), true},
+	}
+
+	for _, tt := range cases {
+		actual := IsUnauthorized(tt.err)
+		g.Expect(actual).To(gomega.Equal(tt.expected))
+	}
+}
+
+func TestOperation(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	redis := "https://management.azure.com/subscriptions/s/resourceGroups/g/providers/Microsoft.Cache/Redis"
+
+	cases := []struct {
+		method   string
+		url      string
+		expected string
+	}{
+		{http.MethodGet, redis + "/r?api-version=2018-03-01", "Redis.get"},
+		{http.MethodPut, redis + "/r", "Redis.createOrUpdate"},
+		{http.MethodPatch, redis + "/r", "Redis.patch"},
+		{http.MethodDelete, redis + "/r", "Redis.delete"},
+		{http.MethodGet, redis, "Redis.list"},
+		{http.MethodPost, redis + "/r/listKeys", "Redis.listKeys"},
+		{http.MethodGet, "https://management.azure.com/subscriptions/s/resourceGroups/g", "resourceGroups.get"},
+		{http.MethodGet, "https://management.azure.com/", http.MethodGet},
+	}
+
+	for _, tt := range cases {
+		req, err := http.NewRequest(tt.method, tt.url, nil)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(Operation(req)).To(gomega.Equal(tt.expected))
+	}
+}
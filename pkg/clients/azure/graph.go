@@ -19,6 +19,7 @@ package azure
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
@@ -69,6 +70,10 @@ func NewApplicationClient(provider *v1alpha1.Provider, clientset kubernetes.Inte
 		return nil, fmt.Errorf("failed to create Azure client: %+v", err)
 	}
 
+	return newApplicationClient(client, nil)
+}
+
+func newApplicationClient(client *Client, hc *http.Client) (*ApplicationClient, error) {
 	graphAuthorizer, err := getGraphAuthorizer(client)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get graph authorizer: %+v", err)
@@ -77,6 +82,9 @@ func NewApplicationClient(provider *v1alpha1.Provider, clientset kubernetes.Inte
 	appClient := graphrbac.NewApplicationsClient(client.tenantID)
 	appClient.Authorizer = graphAuthorizer
 	appClient.AddToUserAgent(UserAgent)
+	if hc != nil {
+		appClient.Sender = hc
+	}
 
 	return &ApplicationClient{appClient}, nil
 }
@@ -153,6 +161,10 @@ func NewServicePrincipalClient(provider *v1alpha1.Provider, clientset kubernetes
 		return nil, fmt.Errorf("failed to create Azure client: %+v", err)
 	}
 
+	return newServicePrincipalClient(client, nil)
+}
+
+func newServicePrincipalClient(client *Client, hc *http.Client) (*ServicePrincipalClient, error) {
 	graphAuthorizer, err := getGraphAuthorizer(client)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get graph authorizer: %+v", err)
@@ -161,6 +173,9 @@ func NewServicePrincipalClient(provider *v1alpha1.Provider, clientset kubernetes
 	spClient := graphrbac.NewServicePrincipalsClient(client.tenantID)
 	spClient.Authorizer = graphAuthorizer
 	spClient.AddToUserAgent(UserAgent)
+	if hc != nil {
+		spClient.Sender = hc
+	}
 
 	return &ServicePrincipalClient{spClient}, nil
 }
@@ -196,6 +211,10 @@ func (c *ServicePrincipalClient) DeleteServicePrincipal(ctx context.Context, spI
 // Graph helpers
 
 func getGraphAuthorizer(client *Client) (autorest.Authorizer, error) {
+	if client.managedIdentity {
+		return getGraphAuthorizerFromMSI(client)
+	}
+
 	oauthConfig, err := adal.NewOAuthConfig(client.activeDirectoryEndpointURL, client.tenantID)
 	if err != nil {
 		return nil, err
@@ -211,3 +230,25 @@ func getGraphAuthorizer(client *Client) (autorest.Authorizer, error) {
 
 	return autorest.NewBearerAuthorizer(token), nil
 }
+
+func getGraphAuthorizerFromMSI(client *Client) (autorest.Authorizer, error) {
+	endpoint, err := adal.GetMSIVMEndpoint()
+	if err != nil {
+		return nil, err
+	}
+
+	var token *adal.ServicePrincipalToken
+	if client.clientID != "" {
+		token, err = adal.NewServicePrincipalTokenFromMSIWithUserAssignedID(endpoint, client.activeDirectoryGraphResourceID, client.clientID)
+	} else {
+		token, err = adal.NewServicePrincipalTokenFromMSI(endpoint, client.activeDirectoryGraphResourceID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := token.Refresh(); err != nil {
+		return nil, err
+	}
+
+	return autorest.NewBearerAuthorizer(token), nil
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	networkmgmt "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-08-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-08-01/network/networkapi"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/pkg/errors"
+
+	"github.com/crossplaneio/crossplane/azure/apis/network/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/azure"
+)
+
+// API is the name of the Azure API used by network clients.
+const API = "Microsoft.Network"
+
+// A VirtualNetworksClient handles CRUD operations for Azure virtual networks.
+// This interface is compatible with the upstream Azure virtual networks client.
+type VirtualNetworksClient networkapi.VirtualNetworksClientAPI
+
+// A SubnetsClient handles CRUD operations for Azure subnets. This interface is
+// compatible with the upstream Azure subnets client.
+type SubnetsClient networkapi.SubnetsClientAPI
+
+// NewVirtualNetworksClient returns a new Azure virtual networks client.
+// Credentials must be passed as JSON encoded data. Requests are sent using the
+// supplied HTTP client, or the default sender if it is nil.
+func NewVirtualNetworksClient(credentials []byte, hc *http.Client) (VirtualNetworksClient, error) {
+	subscription, a, err := authorize(credentials)
+	if err != nil {
+		return nil, err
+	}
+	client := networkmgmt.NewVirtualNetworksClient(subscription)
+	client.Authorizer = a
+	if hc != nil {
+		client.Sender = hc
+	}
+	if err := client.AddToUserAgent(azure.UserAgent); err != nil {
+		return nil, errors.Wrap(err, "cannot add to Azure client user agent")
+	}
+
+	return client, nil
+}
+
+// NewSubnetsClient returns a new Azure subnets client. Credentials must be
+// passed as JSON encoded data. Requests are sent using the supplied HTTP
+// client, or the default sender if it is nil.
+func NewSubnetsClient(credentials []byte, hc *http.Client) (SubnetsClient, error) {
+	subscription, a, err := authorize(credentials)
+	if err != nil {
+		return nil, err
+	}
+	client := networkmgmt.NewSubnetsClient(subscription)
+	client.Authorizer = a
+	if hc != nil {
+		client.Sender = hc
+	}
+	if err := client.AddToUserAgent(azure.UserAgent); err != nil {
+		return nil, errors.Wrap(err, "cannot add to Azure client user agent")
+	}
+
+	return client, nil
+}
+
+// authorize returns the subscription ID and an authorizer for the supplied
+// JSON encoded credentials.
+func authorize(credentials []byte) (string, autorest.Authorizer, error) {
+	c := azure.Credentials{}
+	if err := json.Unmarshal(credentials, &c); err != nil {
+		return "", nil, errors.Wrap(err, "cannot unmarshal Azure client secret data")
+	}
+
+	a, err := azure.NewAuthorizer(c)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return c.SubscriptionID, a, nil
+}
+
+// NewVirtualNetworkParameters returns virtual network parameters suitable for
+// use with the Azure API.
+func NewVirtualNetworkParameters(v *v1alpha1.VirtualNetwork) networkmgmt.VirtualNetwork {
+	p := networkmgmt.VirtualNetwork{
+		Location: azure.ToStringPtr(v.Spec.Location),
+		Tags:     azure.ToStringPtrMap(v.Spec.Tags),
+		VirtualNetworkPropertiesFormat: &networkmgmt.VirtualNetworkPropertiesFormat{
+			AddressSpace: &networkmgmt.AddressSpace{AddressPrefixes: toStringSlicePtr(v.Spec.AddressPrefixes)},
+		},
+	}
+	if len(v.Spec.DNSServers) > 0 {
+		p.DhcpOptions = &networkmgmt.DhcpOptions{DNSServers: toStringSlicePtr(v.Spec.DNSServers)}
+	}
+	return p
+}
+
+// VirtualNetworkNeedsUpdate returns true if the supplied Kubernetes virtual
+// network differs from the supplied Azure virtual network. It considers only
+// its address space, DNS servers, and tags.
+func VirtualNetworkNeedsUpdate(kube *v1alpha1.VirtualNetwork, az networkmgmt.VirtualNetwork) bool {
+	var prefixes, servers []string
+	if f := az.VirtualNetworkPropertiesFormat; f != nil {
+		if f.AddressSpace != nil {
+			prefixes = fromStringSlicePtr(f.AddressSpace.AddressPrefixes)
+		}
+		if f.DhcpOptions != nil {
+			servers = fromStringSlicePtr(f.DhcpOptions.DNSServers)
+		}
+	}
+
+	switch {
+	case !sameStrings(kube.Spec.AddressPrefixes, prefixes):
+		return true
+	case !sameStrings(kube.Spec.DNSServers, servers):
+		return true
+	case !sameTags(kube.Spec.Tags, az.Tags):
+		return true
+	}
+
+	return false
+}
+
+// NewSubnetParameters returns subnet parameters suitable for use with the
+// Azure API.
+func NewSubnetParameters(s *v1alpha1.Subnet) networkmgmt.Subnet {
+	p := networkmgmt.Subnet{
+		SubnetPropertiesFormat: &networkmgmt.SubnetPropertiesFormat{
+			AddressPrefix: azure.ToStringPtr(s.Spec.AddressPrefix),
+		},
+	}
+	if len(s.Spec.ServiceEndpoints) > 0 {
+		endpoints := make([]networkmgmt.ServiceEndpointPropertiesFormat, len(s.Spec.ServiceEndpoints))
+		for i, e := range s.Spec.ServiceEndpoints {
+			endpoints[i] = networkmgmt.ServiceEndpointPropertiesFormat{Service: azure.ToStringPtr(e)}
+		}
+		p.ServiceEndpoints = &endpoints
+	}
+	return p
+}
+
+// SubnetNeedsUpdate returns true if the supplied Kubernetes subnet differs
+// from the supplied Azure subnet. It considers only its address prefix and
+// service endpoints.
+func SubnetNeedsUpdate(kube *v1alpha1.Subnet, az networkmgmt.Subnet) bool {
+	if az.SubnetPropertiesFormat == nil {
+		return true
+	}
+
+	var endpoints []string
+	if az.ServiceEndpoints != nil {
+		for _, e := range *az.ServiceEndpoints {
+			endpoints = append(endpoints, azure.ToString(e.Service))
+		}
+	}
+
+	switch {
+	case kube.Spec.AddressPrefix != azure.ToString(az.AddressPrefix):
+		return true
+	case !sameStrings(kube.Spec.ServiceEndpoints, endpoints):
+		return true
+	}
+
+	return false
+}
+
+func toStringSlicePtr(s []string) *[]string {
+	if len(s) == 0 {
+		return nil
+	}
+	out := make([]string, len(s))
+	copy(out, s)
+	return &out
+}
+
+func fromStringSlicePtr(s *[]string) []string {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
+
+// sameStrings returns true if the supplied slices contain the same strings,
+// in any order.
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sa := append([]string(nil), a...)
+	sb := append([]string(nil), b...)
+	sort.Strings(sa)
+	sort.Strings(sb)
+	for i := range sa {
+		if sa[i] != sb[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// sameTags returns true if the supplied Kubernetes and Azure tags are equal.
+func sameTags(kube map[string]string, az map[string]*string) bool {
+	if len(kube) != len(az) {
+		return false
+	}
+	for k, v := range kube {
+		if t, ok := az[k]; !ok || azure.ToString(t) != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"testing"
+
+	networkmgmt "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-08-01/network"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplaneio/crossplane/azure/apis/network/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/azure"
+)
+
+const (
+	location      = "coolplace"
+	addressPrefix = "10.0.0.0/16"
+	dnsServer     = "10.0.0.4"
+)
+
+func TestNewVirtualNetworkParameters(t *testing.T) {
+	cases := []struct {
+		name string
+		v    *v1alpha1.VirtualNetwork
+		want networkmgmt.VirtualNetwork
+	}{
+		{
+			name: "Successful",
+			v: &v1alpha1.VirtualNetwork{Spec: v1alpha1.VirtualNetworkSpec{VirtualNetworkParameters: v1alpha1.VirtualNetworkParameters{
+				Location:        location,
+				AddressPrefixes: []string{addressPrefix},
+				DNSServers:      []string{dnsServer},
+				Tags:            map[string]string{"cool": "socool"},
+			}}},
+			want: networkmgmt.VirtualNetwork{
+				Location: azure.ToStringPtr(location),
+				Tags:     map[string]*string{"cool": azure.ToStringPtr("socool")},
+				VirtualNetworkPropertiesFormat: &networkmgmt.VirtualNetworkPropertiesFormat{
+					AddressSpace: &networkmgmt.AddressSpace{AddressPrefixes: &[]string{addressPrefix}},
+					DhcpOptions:  &networkmgmt.DhcpOptions{DNSServers: &[]string{dnsServer}},
+				},
+			},
+		},
+		{
+			name: "NoDNSServers",
+			v: &v1alpha1.VirtualNetwork{Spec: v1alpha1.VirtualNetworkSpec{VirtualNetworkParameters: v1alpha1.VirtualNetworkParameters{
+				Location:        location,
+				AddressPrefixes: []string{addressPrefix},
+			}}},
+			want: networkmgmt.VirtualNetwork{
+				Location: azure.ToStringPtr(location),
+				Tags:     map[string]*string{},
+				VirtualNetworkPropertiesFormat: &networkmgmt.VirtualNetworkPropertiesFormat{
+					AddressSpace: &networkmgmt.AddressSpace{AddressPrefixes: &[]string{addressPrefix}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := NewVirtualNetworkParameters(tc.v)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("NewVirtualNetworkParameters(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestVirtualNetworkNeedsUpdate(t *testing.T) {
+	kube := &v1alpha1.VirtualNetwork{Spec: v1alpha1.VirtualNetworkSpec{VirtualNetworkParameters: v1alpha1.VirtualNetworkParameters{
+		AddressPrefixes: []string{addressPrefix, "10.1.0.0/16"},
+	}}}
+
+	cases := []struct {
+		name string
+		az   networkmgmt.VirtualNetwork
+		want bool
+	}{
+		{
+			name: "UpToDate",
+			az: networkmgmt.VirtualNetwork{VirtualNetworkPropertiesFormat: &networkmgmt.VirtualNetworkPropertiesFormat{
+				AddressSpace: &networkmgmt.AddressSpace{AddressPrefixes: &[]string{"10.1.0.0/16", addressPrefix}},
+			}},
+			want: false,
+		},
+		{
+			name: "AddressPrefixesChanged",
+			az: networkmgmt.VirtualNetwork{VirtualNetworkPropertiesFormat: &networkmgmt.VirtualNetworkPropertiesFormat{
+				AddressSpace: &networkmgmt.AddressSpace{AddressPrefixes: &[]string{addressPrefix}},
+			}},
+			want: true,
+		},
+		{
+			name: "DNSServersChanged",
+			az: networkmgmt.VirtualNetwork{VirtualNetworkPropertiesFormat: &networkmgmt.VirtualNetworkPropertiesFormat{
+				AddressSpace: &networkmgmt.AddressSpace{AddressPrefixes: &[]string{addressPrefix, "10.1.0.0/16"}},
+				DhcpOptions:  &networkmgmt.DhcpOptions{DNSServers: &[]string{dnsServer}},
+			}},
+			want: true,
+		},
+		{
+			name: "TagsChanged",
+			az: networkmgmt.VirtualNetwork{
+				Tags: map[string]*string{"cool": azure.ToStringPtr("socool")},
+				VirtualNetworkPropertiesFormat: &networkmgmt.VirtualNetworkPropertiesFormat{
+					AddressSpace: &networkmgmt.AddressSpace{AddressPrefixes: &[]string{addressPrefix, "10.1.0.0/16"}},
+				},
+			},
+			want: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := VirtualNetworkNeedsUpdate(kube, tc.az)
+			if got != tc.want {
+				t.Errorf("VirtualNetworkNeedsUpdate(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSubnetNeedsUpdate(t *testing.T) {
+	kube := &v1alpha1.Subnet{Spec: v1alpha1.SubnetSpec{SubnetParameters: v1alpha1.SubnetParameters{
+		AddressPrefix:    addressPrefix,
+		ServiceEndpoints: []string{"Microsoft.Sql"},
+	}}}
+
+	cases := []struct {
+		name string
+		az   networkmgmt.Subnet
+		want bool
+	}{
+		{
+			name: "UpToDate",
+			az: networkmgmt.Subnet{SubnetPropertiesFormat: &networkmgmt.SubnetPropertiesFormat{
+				AddressPrefix:    azure.ToStringPtr(addressPrefix),
+				ServiceEndpoints: &[]networkmgmt.ServiceEndpointPropertiesFormat{{Service: azure.ToStringPtr("Microsoft.Sql")}},
+			}},
+			want: false,
+		},
+		{
+			name: "AddressPrefixChanged",
+			az: networkmgmt.Subnet{SubnetPropertiesFormat: &networkmgmt.SubnetPropertiesFormat{
+				AddressPrefix:    azure.ToStringPtr("10.1.0.0/16"),
+				ServiceEndpoints: &[]networkmgmt.ServiceEndpointPropertiesFormat{{Service: azure.ToStringPtr("Microsoft.Sql")}},
+			}},
+			want: true,
+		},
+		{
+			name: "ServiceEndpointsChanged",
+			az: networkmgmt.Subnet{SubnetPropertiesFormat: &networkmgmt.SubnetPropertiesFormat{
+				AddressPrefix: azure.ToStringPtr(addressPrefix),
+			}},
+			want: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := SubnetNeedsUpdate(kube, tc.az)
+			if got != tc.want {
+				t.Errorf("SubnetNeedsUpdate(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
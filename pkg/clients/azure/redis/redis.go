@@ -20,46 +20,47 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"reflect"
 
 	redismgmt "github.com/Azure/azure-sdk-for-go/services/redis/mgmt/2018-03-01/redis"
 	"github.com/Azure/azure-sdk-for-go/services/redis/mgmt/2018-03-01/redis/redisapi"
-	"github.com/Azure/go-autorest/autorest/azure/auth"
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/crossplaneio/crossplane/azure/apis/cache/v1alpha1"
 	"github.com/crossplaneio/crossplane/pkg/clients/azure"
+	"github.com/crossplaneio/crossplane/pkg/externalname"
 )
 
 // NamePrefix is the prefix for all created Azure Cache instances.
 const NamePrefix = "acr"
 
+// API is the name of the Azure API used by Azure Cache clients.
+const API = "Microsoft.Cache"
+
 // A Client handles CRUD operations for Azure Cache resources. This interface is
 // compatible with the upstream Azure redis client.
 type Client redisapi.ClientAPI
 
 // NewClient returns a new Azure Cache for Redis client. Credentials must be
-// passed as JSON encoded data.
-func NewClient(ctx context.Context, credentials []byte) (Client, error) {
+// passed as JSON encoded data. Requests are made using the supplied HTTP
+// client, if any.
+func NewClient(ctx context.Context, credentials []byte, hc *http.Client) (Client, error) {
 	c := azure.Credentials{}
 	if err := json.Unmarshal(credentials, &c); err != nil {
 		return nil, errors.Wrap(err, "cannot unmarshal Azure client secret data")
 	}
 	client := redismgmt.NewClient(c.SubscriptionID)
 
-	cfg := auth.ClientCredentialsConfig{
-		ClientID:     c.ClientID,
-		ClientSecret: c.ClientSecret,
-		TenantID:     c.TenantID,
-		AADEndpoint:  c.ActiveDirectoryEndpointURL,
-		Resource:     c.ResourceManagerEndpointURL,
-	}
-	a, err := cfg.Authorizer()
+	a, err := azure.NewAuthorizer(c)
 	if err != nil {
-		return nil, errors.Wrapf(err, "cannot create Azure authorizer from credentials config")
+		return nil, err
 	}
 	client.Authorizer = a
+	if hc != nil {
+		client.Sender = hc
+	}
 	if err := client.AddToUserAgent(azure.UserAgent); err != nil {
 		return nil, errors.Wrap(err, "cannot add to Azure client user agent")
 	}
@@ -68,9 +69,9 @@ func NewClient(ctx context.Context, credentials []byte) (Client, error) {
 }
 
 // NewResourceName returns a resource name used to identify a Redis resource in
-// the Azure API.
+// the Azure API. Objects with an external name use it as their resource name.
 func NewResourceName(o metav1.Object) string {
-	return fmt.Sprintf("%s-%s", NamePrefix, o.GetUID())
+	return externalname.Or(o, fmt.Sprintf("%s-%s", NamePrefix, o.GetUID()))
 }
 
 // NewCreateParameters returns Redis resource creation parameters suitable for
@@ -130,3 +131,20 @@ func NeedsUpdate(kube *v1alpha1.Redis, az redismgmt.ResourceType) bool {
 
 	return false
 }
+
+// UpdateOperation returns the operation that updating the supplied Azure
+// resource to match the supplied Kubernetes resource will perform. Azure
+// cannot change the SKU and shard count of a Redis resource in a single
+// update, so a SKU change takes precedence over a shard count change.
+func UpdateOperation(kube *v1alpha1.Redis, az redismgmt.ResourceType) string {
+	up := NewUpdateParameters(kube)
+
+	switch {
+	case !reflect.DeepEqual(up.Sku, az.Sku):
+		return v1alpha1.OperationScaleSKU
+	case !reflect.DeepEqual(up.ShardCount, az.ShardCount):
+		return v1alpha1.OperationScaleShards
+	}
+
+	return v1alpha1.OperationModify
+}
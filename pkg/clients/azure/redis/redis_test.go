@@ -26,6 +26,7 @@ import (
 
 	"github.com/crossplaneio/crossplane/azure/apis/cache/v1alpha1"
 	"github.com/crossplaneio/crossplane/pkg/clients/azure"
+	"github.com/crossplaneio/crossplane/pkg/externalname"
 )
 
 const (
@@ -53,6 +54,14 @@ func TestNewResourceName(t *testing.T) {
 			o:    &v1alpha1.Redis{ObjectMeta: metav1.ObjectMeta{UID: uid}},
 			want: resourceName,
 		},
+		{
+			name: "ExternalName",
+			o: &v1alpha1.Redis{ObjectMeta: metav1.ObjectMeta{
+				UID:         uid,
+				Annotations: map[string]string{externalname.AnnotationKey: "existing"},
+			}},
+			want: "existing",
+		},
 	}
 
 	for _, tc := range cases {
@@ -372,3 +381,84 @@ func TestNeedsUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateOperation(t *testing.T) {
+	kube := &v1alpha1.Redis{
+		ObjectMeta: metav1.ObjectMeta{UID: uid},
+		Spec: v1alpha1.RedisSpec{
+			RedisParameters: v1alpha1.RedisParameters{
+				SKU: v1alpha1.SKUSpec{
+					Name:     skuName,
+					Family:   skuFamily,
+					Capacity: skuCapacity,
+				},
+				EnableNonSSLPort:   enableNonSSLPort,
+				RedisConfiguration: redisConfiguration,
+				ShardCount:         shardCount,
+			},
+		},
+	}
+
+	cases := []struct {
+		name string
+		kube *v1alpha1.Redis
+		az   redismgmt.ResourceType
+		want string
+	}{
+		{
+			name: "ScaleSKUBeforeShards",
+			kube: kube,
+			az: redismgmt.ResourceType{
+				Properties: &redismgmt.Properties{
+					Sku: &redismgmt.Sku{
+						Name:     redismgmt.SkuName(skuName),
+						Family:   redismgmt.SkuFamily(skuFamily),
+						Capacity: azure.ToInt32Ptr(skuCapacity + 1),
+					},
+					ShardCount: azure.ToInt32Ptr(shardCount + 1),
+				},
+			},
+			want: v1alpha1.OperationScaleSKU,
+		},
+		{
+			name: "ScaleShards",
+			kube: kube,
+			az: redismgmt.ResourceType{
+				Properties: &redismgmt.Properties{
+					Sku: &redismgmt.Sku{
+						Name:     redismgmt.SkuName(skuName),
+						Family:   redismgmt.SkuFamily(skuFamily),
+						Capacity: azure.ToInt32Ptr(skuCapacity),
+					},
+					ShardCount: azure.ToInt32Ptr(shardCount + 1),
+				},
+			},
+			want: v1alpha1.OperationScaleShards,
+		},
+		{
+			name: "Modify",
+			kube: kube,
+			az: redismgmt.ResourceType{
+				Properties: &redismgmt.Properties{
+					Sku: &redismgmt.Sku{
+						Name:     redismgmt.SkuName(skuName),
+						Family:   redismgmt.SkuFamily(skuFamily),
+						Capacity: azure.ToInt32Ptr(skuCapacity),
+					},
+					EnableNonSslPort: azure.ToBoolPtr(!enableNonSSLPort),
+					ShardCount:       azure.ToInt32Ptr(shardCount),
+				},
+			},
+			want: v1alpha1.OperationModify,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := UpdateOperation(tc.kube, tc.az)
+			if got != tc.want {
+				t.Errorf("UpdateOperation(...): want %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,283 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sim provides a simulated Azure Cache for Redis API.
+package sim
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"sync"
+
+	redismgmt "github.com/Azure/azure-sdk-for-go/services/redis/mgmt/2018-03-01/redis"
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/crossplaneio/crossplane/pkg/clients/azure"
+	"github.com/crossplaneio/crossplane/pkg/clients/azure/redis"
+	"github.com/crossplaneio/crossplane/pkg/clients/sim"
+)
+
+// Provisioning states.
+const (
+	stateCreating  = string(redismgmt.Creating)
+	stateSucceeded = string(redismgmt.Succeeded)
+	stateScaling   = string(redismgmt.Scaling)
+	stateUpdating  = string(redismgmt.Updating)
+	stateDeleting  = string(redismgmt.Deleting)
+)
+
+// Defaults of the simulated API.
+const (
+	subscriptionID = "00000000-0000-0000-0000-000000000000"
+	redisVersion   = "3.2.7"
+	port           = 6379
+	sslPort        = 6380
+)
+
+type cache struct {
+	lifecycle *sim.Lifecycle
+	location  string
+	props     redismgmt.CreateProperties
+	keys      redismgmt.AccessKeys
+}
+
+// A Client is a simulated Azure Cache for Redis API. Caches are created in the
+// Creating provisioning state and succeed after they have been observed Polls
+// times. Caches are updated and deleted asynchronously in the same way. The
+// simulated API returns zero value futures; the Azure Cache for Redis
+// controller observes caches rather than futures. Methods the controller does
+// not use panic. Errors may be injected into any method, keyed by its name.
+type Client struct {
+	redis.Client
+	sim.Failures
+
+	// Polls is the number of times a cache in a transitional state must be
+	// observed before it settles.
+	Polls int
+
+	mu     sync.Mutex
+	caches map[string]*cache
+	count  int
+}
+
+var _ redis.Client = &Client{}
+
+// NewClient returns a simulated Azure Cache for Redis API with no caches.
+func NewClient() *Client {
+	return &Client{Polls: sim.DefaultPolls, caches: map[string]*cache{}}
+}
+
+// Create creates the named cache in the supplied resource group. Unlike the
+// real API, which treats it as an update, creating a cache that exists fails.
+func (c *Client) Create(_ context.Context, group, name string, p redismgmt.CreateParameters) (redismgmt.CreateFuture, error) {
+	if err := c.Call("Create"); err != nil {
+		return redismgmt.CreateFuture{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	k := key(group, name)
+	if _, ok := c.caches[k]; ok {
+		return redismgmt.CreateFuture{}, detailedError("Create", http.StatusConflict, "The resource '%s' already exists.", name)
+	}
+	ca := &cache{lifecycle: sim.NewLifecycle(stateCreating).Then(stateSucceeded, c.Polls)}
+	if p.Location != nil {
+		ca.location = *p.Location
+	}
+	if p.CreateProperties != nil {
+		ca.props = *p.CreateProperties
+	}
+	c.count++
+	ca.keys = redismgmt.AccessKeys{
+		PrimaryKey:   azure.ToStringPtr(fmt.Sprintf("sim-primary-key-%d", c.count), azure.FieldRequired),
+		SecondaryKey: azure.ToStringPtr(fmt.Sprintf("sim-secondary-key-%d", c.count), azure.FieldRequired),
+	}
+	c.caches[k] = ca
+	return redismgmt.CreateFuture{}, nil
+}
+
+// Get returns the named cache in the supplied resource group.
+func (c *Client) Get(_ context.Context, group, name string) (redismgmt.ResourceType, error) {
+	if err := c.Call("Get"); err != nil {
+		return redismgmt.ResourceType{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	k := key(group, name)
+	ca, ok := c.caches[k]
+	if !ok {
+		return redismgmt.ResourceType{}, cacheNotFound("Get", name)
+	}
+	if ca.lifecycle.Observe() == sim.Deleted {
+		delete(c.caches, k)
+		return redismgmt.ResourceType{}, cacheNotFound("Get", name)
+	}
+	return view(group, name, ca), nil
+}
+
+// Update applies the supplied parameters to the named cache in the supplied
+// resource group. As with the real API, the SKU and shard count cannot be
+// changed by the same update.
+func (c *Client) Update(_ context.Context, group, name string, p redismgmt.UpdateParameters) (redismgmt.ResourceType, error) {
+	if err := c.Call("Update"); err != nil {
+		return redismgmt.ResourceType{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ca, ok := c.caches[key(group, name)]
+	if !ok {
+		return redismgmt.ResourceType{}, cacheNotFound("Update", name)
+	}
+	if ca.lifecycle.State() != stateSucceeded {
+		return redismgmt.ResourceType{}, detailedError("Update", http.StatusConflict, "The resource '%s' is busy processing a previous update request.", name)
+	}
+
+	state := stateUpdating
+	if up := p.UpdateProperties; up != nil {
+		skuChanged := up.Sku != nil && !reflect.DeepEqual(up.Sku, ca.props.Sku)
+		shardsChanged := up.ShardCount != nil && !reflect.DeepEqual(up.ShardCount, ca.props.ShardCount)
+		if skuChanged && shardsChanged {
+			return redismgmt.ResourceType{}, detailedError("Update", http.StatusBadRequest, "The SKU and shard count of '%s' cannot be changed at the same time.", name)
+		}
+		if skuChanged || shardsChanged {
+			state = stateScaling
+		}
+		if up.Sku != nil {
+			ca.props.Sku = up.Sku
+		}
+		if up.ShardCount != nil {
+			ca.props.ShardCount = up.ShardCount
+		}
+		if up.EnableNonSslPort != nil {
+			ca.props.EnableNonSslPort = up.EnableNonSslPort
+		}
+		ca.props.RedisConfiguration = up.RedisConfiguration
+	}
+	ca.lifecycle.Set(state).Then(stateSucceeded, c.Polls)
+	return view(group, name, ca), nil
+}
+
+// Delete starts deleting the named cache in the supplied resource group.
+func (c *Client) Delete(_ context.Context, group, name string) (redismgmt.DeleteFuture, error) {
+	if err := c.Call("Delete"); err != nil {
+		return redismgmt.DeleteFuture{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ca, ok := c.caches[key(group, name)]
+	if !ok {
+		return redismgmt.DeleteFuture{}, cacheNotFound("Delete", name)
+	}
+	if ca.lifecycle.State() != stateDeleting {
+		ca.lifecycle.Set(stateDeleting).Then(sim.Deleted, c.Polls)
+	}
+	return redismgmt.DeleteFuture{}, nil
+}
+
+// ListKeys returns the access keys of the named cache in the supplied resource
+// group.
+func (c *Client) ListKeys(_ context.Context, group, name string) (redismgmt.AccessKeys, error) {
+	if err := c.Call("ListKeys"); err != nil {
+		return redismgmt.AccessKeys{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ca, ok := c.caches[key(group, name)]
+	if !ok {
+		return redismgmt.AccessKeys{}, cacheNotFound("ListKeys", name)
+	}
+	return ca.keys, nil
+}
+
+// Settle completes all pending cache transitions immediately.
+func (c *Client) Settle() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k, ca := range c.caches {
+		if ca.lifecycle.Settle() == sim.Deleted {
+			delete(c.caches, k)
+		}
+	}
+}
+
+// Caches returns the resource group qualified names of all caches that exist,
+// including those that are being created or deleted, in order.
+func (c *Client) Caches() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	names := make([]string, 0, len(c.caches))
+	for k := range c.caches {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func view(group, name string, ca *cache) redismgmt.ResourceType {
+	props := &redismgmt.Properties{
+		ProvisioningState:  redismgmt.ProvisioningState(ca.lifecycle.State()),
+		RedisVersion:       azure.ToStringPtr(redisVersion, azure.FieldRequired),
+		Sku:                ca.props.Sku,
+		RedisConfiguration: ca.props.RedisConfiguration,
+		EnableNonSslPort:   ca.props.EnableNonSslPort,
+		ShardCount:         ca.props.ShardCount,
+		SubnetID:           ca.props.SubnetID,
+		StaticIP:           ca.props.StaticIP,
+	}
+	if props.ProvisioningState != redismgmt.Creating {
+		props.HostName = azure.ToStringPtr(fmt.Sprintf("%s.redis.cache.windows.net", name), azure.FieldRequired)
+		props.Port = azure.ToInt32Ptr(port)
+		props.SslPort = azure.ToInt32Ptr(sslPort)
+	}
+	return redismgmt.ResourceType{
+		ID:         azure.ToStringPtr(fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Cache/Redis/%s", subscriptionID, group, name), azure.FieldRequired),
+		Name:       azure.ToStringPtr(name, azure.FieldRequired),
+		Type:       azure.ToStringPtr("Microsoft.Cache/Redis", azure.FieldRequired),
+		Location:   azure.ToStringPtr(ca.location),
+		Properties: props,
+	}
+}
+
+func key(group, name string) string {
+	return group + "/" + name
+}
+
+func cacheNotFound(method, name string) error {
+	return detailedError(method, http.StatusNotFound, "The Resource 'Microsoft.Cache/Redis/%s' was not found.", name)
+}
+
+func detailedError(method string, code int, format string, a ...interface{}) error {
+	return autorest.DetailedError{
+		PackageType: "redis.Client",
+		Method:      method,
+		StatusCode:  code,
+		Message:     fmt.Sprintf(format, a...),
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sim
+
+import (
+	"context"
+	"testing"
+
+	redismgmt "github.com/Azure/azure-sdk-for-go/services/redis/mgmt/2018-03-01/redis"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplaneio/crossplane/azure/apis/cache/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/azure"
+	"github.com/crossplaneio/crossplane/pkg/clients/azure/redis"
+)
+
+const group = "cool-group"
+
+func TestCacheLifecycle(t *testing.T) {
+	ctx := context.Background()
+	c := NewClient()
+
+	r := &v1alpha1.Redis{ObjectMeta: metav1.ObjectMeta{UID: "cool-uid"}}
+	r.Spec.ResourceGroupName = group
+	r.Spec.Location = "westus"
+	r.Spec.SKU = v1alpha1.SKUSpec{Name: "Basic", Family: "C", Capacity: 0}
+	name := redis.NewResourceName(r)
+
+	if _, err := c.Create(ctx, group, name, redis.NewCreateParameters(r)); err != nil {
+		t.Fatalf("c.Create(...): %s", err)
+	}
+
+	want := []redismgmt.ProvisioningState{redismgmt.Creating, redismgmt.Succeeded}
+	got := []redismgmt.ProvisioningState{}
+	var az redismgmt.ResourceType
+	for i := 0; i < c.Polls; i++ {
+		var err error
+		az, err = c.Get(ctx, group, name)
+		if err != nil {
+			t.Fatalf("c.Get(...): %s", err)
+		}
+		got = append(got, az.ProvisioningState)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("c.Get(...): -want states, +got states:\n%s", diff)
+	}
+	if redis.NeedsUpdate(r, az) {
+		t.Errorf("redis.NeedsUpdate(...): want false for a new cache, got true")
+	}
+
+	r.Spec.SKU.Capacity = 1
+	if diff := cmp.Diff(v1alpha1.OperationScaleSKU, redis.UpdateOperation(r, az)); diff != "" {
+		t.Errorf("redis.UpdateOperation(...): -want, +got:\n%s", diff)
+	}
+	az, err := c.Update(ctx, group, name, redis.NewUpdateParameters(r))
+	if err != nil {
+		t.Fatalf("c.Update(...): %s", err)
+	}
+	if diff := cmp.Diff(redismgmt.Scaling, az.ProvisioningState); diff != "" {
+		t.Errorf("c.Update(...): -want state, +got state:\n%s", diff)
+	}
+
+	if _, err := c.ListKeys(ctx, group, name); err != nil {
+		t.Errorf("c.ListKeys(...): %s", err)
+	}
+
+	if _, err := c.Delete(ctx, group, name); err != nil {
+		t.Fatalf("c.Delete(...): %s", err)
+	}
+	c.Settle()
+	if _, err := c.Get(ctx, group, name); !azure.IsNotFound(err) {
+		t.Errorf("c.Get(...): want not found error after deletion, got %v", err)
+	}
+}
@@ -18,40 +18,40 @@ package resourcegroup
 
 import (
 	"encoding/json"
+	"net/http"
 
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-05-01/resources"
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-05-01/resources/resourcesapi"
-	"github.com/Azure/go-autorest/autorest/azure/auth"
 	"github.com/pkg/errors"
 
 	"github.com/crossplaneio/crossplane/azure/apis/v1alpha1"
 	"github.com/crossplaneio/crossplane/pkg/clients/azure"
 )
 
+// API is the name of the Azure API used by Resource Group clients.
+const API = "Microsoft.Resources"
+
 // A GroupsClient handles CRUD operations for Azure Resource Group resources.
 type GroupsClient resourcesapi.GroupsClientAPI
 
 // NewClient returns a new Azure Resource Groups client. Credentials must be
-// passed as JSON encoded data.
-func NewClient(credentials []byte) (GroupsClient, error) {
+// passed as JSON encoded data. Requests are sent using the supplied HTTP
+// client, or the default sender if it is nil.
+func NewClient(credentials []byte, hc *http.Client) (GroupsClient, error) {
 	c := azure.Credentials{}
 	if err := json.Unmarshal(credentials, &c); err != nil {
 		return nil, errors.Wrap(err, "cannot unmarshal Azure client secret data")
 	}
 	client := resources.NewGroupsClient(c.SubscriptionID)
 
-	cfg := auth.ClientCredentialsConfig{
-		ClientID:     c.ClientID,
-		ClientSecret: c.ClientSecret,
-		TenantID:     c.TenantID,
-		AADEndpoint:  c.ActiveDirectoryEndpointURL,
-		Resource:     c.ResourceManagerEndpointURL,
-	}
-	a, err := cfg.Authorizer()
+	a, err := azure.NewAuthorizer(c)
 	if err != nil {
-		return nil, errors.Wrapf(err, "cannot create Azure authorizer from credentials config")
+		return nil, err
 	}
 	client.Authorizer = a
+	if hc != nil {
+		client.Sender = hc
+	}
 	if err := client.AddToUserAgent(azure.UserAgent); err != nil {
 		return nil, errors.Wrap(err, "cannot add to Azure client user agent")
 	}
@@ -0,0 +1,220 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sim provides a simulated Azure Resource Groups API.
+package sim
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-05-01/resources"
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/crossplaneio/crossplane/pkg/clients/azure"
+	"github.com/crossplaneio/crossplane/pkg/clients/azure/resourcegroup"
+	"github.com/crossplaneio/crossplane/pkg/clients/sim"
+)
+
+// Provisioning states.
+const (
+	stateSucceeded = "Succeeded"
+	stateDeleting  = "Deleting"
+)
+
+const subscriptionID = "00000000-0000-0000-0000-000000000000"
+
+type group struct {
+	lifecycle *sim.Lifecycle
+	location  string
+	tags      map[string]*string
+}
+
+// A Client is a simulated Azure Resource Groups API. As with the real API,
+// resource groups are created synchronously and deleted asynchronously; a
+// deleted group remains in the Deleting provisioning state until it has been
+// observed Polls times. The simulated API returns zero value delete futures;
+// the resource group controller observes groups rather than futures. Methods
+// the controller does not use panic. Errors may be injected into any method,
+// keyed by its name.
+type Client struct {
+	resourcegroup.GroupsClient
+	sim.Failures
+
+	// Polls is the number of times a group that is being deleted must be
+	// observed before it no longer exists.
+	Polls int
+
+	mu     sync.Mutex
+	groups map[string]*group
+}
+
+var _ resourcegroup.GroupsClient = &Client{}
+
+// NewClient returns a simulated Azure Resource Groups API with no groups.
+func NewClient() *Client {
+	return &Client{Polls: sim.DefaultPolls, groups: map[string]*group{}}
+}
+
+// CreateOrUpdate creates the named group, or updates its tags if it exists.
+// As with the real API, the location of an existing group cannot be changed,
+// and a group that is being deleted cannot be updated.
+func (c *Client) CreateOrUpdate(_ context.Context, name string, p resources.Group) (resources.Group, error) {
+	if err := c.Call("CreateOrUpdate"); err != nil {
+		return resources.Group{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	location := ""
+	if p.Location != nil {
+		location = *p.Location
+	}
+
+	g, ok := c.groups[name]
+	switch {
+	case !ok:
+		g = &group{lifecycle: sim.NewLifecycle(stateSucceeded), location: location}
+		c.groups[name] = g
+	case g.lifecycle.State() == stateDeleting:
+		return resources.Group{}, detailedError("CreateOrUpdate", http.StatusConflict, "The resource group '%s' is in deprovisioning state and cannot perform this operation.", name)
+	case location != "" && location != g.location:
+		return resources.Group{}, detailedError("CreateOrUpdate", http.StatusConflict, "Invalid resource group location '%s'. The Resource group already exists in location '%s'.", location, g.location)
+	}
+	g.tags = p.Tags
+	return view(name, g), nil
+}
+
+// CheckExistence returns a response with status code 204 if the named group
+// exists, or 404 if it does not.
+func (c *Client) CheckExistence(_ context.Context, name string) (autorest.Response, error) {
+	if err := c.Call("CheckExistence"); err != nil {
+		return autorest.Response{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.observe(name); !ok {
+		return response(http.StatusNotFound), nil
+	}
+	return response(http.StatusNoContent), nil
+}
+
+// Get returns the named group.
+func (c *Client) Get(_ context.Context, name string) (resources.Group, error) {
+	if err := c.Call("Get"); err != nil {
+		return resources.Group{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	g, ok := c.observe(name)
+	if !ok {
+		return resources.Group{}, groupNotFound("Get", name)
+	}
+	return view(name, g), nil
+}
+
+// Delete starts deleting the named group.
+func (c *Client) Delete(_ context.Context, name string) (resources.GroupsDeleteFuture, error) {
+	if err := c.Call("Delete"); err != nil {
+		return resources.GroupsDeleteFuture{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	g, ok := c.groups[name]
+	if !ok {
+		return resources.GroupsDeleteFuture{}, groupNotFound("Delete", name)
+	}
+	if g.lifecycle.State() != stateDeleting {
+		g.lifecycle.Set(stateDeleting).Then(sim.Deleted, c.Polls)
+	}
+	return resources.GroupsDeleteFuture{}, nil
+}
+
+// Settle completes all pending group deletions immediately.
+func (c *Client) Settle() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for name, g := range c.groups {
+		if g.lifecycle.Settle() == sim.Deleted {
+			delete(c.groups, name)
+		}
+	}
+}
+
+// Groups returns the names of all groups that exist, including those that
+// are being deleted, in order.
+func (c *Client) Groups() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	names := make([]string, 0, len(c.groups))
+	for name := range c.groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// observe must be called with c.mu held.
+func (c *Client) observe(name string) (*group, bool) {
+	g, ok := c.groups[name]
+	if !ok {
+		return nil, false
+	}
+	if g.lifecycle.Observe() == sim.Deleted {
+		delete(c.groups, name)
+		return nil, false
+	}
+	return g, true
+}
+
+func view(name string, g *group) resources.Group {
+	return resources.Group{
+		ID:         azure.ToStringPtr(fmt.Sprintf("/subscriptions/%s/resourceGroups/%s", subscriptionID, name), azure.FieldRequired),
+		Name:       azure.ToStringPtr(name, azure.FieldRequired),
+		Location:   azure.ToStringPtr(g.location),
+		Tags:       g.tags,
+		Properties: &resources.GroupProperties{ProvisioningState: azure.ToStringPtr(g.lifecycle.State(), azure.FieldRequired)},
+	}
+}
+
+func response(code int) autorest.Response {
+	return autorest.Response{Response: &http.Response{StatusCode: code}}
+}
+
+func groupNotFound(method, name string) error {
+	return detailedError(method, http.StatusNotFound, "Resource group '%s' could not be found.", name)
+}
+
+func detailedError(method string, code int, format string, a ...interface{}) error {
+	return autorest.DetailedError{
+		PackageType: "resources.GroupsClient",
+		Method:      method,
+		StatusCode:  code,
+		Message:     fmt.Sprintf(format, a...),
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sim
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplaneio/crossplane/azure/apis/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/azure"
+	"github.com/crossplaneio/crossplane/pkg/clients/azure/resourcegroup"
+)
+
+func TestGroupLifecycle(t *testing.T) {
+	ctx := context.Background()
+	c := NewClient()
+
+	r := &v1alpha1.ResourceGroup{}
+	r.Spec.Name = "cool-group"
+	r.Spec.Location = "westus"
+
+	g, err := c.CreateOrUpdate(ctx, r.Spec.Name, resourcegroup.NewParameters(r))
+	if err != nil {
+		t.Fatalf("c.CreateOrUpdate(...): %s", err)
+	}
+	if diff := cmp.Diff(stateSucceeded, *g.Properties.ProvisioningState); diff != "" {
+		t.Errorf("c.CreateOrUpdate(...): -want state, +got state:\n%s", diff)
+	}
+
+	r.Spec.Location = "eastus"
+	if _, err := c.CreateOrUpdate(ctx, r.Spec.Name, resourcegroup.NewParameters(r)); err == nil {
+		t.Errorf("c.CreateOrUpdate(...): want error when changing location, got nil")
+	}
+
+	res, err := c.CheckExistence(ctx, r.Spec.Name)
+	if err != nil {
+		t.Fatalf("c.CheckExistence(...): %s", err)
+	}
+	if diff := cmp.Diff(http.StatusNoContent, res.StatusCode); diff != "" {
+		t.Errorf("c.CheckExistence(...): -want status, +got status:\n%s", diff)
+	}
+
+	if _, err := c.Delete(ctx, r.Spec.Name); err != nil {
+		t.Fatalf("c.Delete(...): %s", err)
+	}
+	g, err = c.Get(ctx, r.Spec.Name)
+	if err != nil {
+		t.Fatalf("c.Get(...): %s", err)
+	}
+	if diff := cmp.Diff(stateDeleting, *g.Properties.ProvisioningState); diff != "" {
+		t.Errorf("c.Get(...): -want state, +got state:\n%s", diff)
+	}
+
+	c.Settle()
+	if _, err := c.Get(ctx, r.Spec.Name); !azure.IsNotFound(err) {
+		t.Errorf("c.Get(...): want not found error after deletion, got %v", err)
+	}
+	res, err = c.CheckExistence(ctx, r.Spec.Name)
+	if err != nil {
+		t.Fatalf("c.CheckExistence(...): %s", err)
+	}
+	if diff := cmp.Diff(http.StatusNotFound, res.StatusCode); diff != "" {
+		t.Errorf("c.CheckExistence(...): -want status, +got status:\n%s", diff)
+	}
+}
@@ -0,0 +1,451 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2018-03-31/containerservice"
+	"github.com/Azure/azure-sdk-for-go/services/graphrbac/1.6/graphrbac"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	computev1alpha1 "github.com/crossplaneio/crossplane/azure/apis/compute/v1alpha1"
+	"github.com/crossplaneio/crossplane/azure/apis/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/azure"
+	"github.com/crossplaneio/crossplane/pkg/clients/sim"
+)
+
+type cluster struct {
+	lifecycle *sim.Lifecycle
+	index     int
+	params    containerservice.ManagedCluster
+}
+
+// An aksOperation identifies the cluster that a simulated long running
+// operation concerns.
+type aksOperation struct {
+	Cluster string `json:"cluster"`
+}
+
+// An AKSClient is a simulated Azure Kubernetes Service API, along with the
+// Azure Active Directory application and service principal APIs used to set
+// up AKS clusters. Clusters are created in the Creating provisioning state and
+// succeed after their create operations have been polled Polls times.
+// Clusters are updated and deleted asynchronously in the same way.
+// Applications and service principals are created and deleted synchronously.
+// The simulated API returns zero value delete futures; the AKS cluster
+// controller observes clusters rather than delete futures. An AKSClient is its
+// own factory. Errors may be injected into any method, keyed by its name.
+type AKSClient struct {
+	sim.Failures
+
+	// Polls is the number of times a cluster in a transitional state must be
+	// observed before it settles.
+	Polls int
+
+	mu           sync.Mutex
+	clusters     map[string]*cluster
+	applications map[string]*graphrbac.Application
+	principals   map[string]*graphrbac.ServicePrincipal
+	count        int
+}
+
+var (
+	_ azure.AKSClusterAPI       = &AKSClient{}
+	_ azure.ApplicationAPI      = &AKSClient{}
+	_ azure.ServicePrincipalAPI = &AKSClient{}
+	_ azure.AKSSetupAPIFactory  = &AKSClient{}
+)
+
+// NewAKSClient returns a simulated AKS API with no clusters, applications, or
+// service principals.
+func NewAKSClient() *AKSClient {
+	return &AKSClient{
+		Polls:        sim.DefaultPolls,
+		clusters:     map[string]*cluster{},
+		applications: map[string]*graphrbac.Application{},
+		principals:   map[string]*graphrbac.ServicePrincipal{},
+	}
+}
+
+// CreateSetupClient returns an AKS setup client whose APIs are all served by
+// the AKSClient, regardless of the supplied provider.
+func (c *AKSClient) CreateSetupClient(_ *v1alpha1.Provider, _ kubernetes.Interface) (*azure.AKSSetupClient, error) {
+	if err := c.Call("CreateSetupClient"); err != nil {
+		return nil, err
+	}
+	return &azure.AKSSetupClient{AKSClusterAPI: c, ApplicationAPI: c, ServicePrincipalAPI: c}, nil
+}
+
+// Get returns the supplied cluster.
+func (c *AKSClient) Get(_ context.Context, instance computev1alpha1.AKSCluster) (containerservice.ManagedCluster, error) {
+	if err := c.Call("Get"); err != nil {
+		return containerservice.ManagedCluster{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	group, name := instance.Spec.ResourceGroupName, instance.Status.ClusterName
+	k := key(group, name)
+	cl, ok := c.clusters[k]
+	if !ok {
+		return containerservice.ManagedCluster{}, clusterNotFound("Get", name)
+	}
+	if cl.lifecycle.Observe() == sim.Deleted {
+		delete(c.clusters, k)
+		return containerservice.ManagedCluster{}, clusterNotFound("Get", name)
+	}
+	return clusterView(group, name, cl), nil
+}
+
+// CreateOrUpdateBegin starts creating the supplied cluster, or updating it if
+// it exists. The supplied application ID must be that of an existing
+// application.
+func (c *AKSClient) CreateOrUpdateBegin(_ context.Context, instance computev1alpha1.AKSCluster, clusterName, appID, _ string) ([]byte, error) {
+	if err := c.Call("CreateOrUpdateBegin"); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.applicationExists(appID) {
+		return nil, detailedError("containerservice.ManagedClustersClient", "CreateOrUpdate", http.StatusBadRequest, "Service principal clientID: %s not found in Active Directory tenant.", appID)
+	}
+
+	spec := instance.Spec
+	nodeCount := computev1alpha1.DefaultNodeCount
+	if spec.NodeCount != nil {
+		nodeCount = *spec.NodeCount
+	}
+	params := containerservice.ManagedCluster{
+		Location: azure.ToStringPtr(spec.Location, azure.FieldRequired),
+		ManagedClusterProperties: &containerservice.ManagedClusterProperties{
+			KubernetesVersion: azure.ToStringPtr(spec.Version, azure.FieldRequired),
+			DNSPrefix:         azure.ToStringPtr(spec.DNSNamePrefix, azure.FieldRequired),
+			AgentPoolProfiles: &[]containerservice.ManagedClusterAgentPoolProfile{{
+				Name:   azure.ToStringPtr(azure.AgentPoolProfileName),
+				Count:  azure.ToInt32Ptr(nodeCount),
+				VMSize: containerservice.VMSizeTypes(spec.NodeVMSize),
+			}},
+			// Like the real API, the simulated API never returns the service
+			// principal's secret.
+			ServicePrincipalProfile: &containerservice.ManagedClusterServicePrincipalProfile{ClientID: azure.ToStringPtr(appID, azure.FieldRequired)},
+			EnableRBAC:              azure.ToBoolPtr(!spec.DisableRBAC, azure.FieldRequired),
+		},
+	}
+
+	k := key(spec.ResourceGroupName, clusterName)
+	cl, ok := c.clusters[k]
+	switch {
+	case !ok:
+		c.count++
+		cl = &cluster{lifecycle: sim.NewLifecycle(stateCreating).Then(stateSucceeded, c.Polls), index: c.count}
+		c.clusters[k] = cl
+	case cl.lifecycle.State() != stateSucceeded:
+		return nil, detailedError("containerservice.ManagedClustersClient", "CreateOrUpdate", http.StatusConflict, "Operation is not allowed while cluster %s is being modified.", clusterName)
+	default:
+		cl.lifecycle.Set(stateUpdating).Then(stateSucceeded, c.Polls)
+	}
+	cl.params = params
+	return json.Marshal(aksOperation{Cluster: k})
+}
+
+// CreateOrUpdateEnd returns true once the supplied cluster create or update
+// operation has completed.
+func (c *AKSClient) CreateOrUpdateEnd(createOp []byte) (bool, error) {
+	if err := c.Call("CreateOrUpdateEnd"); err != nil {
+		return false, err
+	}
+
+	op := &aksOperation{}
+	if err := json.Unmarshal(createOp, op); err != nil {
+		return false, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cl, ok := c.clusters[op.Cluster]
+	if !ok {
+		return true, clusterNotFound("CreateOrUpdate", op.Cluster)
+	}
+	switch cl.lifecycle.Observe() {
+	case sim.Deleted:
+		delete(c.clusters, op.Cluster)
+		return true, clusterNotFound("CreateOrUpdate", op.Cluster)
+	case stateCreating, stateUpdating:
+		return false, nil
+	default:
+		return true, nil
+	}
+}
+
+// Delete starts deleting the supplied cluster.
+func (c *AKSClient) Delete(_ context.Context, instance computev1alpha1.AKSCluster) (containerservice.ManagedClustersDeleteFuture, error) {
+	if err := c.Call("Delete"); err != nil {
+		return containerservice.ManagedClustersDeleteFuture{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	name := instance.Status.ClusterName
+	cl, ok := c.clusters[key(instance.Spec.ResourceGroupName, name)]
+	if !ok {
+		return containerservice.ManagedClustersDeleteFuture{}, clusterNotFound("Delete", name)
+	}
+	if cl.lifecycle.State() != stateDeleting {
+		cl.lifecycle.Set(stateDeleting).Then(sim.Deleted, c.Polls)
+	}
+	return containerservice.ManagedClustersDeleteFuture{}, nil
+}
+
+// ListClusterAdminCredentials returns a kubeconfig file that contains a
+// context named after the supplied cluster.
+func (c *AKSClient) ListClusterAdminCredentials(_ context.Context, instance computev1alpha1.AKSCluster) (containerservice.CredentialResults, error) {
+	if err := c.Call("ListClusterAdminCredentials"); err != nil {
+		return containerservice.CredentialResults{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	group, name := instance.Spec.ResourceGroupName, instance.Status.ClusterName
+	cl, ok := c.clusters[key(group, name)]
+	if !ok || cl.lifecycle.State() == stateCreating {
+		return containerservice.CredentialResults{}, clusterNotFound("ListClusterAdminCredentials", name)
+	}
+
+	user := fmt.Sprintf("clusterAdmin_%s_%s", group, name)
+	cfg := clientcmdapi.NewConfig()
+	cfg.Clusters[name] = &clientcmdapi.Cluster{
+		Server:                   fmt.Sprintf("https://%s:443", fqdn(name, cl)),
+		CertificateAuthorityData: []byte(fmt.Sprintf("sim-ca-%d", cl.index)),
+	}
+	cfg.AuthInfos[user] = &clientcmdapi.AuthInfo{
+		ClientCertificateData: []byte(fmt.Sprintf("sim-client-cert-%d", cl.index)),
+		ClientKeyData:         []byte(fmt.Sprintf("sim-client-key-%d", cl.index)),
+	}
+	cfg.Contexts[name] = &clientcmdapi.Context{Cluster: name, AuthInfo: user}
+	cfg.CurrentContext = name
+
+	kubeconfig, err := clientcmd.Write(*cfg)
+	if err != nil {
+		return containerservice.CredentialResults{}, err
+	}
+	return containerservice.CredentialResults{Kubeconfigs: &[]containerservice.CredentialResult{
+		{Name: azure.ToStringPtr("clusterAdmin", azure.FieldRequired), Value: &kubeconfig},
+	}}, nil
+}
+
+// CreateApplication creates an application with the supplied parameters, or
+// returns the existing application with the supplied object ID.
+func (c *AKSClient) CreateApplication(_ context.Context, appParams azure.ApplicationParameters) (*graphrbac.Application, error) {
+	if err := c.Call("CreateApplication"); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if appParams.ObjectID != "" {
+		app, ok := c.applications[appParams.ObjectID]
+		if !ok {
+			return nil, objectNotFound("graphrbac.ApplicationsClient", "Get", appParams.ObjectID)
+		}
+		return app, nil
+	}
+
+	c.count++
+	app := &graphrbac.Application{
+		ObjectID:    azure.ToStringPtr(fmt.Sprintf("sim-app-object-%d", c.count), azure.FieldRequired),
+		AppID:       azure.ToStringPtr(fmt.Sprintf("sim-app-%d", c.count), azure.FieldRequired),
+		DisplayName: azure.ToStringPtr(appParams.Name, azure.FieldRequired),
+	}
+	c.applications[*app.ObjectID] = app
+	return app, nil
+}
+
+// DeleteApplication deletes the application with the supplied object ID, and
+// the service principals of that application.
+func (c *AKSClient) DeleteApplication(_ context.Context, appObjectID string) error {
+	if err := c.Call("DeleteApplication"); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	app, ok := c.applications[appObjectID]
+	if !ok {
+		return objectNotFound("graphrbac.ApplicationsClient", "Delete", appObjectID)
+	}
+	for id, sp := range c.principals {
+		if *sp.AppID == *app.AppID {
+			delete(c.principals, id)
+		}
+	}
+	delete(c.applications, appObjectID)
+	return nil
+}
+
+// CreateServicePrincipal creates a service principal of the application with
+// the supplied application ID, or returns the existing service principal with
+// the supplied object ID.
+func (c *AKSClient) CreateServicePrincipal(_ context.Context, spID, appID string) (*graphrbac.ServicePrincipal, error) {
+	if err := c.Call("CreateServicePrincipal"); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if spID != "" {
+		sp, ok := c.principals[spID]
+		if !ok {
+			return nil, objectNotFound("graphrbac.ServicePrincipalsClient", "Get", spID)
+		}
+		return sp, nil
+	}
+
+	if !c.applicationExists(appID) {
+		return nil, detailedError("graphrbac.ServicePrincipalsClient", "Create", http.StatusBadRequest, "The appId '%s' of the service principal does not reference a valid application object.", appID)
+	}
+	c.count++
+	sp := &graphrbac.ServicePrincipal{
+		ObjectID: azure.ToStringPtr(fmt.Sprintf("sim-sp-object-%d", c.count), azure.FieldRequired),
+		AppID:    azure.ToStringPtr(appID, azure.FieldRequired),
+	}
+	c.principals[*sp.ObjectID] = sp
+	return sp, nil
+}
+
+// DeleteServicePrincipal deletes the service principal with the supplied
+// object ID.
+func (c *AKSClient) DeleteServicePrincipal(_ context.Context, spID string) error {
+	if err := c.Call("DeleteServicePrincipal"); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.principals[spID]; !ok {
+		return objectNotFound("graphrbac.ServicePrincipalsClient", "Delete", spID)
+	}
+	delete(c.principals, spID)
+	return nil
+}
+
+// Settle completes all pending cluster transitions immediately.
+func (c *AKSClient) Settle() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k, cl := range c.clusters {
+		if cl.lifecycle.Settle() == sim.Deleted {
+			delete(c.clusters, k)
+		}
+	}
+}
+
+// Clusters returns the resource group qualified names of all clusters that
+// exist, including those that are being created or deleted, in order.
+func (c *AKSClient) Clusters() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	names := make([]string, 0, len(c.clusters))
+	for k := range c.clusters {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Applications returns the object IDs of all applications, in order.
+func (c *AKSClient) Applications() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ids := make([]string, 0, len(c.applications))
+	for id := range c.applications {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// ServicePrincipals returns the object IDs of all service principals, in
+// order.
+func (c *AKSClient) ServicePrincipals() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ids := make([]string, 0, len(c.principals))
+	for id := range c.principals {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// applicationExists must be called with c.mu held.
+func (c *AKSClient) applicationExists(appID string) bool {
+	for _, app := range c.applications {
+		if *app.AppID == appID {
+			return true
+		}
+	}
+	return false
+}
+
+func clusterView(group, name string, cl *cluster) containerservice.ManagedCluster {
+	props := *cl.params.ManagedClusterProperties
+	props.ProvisioningState = azure.ToStringPtr(cl.lifecycle.State(), azure.FieldRequired)
+	if cl.lifecycle.State() != stateCreating {
+		props.Fqdn = azure.ToStringPtr(fqdn(name, cl), azure.FieldRequired)
+	}
+	return containerservice.ManagedCluster{
+		ID:                       azure.ToStringPtr(fmt.Sprintf("/subscriptions/%s/resourcegroups/%s/providers/Microsoft.ContainerService/managedClusters/%s", subscriptionID, group, name), azure.FieldRequired),
+		Name:                     azure.ToStringPtr(name, azure.FieldRequired),
+		Type:                     azure.ToStringPtr("Microsoft.ContainerService/ManagedClusters", azure.FieldRequired),
+		Location:                 cl.params.Location,
+		ManagedClusterProperties: &props,
+	}
+}
+
+func fqdn(name string, cl *cluster) string {
+	return fmt.Sprintf("%s-%08x.hcp.%s.azmk8s.io", *cl.params.DNSPrefix, cl.index, *cl.params.Location)
+}
+
+func clusterNotFound(method, name string) error {
+	return detailedError("containerservice.ManagedClustersClient", method, http.StatusNotFound, "The Resource 'Microsoft.ContainerService/managedClusters/%s' was not found.", name)
+}
+
+func objectNotFound(pkg, method, id string) error {
+	return detailedError(pkg, method, http.StatusNotFound, "Resource '%s' does not exist or one of its queried reference-property objects are not present.", id)
+}
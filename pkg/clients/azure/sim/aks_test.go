@@ -0,0 +1,118 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sim
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/client-go/tools/clientcmd"
+
+	computev1alpha1 "github.com/crossplaneio/crossplane/azure/apis/compute/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/azure"
+)
+
+const clusterName = "cool-cluster"
+
+func TestAKSClusterLifecycle(t *testing.T) {
+	ctx := context.Background()
+	c := NewAKSClient()
+
+	sc, err := c.CreateSetupClient(nil, nil)
+	if err != nil {
+		t.Fatalf("c.CreateSetupClient(...): %s", err)
+	}
+
+	app, err := sc.CreateApplication(ctx, azure.ApplicationParameters{Name: clusterName})
+	if err != nil {
+		t.Fatalf("sc.CreateApplication(...): %s", err)
+	}
+	sp, err := sc.CreateServicePrincipal(ctx, "", *app.AppID)
+	if err != nil {
+		t.Fatalf("sc.CreateServicePrincipal(...): %s", err)
+	}
+
+	i := computev1alpha1.AKSCluster{}
+	i.Spec.ResourceGroupName = group
+	i.Spec.Location = "westus"
+	i.Spec.Version = "1.14.6"
+	i.Spec.DNSNamePrefix = "cool"
+	i.Status.ClusterName = clusterName
+
+	if _, err := sc.CreateOrUpdateBegin(ctx, i, clusterName, "not-an-app", "secret"); err == nil {
+		t.Errorf("sc.CreateOrUpdateBegin(...): want error for unknown application, got nil")
+	}
+	op, err := sc.CreateOrUpdateBegin(ctx, i, clusterName, *app.AppID, "secret")
+	if err != nil {
+		t.Fatalf("sc.CreateOrUpdateBegin(...): %s", err)
+	}
+
+	want := []bool{false, true}
+	got := []bool{}
+	for n := 0; n < c.Polls; n++ {
+		done, err := sc.CreateOrUpdateEnd(op)
+		if err != nil {
+			t.Fatalf("sc.CreateOrUpdateEnd(...): %s", err)
+		}
+		got = append(got, done)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("sc.CreateOrUpdateEnd(...): -want done, +got done:\n%s", diff)
+	}
+
+	cl, err := sc.Get(ctx, i)
+	if err != nil {
+		t.Fatalf("sc.Get(...): %s", err)
+	}
+	if diff := cmp.Diff(stateSucceeded, *cl.ProvisioningState); diff != "" {
+		t.Errorf("sc.Get(...): -want state, +got state:\n%s", diff)
+	}
+	if cl.Fqdn == nil {
+		t.Errorf("sc.Get(...): want FQDN of created cluster, got nil")
+	}
+
+	creds, err := sc.ListClusterAdminCredentials(ctx, i)
+	if err != nil {
+		t.Fatalf("sc.ListClusterAdminCredentials(...): %s", err)
+	}
+	kcfg, err := clientcmd.Load(*(*creds.Kubeconfigs)[0].Value)
+	if err != nil {
+		t.Fatalf("clientcmd.Load(...): %s", err)
+	}
+	if _, ok := kcfg.Contexts[clusterName]; !ok {
+		t.Errorf("clientcmd.Load(...): want context named %s", clusterName)
+	}
+
+	if _, err := sc.Delete(ctx, i); err != nil {
+		t.Fatalf("sc.Delete(...): %s", err)
+	}
+	c.Settle()
+	if _, err := sc.Get(ctx, i); !azure.IsNotFound(err) {
+		t.Errorf("sc.Get(...): want not found error after deletion, got %v", err)
+	}
+
+	if err := sc.DeleteApplication(ctx, *app.ObjectID); err != nil {
+		t.Fatalf("sc.DeleteApplication(...): %s", err)
+	}
+	if err := sc.DeleteServicePrincipal(ctx, *sp.ObjectID); !azure.IsNotFound(err) {
+		t.Errorf("sc.DeleteServicePrincipal(...): want not found error after application deletion, got %v", err)
+	}
+	if len(c.Applications())+len(c.ServicePrincipals()) != 0 {
+		t.Errorf("c.Applications(), c.ServicePrincipals(): want none, got %v, %v", c.Applications(), c.ServicePrincipals())
+	}
+}
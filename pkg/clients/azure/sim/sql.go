@@ -0,0 +1,364 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sim provides simulated Azure SQL server and Azure Kubernetes Service
+// APIs.
+package sim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/services/mysql/mgmt/2017-12-01/mysql"
+	"github.com/Azure/go-autorest/autorest"
+	azurerest "github.com/Azure/go-autorest/autorest/azure"
+	"k8s.io/client-go/kubernetes"
+
+	azuredbv1alpha1 "github.com/crossplaneio/crossplane/azure/apis/database/v1alpha1"
+	"github.com/crossplaneio/crossplane/azure/apis/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/azure"
+	"github.com/crossplaneio/crossplane/pkg/clients/sim"
+)
+
+// States of simulated resources.
+const (
+	stateCreating  = "Creating"
+	stateSucceeded = "Succeeded"
+	stateUpdating  = "Updating"
+	stateDeleting  = "Deleting"
+	stateReady     = string(mysql.ServerStateReady)
+	stateDropping  = string(mysql.ServerStateDropping)
+)
+
+const subscriptionID = "00000000-0000-0000-0000-000000000000"
+
+// An engine describes how Azure exposes the servers of a SQL engine.
+type engine struct {
+	provider string
+	zone     string
+	pkg      string
+}
+
+var (
+	mysqlEngine      = engine{provider: azure.MySQLAPI, zone: "mysql.database.azure.com", pkg: "mysql"}
+	postgresqlEngine = engine{provider: azure.PostgreSQLAPI, zone: "postgres.database.azure.com", pkg: "postgresql"}
+)
+
+func engineOf(instance azuredbv1alpha1.SQLServer) engine {
+	if _, ok := instance.(*azuredbv1alpha1.PostgresqlServer); ok {
+		return postgresqlEngine
+	}
+	return mysqlEngine
+}
+
+type server struct {
+	lifecycle *sim.Lifecycle
+	engine    engine
+	id        string
+	fqdn      string
+	rules     map[string]*sim.Lifecycle
+}
+
+// A sqlOperation identifies the server, and optionally the firewall rule, that
+// a simulated long running operation concerns.
+type sqlOperation struct {
+	Server       string `json:"server"`
+	FirewallRule string `json:"firewallRule,omitempty"`
+}
+
+// A SQLServerClient is a simulated Azure Database for MySQL and PostgreSQL
+// API. Servers and firewall rules are created by operations that complete
+// after they have been polled Polls times. Servers are not found until they
+// have been created, and are deleted asynchronously in the same way. The
+// simulated API returns zero value delete futures; the SQL server controller
+// observes servers rather than delete futures. A SQLServerClient is its own
+// factory. Errors may be injected into any method, keyed by its name.
+type SQLServerClient struct {
+	sim.Failures
+
+	// Polls is the number of times a server or firewall rule in a
+	// transitional state must be observed before it settles.
+	Polls int
+
+	mu      sync.Mutex
+	servers map[string]*server
+}
+
+var (
+	_ azure.SQLServerAPI        = &SQLServerClient{}
+	_ azure.SQLServerAPIFactory = &SQLServerClient{}
+)
+
+// NewSQLServerClient returns a simulated Azure SQL server API with no servers.
+func NewSQLServerClient() *SQLServerClient {
+	return &SQLServerClient{Polls: sim.DefaultPolls, servers: map[string]*server{}}
+}
+
+// CreateAPIInstance returns the SQLServerClient, regardless of the supplied
+// provider.
+func (c *SQLServerClient) CreateAPIInstance(_ *v1alpha1.Provider, _ kubernetes.Interface) (azure.SQLServerAPI, error) {
+	if err := c.Call("CreateAPIInstance"); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// GetServer returns the supplied server.
+func (c *SQLServerClient) GetServer(_ context.Context, instance azuredbv1alpha1.SQLServer) (*azure.SQLServer, error) {
+	if err := c.Call("GetServer"); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := engineOf(instance)
+	name := azure.SQLServerName(instance)
+	k := key(instance.GetSpec().ResourceGroupName, name)
+	s, ok := c.servers[k]
+
+	// Servers that are being created are only observed by polling their
+	// create operations.
+	if !ok || s.lifecycle.State() == stateCreating {
+		return nil, serverNotFound(e, "Get", name)
+	}
+	state := s.lifecycle.Observe()
+	if state == sim.Deleted {
+		delete(c.servers, k)
+		return nil, serverNotFound(e, "Get", name)
+	}
+	return &azure.SQLServer{State: state, ID: s.id, FQDN: s.fqdn}, nil
+}
+
+// CreateServerBegin starts creating the supplied server. Unlike the real API,
+// which treats it as an update, creating a server that exists fails.
+func (c *SQLServerClient) CreateServerBegin(_ context.Context, instance azuredbv1alpha1.SQLServer, _ string) ([]byte, error) {
+	if err := c.Call("CreateServerBegin"); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.create(instance)
+}
+
+// RestoreServerBegin starts creating the supplied server from the server with
+// the supplied ID, which must exist.
+func (c *SQLServerClient) RestoreServerBegin(_ context.Context, instance azuredbv1alpha1.SQLServer, sourceServerID string) ([]byte, error) {
+	if err := c.Call("RestoreServerBegin"); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, s := range c.servers {
+		if s.id == sourceServerID && s.lifecycle.State() == stateReady {
+			return c.create(instance)
+		}
+	}
+	return nil, detailedError(engineOf(instance).pkg+".ServersClient", "Create", http.StatusNotFound, "The source server '%s' was not found.", sourceServerID)
+}
+
+// CreateServerEnd returns true once the supplied server create operation has
+// completed.
+func (c *SQLServerClient) CreateServerEnd(createOp []byte) (bool, error) {
+	if err := c.Call("CreateServerEnd"); err != nil {
+		return false, err
+	}
+
+	op := &sqlOperation{}
+	if err := json.Unmarshal(createOp, op); err != nil {
+		return false, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, ok := c.servers[op.Server]
+	if !ok {
+		return true, serverNotFound(mysqlEngine, "Create", op.Server)
+	}
+	switch s.lifecycle.Observe() {
+	case sim.Deleted:
+		delete(c.servers, op.Server)
+		return true, serverNotFound(s.engine, "Create", op.Server)
+	case stateCreating:
+		return false, nil
+	default:
+		return true, nil
+	}
+}
+
+// DeleteServer starts deleting the supplied server and its firewall rules.
+func (c *SQLServerClient) DeleteServer(_ context.Context, instance azuredbv1alpha1.SQLServer) (azurerest.Future, error) {
+	if err := c.Call("DeleteServer"); err != nil {
+		return azurerest.Future{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	name := azure.SQLServerName(instance)
+	s, ok := c.servers[key(instance.GetSpec().ResourceGroupName, name)]
+	if !ok {
+		return azurerest.Future{}, serverNotFound(engineOf(instance), "Delete", name)
+	}
+	if s.lifecycle.State() != stateDropping {
+		s.lifecycle.Set(stateDropping).Then(sim.Deleted, c.Polls)
+	}
+	return azurerest.Future{}, nil
+}
+
+// GetFirewallRule returns an error if the named firewall rule of the supplied
+// server has not been created.
+func (c *SQLServerClient) GetFirewallRule(_ context.Context, instance azuredbv1alpha1.SQLServer, firewallRuleName string) error {
+	if err := c.Call("GetFirewallRule"); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := engineOf(instance)
+	name := azure.SQLServerName(instance)
+	s, ok := c.servers[key(instance.GetSpec().ResourceGroupName, name)]
+	if !ok {
+		return serverNotFound(e, "Get", name)
+	}
+	if r, ok := s.rules[firewallRuleName]; !ok || r.Observe() != stateSucceeded {
+		return detailedError(e.pkg+".FirewallRulesClient", "Get", http.StatusNotFound, "The requested firewall rule '%s' was not found.", firewallRuleName)
+	}
+	return nil
+}
+
+// CreateFirewallRulesBegin starts creating the named firewall rule of the
+// supplied server, replacing any existing rule of the same name.
+func (c *SQLServerClient) CreateFirewallRulesBegin(_ context.Context, instance azuredbv1alpha1.SQLServer, firewallRuleName string) ([]byte, error) {
+	if err := c.Call("CreateFirewallRulesBegin"); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	name := azure.SQLServerName(instance)
+	k := key(instance.GetSpec().ResourceGroupName, name)
+	s, ok := c.servers[k]
+	if !ok || s.lifecycle.State() == stateCreating {
+		return nil, serverNotFound(engineOf(instance), "CreateOrUpdate", name)
+	}
+	s.rules[firewallRuleName] = sim.NewLifecycle(stateCreating).Then(stateSucceeded, c.Polls)
+	return json.Marshal(sqlOperation{Server: k, FirewallRule: firewallRuleName})
+}
+
+// CreateFirewallRulesEnd returns true once the supplied firewall rule create
+// operation has completed.
+func (c *SQLServerClient) CreateFirewallRulesEnd(createOp []byte) (bool, error) {
+	if err := c.Call("CreateFirewallRulesEnd"); err != nil {
+		return false, err
+	}
+
+	op := &sqlOperation{}
+	if err := json.Unmarshal(createOp, op); err != nil {
+		return false, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, ok := c.servers[op.Server]
+	if !ok {
+		return true, serverNotFound(mysqlEngine, "CreateOrUpdate", op.Server)
+	}
+	r, ok := s.rules[op.FirewallRule]
+	if !ok {
+		return true, detailedError(s.engine.pkg+".FirewallRulesClient", "CreateOrUpdate", http.StatusNotFound, "The requested firewall rule '%s' was not found.", op.FirewallRule)
+	}
+	return r.Observe() == stateSucceeded, nil
+}
+
+// Settle completes all pending server and firewall rule transitions
+// immediately.
+func (c *SQLServerClient) Settle() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k, s := range c.servers {
+		if s.lifecycle.Settle() == sim.Deleted {
+			delete(c.servers, k)
+			continue
+		}
+		for _, r := range s.rules {
+			r.Settle()
+		}
+	}
+}
+
+// Servers returns the resource group qualified names of all servers that
+// exist, including those that are being created or deleted, in order.
+func (c *SQLServerClient) Servers() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	names := make([]string, 0, len(c.servers))
+	for k := range c.servers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// create must be called with c.mu held.
+func (c *SQLServerClient) create(instance azuredbv1alpha1.SQLServer) ([]byte, error) {
+	e := engineOf(instance)
+	group := instance.GetSpec().ResourceGroupName
+	name := azure.SQLServerName(instance)
+	k := key(group, name)
+	if _, ok := c.servers[k]; ok {
+		return nil, detailedError(e.pkg+".ServersClient", "Create", http.StatusConflict, "The server '%s' already exists.", name)
+	}
+	c.servers[k] = &server{
+		lifecycle: sim.NewLifecycle(stateCreating).Then(stateReady, c.Polls),
+		engine:    e,
+		id:        fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/%s/servers/%s", subscriptionID, group, e.provider, name),
+		fqdn:      fmt.Sprintf("%s.%s", name, e.zone),
+		rules:     map[string]*sim.Lifecycle{},
+	}
+	return json.Marshal(sqlOperation{Server: k})
+}
+
+func key(group, name string) string {
+	return group + "/" + name
+}
+
+func serverNotFound(e engine, method, name string) error {
+	return detailedError(e.pkg+".ServersClient", method, http.StatusNotFound, "The requested resource of type '%s/servers' with name '%s' was not found.", e.provider, name)
+}
+
+func detailedError(pkg, method string, code int, format string, a ...interface{}) error {
+	return autorest.DetailedError{
+		PackageType: pkg,
+		Method:      method,
+		StatusCode:  code,
+		Message:     fmt.Sprintf(format, a...),
+	}
+}
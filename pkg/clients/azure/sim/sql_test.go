@@ -0,0 +1,113 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sim
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	azuredbv1alpha1 "github.com/crossplaneio/crossplane/azure/apis/database/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/azure"
+)
+
+const (
+	group        = "cool-group"
+	firewallRule = "cool-rule"
+)
+
+func TestSQLServerLifecycle(t *testing.T) {
+	ctx := context.Background()
+	c := NewSQLServerClient()
+
+	s := &azuredbv1alpha1.MysqlServer{ObjectMeta: metav1.ObjectMeta{Name: "cool-server"}}
+	s.Spec.ResourceGroupName = group
+
+	op, err := c.CreateServerBegin(ctx, s, "cool-password")
+	if err != nil {
+		t.Fatalf("c.CreateServerBegin(...): %s", err)
+	}
+	if _, err := c.GetServer(ctx, s); !azure.IsNotFound(err) {
+		t.Errorf("c.GetServer(...): want not found error while creating, got %v", err)
+	}
+
+	want := []bool{false, true}
+	got := []bool{}
+	for i := 0; i < c.Polls; i++ {
+		done, err := c.CreateServerEnd(op)
+		if err != nil {
+			t.Fatalf("c.CreateServerEnd(...): %s", err)
+		}
+		got = append(got, done)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("c.CreateServerEnd(...): -want done, +got done:\n%s", diff)
+	}
+
+	az, err := c.GetServer(ctx, s)
+	if err != nil {
+		t.Fatalf("c.GetServer(...): %s", err)
+	}
+	wantServer := &azure.SQLServer{
+		State: stateReady,
+		ID:    "/subscriptions/" + subscriptionID + "/resourceGroups/" + group + "/providers/" + azure.MySQLAPI + "/servers/cool-server",
+		FQDN:  "cool-server.mysql.database.azure.com",
+	}
+	if diff := cmp.Diff(wantServer, az); diff != "" {
+		t.Errorf("c.GetServer(...): -want, +got:\n%s", diff)
+	}
+
+	if err := c.GetFirewallRule(ctx, s, firewallRule); !azure.IsNotFound(err) {
+		t.Errorf("c.GetFirewallRule(...): want not found error before creation, got %v", err)
+	}
+	op, err = c.CreateFirewallRulesBegin(ctx, s, firewallRule)
+	if err != nil {
+		t.Fatalf("c.CreateFirewallRulesBegin(...): %s", err)
+	}
+	c.Settle()
+	if done, err := c.CreateFirewallRulesEnd(op); !done || err != nil {
+		t.Errorf("c.CreateFirewallRulesEnd(...): want done, got %t, %v", done, err)
+	}
+	if err := c.GetFirewallRule(ctx, s, firewallRule); err != nil {
+		t.Errorf("c.GetFirewallRule(...): %s", err)
+	}
+
+	r := &azuredbv1alpha1.MysqlServer{ObjectMeta: metav1.ObjectMeta{Name: "cool-restored-server"}}
+	r.Spec.ResourceGroupName = group
+	if _, err := c.RestoreServerBegin(ctx, r, "/not/a/server"); !azure.IsNotFound(err) {
+		t.Errorf("c.RestoreServerBegin(...): want not found error for unknown source, got %v", err)
+	}
+	if _, err := c.RestoreServerBegin(ctx, r, az.ID); err != nil {
+		t.Errorf("c.RestoreServerBegin(...): %s", err)
+	}
+
+	if _, err := c.DeleteServer(ctx, s); err != nil {
+		t.Fatalf("c.DeleteServer(...): %s", err)
+	}
+	if az, err := c.GetServer(ctx, s); err != nil || az.State != stateDropping {
+		t.Errorf("c.GetServer(...): want %s server, got %v, %v", stateDropping, az, err)
+	}
+	c.Settle()
+	if _, err := c.GetServer(ctx, s); !azure.IsNotFound(err) {
+		t.Errorf("c.GetServer(...): want not found error after deletion, got %v", err)
+	}
+	if diff := cmp.Diff([]string{group + "/cool-restored-server"}, c.Servers()); diff != "" {
+		t.Errorf("c.Servers(): -want, +got:\n%s", diff)
+	}
+}
@@ -19,20 +19,32 @@ package azure
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strconv"
 
 	"github.com/Azure/azure-sdk-for-go/services/mysql/mgmt/2017-12-01/mysql"
 	"github.com/Azure/azure-sdk-for-go/services/postgresql/mgmt/2017-12-01/postgresql"
 	azurerest "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/date"
 	"github.com/Azure/go-autorest/autorest/to"
 	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
 	azuredbv1alpha1 "github.com/crossplaneio/crossplane/azure/apis/database/v1alpha1"
 	"github.com/crossplaneio/crossplane/azure/apis/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
+	"github.com/crossplaneio/crossplane/pkg/externalname"
 )
 
 const (
+	// MySQLAPI is the name of the Azure API used by MySQL server clients.
+	MySQLAPI = "Microsoft.DBforMySQL"
+
+	// PostgreSQLAPI is the name of the Azure API used by PostgreSQL server
+	// clients.
+	PostgreSQLAPI = "Microsoft.DBforPostgreSQL"
+
 	backupRetentionDaysDefault = int32(7)
 )
 
@@ -51,10 +63,17 @@ type SQLServer struct {
 	FQDN  string
 }
 
+// SQLServerName returns the name of the supplied SQL Server in Azure; its
+// external name if it has one, or else its Kubernetes name.
+func SQLServerName(instance azuredbv1alpha1.SQLServer) string {
+	return externalname.Or(instance, instance.GetName())
+}
+
 // SQLServerAPI represents the API interface for a SQL Server client
 type SQLServerAPI interface {
 	GetServer(ctx context.Context, instance azuredbv1alpha1.SQLServer) (*SQLServer, error)
 	CreateServerBegin(ctx context.Context, instance azuredbv1alpha1.SQLServer, adminPassword string) ([]byte, error)
+	RestoreServerBegin(ctx context.Context, instance azuredbv1alpha1.SQLServer, sourceServerID string) ([]byte, error)
 	CreateServerEnd(createOp []byte) (bool, error)
 	DeleteServer(ctx context.Context, instance azuredbv1alpha1.SQLServer) (azurerest.Future, error)
 	GetFirewallRule(ctx context.Context, instance azuredbv1alpha1.SQLServer, firewallRuleName string) (err error)
@@ -78,6 +97,12 @@ func NewMySQLServerClient(provider *v1alpha1.Provider, clientset kubernetes.Inte
 		return nil, fmt.Errorf("failed to create Azure client: %+v", err)
 	}
 
+	return newMySQLServerClient(client, nil), nil
+}
+
+// newMySQLServerClient returns a MySQLServerClient that sends requests using the supplied
+// HTTP client, or the default sender if it is nil.
+func newMySQLServerClient(client *Client, hc *http.Client) *MySQLServerClient {
 	mysqlServersClient := mysql.NewServersClient(client.SubscriptionID)
 	mysqlServersClient.Authorizer = client.Authorizer
 	mysqlServersClient.AddToUserAgent(UserAgent)
@@ -86,15 +111,20 @@ func NewMySQLServerClient(provider *v1alpha1.Provider, clientset kubernetes.Inte
 	firewallRulesClient.Authorizer = client.Authorizer
 	firewallRulesClient.AddToUserAgent(UserAgent)
 
+	if hc != nil {
+		mysqlServersClient.Sender = hc
+		firewallRulesClient.Sender = hc
+	}
+
 	return &MySQLServerClient{
 		ServersClient:       mysqlServersClient,
 		FirewallRulesClient: firewallRulesClient,
-	}, nil
+	}
 }
 
 // GetServer retrieves the requested MySQL Server
 func (c *MySQLServerClient) GetServer(ctx context.Context, instance azuredbv1alpha1.SQLServer) (*SQLServer, error) {
-	server, err := c.ServersClient.Get(ctx, instance.GetSpec().ResourceGroupName, instance.GetName())
+	server, err := c.ServersClient.Get(ctx, instance.GetSpec().ResourceGroupName, SQLServerName(instance))
 	if err != nil {
 		return nil, err
 	}
@@ -151,7 +181,7 @@ func (c *MySQLServerClient) CreateServerBegin(ctx context.Context, instance azur
 	}
 
 	// make the call to the MySQL Server Create API
-	createFuture, err := c.Create(ctx, instance.GetSpec().ResourceGroupName, instance.GetName(), createParams)
+	createFuture, err := c.Create(ctx, instance.GetSpec().ResourceGroupName, SQLServerName(instance), createParams)
 	if err != nil {
 		return nil, err
 	}
@@ -165,6 +195,52 @@ func (c *MySQLServerClient) CreateServerBegin(ctx context.Context, instance azur
 	return createFutureJSON, nil
 }
 
+// RestoreServerBegin begins the create operation for a MySQL Server that is a
+// point in time restore of the server with the given ID. Its completion is
+// checked with CreateServerEnd.
+func (c *MySQLServerClient) RestoreServerBegin(ctx context.Context, instance azuredbv1alpha1.SQLServer, sourceServerID string) ([]byte, error) {
+	spec := instance.GetSpec()
+
+	skuName, err := SQLServerSkuName(spec.PricingTier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create server SKU name: %+v", err)
+	}
+	capacity := int32(spec.PricingTier.VCores)
+	storageMB := int32(spec.StorageProfile.StorageGB * 1024)
+	backupRetentionDays := backupRetentionDaysDefault
+	if spec.StorageProfile.BackupRetentionDays > 0 {
+		backupRetentionDays = int32(spec.StorageProfile.BackupRetentionDays)
+	}
+	createParams := mysql.ServerForCreate{
+		Sku: &mysql.Sku{
+			Name:     &skuName,
+			Tier:     mysql.SkuTier(spec.PricingTier.Tier),
+			Capacity: &capacity,
+			Family:   &spec.PricingTier.Family,
+		},
+		Properties: &mysql.ServerPropertiesForRestore{
+			SourceServerID:     &sourceServerID,
+			RestorePointInTime: &date.Time{Time: spec.RestoreFrom.PointInTime.RestoreTime.Time},
+			Version:            mysql.ServerVersion(spec.Version),
+			SslEnforcement:     ToSslEnforcement(spec.SSLEnforced),
+			StorageProfile: &mysql.StorageProfile{
+				BackupRetentionDays: &backupRetentionDays,
+				GeoRedundantBackup:  ToGeoRedundantBackup(spec.StorageProfile.GeoRedundantBackup),
+				StorageMB:           &storageMB,
+			},
+			CreateMode: mysql.CreateModePointInTimeRestore,
+		},
+		Location: &spec.Location,
+	}
+
+	createFuture, err := c.Create(ctx, spec.ResourceGroupName, SQLServerName(instance), createParams)
+	if err != nil {
+		return nil, err
+	}
+
+	return createFuture.MarshalJSON()
+}
+
 // CreateServerEnd checks to see if the given create operation is completed and
 // if any error has occurred.
 func (c *MySQLServerClient) CreateServerEnd(createOp []byte) (done bool, err error) {
@@ -190,13 +266,13 @@ func (c *MySQLServerClient) CreateServerEnd(createOp []byte) (done bool, err err
 
 // DeleteServer deletes the given MySQLServer resource
 func (c *MySQLServerClient) DeleteServer(ctx context.Context, instance azuredbv1alpha1.SQLServer) (azurerest.Future, error) {
-	result, err := c.ServersClient.Delete(ctx, instance.GetSpec().ResourceGroupName, instance.GetName())
+	result, err := c.ServersClient.Delete(ctx, instance.GetSpec().ResourceGroupName, SQLServerName(instance))
 	return result.Future, err
 }
 
 // GetFirewallRule gets the given firewall rule
 func (c *MySQLServerClient) GetFirewallRule(ctx context.Context, instance azuredbv1alpha1.SQLServer, firewallRuleName string) error {
-	_, err := c.FirewallRulesClient.Get(ctx, instance.GetSpec().ResourceGroupName, instance.GetName(), firewallRuleName)
+	_, err := c.FirewallRulesClient.Get(ctx, instance.GetSpec().ResourceGroupName, SQLServerName(instance), firewallRuleName)
 	return err
 }
 
@@ -214,7 +290,7 @@ func (c *MySQLServerClient) CreateFirewallRulesBegin(ctx context.Context, instan
 	}
 
 	createFuture, err := c.FirewallRulesClient.CreateOrUpdate(ctx, instance.GetSpec().ResourceGroupName,
-		instance.GetName(), firewallRuleName, createParams)
+		SQLServerName(instance), firewallRuleName, createParams)
 	if err != nil {
 		return nil, err
 	}
@@ -263,6 +339,12 @@ func NewPostgreSQLServerClient(provider *v1alpha1.Provider, clientset kubernetes
 		return nil, fmt.Errorf("failed to create Azure client: %+v", err)
 	}
 
+	return newPostgreSQLServerClient(client, nil), nil
+}
+
+// newPostgreSQLServerClient returns a PostgreSQLServerClient that sends requests using the supplied
+// HTTP client, or the default sender if it is nil.
+func newPostgreSQLServerClient(client *Client, hc *http.Client) *PostgreSQLServerClient {
 	postgreSQLServerClient := postgresql.NewServersClient(client.SubscriptionID)
 	postgreSQLServerClient.Authorizer = client.Authorizer
 	postgreSQLServerClient.AddToUserAgent(UserAgent)
@@ -271,15 +353,20 @@ func NewPostgreSQLServerClient(provider *v1alpha1.Provider, clientset kubernetes
 	firewallRulesClient.Authorizer = client.Authorizer
 	firewallRulesClient.AddToUserAgent(UserAgent)
 
+	if hc != nil {
+		postgreSQLServerClient.Sender = hc
+		firewallRulesClient.Sender = hc
+	}
+
 	return &PostgreSQLServerClient{
 		ServersClient:       postgreSQLServerClient,
 		FirewallRulesClient: firewallRulesClient,
-	}, nil
+	}
 }
 
 // GetServer retrieves the requested PostgreSQL Server
 func (c *PostgreSQLServerClient) GetServer(ctx context.Context, instance azuredbv1alpha1.SQLServer) (*SQLServer, error) {
-	server, err := c.ServersClient.Get(ctx, instance.GetSpec().ResourceGroupName, instance.GetName())
+	server, err := c.ServersClient.Get(ctx, instance.GetSpec().ResourceGroupName, SQLServerName(instance))
 	if err != nil {
 		return nil, err
 	}
@@ -335,7 +422,7 @@ func (c *PostgreSQLServerClient) CreateServerBegin(ctx context.Context, instance
 	}
 
 	// make the call to the PostgreSQL Server Create API
-	createFuture, err := c.Create(ctx, instance.GetSpec().ResourceGroupName, instance.GetName(), createParams)
+	createFuture, err := c.Create(ctx, instance.GetSpec().ResourceGroupName, SQLServerName(instance), createParams)
 	if err != nil {
 		return nil, err
 	}
@@ -349,6 +436,52 @@ func (c *PostgreSQLServerClient) CreateServerBegin(ctx context.Context, instance
 	return createFutureJSON, nil
 }
 
+// RestoreServerBegin begins the create operation for a PostgreSQL Server that
+// is a point in time restore of the server with the given ID. Its completion is
+// checked with CreateServerEnd.
+func (c *PostgreSQLServerClient) RestoreServerBegin(ctx context.Context, instance azuredbv1alpha1.SQLServer, sourceServerID string) ([]byte, error) {
+	spec := instance.GetSpec()
+
+	skuName, err := SQLServerSkuName(spec.PricingTier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create server SKU name: %+v", err)
+	}
+	capacity := int32(spec.PricingTier.VCores)
+	storageMB := int32(spec.StorageProfile.StorageGB * 1024)
+	backupRetentionDays := backupRetentionDaysDefault
+	if spec.StorageProfile.BackupRetentionDays > 0 {
+		backupRetentionDays = int32(spec.StorageProfile.BackupRetentionDays)
+	}
+	createParams := postgresql.ServerForCreate{
+		Sku: &postgresql.Sku{
+			Name:     &skuName,
+			Tier:     postgresql.SkuTier(spec.PricingTier.Tier),
+			Capacity: &capacity,
+			Family:   &spec.PricingTier.Family,
+		},
+		Properties: &postgresql.ServerPropertiesForRestore{
+			SourceServerID:     &sourceServerID,
+			RestorePointInTime: &date.Time{Time: spec.RestoreFrom.PointInTime.RestoreTime.Time},
+			Version:            postgresql.ServerVersion(spec.Version),
+			SslEnforcement:     postgresql.SslEnforcementEnum(ToSslEnforcement(spec.SSLEnforced)),
+			StorageProfile: &postgresql.StorageProfile{
+				BackupRetentionDays: &backupRetentionDays,
+				GeoRedundantBackup:  postgresql.GeoRedundantBackup(ToGeoRedundantBackup(spec.StorageProfile.GeoRedundantBackup)),
+				StorageMB:           &storageMB,
+			},
+			CreateMode: postgresql.CreateModePointInTimeRestore,
+		},
+		Location: &spec.Location,
+	}
+
+	createFuture, err := c.Create(ctx, spec.ResourceGroupName, SQLServerName(instance), createParams)
+	if err != nil {
+		return nil, err
+	}
+
+	return createFuture.MarshalJSON()
+}
+
 // CreateServerEnd checks to see if the given create operation is completed and if any error has occurred.
 func (c *PostgreSQLServerClient) CreateServerEnd(createOp []byte) (done bool, err error) {
 	// unmarshal the given create complete data into a future object
@@ -373,13 +506,13 @@ func (c *PostgreSQLServerClient) CreateServerEnd(createOp []byte) (done bool, er
 
 // DeleteServer deletes the given PostgreSQL resource
 func (c *PostgreSQLServerClient) DeleteServer(ctx context.Context, instance azuredbv1alpha1.SQLServer) (azurerest.Future, error) {
-	result, err := c.ServersClient.Delete(ctx, instance.GetSpec().ResourceGroupName, instance.GetName())
+	result, err := c.ServersClient.Delete(ctx, instance.GetSpec().ResourceGroupName, SQLServerName(instance))
 	return result.Future, err
 }
 
 // GetFirewallRule gets the given firewall rule
 func (c *PostgreSQLServerClient) GetFirewallRule(ctx context.Context, instance azuredbv1alpha1.SQLServer, firewallRuleName string) error {
-	_, err := c.FirewallRulesClient.Get(ctx, instance.GetSpec().ResourceGroupName, instance.GetName(), firewallRuleName)
+	_, err := c.FirewallRulesClient.Get(ctx, instance.GetSpec().ResourceGroupName, SQLServerName(instance), firewallRuleName)
 	return err
 }
 
@@ -397,7 +530,7 @@ func (c *PostgreSQLServerClient) CreateFirewallRulesBegin(ctx context.Context, i
 	}
 
 	createFuture, err := c.FirewallRulesClient.CreateOrUpdate(ctx, instance.GetSpec().ResourceGroupName,
-		instance.GetName(), firewallRuleName, createParams)
+		SQLServerName(instance), firewallRuleName, createParams)
 	if err != nil {
 		return nil, err
 	}
@@ -440,20 +573,50 @@ type SQLServerAPIFactory interface {
 
 // MySQLServerClientFactory implements the SQLServerAPIFactory by returning the concrete MySQLServerClient implementation
 type MySQLServerClientFactory struct {
+	// Kube is used to read provider credentials. Clients are created using
+	// the supplied clientset, and are not pooled, if it is nil.
+	Kube client.Client
+
+	// Pool caches clients and limits their requests to the Azure API.
+	Pool *pool.Pool
 }
 
 // CreateAPIInstance returns a concrete MySQLServerClient implementation
 func (f *MySQLServerClientFactory) CreateAPIInstance(provider *v1alpha1.Provider, clientset kubernetes.Interface) (SQLServerAPI, error) {
-	return NewMySQLServerClient(provider, clientset)
+	if f.Kube == nil {
+		return NewMySQLServerClient(provider, clientset)
+	}
+	c, err := PooledClient(context.Background(), f.Pool, f.Kube, provider, MySQLAPI, func(c *Client, hc *http.Client) (interface{}, error) {
+		return newMySQLServerClient(c, hc), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Azure client: %+v", err)
+	}
+	return c.(*MySQLServerClient), nil
 }
 
 // PostgreSQLServerClientFactory implements the SQLServerAPIFactory by returning the concrete PostgreSQLServerClient implementation
 type PostgreSQLServerClientFactory struct {
+	// Kube is used to read provider credentials. Clients are created using
+	// the supplied clientset, and are not pooled, if it is nil.
+	Kube client.Client
+
+	// Pool caches clients and limits their requests to the Azure API.
+	Pool *pool.Pool
 }
 
 // CreateAPIInstance returns a concrete PostgreSQLServerClient implementation
 func (f *PostgreSQLServerClientFactory) CreateAPIInstance(provider *v1alpha1.Provider, clientset kubernetes.Interface) (SQLServerAPI, error) {
-	return NewPostgreSQLServerClient(provider, clientset)
+	if f.Kube == nil {
+		return NewPostgreSQLServerClient(provider, clientset)
+	}
+	c, err := PooledClient(context.Background(), f.Pool, f.Kube, provider, PostgreSQLAPI, func(c *Client, hc *http.Client) (interface{}, error) {
+		return newPostgreSQLServerClient(c, hc), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Azure client: %+v", err)
+	}
+	return c.(*PostgreSQLServerClient), nil
 }
 
 // Helper functions
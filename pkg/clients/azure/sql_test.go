@@ -23,8 +23,20 @@ import (
 	"github.com/onsi/gomega"
 
 	databasev1alpha1 "github.com/crossplaneio/crossplane/azure/apis/database/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/externalname"
 )
 
+func TestSQLServerName(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	s := &databasev1alpha1.MysqlServer{}
+	s.SetName("foo")
+	g.Expect(SQLServerName(s)).To(gomega.Equal("foo"))
+
+	externalname.Set(s, "existing")
+	g.Expect(SQLServerName(s)).To(gomega.Equal("existing"))
+}
+
 func TestSQLServerStatusMessage(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 
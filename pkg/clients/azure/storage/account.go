@@ -19,34 +19,36 @@ package storage
 import (
 	"context"
 	"encoding/json"
-	"fmt"
+	"net/http"
 
 	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2017-06-01/storage"
-	"github.com/Azure/go-autorest/autorest/azure/auth"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
 
 	"github.com/crossplaneio/crossplane/pkg/clients/azure"
 )
 
-// NewStorageAccountClient create Azure storage.AccountClient using provided credentials data
-func NewStorageAccountClient(data []byte) (*storage.AccountsClient, error) {
+// API is the name of the Azure API used by storage account clients.
+const API = "Microsoft.Storage"
+
+// NewStorageAccountClient create Azure storage.AccountClient using provided credentials data.
+// Requests are sent using the supplied HTTP client, or the default sender if it is nil.
+func NewStorageAccountClient(data []byte, hc *http.Client) (*storage.AccountsClient, error) {
 	creds := &azure.Credentials{}
 	if err := json.Unmarshal(data, creds); err != nil {
 		return nil, errors.Wrap(err, "cannot unmarshal Azure client secret data")
 	}
 
-	config := auth.NewClientCredentialsConfig(creds.ClientID, creds.ClientSecret, creds.TenantID)
-	config.AADEndpoint = creds.ActiveDirectoryEndpointURL
-	config.Resource = creds.ResourceManagerEndpointURL
-
-	authorizer, err := config.Authorizer()
+	authorizer, err := azure.NewAuthorizer(*creds)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get authorizer from config: %+v", err)
+		return nil, err
 	}
 
 	client := storage.NewAccountsClient(creds.SubscriptionID)
 	client.Authorizer = authorizer
+	if hc != nil {
+		client.Sender = hc
+	}
 
 	if err := client.AddToUserAgent(azure.UserAgent); err != nil {
 		return nil, errors.Wrap(err, "cannot add to Azure client user agent")
@@ -57,7 +57,7 @@ func TestNewStorageAccountClient(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := NewStorageAccountClient(tt.args)
+			got, err := NewStorageAccountClient(tt.args, nil)
 			if diff := cmp.Diff(err, tt.wantErr, test.EquateErrors()); diff != "" {
 				t.Errorf("NewStorageAccountClient() error = %v, wantErr %v\n%s", err, tt.wantErr, diff)
 			}
@@ -0,0 +1,281 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sim provides a simulated Azure storage account API.
+package sim
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2017-06-01/storage"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/pkg/errors"
+
+	"github.com/crossplaneio/crossplane/pkg/clients/azure"
+	azurestorage "github.com/crossplaneio/crossplane/pkg/clients/azure/storage"
+	"github.com/crossplaneio/crossplane/pkg/clients/sim"
+)
+
+const subscriptionID = "00000000-0000-0000-0000-000000000000"
+
+type account struct {
+	group   string
+	index   int
+	created time.Time
+	params  storage.AccountCreateParameters
+	keys    []storage.AccountKey
+}
+
+// A Client is a simulated Azure storage account API. Storage account handles
+// wait for accounts to be created, so unlike most simulated APIs accounts are
+// created, updated, and deleted synchronously. As with the real API, account
+// names are unique across resource groups. Errors may be injected into any
+// method of the handles the Client returns, keyed by the method's name.
+type Client struct {
+	sim.Failures
+
+	mu       sync.Mutex
+	accounts map[string]*account
+	count    int
+}
+
+// NewClient returns a simulated Azure storage account API with no accounts.
+func NewClient() *Client {
+	return &Client{accounts: map[string]*account{}}
+}
+
+// Handle returns a handle to the named account in the supplied resource
+// group, which need not exist.
+func (c *Client) Handle(group, name string) *AccountHandle {
+	return &AccountHandle{client: c, group: group, name: name}
+}
+
+// Accounts returns the names of all accounts that exist, in order.
+func (c *Client) Accounts() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	names := make([]string, 0, len(c.accounts))
+	for name := range c.accounts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// An AccountHandle operates on a single account of a simulated Azure storage
+// account API.
+type AccountHandle struct {
+	client *Client
+	group  string
+	name   string
+}
+
+var _ azurestorage.AccountOperations = &AccountHandle{}
+
+// Create creates the account, which must not already exist.
+func (h *AccountHandle) Create(ctx context.Context, params storage.AccountCreateParameters) (*storage.Account, error) {
+	if err := h.client.Call("Create"); err != nil {
+		return nil, err
+	}
+	if err := h.IsAccountNameAvailable(ctx, h.name); err != nil {
+		return nil, errors.Wrapf(err, "failed to check account name availability")
+	}
+
+	c := h.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.count++
+	a := &account{
+		group:   h.group,
+		index:   c.count,
+		created: time.Now(),
+		params:  params,
+		keys: []storage.AccountKey{
+			{KeyName: azure.ToStringPtr("key1"), Value: azure.ToStringPtr(fmt.Sprintf("sim-key1-%d", c.count)), Permissions: storage.Full},
+			{KeyName: azure.ToStringPtr("key2"), Value: azure.ToStringPtr(fmt.Sprintf("sim-key2-%d", c.count)), Permissions: storage.Full},
+		},
+	}
+	c.accounts[h.name] = a
+	return view(h.name, a), nil
+}
+
+// Update applies the supplied parameters to the account.
+func (h *AccountHandle) Update(_ context.Context, params storage.AccountUpdateParameters) (*storage.Account, error) {
+	if err := h.client.Call("Update"); err != nil {
+		return nil, err
+	}
+
+	c := h.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	a, ok := c.accounts[h.name]
+	if !ok || a.group != h.group {
+		return nil, accountNotFound("Update", h.name)
+	}
+	if params.Sku != nil {
+		a.params.Sku = params.Sku
+	}
+	if params.Tags != nil {
+		a.params.Tags = params.Tags
+	}
+	if params.Identity != nil {
+		a.params.Identity = params.Identity
+	}
+	if params.Kind != "" {
+		a.params.Kind = params.Kind
+	}
+	if up := params.AccountPropertiesUpdateParameters; up != nil {
+		if a.params.AccountPropertiesCreateParameters == nil {
+			a.params.AccountPropertiesCreateParameters = &storage.AccountPropertiesCreateParameters{}
+		}
+		p := a.params.AccountPropertiesCreateParameters
+		if up.AccessTier != "" {
+			p.AccessTier = up.AccessTier
+		}
+		if up.CustomDomain != nil {
+			p.CustomDomain = up.CustomDomain
+		}
+		if up.EnableHTTPSTrafficOnly != nil {
+			p.EnableHTTPSTrafficOnly = up.EnableHTTPSTrafficOnly
+		}
+		if up.Encryption != nil {
+			p.Encryption = up.Encryption
+		}
+		if up.NetworkRuleSet != nil {
+			p.NetworkRuleSet = up.NetworkRuleSet
+		}
+	}
+	return view(h.name, a), nil
+}
+
+// Get returns the account.
+func (h *AccountHandle) Get(_ context.Context) (*storage.Account, error) {
+	if err := h.client.Call("Get"); err != nil {
+		return nil, err
+	}
+
+	c := h.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	a, ok := c.accounts[h.name]
+	if !ok || a.group != h.group {
+		return nil, accountNotFound("GetProperties", h.name)
+	}
+	return view(h.name, a), nil
+}
+
+// Delete deletes the account. As with the real API, deleting an account that
+// does not exist succeeds.
+func (h *AccountHandle) Delete(_ context.Context) error {
+	if err := h.client.Call("Delete"); err != nil {
+		return err
+	}
+
+	c := h.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if a, ok := c.accounts[h.name]; ok && a.group == h.group {
+		delete(c.accounts, h.name)
+	}
+	return nil
+}
+
+// IsAccountNameAvailable returns an error if an account with the supplied
+// name exists in any resource group.
+func (h *AccountHandle) IsAccountNameAvailable(_ context.Context, name string) error {
+	if err := h.client.Call("IsAccountNameAvailable"); err != nil {
+		return err
+	}
+
+	c := h.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.accounts[name]; ok {
+		return errors.Errorf("%s - The storage account named %s is already taken.", storage.AlreadyExists, name)
+	}
+	return nil
+}
+
+// ListKeys returns the access keys of the account.
+func (h *AccountHandle) ListKeys(_ context.Context) ([]storage.AccountKey, error) {
+	if err := h.client.Call("ListKeys"); err != nil {
+		return nil, err
+	}
+
+	c := h.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	a, ok := c.accounts[h.name]
+	if !ok || a.group != h.group {
+		return nil, accountNotFound("ListKeys", h.name)
+	}
+	return a.keys, nil
+}
+
+func view(name string, a *account) *storage.Account {
+	props := &storage.AccountProperties{
+		ProvisioningState: storage.Succeeded,
+		PrimaryEndpoints: &storage.Endpoints{
+			Blob:  azure.ToStringPtr(fmt.Sprintf("https://%s.blob.core.windows.net/", name), azure.FieldRequired),
+			Queue: azure.ToStringPtr(fmt.Sprintf("https://%s.queue.core.windows.net/", name), azure.FieldRequired),
+			Table: azure.ToStringPtr(fmt.Sprintf("https://%s.table.core.windows.net/", name), azure.FieldRequired),
+			File:  azure.ToStringPtr(fmt.Sprintf("https://%s.file.core.windows.net/", name), azure.FieldRequired),
+		},
+		PrimaryLocation: a.params.Location,
+		StatusOfPrimary: storage.Available,
+		CreationTime:    &date.Time{Time: a.created},
+	}
+	if p := a.params.AccountPropertiesCreateParameters; p != nil {
+		props.AccessTier = p.AccessTier
+		props.CustomDomain = p.CustomDomain
+		props.EnableHTTPSTrafficOnly = p.EnableHTTPSTrafficOnly
+		props.Encryption = p.Encryption
+		props.NetworkRuleSet = p.NetworkRuleSet
+	}
+	return &storage.Account{
+		ID:                azure.ToStringPtr(fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Storage/storageAccounts/%s", subscriptionID, a.group, name), azure.FieldRequired),
+		Name:              azure.ToStringPtr(name, azure.FieldRequired),
+		Type:              azure.ToStringPtr("Microsoft.Storage/storageAccounts", azure.FieldRequired),
+		Location:          a.params.Location,
+		Tags:              a.params.Tags,
+		Sku:               a.params.Sku,
+		Kind:              a.params.Kind,
+		Identity:          a.params.Identity,
+		AccountProperties: props,
+	}
+}
+
+func accountNotFound(method, name string) error {
+	return autorest.DetailedError{
+		PackageType: "storage.AccountsClient",
+		Method:      method,
+		StatusCode:  http.StatusNotFound,
+		Message:     fmt.Sprintf("The Resource 'Microsoft.Storage/storageAccounts/%s' was not found.", name),
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sim
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2017-06-01/storage"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplaneio/crossplane/azure/apis/storage/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/azure"
+)
+
+const (
+	group       = "cool-group"
+	accountName = "coolaccount"
+)
+
+func TestAccountLifecycle(t *testing.T) {
+	ctx := context.Background()
+	c := NewClient()
+	h := c.Handle(group, accountName)
+
+	if _, err := h.Get(ctx); !azure.IsNotFound(err) {
+		t.Errorf("h.Get(...): want not found error before creation, got %v", err)
+	}
+
+	spec := &v1alpha1.StorageAccountSpec{
+		Kind:                         storage.Storage,
+		Location:                     "westus",
+		Sku:                          &v1alpha1.Sku{Name: storage.StandardLRS},
+		StorageAccountSpecProperties: &v1alpha1.StorageAccountSpecProperties{EnableHTTPSTrafficOnly: true},
+		Tags:                         map[string]string{"UID": "cool-uid"},
+	}
+	a, err := h.Create(ctx, v1alpha1.ToStorageAccountCreate(spec))
+	if err != nil {
+		t.Fatalf("h.Create(...): %s", err)
+	}
+	if diff := cmp.Diff(storage.Succeeded, a.ProvisioningState); diff != "" {
+		t.Errorf("h.Create(...): -want state, +got state:\n%s", diff)
+	}
+	if diff := cmp.Diff(spec, v1alpha1.NewStorageAccountSpec(a)); diff != "" {
+		t.Errorf("h.Create(...): -want spec, +got spec:\n%s", diff)
+	}
+
+	if _, err := c.Handle("other-group", accountName).Create(ctx, v1alpha1.ToStorageAccountCreate(spec)); err == nil {
+		t.Errorf("h.Create(...): want error for a name that is taken in another resource group, got nil")
+	}
+
+	spec.Sku.Name = storage.StandardGRS
+	if a, err = h.Update(ctx, v1alpha1.ToStorageAccountUpdate(spec)); err != nil {
+		t.Fatalf("h.Update(...): %s", err)
+	}
+	if diff := cmp.Diff(storage.StandardGRS, a.Sku.Name); diff != "" {
+		t.Errorf("h.Update(...): -want SKU, +got SKU:\n%s", diff)
+	}
+
+	keys, err := h.ListKeys(ctx)
+	if err != nil {
+		t.Fatalf("h.ListKeys(...): %s", err)
+	}
+	if to.String(keys[0].Value) == "" {
+		t.Errorf("h.ListKeys(...): want a primary key, got none")
+	}
+
+	if err := h.Delete(ctx); err != nil {
+		t.Fatalf("h.Delete(...): %s", err)
+	}
+	if _, err := h.Get(ctx); !azure.IsNotFound(err) {
+		t.Errorf("h.Get(...): want not found error after deletion, got %v", err)
+	}
+	if err := h.Delete(ctx); err != nil {
+		t.Errorf("h.Delete(...): want no error deleting an account that does not exist, got %s", err)
+	}
+	if diff := cmp.Diff([]string{}, c.Accounts()); diff != "" {
+		t.Errorf("c.Accounts(): -want, +got:\n%s", diff)
+	}
+}
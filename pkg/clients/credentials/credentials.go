@@ -0,0 +1,86 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package credentials decides which providers may use credentials that
+// belong to the Crossplane pod rather than to the provider.
+//
+// Anyone permitted to create a provider in a namespace can point it at any
+// credentials source. Credentials read from a provider's secret are limited
+// to secrets in the provider's namespace, but the ambient credentials of the
+// Crossplane pod (its environment, instance role, or managed identity) and
+// files mounted into it belong to Crossplane itself. Using them lets a
+// provider act as Crossplane, so they are available only to providers in
+// namespaces the cluster administrator trusts, and files only when they are
+// read from directories the administrator allows.
+package credentials
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// A Policy restricts which providers may use the credentials of the
+// Crossplane pod.
+type Policy struct {
+	// Namespaces whose providers may use the ambient credentials of the
+	// Crossplane pod, or read credentials files mounted into it.
+	Namespaces []string
+
+	// FileDirectories from which providers may read credentials files.
+	FileDirectories []string
+}
+
+// Default is the policy enforced by Crossplane's controllers. It allows no
+// provider to use the credentials of the Crossplane pod.
+var Default = &Policy{}
+
+// AllowAmbient returns an error unless providers in the supplied namespace
+// may use the ambient credentials of the Crossplane pod.
+func (p *Policy) AllowAmbient(namespace string) error {
+	if !p.trusted(namespace) {
+		return errors.Errorf("providers in namespace %q may not use the ambient credentials of the Crossplane pod", namespace)
+	}
+	return nil
+}
+
+// AllowFile returns an error unless providers in the supplied namespace may
+// read the supplied credentials file.
+func (p *Policy) AllowFile(namespace, path string) error {
+	if !p.trusted(namespace) {
+		return errors.Errorf("providers in namespace %q may not read credentials files", namespace)
+	}
+	clean := filepath.Clean(path)
+	if !filepath.IsAbs(clean) {
+		return errors.Errorf("credentials file %s is not an absolute path", path)
+	}
+	for _, d := range p.FileDirectories {
+		if strings.HasPrefix(clean, filepath.Clean(d)+string(filepath.Separator)) {
+			return nil
+		}
+	}
+	return errors.Errorf("credentials file %s is not in an allowed directory", path)
+}
+
+func (p *Policy) trusted(namespace string) bool {
+	for _, ns := range p.Namespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+)
+
+func TestAllowAmbient(t *testing.T) {
+	cases := map[string]struct {
+		policy    *Policy
+		namespace string
+		want      error
+	}{
+		"Trusted": {
+			policy:    &Policy{Namespaces: []string{"crossplane-system"}},
+			namespace: "crossplane-system",
+		},
+		"Untrusted": {
+			policy:    &Policy{Namespaces: []string{"crossplane-system"}},
+			namespace: "app-team",
+			want:      errors.New(`providers in namespace "app-team" may not use the ambient credentials of the Crossplane pod`),
+		},
+		"Default": {
+			policy:    Default,
+			namespace: "crossplane-system",
+			want:      errors.New(`providers in namespace "crossplane-system" may not use the ambient credentials of the Crossplane pod`),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := tc.policy.AllowAmbient(tc.namespace)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("p.AllowAmbient(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAllowFile(t *testing.T) {
+	policy := &Policy{Namespaces: []string{"crossplane-system"}, FileDirectories: []string{"/var/run/secrets/crossplane/"}}
+
+	cases := map[string]struct {
+		namespace string
+		path      string
+		want      error
+	}{
+		"Allowed": {
+			namespace: "crossplane-system",
+			path:      "/var/run/secrets/crossplane/aws/credentials",
+		},
+		"UntrustedNamespace": {
+			namespace: "app-team",
+			path:      "/var/run/secrets/crossplane/aws/credentials",
+			want:      errors.New(`providers in namespace "app-team" may not read credentials files`),
+		},
+		"OutsideDirectory": {
+			namespace: "crossplane-system",
+			path:      "/var/run/secrets/kubernetes.io/serviceaccount/token",
+			want:      errors.New("credentials file /var/run/secrets/kubernetes.io/serviceaccount/token is not in an allowed directory"),
+		},
+		"EscapesDirectory": {
+			namespace: "crossplane-system",
+			path:      "/var/run/secrets/crossplane/../kubernetes.io/serviceaccount/token",
+			want:      errors.New("credentials file /var/run/secrets/crossplane/../kubernetes.io/serviceaccount/token is not in an allowed directory"),
+		},
+		"SiblingDirectory": {
+			namespace: "crossplane-system",
+			path:      "/var/run/secrets/crossplane-other/credentials",
+			want:      errors.New("credentials file /var/run/secrets/crossplane-other/credentials is not in an allowed directory"),
+		},
+		"RelativePath": {
+			namespace: "crossplane-system",
+			path:      "credentials",
+			want:      errors.New("credentials file credentials is not an absolute path"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := policy.AllowFile(tc.namespace, tc.path)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("p.AllowFile(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
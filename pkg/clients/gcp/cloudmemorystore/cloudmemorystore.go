@@ -23,15 +23,26 @@ import (
 
 	redisv1 "cloud.google.com/go/redis/apiv1"
 	"github.com/googleapis/gax-go"
+	"golang.org/x/oauth2/google"
 	"google.golang.org/api/option"
 	redisv1pb "google.golang.org/genproto/googleapis/cloud/redis/v1"
 	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/crossplaneio/crossplane/gcp/apis/cache/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/externalname"
+	"github.com/crossplaneio/crossplane/pkg/metrics"
 )
 
-// NamePrefix is the prefix for all created CloudMemorystore instances.
-const NamePrefix = "cms"
+const (
+	// NamePrefix is the prefix for all created CloudMemorystore instances.
+	NamePrefix = "cms"
+
+	// API is the name of the GCP API used by CloudMemorystore clients.
+	API = "redis.googleapis.com"
+)
 
 // A Client handles CRUD operations for Cloud Memorystore instances. This
 // interface is compatible with the upstream CloudRedisClient.
@@ -42,10 +53,19 @@ type Client interface {
 	GetInstance(ctx context.Context, req *redisv1pb.GetInstanceRequest, opts ...gax.CallOption) (*redisv1pb.Instance, error)
 }
 
-// NewClient returns a new CloudMemorystore Client. Credentials must be passed
-// as JSON encoded data.
-func NewClient(ctx context.Context, credentials []byte) (Client, error) {
-	return redisv1.NewCloudRedisClient(ctx, option.WithCredentialsJSON(credentials))
+// IsNotFound returns true if the supplied error indicates a CloudMemorystore
+// instance was not found.
+func IsNotFound(err error) bool {
+	return status.Code(err) == codes.NotFound
+}
+
+// NewClient returns a new CloudMemorystore Client. Calls made by the client
+// are recorded as cloud API metrics.
+func NewClient(ctx context.Context, creds *google.Credentials) (Client, error) {
+	return redisv1.NewCloudRedisClient(ctx,
+		option.WithCredentials(creds),
+		option.WithGRPCDialOption(grpc.WithUnaryInterceptor(metrics.UnaryClientInterceptor(API))),
+	)
 }
 
 // An InstanceID represents a CloudMemorystore instance in the GCP API.
@@ -66,12 +86,13 @@ type InstanceID struct {
 
 // NewInstanceID returns an identifier used to represent CloudMemorystore
 // instances in the GCP API. Instances may have names of up to 40 characters. We
-// use a four character prefix and a 36 character UUID.
+// use a four character prefix and a 36 character UUID, unless the instance has
+// an external name.
 // https://godoc.org/google.golang.org/genproto/googleapis/cloud/redis/v1#CreateInstanceRequest
 func NewInstanceID(project string, i *v1alpha1.CloudMemorystoreInstance) InstanceID {
 	id := InstanceID{Project: project, Region: i.Spec.Region, Instance: i.Status.InstanceName}
 	if id.Instance == "" {
-		id.Instance = fmt.Sprintf("%s-%s", NamePrefix, i.GetUID())
+		id.Instance = externalname.Or(i, fmt.Sprintf("%s-%s", NamePrefix, i.GetUID()))
 	}
 	return id
 }
@@ -141,6 +162,15 @@ func NeedsUpdate(kube *v1alpha1.CloudMemorystoreInstance, gcp *redisv1pb.Instanc
 	return false
 }
 
+// UpdateOperation returns the operation that updating the supplied GCP resource
+// to match the supplied Kubernetes resource will perform.
+func UpdateOperation(kube *v1alpha1.CloudMemorystoreInstance, gcp *redisv1pb.Instance) string {
+	if kube.Spec.MemorySizeGB != int(gcp.GetMemorySizeGb()) {
+		return v1alpha1.OperationScaleMemory
+	}
+	return v1alpha1.OperationUpdateConfig
+}
+
 // NewDeleteInstanceRequest creates a request to delete an instance suitable for
 // use with the GCP API.
 func NewDeleteInstanceRequest(id InstanceID) *redisv1pb.DeleteInstanceRequest {
@@ -26,6 +26,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/crossplaneio/crossplane/gcp/apis/cache/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/externalname"
 )
 
 const (
@@ -96,6 +97,28 @@ func TestInstanceID(t *testing.T) {
 			wantName:   qualifiedName,
 			wantParent: parent,
 		},
+		{
+			name:    "ExternalNameSet",
+			project: project,
+			i: &v1alpha1.CloudMemorystoreInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					UID:         types.UID("i-am-different"),
+					Annotations: map[string]string{externalname.AnnotationKey: instanceName},
+				},
+				Spec: v1alpha1.CloudMemorystoreInstanceSpec{
+					CloudMemorystoreInstanceParameters: v1alpha1.CloudMemorystoreInstanceParameters{
+						Region: region,
+					},
+				},
+			},
+			want: InstanceID{
+				Project:  project,
+				Region:   region,
+				Instance: instanceName,
+			},
+			wantName:   qualifiedName,
+			wantParent: parent,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -336,6 +359,51 @@ func TestNeedsUpdate(t *testing.T) {
 	}
 }
 
+func TestUpdateOperation(t *testing.T) {
+	cases := []struct {
+		name string
+		kube *v1alpha1.CloudMemorystoreInstance
+		gcp  *redisv1pb.Instance
+		want string
+	}{
+		{
+			name: "ScaleMemory",
+			kube: &v1alpha1.CloudMemorystoreInstance{
+				Spec: v1alpha1.CloudMemorystoreInstanceSpec{
+					CloudMemorystoreInstanceParameters: v1alpha1.CloudMemorystoreInstanceParameters{
+						RedisConfigs: redisConfigs,
+						MemorySizeGB: memorySizeGB,
+					},
+				},
+			},
+			gcp:  &redisv1pb.Instance{MemorySizeGb: memorySizeGB + 1, RedisConfigs: redisConfigs},
+			want: v1alpha1.OperationScaleMemory,
+		},
+		{
+			name: "UpdateConfig",
+			kube: &v1alpha1.CloudMemorystoreInstance{
+				Spec: v1alpha1.CloudMemorystoreInstanceSpec{
+					CloudMemorystoreInstanceParameters: v1alpha1.CloudMemorystoreInstanceParameters{
+						RedisConfigs: redisConfigs,
+						MemorySizeGB: memorySizeGB,
+					},
+				},
+			},
+			gcp:  &redisv1pb.Instance{MemorySizeGb: memorySizeGB, RedisConfigs: map[string]string{"super": "cool"}},
+			want: v1alpha1.OperationUpdateConfig,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := UpdateOperation(tc.kube, tc.gcp)
+			if got != tc.want {
+				t.Errorf("UpdateOperation(...): want: %s got: %s", tc.want, got)
+			}
+		})
+	}
+}
+
 func TestNewDeleteInstanceRequest(t *testing.T) {
 	cases := []struct {
 		name    string
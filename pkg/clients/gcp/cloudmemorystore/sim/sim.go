@@ -0,0 +1,224 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sim provides a simulated Cloud Memorystore API.
+package sim
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	redisv1 "cloud.google.com/go/redis/apiv1"
+	"github.com/golang/protobuf/proto"
+	"github.com/googleapis/gax-go"
+	redisv1pb "google.golang.org/genproto/googleapis/cloud/redis/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/crossplaneio/crossplane/pkg/clients/gcp/cloudmemorystore"
+	"github.com/crossplaneio/crossplane/pkg/clients/sim"
+)
+
+// Instance states.
+var (
+	stateCreating = redisv1pb.Instance_CREATING.String()
+	stateReady    = redisv1pb.Instance_READY.String()
+	stateUpdating = redisv1pb.Instance_UPDATING.String()
+	stateDeleting = redisv1pb.Instance_DELETING.String()
+)
+
+// Fields of an instance that may be updated.
+const (
+	fieldMemorySizeGB = "memory_size_gb"
+	fieldRedisConfigs = "redis_configs"
+)
+
+const (
+	defaultPort    = 6379
+	defaultVersion = "REDIS_4_0"
+)
+
+type instance struct {
+	lifecycle *sim.Lifecycle
+	spec      *redisv1pb.Instance
+	index     int
+}
+
+// A Client is a simulated Cloud Memorystore API. Instances are created in the
+// CREATING state and become READY after they have been observed Polls times.
+// Instances are updated and deleted asynchronously in the same way. The
+// simulated API returns no long running operations; the Cloud Memorystore
+// controller observes instances rather than operations. Errors may be injected
+// into any method, keyed by its name.
+type Client struct {
+	sim.Failures
+
+	// Polls is the number of times an instance in a transitional state must
+	// be observed before it settles.
+	Polls int
+
+	mu        sync.Mutex
+	instances map[string]*instance
+	count     int
+}
+
+var _ cloudmemorystore.Client = &Client{}
+
+// NewClient returns a simulated Cloud Memorystore API with no instances.
+func NewClient() *Client {
+	return &Client{Polls: sim.DefaultPolls, instances: map[string]*instance{}}
+}
+
+// CreateInstance creates the requested instance.
+func (c *Client) CreateInstance(_ context.Context, req *redisv1pb.CreateInstanceRequest, _ ...gax.CallOption) (*redisv1.CreateInstanceOperation, error) {
+	if err := c.Call("CreateInstance"); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	name := fmt.Sprintf("%s/instances/%s", req.GetParent(), req.GetInstanceId())
+	if _, ok := c.instances[name]; ok {
+		return nil, status.Errorf(codes.AlreadyExists, "Resource '%s' already exists", name)
+	}
+	spec := proto.Clone(req.GetInstance()).(*redisv1pb.Instance)
+	spec.Name = name
+	if spec.RedisVersion == "" {
+		spec.RedisVersion = defaultVersion
+	}
+	c.count++
+	c.instances[name] = &instance{
+		lifecycle: sim.NewLifecycle(stateCreating).Then(stateReady, c.Polls),
+		spec:      spec,
+		index:     c.count,
+	}
+	return nil, nil
+}
+
+// UpdateInstance applies the masked fields of the requested instance.
+func (c *Client) UpdateInstance(_ context.Context, req *redisv1pb.UpdateInstanceRequest, _ ...gax.CallOption) (*redisv1.UpdateInstanceOperation, error) {
+	if err := c.Call("UpdateInstance"); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	name := req.GetInstance().GetName()
+	i, ok := c.instances[name]
+	if !ok {
+		return nil, instanceNotFound(name)
+	}
+	if i.lifecycle.State() != stateReady {
+		return nil, status.Errorf(codes.FailedPrecondition, "Instance '%s' is not ready", name)
+	}
+	for _, p := range req.GetUpdateMask().GetPaths() {
+		switch p {
+		case fieldMemorySizeGB:
+			i.spec.MemorySizeGb = req.GetInstance().GetMemorySizeGb()
+		case fieldRedisConfigs:
+			i.spec.RedisConfigs = req.GetInstance().GetRedisConfigs()
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "Field '%s' cannot be updated", p)
+		}
+	}
+	i.lifecycle.Set(stateUpdating).Then(stateReady, c.Polls)
+	return nil, nil
+}
+
+// DeleteInstance starts deleting the requested instance.
+func (c *Client) DeleteInstance(_ context.Context, req *redisv1pb.DeleteInstanceRequest, _ ...gax.CallOption) (*redisv1.DeleteInstanceOperation, error) {
+	if err := c.Call("DeleteInstance"); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i, ok := c.instances[req.GetName()]
+	if !ok {
+		return nil, instanceNotFound(req.GetName())
+	}
+	if i.lifecycle.State() != stateDeleting {
+		i.lifecycle.Set(stateDeleting).Then(sim.Deleted, c.Polls)
+	}
+	return nil, nil
+}
+
+// GetInstance returns the requested instance.
+func (c *Client) GetInstance(_ context.Context, req *redisv1pb.GetInstanceRequest, _ ...gax.CallOption) (*redisv1pb.Instance, error) {
+	if err := c.Call("GetInstance"); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	name := req.GetName()
+	i, ok := c.instances[name]
+	if !ok {
+		return nil, instanceNotFound(name)
+	}
+	if i.lifecycle.Observe() == sim.Deleted {
+		delete(c.instances, name)
+		return nil, instanceNotFound(name)
+	}
+	return view(i), nil
+}
+
+// Settle completes all pending instance transitions immediately.
+func (c *Client) Settle() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for name, i := range c.instances {
+		if i.lifecycle.Settle() == sim.Deleted {
+			delete(c.instances, name)
+		}
+	}
+}
+
+// Instances returns the fully qualified names of all instances that exist,
+// including those that are being created or deleted, in order.
+func (c *Client) Instances() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	names := make([]string, 0, len(c.instances))
+	for name := range c.instances {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func view(i *instance) *redisv1pb.Instance {
+	out := proto.Clone(i.spec).(*redisv1pb.Instance)
+	out.State = redisv1pb.Instance_State(redisv1pb.Instance_State_value[i.lifecycle.State()])
+	out.CurrentLocationId = out.GetLocationId()
+	if out.State != redisv1pb.Instance_CREATING {
+		out.Host = fmt.Sprintf("10.0.0.%d", i.index)
+		out.Port = defaultPort
+	}
+	return out
+}
+
+func instanceNotFound(name string) error {
+	return status.Errorf(codes.NotFound, "Resource '%s' was not found", name)
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sim
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	redisv1pb "google.golang.org/genproto/googleapis/cloud/redis/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplaneio/crossplane/gcp/apis/cache/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/gcp/cloudmemorystore"
+)
+
+func TestInstanceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	c := NewClient()
+
+	i := &v1alpha1.CloudMemorystoreInstance{ObjectMeta: metav1.ObjectMeta{UID: "cool-uid"}}
+	i.Spec.Region = "us-central1"
+	i.Spec.Tier = "BASIC"
+	i.Spec.MemorySizeGB = 1
+	id := cloudmemorystore.NewInstanceID("cool-project", i)
+
+	if _, err := c.CreateInstance(ctx, cloudmemorystore.NewCreateInstanceRequest(id, i)); err != nil {
+		t.Fatalf("c.CreateInstance(...): %s", err)
+	}
+	_, err := c.CreateInstance(ctx, cloudmemorystore.NewCreateInstanceRequest(id, i))
+	if diff := cmp.Diff(codes.AlreadyExists, status.Code(err)); diff != "" {
+		t.Errorf("c.CreateInstance(...): -want code, +got code:\n%s", diff)
+	}
+
+	want := []redisv1pb.Instance_State{redisv1pb.Instance_CREATING, redisv1pb.Instance_READY}
+	got := []redisv1pb.Instance_State{}
+	var gcp *redisv1pb.Instance
+	for n := 0; n < c.Polls; n++ {
+		gcp, err = c.GetInstance(ctx, cloudmemorystore.NewGetInstanceRequest(id))
+		if err != nil {
+			t.Fatalf("c.GetInstance(...): %s", err)
+		}
+		got = append(got, gcp.GetState())
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("c.GetInstance(...): -want states, +got states:\n%s", diff)
+	}
+
+	i.Spec.MemorySizeGB = 2
+	if !cloudmemorystore.NeedsUpdate(i, gcp) {
+		t.Fatalf("cloudmemorystore.NeedsUpdate(...): want true after resizing, got false")
+	}
+	if _, err := c.UpdateInstance(ctx, cloudmemorystore.NewUpdateInstanceRequest(id, i)); err != nil {
+		t.Fatalf("c.UpdateInstance(...): %s", err)
+	}
+	c.Settle()
+	gcp, err = c.GetInstance(ctx, cloudmemorystore.NewGetInstanceRequest(id))
+	if err != nil {
+		t.Fatalf("c.GetInstance(...): %s", err)
+	}
+	if cloudmemorystore.NeedsUpdate(i, gcp) {
+		t.Errorf("cloudmemorystore.NeedsUpdate(...): want false after update, got true")
+	}
+
+	if _, err := c.DeleteInstance(ctx, cloudmemorystore.NewDeleteInstanceRequest(id)); err != nil {
+		t.Fatalf("c.DeleteInstance(...): %s", err)
+	}
+	c.Settle()
+	_, err = c.GetInstance(ctx, cloudmemorystore.NewGetInstanceRequest(id))
+	if diff := cmp.Diff(codes.NotFound, status.Code(err)); diff != "" {
+		t.Errorf("c.GetInstance(...): after deletion: -want code, +got code:\n%s", diff)
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudsql
+
+import (
+	"context"
+	"net/http"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/option"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+)
+
+// BackupRunService provides an interface for operations on cloudsql backup runs
+type BackupRunService interface {
+	Get(context.Context, string, int64) (*sqladmin.BackupRun, error)
+	List(context.Context, string) ([]*sqladmin.BackupRun, error)
+	Insert(context.Context, string, *sqladmin.BackupRun) error
+	Delete(context.Context, string, int64) error
+}
+
+// BackupRunClient implements BackupRunService interface
+type BackupRunClient struct {
+	service   *sqladmin.BackupRunsService
+	projectID string
+}
+
+// Interface validation
+var _ BackupRunService = &BackupRunClient{}
+
+// NewBackupRunClient creates new instance of BackupRunClient. Requests are
+// made using the supplied HTTP client, which must be authorized by the supplied
+// credentials, or using a new HTTP client authorized by them if it is nil.
+func NewBackupRunClient(ctx context.Context, creds *google.Credentials, hc *http.Client) (*BackupRunClient, error) {
+	if hc == nil {
+		hc = oauth2.NewClient(ctx, creds.TokenSource)
+	}
+	service, err := sqladmin.NewService(ctx, option.WithHTTPClient(hc))
+	if err != nil {
+		return nil, err
+	}
+
+	return &BackupRunClient{
+		service:   service.BackupRuns,
+		projectID: creds.ProjectID,
+	}, nil
+}
+
+// Get the backup run with the provided ID from a given instance
+func (c *BackupRunClient) Get(ctx context.Context, instance string, id int64) (*sqladmin.BackupRun, error) {
+	return c.service.Get(c.projectID, instance, id).Context(ctx).Do()
+}
+
+// List all backup runs of a given instance
+func (c *BackupRunClient) List(ctx context.Context, instance string) ([]*sqladmin.BackupRun, error) {
+	runs := make([]*sqladmin.BackupRun, 0)
+	err := c.service.List(c.projectID, instance).Pages(ctx, func(rsp *sqladmin.BackupRunsListResponse) error {
+		runs = append(runs, rsp.Items...)
+		return nil
+	})
+	return runs, err
+}
+
+// Insert starts a new on-demand backup run of a given instance
+func (c *BackupRunClient) Insert(ctx context.Context, instance string, run *sqladmin.BackupRun) error {
+	_, err := c.service.Insert(c.projectID, instance, run).Context(ctx).Do()
+	return err
+}
+
+// Delete the backup run with the provided ID from a given instance
+func (c *BackupRunClient) Delete(ctx context.Context, instance string, id int64) error {
+	_, err := c.service.Delete(c.projectID, instance, id).Context(ctx).Do()
+	return err
+}
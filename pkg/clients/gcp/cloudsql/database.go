@@ -0,0 +1,78 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudsql
+
+import (
+	"context"
+	"net/http"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/option"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+)
+
+// DatabaseService provides an interface for operations on cloudsql databases
+type DatabaseService interface {
+	Get(context.Context, string, string) (*sqladmin.Database, error)
+	Create(context.Context, string, *sqladmin.Database) error
+	Delete(context.Context, string, string) error
+}
+
+// DatabaseClient implements DatabaseService interface
+type DatabaseClient struct {
+	service   *sqladmin.DatabasesService
+	projectID string
+}
+
+// Interface validation
+var _ DatabaseService = &DatabaseClient{}
+
+// NewDatabaseClient creates new instance of DatabaseClient. Requests are
+// made using the supplied HTTP client, which must be authorized by the supplied
+// credentials, or using a new HTTP client authorized by them if it is nil.
+func NewDatabaseClient(ctx context.Context, creds *google.Credentials, hc *http.Client) (*DatabaseClient, error) {
+	if hc == nil {
+		hc = oauth2.NewClient(ctx, creds.TokenSource)
+	}
+	service, err := sqladmin.NewService(ctx, option.WithHTTPClient(hc))
+	if err != nil {
+		return nil, err
+	}
+
+	return &DatabaseClient{
+		service:   service.Databases,
+		projectID: creds.ProjectID,
+	}, nil
+}
+
+// Get the database with the provided name from a given instance
+func (c *DatabaseClient) Get(ctx context.Context, instance, database string) (*sqladmin.Database, error) {
+	return c.service.Get(c.projectID, instance, database).Context(ctx).Do()
+}
+
+// Create new database in a given instance with provided database definition
+func (c *DatabaseClient) Create(ctx context.Context, instance string, database *sqladmin.Database) error {
+	_, err := c.service.Insert(c.projectID, instance, database).Context(ctx).Do()
+	return err
+}
+
+// Delete existing database with matching name from a given instance
+func (c *DatabaseClient) Delete(ctx context.Context, instance, database string) error {
+	_, err := c.service.Delete(c.projectID, instance, database).Context(ctx).Do()
+	return err
+}
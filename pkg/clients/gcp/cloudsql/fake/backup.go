@@ -0,0 +1,55 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+
+	"github.com/crossplaneio/crossplane/pkg/clients/gcp/cloudsql"
+)
+
+// MockBackupRunClient for testing purposes
+type MockBackupRunClient struct {
+	MockGet    func(context.Context, string, int64) (*sqladmin.BackupRun, error)
+	MockList   func(context.Context, string) ([]*sqladmin.BackupRun, error)
+	MockInsert func(context.Context, string, *sqladmin.BackupRun) error
+	MockDelete func(context.Context, string, int64) error
+}
+
+var _ cloudsql.BackupRunService = &MockBackupRunClient{}
+
+// Get the backup run with the provided ID from a given instance
+func (c *MockBackupRunClient) Get(ctx context.Context, instance string, id int64) (*sqladmin.BackupRun, error) {
+	return c.MockGet(ctx, instance, id)
+}
+
+// List all backup runs of a given instance
+func (c *MockBackupRunClient) List(ctx context.Context, instance string) ([]*sqladmin.BackupRun, error) {
+	return c.MockList(ctx, instance)
+}
+
+// Insert starts a new on-demand backup run of a given instance
+func (c *MockBackupRunClient) Insert(ctx context.Context, instance string, run *sqladmin.BackupRun) error {
+	return c.MockInsert(ctx, instance, run)
+}
+
+// Delete the backup run with the provided ID from a given instance
+func (c *MockBackupRunClient) Delete(ctx context.Context, instance string, id int64) error {
+	return c.MockDelete(ctx, instance, id)
+}
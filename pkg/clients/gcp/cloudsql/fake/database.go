@@ -0,0 +1,50 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"github.com/crossplaneio/crossplane/pkg/clients/gcp/cloudsql"
+
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+)
+
+// MockDatabaseClient implements DatabaseService interface
+type MockDatabaseClient struct {
+	MockGet    func(context.Context, string, string) (*sqladmin.Database, error)
+	MockCreate func(context.Context, string, *sqladmin.Database) error
+	MockDelete func(context.Context, string, string) error
+}
+
+// Interface validation
+var _ cloudsql.DatabaseService = &MockDatabaseClient{}
+
+// Get the database with the provided name from a given instance
+func (c *MockDatabaseClient) Get(ctx context.Context, instance, database string) (*sqladmin.Database, error) {
+	return c.MockGet(ctx, instance, database)
+}
+
+// Create new database in a given instance with provided database definition
+func (c *MockDatabaseClient) Create(ctx context.Context, instance string, database *sqladmin.Database) error {
+	return c.MockCreate(ctx, instance, database)
+}
+
+// Delete existing database with matching name from a given instance
+func (c *MockDatabaseClient) Delete(ctx context.Context, instance, database string) error {
+	return c.MockDelete(ctx, instance, database)
+}
@@ -30,6 +30,10 @@ type MockInstanceClient struct {
 	MockCreate func(context.Context, *sqladmin.DatabaseInstance) error
 	MockUpdate func(context.Context, string, *sqladmin.DatabaseInstance) error
 	MockDelete func(context.Context, string) error
+
+	MockRestoreBackup func(context.Context, string, *sqladmin.RestoreBackupContext) error
+	MockExport        func(context.Context, string, *sqladmin.ExportContext) (string, error)
+	MockGetOperation  func(context.Context, string) (*sqladmin.Operation, error)
 }
 
 var _ cloudsql.InstanceService = &MockInstanceClient{}
@@ -53,3 +57,18 @@ func (c *MockInstanceClient) Update(ctx context.Context, name string, instance *
 func (c *MockInstanceClient) Delete(ctx context.Context, name string) error {
 	return c.MockDelete(ctx, name)
 }
+
+// RestoreBackup restores a backup run to the cloudsql instance with matching name
+func (c *MockInstanceClient) RestoreBackup(ctx context.Context, name string, backup *sqladmin.RestoreBackupContext) error {
+	return c.MockRestoreBackup(ctx, name, backup)
+}
+
+// Export the data of the cloudsql instance with matching name to Cloud Storage
+func (c *MockInstanceClient) Export(ctx context.Context, name string, export *sqladmin.ExportContext) (string, error) {
+	return c.MockExport(ctx, name, export)
+}
+
+// GetOperation returns the cloudsql operation with matching name
+func (c *MockInstanceClient) GetOperation(ctx context.Context, name string) (*sqladmin.Operation, error) {
+	return c.MockGetOperation(ctx, name)
+}
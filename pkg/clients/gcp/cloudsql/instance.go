@@ -18,6 +18,7 @@ package cloudsql
 
 import (
 	"context"
+	"net/http"
 
 	"google.golang.org/api/option"
 
@@ -26,8 +27,13 @@ import (
 	sqladmin "google.golang.org/api/sqladmin/v1beta4"
 )
 
-// DefaultScope for sqladmin client
-const DefaultScope = sqladmin.SqlserviceAdminScope
+const (
+	// DefaultScope for sqladmin client
+	DefaultScope = sqladmin.SqlserviceAdminScope
+
+	// API is the name of the GCP API used by CloudSQL clients.
+	API = "sqladmin.googleapis.com"
+)
 
 // InstanceService provides an interface for operations on CloudSQL instances
 type InstanceService interface {
@@ -35,27 +41,37 @@ type InstanceService interface {
 	Create(context.Context, *sqladmin.DatabaseInstance) error
 	Update(context.Context, string, *sqladmin.DatabaseInstance) error
 	Delete(context.Context, string) error
+	RestoreBackup(context.Context, string, *sqladmin.RestoreBackupContext) error
+	Export(context.Context, string, *sqladmin.ExportContext) (string, error)
+	GetOperation(context.Context, string) (*sqladmin.Operation, error)
 }
 
 // InstanceClient implements InstanceService interface
 type InstanceClient struct {
-	service   *sqladmin.InstancesService
-	projectID string
+	service    *sqladmin.InstancesService
+	operations *sqladmin.OperationsService
+	projectID  string
 }
 
 // Interface validation
 var _ InstanceService = &InstanceClient{}
 
-// NewInstanceClient creates a new instance of an InstanceClient
-func NewInstanceClient(ctx context.Context, creds *google.Credentials) (*InstanceClient, error) {
-	service, err := sqladmin.NewService(ctx, option.WithHTTPClient(oauth2.NewClient(ctx, creds.TokenSource)))
+// NewInstanceClient creates a new instance of an InstanceClient. Requests are
+// made using the supplied HTTP client, which must be authorized by the supplied
+// credentials, or using a new HTTP client authorized by them if it is nil.
+func NewInstanceClient(ctx context.Context, creds *google.Credentials, hc *http.Client) (*InstanceClient, error) {
+	if hc == nil {
+		hc = oauth2.NewClient(ctx, creds.TokenSource)
+	}
+	service, err := sqladmin.NewService(ctx, option.WithHTTPClient(hc))
 	if err != nil {
 		return nil, err
 	}
 
 	return &InstanceClient{
-		service:   service.Instances,
-		projectID: creds.ProjectID,
+		service:    service.Instances,
+		operations: service.Operations,
+		projectID:  creds.ProjectID,
 	}, nil
 }
 
@@ -84,3 +100,27 @@ func (c *InstanceClient) Delete(ctx context.Context, name string) error {
 	_, err := c.service.Delete(c.projectID, name).Context(ctx).Do()
 	return err
 }
+
+// RestoreBackup restores a backup run to the cloudsql instance with matching
+// name, overwriting its data
+func (c *InstanceClient) RestoreBackup(ctx context.Context, name string, backup *sqladmin.RestoreBackupContext) error {
+	rq := &sqladmin.InstancesRestoreBackupRequest{RestoreBackupContext: backup}
+	_, err := c.service.RestoreBackup(c.projectID, name, rq).Context(ctx).Do()
+	return err
+}
+
+// Export the data of the cloudsql instance with matching name to Cloud
+// Storage, returning the name of the export operation
+func (c *InstanceClient) Export(ctx context.Context, name string, export *sqladmin.ExportContext) (string, error) {
+	rq := &sqladmin.InstancesExportRequest{ExportContext: export}
+	op, err := c.service.Export(c.projectID, name, rq).Context(ctx).Do()
+	if err != nil {
+		return "", err
+	}
+	return op.Name, nil
+}
+
+// GetOperation returns the cloudsql operation with matching name
+func (c *InstanceClient) GetOperation(ctx context.Context, name string) (*sqladmin.Operation, error) {
+	return c.operations.Get(c.projectID, name).Context(ctx).Do()
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sim
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+
+	"github.com/crossplaneio/crossplane/pkg/clients/gcp/cloudsql"
+	"github.com/crossplaneio/crossplane/pkg/clients/sim"
+)
+
+// A BackupRunService is the simulated backup run service of a Project.
+type BackupRunService struct {
+	p *Project
+}
+
+var _ cloudsql.BackupRunService = &BackupRunService{}
+
+// Get returns the supplied backup run of the named instance.
+func (s *BackupRunService) Get(_ context.Context, instance string, id int64) (*sqladmin.BackupRun, error) {
+	if err := s.p.Call("BackupRuns.Get"); err != nil {
+		return nil, err
+	}
+
+	s.p.mu.Lock()
+	defer s.p.mu.Unlock()
+
+	r, ok := s.p.backupRuns[instance][id]
+	if !ok {
+		return nil, notFound("backup run %d", id)
+	}
+	r.lifecycle.Observe()
+	return viewRun(instance, id, r), nil
+}
+
+// List returns the backup runs of the named instance, newest first.
+func (s *BackupRunService) List(_ context.Context, instance string) ([]*sqladmin.BackupRun, error) {
+	if err := s.p.Call("BackupRuns.List"); err != nil {
+		return nil, err
+	}
+
+	s.p.mu.Lock()
+	defer s.p.mu.Unlock()
+
+	if _, err := s.p.instance(instance); err != nil {
+		return nil, err
+	}
+	runs := make([]*sqladmin.BackupRun, 0, len(s.p.backupRuns[instance]))
+	for id, r := range s.p.backupRuns[instance] {
+		r.lifecycle.Observe()
+		runs = append(runs, viewRun(instance, id, r))
+	}
+	sort.Slice(runs, func(a, b int) bool { return runs[a].Id > runs[b].Id })
+	return runs, nil
+}
+
+// Insert starts an on-demand backup run of the named instance. The run
+// captures the instance's users and databases as they are when it starts.
+func (s *BackupRunService) Insert(_ context.Context, instance string, run *sqladmin.BackupRun) error {
+	if err := s.p.Call("BackupRuns.Insert"); err != nil {
+		return err
+	}
+
+	s.p.mu.Lock()
+	defer s.p.mu.Unlock()
+
+	i, err := s.p.instance(instance)
+	if err != nil {
+		return err
+	}
+	if i.lifecycle.State() != stateRunnable {
+		return notRunnable(instance)
+	}
+	if s.p.backupRuns[instance] == nil {
+		s.p.backupRuns[instance] = map[int64]*backupRun{}
+	}
+	s.p.runs++
+	s.p.backupRuns[instance][s.p.runs] = &backupRun{
+		lifecycle:   sim.NewLifecycle(stateRunning).Then(stateSuccessful, s.p.Polls),
+		description: run.Description,
+		created:     time.Now().UTC().Format(time.RFC3339),
+		users:       copyUsers(i.users, instance),
+		databases:   copyDatabases(i.databases, instance),
+	}
+	return nil
+}
+
+// Delete deletes the supplied backup run of the named instance.
+func (s *BackupRunService) Delete(_ context.Context, instance string, id int64) error {
+	if err := s.p.Call("BackupRuns.Delete"); err != nil {
+		return err
+	}
+
+	s.p.mu.Lock()
+	defer s.p.mu.Unlock()
+
+	if _, ok := s.p.backupRuns[instance][id]; !ok {
+		return notFound("backup run %d", id)
+	}
+	delete(s.p.backupRuns[instance], id)
+	return nil
+}
+
+func viewRun(instance string, id int64, r *backupRun) *sqladmin.BackupRun {
+	out := &sqladmin.BackupRun{
+		Id:          id,
+		Instance:    instance,
+		Description: r.description,
+		Status:      r.lifecycle.State(),
+		Type:        "ON_DEMAND",
+		StartTime:   r.created,
+	}
+	if out.Status == stateSuccessful {
+		out.EndTime = r.created
+	}
+	return out
+}
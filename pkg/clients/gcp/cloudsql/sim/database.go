@@ -0,0 +1,109 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sim
+
+import (
+	"context"
+
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+
+	"github.com/crossplaneio/crossplane/pkg/clients/gcp/cloudsql"
+)
+
+// A DatabaseService is the simulated database service of a Project.
+type DatabaseService struct {
+	p *Project
+}
+
+var _ cloudsql.DatabaseService = &DatabaseService{}
+
+// Get returns the named database of the named instance.
+func (s *DatabaseService) Get(_ context.Context, instance, name string) (*sqladmin.Database, error) {
+	if err := s.p.Call("Databases.Get"); err != nil {
+		return nil, err
+	}
+
+	s.p.mu.Lock()
+	defer s.p.mu.Unlock()
+
+	i, err := s.p.instance(instance)
+	if err != nil {
+		return nil, err
+	}
+	d, ok := i.databases[name]
+	if !ok {
+		return nil, notFound("database %s", name)
+	}
+	out := *d
+	return &out, nil
+}
+
+// Create creates the supplied database in the named instance.
+func (s *DatabaseService) Create(_ context.Context, instance string, database *sqladmin.Database) error {
+	if err := s.p.Call("Databases.Create"); err != nil {
+		return err
+	}
+
+	s.p.mu.Lock()
+	defer s.p.mu.Unlock()
+
+	i, err := s.p.instance(instance)
+	if err != nil {
+		return err
+	}
+	if i.lifecycle.State() != stateRunnable {
+		return notRunnable(instance)
+	}
+	if _, ok := i.databases[database.Name]; ok {
+		return conflict("The database %s already exists.", database.Name)
+	}
+	d := *database
+	d.Instance = instance
+	d.Project = ProjectID
+	i.databases[database.Name] = &d
+	return nil
+}
+
+// Delete deletes the named database of the named instance.
+func (s *DatabaseService) Delete(_ context.Context, instance, name string) error {
+	if err := s.p.Call("Databases.Delete"); err != nil {
+		return err
+	}
+
+	s.p.mu.Lock()
+	defer s.p.mu.Unlock()
+
+	i, err := s.p.instance(instance)
+	if err != nil {
+		return err
+	}
+	if _, ok := i.databases[name]; !ok {
+		return notFound("database %s", name)
+	}
+	delete(i.databases, name)
+	return nil
+}
+
+func copyDatabases(databases map[string]*sqladmin.Database, instance string) map[string]*sqladmin.Database {
+	out := make(map[string]*sqladmin.Database, len(databases))
+	for name, d := range databases {
+		c := *d
+		c.Instance = instance
+		out[name] = &c
+	}
+	return out
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sim
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+
+	"github.com/crossplaneio/crossplane/pkg/clients/gcp/cloudsql"
+	"github.com/crossplaneio/crossplane/pkg/clients/sim"
+)
+
+// An InstanceService is the simulated instance service of a Project.
+type InstanceService struct {
+	p *Project
+}
+
+var _ cloudsql.InstanceService = &InstanceService{}
+
+// Get returns the named instance.
+func (s *InstanceService) Get(_ context.Context, name string) (*sqladmin.DatabaseInstance, error) {
+	if err := s.p.Call("Instances.Get"); err != nil {
+		return nil, err
+	}
+
+	s.p.mu.Lock()
+	defer s.p.mu.Unlock()
+
+	i, err := s.p.instance(name)
+	if err != nil {
+		return nil, err
+	}
+	i.lifecycle.Observe()
+	return view(name, i), nil
+}
+
+// Create starts creating the supplied instance, which has a root user with
+// no password.
+func (s *InstanceService) Create(_ context.Context, in *sqladmin.DatabaseInstance) error {
+	if err := s.p.Call("Instances.Create"); err != nil {
+		return err
+	}
+
+	s.p.mu.Lock()
+	defer s.p.mu.Unlock()
+
+	if _, ok := s.p.instances[in.Name]; ok {
+		return conflict("The Cloud SQL instance %s already exists.", in.Name)
+	}
+	s.p.count++
+	s.p.instances[in.Name] = &instance{
+		lifecycle: sim.NewLifecycle(statePendingCreate).Then(stateRunnable, s.p.Polls),
+		spec:      *in,
+		index:     s.p.count,
+		users:     map[string]*sqladmin.User{"root": {Name: "root", Host: "%", Instance: in.Name}},
+		databases: map[string]*sqladmin.Database{},
+	}
+	return nil
+}
+
+// Update replaces the settings of the named instance with those supplied.
+func (s *InstanceService) Update(_ context.Context, name string, in *sqladmin.DatabaseInstance) error {
+	if err := s.p.Call("Instances.Update"); err != nil {
+		return err
+	}
+
+	s.p.mu.Lock()
+	defer s.p.mu.Unlock()
+
+	i, err := s.p.instance(name)
+	if err != nil {
+		return err
+	}
+	if i.lifecycle.State() != stateRunnable {
+		return notRunnable(name)
+	}
+	if in.Settings != nil {
+		version := int64(1)
+		if i.spec.Settings != nil {
+			version = i.spec.Settings.SettingsVersion + 1
+		}
+		settings := *in.Settings
+		settings.SettingsVersion = version
+		i.spec.Settings = &settings
+	}
+	return nil
+}
+
+// Delete deletes the named instance, along with its users, databases, and
+// backup runs. Instances that are being exported cannot be deleted.
+func (s *InstanceService) Delete(_ context.Context, name string) error {
+	if err := s.p.Call("Instances.Delete"); err != nil {
+		return err
+	}
+
+	s.p.mu.Lock()
+	defer s.p.mu.Unlock()
+
+	if _, err := s.p.instance(name); err != nil {
+		return err
+	}
+	if s.p.exporting(name) {
+		return conflict("Operation failed because another operation was already in progress.")
+	}
+	delete(s.p.instances, name)
+	delete(s.p.backupRuns, name)
+	return nil
+}
+
+// RestoreBackup overwrites the users and databases of the named instance with
+// those of the supplied backup run. The instance is under maintenance until it
+// has been observed Polls times.
+func (s *InstanceService) RestoreBackup(_ context.Context, name string, rc *sqladmin.RestoreBackupContext) error {
+	if err := s.p.Call("Instances.RestoreBackup"); err != nil {
+		return err
+	}
+
+	s.p.mu.Lock()
+	defer s.p.mu.Unlock()
+
+	i, err := s.p.instance(name)
+	if err != nil {
+		return err
+	}
+	if i.lifecycle.State() != stateRunnable {
+		return notRunnable(name)
+	}
+	r, ok := s.p.backupRuns[rc.InstanceId][rc.BackupRunId]
+	if !ok {
+		return notFound("backup run %d of instance %s", rc.BackupRunId, rc.InstanceId)
+	}
+	if r.lifecycle.State() != stateSuccessful {
+		return conflict("The backup run %d is not successful.", rc.BackupRunId)
+	}
+	i.users = copyUsers(r.users, name)
+	i.databases = copyDatabases(r.databases, name)
+	i.lifecycle.Set(stateMaintenance).Then(stateRunnable, s.p.Polls)
+	return nil
+}
+
+// Export starts exporting the named instance, which must be runnable, to the
+// supplied URI. Exports of PostgreSQL instances must name exactly one
+// database.
+func (s *InstanceService) Export(_ context.Context, name string, ec *sqladmin.ExportContext) (string, error) {
+	if err := s.p.Call("Instances.Export"); err != nil {
+		return "", err
+	}
+
+	s.p.mu.Lock()
+	defer s.p.mu.Unlock()
+
+	i, err := s.p.instance(name)
+	if err != nil {
+		return "", err
+	}
+	if i.lifecycle.State() != stateRunnable {
+		return "", notRunnable(name)
+	}
+	if strings.HasPrefix(i.spec.DatabaseVersion, "POSTGRES") && len(ec.Databases) != 1 {
+		return "", badRequest("Exports of PostgreSQL instances must name exactly one database.")
+	}
+	s.p.ops++
+	op := fmt.Sprintf("export-%d", s.p.ops)
+	s.p.operations[op] = &operation{
+		lifecycle: sim.NewLifecycle(stateRunning).Then(stateDone, s.p.Polls),
+		instance:  name,
+		export:    *ec,
+	}
+	return op, nil
+}
+
+// GetOperation returns the named operation.
+func (s *InstanceService) GetOperation(_ context.Context, name string) (*sqladmin.Operation, error) {
+	if err := s.p.Call("Instances.GetOperation"); err != nil {
+		return nil, err
+	}
+
+	s.p.mu.Lock()
+	defer s.p.mu.Unlock()
+
+	o, ok := s.p.operations[name]
+	if !ok {
+		return nil, notFound("operation %s", name)
+	}
+	o.lifecycle.Observe()
+	ec := o.export
+	return &sqladmin.Operation{
+		Name:          name,
+		OperationType: "EXPORT",
+		Status:        o.lifecycle.State(),
+		TargetId:      o.instance,
+		TargetProject: ProjectID,
+		ExportContext: &ec,
+	}, nil
+}
+
+func view(name string, i *instance) *sqladmin.DatabaseInstance {
+	out := i.spec
+	out.Name = name
+	out.Project = ProjectID
+	out.State = i.lifecycle.State()
+	out.ConnectionName = fmt.Sprintf("%s:%s:%s", ProjectID, i.spec.Region, name)
+	out.IpAddresses = nil
+	if out.State == statePendingCreate {
+		return &out
+	}
+
+	var ipc *sqladmin.IpConfiguration
+	if i.spec.Settings != nil {
+		ipc = i.spec.Settings.IpConfiguration
+	}
+	if ipc == nil || ipc.Ipv4Enabled || !forceSent(ipc.ForceSendFields, "Ipv4Enabled") {
+		out.IpAddresses = append(out.IpAddresses, &sqladmin.IpMapping{Type: ipAddressTypePrimary, IpAddress: fmt.Sprintf("203.0.113.%d", i.index)})
+	}
+	if ipc != nil && ipc.PrivateNetwork != "" {
+		out.IpAddresses = append(out.IpAddresses, &sqladmin.IpMapping{Type: ipAddressTypePrivate, IpAddress: fmt.Sprintf("10.0.0.%d", i.index)})
+	}
+	return &out
+}
+
+// forceSent returns true if the named field is one of the supplied force send
+// fields, i.e. if it was explicitly set to its zero value.
+func forceSent(fields []string, name string) bool {
+	for _, f := range fields {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
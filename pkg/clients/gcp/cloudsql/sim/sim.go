@@ -0,0 +1,223 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sim provides a simulated CloudSQL API.
+package sim
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"google.golang.org/api/googleapi"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+
+	"github.com/crossplaneio/crossplane/pkg/clients/sim"
+)
+
+// Instance and backup run states.
+const (
+	statePendingCreate = "PENDING_CREATE"
+	stateRunnable      = "RUNNABLE"
+	stateMaintenance   = "MAINTENANCE"
+	stateRunning       = "RUNNING"
+	stateSuccessful    = "SUCCESSFUL"
+	stateDone          = "DONE"
+)
+
+// IP address types.
+const (
+	ipAddressTypePrimary = "PRIMARY"
+	ipAddressTypePrivate = "PRIVATE"
+)
+
+// ProjectID is the project in which the simulated API creates instances.
+const ProjectID = "sim-project"
+
+type instance struct {
+	lifecycle *sim.Lifecycle
+	spec      sqladmin.DatabaseInstance
+	index     int
+	users     map[string]*sqladmin.User
+	databases map[string]*sqladmin.Database
+}
+
+type backupRun struct {
+	lifecycle   *sim.Lifecycle
+	description string
+	created     string
+	users       map[string]*sqladmin.User
+	databases   map[string]*sqladmin.Database
+}
+
+type operation struct {
+	lifecycle *sim.Lifecycle
+	instance  string
+	export    sqladmin.ExportContext
+}
+
+// A Project is a simulated CloudSQL API. It serves the instance, user,
+// database, and backup run services of a single project, which share its
+// state. Instances are created in the PENDING_CREATE state and become
+// RUNNABLE after they have been observed Polls times; backup runs and export
+// operations are RUNNING until they have been observed Polls times. Errors may be injected into any
+// method of any service, keyed by the service and method names, for example
+// "Instances.Get" or "Users.Update".
+type Project struct {
+	sim.Failures
+
+	// Polls is the number of times an instance, backup run, or operation in a
+	// transitional state must be observed before it settles.
+	Polls int
+
+	mu         sync.Mutex
+	instances  map[string]*instance
+	backupRuns map[string]map[int64]*backupRun
+	operations map[string]*operation
+	count      int
+	runs       int64
+	ops        int
+}
+
+// NewProject returns a simulated CloudSQL API with no instances.
+func NewProject() *Project {
+	return &Project{
+		Polls:      sim.DefaultPolls,
+		instances:  map[string]*instance{},
+		backupRuns: map[string]map[int64]*backupRun{},
+		operations: map[string]*operation{},
+	}
+}
+
+// Instances returns the instance service of the project.
+func (p *Project) Instances() *InstanceService {
+	return &InstanceService{p: p}
+}
+
+// Users returns the user service of the project.
+func (p *Project) Users() *UserService {
+	return &UserService{p: p}
+}
+
+// Databases returns the database service of the project.
+func (p *Project) Databases() *DatabaseService {
+	return &DatabaseService{p: p}
+}
+
+// BackupRuns returns the backup run service of the project.
+func (p *Project) BackupRuns() *BackupRunService {
+	return &BackupRunService{p: p}
+}
+
+// Settle completes all pending instance, backup run, and operation
+// transitions immediately.
+func (p *Project) Settle() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, i := range p.instances {
+		i.lifecycle.Settle()
+	}
+	for _, runs := range p.backupRuns {
+		for _, r := range runs {
+			r.lifecycle.Settle()
+		}
+	}
+	for _, o := range p.operations {
+		o.lifecycle.Settle()
+	}
+}
+
+// InstanceNames returns the names of all instances that exist, in order.
+func (p *Project) InstanceNames() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	names := make([]string, 0, len(p.instances))
+	for name := range p.instances {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Exports returns the URIs of all completed exports, in order.
+func (p *Project) Exports() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	uris := make([]string, 0, len(p.operations))
+	for _, o := range p.operations {
+		if o.lifecycle.State() == stateDone {
+			uris = append(uris, o.export.Uri)
+		}
+	}
+	sort.Strings(uris)
+	return uris
+}
+
+// Password returns the password of the named user of the named instance.
+func (p *Project) Password(instance, user string) (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	i, ok := p.instances[instance]
+	if !ok {
+		return "", false
+	}
+	u, ok := i.users[user]
+	if !ok {
+		return "", false
+	}
+	return u.Password, true
+}
+
+// instance returns the named instance. It must be called with the lock held.
+func (p *Project) instance(name string) (*instance, error) {
+	i, ok := p.instances[name]
+	if !ok {
+		return nil, notFound("instance %s", name)
+	}
+	return i, nil
+}
+
+func notFound(format string, a ...interface{}) error {
+	return &googleapi.Error{Code: http.StatusNotFound, Message: fmt.Sprintf("The "+format+" does not exist.", a...)}
+}
+
+// exporting returns true if an export of the named instance is running. It
+// must be called with the lock held.
+func (p *Project) exporting(name string) bool {
+	for _, o := range p.operations {
+		if o.instance == name && o.lifecycle.State() != stateDone {
+			return true
+		}
+	}
+	return false
+}
+
+func badRequest(format string, a ...interface{}) error {
+	return &googleapi.Error{Code: http.StatusBadRequest, Message: fmt.Sprintf(format, a...)}
+}
+
+func conflict(format string, a ...interface{}) error {
+	return &googleapi.Error{Code: http.StatusConflict, Message: fmt.Sprintf(format, a...)}
+}
+
+func notRunnable(name string) error {
+	return conflict("The instance %s is not in an appropriate state to handle the request.", name)
+}
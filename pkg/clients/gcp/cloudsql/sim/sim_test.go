@@ -0,0 +1,160 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sim
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+
+	"github.com/crossplaneio/crossplane/pkg/util/googleapi"
+)
+
+const instanceName = "cool-instance"
+
+func TestInstanceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	p := NewProject()
+	instances := p.Instances()
+
+	if err := instances.Create(ctx, &sqladmin.DatabaseInstance{Name: instanceName, Region: "us-central1"}); err != nil {
+		t.Fatalf("instances.Create(...): %s", err)
+	}
+	if err := instances.Create(ctx, &sqladmin.DatabaseInstance{Name: instanceName}); err == nil {
+		t.Errorf("instances.Create(...): want error creating an instance that exists, got nil")
+	}
+
+	want := []string{statePendingCreate, stateRunnable}
+	got := []string{}
+	var inst *sqladmin.DatabaseInstance
+	for i := 0; i < p.Polls; i++ {
+		var err error
+		inst, err = instances.Get(ctx, instanceName)
+		if err != nil {
+			t.Fatalf("instances.Get(...): %s", err)
+		}
+		got = append(got, inst.State)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("instances.Get(...): -want states, +got states:\n%s", diff)
+	}
+	if len(inst.IpAddresses) != 1 || inst.IpAddresses[0].Type != ipAddressTypePrimary {
+		t.Errorf("instances.Get(...): want a primary IP address, got %v", inst.IpAddresses)
+	}
+
+	if err := instances.Delete(ctx, instanceName); err != nil {
+		t.Fatalf("instances.Delete(...): %s", err)
+	}
+	if _, err := instances.Get(ctx, instanceName); !googleapi.IsErrorNotFound(err) {
+		t.Errorf("instances.Get(...): want not found error after deletion, got %v", err)
+	}
+	if err := instances.Delete(ctx, instanceName); !googleapi.IsErrorNotFound(err) {
+		t.Errorf("instances.Delete(...): want not found error deleting a missing instance, got %v", err)
+	}
+}
+
+func TestRestoreBackup(t *testing.T) {
+	ctx := context.Background()
+	p := NewProject()
+
+	if err := p.Instances().Create(ctx, &sqladmin.DatabaseInstance{Name: instanceName}); err != nil {
+		t.Fatalf("instances.Create(...): %s", err)
+	}
+	p.Settle()
+	if err := p.Users().Update(ctx, instanceName, "root", &sqladmin.User{Password: "old"}); err != nil {
+		t.Fatalf("users.Update(...): %s", err)
+	}
+	if err := p.BackupRuns().Insert(ctx, instanceName, &sqladmin.BackupRun{Description: "cool-backup"}); err != nil {
+		t.Fatalf("backupRuns.Insert(...): %s", err)
+	}
+	p.Settle()
+
+	runs, err := p.BackupRuns().List(ctx, instanceName)
+	if err != nil {
+		t.Fatalf("backupRuns.List(...): %s", err)
+	}
+	if len(runs) != 1 || runs[0].Status != stateSuccessful || runs[0].Description != "cool-backup" {
+		t.Fatalf("backupRuns.List(...): want one successful run, got %v", runs)
+	}
+
+	if err := p.Users().Update(ctx, instanceName, "root", &sqladmin.User{Password: "new"}); err != nil {
+		t.Fatalf("users.Update(...): %s", err)
+	}
+	rc := &sqladmin.RestoreBackupContext{InstanceId: instanceName, BackupRunId: runs[0].Id}
+	if err := p.Instances().RestoreBackup(ctx, instanceName, rc); err != nil {
+		t.Fatalf("instances.RestoreBackup(...): %s", err)
+	}
+	inst, err := p.Instances().Get(ctx, instanceName)
+	if err != nil {
+		t.Fatalf("instances.Get(...): %s", err)
+	}
+	if diff := cmp.Diff(stateMaintenance, inst.State); diff != "" {
+		t.Errorf("instances.Get(...): restoring instance: -want state, +got state:\n%s", diff)
+	}
+
+	password, _ := p.Password(instanceName, "root")
+	if diff := cmp.Diff("old", password); diff != "" {
+		t.Errorf("p.Password(...): restoring should overwrite users: -want, +got:\n%s", diff)
+	}
+}
+
+func TestExport(t *testing.T) {
+	ctx := context.Background()
+	p := NewProject()
+	instances := p.Instances()
+
+	if err := instances.Create(ctx, &sqladmin.DatabaseInstance{Name: instanceName, DatabaseVersion: "POSTGRES_9_6"}); err != nil {
+		t.Fatalf("instances.Create(...): %s", err)
+	}
+	if _, err := instances.Export(ctx, instanceName, &sqladmin.ExportContext{Uri: "gs://cool-bucket/cool.sql"}); err == nil {
+		t.Errorf("instances.Export(...): want error exporting an instance that is not runnable, got nil")
+	}
+	p.Settle()
+	if _, err := instances.Export(ctx, instanceName, &sqladmin.ExportContext{Uri: "gs://cool-bucket/cool.sql"}); err == nil {
+		t.Errorf("instances.Export(...): want error exporting a PostgreSQL instance without a database, got nil")
+	}
+
+	op, err := instances.Export(ctx, instanceName, &sqladmin.ExportContext{Uri: "gs://cool-bucket/cool.sql", Databases: []string{"cool"}})
+	if err != nil {
+		t.Fatalf("instances.Export(...): %s", err)
+	}
+	if err := instances.Delete(ctx, instanceName); err == nil {
+		t.Errorf("instances.Delete(...): want error deleting an instance that is being exported, got nil")
+	}
+
+	want := []string{stateRunning, stateDone}
+	got := []string{}
+	for i := 0; i < p.Polls; i++ {
+		o, err := instances.GetOperation(ctx, op)
+		if err != nil {
+			t.Fatalf("instances.GetOperation(...): %s", err)
+		}
+		got = append(got, o.Status)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("instances.GetOperation(...): -want states, +got states:\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"gs://cool-bucket/cool.sql"}, p.Exports()); diff != "" {
+		t.Errorf("p.Exports(): -want, +got:\n%s", diff)
+	}
+
+	if err := instances.Delete(ctx, instanceName); err != nil {
+		t.Errorf("instances.Delete(...): %s", err)
+	}
+}
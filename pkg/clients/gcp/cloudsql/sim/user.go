@@ -0,0 +1,133 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sim
+
+import (
+	"context"
+	"sort"
+
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+
+	"github.com/crossplaneio/crossplane/pkg/clients/gcp/cloudsql"
+)
+
+// A UserService is the simulated user service of a Project.
+type UserService struct {
+	p *Project
+}
+
+var _ cloudsql.UserService = &UserService{}
+
+// List returns the users of the named instance, ordered by name. Passwords are
+// never returned.
+func (s *UserService) List(_ context.Context, instance string) ([]*sqladmin.User, error) {
+	if err := s.p.Call("Users.List"); err != nil {
+		return nil, err
+	}
+
+	s.p.mu.Lock()
+	defer s.p.mu.Unlock()
+
+	i, err := s.p.instance(instance)
+	if err != nil {
+		return nil, err
+	}
+	users := make([]*sqladmin.User, 0, len(i.users))
+	for _, u := range i.users {
+		out := *u
+		out.Password = ""
+		users = append(users, &out)
+	}
+	sort.Slice(users, func(a, b int) bool { return users[a].Name < users[b].Name })
+	return users, nil
+}
+
+// Create creates the supplied user of the named instance.
+func (s *UserService) Create(_ context.Context, instance string, user *sqladmin.User) error {
+	if err := s.p.Call("Users.Create"); err != nil {
+		return err
+	}
+
+	s.p.mu.Lock()
+	defer s.p.mu.Unlock()
+
+	i, err := s.p.instance(instance)
+	if err != nil {
+		return err
+	}
+	if _, ok := i.users[user.Name]; ok {
+		return conflict("The user %s already exists.", user.Name)
+	}
+	u := *user
+	u.Instance = instance
+	i.users[user.Name] = &u
+	return nil
+}
+
+// Update replaces the named user of the named instance with the supplied user,
+// for example to change its password.
+func (s *UserService) Update(_ context.Context, instance, name string, user *sqladmin.User) error {
+	if err := s.p.Call("Users.Update"); err != nil {
+		return err
+	}
+
+	s.p.mu.Lock()
+	defer s.p.mu.Unlock()
+
+	i, err := s.p.instance(instance)
+	if err != nil {
+		return err
+	}
+	if _, ok := i.users[name]; !ok {
+		return notFound("user %s", name)
+	}
+	u := *user
+	u.Name = name
+	u.Instance = instance
+	i.users[name] = &u
+	return nil
+}
+
+// Delete deletes the named user of the named instance, regardless of host.
+func (s *UserService) Delete(_ context.Context, instance, _, name string) error {
+	if err := s.p.Call("Users.Delete"); err != nil {
+		return err
+	}
+
+	s.p.mu.Lock()
+	defer s.p.mu.Unlock()
+
+	i, err := s.p.instance(instance)
+	if err != nil {
+		return err
+	}
+	if _, ok := i.users[name]; !ok {
+		return notFound("user %s", name)
+	}
+	delete(i.users, name)
+	return nil
+}
+
+func copyUsers(users map[string]*sqladmin.User, instance string) map[string]*sqladmin.User {
+	out := make(map[string]*sqladmin.User, len(users))
+	for name, u := range users {
+		c := *u
+		c.Instance = instance
+		out[name] = &c
+	}
+	return out
+}
@@ -18,6 +18,7 @@ package cloudsql
 
 import (
 	"context"
+	"net/http"
 
 	"google.golang.org/api/option"
 
@@ -43,9 +44,14 @@ type UserClient struct {
 // Interface validation
 var _ UserService = &UserClient{}
 
-// NewUserClient creates new instance of UserClient
-func NewUserClient(ctx context.Context, creds *google.Credentials) (*UserClient, error) {
-	service, err := sqladmin.NewService(ctx, option.WithHTTPClient(oauth2.NewClient(ctx, creds.TokenSource)))
+// NewUserClient creates new instance of UserClient. Requests are
+// made using the supplied HTTP client, which must be authorized by the supplied
+// credentials, or using a new HTTP client authorized by them if it is nil.
+func NewUserClient(ctx context.Context, creds *google.Credentials, hc *http.Client) (*UserClient, error) {
+	if hc == nil {
+		hc = oauth2.NewClient(ctx, creds.TokenSource)
+	}
+	service, err := sqladmin.NewService(ctx, option.WithHTTPClient(hc))
 	if err != nil {
 		return nil, err
 	}
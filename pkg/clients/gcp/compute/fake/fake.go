@@ -0,0 +1,126 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	compute "google.golang.org/api/compute/v1"
+
+	gcpcompute "github.com/crossplaneio/crossplane/pkg/clients/gcp/compute"
+)
+
+// MockNetworkClient implements the NetworkService interface.
+type MockNetworkClient struct {
+	MockGet    func(ctx context.Context, name string) (*compute.Network, error)
+	MockInsert func(ctx context.Context, network *compute.Network) error
+	MockPatch  func(ctx context.Context, name string, network *compute.Network) error
+	MockDelete func(ctx context.Context, name string) error
+}
+
+// Interface validation
+var _ gcpcompute.NetworkService = &MockNetworkClient{}
+
+// Get calls the MockNetworkClient's MockGet method.
+func (c *MockNetworkClient) Get(ctx context.Context, name string) (*compute.Network, error) {
+	return c.MockGet(ctx, name)
+}
+
+// Insert calls the MockNetworkClient's MockInsert method.
+func (c *MockNetworkClient) Insert(ctx context.Context, network *compute.Network) error {
+	return c.MockInsert(ctx, network)
+}
+
+// Patch calls the MockNetworkClient's MockPatch method.
+func (c *MockNetworkClient) Patch(ctx context.Context, name string, network *compute.Network) error {
+	return c.MockPatch(ctx, name, network)
+}
+
+// Delete calls the MockNetworkClient's MockDelete method.
+func (c *MockNetworkClient) Delete(ctx context.Context, name string) error {
+	return c.MockDelete(ctx, name)
+}
+
+// MockSubnetworkClient implements the SubnetworkService interface.
+type MockSubnetworkClient struct {
+	MockGet                      func(ctx context.Context, region, name string) (*compute.Subnetwork, error)
+	MockInsert                   func(ctx context.Context, region string, subnetwork *compute.Subnetwork) error
+	MockPatch                    func(ctx context.Context, region, name string, subnetwork *compute.Subnetwork) error
+	MockExpandIPCIDRRange        func(ctx context.Context, region, name, cidr string) error
+	MockSetPrivateIPGoogleAccess func(ctx context.Context, region, name string, enabled bool) error
+	MockDelete                   func(ctx context.Context, region, name string) error
+}
+
+// Interface validation
+var _ gcpcompute.SubnetworkService = &MockSubnetworkClient{}
+
+// Get calls the MockSubnetworkClient's MockGet method.
+func (c *MockSubnetworkClient) Get(ctx context.Context, region, name string) (*compute.Subnetwork, error) {
+	return c.MockGet(ctx, region, name)
+}
+
+// Insert calls the MockSubnetworkClient's MockInsert method.
+func (c *MockSubnetworkClient) Insert(ctx context.Context, region string, subnetwork *compute.Subnetwork) error {
+	return c.MockInsert(ctx, region, subnetwork)
+}
+
+// Patch calls the MockSubnetworkClient's MockPatch method.
+func (c *MockSubnetworkClient) Patch(ctx context.Context, region, name string, subnetwork *compute.Subnetwork) error {
+	return c.MockPatch(ctx, region, name, subnetwork)
+}
+
+// ExpandIPCIDRRange calls the MockSubnetworkClient's MockExpandIPCIDRRange
+// method.
+func (c *MockSubnetworkClient) ExpandIPCIDRRange(ctx context.Context, region, name, cidr string) error {
+	return c.MockExpandIPCIDRRange(ctx, region, name, cidr)
+}
+
+// SetPrivateIPGoogleAccess calls the MockSubnetworkClient's
+// MockSetPrivateIPGoogleAccess method.
+func (c *MockSubnetworkClient) SetPrivateIPGoogleAccess(ctx context.Context, region, name string, enabled bool) error {
+	return c.MockSetPrivateIPGoogleAccess(ctx, region, name, enabled)
+}
+
+// Delete calls the MockSubnetworkClient's MockDelete method.
+func (c *MockSubnetworkClient) Delete(ctx context.Context, region, name string) error {
+	return c.MockDelete(ctx, region, name)
+}
+
+// MockGlobalAddressClient implements the GlobalAddressService interface.
+type MockGlobalAddressClient struct {
+	MockGet    func(ctx context.Context, name string) (*compute.Address, error)
+	MockInsert func(ctx context.Context, address *compute.Address) error
+	MockDelete func(ctx context.Context, name string) error
+}
+
+// Interface validation
+var _ gcpcompute.GlobalAddressService = &MockGlobalAddressClient{}
+
+// Get calls the MockGlobalAddressClient's MockGet method.
+func (c *MockGlobalAddressClient) Get(ctx context.Context, name string) (*compute.Address, error) {
+	return c.MockGet(ctx, name)
+}
+
+// Insert calls the MockGlobalAddressClient's MockInsert method.
+func (c *MockGlobalAddressClient) Insert(ctx context.Context, address *compute.Address) error {
+	return c.MockInsert(ctx, address)
+}
+
+// Delete calls the MockGlobalAddressClient's MockDelete method.
+func (c *MockGlobalAddressClient) Delete(ctx context.Context, name string) error {
+	return c.MockDelete(ctx, name)
+}
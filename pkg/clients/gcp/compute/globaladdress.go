@@ -0,0 +1,95 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"net/http"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+
+	"github.com/crossplaneio/crossplane/gcp/apis/compute/v1alpha1"
+)
+
+// GlobalAddressService provides an interface for operations on global
+// addresses. Global addresses cannot be changed after they are created.
+type GlobalAddressService interface {
+	Get(ctx context.Context, name string) (*compute.Address, error)
+	Insert(ctx context.Context, address *compute.Address) error
+	Delete(ctx context.Context, name string) error
+}
+
+// GlobalAddressClient implements the GlobalAddressService interface.
+type GlobalAddressClient struct {
+	service   *compute.GlobalAddressesService
+	projectID string
+}
+
+// Interface validation
+var _ GlobalAddressService = &GlobalAddressClient{}
+
+// NewGlobalAddressClient creates a new instance of GlobalAddressClient. Requests are
+// made using the supplied HTTP client, which must be authorized by the supplied
+// credentials, or using a new HTTP client authorized by them if it is nil.
+func NewGlobalAddressClient(ctx context.Context, creds *google.Credentials, hc *http.Client) (*GlobalAddressClient, error) {
+	if hc == nil {
+		hc = oauth2.NewClient(ctx, creds.TokenSource)
+	}
+	service, err := compute.NewService(ctx, option.WithHTTPClient(hc))
+	if err != nil {
+		return nil, err
+	}
+
+	return &GlobalAddressClient{
+		service:   service.GlobalAddresses,
+		projectID: creds.ProjectID,
+	}, nil
+}
+
+// Get the global address with the supplied name.
+func (c *GlobalAddressClient) Get(ctx context.Context, name string) (*compute.Address, error) {
+	return c.service.Get(c.projectID, name).Context(ctx).Do()
+}
+
+// Insert the supplied global address. Addresses are reserved asynchronously.
+func (c *GlobalAddressClient) Insert(ctx context.Context, address *compute.Address) error {
+	_, err := c.service.Insert(c.projectID, address).Context(ctx).Do()
+	return err
+}
+
+// Delete the global address with the supplied name.
+func (c *GlobalAddressClient) Delete(ctx context.Context, name string) error {
+	_, err := c.service.Delete(c.projectID, name).Context(ctx).Do()
+	return err
+}
+
+// NewGlobalAddress returns a global address suitable for use with the GCP
+// API.
+func NewGlobalAddress(a *v1alpha1.GlobalAddress) *compute.Address {
+	return &compute.Address{
+		Name:         a.GetResourceName(),
+		Description:  a.Spec.Description,
+		Address:      a.Spec.Address,
+		AddressType:  a.Spec.AddressType,
+		Purpose:      a.Spec.Purpose,
+		PrefixLength: a.Spec.PrefixLength,
+		Network:      a.Spec.Network,
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"net/http"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+
+	"github.com/crossplaneio/crossplane/gcp/apis/compute/v1alpha1"
+)
+
+const (
+	// DefaultScope for the compute client.
+	DefaultScope = compute.ComputeScope
+
+	// API is the name of the GCP API used by compute clients.
+	API = "compute.googleapis.com"
+)
+
+// NetworkService provides an interface for operations on VPC networks.
+type NetworkService interface {
+	Get(ctx context.Context, name string) (*compute.Network, error)
+	Insert(ctx context.Context, network *compute.Network) error
+	Patch(ctx context.Context, name string, network *compute.Network) error
+	Delete(ctx context.Context, name string) error
+}
+
+// NetworkClient implements the NetworkService interface.
+type NetworkClient struct {
+	service   *compute.NetworksService
+	projectID string
+}
+
+// Interface validation
+var _ NetworkService = &NetworkClient{}
+
+// NewNetworkClient creates a new instance of NetworkClient. Requests are
+// made using the supplied HTTP client, which must be authorized by the supplied
+// credentials, or using a new HTTP client authorized by them if it is nil.
+func NewNetworkClient(ctx context.Context, creds *google.Credentials, hc *http.Client) (*NetworkClient, error) {
+	if hc == nil {
+		hc = oauth2.NewClient(ctx, creds.TokenSource)
+	}
+	service, err := compute.NewService(ctx, option.WithHTTPClient(hc))
+	if err != nil {
+		return nil, err
+	}
+
+	return &NetworkClient{
+		service:   service.Networks,
+		projectID: creds.ProjectID,
+	}, nil
+}
+
+// Get the network with the supplied name.
+func (c *NetworkClient) Get(ctx context.Context, name string) (*compute.Network, error) {
+	return c.service.Get(c.projectID, name).Context(ctx).Do()
+}
+
+// Insert the supplied network. Networks are created asynchronously.
+func (c *NetworkClient) Insert(ctx context.Context, network *compute.Network) error {
+	_, err := c.service.Insert(c.projectID, network).Context(ctx).Do()
+	return err
+}
+
+// Patch the network with the supplied name. Only its routing configuration
+// may be patched.
+func (c *NetworkClient) Patch(ctx context.Context, name string, network *compute.Network) error {
+	_, err := c.service.Patch(c.projectID, name, network).Context(ctx).Do()
+	return err
+}
+
+// Delete the network with the supplied name.
+func (c *NetworkClient) Delete(ctx context.Context, name string) error {
+	_, err := c.service.Delete(c.projectID, name).Context(ctx).Do()
+	return err
+}
+
+// NewNetwork returns a network suitable for use with the GCP API.
+func NewNetwork(n *v1alpha1.Network) *compute.Network {
+	network := &compute.Network{
+		Name:                  n.GetResourceName(),
+		Description:           n.Spec.Description,
+		AutoCreateSubnetworks: n.Spec.AutoCreateSubnetworks,
+
+		// The API creates a legacy network when AutoCreateSubnetworks is
+		// omitted, so we always send it.
+		ForceSendFields: []string{"AutoCreateSubnetworks"},
+	}
+	if n.Spec.RoutingMode != "" {
+		network.RoutingConfig = &compute.NetworkRoutingConfig{RoutingMode: n.Spec.RoutingMode}
+	}
+	return network
+}
+
+// NetworkNeedsUpdate returns true if the routing mode of the supplied GCP
+// network differs from that of the supplied Network. Other network
+// configuration cannot be changed.
+func NetworkNeedsUpdate(n *v1alpha1.Network, network *compute.Network) bool {
+	if n.Spec.RoutingMode == "" {
+		return false
+	}
+	if network.RoutingConfig == nil {
+		return true
+	}
+	return n.Spec.RoutingMode != network.RoutingConfig.RoutingMode
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplaneio/crossplane/gcp/apis/compute/v1alpha1"
+)
+
+const uid = types.UID("definitely-a-uuid")
+
+func TestNewNetwork(t *testing.T) {
+	cases := []struct {
+		name string
+		n    *v1alpha1.Network
+		want *compute.Network
+	}{
+		{
+			name: "CustomMode",
+			n:    &v1alpha1.Network{ObjectMeta: metav1.ObjectMeta{UID: uid}},
+			want: &compute.Network{
+				Name:            "network-" + string(uid),
+				ForceSendFields: []string{"AutoCreateSubnetworks"},
+			},
+		},
+		{
+			name: "AutoModeGlobalRouting",
+			n: &v1alpha1.Network{
+				ObjectMeta: metav1.ObjectMeta{UID: uid},
+				Spec: v1alpha1.NetworkSpec{NetworkParameters: v1alpha1.NetworkParameters{
+					NameFormat:            "cool",
+					Description:           "very cool",
+					AutoCreateSubnetworks: true,
+					RoutingMode:           v1alpha1.RoutingModeGlobal,
+				}},
+			},
+			want: &compute.Network{
+				Name:                  "cool",
+				Description:           "very cool",
+				AutoCreateSubnetworks: true,
+				RoutingConfig:         &compute.NetworkRoutingConfig{RoutingMode: v1alpha1.RoutingModeGlobal},
+				ForceSendFields:       []string{"AutoCreateSubnetworks"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := NewNetwork(tc.n)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("NewNetwork(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNetworkNeedsUpdate(t *testing.T) {
+	global := &v1alpha1.Network{Spec: v1alpha1.NetworkSpec{NetworkParameters: v1alpha1.NetworkParameters{RoutingMode: v1alpha1.RoutingModeGlobal}}}
+
+	cases := []struct {
+		name    string
+		n       *v1alpha1.Network
+		network *compute.Network
+		want    bool
+	}{
+		{
+			name:    "Unmanaged",
+			n:       &v1alpha1.Network{},
+			network: &compute.Network{RoutingConfig: &compute.NetworkRoutingConfig{RoutingMode: v1alpha1.RoutingModeGlobal}},
+			want:    false,
+		},
+		{
+			name:    "UpToDate",
+			n:       global,
+			network: &compute.Network{RoutingConfig: &compute.NetworkRoutingConfig{RoutingMode: v1alpha1.RoutingModeGlobal}},
+			want:    false,
+		},
+		{
+			name:    "RoutingModeChanged",
+			n:       global,
+			network: &compute.Network{RoutingConfig: &compute.NetworkRoutingConfig{RoutingMode: v1alpha1.RoutingModeRegional}},
+			want:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := NetworkNeedsUpdate(tc.n, tc.network)
+			if got != tc.want {
+				t.Errorf("NetworkNeedsUpdate(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
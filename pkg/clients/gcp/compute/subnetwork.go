@@ -0,0 +1,163 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+
+	"github.com/crossplaneio/crossplane/gcp/apis/compute/v1alpha1"
+)
+
+// SubnetworkService provides an interface for operations on VPC subnetworks.
+type SubnetworkService interface {
+	Get(ctx context.Context, region, name string) (*compute.Subnetwork, error)
+	Insert(ctx context.Context, region string, subnetwork *compute.Subnetwork) error
+	Patch(ctx context.Context, region, name string, subnetwork *compute.Subnetwork) error
+	ExpandIPCIDRRange(ctx context.Context, region, name, cidr string) error
+	SetPrivateIPGoogleAccess(ctx context.Context, region, name string, enabled bool) error
+	Delete(ctx context.Context, region, name string) error
+}
+
+// SubnetworkClient implements the SubnetworkService interface.
+type SubnetworkClient struct {
+	service   *compute.SubnetworksService
+	projectID string
+}
+
+// Interface validation
+var _ SubnetworkService = &SubnetworkClient{}
+
+// NewSubnetworkClient creates a new instance of SubnetworkClient. Requests are
+// made using the supplied HTTP client, which must be authorized by the supplied
+// credentials, or using a new HTTP client authorized by them if it is nil.
+func NewSubnetworkClient(ctx context.Context, creds *google.Credentials, hc *http.Client) (*SubnetworkClient, error) {
+	if hc == nil {
+		hc = oauth2.NewClient(ctx, creds.TokenSource)
+	}
+	service, err := compute.NewService(ctx, option.WithHTTPClient(hc))
+	if err != nil {
+		return nil, err
+	}
+
+	return &SubnetworkClient{
+		service:   service.Subnetworks,
+		projectID: creds.ProjectID,
+	}, nil
+}
+
+// Get the subnetwork with the supplied name in the supplied region.
+func (c *SubnetworkClient) Get(ctx context.Context, region, name string) (*compute.Subnetwork, error) {
+	return c.service.Get(c.projectID, region, name).Context(ctx).Do()
+}
+
+// Insert the supplied subnetwork in the supplied region. Subnetworks are
+// created asynchronously.
+func (c *SubnetworkClient) Insert(ctx context.Context, region string, subnetwork *compute.Subnetwork) error {
+	_, err := c.service.Insert(c.projectID, region, subnetwork).Context(ctx).Do()
+	return err
+}
+
+// Patch the subnetwork with the supplied name. The supplied subnetwork must
+// include the fingerprint of the existing subnetwork.
+func (c *SubnetworkClient) Patch(ctx context.Context, region, name string, subnetwork *compute.Subnetwork) error {
+	_, err := c.service.Patch(c.projectID, region, name, subnetwork).Context(ctx).Do()
+	return err
+}
+
+// ExpandIPCIDRRange expands the primary IP range of the subnetwork with the
+// supplied name to the supplied CIDR range.
+func (c *SubnetworkClient) ExpandIPCIDRRange(ctx context.Context, region, name, cidr string) error {
+	rq := &compute.SubnetworksExpandIpCidrRangeRequest{IpCidrRange: cidr}
+	_, err := c.service.ExpandIpCidrRange(c.projectID, region, name, rq).Context(ctx).Do()
+	return err
+}
+
+// SetPrivateIPGoogleAccess enables or disables private Google access for the
+// subnetwork with the supplied name.
+func (c *SubnetworkClient) SetPrivateIPGoogleAccess(ctx context.Context, region, name string, enabled bool) error {
+	rq := &compute.SubnetworksSetPrivateIpGoogleAccessRequest{
+		PrivateIpGoogleAccess: enabled,
+		ForceSendFields:       []string{"PrivateIpGoogleAccess"},
+	}
+	_, err := c.service.SetPrivateIpGoogleAccess(c.projectID, region, name, rq).Context(ctx).Do()
+	return err
+}
+
+// Delete the subnetwork with the supplied name in the supplied region.
+func (c *SubnetworkClient) Delete(ctx context.Context, region, name string) error {
+	_, err := c.service.Delete(c.projectID, region, name).Context(ctx).Do()
+	return err
+}
+
+// NewSubnetwork returns a subnetwork suitable for use with the GCP API.
+func NewSubnetwork(s *v1alpha1.Subnetwork) *compute.Subnetwork {
+	return &compute.Subnetwork{
+		Name:                  s.GetResourceName(),
+		Description:           s.Spec.Description,
+		Network:               s.Spec.Network,
+		IpCidrRange:           s.Spec.IPCIDRRange,
+		PrivateIpGoogleAccess: s.Spec.PrivateIPGoogleAccess,
+		SecondaryIpRanges:     NewSecondaryRanges(s.Spec.SecondaryIPRanges),
+	}
+}
+
+// NewSecondaryRanges returns secondary ranges suitable for use with the GCP
+// API.
+func NewSecondaryRanges(in []v1alpha1.SecondaryRange) []*compute.SubnetworkSecondaryRange {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]*compute.SubnetworkSecondaryRange, len(in))
+	for i, r := range in {
+		out[i] = &compute.SubnetworkSecondaryRange{RangeName: r.RangeName, IpCidrRange: r.IPCIDRRange}
+	}
+	return out
+}
+
+// SecondaryRangesNeedUpdate returns true if the secondary ranges of the
+// supplied GCP subnetwork differ from those of the supplied Subnetwork. The
+// order of the ranges is not significant.
+func SecondaryRangesNeedUpdate(s *v1alpha1.Subnetwork, subnetwork *compute.Subnetwork) bool {
+	if len(s.Spec.SecondaryIPRanges) != len(subnetwork.SecondaryIpRanges) {
+		return true
+	}
+
+	desired := make([]string, 0, len(s.Spec.SecondaryIPRanges))
+	for _, r := range s.Spec.SecondaryIPRanges {
+		desired = append(desired, r.RangeName+"="+r.IPCIDRRange)
+	}
+	observed := make([]string, 0, len(subnetwork.SecondaryIpRanges))
+	for _, r := range subnetwork.SecondaryIpRanges {
+		observed = append(observed, r.RangeName+"="+r.IpCidrRange)
+	}
+	sort.Strings(desired)
+	sort.Strings(observed)
+
+	for i := range desired {
+		if desired[i] != observed[i] {
+			return true
+		}
+	}
+	return false
+}
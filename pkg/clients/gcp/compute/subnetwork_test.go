@@ -0,0 +1,73 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"testing"
+
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/crossplaneio/crossplane/gcp/apis/compute/v1alpha1"
+)
+
+func TestSecondaryRangesNeedUpdate(t *testing.T) {
+	s := &v1alpha1.Subnetwork{Spec: v1alpha1.SubnetworkSpec{SubnetworkParameters: v1alpha1.SubnetworkParameters{
+		SecondaryIPRanges: []v1alpha1.SecondaryRange{
+			{RangeName: "pods", IPCIDRRange: "10.4.0.0/14"},
+			{RangeName: "services", IPCIDRRange: "10.0.32.0/20"},
+		},
+	}}}
+
+	cases := []struct {
+		name       string
+		subnetwork *compute.Subnetwork
+		want       bool
+	}{
+		{
+			name: "SameRangesDifferentOrder",
+			subnetwork: &compute.Subnetwork{SecondaryIpRanges: []*compute.SubnetworkSecondaryRange{
+				{RangeName: "services", IpCidrRange: "10.0.32.0/20"},
+				{RangeName: "pods", IpCidrRange: "10.4.0.0/14"},
+			}},
+			want: false,
+		},
+		{
+			name: "RangeMissing",
+			subnetwork: &compute.Subnetwork{SecondaryIpRanges: []*compute.SubnetworkSecondaryRange{
+				{RangeName: "pods", IpCidrRange: "10.4.0.0/14"},
+			}},
+			want: true,
+		},
+		{
+			name: "RangeChanged",
+			subnetwork: &compute.Subnetwork{SecondaryIpRanges: []*compute.SubnetworkSecondaryRange{
+				{RangeName: "pods", IpCidrRange: "10.8.0.0/14"},
+				{RangeName: "services", IpCidrRange: "10.0.32.0/20"},
+			}},
+			want: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := SecondaryRangesNeedUpdate(s, tc.subnetwork)
+			if got != tc.want {
+				t.Errorf("SecondaryRangesNeedUpdate(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
@@ -17,22 +17,37 @@ limitations under the License.
 package gcp
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
 
 	"google.golang.org/api/option"
 
+	"github.com/pkg/errors"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/cloudresourcemanager/v1"
 	"google.golang.org/api/googleapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane-runtime/pkg/logging"
 	"github.com/crossplaneio/crossplane-runtime/pkg/util"
 	gcpv1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/credentials"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
 )
 
 var log = logging.Logger.WithName("clients.gcp")
@@ -40,6 +55,14 @@ var log = logging.Logger.WithName("clients.gcp")
 // DefaultScope is the default scope to use for a GCP client
 const DefaultScope = cloudresourcemanager.CloudPlatformScope
 
+// generateAccessTokenURL is the IAM credentials API endpoint used to generate
+// access tokens for an impersonated service account.
+const generateAccessTokenURL = "https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/%s:generateAccessToken"
+
+// impersonatedTokenLifetime is the lifetime of the access tokens generated for
+// an impersonated service account.
+const impersonatedTokenLifetime = time.Hour
+
 // GetGoogleClient returns a client object that can be used to interact with the Google API
 func GetGoogleClient(clientset kubernetes.Interface, namespace string, secretKey v1.SecretKeySelector,
 	scopes ...string) (*http.Client, error) {
@@ -101,15 +124,331 @@ func IsErrorBadRequest(err error) bool {
 	return ok && googleapiErr.Code == http.StatusBadRequest
 }
 
-// ProviderCredentials return google credentials based on the provider's credentials secret data
-func ProviderCredentials(client kubernetes.Interface, p *gcpv1alpha1.Provider, scopes ...string) (*google.Credentials, error) {
-	// retrieve provider secret data
-	data, err := util.SecretData(client, p.Namespace, p.Spec.Secret)
+// A MissingPermissionsError indicates that credentials are not granted some
+// required permissions.
+type MissingPermissionsError struct {
+	Permissions []string
+}
+
+func (e *MissingPermissionsError) Error() string {
+	return fmt.Sprintf("missing permissions: %v", e.Permissions)
+}
+
+// IsErrorUnauthorized returns true if the supplied error indicates that GCP
+// rejected the credentials used to make a request, or that they are not
+// authorized to make it.
+func IsErrorUnauthorized(err error) bool {
+	switch e := errors.Cause(err).(type) {
+	case *googleapi.Error:
+		return e.Code == http.StatusUnauthorized || e.Code == http.StatusForbidden
+	case *oauth2.RetrieveError:
+		// Token endpoints reject invalid credentials as a bad request.
+		return e.Response != nil && (e.Response.StatusCode == http.StatusBadRequest || e.Response.StatusCode == http.StatusUnauthorized)
+	case *MissingPermissionsError:
+		return true
+	}
+	return false
+}
+
+// ErrorCode returns the reason or status code of the supplied GCP API error,
+// if any.
+func ErrorCode(err error) string {
+	err = errors.Cause(err)
+	if e, ok := err.(*googleapi.Error); ok {
+		if len(e.Errors) > 0 && e.Errors[0].Reason != "" {
+			return e.Errors[0].Reason
+		}
+		return strconv.Itoa(e.Code)
+	}
+	if s, ok := status.FromError(err); ok && s.Code() != codes.OK && s.Code() != codes.Unknown {
+		return s.Code().String()
+	}
+	return ""
+}
+
+// ProviderCredentials return google credentials based on the provider's
+// credentials source. The credentials are those of the provider's impersonated
+// service account, if any. Credentials of a provider whose credentials are
+// known to be invalid are not returned.
+func ProviderCredentials(ctx context.Context, c client.Client, p *gcpv1alpha1.Provider, scopes ...string) (*google.Credentials, error) {
+	if cd := p.GetCondition(runtimev1alpha1.TypeReady); cd.Status == v1.ConditionFalse {
+		return nil, errors.Errorf("provider %s is not ready: %s", p.GetName(), cd.Message)
+	}
+	return providerCredentials(ctx, c, p, scopes...)
+}
+
+// A NewClientFn returns a new GCP API client that makes requests using the
+// supplied HTTP client, which is authorized by the supplied credentials.
+type NewClientFn func(creds *google.Credentials, hc *http.Client) (interface{}, error)
+
+// PooledClient returns a client of the supplied GCP API and kind for the
+// supplied provider, created by the supplied function. Clients are cached by the
+// supplied pool until the provider or its credentials secret change. Requests
+// made by a client are rate limited, and back off when any request to the same
+// API using the same provider is throttled.
+func PooledClient(ctx context.Context, pl *pool.Pool, c client.Client, p *gcpv1alpha1.Provider, api, kind string, fn NewClientFn, scopes ...string) (interface{}, error) {
+	if cd := p.GetCondition(runtimev1alpha1.TypeReady); cd.Status == v1.ConditionFalse {
+		return nil, errors.Errorf("provider %s is not ready: %s", p.GetName(), cd.Message)
+	}
+
+	v, err := ProviderVersion(ctx, c, p)
 	if err != nil {
 		return nil, err
 	}
 
-	return google.CredentialsFromJSON(context.Background(), data, scopes...)
+	k := pool.Key{Provider: p.GetUID(), API: api, Kind: kind}
+	return pl.Get(k, v, func() (interface{}, error) {
+		// Pooled credentials outlive the supplied context, so they must not
+		// use it to refresh their tokens.
+		creds, err := providerCredentials(context.Background(), c, p, scopes...)
+		if err != nil {
+			return nil, err
+		}
+		hc := &http.Client{Transport: &oauth2.Transport{Source: creds.TokenSource, Base: pl.Transport(k, Throttled, Operation)}}
+		return fn(creds, hc)
+	})
+}
+
+// ProviderVersion returns a version of the supplied provider's configuration
+// that changes when the provider's spec or its credentials secret change.
+func ProviderVersion(ctx context.Context, c client.Client, p *gcpv1alpha1.Provider) (string, error) {
+	secret := ""
+	if p.Spec.CredentialsSource == "" || p.Spec.CredentialsSource == gcpv1alpha1.CredentialsSourceSecret {
+		secret = p.Spec.Secret.Name
+	}
+	return pool.Version(ctx, c, p, secret)
+}
+
+// Throttled reports whether the supplied response from a GCP API indicates
+// requests are being throttled.
+func Throttled(rsp *http.Response) bool {
+	switch rsp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusForbidden:
+	default:
+		return false
+	}
+
+	// Some GCP APIs report rate limiting as a forbidden error with a rate
+	// limit reason, so we read the body then replace it for the client to
+	// read.
+	body, err := ioutil.ReadAll(rsp.Body)
+	rsp.Body.Close() // nolint:errcheck
+	rsp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+	return bytes.Contains(body, []byte("rateLimitExceeded")) || bytes.Contains(body, []byte("userRateLimitExceeded"))
+}
+
+// Operation names the GCP API operation the supplied request calls, for
+// example clusters.get or clusters.setMasterAuth. The operation is inferred
+// from the request's method and resource path, which alternates between
+// collections and resource IDs, for example
+// /v1/projects/p/locations/l/clusters/c.
+func Operation(req *http.Request) string {
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	for i, s := range segments {
+		if len(s) > 1 && s[0] == 'v' && unicode.IsDigit(rune(s[1])) {
+			segments = segments[i+1:]
+			break
+		}
+	}
+	if len(segments) == 0 || segments[0] == "" {
+		return req.Method
+	}
+
+	// The Compute API scopes global resources using a lone segment, for
+	// example /compute/v1/projects/p/global/networks/n.
+	for i, s := range segments {
+		if s == "global" {
+			segments = append(segments[:i], segments[i+1:]...)
+			break
+		}
+	}
+
+	verb := ""
+	last := segments[len(segments)-1]
+	if i := strings.LastIndex(last, ":"); i >= 0 {
+		segments[len(segments)-1], verb = last[:i], last[i+1:]
+	}
+
+	// A path with an odd number of segments ends in a collection, rather
+	// than a resource ID.
+	collection := len(segments)%2 == 1
+	name := segments[len(segments)-1]
+	if !collection {
+		name = segments[len(segments)-2]
+	}
+
+	switch {
+	case verb != "":
+		return name + "." + verb
+	case collection && req.Method == http.MethodGet:
+		return name + ".list"
+	case collection && req.Method == http.MethodPost:
+		return name + ".create"
+	case req.Method == http.MethodPut:
+		return name + ".update"
+	}
+	return name + "." + strings.ToLower(req.Method)
+}
+
+// ValidateProvider validates the credentials of the supplied provider by
+// obtaining an access token and testing that they are granted the provider's
+// required permissions. It returns the identity as which the credentials
+// authenticate, if it can be determined.
+func ValidateProvider(ctx context.Context, c client.Client, p *gcpv1alpha1.Provider) (string, error) {
+	creds, err := providerCredentials(ctx, c, p, DefaultScope)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := creds.TokenSource.Token(); err != nil {
+		return "", errors.Wrap(err, "cannot obtain access token")
+	}
+
+	if err := TestPermissions(creds, p.Spec.RequiredPermissions); err != nil {
+		return "", err
+	}
+
+	if p.Spec.ImpersonateServiceAccount != "" {
+		return p.Spec.ImpersonateServiceAccount, nil
+	}
+
+	// Credentials read from a service account key identify their service
+	// account. Other credentials, such as those of a user or the metadata
+	// server, do not identify who they authenticate as.
+	key := struct {
+		ClientEmail string `json:"client_email"`
+	}{}
+	_ = json.Unmarshal(creds.JSON, &key)
+	return key.ClientEmail, nil
+}
+
+func providerCredentials(ctx context.Context, c client.Client, p *gcpv1alpha1.Provider, scopes ...string) (*google.Credentials, error) {
+	if p.Spec.ImpersonateServiceAccount == "" {
+		return baseCredentials(ctx, c, p, scopes...)
+	}
+
+	// Generating access tokens for another service account requires the
+	// cloud-platform scope, regardless of the scopes requested for them.
+	base, err := baseCredentials(ctx, c, p, DefaultScope)
+	if err != nil {
+		return nil, err
+	}
+
+	creds := ImpersonatedCredentials(ctx, base, p.Spec.ImpersonateServiceAccount, scopes...)
+	if p.Spec.ProjectID != "" {
+		creds.ProjectID = p.Spec.ProjectID
+	}
+	return creds, nil
+}
+
+// baseCredentials returns google credentials read from the provider's secret
+// or credentials file, or the application default credentials of the
+// Crossplane pod.
+func baseCredentials(ctx context.Context, c client.Client, p *gcpv1alpha1.Provider, scopes ...string) (*google.Credentials, error) {
+	var data []byte
+	switch p.Spec.CredentialsSource {
+	case gcpv1alpha1.CredentialsSourceEnvironment:
+		if err := credentials.Default.AllowAmbient(p.GetNamespace()); err != nil {
+			return nil, err
+		}
+		creds, err := google.FindDefaultCredentials(ctx, scopes...)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot find application default credentials")
+		}
+		if creds.ProjectID == "" {
+			creds.ProjectID = p.Spec.ProjectID
+		}
+		return creds, nil
+	case gcpv1alpha1.CredentialsSourceFile:
+		if err := credentials.Default.AllowFile(p.GetNamespace(), p.Spec.CredentialsFile); err != nil {
+			return nil, err
+		}
+		d, err := ioutil.ReadFile(p.Spec.CredentialsFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot read provider credentials file %s", p.Spec.CredentialsFile)
+		}
+		data = d
+	default:
+		s := &v1.Secret{}
+		n := types.NamespacedName{Namespace: p.GetNamespace(), Name: p.Spec.Secret.Name}
+		if err := c.Get(ctx, n, s); err != nil {
+			return nil, errors.Wrapf(err, "cannot get provider secret %s", n)
+		}
+		data = s.Data[p.Spec.Secret.Key]
+	}
+
+	creds, err := google.CredentialsFromJSON(ctx, data, scopes...)
+	return creds, errors.Wrap(err, "cannot retrieve creds from json")
+}
+
+// ImpersonatedCredentials returns google credentials of the supplied service
+// account. Its access tokens are generated by the IAM credentials API using
+// the supplied base credentials, which must be permitted to create tokens for
+// the service account.
+func ImpersonatedCredentials(ctx context.Context, base *google.Credentials, serviceAccount string, scopes ...string) *google.Credentials {
+	ts := &impersonatedTokenSource{
+		client:         oauth2.NewClient(ctx, base.TokenSource),
+		url:            fmt.Sprintf(generateAccessTokenURL, serviceAccount),
+		serviceAccount: serviceAccount,
+		scopes:         scopes,
+	}
+	return &google.Credentials{ProjectID: base.ProjectID, TokenSource: oauth2.ReuseTokenSource(nil, ts)}
+}
+
+type generateAccessTokenRequest struct {
+	Scope    []string `json:"scope"`
+	Lifetime string   `json:"lifetime"`
+}
+
+type generateAccessTokenResponse struct {
+	AccessToken string `json:"accessToken"`
+	ExpireTime  string `json:"expireTime"`
+}
+
+// An impersonatedTokenSource generates access tokens for a service account.
+type impersonatedTokenSource struct {
+	client         *http.Client
+	url            string
+	serviceAccount string
+	scopes         []string
+}
+
+// Token generates a new access token for the impersonated service account.
+func (s *impersonatedTokenSource) Token() (*oauth2.Token, error) {
+	body, err := json.Marshal(generateAccessTokenRequest{
+		Scope:    s.scopes,
+		Lifetime: fmt.Sprintf("%ds", int(impersonatedTokenLifetime.Seconds())),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot marshal generate access token request")
+	}
+
+	rsp, err := s.client.Post(s.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot generate access token for service account %s", s.serviceAccount)
+	}
+	defer rsp.Body.Close() // nolint:errcheck
+
+	if err := googleapi.CheckResponse(rsp); err != nil {
+		return nil, errors.Wrapf(err, "cannot generate access token for service account %s", s.serviceAccount)
+	}
+
+	r := &generateAccessTokenResponse{}
+	if err := json.NewDecoder(rsp.Body).Decode(r); err != nil {
+		return nil, errors.Wrap(err, "cannot decode generate access token response")
+	}
+
+	expiry, err := time.Parse(time.RFC3339, r.ExpireTime)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot parse access token expire time")
+	}
+
+	return &oauth2.Token{AccessToken: r.AccessToken, TokenType: "Bearer", Expiry: expiry}, nil
 }
 
 // ProjectInfo represent GCP Project information
@@ -153,6 +492,9 @@ func Project(creds *google.Credentials) (*ProjectInfo, error) {
 	}, nil
 }
 
+// ResourceManagerAPI is the name of the GCP API used to test permissions.
+const ResourceManagerAPI = "cloudresourcemanager.googleapis.com"
+
 // TestPermissions tests service account permission using provided credentials and assert that it has
 // all the provided permissions.
 // - return nil - if all permissions are found
@@ -160,8 +502,13 @@ func Project(creds *google.Credentials) (*ProjectInfo, error) {
 func TestPermissions(creds *google.Credentials, permissions []string) error {
 	ctx := context.Background()
 
-	// Create an authenticated client.
-	client := oauth2.NewClient(ctx, creds.TokenSource)
+	// Create an authenticated client. Testing permissions is infrequent, so
+	// it is not rate limited, but its requests are still recorded as cloud
+	// API metrics.
+	client := &http.Client{Transport: &oauth2.Transport{
+		Source: creds.TokenSource,
+		Base:   &pool.Transport{Throttled: Throttled, API: ResourceManagerAPI, Operation: Operation},
+	}}
 
 	// Create a cloud resource manager client from which we can make API calls.
 	crmService, err := cloudresourcemanager.NewService(ctx, option.WithHTTPClient(client))
@@ -182,7 +529,7 @@ func TestPermissions(creds *google.Credentials, permissions []string) error {
 
 		missing := getMissingPermissions(permissions, rs.Permissions)
 		if len(missing) > 0 {
-			return fmt.Errorf("missing permissions: %v", missing)
+			return &MissingPermissionsError{Permissions: missing}
 		}
 	}
 	return nil
@@ -18,13 +18,24 @@ package gcp
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
+	"google.golang.org/api/googleapi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gcpv1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/credentials"
 )
 
 func TestCredentialsFromFile(t *testing.T) {
@@ -81,6 +92,130 @@ func TestCredentialsFromFileError(t *testing.T) {
 	g.Expect(creds).To(BeNil())
 }
 
+func TestProviderCredentialsFromFile(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tmpfile, err := ioutil.TempFile("", "test")
+	g.Expect(err).NotTo(HaveOccurred())
+	defer os.Remove(tmpfile.Name()) // clean up
+
+	_, err = tmpfile.Write([]byte(`{"type": "authorized_user", "client_id": "id", "client_secret": "secret", "refresh_token": "token"}`))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tmpfile.Close()).To(Succeed())
+
+	defer func(p *credentials.Policy) { credentials.Default = p }(credentials.Default)
+	credentials.Default = &credentials.Policy{Namespaces: []string{"crossplane-system"}, FileDirectories: []string{os.TempDir()}}
+
+	p := &gcpv1alpha1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "crossplane-system"},
+		Spec: gcpv1alpha1.ProviderSpec{
+			CredentialsSource: gcpv1alpha1.CredentialsSourceFile,
+			CredentialsFile:   tmpfile.Name(),
+			ProjectID:         "test-project-123456",
+		},
+	}
+
+	// the provider's secret is not read, so no kubernetes client is required
+	creds, err := ProviderCredentials(context.Background(), nil, p, DefaultScope)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(creds).NotTo(BeNil())
+
+	// impersonated credentials manage resources in the provider's project
+	p.Spec.ImpersonateServiceAccount = "crossplane@test-project-123456.iam.gserviceaccount.com"
+	creds, err = ProviderCredentials(context.Background(), nil, p, DefaultScope)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(creds.ProjectID).To(Equal("test-project-123456"))
+
+	p.Spec.CredentialsFile = "file"
+	_, err = ProviderCredentials(context.Background(), nil, p, DefaultScope)
+	g.Expect(err).To(HaveOccurred())
+
+	// providers in untrusted namespaces may not use the Crossplane pod's
+	// credentials
+	p.SetNamespace("app-team")
+	p.Spec.CredentialsFile = tmpfile.Name()
+	_, err = ProviderCredentials(context.Background(), nil, p, DefaultScope)
+	g.Expect(err).To(HaveOccurred())
+	p.Spec.CredentialsSource = gcpv1alpha1.CredentialsSourceEnvironment
+	_, err = ProviderCredentials(context.Background(), nil, p, DefaultScope)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestImpersonatedTokenSource(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	serviceAccount := "crossplane@test-project-123456.iam.gserviceaccount.com"
+	expiry := time.Date(2019, time.August, 1, 0, 0, 0, 0, time.UTC)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer base-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		req := &generateAccessTokenRequest{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil || len(req.Scope) != 1 || req.Scope[0] != DefaultScope {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(&generateAccessTokenResponse{AccessToken: "impersonated-token", ExpireTime: expiry.Format(time.RFC3339)})
+	}))
+	defer srv.Close()
+
+	base := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "base-token"})
+	ts := &impersonatedTokenSource{
+		client:         oauth2.NewClient(context.Background(), base),
+		url:            srv.URL,
+		serviceAccount: serviceAccount,
+		scopes:         []string{DefaultScope},
+	}
+
+	token, err := ts.Token()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(token.AccessToken).To(Equal("impersonated-token"))
+	g.Expect(token.Expiry).To(Equal(expiry))
+
+	// the base credentials are not permitted to impersonate the service account
+	ts.client = oauth2.NewClient(context.Background(), oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "other-token"}))
+	_, err = ts.Token()
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestOperation(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []struct {
+		method   string
+		url      string
+		expected string
+	}{
+		{http.MethodGet, "https://container.googleapis.com/v1/projects/p/locations/l/clusters/c", "clusters.get"},
+		{http.MethodGet, "https://container.googleapis.com/v1/projects/p/locations/l/clusters", "clusters.list"},
+		{http.MethodPost, "https://container.googleapis.com/v1/projects/p/locations/l/clusters", "clusters.create"},
+		{http.MethodDelete, "https://container.googleapis.com/v1/projects/p/locations/l/clusters/c", "clusters.delete"},
+		{http.MethodPost, "https://container.googleapis.com/v1/projects/p/locations/l/clusters/c:setMasterAuth", "clusters.setMasterAuth"},
+		{http.MethodGet, "https://www.googleapis.com/compute/v1/projects/p/global/networks/n", "networks.get"},
+		{http.MethodGet, "https://container.googleapis.com/", http.MethodGet},
+	}
+
+	for _, tt := range cases {
+		req, err := http.NewRequest(tt.method, tt.url, nil)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(Operation(req)).To(Equal(tt.expected))
+	}
+}
+
+func TestIsErrorUnauthorized(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(IsErrorUnauthorized(nil)).To(BeFalse())
+	g.Expect(IsErrorUnauthorized(errors.New("boom"))).To(BeFalse())
+	g.Expect(IsErrorUnauthorized(&googleapi.Error{Code: http.StatusServiceUnavailable})).To(BeFalse())
+	g.Expect(IsErrorUnauthorized(&googleapi.Error{Code: http.StatusForbidden})).To(BeTrue())
+	g.Expect(IsErrorUnauthorized(&oauth2.RetrieveError{Response: &http.Response{StatusCode: http.StatusBadRequest}})).To(BeTrue())
+	g.Expect(IsErrorUnauthorized(&oauth2.RetrieveError{Response: &http.Response{StatusCode: http.StatusInternalServerError}})).To(BeFalse())
+	g.Expect(IsErrorUnauthorized(errors.Wrap(&MissingPermissionsError{Permissions: []string{"a"}}, "boom"))).To(BeTrue())
+}
+
 func TestMissingPermissions(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -18,6 +18,7 @@ package gke
 
 import (
 	"context"
+	"net/http"
 
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
@@ -32,6 +33,9 @@ const (
 	// DefaultScope used by the GKE API.
 	DefaultScope = container.CloudPlatformScope
 
+	// API is the name of the GCP API used by GKE clients.
+	API = "container.googleapis.com"
+
 	// TODO(negz): Is this username special? I can't see any ClusterRoleBindings
 	// that bind it to a role.
 	adminUser = "admin"
@@ -52,7 +56,14 @@ type ClusterClient struct {
 
 // NewClusterClient return new instance of the Client based on credentials
 func NewClusterClient(ctx context.Context, creds *google.Credentials) (*ClusterClient, error) {
-	client, err := container.NewService(ctx, option.WithHTTPClient(oauth2.NewClient(context.Background(), creds.TokenSource)))
+	return NewClusterClientWithHTTPClient(ctx, creds, oauth2.NewClient(context.Background(), creds.TokenSource))
+}
+
+// NewClusterClientWithHTTPClient returns a new instance of the Client that
+// makes requests using the supplied HTTP client, which must be authorized by
+// the supplied credentials.
+func NewClusterClientWithHTTPClient(ctx context.Context, creds *google.Credentials, hc *http.Client) (*ClusterClient, error) {
+	client, err := container.NewService(ctx, option.WithHTTPClient(hc))
 	if err != nil {
 		return nil, err
 	}
@@ -70,12 +81,16 @@ func (c *ClusterClient) CreateCluster(name string, spec computev1alpha1.GKEClust
 			Name:                  name,
 			InitialClusterVersion: spec.ClusterVersion,
 			InitialNodeCount:      spec.NumNodes,
+			Network:               spec.Network,
+			Subnetwork:            spec.Subnetwork,
 			IpAllocationPolicy: &container.IPAllocationPolicy{
-				UseIpAliases:          spec.EnableIPAlias,
-				CreateSubnetwork:      spec.CreateSubnetwork,
-				NodeIpv4CidrBlock:     spec.NodeIPV4CIDR,
-				ClusterIpv4CidrBlock:  spec.ClusterIPV4CIDR,
-				ServicesIpv4CidrBlock: spec.ServiceIPV4CIDR,
+				UseIpAliases:               spec.EnableIPAlias,
+				CreateSubnetwork:           spec.CreateSubnetwork,
+				NodeIpv4CidrBlock:          spec.NodeIPV4CIDR,
+				ClusterIpv4CidrBlock:       spec.ClusterIPV4CIDR,
+				ServicesIpv4CidrBlock:      spec.ServiceIPV4CIDR,
+				ClusterSecondaryRangeName:  spec.ClusterSecondaryRangeName,
+				ServicesSecondaryRangeName: spec.ServiceSecondaryRangeName,
 			},
 			NodeConfig: &container.NodeConfig{
 				MachineType: spec.MachineType,
@@ -0,0 +1,210 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sim provides a simulated GKE API.
+package sim
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"google.golang.org/api/container/v1"
+	"google.golang.org/api/googleapi"
+
+	computev1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/compute/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/gcp/gke"
+	"github.com/crossplaneio/crossplane/pkg/clients/sim"
+)
+
+// Cluster states.
+const (
+	stateProvisioning = computev1alpha1.ClusterStateProvisioning
+	stateRunning      = computev1alpha1.ClusterStateRunning
+	stateStopping     = "STOPPING"
+)
+
+// Defaults of the simulated API.
+const (
+	defaultVersion = "1.13.7-gke.8"
+	adminUser      = "admin"
+)
+
+// clusterCACertificate is the base64 encoded CA certificate of every
+// simulated cluster.
+var clusterCACertificate = base64.StdEncoding.EncodeToString([]byte("simulated cluster CA certificate"))
+
+type cluster struct {
+	lifecycle *sim.Lifecycle
+	spec      container.Cluster
+	index     int
+}
+
+// A Client is a simulated GKE API. Clusters are created in the PROVISIONING
+// state and become RUNNING after they have been observed Polls times. Deleted
+// clusters are STOPPING until they have been observed Polls times. Errors may
+// be injected into any method, keyed by its name.
+type Client struct {
+	sim.Failures
+
+	// Polls is the number of times a cluster in a transitional state must be
+	// observed before it settles.
+	Polls int
+
+	mu       sync.Mutex
+	clusters map[string]*cluster
+	count    int
+}
+
+var _ gke.Client = &Client{}
+
+// NewClient returns a simulated GKE API with no clusters.
+func NewClient() *Client {
+	return &Client{Polls: sim.DefaultPolls, clusters: map[string]*cluster{}}
+}
+
+// CreateCluster creates a cluster with the supplied name and spec.
+func (c *Client) CreateCluster(name string, spec computev1alpha1.GKEClusterSpec) (*container.Cluster, error) {
+	if err := c.Call("CreateCluster"); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	k := key(spec.Zone, name)
+	if _, ok := c.clusters[k]; ok {
+		return nil, &googleapi.Error{Code: http.StatusConflict, Message: fmt.Sprintf("Already exists: projects/sim-project/zones/%s/clusters/%s.", spec.Zone, name)}
+	}
+	version := spec.ClusterVersion
+	if version == "" {
+		version = defaultVersion
+	}
+	c.count++
+	cl := &cluster{
+		lifecycle: sim.NewLifecycle(stateProvisioning).Then(stateRunning, c.Polls),
+		index:     c.count,
+		spec: container.Cluster{
+			Name:                 name,
+			Zone:                 spec.Zone,
+			Location:             spec.Zone,
+			CurrentMasterVersion: version,
+			CurrentNodeVersion:   version,
+			CurrentNodeCount:     spec.NumNodes,
+			InitialNodeCount:     spec.NumNodes,
+			Network:              spec.Network,
+			Subnetwork:           spec.Subnetwork,
+			ResourceLabels:       spec.Labels,
+			NodeConfig:           &container.NodeConfig{MachineType: spec.MachineType, OauthScopes: spec.Scopes},
+			MasterAuth: &container.MasterAuth{
+				Username:             adminUser,
+				Password:             fmt.Sprintf("sim-password-%d", c.count),
+				ClusterCaCertificate: clusterCACertificate,
+			},
+		},
+	}
+	c.clusters[k] = cl
+	return view(cl), nil
+}
+
+// GetCluster returns the named cluster in the supplied zone.
+func (c *Client) GetCluster(zone, name string) (*container.Cluster, error) {
+	if err := c.Call("GetCluster"); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	k := key(zone, name)
+	cl, ok := c.clusters[k]
+	if !ok {
+		return nil, clusterNotFound(zone, name)
+	}
+	if cl.lifecycle.Observe() == sim.Deleted {
+		delete(c.clusters, k)
+		return nil, clusterNotFound(zone, name)
+	}
+	return view(cl), nil
+}
+
+// DeleteCluster starts deleting the named cluster in the supplied zone. Like
+// the GKE client, it succeeds if the cluster does not exist.
+func (c *Client) DeleteCluster(zone, name string) error {
+	if err := c.Call("DeleteCluster"); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cl, ok := c.clusters[key(zone, name)]
+	if !ok {
+		return nil
+	}
+	if cl.lifecycle.State() != stateStopping {
+		cl.lifecycle.Set(stateStopping).Then(sim.Deleted, c.Polls)
+	}
+	return nil
+}
+
+// Settle completes all pending cluster transitions immediately.
+func (c *Client) Settle() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k, cl := range c.clusters {
+		if cl.lifecycle.Settle() == sim.Deleted {
+			delete(c.clusters, k)
+		}
+	}
+}
+
+// Clusters returns the zone qualified names of all clusters that exist,
+// including those that are being created or deleted, in order.
+func (c *Client) Clusters() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	names := make([]string, 0, len(c.clusters))
+	for k := range c.clusters {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func view(cl *cluster) *container.Cluster {
+	out := cl.spec
+	auth := *cl.spec.MasterAuth
+	out.MasterAuth = &auth
+	out.Status = cl.lifecycle.State()
+	out.SelfLink = fmt.Sprintf("https://container.googleapis.com/v1/projects/sim-project/zones/%s/clusters/%s", out.Zone, out.Name)
+	if out.Status != stateProvisioning {
+		out.Endpoint = fmt.Sprintf("35.0.0.%d", cl.index)
+	}
+	return &out
+}
+
+func key(zone, name string) string {
+	return zone + "/" + name
+}
+
+func clusterNotFound(zone, name string) error {
+	return &googleapi.Error{Code: http.StatusNotFound, Message: fmt.Sprintf("Not found: projects/sim-project/zones/%s/clusters/%s.", zone, name)}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sim
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	computev1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/compute/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/gcp"
+)
+
+const (
+	clusterName = "cool-cluster"
+	zone        = "us-central1-a"
+)
+
+func TestClusterLifecycle(t *testing.T) {
+	c := NewClient()
+	spec := computev1alpha1.GKEClusterSpec{}
+	spec.Zone = zone
+
+	if _, err := c.CreateCluster(clusterName, spec); err != nil {
+		t.Fatalf("c.CreateCluster(...): %s", err)
+	}
+	if _, err := c.CreateCluster(clusterName, spec); err == nil {
+		t.Errorf("c.CreateCluster(...): want error creating a cluster that exists, got nil")
+	}
+
+	want := []string{stateProvisioning, stateRunning}
+	got := []string{}
+	for i := 0; i < c.Polls; i++ {
+		cl, err := c.GetCluster(zone, clusterName)
+		if err != nil {
+			t.Fatalf("c.GetCluster(...): %s", err)
+		}
+		got = append(got, cl.Status)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("c.GetCluster(...): -want states, +got states:\n%s", diff)
+	}
+
+	if err := c.DeleteCluster(zone, clusterName); err != nil {
+		t.Fatalf("c.DeleteCluster(...): %s", err)
+	}
+	cl, err := c.GetCluster(zone, clusterName)
+	if err != nil {
+		t.Fatalf("c.GetCluster(...): %s", err)
+	}
+	if diff := cmp.Diff(stateStopping, cl.Status); diff != "" {
+		t.Errorf("c.GetCluster(...): deleting cluster: -want state, +got state:\n%s", diff)
+	}
+	c.Settle()
+	if _, err := c.GetCluster(zone, clusterName); !gcp.IsErrorNotFound(err) {
+		t.Errorf("c.GetCluster(...): want not found error after deletion, got %v", err)
+	}
+	if err := c.DeleteCluster(zone, clusterName); err != nil {
+		t.Errorf("c.DeleteCluster(...): deleting a missing cluster: %s", err)
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	servicenetworking "google.golang.org/api/servicenetworking/v1"
+
+	gcpsn "github.com/crossplaneio/crossplane/pkg/clients/gcp/servicenetworking"
+)
+
+// MockConnectionClient implements the ConnectionService interface.
+type MockConnectionClient struct {
+	MockGet           func(ctx context.Context, parent, network string) (*servicenetworking.Connection, error)
+	MockCreate        func(ctx context.Context, parent string, cn *servicenetworking.Connection) error
+	MockPatch         func(ctx context.Context, parent string, cn *servicenetworking.Connection) error
+	MockRemovePeering func(ctx context.Context, network, peering string) error
+}
+
+// Interface validation
+var _ gcpsn.ConnectionService = &MockConnectionClient{}
+
+// Get calls the MockConnectionClient's MockGet method.
+func (c *MockConnectionClient) Get(ctx context.Context, parent, network string) (*servicenetworking.Connection, error) {
+	return c.MockGet(ctx, parent, network)
+}
+
+// Create calls the MockConnectionClient's MockCreate method.
+func (c *MockConnectionClient) Create(ctx context.Context, parent string, cn *servicenetworking.Connection) error {
+	return c.MockCreate(ctx, parent, cn)
+}
+
+// Patch calls the MockConnectionClient's MockPatch method.
+func (c *MockConnectionClient) Patch(ctx context.Context, parent string, cn *servicenetworking.Connection) error {
+	return c.MockPatch(ctx, parent, cn)
+}
+
+// RemovePeering calls the MockConnectionClient's MockRemovePeering method.
+func (c *MockConnectionClient) RemovePeering(ctx context.Context, network, peering string) error {
+	return c.MockRemovePeering(ctx, network, peering)
+}
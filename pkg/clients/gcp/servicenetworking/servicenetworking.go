@@ -0,0 +1,196 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package servicenetworking contains a client for GCP private services access
+// connections.
+package servicenetworking
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	servicenetworking "google.golang.org/api/servicenetworking/v1"
+
+	"github.com/crossplaneio/crossplane/gcp/apis/servicenetworking/v1alpha1"
+)
+
+const (
+	// DefaultScope for the service networking client.
+	DefaultScope = servicenetworking.CloudPlatformScope
+
+	// API is the name of the GCP API used by service networking clients. The
+	// compute API calls made by these clients are limited as part of it.
+	API = "servicenetworking.googleapis.com"
+)
+
+// ConnectionService provides an interface for operations on private services
+// access connections.
+type ConnectionService interface {
+	Get(ctx context.Context, parent, network string) (*servicenetworking.Connection, error)
+	Create(ctx context.Context, parent string, cn *servicenetworking.Connection) error
+	Patch(ctx context.Context, parent string, cn *servicenetworking.Connection) error
+	RemovePeering(ctx context.Context, network, peering string) error
+}
+
+// ConnectionClient implements the ConnectionService interface.
+type ConnectionClient struct {
+	connections *servicenetworking.ServicesConnectionsService
+	projects    *compute.ProjectsService
+	networks    *compute.NetworksService
+	projectID   string
+}
+
+// Interface validation
+var _ ConnectionService = &ConnectionClient{}
+
+// NewConnectionClient creates a new instance of ConnectionClient. Requests are
+// made using the supplied HTTP client, which must be authorized by the supplied
+// credentials, or using a new HTTP client authorized by them if it is nil.
+func NewConnectionClient(ctx context.Context, creds *google.Credentials, hc *http.Client) (*ConnectionClient, error) {
+	if hc == nil {
+		hc = oauth2.NewClient(ctx, creds.TokenSource)
+	}
+
+	sn, err := servicenetworking.NewService(ctx, option.WithHTTPClient(hc))
+	if err != nil {
+		return nil, err
+	}
+	cs, err := compute.NewService(ctx, option.WithHTTPClient(hc))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConnectionClient{
+		connections: sn.Services.Connections,
+		projects:    cs.Projects,
+		networks:    cs.Networks,
+		projectID:   creds.ProjectID,
+	}, nil
+}
+
+// Get the connection of the supplied network to the supplied service
+// producer. A googleapi.Error with status 404 is returned if the network is
+// not connected.
+func (c *ConnectionClient) Get(ctx context.Context, parent, network string) (*servicenetworking.Connection, error) {
+	n, err := c.consumerNetwork(ctx, network)
+	if err != nil {
+		return nil, err
+	}
+	rsp, err := c.connections.List(parent).Network(n).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	for _, cn := range rsp.Connections {
+		return cn, nil
+	}
+	return nil, &googleapi.Error{
+		Code:    http.StatusNotFound,
+		Message: fmt.Sprintf("network %s is not connected to %s", network, parent),
+	}
+}
+
+// Create a connection to the supplied service producer. Connections are
+// created asynchronously.
+func (c *ConnectionClient) Create(ctx context.Context, parent string, cn *servicenetworking.Connection) error {
+	n, err := c.consumerNetwork(ctx, cn.Network)
+	if err != nil {
+		return err
+	}
+	cn.Network = n
+	_, err = c.connections.Create(parent, cn).Context(ctx).Do()
+	return err
+}
+
+// Patch the reserved peering ranges of the connection to the supplied service
+// producer. Ranges are removed even if they are in use.
+func (c *ConnectionClient) Patch(ctx context.Context, parent string, cn *servicenetworking.Connection) error {
+	n, err := c.consumerNetwork(ctx, cn.Network)
+	if err != nil {
+		return err
+	}
+	cn.Network = n
+	_, err = c.connections.Patch(parent+"/connections/-", cn).UpdateMask("reservedPeeringRanges").Force(true).Context(ctx).Do()
+	return err
+}
+
+// RemovePeering removes the VPC network peering with the supplied name from
+// the supplied network. The service networking API cannot delete connections,
+// so they are deleted by removing their peering.
+func (c *ConnectionClient) RemovePeering(ctx context.Context, network, peering string) error {
+	project, name := parseNetwork(network, c.projectID)
+	_, err := c.networks.RemovePeering(project, name, &compute.NetworksRemovePeeringRequest{Name: peering}).Context(ctx).Do()
+	return err
+}
+
+// consumerNetwork returns the supplied network in the form required by the
+// service networking API, i.e. projects/{project-number}/global/networks/{name}.
+func (c *ConnectionClient) consumerNetwork(ctx context.Context, network string) (string, error) {
+	project, name := parseNetwork(network, c.projectID)
+	p, err := c.projects.Get(project).Context(ctx).Do()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("projects/%d/global/networks/%s", p.Id, name), nil
+}
+
+// parseNetwork returns the project and name of the supplied network, which may
+// be a URL, a relative path such as projects/p/global/networks/n, or a name.
+// The supplied default project is returned if the network does not specify
+// one.
+func parseNetwork(network, defaultProject string) (project, name string) {
+	parts := strings.Split(strings.Trim(network, "/"), "/")
+	project = defaultProject
+	for i := 0; i < len(parts)-1; i++ {
+		if parts[i] == "projects" {
+			project = parts[i+1]
+		}
+	}
+	return project, parts[len(parts)-1]
+}
+
+// NewConnection returns a connection suitable for use with the GCP API.
+func NewConnection(cn *v1alpha1.Connection) *servicenetworking.Connection {
+	return &servicenetworking.Connection{
+		Network:               cn.Spec.Network,
+		ReservedPeeringRanges: cn.Spec.ReservedPeeringRanges,
+	}
+}
+
+// ConnectionNeedsUpdate returns true if the reserved peering ranges of the
+// supplied GCP connection differ from those of the supplied Connection.
+func ConnectionNeedsUpdate(cn *v1alpha1.Connection, existing *servicenetworking.Connection) bool {
+	want := append([]string{}, cn.Spec.ReservedPeeringRanges...)
+	got := append([]string{}, existing.ReservedPeeringRanges...)
+	if len(want) != len(got) {
+		return true
+	}
+	sort.Strings(want)
+	sort.Strings(got)
+	for i := range want {
+		if want[i] != got[i] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicenetworking
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	servicenetworking "google.golang.org/api/servicenetworking/v1"
+
+	"github.com/crossplaneio/crossplane/gcp/apis/servicenetworking/v1alpha1"
+)
+
+func TestParseNetwork(t *testing.T) {
+	type want struct {
+		project string
+		name    string
+	}
+
+	cases := map[string]struct {
+		network string
+		want    want
+	}{
+		"URL": {
+			network: "https://www.googleapis.com/compute/v1/projects/cool-project/global/networks/cool-network",
+			want:    want{project: "cool-project", name: "cool-network"},
+		},
+		"RelativePath": {
+			network: "projects/cool-project/global/networks/cool-network",
+			want:    want{project: "cool-project", name: "cool-network"},
+		},
+		"GlobalPath": {
+			network: "global/networks/cool-network",
+			want:    want{project: "default-project", name: "cool-network"},
+		},
+		"Name": {
+			network: "cool-network",
+			want:    want{project: "default-project", name: "cool-network"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			project, n := parseNetwork(tc.network, "default-project")
+			if diff := cmp.Diff(tc.want, want{project: project, name: n}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("parseNetwork(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConnectionNeedsUpdate(t *testing.T) {
+	cases := map[string]struct {
+		cn       *v1alpha1.Connection
+		existing *servicenetworking.Connection
+		want     bool
+	}{
+		"SameRangesInDifferentOrder": {
+			cn: &v1alpha1.Connection{Spec: v1alpha1.ConnectionSpec{ConnectionParameters: v1alpha1.ConnectionParameters{
+				ReservedPeeringRanges: []string{"a", "b"},
+			}}},
+			existing: &servicenetworking.Connection{ReservedPeeringRanges: []string{"b", "a"}},
+			want:     false,
+		},
+		"RangeAdded": {
+			cn: &v1alpha1.Connection{Spec: v1alpha1.ConnectionSpec{ConnectionParameters: v1alpha1.ConnectionParameters{
+				ReservedPeeringRanges: []string{"a", "b"},
+			}}},
+			existing: &servicenetworking.Connection{ReservedPeeringRanges: []string{"a"}},
+			want:     true,
+		},
+		"RangeReplaced": {
+			cn: &v1alpha1.Connection{Spec: v1alpha1.ConnectionSpec{ConnectionParameters: v1alpha1.ConnectionParameters{
+				ReservedPeeringRanges: []string{"a", "c"},
+			}}},
+			existing: &servicenetworking.Connection{ReservedPeeringRanges: []string{"a", "b"}},
+			want:     true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ConnectionNeedsUpdate(tc.cn, tc.existing)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ConnectionNeedsUpdate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -22,6 +22,9 @@ import (
 	"cloud.google.com/go/storage"
 )
 
+// API is the name of the GCP API used by storage clients.
+const API = "storage.googleapis.com"
+
 // Client bucket resource operations interface
 type Client interface {
 	Attrs(context.Context) (*storage.BucketAttrs, error)
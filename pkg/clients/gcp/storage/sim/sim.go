@@ -0,0 +1,196 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sim provides a simulated Cloud Storage API.
+package sim
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/googleapi"
+
+	gcpstorage "github.com/crossplaneio/crossplane/pkg/clients/gcp/storage"
+	"github.com/crossplaneio/crossplane/pkg/clients/sim"
+)
+
+// defaultLocation is the location of buckets created without one.
+const defaultLocation = "US"
+
+// A Service is a simulated Cloud Storage API. Cloud Storage creates, updates,
+// and deletes buckets synchronously, so the simulated API has no transitional
+// states. Errors may be injected into any bucket method, keyed by its name,
+// for example "Attrs" or "Update".
+type Service struct {
+	sim.Failures
+
+	mu      sync.Mutex
+	buckets map[string]*storage.BucketAttrs
+}
+
+// NewService returns a simulated Cloud Storage API with no buckets.
+func NewService() *Service {
+	return &Service{buckets: map[string]*storage.BucketAttrs{}}
+}
+
+// Bucket returns a client for the named bucket, which need not exist.
+func (s *Service) Bucket(name string) *BucketClient {
+	return &BucketClient{s: s, name: name}
+}
+
+// Buckets returns the names of all buckets that exist, in order.
+func (s *Service) Buckets() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := make([]string, 0, len(s.buckets))
+	for name := range s.buckets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// A BucketClient operates on a single bucket of a simulated Cloud Storage
+// API.
+type BucketClient struct {
+	s    *Service
+	name string
+}
+
+var _ gcpstorage.Client = &BucketClient{}
+
+// Attrs returns the attributes of the bucket.
+func (c *BucketClient) Attrs(_ context.Context) (*storage.BucketAttrs, error) {
+	if err := c.s.Call("Attrs"); err != nil {
+		return nil, err
+	}
+
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+
+	a, ok := c.s.buckets[c.name]
+	if !ok {
+		return nil, storage.ErrBucketNotExist
+	}
+	out := *a
+	return &out, nil
+}
+
+// Create creates the bucket in the supplied project with the supplied
+// attributes. Predefined ACLs are applied and then forgotten, as they are by
+// the real API.
+func (c *BucketClient) Create(_ context.Context, projectID string, attrs *storage.BucketAttrs) error {
+	if err := c.s.Call("Create"); err != nil {
+		return err
+	}
+
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+
+	if _, ok := c.s.buckets[c.name]; ok {
+		return &googleapi.Error{Code: http.StatusConflict, Message: fmt.Sprintf("You already own bucket %s.", c.name)}
+	}
+	a := &storage.BucketAttrs{}
+	if attrs != nil {
+		*a = *attrs
+	}
+	a.Name = c.name
+	a.Created = time.Now().UTC()
+	a.MetaGeneration = 1
+	a.PredefinedACL = ""
+	a.PredefinedDefaultObjectACL = ""
+	if a.Location == "" {
+		a.Location = defaultLocation
+	}
+	if a.StorageClass == "" {
+		a.StorageClass = "STANDARD"
+	}
+	c.s.buckets[c.name] = a
+	return nil
+}
+
+// Update applies the supplied updates to the bucket. Label updates are not
+// simulated, because the labels to set and delete are not exported by the
+// storage package.
+func (c *BucketClient) Update(_ context.Context, u storage.BucketAttrsToUpdate) (*storage.BucketAttrs, error) {
+	if err := c.s.Call("Update"); err != nil {
+		return nil, err
+	}
+
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+
+	a, ok := c.s.buckets[c.name]
+	if !ok {
+		return nil, storage.ErrBucketNotExist
+	}
+	if v, ok := u.VersioningEnabled.(bool); ok {
+		a.VersioningEnabled = v
+	}
+	if v, ok := u.RequesterPays.(bool); ok {
+		a.RequesterPays = v
+	}
+	if v, ok := u.DefaultEventBasedHold.(bool); ok {
+		a.DefaultEventBasedHold = v
+	}
+	if u.BucketPolicyOnly != nil {
+		a.BucketPolicyOnly = *u.BucketPolicyOnly
+	}
+	if u.CORS != nil {
+		a.CORS = u.CORS
+	}
+	if u.Lifecycle != nil {
+		a.Lifecycle = *u.Lifecycle
+	}
+	if u.Logging != nil {
+		a.Logging = u.Logging
+	}
+	if u.Website != nil {
+		a.Website = u.Website
+	}
+	if u.Encryption != nil {
+		a.Encryption = u.Encryption
+	}
+	if u.RetentionPolicy != nil {
+		a.RetentionPolicy = u.RetentionPolicy
+	}
+	a.MetaGeneration++
+
+	out := *a
+	return &out, nil
+}
+
+// Delete deletes the bucket.
+func (c *BucketClient) Delete(_ context.Context) error {
+	if err := c.s.Call("Delete"); err != nil {
+		return err
+	}
+
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+
+	if _, ok := c.s.buckets[c.name]; !ok {
+		return storage.ErrBucketNotExist
+	}
+	delete(c.s.buckets, c.name)
+	return nil
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sim
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+var errBoom = errors.New("boom")
+
+func TestBucketLifecycle(t *testing.T) {
+	ctx := context.Background()
+	s := NewService()
+	b := s.Bucket("cool-bucket")
+
+	if _, err := b.Attrs(ctx); err != storage.ErrBucketNotExist {
+		t.Errorf("b.Attrs(...): want %v before creation, got %v", storage.ErrBucketNotExist, err)
+	}
+	if err := b.Create(ctx, "cool-project", &storage.BucketAttrs{PredefinedACL: "private"}); err != nil {
+		t.Fatalf("b.Create(...): %s", err)
+	}
+	if err := b.Create(ctx, "cool-project", nil); err == nil {
+		t.Errorf("b.Create(...): want error creating a bucket that exists, got nil")
+	}
+
+	got, err := b.Update(ctx, storage.BucketAttrsToUpdate{VersioningEnabled: true})
+	if err != nil {
+		t.Fatalf("b.Update(...): %s", err)
+	}
+	if !got.VersioningEnabled || got.MetaGeneration != 2 || got.PredefinedACL != "" {
+		t.Errorf("b.Update(...): want versioned bucket at generation 2 without a predefined ACL, got %+v", got)
+	}
+
+	s.Inject("Delete", errBoom, 1)
+	if err := b.Delete(ctx); err != errBoom {
+		t.Errorf("b.Delete(...): want injected error %v, got %v", errBoom, err)
+	}
+	if err := b.Delete(ctx); err != nil {
+		t.Fatalf("b.Delete(...): %s", err)
+	}
+	if diff := cmp.Diff([]string{}, s.Buckets()); diff != "" {
+		t.Errorf("s.Buckets(): -want, +got:\n%s", diff)
+	}
+}
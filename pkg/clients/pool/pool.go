@@ -0,0 +1,430 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pool caches cloud API clients per provider, and rate limits the
+// requests they make.
+package pool
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/plugin/ochttp"
+	"go.opencensus.io/trace"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplaneio/crossplane/pkg/metrics"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
+)
+
+// DefaultLimit is the rate limit applied to requests made to a cloud API
+// using one provider's credentials, unless the pool is configured otherwise.
+var DefaultLimit = Limit{QPS: 10, Burst: 20}
+
+// Backoff applied to all requests made to a cloud API using one provider's
+// credentials once the API reports they are being throttled. The backoff
+// doubles each time the API reports throttling, and resets once a request
+// succeeds.
+const (
+	minBackoff = 1 * time.Second
+	maxBackoff = 2 * time.Minute
+)
+
+// Default is the pool used by Crossplane's controllers.
+var Default = New(DefaultLimit, nil)
+
+// A Limit is a token bucket rate limit.
+type Limit struct {
+	// QPS is the number of requests per second allowed on average.
+	QPS float64
+
+	// Burst is the number of requests allowed at once.
+	Burst int
+}
+
+// ParseLimit parses a limit of the form QPS[:BURST], for example 5:10. The
+// burst defaults to the QPS, rounded up.
+func ParseLimit(s string) (Limit, error) {
+	parts := strings.SplitN(s, ":", 2)
+
+	qps, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil || qps <= 0 {
+		return Limit{}, errors.Errorf("invalid QPS %q", parts[0])
+	}
+	l := Limit{QPS: qps, Burst: int(qps + 0.999)}
+	if len(parts) == 1 {
+		return l, nil
+	}
+
+	burst, err := strconv.Atoi(parts[1])
+	if err != nil || burst <= 0 {
+		return Limit{}, errors.Errorf("invalid burst %q", parts[1])
+	}
+	l.Burst = burst
+	return l, nil
+}
+
+// A Key identifies the clients of one cloud API that use one provider's
+// credentials.
+type Key struct {
+	// Provider is the UID of the provider.
+	Provider types.UID
+
+	// API is the name of the cloud API, for example rds.amazonaws.com.
+	API string
+
+	// Kind distinguishes clients of different types that call the same API,
+	// for example network and subnetwork clients of a compute API. Clients of
+	// all kinds share one limiter per provider and API.
+	Kind string
+}
+
+type entry struct {
+	version string
+	client  interface{}
+}
+
+// A Pool caches cloud API clients per provider, and rate limits the requests
+// they make. A nil Pool caches nothing and does not rate limit requests.
+type Pool struct {
+	fallback Limit
+	limits   map[string]Limit
+
+	mu       sync.Mutex
+	clients  map[Key]entry
+	limiters map[Key]*Limiter
+}
+
+// New returns a Pool that limits requests to each cloud API using each
+// provider's credentials. Requests to APIs without a limit use the supplied
+// fallback limit.
+func New(fallback Limit, limits map[string]Limit) *Pool {
+	return &Pool{
+		fallback: fallback,
+		limits:   limits,
+		clients:  make(map[Key]entry),
+		limiters: make(map[Key]*Limiter),
+	}
+}
+
+// Get returns the client identified by the supplied key. Clients are cached
+// until the supplied version changes, at which point fn is called to create a
+// new client. Clients of other kinds cached for the same provider at another
+// version are discarded, since they use outdated configuration.
+func (p *Pool) Get(k Key, version string, fn func() (interface{}, error)) (interface{}, error) {
+	if p == nil {
+		return fn()
+	}
+
+	p.mu.Lock()
+	e, ok := p.clients[k]
+	p.mu.Unlock()
+	if ok && e.version == version {
+		return e.client, nil
+	}
+
+	// Creating a client may involve reading credentials or authenticating,
+	// so we don't hold the lock while doing so. Concurrent callers may
+	// create the same client; the last one created is cached.
+	c, err := fn()
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	for ek, e := range p.clients {
+		if ek.Provider == k.Provider && e.version != version {
+			delete(p.clients, ek)
+		}
+	}
+	p.clients[k] = entry{version: version, client: c}
+	p.mu.Unlock()
+	return c, nil
+}
+
+// Remove discards the clients and limiters of the supplied provider, for
+// example because it was deleted.
+func (p *Pool) Remove(provider types.UID) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for k := range p.clients {
+		if k.Provider == provider {
+			delete(p.clients, k)
+		}
+	}
+	for k := range p.limiters {
+		if k.Provider == provider {
+			delete(p.limiters, k)
+		}
+	}
+}
+
+// Limiter returns the Limiter shared by all requests to the cloud API using
+// the provider identified by the supplied key.
+func (p *Pool) Limiter(k Key) *Limiter {
+	if p == nil {
+		return nil
+	}
+
+	k.Kind = ""
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if l, ok := p.limiters[k]; ok {
+		return l
+	}
+
+	lm, ok := p.limits[k.API]
+	if !ok {
+		lm = p.fallback
+	}
+	l := NewLimiter(lm)
+	p.limiters[k] = l
+	return l
+}
+
+// Transport returns an HTTP transport that rate limits requests to the cloud
+// API using the provider identified by the supplied key, and records metrics
+// and spans about them. The supplied functions report whether a response indicates
+// requests are being throttled, and name the operation a request calls.
+func (p *Pool) Transport(k Key, throttled ThrottledFn, operation OperationFn) http.RoundTripper {
+	if p == nil {
+		return http.DefaultTransport
+	}
+	return &Transport{
+		Base:      http.DefaultTransport,
+		Limiter:   p.Limiter(k),
+		Throttled: throttled,
+		API:       k.API,
+		Operation: operation,
+		Provider:  k.Provider,
+	}
+}
+
+// HTTPClient returns an HTTP client that uses the Transport returned by
+// Transport.
+func (p *Pool) HTTPClient(k Key, throttled ThrottledFn, operation OperationFn) *http.Client {
+	return &http.Client{Transport: p.Transport(k, throttled, operation)}
+}
+
+// Version returns a version of the supplied provider's configuration that
+// changes when the provider's spec or its named credentials secret changes.
+// An empty secret name indicates the provider does not read its credentials
+// from a secret.
+func Version(ctx context.Context, c client.Client, p metav1.Object, secret string) (string, error) {
+	if secret == "" {
+		return fmt.Sprintf("%d", p.GetGeneration()), nil
+	}
+
+	s := &corev1.Secret{}
+	n := types.NamespacedName{Namespace: p.GetNamespace(), Name: secret}
+	if err := c.Get(ctx, n, s); err != nil {
+		return "", errors.Wrapf(err, "cannot get provider secret %s", n)
+	}
+	return fmt.Sprintf("%d/%s", p.GetGeneration(), s.GetResourceVersion()), nil
+}
+
+// A Limiter rate limits requests, and backs off all requests when any of them
+// are throttled.
+type Limiter struct {
+	limiter *rate.Limiter
+
+	mu      sync.Mutex
+	backoff time.Duration
+	until   time.Time
+}
+
+// NewLimiter returns a Limiter that allows requests at the supplied limit.
+func NewLimiter(l Limit) *Limiter {
+	return &Limiter{limiter: rate.NewLimiter(rate.Limit(l.QPS), l.Burst)}
+}
+
+// Wait until a request is allowed, or the supplied context is done.
+func (l *Limiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	d := time.Until(l.until)
+	l.mu.Unlock()
+
+	if d > 0 {
+		t := time.NewTimer(d)
+		defer t.Stop()
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return l.limiter.Wait(ctx)
+}
+
+// Throttled backs off all requests, doubling the backoff each time requests
+// are throttled.
+func (l *Limiter) Throttled() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.backoff *= 2
+	if l.backoff < minBackoff {
+		l.backoff = minBackoff
+	}
+	if l.backoff > maxBackoff {
+		l.backoff = maxBackoff
+	}
+	l.until = time.Now().Add(l.backoff)
+}
+
+// Succeeded resets the backoff.
+func (l *Limiter) Succeeded() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.backoff = 0
+}
+
+// A ThrottledFn reports whether the supplied response indicates requests are
+// being throttled.
+type ThrottledFn func(rsp *http.Response) bool
+
+// TooManyRequests reports whether the supplied response has status 429 Too
+// Many Requests.
+func TooManyRequests(rsp *http.Response) bool {
+	return rsp.StatusCode == http.StatusTooManyRequests
+}
+
+// An OperationFn returns the name of the cloud API operation the supplied
+// request calls.
+type OperationFn func(req *http.Request) string
+
+// Method names operations by the HTTP method of the request, unless the
+// request's context names the operation.
+func Method(req *http.Request) string {
+	if op, ok := metrics.OperationFrom(req.Context()); ok {
+		return op
+	}
+	return req.Method
+}
+
+// A Transport rate limits HTTP requests, and records metrics and spans about
+// them.
+type Transport struct {
+	// Base transport used to make requests.
+	Base http.RoundTripper
+
+	// Limiter used to rate limit requests. Requests are not rate limited if
+	// it is nil.
+	Limiter *Limiter
+
+	// Throttled reports whether a response indicates requests are being
+	// throttled. Responses with status 429 Too Many Requests are considered
+	// throttled if it is nil.
+	Throttled ThrottledFn
+
+	// API is the name of the cloud API to which requests are made, used to
+	// label metrics.
+	API string
+
+	// Operation names the operation a request calls, used to label
+	// metrics. Operations are named using Method if it is nil.
+	Operation OperationFn
+
+	// Provider is the UID of the provider whose credentials requests use,
+	// used to correlate spans.
+	Provider types.UID
+}
+
+// RoundTrip waits until the request is allowed, if the transport has a
+// limiter, then makes it. The request is traced as a child of the span in its
+// context, if any.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	operation := t.Operation
+	if operation == nil {
+		operation = Method
+	}
+	op := operation(req)
+
+	ctx, s := trace.StartSpan(req.Context(), t.API+"/"+op, trace.WithSpanKind(trace.SpanKindClient))
+	defer s.End()
+	s.AddAttributes(
+		trace.StringAttribute(tracing.AttributeAPI, t.API),
+		trace.StringAttribute(tracing.AttributeOperation, op),
+		trace.StringAttribute(tracing.AttributeProviderUID, string(t.Provider)),
+		trace.StringAttribute(ochttp.MethodAttribute, req.Method),
+		trace.StringAttribute(ochttp.HostAttribute, req.URL.Host),
+		trace.StringAttribute(ochttp.PathAttribute, req.URL.Path),
+	)
+	req = req.WithContext(ctx)
+
+	waiting := time.Now()
+	if err := t.wait(ctx); err != nil {
+		s.SetStatus(trace.Status{Code: int32(codes.Unknown), Message: err.Error()})
+		return nil, errors.Wrap(err, "cannot wait for rate limiter")
+	}
+	if d := time.Since(waiting); d >= time.Millisecond {
+		s.Annotate([]trace.Attribute{trace.Int64Attribute("crossplane.io/wait-ms", int64(d/time.Millisecond))}, "Waited for rate limiter")
+	}
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	start := time.Now()
+	rsp, err := base.RoundTrip(req)
+	metrics.ObserveCloudAPIRequest(t.API, op, rsp, time.Since(start))
+	if err != nil {
+		s.SetStatus(trace.Status{Code: int32(codes.Unknown), Message: err.Error()})
+		return nil, err
+	}
+	s.AddAttributes(trace.Int64Attribute(ochttp.StatusCodeAttribute, int64(rsp.StatusCode)))
+	s.SetStatus(ochttp.TraceStatus(rsp.StatusCode, rsp.Status))
+
+	throttled := t.Throttled
+	if throttled == nil {
+		throttled = TooManyRequests
+	}
+	if throttled(rsp) {
+		s.AddAttributes(trace.BoolAttribute(tracing.AttributeThrottled, true))
+		if t.Limiter != nil {
+			t.Limiter.Throttled()
+		}
+		return rsp, nil
+	}
+	if t.Limiter != nil {
+		t.Limiter.Succeeded()
+	}
+	return rsp, nil
+}
+
+func (t *Transport) wait(ctx context.Context) error {
+	if t.Limiter == nil {
+		return nil
+	}
+	return t.Limiter.Wait(ctx)
+}
@@ -0,0 +1,357 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pool
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	dto "github.com/prometheus/client_model/go"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	"github.com/crossplaneio/crossplane/pkg/metrics"
+)
+
+var errBoom = errors.New("boom")
+
+func TestParseLimit(t *testing.T) {
+	cases := map[string]struct {
+		s       string
+		want    Limit
+		wantErr error
+	}{
+		"QPSAndBurst":  {s: "5:10", want: Limit{QPS: 5, Burst: 10}},
+		"QPSOnly":      {s: "2.5", want: Limit{QPS: 2.5, Burst: 3}},
+		"InvalidQPS":   {s: "fast", wantErr: errors.New(`invalid QPS "fast"`)},
+		"InvalidBurst": {s: "5:0", wantErr: errors.New(`invalid burst "0"`)},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseLimit(tc.s)
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ParseLimit(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ParseLimit(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	k := Key{Provider: "cool-uid", API: "cool.example.org"}
+	calls := 0
+	fn := func() (interface{}, error) { calls++; return calls, nil }
+
+	cases := map[string]struct {
+		pool    *Pool
+		version string
+		fn      func() (interface{}, error)
+		want    interface{}
+		wantErr error
+	}{
+		"Created": {
+			pool:    New(DefaultLimit, nil),
+			version: "1",
+			fn:      fn,
+			want:    1,
+		},
+		"Cached": {
+			pool: func() *Pool {
+				p := New(DefaultLimit, nil)
+				p.clients[k] = entry{version: "1", client: 42}
+				return p
+			}(),
+			version: "1",
+			fn:      fn,
+			want:    42,
+		},
+		"VersionChanged": {
+			pool: func() *Pool {
+				p := New(DefaultLimit, nil)
+				p.clients[k] = entry{version: "1", client: 42}
+				return p
+			}(),
+			version: "2",
+			fn:      func() (interface{}, error) { return 43, nil },
+			want:    43,
+		},
+		"NilPool": {
+			version: "1",
+			fn:      func() (interface{}, error) { return 44, nil },
+			want:    44,
+		},
+		"CreateFailed": {
+			pool:    New(DefaultLimit, nil),
+			version: "1",
+			fn:      func() (interface{}, error) { return nil, errBoom },
+			wantErr: errBoom,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.pool.Get(k, tc.version, tc.fn)
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("p.Get(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("p.Get(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLimiterIsShared(t *testing.T) {
+	p := New(DefaultLimit, map[string]Limit{"slow.example.org": {QPS: 1, Burst: 1}})
+	a := Key{Provider: "cool-uid", API: "slow.example.org"}
+	b := Key{Provider: "cool-uid", API: "fast.example.org"}
+
+	if p.Limiter(a) != p.Limiter(a) {
+		t.Errorf("p.Limiter(...): want the same limiter for the same key")
+	}
+	if p.Limiter(a) == p.Limiter(b) {
+		t.Errorf("p.Limiter(...): want different limiters for different keys")
+	}
+	if got := p.Limiter(a).limiter.Burst(); got != 1 {
+		t.Errorf("p.Limiter(...): want burst 1, got %d", got)
+	}
+
+	c := Key{Provider: "cool-uid", API: "slow.example.org", Kind: "cool"}
+	if p.Limiter(a) != p.Limiter(c) {
+		t.Errorf("p.Limiter(...): want the same limiter for different kinds of client")
+	}
+}
+
+func TestGetKind(t *testing.T) {
+	p := New(DefaultLimit, nil)
+	a := Key{Provider: "cool-uid", API: "cool.example.org", Kind: "a"}
+	b := Key{Provider: "cool-uid", API: "cool.example.org", Kind: "b"}
+
+	if _, err := p.Get(a, "1", func() (interface{}, error) { return "a", nil }); err != nil {
+		t.Fatalf("p.Get(...): %s", err)
+	}
+	got, err := p.Get(b, "1", func() (interface{}, error) { return "b", nil })
+	if err != nil {
+		t.Fatalf("p.Get(...): %s", err)
+	}
+	if got != "b" {
+		t.Errorf("p.Get(...): want a client of kind b, got %v", got)
+	}
+}
+
+func TestGetRemovesStaleVersions(t *testing.T) {
+	p := New(DefaultLimit, nil)
+	a := Key{Provider: "cool-uid", API: "cool.example.org", Kind: "a"}
+	b := Key{Provider: "cool-uid", API: "cool.example.org", Kind: "b"}
+	other := Key{Provider: "other-uid", API: "cool.example.org", Kind: "a"}
+
+	for _, k := range []Key{a, b, other} {
+		if _, err := p.Get(k, "1", func() (interface{}, error) { return k.Kind, nil }); err != nil {
+			t.Fatalf("p.Get(...): %s", err)
+		}
+	}
+	if _, err := p.Get(a, "2", func() (interface{}, error) { return "a", nil }); err != nil {
+		t.Fatalf("p.Get(...): %s", err)
+	}
+
+	want := map[Key]entry{
+		a:     {version: "2", client: "a"},
+		other: {version: "1", client: "a"},
+	}
+	if diff := cmp.Diff(want, p.clients, cmp.AllowUnexported(entry{})); diff != "" {
+		t.Errorf("p.Get(...): -want clients, +got clients:\n%s", diff)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	p := New(DefaultLimit, nil)
+	a := Key{Provider: "cool-uid", API: "cool.example.org"}
+	b := Key{Provider: "other-uid", API: "cool.example.org"}
+
+	for _, k := range []Key{a, b} {
+		if _, err := p.Get(k, "1", func() (interface{}, error) { return string(k.Provider), nil }); err != nil {
+			t.Fatalf("p.Get(...): %s", err)
+		}
+		p.Limiter(k)
+	}
+	p.Remove(a.Provider)
+
+	if _, ok := p.clients[a]; ok {
+		t.Errorf("p.Remove(...): want client of removed provider to be removed")
+	}
+	if _, ok := p.limiters[a]; ok {
+		t.Errorf("p.Remove(...): want limiter of removed provider to be removed")
+	}
+	if _, ok := p.clients[b]; !ok {
+		t.Errorf("p.Remove(...): want client of other provider to be kept")
+	}
+	if _, ok := p.limiters[b]; !ok {
+		t.Errorf("p.Remove(...): want limiter of other provider to be kept")
+	}
+
+	var nilPool *Pool
+	nilPool.Remove(a.Provider)
+}
+
+func TestTransport(t *testing.T) {
+	status := http.StatusTooManyRequests
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(status) }))
+	defer srv.Close()
+
+	l := NewLimiter(Limit{QPS: 100, Burst: 100})
+	hc := &http.Client{Transport: &Transport{Limiter: l}}
+
+	rsp, err := hc.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("hc.Get(...): %s", err)
+	}
+	rsp.Body.Close() // nolint:errcheck
+	if l.backoff != minBackoff || time.Until(l.until) <= 0 {
+		t.Errorf("hc.Get(...): want backoff %s after throttled response, got %s", minBackoff, l.backoff)
+	}
+
+	// Requests made while backing off wait until the backoff has passed.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	if _, err := hc.Do(req.WithContext(ctx)); err == nil {
+		t.Errorf("hc.Do(...): want error while backing off, got none")
+	}
+
+	status = http.StatusOK
+	l.until = time.Now()
+	rsp, err = hc.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("hc.Get(...): %s", err)
+	}
+	rsp.Body.Close() // nolint:errcheck
+	if l.backoff != 0 {
+		t.Errorf("hc.Get(...): want backoff reset after successful response, got %s", l.backoff)
+	}
+}
+
+func TestTransportMetrics(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) }))
+	defer srv.Close()
+
+	hc := &http.Client{Transport: &Transport{
+		Limiter:   NewLimiter(DefaultLimit),
+		API:       "metrics.example.org",
+		Operation: func(_ *http.Request) string { return "CoolOperation" },
+	}}
+
+	rsp, err := hc.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("hc.Get(...): %s", err)
+	}
+	rsp.Body.Close() // nolint:errcheck
+
+	m := &dto.Metric{}
+	if err := metrics.CloudAPIRequests.WithLabelValues("metrics.example.org", "CoolOperation", "200").Write(m); err != nil {
+		t.Fatalf("Write(...): %s", err)
+	}
+	if diff := cmp.Diff(1.0, m.GetCounter().GetValue()); diff != "" {
+		t.Errorf("hc.Get(...): -want requests, +got requests:\n%s", diff)
+	}
+}
+
+func TestTransportWithoutLimiter(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusTooManyRequests) }))
+	defer srv.Close()
+
+	hc := &http.Client{Transport: &Transport{
+		API:       "unlimited.example.org",
+		Operation: func(_ *http.Request) string { return "CoolOperation" },
+	}}
+
+	rsp, err := hc.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("hc.Get(...): %s", err)
+	}
+	rsp.Body.Close() // nolint:errcheck
+
+	m := &dto.Metric{}
+	if err := metrics.CloudAPIRequests.WithLabelValues("unlimited.example.org", "CoolOperation", "429").Write(m); err != nil {
+		t.Fatalf("Write(...): %s", err)
+	}
+	if diff := cmp.Diff(1.0, m.GetCounter().GetValue()); diff != "" {
+		t.Errorf("hc.Get(...): -want requests, +got requests:\n%s", diff)
+	}
+}
+
+func TestMethod(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://example.org", nil)
+	if diff := cmp.Diff(http.MethodGet, Method(req)); diff != "" {
+		t.Errorf("Method(...): -want, +got:\n%s", diff)
+	}
+
+	req = req.WithContext(metrics.WithOperation(req.Context(), "CoolOperation"))
+	if diff := cmp.Diff("CoolOperation", Method(req)); diff != "" {
+		t.Errorf("Method(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestVersion(t *testing.T) {
+	p := &metav1.ObjectMeta{Namespace: "cool-namespace", Name: "cool-provider", Generation: 3}
+
+	cases := map[string]struct {
+		c       client.Client
+		secret  string
+		want    string
+		wantErr error
+	}{
+		"NoSecret": {
+			want: "3",
+		},
+		"Secret": {
+			c: &test.MockClient{MockGet: func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+				obj.(*corev1.Secret).SetResourceVersion("42")
+				return nil
+			}},
+			secret: "cool-secret",
+			want:   "3/42",
+		},
+		"GetSecretFailed": {
+			c:       &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			secret:  "cool-secret",
+			wantErr: errors.Wrap(errBoom, "cannot get provider secret cool-namespace/cool-secret"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Version(context.Background(), tc.c, p, tc.secret)
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Version(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Version(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sim
+
+import "sync"
+
+type failure struct {
+	err error
+
+	// remaining is the number of calls left to fail. A negative value means
+	// every call fails until the failure is cleared.
+	remaining int
+}
+
+// Failures injects errors into the operations of a simulated cloud provider,
+// and counts how many times each operation was called. Operations are
+// identified by name, by convention that of the client method that performs
+// them, for example "CreateInstance". The zero value is ready to use.
+type Failures struct {
+	mu       sync.Mutex
+	failures map[string][]*failure
+	calls    map[string]int
+}
+
+// Inject causes the next n calls of the named operation to fail with the
+// supplied error. Every call fails until the operation is cleared if n is less
+// than one. Injected errors are returned in the order they were injected.
+func (f *Failures) Inject(op string, err error, n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if n < 1 {
+		n = -1
+	}
+	if f.failures == nil {
+		f.failures = map[string][]*failure{}
+	}
+	f.failures[op] = append(f.failures[op], &failure{err: err, remaining: n})
+}
+
+// Clear removes any errors injected into the named operation.
+func (f *Failures) Clear(op string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.failures, op)
+}
+
+// Call records a call of the named operation, and returns the error the call
+// should fail with, if any.
+func (f *Failures) Call(op string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.calls == nil {
+		f.calls = map[string]int{}
+	}
+	f.calls[op]++
+
+	queued := f.failures[op]
+	if len(queued) == 0 {
+		return nil
+	}
+	next := queued[0]
+	if next.remaining > 0 {
+		next.remaining--
+		if next.remaining == 0 {
+			f.failures[op] = queued[1:]
+		}
+	}
+	return next.err
+}
+
+// Calls returns the number of times the named operation has been called.
+func (f *Failures) Calls(op string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.calls[op]
+}
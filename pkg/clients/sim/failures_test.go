@@ -0,0 +1,98 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sim
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+)
+
+func TestFailures(t *testing.T) {
+	errBoom := errors.New("boom")
+	errBang := errors.New("bang")
+
+	type inject struct {
+		op  string
+		err error
+		n   int
+	}
+
+	cases := map[string]struct {
+		inject []inject
+		clear  bool
+		calls  int
+		want   []error
+	}{
+		"NoFailures": {
+			calls: 2,
+			want:  []error{nil, nil},
+		},
+		"FailOnce": {
+			inject: []inject{{op: "Create", err: errBoom, n: 1}},
+			calls:  2,
+			want:   []error{errBoom, nil},
+		},
+		"FailForever": {
+			inject: []inject{{op: "Create", err: errBoom}},
+			calls:  3,
+			want:   []error{errBoom, errBoom, errBoom},
+		},
+		"FailInOrder": {
+			inject: []inject{{op: "Create", err: errBoom, n: 2}, {op: "Create", err: errBang, n: 1}},
+			calls:  4,
+			want:   []error{errBoom, errBoom, errBang, nil},
+		},
+		"OtherOperation": {
+			inject: []inject{{op: "Delete", err: errBoom}},
+			calls:  1,
+			want:   []error{nil},
+		},
+		"Cleared": {
+			inject: []inject{{op: "Create", err: errBoom}},
+			clear:  true,
+			calls:  1,
+			want:   []error{nil},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			f := &Failures{}
+			for _, i := range tc.inject {
+				f.Inject(i.op, i.err, i.n)
+			}
+			if tc.clear {
+				f.Clear("Create")
+			}
+
+			got := make([]error, 0, tc.calls)
+			for i := 0; i < tc.calls; i++ {
+				got = append(got, f.Call("Create"))
+			}
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("Call(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.calls, f.Calls("Create")); diff != "" {
+				t.Errorf("Calls(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
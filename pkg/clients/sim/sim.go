@@ -0,0 +1,103 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sim provides the building blocks of simulated cloud providers.
+// Simulated providers are stateful, in-process stand-ins for cloud provider
+// APIs. Unlike the fakes that accompany each client, which return whatever a
+// test tells them to, simulated providers remember the resources that are
+// created, move them through the same asynchronous states the real API does,
+// and fail when asked to. Controllers can be tested end to end against them
+// without a network.
+package sim
+
+// DefaultPolls is the number of times a simulated resource in a transitional
+// state, for example "creating", must be observed before it settles.
+const DefaultPolls = 2
+
+// Deleted is the state of a simulated resource that no longer exists.
+// Simulated providers forget a resource once it is observed in this state.
+const Deleted = "<deleted>"
+
+type step struct {
+	state string
+	polls int
+}
+
+// A Lifecycle tracks the state of a simulated resource. Cloud providers
+// create, modify, and delete most resources asynchronously; a resource passes
+// through a transitional state before it settles in the state that was asked
+// for. A Lifecycle models this by moving to its next state only after it has
+// been observed a number of times. A Lifecycle is not safe for concurrent
+// use; simulated providers guard their Lifecycles with their own locks.
+type Lifecycle struct {
+	state   string
+	pending []step
+}
+
+// NewLifecycle returns a Lifecycle in the supplied state.
+func NewLifecycle(state string) *Lifecycle {
+	return &Lifecycle{state: state}
+}
+
+// State returns the current state of the Lifecycle without observing it.
+func (l *Lifecycle) State() string {
+	return l.state
+}
+
+// Then queues a transition to the supplied state. The transition completes
+// once any transitions queued before it have completed and the Lifecycle has
+// then been observed the supplied number of times.
+func (l *Lifecycle) Then(state string, polls int) *Lifecycle {
+	l.pending = append(l.pending, step{state: state, polls: polls})
+	return l
+}
+
+// Set moves the Lifecycle to the supplied state immediately, discarding any
+// pending transitions.
+func (l *Lifecycle) Set(state string) *Lifecycle {
+	l.state = state
+	l.pending = nil
+	return l
+}
+
+// Observe advances any pending transition by one poll and returns the
+// resulting state of the Lifecycle.
+func (l *Lifecycle) Observe() string {
+	if len(l.pending) == 0 {
+		return l.state
+	}
+	l.pending[0].polls--
+	if l.pending[0].polls <= 0 {
+		l.state = l.pending[0].state
+		l.pending = l.pending[1:]
+	}
+	return l.state
+}
+
+// Settle completes any pending transitions immediately, leaving the Lifecycle
+// in the state it would eventually have settled in.
+func (l *Lifecycle) Settle() string {
+	if n := len(l.pending); n > 0 {
+		l.state = l.pending[n-1].state
+		l.pending = nil
+	}
+	return l.state
+}
+
+// Settled returns true if the Lifecycle has no pending transitions.
+func (l *Lifecycle) Settled() bool {
+	return len(l.pending) == 0
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sim
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLifecycle(t *testing.T) {
+	type want struct {
+		observed []string
+		settled  bool
+	}
+
+	cases := map[string]struct {
+		l     *Lifecycle
+		polls int
+		want  want
+	}{
+		"NoTransitions": {
+			l:     NewLifecycle("available"),
+			polls: 2,
+			want: want{
+				observed: []string{"available", "available"},
+				settled:  true,
+			},
+		},
+		"SingleTransition": {
+			l:     NewLifecycle("creating").Then("available", 2),
+			polls: 3,
+			want: want{
+				observed: []string{"creating", "available", "available"},
+				settled:  true,
+			},
+		},
+		"ChainedTransitions": {
+			l:     NewLifecycle("available").Then("modifying", 1).Then("available", 2),
+			polls: 3,
+			want: want{
+				observed: []string{"modifying", "modifying", "available"},
+				settled:  true,
+			},
+		},
+		"ImmediateTransition": {
+			l:     NewLifecycle("deleting").Then(Deleted, 0),
+			polls: 1,
+			want: want{
+				observed: []string{Deleted},
+				settled:  true,
+			},
+		},
+		"Pending": {
+			l:     NewLifecycle("creating").Then("available", 5),
+			polls: 2,
+			want: want{
+				observed: []string{"creating", "creating"},
+				settled:  false,
+			},
+		},
+		"Set": {
+			l:     NewLifecycle("creating").Then("available", 5).Set("failed"),
+			polls: 1,
+			want: want{
+				observed: []string{"failed"},
+				settled:  true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := make([]string, 0, tc.polls)
+			for i := 0; i < tc.polls; i++ {
+				got = append(got, tc.l.Observe())
+			}
+			if diff := cmp.Diff(tc.want.observed, got); diff != "" {
+				t.Errorf("Observe(): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.settled, tc.l.Settled()); diff != "" {
+				t.Errorf("Settled(): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSettle(t *testing.T) {
+	cases := map[string]struct {
+		l    *Lifecycle
+		want string
+	}{
+		"NoTransitions": {
+			l:    NewLifecycle("available"),
+			want: "available",
+		},
+		"PendingTransitions": {
+			l:    NewLifecycle("creating").Then("available", 5).Then("backing-up", 5),
+			want: "backing-up",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := tc.l.Settle()
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Settle(): -want, +got:\n%s", diff)
+			}
+			if !tc.l.Settled() {
+				t.Errorf("Settled(): want true, got false")
+			}
+		})
+	}
+}
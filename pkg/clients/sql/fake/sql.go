@@ -0,0 +1,86 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"github.com/crossplaneio/crossplane/pkg/clients/sql"
+)
+
+// MockClient implements the Client interface
+type MockClient struct {
+	MockUserExists func(ctx context.Context, name string) (bool, error)
+	MockCreateUser func(ctx context.Context, name, password string) error
+	MockDeleteUser func(ctx context.Context, name string) error
+
+	MockDatabaseExists func(ctx context.Context, name string) (bool, error)
+	MockCreateDatabase func(ctx context.Context, name string) error
+	MockDeleteDatabase func(ctx context.Context, name string) error
+
+	MockGrants func(ctx context.Context, user string) ([]string, error)
+	MockGrant  func(ctx context.Context, user, database string) error
+	MockRevoke func(ctx context.Context, user, database string) error
+}
+
+// Interface validation
+var _ sql.Client = &MockClient{}
+
+// UserExists calls the underlying MockUserExists method.
+func (c *MockClient) UserExists(ctx context.Context, name string) (bool, error) {
+	return c.MockUserExists(ctx, name)
+}
+
+// CreateUser calls the underlying MockCreateUser method.
+func (c *MockClient) CreateUser(ctx context.Context, name, password string) error {
+	return c.MockCreateUser(ctx, name, password)
+}
+
+// DeleteUser calls the underlying MockDeleteUser method.
+func (c *MockClient) DeleteUser(ctx context.Context, name string) error {
+	return c.MockDeleteUser(ctx, name)
+}
+
+// DatabaseExists calls the underlying MockDatabaseExists method.
+func (c *MockClient) DatabaseExists(ctx context.Context, name string) (bool, error) {
+	return c.MockDatabaseExists(ctx, name)
+}
+
+// CreateDatabase calls the underlying MockCreateDatabase method.
+func (c *MockClient) CreateDatabase(ctx context.Context, name string) error {
+	return c.MockCreateDatabase(ctx, name)
+}
+
+// DeleteDatabase calls the underlying MockDeleteDatabase method.
+func (c *MockClient) DeleteDatabase(ctx context.Context, name string) error {
+	return c.MockDeleteDatabase(ctx, name)
+}
+
+// Grants calls the underlying MockGrants method.
+func (c *MockClient) Grants(ctx context.Context, user string) ([]string, error) {
+	return c.MockGrants(ctx, user)
+}
+
+// Grant calls the underlying MockGrant method.
+func (c *MockClient) Grant(ctx context.Context, user, database string) error {
+	return c.MockGrant(ctx, user, database)
+}
+
+// Revoke calls the underlying MockRevoke method.
+func (c *MockClient) Revoke(ctx context.Context, user, database string) error {
+	return c.MockRevoke(ctx, user, database)
+}
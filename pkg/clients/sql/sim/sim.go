@@ -0,0 +1,256 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sim provides a simulated SQL server.
+package sim
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"github.com/crossplaneio/crossplane/pkg/clients/sim"
+	"github.com/crossplaneio/crossplane/pkg/clients/sql"
+)
+
+// A Client is a simulated SQL server of either supported engine. Users,
+// databases, and grants are created and deleted synchronously. The simulated
+// server returns the same errors as the real engine in the cases the SQL
+// controllers care about; for example creating a user that exists fails. As
+// with the real engines, dropping a PostgreSQL database revokes the privileges
+// granted on it, while dropping a MySQL database does not. Errors may be injected into any method, keyed
+// by its name.
+type Client struct {
+	sim.Failures
+
+	engine sql.Engine
+
+	mu        sync.Mutex
+	users     map[string]string
+	databases map[string]bool
+	grants    map[string]map[string]bool
+}
+
+var _ sql.Client = &Client{}
+
+// NewClient returns a simulated SQL server of the supplied engine with no
+// users or databases.
+func NewClient(e sql.Engine) *Client {
+	return &Client{
+		engine:    e,
+		users:     map[string]string{},
+		databases: map[string]bool{},
+		grants:    map[string]map[string]bool{},
+	}
+}
+
+// UserExists returns true if a user with the supplied name exists.
+func (c *Client) UserExists(_ context.Context, name string) (bool, error) {
+	if err := c.Call("UserExists"); err != nil {
+		return false, errors.Wrapf(err, "cannot determine whether user %s exists", name)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.users[name]
+	return ok, nil
+}
+
+// CreateUser creates a user that may log in with the supplied password.
+func (c *Client) CreateUser(_ context.Context, name, password string) error {
+	if err := c.Call("CreateUser"); err != nil {
+		return errors.Wrapf(err, "cannot create user %s", name)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.users[name]; ok {
+		return errors.Wrapf(c.engineError(1396, "42710", "role %q already exists", name), "cannot create user %s", name)
+	}
+	c.users[name] = password
+	c.grants[name] = map[string]bool{}
+	return nil
+}
+
+// DeleteUser deletes the named user and its grants. It does not return an
+// error if the user does not exist.
+func (c *Client) DeleteUser(_ context.Context, name string) error {
+	if err := c.Call("DeleteUser"); err != nil {
+		return errors.Wrapf(err, "cannot delete user %s", name)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.users, name)
+	delete(c.grants, name)
+	return nil
+}
+
+// DatabaseExists returns true if a database with the supplied name exists.
+func (c *Client) DatabaseExists(_ context.Context, name string) (bool, error) {
+	if err := c.Call("DatabaseExists"); err != nil {
+		return false, errors.Wrapf(err, "cannot determine whether database %s exists", name)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.databases[name], nil
+}
+
+// CreateDatabase creates a database with the supplied name.
+func (c *Client) CreateDatabase(_ context.Context, name string) error {
+	if err := c.Call("CreateDatabase"); err != nil {
+		return errors.Wrapf(err, "cannot create database %s", name)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.databases[name] {
+		return errors.Wrapf(c.engineError(1007, "42P04", "database %q already exists", name), "cannot create database %s", name)
+	}
+	c.databases[name] = true
+	return nil
+}
+
+// DeleteDatabase deletes the named database. It does not return an error if
+// the database does not exist.
+func (c *Client) DeleteDatabase(_ context.Context, name string) error {
+	if err := c.Call("DeleteDatabase"); err != nil {
+		return errors.Wrapf(err, "cannot delete database %s", name)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.databases, name)
+	if c.engine == sql.PostgreSQL {
+		for _, databases := range c.grants {
+			delete(databases, name)
+		}
+	}
+	return nil
+}
+
+// Grants returns the databases on which the named user has been granted
+// privileges, in order.
+func (c *Client) Grants(_ context.Context, user string) ([]string, error) {
+	if err := c.Call("Grants"); err != nil {
+		return nil, errors.Wrapf(err, "cannot list grants of user %s", user)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	databases := []string{}
+	for d := range c.grants[user] {
+		databases = append(databases, d)
+	}
+	sort.Strings(databases)
+	return databases, nil
+}
+
+// Grant grants the named user all privileges on the named database. Like
+// MySQL, but unlike PostgreSQL, the database need not exist.
+func (c *Client) Grant(_ context.Context, user, database string) error {
+	if err := c.Call("Grant"); err != nil {
+		return errors.Wrapf(err, "cannot grant user %s privileges on database %s", user, database)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.users[user]; !ok {
+		return errors.Wrapf(c.engineError(1133, "42704", "role %q does not exist", user), "cannot grant user %s privileges on database %s", user, database)
+	}
+	if c.engine == sql.PostgreSQL && !c.databases[database] {
+		return errors.Wrapf(c.engineError(0, "3D000", "database %q does not exist", database), "cannot grant user %s privileges on database %s", user, database)
+	}
+	c.grants[user][database] = true
+	return nil
+}
+
+// Revoke revokes all privileges of the named user on the named database.
+func (c *Client) Revoke(_ context.Context, user, database string) error {
+	if err := c.Call("Revoke"); err != nil {
+		return errors.Wrapf(err, "cannot revoke privileges of user %s on database %s", user, database)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.users[user]; !ok {
+		return errors.Wrapf(c.engineError(1133, "42704", "role %q does not exist", user), "cannot revoke privileges of user %s on database %s", user, database)
+	}
+	if c.engine == sql.MySQL && !c.grants[user][database] {
+		return errors.Wrapf(c.engineError(1141, "", "there is no such grant defined for user %q on database %q", user, database), "cannot revoke privileges of user %s on database %s", user, database)
+	}
+	delete(c.grants[user], database)
+	return nil
+}
+
+// Users returns the names of all users, in order.
+func (c *Client) Users() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	names := make([]string, 0, len(c.users))
+	for name := range c.users {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Databases returns the names of all databases, in order.
+func (c *Client) Databases() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	names := make([]string, 0, len(c.databases))
+	for name := range c.databases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Password returns the password of the named user, if it exists.
+func (c *Client) Password(user string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	p, ok := c.users[user]
+	return p, ok
+}
+
+// engineError returns an error of the Client's engine, identified by the
+// supplied MySQL error number or PostgreSQL SQLSTATE code.
+func (c *Client) engineError(number uint16, code pq.ErrorCode, format string, a ...interface{}) error {
+	if c.engine == sql.PostgreSQL {
+		return &pq.Error{Severity: "ERROR", Code: code, Message: fmt.Sprintf(format, a...)}
+	}
+	return &mysql.MySQLError{Number: number, Message: fmt.Sprintf(format, a...)}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sim
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplaneio/crossplane/pkg/clients/sql"
+)
+
+const (
+	user     = "cool-user"
+	database = "cool-db"
+)
+
+func TestDatabaseLifecycle(t *testing.T) {
+	cases := map[string]struct {
+		engine sql.Engine
+		code   string
+
+		// grantsAfterDrop are the grants of the user after its database is
+		// dropped.
+		grantsAfterDrop []string
+	}{
+		"MySQL": {
+			engine:          sql.MySQL,
+			code:            "1396",
+			grantsAfterDrop: []string{database},
+		},
+		"PostgreSQL": {
+			engine:          sql.PostgreSQL,
+			code:            "42710",
+			grantsAfterDrop: []string{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			c := NewClient(tc.engine)
+
+			if err := c.CreateDatabase(ctx, database); err != nil {
+				t.Fatalf("c.CreateDatabase(...): %s", err)
+			}
+			if err := sql.CreateOwner(ctx, c, user, "cool-password", database); err != nil {
+				t.Fatalf("sql.CreateOwner(...): %s", err)
+			}
+			if granted, err := sql.IsGranted(ctx, c, user, database); !granted || err != nil {
+				t.Errorf("sql.IsGranted(...): want true, got %t, %v", granted, err)
+			}
+			if diff := cmp.Diff(tc.code, sql.ErrorCode(c.CreateUser(ctx, user, "other-password"))); diff != "" {
+				t.Errorf("c.CreateUser(...): -want error code, +got error code:\n%s", diff)
+			}
+
+			if err := c.DeleteDatabase(ctx, database); err != nil {
+				t.Fatalf("c.DeleteDatabase(...): %s", err)
+			}
+			got, err := c.Grants(ctx, user)
+			if err != nil {
+				t.Fatalf("c.Grants(...): %s", err)
+			}
+			if diff := cmp.Diff(tc.grantsAfterDrop, got); diff != "" {
+				t.Errorf("c.Grants(...): -want, +got:\n%s", diff)
+			}
+
+			if err := c.DeleteUser(ctx, user); err != nil {
+				t.Fatalf("c.DeleteUser(...): %s", err)
+			}
+			if diff := cmp.Diff([]string{}, c.Users()); diff != "" {
+				t.Errorf("c.Users(): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,468 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"context"
+	gosql "database/sql"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Engine is a SQL database engine.
+type Engine string
+
+// Supported SQL database engines. The values double as the names of the
+// database/sql drivers that speak to them.
+const (
+	MySQL      Engine = "mysql"
+	PostgreSQL Engine = "postgres"
+)
+
+// Default ports of the supported engines.
+const (
+	DefaultMySQLPort      = "3306"
+	DefaultPostgreSQLPort = "5432"
+)
+
+// PostgreSQL requires a database to connect to. Every PostgreSQL server we
+// manage has one named postgres.
+const postgresMaintenanceDatabase = "postgres"
+
+// MySQL users are created for any host by default; Crossplane does not know
+// where the applications that use them will connect from.
+const mysqlAnyHost = "%"
+
+// MySQL treats the database names of grants as patterns, in which _ and %
+// are wildcards that match any character and any characters respectively.
+// They, and the backslash that escapes them, must be escaped to grant
+// privileges on exactly one database.
+var (
+	mysqlDatabasePattern = strings.NewReplacer(`\`, `\\`, "_", `\_`, "%", `\%`)
+	mysqlDatabaseName    = strings.NewReplacer(`\\`, `\`, `\_`, "_", `\%`, "%")
+)
+
+// Options specify how to connect to a SQL server.
+type Options struct {
+	// Engine of the SQL server.
+	Engine Engine
+
+	// Endpoint of the SQL server, either a host or a host:port pair. The
+	// engine's default port is used if no port is specified.
+	Endpoint string
+
+	// Username and Password of an administrative user of the SQL server.
+	Username string
+	Password string
+
+	// TLS requires a verified, encrypted connection to the SQL server. The
+	// server's certificate must be signed by a trusted certificate authority
+	// and, unless VerifyCAOnly is set, issued for the host of its endpoint.
+	TLS bool
+
+	// CACertificates is a PEM encoded bundle of the certificate authorities
+	// trusted to sign the SQL server's certificate when TLS is required. The
+	// system's certificate authorities are trusted if it is empty.
+	CACertificates []byte
+
+	// VerifyCAOnly skips verifying that the SQL server's certificate was
+	// issued for the host of its endpoint. It should only be set when
+	// CACertificates contains a certificate authority that signs nothing but
+	// the server's certificate, as is the case for Cloud SQL instances, whose
+	// certificates are not issued for the hosts they are reached at.
+	VerifyCAOnly bool
+
+	// UserHost is the host of the MySQL users the client manages. MySQL users
+	// are identified by their name and host. Defaults to any host. Ignored by
+	// PostgreSQL, whose users have no host.
+	UserHost string
+}
+
+// A Client manages users and databases on a SQL server.
+type Client interface {
+	UserExists(ctx context.Context, name string) (bool, error)
+	CreateUser(ctx context.Context, name, password string) error
+	DeleteUser(ctx context.Context, name string) error
+
+	DatabaseExists(ctx context.Context, name string) (bool, error)
+	CreateDatabase(ctx context.Context, name string) error
+	DeleteDatabase(ctx context.Context, name string) error
+
+	Grants(ctx context.Context, user string) ([]string, error)
+	Grant(ctx context.Context, user, database string) error
+	Revoke(ctx context.Context, user, database string) error
+}
+
+type client struct {
+	driver string
+	dsn    string
+	engine Engine
+	host   string
+}
+
+// NewClient returns a Client that connects to the SQL server described by the
+// supplied options. Connections are opened and closed for each operation, so
+// a Client may be safely discarded without being closed.
+func NewClient(o Options) (Client, error) {
+	dsn, err := DSN(o)
+	if err != nil {
+		return nil, err
+	}
+	host := o.UserHost
+	if host == "" {
+		host = mysqlAnyHost
+	}
+	return &client{driver: string(o.Engine), dsn: dsn, engine: o.Engine, host: host}, nil
+}
+
+// ErrorCode returns the error code of the supplied SQL server error, if any.
+// MySQL errors are identified by their error number, and PostgreSQL errors by
+// their SQLSTATE code.
+func ErrorCode(err error) string {
+	switch e := errors.Cause(err).(type) {
+	case *mysql.MySQLError:
+		return strconv.Itoa(int(e.Number))
+	case *pq.Error:
+		return string(e.Code)
+	}
+	return ""
+}
+
+// DSN returns a data source name for the supplied options, suitable for use
+// with the database/sql driver named after the options' engine.
+func DSN(o Options) (string, error) {
+	switch o.Engine {
+	case MySQL:
+		cfg := mysql.NewConfig()
+		cfg.User = o.Username
+		cfg.Passwd = o.Password
+		cfg.Net = "tcp"
+		cfg.Addr = withDefaultPort(o.Endpoint, DefaultMySQLPort)
+		if o.TLS {
+			cfg.TLSConfig = "true"
+		}
+		if o.TLS && (len(o.CACertificates) > 0 || o.VerifyCAOnly) {
+			name, err := registerTLSConfig(o)
+			if err != nil {
+				return "", err
+			}
+			cfg.TLSConfig = name
+		}
+		return cfg.FormatDSN(), nil
+	case PostgreSQL:
+		q := url.Values{"sslmode": []string{"disable"}}
+		if o.TLS {
+			q.Set("sslmode", "verify-full")
+		}
+		if o.TLS && o.VerifyCAOnly {
+			q.Set("sslmode", "verify-ca")
+		}
+		if o.TLS && len(o.CACertificates) > 0 {
+			// The PostgreSQL driver only reads certificate authorities
+			// from files.
+			f, err := writeCACertificates(o.CACertificates)
+			if err != nil {
+				return "", err
+			}
+			q.Set("sslrootcert", f)
+		}
+		u := url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(o.Username, o.Password),
+			Host:     withDefaultPort(o.Endpoint, DefaultPostgreSQLPort),
+			Path:     "/" + postgresMaintenanceDatabase,
+			RawQuery: q.Encode(),
+		}
+		return u.String(), nil
+	}
+	return "", errors.Errorf("unsupported SQL engine %q", o.Engine)
+}
+
+func withDefaultPort(endpoint, port string) string {
+	if _, _, err := net.SplitHostPort(endpoint); err == nil {
+		return endpoint
+	}
+	return net.JoinHostPort(endpoint, port)
+}
+
+func (c *client) withDB(fn func(*gosql.DB) error) error {
+	db, err := gosql.Open(c.driver, c.dsn)
+	if err != nil {
+		return errors.Wrap(err, "cannot open SQL connection")
+	}
+	defer db.Close() // nolint:errcheck
+
+	return fn(db)
+}
+
+// UserExists returns true if a user with the supplied name exists.
+func (c *client) UserExists(ctx context.Context, name string) (bool, error) {
+	q := "SELECT COUNT(*) FROM mysql.user WHERE User = ? AND Host = ?"
+	args := []interface{}{name, c.host}
+	if c.engine == PostgreSQL {
+		q = "SELECT COUNT(*) FROM pg_roles WHERE rolname = $1"
+		args = []interface{}{name}
+	}
+
+	count := 0
+	err := c.withDB(func(db *gosql.DB) error {
+		return db.QueryRowContext(ctx, q, args...).Scan(&count)
+	})
+	return count > 0, errors.Wrapf(err, "cannot determine whether user %s exists", name)
+}
+
+// CreateUser creates a user that may log in with the supplied password. The
+// new user is granted no privileges.
+func (c *client) CreateUser(ctx context.Context, name, password string) error {
+	q := "CREATE USER " + QuoteLiteral(c.engine, name) + "@" + QuoteLiteral(c.engine, c.host) +
+		" IDENTIFIED BY " + QuoteLiteral(c.engine, password)
+	if c.engine == PostgreSQL {
+		q = "CREATE ROLE " + QuoteIdentifier(c.engine, name) + " WITH LOGIN PASSWORD " + QuoteLiteral(c.engine, password)
+	}
+
+	err := c.withDB(func(db *gosql.DB) error {
+		_, err := db.ExecContext(ctx, q)
+		return err
+	})
+	return errors.Wrapf(err, "cannot create user %s", name)
+}
+
+// DeleteUser deletes the named user. It does not return an error if the user
+// does not exist.
+func (c *client) DeleteUser(ctx context.Context, name string) error {
+	// MySQL 5.6 does not support DROP USER IF EXISTS, so we check first.
+	exists, err := c.UserExists(ctx, name)
+	if err != nil || !exists {
+		return err
+	}
+
+	q := "DROP USER " + QuoteLiteral(c.engine, name) + "@" + QuoteLiteral(c.engine, c.host)
+	if c.engine == PostgreSQL {
+		q = "DROP ROLE IF EXISTS " + QuoteIdentifier(c.engine, name)
+	}
+
+	err = c.withDB(func(db *gosql.DB) error {
+		_, err := db.ExecContext(ctx, q)
+		return err
+	})
+	return errors.Wrapf(err, "cannot delete user %s", name)
+}
+
+// DatabaseExists returns true if a database with the supplied name exists.
+func (c *client) DatabaseExists(ctx context.Context, name string) (bool, error) {
+	q := "SELECT COUNT(*) FROM information_schema.schemata WHERE schema_name = ?"
+	if c.engine == PostgreSQL {
+		q = "SELECT COUNT(*) FROM pg_database WHERE datname = $1"
+	}
+
+	count := 0
+	err := c.withDB(func(db *gosql.DB) error {
+		return db.QueryRowContext(ctx, q, name).Scan(&count)
+	})
+	return count > 0, errors.Wrapf(err, "cannot determine whether database %s exists", name)
+}
+
+// CreateDatabase creates a database with the supplied name, using the server's
+// default character set and collation.
+func (c *client) CreateDatabase(ctx context.Context, name string) error {
+	err := c.withDB(func(db *gosql.DB) error {
+		_, err := db.ExecContext(ctx, "CREATE DATABASE "+QuoteIdentifier(c.engine, name))
+		return err
+	})
+	return errors.Wrapf(err, "cannot create database %s", name)
+}
+
+// DeleteDatabase deletes the named database and all of its data. It does not
+// return an error if the database does not exist.
+func (c *client) DeleteDatabase(ctx context.Context, name string) error {
+	err := c.withDB(func(db *gosql.DB) error {
+		_, err := db.ExecContext(ctx, "DROP DATABASE IF EXISTS "+QuoteIdentifier(c.engine, name))
+		return err
+	})
+	return errors.Wrapf(err, "cannot delete database %s", name)
+}
+
+// Grants returns the databases on which the named user has been granted
+// privileges.
+func (c *client) Grants(ctx context.Context, user string) ([]string, error) {
+	q := "SELECT Db FROM mysql.db WHERE User = ? AND Host = ?"
+	args := []interface{}{user, c.host}
+	if c.engine == PostgreSQL {
+		// PUBLIC may connect to any database, but is not granted CREATE.
+		q = "SELECT datname FROM pg_database WHERE NOT datistemplate AND has_database_privilege($1, datname, 'CREATE')"
+		args = []interface{}{user}
+	}
+
+	databases := []string{}
+	err := c.withDB(func(db *gosql.DB) error {
+		rows, err := db.QueryContext(ctx, q, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close() // nolint:errcheck
+
+		for rows.Next() {
+			d := ""
+			if err := rows.Scan(&d); err != nil {
+				return err
+			}
+			// MySQL stores the database names of grants as patterns.
+			databases = append(databases, mysqlDatabaseName.Replace(d))
+		}
+		return rows.Err()
+	})
+	return databases, errors.Wrapf(err, "cannot list grants of user %s", user)
+}
+
+// Grant grants the named user all privileges on the named database.
+func (c *client) Grant(ctx context.Context, user, database string) error {
+	q := "GRANT ALL PRIVILEGES ON " + QuoteIdentifier(c.engine, mysqlDatabasePattern.Replace(database)) + ".* TO " +
+		QuoteLiteral(c.engine, user) + "@" + QuoteLiteral(c.engine, c.host)
+	if c.engine == PostgreSQL {
+		q = "GRANT ALL PRIVILEGES ON DATABASE " + QuoteIdentifier(c.engine, database) + " TO " + QuoteIdentifier(c.engine, user)
+	}
+
+	err := c.withDB(func(db *gosql.DB) error {
+		_, err := db.ExecContext(ctx, q)
+		return err
+	})
+	return errors.Wrapf(err, "cannot grant user %s privileges on database %s", user, database)
+}
+
+// Revoke revokes all privileges of the named user on the named database.
+func (c *client) Revoke(ctx context.Context, user, database string) error {
+	q := "REVOKE ALL PRIVILEGES ON " + QuoteIdentifier(c.engine, mysqlDatabasePattern.Replace(database)) + ".* FROM " +
+		QuoteLiteral(c.engine, user) + "@" + QuoteLiteral(c.engine, c.host)
+	if c.engine == PostgreSQL {
+		q = "REVOKE ALL PRIVILEGES ON DATABASE " + QuoteIdentifier(c.engine, database) + " FROM " + QuoteIdentifier(c.engine, user)
+	}
+
+	err := c.withDB(func(db *gosql.DB) error {
+		_, err := db.ExecContext(ctx, q)
+		return err
+	})
+	return errors.Wrapf(err, "cannot revoke privileges of user %s on database %s", user, database)
+}
+
+// ReconcileGrants grants the named user all privileges on each of the supplied
+// databases, and revokes its privileges on any other database.
+func ReconcileGrants(ctx context.Context, c Client, user string, databases []string) error {
+	existing, err := c.Grants(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	want := map[string]bool{}
+	for _, d := range databases {
+		want[d] = true
+	}
+
+	for _, d := range existing {
+		if want[d] {
+			delete(want, d)
+			continue
+		}
+		if err := c.Revoke(ctx, user, d); err != nil {
+			return err
+		}
+	}
+
+	for _, d := range databases {
+		if !want[d] {
+			continue
+		}
+		if err := c.Grant(ctx, user, d); err != nil {
+			return err
+		}
+		delete(want, d)
+	}
+
+	return nil
+}
+
+// OwnerName returns the name of the user that owns the database managed by the
+// resource with the supplied UID. Owner names are derived from UIDs so that
+// they are unique and stable, and are truncated to suit MySQL 5.6, which limits
+// user names to 16 characters.
+func OwnerName(uid types.UID) string {
+	n := strings.Replace(string(uid), "-", "", -1)
+	if len(n) > 15 {
+		n = n[:15]
+	}
+	return "o" + n
+}
+
+// IsGranted returns true if the named user exists and has been granted
+// privileges on the named database.
+func IsGranted(ctx context.Context, c Client, user, database string) (bool, error) {
+	exists, err := c.UserExists(ctx, user)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	databases, err := c.Grants(ctx, user)
+	if err != nil {
+		return false, err
+	}
+	for _, d := range databases {
+		if d == database {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// CreateOwner creates a user that may log in with the supplied password and is
+// granted all privileges on the named database. Any existing user with the
+// same name is replaced, because its password cannot be recovered.
+func CreateOwner(ctx context.Context, c Client, user, password, database string) error {
+	if err := c.DeleteUser(ctx, user); err != nil {
+		return err
+	}
+	if err := c.CreateUser(ctx, user, password); err != nil {
+		return err
+	}
+	return c.Grant(ctx, user, database)
+}
+
+// QuoteIdentifier quotes the supplied identifier (e.g. a table or role name)
+// for safe use in a statement for the supplied engine. Statements that create
+// users and databases cannot use placeholders, so we must quote instead.
+func QuoteIdentifier(e Engine, id string) string {
+	if e == MySQL {
+		return "`" + strings.Replace(id, "`", "``", -1) + "`"
+	}
+	return `"` + strings.Replace(id, `"`, `""`, -1) + `"`
+}
+
+// QuoteLiteral quotes the supplied string literal for safe use in a statement
+// for the supplied engine.
+func QuoteLiteral(e Engine, l string) string {
+	if e == MySQL {
+		// MySQL treats backslashes in string literals as escape characters by
+		// default, while PostgreSQL does not.
+		l = strings.Replace(l, `\`, `\\`, -1)
+	}
+	return "'" + strings.Replace(l, "'", "''", -1) + "'"
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/pkg/errors"
+)
+
+// Error strings.
+const (
+	errParseCACertificates = "cannot parse PEM encoded certificate authorities"
+	errRegisterTLSConfig   = "cannot register MySQL TLS config"
+	errWriteCACertificates = "cannot write certificate authorities file"
+	errParseCertificate    = "cannot parse SQL server certificate"
+	errNoCertificate       = "SQL server presented no certificate"
+	errVerifyCertificate   = "cannot verify SQL server certificate"
+)
+
+// tlsConfigName returns the name under which the TLS config for the supplied
+// options is registered with the MySQL driver. Names are derived from the
+// options, so registering the config for the same options again is harmless.
+func tlsConfigName(o Options) string {
+	return "crossplane-" + digest(o.CACertificates, []byte(host(o.Endpoint)), []byte{boolByte(o.VerifyCAOnly)})
+}
+
+// registerTLSConfig registers a TLS config for the supplied options with the
+// MySQL driver, and returns the name under which it was registered.
+func registerTLSConfig(o Options) (string, error) {
+	c := &tls.Config{ServerName: host(o.Endpoint)}
+	if len(o.CACertificates) > 0 {
+		c.RootCAs = x509.NewCertPool()
+		if !c.RootCAs.AppendCertsFromPEM(o.CACertificates) {
+			return "", errors.New(errParseCACertificates)
+		}
+	}
+	if o.VerifyCAOnly {
+		// Go cannot verify a certificate's chain without also verifying its
+		// host, so we skip its verification and verify the chain ourselves.
+		c.InsecureSkipVerify = true
+		c.VerifyPeerCertificate = verifyChain(c.RootCAs)
+	}
+
+	name := tlsConfigName(o)
+	return name, errors.Wrap(mysql.RegisterTLSConfig(name, c), errRegisterTLSConfig)
+}
+
+// verifyChain returns a function that verifies that the first of the supplied
+// raw certificates is signed by one of the supplied roots, optionally via the
+// others. The system's roots are used if roots is nil.
+func verifyChain(roots *x509.CertPool) func(raw [][]byte, _ [][]*x509.Certificate) error {
+	return func(raw [][]byte, _ [][]*x509.Certificate) error {
+		if len(raw) == 0 {
+			return errors.New(errNoCertificate)
+		}
+		certs := make([]*x509.Certificate, len(raw))
+		for i := range raw {
+			c, err := x509.ParseCertificate(raw[i])
+			if err != nil {
+				return errors.Wrap(err, errParseCertificate)
+			}
+			certs[i] = c
+		}
+		o := x509.VerifyOptions{Roots: roots, Intermediates: x509.NewCertPool()}
+		for _, c := range certs[1:] {
+			o.Intermediates.AddCert(c)
+		}
+		_, err := certs[0].Verify(o)
+		return errors.Wrap(err, errVerifyCertificate)
+	}
+}
+
+// writeCACertificates writes the supplied PEM encoded certificate authorities
+// to a file named for their content in the system's temporary directory, and
+// returns the file's path.
+func writeCACertificates(pem []byte) (string, error) {
+	if !x509.NewCertPool().AppendCertsFromPEM(pem) {
+		return "", errors.New(errParseCACertificates)
+	}
+
+	path := caCertificatesFile(pem)
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+
+	// Write to a temporary file and rename it into place so that concurrent
+	// connections never read a partially written file.
+	f, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path))
+	if err != nil {
+		return "", errors.Wrap(err, errWriteCACertificates)
+	}
+	defer os.Remove(f.Name()) // nolint:errcheck
+	if _, err := f.Write(pem); err != nil {
+		_ = f.Close()
+		return "", errors.Wrap(err, errWriteCACertificates)
+	}
+	if err := f.Close(); err != nil {
+		return "", errors.Wrap(err, errWriteCACertificates)
+	}
+	return path, errors.Wrap(os.Rename(f.Name(), path), errWriteCACertificates)
+}
+
+// caCertificatesFile returns the path to which the supplied PEM encoded
+// certificate authorities are written.
+func caCertificatesFile(pem []byte) string {
+	return filepath.Join(os.TempDir(), "crossplane-sql-ca-"+digest(pem)+".pem")
+}
+
+func host(endpoint string) string {
+	if h, _, err := net.SplitHostPort(endpoint); err == nil {
+		return h
+	}
+	return endpoint
+}
+
+func digest(parts ...[]byte) string {
+	h := sha256.New()
+	for _, p := range parts {
+		_, _ = h.Write(p)
+		_, _ = h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+func boolByte(b bool) byte {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+)
+
+// An authority is a certificate authority that issues test certificates.
+type authority struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newAuthority(t *testing.T, name string) *authority {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey(...): %s", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("x509.CreateCertificate(...): %s", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("x509.ParseCertificate(...): %s", err)
+	}
+	return &authority{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns a DER encoded server certificate with the supplied common name
+// and no subject alternative names, like those of Cloud SQL instances.
+func (a *authority) issue(t *testing.T, name string) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey(...): %s", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, a.cert, &key.PublicKey, a.key)
+	if err != nil {
+		t.Fatalf("x509.CreateCertificate(...): %s", err)
+	}
+	return der
+}
+
+func TestVerifyChain(t *testing.T) {
+	ca := newAuthority(t, "cool-ca")
+	other := newAuthority(t, "other-ca")
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	cases := map[string]struct {
+		raw     [][]byte
+		wantErr bool
+	}{
+		"SignedByRoot": {
+			raw: [][]byte{ca.issue(t, "cool-project:cool-instance")},
+		},
+		"SignedByOther": {
+			raw:     [][]byte{other.issue(t, "cool-project:cool-instance")},
+			wantErr: true,
+		},
+		"NotACertificate": {
+			raw:     [][]byte{[]byte("definitely-not-der")},
+			wantErr: true,
+		},
+		"NoCertificate": {
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := verifyChain(roots)(tc.raw, nil)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("verifyChain(...): want error %t, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestWriteCACertificates(t *testing.T) {
+	ca := newAuthority(t, "cool-ca")
+
+	path, err := writeCACertificates(ca.pem)
+	if err != nil {
+		t.Fatalf("writeCACertificates(...): %s", err)
+	}
+	if diff := cmp.Diff(caCertificatesFile(ca.pem), path); diff != "" {
+		t.Errorf("writeCACertificates(...): -want path, +got path:\n%s", diff)
+	}
+	got, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ioutil.ReadFile(...): %s", err)
+	}
+	if diff := cmp.Diff(ca.pem, got); diff != "" {
+		t.Errorf("writeCACertificates(...): -want content, +got content:\n%s", diff)
+	}
+
+	// Writing the same certificate authorities again is harmless.
+	if _, err := writeCACertificates(ca.pem); err != nil {
+		t.Errorf("writeCACertificates(...): %s", err)
+	}
+
+	_, err = writeCACertificates([]byte("definitely-not-pem"))
+	if diff := cmp.Diff(errors.New(errParseCACertificates), err, test.EquateErrors()); diff != "" {
+		t.Errorf("writeCACertificates(...): -want error, +got error:\n%s", diff)
+	}
+}
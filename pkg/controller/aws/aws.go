@@ -21,6 +21,7 @@ import (
 
 	"github.com/crossplaneio/crossplane/pkg/controller/aws/cache"
 	"github.com/crossplaneio/crossplane/pkg/controller/aws/compute"
+	"github.com/crossplaneio/crossplane/pkg/controller/aws/network"
 	"github.com/crossplaneio/crossplane/pkg/controller/aws/rds"
 	"github.com/crossplaneio/crossplane/pkg/controller/aws/s3"
 )
@@ -46,6 +47,18 @@ func (c *Controllers) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
+	if err := (&network.VPCController{}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&network.SubnetController{}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&network.SecurityGroupController{}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
 	if err := (&rds.PostgreSQLInstanceClaimController{}).SetupWithManager(mgr); err != nil {
 		return err
 	}
@@ -58,6 +71,26 @@ func (c *Controllers) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
+	if err := (&rds.UserController{}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&rds.PostgreSQLInstanceDatabaseClaimController{}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&rds.MySQLInstanceDatabaseClaimController{}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&rds.DatabaseController{}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&rds.SnapshotController{}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
 	if err := (&s3.BucketClaimController{}).SetupWithManager(mgr); err != nil {
 		return err
 	}
@@ -29,7 +29,13 @@ import (
 	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
 	cachev1alpha1 "github.com/crossplaneio/crossplane/apis/cache/v1alpha1"
+	corev1alpha1 "github.com/crossplaneio/crossplane/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane/aws/apis/cache/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/secrets"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
 )
 
 // ReplicationGroupClaimController is responsible for adding the ReplicationGroup
@@ -38,28 +44,32 @@ type ReplicationGroupClaimController struct{}
 
 // SetupWithManager adds a controller that reconciles RedisCluster resource claims.
 func (c *ReplicationGroupClaimController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s.%s",
+		cachev1alpha1.RedisClusterKind,
+		v1alpha1.ReplicationGroupKind,
+		v1alpha1.Group))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(event.KubernetesErrorCode))
+	tracer := tracing.NewTracer(name)
+
 	r := resource.NewClaimReconciler(mgr,
 		resource.ClaimKind(cachev1alpha1.RedisClusterGroupVersionKind),
 		resource.ClassKind(v1alpha1.ReplicationGroupClassGroupVersionKind),
 		resource.ManagedKind(v1alpha1.ReplicationGroupGroupVersionKind),
-		resource.WithManagedBinder(resource.NewAPIManagedStatusBinder(mgr.GetClient())),
+		resource.WithManagedBinder(event.NewBinder(recorder, tracer.Binder(resource.NewAPIManagedStatusBinder(mgr.GetClient())))),
 		resource.WithManagedFinalizer(resource.NewAPIManagedStatusUnbinder(mgr.GetClient())),
 		resource.WithManagedConfigurators(
 			resource.ManagedConfiguratorFn(ConfigureReplicationGroup),
 			resource.NewObjectMetaConfigurator(mgr.GetScheme()),
-		))
-
-	name := strings.ToLower(fmt.Sprintf("%s.%s.%s",
-		cachev1alpha1.RedisClusterKind,
-		v1alpha1.ReplicationGroupKind,
-		v1alpha1.Group))
+			resource.ManagedConfiguratorFn(protection.ConfigureManaged),
+		),
+		resource.WithManagedConnectionPropagator(secrets.NewAPIConnectionPropagator(mgr.GetClient(), resource.ClaimKind(cachev1alpha1.RedisClusterGroupVersionKind), cachev1alpha1.RedisClusterSecretDefinition)))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		Watches(&source.Kind{Type: &v1alpha1.ReplicationGroup{}}, &resource.EnqueueRequestForClaim{}).
 		For(&cachev1alpha1.RedisCluster{}).
 		WithEventFilter(resource.NewPredicates(resource.HasClassReferenceKind(resource.ClassKind(v1alpha1.ReplicationGroupClassGroupVersionKind)))).
-		Complete(r)
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &cachev1alpha1.RedisCluster{}, protection.NewReconciler(mgr.GetClient(), &cachev1alpha1.RedisCluster{}, r))))
 }
 
 // ConfigureReplicationGroup configures the supplied resource (presumed
@@ -92,6 +102,10 @@ func ConfigureReplicationGroup(_ context.Context, cm resource.Claim, cs resource
 		return errors.Wrap(err, "cannot resolve AWS class instance values")
 	}
 
+	if err := configureClaimParameters(spec, rs.SpecTemplate.ClaimParameters, rc); err != nil {
+		return err
+	}
+
 	spec.WriteConnectionSecretToReference = corev1.LocalObjectReference{Name: string(cm.GetUID())}
 	spec.ProviderReference = rs.SpecTemplate.ProviderReference
 	spec.ReclaimPolicy = rs.SpecTemplate.ReclaimPolicy
@@ -101,6 +115,24 @@ func ConfigureReplicationGroup(_ context.Context, cm resource.Claim, cs resource
 	return nil
 }
 
+// configureClaimParameters applies the instance size requested by a Redis
+// cluster claim to the supplied spec, if the class's policy allows it.
+// ElastiCache sizes replication groups by node type, so claims may not request
+// memory.
+func configureClaimParameters(spec *v1alpha1.ReplicationGroupSpec, p *corev1alpha1.ClaimParameterPolicy, rc *cachev1alpha1.RedisCluster) error {
+	if rc.Spec.MemoryGB != nil {
+		return corev1alpha1.NewNotSupportedError(v1alpha1.ReplicationGroupClassKind, corev1alpha1.ClaimParameterMemoryGB)
+	}
+	if rc.Spec.InstanceSize != "" {
+		t, err := p.InstanceType(rc.Spec.InstanceSize)
+		if err != nil {
+			return err
+		}
+		spec.CacheNodeType = t
+	}
+	return nil
+}
+
 func resolveAWSClassInstanceValues(spec *v1alpha1.ReplicationGroupSpec, rc *cachev1alpha1.RedisCluster) error {
 	var err error
 	switch {
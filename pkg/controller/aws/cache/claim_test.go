@@ -30,6 +30,7 @@ import (
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
 	"github.com/crossplaneio/crossplane-runtime/pkg/test"
 	cachev1alpha1 "github.com/crossplaneio/crossplane/apis/cache/v1alpha1"
+	corev1alpha1 "github.com/crossplaneio/crossplane/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane/aws/apis/cache/v1alpha1"
 )
 
@@ -59,6 +60,7 @@ func TestConfigureReplicationGroup(t *testing.T) {
 
 	claimUID := types.UID("definitely-a-uuid")
 	providerName := "coolprovider"
+	memoryGB := int64(1)
 
 	cases := map[string]struct {
 		args args
@@ -96,6 +98,55 @@ func TestConfigureReplicationGroup(t *testing.T) {
 				err: nil,
 			},
 		},
+		"ClaimParameters": {
+			args: args{
+				cm: &cachev1alpha1.RedisCluster{
+					ObjectMeta: metav1.ObjectMeta{UID: claimUID},
+					Spec:       cachev1alpha1.RedisClusterSpec{InstanceSize: "small"},
+				},
+				cs: &v1alpha1.ReplicationGroupClass{
+					SpecTemplate: v1alpha1.ReplicationGroupClassSpecTemplate{
+						ResourceClassSpecTemplate: runtimev1alpha1.ResourceClassSpecTemplate{
+							ProviderReference: &corev1.ObjectReference{Name: providerName},
+							ReclaimPolicy:     runtimev1alpha1.ReclaimDelete,
+						},
+						ClaimParameters: &corev1alpha1.ClaimParameterPolicy{
+							InstanceSizes: map[string]string{"small": "cache.t2.micro"},
+						},
+					},
+				},
+				mg: &v1alpha1.ReplicationGroup{},
+			},
+			want: want{
+				mg: &v1alpha1.ReplicationGroup{
+					Spec: v1alpha1.ReplicationGroupSpec{
+						ResourceSpec: runtimev1alpha1.ResourceSpec{
+							ReclaimPolicy:                    runtimev1alpha1.ReclaimDelete,
+							WriteConnectionSecretToReference: corev1.LocalObjectReference{Name: string(claimUID)},
+							ProviderReference:                &corev1.ObjectReference{Name: providerName},
+						},
+						ReplicationGroupParameters: v1alpha1.ReplicationGroupParameters{
+							CacheNodeType: "cache.t2.micro",
+						},
+					},
+				},
+				err: nil,
+			},
+		},
+		"MemoryGBNotSupported": {
+			args: args{
+				cm: &cachev1alpha1.RedisCluster{
+					ObjectMeta: metav1.ObjectMeta{UID: claimUID},
+					Spec:       cachev1alpha1.RedisClusterSpec{MemoryGB: &memoryGB},
+				},
+				cs: &v1alpha1.ReplicationGroupClass{},
+				mg: &v1alpha1.ReplicationGroup{},
+			},
+			want: want{
+				mg:  &v1alpha1.ReplicationGroup{},
+				err: errors.New("ReplicationGroupClass does not support claims that request memoryGB"),
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -19,11 +19,10 @@ package cache
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/pkg/errors"
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -35,6 +34,11 @@ import (
 	awsv1alpha1 "github.com/crossplaneio/crossplane/aws/apis/v1alpha1"
 	"github.com/crossplaneio/crossplane/pkg/clients/aws"
 	"github.com/crossplaneio/crossplane/pkg/clients/aws/elasticache"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
 )
 
 // Error strings.
@@ -45,6 +49,9 @@ const (
 	errGenerateAuthToken        = "cannot generate ElastiCache auth token"
 	errCreateReplicationGroup   = "cannot create ElastiCache replication group"
 	errModifyReplicationGroup   = "cannot modify ElastiCache replication group"
+	errModifyShardConfiguration = "cannot modify ElastiCache replication group shard configuration"
+	errIncreaseReplicaCount     = "cannot increase ElastiCache replication group replica count"
+	errDecreaseReplicaCount     = "cannot decrease ElastiCache replication group replica count"
 	errDescribeCacheCluster     = "cannot describe ElastiCache cache cluster"
 	errDeleteReplicationGroup   = "cannot delete ElastiCache replication group"
 )
@@ -61,21 +68,24 @@ type ReplicationGroupController struct{}
 // Manager with default RBAC. The Manager will set fields on the Controller and
 // start it when the Manager is Started.
 func (c *ReplicationGroupController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s", v1alpha1.ReplicationGroupKind, v1alpha1.Group))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(aws.ErrorCode))
+	tracer := tracing.NewTracer(name)
+
 	r := resource.NewManagedReconciler(mgr,
 		resource.ManagedKind(v1alpha1.ReplicationGroupGroupVersionKind),
-		resource.WithExternalConnecter(&connecter{client: mgr.GetClient()}))
-
-	name := strings.ToLower(fmt.Sprintf("%s.%s", v1alpha1.ReplicationGroupKind, v1alpha1.Group))
+		resource.WithExternalConnecter(event.NewConnecter(recorder, protection.NewConnecter(tracer.Connecter(&connecter{client: mgr.GetClient(), pool: pool.Default})))))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		For(&v1alpha1.ReplicationGroup{}).
-		Complete(r)
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &v1alpha1.ReplicationGroup{}, r)))
 }
 
 type connecter struct {
 	client      client.Client
-	newClientFn func(credentials []byte, region string) (elasticache.Client, error)
+	pool        *pool.Pool
+	newClientFn func(credentials []byte, region string, hc *http.Client) (elasticache.Client, error)
 }
 
 func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
@@ -90,17 +100,29 @@ func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (resource.
 		return nil, errors.Wrapf(err, "cannot get provider %s", n)
 	}
 
-	s := &corev1.Secret{}
-	n = types.NamespacedName{Namespace: p.GetNamespace(), Name: p.Spec.Secret.Name}
-	if err := c.client.Get(ctx, n, s); err != nil {
-		return nil, errors.Wrapf(err, "cannot get provider secret %s", n)
+	v, err := aws.ProviderVersion(ctx, c.client, p)
+	if err != nil {
+		return nil, err
 	}
+
 	newClientFn := elasticache.NewClient
 	if c.newClientFn != nil {
 		newClientFn = c.newClientFn
 	}
-	client, err := newClientFn(s.Data[p.Spec.Secret.Key], p.Spec.Region)
-	return &external{client: client}, errors.Wrap(err, errNewClient)
+
+	k := pool.Key{Provider: p.GetUID(), API: elasticache.API}
+	client, err := c.pool.Get(k, v, func() (interface{}, error) {
+		data, err := aws.ProviderCredentials(ctx, c.client, p)
+		if err != nil {
+			return nil, err
+		}
+		client, err := newClientFn(data, p.Spec.Region, c.pool.HTTPClient(k, aws.Throttled, pool.Method))
+		return client, errors.Wrap(err, errNewClient)
+	})
+	if err != nil {
+		return &external{}, err
+	}
+	return &external{client: client.(elasticache.Client)}, nil
 }
 
 type external struct{ client elasticache.Client }
@@ -134,6 +156,7 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (resource.E
 
 	switch g.Status.State {
 	case v1alpha1.StatusAvailable:
+		g.Status.Operation = ""
 		g.Status.SetConditions(runtimev1alpha1.Available())
 		resource.SetBindable(g)
 	case v1alpha1.StatusCreating:
@@ -212,13 +235,45 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (resource.Ex
 	// DescribeReplicationGroups can return one or many replication groups. We
 	// ask for one group by name, so we should get either a single element list
 	//  or an error.
-	if elasticache.ReplicationGroupNeedsUpdate(g, rsp.ReplicationGroups[0]) {
+	rg := rsp.ReplicationGroups[0]
+
+	// ElastiCache rejects modifications to a replication group that is already
+	// being modified, so we wait until any in-progress operation completes.
+	if aws.StringValue(rg.Status) != v1alpha1.StatusAvailable {
+		return resource.ExternalUpdate{}, nil
+	}
+
+	if elasticache.ReplicationGroupNeedsUpdate(g, rg) {
+		g.Status.Operation = elasticache.ReplicationGroupOperation(g, rg)
 		mr := e.client.ModifyReplicationGroupRequest(elasticache.NewModifyReplicationGroupInput(g))
 		mr.SetContext(ctx)
 		_, err = mr.Send()
 		return resource.ExternalUpdate{}, errors.Wrap(err, errModifyReplicationGroup)
 	}
 
+	if elasticache.NodeGroupsNeedUpdate(g, rg) {
+		g.Status.Operation = v1alpha1.OperationScaleShards
+		sr := e.client.ModifyReplicationGroupShardConfigurationRequest(elasticache.NewModifyReplicationGroupShardConfigurationInput(g, rg))
+		sr.SetContext(ctx)
+		_, err = sr.Send()
+		return resource.ExternalUpdate{}, errors.Wrap(err, errModifyShardConfiguration)
+	}
+
+	switch op := elasticache.ReplicaCountOperation(g, rg); op {
+	case v1alpha1.OperationIncreaseReplicaCount:
+		g.Status.Operation = op
+		ir := e.client.IncreaseReplicaCountRequest(elasticache.NewIncreaseReplicaCountInput(g))
+		ir.SetContext(ctx)
+		_, err = ir.Send()
+		return resource.ExternalUpdate{}, errors.Wrap(err, errIncreaseReplicaCount)
+	case v1alpha1.OperationDecreaseReplicaCount:
+		g.Status.Operation = op
+		dr := e.client.DecreaseReplicaCountRequest(elasticache.NewDecreaseReplicaCountInput(g))
+		dr.SetContext(ctx)
+		_, err = dr.Send()
+		return resource.ExternalUpdate{}, errors.Wrap(err, errDecreaseReplicaCount)
+	}
+
 	for _, cc := range g.Status.MemberClusters {
 		dcc := e.client.DescribeCacheClustersRequest(elasticache.NewDescribeCacheClustersInput(cc))
 		dcc.SetContext(ctx)
@@ -231,6 +286,7 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (resource.Ex
 		// for one cluster by name, so we should get either a single element
 		// list or an error.
 		if elasticache.CacheClusterNeedsUpdate(g, rsp.CacheClusters[0]) {
+			g.Status.Operation = elasticache.CacheClusterOperation(g, rsp.CacheClusters[0])
 			mr := e.client.ModifyReplicationGroupRequest(elasticache.NewModifyReplicationGroupInput(g))
 			mr.SetContext(ctx)
 			_, err = mr.Send()
@@ -140,6 +140,18 @@ func withClusterEnabled(e bool) replicationGroupModifier {
 	return func(r *v1alpha1.ReplicationGroup) { r.Status.ClusterEnabled = e }
 }
 
+func withOperation(o string) replicationGroupModifier {
+	return func(r *v1alpha1.ReplicationGroup) { r.Status.Operation = o }
+}
+
+func withNumNodeGroups(n int) replicationGroupModifier {
+	return func(r *v1alpha1.ReplicationGroup) { r.Spec.NumNodeGroups = n }
+}
+
+func withReplicasPerNodeGroup(n int) replicationGroupModifier {
+	return func(r *v1alpha1.ReplicationGroup) { r.Spec.ReplicasPerNodeGroup = n }
+}
+
 func replicationGroup(rm ...replicationGroupModifier) *v1alpha1.ReplicationGroup {
 	r := &v1alpha1.ReplicationGroup{
 		ObjectMeta: objectMeta,
@@ -460,6 +472,7 @@ func TestUpdate(t *testing.T) {
 			),
 			want: replicationGroup(
 				withGroupName(name),
+				withOperation(v1alpha1.OperationModify),
 			),
 		},
 		{
@@ -510,6 +523,311 @@ func TestUpdate(t *testing.T) {
 			want: replicationGroup(
 				withGroupName(name),
 				withMemberClusters([]string{cacheClusterID}),
+				withOperation(v1alpha1.OperationModify),
+			),
+		},
+		{
+			name: "SuccessfulCacheClustersNeedEngineUpgrade",
+			e: &external{client: &fake.MockClient{
+				MockDescribeReplicationGroupsRequest: func(_ *elasticache.DescribeReplicationGroupsInput) elasticache.DescribeReplicationGroupsRequest {
+					return elasticache.DescribeReplicationGroupsRequest{
+						Request: &aws.Request{
+							HTTPRequest: &http.Request{},
+							Data: &elasticache.DescribeReplicationGroupsOutput{
+								ReplicationGroups: []elasticache.ReplicationGroup{{
+									Status:                 aws.String(v1alpha1.StatusAvailable),
+									MemberClusters:         []string{cacheClusterID},
+									AutomaticFailover:      elasticache.AutomaticFailoverStatusEnabled,
+									CacheNodeType:          aws.String(cacheNodeType),
+									SnapshotRetentionLimit: aws.Int64(snapshotRetentionLimit),
+									SnapshotWindow:         aws.String(snapshotWindow),
+								}},
+							},
+						},
+					}
+				},
+				MockDescribeCacheClustersRequest: func(_ *elasticache.DescribeCacheClustersInput) elasticache.DescribeCacheClustersRequest {
+					return elasticache.DescribeCacheClustersRequest{
+						Request: &aws.Request{
+							HTTPRequest: &http.Request{},
+							Data: &elasticache.DescribeCacheClustersOutput{
+								CacheClusters: []elasticache.CacheCluster{{
+									EngineVersion:              aws.String("4.0.10"), // This field needs to be updated.
+									PreferredMaintenanceWindow: aws.String(maintenanceWindow),
+								}},
+							},
+						},
+					}
+				},
+				MockModifyReplicationGroupRequest: func(_ *elasticache.ModifyReplicationGroupInput) elasticache.ModifyReplicationGroupRequest {
+					return elasticache.ModifyReplicationGroupRequest{
+						Request: &aws.Request{HTTPRequest: &http.Request{}, Data: &elasticache.ModifyReplicationGroupOutput{}},
+					}
+				},
+			}},
+			r: replicationGroup(
+				withGroupName(name),
+				withMemberClusters([]string{cacheClusterID}),
+			),
+			want: replicationGroup(
+				withGroupName(name),
+				withMemberClusters([]string{cacheClusterID}),
+				withOperation(v1alpha1.OperationUpgradeEngine),
+			),
+		},
+		{
+			name: "SuccessfulNodeGroupsNeedUpdate",
+			e: &external{client: &fake.MockClient{
+				MockDescribeReplicationGroupsRequest: func(_ *elasticache.DescribeReplicationGroupsInput) elasticache.DescribeReplicationGroupsRequest {
+					return elasticache.DescribeReplicationGroupsRequest{
+						Request: &aws.Request{
+							HTTPRequest: &http.Request{},
+							Data: &elasticache.DescribeReplicationGroupsOutput{
+								ReplicationGroups: []elasticache.ReplicationGroup{{
+									Status:                 aws.String(v1alpha1.StatusAvailable),
+									AutomaticFailover:      elasticache.AutomaticFailoverStatusEnabled,
+									CacheNodeType:          aws.String(cacheNodeType),
+									SnapshotRetentionLimit: aws.Int64(snapshotRetentionLimit),
+									SnapshotWindow:         aws.String(snapshotWindow),
+									ClusterEnabled:         aws.Bool(true),
+									NodeGroups:             []elasticache.NodeGroup{{NodeGroupId: aws.String("0001")}}, // We want two.
+								}},
+							},
+						},
+					}
+				},
+				MockModifyReplicationGroupShardConfigurationRequest: func(_ *elasticache.ModifyReplicationGroupShardConfigurationInput) elasticache.ModifyReplicationGroupShardConfigurationRequest {
+					return elasticache.ModifyReplicationGroupShardConfigurationRequest{
+						Request: &aws.Request{HTTPRequest: &http.Request{}, Data: &elasticache.ModifyReplicationGroupShardConfigurationOutput{}},
+					}
+				},
+			}},
+			r: replicationGroup(
+				withGroupName(name),
+				withNumNodeGroups(2),
+			),
+			want: replicationGroup(
+				withGroupName(name),
+				withNumNodeGroups(2),
+				withOperation(v1alpha1.OperationScaleShards),
+			),
+		},
+		{
+			name: "FailedModifyShardConfiguration",
+			e: &external{client: &fake.MockClient{
+				MockDescribeReplicationGroupsRequest: func(_ *elasticache.DescribeReplicationGroupsInput) elasticache.DescribeReplicationGroupsRequest {
+					return elasticache.DescribeReplicationGroupsRequest{
+						Request: &aws.Request{
+							HTTPRequest: &http.Request{},
+							Data: &elasticache.DescribeReplicationGroupsOutput{
+								ReplicationGroups: []elasticache.ReplicationGroup{{
+									Status:                 aws.String(v1alpha1.StatusAvailable),
+									AutomaticFailover:      elasticache.AutomaticFailoverStatusEnabled,
+									CacheNodeType:          aws.String(cacheNodeType),
+									SnapshotRetentionLimit: aws.Int64(snapshotRetentionLimit),
+									SnapshotWindow:         aws.String(snapshotWindow),
+									ClusterEnabled:         aws.Bool(true),
+									NodeGroups:             []elasticache.NodeGroup{{NodeGroupId: aws.String("0001")}},
+								}},
+							},
+						},
+					}
+				},
+				MockModifyReplicationGroupShardConfigurationRequest: func(_ *elasticache.ModifyReplicationGroupShardConfigurationInput) elasticache.ModifyReplicationGroupShardConfigurationRequest {
+					return elasticache.ModifyReplicationGroupShardConfigurationRequest{
+						Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errorBoom},
+					}
+				},
+			}},
+			r: replicationGroup(
+				withGroupName(name),
+				withNumNodeGroups(2),
+			),
+			want: replicationGroup(
+				withGroupName(name),
+				withNumNodeGroups(2),
+				withOperation(v1alpha1.OperationScaleShards),
+			),
+			returnsErr: true,
+		},
+		{
+			name: "SuccessfulIncreaseReplicaCount",
+			e: &external{client: &fake.MockClient{
+				MockDescribeReplicationGroupsRequest: func(_ *elasticache.DescribeReplicationGroupsInput) elasticache.DescribeReplicationGroupsRequest {
+					return elasticache.DescribeReplicationGroupsRequest{
+						Request: &aws.Request{
+							HTTPRequest: &http.Request{},
+							Data: &elasticache.DescribeReplicationGroupsOutput{
+								ReplicationGroups: []elasticache.ReplicationGroup{{
+									Status:                 aws.String(v1alpha1.StatusAvailable),
+									AutomaticFailover:      elasticache.AutomaticFailoverStatusEnabled,
+									CacheNodeType:          aws.String(cacheNodeType),
+									SnapshotRetentionLimit: aws.Int64(snapshotRetentionLimit),
+									SnapshotWindow:         aws.String(snapshotWindow),
+									NodeGroups: []elasticache.NodeGroup{{
+										NodeGroupMembers: make([]elasticache.NodeGroupMember, 2), // A primary and replicas.
+									}},
+								}},
+							},
+						},
+					}
+				},
+				MockIncreaseReplicaCountRequest: func(_ *elasticache.IncreaseReplicaCountInput) elasticache.IncreaseReplicaCountRequest {
+					return elasticache.IncreaseReplicaCountRequest{
+						Request: &aws.Request{HTTPRequest: &http.Request{}, Data: &elasticache.IncreaseReplicaCountOutput{}},
+					}
+				},
+			}},
+			r: replicationGroup(
+				withGroupName(name),
+				withReplicasPerNodeGroup(2),
+			),
+			want: replicationGroup(
+				withGroupName(name),
+				withReplicasPerNodeGroup(2),
+				withOperation(v1alpha1.OperationIncreaseReplicaCount),
+			),
+		},
+		{
+			name: "FailedIncreaseReplicaCount",
+			e: &external{client: &fake.MockClient{
+				MockDescribeReplicationGroupsRequest: func(_ *elasticache.DescribeReplicationGroupsInput) elasticache.DescribeReplicationGroupsRequest {
+					return elasticache.DescribeReplicationGroupsRequest{
+						Request: &aws.Request{
+							HTTPRequest: &http.Request{},
+							Data: &elasticache.DescribeReplicationGroupsOutput{
+								ReplicationGroups: []elasticache.ReplicationGroup{{
+									Status:                 aws.String(v1alpha1.StatusAvailable),
+									AutomaticFailover:      elasticache.AutomaticFailoverStatusEnabled,
+									CacheNodeType:          aws.String(cacheNodeType),
+									SnapshotRetentionLimit: aws.Int64(snapshotRetentionLimit),
+									SnapshotWindow:         aws.String(snapshotWindow),
+									NodeGroups: []elasticache.NodeGroup{{
+										NodeGroupMembers: make([]elasticache.NodeGroupMember, 2), // A primary and replicas.
+									}},
+								}},
+							},
+						},
+					}
+				},
+				MockIncreaseReplicaCountRequest: func(_ *elasticache.IncreaseReplicaCountInput) elasticache.IncreaseReplicaCountRequest {
+					return elasticache.IncreaseReplicaCountRequest{
+						Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errorBoom},
+					}
+				},
+			}},
+			r: replicationGroup(
+				withGroupName(name),
+				withReplicasPerNodeGroup(2),
+			),
+			want: replicationGroup(
+				withGroupName(name),
+				withReplicasPerNodeGroup(2),
+				withOperation(v1alpha1.OperationIncreaseReplicaCount),
+			),
+			returnsErr: true,
+		},
+		{
+			name: "SuccessfulDecreaseReplicaCount",
+			e: &external{client: &fake.MockClient{
+				MockDescribeReplicationGroupsRequest: func(_ *elasticache.DescribeReplicationGroupsInput) elasticache.DescribeReplicationGroupsRequest {
+					return elasticache.DescribeReplicationGroupsRequest{
+						Request: &aws.Request{
+							HTTPRequest: &http.Request{},
+							Data: &elasticache.DescribeReplicationGroupsOutput{
+								ReplicationGroups: []elasticache.ReplicationGroup{{
+									Status:                 aws.String(v1alpha1.StatusAvailable),
+									AutomaticFailover:      elasticache.AutomaticFailoverStatusEnabled,
+									CacheNodeType:          aws.String(cacheNodeType),
+									SnapshotRetentionLimit: aws.Int64(snapshotRetentionLimit),
+									SnapshotWindow:         aws.String(snapshotWindow),
+									NodeGroups: []elasticache.NodeGroup{{
+										NodeGroupMembers: make([]elasticache.NodeGroupMember, 4), // A primary and replicas.
+									}},
+								}},
+							},
+						},
+					}
+				},
+				MockDecreaseReplicaCountRequest: func(_ *elasticache.DecreaseReplicaCountInput) elasticache.DecreaseReplicaCountRequest {
+					return elasticache.DecreaseReplicaCountRequest{
+						Request: &aws.Request{HTTPRequest: &http.Request{}, Data: &elasticache.DecreaseReplicaCountOutput{}},
+					}
+				},
+			}},
+			r: replicationGroup(
+				withGroupName(name),
+				withReplicasPerNodeGroup(2),
+			),
+			want: replicationGroup(
+				withGroupName(name),
+				withReplicasPerNodeGroup(2),
+				withOperation(v1alpha1.OperationDecreaseReplicaCount),
+			),
+		},
+		{
+			name: "FailedDecreaseReplicaCount",
+			e: &external{client: &fake.MockClient{
+				MockDescribeReplicationGroupsRequest: func(_ *elasticache.DescribeReplicationGroupsInput) elasticache.DescribeReplicationGroupsRequest {
+					return elasticache.DescribeReplicationGroupsRequest{
+						Request: &aws.Request{
+							HTTPRequest: &http.Request{},
+							Data: &elasticache.DescribeReplicationGroupsOutput{
+								ReplicationGroups: []elasticache.ReplicationGroup{{
+									Status:                 aws.String(v1alpha1.StatusAvailable),
+									AutomaticFailover:      elasticache.AutomaticFailoverStatusEnabled,
+									CacheNodeType:          aws.String(cacheNodeType),
+									SnapshotRetentionLimit: aws.Int64(snapshotRetentionLimit),
+									SnapshotWindow:         aws.String(snapshotWindow),
+									NodeGroups: []elasticache.NodeGroup{{
+										NodeGroupMembers: make([]elasticache.NodeGroupMember, 4), // A primary and replicas.
+									}},
+								}},
+							},
+						},
+					}
+				},
+				MockDecreaseReplicaCountRequest: func(_ *elasticache.DecreaseReplicaCountInput) elasticache.DecreaseReplicaCountRequest {
+					return elasticache.DecreaseReplicaCountRequest{
+						Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errorBoom},
+					}
+				},
+			}},
+			r: replicationGroup(
+				withGroupName(name),
+				withReplicasPerNodeGroup(2),
+			),
+			want: replicationGroup(
+				withGroupName(name),
+				withReplicasPerNodeGroup(2),
+				withOperation(v1alpha1.OperationDecreaseReplicaCount),
+			),
+			returnsErr: true,
+		},
+		{
+			name: "GroupIsModifying",
+			e: &external{client: &fake.MockClient{
+				MockDescribeReplicationGroupsRequest: func(_ *elasticache.DescribeReplicationGroupsInput) elasticache.DescribeReplicationGroupsRequest {
+					return elasticache.DescribeReplicationGroupsRequest{
+						Request: &aws.Request{
+							HTTPRequest: &http.Request{},
+							Data: &elasticache.DescribeReplicationGroupsOutput{
+								ReplicationGroups: []elasticache.ReplicationGroup{{
+									Status:        aws.String(v1alpha1.StatusModifying),
+									CacheNodeType: aws.String("n1.not.cool"), // Not yet updated.
+								}},
+							},
+						},
+					}
+				},
+			}},
+			r: replicationGroup(
+				withGroupName(name),
+				withOperation(v1alpha1.OperationScaleNodeType),
+			),
+			want: replicationGroup(
+				withGroupName(name),
+				withOperation(v1alpha1.OperationScaleNodeType),
 			),
 		},
 		{
@@ -627,6 +945,7 @@ func TestUpdate(t *testing.T) {
 				withGroupName(name),
 				withConditions(runtimev1alpha1.Available()),
 				withMemberClusters([]string{cacheClusterID}),
+				withOperation(v1alpha1.OperationModify),
 			),
 			returnsErr: true,
 		},
@@ -741,7 +1060,9 @@ func TestConnect(t *testing.T) {
 						return nil
 					},
 				},
-				newClientFn: func(_ []byte, _ string) (elasticacheclient.Client, error) { return &fake.MockClient{}, nil },
+				newClientFn: func(_ []byte, _ string, _ *http.Client) (elasticacheclient.Client, error) {
+					return &fake.MockClient{}, nil
+				},
 			},
 			i:    replicationGroup(),
 			want: &external{client: &fake.MockClient{}},
@@ -752,7 +1073,9 @@ func TestConnect(t *testing.T) {
 				client: &test.MockClient{MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
 					return kerrors.NewNotFound(schema.GroupResource{}, providerName)
 				}},
-				newClientFn: func(_ []byte, _ string) (elasticacheclient.Client, error) { return &fake.MockClient{}, nil },
+				newClientFn: func(_ []byte, _ string, _ *http.Client) (elasticacheclient.Client, error) {
+					return &fake.MockClient{}, nil
+				},
 			},
 			i:       replicationGroup(),
 			wantErr: errors.WithStack(errors.Errorf("cannot get provider %s/%s:  \"%s\" not found", namespace, providerName, providerName)),
@@ -769,7 +1092,9 @@ func TestConnect(t *testing.T) {
 					}
 					return nil
 				}},
-				newClientFn: func(_ []byte, _ string) (elasticacheclient.Client, error) { return &fake.MockClient{}, nil },
+				newClientFn: func(_ []byte, _ string, _ *http.Client) (elasticacheclient.Client, error) {
+					return &fake.MockClient{}, nil
+				},
 			},
 			i:       replicationGroup(),
 			wantErr: errors.WithStack(errors.Errorf("cannot get provider secret %s/%s:  \"%s\" not found", namespace, providerSecretName, providerSecretName)),
@@ -786,7 +1111,7 @@ func TestConnect(t *testing.T) {
 					}
 					return nil
 				}},
-				newClientFn: func(_ []byte, _ string) (elasticacheclient.Client, error) { return nil, errorBoom },
+				newClientFn: func(_ []byte, _ string, _ *http.Client) (elasticacheclient.Client, error) { return nil, errorBoom },
 			},
 			i:       replicationGroup(),
 			want:    &external{},
@@ -29,7 +29,13 @@ import (
 	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
 	computev1alpha1 "github.com/crossplaneio/crossplane/apis/compute/v1alpha1"
+	corev1alpha1 "github.com/crossplaneio/crossplane/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane/aws/apis/compute/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/secrets"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
 )
 
 // EKSClusterClaimController is responsible for adding the EKSCluster
@@ -38,30 +44,36 @@ type EKSClusterClaimController struct{}
 
 // SetupWithManager adds a controller that reconciles KubernetesCluster resource claims.
 func (c *EKSClusterClaimController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s", computev1alpha1.KubernetesClusterKind, controllerName))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(event.KubernetesErrorCode))
+	tracer := tracing.NewTracer(name)
+
 	r := resource.NewClaimReconciler(mgr,
 		resource.ClaimKind(computev1alpha1.KubernetesClusterGroupVersionKind),
 		resource.ClassKind(v1alpha1.EKSClusterClassGroupVersionKind),
 		resource.ManagedKind(v1alpha1.EKSClusterGroupVersionKind),
+		resource.WithManagedBinder(event.NewBinder(recorder, tracer.Binder(resource.NewAPIManagedBinder(mgr.GetClient())))),
 		resource.WithManagedConfigurators(
 			resource.ManagedConfiguratorFn(ConfigureEKSCluster),
 			resource.NewObjectMetaConfigurator(mgr.GetScheme()),
-		))
-
-	name := strings.ToLower(fmt.Sprintf("%s.%s", computev1alpha1.KubernetesClusterKind, controllerName))
+			resource.ManagedConfiguratorFn(protection.ConfigureManaged),
+		),
+		resource.WithManagedConnectionPropagator(secrets.NewAPIConnectionPropagator(mgr.GetClient(), resource.ClaimKind(computev1alpha1.KubernetesClusterGroupVersionKind), computev1alpha1.KubernetesClusterSecretDefinition)))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		Watches(&source.Kind{Type: &v1alpha1.EKSCluster{}}, &resource.EnqueueRequestForClaim{}).
 		For(&computev1alpha1.KubernetesCluster{}).
 		WithEventFilter(resource.NewPredicates(resource.HasClassReferenceKind(resource.ClassKind(v1alpha1.EKSClusterClassGroupVersionKind)))).
-		Complete(r)
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &computev1alpha1.KubernetesCluster{}, protection.NewReconciler(mgr.GetClient(), &computev1alpha1.KubernetesCluster{}, r))))
 }
 
 // ConfigureEKSCluster configures the supplied resource (presumed to be a
 // EKSCluster) using the supplied resource claim (presumed to be a
 // KubernetesCluster) and resource class.
 func ConfigureEKSCluster(_ context.Context, cm resource.Claim, cs resource.Class, mg resource.Managed) error {
-	if _, cmok := cm.(*computev1alpha1.KubernetesCluster); !cmok {
+	kc, cmok := cm.(*computev1alpha1.KubernetesCluster)
+	if !cmok {
 		return errors.Errorf("expected resource claim %s to be %s", cm.GetName(), computev1alpha1.KubernetesClusterGroupVersionKind)
 	}
 
@@ -81,6 +93,10 @@ func ConfigureEKSCluster(_ context.Context, cm resource.Claim, cs resource.Class
 		},
 		EKSClusterParameters: rs.SpecTemplate.EKSClusterParameters,
 	}
+	if err := configureClaimParameters(spec, rs.SpecTemplate.ClaimParameters, kc.Spec.NodeCount, kc.Spec.InstanceSize); err != nil {
+		return err
+	}
+
 	spec.WriteConnectionSecretToReference = corev1.LocalObjectReference{Name: string(cm.GetUID())}
 	spec.ProviderReference = rs.SpecTemplate.ProviderReference
 	spec.ReclaimPolicy = rs.SpecTemplate.ReclaimPolicy
@@ -89,3 +105,25 @@ func ConfigureEKSCluster(_ context.Context, cm resource.Claim, cs resource.Class
 
 	return nil
 }
+
+// configureClaimParameters applies the node count and instance size requested
+// by a cluster claim to the supplied spec, if the class's policy allows them.
+// The worker node group is fixed at the requested node count.
+func configureClaimParameters(spec *v1alpha1.EKSClusterSpec, p *corev1alpha1.ClaimParameterPolicy, nodeCount *int64, size string) error {
+	if nodeCount != nil {
+		if err := p.AllowNodeCount(*nodeCount); err != nil {
+			return err
+		}
+		min, max := int(*nodeCount), int(*nodeCount)
+		spec.WorkerNodes.NodeAutoScalingGroupMinSize = &min
+		spec.WorkerNodes.NodeAutoScalingGroupMaxSize = &max
+	}
+	if size != "" {
+		t, err := p.InstanceType(size)
+		if err != nil {
+			return err
+		}
+		spec.WorkerNodes.NodeInstanceType = t
+	}
+	return nil
+}
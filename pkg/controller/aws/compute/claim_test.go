@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -29,6 +30,7 @@ import (
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
 	"github.com/crossplaneio/crossplane-runtime/pkg/test"
 	computev1alpha1 "github.com/crossplaneio/crossplane/apis/compute/v1alpha1"
+	corev1alpha1 "github.com/crossplaneio/crossplane/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane/aws/apis/compute/v1alpha1"
 )
 
@@ -49,6 +51,9 @@ func TestConfigureEKSCluster(t *testing.T) {
 
 	claimUID := types.UID("definitely-a-uuid")
 	providerName := "coolprovider"
+	nodeCount := int64(3)
+	maxNodeCount := int64(5)
+	nodes := 3
 
 	cases := map[string]struct {
 		args args
@@ -80,6 +85,69 @@ func TestConfigureEKSCluster(t *testing.T) {
 				err: nil,
 			},
 		},
+		"ClaimParameters": {
+			args: args{
+				cm: &computev1alpha1.KubernetesCluster{
+					ObjectMeta: metav1.ObjectMeta{UID: claimUID},
+					Spec: computev1alpha1.KubernetesClusterSpec{
+						NodeCount:    &nodeCount,
+						InstanceSize: "large",
+					},
+				},
+				cs: &v1alpha1.EKSClusterClass{
+					SpecTemplate: v1alpha1.EKSClusterClassSpecTemplate{
+						ResourceClassSpecTemplate: runtimev1alpha1.ResourceClassSpecTemplate{
+							ProviderReference: &corev1.ObjectReference{Name: providerName},
+							ReclaimPolicy:     runtimev1alpha1.ReclaimDelete,
+						},
+						ClaimParameters: &corev1alpha1.ClaimParameterPolicy{
+							NodeCount:     &corev1alpha1.Int64Range{Max: &maxNodeCount},
+							InstanceSizes: map[string]string{"large": "m5.large"},
+						},
+					},
+				},
+				mg: &v1alpha1.EKSCluster{},
+			},
+			want: want{
+				mg: &v1alpha1.EKSCluster{
+					Spec: v1alpha1.EKSClusterSpec{
+						ResourceSpec: runtimev1alpha1.ResourceSpec{
+							ReclaimPolicy:                    runtimev1alpha1.ReclaimDelete,
+							WriteConnectionSecretToReference: corev1.LocalObjectReference{Name: string(claimUID)},
+							ProviderReference:                &corev1.ObjectReference{Name: providerName},
+						},
+						EKSClusterParameters: v1alpha1.EKSClusterParameters{
+							WorkerNodes: v1alpha1.WorkerNodesSpec{
+								NodeInstanceType:            "m5.large",
+								NodeAutoScalingGroupMinSize: &nodes,
+								NodeAutoScalingGroupMaxSize: &nodes,
+							},
+						},
+					},
+				},
+				err: nil,
+			},
+		},
+		"InstanceSizeNotAllowed": {
+			args: args{
+				cm: &computev1alpha1.KubernetesCluster{
+					ObjectMeta: metav1.ObjectMeta{UID: claimUID},
+					Spec:       computev1alpha1.KubernetesClusterSpec{InstanceSize: "huge"},
+				},
+				cs: &v1alpha1.EKSClusterClass{
+					SpecTemplate: v1alpha1.EKSClusterClassSpecTemplate{
+						ClaimParameters: &corev1alpha1.ClaimParameterPolicy{
+							InstanceSizes: map[string]string{"large": "m5.large", "small": "t3.small"},
+						},
+					},
+				},
+				mg: &v1alpha1.EKSCluster{},
+			},
+			want: want{
+				mg:  &v1alpha1.EKSCluster{},
+				err: errors.New(`requested instance size "huge" is not allowed by the resource class, which allows large, small`),
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -32,7 +32,6 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -42,12 +41,18 @@ import (
 	awsv1alpha1 "github.com/crossplaneio/crossplane/aws/apis/v1alpha1"
 	awsClient "github.com/crossplaneio/crossplane/pkg/clients/aws"
 	cloudformationclient "github.com/crossplaneio/crossplane/pkg/clients/aws/cloudformation"
+	"github.com/crossplaneio/crossplane/pkg/pause"
 
 	"github.com/crossplaneio/crossplane-runtime/pkg/logging"
 	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
 	"github.com/crossplaneio/crossplane-runtime/pkg/util"
 	"github.com/crossplaneio/crossplane/pkg/clients/aws/eks"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/reference"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
 )
 
 const (
@@ -88,7 +93,8 @@ type Reconciler struct {
 	client.Client
 	scheme     *runtime.Scheme
 	kubeclient kubernetes.Interface
-	recorder   record.EventRecorder
+	recorder   *event.Recorder
+	pool       *pool.Pool
 
 	connect func(*awscomputev1alpha1.EKSCluster) (eks.Client, error)
 	create  func(*awscomputev1alpha1.EKSCluster, eks.Client) (reconcile.Result, error)
@@ -109,7 +115,8 @@ func (c *EKSClusterController) SetupWithManager(mgr ctrl.Manager) error {
 		Client:     mgr.GetClient(),
 		scheme:     mgr.GetScheme(),
 		kubeclient: kubernetes.NewForConfigOrDie(mgr.GetConfig()),
-		recorder:   mgr.GetEventRecorderFor(controllerName),
+		recorder:   event.NewRecorder(mgr.GetEventRecorderFor(controllerName), event.WithErrorCoder(awsClient.ErrorCode)),
+		pool:       pool.Default,
 	}
 	r.connect = r._connect
 	r.create = r._create
@@ -121,11 +128,12 @@ func (c *EKSClusterController) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(controllerName).
 		For(&awscomputev1alpha1.EKSCluster{}).
-		Complete(r)
+		Complete(tracing.NewTracer(controllerName).Reconciler(pause.NewReconciler(mgr.GetClient(), &awscomputev1alpha1.EKSCluster{}, r, pause.WithObjectUpdate())))
 }
 
 // fail - helper function to set fail condition with reason and message
 func (r *Reconciler) fail(instance *awscomputev1alpha1.EKSCluster, err error) (reconcile.Result, error) {
+	r.recorder.Failed(instance, err)
 	instance.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
 	return resultRequeue, r.Update(context.TODO(), instance)
 }
@@ -139,7 +147,7 @@ func (r *Reconciler) _connect(instance *awscomputev1alpha1.EKSCluster) (eks.Clie
 	}
 
 	// Get Provider's AWS Config
-	config, err := awsClient.Config(r.kubeclient, p)
+	config, err := awsClient.PooledConfig(ctx, r.pool, r.Client, p, eks.API)
 	if err != nil {
 		return nil, err
 	}
@@ -162,6 +170,7 @@ func (r *Reconciler) _create(instance *awscomputev1alpha1.EKSCluster, client eks
 	if err != nil && !eks.IsErrorAlreadyExists(err) {
 		if eks.IsErrorBadRequest(err) {
 			// do not requeue on bad requests
+			r.recorder.Failed(instance, err)
 			instance.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
 			return result, r.Update(ctx, instance)
 		}
@@ -175,6 +184,7 @@ func (r *Reconciler) _create(instance *awscomputev1alpha1.EKSCluster, client eks
 	// Update status
 	instance.Status.State = awscomputev1alpha1.ClusterStatusCreating
 	instance.Status.ClusterName = clusterName
+	r.recorder.Normal(instance, event.ReasonCreateRequested, "Requested creation of external resource")
 
 	instance.Status.SetConditions(runtimev1alpha1.ReconcileSuccess())
 	return resultRequeue, r.Update(ctx, instance)
@@ -311,6 +321,9 @@ func (r *Reconciler) _sync(instance *awscomputev1alpha1.EKSCluster, client eks.C
 	}
 
 	// update resource status
+	if !event.Ready(instance) {
+		r.recorder.Normal(instance, event.ReasonBecameReady, "External resource became ready")
+	}
 	instance.Status.Endpoint = cluster.Endpoint
 	instance.Status.State = awscomputev1alpha1.ClusterStatusActive
 	instance.Status.SetConditions(runtimev1alpha1.Available(), runtimev1alpha1.ReconcileSuccess())
@@ -365,6 +378,7 @@ func (r *Reconciler) _delete(instance *awscomputev1alpha1.EKSCluster, client eks
 		if len(deleteErrors) > 0 {
 			return r.fail(instance, errors.New(strings.Join(deleteErrors, ", ")))
 		}
+		r.recorder.Normal(instance, event.ReasonDeletionStarted, "Requested deletion of external resource")
 	}
 
 	meta.RemoveFinalizer(instance, finalizer)
@@ -389,6 +403,18 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 		return reconcile.Result{}, err
 	}
 
+	// Resolve references to other managed resources
+	if instance.DeletionTimestamp == nil && len(instance.GetReferencers()) > 0 {
+		if err := reference.Resolve(ctx, r, instance); err != nil {
+			if reference.IsNotReady(err) {
+				instance.Status.SetConditions(reference.WaitingForReferences(err))
+				return resultRequeue, r.Update(ctx, instance)
+			}
+			return r.fail(instance, err)
+		}
+		instance.Status.SetConditions(reference.ReferencesResolved())
+	}
+
 	// Create EKS Client
 	eksClient, err := r.connect(instance)
 	if err != nil {
@@ -400,6 +426,9 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 
 	// Check for deletion
 	if instance.DeletionTimestamp != nil {
+		if err := protection.Check(instance); err != nil {
+			return r.fail(instance, err)
+		}
 		return r.delete(instance, eksClient)
 	}
 
@@ -0,0 +1,76 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package network contains controllers for AWS VPC networking resources.
+package network
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	awsv1alpha1 "github.com/crossplaneio/crossplane/aws/apis/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/aws"
+	"github.com/crossplaneio/crossplane/pkg/clients/aws/ec2"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
+)
+
+const errNewClient = "cannot create new EC2 client"
+
+// A connecter returns EC2 clients authenticated using credentials read from a
+// Crossplane Provider resource. It is embedded by the connecters of each kind
+// of networking resource. Clients are cached per provider.
+type connecter struct {
+	client      client.Client
+	pool        *pool.Pool
+	newClientFn func(credentials []byte, region string, hc *http.Client) (ec2.Client, error)
+}
+
+func (c *connecter) connect(ctx context.Context, ref *corev1.ObjectReference) (ec2.Client, error) {
+	p := &awsv1alpha1.Provider{}
+	n := meta.NamespacedNameOf(ref)
+	if err := c.client.Get(ctx, n, p); err != nil {
+		return nil, errors.Wrapf(err, "cannot get provider %s", n)
+	}
+
+	v, err := aws.ProviderVersion(ctx, c.client, p)
+	if err != nil {
+		return nil, err
+	}
+
+	newClientFn := ec2.NewClient
+	if c.newClientFn != nil {
+		newClientFn = c.newClientFn
+	}
+
+	k := pool.Key{Provider: p.GetUID(), API: ec2.API}
+	client, err := c.pool.Get(k, v, func() (interface{}, error) {
+		data, err := aws.ProviderCredentials(ctx, c.client, p)
+		if err != nil {
+			return nil, err
+		}
+		client, err := newClientFn(data, p.Spec.Region, c.pool.HTTPClient(k, aws.Throttled, pool.Method))
+		return client, errors.Wrap(err, errNewClient)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return client.(ec2.Client), nil
+}
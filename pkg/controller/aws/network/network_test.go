@@ -0,0 +1,153 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	awsv1alpha1 "github.com/crossplaneio/crossplane/aws/apis/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/aws/ec2"
+	"github.com/crossplaneio/crossplane/pkg/clients/aws/ec2/fake"
+)
+
+const (
+	namespace = "cool-namespace"
+	name      = "cool-network"
+
+	providerName       = "cool-aws"
+	providerSecretName = "cool-aws-secret"
+	providerSecretKey  = "credentials"
+	providerSecretData = "definitelyini"
+)
+
+var (
+	ctx       = context.Background()
+	errorBoom = errors.New("boom")
+
+	provider = awsv1alpha1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: providerName},
+		Spec: awsv1alpha1.ProviderSpec{
+			Secret: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: providerSecretName},
+				Key:                  providerSecretKey,
+			},
+		},
+	}
+
+	providerSecret = corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: providerSecretName},
+		Data:       map[string][]byte{providerSecretKey: []byte(providerSecretData)},
+	}
+
+	providerRef = &corev1.ObjectReference{Namespace: namespace, Name: providerName}
+)
+
+func TestConnect(t *testing.T) {
+	cases := []struct {
+		name    string
+		conn    *connecter
+		want    ec2.Client
+		wantErr error
+	}{
+		{
+			name: "SuccessfulConnect",
+			conn: &connecter{
+				client: &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+						switch key {
+						case client.ObjectKey{Namespace: namespace, Name: providerName}:
+							*obj.(*awsv1alpha1.Provider) = provider
+						case client.ObjectKey{Namespace: namespace, Name: providerSecretName}:
+							*obj.(*corev1.Secret) = providerSecret
+						}
+						return nil
+					},
+				},
+				newClientFn: func(_ []byte, _ string, _ *http.Client) (ec2.Client, error) { return &fake.MockClient{}, nil },
+			},
+			want: &fake.MockClient{},
+		},
+		{
+			name: "FailedToGetProvider",
+			conn: &connecter{
+				client: &test.MockClient{MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+					return kerrors.NewNotFound(schema.GroupResource{}, providerName)
+				}},
+				newClientFn: func(_ []byte, _ string, _ *http.Client) (ec2.Client, error) { return &fake.MockClient{}, nil },
+			},
+			wantErr: errors.WithStack(errors.Errorf("cannot get provider %s/%s:  \"%s\" not found", namespace, providerName, providerName)),
+		},
+		{
+			name: "FailedToGetProviderSecret",
+			conn: &connecter{
+				client: &test.MockClient{MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+					switch key {
+					case client.ObjectKey{Namespace: namespace, Name: providerName}:
+						*obj.(*awsv1alpha1.Provider) = provider
+					case client.ObjectKey{Namespace: namespace, Name: providerSecretName}:
+						return kerrors.NewNotFound(schema.GroupResource{}, providerSecretName)
+					}
+					return nil
+				}},
+				newClientFn: func(_ []byte, _ string, _ *http.Client) (ec2.Client, error) { return &fake.MockClient{}, nil },
+			},
+			wantErr: errors.WithStack(errors.Errorf("cannot get provider secret %s/%s:  \"%s\" not found", namespace, providerSecretName, providerSecretName)),
+		},
+		{
+			name: "FailedToCreateEC2Client",
+			conn: &connecter{
+				client: &test.MockClient{MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+					switch key {
+					case client.ObjectKey{Namespace: namespace, Name: providerName}:
+						*obj.(*awsv1alpha1.Provider) = provider
+					case client.ObjectKey{Namespace: namespace, Name: providerSecretName}:
+						*obj.(*corev1.Secret) = providerSecret
+					}
+					return nil
+				}},
+				newClientFn: func(_ []byte, _ string, _ *http.Client) (ec2.Client, error) { return nil, errorBoom },
+			},
+			wantErr: errors.Wrap(errorBoom, errNewClient),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, gotErr := tc.conn.connect(ctx, providerRef)
+
+			if diff := cmp.Diff(tc.wantErr, gotErr, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.conn.connect(...): want error != got error:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("tc.conn.connect(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	awsec2 "github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane/aws/apis/network/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/aws"
+	"github.com/crossplaneio/crossplane/pkg/clients/aws/ec2"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/reference"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
+)
+
+// Error strings.
+const (
+	errNotSecurityGroup      = "managed resource is not a security group"
+	errDescribeSecurityGroup = "cannot describe security group"
+	errCreateSecurityGroup   = "cannot create security group"
+	errAuthorizeIngress      = "cannot authorize security group ingress"
+	errRevokeIngress         = "cannot revoke security group ingress"
+	errAuthorizeEgress       = "cannot authorize security group egress"
+	errRevokeEgress          = "cannot revoke security group egress"
+	errDeleteSecurityGroup   = "cannot delete security group"
+)
+
+// SecurityGroupController is responsible for adding the SecurityGroup
+// controller and its corresponding reconciler to the manager with any runtime
+// configuration.
+type SecurityGroupController struct{}
+
+// SetupWithManager creates a new SecurityGroup Controller and adds it to the
+// Manager with default RBAC. The Manager will set fields on the Controller and
+// start it when the Manager is Started.
+func (c *SecurityGroupController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s", v1alpha1.SecurityGroupKind, v1alpha1.Group))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(aws.ErrorCode))
+	tracer := tracing.NewTracer(name)
+
+	r := resource.NewManagedReconciler(mgr,
+		resource.ManagedKind(v1alpha1.SecurityGroupGroupVersionKind),
+		resource.WithExternalConnecter(event.NewConnecter(recorder, protection.NewConnecter(tracer.Connecter(reference.NewConnecter(mgr.GetClient(), &securityGroupConnecter{connecter{client: mgr.GetClient(), pool: pool.Default}}))))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.SecurityGroup{}).
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &v1alpha1.SecurityGroup{}, r)))
+}
+
+type securityGroupConnecter struct{ connecter }
+
+func (c *securityGroupConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	g, ok := mg.(*v1alpha1.SecurityGroup)
+	if !ok {
+		return nil, errors.New(errNotSecurityGroup)
+	}
+
+	client, err := c.connect(ctx, g.Spec.ProviderReference)
+	return &securityGroupExternal{client: client}, err
+}
+
+type securityGroupExternal struct{ client ec2.Client }
+
+// describe returns the AWS security group of the supplied SecurityGroup, or
+// nil if it does not exist.
+func (e *securityGroupExternal) describe(ctx context.Context, g *v1alpha1.SecurityGroup) (*awsec2.SecurityGroup, error) {
+	req := e.client.DescribeSecurityGroupsRequest(&awsec2.DescribeSecurityGroupsInput{GroupIds: []string{g.Status.SecurityGroupID}})
+	req.SetContext(ctx)
+	rsp, err := req.Send()
+	if ec2.IsSecurityGroupNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, errDescribeSecurityGroup)
+	}
+	if len(rsp.SecurityGroups) == 0 {
+		return nil, nil
+	}
+	return &rsp.SecurityGroups[0], nil
+}
+
+func (e *securityGroupExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	g, ok := mg.(*v1alpha1.SecurityGroup)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotSecurityGroup)
+	}
+
+	if g.Status.SecurityGroupID == "" {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	existing, err := e.describe(ctx, g)
+	if err != nil || existing == nil {
+		return resource.ExternalObservation{ResourceExists: false}, err
+	}
+
+	// Security groups are usable as soon as they are created.
+	g.Status.SetConditions(runtimev1alpha1.Available())
+	resource.SetBindable(g)
+
+	return resource.ExternalObservation{ResourceExists: true}, nil
+}
+
+func (e *securityGroupExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	g, ok := mg.(*v1alpha1.SecurityGroup)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotSecurityGroup)
+	}
+
+	g.Status.SetConditions(runtimev1alpha1.Creating())
+
+	req := e.client.CreateSecurityGroupRequest(ec2.NewCreateSecurityGroupInput(g))
+	req.SetContext(ctx)
+	rsp, err := req.Send()
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, errCreateSecurityGroup)
+	}
+
+	// Rules are authorized by the first update after the group is created.
+	g.Status.SecurityGroupID = aws.StringValue(rsp.GroupId)
+
+	return resource.ExternalCreation{}, nil
+}
+
+// Update authorizes and revokes rules until the security group's IPv4 rules
+// match its desired ingress and egress rules. Rules are authorized before
+// they are revoked so that replacing a rule never drops allowed traffic.
+func (e *securityGroupExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	g, ok := mg.(*v1alpha1.SecurityGroup)
+	if !ok {
+		return resource.ExternalUpdate{}, errors.New(errNotSecurityGroup)
+	}
+
+	existing, err := e.describe(ctx, g)
+	if err != nil || existing == nil {
+		return resource.ExternalUpdate{}, err
+	}
+
+	id := aws.String(g.Status.SecurityGroupID)
+
+	authorize, revoke := ec2.DiffPermissions(g.Spec.Ingress, existing.IpPermissions)
+	if len(authorize) > 0 {
+		req := e.client.AuthorizeSecurityGroupIngressRequest(&awsec2.AuthorizeSecurityGroupIngressInput{GroupId: id, IpPermissions: authorize})
+		req.SetContext(ctx)
+		if _, err := req.Send(); err != nil {
+			return resource.ExternalUpdate{}, errors.Wrap(err, errAuthorizeIngress)
+		}
+	}
+	if len(revoke) > 0 {
+		req := e.client.RevokeSecurityGroupIngressRequest(&awsec2.RevokeSecurityGroupIngressInput{GroupId: id, IpPermissions: revoke})
+		req.SetContext(ctx)
+		if _, err := req.Send(); err != nil {
+			return resource.ExternalUpdate{}, errors.Wrap(err, errRevokeIngress)
+		}
+	}
+
+	// The default rule that allows all outbound traffic is left in place
+	// unless egress rules are specified.
+	if len(g.Spec.Egress) == 0 {
+		return resource.ExternalUpdate{}, nil
+	}
+
+	authorize, revoke = ec2.DiffPermissions(g.Spec.Egress, existing.IpPermissionsEgress)
+	if len(authorize) > 0 {
+		req := e.client.AuthorizeSecurityGroupEgressRequest(&awsec2.AuthorizeSecurityGroupEgressInput{GroupId: id, IpPermissions: authorize})
+		req.SetContext(ctx)
+		if _, err := req.Send(); err != nil {
+			return resource.ExternalUpdate{}, errors.Wrap(err, errAuthorizeEgress)
+		}
+	}
+	if len(revoke) > 0 {
+		req := e.client.RevokeSecurityGroupEgressRequest(&awsec2.RevokeSecurityGroupEgressInput{GroupId: id, IpPermissions: revoke})
+		req.SetContext(ctx)
+		if _, err := req.Send(); err != nil {
+			return resource.ExternalUpdate{}, errors.Wrap(err, errRevokeEgress)
+		}
+	}
+
+	return resource.ExternalUpdate{}, nil
+}
+
+func (e *securityGroupExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	g, ok := mg.(*v1alpha1.SecurityGroup)
+	if !ok {
+		return errors.New(errNotSecurityGroup)
+	}
+
+	g.Status.SetConditions(runtimev1alpha1.Deleting())
+
+	req := e.client.DeleteSecurityGroupRequest(&awsec2.DeleteSecurityGroupInput{GroupId: aws.String(g.Status.SecurityGroupID)})
+	req.SetContext(ctx)
+	_, err := req.Send()
+	return errors.Wrap(resource.Ignore(ec2.IsSecurityGroupNotFound, err), errDeleteSecurityGroup)
+}
@@ -0,0 +1,267 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	awsec2 "github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	"github.com/crossplaneio/crossplane/aws/apis/network/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/aws/ec2/fake"
+)
+
+const securityGroupID = "sg-cool"
+
+var _ resource.ExternalConnecter = &securityGroupConnecter{}
+var _ resource.ExternalClient = &securityGroupExternal{}
+
+var (
+	httpsFromVPC = v1alpha1.IPPermission{
+		IPProtocol: "tcp",
+		FromPort:   aws.Int64(443),
+		ToPort:     aws.Int64(443),
+		CIDRBlocks: []string{vpcCIDR},
+	}
+	allOutbound = awsec2.IpPermission{
+		IpProtocol: aws.String(v1alpha1.IPProtocolAll),
+		IpRanges:   []awsec2.IpRange{{CidrIp: aws.String("0.0.0.0/0")}},
+	}
+)
+
+type securityGroupModifier func(*v1alpha1.SecurityGroup)
+
+func withSecurityGroupConditions(c ...runtimev1alpha1.Condition) securityGroupModifier {
+	return func(g *v1alpha1.SecurityGroup) { g.Status.ConditionedStatus.Conditions = c }
+}
+
+func withSecurityGroupBindingPhase(p runtimev1alpha1.BindingPhase) securityGroupModifier {
+	return func(g *v1alpha1.SecurityGroup) { g.Status.SetBindingPhase(p) }
+}
+
+func withSecurityGroupID(id string) securityGroupModifier {
+	return func(g *v1alpha1.SecurityGroup) { g.Status.SecurityGroupID = id }
+}
+
+func withIngress(p ...v1alpha1.IPPermission) securityGroupModifier {
+	return func(g *v1alpha1.SecurityGroup) { g.Spec.Ingress = p }
+}
+
+func withEgress(p ...v1alpha1.IPPermission) securityGroupModifier {
+	return func(g *v1alpha1.SecurityGroup) { g.Spec.Egress = p }
+}
+
+func securityGroup(m ...securityGroupModifier) *v1alpha1.SecurityGroup {
+	g := &v1alpha1.SecurityGroup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: v1alpha1.SecurityGroupSpec{
+			ResourceSpec:            runtimev1alpha1.ResourceSpec{ProviderReference: providerRef},
+			SecurityGroupParameters: v1alpha1.SecurityGroupParameters{VPCID: vpcID, Description: "cool"},
+		},
+	}
+
+	for _, fn := range m {
+		fn(g)
+	}
+
+	return g
+}
+
+func describeSecurityGroup(g awsec2.SecurityGroup) func(*awsec2.DescribeSecurityGroupsInput) awsec2.DescribeSecurityGroupsRequest {
+	return func(_ *awsec2.DescribeSecurityGroupsInput) awsec2.DescribeSecurityGroupsRequest {
+		return awsec2.DescribeSecurityGroupsRequest{Request: &aws.Request{
+			HTTPRequest: &http.Request{},
+			Data:        &awsec2.DescribeSecurityGroupsOutput{SecurityGroups: []awsec2.SecurityGroup{g}},
+		}}
+	}
+}
+
+func TestSecurityGroupObserve(t *testing.T) {
+	type want struct {
+		o   resource.ExternalObservation
+		g   *v1alpha1.SecurityGroup
+		err error
+	}
+
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		g    *v1alpha1.SecurityGroup
+		want want
+	}{
+		"NotYetCreated": {
+			e:    &securityGroupExternal{client: &fake.MockClient{}},
+			g:    securityGroup(),
+			want: want{o: resource.ExternalObservation{ResourceExists: false}, g: securityGroup()},
+		},
+		"Exists": {
+			e: &securityGroupExternal{client: &fake.MockClient{
+				MockDescribeSecurityGroupsRequest: describeSecurityGroup(awsec2.SecurityGroup{GroupId: aws.String(securityGroupID)}),
+			}},
+			g: securityGroup(withSecurityGroupID(securityGroupID)),
+			want: want{
+				o: resource.ExternalObservation{ResourceExists: true},
+				g: securityGroup(
+					withSecurityGroupID(securityGroupID),
+					withSecurityGroupConditions(runtimev1alpha1.Available()),
+					withSecurityGroupBindingPhase(runtimev1alpha1.BindingPhaseUnbound),
+				),
+			},
+		},
+		"NotFound": {
+			e: &securityGroupExternal{client: &fake.MockClient{
+				MockDescribeSecurityGroupsRequest: func(_ *awsec2.DescribeSecurityGroupsInput) awsec2.DescribeSecurityGroupsRequest {
+					return awsec2.DescribeSecurityGroupsRequest{Request: &aws.Request{
+						HTTPRequest: &http.Request{},
+						Error:       awserr.New("InvalidGroup.NotFound", "gone", nil),
+					}}
+				},
+			}},
+			g:    securityGroup(withSecurityGroupID(securityGroupID)),
+			want: want{o: resource.ExternalObservation{ResourceExists: false}, g: securityGroup(withSecurityGroupID(securityGroupID))},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(ctx, tc.g)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("e.Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.g, tc.g, test.EquateConditions()); diff != "" {
+				t.Errorf("e.Observe(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSecurityGroupCreate(t *testing.T) {
+	e := &securityGroupExternal{client: &fake.MockClient{
+		MockCreateSecurityGroupRequest: func(_ *awsec2.CreateSecurityGroupInput) awsec2.CreateSecurityGroupRequest {
+			return awsec2.CreateSecurityGroupRequest{Request: &aws.Request{
+				HTTPRequest: &http.Request{},
+				Data:        &awsec2.CreateSecurityGroupOutput{GroupId: aws.String(securityGroupID)},
+			}}
+		},
+	}}
+
+	g := securityGroup()
+	if _, err := e.Create(ctx, g); err != nil {
+		t.Errorf("e.Create(...): %s", err)
+	}
+	want := securityGroup(withSecurityGroupID(securityGroupID), withSecurityGroupConditions(runtimev1alpha1.Creating()))
+	if diff := cmp.Diff(want, g, test.EquateConditions()); diff != "" {
+		t.Errorf("e.Create(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestSecurityGroupUpdate(t *testing.T) {
+	type calls struct {
+		authorizeIngress bool
+		revokeIngress    bool
+		authorizeEgress  bool
+		revokeEgress     bool
+	}
+
+	type want struct {
+		calls calls
+		err   error
+	}
+
+	cases := map[string]struct {
+		observed awsec2.SecurityGroup
+		g        *v1alpha1.SecurityGroup
+		want     want
+	}{
+		"UpToDate": {
+			observed: awsec2.SecurityGroup{IpPermissionsEgress: []awsec2.IpPermission{allOutbound}},
+			g:        securityGroup(withSecurityGroupID(securityGroupID)),
+		},
+		"AuthorizeIngress": {
+			observed: awsec2.SecurityGroup{IpPermissionsEgress: []awsec2.IpPermission{allOutbound}},
+			g:        securityGroup(withSecurityGroupID(securityGroupID), withIngress(httpsFromVPC)),
+			want:     want{calls: calls{authorizeIngress: true}},
+		},
+		"ReplaceEgress": {
+			observed: awsec2.SecurityGroup{IpPermissionsEgress: []awsec2.IpPermission{allOutbound}},
+			g:        securityGroup(withSecurityGroupID(securityGroupID), withEgress(httpsFromVPC)),
+			want:     want{calls: calls{authorizeEgress: true, revokeEgress: true}},
+		},
+		"RevokeIngress": {
+			observed: awsec2.SecurityGroup{IpPermissions: []awsec2.IpPermission{allOutbound}},
+			g:        securityGroup(withSecurityGroupID(securityGroupID)),
+			want:     want{calls: calls{revokeIngress: true}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := calls{}
+			e := &securityGroupExternal{client: &fake.MockClient{
+				MockDescribeSecurityGroupsRequest: describeSecurityGroup(tc.observed),
+				MockAuthorizeSecurityGroupIngressRequest: func(_ *awsec2.AuthorizeSecurityGroupIngressInput) awsec2.AuthorizeSecurityGroupIngressRequest {
+					got.authorizeIngress = true
+					return awsec2.AuthorizeSecurityGroupIngressRequest{Request: &aws.Request{HTTPRequest: &http.Request{}, Data: &awsec2.AuthorizeSecurityGroupIngressOutput{}}}
+				},
+				MockRevokeSecurityGroupIngressRequest: func(_ *awsec2.RevokeSecurityGroupIngressInput) awsec2.RevokeSecurityGroupIngressRequest {
+					got.revokeIngress = true
+					return awsec2.RevokeSecurityGroupIngressRequest{Request: &aws.Request{HTTPRequest: &http.Request{}, Data: &awsec2.RevokeSecurityGroupIngressOutput{}}}
+				},
+				MockAuthorizeSecurityGroupEgressRequest: func(_ *awsec2.AuthorizeSecurityGroupEgressInput) awsec2.AuthorizeSecurityGroupEgressRequest {
+					got.authorizeEgress = true
+					return awsec2.AuthorizeSecurityGroupEgressRequest{Request: &aws.Request{HTTPRequest: &http.Request{}, Data: &awsec2.AuthorizeSecurityGroupEgressOutput{}}}
+				},
+				MockRevokeSecurityGroupEgressRequest: func(_ *awsec2.RevokeSecurityGroupEgressInput) awsec2.RevokeSecurityGroupEgressRequest {
+					got.revokeEgress = true
+					return awsec2.RevokeSecurityGroupEgressRequest{Request: &aws.Request{HTTPRequest: &http.Request{}, Data: &awsec2.RevokeSecurityGroupEgressOutput{}}}
+				},
+			}}
+
+			_, err := e.Update(ctx, tc.g)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Update(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, got, cmp.AllowUnexported(calls{})); diff != "" {
+				t.Errorf("e.Update(...): -want calls, +got calls:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSecurityGroupDelete(t *testing.T) {
+	e := &securityGroupExternal{client: &fake.MockClient{
+		MockDeleteSecurityGroupRequest: func(_ *awsec2.DeleteSecurityGroupInput) awsec2.DeleteSecurityGroupRequest {
+			return awsec2.DeleteSecurityGroupRequest{Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errorBoom}}
+		},
+	}}
+
+	err := e.Delete(ctx, securityGroup(withSecurityGroupID(securityGroupID)))
+	if diff := cmp.Diff(errors.Wrap(errorBoom, errDeleteSecurityGroup), err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Delete(...): -want error, +got error:\n%s", diff)
+	}
+}
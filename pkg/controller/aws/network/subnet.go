@@ -0,0 +1,198 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	awsec2 "github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane/aws/apis/network/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/aws"
+	"github.com/crossplaneio/crossplane/pkg/clients/aws/ec2"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/reference"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
+)
+
+// Error strings.
+const (
+	errNotSubnet             = "managed resource is not a subnet"
+	errDescribeSubnet        = "cannot describe subnet"
+	errCreateSubnet          = "cannot create subnet"
+	errModifySubnetAttribute = "cannot modify subnet attribute"
+	errDeleteSubnet          = "cannot delete subnet"
+)
+
+// SubnetController is responsible for adding the Subnet controller and its
+// corresponding reconciler to the manager with any runtime configuration.
+type SubnetController struct{}
+
+// SetupWithManager creates a new Subnet Controller and adds it to the Manager
+// with default RBAC. The Manager will set fields on the Controller and start
+// it when the Manager is Started.
+func (c *SubnetController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s", v1alpha1.SubnetKind, v1alpha1.Group))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(aws.ErrorCode))
+	tracer := tracing.NewTracer(name)
+
+	r := resource.NewManagedReconciler(mgr,
+		resource.ManagedKind(v1alpha1.SubnetGroupVersionKind),
+		resource.WithExternalConnecter(event.NewConnecter(recorder, protection.NewConnecter(tracer.Connecter(reference.NewConnecter(mgr.GetClient(), &subnetConnecter{connecter{client: mgr.GetClient(), pool: pool.Default}}))))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.Subnet{}).
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &v1alpha1.Subnet{}, r)))
+}
+
+type subnetConnecter struct{ connecter }
+
+func (c *subnetConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	s, ok := mg.(*v1alpha1.Subnet)
+	if !ok {
+		return nil, errors.New(errNotSubnet)
+	}
+
+	client, err := c.connect(ctx, s.Spec.ProviderReference)
+	return &subnetExternal{client: client}, err
+}
+
+type subnetExternal struct{ client ec2.Client }
+
+// describe returns the AWS subnet of the supplied Subnet, or nil if it does
+// not exist.
+func (e *subnetExternal) describe(ctx context.Context, s *v1alpha1.Subnet) (*awsec2.Subnet, error) {
+	req := e.client.DescribeSubnetsRequest(&awsec2.DescribeSubnetsInput{SubnetIds: []string{s.Status.SubnetID}})
+	req.SetContext(ctx)
+	rsp, err := req.Send()
+	if ec2.IsSubnetNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, errDescribeSubnet)
+	}
+	if len(rsp.Subnets) == 0 {
+		return nil, nil
+	}
+	return &rsp.Subnets[0], nil
+}
+
+func (e *subnetExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	s, ok := mg.(*v1alpha1.Subnet)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotSubnet)
+	}
+
+	// Like VPCs, subnets are identified by an ID that AWS assigns when they are
+	// created.
+	if s.Status.SubnetID == "" {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	existing, err := e.describe(ctx, s)
+	if err != nil || existing == nil {
+		return resource.ExternalObservation{ResourceExists: false}, err
+	}
+
+	s.Status.State = string(existing.State)
+	s.Status.AvailabilityZone = aws.StringValue(existing.AvailabilityZone)
+
+	switch s.Status.State {
+	case v1alpha1.SubnetStateAvailable:
+		s.Status.SetConditions(runtimev1alpha1.Available())
+		resource.SetBindable(s)
+	case v1alpha1.SubnetStatePending:
+		s.Status.SetConditions(runtimev1alpha1.Creating())
+	}
+
+	return resource.ExternalObservation{ResourceExists: true}, nil
+}
+
+func (e *subnetExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	s, ok := mg.(*v1alpha1.Subnet)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotSubnet)
+	}
+
+	s.Status.SetConditions(runtimev1alpha1.Creating())
+
+	req := e.client.CreateSubnetRequest(ec2.NewCreateSubnetInput(s))
+	req.SetContext(ctx)
+	rsp, err := req.Send()
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, errCreateSubnet)
+	}
+
+	s.Status.SubnetID = aws.StringValue(rsp.Subnet.SubnetId)
+	s.Status.State = string(rsp.Subnet.State)
+
+	return resource.ExternalCreation{}, nil
+}
+
+// Update modifies whether the subnet maps public IPs on launch. Its VPC, CIDR
+// block, and availability zone cannot be changed.
+func (e *subnetExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	s, ok := mg.(*v1alpha1.Subnet)
+	if !ok {
+		return resource.ExternalUpdate{}, errors.New(errNotSubnet)
+	}
+
+	if s.Spec.MapPublicIPOnLaunch == nil {
+		return resource.ExternalUpdate{}, nil
+	}
+
+	existing, err := e.describe(ctx, s)
+	if err != nil || existing == nil {
+		return resource.ExternalUpdate{}, err
+	}
+
+	if *s.Spec.MapPublicIPOnLaunch == aws.BoolValue(existing.MapPublicIpOnLaunch) {
+		return resource.ExternalUpdate{}, nil
+	}
+
+	req := e.client.ModifySubnetAttributeRequest(&awsec2.ModifySubnetAttributeInput{
+		SubnetId:            aws.String(s.Status.SubnetID),
+		MapPublicIpOnLaunch: &awsec2.AttributeBooleanValue{Value: s.Spec.MapPublicIPOnLaunch},
+	})
+	req.SetContext(ctx)
+	_, err = req.Send()
+	return resource.ExternalUpdate{}, errors.Wrap(err, errModifySubnetAttribute)
+}
+
+func (e *subnetExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	s, ok := mg.(*v1alpha1.Subnet)
+	if !ok {
+		return errors.New(errNotSubnet)
+	}
+
+	s.Status.SetConditions(runtimev1alpha1.Deleting())
+
+	req := e.client.DeleteSubnetRequest(&awsec2.DeleteSubnetInput{SubnetId: aws.String(s.Status.SubnetID)})
+	req.SetContext(ctx)
+	_, err := req.Send()
+	return errors.Wrap(resource.Ignore(ec2.IsSubnetNotFound, err), errDeleteSubnet)
+}
@@ -0,0 +1,304 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	awsec2 "github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	"github.com/crossplaneio/crossplane/aws/apis/network/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/aws/ec2/fake"
+)
+
+const (
+	subnetID   = "subnet-cool"
+	subnetCIDR = "10.0.1.0/24"
+	subnetZone = "us-cool-1a"
+)
+
+var _ resource.ExternalConnecter = &subnetConnecter{}
+var _ resource.ExternalClient = &subnetExternal{}
+
+type subnetModifier func(*v1alpha1.Subnet)
+
+func withSubnetConditions(c ...runtimev1alpha1.Condition) subnetModifier {
+	return func(s *v1alpha1.Subnet) { s.Status.ConditionedStatus.Conditions = c }
+}
+
+func withSubnetBindingPhase(p runtimev1alpha1.BindingPhase) subnetModifier {
+	return func(s *v1alpha1.Subnet) { s.Status.SetBindingPhase(p) }
+}
+
+func withSubnetID(id string) subnetModifier {
+	return func(s *v1alpha1.Subnet) { s.Status.SubnetID = id }
+}
+
+func withSubnetState(st string) subnetModifier {
+	return func(s *v1alpha1.Subnet) { s.Status.State = st }
+}
+
+func withSubnetZone(z string) subnetModifier {
+	return func(s *v1alpha1.Subnet) { s.Status.AvailabilityZone = z }
+}
+
+func withMapPublicIP(m bool) subnetModifier {
+	return func(s *v1alpha1.Subnet) { s.Spec.MapPublicIPOnLaunch = &m }
+}
+
+func subnet(m ...subnetModifier) *v1alpha1.Subnet {
+	s := &v1alpha1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: v1alpha1.SubnetSpec{
+			ResourceSpec:     runtimev1alpha1.ResourceSpec{ProviderReference: providerRef},
+			SubnetParameters: v1alpha1.SubnetParameters{VPCID: vpcID, CIDRBlock: subnetCIDR},
+		},
+	}
+
+	for _, fn := range m {
+		fn(s)
+	}
+
+	return s
+}
+
+func describeSubnet(s awsec2.Subnet) func(*awsec2.DescribeSubnetsInput) awsec2.DescribeSubnetsRequest {
+	return func(_ *awsec2.DescribeSubnetsInput) awsec2.DescribeSubnetsRequest {
+		return awsec2.DescribeSubnetsRequest{Request: &aws.Request{
+			HTTPRequest: &http.Request{},
+			Data:        &awsec2.DescribeSubnetsOutput{Subnets: []awsec2.Subnet{s}},
+		}}
+	}
+}
+
+func TestSubnetObserve(t *testing.T) {
+	type want struct {
+		o   resource.ExternalObservation
+		s   *v1alpha1.Subnet
+		err error
+	}
+
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		s    *v1alpha1.Subnet
+		want want
+	}{
+		"NotYetCreated": {
+			e:    &subnetExternal{client: &fake.MockClient{}},
+			s:    subnet(),
+			want: want{o: resource.ExternalObservation{ResourceExists: false}, s: subnet()},
+		},
+		"Available": {
+			e: &subnetExternal{client: &fake.MockClient{
+				MockDescribeSubnetsRequest: describeSubnet(awsec2.Subnet{
+					SubnetId:         aws.String(subnetID),
+					State:            awsec2.SubnetStateAvailable,
+					AvailabilityZone: aws.String(subnetZone),
+				}),
+			}},
+			s: subnet(withSubnetID(subnetID)),
+			want: want{
+				o: resource.ExternalObservation{ResourceExists: true},
+				s: subnet(
+					withSubnetID(subnetID),
+					withSubnetState(v1alpha1.SubnetStateAvailable),
+					withSubnetZone(subnetZone),
+					withSubnetConditions(runtimev1alpha1.Available()),
+					withSubnetBindingPhase(runtimev1alpha1.BindingPhaseUnbound),
+				),
+			},
+		},
+		"NotFound": {
+			e: &subnetExternal{client: &fake.MockClient{
+				MockDescribeSubnetsRequest: func(_ *awsec2.DescribeSubnetsInput) awsec2.DescribeSubnetsRequest {
+					return awsec2.DescribeSubnetsRequest{Request: &aws.Request{
+						HTTPRequest: &http.Request{},
+						Error:       awserr.New("InvalidSubnetID.NotFound", "gone", nil),
+					}}
+				},
+			}},
+			s:    subnet(withSubnetID(subnetID)),
+			want: want{o: resource.ExternalObservation{ResourceExists: false}, s: subnet(withSubnetID(subnetID))},
+		},
+		"DescribeFailed": {
+			e: &subnetExternal{client: &fake.MockClient{
+				MockDescribeSubnetsRequest: func(_ *awsec2.DescribeSubnetsInput) awsec2.DescribeSubnetsRequest {
+					return awsec2.DescribeSubnetsRequest{Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errorBoom}}
+				},
+			}},
+			s:    subnet(withSubnetID(subnetID)),
+			want: want{s: subnet(withSubnetID(subnetID)), err: errors.Wrap(errorBoom, errDescribeSubnet)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(ctx, tc.s)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("e.Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.s, tc.s, test.EquateConditions()); diff != "" {
+				t.Errorf("e.Observe(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSubnetCreate(t *testing.T) {
+	type want struct {
+		s   *v1alpha1.Subnet
+		err error
+	}
+
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		s    *v1alpha1.Subnet
+		want want
+	}{
+		"Successful": {
+			e: &subnetExternal{client: &fake.MockClient{
+				MockCreateSubnetRequest: func(_ *awsec2.CreateSubnetInput) awsec2.CreateSubnetRequest {
+					return awsec2.CreateSubnetRequest{Request: &aws.Request{
+						HTTPRequest: &http.Request{},
+						Data:        &awsec2.CreateSubnetOutput{Subnet: &awsec2.Subnet{SubnetId: aws.String(subnetID), State: awsec2.SubnetStatePending}},
+					}}
+				},
+			}},
+			s: subnet(),
+			want: want{s: subnet(
+				withSubnetID(subnetID),
+				withSubnetState(v1alpha1.SubnetStatePending),
+				withSubnetConditions(runtimev1alpha1.Creating()),
+			)},
+		},
+		"Failed": {
+			e: &subnetExternal{client: &fake.MockClient{
+				MockCreateSubnetRequest: func(_ *awsec2.CreateSubnetInput) awsec2.CreateSubnetRequest {
+					return awsec2.CreateSubnetRequest{Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errorBoom}}
+				},
+			}},
+			s:    subnet(),
+			want: want{s: subnet(withSubnetConditions(runtimev1alpha1.Creating())), err: errors.Wrap(errorBoom, errCreateSubnet)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Create(ctx, tc.s)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Create(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.s, tc.s, test.EquateConditions()); diff != "" {
+				t.Errorf("e.Create(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSubnetUpdate(t *testing.T) {
+	type want struct {
+		modified bool
+		err      error
+	}
+
+	cases := map[string]struct {
+		client *fake.MockClient
+		s      *v1alpha1.Subnet
+		want   want
+	}{
+		"Unmanaged": {
+			client: &fake.MockClient{},
+			s:      subnet(withSubnetID(subnetID)),
+		},
+		"UpToDate": {
+			client: &fake.MockClient{MockDescribeSubnetsRequest: describeSubnet(awsec2.Subnet{MapPublicIpOnLaunch: aws.Bool(true)})},
+			s:      subnet(withSubnetID(subnetID), withMapPublicIP(true)),
+		},
+		"Modified": {
+			client: &fake.MockClient{MockDescribeSubnetsRequest: describeSubnet(awsec2.Subnet{MapPublicIpOnLaunch: aws.Bool(false)})},
+			s:      subnet(withSubnetID(subnetID), withMapPublicIP(true)),
+			want:   want{modified: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			modified := false
+			tc.client.MockModifySubnetAttributeRequest = func(_ *awsec2.ModifySubnetAttributeInput) awsec2.ModifySubnetAttributeRequest {
+				modified = true
+				return awsec2.ModifySubnetAttributeRequest{Request: &aws.Request{HTTPRequest: &http.Request{}, Data: &awsec2.ModifySubnetAttributeOutput{}}}
+			}
+
+			e := &subnetExternal{client: tc.client}
+			_, err := e.Update(ctx, tc.s)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Update(...): -want error, +got error:\n%s", diff)
+			}
+			if modified != tc.want.modified {
+				t.Errorf("e.Update(...): want modified %t, got %t", tc.want.modified, modified)
+			}
+		})
+	}
+}
+
+func TestSubnetDelete(t *testing.T) {
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		want error
+	}{
+		"Successful": {
+			e: &subnetExternal{client: &fake.MockClient{
+				MockDeleteSubnetRequest: func(_ *awsec2.DeleteSubnetInput) awsec2.DeleteSubnetRequest {
+					return awsec2.DeleteSubnetRequest{Request: &aws.Request{HTTPRequest: &http.Request{}, Data: &awsec2.DeleteSubnetOutput{}}}
+				},
+			}},
+		},
+		"Failed": {
+			e: &subnetExternal{client: &fake.MockClient{
+				MockDeleteSubnetRequest: func(_ *awsec2.DeleteSubnetInput) awsec2.DeleteSubnetRequest {
+					return awsec2.DeleteSubnetRequest{Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errorBoom}}
+				},
+			}},
+			want: errors.Wrap(errorBoom, errDeleteSubnet),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := subnet(withSubnetID(subnetID))
+			err := tc.e.Delete(ctx, s)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Delete(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(subnet(withSubnetID(subnetID), withSubnetConditions(runtimev1alpha1.Deleting())), s, test.EquateConditions()); diff != "" {
+				t.Errorf("e.Delete(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
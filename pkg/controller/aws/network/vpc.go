@@ -0,0 +1,212 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	awsec2 "github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane/aws/apis/network/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/aws"
+	"github.com/crossplaneio/crossplane/pkg/clients/aws/ec2"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
+)
+
+// Error strings.
+const (
+	errNotVPC               = "managed resource is not a VPC"
+	errDescribeVPC          = "cannot describe VPC"
+	errCreateVPC            = "cannot create VPC"
+	errDescribeVPCAttribute = "cannot describe VPC attribute"
+	errModifyVPCAttribute   = "cannot modify VPC attribute"
+	errDeleteVPC            = "cannot delete VPC"
+)
+
+// VPCController is responsible for adding the VPC controller and its
+// corresponding reconciler to the manager with any runtime configuration.
+type VPCController struct{}
+
+// SetupWithManager creates a new VPC Controller and adds it to the Manager
+// with default RBAC. The Manager will set fields on the Controller and start
+// it when the Manager is Started.
+func (c *VPCController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s", v1alpha1.VPCKind, v1alpha1.Group))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(aws.ErrorCode))
+	tracer := tracing.NewTracer(name)
+
+	r := resource.NewManagedReconciler(mgr,
+		resource.ManagedKind(v1alpha1.VPCGroupVersionKind),
+		resource.WithExternalConnecter(event.NewConnecter(recorder, protection.NewConnecter(tracer.Connecter(&vpcConnecter{connecter{client: mgr.GetClient(), pool: pool.Default}})))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.VPC{}).
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &v1alpha1.VPC{}, r)))
+}
+
+type vpcConnecter struct{ connecter }
+
+func (c *vpcConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	v, ok := mg.(*v1alpha1.VPC)
+	if !ok {
+		return nil, errors.New(errNotVPC)
+	}
+
+	client, err := c.connect(ctx, v.Spec.ProviderReference)
+	return &vpcExternal{client: client}, err
+}
+
+type vpcExternal struct{ client ec2.Client }
+
+func (e *vpcExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	v, ok := mg.(*v1alpha1.VPC)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotVPC)
+	}
+
+	// AWS assigns VPC IDs, so we cannot know which VPC to observe until we
+	// have created it.
+	if v.Status.VPCID == "" {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	req := e.client.DescribeVpcsRequest(&awsec2.DescribeVpcsInput{VpcIds: []string{v.Status.VPCID}})
+	req.SetContext(ctx)
+	rsp, err := req.Send()
+	if ec2.IsVPCNotFound(err) {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, errDescribeVPC)
+	}
+	if len(rsp.Vpcs) == 0 {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	v.Status.State = string(rsp.Vpcs[0].State)
+
+	switch v.Status.State {
+	case v1alpha1.VPCStateAvailable:
+		v.Status.SetConditions(runtimev1alpha1.Available())
+		resource.SetBindable(v)
+	case v1alpha1.VPCStatePending:
+		v.Status.SetConditions(runtimev1alpha1.Creating())
+	}
+
+	return resource.ExternalObservation{ResourceExists: true}, nil
+}
+
+func (e *vpcExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	v, ok := mg.(*v1alpha1.VPC)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotVPC)
+	}
+
+	v.Status.SetConditions(runtimev1alpha1.Creating())
+
+	req := e.client.CreateVpcRequest(ec2.NewCreateVpcInput(v))
+	req.SetContext(ctx)
+	rsp, err := req.Send()
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, errCreateVPC)
+	}
+
+	v.Status.VPCID = aws.StringValue(rsp.Vpc.VpcId)
+	v.Status.State = string(rsp.Vpc.State)
+
+	return resource.ExternalCreation{}, nil
+}
+
+// Update modifies the DNS attributes of the VPC. Its CIDR block and instance
+// tenancy cannot be changed. AWS allows only one attribute to be modified per
+// request.
+func (e *vpcExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	v, ok := mg.(*v1alpha1.VPC)
+	if !ok {
+		return resource.ExternalUpdate{}, errors.New(errNotVPC)
+	}
+
+	if v.Status.State != v1alpha1.VPCStateAvailable {
+		return resource.ExternalUpdate{}, nil
+	}
+
+	if err := e.updateAttribute(ctx, v.Status.VPCID, awsec2.VpcAttributeNameEnableDnsSupport, v.Spec.EnableDNSSupport); err != nil {
+		return resource.ExternalUpdate{}, err
+	}
+
+	err := e.updateAttribute(ctx, v.Status.VPCID, awsec2.VpcAttributeNameEnableDnsHostnames, v.Spec.EnableDNSHostnames)
+	return resource.ExternalUpdate{}, err
+}
+
+// updateAttribute modifies the named boolean attribute of the supplied VPC if
+// it differs from the desired value. Unset attributes are not managed.
+func (e *vpcExternal) updateAttribute(ctx context.Context, id string, name awsec2.VpcAttributeName, desired *bool) error {
+	if desired == nil {
+		return nil
+	}
+
+	dr := e.client.DescribeVpcAttributeRequest(&awsec2.DescribeVpcAttributeInput{VpcId: aws.String(id), Attribute: name})
+	dr.SetContext(ctx)
+	rsp, err := dr.Send()
+	if err != nil {
+		return errors.Wrap(err, errDescribeVPCAttribute)
+	}
+
+	observed := rsp.EnableDnsSupport
+	in := &awsec2.ModifyVpcAttributeInput{VpcId: aws.String(id)}
+	switch name {
+	case awsec2.VpcAttributeNameEnableDnsSupport:
+		in.EnableDnsSupport = &awsec2.AttributeBooleanValue{Value: desired}
+	case awsec2.VpcAttributeNameEnableDnsHostnames:
+		observed = rsp.EnableDnsHostnames
+		in.EnableDnsHostnames = &awsec2.AttributeBooleanValue{Value: desired}
+	}
+
+	if !ec2.BoolNeedsUpdate(desired, observed) {
+		return nil
+	}
+
+	mr := e.client.ModifyVpcAttributeRequest(in)
+	mr.SetContext(ctx)
+	_, err = mr.Send()
+	return errors.Wrap(err, errModifyVPCAttribute)
+}
+
+func (e *vpcExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	v, ok := mg.(*v1alpha1.VPC)
+	if !ok {
+		return errors.New(errNotVPC)
+	}
+
+	v.Status.SetConditions(runtimev1alpha1.Deleting())
+
+	req := e.client.DeleteVpcRequest(&awsec2.DeleteVpcInput{VpcId: aws.String(v.Status.VPCID)})
+	req.SetContext(ctx)
+	_, err := req.Send()
+	return errors.Wrap(resource.Ignore(ec2.IsVPCNotFound, err), errDeleteVPC)
+}
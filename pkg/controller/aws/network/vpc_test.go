@@ -0,0 +1,356 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/awserr"
+	awsec2 "github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	"github.com/crossplaneio/crossplane/aws/apis/network/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/aws/ec2/fake"
+)
+
+const (
+	vpcID   = "vpc-cool"
+	vpcCIDR = "10.0.0.0/16"
+)
+
+var _ resource.ExternalConnecter = &vpcConnecter{}
+var _ resource.ExternalClient = &vpcExternal{}
+
+type vpcModifier func(*v1alpha1.VPC)
+
+func withVPCConditions(c ...runtimev1alpha1.Condition) vpcModifier {
+	return func(v *v1alpha1.VPC) { v.Status.ConditionedStatus.Conditions = c }
+}
+
+func withVPCBindingPhase(p runtimev1alpha1.BindingPhase) vpcModifier {
+	return func(v *v1alpha1.VPC) { v.Status.SetBindingPhase(p) }
+}
+
+func withVPCID(id string) vpcModifier {
+	return func(v *v1alpha1.VPC) { v.Status.VPCID = id }
+}
+
+func withVPCState(s string) vpcModifier {
+	return func(v *v1alpha1.VPC) { v.Status.State = s }
+}
+
+func withDNSHostnames(e bool) vpcModifier {
+	return func(v *v1alpha1.VPC) { v.Spec.EnableDNSHostnames = &e }
+}
+
+func vpc(m ...vpcModifier) *v1alpha1.VPC {
+	v := &v1alpha1.VPC{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: v1alpha1.VPCSpec{
+			ResourceSpec:  runtimev1alpha1.ResourceSpec{ProviderReference: providerRef},
+			VPCParameters: v1alpha1.VPCParameters{CIDRBlock: vpcCIDR},
+		},
+	}
+
+	for _, fn := range m {
+		fn(v)
+	}
+
+	return v
+}
+
+func TestVPCObserve(t *testing.T) {
+	type want struct {
+		o   resource.ExternalObservation
+		v   *v1alpha1.VPC
+		err error
+	}
+
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		v    *v1alpha1.VPC
+		want want
+	}{
+		"NotYetCreated": {
+			e:    &vpcExternal{client: &fake.MockClient{}},
+			v:    vpc(),
+			want: want{o: resource.ExternalObservation{ResourceExists: false}, v: vpc()},
+		},
+		"Available": {
+			e: &vpcExternal{client: &fake.MockClient{
+				MockDescribeVpcsRequest: func(_ *awsec2.DescribeVpcsInput) awsec2.DescribeVpcsRequest {
+					return awsec2.DescribeVpcsRequest{Request: &aws.Request{
+						HTTPRequest: &http.Request{},
+						Data:        &awsec2.DescribeVpcsOutput{Vpcs: []awsec2.Vpc{{VpcId: aws.String(vpcID), State: awsec2.VpcStateAvailable}}},
+					}}
+				},
+			}},
+			v: vpc(withVPCID(vpcID)),
+			want: want{
+				o: resource.ExternalObservation{ResourceExists: true},
+				v: vpc(
+					withVPCID(vpcID),
+					withVPCState(v1alpha1.VPCStateAvailable),
+					withVPCConditions(runtimev1alpha1.Available()),
+					withVPCBindingPhase(runtimev1alpha1.BindingPhaseUnbound),
+				),
+			},
+		},
+		"Pending": {
+			e: &vpcExternal{client: &fake.MockClient{
+				MockDescribeVpcsRequest: func(_ *awsec2.DescribeVpcsInput) awsec2.DescribeVpcsRequest {
+					return awsec2.DescribeVpcsRequest{Request: &aws.Request{
+						HTTPRequest: &http.Request{},
+						Data:        &awsec2.DescribeVpcsOutput{Vpcs: []awsec2.Vpc{{VpcId: aws.String(vpcID), State: awsec2.VpcStatePending}}},
+					}}
+				},
+			}},
+			v: vpc(withVPCID(vpcID)),
+			want: want{
+				o: resource.ExternalObservation{ResourceExists: true},
+				v: vpc(
+					withVPCID(vpcID),
+					withVPCState(v1alpha1.VPCStatePending),
+					withVPCConditions(runtimev1alpha1.Creating()),
+				),
+			},
+		},
+		"NotFound": {
+			e: &vpcExternal{client: &fake.MockClient{
+				MockDescribeVpcsRequest: func(_ *awsec2.DescribeVpcsInput) awsec2.DescribeVpcsRequest {
+					return awsec2.DescribeVpcsRequest{Request: &aws.Request{
+						HTTPRequest: &http.Request{},
+						Error:       awserr.New("InvalidVpcID.NotFound", "gone", nil),
+					}}
+				},
+			}},
+			v:    vpc(withVPCID(vpcID)),
+			want: want{o: resource.ExternalObservation{ResourceExists: false}, v: vpc(withVPCID(vpcID))},
+		},
+		"DescribeFailed": {
+			e: &vpcExternal{client: &fake.MockClient{
+				MockDescribeVpcsRequest: func(_ *awsec2.DescribeVpcsInput) awsec2.DescribeVpcsRequest {
+					return awsec2.DescribeVpcsRequest{Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errorBoom}}
+				},
+			}},
+			v:    vpc(withVPCID(vpcID)),
+			want: want{v: vpc(withVPCID(vpcID)), err: errors.Wrap(errorBoom, errDescribeVPC)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(ctx, tc.v)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("e.Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.v, tc.v, test.EquateConditions()); diff != "" {
+				t.Errorf("e.Observe(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestVPCCreate(t *testing.T) {
+	type want struct {
+		v   *v1alpha1.VPC
+		err error
+	}
+
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		v    *v1alpha1.VPC
+		want want
+	}{
+		"Successful": {
+			e: &vpcExternal{client: &fake.MockClient{
+				MockCreateVpcRequest: func(_ *awsec2.CreateVpcInput) awsec2.CreateVpcRequest {
+					return awsec2.CreateVpcRequest{Request: &aws.Request{
+						HTTPRequest: &http.Request{},
+						Data:        &awsec2.CreateVpcOutput{Vpc: &awsec2.Vpc{VpcId: aws.String(vpcID), State: awsec2.VpcStatePending}},
+					}}
+				},
+			}},
+			v: vpc(),
+			want: want{v: vpc(
+				withVPCID(vpcID),
+				withVPCState(v1alpha1.VPCStatePending),
+				withVPCConditions(runtimev1alpha1.Creating()),
+			)},
+		},
+		"Failed": {
+			e: &vpcExternal{client: &fake.MockClient{
+				MockCreateVpcRequest: func(_ *awsec2.CreateVpcInput) awsec2.CreateVpcRequest {
+					return awsec2.CreateVpcRequest{Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errorBoom}}
+				},
+			}},
+			v:    vpc(),
+			want: want{v: vpc(withVPCConditions(runtimev1alpha1.Creating())), err: errors.Wrap(errorBoom, errCreateVPC)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Create(ctx, tc.v)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Create(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.v, tc.v, test.EquateConditions()); diff != "" {
+				t.Errorf("e.Create(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestVPCUpdate(t *testing.T) {
+	type want struct {
+		modified bool
+		err      error
+	}
+
+	describe := func(enabled bool) func(*awsec2.DescribeVpcAttributeInput) awsec2.DescribeVpcAttributeRequest {
+		return func(_ *awsec2.DescribeVpcAttributeInput) awsec2.DescribeVpcAttributeRequest {
+			return awsec2.DescribeVpcAttributeRequest{Request: &aws.Request{
+				HTTPRequest: &http.Request{},
+				Data: &awsec2.DescribeVpcAttributeOutput{
+					EnableDnsHostnames: &awsec2.AttributeBooleanValue{Value: aws.Bool(enabled)},
+				},
+			}}
+		}
+	}
+
+	cases := map[string]struct {
+		client *fake.MockClient
+		v      *v1alpha1.VPC
+		want   want
+	}{
+		"AttributesUnmanaged": {
+			client: &fake.MockClient{},
+			v:      vpc(withVPCID(vpcID), withVPCState(v1alpha1.VPCStateAvailable)),
+		},
+		"NotYetAvailable": {
+			client: &fake.MockClient{},
+			v:      vpc(withVPCID(vpcID), withVPCState(v1alpha1.VPCStatePending), withDNSHostnames(true)),
+		},
+		"AttributeUpToDate": {
+			client: &fake.MockClient{MockDescribeVpcAttributeRequest: describe(true)},
+			v:      vpc(withVPCID(vpcID), withVPCState(v1alpha1.VPCStateAvailable), withDNSHostnames(true)),
+		},
+		"AttributeModified": {
+			client: &fake.MockClient{MockDescribeVpcAttributeRequest: describe(false)},
+			v:      vpc(withVPCID(vpcID), withVPCState(v1alpha1.VPCStateAvailable), withDNSHostnames(true)),
+			want:   want{modified: true},
+		},
+		"DescribeAttributeFailed": {
+			client: &fake.MockClient{
+				MockDescribeVpcAttributeRequest: func(_ *awsec2.DescribeVpcAttributeInput) awsec2.DescribeVpcAttributeRequest {
+					return awsec2.DescribeVpcAttributeRequest{Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errorBoom}}
+				},
+			},
+			v:    vpc(withVPCID(vpcID), withVPCState(v1alpha1.VPCStateAvailable), withDNSHostnames(true)),
+			want: want{err: errors.Wrap(errorBoom, errDescribeVPCAttribute)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			modified := false
+			tc.client.MockModifyVpcAttributeRequest = func(i *awsec2.ModifyVpcAttributeInput) awsec2.ModifyVpcAttributeRequest {
+				modified = true
+				if i.EnableDnsHostnames == nil || !aws.BoolValue(i.EnableDnsHostnames.Value) {
+					t.Errorf("ModifyVpcAttribute(...): want EnableDnsHostnames true, got %v", i.EnableDnsHostnames)
+				}
+				return awsec2.ModifyVpcAttributeRequest{Request: &aws.Request{HTTPRequest: &http.Request{}, Data: &awsec2.ModifyVpcAttributeOutput{}}}
+			}
+
+			e := &vpcExternal{client: tc.client}
+			_, err := e.Update(ctx, tc.v)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Update(...): -want error, +got error:\n%s", diff)
+			}
+			if modified != tc.want.modified {
+				t.Errorf("e.Update(...): want modified %t, got %t", tc.want.modified, modified)
+			}
+		})
+	}
+}
+
+func TestVPCDelete(t *testing.T) {
+	type want struct {
+		v   *v1alpha1.VPC
+		err error
+	}
+
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		v    *v1alpha1.VPC
+		want want
+	}{
+		"Successful": {
+			e: &vpcExternal{client: &fake.MockClient{
+				MockDeleteVpcRequest: func(_ *awsec2.DeleteVpcInput) awsec2.DeleteVpcRequest {
+					return awsec2.DeleteVpcRequest{Request: &aws.Request{HTTPRequest: &http.Request{}, Data: &awsec2.DeleteVpcOutput{}}}
+				},
+			}},
+			v:    vpc(withVPCID(vpcID)),
+			want: want{v: vpc(withVPCID(vpcID), withVPCConditions(runtimev1alpha1.Deleting()))},
+		},
+		"AlreadyGone": {
+			e: &vpcExternal{client: &fake.MockClient{
+				MockDeleteVpcRequest: func(_ *awsec2.DeleteVpcInput) awsec2.DeleteVpcRequest {
+					return awsec2.DeleteVpcRequest{Request: &aws.Request{
+						HTTPRequest: &http.Request{},
+						Error:       awserr.New("InvalidVpcID.NotFound", "gone", nil),
+					}}
+				},
+			}},
+			v:    vpc(withVPCID(vpcID)),
+			want: want{v: vpc(withVPCID(vpcID), withVPCConditions(runtimev1alpha1.Deleting()))},
+		},
+		"Failed": {
+			e: &vpcExternal{client: &fake.MockClient{
+				MockDeleteVpcRequest: func(_ *awsec2.DeleteVpcInput) awsec2.DeleteVpcRequest {
+					return awsec2.DeleteVpcRequest{Request: &aws.Request{HTTPRequest: &http.Request{}, Error: errorBoom}}
+				},
+			}},
+			v:    vpc(withVPCID(vpcID)),
+			want: want{v: vpc(withVPCID(vpcID), withVPCConditions(runtimev1alpha1.Deleting())), err: errors.Wrap(errorBoom, errDeleteVPC)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.e.Delete(ctx, tc.v)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Delete(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.v, tc.v, test.EquateConditions()); diff != "" {
+				t.Errorf("e.Delete(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
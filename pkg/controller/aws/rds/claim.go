@@ -28,8 +28,14 @@ import (
 
 	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	corev1alpha1 "github.com/crossplaneio/crossplane/apis/core/v1alpha1"
 	databasev1alpha1 "github.com/crossplaneio/crossplane/apis/database/v1alpha1"
 	"github.com/crossplaneio/crossplane/aws/apis/database/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/secrets"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
 )
 
 // PostgreSQLInstanceClaimController is responsible for adding the PostgreSQLInstance
@@ -38,23 +44,28 @@ type PostgreSQLInstanceClaimController struct{}
 
 // SetupWithManager adds a controller that reconciles PostgreSQLInstance instance claims.
 func (c *PostgreSQLInstanceClaimController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s", databasev1alpha1.PostgreSQLInstanceKind, controllerName))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(event.KubernetesErrorCode))
+	tracer := tracing.NewTracer(name)
+
 	r := resource.NewClaimReconciler(mgr,
 		resource.ClaimKind(databasev1alpha1.PostgreSQLInstanceGroupVersionKind),
 		resource.ClassKind(v1alpha1.RDSInstanceClassGroupVersionKind),
 		resource.ManagedKind(v1alpha1.RDSInstanceGroupVersionKind),
+		resource.WithManagedBinder(event.NewBinder(recorder, tracer.Binder(resource.NewAPIManagedBinder(mgr.GetClient())))),
 		resource.WithManagedConfigurators(
 			resource.ManagedConfiguratorFn(ConfigurePostgreRDSInstance),
 			resource.NewObjectMetaConfigurator(mgr.GetScheme()),
-		))
-
-	name := strings.ToLower(fmt.Sprintf("%s.%s", databasev1alpha1.PostgreSQLInstanceKind, controllerName))
+			resource.ManagedConfiguratorFn(protection.ConfigureManaged),
+		),
+		resource.WithManagedConnectionPropagator(secrets.NewAPIConnectionPropagator(mgr.GetClient(), resource.ClaimKind(databasev1alpha1.PostgreSQLInstanceGroupVersionKind), databasev1alpha1.PostgreSQLInstanceSecretDefinition)))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		Watches(&source.Kind{Type: &v1alpha1.RDSInstance{}}, &resource.EnqueueRequestForClaim{}).
 		For(&databasev1alpha1.PostgreSQLInstance{}).
 		WithEventFilter(resource.NewPredicates(resource.HasClassReferenceKind(resource.ClassKind(v1alpha1.RDSInstanceClassGroupVersionKind)))).
-		Complete(r)
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &databasev1alpha1.PostgreSQLInstance{}, protection.NewReconciler(mgr.GetClient(), &databasev1alpha1.PostgreSQLInstance{}, r))))
 }
 
 // MySQLInstanceClaimController is responsible for adding the MySQLInstance
@@ -63,23 +74,94 @@ type MySQLInstanceClaimController struct{}
 
 // SetupWithManager adds a controller that reconciles MySQLInstance instance claims.
 func (c *MySQLInstanceClaimController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s", databasev1alpha1.MySQLInstanceKind, controllerName))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(event.KubernetesErrorCode))
+	tracer := tracing.NewTracer(name)
+
 	r := resource.NewClaimReconciler(mgr,
 		resource.ClaimKind(databasev1alpha1.MySQLInstanceGroupVersionKind),
 		resource.ClassKind(v1alpha1.RDSInstanceClassGroupVersionKind),
 		resource.ManagedKind(v1alpha1.RDSInstanceGroupVersionKind),
+		resource.WithManagedBinder(event.NewBinder(recorder, tracer.Binder(resource.NewAPIManagedBinder(mgr.GetClient())))),
 		resource.WithManagedConfigurators(
 			resource.ManagedConfiguratorFn(ConfigureMyRDSInstance),
 			resource.NewObjectMetaConfigurator(mgr.GetScheme()),
-		))
-
-	name := strings.ToLower(fmt.Sprintf("%s.%s", databasev1alpha1.MySQLInstanceKind, controllerName))
+			resource.ManagedConfiguratorFn(protection.ConfigureManaged),
+		),
+		resource.WithManagedConnectionPropagator(secrets.NewAPIConnectionPropagator(mgr.GetClient(), resource.ClaimKind(databasev1alpha1.MySQLInstanceGroupVersionKind), databasev1alpha1.MySQLInstanceSecretDefinition)))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		Watches(&source.Kind{Type: &v1alpha1.RDSInstance{}}, &resource.EnqueueRequestForClaim{}).
 		For(&databasev1alpha1.MySQLInstance{}).
 		WithEventFilter(resource.NewPredicates(resource.HasClassReferenceKind(resource.ClassKind(v1alpha1.RDSInstanceClassGroupVersionKind)))).
-		Complete(r)
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &databasev1alpha1.MySQLInstance{}, protection.NewReconciler(mgr.GetClient(), &databasev1alpha1.MySQLInstance{}, r))))
+}
+
+// PostgreSQLInstanceDatabaseClaimController is responsible for adding the PostgreSQLInstance claim
+// controller for claims that use an RDSDatabaseClass, and its corresponding
+// reconciler to the manager with any runtime configuration.
+type PostgreSQLInstanceDatabaseClaimController struct{}
+
+// SetupWithManager adds a controller that binds PostgreSQLInstance resource claims to
+// RDSDatabases.
+func (c *PostgreSQLInstanceDatabaseClaimController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s.%s", databasev1alpha1.PostgreSQLInstanceKind, v1alpha1.RDSDatabaseKind, v1alpha1.Group))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(event.KubernetesErrorCode))
+	tracer := tracing.NewTracer(name)
+
+	r := resource.NewClaimReconciler(mgr,
+		resource.ClaimKind(databasev1alpha1.PostgreSQLInstanceGroupVersionKind),
+		resource.ClassKind(v1alpha1.RDSDatabaseClassGroupVersionKind),
+		resource.ManagedKind(v1alpha1.RDSDatabaseGroupVersionKind),
+		resource.WithManagedBinder(event.NewBinder(recorder, tracer.Binder(resource.NewAPIManagedStatusBinder(mgr.GetClient())))),
+		resource.WithManagedFinalizer(resource.NewAPIManagedStatusUnbinder(mgr.GetClient())),
+		resource.WithManagedConfigurators(
+			resource.ManagedConfiguratorFn(ConfigureRDSDatabase),
+			resource.NewObjectMetaConfigurator(mgr.GetScheme()),
+			resource.ManagedConfiguratorFn(protection.ConfigureManaged),
+		),
+		resource.WithManagedConnectionPropagator(secrets.NewAPIConnectionPropagator(mgr.GetClient(), resource.ClaimKind(databasev1alpha1.PostgreSQLInstanceGroupVersionKind), databasev1alpha1.PostgreSQLInstanceSecretDefinition)))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		Watches(&source.Kind{Type: &v1alpha1.RDSDatabase{}}, &resource.EnqueueRequestForClaim{}).
+		For(&databasev1alpha1.PostgreSQLInstance{}).
+		WithEventFilter(resource.NewPredicates(resource.HasClassReferenceKind(resource.ClassKind(v1alpha1.RDSDatabaseClassGroupVersionKind)))).
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &databasev1alpha1.PostgreSQLInstance{}, protection.NewReconciler(mgr.GetClient(), &databasev1alpha1.PostgreSQLInstance{}, r))))
+}
+
+// MySQLInstanceDatabaseClaimController is responsible for adding the MySQLInstance claim
+// controller for claims that use an RDSDatabaseClass, and its corresponding
+// reconciler to the manager with any runtime configuration.
+type MySQLInstanceDatabaseClaimController struct{}
+
+// SetupWithManager adds a controller that binds MySQLInstance resource claims to
+// RDSDatabases.
+func (c *MySQLInstanceDatabaseClaimController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s.%s", databasev1alpha1.MySQLInstanceKind, v1alpha1.RDSDatabaseKind, v1alpha1.Group))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(event.KubernetesErrorCode))
+	tracer := tracing.NewTracer(name)
+
+	r := resource.NewClaimReconciler(mgr,
+		resource.ClaimKind(databasev1alpha1.MySQLInstanceGroupVersionKind),
+		resource.ClassKind(v1alpha1.RDSDatabaseClassGroupVersionKind),
+		resource.ManagedKind(v1alpha1.RDSDatabaseGroupVersionKind),
+		resource.WithManagedBinder(event.NewBinder(recorder, tracer.Binder(resource.NewAPIManagedStatusBinder(mgr.GetClient())))),
+		resource.WithManagedFinalizer(resource.NewAPIManagedStatusUnbinder(mgr.GetClient())),
+		resource.WithManagedConfigurators(
+			resource.ManagedConfiguratorFn(ConfigureRDSDatabase),
+			resource.NewObjectMetaConfigurator(mgr.GetScheme()),
+			resource.ManagedConfiguratorFn(protection.ConfigureManaged),
+		),
+		resource.WithManagedConnectionPropagator(secrets.NewAPIConnectionPropagator(mgr.GetClient(), resource.ClaimKind(databasev1alpha1.MySQLInstanceGroupVersionKind), databasev1alpha1.MySQLInstanceSecretDefinition)))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		Watches(&source.Kind{Type: &v1alpha1.RDSDatabase{}}, &resource.EnqueueRequestForClaim{}).
+		For(&databasev1alpha1.MySQLInstance{}).
+		WithEventFilter(resource.NewPredicates(resource.HasClassReferenceKind(resource.ClassKind(v1alpha1.RDSDatabaseClassGroupVersionKind)))).
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &databasev1alpha1.MySQLInstance{}, protection.NewReconciler(mgr.GetClient(), &databasev1alpha1.MySQLInstance{}, r))))
 }
 
 // ConfigurePostgreRDSInstance configures the supplied resource (presumed
@@ -114,6 +196,10 @@ func ConfigurePostgreRDSInstance(_ context.Context, cm resource.Claim, cs resour
 	}
 	spec.EngineVersion = v
 
+	if err := configureClaimParameters(spec, rs.SpecTemplate.ClaimParameters, pg.Spec.StorageGB, pg.Spec.InstanceSize); err != nil {
+		return err
+	}
+
 	spec.WriteConnectionSecretToReference = corev1.LocalObjectReference{Name: string(cm.GetUID())}
 	spec.ProviderReference = rs.SpecTemplate.ProviderReference
 	spec.ReclaimPolicy = rs.SpecTemplate.ReclaimPolicy
@@ -155,6 +241,10 @@ func ConfigureMyRDSInstance(_ context.Context, cm resource.Claim, cs resource.Cl
 	}
 	spec.EngineVersion = v
 
+	if err := configureClaimParameters(spec, rs.SpecTemplate.ClaimParameters, my.Spec.StorageGB, my.Spec.InstanceSize); err != nil {
+		return err
+	}
+
 	spec.WriteConnectionSecretToReference = corev1.LocalObjectReference{Name: string(cm.GetUID())}
 	spec.ProviderReference = rs.SpecTemplate.ProviderReference
 	spec.ReclaimPolicy = rs.SpecTemplate.ReclaimPolicy
@@ -164,6 +254,63 @@ func ConfigureMyRDSInstance(_ context.Context, cm resource.Claim, cs resource.Cl
 	return nil
 }
 
+// ConfigureRDSDatabase configures the supplied database (presumed to be an
+// RDSDatabase) using the supplied resource claim (presumed to be a
+// MySQLInstance or PostgreSQLInstance) and database class. The claim's engine
+// version is not used; the database uses whatever version its instance runs.
+func ConfigureRDSDatabase(_ context.Context, cm resource.Claim, cs resource.Class, mg resource.Managed) error {
+	switch cm.(type) {
+	case *databasev1alpha1.MySQLInstance, *databasev1alpha1.PostgreSQLInstance:
+	default:
+		return errors.Errorf("expected resource claim %s to be %s or %s", cm.GetName(),
+			databasev1alpha1.MySQLInstanceGroupVersionKind, databasev1alpha1.PostgreSQLInstanceGroupVersionKind)
+	}
+
+	rs, csok := cs.(*v1alpha1.RDSDatabaseClass)
+	if !csok {
+		return errors.Errorf("expected resource class %s to be %s", cs.GetName(), v1alpha1.RDSDatabaseClassGroupVersionKind)
+	}
+
+	d, mgok := mg.(*v1alpha1.RDSDatabase)
+	if !mgok {
+		return errors.Errorf("expected managed resource %s to be %s", mg.GetName(), v1alpha1.RDSDatabaseGroupVersionKind)
+	}
+
+	spec := &v1alpha1.RDSDatabaseSpec{
+		ResourceSpec: runtimev1alpha1.ResourceSpec{
+			ReclaimPolicy: runtimev1alpha1.ReclaimRetain,
+		},
+		RDSDatabaseParameters: rs.SpecTemplate.RDSDatabaseParameters,
+	}
+
+	spec.WriteConnectionSecretToReference = corev1.LocalObjectReference{Name: string(cm.GetUID())}
+	spec.ProviderReference = rs.SpecTemplate.ProviderReference
+	spec.ReclaimPolicy = rs.SpecTemplate.ReclaimPolicy
+
+	d.Spec = *spec
+
+	return nil
+}
+
+// configureClaimParameters applies the storage and instance size requested by
+// a resource claim to the supplied spec, if the class's policy allows them.
+func configureClaimParameters(spec *v1alpha1.RDSInstanceSpec, p *corev1alpha1.ClaimParameterPolicy, storageGB *int64, size string) error {
+	if storageGB != nil {
+		if err := p.AllowStorageGB(*storageGB); err != nil {
+			return err
+		}
+		spec.Size = *storageGB
+	}
+	if size != "" {
+		c, err := p.InstanceType(size)
+		if err != nil {
+			return err
+		}
+		spec.Class = c
+	}
+	return nil
+}
+
 // validateEngineVersion compares class and claim engine values and returns an engine value or error
 // if class values is empty - claim value returned (could be an empty string),
 // otherwise if claim value is not a prefix of the class value - return an error
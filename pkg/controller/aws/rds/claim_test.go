@@ -29,6 +29,7 @@ import (
 	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
 	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	corev1alpha1 "github.com/crossplaneio/crossplane/apis/core/v1alpha1"
 	databasev1alpha1 "github.com/crossplaneio/crossplane/apis/database/v1alpha1"
 	"github.com/crossplaneio/crossplane/aws/apis/database/v1alpha1"
 )
@@ -36,6 +37,7 @@ import (
 var (
 	_ resource.ManagedConfigurator = resource.ManagedConfiguratorFn(ConfigurePostgreRDSInstance)
 	_ resource.ManagedConfigurator = resource.ManagedConfiguratorFn(ConfigureMyRDSInstance)
+	_ resource.ManagedConfigurator = resource.ManagedConfiguratorFn(ConfigureRDSDatabase)
 )
 
 func TestConfigurePostgreRDSInstance(t *testing.T) {
@@ -53,6 +55,12 @@ func TestConfigurePostgreRDSInstance(t *testing.T) {
 
 	claimUID := types.UID("definitely-a-uuid")
 	providerName := "coolprovider"
+	storageGB := int64(20)
+	minStorageGB := int64(50)
+	policy := &corev1alpha1.ClaimParameterPolicy{
+		StorageGB:     &corev1alpha1.Int64Range{},
+		InstanceSizes: map[string]string{"small": "db.t2.micro"},
+	}
 
 	cases := map[string]struct {
 		args args
@@ -91,6 +99,64 @@ func TestConfigurePostgreRDSInstance(t *testing.T) {
 				err: nil,
 			},
 		},
+		"ClaimParameters": {
+			args: args{
+				cm: &databasev1alpha1.PostgreSQLInstance{
+					ObjectMeta: metav1.ObjectMeta{UID: claimUID},
+					Spec: databasev1alpha1.PostgreSQLInstanceSpec{
+						StorageGB:    &storageGB,
+						InstanceSize: "small",
+					},
+				},
+				cs: &v1alpha1.RDSInstanceClass{
+					SpecTemplate: v1alpha1.RDSInstanceClassSpecTemplate{
+						ResourceClassSpecTemplate: runtimev1alpha1.ResourceClassSpecTemplate{
+							ProviderReference: &corev1.ObjectReference{Name: providerName},
+							ReclaimPolicy:     runtimev1alpha1.ReclaimDelete,
+						},
+						ClaimParameters: policy,
+					},
+				},
+				mg: &v1alpha1.RDSInstance{},
+			},
+			want: want{
+				mg: &v1alpha1.RDSInstance{
+					Spec: v1alpha1.RDSInstanceSpec{
+						ResourceSpec: runtimev1alpha1.ResourceSpec{
+							ReclaimPolicy:                    runtimev1alpha1.ReclaimDelete,
+							WriteConnectionSecretToReference: corev1.LocalObjectReference{Name: string(claimUID)},
+							ProviderReference:                &corev1.ObjectReference{Name: providerName},
+						},
+						RDSInstanceParameters: v1alpha1.RDSInstanceParameters{
+							Engine: v1alpha1.PostgresqlEngine,
+							Class:  "db.t2.micro",
+							Size:   storageGB,
+						},
+					},
+				},
+				err: nil,
+			},
+		},
+		"StorageGBOutOfRange": {
+			args: args{
+				cm: &databasev1alpha1.PostgreSQLInstance{
+					ObjectMeta: metav1.ObjectMeta{UID: claimUID},
+					Spec:       databasev1alpha1.PostgreSQLInstanceSpec{StorageGB: &storageGB},
+				},
+				cs: &v1alpha1.RDSInstanceClass{
+					SpecTemplate: v1alpha1.RDSInstanceClassSpecTemplate{
+						ClaimParameters: &corev1alpha1.ClaimParameterPolicy{
+							StorageGB: &corev1alpha1.Int64Range{Min: &minStorageGB},
+						},
+					},
+				},
+				mg: &v1alpha1.RDSInstance{},
+			},
+			want: want{
+				mg:  &v1alpha1.RDSInstance{},
+				err: errors.New("requested storageGB 20 is not allowed by the resource class, which requires at least 50"),
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -173,6 +239,95 @@ func TestConfigureMyRDSInstance(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigureRDSDatabase(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		cm  resource.Claim
+		cs  resource.Class
+		mg  resource.Managed
+	}
+
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	claimUID := types.UID("definitely-a-uuid")
+	providerName := "coolprovider"
+	instanceName := "coolinstance"
+
+	class := &v1alpha1.RDSDatabaseClass{
+		SpecTemplate: v1alpha1.RDSDatabaseClassSpecTemplate{
+			ResourceClassSpecTemplate: runtimev1alpha1.ResourceClassSpecTemplate{
+				ProviderReference: &corev1.ObjectReference{Name: providerName},
+				ReclaimPolicy:     runtimev1alpha1.ReclaimDelete,
+			},
+			RDSDatabaseParameters: v1alpha1.RDSDatabaseParameters{
+				InstanceRef: corev1.LocalObjectReference{Name: instanceName},
+			},
+		},
+	}
+
+	configured := &v1alpha1.RDSDatabase{
+		Spec: v1alpha1.RDSDatabaseSpec{
+			ResourceSpec: runtimev1alpha1.ResourceSpec{
+				ReclaimPolicy:                    runtimev1alpha1.ReclaimDelete,
+				WriteConnectionSecretToReference: corev1.LocalObjectReference{Name: string(claimUID)},
+				ProviderReference:                &corev1.ObjectReference{Name: providerName},
+			},
+			RDSDatabaseParameters: v1alpha1.RDSDatabaseParameters{
+				InstanceRef: corev1.LocalObjectReference{Name: instanceName},
+			},
+		},
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"MySQLInstance": {
+			args: args{
+				cm: &databasev1alpha1.MySQLInstance{ObjectMeta: metav1.ObjectMeta{UID: claimUID}},
+				cs: class,
+				mg: &v1alpha1.RDSDatabase{},
+			},
+			want: want{mg: configured},
+		},
+		"PostgreSQLInstance": {
+			args: args{
+				cm: &databasev1alpha1.PostgreSQLInstance{ObjectMeta: metav1.ObjectMeta{UID: claimUID}},
+				cs: class,
+				mg: &v1alpha1.RDSDatabase{},
+			},
+			want: want{mg: configured},
+		},
+		"WrongClass": {
+			args: args{
+				cm: &databasev1alpha1.MySQLInstance{ObjectMeta: metav1.ObjectMeta{UID: claimUID}},
+				cs: &v1alpha1.RDSInstanceClass{},
+				mg: &v1alpha1.RDSDatabase{},
+			},
+			want: want{
+				mg:  &v1alpha1.RDSDatabase{},
+				err: errors.Errorf("expected resource class %s to be %s", "", v1alpha1.RDSDatabaseClassGroupVersionKind),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ConfigureRDSDatabase(tc.args.ctx, tc.args.cm, tc.args.cs, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ConfigureRDSDatabase(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("ConfigureRDSDatabase(...) Managed: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestValidateEngineVersion(t *testing.T) {
 	type args struct {
 		classValue string
@@ -0,0 +1,211 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rds
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane-runtime/pkg/util"
+	databasev1alpha1 "github.com/crossplaneio/crossplane/apis/database/v1alpha1"
+	"github.com/crossplaneio/crossplane/aws/apis/database/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/sql"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
+)
+
+// Error strings.
+const (
+	errNotDatabase    = "managed resource is not an RDS database"
+	errDatabaseExists = "cannot determine whether RDS database exists"
+	errCreateDatabase = "cannot create RDS database"
+	errDeleteDatabase = "cannot delete RDS database"
+	errOwnerGranted   = "cannot determine whether RDS database owner exists"
+	errCreateOwner    = "cannot create RDS database owner"
+	errDeleteOwner    = "cannot delete RDS database owner"
+)
+
+// DatabaseController is responsible for adding the RDSDatabase controller and
+// its corresponding reconciler to the manager with any runtime configuration.
+type DatabaseController struct{}
+
+// SetupWithManager creates a new RDSDatabase Controller and adds it to the
+// Manager with default RBAC. The Manager will set fields on the Controller and
+// start it when the Manager is Started.
+func (c *DatabaseController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s", v1alpha1.RDSDatabaseKind, v1alpha1.Group))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(sql.ErrorCode))
+	tracer := tracing.NewTracer(name)
+
+	r := resource.NewManagedReconciler(mgr,
+		resource.ManagedKind(v1alpha1.RDSDatabaseGroupVersionKind),
+		resource.WithExternalConnecter(event.NewConnecter(recorder, protection.NewConnecter(tracer.Connecter(&databaseConnecter{client: mgr.GetClient()})))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.RDSDatabase{}).
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &v1alpha1.RDSDatabase{}, r)))
+}
+
+// A databaseConnecter connects to the RDSInstance referenced by an
+// RDSDatabase. Like RDSUsers, RDSDatabases are managed via SQL rather than the
+// AWS API.
+type databaseConnecter struct {
+	client       client.Client
+	newClientFn  func(o sql.Options) (sql.Client, error)
+	caBundleFile string
+}
+
+func (c *databaseConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	d, ok := mg.(*v1alpha1.RDSDatabase)
+	if !ok {
+		return nil, errors.New(errNotDatabase)
+	}
+
+	conn, err := connectInstance(ctx, c.client, c.newClientFn, c.caBundleFile, d, d.Spec.InstanceRef)
+	if err != nil {
+		return nil, err
+	}
+	if conn == nil {
+		return &databaseExternal{}, nil
+	}
+	return &databaseExternal{db: conn.db, endpoint: conn.endpoint}, nil
+}
+
+// A databaseExternal manages an RDSDatabase and the user that owns it. Its db
+// client is nil when the referenced RDSInstance no longer exists.
+type databaseExternal struct {
+	db       sql.Client
+	endpoint string
+}
+
+func (e *databaseExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	d, ok := mg.(*v1alpha1.RDSDatabase)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotDatabase)
+	}
+
+	if e.db == nil {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	exists, err := e.db.DatabaseExists(ctx, d.GetDatabaseName())
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, errDatabaseExists)
+	}
+	if !exists {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	// A database is not usable until its owner exists and has been granted
+	// privileges on it. Creating the database creates its owner.
+	owner := sql.OwnerName(d.GetUID())
+	granted, err := sql.IsGranted(ctx, e.db, owner, d.GetDatabaseName())
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, errOwnerGranted)
+	}
+	if !granted {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	d.Status.SetConditions(runtimev1alpha1.Available())
+	resource.SetBindable(d)
+
+	// A database's connection details are those of its owner, never the
+	// master credentials of its instance.
+	o := resource.ExternalObservation{
+		ResourceExists: true,
+		ConnectionDetails: resource.ConnectionDetails{
+			runtimev1alpha1.ResourceCredentialsSecretUserKey:      []byte(owner),
+			runtimev1alpha1.ResourceCredentialsSecretEndpointKey:  []byte(e.endpoint),
+			databasev1alpha1.ResourceCredentialsSecretDatabaseKey: []byte(d.GetDatabaseName()),
+		},
+	}
+	return o, nil
+}
+
+func (e *databaseExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	d, ok := mg.(*v1alpha1.RDSDatabase)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotDatabase)
+	}
+
+	d.Status.SetConditions(runtimev1alpha1.Creating())
+
+	// The database may already exist if we previously failed to create its
+	// owner.
+	exists, err := e.db.DatabaseExists(ctx, d.GetDatabaseName())
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, errDatabaseExists)
+	}
+	if !exists {
+		if err := e.db.CreateDatabase(ctx, d.GetDatabaseName()); err != nil {
+			return resource.ExternalCreation{}, errors.Wrap(err, errCreateDatabase)
+		}
+	}
+
+	password, err := util.GeneratePassword(userPasswordLength)
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, errGeneratePassword)
+	}
+
+	owner := sql.OwnerName(d.GetUID())
+	if err := sql.CreateOwner(ctx, e.db, owner, password, d.GetDatabaseName()); err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, errCreateOwner)
+	}
+
+	c := resource.ExternalCreation{
+		ConnectionDetails: resource.ConnectionDetails{
+			runtimev1alpha1.ResourceCredentialsSecretUserKey:     []byte(owner),
+			runtimev1alpha1.ResourceCredentialsSecretPasswordKey: []byte(password),
+		},
+	}
+	return c, nil
+}
+
+// Update is a no-op; a database's name is its identity.
+func (e *databaseExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	return resource.ExternalUpdate{}, nil
+}
+
+func (e *databaseExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	d, ok := mg.(*v1alpha1.RDSDatabase)
+	if !ok {
+		return errors.New(errNotDatabase)
+	}
+
+	d.Status.SetConditions(runtimev1alpha1.Deleting())
+
+	if e.db == nil {
+		return nil
+	}
+
+	if err := e.db.DeleteDatabase(ctx, d.GetDatabaseName()); err != nil {
+		return errors.Wrap(err, errDeleteDatabase)
+	}
+
+	return errors.Wrap(e.db.DeleteUser(ctx, sql.OwnerName(d.GetUID())), errDeleteOwner)
+}
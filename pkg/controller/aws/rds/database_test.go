@@ -0,0 +1,374 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rds
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	databasev1alpha1 "github.com/crossplaneio/crossplane/apis/database/v1alpha1"
+	"github.com/crossplaneio/crossplane/aws/apis/database/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/sql"
+	"github.com/crossplaneio/crossplane/pkg/clients/sql/fake"
+)
+
+const (
+	databaseName = "cool-database"
+	databaseUID  = types.UID("0b1c1a5e-7f43-11e9-8d3c-42010a800002")
+)
+
+var databaseOwner = sql.OwnerName(databaseUID)
+
+var errDatabaseBoom = errors.New("boom")
+
+type rdsDatabaseModifier func(*v1alpha1.RDSDatabase)
+
+func withDatabaseConditions(c ...runtimev1alpha1.Condition) rdsDatabaseModifier {
+	return func(d *v1alpha1.RDSDatabase) { d.Status.ConditionedStatus.Conditions = c }
+}
+
+func withDatabaseBindingPhase(p runtimev1alpha1.BindingPhase) rdsDatabaseModifier {
+	return func(d *v1alpha1.RDSDatabase) { d.Status.SetBindingPhase(p) }
+}
+
+func rdsDatabase(m ...rdsDatabaseModifier) *v1alpha1.RDSDatabase {
+	d := &v1alpha1.RDSDatabase{
+		ObjectMeta: metav1.ObjectMeta{Namespace: userNamespace, Name: databaseName, UID: databaseUID},
+		Spec: v1alpha1.RDSDatabaseSpec{
+			RDSDatabaseParameters: v1alpha1.RDSDatabaseParameters{
+				InstanceRef: corev1.LocalObjectReference{Name: userInstanceName},
+			},
+		},
+	}
+
+	for _, fn := range m {
+		fn(d)
+	}
+
+	return d
+}
+
+var _ resource.ExternalConnecter = &databaseConnecter{}
+var _ resource.ExternalClient = &databaseExternal{}
+
+// ownedDatabase returns a mock SQL client for an instance in which the named
+// databases exist, and in which the supplied users have been granted
+// privileges on the supplied databases.
+func ownedDatabase(databases []string, grants map[string][]string) *fake.MockClient {
+	return &fake.MockClient{
+		MockDatabaseExists: func(_ context.Context, name string) (bool, error) {
+			for _, d := range databases {
+				if d == name {
+					return true, nil
+				}
+			}
+			return false, nil
+		},
+		MockUserExists: func(_ context.Context, name string) (bool, error) {
+			_, ok := grants[name]
+			return ok, nil
+		},
+		MockGrants: func(_ context.Context, user string) ([]string, error) {
+			return grants[user], nil
+		},
+	}
+}
+
+func TestDatabaseObserve(t *testing.T) {
+	type want struct {
+		o   resource.ExternalObservation
+		d   *v1alpha1.RDSDatabase
+		err error
+	}
+
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		d    *v1alpha1.RDSDatabase
+		want want
+	}{
+		"DatabaseExists": {
+			e: &databaseExternal{
+				db:       ownedDatabase([]string{databaseName}, map[string][]string{databaseOwner: {databaseName}}),
+				endpoint: userEndpoint,
+			},
+			d: rdsDatabase(),
+			want: want{
+				o: resource.ExternalObservation{
+					ResourceExists: true,
+					ConnectionDetails: resource.ConnectionDetails{
+						runtimev1alpha1.ResourceCredentialsSecretEndpointKey:  []byte(userEndpoint),
+						runtimev1alpha1.ResourceCredentialsSecretUserKey:      []byte(databaseOwner),
+						databasev1alpha1.ResourceCredentialsSecretDatabaseKey: []byte(databaseName),
+					},
+				},
+				d: rdsDatabase(
+					withDatabaseConditions(runtimev1alpha1.Available()),
+					withDatabaseBindingPhase(runtimev1alpha1.BindingPhaseUnbound),
+				),
+			},
+		},
+		"DatabaseDoesNotExist": {
+			e:    &databaseExternal{db: ownedDatabase(nil, nil)},
+			d:    rdsDatabase(),
+			want: want{o: resource.ExternalObservation{ResourceExists: false}, d: rdsDatabase()},
+		},
+		"OwnerDoesNotExist": {
+			e:    &databaseExternal{db: ownedDatabase([]string{databaseName}, nil)},
+			d:    rdsDatabase(),
+			want: want{o: resource.ExternalObservation{ResourceExists: false}, d: rdsDatabase()},
+		},
+		"OwnerNotGranted": {
+			e:    &databaseExternal{db: ownedDatabase([]string{databaseName}, map[string][]string{databaseOwner: {}})},
+			d:    rdsDatabase(),
+			want: want{o: resource.ExternalObservation{ResourceExists: false}, d: rdsDatabase()},
+		},
+		"InstanceGone": {
+			e:    &databaseExternal{},
+			d:    rdsDatabase(),
+			want: want{o: resource.ExternalObservation{ResourceExists: false}, d: rdsDatabase()},
+		},
+		"DatabaseExistsFailed": {
+			e: &databaseExternal{db: &fake.MockClient{MockDatabaseExists: func(_ context.Context, _ string) (bool, error) {
+				return false, errDatabaseBoom
+			}}},
+			d:    rdsDatabase(),
+			want: want{d: rdsDatabase(), err: errors.Wrap(errDatabaseBoom, errDatabaseExists)},
+		},
+		"OwnerGrantedFailed": {
+			e: &databaseExternal{db: &fake.MockClient{
+				MockDatabaseExists: func(_ context.Context, _ string) (bool, error) { return true, nil },
+				MockUserExists:     func(_ context.Context, _ string) (bool, error) { return false, errDatabaseBoom },
+			}},
+			d:    rdsDatabase(),
+			want: want{d: rdsDatabase(), err: errors.Wrap(errDatabaseBoom, errOwnerGranted)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.d)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("e.Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.d, tc.d, test.EquateConditions()); diff != "" {
+				t.Errorf("e.Observe(...): -want database, +got database:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDatabaseCreate(t *testing.T) {
+	type want struct {
+		password bool
+		d        *v1alpha1.RDSDatabase
+		err      error
+	}
+
+	// owner returns a mock SQL client in which the named database may be
+	// created and granted to its owner.
+	owner := func(exists bool, createErr error) *fake.MockClient {
+		return &fake.MockClient{
+			MockDatabaseExists: func(_ context.Context, _ string) (bool, error) { return exists, nil },
+			MockCreateDatabase: func(_ context.Context, name string) error {
+				if exists {
+					return errors.Errorf("database %s already exists", name)
+				}
+				return createErr
+			},
+			MockDeleteUser: func(_ context.Context, _ string) error { return nil },
+			MockCreateUser: func(_ context.Context, name, password string) error {
+				if name != databaseOwner || password == "" {
+					return errors.Errorf("unexpected user %s", name)
+				}
+				return nil
+			},
+			MockGrant: func(_ context.Context, user, database string) error {
+				if user != databaseOwner || database != databaseName {
+					return errors.Errorf("unexpected grant of %s to %s", database, user)
+				}
+				return nil
+			},
+		}
+	}
+
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		d    *v1alpha1.RDSDatabase
+		want want
+	}{
+		"Successful": {
+			e: &databaseExternal{db: owner(false, nil)},
+			d: rdsDatabase(),
+			want: want{
+				password: true,
+				d:        rdsDatabase(withDatabaseConditions(runtimev1alpha1.Creating())),
+			},
+		},
+		"DatabaseAlreadyExists": {
+			e: &databaseExternal{db: owner(true, nil)},
+			d: rdsDatabase(),
+			want: want{
+				password: true,
+				d:        rdsDatabase(withDatabaseConditions(runtimev1alpha1.Creating())),
+			},
+		},
+		"CreateFailed": {
+			e: &databaseExternal{db: owner(false, errDatabaseBoom)},
+			d: rdsDatabase(),
+			want: want{
+				d:   rdsDatabase(withDatabaseConditions(runtimev1alpha1.Creating())),
+				err: errors.Wrap(errDatabaseBoom, errCreateDatabase),
+			},
+		},
+		"CreateOwnerFailed": {
+			e: &databaseExternal{db: func() *fake.MockClient {
+				c := owner(false, nil)
+				c.MockGrant = func(_ context.Context, _, _ string) error { return errDatabaseBoom }
+				return c
+			}()},
+			d: rdsDatabase(),
+			want: want{
+				d:   rdsDatabase(withDatabaseConditions(runtimev1alpha1.Creating())),
+				err: errors.Wrap(errDatabaseBoom, errCreateOwner),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c, err := tc.e.Create(context.Background(), tc.d)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Create(...): -want error, +got error:\n%s", diff)
+			}
+			if got := len(c.ConnectionDetails[runtimev1alpha1.ResourceCredentialsSecretPasswordKey]) > 0; got != tc.want.password {
+				t.Errorf("e.Create(...): want password %t, got %t", tc.want.password, got)
+			}
+			if diff := cmp.Diff(tc.want.d, tc.d, test.EquateConditions()); diff != "" {
+				t.Errorf("e.Create(...): -want database, +got database:\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestDatabaseConnectionDetails ensures that the connection details of a
+// database never include the master credentials of its instance, which are
+// used to create the database.
+func TestDatabaseConnectionDetails(t *testing.T) {
+	var created, exists bool
+	db := &fake.MockClient{
+		MockDatabaseExists: func(_ context.Context, _ string) (bool, error) { return created, nil },
+		MockCreateDatabase: func(_ context.Context, _ string) error { created = true; return nil },
+		MockUserExists:     func(_ context.Context, _ string) (bool, error) { return exists, nil },
+		MockDeleteUser:     func(_ context.Context, _ string) error { return nil },
+		MockCreateUser:     func(_ context.Context, _, _ string) error { exists = true; return nil },
+		MockGrant:          func(_ context.Context, _, _ string) error { return nil },
+		MockGrants:         func(_ context.Context, _ string) ([]string, error) { return []string{databaseName}, nil },
+	}
+
+	kube := userKube(userInstance(v1alpha1.RDSInstanceStateAvailable), nil)
+	bundleFile, cleanup := caBundle(t, []byte("cool-ca-bundle"))
+	defer cleanup()
+	c := &databaseConnecter{client: kube, caBundleFile: bundleFile, newClientFn: func(o sql.Options) (sql.Client, error) {
+		if o.Password != userMasterPass {
+			return nil, errors.New("unexpected credentials")
+		}
+		return db, nil
+	}}
+
+	d := rdsDatabase()
+	e, err := c.Connect(context.Background(), d)
+	if err != nil {
+		t.Fatalf("c.Connect(...): %s", err)
+	}
+	cr, err := e.Create(context.Background(), d)
+	if err != nil {
+		t.Fatalf("e.Create(...): %s", err)
+	}
+	o, err := e.Observe(context.Background(), d)
+	if err != nil {
+		t.Fatalf("e.Observe(...): %s", err)
+	}
+
+	for _, cd := range []resource.ConnectionDetails{cr.ConnectionDetails, o.ConnectionDetails} {
+		for k, v := range cd {
+			if string(v) == userMasterPass || string(v) == userMasterName {
+				t.Errorf("connection detail %s: must not contain the instance's master credentials", k)
+			}
+		}
+	}
+}
+
+func TestDatabaseDelete(t *testing.T) {
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		d    *v1alpha1.RDSDatabase
+		want error
+	}{
+		"Successful": {
+			e: &databaseExternal{db: &fake.MockClient{
+				MockDeleteDatabase: func(_ context.Context, _ string) error { return nil },
+				MockDeleteUser: func(_ context.Context, name string) error {
+					if name != databaseOwner {
+						return errors.Errorf("unexpected user %s", name)
+					}
+					return nil
+				},
+			}},
+			d: rdsDatabase(),
+		},
+		"InstanceGone": {
+			e: &databaseExternal{},
+			d: rdsDatabase(),
+		},
+		"DeleteFailed": {
+			e: &databaseExternal{db: &fake.MockClient{MockDeleteDatabase: func(_ context.Context, _ string) error {
+				return errDatabaseBoom
+			}}},
+			d:    rdsDatabase(),
+			want: errors.Wrap(errDatabaseBoom, errDeleteDatabase),
+		},
+		"DeleteOwnerFailed": {
+			e: &databaseExternal{db: &fake.MockClient{
+				MockDeleteDatabase: func(_ context.Context, _ string) error { return nil },
+				MockDeleteUser:     func(_ context.Context, _ string) error { return errDatabaseBoom },
+			}},
+			d:    rdsDatabase(),
+			want: errors.Wrap(errDatabaseBoom, errDeleteOwner),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.e.Delete(context.Background(), tc.d)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Delete(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rds
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	"github.com/crossplaneio/crossplane/apis"
+	awsapis "github.com/crossplaneio/crossplane/aws/apis"
+	localtest "github.com/crossplaneio/crossplane/pkg/test"
+)
+
+var cfg *rest.Config
+
+func TestMain(m *testing.M) {
+	t := test.NewEnv(namespace, runtime.SchemeBuilder{apis.AddToScheme, awsapis.AddToScheme}, localtest.CRDs())
+	cfg = t.Start()
+	t.StopAndExit(m.Run())
+}
@@ -25,8 +25,8 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -40,6 +40,13 @@ import (
 	awsv1alpha1 "github.com/crossplaneio/crossplane/aws/apis/v1alpha1"
 	"github.com/crossplaneio/crossplane/pkg/clients/aws"
 	"github.com/crossplaneio/crossplane/pkg/clients/aws/rds"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/externalname"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/reference"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
 )
 
 const (
@@ -54,15 +61,23 @@ var (
 	resultRequeue = reconcile.Result{Requeue: true}
 )
 
+// Error strings.
+const (
+	errDeletionProtected   = "cannot delete external resource: deletion protection is enabled; set spec.deletionProtection to false to delete it"
+	errFinalSnapshotExists = "cannot take final snapshot of external resource: a snapshot with the same name already exists"
+)
+
 // Reconciler reconciles a Instance object
 type Reconciler struct {
 	client.Client
 	scheme     *runtime.Scheme
 	kubeclient kubernetes.Interface
-	recorder   record.EventRecorder
+	recorder   *event.Recorder
+	pool       *pool.Pool
 
 	connect func(*databasev1alpha1.RDSInstance) (rds.Client, error)
 	create  func(*databasev1alpha1.RDSInstance, rds.Client) (reconcile.Result, error)
+	adopt   func(*databasev1alpha1.RDSInstance, rds.Client) (reconcile.Result, error)
 	sync    func(*databasev1alpha1.RDSInstance, rds.Client) (reconcile.Result, error)
 	delete  func(*databasev1alpha1.RDSInstance, rds.Client) (reconcile.Result, error)
 }
@@ -78,10 +93,12 @@ func (c *InstanceController) SetupWithManager(mgr ctrl.Manager) error {
 		Client:     mgr.GetClient(),
 		scheme:     mgr.GetScheme(),
 		kubeclient: kubernetes.NewForConfigOrDie(mgr.GetConfig()),
-		recorder:   mgr.GetEventRecorderFor(controllerName),
+		recorder:   event.NewRecorder(mgr.GetEventRecorderFor(controllerName), event.WithErrorCoder(aws.ErrorCode)),
+		pool:       pool.Default,
 	}
 	r.connect = r._connect
 	r.create = r._create
+	r.adopt = r._adopt
 	r.sync = r._sync
 	r.delete = r._delete
 
@@ -89,11 +106,12 @@ func (c *InstanceController) SetupWithManager(mgr ctrl.Manager) error {
 		Named("instance-controller").
 		For(&databasev1alpha1.RDSInstance{}).
 		Owns(&corev1.Secret{}).
-		Complete(r)
+		Complete(tracing.NewTracer("instance-controller").Reconciler(pause.NewReconciler(mgr.GetClient(), &databasev1alpha1.RDSInstance{}, r, pause.WithObjectUpdate())))
 }
 
 // fail - helper function to set fail condition with reason and message
 func (r *Reconciler) fail(instance *databasev1alpha1.RDSInstance, err error) (reconcile.Result, error) {
+	r.recorder.Failed(instance, err)
 	instance.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
 	return reconcile.Result{Requeue: true}, r.Update(context.TODO(), instance)
 }
@@ -117,7 +135,7 @@ func (r *Reconciler) _connect(instance *databasev1alpha1.RDSInstance) (rds.Clien
 	}
 
 	// Get Provider's AWS Config
-	config, err := aws.Config(r.kubeclient, p)
+	config, err := aws.PooledConfig(ctx, r.pool, r.Client, p, rds.API)
 	if err != nil {
 		return nil, err
 	}
@@ -141,19 +159,98 @@ func (r *Reconciler) _create(instance *databasev1alpha1.RDSInstance, client rds.
 		return r.fail(instance, err)
 	}
 
-	// Create DB Instance
-	_, err = client.CreateInstance(resourceName, password, &instance.Spec)
-	if err != nil && !rds.IsErrorAlreadyExists(err) {
+	// Create DB Instance, or restore it from a snapshot or point in time
+	if instance.Spec.RestoreFrom != nil {
+		src, err := r.restoreSource(instance)
+		if err != nil {
+			return r.fail(instance, err)
+		}
+		_, err = client.RestoreInstance(resourceName, src, &instance.Spec)
+		if err != nil && !rds.IsErrorAlreadyExists(err) {
+			return r.fail(instance, err)
+		}
+	} else {
+		_, err = client.CreateInstance(resourceName, password, &instance.Spec)
+		if err != nil && !rds.IsErrorAlreadyExists(err) {
+			return r.fail(instance, err)
+		}
+	}
+
+	instance.Status.InstanceName = resourceName
+	meta.AddFinalizer(instance, finalizer)
+	r.recorder.Normal(instance, event.ReasonCreateRequested, "Requested creation of external resource")
+	instance.Status.SetConditions(runtimev1alpha1.ReconcileSuccess())
+
+	return resultRequeue, r.Update(ctx, instance)
+}
+
+// _adopt imports the existing RDS instance named by the supplied instance's
+// external name. RDS never returns the master password of an instance, so any
+// password already written to the connection secret is kept.
+func (r *Reconciler) _adopt(instance *databasev1alpha1.RDSInstance, client rds.Client) (reconcile.Result, error) {
+	resourceName := externalname.Get(instance)
+	if _, err := client.GetInstance(resourceName); err != nil {
+		return r.fail(instance, errors.Wrapf(err, "cannot import RDS instance %s", resourceName))
+	}
+
+	password := ""
+	s, err := r.kubeclient.CoreV1().
+		Secrets(instance.GetNamespace()).
+		Get(instance.GetWriteConnectionSecretToReference().Name, metav1.GetOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return r.fail(instance, err)
+	}
+	if err == nil {
+		password = string(s.Data[runtimev1alpha1.ResourceCredentialsSecretPasswordKey])
+	}
+	if _, err := util.ApplySecret(r.kubeclient, connectionSecret(instance, password)); err != nil {
 		return r.fail(instance, err)
 	}
 
 	instance.Status.InstanceName = resourceName
 	meta.AddFinalizer(instance, finalizer)
+	r.recorder.Normal(instance, event.ReasonImported, "Imported existing external resource")
 	instance.Status.SetConditions(runtimev1alpha1.ReconcileSuccess())
 
 	return resultRequeue, r.Update(ctx, instance)
 }
 
+// restoreSource resolves the snapshot or point in time from which the supplied
+// instance should be restored.
+func (r *Reconciler) restoreSource(instance *databasev1alpha1.RDSInstance) (rds.RestoreSource, error) {
+	from := instance.Spec.RestoreFrom
+
+	if from.SnapshotRef != nil {
+		snapshot := &databasev1alpha1.RDSSnapshot{}
+		n := types.NamespacedName{Namespace: instance.GetNamespace(), Name: from.SnapshotRef.Name}
+		if err := r.Get(ctx, n, snapshot); err != nil {
+			return rds.RestoreSource{}, errors.Wrap(err, "cannot get RDSSnapshot to restore from")
+		}
+		if snapshot.Status.State != databasev1alpha1.RDSSnapshotStateAvailable {
+			return rds.RestoreSource{}, errors.Errorf("RDSSnapshot %s is not yet available", n.Name)
+		}
+		return rds.RestoreSource{SnapshotName: snapshot.Status.SnapshotIdentifier}, nil
+	}
+
+	if from.PointInTime != nil {
+		source := &databasev1alpha1.RDSInstance{}
+		n := types.NamespacedName{Namespace: instance.GetNamespace(), Name: from.PointInTime.InstanceRef.Name}
+		if err := r.Get(ctx, n, source); err != nil {
+			return rds.RestoreSource{}, errors.Wrap(err, "cannot get RDSInstance to restore from")
+		}
+		if source.Status.InstanceName == "" {
+			return rds.RestoreSource{}, errors.Errorf("RDSInstance %s has not yet been created", n.Name)
+		}
+		src := rds.RestoreSource{SourceInstanceName: source.Status.InstanceName}
+		if t := from.PointInTime.RestoreTime; t != nil {
+			src.RestoreTime = &t.Time
+		}
+		return src, nil
+	}
+
+	return rds.RestoreSource{}, errors.New("restoreFrom must specify either snapshotRef or pointInTime")
+}
+
 func (r *Reconciler) _sync(instance *databasev1alpha1.RDSInstance, client rds.Client) (reconcile.Result, error) {
 	// Search for the RDS instance in AWS
 	db, err := client.GetInstance(instance.Status.InstanceName)
@@ -170,7 +267,13 @@ func (r *Reconciler) _sync(instance *databasev1alpha1.RDSInstance, client rds.Cl
 	case string(databasev1alpha1.RDSInstanceStateFailed):
 		instance.Status.SetConditions(runtimev1alpha1.Unavailable(), runtimev1alpha1.ReconcileSuccess())
 		return result, r.Update(ctx, instance)
-	case string(databasev1alpha1.RDSInstanceStateAvailable):
+	case string(databasev1alpha1.RDSInstanceStateModifying), string(databasev1alpha1.RDSInstanceStateResettingMasterCredentials):
+		instance.Status.SetConditions(runtimev1alpha1.Unavailable(), runtimev1alpha1.ReconcileSuccess())
+		return resultRequeue, r.Update(ctx, instance)
+	case string(databasev1alpha1.RDSInstanceStateAvailable), string(databasev1alpha1.RDSInstanceStateBackingUp):
+		if !event.Ready(instance) {
+			r.recorder.Normal(instance, event.ReasonBecameReady, "External resource became ready")
+		}
 		instance.Status.SetConditions(runtimev1alpha1.Available())
 		resource.SetBindable(instance)
 	default:
@@ -185,6 +288,41 @@ func (r *Reconciler) _sync(instance *databasev1alpha1.RDSInstance, client rds.Cl
 		return r.fail(instance, err)
 	}
 
+	// Restored instances keep the master password of their source, so we
+	// reset it to the one we generated when the instance was created. Their
+	// security groups cannot be set on restore, so we set them now too.
+	if instance.Spec.RestoreFrom != nil && !instance.Status.MasterPasswordReset {
+		pw := string(connSecret.Data[runtimev1alpha1.ResourceCredentialsSecretPasswordKey])
+		if err := client.ModifyRestoredInstance(instance.Status.InstanceName, pw, &instance.Spec); err != nil {
+			return r.fail(instance, err)
+		}
+		instance.Status.MasterPasswordReset = true
+		r.recorder.Normal(instance, event.ReasonUpdateApplied, "Reset master password and security groups of external resource")
+		instance.Status.SetConditions(runtimev1alpha1.ReconcileSuccess())
+		return resultRequeue, r.Update(ctx, instance)
+	}
+
+	// An instance whose spec does not set deletion protection, for example
+	// because it was adopted, keeps the protection it was observed with rather
+	// than having protection that was enabled out of band disabled.
+	if instance.Spec.DeletionProtection == nil {
+		observed := db.DeletionProtection
+		instance.Spec.DeletionProtection = &observed
+		instance.Status.SetConditions(runtimev1alpha1.ReconcileSuccess())
+		return resultRequeue, r.Update(ctx, instance)
+	}
+
+	// Deletion protection may be changed after the instance is created, either
+	// in its spec or out of band, so we keep it in sync with the spec.
+	if db.DeletionProtection != *instance.Spec.DeletionProtection {
+		if err := client.SetDeletionProtection(instance.Status.InstanceName, *instance.Spec.DeletionProtection); err != nil {
+			return r.fail(instance, err)
+		}
+		r.recorder.Normal(instance, event.ReasonUpdateApplied, "Updated deletion protection of external resource")
+		instance.Status.SetConditions(runtimev1alpha1.ReconcileSuccess())
+		return resultRequeue, r.Update(ctx, instance)
+	}
+
 	// Save resource endpoint
 	instance.Status.Endpoint = db.Endpoint
 	instance.Status.ProviderID = db.ARN
@@ -203,10 +341,46 @@ func (r *Reconciler) _sync(instance *databasev1alpha1.RDSInstance, client rds.Cl
 func (r *Reconciler) _delete(instance *databasev1alpha1.RDSInstance, client rds.Client) (reconcile.Result, error) {
 	instance.Status.SetConditions(runtimev1alpha1.Deleting())
 
-	if instance.Spec.ReclaimPolicy == runtimev1alpha1.ReclaimDelete {
-		if _, err := client.DeleteInstance(instance.Status.InstanceName); err != nil && !rds.IsErrorNotFound(err) {
+	if instance.Spec.ReclaimPolicy == runtimev1alpha1.ReclaimDelete && instance.Status.InstanceName != "" {
+		db, err := client.GetInstance(instance.Status.InstanceName)
+		if err != nil && !rds.IsErrorNotFound(err) {
 			return r.fail(instance, err)
 		}
+
+		// The instance is gone, or a previous reconcile already started
+		// deleting it.
+		if err != nil || db.Status == string(databasev1alpha1.RDSInstanceStateDeleting) {
+			meta.RemoveFinalizer(instance, finalizer)
+			instance.Status.SetConditions(runtimev1alpha1.ReconcileSuccess())
+			return result, r.Update(ctx, instance)
+		}
+
+		// RDS refuses to delete instances with deletion protection enabled. We
+		// disable it first unless the spec asks for it, in which case deleting
+		// the instance would go against the spec.
+		if db.DeletionProtection {
+			if aws.BoolValue(instance.Spec.DeletionProtection) {
+				return r.fail(instance, errors.New(errDeletionProtected))
+			}
+			if err := client.SetDeletionProtection(instance.Status.InstanceName, false); err != nil {
+				return r.fail(instance, err)
+			}
+			r.recorder.Normal(instance, event.ReasonUpdateApplied, "Disabled deletion protection of external resource")
+			instance.Status.SetConditions(runtimev1alpha1.ReconcileSuccess())
+			return resultRequeue, r.Update(ctx, instance)
+		}
+
+		finalSnapshot := ""
+		if instance.Spec.FinalSnapshot {
+			finalSnapshot = rds.FinalSnapshotName(instance.Status.InstanceName, instance.GetDeletionTimestamp().Time)
+		}
+		if _, err := client.DeleteInstance(instance.Status.InstanceName, finalSnapshot); err != nil && !rds.IsErrorNotFound(err) {
+			if rds.IsErrorSnapshotAlreadyExists(err) {
+				return r.fail(instance, errors.Wrap(err, errFinalSnapshotExists))
+			}
+			return r.fail(instance, err)
+		}
+		r.recorder.Normal(instance, event.ReasonDeletionStarted, "Requested deletion of external resource")
 	}
 
 	meta.RemoveFinalizer(instance, finalizer)
@@ -232,6 +406,18 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 		return reconcile.Result{}, err
 	}
 
+	// Resolve references to other managed resources
+	if instance.DeletionTimestamp == nil && len(instance.GetReferencers()) > 0 {
+		if err := reference.Resolve(ctx, r, instance); err != nil {
+			if reference.IsNotReady(err) {
+				instance.Status.SetConditions(reference.WaitingForReferences(err))
+				return resultRequeue, r.Update(ctx, instance)
+			}
+			return r.fail(instance, err)
+		}
+		instance.Status.SetConditions(reference.ReferencesResolved())
+	}
+
 	rdsClient, err := r.connect(instance)
 	if err != nil {
 		return r.fail(instance, err)
@@ -239,11 +425,17 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 
 	// Check for deletion
 	if instance.DeletionTimestamp != nil {
+		if err := protection.Check(instance); err != nil {
+			return r.fail(instance, err)
+		}
 		return r.delete(instance, rdsClient)
 	}
 
-	// Create cluster instance
+	// Import an existing instance, or create a new one
 	if instance.Status.InstanceName == "" {
+		if externalname.Get(instance) != "" {
+			return r.adopt(instance, rdsClient)
+		}
 		return r.create(instance, rdsClient)
 	}
 
@@ -19,6 +19,9 @@ package rds
 import (
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsrds "github.com/aws/aws-sdk-go-v2/service/rds"
+
 	"github.com/crossplaneio/crossplane/aws/apis"
 
 	"github.com/google/go-cmp/cmp"
@@ -38,9 +41,13 @@ import (
 	"github.com/crossplaneio/crossplane-runtime/pkg/test"
 	"github.com/crossplaneio/crossplane/aws/apis/database/v1alpha1"
 	. "github.com/crossplaneio/crossplane/aws/apis/database/v1alpha1"
+	networkv1alpha1 "github.com/crossplaneio/crossplane/aws/apis/network/v1alpha1"
 	awsv1alpha1 "github.com/crossplaneio/crossplane/aws/apis/v1alpha1"
 	"github.com/crossplaneio/crossplane/pkg/clients/aws/rds"
 	. "github.com/crossplaneio/crossplane/pkg/clients/aws/rds/fake"
+	"github.com/crossplaneio/crossplane/pkg/externalname"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/reference"
 )
 
 const (
@@ -90,10 +97,11 @@ func testResource() *RDSInstance {
 				ProviderReference: &corev1.ObjectReference{},
 			},
 			RDSInstanceParameters: v1alpha1.RDSInstanceParameters{
-				MasterUsername: masterUserName,
-				Engine:         engine,
-				Class:          class,
-				Size:           size,
+				MasterUsername:     masterUserName,
+				Engine:             engine,
+				Class:              class,
+				Size:               size,
+				DeletionProtection: aws.Bool(false),
 			},
 		},
 	}
@@ -262,10 +270,130 @@ func TestSyncCluster(t *testing.T) {
 	g.Expect(rr.Status.State).To(Equal(string(RDSInstanceStateAvailable)))
 }
 
+func TestSyncClusterModifyRestoredInstance(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tr := testResource()
+	tr.Spec.SecurityGroups = []string{"sg-cool"}
+	tr.Spec.RestoreFrom = &RDSInstanceRestoreSource{SnapshotRef: &corev1.LocalObjectReference{Name: "test-snapshot"}}
+	tr.Status.InstanceName = "mysql-test"
+	ts := connectionSecret(tr, "testPassword")
+
+	r := &Reconciler{
+		Client:     NewFakeClient(tr),
+		kubeclient: NewSimpleClientset(ts),
+	}
+
+	var gotName, gotPassword string
+	var gotSecurityGroups []string
+	cl := &MockRDSClient{
+		MockGetInstance: func(s string) (instance *rds.Instance, e error) {
+			return &rds.Instance{
+				Status: string(RDSInstanceStateAvailable),
+			}, nil
+		},
+		MockModifyRestoredInstance: func(name, password string, spec *RDSInstanceSpec) error {
+			gotName, gotPassword, gotSecurityGroups = name, password, spec.SecurityGroups
+			return nil
+		},
+	}
+
+	expectedStatus := runtimev1alpha1.ConditionedStatus{}
+	expectedStatus.SetConditions(runtimev1alpha1.Available(), runtimev1alpha1.ReconcileSuccess())
+
+	rs, err := r._sync(tr, cl)
+	g.Expect(rs).To(Equal(resultRequeue))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(gotName).To(Equal("mysql-test"))
+	g.Expect(gotPassword).To(Equal("testPassword"))
+	g.Expect(gotSecurityGroups).To(Equal([]string{"sg-cool"}))
+	rr := assertResource(g, r, expectedStatus)
+	g.Expect(rr.Status.MasterPasswordReset).To(BeTrue())
+}
+
+func TestSyncClusterDeletionProtection(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tr := testResource()
+	tr.Spec.DeletionProtection = aws.Bool(true)
+	tr.Status.InstanceName = "mysql-test"
+	ts := connectionSecret(tr, "testPassword")
+
+	r := &Reconciler{
+		Client:     NewFakeClient(tr),
+		kubeclient: NewSimpleClientset(ts),
+	}
+
+	var gotName string
+	var gotEnabled bool
+	cl := &MockRDSClient{
+		MockGetInstance: func(s string) (instance *rds.Instance, e error) {
+			return &rds.Instance{
+				Status:             string(RDSInstanceStateAvailable),
+				DeletionProtection: false,
+			}, nil
+		},
+		MockSetDeletionProtection: func(name string, enabled bool) error {
+			gotName, gotEnabled = name, enabled
+			return nil
+		},
+	}
+
+	expectedStatus := runtimev1alpha1.ConditionedStatus{}
+	expectedStatus.SetConditions(runtimev1alpha1.Available(), runtimev1alpha1.ReconcileSuccess())
+
+	rs, err := r._sync(tr, cl)
+	g.Expect(rs).To(Equal(resultRequeue))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(gotName).To(Equal("mysql-test"))
+	g.Expect(gotEnabled).To(BeTrue())
+	assertResource(g, r, expectedStatus)
+}
+
+func TestSyncClusterAdoptDeletionProtection(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tr := testResource()
+	tr.Spec.DeletionProtection = nil
+	tr.Status.InstanceName = "mysql-test"
+	ts := connectionSecret(tr, "testPassword")
+
+	r := &Reconciler{
+		Client:     NewFakeClient(tr),
+		kubeclient: NewSimpleClientset(ts),
+	}
+
+	called := false
+	cl := &MockRDSClient{
+		MockGetInstance: func(s string) (instance *rds.Instance, e error) {
+			return &rds.Instance{
+				Status:             string(RDSInstanceStateAvailable),
+				DeletionProtection: true,
+			}, nil
+		},
+		MockSetDeletionProtection: func(name string, enabled bool) error {
+			called = true
+			return nil
+		},
+	}
+
+	expectedStatus := runtimev1alpha1.ConditionedStatus{}
+	expectedStatus.SetConditions(runtimev1alpha1.Available(), runtimev1alpha1.ReconcileSuccess())
+
+	rs, err := r._sync(tr, cl)
+	g.Expect(rs).To(Equal(resultRequeue))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(called).To(BeFalse())
+	rr := assertResource(g, r, expectedStatus)
+	g.Expect(rr.Spec.DeletionProtection).To(Equal(aws.Bool(true)))
+}
+
 func TestDelete(t *testing.T) {
 	g := NewGomegaWithT(t)
 
 	tr := testResource()
+	deleted := metav1.Unix(1567000000, 0)
+	tr.DeletionTimestamp = &deleted
 
 	r := &Reconciler{
 		Client:     NewFakeClient(tr),
@@ -286,9 +414,15 @@ func TestDelete(t *testing.T) {
 
 	// test delete w/ delete policy
 	tr.Spec.ReclaimPolicy = runtimev1alpha1.ReclaimDelete
+	tr.Status.InstanceName = "mysql-test"
+	cl.MockGetInstance = func(name string) (*rds.Instance, error) {
+		return &rds.Instance{Name: name, Status: string(RDSInstanceStateAvailable)}, nil
+	}
 	called := false
-	cl.MockDeleteInstance = func(name string) (instance *rds.Instance, e error) {
+	var gotFinalSnapshot string
+	cl.MockDeleteInstance = func(name, finalSnapshot string) (instance *rds.Instance, e error) {
 		called = true
+		gotFinalSnapshot = finalSnapshot
 		return nil, nil
 	}
 
@@ -296,12 +430,86 @@ func TestDelete(t *testing.T) {
 	g.Expect(rs).To(Equal(result))
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(called).To(BeTrue())
+	g.Expect(gotFinalSnapshot).To(BeEmpty())
+	assertResource(g, r, expectedStatus)
+
+	// test delete w/ delete policy and final snapshot
+	tr.Spec.FinalSnapshot = true
+	called = false
+
+	rs, err = r._delete(tr, cl)
+	g.Expect(rs).To(Equal(result))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(called).To(BeTrue())
+	g.Expect(gotFinalSnapshot).To(Equal("final-mysql-test-1567000000"))
+	assertResource(g, r, expectedStatus)
+
+	// test delete of an instance that is already being deleted
+	cl.MockGetInstance = func(name string) (*rds.Instance, error) {
+		return &rds.Instance{Name: name, Status: string(RDSInstanceStateDeleting)}, nil
+	}
+	called = false
+
+	rs, err = r._delete(tr, cl)
+	g.Expect(rs).To(Equal(result))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(called).To(BeFalse())
+	assertResource(g, r, expectedStatus)
+
+	// test delete of an instance with deletion protection enabled out of band
+	cl.MockGetInstance = func(name string) (*rds.Instance, error) {
+		return &rds.Instance{Name: name, Status: string(RDSInstanceStateAvailable), DeletionProtection: true}, nil
+	}
+	var gotEnabled *bool
+	cl.MockSetDeletionProtection = func(name string, enabled bool) error {
+		gotEnabled = &enabled
+		return nil
+	}
+
+	rs, err = r._delete(tr, cl)
+	g.Expect(rs).To(Equal(resultRequeue))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(called).To(BeFalse())
+	g.Expect(gotEnabled).NotTo(BeNil())
+	g.Expect(*gotEnabled).To(BeFalse())
+	assertResource(g, r, expectedStatus)
+
+	// test delete of an instance whose spec enables deletion protection
+	tr.Spec.DeletionProtection = aws.Bool(true)
+	gotEnabled = nil
+	expectedStatus = runtimev1alpha1.ConditionedStatus{}
+	expectedStatus.SetConditions(runtimev1alpha1.Deleting(), runtimev1alpha1.ReconcileError(errors.New(errDeletionProtected)))
+
+	rs, err = r._delete(tr, cl)
+	g.Expect(rs).To(Equal(resultRequeue))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(called).To(BeFalse())
+	g.Expect(gotEnabled).To(BeNil())
+	assertResource(g, r, expectedStatus)
+
+	// test delete w/ delete policy and an existing final snapshot
+	tr.Spec.DeletionProtection = aws.Bool(false)
+	cl.MockGetInstance = func(name string) (*rds.Instance, error) {
+		return &rds.Instance{Name: name, Status: string(RDSInstanceStateAvailable)}, nil
+	}
+	snapshotExists := errors.New(awsrds.ErrCodeDBSnapshotAlreadyExistsFault)
+	cl.MockDeleteInstance = func(name, finalSnapshot string) (instance *rds.Instance, e error) {
+		called = true
+		return nil, snapshotExists
+	}
+	expectedStatus = runtimev1alpha1.ConditionedStatus{}
+	expectedStatus.SetConditions(runtimev1alpha1.Deleting(), runtimev1alpha1.ReconcileError(errors.Wrap(snapshotExists, errFinalSnapshotExists)))
+
+	rs, err = r._delete(tr, cl)
+	g.Expect(rs).To(Equal(resultRequeue))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(called).To(BeTrue())
 	assertResource(g, r, expectedStatus)
 
 	// test delete w/ delete policy and delete error
 	testError := errors.New("test-delete-error")
 	called = false
-	cl.MockDeleteInstance = func(name string) (instance *rds.Instance, e error) {
+	cl.MockDeleteInstance = func(name, finalSnapshot string) (instance *rds.Instance, e error) {
 		called = true
 		return nil, testError
 	}
@@ -397,6 +605,130 @@ func TestCreateFail(t *testing.T) {
 	assertResource(g, r, expectedStatus)
 }
 
+func TestAdopt(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tr := testResource()
+	externalname.Set(tr, "existing")
+	tk := NewSimpleClientset(connectionSecret(tr, "testPassword"))
+
+	r := &Reconciler{
+		Client:     NewFakeClient(tr),
+		kubeclient: tk,
+	}
+
+	cl := &MockRDSClient{
+		MockGetInstance: func(s string) (instance *rds.Instance, e error) {
+			g.Expect(s).To(Equal("existing"))
+			return &rds.Instance{Status: string(RDSInstanceStateAvailable)}, nil
+		},
+	}
+
+	expectedStatus := runtimev1alpha1.ConditionedStatus{}
+	expectedStatus.SetConditions(runtimev1alpha1.ReconcileSuccess())
+
+	rs, err := r._adopt(tr, cl)
+	g.Expect(rs).To(Equal(resultRequeue))
+	g.Expect(err).NotTo(HaveOccurred())
+	rr := assertResource(g, r, expectedStatus)
+	g.Expect(rr.Status.InstanceName).To(Equal("existing"))
+	g.Expect(rr.GetFinalizers()).To(ContainElement(finalizer))
+
+	// the password written before the import is kept
+	s, err := tk.CoreV1().Secrets(tr.GetNamespace()).Get(tr.GetWriteConnectionSecretToReference().Name, metav1.GetOptions{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(s.Data[runtimev1alpha1.ResourceCredentialsSecretPasswordKey])).To(Equal("testPassword"))
+}
+
+func TestAdoptNotFound(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tr := testResource()
+	externalname.Set(tr, "existing")
+
+	r := &Reconciler{
+		Client:     NewFakeClient(tr),
+		kubeclient: NewSimpleClientset(),
+	}
+
+	testError := errors.New("test-not-found")
+	cl := &MockRDSClient{
+		MockGetInstance: func(s string) (instance *rds.Instance, e error) {
+			return nil, testError
+		},
+	}
+
+	expectedStatus := runtimev1alpha1.ConditionedStatus{}
+	expectedStatus.SetConditions(runtimev1alpha1.ReconcileError(errors.Wrap(testError, "cannot import RDS instance existing")))
+
+	rs, err := r._adopt(tr, cl)
+	g.Expect(rs).To(Equal(resultRequeue))
+	g.Expect(err).NotTo(HaveOccurred())
+	rr := assertResource(g, r, expectedStatus)
+	g.Expect(rr.Status.InstanceName).To(BeEmpty())
+}
+
+func TestCreateRestoreFromSnapshot(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	snapshot := &RDSSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-snapshot", Namespace: namespace},
+		Status: RDSSnapshotStatus{
+			State:              RDSSnapshotStateAvailable,
+			SnapshotIdentifier: "snapshot-test",
+		},
+	}
+	tr := testResource()
+	tr.Spec.RestoreFrom = &RDSInstanceRestoreSource{SnapshotRef: &corev1.LocalObjectReference{Name: snapshot.GetName()}}
+
+	r := &Reconciler{
+		Client:     NewFakeClient(tr, snapshot),
+		kubeclient: NewSimpleClientset(),
+	}
+
+	var got rds.RestoreSource
+	cl := &MockRDSClient{
+		MockRestoreInstance: func(s string, src rds.RestoreSource, spec *RDSInstanceSpec) (instance *rds.Instance, e error) {
+			got = src
+			return nil, nil
+		},
+	}
+
+	expectedStatus := runtimev1alpha1.ConditionedStatus{}
+	expectedStatus.SetConditions(runtimev1alpha1.Creating(), runtimev1alpha1.ReconcileSuccess())
+
+	rs, err := r._create(tr.DeepCopy(), cl)
+	g.Expect(rs).To(Equal(resultRequeue))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got).To(Equal(rds.RestoreSource{SnapshotName: "snapshot-test"}))
+	assertResource(g, r, expectedStatus)
+}
+
+func TestCreateRestoreFromUnavailableSnapshot(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	snapshot := &RDSSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-snapshot", Namespace: namespace},
+		Status:     RDSSnapshotStatus{State: RDSSnapshotStateCreating},
+	}
+	tr := testResource()
+	tr.Spec.RestoreFrom = &RDSInstanceRestoreSource{SnapshotRef: &corev1.LocalObjectReference{Name: snapshot.GetName()}}
+
+	r := &Reconciler{
+		Client:     NewFakeClient(tr, snapshot),
+		kubeclient: NewSimpleClientset(),
+	}
+
+	testError := errors.Errorf("RDSSnapshot %s is not yet available", snapshot.GetName())
+	expectedStatus := runtimev1alpha1.ConditionedStatus{}
+	expectedStatus.SetConditions(runtimev1alpha1.Creating(), runtimev1alpha1.ReconcileError(testError))
+
+	rs, err := r._create(tr.DeepCopy(), &MockRDSClient{})
+	g.Expect(rs).To(Equal(resultRequeue))
+	g.Expect(err).NotTo(HaveOccurred())
+	assertResource(g, r, expectedStatus)
+}
+
 func TestConnect(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -469,6 +801,19 @@ func TestReconcile(t *testing.T) {
 	r.Reconcile(request)
 	g.Expect(called).To(BeTrue())
 
+	// test adopt
+	r.connect = func(instance *RDSInstance) (client rds.Client, e error) {
+		externalname.Set(instance, "existing")
+		return nil, nil
+	}
+	called = false
+	r.adopt = func(instance *RDSInstance, client rds.Client) (i reconcile.Result, e error) {
+		called = true
+		return result, nil
+	}
+	r.Reconcile(request)
+	g.Expect(called).To(BeTrue())
+
 	// test sync
 	r.connect = func(instance *RDSInstance) (client rds.Client, e error) {
 		instance.Status.InstanceName = "foo"
@@ -483,4 +828,53 @@ func TestReconcile(t *testing.T) {
 	r.Reconcile(request)
 	g.Expect(called).To(BeTrue())
 
+	// test delete of a protected instance
+	r.connect = func(instance *RDSInstance) (client rds.Client, e error) {
+		t := metav1.Now()
+		instance.DeletionTimestamp = &t
+		protection.Set(instance, true)
+		return nil, nil
+	}
+	called = false
+	r.delete = func(instance *RDSInstance, client rds.Client) (i reconcile.Result, e error) {
+		called = true
+		return result, nil
+	}
+	rs, err = r.Reconcile(request)
+	g.Expect(rs).To(Equal(resultRequeue))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(called).To(BeFalse())
+}
+
+func TestReconcileWaitingForReferences(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	sgName := "test-securitygroup"
+	tr := testResource()
+	tr.Spec.SecurityGroupRefs = []*SecurityGroupIDReferencerForRDSInstance{{
+		SecurityGroupIDReferencer: networkv1alpha1.SecurityGroupIDReferencer{
+			LocalObjectReference: corev1.LocalObjectReference{Name: sgName},
+		},
+	}}
+
+	r := &Reconciler{
+		Client:     NewFakeClient(tr),
+		kubeclient: NewSimpleClientset(),
+	}
+
+	called := false
+	r.connect = func(instance *RDSInstance) (client rds.Client, e error) {
+		called = true
+		return nil, nil
+	}
+
+	expectedStatus := runtimev1alpha1.ConditionedStatus{}
+	expectedStatus.SetConditions(reference.WaitingForReferences(
+		reference.NewNotReadyError("referenced resource %s does not exist", sgName)))
+
+	rs, err := r.Reconcile(request)
+	g.Expect(rs).To(Equal(resultRequeue))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(called).To(BeFalse())
+	assertResource(g, r, expectedStatus)
 }
@@ -0,0 +1,270 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rds
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	databasev1alpha1 "github.com/crossplaneio/crossplane/apis/database/v1alpha1"
+	"github.com/crossplaneio/crossplane/aws/apis/database/v1alpha1"
+	awsv1alpha1 "github.com/crossplaneio/crossplane/aws/apis/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/aws/rds"
+	rdssim "github.com/crossplaneio/crossplane/pkg/clients/aws/rds/sim"
+	"github.com/crossplaneio/crossplane/pkg/event"
+)
+
+const (
+	simTimeout   = 30 * time.Second
+	simClassName = "sim-mysql"
+)
+
+// startSim starts a manager that runs the MySQLInstance claim controller and
+// an RDSInstance controller that uses the supplied simulated RDS API. It
+// returns a function that stops the manager and fails the test if the manager
+// returned an error. Assertions must not be made in the manager's goroutine.
+func startSim(g *GomegaWithT, sim *rdssim.Client) (client.Client, func()) {
+	mgr, err := manager.New(cfg, manager.Options{MetricsBindAddress: "0"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect((&MySQLInstanceClaimController{}).SetupWithManager(mgr)).To(Succeed())
+
+	r := &Reconciler{
+		Client:     mgr.GetClient(),
+		scheme:     mgr.GetScheme(),
+		kubeclient: kubernetes.NewForConfigOrDie(mgr.GetConfig()),
+		recorder:   event.NewRecorder(mgr.GetEventRecorderFor(controllerName)),
+		connect:    func(*v1alpha1.RDSInstance) (rds.Client, error) { return sim, nil },
+	}
+	r.create = r._create
+	r.adopt = r._adopt
+	r.sync = r._sync
+	r.delete = r._delete
+
+	err = ctrl.NewControllerManagedBy(mgr).
+		Named("instance-controller").
+		For(&v1alpha1.RDSInstance{}).
+		Owns(&corev1.Secret{}).
+		Complete(r)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	stop := make(chan struct{})
+	errs := make(chan error, 1)
+	go func() { errs <- mgr.Start(stop) }()
+	return mgr.GetClient(), func() {
+		close(stop)
+		g.Expect(<-errs).NotTo(HaveOccurred())
+	}
+}
+
+func simProvider() *awsv1alpha1.Provider {
+	return &awsv1alpha1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: providerName},
+		Spec: awsv1alpha1.ProviderSpec{
+			Region:            "sim-region-1",
+			CredentialsSource: awsv1alpha1.CredentialsSourceEnvironment,
+		},
+	}
+}
+
+func simClass() *v1alpha1.RDSInstanceClass {
+	return &v1alpha1.RDSInstanceClass{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: simClassName},
+		SpecTemplate: v1alpha1.RDSInstanceClassSpecTemplate{
+			ResourceClassSpecTemplate: runtimev1alpha1.ResourceClassSpecTemplate{
+				ProviderReference: &corev1.ObjectReference{Namespace: namespace, Name: providerName},
+				ReclaimPolicy:     runtimev1alpha1.ReclaimDelete,
+			},
+			RDSInstanceParameters: v1alpha1.RDSInstanceParameters{
+				MasterUsername: masterUserName,
+				Class:          class,
+				Size:           size,
+			},
+		},
+	}
+}
+
+func simClaim(name string) *databasev1alpha1.MySQLInstance {
+	return &databasev1alpha1.MySQLInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: databasev1alpha1.MySQLInstanceSpec{
+			ResourceClaimSpec: runtimev1alpha1.ResourceClaimSpec{
+				ClassReference: &corev1.ObjectReference{
+					APIVersion: v1alpha1.SchemeGroupVersion.String(),
+					Kind:       v1alpha1.RDSInstanceClassKind,
+					Namespace:  namespace,
+					Name:       simClassName,
+				},
+				WriteConnectionSecretToReference: corev1.LocalObjectReference{Name: name},
+			},
+			EngineVersion: "5.7",
+		},
+	}
+}
+
+// TestMySQLInstanceClaimSim provisions, binds, and deletes MySQLInstance
+// claims against a simulated RDS API.
+func TestMySQLInstanceClaimSim(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	sim := rdssim.NewClient()
+	sim.Polls = 1
+
+	c, stop := startSim(g, sim)
+	defer stop()
+
+	provider := simProvider()
+	g.Expect(c.Create(ctx, provider)).To(Succeed())
+	defer c.Delete(ctx, provider)
+
+	cs := simClass()
+	g.Expect(c.Create(ctx, cs)).To(Succeed())
+	defer c.Delete(ctx, cs)
+
+	cases := map[string]struct {
+		claim  string
+		inject func(sim *rdssim.Client)
+	}{
+		"Succeeds": {
+			claim:  "sim-claim",
+			inject: func(_ *rdssim.Client) {},
+		},
+		"RecoversFromTransientErrors": {
+			claim: "sim-claim-flaky",
+			inject: func(sim *rdssim.Client) {
+				sim.Inject("CreateInstance", errors.New("boom"), 1)
+				sim.Inject("GetInstance", errors.New("boom"), 2)
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			tc.inject(sim)
+
+			cm := simClaim(tc.claim)
+			g.Expect(c.Create(ctx, cm)).To(Succeed())
+			nn := types.NamespacedName{Namespace: namespace, Name: tc.claim}
+
+			// The claim binds once the simulated instance becomes available.
+			g.Eventually(func() (runtimev1alpha1.BindingPhase, error) {
+				got := &databasev1alpha1.MySQLInstance{}
+				err := c.Get(ctx, nn, got)
+				return got.GetBindingPhase(), err
+			}, simTimeout).Should(Equal(runtimev1alpha1.BindingPhaseBound))
+			g.Expect(sim.Instances()).To(HaveLen(1))
+
+			// Its connection secret is propagated from the managed resource.
+			g.Eventually(func() (string, error) {
+				s := &corev1.Secret{}
+				err := c.Get(ctx, nn, s)
+				return string(s.Data[runtimev1alpha1.ResourceCredentialsSecretEndpointKey]), err
+			}, simTimeout).ShouldNot(BeEmpty())
+
+			// Deleting the claim deletes its managed resource, which in turn
+			// deletes the simulated instance.
+			g.Expect(c.Delete(ctx, cm)).To(Succeed())
+			g.Eventually(func() bool {
+				err := c.Get(ctx, nn, &databasev1alpha1.MySQLInstance{})
+				return kerrors.IsNotFound(err)
+			}, simTimeout).Should(BeTrue())
+			g.Eventually(func() []string {
+				sim.Settle()
+				return sim.Instances()
+			}, simTimeout).Should(BeEmpty())
+		})
+	}
+}
+
+// TestMySQLInstanceClaimSimDeletionProtection deletes a MySQLInstance claim
+// whose RDSInstance is protected from deletion against a simulated RDS API.
+func TestMySQLInstanceClaimSimDeletionProtection(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	sim := rdssim.NewClient()
+	sim.Polls = 1
+
+	c, stop := startSim(g, sim)
+	defer stop()
+
+	provider := simProvider()
+	g.Expect(c.Create(ctx, provider)).To(Succeed())
+	defer c.Delete(ctx, provider)
+
+	cs := simClass()
+	cs.SetName("sim-mysql-protected")
+	cs.SpecTemplate.DeletionProtection = aws.Bool(true)
+	cs.SpecTemplate.FinalSnapshot = true
+	g.Expect(c.Create(ctx, cs)).To(Succeed())
+	defer c.Delete(ctx, cs)
+
+	cm := simClaim("sim-claim-protected")
+	cm.Spec.ClassReference.Name = cs.GetName()
+	g.Expect(c.Create(ctx, cm)).To(Succeed())
+	nn := types.NamespacedName{Namespace: namespace, Name: cm.GetName()}
+
+	g.Eventually(func() (runtimev1alpha1.BindingPhase, error) {
+		err := c.Get(ctx, nn, cm)
+		return cm.GetBindingPhase(), err
+	}, simTimeout).Should(Equal(runtimev1alpha1.BindingPhaseBound))
+	g.Expect(sim.Instances()).To(HaveLen(1))
+	mn := meta.NamespacedNameOf(cm.GetResourceReference())
+
+	// The managed resource is kept, and explains why, while its spec protects
+	// the instance from deletion.
+	g.Expect(c.Delete(ctx, cm)).To(Succeed())
+	g.Eventually(func() (string, error) {
+		mg := &v1alpha1.RDSInstance{}
+		err := c.Get(ctx, mn, mg)
+		return mg.Status.GetCondition(runtimev1alpha1.TypeSynced).Message, err
+	}, simTimeout).Should(Equal(errDeletionProtected))
+	g.Expect(sim.Instances()).To(HaveLen(1))
+
+	// Disabling protection in the spec lets the instance be deleted, after a
+	// final snapshot is taken.
+	g.Eventually(func() error {
+		mg := &v1alpha1.RDSInstance{}
+		if err := c.Get(ctx, mn, mg); err != nil {
+			return err
+		}
+		mg.Spec.DeletionProtection = aws.Bool(false)
+		return c.Update(ctx, mg)
+	}, simTimeout).Should(Succeed())
+	g.Eventually(func() bool {
+		err := c.Get(ctx, mn, &v1alpha1.RDSInstance{})
+		return kerrors.IsNotFound(err)
+	}, simTimeout).Should(BeTrue())
+	g.Eventually(func() []string {
+		sim.Settle()
+		return sim.Instances()
+	}, simTimeout).Should(BeEmpty())
+	g.Expect(sim.Snapshots()).To(HaveLen(1))
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rds
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane/aws/apis/database/v1alpha1"
+	awsv1alpha1 "github.com/crossplaneio/crossplane/aws/apis/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/aws"
+	"github.com/crossplaneio/crossplane/pkg/clients/aws/rds"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
+)
+
+// Error strings.
+const (
+	errNewSnapshotClient     = "cannot create new RDS client"
+	errNotSnapshot           = "managed resource is not an RDS snapshot"
+	errGetSnapshot           = "cannot get RDS snapshot"
+	errGetSnapshotInstance   = "cannot get referenced RDSInstance"
+	errSnapshotInstanceState = "referenced RDSInstance has not yet been created"
+	errCreateSnapshot        = "cannot create RDS snapshot"
+	errDeleteSnapshot        = "cannot delete RDS snapshot"
+)
+
+// SnapshotController is responsible for adding the RDSSnapshot controller and
+// its corresponding reconciler to the manager with any runtime configuration.
+type SnapshotController struct{}
+
+// SetupWithManager creates a new RDSSnapshot Controller and adds it to the
+// Manager with default RBAC. The Manager will set fields on the Controller and
+// start it when the Manager is Started.
+func (c *SnapshotController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s", v1alpha1.RDSSnapshotKind, v1alpha1.Group))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(aws.ErrorCode))
+	tracer := tracing.NewTracer(name)
+
+	r := resource.NewManagedReconciler(mgr,
+		resource.ManagedKind(v1alpha1.RDSSnapshotGroupVersionKind),
+		resource.WithExternalConnecter(event.NewConnecter(recorder, protection.NewConnecter(tracer.Connecter(&snapshotConnecter{client: mgr.GetClient(), pool: pool.Default})))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.RDSSnapshot{}).
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &v1alpha1.RDSSnapshot{}, r)))
+}
+
+type snapshotConnecter struct {
+	client      client.Client
+	pool        *pool.Pool
+	newClientFn func(credentials []byte, region string, hc *http.Client) (rds.Client, error)
+}
+
+func (c *snapshotConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	s, ok := mg.(*v1alpha1.RDSSnapshot)
+	if !ok {
+		return nil, errors.New(errNotSnapshot)
+	}
+
+	p := &awsv1alpha1.Provider{}
+	n := meta.NamespacedNameOf(s.Spec.ProviderReference)
+	if err := c.client.Get(ctx, n, p); err != nil {
+		return nil, errors.Wrapf(err, "cannot get provider %s", n)
+	}
+
+	v, err := aws.ProviderVersion(ctx, c.client, p)
+	if err != nil {
+		return nil, err
+	}
+
+	newClientFn := rds.NewClientWithCredentials
+	if c.newClientFn != nil {
+		newClientFn = c.newClientFn
+	}
+
+	k := pool.Key{Provider: p.GetUID(), API: rds.API}
+	client, err := c.pool.Get(k, v, func() (interface{}, error) {
+		data, err := aws.ProviderCredentials(ctx, c.client, p)
+		if err != nil {
+			return nil, err
+		}
+		client, err := newClientFn(data, p.Spec.Region, c.pool.HTTPClient(k, aws.Throttled, pool.Method))
+		return client, errors.Wrap(err, errNewSnapshotClient)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &snapshotExternal{kube: c.client, client: client.(rds.Client)}, nil
+}
+
+type snapshotExternal struct {
+	kube   client.Client
+	client rds.Client
+}
+
+func (e *snapshotExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	s, ok := mg.(*v1alpha1.RDSSnapshot)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotSnapshot)
+	}
+
+	snapshot, err := e.client.GetSnapshot(rds.NewSnapshotName(s))
+	if err != nil && rds.IsErrorSnapshotNotFound(err) {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, errGetSnapshot)
+	}
+
+	s.Status.State = snapshot.Status
+	s.Status.SnapshotIdentifier = snapshot.Name
+	s.Status.ProviderID = snapshot.ARN
+	s.Status.AllocatedStorage = snapshot.AllocatedStorage
+	s.Status.PercentProgress = snapshot.PercentProgress
+	if snapshot.CreateTime != nil {
+		t := metav1.NewTime(*snapshot.CreateTime)
+		s.Status.SnapshotCreateTime = &t
+	}
+
+	switch s.Status.State {
+	case v1alpha1.RDSSnapshotStateAvailable:
+		s.Status.SetConditions(runtimev1alpha1.Available())
+	case v1alpha1.RDSSnapshotStateCreating:
+		s.Status.SetConditions(runtimev1alpha1.Creating())
+	case v1alpha1.RDSSnapshotStateFailed:
+		s.Status.SetConditions(runtimev1alpha1.Unavailable())
+	}
+
+	return resource.ExternalObservation{ResourceExists: true}, nil
+}
+
+func (e *snapshotExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	s, ok := mg.(*v1alpha1.RDSSnapshot)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotSnapshot)
+	}
+
+	s.Status.SetConditions(runtimev1alpha1.Creating())
+
+	i := &v1alpha1.RDSInstance{}
+	n := types.NamespacedName{Namespace: s.GetNamespace(), Name: s.Spec.InstanceRef.Name}
+	if err := e.kube.Get(ctx, n, i); err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, errGetSnapshotInstance)
+	}
+	if i.Status.InstanceName == "" {
+		return resource.ExternalCreation{}, errors.New(errSnapshotInstanceState)
+	}
+
+	_, err := e.client.CreateSnapshot(i.Status.InstanceName, rds.NewSnapshotName(s))
+	return resource.ExternalCreation{}, errors.Wrap(err, errCreateSnapshot)
+}
+
+// Update is a no-op; snapshots are immutable.
+func (e *snapshotExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	return resource.ExternalUpdate{}, nil
+}
+
+func (e *snapshotExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	s, ok := mg.(*v1alpha1.RDSSnapshot)
+	if !ok {
+		return errors.New(errNotSnapshot)
+	}
+
+	s.Status.SetConditions(runtimev1alpha1.Deleting())
+
+	err := e.client.DeleteSnapshot(rds.NewSnapshotName(s))
+	if err != nil && rds.IsErrorSnapshotNotFound(err) {
+		return nil
+	}
+	return errors.Wrap(err, errDeleteSnapshot)
+}
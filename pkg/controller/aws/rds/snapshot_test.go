@@ -0,0 +1,261 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rds
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	awsrds "github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	"github.com/crossplaneio/crossplane/aws/apis/database/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/aws/rds"
+	"github.com/crossplaneio/crossplane/pkg/clients/aws/rds/fake"
+)
+
+const (
+	snapshotNamespace    = "cool-namespace"
+	snapshotUID          = types.UID("definitely-a-uuid")
+	snapshotInstanceName = "cool-instance"
+	snapshotExternalName = "snapshot-definitely-a-uuid"
+	snapshotRDSInstance  = "mysql-definitely-another-uuid"
+)
+
+var (
+	errSnapshotBoom     = errors.New("boom")
+	errSnapshotNotFound = errors.New(awsrds.ErrCodeDBSnapshotNotFoundFault)
+)
+
+type rdsSnapshotModifier func(*v1alpha1.RDSSnapshot)
+
+func withSnapshotConditions(c ...runtimev1alpha1.Condition) rdsSnapshotModifier {
+	return func(s *v1alpha1.RDSSnapshot) { s.Status.ConditionedStatus.Conditions = c }
+}
+
+func withSnapshotStatus(st v1alpha1.RDSSnapshotStatus) rdsSnapshotModifier {
+	return func(s *v1alpha1.RDSSnapshot) {
+		c := s.Status.ConditionedStatus
+		s.Status = st
+		s.Status.ConditionedStatus = c
+	}
+}
+
+func rdsSnapshot(m ...rdsSnapshotModifier) *v1alpha1.RDSSnapshot {
+	s := &v1alpha1.RDSSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Namespace: snapshotNamespace, Name: "cool-snapshot", UID: snapshotUID},
+		Spec: v1alpha1.RDSSnapshotSpec{
+			RDSSnapshotParameters: v1alpha1.RDSSnapshotParameters{
+				InstanceRef: corev1.LocalObjectReference{Name: snapshotInstanceName},
+			},
+		},
+	}
+
+	for _, fn := range m {
+		fn(s)
+	}
+
+	return s
+}
+
+func snapshotKube(instanceName string) client.Client {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+			if key.Name != snapshotInstanceName {
+				return errors.Errorf("unexpected instance %s", key.Name)
+			}
+			i := obj.(*v1alpha1.RDSInstance)
+			i.Status.InstanceName = instanceName
+			return nil
+		},
+	}
+}
+
+var _ resource.ExternalConnecter = &snapshotConnecter{}
+var _ resource.ExternalClient = &snapshotExternal{}
+
+func TestSnapshotObserve(t *testing.T) {
+	created := time.Now()
+
+	type want struct {
+		o   resource.ExternalObservation
+		s   *v1alpha1.RDSSnapshot
+		err error
+	}
+
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		s    *v1alpha1.RDSSnapshot
+		want want
+	}{
+		"SnapshotAvailable": {
+			e: &snapshotExternal{client: &fake.MockRDSClient{
+				MockGetSnapshot: func(name string) (*rds.Snapshot, error) {
+					if name != snapshotExternalName {
+						return nil, errSnapshotNotFound
+					}
+					return &rds.Snapshot{
+						Name:             name,
+						ARN:              "arn",
+						Status:           v1alpha1.RDSSnapshotStateAvailable,
+						AllocatedStorage: 20,
+						PercentProgress:  100,
+						CreateTime:       &created,
+					}, nil
+				},
+			}},
+			s: rdsSnapshot(),
+			want: want{
+				o: resource.ExternalObservation{ResourceExists: true},
+				s: rdsSnapshot(
+					withSnapshotConditions(runtimev1alpha1.Available()),
+					withSnapshotStatus(v1alpha1.RDSSnapshotStatus{
+						State:              v1alpha1.RDSSnapshotStateAvailable,
+						SnapshotIdentifier: snapshotExternalName,
+						ProviderID:         "arn",
+						AllocatedStorage:   20,
+						PercentProgress:    100,
+						SnapshotCreateTime: &metav1.Time{Time: created},
+					}),
+				),
+			},
+		},
+		"SnapshotDoesNotExist": {
+			e: &snapshotExternal{client: &fake.MockRDSClient{
+				MockGetSnapshot: func(_ string) (*rds.Snapshot, error) { return nil, errSnapshotNotFound },
+			}},
+			s:    rdsSnapshot(),
+			want: want{o: resource.ExternalObservation{ResourceExists: false}, s: rdsSnapshot()},
+		},
+		"GetSnapshotFailed": {
+			e: &snapshotExternal{client: &fake.MockRDSClient{
+				MockGetSnapshot: func(_ string) (*rds.Snapshot, error) { return nil, errSnapshotBoom },
+			}},
+			s:    rdsSnapshot(),
+			want: want{s: rdsSnapshot(), err: errors.Wrap(errSnapshotBoom, errGetSnapshot)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.s)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("e.Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.s, tc.s, test.EquateConditions()); diff != "" {
+				t.Errorf("e.Observe(...): -want snapshot, +got snapshot:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSnapshotCreate(t *testing.T) {
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		s    *v1alpha1.RDSSnapshot
+		want error
+	}{
+		"Successful": {
+			e: &snapshotExternal{
+				kube: snapshotKube(snapshotRDSInstance),
+				client: &fake.MockRDSClient{
+					MockCreateSnapshot: func(instance, name string) (*rds.Snapshot, error) {
+						if instance != snapshotRDSInstance || name != snapshotExternalName {
+							return nil, errors.Errorf("unexpected snapshot %s of %s", name, instance)
+						}
+						return &rds.Snapshot{}, nil
+					},
+				},
+			},
+			s: rdsSnapshot(),
+		},
+		"InstanceNotCreated": {
+			e:    &snapshotExternal{kube: snapshotKube(""), client: &fake.MockRDSClient{}},
+			s:    rdsSnapshot(),
+			want: errors.New(errSnapshotInstanceState),
+		},
+		"CreateFailed": {
+			e: &snapshotExternal{
+				kube: snapshotKube(snapshotRDSInstance),
+				client: &fake.MockRDSClient{
+					MockCreateSnapshot: func(_, _ string) (*rds.Snapshot, error) { return nil, errSnapshotBoom },
+				},
+			},
+			s:    rdsSnapshot(),
+			want: errors.Wrap(errSnapshotBoom, errCreateSnapshot),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Create(context.Background(), tc.s)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Create(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSnapshotDelete(t *testing.T) {
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		s    *v1alpha1.RDSSnapshot
+		want error
+	}{
+		"Successful": {
+			e: &snapshotExternal{client: &fake.MockRDSClient{
+				MockDeleteSnapshot: func(_ string) error { return nil },
+			}},
+			s: rdsSnapshot(),
+		},
+		"AlreadyDeleted": {
+			e: &snapshotExternal{client: &fake.MockRDSClient{
+				MockDeleteSnapshot: func(_ string) error { return errSnapshotNotFound },
+			}},
+			s: rdsSnapshot(),
+		},
+		"DeleteFailed": {
+			e: &snapshotExternal{client: &fake.MockRDSClient{
+				MockDeleteSnapshot: func(_ string) error { return errSnapshotBoom },
+			}},
+			s:    rdsSnapshot(),
+			want: errors.Wrap(errSnapshotBoom, errDeleteSnapshot),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.e.Delete(context.Background(), tc.s)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Delete(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rds
+
+import (
+	"context"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane/aws/apis/database/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/aws/rds"
+	"github.com/crossplaneio/crossplane/pkg/clients/sql"
+)
+
+// Error strings.
+const (
+	errNewSQLClient      = "cannot create new SQL client for referenced RDSInstance"
+	errGetInstance       = "cannot get referenced RDSInstance"
+	errInstanceState     = "referenced RDSInstance is not yet available"
+	errGetInstanceSecret = "cannot get referenced RDSInstance connection secret"
+	errReadCABundle      = "cannot read RDS certificate authority bundle"
+)
+
+// An instanceConnection is a SQL connection to an RDSInstance, made using the
+// master credentials from its connection secret.
+type instanceConnection struct {
+	db       sql.Client
+	endpoint string
+}
+
+// connectInstance connects to the RDSInstance referenced by the supplied
+// managed resource. It returns a nil connection and no error if the instance
+// no longer exists and the managed resource has been deleted, because nothing
+// created within the instance can outlive it. The connection is encrypted, and
+// the instance's certificate is verified using the RDS certificate authority
+// bundle read from the supplied file, or from rds.CABundleFile if it is empty.
+func connectInstance(ctx context.Context, kube client.Client, newClientFn func(sql.Options) (sql.Client, error), caBundleFile string, mg resource.Managed, ref corev1.LocalObjectReference) (*instanceConnection, error) {
+	i := &v1alpha1.RDSInstance{}
+	n := types.NamespacedName{Namespace: mg.GetNamespace(), Name: ref.Name}
+	if err := kube.Get(ctx, n, i); err != nil {
+		if kerrors.IsNotFound(err) && meta.WasDeleted(mg) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, errGetInstance)
+	}
+	switch v1alpha1.RDSInstanceState(i.Status.State) {
+	case v1alpha1.RDSInstanceStateAvailable, v1alpha1.RDSInstanceStateBackingUp:
+	default:
+		return nil, errors.New(errInstanceState)
+	}
+
+	s := &corev1.Secret{}
+	n = types.NamespacedName{Namespace: i.GetNamespace(), Name: i.GetWriteConnectionSecretToReference().Name}
+	if err := kube.Get(ctx, n, s); err != nil {
+		return nil, errors.Wrap(err, errGetInstanceSecret)
+	}
+
+	if caBundleFile == "" {
+		caBundleFile = rds.CABundleFile
+	}
+	ca, err := ioutil.ReadFile(caBundleFile)
+	if err != nil {
+		return nil, errors.Wrap(err, errReadCABundle)
+	}
+
+	if newClientFn == nil {
+		newClientFn = sql.NewClient
+	}
+	db, err := newClientFn(sql.Options{
+		// RDSInstance engines are named for their database/sql drivers.
+		Engine:         sql.Engine(i.Spec.Engine),
+		Endpoint:       i.Status.Endpoint,
+		Username:       string(s.Data[runtimev1alpha1.ResourceCredentialsSecretUserKey]),
+		Password:       string(s.Data[runtimev1alpha1.ResourceCredentialsSecretPasswordKey]),
+		TLS:            true,
+		CACertificates: ca,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, errNewSQLClient)
+	}
+
+	return &instanceConnection{db: db, endpoint: i.Status.Endpoint}, nil
+}
@@ -0,0 +1,454 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rds
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	"github.com/crossplaneio/crossplane/aws/apis/database/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/sql"
+	"github.com/crossplaneio/crossplane/pkg/clients/sql/fake"
+)
+
+const (
+	userNamespace    = "cool-namespace"
+	userName         = "cool-user"
+	userInstanceName = "cool-instance"
+	userSecretName   = "cool-instance-secret"
+	userEndpoint     = "cool.rds.amazonaws.com"
+	userMasterName   = "master"
+	userMasterPass   = "hunter2"
+)
+
+var errUserBoom = errors.New("boom")
+
+type rdsUserModifier func(*v1alpha1.RDSUser)
+
+func withUserConditions(c ...runtimev1alpha1.Condition) rdsUserModifier {
+	return func(u *v1alpha1.RDSUser) { u.Status.ConditionedStatus.Conditions = c }
+}
+
+func withUserBindingPhase(p runtimev1alpha1.BindingPhase) rdsUserModifier {
+	return func(u *v1alpha1.RDSUser) { u.Status.SetBindingPhase(p) }
+}
+
+func withUserGrants(databases ...string) rdsUserModifier {
+	return func(u *v1alpha1.RDSUser) {
+		for _, d := range databases {
+			u.Spec.Grants = append(u.Spec.Grants, v1alpha1.DatabaseGrant{Database: d})
+		}
+	}
+}
+
+func withUserDeletionTimestamp(t time.Time) rdsUserModifier {
+	return func(u *v1alpha1.RDSUser) { u.SetDeletionTimestamp(&metav1.Time{Time: t}) }
+}
+
+func rdsUser(m ...rdsUserModifier) *v1alpha1.RDSUser {
+	u := &v1alpha1.RDSUser{
+		ObjectMeta: metav1.ObjectMeta{Namespace: userNamespace, Name: userName},
+		Spec: v1alpha1.RDSUserSpec{
+			RDSUserParameters: v1alpha1.RDSUserParameters{
+				InstanceRef: corev1.LocalObjectReference{Name: userInstanceName},
+			},
+		},
+	}
+
+	for _, fn := range m {
+		fn(u)
+	}
+
+	return u
+}
+
+func userInstance(state v1alpha1.RDSInstanceState) *v1alpha1.RDSInstance {
+	return &v1alpha1.RDSInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: userNamespace, Name: userInstanceName},
+		Spec: v1alpha1.RDSInstanceSpec{
+			ResourceSpec: runtimev1alpha1.ResourceSpec{
+				WriteConnectionSecretToReference: corev1.LocalObjectReference{Name: userSecretName},
+			},
+			RDSInstanceParameters: v1alpha1.RDSInstanceParameters{Engine: v1alpha1.PostgresqlEngine},
+		},
+		Status: v1alpha1.RDSInstanceStatus{State: string(state), Endpoint: userEndpoint},
+	}
+}
+
+func userKube(i *v1alpha1.RDSInstance, secretErr error) client.Client {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+			switch o := obj.(type) {
+			case *v1alpha1.RDSInstance:
+				if i == nil {
+					return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+				}
+				*o = *i
+			case *corev1.Secret:
+				if secretErr != nil {
+					return secretErr
+				}
+				o.Data = map[string][]byte{
+					runtimev1alpha1.ResourceCredentialsSecretUserKey:     []byte(userMasterName),
+					runtimev1alpha1.ResourceCredentialsSecretPasswordKey: []byte(userMasterPass),
+				}
+			}
+			return nil
+		},
+	}
+}
+
+var _ resource.ExternalConnecter = &userConnecter{}
+var _ resource.ExternalClient = &userExternal{}
+
+// caBundle writes a certificate authority bundle to a temporary file. It
+// returns the file's path and a function that removes it.
+func caBundle(t *testing.T, pem []byte) (string, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "rds")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir(...): %s", err)
+	}
+	f := filepath.Join(dir, "ca-bundle.pem")
+	if err := ioutil.WriteFile(f, pem, 0600); err != nil {
+		os.RemoveAll(dir)
+		t.Fatalf("ioutil.WriteFile(...): %s", err)
+	}
+	return f, func() { os.RemoveAll(dir) }
+}
+
+func TestUserConnect(t *testing.T) {
+	bundle := []byte("cool-ca-bundle")
+	bundleFile, cleanup := caBundle(t, bundle)
+	defer cleanup()
+	missingFile := filepath.Join(filepath.Dir(bundleFile), "missing.pem")
+	_, errMissing := ioutil.ReadFile(missingFile)
+
+	wantOptions := sql.Options{
+		Engine:         sql.PostgreSQL,
+		Endpoint:       userEndpoint,
+		Username:       userMasterName,
+		Password:       userMasterPass,
+		TLS:            true,
+		CACertificates: bundle,
+	}
+
+	cases := map[string]struct {
+		kube         client.Client
+		caBundleFile string
+		u            *v1alpha1.RDSUser
+		wantErr      error
+	}{
+		"Successful": {
+			kube: userKube(userInstance(v1alpha1.RDSInstanceStateAvailable), nil),
+			u:    rdsUser(),
+		},
+		"InstanceNotFound": {
+			kube:    userKube(nil, nil),
+			u:       rdsUser(),
+			wantErr: errors.Wrap(kerrors.NewNotFound(schema.GroupResource{}, userInstanceName), errGetInstance),
+		},
+		"InstanceNotFoundWhileDeleting": {
+			kube: userKube(nil, nil),
+			u:    rdsUser(withUserDeletionTimestamp(time.Now())),
+		},
+		"InstanceNotAvailable": {
+			kube:    userKube(userInstance(v1alpha1.RDSInstanceStateCreating), nil),
+			u:       rdsUser(),
+			wantErr: errors.New(errInstanceState),
+		},
+		"FailedToGetSecret": {
+			kube:    userKube(userInstance(v1alpha1.RDSInstanceStateAvailable), errUserBoom),
+			u:       rdsUser(),
+			wantErr: errors.Wrap(errUserBoom, errGetInstanceSecret),
+		},
+		"FailedToReadCABundle": {
+			kube:         userKube(userInstance(v1alpha1.RDSInstanceStateAvailable), nil),
+			caBundleFile: missingFile,
+			u:            rdsUser(),
+			wantErr:      errors.Wrap(errMissing, errReadCABundle),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			f := tc.caBundleFile
+			if f == "" {
+				f = bundleFile
+			}
+			c := &userConnecter{
+				client:       tc.kube,
+				caBundleFile: f,
+				newClientFn: func(o sql.Options) (sql.Client, error) {
+					if diff := cmp.Diff(wantOptions, o); diff != "" {
+						t.Errorf("newClientFn(...): -want options, +got options:\n%s", diff)
+					}
+					return &fake.MockClient{}, nil
+				},
+			}
+			_, err := c.Connect(context.Background(), tc.u)
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("c.Connect(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUserObserve(t *testing.T) {
+	type want struct {
+		o   resource.ExternalObservation
+		u   *v1alpha1.RDSUser
+		err error
+	}
+
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		u    *v1alpha1.RDSUser
+		want want
+	}{
+		"UserExists": {
+			e: &userExternal{
+				db: &fake.MockClient{MockUserExists: func(_ context.Context, name string) (bool, error) {
+					return name == userName, nil
+				}},
+				endpoint: userEndpoint,
+			},
+			u: rdsUser(),
+			want: want{
+				o: resource.ExternalObservation{
+					ResourceExists: true,
+					ConnectionDetails: resource.ConnectionDetails{
+						runtimev1alpha1.ResourceCredentialsSecretUserKey:     []byte(userName),
+						runtimev1alpha1.ResourceCredentialsSecretEndpointKey: []byte(userEndpoint),
+					},
+				},
+				u: rdsUser(
+					withUserConditions(runtimev1alpha1.Available()),
+					withUserBindingPhase(runtimev1alpha1.BindingPhaseUnbound),
+				),
+			},
+		},
+		"UserDoesNotExist": {
+			e: &userExternal{db: &fake.MockClient{MockUserExists: func(_ context.Context, _ string) (bool, error) {
+				return false, nil
+			}}},
+			u:    rdsUser(),
+			want: want{o: resource.ExternalObservation{ResourceExists: false}, u: rdsUser()},
+		},
+		"InstanceGone": {
+			e:    &userExternal{},
+			u:    rdsUser(),
+			want: want{o: resource.ExternalObservation{ResourceExists: false}, u: rdsUser()},
+		},
+		"UserExistsFailed": {
+			e: &userExternal{db: &fake.MockClient{MockUserExists: func(_ context.Context, _ string) (bool, error) {
+				return false, errUserBoom
+			}}},
+			u:    rdsUser(),
+			want: want{u: rdsUser(), err: errors.Wrap(errUserBoom, errUserExists)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.u)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("e.Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.u, tc.u, test.EquateConditions()); diff != "" {
+				t.Errorf("e.Observe(...): -want user, +got user:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUserCreate(t *testing.T) {
+	type want struct {
+		password bool
+		u        *v1alpha1.RDSUser
+		err      error
+	}
+
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		u    *v1alpha1.RDSUser
+		want want
+	}{
+		"Successful": {
+			e: &userExternal{db: &fake.MockClient{MockCreateUser: func(_ context.Context, name, password string) error {
+				if name != userName || password == "" {
+					return errors.Errorf("unexpected user %s", name)
+				}
+				return nil
+			}}},
+			u: rdsUser(),
+			want: want{
+				password: true,
+				u:        rdsUser(withUserConditions(runtimev1alpha1.Creating())),
+			},
+		},
+		"CreateFailed": {
+			e: &userExternal{db: &fake.MockClient{MockCreateUser: func(_ context.Context, _, _ string) error {
+				return errUserBoom
+			}}},
+			u: rdsUser(),
+			want: want{
+				u:   rdsUser(withUserConditions(runtimev1alpha1.Creating())),
+				err: errors.Wrap(errUserBoom, errCreateUser),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c, err := tc.e.Create(context.Background(), tc.u)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Create(...): -want error, +got error:\n%s", diff)
+			}
+			if got := len(c.ConnectionDetails[runtimev1alpha1.ResourceCredentialsSecretPasswordKey]) > 0; got != tc.want.password {
+				t.Errorf("e.Create(...): want password %t, got %t", tc.want.password, got)
+			}
+			if diff := cmp.Diff(tc.want.u, tc.u, test.EquateConditions()); diff != "" {
+				t.Errorf("e.Create(...): -want user, +got user:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUserUpdate(t *testing.T) {
+	type want struct {
+		granted []string
+		revoked []string
+		err     error
+	}
+
+	cases := map[string]struct {
+		existing  []string
+		grantsErr error
+		u         *v1alpha1.RDSUser
+		want      want
+	}{
+		"GrantsAndRevokes": {
+			existing: []string{"cool-db", "lame-db"},
+			u:        rdsUser(withUserGrants("cool-db", "new-db")),
+			want: want{
+				granted: []string{"new-db"},
+				revoked: []string{"lame-db"},
+			},
+		},
+		"UpToDate": {
+			existing: []string{"cool-db"},
+			u:        rdsUser(withUserGrants("cool-db")),
+			want:     want{},
+		},
+		"GrantsFailed": {
+			grantsErr: errUserBoom,
+			u:         rdsUser(withUserGrants("cool-db")),
+			want: want{
+				err: errors.Wrap(errUserBoom, errUpdateGrants),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var granted, revoked []string
+			db := &fake.MockClient{
+				MockGrants: func(_ context.Context, user string) ([]string, error) {
+					if user != userName {
+						return nil, errors.Errorf("unexpected user %s", user)
+					}
+					return tc.existing, tc.grantsErr
+				},
+				MockGrant: func(_ context.Context, _, database string) error {
+					granted = append(granted, database)
+					return nil
+				},
+				MockRevoke: func(_ context.Context, _, database string) error {
+					revoked = append(revoked, database)
+					return nil
+				},
+			}
+			e := &userExternal{db: db}
+
+			_, err := e.Update(context.Background(), tc.u)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Update(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.granted, granted); diff != "" {
+				t.Errorf("e.Update(...): -want granted, +got granted:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.revoked, revoked); diff != "" {
+				t.Errorf("e.Update(...): -want revoked, +got revoked:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUserDelete(t *testing.T) {
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		u    *v1alpha1.RDSUser
+		want error
+	}{
+		"Successful": {
+			e: &userExternal{db: &fake.MockClient{MockDeleteUser: func(_ context.Context, _ string) error {
+				return nil
+			}}},
+			u: rdsUser(),
+		},
+		"InstanceGone": {
+			e: &userExternal{},
+			u: rdsUser(),
+		},
+		"DeleteFailed": {
+			e: &userExternal{db: &fake.MockClient{MockDeleteUser: func(_ context.Context, _ string) error {
+				return errUserBoom
+			}}},
+			u:    rdsUser(),
+			want: errors.Wrap(errUserBoom, errDeleteUser),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.e.Delete(context.Background(), tc.u)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Delete(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
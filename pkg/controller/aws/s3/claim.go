@@ -31,6 +31,11 @@ import (
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
 	storagev1alpha1 "github.com/crossplaneio/crossplane/apis/storage/v1alpha1"
 	"github.com/crossplaneio/crossplane/aws/apis/storage/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/secrets"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
 )
 
 var s3ACL = map[storagev1alpha1.PredefinedACL]s3.BucketCannedACL{
@@ -46,23 +51,28 @@ type BucketClaimController struct{}
 
 // SetupWithManager adds a controller that reconciles Bucket resource claims.
 func (c *BucketClaimController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s", storagev1alpha1.BucketKind, controllerName))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(event.KubernetesErrorCode))
+	tracer := tracing.NewTracer(name)
+
 	r := resource.NewClaimReconciler(mgr,
 		resource.ClaimKind(storagev1alpha1.BucketGroupVersionKind),
 		resource.ClassKind(v1alpha1.S3BucketClassGroupVersionKind),
 		resource.ManagedKind(v1alpha1.S3BucketGroupVersionKind),
+		resource.WithManagedBinder(event.NewBinder(recorder, tracer.Binder(resource.NewAPIManagedBinder(mgr.GetClient())))),
 		resource.WithManagedConfigurators(
 			resource.ManagedConfiguratorFn(ConfigureS3Bucket),
 			resource.NewObjectMetaConfigurator(mgr.GetScheme()),
-		))
-
-	name := strings.ToLower(fmt.Sprintf("%s.%s", storagev1alpha1.BucketKind, controllerName))
+			resource.ManagedConfiguratorFn(protection.ConfigureManaged),
+		),
+		resource.WithManagedConnectionPropagator(secrets.NewAPIConnectionPropagator(mgr.GetClient(), resource.ClaimKind(storagev1alpha1.BucketGroupVersionKind), storagev1alpha1.BucketSecretDefinition)))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		Watches(&source.Kind{Type: &v1alpha1.S3Bucket{}}, &resource.EnqueueRequestForClaim{}).
 		For(&storagev1alpha1.Bucket{}).
 		WithEventFilter(resource.NewPredicates(resource.HasClassReferenceKind(resource.ClassKind(v1alpha1.S3BucketClassGroupVersionKind)))).
-		Complete(r)
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &storagev1alpha1.Bucket{}, protection.NewReconciler(mgr.GetClient(), &storagev1alpha1.Bucket{}, r))))
 }
 
 // ConfigureS3Bucket configures the supplied resource (presumed
@@ -25,7 +25,6 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -39,6 +38,11 @@ import (
 	awsv1alpha1 "github.com/crossplaneio/crossplane/aws/apis/v1alpha1"
 	"github.com/crossplaneio/crossplane/pkg/clients/aws"
 	"github.com/crossplaneio/crossplane/pkg/clients/aws/s3"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
 )
 
 const (
@@ -58,7 +62,8 @@ type Reconciler struct {
 	client.Client
 	scheme     *runtime.Scheme
 	kubeclient kubernetes.Interface
-	recorder   record.EventRecorder
+	recorder   *event.Recorder
+	pool       *pool.Pool
 
 	connect func(*bucketv1alpha1.S3Bucket) (s3.Service, error)
 	create  func(*bucketv1alpha1.S3Bucket, s3.Service) (reconcile.Result, error)
@@ -77,7 +82,8 @@ func (c *BucketController) SetupWithManager(mgr ctrl.Manager) error {
 		Client:     mgr.GetClient(),
 		scheme:     mgr.GetScheme(),
 		kubeclient: kubernetes.NewForConfigOrDie(mgr.GetConfig()),
-		recorder:   mgr.GetEventRecorderFor(controllerName),
+		recorder:   event.NewRecorder(mgr.GetEventRecorderFor(controllerName), event.WithErrorCoder(aws.ErrorCode)),
+		pool:       pool.Default,
 	}
 	r.connect = r._connect
 	r.create = r._create
@@ -88,11 +94,12 @@ func (c *BucketController) SetupWithManager(mgr ctrl.Manager) error {
 		Named(controllerName).
 		For(&bucketv1alpha1.S3Bucket{}).
 		Owns(&corev1.Secret{}).
-		Complete(r)
+		Complete(tracing.NewTracer(controllerName).Reconciler(pause.NewReconciler(mgr.GetClient(), &bucketv1alpha1.S3Bucket{}, r, pause.WithObjectUpdate())))
 }
 
 // fail - helper function to set fail condition with reason and message
 func (r *Reconciler) fail(bucket *bucketv1alpha1.S3Bucket, err error) (reconcile.Result, error) {
+	r.recorder.Failed(bucket, err)
 	bucket.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
 	return reconcile.Result{Requeue: true}, r.Update(context.TODO(), bucket)
 }
@@ -117,7 +124,7 @@ func (r *Reconciler) _connect(instance *bucketv1alpha1.S3Bucket) (s3.Service, er
 	}
 
 	// Get Provider's AWS Config
-	config, err := aws.Config(r.kubeclient, p)
+	config, err := aws.PooledConfig(ctx, r.pool, r.Client, p, s3.API)
 	if err != nil {
 		return nil, err
 	}
@@ -136,6 +143,7 @@ func (r *Reconciler) _create(bucket *bucketv1alpha1.S3Bucket, client s3.Service)
 	if err != nil {
 		return r.fail(bucket, err)
 	}
+	r.recorder.Normal(bucket, event.ReasonCreateRequested, "Requested creation of external resource")
 
 	// Set username for iam user
 	if bucket.Status.IAMUsername == "" {
@@ -163,6 +171,9 @@ func (r *Reconciler) _create(bucket *bucketv1alpha1.S3Bucket, client s3.Service)
 	}
 
 	// No longer creating, we're ready!
+	if !event.Ready(bucket) {
+		r.recorder.Normal(bucket, event.ReasonBecameReady, "External resource became ready")
+	}
 	bucket.Status.SetConditions(runtimev1alpha1.Available())
 	resource.SetBindable(bucket)
 	return result, r.Update(ctx, bucket)
@@ -182,6 +193,7 @@ func (r *Reconciler) _sync(bucket *bucketv1alpha1.S3Bucket, client s3.Service) (
 		if err != nil {
 			return r.fail(bucket, err)
 		}
+		r.recorder.Normal(bucket, event.ReasonUpdateApplied, "Updated versioning of external resource")
 	}
 
 	// TODO: Detect if the bucket CannedACL has changed, possibly by managing grants list directly.
@@ -204,18 +216,45 @@ func (r *Reconciler) _sync(bucket *bucketv1alpha1.S3Bucket, client s3.Service) (
 		if err != nil {
 			return r.fail(bucket, err)
 		}
+		r.recorder.Normal(bucket, event.ReasonUpdateApplied, "Updated user policy of external resource")
+	}
+
+	if err := updateConfiguration(bucket, client); err != nil {
+		return r.fail(bucket, err)
 	}
 
 	bucket.Status.SetConditions(runtimev1alpha1.ReconcileSuccess())
 	return result, r.Update(ctx, bucket)
 }
 
+// updateConfiguration corrects any drift between the optional configuration
+// of the supplied bucket, such as its lifecycle rules and tags, and that of
+// the external S3 bucket.
+func updateConfiguration(bucket *bucketv1alpha1.S3Bucket, client s3.Service) error {
+	updates := []func(*bucketv1alpha1.S3Bucket) error{
+		client.UpdateLifecycleConfiguration,
+		client.UpdateCORS,
+		client.UpdateEncryption,
+		client.UpdateLogging,
+		client.UpdateWebsite,
+		client.UpdateTagging,
+		client.UpdatePublicAccessBlock,
+	}
+	for _, update := range updates {
+		if err := update(bucket); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (r *Reconciler) _delete(bucket *bucketv1alpha1.S3Bucket, client s3.Service) (reconcile.Result, error) {
 	bucket.Status.SetConditions(runtimev1alpha1.Deleting(), runtimev1alpha1.ReconcileSuccess())
 	if bucket.Spec.ReclaimPolicy == runtimev1alpha1.ReclaimDelete {
 		if err := client.DeleteBucket(bucket); err != nil {
 			return r.fail(bucket, err)
 		}
+		r.recorder.Normal(bucket, event.ReasonDeletionStarted, "Requested deletion of external resource")
 	}
 
 	meta.RemoveFinalizer(bucket, finalizer)
@@ -248,6 +287,9 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 
 	// Check for deletion
 	if bucket.DeletionTimestamp != nil {
+		if err := protection.Check(bucket); err != nil {
+			return r.fail(bucket, err)
+		}
 		return r.delete(bucket, s3Client)
 	}
 
@@ -184,6 +184,19 @@ func TestSyncBucketError(t *testing.T) {
 	expectedStatus = runtimev1alpha1.ConditionedStatus{}
 	expectedStatus.SetConditions(runtimev1alpha1.ReconcileError(testError))
 	assert(testResource(), cl, resultRequeue, expectedStatus)
+
+	// Update configuration error
+	bucketWithoutPolicyChanges := testResource()
+	bucketWithoutPolicyChanges.Status.LastUserPolicyVersion = 1
+	bucketWithoutPolicyChanges.Status.LastLocalPermission = storagev1alpha1.ReadOnlyPermission
+
+	testError = errors.New("lifecycle-update-err")
+	cl.MockUpdateLifecycleConfiguration = func(bucket *S3Bucket) error {
+		return testError
+	}
+	expectedStatus = runtimev1alpha1.ConditionedStatus{}
+	expectedStatus.SetConditions(runtimev1alpha1.ReconcileError(testError))
+	assert(bucketWithoutPolicyChanges, cl, resultRequeue, expectedStatus)
 }
 
 func TestSyncBucket(t *testing.T) {
@@ -210,6 +223,21 @@ func TestSyncBucket(t *testing.T) {
 		},
 	}
 
+	updated := map[string]bool{}
+	update := func(name string) func(*S3Bucket) error {
+		return func(_ *S3Bucket) error {
+			updated[name] = true
+			return nil
+		}
+	}
+	cl.MockUpdateLifecycleConfiguration = update("lifecycle")
+	cl.MockUpdateCORS = update("cors")
+	cl.MockUpdateEncryption = update("encryption")
+	cl.MockUpdateLogging = update("logging")
+	cl.MockUpdateWebsite = update("website")
+	cl.MockUpdateTagging = update("tagging")
+	cl.MockUpdatePublicAccessBlock = update("publicaccessblock")
+
 	expectedStatus := runtimev1alpha1.ConditionedStatus{}
 	expectedStatus.SetConditions(runtimev1alpha1.ReconcileSuccess())
 	rs, err := r._sync(tr, cl)
@@ -217,6 +245,7 @@ func TestSyncBucket(t *testing.T) {
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(updateBucketACLCalled).To(BeTrue())
 	g.Expect(getBucketInfoCalled).To(BeTrue())
+	g.Expect(updated).To(HaveLen(7))
 	assertResource(g, r, expectedStatus)
 }
 
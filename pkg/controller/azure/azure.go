@@ -22,9 +22,11 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	azureclients "github.com/crossplaneio/crossplane/pkg/clients/azure"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
 	"github.com/crossplaneio/crossplane/pkg/controller/azure/cache"
 	"github.com/crossplaneio/crossplane/pkg/controller/azure/compute"
 	"github.com/crossplaneio/crossplane/pkg/controller/azure/database"
+	"github.com/crossplaneio/crossplane/pkg/controller/azure/network"
 	"github.com/crossplaneio/crossplane/pkg/controller/azure/storage/account"
 	"github.com/crossplaneio/crossplane/pkg/controller/azure/storage/container"
 )
@@ -56,7 +58,7 @@ func (c *Controllers) SetupWithManager(mgr ctrl.Manager) error { // nolint:gocyc
 	}
 
 	if err := (&compute.AKSClusterController{
-		Reconciler: compute.NewAKSClusterReconciler(mgr, &azureclients.AKSSetupClientFactory{}, clientset),
+		Reconciler: compute.NewAKSClusterReconciler(mgr, &azureclients.AKSSetupClientFactory{Kube: mgr.GetClient(), Pool: pool.Default}, clientset),
 	}).SetupWithManager(mgr); err != nil {
 		return err
 	}
@@ -66,7 +68,7 @@ func (c *Controllers) SetupWithManager(mgr ctrl.Manager) error { // nolint:gocyc
 	}
 
 	if err := (&database.MysqlServerController{
-		Reconciler: database.NewMysqlServerReconciler(mgr, &azureclients.MySQLServerClientFactory{}, clientset),
+		Reconciler: database.NewMysqlServerReconciler(mgr, &azureclients.MySQLServerClientFactory{Kube: mgr.GetClient(), Pool: pool.Default}, clientset),
 	}).SetupWithManager(mgr); err != nil {
 		return err
 	}
@@ -76,11 +78,35 @@ func (c *Controllers) SetupWithManager(mgr ctrl.Manager) error { // nolint:gocyc
 	}
 
 	if err := (&database.PostgresqlServerController{
-		Reconciler: database.NewPostgreSQLServerReconciler(mgr, &azureclients.PostgreSQLServerClientFactory{}, clientset),
+		Reconciler: database.NewPostgreSQLServerReconciler(mgr, &azureclients.PostgreSQLServerClientFactory{Kube: mgr.GetClient(), Pool: pool.Default}, clientset),
 	}).SetupWithManager(mgr); err != nil {
 		return err
 	}
 
+	if err := (&database.SQLServerUserController{}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&database.PostgreSQLInstanceDatabaseClaimController{}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&database.MySQLInstanceDatabaseClaimController{}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&database.SQLServerDatabaseController{}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&network.VirtualNetworkController{}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&network.SubnetController{}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
 	if err := (&account.ClaimController{}).SetupWithManager(mgr); err != nil {
 		return err
 	}
@@ -29,7 +29,13 @@ import (
 	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
 	cachev1alpha1 "github.com/crossplaneio/crossplane/apis/cache/v1alpha1"
+	corev1alpha1 "github.com/crossplaneio/crossplane/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane/azure/apis/cache/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/secrets"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
 )
 
 // RedisClaimController is responsible for adding the Redis
@@ -38,23 +44,28 @@ type RedisClaimController struct{}
 
 // SetupWithManager adds a controller that reconciles RedisCluster resource claims.
 func (c *RedisClaimController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s", cachev1alpha1.RedisClusterKind, controllerName))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(event.KubernetesErrorCode))
+	tracer := tracing.NewTracer(name)
+
 	r := resource.NewClaimReconciler(mgr,
 		resource.ClaimKind(cachev1alpha1.RedisClusterGroupVersionKind),
 		resource.ClassKind(v1alpha1.RedisClassGroupVersionKind),
 		resource.ManagedKind(v1alpha1.RedisGroupVersionKind),
+		resource.WithManagedBinder(event.NewBinder(recorder, tracer.Binder(resource.NewAPIManagedBinder(mgr.GetClient())))),
 		resource.WithManagedConfigurators(
 			resource.ManagedConfiguratorFn(ConfigureRedis),
 			resource.NewObjectMetaConfigurator(mgr.GetScheme()),
-		))
-
-	name := strings.ToLower(fmt.Sprintf("%s.%s", cachev1alpha1.RedisClusterKind, controllerName))
+			resource.ManagedConfiguratorFn(protection.ConfigureManaged),
+		),
+		resource.WithManagedConnectionPropagator(secrets.NewAPIConnectionPropagator(mgr.GetClient(), resource.ClaimKind(cachev1alpha1.RedisClusterGroupVersionKind), cachev1alpha1.RedisClusterSecretDefinition)))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		Watches(&source.Kind{Type: &v1alpha1.Redis{}}, &resource.EnqueueRequestForClaim{}).
 		For(&cachev1alpha1.RedisCluster{}).
 		WithEventFilter(resource.NewPredicates(resource.HasClassReferenceKind(resource.ClassKind(v1alpha1.RedisClassGroupVersionKind)))).
-		Complete(r)
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &cachev1alpha1.RedisCluster{}, protection.NewReconciler(mgr.GetClient(), &cachev1alpha1.RedisCluster{}, r))))
 }
 
 // ConfigureRedis configures the supplied resource (presumed
@@ -101,10 +112,16 @@ func ConfigureRedis(_ context.Context, cm resource.Claim, cs resource.Class, mg
 }
 
 func resolveAzureClassValues(rc *cachev1alpha1.RedisCluster) error {
-	// EngineVersion is currently the only option we expose at the claim level,
-	// and Azure only supports Redis 3.2.
+	// Azure only supports Redis 3.2, and sizes Redis caches by SKU rather than
+	// by memory or instance size.
 	if rc.Spec.EngineVersion != "" && rc.Spec.EngineVersion != v1alpha1.SupportedRedisVersion {
 		return errors.Errorf("Azure supports only Redis version %s", v1alpha1.SupportedRedisVersion)
 	}
+	if rc.Spec.MemoryGB != nil {
+		return corev1alpha1.NewNotSupportedError(v1alpha1.RedisClassKind, corev1alpha1.ClaimParameterMemoryGB)
+	}
+	if rc.Spec.InstanceSize != "" {
+		return corev1alpha1.NewNotSupportedError(v1alpha1.RedisClassKind, corev1alpha1.ClaimParameterInstanceSize)
+	}
 	return nil
 }
@@ -108,6 +108,8 @@ func TestConfigureRedis(t *testing.T) {
 }
 
 func TestResolveAzureClassValues(t *testing.T) {
+	memoryGB := int64(1)
+
 	cases := []struct {
 		name  string
 		claim *cachev1alpha1.RedisCluster
@@ -126,6 +128,16 @@ func TestResolveAzureClassValues(t *testing.T) {
 			claim: &cachev1alpha1.RedisCluster{Spec: cachev1alpha1.RedisClusterSpec{EngineVersion: claimVersion40}},
 			want:  errors.Errorf("Azure supports only Redis version %s", v1alpha1.SupportedRedisVersion),
 		},
+		{
+			name:  "MemoryGBSet",
+			claim: &cachev1alpha1.RedisCluster{Spec: cachev1alpha1.RedisClusterSpec{MemoryGB: &memoryGB}},
+			want:  errors.New("RedisClass does not support claims that request memoryGB"),
+		},
+		{
+			name:  "InstanceSizeSet",
+			claim: &cachev1alpha1.RedisCluster{Spec: cachev1alpha1.RedisClusterSpec{InstanceSize: "small"}},
+			want:  errors.New("RedisClass does not support claims that request instanceSize"),
+		},
 	}
 
 	for _, tc := range cases {
@@ -18,6 +18,7 @@ package cache
 
 import (
 	"context"
+	"net/http"
 	"time"
 
 	"github.com/pkg/errors"
@@ -36,6 +37,12 @@ import (
 	azurev1alpha1 "github.com/crossplaneio/crossplane/azure/apis/v1alpha1"
 	"github.com/crossplaneio/crossplane/pkg/clients/azure"
 	"github.com/crossplaneio/crossplane/pkg/clients/azure/redis"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/externalname"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
 )
 
 const (
@@ -53,6 +60,15 @@ type creator interface {
 	Create(ctx context.Context, r *v1alpha1.Redis) (requeue bool)
 }
 
+// An importer can import existing resources from an external store - e.g. the
+// Azure API.
+type importer interface {
+	// Import the existing external resource named by the supplied resource's
+	// external name. Returns true if the resource requires further
+	// reconciliation.
+	Import(ctx context.Context, r *v1alpha1.Redis) (requeue bool)
+}
+
 // A syncer can sync resources with an external store - e.g. the Azure API.
 type syncer interface {
 	// Sync the supplied resource with the external store. Returns true if the
@@ -73,11 +89,12 @@ type keyer interface {
 	Key(ctx context.Context, r *v1alpha1.Redis) (key string)
 }
 
-// A createsyncdeletekeyer an create, sync, and delete resources in an external
-// store - e.g. the Azure API. It can also return keys (i.e. credentials) for
-// resources.
+// A createsyncdeletekeyer an create, import, sync, and delete resources in an
+// external store - e.g. the Azure API. It can also return keys (i.e.
+// credentials) for resources.
 type createsyncdeletekeyer interface {
 	creator
+	importer
 	syncer
 	deleter
 	keyer
@@ -86,6 +103,7 @@ type createsyncdeletekeyer interface {
 // azureRedisCache is a createsyncdeletekeyer using the Azure Azure Cache API.
 type azureRedisCache struct {
 	client redis.Client
+	record *event.Recorder
 }
 
 func (a *azureRedisCache) Create(ctx context.Context, r *v1alpha1.Redis) bool {
@@ -93,8 +111,30 @@ func (a *azureRedisCache) Create(ctx context.Context, r *v1alpha1.Redis) bool {
 	n := redis.NewResourceName(r)
 	if _, err := a.client.Create(ctx, r.Spec.ResourceGroupName, n, redis.NewCreateParameters(r)); err != nil {
 		r.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+		a.record.Failed(r, err)
+		return true
+	}
+	a.record.Normal(r, event.ReasonCreateRequested, "Requested creation of external resource")
+
+	r.Status.ResourceName = n
+	meta.AddFinalizer(r, finalizerName)
+	r.Status.SetConditions(runtimev1alpha1.ReconcileSuccess())
+	return true
+}
+
+func (a *azureRedisCache) Import(ctx context.Context, r *v1alpha1.Redis) bool {
+	n := redis.NewResourceName(r)
+	if _, err := a.client.Get(ctx, r.Spec.ResourceGroupName, n); err != nil {
+		if azure.IsNotFound(err) {
+			err = errors.Errorf("cannot import external resource: Redis resource %s does not exist in resource group %s", n, r.Spec.ResourceGroupName)
+		} else {
+			err = errors.Wrap(err, "cannot import external resource")
+		}
+		r.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+		a.record.Failed(r, err)
 		return true
 	}
+	a.record.Normal(r, event.ReasonImported, "Imported existing external resource")
 
 	r.Status.ResourceName = n
 	meta.AddFinalizer(r, finalizerName)
@@ -107,6 +147,7 @@ func (a *azureRedisCache) Sync(ctx context.Context, r *v1alpha1.Redis) bool {
 	cacheResource, err := a.client.Get(ctx, r.Spec.ResourceGroupName, n)
 	if err != nil {
 		r.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+		a.record.Failed(r, err)
 		return true
 	}
 
@@ -118,6 +159,10 @@ func (a *azureRedisCache) Sync(ctx context.Context, r *v1alpha1.Redis) bool {
 		// portal shows an instance as 'Ready', but the API shows only that the
 		// provisioning state is 'Succeeded'. It's a little weird to see a Redis
 		// resource in state 'Succeeded' in kubectl.
+		if !event.Ready(r) {
+			a.record.Normal(r, event.ReasonBecameReady, "External resource became ready")
+		}
+		r.Status.Operation = ""
 		r.Status.SetConditions(runtimev1alpha1.Available())
 		resource.SetBindable(r)
 	case v1alpha1.ProvisioningStateCreating:
@@ -144,10 +189,20 @@ func (a *azureRedisCache) Sync(ctx context.Context, r *v1alpha1.Redis) bool {
 		return false
 	}
 
-	if _, err := a.client.Update(ctx, r.Spec.ResourceGroupName, n, redis.NewUpdateParameters(r)); err != nil {
+	r.Status.Operation = redis.UpdateOperation(r, cacheResource)
+	up := redis.NewUpdateParameters(r)
+	if r.Status.Operation == v1alpha1.OperationScaleSKU {
+		// Leave the shard count alone until the SKU has been scaled; we'll
+		// update it on a subsequent sync if necessary.
+		up.ShardCount = cacheResource.ShardCount
+	}
+
+	if _, err := a.client.Update(ctx, r.Spec.ResourceGroupName, n, up); err != nil {
 		r.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+		a.record.Failed(r, err)
 		return true
 	}
+	a.record.Normal(r, event.ReasonUpdateApplied, "Applied update to external resource")
 
 	r.Status.SetConditions(runtimev1alpha1.ReconcileSuccess())
 	return false
@@ -158,8 +213,10 @@ func (a *azureRedisCache) Delete(ctx context.Context, r *v1alpha1.Redis) bool {
 	if r.Spec.ReclaimPolicy == runtimev1alpha1.ReclaimDelete {
 		if _, err := a.client.Delete(ctx, r.Spec.ResourceGroupName, redis.NewResourceName(r)); err != nil {
 			r.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+			a.record.Failed(r, err)
 			return true
 		}
+		a.record.Normal(r, event.ReasonDeletionStarted, "Requested deletion of external resource")
 	}
 	meta.RemoveFinalizer(r, finalizerName)
 	r.Status.SetConditions(runtimev1alpha1.ReconcileSuccess())
@@ -171,6 +228,7 @@ func (a *azureRedisCache) Key(ctx context.Context, r *v1alpha1.Redis) string {
 	k, err := a.client.ListKeys(ctx, r.Spec.ResourceGroupName, n)
 	if err != nil {
 		r.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+		a.record.Failed(r, err)
 		return ""
 	}
 	return azure.ToString(k.PrimaryKey)
@@ -186,12 +244,14 @@ type connecter interface {
 // authenticated using credentials read from a Crossplane Provider resource.
 type providerConnecter struct {
 	kube      client.Client
-	newClient func(ctx context.Context, creds []byte) (redis.Client, error)
+	pool      *pool.Pool
+	newClient func(ctx context.Context, creds []byte, hc *http.Client) (redis.Client, error)
+	record    *event.Recorder
 }
 
 // Connect returns a createsyncdeletekeyer backed by the Azure API. Azure
 // credentials are read from the Crossplane Provider referenced by the supplied
-// Redis.
+// Redis. Clients are cached per provider.
 func (c *providerConnecter) Connect(ctx context.Context, r *v1alpha1.Redis) (createsyncdeletekeyer, error) {
 	p := &azurev1alpha1.Provider{}
 	n := meta.NamespacedNameOf(r.Spec.ProviderReference)
@@ -199,21 +259,32 @@ func (c *providerConnecter) Connect(ctx context.Context, r *v1alpha1.Redis) (cre
 		return nil, errors.Wrapf(err, "cannot get provider %s", n)
 	}
 
-	s := &corev1.Secret{}
-	n = types.NamespacedName{Namespace: p.Namespace, Name: p.Spec.Secret.Name}
-	if err := c.kube.Get(ctx, n, s); err != nil {
-		return nil, errors.Wrapf(err, "cannot get provider secret %s", n)
+	v, err := azure.ProviderVersion(ctx, c.kube, p)
+	if err != nil {
+		return nil, err
 	}
 
-	client, err := c.newClient(ctx, s.Data[p.Spec.Secret.Key])
-	return &azureRedisCache{client: client}, errors.Wrap(err, "cannot create new Azure Cache client")
+	k := pool.Key{Provider: p.GetUID(), API: redis.API}
+	client, err := c.pool.Get(k, v, func() (interface{}, error) {
+		data, err := azure.ProviderCredentials(ctx, c.kube, p)
+		if err != nil {
+			return nil, err
+		}
+		client, err := c.newClient(ctx, data, c.pool.HTTPClient(k, pool.TooManyRequests, azure.Operation))
+		return client, errors.Wrap(err, "cannot create new Azure Cache client")
+	})
+	if err != nil {
+		return &azureRedisCache{}, err
+	}
+	return &azureRedisCache{client: client.(redis.Client), record: c.record}, nil
 }
 
 // Reconciler reconciles Redis read from the Kubernetes API
 // with an external store, typically the Azure API.
 type Reconciler struct {
 	connecter
-	kube client.Client
+	kube   client.Client
+	record *event.Recorder
 }
 
 // RedisController is responsible for adding the Redis
@@ -224,15 +295,17 @@ type RedisController struct{}
 // Manager with default RBAC. The Manager will set fields on the Controller and
 // start it when the Manager is Started.
 func (c *RedisController) SetupWithManager(mgr ctrl.Manager) error {
+	record := event.NewRecorder(mgr.GetEventRecorderFor(controllerName), event.WithErrorCoder(azure.ErrorCode))
 	r := &Reconciler{
-		connecter: &providerConnecter{kube: mgr.GetClient(), newClient: redis.NewClient},
+		connecter: &providerConnecter{kube: mgr.GetClient(), pool: pool.Default, newClient: redis.NewClient, record: record},
 		kube:      mgr.GetClient(),
+		record:    record,
 	}
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(controllerName).
 		For(&v1alpha1.Redis{}).
-		Complete(r)
+		Complete(tracing.NewTracer(controllerName).Reconciler(pause.NewReconciler(mgr.GetClient(), &v1alpha1.Redis{}, r, pause.WithObjectUpdate())))
 }
 
 // Reconcile Azure Cache resources with the Azure API.
@@ -253,14 +326,26 @@ func (r *Reconciler) Reconcile(req reconcile.Request) (reconcile.Result, error)
 	client, err := r.Connect(ctx, rd)
 	if err != nil {
 		rd.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+		r.record.Failed(rd, err)
 		return reconcile.Result{Requeue: true}, errors.Wrapf(r.kube.Update(ctx, rd), "cannot update resource %s", req.NamespacedName)
 	}
 
 	// The resource has been deleted from the API server. Delete from Azure.
 	if rd.DeletionTimestamp != nil {
+		if err := protection.Check(rd); err != nil {
+			rd.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+			r.record.Failed(rd, err)
+			return reconcile.Result{Requeue: true}, errors.Wrapf(r.kube.Update(ctx, rd), "cannot update resource %s", req.NamespacedName)
+		}
 		return reconcile.Result{Requeue: client.Delete(ctx, rd)}, errors.Wrapf(r.kube.Update(ctx, rd), "cannot update resource %s", req.NamespacedName)
 	}
 
+	// The resource is unnamed but has an external name. Import the existing
+	// Azure resource of that name rather than creating a new one.
+	if rd.Status.ResourceName == "" && externalname.Get(rd) != "" {
+		return reconcile.Result{Requeue: client.Import(ctx, rd)}, errors.Wrapf(r.kube.Update(ctx, rd), "cannot update resource %s", req.NamespacedName)
+	}
+
 	// The resource is unnamed. Assume it has not been created in Azure.
 	if rd.Status.ResourceName == "" {
 		return reconcile.Result{Requeue: client.Create(ctx, rd)}, errors.Wrapf(r.kube.Update(ctx, rd), "cannot update resource %s", req.NamespacedName)
@@ -268,6 +353,7 @@ func (r *Reconciler) Reconcile(req reconcile.Request) (reconcile.Result, error)
 
 	if err := r.upsertSecret(ctx, connectionSecret(rd, client.Key(ctx, rd))); err != nil {
 		rd.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+		r.record.Failed(rd, err)
 		return reconcile.Result{Requeue: true}, errors.Wrapf(r.kube.Update(ctx, rd), "cannot update resource %s", req.NamespacedName)
 	}
 
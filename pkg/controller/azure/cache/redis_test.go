@@ -18,10 +18,12 @@ package cache
 
 import (
 	"context"
+	"net/http"
 	"testing"
 	"time"
 
 	redismgmt "github.com/Azure/azure-sdk-for-go/services/redis/mgmt/2018-03-01/redis"
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -41,6 +43,7 @@ import (
 	"github.com/crossplaneio/crossplane/pkg/clients/azure"
 	"github.com/crossplaneio/crossplane/pkg/clients/azure/redis"
 	fakeredis "github.com/crossplaneio/crossplane/pkg/clients/azure/redis/fake"
+	"github.com/crossplaneio/crossplane/pkg/externalname"
 )
 
 const (
@@ -117,6 +120,10 @@ func withResourceName(n string) redisResourceModifier {
 	return func(r *v1alpha1.Redis) { r.Status.ResourceName = n }
 }
 
+func withExternalName(n string) redisResourceModifier {
+	return func(r *v1alpha1.Redis) { externalname.Set(r, n) }
+}
+
 func withProviderID(id string) redisResourceModifier {
 	return func(r *v1alpha1.Redis) { r.Status.ProviderID = id }
 }
@@ -133,6 +140,10 @@ func withSSLPort(p int) redisResourceModifier {
 	return func(r *v1alpha1.Redis) { r.Status.SSLPort = p }
 }
 
+func withOperation(o string) redisResourceModifier {
+	return func(r *v1alpha1.Redis) { r.Status.Operation = o }
+}
+
 func withDeletionTimestamp(t time.Time) redisResourceModifier {
 	return func(r *v1alpha1.Redis) { r.ObjectMeta.DeletionTimestamp = &metav1.Time{Time: t} }
 }
@@ -233,6 +244,75 @@ func TestCreate(t *testing.T) {
 	}
 }
 
+func TestImport(t *testing.T) {
+	cases := []struct {
+		name        string
+		csdk        createsyncdeletekeyer
+		r           *v1alpha1.Redis
+		want        *v1alpha1.Redis
+		wantRequeue bool
+	}{
+		{
+			name: "SuccessfulImport",
+			csdk: &azureRedisCache{client: &fakeredis.MockClient{
+				MockGet: func(_ context.Context, _, _ string) (redismgmt.ResourceType, error) {
+					return redismgmt.ResourceType{}, nil
+				},
+			}},
+			r: redisResource(withExternalName("existing")),
+			want: redisResource(
+				withExternalName("existing"),
+				withConditions(runtimev1alpha1.ReconcileSuccess()),
+				withFinalizers(finalizerName),
+				withResourceName("existing"),
+			),
+			wantRequeue: true,
+		},
+		{
+			name: "NotFound",
+			csdk: &azureRedisCache{client: &fakeredis.MockClient{
+				MockGet: func(_ context.Context, _, _ string) (redismgmt.ResourceType, error) {
+					return redismgmt.ResourceType{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			}},
+			r: redisResource(withExternalName("existing")),
+			want: redisResource(
+				withExternalName("existing"),
+				withConditions(runtimev1alpha1.ReconcileError(errors.New("cannot import external resource: Redis resource existing does not exist in resource group "+redisResourceGroupName))),
+			),
+			wantRequeue: true,
+		},
+		{
+			name: "FailedGet",
+			csdk: &azureRedisCache{client: &fakeredis.MockClient{
+				MockGet: func(_ context.Context, _, _ string) (redismgmt.ResourceType, error) {
+					return redismgmt.ResourceType{}, errorBoom
+				},
+			}},
+			r: redisResource(withExternalName("existing")),
+			want: redisResource(
+				withExternalName("existing"),
+				withConditions(runtimev1alpha1.ReconcileError(errors.Wrap(errorBoom, "cannot import external resource"))),
+			),
+			wantRequeue: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotRequeue := tc.csdk.Import(ctx, tc.r)
+
+			if gotRequeue != tc.wantRequeue {
+				t.Errorf("tc.csdk.Import(...): want: %t got: %t", tc.wantRequeue, gotRequeue)
+			}
+
+			if diff := cmp.Diff(tc.want, tc.r, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestSync(t *testing.T) {
 	cases := []struct {
 		name        string
@@ -371,6 +451,56 @@ func TestSync(t *testing.T) {
 				withSSLPort(sslPort),
 				withConditions(runtimev1alpha1.Available(), runtimev1alpha1.ReconcileSuccess()),
 				withBindingPhase(runtimev1alpha1.BindingPhaseUnbound),
+				withOperation(v1alpha1.OperationScaleShards),
+			),
+			wantRequeue: false,
+		},
+		{
+			name: "SuccessfulSyncWhileResourceReadyAndNeedsSKUScale",
+			csdk: &azureRedisCache{client: &fakeredis.MockClient{
+				MockGet: func(_ context.Context, _, _ string) (redismgmt.ResourceType, error) {
+					return redismgmt.ResourceType{
+						ID: azure.ToStringPtr(qualifiedName),
+						Properties: &redismgmt.Properties{
+							ProvisioningState: redismgmt.Succeeded,
+							Sku: &redismgmt.Sku{
+								Name:     redismgmt.SkuName(skuName),
+								Family:   redismgmt.SkuFamily(skuFamily),
+								Capacity: azure.ToInt32Ptr(skuCapacity + 1),
+							},
+							EnableNonSslPort:   azure.ToBoolPtr(enableNonSSLPort),
+							RedisConfiguration: azure.ToStringPtrMap(redisConfiguration),
+							ShardCount:         azure.ToInt32Ptr(shardCount + 1),
+							HostName:           azure.ToStringPtr(host),
+							Port:               azure.ToInt32Ptr(port),
+							SslPort:            azure.ToInt32Ptr(sslPort),
+						},
+					}, nil
+				},
+				MockUpdate: func(_ context.Context, _, _ string, p redismgmt.UpdateParameters) (redismgmt.ResourceType, error) {
+					if azure.ToInt(p.Sku.Capacity) != skuCapacity {
+						t.Errorf("p.Sku.Capacity: want %d, got %d", skuCapacity, azure.ToInt(p.Sku.Capacity))
+					}
+					// The shard count must not change while the SKU scales.
+					if azure.ToInt(p.ShardCount) != shardCount+1 {
+						t.Errorf("p.ShardCount: want %d, got %d", shardCount+1, azure.ToInt(p.ShardCount))
+					}
+					return redismgmt.ResourceType{}, nil
+				},
+			}},
+			r: redisResource(
+				withResourceName(redisResourceName),
+			),
+			want: redisResource(
+				withResourceName(redisResourceName),
+				withState(v1alpha1.ProvisioningStateSucceeded),
+				withProviderID(qualifiedName),
+				withEndpoint(host),
+				withPort(port),
+				withSSLPort(sslPort),
+				withConditions(runtimev1alpha1.Available(), runtimev1alpha1.ReconcileSuccess()),
+				withBindingPhase(runtimev1alpha1.BindingPhaseUnbound),
+				withOperation(v1alpha1.OperationScaleSKU),
 			),
 			wantRequeue: false,
 		},
@@ -428,6 +558,7 @@ func TestSync(t *testing.T) {
 				withSSLPort(sslPort),
 				withConditions(runtimev1alpha1.Available(), runtimev1alpha1.ReconcileError(errorBoom)),
 				withBindingPhase(runtimev1alpha1.BindingPhaseUnbound),
+				withOperation(v1alpha1.OperationScaleShards),
 			),
 			wantRequeue: true,
 		},
@@ -581,7 +712,9 @@ func TestConnect(t *testing.T) {
 					}
 					return nil
 				}},
-				newClient: func(_ context.Context, _ []byte) (redis.Client, error) { return &fakeredis.MockClient{}, nil },
+				newClient: func(_ context.Context, _ []byte, _ *http.Client) (redis.Client, error) {
+					return &fakeredis.MockClient{}, nil
+				},
 			},
 			i:    redisResource(),
 			want: &azureRedisCache{client: &fakeredis.MockClient{}},
@@ -592,7 +725,9 @@ func TestConnect(t *testing.T) {
 				kube: &test.MockClient{MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
 					return kerrors.NewNotFound(schema.GroupResource{}, providerName)
 				}},
-				newClient: func(_ context.Context, _ []byte) (redis.Client, error) { return &fakeredis.MockClient{}, nil },
+				newClient: func(_ context.Context, _ []byte, _ *http.Client) (redis.Client, error) {
+					return &fakeredis.MockClient{}, nil
+				},
 			},
 			i:       redisResource(),
 			wantErr: errors.WithStack(errors.Errorf("cannot get provider %s/%s:  \"%s\" not found", namespace, providerName, providerName)),
@@ -609,7 +744,9 @@ func TestConnect(t *testing.T) {
 					}
 					return nil
 				}},
-				newClient: func(_ context.Context, _ []byte) (redis.Client, error) { return &fakeredis.MockClient{}, nil },
+				newClient: func(_ context.Context, _ []byte, _ *http.Client) (redis.Client, error) {
+					return &fakeredis.MockClient{}, nil
+				},
 			},
 			i:       redisResource(),
 			wantErr: errors.WithStack(errors.Errorf("cannot get provider secret %s/%s:  \"%s\" not found", namespace, providerSecretName, providerSecretName)),
@@ -626,7 +763,7 @@ func TestConnect(t *testing.T) {
 					}
 					return nil
 				}},
-				newClient: func(_ context.Context, _ []byte) (redis.Client, error) { return nil, errorBoom },
+				newClient: func(_ context.Context, _ []byte, _ *http.Client) (redis.Client, error) { return nil, errorBoom },
 			},
 			i:       redisResource(),
 			want:    &azureRedisCache{},
@@ -659,6 +796,7 @@ func (c *mockConnector) Connect(ctx context.Context, i *v1alpha1.Redis) (creates
 
 type mockCSDK struct {
 	MockCreate func(ctx context.Context, i *v1alpha1.Redis) bool
+	MockImport func(ctx context.Context, i *v1alpha1.Redis) bool
 	MockSync   func(ctx context.Context, i *v1alpha1.Redis) bool
 	MockDelete func(ctx context.Context, i *v1alpha1.Redis) bool
 	MockKey    func(ctx context.Context, i *v1alpha1.Redis) string
@@ -668,6 +806,10 @@ func (csdk *mockCSDK) Create(ctx context.Context, i *v1alpha1.Redis) bool {
 	return csdk.MockCreate(ctx, i)
 }
 
+func (csdk *mockCSDK) Import(ctx context.Context, i *v1alpha1.Redis) bool {
+	return csdk.MockImport(ctx, i)
+}
+
 func (csdk *mockCSDK) Sync(ctx context.Context, i *v1alpha1.Redis) bool {
 	return csdk.MockSync(ctx, i)
 }
@@ -724,6 +866,24 @@ func TestReconcile(t *testing.T) {
 			want:    reconcile.Result{Requeue: true},
 			wantErr: nil,
 		},
+		{
+			name: "SuccessfulImport",
+			rec: &Reconciler{
+				connecter: &mockConnector{MockConnect: func(_ context.Context, _ *v1alpha1.Redis) (createsyncdeletekeyer, error) {
+					return &mockCSDK{MockImport: func(_ context.Context, _ *v1alpha1.Redis) bool { return true }}, nil
+				}},
+				kube: &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+						*obj.(*v1alpha1.Redis) = *(redisResource(withExternalName("existing")))
+						return nil
+					},
+					MockUpdate: func(_ context.Context, _ runtime.Object, _ ...client.UpdateOption) error { return nil },
+				},
+			},
+			req:     reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: redisResourceName}},
+			want:    reconcile.Result{Requeue: true},
+			wantErr: nil,
+		},
 		{
 			name: "SuccessfulSync",
 			rec: &Reconciler{
@@ -43,6 +43,10 @@ import (
 	computev1alpha1 "github.com/crossplaneio/crossplane/azure/apis/compute/v1alpha1"
 	azurev1alpha1 "github.com/crossplaneio/crossplane/azure/apis/v1alpha1"
 	azureclients "github.com/crossplaneio/crossplane/pkg/clients/azure"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
 )
 
 const (
@@ -65,6 +69,7 @@ type Reconciler struct {
 	clientset          kubernetes.Interface
 	aksSetupAPIFactory azureclients.AKSSetupAPIFactory
 	scheme             *runtime.Scheme
+	record             *event.Recorder
 }
 
 // AKSClusterController is responsible for adding the AKSCluster
@@ -79,7 +84,7 @@ func (c *AKSClusterController) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		Named("AKSCluster-controller").
 		For(&computev1alpha1.AKSCluster{}).
-		Complete(c.Reconciler)
+		Complete(tracing.NewTracer("AKSCluster-controller").Reconciler(pause.NewReconciler(mgr.GetClient(), &computev1alpha1.AKSCluster{}, c.Reconciler, pause.WithObjectUpdate())))
 }
 
 // NewAKSClusterReconciler returns a new reconcile.Reconciler
@@ -91,6 +96,7 @@ func NewAKSClusterReconciler(mgr manager.Manager, aksSetupAPIFactory azureclient
 		clientset:          clientset,
 		aksSetupAPIFactory: aksSetupAPIFactory,
 		scheme:             mgr.GetScheme(),
+		record:             event.NewRecorder(mgr.GetEventRecorderFor(controllerName), event.WithErrorCoder(azureclients.ErrorCode)),
 	}
 }
 
@@ -119,6 +125,9 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 
 	// Check for deletion
 	if instance.DeletionTimestamp != nil {
+		if err := protection.Check(instance); err != nil {
+			return r.fail(instance, err)
+		}
 		log.V(logging.Debug).Info("AKS cluster has been deleted, running finalizer now", "instance", instance)
 		return r.delete(instance, aksClient)
 	}
@@ -210,6 +219,7 @@ func (r *Reconciler) create(instance *computev1alpha1.AKSCluster, aksClient *azu
 	}
 
 	log.V(logging.Debug).Info("started create of AKS cluster", "instance", instance, "operation", string(createOp))
+	r.record.Normal(instance, event.ReasonCreateRequested, "Requested creation of external resource")
 
 	// save the create operation to the CRD status
 	instance.Status.RunningOperation = string(createOp)
@@ -297,6 +307,10 @@ func (r *Reconciler) sync(instance *computev1alpha1.AKSCluster, aksClient *azure
 		instance.Status.Endpoint = *cluster.Fqdn
 	}
 
+	if !event.Ready(instance) {
+		r.record.Normal(instance, event.ReasonBecameReady, "External resource became ready")
+	}
+
 	instance.Status.SetConditions(runtimev1alpha1.Available(), runtimev1alpha1.ReconcileSuccess())
 	resource.SetBindable(instance)
 	return result, r.Update(ctx, instance)
@@ -330,6 +344,7 @@ func (r *Reconciler) delete(instance *computev1alpha1.AKSCluster, aksClient *azu
 		}
 
 		log.V(logging.Debug).Info("all resources deleted for AKS cluster", "instance", instance)
+		r.record.Normal(instance, event.ReasonDeletionStarted, "Requested deletion of external resource")
 	}
 
 	meta.RemoveFinalizer(instance, finalizer)
@@ -339,6 +354,7 @@ func (r *Reconciler) delete(instance *computev1alpha1.AKSCluster, aksClient *azu
 
 // fail - helper function to set fail condition with reason and message
 func (r *Reconciler) fail(instance *computev1alpha1.AKSCluster, err error) (reconcile.Result, error) {
+	r.record.Failed(instance, err)
 	instance.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
 	return resultRequeue, r.Update(ctx, instance)
 }
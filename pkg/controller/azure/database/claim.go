@@ -28,8 +28,14 @@ import (
 
 	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	corev1alpha1 "github.com/crossplaneio/crossplane/apis/core/v1alpha1"
 	databasev1alpha1 "github.com/crossplaneio/crossplane/apis/database/v1alpha1"
 	"github.com/crossplaneio/crossplane/azure/apis/database/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/secrets"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
 )
 
 // NOTE(hasheddan): consider combining into single controller
@@ -40,23 +46,28 @@ type PostgreSQLInstanceClaimController struct{}
 
 // SetupWithManager adds a controller that reconciles PostgreSQLInstance instance claims.
 func (c *PostgreSQLInstanceClaimController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s", databasev1alpha1.PostgreSQLInstanceKind, controllerName))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(event.KubernetesErrorCode))
+	tracer := tracing.NewTracer(name)
+
 	r := resource.NewClaimReconciler(mgr,
 		resource.ClaimKind(databasev1alpha1.PostgreSQLInstanceGroupVersionKind),
 		resource.ClassKind(v1alpha1.SQLServerClassGroupVersionKind),
 		resource.ManagedKind(v1alpha1.PostgresqlServerGroupVersionKind),
+		resource.WithManagedBinder(event.NewBinder(recorder, tracer.Binder(resource.NewAPIManagedBinder(mgr.GetClient())))),
 		resource.WithManagedConfigurators(
 			resource.ManagedConfiguratorFn(ConfigurePostgresqlServer),
 			resource.NewObjectMetaConfigurator(mgr.GetScheme()),
-		))
-
-	name := strings.ToLower(fmt.Sprintf("%s.%s", databasev1alpha1.PostgreSQLInstanceKind, controllerName))
+			resource.ManagedConfiguratorFn(protection.ConfigureManaged),
+		),
+		resource.WithManagedConnectionPropagator(secrets.NewAPIConnectionPropagator(mgr.GetClient(), resource.ClaimKind(databasev1alpha1.PostgreSQLInstanceGroupVersionKind), databasev1alpha1.PostgreSQLInstanceSecretDefinition)))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		Watches(&source.Kind{Type: &v1alpha1.PostgresqlServer{}}, &resource.EnqueueRequestForClaim{}).
 		For(&databasev1alpha1.PostgreSQLInstance{}).
 		WithEventFilter(resource.NewPredicates(resource.HasClassReferenceKind(resource.ClassKind(v1alpha1.SQLServerClassGroupVersionKind)))).
-		Complete(r)
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &databasev1alpha1.PostgreSQLInstance{}, protection.NewReconciler(mgr.GetClient(), &databasev1alpha1.PostgreSQLInstance{}, r))))
 }
 
 // ConfigurePostgresqlServer configures the supplied resource (presumed to be a
@@ -90,6 +101,10 @@ func ConfigurePostgresqlServer(_ context.Context, cm resource.Claim, cs resource
 	}
 	spec.Version = v
 
+	if err := configureClaimParameters(spec, rs.SpecTemplate.ClaimParameters, pg.Spec.StorageGB, pg.Spec.InstanceSize); err != nil {
+		return err
+	}
+
 	spec.WriteConnectionSecretToReference = corev1.LocalObjectReference{Name: string(cm.GetUID())}
 	spec.ProviderReference = rs.SpecTemplate.ProviderReference
 	spec.ReclaimPolicy = rs.SpecTemplate.ReclaimPolicy
@@ -105,23 +120,28 @@ type MySQLInstanceClaimController struct{}
 
 // SetupWithManager adds a controller that reconciles MySQLInstance instance claims.
 func (c *MySQLInstanceClaimController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s", databasev1alpha1.MySQLInstanceKind, controllerName))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(event.KubernetesErrorCode))
+	tracer := tracing.NewTracer(name)
+
 	r := resource.NewClaimReconciler(mgr,
 		resource.ClaimKind(databasev1alpha1.MySQLInstanceGroupVersionKind),
 		resource.ClassKind(v1alpha1.SQLServerClassGroupVersionKind),
 		resource.ManagedKind(v1alpha1.MysqlServerGroupVersionKind),
+		resource.WithManagedBinder(event.NewBinder(recorder, tracer.Binder(resource.NewAPIManagedBinder(mgr.GetClient())))),
 		resource.WithManagedConfigurators(
 			resource.ManagedConfiguratorFn(ConfigureMysqlServer),
 			resource.NewObjectMetaConfigurator(mgr.GetScheme()),
-		))
-
-	name := strings.ToLower(fmt.Sprintf("%s.%s", databasev1alpha1.MySQLInstanceKind, controllerName))
+			resource.ManagedConfiguratorFn(protection.ConfigureManaged),
+		),
+		resource.WithManagedConnectionPropagator(secrets.NewAPIConnectionPropagator(mgr.GetClient(), resource.ClaimKind(databasev1alpha1.MySQLInstanceGroupVersionKind), databasev1alpha1.MySQLInstanceSecretDefinition)))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		Watches(&source.Kind{Type: &v1alpha1.MysqlServer{}}, &resource.EnqueueRequestForClaim{}).
 		For(&databasev1alpha1.MySQLInstance{}).
 		WithEventFilter(resource.NewPredicates(resource.HasClassReferenceKind(resource.ClassKind(v1alpha1.SQLServerClassGroupVersionKind)))).
-		Complete(r)
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &databasev1alpha1.MySQLInstance{}, protection.NewReconciler(mgr.GetClient(), &databasev1alpha1.MySQLInstance{}, r))))
 }
 
 // ConfigureMysqlServer configures the supplied resource (presumed to be
@@ -155,6 +175,10 @@ func ConfigureMysqlServer(_ context.Context, cm resource.Claim, cs resource.Clas
 	}
 	spec.Version = v
 
+	if err := configureClaimParameters(spec, rs.SpecTemplate.ClaimParameters, my.Spec.StorageGB, my.Spec.InstanceSize); err != nil {
+		return err
+	}
+
 	spec.WriteConnectionSecretToReference = corev1.LocalObjectReference{Name: string(cm.GetUID())}
 	spec.ProviderReference = rs.SpecTemplate.ProviderReference
 	spec.ReclaimPolicy = rs.SpecTemplate.ReclaimPolicy
@@ -163,3 +187,134 @@ func ConfigureMysqlServer(_ context.Context, cm resource.Claim, cs resource.Clas
 
 	return nil
 }
+
+// PostgreSQLInstanceDatabaseClaimController is responsible for adding the
+// PostgreSQLInstance claim controller for claims that use a
+// SQLServerDatabaseClass, and its corresponding reconciler to the manager with
+// any runtime configuration.
+type PostgreSQLInstanceDatabaseClaimController struct{}
+
+// SetupWithManager adds a controller that binds PostgreSQLInstance resource
+// claims to SQLServerDatabases.
+func (c *PostgreSQLInstanceDatabaseClaimController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s.%s", databasev1alpha1.PostgreSQLInstanceKind, v1alpha1.SQLServerDatabaseKind, v1alpha1.Group))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(event.KubernetesErrorCode))
+	tracer := tracing.NewTracer(name)
+
+	r := resource.NewClaimReconciler(mgr,
+		resource.ClaimKind(databasev1alpha1.PostgreSQLInstanceGroupVersionKind),
+		resource.ClassKind(v1alpha1.SQLServerDatabaseClassGroupVersionKind),
+		resource.ManagedKind(v1alpha1.SQLServerDatabaseGroupVersionKind),
+		resource.WithManagedBinder(event.NewBinder(recorder, tracer.Binder(resource.NewAPIManagedStatusBinder(mgr.GetClient())))),
+		resource.WithManagedFinalizer(resource.NewAPIManagedStatusUnbinder(mgr.GetClient())),
+		resource.WithManagedConfigurators(
+			resource.ManagedConfiguratorFn(ConfigureSQLServerDatabase),
+			resource.NewObjectMetaConfigurator(mgr.GetScheme()),
+			resource.ManagedConfiguratorFn(protection.ConfigureManaged),
+		),
+		resource.WithManagedConnectionPropagator(secrets.NewAPIConnectionPropagator(mgr.GetClient(), resource.ClaimKind(databasev1alpha1.PostgreSQLInstanceGroupVersionKind), databasev1alpha1.PostgreSQLInstanceSecretDefinition)))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		Watches(&source.Kind{Type: &v1alpha1.SQLServerDatabase{}}, &resource.EnqueueRequestForClaim{}).
+		For(&databasev1alpha1.PostgreSQLInstance{}).
+		WithEventFilter(resource.NewPredicates(resource.HasClassReferenceKind(resource.ClassKind(v1alpha1.SQLServerDatabaseClassGroupVersionKind)))).
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &databasev1alpha1.PostgreSQLInstance{}, protection.NewReconciler(mgr.GetClient(), &databasev1alpha1.PostgreSQLInstance{}, r))))
+}
+
+// MySQLInstanceDatabaseClaimController is responsible for adding the
+// MySQLInstance claim controller for claims that use a SQLServerDatabaseClass,
+// and its corresponding reconciler to the manager with any runtime
+// configuration.
+type MySQLInstanceDatabaseClaimController struct{}
+
+// SetupWithManager adds a controller that binds MySQLInstance resource claims
+// to SQLServerDatabases.
+func (c *MySQLInstanceDatabaseClaimController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s.%s", databasev1alpha1.MySQLInstanceKind, v1alpha1.SQLServerDatabaseKind, v1alpha1.Group))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(event.KubernetesErrorCode))
+	tracer := tracing.NewTracer(name)
+
+	r := resource.NewClaimReconciler(mgr,
+		resource.ClaimKind(databasev1alpha1.MySQLInstanceGroupVersionKind),
+		resource.ClassKind(v1alpha1.SQLServerDatabaseClassGroupVersionKind),
+		resource.ManagedKind(v1alpha1.SQLServerDatabaseGroupVersionKind),
+		resource.WithManagedBinder(event.NewBinder(recorder, tracer.Binder(resource.NewAPIManagedStatusBinder(mgr.GetClient())))),
+		resource.WithManagedFinalizer(resource.NewAPIManagedStatusUnbinder(mgr.GetClient())),
+		resource.WithManagedConfigurators(
+			resource.ManagedConfiguratorFn(ConfigureSQLServerDatabase),
+			resource.NewObjectMetaConfigurator(mgr.GetScheme()),
+			resource.ManagedConfiguratorFn(protection.ConfigureManaged),
+		),
+		resource.WithManagedConnectionPropagator(secrets.NewAPIConnectionPropagator(mgr.GetClient(), resource.ClaimKind(databasev1alpha1.MySQLInstanceGroupVersionKind), databasev1alpha1.MySQLInstanceSecretDefinition)))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		Watches(&source.Kind{Type: &v1alpha1.SQLServerDatabase{}}, &resource.EnqueueRequestForClaim{}).
+		For(&databasev1alpha1.MySQLInstance{}).
+		WithEventFilter(resource.NewPredicates(resource.HasClassReferenceKind(resource.ClassKind(v1alpha1.SQLServerDatabaseClassGroupVersionKind)))).
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &databasev1alpha1.MySQLInstance{}, protection.NewReconciler(mgr.GetClient(), &databasev1alpha1.MySQLInstance{}, r))))
+}
+
+// ConfigureSQLServerDatabase configures the supplied database (presumed to be
+// a SQLServerDatabase) using the supplied resource claim (presumed to be a
+// MySQLInstance or PostgreSQLInstance) and database class. The class must
+// reference a server of the kind the claim asks for; the claim's engine
+// version is not used.
+func ConfigureSQLServerDatabase(_ context.Context, cm resource.Claim, cs resource.Class, mg resource.Managed) error {
+	var serverKind string
+	switch cm.(type) {
+	case *databasev1alpha1.MySQLInstance:
+		serverKind = v1alpha1.MysqlServerKind
+	case *databasev1alpha1.PostgreSQLInstance:
+		serverKind = v1alpha1.PostgresqlServerKind
+	default:
+		return errors.Errorf("expected resource claim %s to be %s or %s", cm.GetName(),
+			databasev1alpha1.MySQLInstanceGroupVersionKind, databasev1alpha1.PostgreSQLInstanceGroupVersionKind)
+	}
+
+	rs, csok := cs.(*v1alpha1.SQLServerDatabaseClass)
+	if !csok {
+		return errors.Errorf("expected resource class %s to be %s", cs.GetName(), v1alpha1.SQLServerDatabaseClassGroupVersionKind)
+	}
+	if rs.SpecTemplate.ServerRef.Kind != serverKind {
+		return errors.Errorf("expected resource class %s to reference a %s", cs.GetName(), serverKind)
+	}
+
+	d, mgok := mg.(*v1alpha1.SQLServerDatabase)
+	if !mgok {
+		return errors.Errorf("expected managed resource %s to be %s", mg.GetName(), v1alpha1.SQLServerDatabaseGroupVersionKind)
+	}
+
+	spec := &v1alpha1.SQLServerDatabaseSpec{
+		ResourceSpec: runtimev1alpha1.ResourceSpec{
+			ReclaimPolicy: runtimev1alpha1.ReclaimRetain,
+		},
+		SQLServerDatabaseParameters: rs.SpecTemplate.SQLServerDatabaseParameters,
+	}
+
+	spec.WriteConnectionSecretToReference = corev1.LocalObjectReference{Name: string(cm.GetUID())}
+	spec.ProviderReference = rs.SpecTemplate.ProviderReference
+	spec.ReclaimPolicy = rs.SpecTemplate.ReclaimPolicy
+
+	d.Spec = *spec
+
+	return nil
+}
+
+// configureClaimParameters applies the storage requested by a resource claim to
+// the supplied spec, if the class's policy allows it. Azure database servers
+// are sized by pricing tier, family, and vCores, so claims may not request a
+// portable instance size.
+func configureClaimParameters(spec *v1alpha1.SQLServerSpec, p *corev1alpha1.ClaimParameterPolicy, storageGB *int64, size string) error {
+	if size != "" {
+		return corev1alpha1.NewNotSupportedError(v1alpha1.SQLServerClassKind, corev1alpha1.ClaimParameterInstanceSize)
+	}
+	if storageGB != nil {
+		if err := p.AllowStorageGB(*storageGB); err != nil {
+			return err
+		}
+		spec.StorageProfile.StorageGB = int(*storageGB)
+	}
+	return nil
+}
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -28,6 +29,7 @@ import (
 	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
 	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	corev1alpha1 "github.com/crossplaneio/crossplane/apis/core/v1alpha1"
 	databasev1alpha1 "github.com/crossplaneio/crossplane/apis/database/v1alpha1"
 	"github.com/crossplaneio/crossplane/azure/apis/database/v1alpha1"
 )
@@ -35,6 +37,7 @@ import (
 var (
 	_ resource.ManagedConfigurator = resource.ManagedConfiguratorFn(ConfigurePostgresqlServer)
 	_ resource.ManagedConfigurator = resource.ManagedConfiguratorFn(ConfigureMysqlServer)
+	_ resource.ManagedConfigurator = resource.ManagedConfiguratorFn(ConfigureSQLServerDatabase)
 )
 
 func TestConfigurePostgresqlServer(t *testing.T) {
@@ -119,6 +122,7 @@ func TestConfigureMyPostgresqlServer(t *testing.T) {
 
 	claimUID := types.UID("definitely-a-uuid")
 	providerName := "coolprovider"
+	storageGB := int64(20)
 
 	cases := map[string]struct {
 		args args
@@ -156,6 +160,53 @@ func TestConfigureMyPostgresqlServer(t *testing.T) {
 				err: nil,
 			},
 		},
+		"ClaimParameters": {
+			args: args{
+				cm: &databasev1alpha1.MySQLInstance{
+					ObjectMeta: metav1.ObjectMeta{UID: claimUID},
+					Spec:       databasev1alpha1.MySQLInstanceSpec{StorageGB: &storageGB},
+				},
+				cs: &v1alpha1.SQLServerClass{
+					SpecTemplate: v1alpha1.SQLServerClassSpecTemplate{
+						ResourceClassSpecTemplate: runtimev1alpha1.ResourceClassSpecTemplate{
+							ProviderReference: &corev1.ObjectReference{Name: providerName},
+							ReclaimPolicy:     runtimev1alpha1.ReclaimDelete,
+						},
+						ClaimParameters: &corev1alpha1.ClaimParameterPolicy{StorageGB: &corev1alpha1.Int64Range{}},
+					},
+				},
+				mg: &v1alpha1.MysqlServer{},
+			},
+			want: want{
+				mg: &v1alpha1.MysqlServer{
+					Spec: v1alpha1.SQLServerSpec{
+						ResourceSpec: runtimev1alpha1.ResourceSpec{
+							ReclaimPolicy:                    runtimev1alpha1.ReclaimDelete,
+							WriteConnectionSecretToReference: corev1.LocalObjectReference{Name: string(claimUID)},
+							ProviderReference:                &corev1.ObjectReference{Name: providerName},
+						},
+						SQLServerParameters: v1alpha1.SQLServerParameters{
+							StorageProfile: v1alpha1.StorageProfileSpec{StorageGB: int(storageGB)},
+						},
+					},
+				},
+				err: nil,
+			},
+		},
+		"InstanceSizeNotSupported": {
+			args: args{
+				cm: &databasev1alpha1.MySQLInstance{
+					ObjectMeta: metav1.ObjectMeta{UID: claimUID},
+					Spec:       databasev1alpha1.MySQLInstanceSpec{InstanceSize: "small"},
+				},
+				cs: &v1alpha1.SQLServerClass{},
+				mg: &v1alpha1.MysqlServer{},
+			},
+			want: want{
+				mg:  &v1alpha1.MysqlServer{},
+				err: errors.New("SQLServerClass does not support claims that request instanceSize"),
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -170,3 +221,106 @@ func TestConfigureMyPostgresqlServer(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigureSQLServerDatabase(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		cm  resource.Claim
+		cs  resource.Class
+		mg  resource.Managed
+	}
+
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	claimUID := types.UID("definitely-a-uuid")
+	providerName := "coolprovider"
+	serverName := "coolserver"
+
+	class := func(kind string) *v1alpha1.SQLServerDatabaseClass {
+		return &v1alpha1.SQLServerDatabaseClass{
+			SpecTemplate: v1alpha1.SQLServerDatabaseClassSpecTemplate{
+				ResourceClassSpecTemplate: runtimev1alpha1.ResourceClassSpecTemplate{
+					ProviderReference: &corev1.ObjectReference{Name: providerName},
+					ReclaimPolicy:     runtimev1alpha1.ReclaimDelete,
+				},
+				SQLServerDatabaseParameters: v1alpha1.SQLServerDatabaseParameters{
+					ServerRef: v1alpha1.SQLServerReference{Kind: kind, Name: serverName},
+				},
+			},
+		}
+	}
+
+	configured := func(kind string) *v1alpha1.SQLServerDatabase {
+		return &v1alpha1.SQLServerDatabase{
+			Spec: v1alpha1.SQLServerDatabaseSpec{
+				ResourceSpec: runtimev1alpha1.ResourceSpec{
+					ReclaimPolicy:                    runtimev1alpha1.ReclaimDelete,
+					WriteConnectionSecretToReference: corev1.LocalObjectReference{Name: string(claimUID)},
+					ProviderReference:                &corev1.ObjectReference{Name: providerName},
+				},
+				SQLServerDatabaseParameters: v1alpha1.SQLServerDatabaseParameters{
+					ServerRef: v1alpha1.SQLServerReference{Kind: kind, Name: serverName},
+				},
+			},
+		}
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"MySQLInstance": {
+			args: args{
+				cm: &databasev1alpha1.MySQLInstance{ObjectMeta: metav1.ObjectMeta{UID: claimUID}},
+				cs: class(v1alpha1.MysqlServerKind),
+				mg: &v1alpha1.SQLServerDatabase{},
+			},
+			want: want{mg: configured(v1alpha1.MysqlServerKind)},
+		},
+		"PostgreSQLInstance": {
+			args: args{
+				cm: &databasev1alpha1.PostgreSQLInstance{ObjectMeta: metav1.ObjectMeta{UID: claimUID}},
+				cs: class(v1alpha1.PostgresqlServerKind),
+				mg: &v1alpha1.SQLServerDatabase{},
+			},
+			want: want{mg: configured(v1alpha1.PostgresqlServerKind)},
+		},
+		"ServerKindMismatch": {
+			args: args{
+				cm: &databasev1alpha1.MySQLInstance{ObjectMeta: metav1.ObjectMeta{UID: claimUID}},
+				cs: class(v1alpha1.PostgresqlServerKind),
+				mg: &v1alpha1.SQLServerDatabase{},
+			},
+			want: want{
+				mg:  &v1alpha1.SQLServerDatabase{},
+				err: errors.Errorf("expected resource class %s to reference a %s", "", v1alpha1.MysqlServerKind),
+			},
+		},
+		"WrongClass": {
+			args: args{
+				cm: &databasev1alpha1.MySQLInstance{ObjectMeta: metav1.ObjectMeta{UID: claimUID}},
+				cs: &v1alpha1.SQLServerClass{},
+				mg: &v1alpha1.SQLServerDatabase{},
+			},
+			want: want{
+				mg:  &v1alpha1.SQLServerDatabase{},
+				err: errors.Errorf("expected resource class %s to be %s", "", v1alpha1.SQLServerDatabaseClassGroupVersionKind),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ConfigureSQLServerDatabase(tc.args.ctx, tc.args.cm, tc.args.cs, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ConfigureSQLServerDatabase(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("ConfigureSQLServerDatabase(...) Managed: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -20,11 +20,10 @@ import (
 	"testing"
 	"time"
 
-	"github.com/crossplaneio/crossplane/azure/apis"
-
 	"github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -32,6 +31,8 @@ import (
 
 	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	"github.com/crossplaneio/crossplane/apis"
+	azureapis "github.com/crossplaneio/crossplane/azure/apis"
 	"github.com/crossplaneio/crossplane/azure/apis/database/v1alpha1"
 	azuredbv1alpha1 "github.com/crossplaneio/crossplane/azure/apis/database/v1alpha1"
 	azurev1alpha1 "github.com/crossplaneio/crossplane/azure/apis/v1alpha1"
@@ -53,7 +54,7 @@ var (
 )
 
 func TestMain(m *testing.M) {
-	t := test.NewEnv(namespace, apis.AddToSchemes, localtest.CRDs())
+	t := test.NewEnv(namespace, runtime.SchemeBuilder{apis.AddToScheme, azureapis.AddToScheme}, localtest.CRDs())
 	cfg = t.Start()
 	t.StopAndExit(m.Run())
 }
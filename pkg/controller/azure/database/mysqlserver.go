@@ -27,6 +27,8 @@ import (
 	"github.com/crossplaneio/crossplane-runtime/pkg/logging"
 	azuredbv1alpha1 "github.com/crossplaneio/crossplane/azure/apis/database/v1alpha1"
 	azureclients "github.com/crossplaneio/crossplane/pkg/clients/azure"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
 )
 
 const (
@@ -44,7 +46,7 @@ func (c *MysqlServerController) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		Named("mysqlservers." + controllerName).
 		For(&azuredbv1alpha1.MysqlServer{}).
-		Complete(c.Reconciler)
+		Complete(tracing.NewTracer("mysqlservers." + controllerName).Reconciler(pause.NewReconciler(mgr.GetClient(), &azuredbv1alpha1.MysqlServer{}, c.Reconciler, pause.WithObjectUpdate())))
 }
 
 // NewMysqlServerReconciler returns a new reconcile.Reconciler
@@ -59,6 +61,7 @@ func NewMysqlServerReconciler(mgr manager.Manager, sqlServerAPIFactory azureclie
 		findInstance:        r.findMySQLInstance,
 		scheme:              mgr.GetScheme(),
 		finalizer:           mysqlFinalizer,
+		tracer:              tracing.NewTracer("mysqlservers." + controllerName),
 	}
 
 	return r
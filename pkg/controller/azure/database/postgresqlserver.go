@@ -27,6 +27,8 @@ import (
 	"github.com/crossplaneio/crossplane-runtime/pkg/logging"
 	azuredbv1alpha1 "github.com/crossplaneio/crossplane/azure/apis/database/v1alpha1"
 	azureclients "github.com/crossplaneio/crossplane/pkg/clients/azure"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
 )
 
 const (
@@ -44,7 +46,7 @@ func (c *PostgresqlServerController) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		Named("Postgresqlservers." + controllerName).
 		For(&azuredbv1alpha1.PostgresqlServer{}).
-		Complete(c.Reconciler)
+		Complete(tracing.NewTracer("Postgresqlservers." + controllerName).Reconciler(pause.NewReconciler(mgr.GetClient(), &azuredbv1alpha1.PostgresqlServer{}, c.Reconciler, pause.WithObjectUpdate())))
 }
 
 // NewPostgreSQLServerReconciler returns a new reconcile.Reconciler
@@ -59,6 +61,7 @@ func NewPostgreSQLServerReconciler(mgr manager.Manager, sqlServerAPIFactory azur
 		findInstance:        r.findPostgreSQLInstance,
 		scheme:              mgr.GetScheme(),
 		finalizer:           postgresqlFinalizer,
+		tracer:              tracing.NewTracer("Postgresqlservers." + controllerName),
 	}
 
 	return r
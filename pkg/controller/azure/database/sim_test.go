@@ -0,0 +1,188 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	databasev1alpha1 "github.com/crossplaneio/crossplane/apis/database/v1alpha1"
+	azuredbv1alpha1 "github.com/crossplaneio/crossplane/azure/apis/database/v1alpha1"
+	azurev1alpha1 "github.com/crossplaneio/crossplane/azure/apis/v1alpha1"
+	azuresim "github.com/crossplaneio/crossplane/pkg/clients/azure/sim"
+)
+
+const (
+	simTimeout      = 30 * time.Second
+	simClassName    = "sim-mysql"
+	simProviderName = "sim-provider"
+)
+
+// startSim starts a manager that runs the MySQLInstance claim controller and
+// a MysqlServer controller that uses the supplied simulated SQL server API.
+// Calling the returned function stops the manager, then fails the test if the
+// manager returned an error.
+func startSim(g *GomegaWithT, sim *azuresim.SQLServerClient) (client.Client, func()) {
+	mgr, err := manager.New(cfg, manager.Options{MetricsBindAddress: "0"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect((&MySQLInstanceClaimController{}).SetupWithManager(mgr)).To(Succeed())
+	g.Expect((&MysqlServerController{Reconciler: NewMysqlServerReconciler(mgr, sim, nil)}).SetupWithManager(mgr)).To(Succeed())
+
+	stop := make(chan struct{})
+	errs := make(chan error, 1)
+	go func() { errs <- mgr.Start(stop) }()
+	return mgr.GetClient(), func() {
+		close(stop)
+		g.Expect(<-errs).NotTo(HaveOccurred())
+	}
+}
+
+func simProvider() *azurev1alpha1.Provider {
+	return &azurev1alpha1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: simProviderName},
+		Spec: azurev1alpha1.ProviderSpec{
+			Secret: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+				Key:                  secretDataKey,
+			},
+		},
+	}
+}
+
+func simClass() *azuredbv1alpha1.SQLServerClass {
+	return &azuredbv1alpha1.SQLServerClass{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: simClassName},
+		SpecTemplate: azuredbv1alpha1.SQLServerClassSpecTemplate{
+			ResourceClassSpecTemplate: runtimev1alpha1.ResourceClassSpecTemplate{
+				ProviderReference: &corev1.ObjectReference{Namespace: namespace, Name: simProviderName},
+				ReclaimPolicy:     runtimev1alpha1.ReclaimDelete,
+			},
+			SQLServerParameters: azuredbv1alpha1.SQLServerParameters{
+				ResourceGroupName: "sim-group",
+				Location:          "westus",
+				PricingTier:       azuredbv1alpha1.PricingTierSpec{Tier: "Basic", VCores: 1, Family: "Gen5"},
+				StorageProfile:    azuredbv1alpha1.StorageProfileSpec{StorageGB: 25},
+				AdminLoginName:    "myadmin",
+				Version:           "5.7",
+			},
+		},
+	}
+}
+
+func simClaim(name string) *databasev1alpha1.MySQLInstance {
+	return &databasev1alpha1.MySQLInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: databasev1alpha1.MySQLInstanceSpec{
+			ResourceClaimSpec: runtimev1alpha1.ResourceClaimSpec{
+				ClassReference: &corev1.ObjectReference{
+					APIVersion: azuredbv1alpha1.SchemeGroupVersion.String(),
+					Kind:       azuredbv1alpha1.SQLServerClassKind,
+					Namespace:  namespace,
+					Name:       simClassName,
+				},
+				WriteConnectionSecretToReference: corev1.LocalObjectReference{Name: name},
+			},
+			EngineVersion: "5.7",
+		},
+	}
+}
+
+// TestMySQLInstanceClaimSim provisions, binds, and deletes MySQLInstance
+// claims against a simulated Azure Database for MySQL API.
+func TestMySQLInstanceClaimSim(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	sim := azuresim.NewSQLServerClient()
+	sim.Polls = 1
+
+	c, stop := startSim(g, sim)
+	defer stop()
+
+	provider := simProvider()
+	g.Expect(c.Create(ctx, provider)).To(Succeed())
+	defer c.Delete(ctx, provider)
+
+	cs := simClass()
+	g.Expect(c.Create(ctx, cs)).To(Succeed())
+	defer c.Delete(ctx, cs)
+
+	cases := map[string]struct {
+		claim  string
+		inject func(sim *azuresim.SQLServerClient)
+	}{
+		"Succeeds": {
+			claim:  "sim-claim",
+			inject: func(_ *azuresim.SQLServerClient) {},
+		},
+		"RecoversFromTransientErrors": {
+			claim: "sim-claim-flaky",
+			inject: func(sim *azuresim.SQLServerClient) {
+				sim.Inject("CreateServerBegin", errors.New("boom"), 1)
+				sim.Inject("GetServer", errors.New("boom"), 2)
+				sim.Inject("CreateFirewallRulesEnd", errors.New("boom"), 1)
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			tc.inject(sim)
+
+			cm := simClaim(tc.claim)
+			g.Expect(c.Create(ctx, cm)).To(Succeed())
+			nn := types.NamespacedName{Namespace: namespace, Name: tc.claim}
+
+			// The claim binds once the simulated server becomes ready.
+			g.Eventually(func() (runtimev1alpha1.BindingPhase, error) {
+				got := &databasev1alpha1.MySQLInstance{}
+				err := c.Get(ctx, nn, got)
+				return got.GetBindingPhase(), err
+			}, simTimeout).Should(Equal(runtimev1alpha1.BindingPhaseBound))
+			g.Expect(sim.Servers()).To(HaveLen(1))
+
+			// Its connection secret is propagated from the managed resource.
+			g.Eventually(func() (string, error) {
+				s := &corev1.Secret{}
+				err := c.Get(ctx, nn, s)
+				return string(s.Data[runtimev1alpha1.ResourceCredentialsSecretEndpointKey]), err
+			}, simTimeout).Should(HaveSuffix(".mysql.database.azure.com"))
+
+			// Deleting the claim deletes its managed resource, which in turn
+			// deletes the simulated server.
+			g.Expect(c.Delete(ctx, cm)).To(Succeed())
+			g.Eventually(func() bool {
+				err := c.Get(ctx, nn, &databasev1alpha1.MySQLInstance{})
+				return kerrors.IsNotFound(err)
+			}, simTimeout).Should(BeTrue())
+			g.Eventually(func() []string {
+				sim.Settle()
+				return sim.Servers()
+			}, simTimeout).Should(BeEmpty())
+		})
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/mysql/mgmt/2017-12-01/mysql"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane/azure/apis/database/v1alpha1"
+	azureclients "github.com/crossplaneio/crossplane/pkg/clients/azure"
+	"github.com/crossplaneio/crossplane/pkg/clients/sql"
+)
+
+// Error strings.
+const (
+	errNewSQLClient    = "cannot create new SQL client for referenced SQL server"
+	errServerKind      = "unsupported SQL server kind"
+	errGetServer       = "cannot get referenced SQL server"
+	errServerState     = "referenced SQL server is not yet ready"
+	errGetServerSecret = "cannot get referenced SQL server connection secret"
+)
+
+// A serverConnection is a SQL connection to a MysqlServer or PostgresqlServer,
+// made using the administrator credentials from its connection secret.
+type serverConnection struct {
+	db       sql.Client
+	server   string
+	endpoint string
+}
+
+// connectServer connects to the SQL server referenced by the supplied managed
+// resource. It returns a nil connection and no error if the server no longer
+// exists and the managed resource has been deleted, because nothing created
+// within the server can outlive it.
+func connectServer(ctx context.Context, kube client.Client, newClientFn func(sql.Options) (sql.Client, error), mg resource.Managed, ref v1alpha1.SQLServerReference) (*serverConnection, error) {
+	var srv v1alpha1.SQLServer
+	var engine sql.Engine
+	switch ref.Kind {
+	case v1alpha1.MysqlServerKind:
+		srv, engine = &v1alpha1.MysqlServer{}, sql.MySQL
+	case v1alpha1.PostgresqlServerKind:
+		srv, engine = &v1alpha1.PostgresqlServer{}, sql.PostgreSQL
+	default:
+		return nil, errors.Errorf("%s: %q", errServerKind, ref.Kind)
+	}
+
+	n := types.NamespacedName{Namespace: mg.GetNamespace(), Name: ref.Name}
+	if err := kube.Get(ctx, n, srv); err != nil {
+		if kerrors.IsNotFound(err) && meta.WasDeleted(mg) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, errGetServer)
+	}
+	if mysql.ServerState(srv.GetStatus().State) != mysql.ServerStateReady {
+		return nil, errors.New(errServerState)
+	}
+
+	s := &corev1.Secret{}
+	n = types.NamespacedName{Namespace: srv.GetNamespace(), Name: srv.GetWriteConnectionSecretToReference().Name}
+	if err := kube.Get(ctx, n, s); err != nil {
+		return nil, errors.Wrap(err, errGetServerSecret)
+	}
+
+	if newClientFn == nil {
+		newClientFn = sql.NewClient
+	}
+	db, err := newClientFn(sql.Options{
+		Engine:   engine,
+		Endpoint: srv.GetStatus().Endpoint,
+		Username: string(s.Data[runtimev1alpha1.ResourceCredentialsSecretUserKey]),
+		Password: string(s.Data[runtimev1alpha1.ResourceCredentialsSecretPasswordKey]),
+		TLS:      srv.GetSpec().SSLEnforced,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, errNewSQLClient)
+	}
+
+	return &serverConnection{db: db, server: azureclients.SQLServerName(srv), endpoint: srv.GetStatus().Endpoint}, nil
+}
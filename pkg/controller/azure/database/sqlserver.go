@@ -22,14 +22,17 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/services/mysql/mgmt/2017-12-01/mysql"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
 
 	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane-runtime/pkg/logging"
@@ -39,6 +42,8 @@ import (
 	azuredbv1alpha1 "github.com/crossplaneio/crossplane/azure/apis/database/v1alpha1"
 	azurev1alpha1 "github.com/crossplaneio/crossplane/azure/apis/v1alpha1"
 	azureclients "github.com/crossplaneio/crossplane/pkg/clients/azure"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
 )
 
 const (
@@ -61,6 +66,7 @@ type SQLReconciler struct {
 	findInstance        func(instance azuredbv1alpha1.SQLServer) (azuredbv1alpha1.SQLServer, error)
 	scheme              *runtime.Scheme
 	finalizer           string
+	tracer              *tracing.Tracer
 }
 
 // TODO(negz): This method's cyclomatic complexity is very high. Consider
@@ -84,6 +90,9 @@ func (r *SQLReconciler) handleReconcile(instance azuredbv1alpha1.SQLServer) (rec
 	// check for CRD deletion and handle it if needed
 	if instance.GetDeletionTimestamp() != nil {
 		log.V(logging.Debug).Info("sql server has been deleted, running finalizer now", "instance", instance)
+		if err := protection.Check(instance); err != nil {
+			return r.fail(instance, err)
+		}
 		return r.handleDeletion(sqlServersClient, instance)
 	}
 
@@ -143,13 +152,24 @@ func (r *SQLReconciler) handleReconcile(instance azuredbv1alpha1.SQLServer) (rec
 // handle the creation of the given SQL Server instance
 func (r *SQLReconciler) handleCreation(sqlServersClient azureclients.SQLServerAPI, instance azuredbv1alpha1.SQLServer) (reconcile.Result, error) {
 	// TODO(negz): Why not use the package scoped context?
-	ctx := context.Background()
+	ctx, span := r.tracer.StartSpan(context.Background(), "CreateServer", instance)
+	defer span.End()
 	instance.GetStatus().SetConditions(runtimev1alpha1.Creating())
 
-	// generate a password for the admin user
-	adminPassword, err := util.GeneratePassword(passwordDataLen)
-	if err != nil {
-		return r.fail(instance, errors.Wrapf(err, "failed to create password for SQL Server instance %s", instance.GetName()))
+	// a restored server inherits the admin password of the server it was
+	// restored from, while a new server gets a generated one
+	var sourceID, adminPassword string
+	var err error
+	if instance.GetSpec().RestoreFrom != nil {
+		sourceID, adminPassword, err = r.restoreSource(instance)
+		if err != nil {
+			return r.fail(instance, errors.Wrapf(err, "failed to get restore source for SQL Server instance %s", instance.GetName()))
+		}
+	} else {
+		adminPassword, err = util.GeneratePassword(passwordDataLen)
+		if err != nil {
+			return r.fail(instance, errors.Wrapf(err, "failed to create password for SQL Server instance %s", instance.GetName()))
+		}
 	}
 
 	// save the password to the connection info secret, we'll update the secret later with the
@@ -160,7 +180,12 @@ func (r *SQLReconciler) handleCreation(sqlServersClient azureclients.SQLServerAP
 
 	// make the API call to start the create server operation
 	log.V(logging.Debug).Info("starting create of SQL Server instance", "instance", instance)
-	createOp, err := sqlServersClient.CreateServerBegin(ctx, instance, adminPassword)
+	var createOp []byte
+	if sourceID != "" {
+		createOp, err = sqlServersClient.RestoreServerBegin(ctx, instance, sourceID)
+	} else {
+		createOp, err = sqlServersClient.CreateServerBegin(ctx, instance, adminPassword)
+	}
 	if err != nil {
 		return r.fail(instance, errors.Wrapf(err, "failed to start create operation for SQL Server instance %s", instance.GetName()))
 	}
@@ -198,12 +223,43 @@ func (r *SQLReconciler) handleCreation(sqlServersClient azureclients.SQLServerAP
 	return reconcile.Result{Requeue: true}, updateWaitErr
 }
 
+// restoreSource returns the Azure ID and admin password of the server the given
+// SQL Server instance should be restored from.
+func (r *SQLReconciler) restoreSource(instance azuredbv1alpha1.SQLServer) (string, string, error) {
+	// the source server is always of the same kind as the restored server
+	source := instance.DeepCopyObject().(azuredbv1alpha1.SQLServer)
+	n := types.NamespacedName{Namespace: instance.GetNamespace(), Name: instance.GetSpec().RestoreFrom.PointInTime.ServerRef.Name}
+	if err := r.Get(ctx, n, source); err != nil {
+		return "", "", errors.Wrapf(err, "failed to get source server %s", n)
+	}
+	if source.GetStatus().ProviderID == "" {
+		return "", "", errors.Errorf("source server %s has not yet been created", n)
+	}
+
+	s := &corev1.Secret{}
+	sn := types.NamespacedName{Namespace: source.GetNamespace(), Name: source.GetWriteConnectionSecretToReference().Name}
+	if err := r.Get(ctx, sn, s); err != nil {
+		return "", "", errors.Wrapf(err, "failed to get source server connection secret %s", sn)
+	}
+
+	return source.GetStatus().ProviderID, string(s.Data[runtimev1alpha1.ResourceCredentialsSecretPasswordKey]), nil
+}
+
 // handle the deletion of the given SQL Server instance
 func (r *SQLReconciler) handleDeletion(sqlServersClient azureclients.SQLServerAPI, instance azuredbv1alpha1.SQLServer) (reconcile.Result, error) {
 	// TODO(negz): Why not use the package scoped context?
 	ctx := context.Background()
 	instance.GetStatus().SetConditions(runtimev1alpha1.Deleting())
 
+	// leave the SQL Server instance in place unless its reclaim policy asks
+	// for it to be deleted
+	if instance.GetReclaimPolicy() != runtimev1alpha1.ReclaimDelete {
+		log.V(logging.Debug).Info("SQL Server instance is retained, removing finalizer", "instance", instance.GetName())
+		meta.RemoveFinalizer(instance, r.finalizer)
+		instance.GetStatus().SetConditions(runtimev1alpha1.ReconcileSuccess())
+		return reconcile.Result{}, r.Update(ctx, instance)
+	}
+
 	// first get the latest status of the SQL Server resource that needs to be deleted
 	_, err := sqlServersClient.GetServer(ctx, instance)
 	if err != nil {
@@ -252,11 +308,13 @@ func (r *SQLReconciler) handleFirewallRuleCreation(sqlServersClient azureclients
 
 // handle a running operation for the given SQL Server instance
 func (r *SQLReconciler) handleRunningOperation(sqlServersClient azureclients.SQLServerAPI, instance azuredbv1alpha1.SQLServer) (reconcile.Result, error) {
-	ctx := context.Background()
+	ctx, span := r.tracer.StartSpan(context.Background(), "RunningOperation", instance)
+	defer span.End()
 
 	var done bool
 	var err error
 	opType := instance.GetStatus().RunningOperationType
+	span.AddAttributes(trace.StringAttribute(tracing.AttributeOperation, opType))
 
 	// check if the operation is done yet and if there was any error
 	switch opType {
@@ -269,6 +327,7 @@ func (r *SQLReconciler) handleRunningOperation(sqlServersClient azureclients.SQL
 			errors.Errorf("unknown running operation type for SQL Server instance %s: %s", instance.GetName(), opType))
 	}
 
+	span.AddAttributes(trace.BoolAttribute(tracing.AttributeOperationDone, done))
 	if !done {
 		// not done yet, check again on the next reconcile
 		log.Error(err, "waiting on create operation for SQL Server instance",
@@ -341,7 +400,7 @@ func (r *SQLReconciler) createOrUpdateConnectionSecret(instance azuredbv1alpha1.
 	return errors.Wrapf(util.CreateOrUpdate(ctx, r.Client, s, func() error {
 		// TODO(negz): Make sure we own any existing secret before overwriting it.
 		s.Data[runtimev1alpha1.ResourceCredentialsSecretEndpointKey] = []byte(instance.GetStatus().Endpoint)
-		s.Data[runtimev1alpha1.ResourceCredentialsSecretUserKey] = []byte(fmt.Sprintf("%s@%s", instance.GetSpec().AdminLoginName, instance.GetName()))
+		s.Data[runtimev1alpha1.ResourceCredentialsSecretUserKey] = []byte(fmt.Sprintf("%s@%s", instance.GetSpec().AdminLoginName, azureclients.SQLServerName(instance)))
 
 		// Don't overwrite the password if it has already been set.
 		if _, ok := s.Data[runtimev1alpha1.ResourceCredentialsSecretPasswordKey]; !ok && password != "" {
@@ -27,8 +27,11 @@ import (
 	azurerest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/google/go-cmp/cmp"
 	"github.com/onsi/gomega"
+	pkgerrors "github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
@@ -47,6 +50,7 @@ import (
 type mockSQLServerClient struct {
 	MockGetServer                func(ctx context.Context, instance azuredbv1alpha1.SQLServer) (*azureclients.SQLServer, error)
 	MockCreateServerBegin        func(ctx context.Context, instance azuredbv1alpha1.SQLServer, adminPassword string) ([]byte, error)
+	MockRestoreServerBegin       func(ctx context.Context, instance azuredbv1alpha1.SQLServer, sourceServerID string) ([]byte, error)
 	MockCreateServerEnd          func(createOp []byte) (bool, error)
 	MockDeleteServer             func(ctx context.Context, instance azuredbv1alpha1.SQLServer) (azurerest.Future, error)
 	MockGetFirewallRule          func(ctx context.Context, instance azuredbv1alpha1.SQLServer, firewallRuleName string) error
@@ -68,6 +72,13 @@ func (m *mockSQLServerClient) CreateServerBegin(ctx context.Context, instance az
 	return nil, nil
 }
 
+func (m *mockSQLServerClient) RestoreServerBegin(ctx context.Context, instance azuredbv1alpha1.SQLServer, sourceServerID string) ([]byte, error) {
+	if m.MockRestoreServerBegin != nil {
+		return m.MockRestoreServerBegin(ctx, instance, sourceServerID)
+	}
+	return nil, nil
+}
+
 func (m *mockSQLServerClient) CreateServerEnd(createOp []byte) (bool, error) {
 	if m.MockCreateServerEnd != nil {
 		return m.MockCreateServerEnd(createOp)
@@ -255,6 +266,195 @@ func TestReconcile(t *testing.T) {
 	cleanupSQLServer(t, g, c, requests, instance)
 }
 
+func TestRestoreSource(t *testing.T) {
+	sourceName := "source-instance"
+	sourceKey := types.NamespacedName{Namespace: namespace, Name: sourceName}
+	secretKey := types.NamespacedName{Namespace: namespace, Name: "source-secret"}
+	errBoom := pkgerrors.New("boom")
+
+	instance := &azuredbv1alpha1.MysqlServer{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: instanceName},
+		Spec: azuredbv1alpha1.SQLServerSpec{
+			SQLServerParameters: azuredbv1alpha1.SQLServerParameters{
+				RestoreFrom: &azuredbv1alpha1.SQLServerRestoreSource{
+					PointInTime: azuredbv1alpha1.SQLServerPointInTime{
+						ServerRef:   v1.LocalObjectReference{Name: sourceName},
+						RestoreTime: metav1.Now(),
+					},
+				},
+			},
+		},
+	}
+
+	getSource := func(providerID string) func(context.Context, client.ObjectKey, runtime.Object) error {
+		return func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+			switch o := obj.(type) {
+			case *azuredbv1alpha1.MysqlServer:
+				if key != sourceKey {
+					return pkgerrors.Errorf("unexpected source server %s", key)
+				}
+				o.SetNamespace(namespace)
+				o.Spec.WriteConnectionSecretToReference = v1.LocalObjectReference{Name: secretKey.Name}
+				o.Status.ProviderID = providerID
+			case *v1.Secret:
+				if key != secretKey {
+					return pkgerrors.Errorf("unexpected source secret %s", key)
+				}
+				o.Data = map[string][]byte{runtimev1alpha1.ResourceCredentialsSecretPasswordKey: []byte("hunter2")}
+			}
+			return nil
+		}
+	}
+
+	type want struct {
+		id       string
+		password string
+		err      error
+	}
+
+	cases := map[string]struct {
+		kube client.Client
+		want want
+	}{
+		"Successful": {
+			kube: &test.MockClient{MockGet: getSource("source-azure-id")},
+			want: want{id: "source-azure-id", password: "hunter2"},
+		},
+		"SourceNotCreated": {
+			kube: &test.MockClient{MockGet: getSource("")},
+			want: want{err: pkgerrors.Errorf("source server %s has not yet been created", sourceKey)},
+		},
+		"GetSourceFailed": {
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			want: want{err: pkgerrors.Wrapf(errBoom, "failed to get source server %s", sourceKey)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &SQLReconciler{Client: tc.kube}
+			id, password, err := r.restoreSource(instance)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r.restoreSource(...): -want error, +got error:\n%s", diff)
+			}
+			if id != tc.want.id || password != tc.want.password {
+				t.Errorf("r.restoreSource(...): want %s, %s, got %s, %s", tc.want.id, tc.want.password, id, password)
+			}
+		})
+	}
+}
+
+func TestHandleDeletion(t *testing.T) {
+	errBoom := pkgerrors.New("boom")
+	finalizer := "finalizer.test"
+
+	type want struct {
+		result     reconcile.Result
+		conditions []runtimev1alpha1.Condition
+		finalizer  bool
+		deleted    bool
+	}
+
+	cases := map[string]struct {
+		policy runtimev1alpha1.ReclaimPolicy
+		client *mockSQLServerClient
+		want   want
+	}{
+		"Retained": {
+			policy: runtimev1alpha1.ReclaimRetain,
+			client: &mockSQLServerClient{
+				MockGetServer: func(_ context.Context, _ azuredbv1alpha1.SQLServer) (*azureclients.SQLServer, error) {
+					t.Errorf("handleDeletion(...): unexpected call to GetServer for a retained server")
+					return nil, nil
+				},
+			},
+			want: want{
+				result:     reconcile.Result{},
+				conditions: []runtimev1alpha1.Condition{runtimev1alpha1.Deleting(), runtimev1alpha1.ReconcileSuccess()},
+			},
+		},
+		"Deleted": {
+			policy: runtimev1alpha1.ReclaimDelete,
+			client: &mockSQLServerClient{},
+			want: want{
+				result:     reconcile.Result{},
+				conditions: []runtimev1alpha1.Condition{runtimev1alpha1.Deleting(), runtimev1alpha1.ReconcileSuccess()},
+				deleted:    true,
+			},
+		},
+		"AlreadyDeleted": {
+			policy: runtimev1alpha1.ReclaimDelete,
+			client: &mockSQLServerClient{
+				MockGetServer: func(_ context.Context, _ azuredbv1alpha1.SQLServer) (*azureclients.SQLServer, error) {
+					return nil, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			},
+			want: want{
+				result:     reconcile.Result{},
+				conditions: []runtimev1alpha1.Condition{runtimev1alpha1.Deleting(), runtimev1alpha1.ReconcileSuccess()},
+			},
+		},
+		"GetServerFailed": {
+			policy: runtimev1alpha1.ReclaimDelete,
+			client: &mockSQLServerClient{
+				MockGetServer: func(_ context.Context, _ azuredbv1alpha1.SQLServer) (*azureclients.SQLServer, error) {
+					return nil, errBoom
+				},
+			},
+			want: want{
+				result: reconcile.Result{Requeue: true},
+				conditions: []runtimev1alpha1.Condition{
+					runtimev1alpha1.Deleting(),
+					runtimev1alpha1.ReconcileError(pkgerrors.Wrapf(errBoom, "failed to get SQL Server instance %s for deletion", instanceName)),
+				},
+				finalizer: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			deleted := false
+			if tc.client.MockDeleteServer == nil {
+				tc.client.MockDeleteServer = func(_ context.Context, _ azuredbv1alpha1.SQLServer) (azurerest.Future, error) {
+					deleted = true
+					return azurerest.Future{}, nil
+				}
+			}
+
+			instance := &azuredbv1alpha1.MysqlServer{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: instanceName, Finalizers: []string{finalizer}},
+				Spec: azuredbv1alpha1.SQLServerSpec{
+					ResourceSpec: runtimev1alpha1.ResourceSpec{ReclaimPolicy: tc.policy},
+				},
+			}
+			r := &SQLReconciler{
+				Client:    &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				finalizer: finalizer,
+			}
+
+			got, err := r.handleDeletion(tc.client, instance)
+			if err != nil {
+				t.Errorf("r.handleDeletion(...): %s", err)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("r.handleDeletion(...): -want result, +got result:\n%s", diff)
+			}
+			want := runtimev1alpha1.ConditionedStatus{}
+			want.SetConditions(tc.want.conditions...)
+			if diff := cmp.Diff(want, instance.Status.ConditionedStatus, test.EquateConditions()); diff != "" {
+				t.Errorf("r.handleDeletion(...): -want conditions, +got conditions:\n%s", diff)
+			}
+			if got := len(instance.GetFinalizers()) > 0; got != tc.want.finalizer {
+				t.Errorf("r.handleDeletion(...): want finalizer %t, got %t", tc.want.finalizer, got)
+			}
+			if deleted != tc.want.deleted {
+				t.Errorf("r.handleDeletion(...): want deleted %t, got %t", tc.want.deleted, deleted)
+			}
+		})
+	}
+}
+
 func cleanupSQLServer(t *testing.T, g *gomega.GomegaWithT, c client.Client, requests chan reconcile.Request, instance *azuredbv1alpha1.MysqlServer) {
 	deletedInstance := &azuredbv1alpha1.MysqlServer{}
 	if err := c.Get(ctx, expectedRequest.NamespacedName, deletedInstance); errors.IsNotFound(err) {
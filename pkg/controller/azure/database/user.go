@@ -0,0 +1,201 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane-runtime/pkg/util"
+	"github.com/crossplaneio/crossplane/azure/apis/database/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/sql"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
+)
+
+// Error strings.
+const (
+	errNotUser          = "managed resource is not a SQL server user"
+	errUserExists       = "cannot determine whether SQL server user exists"
+	errGeneratePassword = "cannot generate SQL server user password"
+	errCreateUser       = "cannot create SQL server user"
+	errDeleteUser       = "cannot delete SQL server user"
+	errUpdateGrants     = "cannot update SQL server user grants"
+)
+
+// SQLServerUserController is responsible for adding the SQLServerUser
+// controller and its corresponding reconciler to the manager with any runtime configuration.
+type SQLServerUserController struct{}
+
+// SetupWithManager creates a new SQLServerUser Controller and adds it to the
+// Manager with default RBAC. The Manager will set fields on the Controller and
+// start it when the Manager is Started.
+func (c *SQLServerUserController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s", v1alpha1.SQLServerUserKind, v1alpha1.Group))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(sql.ErrorCode))
+	tracer := tracing.NewTracer(name)
+
+	r := resource.NewManagedReconciler(mgr,
+		resource.ManagedKind(v1alpha1.SQLServerUserGroupVersionKind),
+		resource.WithExternalConnecter(event.NewConnecter(recorder, protection.NewConnecter(tracer.Connecter(&userConnecter{client: mgr.GetClient()})))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.SQLServerUser{}).
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &v1alpha1.SQLServerUser{}, r)))
+}
+
+// A userConnecter connects to the SQL server referenced by a SQLServerUser,
+// using the administrator credentials from the server's connection secret.
+// Users are managed via SQL rather than the Azure API, so the Azure Provider
+// is not used.
+type userConnecter struct {
+	client      client.Client
+	newClientFn func(o sql.Options) (sql.Client, error)
+}
+
+func (c *userConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	u, ok := mg.(*v1alpha1.SQLServerUser)
+	if !ok {
+		return nil, errors.New(errNotUser)
+	}
+
+	conn, err := connectServer(ctx, c.client, c.newClientFn, u, u.Spec.ServerRef)
+	if err != nil {
+		return nil, err
+	}
+	if conn == nil {
+		// Users cannot outlive the server they were created in.
+		return &userExternal{}, nil
+	}
+	return &userExternal{db: conn.db, server: conn.server, endpoint: conn.endpoint}, nil
+}
+
+// A userExternal manages a SQLServerUser. Its db client is nil when the
+// referenced SQL server no longer exists.
+type userExternal struct {
+	db       sql.Client
+	server   string
+	endpoint string
+}
+
+// login returns the username with which clients must connect as the supplied
+// user. Azure requires logins to be qualified with the server name.
+func (e *userExternal) login(u *v1alpha1.SQLServerUser) string {
+	return fmt.Sprintf("%s@%s", u.GetUsername(), e.server)
+}
+
+func (e *userExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	u, ok := mg.(*v1alpha1.SQLServerUser)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotUser)
+	}
+
+	if e.db == nil {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	exists, err := e.db.UserExists(ctx, u.GetUsername())
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, errUserExists)
+	}
+	if !exists {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	u.Status.SetConditions(runtimev1alpha1.Available())
+	resource.SetBindable(u)
+
+	o := resource.ExternalObservation{
+		ResourceExists: true,
+		ConnectionDetails: resource.ConnectionDetails{
+			runtimev1alpha1.ResourceCredentialsSecretUserKey:     []byte(e.login(u)),
+			runtimev1alpha1.ResourceCredentialsSecretEndpointKey: []byte(e.endpoint),
+		},
+	}
+	return o, nil
+}
+
+func (e *userExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	u, ok := mg.(*v1alpha1.SQLServerUser)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotUser)
+	}
+
+	u.Status.SetConditions(runtimev1alpha1.Creating())
+
+	password, err := util.GeneratePassword(passwordDataLen)
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, errGeneratePassword)
+	}
+
+	if err := e.db.CreateUser(ctx, u.GetUsername(), password); err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, errCreateUser)
+	}
+
+	c := resource.ExternalCreation{
+		ConnectionDetails: resource.ConnectionDetails{
+			runtimev1alpha1.ResourceCredentialsSecretUserKey:     []byte(e.login(u)),
+			runtimev1alpha1.ResourceCredentialsSecretPasswordKey: []byte(password),
+		},
+	}
+
+	return c, nil
+}
+
+// Update grants the user privileges on the databases named by its grants, and
+// revokes any others. A user's name is its identity, and its password is
+// generated once when it is created. Grants are not made by Create, because a
+// failure to grant would lose the password of the newly created user.
+func (e *userExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	u, ok := mg.(*v1alpha1.SQLServerUser)
+	if !ok {
+		return resource.ExternalUpdate{}, errors.New(errNotUser)
+	}
+
+	databases := make([]string, 0, len(u.Spec.Grants))
+	for _, g := range u.Spec.Grants {
+		databases = append(databases, g.Database)
+	}
+
+	err := sql.ReconcileGrants(ctx, e.db, u.GetUsername(), databases)
+	return resource.ExternalUpdate{}, errors.Wrap(err, errUpdateGrants)
+}
+
+func (e *userExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	u, ok := mg.(*v1alpha1.SQLServerUser)
+	if !ok {
+		return errors.New(errNotUser)
+	}
+
+	u.Status.SetConditions(runtimev1alpha1.Deleting())
+
+	if e.db == nil {
+		return nil
+	}
+
+	return errors.Wrap(e.db.DeleteUser(ctx, u.GetUsername()), errDeleteUser)
+}
@@ -0,0 +1,435 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/mysql/mgmt/2017-12-01/mysql"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	"github.com/crossplaneio/crossplane/azure/apis/database/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/sql"
+	"github.com/crossplaneio/crossplane/pkg/clients/sql/fake"
+)
+
+const (
+	userNamespace  = "cool-namespace"
+	userName       = "cool-user"
+	userServerName = "cool-server"
+	userSecretName = "cool-server-secret"
+	userEndpoint   = "cool-server.mysql.database.azure.com"
+	userAdminName  = "admin@cool-server"
+	userAdminPass  = "hunter2"
+	userLogin      = userName + "@" + userServerName
+)
+
+var errUserBoom = errors.New("boom")
+
+type sqlServerUserModifier func(*v1alpha1.SQLServerUser)
+
+func withUserConditions(c ...runtimev1alpha1.Condition) sqlServerUserModifier {
+	return func(u *v1alpha1.SQLServerUser) { u.Status.ConditionedStatus.Conditions = c }
+}
+
+func withUserBindingPhase(p runtimev1alpha1.BindingPhase) sqlServerUserModifier {
+	return func(u *v1alpha1.SQLServerUser) { u.Status.SetBindingPhase(p) }
+}
+
+func withUserServerKind(k string) sqlServerUserModifier {
+	return func(u *v1alpha1.SQLServerUser) { u.Spec.ServerRef.Kind = k }
+}
+
+func withUserGrants(databases ...string) sqlServerUserModifier {
+	return func(u *v1alpha1.SQLServerUser) {
+		for _, d := range databases {
+			u.Spec.Grants = append(u.Spec.Grants, v1alpha1.DatabaseGrant{Database: d})
+		}
+	}
+}
+
+func withUserDeletionTimestamp(t time.Time) sqlServerUserModifier {
+	return func(u *v1alpha1.SQLServerUser) { u.SetDeletionTimestamp(&metav1.Time{Time: t}) }
+}
+
+func sqlServerUser(m ...sqlServerUserModifier) *v1alpha1.SQLServerUser {
+	u := &v1alpha1.SQLServerUser{
+		ObjectMeta: metav1.ObjectMeta{Namespace: userNamespace, Name: userName},
+		Spec: v1alpha1.SQLServerUserSpec{
+			SQLServerUserParameters: v1alpha1.SQLServerUserParameters{
+				ServerRef: v1alpha1.SQLServerReference{Kind: v1alpha1.MysqlServerKind, Name: userServerName},
+			},
+		},
+	}
+
+	for _, fn := range m {
+		fn(u)
+	}
+
+	return u
+}
+
+func userServer(state mysql.ServerState) *v1alpha1.MysqlServer {
+	return &v1alpha1.MysqlServer{
+		ObjectMeta: metav1.ObjectMeta{Namespace: userNamespace, Name: userServerName},
+		Spec: v1alpha1.SQLServerSpec{
+			ResourceSpec: runtimev1alpha1.ResourceSpec{
+				WriteConnectionSecretToReference: corev1.LocalObjectReference{Name: userSecretName},
+			},
+			SQLServerParameters: v1alpha1.SQLServerParameters{SSLEnforced: true},
+		},
+		Status: v1alpha1.SQLServerStatus{State: string(state), Endpoint: userEndpoint},
+	}
+}
+
+func userKube(s *v1alpha1.MysqlServer, secretErr error) client.Client {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+			switch o := obj.(type) {
+			case *v1alpha1.MysqlServer:
+				if s == nil {
+					return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+				}
+				*o = *s
+			case *corev1.Secret:
+				if secretErr != nil {
+					return secretErr
+				}
+				o.Data = map[string][]byte{
+					runtimev1alpha1.ResourceCredentialsSecretUserKey:     []byte(userAdminName),
+					runtimev1alpha1.ResourceCredentialsSecretPasswordKey: []byte(userAdminPass),
+				}
+			}
+			return nil
+		},
+	}
+}
+
+var _ resource.ExternalConnecter = &userConnecter{}
+var _ resource.ExternalClient = &userExternal{}
+
+func TestUserConnect(t *testing.T) {
+	wantOptions := sql.Options{
+		Engine:   sql.MySQL,
+		Endpoint: userEndpoint,
+		Username: userAdminName,
+		Password: userAdminPass,
+		TLS:      true,
+	}
+
+	cases := map[string]struct {
+		kube    client.Client
+		u       *v1alpha1.SQLServerUser
+		wantErr error
+	}{
+		"Successful": {
+			kube: userKube(userServer(mysql.ServerStateReady), nil),
+			u:    sqlServerUser(),
+		},
+		"UnsupportedServerKind": {
+			kube:    userKube(userServer(mysql.ServerStateReady), nil),
+			u:       sqlServerUser(withUserServerKind("SQLServerClass")),
+			wantErr: errors.Errorf("%s: %q", errServerKind, "SQLServerClass"),
+		},
+		"ServerNotFound": {
+			kube:    userKube(nil, nil),
+			u:       sqlServerUser(),
+			wantErr: errors.Wrap(kerrors.NewNotFound(schema.GroupResource{}, userServerName), errGetServer),
+		},
+		"ServerNotFoundWhileDeleting": {
+			kube: userKube(nil, nil),
+			u:    sqlServerUser(withUserDeletionTimestamp(time.Now())),
+		},
+		"ServerNotReady": {
+			kube:    userKube(userServer(mysql.ServerStateDisabled), nil),
+			u:       sqlServerUser(),
+			wantErr: errors.New(errServerState),
+		},
+		"FailedToGetSecret": {
+			kube:    userKube(userServer(mysql.ServerStateReady), errUserBoom),
+			u:       sqlServerUser(),
+			wantErr: errors.Wrap(errUserBoom, errGetServerSecret),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := &userConnecter{
+				client: tc.kube,
+				newClientFn: func(o sql.Options) (sql.Client, error) {
+					if diff := cmp.Diff(wantOptions, o); diff != "" {
+						t.Errorf("newClientFn(...): -want options, +got options:\n%s", diff)
+					}
+					return &fake.MockClient{}, nil
+				},
+			}
+			_, err := c.Connect(context.Background(), tc.u)
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("c.Connect(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUserObserve(t *testing.T) {
+	type want struct {
+		o   resource.ExternalObservation
+		u   *v1alpha1.SQLServerUser
+		err error
+	}
+
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		u    *v1alpha1.SQLServerUser
+		want want
+	}{
+		"UserExists": {
+			e: &userExternal{
+				db: &fake.MockClient{MockUserExists: func(_ context.Context, name string) (bool, error) {
+					return name == userName, nil
+				}},
+				server:   userServerName,
+				endpoint: userEndpoint,
+			},
+			u: sqlServerUser(),
+			want: want{
+				o: resource.ExternalObservation{
+					ResourceExists: true,
+					ConnectionDetails: resource.ConnectionDetails{
+						runtimev1alpha1.ResourceCredentialsSecretUserKey:     []byte(userLogin),
+						runtimev1alpha1.ResourceCredentialsSecretEndpointKey: []byte(userEndpoint),
+					},
+				},
+				u: sqlServerUser(
+					withUserConditions(runtimev1alpha1.Available()),
+					withUserBindingPhase(runtimev1alpha1.BindingPhaseUnbound),
+				),
+			},
+		},
+		"UserDoesNotExist": {
+			e: &userExternal{db: &fake.MockClient{MockUserExists: func(_ context.Context, _ string) (bool, error) {
+				return false, nil
+			}}},
+			u:    sqlServerUser(),
+			want: want{o: resource.ExternalObservation{ResourceExists: false}, u: sqlServerUser()},
+		},
+		"ServerGone": {
+			e:    &userExternal{},
+			u:    sqlServerUser(),
+			want: want{o: resource.ExternalObservation{ResourceExists: false}, u: sqlServerUser()},
+		},
+		"UserExistsFailed": {
+			e: &userExternal{db: &fake.MockClient{MockUserExists: func(_ context.Context, _ string) (bool, error) {
+				return false, errUserBoom
+			}}},
+			u:    sqlServerUser(),
+			want: want{u: sqlServerUser(), err: errors.Wrap(errUserBoom, errUserExists)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.u)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("e.Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.u, tc.u, test.EquateConditions()); diff != "" {
+				t.Errorf("e.Observe(...): -want user, +got user:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUserCreate(t *testing.T) {
+	type want struct {
+		c   resource.ConnectionDetails
+		u   *v1alpha1.SQLServerUser
+		err error
+	}
+
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		u    *v1alpha1.SQLServerUser
+		want want
+	}{
+		"Successful": {
+			e: &userExternal{
+				db: &fake.MockClient{MockCreateUser: func(_ context.Context, name, password string) error {
+					if name != userName || password == "" {
+						return errors.Errorf("unexpected user %s", name)
+					}
+					return nil
+				}},
+				server: userServerName,
+			},
+			u: sqlServerUser(),
+			want: want{
+				c: resource.ConnectionDetails{runtimev1alpha1.ResourceCredentialsSecretUserKey: []byte(userLogin)},
+				u: sqlServerUser(withUserConditions(runtimev1alpha1.Creating())),
+			},
+		},
+		"CreateFailed": {
+			e: &userExternal{db: &fake.MockClient{MockCreateUser: func(_ context.Context, _, _ string) error {
+				return errUserBoom
+			}}},
+			u: sqlServerUser(),
+			want: want{
+				u:   sqlServerUser(withUserConditions(runtimev1alpha1.Creating())),
+				err: errors.Wrap(errUserBoom, errCreateUser),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c, err := tc.e.Create(context.Background(), tc.u)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Create(...): -want error, +got error:\n%s", diff)
+			}
+			if tc.want.c != nil && len(c.ConnectionDetails[runtimev1alpha1.ResourceCredentialsSecretPasswordKey]) == 0 {
+				t.Errorf("e.Create(...): want password, got none")
+			}
+			delete(c.ConnectionDetails, runtimev1alpha1.ResourceCredentialsSecretPasswordKey)
+			if diff := cmp.Diff(tc.want.c, c.ConnectionDetails); diff != "" {
+				t.Errorf("e.Create(...): -want connection details, +got connection details:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.u, tc.u, test.EquateConditions()); diff != "" {
+				t.Errorf("e.Create(...): -want user, +got user:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUserUpdate(t *testing.T) {
+	type want struct {
+		granted []string
+		revoked []string
+		err     error
+	}
+
+	cases := map[string]struct {
+		existing  []string
+		grantsErr error
+		u         *v1alpha1.SQLServerUser
+		want      want
+	}{
+		"GrantsAndRevokes": {
+			existing: []string{"cool-db", "lame-db"},
+			u:        sqlServerUser(withUserGrants("cool-db", "new-db")),
+			want: want{
+				granted: []string{"new-db"},
+				revoked: []string{"lame-db"},
+			},
+		},
+		"UpToDate": {
+			existing: []string{"cool-db"},
+			u:        sqlServerUser(withUserGrants("cool-db")),
+			want:     want{},
+		},
+		"GrantsFailed": {
+			grantsErr: errUserBoom,
+			u:         sqlServerUser(withUserGrants("cool-db")),
+			want: want{
+				err: errors.Wrap(errUserBoom, errUpdateGrants),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var granted, revoked []string
+			db := &fake.MockClient{
+				MockGrants: func(_ context.Context, user string) ([]string, error) {
+					if user != userName {
+						return nil, errors.Errorf("unexpected user %s", user)
+					}
+					return tc.existing, tc.grantsErr
+				},
+				MockGrant: func(_ context.Context, _, database string) error {
+					granted = append(granted, database)
+					return nil
+				},
+				MockRevoke: func(_ context.Context, _, database string) error {
+					revoked = append(revoked, database)
+					return nil
+				},
+			}
+			e := &userExternal{db: db}
+
+			_, err := e.Update(context.Background(), tc.u)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Update(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.granted, granted); diff != "" {
+				t.Errorf("e.Update(...): -want granted, +got granted:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.revoked, revoked); diff != "" {
+				t.Errorf("e.Update(...): -want revoked, +got revoked:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUserDelete(t *testing.T) {
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		u    *v1alpha1.SQLServerUser
+		want error
+	}{
+		"Successful": {
+			e: &userExternal{db: &fake.MockClient{MockDeleteUser: func(_ context.Context, _ string) error {
+				return nil
+			}}},
+			u: sqlServerUser(),
+		},
+		"ServerGone": {
+			e: &userExternal{},
+			u: sqlServerUser(),
+		},
+		"DeleteFailed": {
+			e: &userExternal{db: &fake.MockClient{MockDeleteUser: func(_ context.Context, _ string) error {
+				return errUserBoom
+			}}},
+			u:    sqlServerUser(),
+			want: errors.Wrap(errUserBoom, errDeleteUser),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.e.Delete(context.Background(), tc.u)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Delete(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package network contains controllers for Azure virtual networking
+// resources.
+package network
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	azurev1alpha1 "github.com/crossplaneio/crossplane/azure/apis/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/azure"
+	"github.com/crossplaneio/crossplane/pkg/clients/azure/network"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
+)
+
+// pooledClient returns a client of the supplied kind for the referenced
+// provider. The client is created by calling fn with the provider's JSON
+// encoded credentials and an HTTP client that limits requests to the Azure
+// network API, unless the supplied pool holds a current one.
+func pooledClient(ctx context.Context, pl *pool.Pool, kube client.Client, ref *corev1.ObjectReference, kind string, fn func(creds []byte, hc *http.Client) (interface{}, error)) (interface{}, error) {
+	p := &azurev1alpha1.Provider{}
+	n := meta.NamespacedNameOf(ref)
+	if err := kube.Get(ctx, n, p); err != nil {
+		return nil, errors.Wrapf(err, "cannot get provider %s", n)
+	}
+
+	v, err := azure.ProviderVersion(ctx, kube, p)
+	if err != nil {
+		return nil, err
+	}
+
+	k := pool.Key{Provider: p.GetUID(), API: network.API, Kind: kind}
+	return pl.Get(k, v, func() (interface{}, error) {
+		creds, err := azure.ProviderCredentials(ctx, kube, p)
+		if err != nil {
+			return nil, err
+		}
+		return fn(creds, pl.HTTPClient(k, pool.TooManyRequests, azure.Operation))
+	})
+}
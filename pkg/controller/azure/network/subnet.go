@@ -0,0 +1,169 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane/azure/apis/network/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/azure"
+	"github.com/crossplaneio/crossplane/pkg/clients/azure/network"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/reference"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
+)
+
+// Error strings.
+const (
+	errNewSubnetsClient = "cannot create new Azure subnets client"
+	errNotSubnet        = "managed resource is not an Azure subnet"
+	errGetSubnet        = "cannot get Azure subnet"
+	errCreateSubnet     = "cannot create Azure subnet"
+	errUpdateSubnet     = "cannot update Azure subnet"
+	errDeleteSubnet     = "cannot delete Azure subnet"
+)
+
+// SubnetController is responsible for adding the Subnet controller and its
+// corresponding reconciler to the manager with any runtime configuration.
+type SubnetController struct{}
+
+// SetupWithManager creates a new Subnet Controller and adds it to the Manager
+// with default RBAC. The Manager will set fields on the Controller and start it
+// when the Manager is Started.
+func (c *SubnetController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s", v1alpha1.SubnetKind, v1alpha1.Group))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(azure.ErrorCode))
+	tracer := tracing.NewTracer(name)
+
+	r := resource.NewManagedReconciler(mgr,
+		resource.ManagedKind(v1alpha1.SubnetGroupVersionKind),
+		resource.WithExternalConnecter(event.NewConnecter(recorder, protection.NewConnecter(tracer.Connecter(reference.NewConnecter(mgr.GetClient(), &subnetConnecter{client: mgr.GetClient(), pool: pool.Default}))))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.Subnet{}).
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &v1alpha1.Subnet{}, r)))
+}
+
+type subnetConnecter struct {
+	client      client.Client
+	pool        *pool.Pool
+	newClientFn func(credentials []byte, hc *http.Client) (network.SubnetsClient, error)
+}
+
+func (c *subnetConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	s, ok := mg.(*v1alpha1.Subnet)
+	if !ok {
+		return nil, errors.New(errNotSubnet)
+	}
+
+	newClientFn := network.NewSubnetsClient
+	if c.newClientFn != nil {
+		newClientFn = c.newClientFn
+	}
+	client, err := pooledClient(ctx, c.pool, c.client, s.Spec.ProviderReference, "subnets", func(creds []byte, hc *http.Client) (interface{}, error) {
+		client, err := newClientFn(creds, hc)
+		return client, errors.Wrap(err, errNewSubnetsClient)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &subnetExternal{client: client.(network.SubnetsClient)}, nil
+}
+
+type subnetExternal struct {
+	client network.SubnetsClient
+}
+
+func (e *subnetExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	s, ok := mg.(*v1alpha1.Subnet)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotSubnet)
+	}
+
+	az, err := e.client.Get(ctx, s.Spec.ResourceGroupName, s.Spec.VirtualNetworkName, s.GetResourceName(), "")
+	if azure.IsNotFound(err) {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, errGetSubnet)
+	}
+
+	s.Status.ID = azure.ToString(az.ID)
+	s.Status.ResourceName = azure.ToString(az.Name)
+	if az.SubnetPropertiesFormat != nil {
+		s.Status.State = azure.ToString(az.ProvisioningState)
+	}
+	setProvisioningConditions(s, s.Status.State)
+
+	return resource.ExternalObservation{ResourceExists: true}, nil
+}
+
+func (e *subnetExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	s, ok := mg.(*v1alpha1.Subnet)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotSubnet)
+	}
+
+	s.Status.SetConditions(runtimev1alpha1.Creating())
+
+	_, err := e.client.CreateOrUpdate(ctx, s.Spec.ResourceGroupName, s.Spec.VirtualNetworkName, s.GetResourceName(), network.NewSubnetParameters(s))
+	return resource.ExternalCreation{}, errors.Wrap(err, errCreateSubnet)
+}
+
+func (e *subnetExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	s, ok := mg.(*v1alpha1.Subnet)
+	if !ok {
+		return resource.ExternalUpdate{}, errors.New(errNotSubnet)
+	}
+
+	az, err := e.client.Get(ctx, s.Spec.ResourceGroupName, s.Spec.VirtualNetworkName, s.GetResourceName(), "")
+	if err != nil {
+		return resource.ExternalUpdate{}, errors.Wrap(err, errGetSubnet)
+	}
+
+	if !network.SubnetNeedsUpdate(s, az) {
+		return resource.ExternalUpdate{}, nil
+	}
+
+	_, err = e.client.CreateOrUpdate(ctx, s.Spec.ResourceGroupName, s.Spec.VirtualNetworkName, s.GetResourceName(), network.NewSubnetParameters(s))
+	return resource.ExternalUpdate{}, errors.Wrap(err, errUpdateSubnet)
+}
+
+func (e *subnetExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	s, ok := mg.(*v1alpha1.Subnet)
+	if !ok {
+		return errors.New(errNotSubnet)
+	}
+
+	s.Status.SetConditions(runtimev1alpha1.Deleting())
+
+	_, err := e.client.Delete(ctx, s.Spec.ResourceGroupName, s.Spec.VirtualNetworkName, s.GetResourceName())
+	return errors.Wrap(resource.Ignore(azure.IsNotFound, err), errDeleteSubnet)
+}
@@ -0,0 +1,321 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"testing"
+
+	networkmgmt "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-08-01/network"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	"github.com/crossplaneio/crossplane/azure/apis/network/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/azure"
+	"github.com/crossplaneio/crossplane/pkg/clients/azure/network/fake"
+)
+
+const (
+	subnetName          = "cool-subnet"
+	subnetResourceName  = "subnet-" + string(uid)
+	subnetAddressPrefix = "10.0.1.0/24"
+	subnetID            = id + "/subnets/" + subnetResourceName
+)
+
+var _ resource.ExternalConnecter = &subnetConnecter{}
+var _ resource.ExternalClient = &subnetExternal{}
+
+type subnetModifier func(*v1alpha1.Subnet)
+
+func withSubnetConditions(c ...runtimev1alpha1.Condition) subnetModifier {
+	return func(s *v1alpha1.Subnet) { s.Status.ConditionedStatus.Conditions = c }
+}
+
+func withSubnetBindingPhase(p runtimev1alpha1.BindingPhase) subnetModifier {
+	return func(s *v1alpha1.Subnet) { s.Status.SetBindingPhase(p) }
+}
+
+func withSubnetStatus(id, name, state string) subnetModifier {
+	return func(s *v1alpha1.Subnet) {
+		s.Status.ID = id
+		s.Status.ResourceName = name
+		s.Status.State = state
+	}
+}
+
+func subnet(m ...subnetModifier) *v1alpha1.Subnet {
+	s := &v1alpha1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: subnetName, UID: uid},
+		Spec: v1alpha1.SubnetSpec{
+			ResourceSpec: runtimev1alpha1.ResourceSpec{
+				ProviderReference: &corev1.ObjectReference{Namespace: namespace, Name: providerName},
+			},
+			SubnetParameters: v1alpha1.SubnetParameters{
+				ResourceGroupName:  resourceGroupName,
+				VirtualNetworkName: virtualNetworkResourceName,
+				AddressPrefix:      subnetAddressPrefix,
+			},
+		},
+	}
+
+	for _, fn := range m {
+		fn(s)
+	}
+
+	return s
+}
+
+func azureSubnet(state, prefix string) networkmgmt.Subnet {
+	return networkmgmt.Subnet{
+		ID:   azure.ToStringPtr(subnetID),
+		Name: azure.ToStringPtr(subnetResourceName),
+		SubnetPropertiesFormat: &networkmgmt.SubnetPropertiesFormat{
+			ProvisioningState: azure.ToStringPtr(state),
+			AddressPrefix:     azure.ToStringPtr(prefix),
+		},
+	}
+}
+
+func TestSubnetObserve(t *testing.T) {
+	type want struct {
+		o   resource.ExternalObservation
+		s   *v1alpha1.Subnet
+		err error
+	}
+
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		s    *v1alpha1.Subnet
+		want want
+	}{
+		"SubnetAvailable": {
+			e: &subnetExternal{client: &fake.MockSubnetsClient{
+				MockGet: func(_ context.Context, group, network, name, _ string) (networkmgmt.Subnet, error) {
+					if group != resourceGroupName || network != virtualNetworkResourceName || name != subnetResourceName {
+						return networkmgmt.Subnet{}, errNotFound
+					}
+					return azureSubnet(v1alpha1.ProvisioningStateSucceeded, subnetAddressPrefix), nil
+				},
+			}},
+			s: subnet(),
+			want: want{
+				o: resource.ExternalObservation{ResourceExists: true},
+				s: subnet(
+					withSubnetStatus(subnetID, subnetResourceName, v1alpha1.ProvisioningStateSucceeded),
+					withSubnetConditions(runtimev1alpha1.Available()),
+					withSubnetBindingPhase(runtimev1alpha1.BindingPhaseUnbound),
+				),
+			},
+		},
+		"SubnetDeleting": {
+			e: &subnetExternal{client: &fake.MockSubnetsClient{
+				MockGet: func(_ context.Context, _, _, _, _ string) (networkmgmt.Subnet, error) {
+					return azureSubnet(v1alpha1.ProvisioningStateDeleting, subnetAddressPrefix), nil
+				},
+			}},
+			s: subnet(),
+			want: want{
+				o: resource.ExternalObservation{ResourceExists: true},
+				s: subnet(
+					withSubnetStatus(subnetID, subnetResourceName, v1alpha1.ProvisioningStateDeleting),
+					withSubnetConditions(runtimev1alpha1.Deleting()),
+				),
+			},
+		},
+		"SubnetNotFound": {
+			e: &subnetExternal{client: &fake.MockSubnetsClient{
+				MockGet: func(_ context.Context, _, _, _, _ string) (networkmgmt.Subnet, error) {
+					return networkmgmt.Subnet{}, errNotFound
+				},
+			}},
+			s:    subnet(),
+			want: want{o: resource.ExternalObservation{ResourceExists: false}, s: subnet()},
+		},
+		"GetFailed": {
+			e: &subnetExternal{client: &fake.MockSubnetsClient{
+				MockGet: func(_ context.Context, _, _, _, _ string) (networkmgmt.Subnet, error) {
+					return networkmgmt.Subnet{}, errorBoom
+				},
+			}},
+			s:    subnet(),
+			want: want{s: subnet(), err: errors.Wrap(errorBoom, errGetSubnet)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.s)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("e.Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.s, tc.s, test.EquateConditions()); diff != "" {
+				t.Errorf("e.Observe(...): -want subnet, +got subnet:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSubnetCreate(t *testing.T) {
+	type want struct {
+		s   *v1alpha1.Subnet
+		err error
+	}
+
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		s    *v1alpha1.Subnet
+		want want
+	}{
+		"Successful": {
+			e: &subnetExternal{client: &fake.MockSubnetsClient{
+				MockCreateOrUpdate: func(_ context.Context, _, _, name string, p networkmgmt.Subnet) (networkmgmt.SubnetsCreateOrUpdateFuture, error) {
+					if name != subnetResourceName || azure.ToString(p.AddressPrefix) != subnetAddressPrefix {
+						return networkmgmt.SubnetsCreateOrUpdateFuture{}, errors.Errorf("unexpected subnet %s", name)
+					}
+					return networkmgmt.SubnetsCreateOrUpdateFuture{}, nil
+				},
+			}},
+			s:    subnet(),
+			want: want{s: subnet(withSubnetConditions(runtimev1alpha1.Creating()))},
+		},
+		"CreateFailed": {
+			e: &subnetExternal{client: &fake.MockSubnetsClient{
+				MockCreateOrUpdate: func(_ context.Context, _, _, _ string, _ networkmgmt.Subnet) (networkmgmt.SubnetsCreateOrUpdateFuture, error) {
+					return networkmgmt.SubnetsCreateOrUpdateFuture{}, errorBoom
+				},
+			}},
+			s: subnet(),
+			want: want{
+				s:   subnet(withSubnetConditions(runtimev1alpha1.Creating())),
+				err: errors.Wrap(errorBoom, errCreateSubnet),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Create(context.Background(), tc.s)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Create(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.s, tc.s, test.EquateConditions()); diff != "" {
+				t.Errorf("e.Create(...): -want subnet, +got subnet:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSubnetUpdate(t *testing.T) {
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		s    *v1alpha1.Subnet
+		want error
+	}{
+		"UpToDate": {
+			e: &subnetExternal{client: &fake.MockSubnetsClient{
+				MockGet: func(_ context.Context, _, _, _, _ string) (networkmgmt.Subnet, error) {
+					return azureSubnet(v1alpha1.ProvisioningStateSucceeded, subnetAddressPrefix), nil
+				},
+				MockCreateOrUpdate: func(_ context.Context, _, _, _ string, _ networkmgmt.Subnet) (networkmgmt.SubnetsCreateOrUpdateFuture, error) {
+					return networkmgmt.SubnetsCreateOrUpdateFuture{}, errors.New("unexpected update")
+				},
+			}},
+			s: subnet(),
+		},
+		"UpdateFailed": {
+			e: &subnetExternal{client: &fake.MockSubnetsClient{
+				MockGet: func(_ context.Context, _, _, _, _ string) (networkmgmt.Subnet, error) {
+					return azureSubnet(v1alpha1.ProvisioningStateSucceeded, "10.0.2.0/24"), nil
+				},
+				MockCreateOrUpdate: func(_ context.Context, _, _, _ string, _ networkmgmt.Subnet) (networkmgmt.SubnetsCreateOrUpdateFuture, error) {
+					return networkmgmt.SubnetsCreateOrUpdateFuture{}, errorBoom
+				},
+			}},
+			s:    subnet(),
+			want: errors.Wrap(errorBoom, errUpdateSubnet),
+		},
+		"GetFailed": {
+			e: &subnetExternal{client: &fake.MockSubnetsClient{
+				MockGet: func(_ context.Context, _, _, _, _ string) (networkmgmt.Subnet, error) {
+					return networkmgmt.Subnet{}, errorBoom
+				},
+			}},
+			s:    subnet(),
+			want: errors.Wrap(errorBoom, errGetSubnet),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Update(context.Background(), tc.s)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Update(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSubnetDelete(t *testing.T) {
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		s    *v1alpha1.Subnet
+		want error
+	}{
+		"Successful": {
+			e: &subnetExternal{client: &fake.MockSubnetsClient{
+				MockDelete: func(_ context.Context, _, _, _ string) (networkmgmt.SubnetsDeleteFuture, error) {
+					return networkmgmt.SubnetsDeleteFuture{}, nil
+				},
+			}},
+			s: subnet(),
+		},
+		"NotFound": {
+			e: &subnetExternal{client: &fake.MockSubnetsClient{
+				MockDelete: func(_ context.Context, _, _, _ string) (networkmgmt.SubnetsDeleteFuture, error) {
+					return networkmgmt.SubnetsDeleteFuture{}, errNotFound
+				},
+			}},
+			s: subnet(),
+		},
+		"DeleteFailed": {
+			e: &subnetExternal{client: &fake.MockSubnetsClient{
+				MockDelete: func(_ context.Context, _, _, _ string) (networkmgmt.SubnetsDeleteFuture, error) {
+					return networkmgmt.SubnetsDeleteFuture{}, errorBoom
+				},
+			}},
+			s:    subnet(),
+			want: errors.Wrap(errorBoom, errDeleteSubnet),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.e.Delete(context.Background(), tc.s)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Delete(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
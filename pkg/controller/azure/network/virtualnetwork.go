@@ -0,0 +1,191 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane/azure/apis/network/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/azure"
+	"github.com/crossplaneio/crossplane/pkg/clients/azure/network"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
+)
+
+// Error strings.
+const (
+	errNewVirtualNetworkClient = "cannot create new Azure virtual networks client"
+	errNotVirtualNetwork       = "managed resource is not an Azure virtual network"
+	errGetVirtualNetwork       = "cannot get Azure virtual network"
+	errCreateVirtualNetwork    = "cannot create Azure virtual network"
+	errUpdateVirtualNetwork    = "cannot update Azure virtual network"
+	errDeleteVirtualNetwork    = "cannot delete Azure virtual network"
+)
+
+// VirtualNetworkController is responsible for adding the VirtualNetwork
+// controller and its corresponding reconciler to the manager with any runtime
+// configuration.
+type VirtualNetworkController struct{}
+
+// SetupWithManager creates a new VirtualNetwork Controller and adds it to the
+// Manager with default RBAC. The Manager will set fields on the Controller and
+// start it when the Manager is Started.
+func (c *VirtualNetworkController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s", v1alpha1.VirtualNetworkKind, v1alpha1.Group))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(azure.ErrorCode))
+	tracer := tracing.NewTracer(name)
+
+	r := resource.NewManagedReconciler(mgr,
+		resource.ManagedKind(v1alpha1.VirtualNetworkGroupVersionKind),
+		resource.WithExternalConnecter(event.NewConnecter(recorder, protection.NewConnecter(tracer.Connecter(&virtualNetworkConnecter{client: mgr.GetClient(), pool: pool.Default})))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.VirtualNetwork{}).
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &v1alpha1.VirtualNetwork{}, r)))
+}
+
+type virtualNetworkConnecter struct {
+	client      client.Client
+	pool        *pool.Pool
+	newClientFn func(credentials []byte, hc *http.Client) (network.VirtualNetworksClient, error)
+}
+
+func (c *virtualNetworkConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	v, ok := mg.(*v1alpha1.VirtualNetwork)
+	if !ok {
+		return nil, errors.New(errNotVirtualNetwork)
+	}
+
+	newClientFn := network.NewVirtualNetworksClient
+	if c.newClientFn != nil {
+		newClientFn = c.newClientFn
+	}
+	client, err := pooledClient(ctx, c.pool, c.client, v.Spec.ProviderReference, "virtualnetworks", func(creds []byte, hc *http.Client) (interface{}, error) {
+		client, err := newClientFn(creds, hc)
+		return client, errors.Wrap(err, errNewVirtualNetworkClient)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &virtualNetworkExternal{client: client.(network.VirtualNetworksClient)}, nil
+}
+
+type virtualNetworkExternal struct {
+	client network.VirtualNetworksClient
+}
+
+func (e *virtualNetworkExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	v, ok := mg.(*v1alpha1.VirtualNetwork)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotVirtualNetwork)
+	}
+
+	az, err := e.client.Get(ctx, v.Spec.ResourceGroupName, v.GetResourceName(), "")
+	if azure.IsNotFound(err) {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, errGetVirtualNetwork)
+	}
+
+	v.Status.ID = azure.ToString(az.ID)
+	v.Status.ResourceName = azure.ToString(az.Name)
+	if az.VirtualNetworkPropertiesFormat != nil {
+		v.Status.State = azure.ToString(az.ProvisioningState)
+	}
+	setProvisioningConditions(v, v.Status.State)
+
+	return resource.ExternalObservation{ResourceExists: true}, nil
+}
+
+func (e *virtualNetworkExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	v, ok := mg.(*v1alpha1.VirtualNetwork)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotVirtualNetwork)
+	}
+
+	v.Status.SetConditions(runtimev1alpha1.Creating())
+
+	_, err := e.client.CreateOrUpdate(ctx, v.Spec.ResourceGroupName, v.GetResourceName(), network.NewVirtualNetworkParameters(v))
+	return resource.ExternalCreation{}, errors.Wrap(err, errCreateVirtualNetwork)
+}
+
+func (e *virtualNetworkExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	v, ok := mg.(*v1alpha1.VirtualNetwork)
+	if !ok {
+		return resource.ExternalUpdate{}, errors.New(errNotVirtualNetwork)
+	}
+
+	az, err := e.client.Get(ctx, v.Spec.ResourceGroupName, v.GetResourceName(), "")
+	if err != nil {
+		return resource.ExternalUpdate{}, errors.Wrap(err, errGetVirtualNetwork)
+	}
+
+	if !network.VirtualNetworkNeedsUpdate(v, az) {
+		return resource.ExternalUpdate{}, nil
+	}
+
+	// Azure deletes any subnets that are omitted when a virtual network is
+	// updated, so we must send back the subnets it already has. They may be
+	// managed by Subnet resources.
+	p := network.NewVirtualNetworkParameters(v)
+	if az.VirtualNetworkPropertiesFormat != nil {
+		p.Subnets = az.Subnets
+	}
+
+	_, err = e.client.CreateOrUpdate(ctx, v.Spec.ResourceGroupName, v.GetResourceName(), p)
+	return resource.ExternalUpdate{}, errors.Wrap(err, errUpdateVirtualNetwork)
+}
+
+func (e *virtualNetworkExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	v, ok := mg.(*v1alpha1.VirtualNetwork)
+	if !ok {
+		return errors.New(errNotVirtualNetwork)
+	}
+
+	v.Status.SetConditions(runtimev1alpha1.Deleting())
+
+	_, err := e.client.Delete(ctx, v.Spec.ResourceGroupName, v.GetResourceName())
+	return errors.Wrap(resource.Ignore(azure.IsNotFound, err), errDeleteVirtualNetwork)
+}
+
+// setProvisioningConditions sets the conditions of the supplied managed
+// resource according to the supplied Azure provisioning state.
+func setProvisioningConditions(mg resource.Managed, state string) {
+	switch state {
+	case v1alpha1.ProvisioningStateSucceeded:
+		mg.SetConditions(runtimev1alpha1.Available())
+		resource.SetBindable(mg)
+	case v1alpha1.ProvisioningStateDeleting:
+		mg.SetConditions(runtimev1alpha1.Deleting())
+	default:
+		mg.SetConditions(runtimev1alpha1.Unavailable())
+	}
+}
@@ -0,0 +1,440 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	networkmgmt "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-08-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	"github.com/crossplaneio/crossplane/azure/apis/network/v1alpha1"
+	azurev1alpha1 "github.com/crossplaneio/crossplane/azure/apis/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/azure"
+	"github.com/crossplaneio/crossplane/pkg/clients/azure/network"
+	"github.com/crossplaneio/crossplane/pkg/clients/azure/network/fake"
+)
+
+const (
+	namespace         = "cool-namespace"
+	uid               = types.UID("definitely-a-uuid")
+	resourceGroupName = "coolgroup"
+	location          = "coolplace"
+	addressPrefix     = "10.0.0.0/16"
+	id                = "/subscriptions/totally-a-uuid/resourceGroups/coolgroup/providers/Microsoft.Network/virtualNetworks/virtualnetwork-definitely-a-uuid"
+
+	virtualNetworkName         = "cool-network"
+	virtualNetworkResourceName = "virtualnetwork-" + string(uid)
+
+	providerName       = "cool-azure"
+	providerSecretName = "cool-azure-secret"
+	providerSecretKey  = "credentials"
+	providerSecretData = "definitelyjson"
+)
+
+var (
+	errorBoom   = errors.New("boom")
+	errNotFound = autorest.DetailedError{StatusCode: http.StatusNotFound}
+
+	provider = azurev1alpha1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: providerName},
+		Spec: azurev1alpha1.ProviderSpec{
+			Secret: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: providerSecretName},
+				Key:                  providerSecretKey,
+			},
+		},
+	}
+
+	providerSecret = corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: providerSecretName},
+		Data:       map[string][]byte{providerSecretKey: []byte(providerSecretData)},
+	}
+)
+
+var _ resource.ExternalConnecter = &virtualNetworkConnecter{}
+var _ resource.ExternalClient = &virtualNetworkExternal{}
+
+type virtualNetworkModifier func(*v1alpha1.VirtualNetwork)
+
+func withVirtualNetworkConditions(c ...runtimev1alpha1.Condition) virtualNetworkModifier {
+	return func(v *v1alpha1.VirtualNetwork) { v.Status.ConditionedStatus.Conditions = c }
+}
+
+func withVirtualNetworkBindingPhase(p runtimev1alpha1.BindingPhase) virtualNetworkModifier {
+	return func(v *v1alpha1.VirtualNetwork) { v.Status.SetBindingPhase(p) }
+}
+
+func withVirtualNetworkStatus(id, name, state string) virtualNetworkModifier {
+	return func(v *v1alpha1.VirtualNetwork) {
+		v.Status.ID = id
+		v.Status.ResourceName = name
+		v.Status.State = state
+	}
+}
+
+func virtualNetwork(m ...virtualNetworkModifier) *v1alpha1.VirtualNetwork {
+	v := &v1alpha1.VirtualNetwork{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: virtualNetworkName, UID: uid},
+		Spec: v1alpha1.VirtualNetworkSpec{
+			ResourceSpec: runtimev1alpha1.ResourceSpec{
+				ProviderReference: &corev1.ObjectReference{Namespace: namespace, Name: providerName},
+			},
+			VirtualNetworkParameters: v1alpha1.VirtualNetworkParameters{
+				ResourceGroupName: resourceGroupName,
+				Location:          location,
+				AddressPrefixes:   []string{addressPrefix},
+			},
+		},
+	}
+
+	for _, fn := range m {
+		fn(v)
+	}
+
+	return v
+}
+
+func azureVirtualNetwork(state string, prefixes ...string) networkmgmt.VirtualNetwork {
+	return networkmgmt.VirtualNetwork{
+		ID:   azure.ToStringPtr(id),
+		Name: azure.ToStringPtr(virtualNetworkResourceName),
+		VirtualNetworkPropertiesFormat: &networkmgmt.VirtualNetworkPropertiesFormat{
+			ProvisioningState: azure.ToStringPtr(state),
+			AddressSpace:      &networkmgmt.AddressSpace{AddressPrefixes: &prefixes},
+			Subnets:           &[]networkmgmt.Subnet{{Name: azure.ToStringPtr("coolsubnet")}},
+		},
+	}
+}
+
+func TestConnect(t *testing.T) {
+	cases := map[string]struct {
+		conn    resource.ExternalConnecter
+		mg      resource.Managed
+		wantErr error
+	}{
+		"Successful": {
+			conn: &virtualNetworkConnecter{
+				client: &test.MockClient{MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+					switch key {
+					case client.ObjectKey{Namespace: namespace, Name: providerName}:
+						*obj.(*azurev1alpha1.Provider) = provider
+					case client.ObjectKey{Namespace: namespace, Name: providerSecretName}:
+						*obj.(*corev1.Secret) = providerSecret
+					}
+					return nil
+				}},
+				newClientFn: func(credentials []byte, _ *http.Client) (network.VirtualNetworksClient, error) {
+					if string(credentials) != providerSecretData {
+						return nil, errors.Errorf("unexpected credentials %s", credentials)
+					}
+					return &fake.MockVirtualNetworksClient{}, nil
+				},
+			},
+			mg: virtualNetwork(),
+		},
+		"NotVirtualNetwork": {
+			conn:    &virtualNetworkConnecter{},
+			mg:      &v1alpha1.Subnet{},
+			wantErr: errors.New(errNotVirtualNetwork),
+		},
+		"FailedToGetProvider": {
+			conn: &subnetConnecter{
+				client: &test.MockClient{MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+					return kerrors.NewNotFound(schema.GroupResource{}, providerName)
+				}},
+			},
+			mg:      subnet(),
+			wantErr: errors.WithStack(errors.Errorf("cannot get provider %s/%s:  \"%s\" not found", namespace, providerName, providerName)),
+		},
+		"FailedToCreateClient": {
+			conn: &subnetConnecter{
+				client: &test.MockClient{MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+					if p, ok := obj.(*azurev1alpha1.Provider); ok {
+						*p = provider
+					}
+					return nil
+				}},
+				newClientFn: func(_ []byte, _ *http.Client) (network.SubnetsClient, error) { return nil, errorBoom },
+			},
+			mg:      subnet(),
+			wantErr: errors.Wrap(errorBoom, errNewSubnetsClient),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.conn.Connect(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.conn.Connect(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestVirtualNetworkObserve(t *testing.T) {
+	type want struct {
+		o   resource.ExternalObservation
+		v   *v1alpha1.VirtualNetwork
+		err error
+	}
+
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		v    *v1alpha1.VirtualNetwork
+		want want
+	}{
+		"VirtualNetworkAvailable": {
+			e: &virtualNetworkExternal{client: &fake.MockVirtualNetworksClient{
+				MockGet: func(_ context.Context, group, name, _ string) (networkmgmt.VirtualNetwork, error) {
+					if group != resourceGroupName || name != virtualNetworkResourceName {
+						return networkmgmt.VirtualNetwork{}, errNotFound
+					}
+					return azureVirtualNetwork(v1alpha1.ProvisioningStateSucceeded, addressPrefix), nil
+				},
+			}},
+			v: virtualNetwork(),
+			want: want{
+				o: resource.ExternalObservation{ResourceExists: true},
+				v: virtualNetwork(
+					withVirtualNetworkStatus(id, virtualNetworkResourceName, v1alpha1.ProvisioningStateSucceeded),
+					withVirtualNetworkConditions(runtimev1alpha1.Available()),
+					withVirtualNetworkBindingPhase(runtimev1alpha1.BindingPhaseUnbound),
+				),
+			},
+		},
+		"VirtualNetworkUpdating": {
+			e: &virtualNetworkExternal{client: &fake.MockVirtualNetworksClient{
+				MockGet: func(_ context.Context, _, _, _ string) (networkmgmt.VirtualNetwork, error) {
+					return azureVirtualNetwork(v1alpha1.ProvisioningStateUpdating, addressPrefix), nil
+				},
+			}},
+			v: virtualNetwork(),
+			want: want{
+				o: resource.ExternalObservation{ResourceExists: true},
+				v: virtualNetwork(
+					withVirtualNetworkStatus(id, virtualNetworkResourceName, v1alpha1.ProvisioningStateUpdating),
+					withVirtualNetworkConditions(runtimev1alpha1.Unavailable()),
+				),
+			},
+		},
+		"VirtualNetworkNotFound": {
+			e: &virtualNetworkExternal{client: &fake.MockVirtualNetworksClient{
+				MockGet: func(_ context.Context, _, _, _ string) (networkmgmt.VirtualNetwork, error) {
+					return networkmgmt.VirtualNetwork{}, errNotFound
+				},
+			}},
+			v:    virtualNetwork(),
+			want: want{o: resource.ExternalObservation{ResourceExists: false}, v: virtualNetwork()},
+		},
+		"GetFailed": {
+			e: &virtualNetworkExternal{client: &fake.MockVirtualNetworksClient{
+				MockGet: func(_ context.Context, _, _, _ string) (networkmgmt.VirtualNetwork, error) {
+					return networkmgmt.VirtualNetwork{}, errorBoom
+				},
+			}},
+			v:    virtualNetwork(),
+			want: want{v: virtualNetwork(), err: errors.Wrap(errorBoom, errGetVirtualNetwork)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.v)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("e.Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.v, tc.v, test.EquateConditions()); diff != "" {
+				t.Errorf("e.Observe(...): -want virtual network, +got virtual network:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestVirtualNetworkCreate(t *testing.T) {
+	type want struct {
+		v   *v1alpha1.VirtualNetwork
+		err error
+	}
+
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		v    *v1alpha1.VirtualNetwork
+		want want
+	}{
+		"Successful": {
+			e: &virtualNetworkExternal{client: &fake.MockVirtualNetworksClient{
+				MockCreateOrUpdate: func(_ context.Context, _, name string, _ networkmgmt.VirtualNetwork) (networkmgmt.VirtualNetworksCreateOrUpdateFuture, error) {
+					if name != virtualNetworkResourceName {
+						return networkmgmt.VirtualNetworksCreateOrUpdateFuture{}, errors.Errorf("unexpected name %s", name)
+					}
+					return networkmgmt.VirtualNetworksCreateOrUpdateFuture{}, nil
+				},
+			}},
+			v:    virtualNetwork(),
+			want: want{v: virtualNetwork(withVirtualNetworkConditions(runtimev1alpha1.Creating()))},
+		},
+		"CreateFailed": {
+			e: &virtualNetworkExternal{client: &fake.MockVirtualNetworksClient{
+				MockCreateOrUpdate: func(_ context.Context, _, _ string, _ networkmgmt.VirtualNetwork) (networkmgmt.VirtualNetworksCreateOrUpdateFuture, error) {
+					return networkmgmt.VirtualNetworksCreateOrUpdateFuture{}, errorBoom
+				},
+			}},
+			v: virtualNetwork(),
+			want: want{
+				v:   virtualNetwork(withVirtualNetworkConditions(runtimev1alpha1.Creating())),
+				err: errors.Wrap(errorBoom, errCreateVirtualNetwork),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Create(context.Background(), tc.v)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Create(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.v, tc.v, test.EquateConditions()); diff != "" {
+				t.Errorf("e.Create(...): -want virtual network, +got virtual network:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestVirtualNetworkUpdate(t *testing.T) {
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		v    *v1alpha1.VirtualNetwork
+		want error
+	}{
+		"UpToDate": {
+			e: &virtualNetworkExternal{client: &fake.MockVirtualNetworksClient{
+				MockGet: func(_ context.Context, _, _, _ string) (networkmgmt.VirtualNetwork, error) {
+					return azureVirtualNetwork(v1alpha1.ProvisioningStateSucceeded, addressPrefix), nil
+				},
+				MockCreateOrUpdate: func(_ context.Context, _, _ string, _ networkmgmt.VirtualNetwork) (networkmgmt.VirtualNetworksCreateOrUpdateFuture, error) {
+					return networkmgmt.VirtualNetworksCreateOrUpdateFuture{}, errors.New("unexpected update")
+				},
+			}},
+			v: virtualNetwork(),
+		},
+		"PreservesSubnets": {
+			e: &virtualNetworkExternal{client: &fake.MockVirtualNetworksClient{
+				MockGet: func(_ context.Context, _, _, _ string) (networkmgmt.VirtualNetwork, error) {
+					return azureVirtualNetwork(v1alpha1.ProvisioningStateSucceeded, "10.1.0.0/16"), nil
+				},
+				MockCreateOrUpdate: func(_ context.Context, _, _ string, p networkmgmt.VirtualNetwork) (networkmgmt.VirtualNetworksCreateOrUpdateFuture, error) {
+					if p.Subnets == nil || len(*p.Subnets) != 1 {
+						return networkmgmt.VirtualNetworksCreateOrUpdateFuture{}, errors.New("subnets were not preserved")
+					}
+					return networkmgmt.VirtualNetworksCreateOrUpdateFuture{}, nil
+				},
+			}},
+			v: virtualNetwork(),
+		},
+		"UpdateFailed": {
+			e: &virtualNetworkExternal{client: &fake.MockVirtualNetworksClient{
+				MockGet: func(_ context.Context, _, _, _ string) (networkmgmt.VirtualNetwork, error) {
+					return azureVirtualNetwork(v1alpha1.ProvisioningStateSucceeded, "10.1.0.0/16"), nil
+				},
+				MockCreateOrUpdate: func(_ context.Context, _, _ string, _ networkmgmt.VirtualNetwork) (networkmgmt.VirtualNetworksCreateOrUpdateFuture, error) {
+					return networkmgmt.VirtualNetworksCreateOrUpdateFuture{}, errorBoom
+				},
+			}},
+			v:    virtualNetwork(),
+			want: errors.Wrap(errorBoom, errUpdateVirtualNetwork),
+		},
+		"GetFailed": {
+			e: &virtualNetworkExternal{client: &fake.MockVirtualNetworksClient{
+				MockGet: func(_ context.Context, _, _, _ string) (networkmgmt.VirtualNetwork, error) {
+					return networkmgmt.VirtualNetwork{}, errorBoom
+				},
+			}},
+			v:    virtualNetwork(),
+			want: errors.Wrap(errorBoom, errGetVirtualNetwork),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Update(context.Background(), tc.v)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Update(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestVirtualNetworkDelete(t *testing.T) {
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		v    *v1alpha1.VirtualNetwork
+		want error
+	}{
+		"Successful": {
+			e: &virtualNetworkExternal{client: &fake.MockVirtualNetworksClient{
+				MockDelete: func(_ context.Context, _, _ string) (networkmgmt.VirtualNetworksDeleteFuture, error) {
+					return networkmgmt.VirtualNetworksDeleteFuture{}, nil
+				},
+			}},
+			v: virtualNetwork(),
+		},
+		"NotFound": {
+			e: &virtualNetworkExternal{client: &fake.MockVirtualNetworksClient{
+				MockDelete: func(_ context.Context, _, _ string) (networkmgmt.VirtualNetworksDeleteFuture, error) {
+					return networkmgmt.VirtualNetworksDeleteFuture{}, errNotFound
+				},
+			}},
+			v: virtualNetwork(),
+		},
+		"DeleteFailed": {
+			e: &virtualNetworkExternal{client: &fake.MockVirtualNetworksClient{
+				MockDelete: func(_ context.Context, _, _ string) (networkmgmt.VirtualNetworksDeleteFuture, error) {
+					return networkmgmt.VirtualNetworksDeleteFuture{}, errorBoom
+				},
+			}},
+			v:    virtualNetwork(),
+			want: errors.Wrap(errorBoom, errDeleteVirtualNetwork),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.e.Delete(context.Background(), tc.v)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Delete(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
@@ -22,9 +22,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
-	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -33,7 +31,13 @@ import (
 	"github.com/crossplaneio/crossplane-runtime/pkg/logging"
 	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
 	"github.com/crossplaneio/crossplane/azure/apis/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/azure"
 	"github.com/crossplaneio/crossplane/pkg/clients/azure/resourcegroup"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
 )
 
 const (
@@ -79,14 +83,17 @@ type createsyncdeleter interface {
 // azureResourceGroup is a createsyncdeleter using the Azure Groups API.
 type azureResourceGroup struct {
 	client resourcegroup.GroupsClient
+	record *event.Recorder
 }
 
 func (a *azureResourceGroup) Create(ctx context.Context, r *v1alpha1.ResourceGroup) bool {
 	r.Status.SetConditions(runtimev1alpha1.Creating())
 	if _, err := a.client.CreateOrUpdate(ctx, r.Spec.Name, resourcegroup.NewParameters(r)); err != nil {
 		r.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+		a.record.Failed(r, err)
 		return true
 	}
+	a.record.Normal(r, event.ReasonCreateRequested, "Requested creation of external resource")
 
 	r.Status.Name = r.Spec.Name
 	meta.AddFinalizer(r, finalizer)
@@ -99,16 +106,21 @@ func (a *azureResourceGroup) Sync(ctx context.Context, r *v1alpha1.ResourceGroup
 	res, err := a.client.CheckExistence(ctx, r.Spec.Name)
 	if err != nil {
 		r.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+		a.record.Failed(r, err)
 		return true
 	}
 
 	switch res.Response.StatusCode {
 	case http.StatusNoContent:
+		if !event.Ready(r) {
+			a.record.Normal(r, event.ReasonBecameReady, "External resource became ready")
+		}
 		r.Status.SetConditions(runtimev1alpha1.Available(), runtimev1alpha1.ReconcileSuccess())
 		return false
 	case http.StatusNotFound:
 		// Custom error passed to SetFailed due to Azure API returning 404 instead of error
 		r.Status.SetConditions(runtimev1alpha1.ReconcileError(errDeleted))
+		a.record.Failed(r, errDeleted)
 		return true
 	}
 
@@ -121,8 +133,10 @@ func (a *azureResourceGroup) Delete(ctx context.Context, r *v1alpha1.ResourceGro
 	if r.Spec.ReclaimPolicy == runtimev1alpha1.ReclaimDelete {
 		if _, err := a.client.Delete(ctx, r.Spec.Name); err != nil {
 			r.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+			a.record.Failed(r, err)
 			return true
 		}
+		a.record.Normal(r, event.ReasonDeletionStarted, "Requested deletion of external resource")
 	}
 	meta.RemoveFinalizer(r, finalizer)
 	r.Status.SetConditions(runtimev1alpha1.ReconcileSuccess())
@@ -140,12 +154,14 @@ type connecter interface {
 // authenticated using credentials read from a Crossplane Provider resource.
 type providerConnecter struct {
 	kube      client.Client
-	newClient func(creds []byte) (resourcegroup.GroupsClient, error)
+	pool      *pool.Pool
+	newClient func(creds []byte, hc *http.Client) (resourcegroup.GroupsClient, error)
+	record    *event.Recorder
 }
 
 // Connect returns a createsyncdeleter backed by the Azure API. Azure
 // credentials are read from the Crossplane Provider referenced by the supplied
-// Resource Group.
+// Resource Group. Clients are cached per provider.
 func (c *providerConnecter) Connect(ctx context.Context, r *v1alpha1.ResourceGroup) (createsyncdeleter, error) {
 	p := &v1alpha1.Provider{}
 	n := meta.NamespacedNameOf(r.Spec.ProviderReference)
@@ -153,21 +169,32 @@ func (c *providerConnecter) Connect(ctx context.Context, r *v1alpha1.ResourceGro
 		return nil, errors.Wrapf(err, "cannot get provider %s", n)
 	}
 
-	s := &corev1.Secret{}
-	n = types.NamespacedName{Namespace: p.Namespace, Name: p.Spec.Secret.Name}
-	if err := c.kube.Get(ctx, n, s); err != nil {
-		return nil, errors.Wrapf(err, "cannot get provider secret %s", n)
+	v, err := azure.ProviderVersion(ctx, c.kube, p)
+	if err != nil {
+		return nil, err
 	}
 
-	client, err := c.newClient(s.Data[p.Spec.Secret.Key])
-	return &azureResourceGroup{client: client}, errors.Wrap(err, "cannot create new Azure Resource Group client")
+	k := pool.Key{Provider: p.GetUID(), API: resourcegroup.API}
+	client, err := c.pool.Get(k, v, func() (interface{}, error) {
+		data, err := azure.ProviderCredentials(ctx, c.kube, p)
+		if err != nil {
+			return nil, err
+		}
+		client, err := c.newClient(data, c.pool.HTTPClient(k, pool.TooManyRequests, azure.Operation))
+		return client, errors.Wrap(err, "cannot create new Azure Resource Group client")
+	})
+	if err != nil {
+		return &azureResourceGroup{}, err
+	}
+	return &azureResourceGroup{client: client.(resourcegroup.GroupsClient), record: c.record}, nil
 }
 
 // Reconciler reconciles Resource Group read from the Kubernetes API
 // with an external store, typically the Azure API.
 type Reconciler struct {
 	connecter
-	kube client.Client
+	kube   client.Client
+	record *event.Recorder
 }
 
 // Controller is responsible for adding the ResourceGroup controller and its
@@ -176,15 +203,17 @@ type Controller struct{}
 
 // SetupWithManager creates a Controller that reconciles ResourceGroup resources.
 func (c *Controller) SetupWithManager(mgr ctrl.Manager) error {
+	record := event.NewRecorder(mgr.GetEventRecorderFor(controllerName), event.WithErrorCoder(azure.ErrorCode))
 	r := &Reconciler{
-		connecter: &providerConnecter{kube: mgr.GetClient(), newClient: resourcegroup.NewClient},
+		connecter: &providerConnecter{kube: mgr.GetClient(), pool: pool.Default, newClient: resourcegroup.NewClient, record: record},
 		kube:      mgr.GetClient(),
+		record:    record,
 	}
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(controllerName).
 		For(&v1alpha1.ResourceGroup{}).
-		Complete(r)
+		Complete(tracing.NewTracer(controllerName).Reconciler(pause.NewReconciler(mgr.GetClient(), &v1alpha1.ResourceGroup{}, r, pause.WithObjectUpdate())))
 }
 
 // Reconcile Azure Resource Group resources with the Azure API.
@@ -205,11 +234,17 @@ func (r *Reconciler) Reconcile(req reconcile.Request) (reconcile.Result, error)
 	client, err := r.Connect(ctx, rg)
 	if err != nil {
 		rg.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+		r.record.Failed(rg, err)
 		return reconcile.Result{Requeue: true}, errors.Wrapf(r.kube.Update(ctx, rg), "cannot update resource %s", req.NamespacedName)
 	}
 
 	// The resource has been deleted from the API server. Delete from Azure.
 	if rg.DeletionTimestamp != nil {
+		if err := protection.Check(rg); err != nil {
+			rg.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+			r.record.Failed(rg, err)
+			return reconcile.Result{Requeue: true}, errors.Wrapf(r.kube.Update(ctx, rg), "cannot update resource %s", req.NamespacedName)
+		}
 		return reconcile.Result{Requeue: client.Delete(ctx, rg)}, errors.Wrapf(r.kube.Update(ctx, rg), "cannot update resource %s", req.NamespacedName)
 	}
 
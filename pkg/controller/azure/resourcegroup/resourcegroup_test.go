@@ -386,7 +386,7 @@ func TestConnect(t *testing.T) {
 					}
 					return nil
 				}},
-				newClient: func(_ []byte) (resourcegroup.GroupsClient, error) {
+				newClient: func(_ []byte, _ *http.Client) (resourcegroup.GroupsClient, error) {
 					return &fakerg.MockClient{}, nil
 				},
 			},
@@ -399,7 +399,7 @@ func TestConnect(t *testing.T) {
 				kube: &test.MockClient{MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
 					return kerrors.NewNotFound(schema.GroupResource{}, providerName)
 				}},
-				newClient: func(_ []byte) (resourcegroup.GroupsClient, error) {
+				newClient: func(_ []byte, _ *http.Client) (resourcegroup.GroupsClient, error) {
 					return &fakerg.MockClient{}, nil
 				},
 			},
@@ -418,7 +418,7 @@ func TestConnect(t *testing.T) {
 					}
 					return nil
 				}},
-				newClient: func(_ []byte) (resourcegroup.GroupsClient, error) {
+				newClient: func(_ []byte, _ *http.Client) (resourcegroup.GroupsClient, error) {
 					return &fakerg.MockClient{}, nil
 				},
 			},
@@ -437,7 +437,7 @@ func TestConnect(t *testing.T) {
 					}
 					return nil
 				}},
-				newClient: func(_ []byte) (resourcegroup.GroupsClient, error) { return nil, errorBoom },
+				newClient: func(_ []byte, _ *http.Client) (resourcegroup.GroupsClient, error) { return nil, errorBoom },
 			},
 			i:       resource(),
 			want:    &azureResourceGroup{},
@@ -39,6 +39,11 @@ import (
 	azurev1alpha1 "github.com/crossplaneio/crossplane/azure/apis/v1alpha1"
 	"github.com/crossplaneio/crossplane/pkg/clients/azure"
 	azurestorage "github.com/crossplaneio/crossplane/pkg/clients/azure/storage"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
 )
 
 const (
@@ -72,15 +77,19 @@ type Controller struct{}
 // The Manager will set fields on the Controller and Start it when the Manager is Started.
 func (c *Controller) SetupWithManager(mgr ctrl.Manager) error {
 	r := &Reconciler{
-		Client:           mgr.GetClient(),
-		syncdeleterMaker: &accountSyncdeleterMaker{mgr.GetClient()},
+		Client: mgr.GetClient(),
+		syncdeleterMaker: &accountSyncdeleterMaker{
+			Client: mgr.GetClient(),
+			pool:   pool.Default,
+			record: event.NewRecorder(mgr.GetEventRecorderFor(controllerName), event.WithErrorCoder(azure.ErrorCode)),
+		},
 	}
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(controllerName).
 		For(&v1alpha1.Account{}).
 		Owns(&corev1.Secret{}).
-		Complete(r)
+		Complete(tracing.NewTracer(controllerName).Reconciler(pause.NewReconciler(mgr.GetClient(), &v1alpha1.Account{}, r)))
 }
 
 // Reconcile reads that state of the cluster for a Provider acct and makes changes based on the state read
@@ -107,6 +116,10 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 
 	// Check for deletion
 	if b.DeletionTimestamp != nil {
+		if err := protection.Check(b); err != nil {
+			b.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+			return resultRequeue, r.Status().Update(ctx, b)
+		}
 		return bh.delete(ctx)
 	}
 
@@ -119,6 +132,8 @@ type syncdeleterMaker interface {
 
 type accountSyncdeleterMaker struct {
 	client.Client
+	pool   *pool.Pool
+	record *event.Recorder
 }
 
 func (m *accountSyncdeleterMaker) newSyncdeleter(ctx context.Context, b *v1alpha1.Account) (syncdeleter, error) {
@@ -128,20 +143,27 @@ func (m *accountSyncdeleterMaker) newSyncdeleter(ctx context.Context, b *v1alpha
 		return nil, errors.Wrapf(err, "cannot get provider %s", n)
 	}
 
-	s := &corev1.Secret{}
-	n = types.NamespacedName{Namespace: p.GetNamespace(), Name: p.Spec.Secret.Name}
-	if err := m.Get(ctx, n, s); err != nil {
-		return nil, errors.Wrapf(err, "cannot get provider's secret %s", n)
+	v, err := azure.ProviderVersion(ctx, m.Client, p)
+	if err != nil {
+		return nil, err
 	}
 
-	storageClient, err := azurestorage.NewStorageAccountClient(s.Data[p.Spec.Secret.Key])
+	k := pool.Key{Provider: p.GetUID(), API: azurestorage.API}
+	storageClient, err := m.pool.Get(k, v, func() (interface{}, error) {
+		data, err := azure.ProviderCredentials(ctx, m.Client, p)
+		if err != nil {
+			return nil, err
+		}
+		storageClient, err := azurestorage.NewStorageAccountClient(data, m.pool.HTTPClient(k, pool.TooManyRequests, azure.Operation))
+		return storageClient, errors.Wrapf(err, "cannot create storageClient from json")
+	})
 	if err != nil {
-		return nil, errors.Wrapf(err, "cannot create storageClient from json")
+		return nil, err
 	}
 
 	return newAccountSyncDeleter(
-		azurestorage.NewAccountHandle(storageClient, b.Spec.ResourceGroupName, b.Spec.StorageAccountName),
-		m.Client, b), nil
+		azurestorage.NewAccountHandle(storageClient.(*storage.AccountsClient), b.Spec.ResourceGroupName, b.Spec.StorageAccountName),
+		m.Client, b, m.record), nil
 }
 
 type deleter interface {
@@ -176,16 +198,18 @@ type syncdeleter interface {
 type accountSyncDeleter struct {
 	createupdater
 	azurestorage.AccountOperations
-	kube client.Client
-	acct *v1alpha1.Account
+	kube   client.Client
+	acct   *v1alpha1.Account
+	record *event.Recorder
 }
 
-func newAccountSyncDeleter(ao azurestorage.AccountOperations, kube client.Client, b *v1alpha1.Account) *accountSyncDeleter {
+func newAccountSyncDeleter(ao azurestorage.AccountOperations, kube client.Client, b *v1alpha1.Account, r *event.Recorder) *accountSyncDeleter {
 	return &accountSyncDeleter{
-		createupdater:     newAccountCreateUpdater(ao, kube, b),
+		createupdater:     newAccountCreateUpdater(ao, kube, b, r),
 		AccountOperations: ao,
 		kube:              kube,
 		acct:              b,
+		record:            r,
 	}
 }
 
@@ -194,8 +218,10 @@ func (asd *accountSyncDeleter) delete(ctx context.Context) (reconcile.Result, er
 	if asd.acct.Spec.ReclaimPolicy == runtimev1alpha1.ReclaimDelete {
 		if err := asd.Delete(ctx); err != nil && !azure.IsNotFound(err) {
 			asd.acct.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+			asd.record.Failed(asd.acct, err)
 			return resultRequeue, asd.kube.Status().Update(ctx, asd.acct)
 		}
+		asd.record.Normal(asd.acct, event.ReasonDeletionStarted, "Requested deletion of external resource")
 	}
 
 	// NOTE(negz): We don't update the conditioned status here because assuming
@@ -213,6 +239,7 @@ func (asd *accountSyncDeleter) sync(ctx context.Context) (reconcile.Result, erro
 	account, err := asd.Get(ctx)
 	if err != nil && !azure.IsNotFound(err) {
 		asd.acct.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+		asd.record.Failed(asd.acct, err)
 		return resultRequeue, asd.kube.Status().Update(ctx, asd.acct)
 	}
 
@@ -224,6 +251,7 @@ func (asd *accountSyncDeleter) sync(ctx context.Context) (reconcile.Result, erro
 	if uid := to.String(account.Tags[uidTag]); uid != "" && uid != string(asd.acct.GetUID()) {
 		err := errors.Errorf("storage account: %s already exists and owned by: %s", to.String(account.Name), uid)
 		asd.acct.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+		asd.record.Failed(asd.acct, err)
 		return reconcile.Result{}, asd.kube.Status().Update(ctx, asd.acct)
 	}
 
@@ -243,15 +271,17 @@ type accountCreateUpdater struct {
 	kube      client.Client
 	acct      *v1alpha1.Account
 	projectID string
+	record    *event.Recorder
 }
 
 // newAccountCreateUpdater new instance of accountCreateUpdater
-func newAccountCreateUpdater(ao azurestorage.AccountOperations, kube client.Client, acct *v1alpha1.Account) *accountCreateUpdater {
+func newAccountCreateUpdater(ao azurestorage.AccountOperations, kube client.Client, acct *v1alpha1.Account, r *event.Recorder) *accountCreateUpdater {
 	return &accountCreateUpdater{
 		syncbacker:        newAccountSyncBacker(ao, kube, acct),
 		AccountOperations: ao,
 		kube:              kube,
 		acct:              acct,
+		record:            r,
 	}
 }
 
@@ -272,8 +302,10 @@ func (acu *accountCreateUpdater) create(ctx context.Context) (reconcile.Result,
 	a, err := acu.Create(ctx, accountSpec)
 	if err != nil {
 		acu.acct.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+		acu.record.Failed(acu.acct, err)
 		return resultRequeue, acu.kube.Status().Update(ctx, acu.acct)
 	}
+	acu.record.Normal(acu.acct, event.ReasonCreateRequested, "Requested creation of external resource")
 
 	return acu.syncback(ctx, a)
 }
@@ -281,6 +313,9 @@ func (acu *accountCreateUpdater) create(ctx context.Context) (reconcile.Result,
 // update storage account resource if needed
 func (acu *accountCreateUpdater) update(ctx context.Context, account *storage.Account) (reconcile.Result, error) {
 	if account.ProvisioningState == storage.Succeeded {
+		if !event.Ready(acu.acct) {
+			acu.record.Normal(acu.acct, event.ReasonBecameReady, "External resource became ready")
+		}
 		acu.acct.Status.SetConditions(runtimev1alpha1.Available())
 		resource.SetBindable(acu.acct)
 
@@ -293,8 +328,10 @@ func (acu *accountCreateUpdater) update(ctx context.Context, account *storage.Ac
 		a, err := acu.Update(ctx, v1alpha1.ToStorageAccountUpdate(acu.acct.Spec.StorageAccountSpec))
 		if err != nil {
 			acu.acct.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+			acu.record.Failed(acu.acct, err)
 			return resultRequeue, acu.kube.Status().Update(ctx, acu.acct)
 		}
+		acu.record.Normal(acu.acct, event.ReasonUpdateApplied, "Applied update to external resource")
 		account = a
 	}
 
@@ -368,7 +368,7 @@ func Test_accountHandleMaker_newHandler(t *testing.T) {
 				withSecret(secretName, secretKey).Provider),
 			acct: v1alpha1test.NewMockAccount(ns, bucketName).WithSpecProvider(ns, providerName).Account,
 			wantErr: errors.WithStack(
-				errors.Errorf("cannot get provider's secret %s/%s: secrets \"%s\" not found", ns, secretName, secretName)),
+				errors.Errorf("cannot get provider secret %s/%s: secrets \"%s\" not found", ns, secretName, secretName)),
 		},
 		{
 			name: "InvalidCredentials",
@@ -385,7 +385,7 @@ func Test_accountHandleMaker_newHandler(t *testing.T) {
 				withSecret(secretName, secretKey).Provider,
 				newSecret(ns, secretName).withKeyData(secretKey, secretData).Secret),
 			acct: v1alpha1test.NewMockAccount(ns, bucketName).WithSpecProvider(ns, providerName).Account,
-			want: newAccountSyncDeleter(&azurestorage.AccountHandle{}, nil, nil),
+			want: newAccountSyncDeleter(&azurestorage.AccountHandle{}, nil, nil, nil),
 		},
 	}
 	for _, tt := range tests {
@@ -528,7 +528,7 @@ func Test_syncdeleter_delete(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			bh := newAccountSyncDeleter(tt.fields.ao, tt.fields.cc, tt.fields.acct)
+			bh := newAccountSyncDeleter(tt.fields.ao, tt.fields.cc, tt.fields.acct, nil)
 			got, err := bh.delete(ctx)
 			if diff := cmp.Diff(tt.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("accountSyncDeleter.delete(): -want error, +got error: \n%s", diff)
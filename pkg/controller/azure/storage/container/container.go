@@ -38,6 +38,10 @@ import (
 	"github.com/crossplaneio/crossplane/azure/apis/storage/v1alpha1"
 	"github.com/crossplaneio/crossplane/pkg/clients/azure"
 	"github.com/crossplaneio/crossplane/pkg/clients/azure/storage"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
 )
 
 const (
@@ -69,14 +73,17 @@ type Controller struct{}
 // The Manager will set fields on the Controller and Start it when the Manager is Started.
 func (c *Controller) SetupWithManager(mgr ctrl.Manager) error {
 	r := &Reconciler{
-		Client:           mgr.GetClient(),
-		syncdeleterMaker: &containerSyncdeleterMaker{mgr.GetClient()},
+		Client: mgr.GetClient(),
+		syncdeleterMaker: &containerSyncdeleterMaker{
+			Client: mgr.GetClient(),
+			record: event.NewRecorder(mgr.GetEventRecorderFor(controllerName), event.WithErrorCoder(azure.ErrorCode)),
+		},
 	}
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(controllerName).
 		For(&v1alpha1.Container{}).
-		Complete(r)
+		Complete(tracing.NewTracer(controllerName).Reconciler(pause.NewReconciler(mgr.GetClient(), &v1alpha1.Container{}, r)))
 }
 
 // Reconcile reads that state of the cluster for a Provider acct and makes changes based on the state read
@@ -103,6 +110,10 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 
 	// Check for deletion
 	if c.DeletionTimestamp != nil {
+		if err := protection.Check(c); err != nil {
+			c.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+			return resultRequeue, r.Status().Update(ctx, c)
+		}
 		return sd.delete(ctx)
 	}
 
@@ -115,6 +126,7 @@ type syncdeleterMaker interface {
 
 type containerSyncdeleterMaker struct {
 	client.Client
+	record *event.Recorder
 }
 
 func (m *containerSyncdeleterMaker) newSyncdeleter(ctx context.Context, c *v1alpha1.Container) (syncdeleter, error) {
@@ -160,10 +172,12 @@ func (m *containerSyncdeleterMaker) newSyncdeleter(ctx context.Context, c *v1alp
 			ContainerOperations: ch,
 			kube:                m.Client,
 			container:           c,
+			record:              m.record,
 		},
 		ContainerOperations: ch,
 		kube:                m.Client,
 		container:           c,
+		record:              m.record,
 	}, nil
 }
 
@@ -193,6 +207,7 @@ type containerSyncdeleter struct {
 	storage.ContainerOperations
 	kube      client.Client
 	container *v1alpha1.Container
+	record    *event.Recorder
 }
 
 func (csd *containerSyncdeleter) delete(ctx context.Context) (reconcile.Result, error) {
@@ -200,8 +215,10 @@ func (csd *containerSyncdeleter) delete(ctx context.Context) (reconcile.Result,
 	if csd.container.Spec.ReclaimPolicy == runtimev1alpha1.ReclaimDelete {
 		if err := csd.Delete(ctx); err != nil && !azure.IsNotFound(err) {
 			csd.container.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+			csd.record.Failed(csd.container, err)
 			return resultRequeue, csd.kube.Status().Update(ctx, csd.container)
 		}
+		csd.record.Normal(csd.container, event.ReasonDeletionStarted, "Requested deletion of external resource")
 	}
 
 	// NOTE(negz): We don't update the conditioned status here because assuming
@@ -215,6 +232,7 @@ func (csd *containerSyncdeleter) sync(ctx context.Context) (reconcile.Result, er
 	access, meta, err := csd.Get(ctx)
 	if err != nil && !storage.IsNotFoundError(err) {
 		csd.container.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+		csd.record.Failed(csd.container, err)
 		return resultRequeue, csd.kube.Status().Update(ctx, csd.container)
 	}
 
@@ -235,6 +253,7 @@ type containerCreateUpdater struct {
 	storage.ContainerOperations
 	kube      client.Client
 	container *v1alpha1.Container
+	record    *event.Recorder
 }
 
 var _ createupdater = &containerCreateUpdater{}
@@ -251,8 +270,13 @@ func (ccu *containerCreateUpdater) create(ctx context.Context) (reconcile.Result
 	spec := container.Spec
 	if err := ccu.Create(ctx, spec.PublicAccessType, spec.Metadata); err != nil {
 		container.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+		ccu.record.Failed(container, err)
 		return resultRequeue, ccu.kube.Status().Update(ctx, container)
 	}
+	ccu.record.Normal(container, event.ReasonCreateRequested, "Requested creation of external resource")
+	if !event.Ready(container) {
+		ccu.record.Normal(container, event.ReasonBecameReady, "External resource became ready")
+	}
 
 	container.Status.SetConditions(runtimev1alpha1.Available(), runtimev1alpha1.ReconcileSuccess())
 	resource.SetBindable(container)
@@ -266,8 +290,14 @@ func (ccu *containerCreateUpdater) update(ctx context.Context, accessType *azblo
 	if !reflect.DeepEqual(*accessType, spec.PublicAccessType) || !reflect.DeepEqual(meta, spec.Metadata) {
 		if err := ccu.Update(ctx, spec.PublicAccessType, spec.Metadata); err != nil {
 			container.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+			ccu.record.Failed(container, err)
 			return resultRequeue, ccu.kube.Status().Update(ctx, container)
 		}
+		ccu.record.Normal(container, event.ReasonUpdateApplied, "Applied update to external resource")
+	}
+
+	if !event.Ready(container) {
+		ccu.record.Normal(container, event.ReasonBecameReady, "External resource became ready")
 	}
 
 	container.Status.SetConditions(runtimev1alpha1.Available(), runtimev1alpha1.ReconcileSuccess())
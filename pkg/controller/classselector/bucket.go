@@ -0,0 +1,57 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package classselector
+
+import (
+	"fmt"
+	"strings"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	storagev1alpha1 "github.com/crossplaneio/crossplane/apis/storage/v1alpha1"
+	awsstoragev1alpha1 "github.com/crossplaneio/crossplane/aws/apis/storage/v1alpha1"
+	azurestoragev1alpha1 "github.com/crossplaneio/crossplane/azure/apis/storage/v1alpha1"
+	gcpstoragev1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/storage/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
+)
+
+// BucketController is responsible for adding the class selector controller
+// for Bucket and its corresponding reconciler to the manager with any runtime configuration.
+type BucketController struct{}
+
+// SetupWithManager adds a class selector controller that reconciles claims
+// of kind Bucket to a resource class that matches their class selector.
+func (c *BucketController) SetupWithManager(mgr ctrl.Manager) error {
+	r := NewReconciler(mgr,
+		resource.ClaimKind(storagev1alpha1.BucketGroupVersionKind),
+		resource.ClassKind(awsstoragev1alpha1.S3BucketClassGroupVersionKind),
+		resource.ClassKind(azurestoragev1alpha1.AccountClassGroupVersionKind),
+		resource.ClassKind(azurestoragev1alpha1.ContainerClassGroupVersionKind),
+		resource.ClassKind(gcpstoragev1alpha1.BucketClassGroupVersionKind),
+	)
+
+	name := strings.ToLower(fmt.Sprintf("%s.%s", storagev1alpha1.BucketKind, controllerBaseName))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&storagev1alpha1.Bucket{}).
+		WithEventFilter(resource.NewPredicates(resource.NoClassReference())).
+		WithEventFilter(resource.NewPredicates(HasClassSelector())).
+		Complete(tracing.NewTracer(name).Reconciler(pause.NewReconciler(mgr.GetClient(), &storagev1alpha1.Bucket{}, r)))
+}
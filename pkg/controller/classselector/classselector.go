@@ -0,0 +1,309 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package classselector contains controllers that select a resource class for
+// resource claims by label.
+package classselector
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/logging"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+)
+
+const (
+	controllerBaseName = "classselector.crossplane.io"
+
+	reconcileTimeout = 1 * time.Minute
+	aShortWait       = 30 * time.Second
+)
+
+var log = logging.Logger.WithName("controller." + controllerBaseName)
+
+// AnnotationKeyClassWeight is the key of an optional resource class
+// annotation that weights the class during selection. Classes with a higher
+// integer weight are preferred. Classes without a valid weight have weight 0.
+const AnnotationKeyClassWeight = "resourceclass.crossplane.io/weight"
+
+// Error strings.
+const (
+	errGetClaim          = "cannot get resource claim"
+	errUpdateClaim       = "cannot update resource claim"
+	errUpdateClaimStatus = "cannot update resource claim status"
+	errParseSelector     = "cannot parse class selector"
+	errListClasses       = "cannot list resource classes"
+)
+
+// TypeClassSelected resource claims have selected a resource class using
+// their class selector.
+const TypeClassSelected runtimev1alpha1.ConditionType = "ClassSelected"
+
+// Reasons a resource claim has or has not selected a resource class.
+const (
+	ReasonClassSelected           runtimev1alpha1.ConditionReason = "Selected a resource class matching the class selector"
+	ReasonClassSelectionAmbiguous runtimev1alpha1.ConditionReason = "Selected one of several equally weighted resource classes matching the class selector"
+	ReasonNoMatchingClass         runtimev1alpha1.ConditionReason = "No resource class matches the class selector"
+)
+
+// ClassSelected returns a condition that indicates a resource claim selected
+// the referenced resource class.
+func ClassSelected(ref *corev1.ObjectReference) runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypeClassSelected,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonClassSelected,
+		Message:            fmt.Sprintf("selected %s %s/%s", ref.Kind, ref.Namespace, ref.Name),
+	}
+}
+
+// ClassSelectionAmbiguous returns a condition that indicates a resource claim
+// selected the referenced resource class from the supplied number of equally
+// weighted matching classes.
+func ClassSelectionAmbiguous(ref *corev1.ObjectReference, matches int) runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypeClassSelected,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonClassSelectionAmbiguous,
+		Message:            fmt.Sprintf("selected %s %s/%s, the first by namespace and name of %d equally weighted classes", ref.Kind, ref.Namespace, ref.Name, matches),
+	}
+}
+
+// NoMatchingClass returns a condition that indicates no resource class
+// matches the class selector of a resource claim.
+func NoMatchingClass() runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypeClassSelected,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNoMatchingClass,
+	}
+}
+
+// A SelectorClaim is a resource claim that may select its resource class by
+// label.
+type SelectorClaim interface {
+	resource.Claim
+
+	GetClassSelector() *metav1.LabelSelector
+}
+
+// HasClassSelector accepts resource claims that have a class selector.
+func HasClassSelector() resource.PredicateFn {
+	return func(obj runtime.Object) bool {
+		c, ok := obj.(SelectorClaim)
+		return ok && c.GetClassSelector() != nil
+	}
+}
+
+// NoClassSelector accepts resource claims that do not have a class selector.
+func NoClassSelector() resource.PredicateFn {
+	return func(obj runtime.Object) bool {
+		return !HasClassSelector()(obj)
+	}
+}
+
+// A candidate resource class that matches a class selector.
+type candidate struct {
+	ref    *corev1.ObjectReference
+	weight int
+}
+
+// weight returns the class weight of the supplied annotations.
+func weight(annotations map[string]string) int {
+	w, err := strconv.Atoi(annotations[AnnotationKeyClassWeight])
+	if err != nil {
+		return 0
+	}
+	return w
+}
+
+// selectClass returns the candidate with the highest weight, breaking ties by
+// namespace then name. It also returns the number of candidates that share
+// the highest weight.
+func selectClass(candidates []candidate) (candidate, int) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.weight != b.weight {
+			return a.weight > b.weight
+		}
+		if a.ref.Namespace != b.ref.Namespace {
+			return a.ref.Namespace < b.ref.Namespace
+		}
+		if a.ref.Name != b.ref.Name {
+			return a.ref.Name < b.ref.Name
+		}
+		return a.ref.Kind < b.ref.Kind
+	})
+
+	ties := 0
+	for _, c := range candidates {
+		if c.weight != candidates[0].weight {
+			break
+		}
+		ties++
+	}
+	return candidates[0], ties
+}
+
+// A Reconciler selects a resource class for resource claims of one kind from
+// the resource classes of several kinds, using the claim's class selector.
+type Reconciler struct {
+	client     client.Client
+	newClaim   func() SelectorClaim
+	classKinds []schema.GroupVersionKind
+}
+
+// NewReconciler returns a Reconciler that selects a resource class of one of
+// the supplied kinds for resource claims of the supplied kind. Class kinds that
+// are not registered with the manager's scheme are never selected; their
+// cloud provider is not enabled and their CRDs may not be installed.
+func NewReconciler(m ctrl.Manager, of resource.ClaimKind, classes ...resource.ClassKind) *Reconciler {
+	nc := func() SelectorClaim {
+		o, err := m.GetScheme().New(schema.GroupVersionKind(of))
+		if err != nil {
+			// The claim kind is not registered with the manager's scheme,
+			// which is a programming error.
+			panic(err)
+		}
+		return o.(SelectorClaim)
+	}
+
+	kinds := make([]schema.GroupVersionKind, 0, len(classes))
+	for _, c := range classes {
+		if !m.GetScheme().Recognizes(schema.GroupVersionKind(c)) {
+			continue
+		}
+		kinds = append(kinds, schema.GroupVersionKind(c))
+	}
+
+	return &Reconciler{client: m.GetClient(), newClaim: nc, classKinds: kinds}
+}
+
+// Reconcile a resource claim with a class selector by referencing the most
+// heavily weighted resource class that matches the selector.
+func (r *Reconciler) Reconcile(req reconcile.Request) (reconcile.Result, error) {
+	log.V(logging.Debug).Info("reconciling", "controller", controllerBaseName, "request", req)
+
+	ctx, cancel := context.WithTimeout(context.Background(), reconcileTimeout)
+	defer cancel()
+
+	claim := r.newClaim()
+	if err := r.client.Get(ctx, req.NamespacedName, claim); err != nil {
+		if kerrors.IsNotFound(err) {
+			return reconcile.Result{Requeue: false}, nil
+		}
+		return reconcile.Result{Requeue: false}, errors.Wrap(err, errGetClaim)
+	}
+
+	// Someone already chose a class for this claim, or it does not want us
+	// to choose one.
+	if claim.GetClassReference() != nil || claim.GetClassSelector() == nil {
+		return reconcile.Result{Requeue: false}, nil
+	}
+
+	sel, err := metav1.LabelSelectorAsSelector(claim.GetClassSelector())
+	if err != nil {
+		// An invalid selector will not become valid until the claim is
+		// updated, so we don't requeue.
+		claim.SetConditions(runtimev1alpha1.ReconcileError(errors.Wrap(err, errParseSelector)))
+		return reconcile.Result{Requeue: false}, errors.Wrap(r.client.Status().Update(ctx, claim), errUpdateClaimStatus)
+	}
+
+	candidates := []candidate{}
+	for _, k := range r.classKinds {
+		l := &unstructured.UnstructuredList{}
+		l.SetGroupVersionKind(k.GroupVersion().WithKind(k.Kind + "List"))
+		if err := r.client.List(ctx, l); err != nil {
+			claim.SetConditions(runtimev1alpha1.ReconcileError(errors.Wrap(err, errListClasses)))
+			return reconcile.Result{RequeueAfter: aShortWait}, errors.Wrap(r.client.Status().Update(ctx, claim), errUpdateClaimStatus)
+		}
+		for i := range l.Items {
+			c := &l.Items[i]
+			if !sel.Matches(labels.Set(c.GetLabels())) {
+				continue
+			}
+			candidates = append(candidates, candidate{ref: meta.ReferenceTo(c, k), weight: weight(c.GetAnnotations())})
+		}
+	}
+
+	if len(candidates) == 0 {
+		// A matching class may be created later.
+		claim.SetConditions(NoMatchingClass())
+		return reconcile.Result{RequeueAfter: aShortWait}, errors.Wrap(r.client.Status().Update(ctx, claim), errUpdateClaimStatus)
+	}
+
+	selected, ties := selectClass(candidates)
+	claim.SetClassReference(selected.ref)
+	if err := r.client.Update(ctx, claim); err != nil {
+		return reconcile.Result{RequeueAfter: aShortWait}, errors.Wrap(err, errUpdateClaim)
+	}
+
+	c := ClassSelected(selected.ref)
+	if ties > 1 {
+		c = ClassSelectionAmbiguous(selected.ref, ties)
+	}
+	claim.SetConditions(c)
+	return reconcile.Result{Requeue: false}, errors.Wrap(r.client.Status().Update(ctx, claim), errUpdateClaimStatus)
+}
+
+// Controllers passes down config and adds individual controllers to the manager.
+type Controllers struct{}
+
+// SetupWithManager adds all class selector controllers to the manager.
+func (c *Controllers) SetupWithManager(mgr ctrl.Manager) error {
+	if err := (&BucketController{}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&KubernetesClusterController{}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&MySQLInstanceController{}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&PostgreSQLInstanceController{}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&RedisClusterController{}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,253 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package classselector
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	databasev1alpha1 "github.com/crossplaneio/crossplane/apis/database/v1alpha1"
+)
+
+const (
+	namespace      = "cool-namespace"
+	claimName      = "cool-claim"
+	classNamespace = "crossplane-system"
+)
+
+var (
+	errBoom = errors.New("boom")
+
+	classKindA = schema.GroupVersionKind{Group: "a.example.org", Version: "v1alpha1", Kind: "CoolClass"}
+	classKindB = schema.GroupVersionKind{Group: "b.example.org", Version: "v1alpha1", Kind: "CoolerClass"}
+
+	production = map[string]string{"tier": "production"}
+)
+
+type claimModifier func(*databasev1alpha1.MySQLInstance)
+
+func withClassSelector(s *metav1.LabelSelector) claimModifier {
+	return func(c *databasev1alpha1.MySQLInstance) { c.Spec.ClassSelector = s }
+}
+
+func withClassReference(r *corev1.ObjectReference) claimModifier {
+	return func(c *databasev1alpha1.MySQLInstance) { c.Spec.ClassReference = r }
+}
+
+func claim(m ...claimModifier) *databasev1alpha1.MySQLInstance {
+	c := &databasev1alpha1.MySQLInstance{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: claimName}}
+	for _, fn := range m {
+		fn(c)
+	}
+	return c
+}
+
+func class(gvk schema.GroupVersionKind, name string, l map[string]string, weight string) unstructured.Unstructured {
+	u := unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	u.SetNamespace(classNamespace)
+	u.SetName(name)
+	u.SetLabels(l)
+	if weight != "" {
+		u.SetAnnotations(map[string]string{AnnotationKeyClassWeight: weight})
+	}
+	return u
+}
+
+func ref(gvk schema.GroupVersionKind, name string) *corev1.ObjectReference {
+	return &corev1.ObjectReference{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Namespace:  classNamespace,
+		Name:       name,
+	}
+}
+
+// mockList returns a MockList function that lists the supplied classes of
+// each kind.
+func mockList(classes map[schema.GroupVersionKind][]unstructured.Unstructured) func(context.Context, runtime.Object, ...client.ListOption) error {
+	return func(_ context.Context, list runtime.Object, _ ...client.ListOption) error {
+		l := list.(*unstructured.UnstructuredList)
+		gvk := l.GroupVersionKind()
+		l.Items = classes[gvk.GroupVersion().WithKind(strings.TrimSuffix(gvk.Kind, "List"))]
+		return nil
+	}
+}
+
+func TestSelectClass(t *testing.T) {
+	type want struct {
+		selected candidate
+		ties     int
+	}
+
+	cases := map[string]struct {
+		candidates []candidate
+		want       want
+	}{
+		"HighestWeight": {
+			candidates: []candidate{
+				{ref: ref(classKindA, "a"), weight: 1},
+				{ref: ref(classKindB, "b"), weight: 10},
+			},
+			want: want{selected: candidate{ref: ref(classKindB, "b"), weight: 10}, ties: 1},
+		},
+		"TieBrokenByName": {
+			candidates: []candidate{
+				{ref: ref(classKindB, "b")},
+				{ref: ref(classKindA, "a")},
+				{ref: ref(classKindA, "c"), weight: -1},
+			},
+			want: want{selected: candidate{ref: ref(classKindA, "a")}, ties: 2},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			selected, ties := selectClass(tc.candidates)
+			if diff := cmp.Diff(tc.want.selected, selected, cmp.AllowUnexported(candidate{})); diff != "" {
+				t.Errorf("selectClass(...): -want selected, +got selected:\n%s", diff)
+			}
+			if ties != tc.want.ties {
+				t.Errorf("selectClass(...): want %d ties, got %d", tc.want.ties, ties)
+			}
+		})
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	type want struct {
+		result    reconcile.Result
+		err       error
+		ref       *corev1.ObjectReference
+		condition *runtimev1alpha1.Condition
+	}
+
+	selector := &metav1.LabelSelector{MatchLabels: production}
+	selected := ClassSelected(ref(classKindB, "b"))
+	ambiguous := ClassSelectionAmbiguous(ref(classKindA, "a"), 2)
+	noMatch := NoMatchingClass()
+	listFailed := runtimev1alpha1.ReconcileError(errors.Wrap(errBoom, errListClasses))
+
+	cases := map[string]struct {
+		claim   *databasev1alpha1.MySQLInstance
+		classes map[schema.GroupVersionKind][]unstructured.Unstructured
+		listErr error
+		want    want
+	}{
+		"AlreadyHasClass": {
+			claim: claim(withClassSelector(selector), withClassReference(ref(classKindA, "a"))),
+			want:  want{ref: ref(classKindA, "a")},
+		},
+		"NoSelector": {
+			claim: claim(),
+		},
+		"Selected": {
+			claim: claim(withClassSelector(selector)),
+			classes: map[schema.GroupVersionKind][]unstructured.Unstructured{
+				classKindA: {
+					class(classKindA, "a", production, "1"),
+					class(classKindA, "unlabelled", nil, "100"),
+				},
+				classKindB: {class(classKindB, "b", production, "10")},
+			},
+			want: want{ref: ref(classKindB, "b"), condition: &selected},
+		},
+		"Ambiguous": {
+			claim: claim(withClassSelector(selector)),
+			classes: map[schema.GroupVersionKind][]unstructured.Unstructured{
+				classKindA: {class(classKindA, "a", production, "")},
+				classKindB: {class(classKindB, "b", production, "not-a-number")},
+			},
+			want: want{ref: ref(classKindA, "a"), condition: &ambiguous},
+		},
+		"NoMatchingClass": {
+			claim: claim(withClassSelector(selector)),
+			classes: map[schema.GroupVersionKind][]unstructured.Unstructured{
+				classKindA: {class(classKindA, "a", map[string]string{"tier": "dev"}, "")},
+			},
+			want: want{result: reconcile.Result{RequeueAfter: aShortWait}, condition: &noMatch},
+		},
+		"ListFailed": {
+			claim:   claim(withClassSelector(selector)),
+			listErr: errBoom,
+			want:    want{result: reconcile.Result{RequeueAfter: aShortWait}, condition: &listFailed},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := tc.claim.DeepCopy()
+			list := mockList(tc.classes)
+			if tc.listErr != nil {
+				list = func(_ context.Context, _ runtime.Object, _ ...client.ListOption) error { return tc.listErr }
+			}
+
+			r := &Reconciler{
+				client: &test.MockClient{
+					MockGet: func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+						tc.claim.DeepCopyInto(obj.(*databasev1alpha1.MySQLInstance))
+						return nil
+					},
+					MockList: list,
+					MockUpdate: func(_ context.Context, obj runtime.Object, _ ...client.UpdateOption) error {
+						obj.(*databasev1alpha1.MySQLInstance).DeepCopyInto(got)
+						return nil
+					},
+					MockStatusUpdate: func(_ context.Context, obj runtime.Object, _ ...client.UpdateOption) error {
+						obj.(*databasev1alpha1.MySQLInstance).DeepCopyInto(got)
+						return nil
+					},
+				},
+				newClaim:   func() SelectorClaim { return &databasev1alpha1.MySQLInstance{} },
+				classKinds: []schema.GroupVersionKind{classKindA, classKindB},
+			}
+
+			result, err := r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: claimName}})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r.Reconcile(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, result); diff != "" {
+				t.Errorf("r.Reconcile(...): -want result, +got result:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.ref, got.GetClassReference()); diff != "" {
+				t.Errorf("r.Reconcile(...): -want class reference, +got class reference:\n%s", diff)
+			}
+
+			var c *runtimev1alpha1.Condition
+			if len(got.Status.Conditions) > 0 {
+				c = &got.Status.Conditions[0]
+			}
+			if diff := cmp.Diff(tc.want.condition, c, test.EquateConditions()); diff != "" {
+				t.Errorf("r.Reconcile(...): -want condition, +got condition:\n%s", diff)
+			}
+		})
+	}
+}
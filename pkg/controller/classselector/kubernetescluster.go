@@ -0,0 +1,56 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package classselector
+
+import (
+	"fmt"
+	"strings"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	computev1alpha1 "github.com/crossplaneio/crossplane/apis/compute/v1alpha1"
+	awscomputev1alpha1 "github.com/crossplaneio/crossplane/aws/apis/compute/v1alpha1"
+	azurecomputev1alpha1 "github.com/crossplaneio/crossplane/azure/apis/compute/v1alpha1"
+	gcpcomputev1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/compute/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
+)
+
+// KubernetesClusterController is responsible for adding the class selector controller
+// for KubernetesCluster and its corresponding reconciler to the manager with any runtime configuration.
+type KubernetesClusterController struct{}
+
+// SetupWithManager adds a class selector controller that reconciles claims
+// of kind KubernetesCluster to a resource class that matches their class selector.
+func (c *KubernetesClusterController) SetupWithManager(mgr ctrl.Manager) error {
+	r := NewReconciler(mgr,
+		resource.ClaimKind(computev1alpha1.KubernetesClusterGroupVersionKind),
+		resource.ClassKind(awscomputev1alpha1.EKSClusterClassGroupVersionKind),
+		resource.ClassKind(azurecomputev1alpha1.AKSClusterClassGroupVersionKind),
+		resource.ClassKind(gcpcomputev1alpha1.GKEClusterClassGroupVersionKind),
+	)
+
+	name := strings.ToLower(fmt.Sprintf("%s.%s", computev1alpha1.KubernetesClusterKind, controllerBaseName))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&computev1alpha1.KubernetesCluster{}).
+		WithEventFilter(resource.NewPredicates(resource.NoClassReference())).
+		WithEventFilter(resource.NewPredicates(HasClassSelector())).
+		Complete(tracing.NewTracer(name).Reconciler(pause.NewReconciler(mgr.GetClient(), &computev1alpha1.KubernetesCluster{}, r)))
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package classselector
+
+import (
+	"fmt"
+	"strings"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	databasev1alpha1 "github.com/crossplaneio/crossplane/apis/database/v1alpha1"
+	awsdatabasev1alpha1 "github.com/crossplaneio/crossplane/aws/apis/database/v1alpha1"
+	azuredatabasev1alpha1 "github.com/crossplaneio/crossplane/azure/apis/database/v1alpha1"
+	gcpdatabasev1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/database/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
+)
+
+// MySQLInstanceController is responsible for adding the class selector controller
+// for MySQLInstance and its corresponding reconciler to the manager with any runtime configuration.
+type MySQLInstanceController struct{}
+
+// SetupWithManager adds a class selector controller that reconciles claims
+// of kind MySQLInstance to a resource class that matches their class selector.
+func (c *MySQLInstanceController) SetupWithManager(mgr ctrl.Manager) error {
+	r := NewReconciler(mgr,
+		resource.ClaimKind(databasev1alpha1.MySQLInstanceGroupVersionKind),
+		resource.ClassKind(awsdatabasev1alpha1.RDSInstanceClassGroupVersionKind),
+		resource.ClassKind(awsdatabasev1alpha1.RDSDatabaseClassGroupVersionKind),
+		resource.ClassKind(azuredatabasev1alpha1.SQLServerClassGroupVersionKind),
+		resource.ClassKind(azuredatabasev1alpha1.SQLServerDatabaseClassGroupVersionKind),
+		resource.ClassKind(gcpdatabasev1alpha1.CloudsqlInstanceClassGroupVersionKind),
+		resource.ClassKind(gcpdatabasev1alpha1.CloudsqlDatabaseClassGroupVersionKind),
+	)
+
+	name := strings.ToLower(fmt.Sprintf("%s.%s", databasev1alpha1.MySQLInstanceKind, controllerBaseName))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&databasev1alpha1.MySQLInstance{}).
+		WithEventFilter(resource.NewPredicates(resource.NoClassReference())).
+		WithEventFilter(resource.NewPredicates(HasClassSelector())).
+		Complete(tracing.NewTracer(name).Reconciler(pause.NewReconciler(mgr.GetClient(), &databasev1alpha1.MySQLInstance{}, r)))
+}
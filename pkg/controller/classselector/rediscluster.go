@@ -0,0 +1,56 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package classselector
+
+import (
+	"fmt"
+	"strings"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	cachev1alpha1 "github.com/crossplaneio/crossplane/apis/cache/v1alpha1"
+	awscachev1alpha1 "github.com/crossplaneio/crossplane/aws/apis/cache/v1alpha1"
+	azurecachev1alpha1 "github.com/crossplaneio/crossplane/azure/apis/cache/v1alpha1"
+	gcpcachev1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/cache/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
+)
+
+// RedisClusterController is responsible for adding the class selector controller
+// for RedisCluster and its corresponding reconciler to the manager with any runtime configuration.
+type RedisClusterController struct{}
+
+// SetupWithManager adds a class selector controller that reconciles claims
+// of kind RedisCluster to a resource class that matches their class selector.
+func (c *RedisClusterController) SetupWithManager(mgr ctrl.Manager) error {
+	r := NewReconciler(mgr,
+		resource.ClaimKind(cachev1alpha1.RedisClusterGroupVersionKind),
+		resource.ClassKind(awscachev1alpha1.ReplicationGroupClassGroupVersionKind),
+		resource.ClassKind(azurecachev1alpha1.RedisClassGroupVersionKind),
+		resource.ClassKind(gcpcachev1alpha1.CloudMemorystoreInstanceClassGroupVersionKind),
+	)
+
+	name := strings.ToLower(fmt.Sprintf("%s.%s", cachev1alpha1.RedisClusterKind, controllerBaseName))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&cachev1alpha1.RedisCluster{}).
+		WithEventFilter(resource.NewPredicates(resource.NoClassReference())).
+		WithEventFilter(resource.NewPredicates(HasClassSelector())).
+		Complete(tracing.NewTracer(name).Reconciler(pause.NewReconciler(mgr.GetClient(), &cachev1alpha1.RedisCluster{}, r)))
+}
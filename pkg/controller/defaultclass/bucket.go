@@ -24,6 +24,9 @@ import (
 
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
 	storagev1alpha1 "github.com/crossplaneio/crossplane/apis/storage/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/controller/classselector"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
 )
 
 // BucketController is responsible for adding the default class controller
@@ -46,5 +49,6 @@ func (c *BucketController) SetupWithManager(mgr ctrl.Manager) error {
 		For(&storagev1alpha1.Bucket{}).
 		WithEventFilter(resource.NewPredicates(resource.NoClassReference())).
 		WithEventFilter(resource.NewPredicates(resource.NoManagedResourceReference())).
-		Complete(r)
+		WithEventFilter(resource.NewPredicates(classselector.NoClassSelector())).
+		Complete(tracing.NewTracer(name).Reconciler(pause.NewReconciler(mgr.GetClient(), &storagev1alpha1.Bucket{}, r)))
 }
@@ -24,6 +24,9 @@ import (
 
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
 	computev1alpha1 "github.com/crossplaneio/crossplane/apis/compute/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/controller/classselector"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
 )
 
 // KubernetesClusterController is responsible for adding the default class controller
@@ -46,5 +49,6 @@ func (c *KubernetesClusterController) SetupWithManager(mgr ctrl.Manager) error {
 		For(&computev1alpha1.KubernetesCluster{}).
 		WithEventFilter(resource.NewPredicates(resource.NoClassReference())).
 		WithEventFilter(resource.NewPredicates(resource.NoManagedResourceReference())).
-		Complete(r)
+		WithEventFilter(resource.NewPredicates(classselector.NoClassSelector())).
+		Complete(tracing.NewTracer(name).Reconciler(pause.NewReconciler(mgr.GetClient(), &computev1alpha1.KubernetesCluster{}, r)))
 }
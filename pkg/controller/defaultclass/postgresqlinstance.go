@@ -24,6 +24,9 @@ import (
 
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
 	databasev1alpha1 "github.com/crossplaneio/crossplane/apis/database/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/controller/classselector"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
 )
 
 // PostgreSQLInstanceController is responsible for adding the default class controller
@@ -46,5 +49,6 @@ func (c *PostgreSQLInstanceController) SetupWithManager(mgr ctrl.Manager) error
 		For(&databasev1alpha1.PostgreSQLInstance{}).
 		WithEventFilter(resource.NewPredicates(resource.NoClassReference())).
 		WithEventFilter(resource.NewPredicates(resource.NoManagedResourceReference())).
-		Complete(r)
+		WithEventFilter(resource.NewPredicates(classselector.NoClassSelector())).
+		Complete(tracing.NewTracer(name).Reconciler(pause.NewReconciler(mgr.GetClient(), &databasev1alpha1.PostgreSQLInstance{}, r)))
 }
@@ -29,7 +29,13 @@ import (
 	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
 	cachev1alpha1 "github.com/crossplaneio/crossplane/apis/cache/v1alpha1"
+	corev1alpha1 "github.com/crossplaneio/crossplane/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane/gcp/apis/cache/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/secrets"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
 )
 
 // CloudMemorystoreInstanceClaimController is responsible for adding the Cloud Memorystore
@@ -38,23 +44,28 @@ type CloudMemorystoreInstanceClaimController struct{}
 
 // SetupWithManager adds a controller that reconciles RedisCluster resource claims.
 func (c *CloudMemorystoreInstanceClaimController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s", cachev1alpha1.RedisClusterKind, controllerName))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(event.KubernetesErrorCode))
+	tracer := tracing.NewTracer(name)
+
 	r := resource.NewClaimReconciler(mgr,
 		resource.ClaimKind(cachev1alpha1.RedisClusterGroupVersionKind),
 		resource.ClassKind(v1alpha1.CloudMemorystoreInstanceClassGroupVersionKind),
 		resource.ManagedKind(v1alpha1.CloudMemorystoreInstanceGroupVersionKind),
+		resource.WithManagedBinder(event.NewBinder(recorder, tracer.Binder(resource.NewAPIManagedBinder(mgr.GetClient())))),
 		resource.WithManagedConfigurators(
 			resource.ManagedConfiguratorFn(ConfigureCloudMemorystoreInstance),
 			resource.NewObjectMetaConfigurator(mgr.GetScheme()),
-		))
-
-	name := strings.ToLower(fmt.Sprintf("%s.%s", cachev1alpha1.RedisClusterKind, controllerName))
+			resource.ManagedConfiguratorFn(protection.ConfigureManaged),
+		),
+		resource.WithManagedConnectionPropagator(secrets.NewAPIConnectionPropagator(mgr.GetClient(), resource.ClaimKind(cachev1alpha1.RedisClusterGroupVersionKind), cachev1alpha1.RedisClusterSecretDefinition)))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		Watches(&source.Kind{Type: &v1alpha1.CloudMemorystoreInstance{}}, &resource.EnqueueRequestForClaim{}).
 		For(&cachev1alpha1.RedisCluster{}).
 		WithEventFilter(resource.NewPredicates(resource.HasClassReferenceKind(resource.ClassKind(v1alpha1.CloudMemorystoreInstanceClassGroupVersionKind)))).
-		Complete(r)
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &cachev1alpha1.RedisCluster{}, protection.NewReconciler(mgr.GetClient(), &cachev1alpha1.RedisCluster{}, r))))
 }
 
 // ConfigureCloudMemorystoreInstance configures the supplied resource (presumed
@@ -89,6 +100,10 @@ func ConfigureCloudMemorystoreInstance(_ context.Context, cm resource.Claim, cs
 	}
 	spec.RedisVersion = v
 
+	if err := configureClaimParameters(spec, rl.SpecTemplate.ClaimParameters, rc); err != nil {
+		return err
+	}
+
 	spec.WriteConnectionSecretToReference = corev1.LocalObjectReference{Name: string(cm.GetUID())}
 	spec.ProviderReference = rl.SpecTemplate.ProviderReference
 	spec.ReclaimPolicy = rl.SpecTemplate.ReclaimPolicy
@@ -107,3 +122,20 @@ func toGCPFormat(version string) string {
 	}
 	return fmt.Sprintf("REDIS_%s", strings.Replace(version, ".", "_", -1))
 }
+
+// configureClaimParameters applies the memory requested by a Redis cluster
+// claim to the supplied spec, if the class's policy allows it. Cloud
+// Memorystore sizes instances by memory alone, so claims may not request an
+// instance size.
+func configureClaimParameters(spec *v1alpha1.CloudMemorystoreInstanceSpec, p *corev1alpha1.ClaimParameterPolicy, rc *cachev1alpha1.RedisCluster) error {
+	if rc.Spec.InstanceSize != "" {
+		return corev1alpha1.NewNotSupportedError(v1alpha1.CloudMemorystoreInstanceClassKind, corev1alpha1.ClaimParameterInstanceSize)
+	}
+	if rc.Spec.MemoryGB != nil {
+		if err := p.AllowMemoryGB(*rc.Spec.MemoryGB); err != nil {
+			return err
+		}
+		spec.MemorySizeGB = int(*rc.Spec.MemoryGB)
+	}
+	return nil
+}
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -29,6 +30,7 @@ import (
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
 	"github.com/crossplaneio/crossplane-runtime/pkg/test"
 	cachev1alpha1 "github.com/crossplaneio/crossplane/apis/cache/v1alpha1"
+	corev1alpha1 "github.com/crossplaneio/crossplane/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane/gcp/apis/cache/v1alpha1"
 )
 
@@ -51,6 +53,8 @@ func TestConfigureCloudMemorystoreInstance(t *testing.T) {
 	providerName := "coolprovider"
 	region := "cool-region"
 	tier := "cool-tier"
+	memoryGB := int64(4)
+	maxMemoryGB := int64(2)
 
 	cases := map[string]struct {
 		args args
@@ -94,6 +98,79 @@ func TestConfigureCloudMemorystoreInstance(t *testing.T) {
 				err: nil,
 			},
 		},
+		"ClaimParameters": {
+			args: args{
+				cm: &cachev1alpha1.RedisCluster{
+					ObjectMeta: metav1.ObjectMeta{UID: claimUID},
+					Spec:       cachev1alpha1.RedisClusterSpec{MemoryGB: &memoryGB},
+				},
+				cs: &v1alpha1.CloudMemorystoreInstanceClass{
+					SpecTemplate: v1alpha1.CloudMemorystoreInstanceClassSpecTemplate{
+						ResourceClassSpecTemplate: runtimev1alpha1.ResourceClassSpecTemplate{
+							ProviderReference: &corev1.ObjectReference{Name: providerName},
+							ReclaimPolicy:     runtimev1alpha1.ReclaimDelete,
+						},
+						CloudMemorystoreInstanceParameters: v1alpha1.CloudMemorystoreInstanceParameters{
+							Region: region,
+							Tier:   tier,
+						},
+						ClaimParameters: &corev1alpha1.ClaimParameterPolicy{MemoryGB: &corev1alpha1.Int64Range{}},
+					},
+				},
+				mg: &v1alpha1.CloudMemorystoreInstance{},
+			},
+			want: want{
+				mg: &v1alpha1.CloudMemorystoreInstance{
+					Spec: v1alpha1.CloudMemorystoreInstanceSpec{
+						ResourceSpec: runtimev1alpha1.ResourceSpec{
+							ReclaimPolicy:                    runtimev1alpha1.ReclaimDelete,
+							WriteConnectionSecretToReference: corev1.LocalObjectReference{Name: string(claimUID)},
+							ProviderReference:                &corev1.ObjectReference{Name: providerName},
+						},
+						CloudMemorystoreInstanceParameters: v1alpha1.CloudMemorystoreInstanceParameters{
+							Region:       region,
+							Tier:         tier,
+							MemorySizeGB: int(memoryGB),
+						},
+					},
+				},
+				err: nil,
+			},
+		},
+		"MemoryGBOutOfRange": {
+			args: args{
+				cm: &cachev1alpha1.RedisCluster{
+					ObjectMeta: metav1.ObjectMeta{UID: claimUID},
+					Spec:       cachev1alpha1.RedisClusterSpec{MemoryGB: &memoryGB},
+				},
+				cs: &v1alpha1.CloudMemorystoreInstanceClass{
+					SpecTemplate: v1alpha1.CloudMemorystoreInstanceClassSpecTemplate{
+						ClaimParameters: &corev1alpha1.ClaimParameterPolicy{
+							MemoryGB: &corev1alpha1.Int64Range{Max: &maxMemoryGB},
+						},
+					},
+				},
+				mg: &v1alpha1.CloudMemorystoreInstance{},
+			},
+			want: want{
+				mg:  &v1alpha1.CloudMemorystoreInstance{},
+				err: errors.New("requested memoryGB 4 is not allowed by the resource class, which requires at most 2"),
+			},
+		},
+		"InstanceSizeNotSupported": {
+			args: args{
+				cm: &cachev1alpha1.RedisCluster{
+					ObjectMeta: metav1.ObjectMeta{UID: claimUID},
+					Spec:       cachev1alpha1.RedisClusterSpec{InstanceSize: "small"},
+				},
+				cs: &v1alpha1.CloudMemorystoreInstanceClass{},
+				mg: &v1alpha1.CloudMemorystoreInstance{},
+			},
+			want: want{
+				mg:  &v1alpha1.CloudMemorystoreInstance{},
+				err: errors.New("CloudMemorystoreInstanceClass does not support claims that request instanceSize"),
+			},
+		},
 	}
 
 	for name, tc := range cases {
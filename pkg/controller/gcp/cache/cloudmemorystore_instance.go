@@ -18,9 +18,11 @@ package cache
 
 import (
 	"context"
+	"net/http"
 	"time"
 
 	"github.com/pkg/errors"
+	"golang.org/x/oauth2/google"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
@@ -34,7 +36,15 @@ import (
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
 	"github.com/crossplaneio/crossplane/gcp/apis/cache/v1alpha1"
 	gcpv1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/gcp"
 	"github.com/crossplaneio/crossplane/pkg/clients/gcp/cloudmemorystore"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/externalname"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/reference"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
 )
 
 const (
@@ -66,10 +76,20 @@ type deleter interface {
 	Delete(ctx context.Context, i *v1alpha1.CloudMemorystoreInstance) (requeue bool)
 }
 
-// A createsyncdeleter an create, sync, and delete instances in an external
-// store - e.g. the GCP API.
+// An importer can import existing instances from an external store - e.g.
+// the GCP API.
+type importer interface {
+	// Import the existing external instance named by the supplied instance's
+	// external name. Returns true if the instance requires further
+	// reconciliation.
+	Import(ctx context.Context, i *v1alpha1.CloudMemorystoreInstance) (requeue bool)
+}
+
+// A createsyncdeleter an create, import, sync, and delete instances in an
+// external store - e.g. the GCP API.
 type createsyncdeleter interface {
 	creator
+	importer
 	syncer
 	deleter
 }
@@ -78,6 +98,7 @@ type createsyncdeleter interface {
 type cloudMemorystore struct {
 	client  cloudmemorystore.Client
 	project string
+	record  *event.Recorder
 }
 
 func (c *cloudMemorystore) Create(ctx context.Context, i *v1alpha1.CloudMemorystoreInstance) bool {
@@ -86,8 +107,30 @@ func (c *cloudMemorystore) Create(ctx context.Context, i *v1alpha1.CloudMemoryst
 	id := cloudmemorystore.NewInstanceID(c.project, i)
 	if _, err := c.client.CreateInstance(ctx, cloudmemorystore.NewCreateInstanceRequest(id, i)); err != nil {
 		i.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+		c.record.Failed(i, err)
+		return true
+	}
+	c.record.Normal(i, event.ReasonCreateRequested, "Requested creation of external resource")
+
+	i.Status.InstanceName = id.Instance
+	meta.AddFinalizer(i, finalizerName)
+	i.Status.SetConditions(runtimev1alpha1.ReconcileSuccess())
+	return true
+}
+
+func (c *cloudMemorystore) Import(ctx context.Context, i *v1alpha1.CloudMemorystoreInstance) bool {
+	id := cloudmemorystore.NewInstanceID(c.project, i)
+	if _, err := c.client.GetInstance(ctx, cloudmemorystore.NewGetInstanceRequest(id)); err != nil {
+		if cloudmemorystore.IsNotFound(err) {
+			err = errors.Errorf("cannot import external resource: CloudMemorystore instance %s does not exist", id.Name())
+		} else {
+			err = errors.Wrap(err, "cannot import external resource")
+		}
+		i.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+		c.record.Failed(i, err)
 		return true
 	}
+	c.record.Normal(i, event.ReasonImported, "Imported existing external resource")
 
 	i.Status.InstanceName = id.Instance
 	meta.AddFinalizer(i, finalizerName)
@@ -100,6 +143,7 @@ func (c *cloudMemorystore) Sync(ctx context.Context, i *v1alpha1.CloudMemorystor
 	gcpInstance, err := c.client.GetInstance(ctx, cloudmemorystore.NewGetInstanceRequest(id))
 	if err != nil {
 		i.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+		c.record.Failed(i, err)
 		return true
 	}
 
@@ -107,6 +151,10 @@ func (c *cloudMemorystore) Sync(ctx context.Context, i *v1alpha1.CloudMemorystor
 
 	switch i.Status.State {
 	case v1alpha1.StateReady:
+		if !event.Ready(i) {
+			c.record.Normal(i, event.ReasonBecameReady, "External resource became ready")
+		}
+		i.Status.Operation = ""
 		i.Status.SetConditions(runtimev1alpha1.Available())
 		resource.SetBindable(i)
 	case v1alpha1.StateCreating:
@@ -131,10 +179,13 @@ func (c *cloudMemorystore) Sync(ctx context.Context, i *v1alpha1.CloudMemorystor
 		return false
 	}
 
+	i.Status.Operation = cloudmemorystore.UpdateOperation(i, gcpInstance)
 	if _, err := c.client.UpdateInstance(ctx, cloudmemorystore.NewUpdateInstanceRequest(id, i)); err != nil {
 		i.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+		c.record.Failed(i, err)
 		return true
 	}
+	c.record.Normal(i, event.ReasonUpdateApplied, "Applied update to external resource")
 
 	i.Status.SetConditions(runtimev1alpha1.ReconcileSuccess())
 	return false
@@ -147,8 +198,10 @@ func (c *cloudMemorystore) Delete(ctx context.Context, i *v1alpha1.CloudMemoryst
 		id := cloudmemorystore.NewInstanceID(c.project, i)
 		if _, err := c.client.DeleteInstance(ctx, cloudmemorystore.NewDeleteInstanceRequest(id)); err != nil {
 			i.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+			c.record.Failed(i, err)
 			return true
 		}
+		c.record.Normal(i, event.ReasonDeletionStarted, "Requested deletion of external resource")
 	}
 
 	meta.RemoveFinalizer(i, finalizerName)
@@ -166,7 +219,9 @@ type connecter interface {
 // authenticated using credentials read from a Crossplane Provider resource.
 type providerConnecter struct {
 	kube      client.Client
-	newClient func(ctx context.Context, creds []byte) (cloudmemorystore.Client, error)
+	pool      *pool.Pool
+	newClient func(ctx context.Context, creds *google.Credentials) (cloudmemorystore.Client, error)
+	record    *event.Recorder
 }
 
 // Connect returns a createsyncdeleter backed by the GCP API. GCP credentials
@@ -179,21 +234,27 @@ func (c *providerConnecter) Connect(ctx context.Context, i *v1alpha1.CloudMemory
 		return nil, errors.Wrapf(err, "cannot get provider %s", n)
 	}
 
-	s := &corev1.Secret{}
-	n = types.NamespacedName{Namespace: p.Namespace, Name: p.Spec.Secret.Name}
-	if err := c.kube.Get(ctx, n, s); err != nil {
-		return nil, errors.Wrapf(err, "cannot get provider secret %s", n)
+	// CloudMemorystore clients use gRPC rather than the supplied HTTP client,
+	// so their requests are not limited by the pool, though they are still
+	// recorded as cloud API metrics. Pooling them lets reconciles share one
+	// connection per provider.
+	client, err := gcp.PooledClient(ctx, c.pool, c.kube, p, cloudmemorystore.API, "", func(creds *google.Credentials, _ *http.Client) (interface{}, error) {
+		// Pooled clients outlive the supplied context.
+		client, err := c.newClient(context.Background(), creds)
+		return client, errors.Wrap(err, "cannot create new CloudMemorystore client")
+	}, gcp.DefaultScope)
+	if err != nil {
+		return nil, err
 	}
-
-	client, err := c.newClient(ctx, s.Data[p.Spec.Secret.Key])
-	return &cloudMemorystore{client: client, project: p.Spec.ProjectID}, errors.Wrap(err, "cannot create new CloudMemorystore client")
+	return &cloudMemorystore{client: client.(cloudmemorystore.Client), project: p.Spec.ProjectID, record: c.record}, nil
 }
 
 // Reconciler reconciles CloudMemorystoreInstances read from the Kubernetes API
 // with an external store, typically the GCP API.
 type Reconciler struct {
 	connecter
-	kube client.Client
+	kube   client.Client
+	record *event.Recorder
 }
 
 // CloudMemorystoreInstanceController is responsible for adding the Cloud Memorystore
@@ -204,15 +265,17 @@ type CloudMemorystoreInstanceController struct{}
 // Manager with default RBAC. The Manager will set fields on the Controller and
 // start it when the Manager is Started.
 func (c *CloudMemorystoreInstanceController) SetupWithManager(mgr ctrl.Manager) error {
+	record := event.NewRecorder(mgr.GetEventRecorderFor(controllerName), event.WithErrorCoder(gcp.ErrorCode))
 	r := &Reconciler{
-		connecter: &providerConnecter{kube: mgr.GetClient(), newClient: cloudmemorystore.NewClient},
+		connecter: &providerConnecter{kube: mgr.GetClient(), pool: pool.Default, newClient: cloudmemorystore.NewClient, record: record},
 		kube:      mgr.GetClient(),
+		record:    record,
 	}
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(controllerName).
 		For(&v1alpha1.CloudMemorystoreInstance{}).
-		Complete(r)
+		Complete(tracing.NewTracer(controllerName).Reconciler(pause.NewReconciler(mgr.GetClient(), &v1alpha1.CloudMemorystoreInstance{}, r, pause.WithObjectUpdate())))
 }
 
 // Reconcile Google CloudMemorystore resources with the GCP API.
@@ -230,17 +293,44 @@ func (r *Reconciler) Reconcile(req reconcile.Request) (reconcile.Result, error)
 		return reconcile.Result{Requeue: false}, errors.Wrapf(err, "cannot get instance %s", req.NamespacedName)
 	}
 
+	// Resolve references to other managed resources, e.g. the authorized
+	// network.
+	if i.DeletionTimestamp == nil && len(i.GetReferencers()) > 0 {
+		if err := reference.Resolve(ctx, r.kube, i); err != nil {
+			if reference.IsNotReady(err) {
+				i.Status.SetConditions(reference.WaitingForReferences(err))
+			} else {
+				i.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+				r.record.Failed(i, err)
+			}
+			return reconcile.Result{Requeue: true}, errors.Wrapf(r.kube.Update(ctx, i), "cannot update instance %s", req.NamespacedName)
+		}
+		i.Status.SetConditions(reference.ReferencesResolved())
+	}
+
 	client, err := r.Connect(ctx, i)
 	if err != nil {
 		i.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+		r.record.Failed(i, err)
 		return reconcile.Result{Requeue: true}, errors.Wrapf(r.kube.Update(ctx, i), "cannot update instance %s", req.NamespacedName)
 	}
 
 	// The instance has been deleted from the API server. Delete from GCP.
 	if i.DeletionTimestamp != nil {
+		if err := protection.Check(i); err != nil {
+			i.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+			r.record.Failed(i, err)
+			return reconcile.Result{Requeue: true}, errors.Wrapf(r.kube.Update(ctx, i), "cannot update instance %s", req.NamespacedName)
+		}
 		return reconcile.Result{Requeue: client.Delete(ctx, i)}, errors.Wrapf(r.kube.Update(ctx, i), "cannot update instance %s", req.NamespacedName)
 	}
 
+	// The instance is unnamed but has an external name. Import the existing
+	// GCP instance of that name rather than creating a new one.
+	if i.Status.InstanceName == "" && externalname.Get(i) != "" {
+		return reconcile.Result{Requeue: client.Import(ctx, i)}, errors.Wrapf(r.kube.Update(ctx, i), "cannot update instance %s", req.NamespacedName)
+	}
+
 	// The instance is unnamed. Assume it has not been created in GCP.
 	if i.Status.InstanceName == "" {
 		return reconcile.Result{Requeue: client.Create(ctx, i)}, errors.Wrapf(r.kube.Update(ctx, i), "cannot update instance %s", req.NamespacedName)
@@ -248,6 +338,7 @@ func (r *Reconciler) Reconcile(req reconcile.Request) (reconcile.Result, error)
 
 	if err := r.upsertSecret(ctx, connectionSecret(i)); err != nil {
 		i.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+		r.record.Failed(i, err)
 		return reconcile.Result{Requeue: true}, errors.Wrapf(r.kube.Update(ctx, i), "cannot update instance %s", req.NamespacedName)
 	}
 
@@ -27,7 +27,10 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/gax-go"
 	"github.com/pkg/errors"
+	"golang.org/x/oauth2/google"
 	redisv1pb "google.golang.org/genproto/googleapis/cloud/redis/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -43,6 +46,7 @@ import (
 	gcpv1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/v1alpha1"
 	"github.com/crossplaneio/crossplane/pkg/clients/gcp/cloudmemorystore"
 	fakecloudmemorystore "github.com/crossplaneio/crossplane/pkg/clients/gcp/cloudmemorystore/fake"
+	"github.com/crossplaneio/crossplane/pkg/externalname"
 )
 
 const (
@@ -60,7 +64,7 @@ const (
 	providerName       = "cool-gcp"
 	providerSecretName = "cool-gcp-secret"
 	providerSecretKey  = "credentials.json"
-	providerSecretData = "definitelyjson"
+	providerSecretData = `{"type": "authorized_user", "client_id": "cool-id", "client_secret": "cool-secret", "refresh_token": "cool-token"}`
 
 	connectionSecretName = "cool-connection-secret"
 )
@@ -113,6 +117,10 @@ func withInstanceName(n string) instanceModifier {
 	return func(i *v1alpha1.CloudMemorystoreInstance) { i.Status.InstanceName = n }
 }
 
+func withExternalName(n string) instanceModifier {
+	return func(i *v1alpha1.CloudMemorystoreInstance) { externalname.Set(i, n) }
+}
+
 func withProviderID(id string) instanceModifier {
 	return func(i *v1alpha1.CloudMemorystoreInstance) { i.Status.ProviderID = id }
 }
@@ -125,6 +133,10 @@ func withPort(p int) instanceModifier {
 	return func(i *v1alpha1.CloudMemorystoreInstance) { i.Status.Port = p }
 }
 
+func withOperation(o string) instanceModifier {
+	return func(i *v1alpha1.CloudMemorystoreInstance) { i.Status.Operation = o }
+}
+
 func withDeletionTimestamp(t time.Time) instanceModifier {
 	return func(i *v1alpha1.CloudMemorystoreInstance) { i.ObjectMeta.DeletionTimestamp = &metav1.Time{Time: t} }
 }
@@ -221,6 +233,77 @@ func TestCreate(t *testing.T) {
 	}
 }
 
+func TestImport(t *testing.T) {
+	errNotFound := status.Errorf(codes.NotFound, "Resource '%s' was not found", "existing")
+
+	cases := []struct {
+		name        string
+		csd         createsyncdeleter
+		i           *v1alpha1.CloudMemorystoreInstance
+		want        *v1alpha1.CloudMemorystoreInstance
+		wantRequeue bool
+	}{
+		{
+			name: "SuccessfulImport",
+			csd: &cloudMemorystore{project: project, client: &fakecloudmemorystore.MockClient{
+				MockGetInstance: func(_ context.Context, _ *redisv1pb.GetInstanceRequest, _ ...gax.CallOption) (*redisv1pb.Instance, error) {
+					return &redisv1pb.Instance{}, nil
+				}},
+			},
+			i: instance(withExternalName("existing")),
+			want: instance(
+				withExternalName("existing"),
+				withConditions(runtimev1alpha1.ReconcileSuccess()),
+				withFinalizers(finalizerName),
+				withInstanceName("existing"),
+			),
+			wantRequeue: true,
+		},
+		{
+			name: "NotFound",
+			csd: &cloudMemorystore{project: project, client: &fakecloudmemorystore.MockClient{
+				MockGetInstance: func(_ context.Context, _ *redisv1pb.GetInstanceRequest, _ ...gax.CallOption) (*redisv1pb.Instance, error) {
+					return nil, errNotFound
+				}},
+			},
+			i: instance(withExternalName("existing")),
+			want: instance(
+				withExternalName("existing"),
+				withConditions(runtimev1alpha1.ReconcileError(errors.New("cannot import external resource: CloudMemorystore instance projects/"+project+"/locations/"+region+"/instances/existing does not exist"))),
+			),
+			wantRequeue: true,
+		},
+		{
+			name: "FailedGet",
+			csd: &cloudMemorystore{project: project, client: &fakecloudmemorystore.MockClient{
+				MockGetInstance: func(_ context.Context, _ *redisv1pb.GetInstanceRequest, _ ...gax.CallOption) (*redisv1pb.Instance, error) {
+					return nil, errorBoom
+				}},
+			},
+			i: instance(withExternalName("existing")),
+			want: instance(
+				withExternalName("existing"),
+				withConditions(runtimev1alpha1.ReconcileError(errors.Wrap(errorBoom, "cannot import external resource"))),
+			),
+			wantRequeue: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotRequeue := tc.csd.Import(ctx, tc.i)
+
+			if gotRequeue != tc.wantRequeue {
+				t.Errorf("tc.csd.Import(...): want: %t got: %t", tc.wantRequeue, gotRequeue)
+			}
+
+			if diff := cmp.Diff(tc.want, tc.i, test.EquateConditions()); diff != "" {
+				t.Errorf("i: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestSync(t *testing.T) {
 	cases := []struct {
 		name        string
@@ -346,9 +429,29 @@ func TestSync(t *testing.T) {
 				withPort(port),
 				withConditions(runtimev1alpha1.Available(), runtimev1alpha1.ReconcileSuccess()),
 				withBindingPhase(runtimev1alpha1.BindingPhaseUnbound),
+				withOperation(v1alpha1.OperationScaleMemory),
 			),
 			wantRequeue: false,
 		},
+		{
+			name: "SuccessfulSyncWhileInstanceUpdating",
+			csd: &cloudMemorystore{client: &fakecloudmemorystore.MockClient{
+				MockGetInstance: func(_ context.Context, _ *redisv1pb.GetInstanceRequest, _ ...gax.CallOption) (*redisv1pb.Instance, error) {
+					return &redisv1pb.Instance{State: redisv1pb.Instance_UPDATING}, nil
+				},
+			}},
+			i: instance(
+				withInstanceName(instanceName),
+				withOperation(v1alpha1.OperationScaleMemory),
+			),
+			want: instance(
+				withInstanceName(instanceName),
+				withState(v1alpha1.StateUpdating),
+				withOperation(v1alpha1.OperationScaleMemory),
+				withConditions(runtimev1alpha1.ReconcileSuccess()),
+			),
+			wantRequeue: true,
+		},
 		{
 			name: "FailedGet",
 			csd: &cloudMemorystore{client: &fakecloudmemorystore.MockClient{
@@ -391,6 +494,7 @@ func TestSync(t *testing.T) {
 				withPort(port),
 				withConditions(runtimev1alpha1.Available(), runtimev1alpha1.ReconcileError(errorBoom)),
 				withBindingPhase(runtimev1alpha1.BindingPhaseUnbound),
+				withOperation(v1alpha1.OperationScaleMemory),
 			),
 			wantRequeue: true,
 		},
@@ -499,7 +603,7 @@ func TestConnect(t *testing.T) {
 					}
 					return nil
 				}},
-				newClient: func(_ context.Context, _ []byte) (cloudmemorystore.Client, error) {
+				newClient: func(_ context.Context, _ *google.Credentials) (cloudmemorystore.Client, error) {
 					return &fakecloudmemorystore.MockClient{}, nil
 				},
 			},
@@ -512,7 +616,7 @@ func TestConnect(t *testing.T) {
 				kube: &test.MockClient{MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
 					return kerrors.NewNotFound(schema.GroupResource{}, providerName)
 				}},
-				newClient: func(_ context.Context, _ []byte) (cloudmemorystore.Client, error) {
+				newClient: func(_ context.Context, _ *google.Credentials) (cloudmemorystore.Client, error) {
 					return &fakecloudmemorystore.MockClient{}, nil
 				},
 			},
@@ -531,7 +635,7 @@ func TestConnect(t *testing.T) {
 					}
 					return nil
 				}},
-				newClient: func(_ context.Context, _ []byte) (cloudmemorystore.Client, error) {
+				newClient: func(_ context.Context, _ *google.Credentials) (cloudmemorystore.Client, error) {
 					return &fakecloudmemorystore.MockClient{}, nil
 				},
 			},
@@ -550,10 +654,9 @@ func TestConnect(t *testing.T) {
 					}
 					return nil
 				}},
-				newClient: func(_ context.Context, _ []byte) (cloudmemorystore.Client, error) { return nil, errorBoom },
+				newClient: func(_ context.Context, _ *google.Credentials) (cloudmemorystore.Client, error) { return nil, errorBoom },
 			},
 			i:       instance(),
-			want:    &cloudMemorystore{project: project},
 			wantErr: errors.Wrap(errorBoom, "cannot create new CloudMemorystore client"),
 		},
 	}
@@ -583,6 +686,7 @@ func (c *mockConnector) Connect(ctx context.Context, i *v1alpha1.CloudMemorystor
 
 type mockCSD struct {
 	MockCreate func(ctx context.Context, i *v1alpha1.CloudMemorystoreInstance) bool
+	MockImport func(ctx context.Context, i *v1alpha1.CloudMemorystoreInstance) bool
 	MockSync   func(ctx context.Context, i *v1alpha1.CloudMemorystoreInstance) bool
 	MockDelete func(ctx context.Context, i *v1alpha1.CloudMemorystoreInstance) bool
 }
@@ -591,6 +695,10 @@ func (csd *mockCSD) Create(ctx context.Context, i *v1alpha1.CloudMemorystoreInst
 	return csd.MockCreate(ctx, i)
 }
 
+func (csd *mockCSD) Import(ctx context.Context, i *v1alpha1.CloudMemorystoreInstance) bool {
+	return csd.MockImport(ctx, i)
+}
+
 func (csd *mockCSD) Sync(ctx context.Context, i *v1alpha1.CloudMemorystoreInstance) bool {
 	return csd.MockSync(ctx, i)
 }
@@ -643,6 +751,24 @@ func TestReconcile(t *testing.T) {
 			want:    reconcile.Result{Requeue: true},
 			wantErr: nil,
 		},
+		{
+			name: "SuccessfulImport",
+			rec: &Reconciler{
+				connecter: &mockConnector{MockConnect: func(_ context.Context, _ *v1alpha1.CloudMemorystoreInstance) (createsyncdeleter, error) {
+					return &mockCSD{MockImport: func(_ context.Context, _ *v1alpha1.CloudMemorystoreInstance) bool { return true }}, nil
+				}},
+				kube: &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+						*obj.(*v1alpha1.CloudMemorystoreInstance) = *(instance(withExternalName("existing")))
+						return nil
+					},
+					MockUpdate: func(_ context.Context, _ runtime.Object, _ ...client.UpdateOption) error { return nil },
+				},
+			},
+			req:     reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: instanceName}},
+			want:    reconcile.Result{Requeue: true},
+			wantErr: nil,
+		},
 		{
 			name: "SuccessfulSync",
 			rec: &Reconciler{
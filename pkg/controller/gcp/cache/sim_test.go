@@ -0,0 +1,201 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	cachev1alpha1 "github.com/crossplaneio/crossplane/apis/cache/v1alpha1"
+	"github.com/crossplaneio/crossplane/gcp/apis/cache/v1alpha1"
+	gcpv1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/v1alpha1"
+	cmssim "github.com/crossplaneio/crossplane/pkg/clients/gcp/cloudmemorystore/sim"
+	"github.com/crossplaneio/crossplane/pkg/event"
+)
+
+const (
+	simTimeout   = 30 * time.Second
+	simClassName = "sim-redis"
+)
+
+// startSim starts a manager that runs the RedisCluster claim controller and a
+// CloudMemorystoreInstance controller that uses the supplied simulated Cloud
+// Memorystore API. The returned function stops the manager and fails the test
+// if the manager returned an error.
+func startSim(g *GomegaWithT, sim *cmssim.Client) (client.Client, func()) {
+	mgr, err := manager.New(cfg, manager.Options{MetricsBindAddress: "0"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect((&CloudMemorystoreInstanceClaimController{}).SetupWithManager(mgr)).To(Succeed())
+
+	record := event.NewRecorder(mgr.GetEventRecorderFor(controllerName))
+	r := &Reconciler{
+		connecter: &mockConnector{MockConnect: func(_ context.Context, _ *v1alpha1.CloudMemorystoreInstance) (createsyncdeleter, error) {
+			return &cloudMemorystore{client: sim, project: project, record: record}, nil
+		}},
+		kube:   mgr.GetClient(),
+		record: record,
+	}
+
+	err = ctrl.NewControllerManagedBy(mgr).
+		Named(controllerName).
+		For(&v1alpha1.CloudMemorystoreInstance{}).
+		Complete(r)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	stop := make(chan struct{})
+	errs := make(chan error, 1)
+	go func() { errs <- mgr.Start(stop) }()
+	return mgr.GetClient(), func() {
+		close(stop)
+		g.Expect(<-errs).NotTo(HaveOccurred())
+	}
+}
+
+func simProvider() *gcpv1alpha1.Provider {
+	return &gcpv1alpha1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: providerName},
+		Spec: gcpv1alpha1.ProviderSpec{
+			ProjectID: project,
+			Secret: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: providerSecretName},
+				Key:                  providerSecretKey,
+			},
+		},
+	}
+}
+
+func simClass() *v1alpha1.CloudMemorystoreInstanceClass {
+	return &v1alpha1.CloudMemorystoreInstanceClass{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: simClassName},
+		SpecTemplate: v1alpha1.CloudMemorystoreInstanceClassSpecTemplate{
+			ResourceClassSpecTemplate: runtimev1alpha1.ResourceClassSpecTemplate{
+				ProviderReference: &corev1.ObjectReference{Namespace: namespace, Name: providerName},
+				ReclaimPolicy:     runtimev1alpha1.ReclaimDelete,
+			},
+			CloudMemorystoreInstanceParameters: v1alpha1.CloudMemorystoreInstanceParameters{
+				Region:       region,
+				Tier:         v1alpha1.TierBasic,
+				MemorySizeGB: memorySizeGB,
+			},
+		},
+	}
+}
+
+func simClaim(name string) *cachev1alpha1.RedisCluster {
+	return &cachev1alpha1.RedisCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: cachev1alpha1.RedisClusterSpec{
+			ResourceClaimSpec: runtimev1alpha1.ResourceClaimSpec{
+				ClassReference: &corev1.ObjectReference{
+					APIVersion: v1alpha1.SchemeGroupVersion.String(),
+					Kind:       v1alpha1.CloudMemorystoreInstanceClassKind,
+					Namespace:  namespace,
+					Name:       simClassName,
+				},
+				WriteConnectionSecretToReference: corev1.LocalObjectReference{Name: name},
+			},
+			EngineVersion: "4.0",
+		},
+	}
+}
+
+// TestRedisClusterClaimSim provisions, binds, and deletes RedisCluster claims
+// against a simulated Cloud Memorystore API.
+func TestRedisClusterClaimSim(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	sim := cmssim.NewClient()
+	sim.Polls = 1
+
+	c, stop := startSim(g, sim)
+	defer stop()
+
+	p := simProvider()
+	g.Expect(c.Create(ctx, p)).To(Succeed())
+	defer c.Delete(ctx, p)
+
+	cs := simClass()
+	g.Expect(c.Create(ctx, cs)).To(Succeed())
+	defer c.Delete(ctx, cs)
+
+	cases := map[string]struct {
+		claim  string
+		inject func(sim *cmssim.Client)
+	}{
+		"Succeeds": {
+			claim:  "sim-claim",
+			inject: func(_ *cmssim.Client) {},
+		},
+		"RecoversFromTransientErrors": {
+			claim: "sim-claim-flaky",
+			inject: func(sim *cmssim.Client) {
+				sim.Inject("CreateInstance", errors.New("boom"), 1)
+				sim.Inject("GetInstance", errors.New("boom"), 2)
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			tc.inject(sim)
+
+			cm := simClaim(tc.claim)
+			g.Expect(c.Create(ctx, cm)).To(Succeed())
+			nn := types.NamespacedName{Namespace: namespace, Name: tc.claim}
+
+			// The claim binds once the simulated instance becomes ready.
+			g.Eventually(func() (runtimev1alpha1.BindingPhase, error) {
+				got := &cachev1alpha1.RedisCluster{}
+				err := c.Get(ctx, nn, got)
+				return got.GetBindingPhase(), err
+			}, simTimeout).Should(Equal(runtimev1alpha1.BindingPhaseBound))
+			g.Expect(sim.Instances()).To(HaveLen(1))
+
+			// Its connection secret is propagated from the managed resource.
+			g.Eventually(func() (string, error) {
+				s := &corev1.Secret{}
+				err := c.Get(ctx, nn, s)
+				return string(s.Data[runtimev1alpha1.ResourceCredentialsSecretEndpointKey]), err
+			}, simTimeout).ShouldNot(BeEmpty())
+
+			// Deleting the claim deletes its managed resource, which in turn
+			// deletes the simulated instance.
+			g.Expect(c.Delete(ctx, cm)).To(Succeed())
+			g.Eventually(func() bool {
+				err := c.Get(ctx, nn, &cachev1alpha1.RedisCluster{})
+				return kerrors.IsNotFound(err)
+			}, simTimeout).Should(BeTrue())
+			g.Eventually(func() []string {
+				sim.Settle()
+				return sim.Instances()
+			}, simTimeout).Should(BeEmpty())
+		})
+	}
+}
@@ -29,7 +29,13 @@ import (
 	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
 	computev1alpha1 "github.com/crossplaneio/crossplane/apis/compute/v1alpha1"
+	corev1alpha1 "github.com/crossplaneio/crossplane/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane/gcp/apis/compute/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/secrets"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
 )
 
 // GKEClusterClaimController is responsible for adding the GKECluster
@@ -38,30 +44,36 @@ type GKEClusterClaimController struct{}
 
 // SetupWithManager adds a controller that reconciles KubernetesCluster resource claims.
 func (c *GKEClusterClaimController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s", computev1alpha1.KubernetesClusterKind, controllerName))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(event.KubernetesErrorCode))
+	tracer := tracing.NewTracer(name)
+
 	r := resource.NewClaimReconciler(mgr,
 		resource.ClaimKind(computev1alpha1.KubernetesClusterGroupVersionKind),
 		resource.ClassKind(v1alpha1.GKEClusterClassGroupVersionKind),
 		resource.ManagedKind(v1alpha1.GKEClusterGroupVersionKind),
+		resource.WithManagedBinder(event.NewBinder(recorder, tracer.Binder(resource.NewAPIManagedBinder(mgr.GetClient())))),
 		resource.WithManagedConfigurators(
 			resource.ManagedConfiguratorFn(ConfigureGKECluster),
 			resource.NewObjectMetaConfigurator(mgr.GetScheme()),
-		))
-
-	name := strings.ToLower(fmt.Sprintf("%s.%s", computev1alpha1.KubernetesClusterKind, controllerName))
+			resource.ManagedConfiguratorFn(protection.ConfigureManaged),
+		),
+		resource.WithManagedConnectionPropagator(secrets.NewAPIConnectionPropagator(mgr.GetClient(), resource.ClaimKind(computev1alpha1.KubernetesClusterGroupVersionKind), computev1alpha1.KubernetesClusterSecretDefinition)))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		Watches(&source.Kind{Type: &v1alpha1.GKECluster{}}, &resource.EnqueueRequestForClaim{}).
 		For(&computev1alpha1.KubernetesCluster{}).
 		WithEventFilter(resource.NewPredicates(resource.HasClassReferenceKind(resource.ClassKind(v1alpha1.GKEClusterClassGroupVersionKind)))).
-		Complete(r)
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &computev1alpha1.KubernetesCluster{}, protection.NewReconciler(mgr.GetClient(), &computev1alpha1.KubernetesCluster{}, r))))
 }
 
 // ConfigureGKECluster configures the supplied resource (presumed to be a
 // GKECluster) using the supplied resource claim (presumed to be a
 // KubernetesCluster) and resource class.
 func ConfigureGKECluster(_ context.Context, cm resource.Claim, cs resource.Class, mg resource.Managed) error {
-	if _, cmok := cm.(*computev1alpha1.KubernetesCluster); !cmok {
+	kc, cmok := cm.(*computev1alpha1.KubernetesCluster)
+	if !cmok {
 		return errors.Errorf("expected resource claim %s to be %s", cm.GetName(), computev1alpha1.KubernetesClusterGroupVersionKind)
 	}
 
@@ -82,6 +94,10 @@ func ConfigureGKECluster(_ context.Context, cm resource.Claim, cs resource.Class
 		GKEClusterParameters: rs.SpecTemplate.GKEClusterParameters,
 	}
 
+	if err := configureClaimParameters(spec, rs.SpecTemplate.ClaimParameters, kc.Spec.NodeCount, kc.Spec.InstanceSize); err != nil {
+		return err
+	}
+
 	// NOTE(hasheddan): consider moving defaulting to either CRD or managed reconciler level
 	if spec.Labels == nil {
 		spec.Labels = map[string]string{}
@@ -101,3 +117,22 @@ func ConfigureGKECluster(_ context.Context, cm resource.Claim, cs resource.Class
 
 	return nil
 }
+
+// configureClaimParameters applies the node count and instance size requested
+// by a cluster claim to the supplied spec, if the class's policy allows them.
+func configureClaimParameters(spec *v1alpha1.GKEClusterSpec, p *corev1alpha1.ClaimParameterPolicy, nodeCount *int64, size string) error {
+	if nodeCount != nil {
+		if err := p.AllowNodeCount(*nodeCount); err != nil {
+			return err
+		}
+		spec.NumNodes = *nodeCount
+	}
+	if size != "" {
+		t, err := p.InstanceType(size)
+		if err != nil {
+			return err
+		}
+		spec.MachineType = t
+	}
+	return nil
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
 	"github.com/crossplaneio/crossplane-runtime/pkg/test"
 	computev1alpha1 "github.com/crossplaneio/crossplane/apis/compute/v1alpha1"
+	corev1alpha1 "github.com/crossplaneio/crossplane/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane/gcp/apis/compute/v1alpha1"
 )
 
@@ -49,6 +50,7 @@ func TestConfigureGKECluster(t *testing.T) {
 
 	claimUID := types.UID("definitely-a-uuid")
 	providerName := "coolprovider"
+	nodeCount := int64(3)
 
 	cases := map[string]struct {
 		args args
@@ -85,6 +87,48 @@ func TestConfigureGKECluster(t *testing.T) {
 				err: nil,
 			},
 		},
+		"ClaimParameters": {
+			args: args{
+				cm: &computev1alpha1.KubernetesCluster{
+					ObjectMeta: metav1.ObjectMeta{UID: claimUID},
+					Spec: computev1alpha1.KubernetesClusterSpec{
+						NodeCount:    &nodeCount,
+						InstanceSize: "small",
+					},
+				},
+				cs: &v1alpha1.GKEClusterClass{
+					SpecTemplate: v1alpha1.GKEClusterClassSpecTemplate{
+						ResourceClassSpecTemplate: runtimev1alpha1.ResourceClassSpecTemplate{
+							ProviderReference: &corev1.ObjectReference{Name: providerName},
+							ReclaimPolicy:     runtimev1alpha1.ReclaimDelete,
+						},
+						ClaimParameters: &corev1alpha1.ClaimParameterPolicy{
+							NodeCount:     &corev1alpha1.Int64Range{},
+							InstanceSizes: map[string]string{"small": "n1-standard-1"},
+						},
+					},
+				},
+				mg: &v1alpha1.GKECluster{},
+			},
+			want: want{
+				mg: &v1alpha1.GKECluster{
+					Spec: v1alpha1.GKEClusterSpec{
+						ResourceSpec: runtimev1alpha1.ResourceSpec{
+							ReclaimPolicy:                    runtimev1alpha1.ReclaimDelete,
+							WriteConnectionSecretToReference: corev1.LocalObjectReference{Name: string(claimUID)},
+							ProviderReference:                &corev1.ObjectReference{Name: providerName},
+						},
+						GKEClusterParameters: v1alpha1.GKEClusterParameters{
+							MachineType: "n1-standard-1",
+							NumNodes:    nodeCount,
+							Scopes:      []string{},
+							Labels:      map[string]string{},
+						},
+					},
+				},
+				err: nil,
+			},
+		},
 	}
 
 	for name, tc := range cases {
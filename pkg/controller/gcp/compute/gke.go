@@ -20,15 +20,16 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/pkg/errors"
+	"golang.org/x/oauth2/google"
 	"google.golang.org/api/container/v1"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -42,6 +43,12 @@ import (
 	gcpv1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/v1alpha1"
 	"github.com/crossplaneio/crossplane/pkg/clients/gcp"
 	"github.com/crossplaneio/crossplane/pkg/clients/gcp/gke"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/externalname"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
 )
 
 const (
@@ -67,10 +74,12 @@ type Reconciler struct {
 	client.Client
 	scheme     *runtime.Scheme
 	kubeclient kubernetes.Interface
-	recorder   record.EventRecorder
+	recorder   *event.Recorder
+	pool       *pool.Pool
 
 	connect func(*gcpcomputev1alpha1.GKECluster) (gke.Client, error)
 	create  func(*gcpcomputev1alpha1.GKECluster, gke.Client) (reconcile.Result, error)
+	adopt   func(*gcpcomputev1alpha1.GKECluster, gke.Client) (reconcile.Result, error)
 	sync    func(*gcpcomputev1alpha1.GKECluster, gke.Client) (reconcile.Result, error)
 	delete  func(*gcpcomputev1alpha1.GKECluster, gke.Client) (reconcile.Result, error)
 }
@@ -86,21 +95,24 @@ func (c *GKEClusterController) SetupWithManager(mgr ctrl.Manager) error {
 		Client:     mgr.GetClient(),
 		scheme:     mgr.GetScheme(),
 		kubeclient: kubernetes.NewForConfigOrDie(mgr.GetConfig()),
-		recorder:   mgr.GetEventRecorderFor(controllerName),
+		recorder:   event.NewRecorder(mgr.GetEventRecorderFor(controllerName), event.WithErrorCoder(gcp.ErrorCode)),
+		pool:       pool.Default,
 	}
 	r.connect = r._connect
 	r.create = r._create
+	r.adopt = r._adopt
 	r.sync = r._sync
 	r.delete = r._delete
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(controllerName).
 		For(&gcpcomputev1alpha1.GKECluster{}).
-		Complete(r)
+		Complete(tracing.NewTracer(controllerName).Reconciler(pause.NewReconciler(mgr.GetClient(), &gcpcomputev1alpha1.GKECluster{}, r, pause.WithObjectUpdate())))
 }
 
 // fail - helper function to set fail condition with reason and message
 func (r *Reconciler) fail(instance *gcpcomputev1alpha1.GKECluster, err error) (reconcile.Result, error) {
+	r.recorder.Failed(instance, err)
 	instance.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
 	return resultRequeue, r.Update(context.TODO(), instance)
 }
@@ -144,12 +156,14 @@ func (r *Reconciler) _connect(instance *gcpcomputev1alpha1.GKECluster) (gke.Clie
 		return nil, err
 	}
 
-	creds, err := gcp.ProviderCredentials(r.kubeclient, p, gke.DefaultScope)
+	c, err := gcp.PooledClient(ctx, r.pool, r.Client, p, gke.API, "", func(creds *google.Credentials, hc *http.Client) (interface{}, error) {
+		return gke.NewClusterClientWithHTTPClient(ctx, creds, hc)
+	}, gke.DefaultScope)
 	if err != nil {
 		return nil, err
 	}
 
-	return gke.NewClusterClient(ctx, creds)
+	return c.(gke.Client), nil
 }
 
 func (r *Reconciler) _create(instance *gcpcomputev1alpha1.GKECluster, client gke.Client) (reconcile.Result, error) {
@@ -162,6 +176,7 @@ func (r *Reconciler) _create(instance *gcpcomputev1alpha1.GKECluster, client gke
 	if err != nil && !gcp.IsErrorAlreadyExists(err) {
 		if gcp.IsErrorBadRequest(err) {
 			instance.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+			r.recorder.Failed(instance, err)
 			// do not requeue on bad requests
 			return result, r.Update(ctx, instance)
 		}
@@ -170,11 +185,30 @@ func (r *Reconciler) _create(instance *gcpcomputev1alpha1.GKECluster, client gke
 
 	instance.Status.State = gcpcomputev1alpha1.ClusterStateProvisioning
 	instance.Status.ClusterName = clusterName
+	r.recorder.Normal(instance, event.ReasonCreateRequested, "Requested creation of external resource")
 	instance.Status.SetConditions(runtimev1alpha1.ReconcileSuccess())
 
 	return reconcile.Result{}, errors.Wrapf(r.Update(ctx, instance), updateErrorMessageFormat, instance.GetName())
 }
 
+// _adopt imports the existing GKE cluster named by the supplied instance's
+// external name, rather than creating a new one.
+func (r *Reconciler) _adopt(instance *gcpcomputev1alpha1.GKECluster, client gke.Client) (reconcile.Result, error) {
+	clusterName := externalname.Get(instance)
+	cluster, err := client.GetCluster(instance.Spec.Zone, clusterName)
+	if err != nil {
+		return r.fail(instance, errors.Wrapf(err, "cannot import GKE cluster %s", clusterName))
+	}
+
+	meta.AddFinalizer(instance, finalizer)
+	instance.Status.State = cluster.Status
+	instance.Status.ClusterName = clusterName
+	r.recorder.Normal(instance, event.ReasonImported, "Imported existing external resource")
+	instance.Status.SetConditions(runtimev1alpha1.ReconcileSuccess())
+
+	return resultRequeue, errors.Wrapf(r.Update(ctx, instance), updateErrorMessageFormat, instance.GetName())
+}
+
 func (r *Reconciler) _sync(instance *gcpcomputev1alpha1.GKECluster, client gke.Client) (reconcile.Result, error) {
 	cluster, err := client.GetCluster(instance.Spec.Zone, instance.Status.ClusterName)
 	if err != nil {
@@ -197,6 +231,9 @@ func (r *Reconciler) _sync(instance *gcpcomputev1alpha1.GKECluster, client gke.C
 	}
 
 	// update resource status
+	if !event.Ready(instance) {
+		r.recorder.Normal(instance, event.ReasonBecameReady, "External resource became ready")
+	}
 	instance.Status.Endpoint = cluster.Endpoint
 	instance.Status.State = gcpcomputev1alpha1.ClusterStateRunning
 	instance.Status.SetConditions(runtimev1alpha1.Available(), runtimev1alpha1.ReconcileSuccess())
@@ -213,6 +250,7 @@ func (r *Reconciler) _delete(instance *gcpcomputev1alpha1.GKECluster, client gke
 		if err := client.DeleteCluster(instance.Spec.Zone, instance.Status.ClusterName); err != nil {
 			return r.fail(instance, err)
 		}
+		r.recorder.Normal(instance, event.ReasonDeletionStarted, "Requested deletion of external resource")
 	}
 	meta.RemoveFinalizer(instance, finalizer)
 	instance.Status.SetConditions(runtimev1alpha1.ReconcileSuccess())
@@ -241,11 +279,17 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 
 	// Check for deletion
 	if instance.DeletionTimestamp != nil {
+		if err := protection.Check(instance); err != nil {
+			return r.fail(instance, err)
+		}
 		return r.delete(instance, gkeClient)
 	}
 
-	// Create cluster instance
+	// Import an existing cluster, or create a new one
 	if instance.Status.ClusterName == "" {
+		if externalname.Get(instance) != "" {
+			return r.adopt(instance, gkeClient)
+		}
 		return r.create(instance, gkeClient)
 	}
 
@@ -25,6 +25,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
+	pkgerrors "github.com/pkg/errors"
 	"google.golang.org/api/container/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -41,6 +42,7 @@ import (
 	. "github.com/crossplaneio/crossplane/gcp/apis/compute/v1alpha1"
 	"github.com/crossplaneio/crossplane/pkg/clients/gcp/fake"
 	"github.com/crossplaneio/crossplane/pkg/clients/gcp/gke"
+	"github.com/crossplaneio/crossplane/pkg/externalname"
 )
 
 const (
@@ -94,6 +96,62 @@ func assertResource(g *GomegaWithT, r *Reconciler, s runtimev1alpha1.Conditioned
 	return rc
 }
 
+func TestAdopt(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := testCluster()
+	externalname.Set(tc, "existing")
+
+	r := &Reconciler{
+		Client:     NewFakeClient(tc),
+		kubeclient: NewSimpleClientset(),
+	}
+
+	cl := fake.NewGKEClient()
+	cl.MockGetCluster = func(_, name string) (*container.Cluster, error) {
+		g.Expect(name).To(Equal("existing"))
+		return &container.Cluster{Status: ClusterStateRunning}, nil
+	}
+
+	expectedStatus := runtimev1alpha1.ConditionedStatus{}
+	expectedStatus.SetConditions(runtimev1alpha1.ReconcileSuccess())
+
+	rs, err := r._adopt(tc, cl)
+	g.Expect(rs).To(Equal(resultRequeue))
+	g.Expect(err).NotTo(HaveOccurred())
+	rc := assertResource(g, r, expectedStatus)
+	g.Expect(rc.Status.ClusterName).To(Equal("existing"))
+	g.Expect(rc.Status.State).To(Equal(ClusterStateRunning))
+	g.Expect(rc.Finalizers).To(ContainElement(finalizer))
+}
+
+func TestAdoptGetError(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tc := testCluster()
+	externalname.Set(tc, "existing")
+
+	r := &Reconciler{
+		Client:     NewFakeClient(tc),
+		kubeclient: NewSimpleClientset(),
+	}
+
+	testError := errors.New("test-cluster-not-found")
+	cl := fake.NewGKEClient()
+	cl.MockGetCluster = func(string, string) (*container.Cluster, error) {
+		return nil, testError
+	}
+
+	expectedStatus := runtimev1alpha1.ConditionedStatus{}
+	expectedStatus.SetConditions(runtimev1alpha1.ReconcileError(pkgerrors.Wrap(testError, "cannot import GKE cluster existing")))
+
+	rs, err := r._adopt(tc, cl)
+	g.Expect(rs).To(Equal(resultRequeue))
+	g.Expect(err).NotTo(HaveOccurred())
+	rc := assertResource(g, r, expectedStatus)
+	g.Expect(rc.Status.ClusterName).To(BeEmpty())
+}
+
 func TestSyncClusterGetError(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -413,6 +471,32 @@ func TestReconcileCreate(t *testing.T) {
 	g.Expect(called).To(BeTrue())
 }
 
+func TestReconcileAdopt(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	called := false
+
+	tc := testCluster()
+	externalname.Set(tc, "existing")
+
+	r := &Reconciler{
+		Client:     NewFakeClient(tc),
+		kubeclient: NewSimpleClientset(),
+		connect: func(*GKECluster) (gke.Client, error) {
+			return nil, nil
+		},
+		adopt: func(*GKECluster, gke.Client) (reconcile.Result, error) {
+			called = true
+			return resultRequeue, nil
+		},
+	}
+
+	rs, err := r.Reconcile(request)
+	g.Expect(rs).To(Equal(resultRequeue))
+	g.Expect(err).To(BeNil())
+	g.Expect(called).To(BeTrue())
+}
+
 func TestReconcileSync(t *testing.T) {
 	g := NewGomegaWithT(t)
 
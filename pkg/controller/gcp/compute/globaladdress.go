@@ -0,0 +1,173 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2/google"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane/gcp/apis/compute/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/gcp"
+	gcpcompute "github.com/crossplaneio/crossplane/pkg/clients/gcp/compute"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/reference"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
+	"github.com/crossplaneio/crossplane/pkg/util/googleapi"
+)
+
+// Error strings.
+const (
+	errNewGlobalAddressClient = "cannot create new GCP global address client"
+	errNotGlobalAddress       = "managed resource is not a GCP global address"
+	errGetGlobalAddress       = "cannot get GCP global address"
+	errInsertGlobalAddress    = "cannot insert GCP global address"
+	errDeleteGlobalAddress    = "cannot delete GCP global address"
+)
+
+// GlobalAddressController is responsible for adding the GlobalAddress
+// controller and its corresponding reconciler to the manager with any runtime
+// configuration.
+type GlobalAddressController struct{}
+
+// SetupWithManager creates a new GlobalAddress Controller and adds it to the
+// Manager with default RBAC. The Manager will set fields on the Controller and
+// start it when the Manager is Started.
+func (c *GlobalAddressController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s", v1alpha1.GlobalAddressKind, v1alpha1.Group))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(gcp.ErrorCode))
+	tracer := tracing.NewTracer(name)
+
+	r := resource.NewManagedReconciler(mgr,
+		resource.ManagedKind(v1alpha1.GlobalAddressGroupVersionKind),
+		resource.WithExternalConnecter(event.NewConnecter(recorder, protection.NewConnecter(tracer.Connecter(reference.NewConnecter(mgr.GetClient(), &globalAddressConnecter{client: mgr.GetClient(), pool: pool.Default}))))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.GlobalAddress{}).
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &v1alpha1.GlobalAddress{}, r)))
+}
+
+type globalAddressConnecter struct {
+	client      client.Client
+	pool        *pool.Pool
+	newClientFn func(ctx context.Context, creds *google.Credentials, hc *http.Client) (gcpcompute.GlobalAddressService, error)
+}
+
+func (c *globalAddressConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	a, ok := mg.(*v1alpha1.GlobalAddress)
+	if !ok {
+		return nil, errors.New(errNotGlobalAddress)
+	}
+
+	p, err := provider(ctx, c.client, a.Spec.ProviderReference)
+	if err != nil {
+		return nil, err
+	}
+
+	newClientFn := newGlobalAddressClient
+	if c.newClientFn != nil {
+		newClientFn = c.newClientFn
+	}
+	addresses, err := gcp.PooledClient(ctx, c.pool, c.client, p, gcpcompute.API, "globaladdresses", func(creds *google.Credentials, hc *http.Client) (interface{}, error) {
+		// Pooled clients outlive the supplied context.
+		cl, err := newClientFn(context.Background(), creds, hc)
+		return cl, errors.Wrap(err, errNewGlobalAddressClient)
+	}, gcpcompute.DefaultScope)
+	if err != nil {
+		return nil, err
+	}
+	return &globalAddressExternal{addresses: addresses.(gcpcompute.GlobalAddressService)}, nil
+}
+
+func newGlobalAddressClient(ctx context.Context, creds *google.Credentials, hc *http.Client) (gcpcompute.GlobalAddressService, error) {
+	return gcpcompute.NewGlobalAddressClient(ctx, creds, hc)
+}
+
+type globalAddressExternal struct {
+	addresses gcpcompute.GlobalAddressService
+}
+
+func (e *globalAddressExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	a, ok := mg.(*v1alpha1.GlobalAddress)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotGlobalAddress)
+	}
+
+	existing, err := e.addresses.Get(ctx, a.GetResourceName())
+	if googleapi.IsErrorNotFound(err) {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, errGetGlobalAddress)
+	}
+
+	a.Status.Name = existing.Name
+	a.Status.SelfLink = existing.SelfLink
+	a.Status.Address = existing.Address
+	a.Status.Status = existing.Status
+
+	switch existing.Status {
+	case v1alpha1.AddressStatusReserved, v1alpha1.AddressStatusInUse:
+		a.Status.SetConditions(runtimev1alpha1.Available())
+		resource.SetBindable(a)
+	default:
+		a.Status.SetConditions(runtimev1alpha1.Creating())
+	}
+
+	return resource.ExternalObservation{ResourceExists: true}, nil
+}
+
+func (e *globalAddressExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	a, ok := mg.(*v1alpha1.GlobalAddress)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotGlobalAddress)
+	}
+
+	a.Status.SetConditions(runtimev1alpha1.Creating())
+
+	return resource.ExternalCreation{}, errors.Wrap(e.addresses.Insert(ctx, gcpcompute.NewGlobalAddress(a)), errInsertGlobalAddress)
+}
+
+// Update is a no-op, because global addresses cannot be changed after they
+// are created.
+func (e *globalAddressExternal) Update(_ context.Context, _ resource.Managed) (resource.ExternalUpdate, error) {
+	return resource.ExternalUpdate{}, nil
+}
+
+func (e *globalAddressExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	a, ok := mg.(*v1alpha1.GlobalAddress)
+	if !ok {
+		return errors.New(errNotGlobalAddress)
+	}
+
+	a.Status.SetConditions(runtimev1alpha1.Deleting())
+
+	err := e.addresses.Delete(ctx, a.GetResourceName())
+	return errors.Wrap(resource.Ignore(googleapi.IsErrorNotFound, err), errDeleteGlobalAddress)
+}
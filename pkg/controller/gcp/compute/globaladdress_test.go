@@ -0,0 +1,250 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	"github.com/crossplaneio/crossplane/gcp/apis/compute/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/gcp/compute/fake"
+)
+
+const (
+	globalAddressName     = "cool-address"
+	globalAddressUID      = "definitely-a-uuid"
+	globalAddressAddress  = "10.100.0.0"
+	globalAddressSelfLink = "https://www.googleapis.com/compute/v1/projects/cool-project/global/addresses/globaladdress-definitely-a-uuid"
+)
+
+var globalAddressResourceName = "globaladdress-" + globalAddressUID
+
+var _ resource.ExternalConnecter = &globalAddressConnecter{}
+var _ resource.ExternalClient = &globalAddressExternal{}
+
+type globalAddressModifier func(*v1alpha1.GlobalAddress)
+
+func withGlobalAddressConditions(c ...runtimev1alpha1.Condition) globalAddressModifier {
+	return func(a *v1alpha1.GlobalAddress) { a.Status.ConditionedStatus.Conditions = c }
+}
+
+func withGlobalAddressBindingPhase(p runtimev1alpha1.BindingPhase) globalAddressModifier {
+	return func(a *v1alpha1.GlobalAddress) { a.Status.SetBindingPhase(p) }
+}
+
+func withGlobalAddressStatus(status string) globalAddressModifier {
+	return func(a *v1alpha1.GlobalAddress) {
+		a.Status.Name = globalAddressResourceName
+		a.Status.SelfLink = globalAddressSelfLink
+		a.Status.Address = globalAddressAddress
+		a.Status.Status = status
+	}
+}
+
+func globalAddress(m ...globalAddressModifier) *v1alpha1.GlobalAddress {
+	a := &v1alpha1.GlobalAddress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: networkNamespace, Name: globalAddressName, UID: globalAddressUID},
+		Spec: v1alpha1.GlobalAddressSpec{
+			ResourceSpec: runtimev1alpha1.ResourceSpec{
+				ProviderReference: &corev1.ObjectReference{Namespace: networkNamespace, Name: networkProviderName},
+			},
+			GlobalAddressParameters: v1alpha1.GlobalAddressParameters{
+				AddressType:  v1alpha1.AddressTypeInternal,
+				Purpose:      v1alpha1.AddressPurposeVPCPeering,
+				PrefixLength: 16,
+				Network:      networkSelfLink,
+			},
+		},
+	}
+
+	for _, fn := range m {
+		fn(a)
+	}
+
+	return a
+}
+
+func TestGlobalAddressObserve(t *testing.T) {
+	type want struct {
+		o   resource.ExternalObservation
+		a   *v1alpha1.GlobalAddress
+		err error
+	}
+
+	existing := func(status string) func(context.Context, string) (*compute.Address, error) {
+		return func(_ context.Context, name string) (*compute.Address, error) {
+			return &compute.Address{Name: name, SelfLink: globalAddressSelfLink, Address: globalAddressAddress, Status: status}, nil
+		}
+	}
+
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		a    *v1alpha1.GlobalAddress
+		want want
+	}{
+		"AddressReserved": {
+			e: &globalAddressExternal{addresses: &fake.MockGlobalAddressClient{MockGet: existing(v1alpha1.AddressStatusReserved)}},
+			a: globalAddress(),
+			want: want{
+				o: resource.ExternalObservation{ResourceExists: true},
+				a: globalAddress(
+					withGlobalAddressStatus(v1alpha1.AddressStatusReserved),
+					withGlobalAddressConditions(runtimev1alpha1.Available()),
+					withGlobalAddressBindingPhase(runtimev1alpha1.BindingPhaseUnbound),
+				),
+			},
+		},
+		"AddressReserving": {
+			e: &globalAddressExternal{addresses: &fake.MockGlobalAddressClient{MockGet: existing(v1alpha1.AddressStatusReserving)}},
+			a: globalAddress(),
+			want: want{
+				o: resource.ExternalObservation{ResourceExists: true},
+				a: globalAddress(
+					withGlobalAddressStatus(v1alpha1.AddressStatusReserving),
+					withGlobalAddressConditions(runtimev1alpha1.Creating()),
+				),
+			},
+		},
+		"AddressDoesNotExist": {
+			e: &globalAddressExternal{addresses: &fake.MockGlobalAddressClient{
+				MockGet: func(_ context.Context, _ string) (*compute.Address, error) {
+					return nil, &googleapi.Error{Code: http.StatusNotFound}
+				},
+			}},
+			a:    globalAddress(),
+			want: want{o: resource.ExternalObservation{ResourceExists: false}, a: globalAddress()},
+		},
+		"GetAddressFailed": {
+			e: &globalAddressExternal{addresses: &fake.MockGlobalAddressClient{
+				MockGet: func(_ context.Context, _ string) (*compute.Address, error) { return nil, errNetworkBoom },
+			}},
+			a:    globalAddress(),
+			want: want{a: globalAddress(), err: errors.Wrap(errNetworkBoom, errGetGlobalAddress)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.a)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("e.Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.a, tc.a, test.EquateConditions()); diff != "" {
+				t.Errorf("e.Observe(...): -want address, +got address:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGlobalAddressCreate(t *testing.T) {
+	type want struct {
+		a   *v1alpha1.GlobalAddress
+		err error
+	}
+
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		a    *v1alpha1.GlobalAddress
+		want want
+	}{
+		"Successful": {
+			e: &globalAddressExternal{addresses: &fake.MockGlobalAddressClient{
+				MockInsert: func(_ context.Context, a *compute.Address) error {
+					if a.Name != globalAddressResourceName || a.Network != networkSelfLink {
+						return errors.Errorf("unexpected address %s", a.Name)
+					}
+					return nil
+				},
+			}},
+			a:    globalAddress(),
+			want: want{a: globalAddress(withGlobalAddressConditions(runtimev1alpha1.Creating()))},
+		},
+		"InsertFailed": {
+			e: &globalAddressExternal{addresses: &fake.MockGlobalAddressClient{
+				MockInsert: func(_ context.Context, _ *compute.Address) error { return errNetworkBoom },
+			}},
+			a: globalAddress(),
+			want: want{
+				a:   globalAddress(withGlobalAddressConditions(runtimev1alpha1.Creating())),
+				err: errors.Wrap(errNetworkBoom, errInsertGlobalAddress),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Create(context.Background(), tc.a)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Create(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.a, tc.a, test.EquateConditions()); diff != "" {
+				t.Errorf("e.Create(...): -want address, +got address:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGlobalAddressDelete(t *testing.T) {
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		a    *v1alpha1.GlobalAddress
+		want error
+	}{
+		"Successful": {
+			e: &globalAddressExternal{addresses: &fake.MockGlobalAddressClient{
+				MockDelete: func(_ context.Context, _ string) error { return nil },
+			}},
+			a: globalAddress(),
+		},
+		"AddressNotFound": {
+			e: &globalAddressExternal{addresses: &fake.MockGlobalAddressClient{
+				MockDelete: func(_ context.Context, _ string) error { return &googleapi.Error{Code: http.StatusNotFound} },
+			}},
+			a: globalAddress(),
+		},
+		"DeleteFailed": {
+			e: &globalAddressExternal{addresses: &fake.MockGlobalAddressClient{
+				MockDelete: func(_ context.Context, _ string) error { return errNetworkBoom },
+			}},
+			a:    globalAddress(),
+			want: errors.Wrap(errNetworkBoom, errDeleteGlobalAddress),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.e.Delete(context.Background(), tc.a)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Delete(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
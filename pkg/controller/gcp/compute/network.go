@@ -0,0 +1,193 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2/google"
+	compute "google.golang.org/api/compute/v1"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane/gcp/apis/compute/v1alpha1"
+	gcpv1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/gcp"
+	gcpcompute "github.com/crossplaneio/crossplane/pkg/clients/gcp/compute"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
+	"github.com/crossplaneio/crossplane/pkg/util/googleapi"
+)
+
+// Error strings.
+const (
+	errNewNetworkClient = "cannot create new GCP network client"
+	errNotNetwork       = "managed resource is not a GCP network"
+	errGetNetwork       = "cannot get GCP network"
+	errInsertNetwork    = "cannot insert GCP network"
+	errPatchNetwork     = "cannot patch GCP network"
+	errDeleteNetwork    = "cannot delete GCP network"
+)
+
+// NetworkController is responsible for adding the Network controller and its
+// corresponding reconciler to the manager with any runtime configuration.
+type NetworkController struct{}
+
+// SetupWithManager creates a new Network Controller and adds it to the
+// Manager with default RBAC. The Manager will set fields on the Controller and
+// start it when the Manager is Started.
+func (c *NetworkController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s", v1alpha1.NetworkKind, v1alpha1.Group))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(gcp.ErrorCode))
+	tracer := tracing.NewTracer(name)
+
+	r := resource.NewManagedReconciler(mgr,
+		resource.ManagedKind(v1alpha1.NetworkGroupVersionKind),
+		resource.WithExternalConnecter(event.NewConnecter(recorder, protection.NewConnecter(tracer.Connecter(&networkConnecter{client: mgr.GetClient(), pool: pool.Default})))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.Network{}).
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &v1alpha1.Network{}, r)))
+}
+
+// provider returns the referenced GCP provider.
+func provider(ctx context.Context, kube client.Client, ref *corev1.ObjectReference) (*gcpv1alpha1.Provider, error) {
+	p := &gcpv1alpha1.Provider{}
+	n := meta.NamespacedNameOf(ref)
+	return p, errors.Wrapf(kube.Get(ctx, n, p), "cannot get provider %s", n)
+}
+
+type networkConnecter struct {
+	client      client.Client
+	pool        *pool.Pool
+	newClientFn func(ctx context.Context, creds *google.Credentials, hc *http.Client) (gcpcompute.NetworkService, error)
+}
+
+func (c *networkConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	n, ok := mg.(*v1alpha1.Network)
+	if !ok {
+		return nil, errors.New(errNotNetwork)
+	}
+
+	p, err := provider(ctx, c.client, n.Spec.ProviderReference)
+	if err != nil {
+		return nil, err
+	}
+
+	newClientFn := newNetworkClient
+	if c.newClientFn != nil {
+		newClientFn = c.newClientFn
+	}
+	networks, err := gcp.PooledClient(ctx, c.pool, c.client, p, gcpcompute.API, "networks", func(creds *google.Credentials, hc *http.Client) (interface{}, error) {
+		// Pooled clients outlive the supplied context.
+		cl, err := newClientFn(context.Background(), creds, hc)
+		return cl, errors.Wrap(err, errNewNetworkClient)
+	}, gcpcompute.DefaultScope)
+	if err != nil {
+		return nil, err
+	}
+	return &networkExternal{networks: networks.(gcpcompute.NetworkService)}, nil
+}
+
+func newNetworkClient(ctx context.Context, creds *google.Credentials, hc *http.Client) (gcpcompute.NetworkService, error) {
+	return gcpcompute.NewNetworkClient(ctx, creds, hc)
+}
+
+type networkExternal struct {
+	networks gcpcompute.NetworkService
+}
+
+func (e *networkExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	n, ok := mg.(*v1alpha1.Network)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotNetwork)
+	}
+
+	existing, err := e.networks.Get(ctx, n.GetResourceName())
+	if googleapi.IsErrorNotFound(err) {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, errGetNetwork)
+	}
+
+	n.Status.Name = existing.Name
+	n.Status.SelfLink = existing.SelfLink
+	n.Status.Subnetworks = existing.Subnetworks
+
+	// Networks are only returned once their insert operation is done.
+	n.Status.SetConditions(runtimev1alpha1.Available())
+	resource.SetBindable(n)
+
+	return resource.ExternalObservation{ResourceExists: true}, nil
+}
+
+func (e *networkExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	n, ok := mg.(*v1alpha1.Network)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotNetwork)
+	}
+
+	n.Status.SetConditions(runtimev1alpha1.Creating())
+
+	return resource.ExternalCreation{}, errors.Wrap(e.networks.Insert(ctx, gcpcompute.NewNetwork(n)), errInsertNetwork)
+}
+
+// Update patches the routing mode of the network. Its other configuration
+// cannot be changed.
+func (e *networkExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	n, ok := mg.(*v1alpha1.Network)
+	if !ok {
+		return resource.ExternalUpdate{}, errors.New(errNotNetwork)
+	}
+
+	existing, err := e.networks.Get(ctx, n.GetResourceName())
+	if err != nil {
+		return resource.ExternalUpdate{}, errors.Wrap(err, errGetNetwork)
+	}
+
+	if !gcpcompute.NetworkNeedsUpdate(n, existing) {
+		return resource.ExternalUpdate{}, nil
+	}
+
+	patch := &compute.Network{RoutingConfig: &compute.NetworkRoutingConfig{RoutingMode: n.Spec.RoutingMode}}
+	return resource.ExternalUpdate{}, errors.Wrap(e.networks.Patch(ctx, n.GetResourceName(), patch), errPatchNetwork)
+}
+
+func (e *networkExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	n, ok := mg.(*v1alpha1.Network)
+	if !ok {
+		return errors.New(errNotNetwork)
+	}
+
+	n.Status.SetConditions(runtimev1alpha1.Deleting())
+
+	err := e.networks.Delete(ctx, n.GetResourceName())
+	return errors.Wrap(resource.Ignore(googleapi.IsErrorNotFound, err), errDeleteNetwork)
+}
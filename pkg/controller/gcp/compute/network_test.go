@@ -0,0 +1,360 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2/google"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	"github.com/crossplaneio/crossplane/gcp/apis/compute/v1alpha1"
+	gcpv1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/v1alpha1"
+	gcpcompute "github.com/crossplaneio/crossplane/pkg/clients/gcp/compute"
+	"github.com/crossplaneio/crossplane/pkg/clients/gcp/compute/fake"
+)
+
+const (
+	networkNamespace = "cool-namespace"
+	networkName      = "cool-network"
+	networkUID       = "definitely-a-uuid"
+	networkSelfLink  = "https://www.googleapis.com/compute/v1/projects/cool-project/global/networks/network-definitely-a-uuid"
+
+	networkProviderName       = "cool-gcp"
+	networkProviderSecretName = "cool-gcp-secret"
+	networkProviderSecretKey  = "credentials.json"
+)
+
+var (
+	errNetworkBoom = errors.New("boom")
+
+	networkResourceName = "network-" + networkUID
+
+	networkProvider = gcpv1alpha1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Namespace: networkNamespace, Name: networkProviderName},
+		Spec: gcpv1alpha1.ProviderSpec{
+			Secret: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: networkProviderSecretName},
+				Key:                  networkProviderSecretKey,
+			},
+		},
+	}
+)
+
+var _ resource.ExternalConnecter = &networkConnecter{}
+var _ resource.ExternalClient = &networkExternal{}
+
+type networkModifier func(*v1alpha1.Network)
+
+func withNetworkConditions(c ...runtimev1alpha1.Condition) networkModifier {
+	return func(n *v1alpha1.Network) { n.Status.ConditionedStatus.Conditions = c }
+}
+
+func withNetworkBindingPhase(p runtimev1alpha1.BindingPhase) networkModifier {
+	return func(n *v1alpha1.Network) { n.Status.SetBindingPhase(p) }
+}
+
+func withNetworkStatus(name, selfLink string) networkModifier {
+	return func(n *v1alpha1.Network) {
+		n.Status.Name = name
+		n.Status.SelfLink = selfLink
+	}
+}
+
+func network(m ...networkModifier) *v1alpha1.Network {
+	n := &v1alpha1.Network{
+		ObjectMeta: metav1.ObjectMeta{Namespace: networkNamespace, Name: networkName, UID: networkUID},
+		Spec: v1alpha1.NetworkSpec{
+			ResourceSpec: runtimev1alpha1.ResourceSpec{
+				ProviderReference: &corev1.ObjectReference{Namespace: networkNamespace, Name: networkProviderName},
+			},
+			NetworkParameters: v1alpha1.NetworkParameters{RoutingMode: v1alpha1.RoutingModeGlobal},
+		},
+	}
+
+	for _, fn := range m {
+		fn(n)
+	}
+
+	return n
+}
+
+func TestNetworkConnect(t *testing.T) {
+	cases := map[string]struct {
+		conn    *networkConnecter
+		n       *v1alpha1.Network
+		wantErr error
+	}{
+		"FailedToGetProvider": {
+			conn: &networkConnecter{
+				client: &test.MockClient{MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+					return kerrors.NewNotFound(schema.GroupResource{}, networkProviderName)
+				}},
+			},
+			n:       network(),
+			wantErr: errors.WithStack(errors.Errorf("cannot get provider %s/%s:  \"%s\" not found", networkNamespace, networkProviderName, networkProviderName)),
+		},
+		"FailedToGetProviderSecret": {
+			conn: &networkConnecter{
+				client: &test.MockClient{MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+					switch key {
+					case client.ObjectKey{Namespace: networkNamespace, Name: networkProviderName}:
+						*obj.(*gcpv1alpha1.Provider) = networkProvider
+					case client.ObjectKey{Namespace: networkNamespace, Name: networkProviderSecretName}:
+						return kerrors.NewNotFound(schema.GroupResource{}, networkProviderSecretName)
+					}
+					return nil
+				}},
+				newClientFn: func(_ context.Context, _ *google.Credentials, _ *http.Client) (gcpcompute.NetworkService, error) {
+					return &fake.MockNetworkClient{}, nil
+				},
+			},
+			n:       network(),
+			wantErr: errors.WithStack(errors.Errorf("cannot get provider secret %s/%s:  \"%s\" not found", networkNamespace, networkProviderSecretName, networkProviderSecretName)),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.conn.Connect(context.Background(), tc.n)
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.conn.Connect(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNetworkObserve(t *testing.T) {
+	type want struct {
+		o   resource.ExternalObservation
+		n   *v1alpha1.Network
+		err error
+	}
+
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		n    *v1alpha1.Network
+		want want
+	}{
+		"NetworkExists": {
+			e: &networkExternal{networks: &fake.MockNetworkClient{
+				MockGet: func(_ context.Context, name string) (*compute.Network, error) {
+					return &compute.Network{Name: name, SelfLink: networkSelfLink}, nil
+				},
+			}},
+			n: network(),
+			want: want{
+				o: resource.ExternalObservation{ResourceExists: true},
+				n: network(
+					withNetworkStatus(networkResourceName, networkSelfLink),
+					withNetworkConditions(runtimev1alpha1.Available()),
+					withNetworkBindingPhase(runtimev1alpha1.BindingPhaseUnbound),
+				),
+			},
+		},
+		"NetworkDoesNotExist": {
+			e: &networkExternal{networks: &fake.MockNetworkClient{
+				MockGet: func(_ context.Context, _ string) (*compute.Network, error) {
+					return nil, &googleapi.Error{Code: http.StatusNotFound}
+				},
+			}},
+			n:    network(),
+			want: want{o: resource.ExternalObservation{ResourceExists: false}, n: network()},
+		},
+		"GetNetworkFailed": {
+			e: &networkExternal{networks: &fake.MockNetworkClient{
+				MockGet: func(_ context.Context, _ string) (*compute.Network, error) { return nil, errNetworkBoom },
+			}},
+			n:    network(),
+			want: want{n: network(), err: errors.Wrap(errNetworkBoom, errGetNetwork)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.n)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("e.Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.n, tc.n, test.EquateConditions()); diff != "" {
+				t.Errorf("e.Observe(...): -want network, +got network:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNetworkCreate(t *testing.T) {
+	type want struct {
+		n   *v1alpha1.Network
+		err error
+	}
+
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		n    *v1alpha1.Network
+		want want
+	}{
+		"Successful": {
+			e: &networkExternal{networks: &fake.MockNetworkClient{
+				MockInsert: func(_ context.Context, n *compute.Network) error {
+					if n.Name != networkResourceName {
+						return errors.Errorf("unexpected network %s", n.Name)
+					}
+					return nil
+				},
+			}},
+			n:    network(),
+			want: want{n: network(withNetworkConditions(runtimev1alpha1.Creating()))},
+		},
+		"InsertFailed": {
+			e: &networkExternal{networks: &fake.MockNetworkClient{
+				MockInsert: func(_ context.Context, _ *compute.Network) error { return errNetworkBoom },
+			}},
+			n: network(),
+			want: want{
+				n:   network(withNetworkConditions(runtimev1alpha1.Creating())),
+				err: errors.Wrap(errNetworkBoom, errInsertNetwork),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Create(context.Background(), tc.n)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Create(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.n, tc.n, test.EquateConditions()); diff != "" {
+				t.Errorf("e.Create(...): -want network, +got network:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNetworkUpdate(t *testing.T) {
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		n    *v1alpha1.Network
+		want error
+	}{
+		"UpToDate": {
+			e: &networkExternal{networks: &fake.MockNetworkClient{
+				MockGet: func(_ context.Context, _ string) (*compute.Network, error) {
+					return &compute.Network{RoutingConfig: &compute.NetworkRoutingConfig{RoutingMode: v1alpha1.RoutingModeGlobal}}, nil
+				},
+				MockPatch: func(_ context.Context, _ string, _ *compute.Network) error {
+					return errors.New("unexpected patch")
+				},
+			}},
+			n: network(),
+		},
+		"RoutingModeChanged": {
+			e: &networkExternal{networks: &fake.MockNetworkClient{
+				MockGet: func(_ context.Context, _ string) (*compute.Network, error) {
+					return &compute.Network{RoutingConfig: &compute.NetworkRoutingConfig{RoutingMode: v1alpha1.RoutingModeRegional}}, nil
+				},
+				MockPatch: func(_ context.Context, name string, n *compute.Network) error {
+					if name != networkResourceName || n.RoutingConfig.RoutingMode != v1alpha1.RoutingModeGlobal {
+						return errors.Errorf("unexpected patch of network %s", name)
+					}
+					return nil
+				},
+			}},
+			n: network(),
+		},
+		"GetNetworkFailed": {
+			e: &networkExternal{networks: &fake.MockNetworkClient{
+				MockGet: func(_ context.Context, _ string) (*compute.Network, error) { return nil, errNetworkBoom },
+			}},
+			n:    network(),
+			want: errors.Wrap(errNetworkBoom, errGetNetwork),
+		},
+		"PatchFailed": {
+			e: &networkExternal{networks: &fake.MockNetworkClient{
+				MockGet: func(_ context.Context, _ string) (*compute.Network, error) {
+					return &compute.Network{RoutingConfig: &compute.NetworkRoutingConfig{RoutingMode: v1alpha1.RoutingModeRegional}}, nil
+				},
+				MockPatch: func(_ context.Context, _ string, _ *compute.Network) error { return errNetworkBoom },
+			}},
+			n:    network(),
+			want: errors.Wrap(errNetworkBoom, errPatchNetwork),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Update(context.Background(), tc.n)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Update(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNetworkDelete(t *testing.T) {
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		n    *v1alpha1.Network
+		want error
+	}{
+		"Successful": {
+			e: &networkExternal{networks: &fake.MockNetworkClient{
+				MockDelete: func(_ context.Context, _ string) error { return nil },
+			}},
+			n: network(),
+		},
+		"NetworkNotFound": {
+			e: &networkExternal{networks: &fake.MockNetworkClient{
+				MockDelete: func(_ context.Context, _ string) error { return &googleapi.Error{Code: http.StatusNotFound} },
+			}},
+			n: network(),
+		},
+		"DeleteFailed": {
+			e: &networkExternal{networks: &fake.MockNetworkClient{
+				MockDelete: func(_ context.Context, _ string) error { return errNetworkBoom },
+			}},
+			n:    network(),
+			want: errors.Wrap(errNetworkBoom, errDeleteNetwork),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.e.Delete(context.Background(), tc.n)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Delete(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
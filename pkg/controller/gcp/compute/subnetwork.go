@@ -0,0 +1,206 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2/google"
+	compute "google.golang.org/api/compute/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane/gcp/apis/compute/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/gcp"
+	gcpcompute "github.com/crossplaneio/crossplane/pkg/clients/gcp/compute"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/reference"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
+	"github.com/crossplaneio/crossplane/pkg/util/googleapi"
+)
+
+// Error strings.
+const (
+	errNewSubnetworkClient     = "cannot create new GCP subnetwork client"
+	errNotSubnetwork           = "managed resource is not a GCP subnetwork"
+	errGetSubnetwork           = "cannot get GCP subnetwork"
+	errInsertSubnetwork        = "cannot insert GCP subnetwork"
+	errExpandSubnetwork        = "cannot expand GCP subnetwork IP CIDR range"
+	errSetPrivateGoogleAccess  = "cannot set GCP subnetwork private Google access"
+	errPatchSubnetwork         = "cannot patch GCP subnetwork"
+	errDeleteSubnetwork        = "cannot delete GCP subnetwork"
+	secondaryIPRangesFieldName = "SecondaryIpRanges"
+)
+
+// SubnetworkController is responsible for adding the Subnetwork controller and
+// its corresponding reconciler to the manager with any runtime configuration.
+type SubnetworkController struct{}
+
+// SetupWithManager creates a new Subnetwork Controller and adds it to the
+// Manager with default RBAC. The Manager will set fields on the Controller and
+// start it when the Manager is Started.
+func (c *SubnetworkController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s", v1alpha1.SubnetworkKind, v1alpha1.Group))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(gcp.ErrorCode))
+	tracer := tracing.NewTracer(name)
+
+	r := resource.NewManagedReconciler(mgr,
+		resource.ManagedKind(v1alpha1.SubnetworkGroupVersionKind),
+		resource.WithExternalConnecter(event.NewConnecter(recorder, protection.NewConnecter(tracer.Connecter(reference.NewConnecter(mgr.GetClient(), &subnetworkConnecter{client: mgr.GetClient(), pool: pool.Default}))))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.Subnetwork{}).
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &v1alpha1.Subnetwork{}, r)))
+}
+
+type subnetworkConnecter struct {
+	client      client.Client
+	pool        *pool.Pool
+	newClientFn func(ctx context.Context, creds *google.Credentials, hc *http.Client) (gcpcompute.SubnetworkService, error)
+}
+
+func (c *subnetworkConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	s, ok := mg.(*v1alpha1.Subnetwork)
+	if !ok {
+		return nil, errors.New(errNotSubnetwork)
+	}
+
+	p, err := provider(ctx, c.client, s.Spec.ProviderReference)
+	if err != nil {
+		return nil, err
+	}
+
+	newClientFn := newSubnetworkClient
+	if c.newClientFn != nil {
+		newClientFn = c.newClientFn
+	}
+	subnetworks, err := gcp.PooledClient(ctx, c.pool, c.client, p, gcpcompute.API, "subnetworks", func(creds *google.Credentials, hc *http.Client) (interface{}, error) {
+		// Pooled clients outlive the supplied context.
+		cl, err := newClientFn(context.Background(), creds, hc)
+		return cl, errors.Wrap(err, errNewSubnetworkClient)
+	}, gcpcompute.DefaultScope)
+	if err != nil {
+		return nil, err
+	}
+	return &subnetworkExternal{subnetworks: subnetworks.(gcpcompute.SubnetworkService)}, nil
+}
+
+func newSubnetworkClient(ctx context.Context, creds *google.Credentials, hc *http.Client) (gcpcompute.SubnetworkService, error) {
+	return gcpcompute.NewSubnetworkClient(ctx, creds, hc)
+}
+
+type subnetworkExternal struct {
+	subnetworks gcpcompute.SubnetworkService
+}
+
+func (e *subnetworkExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	s, ok := mg.(*v1alpha1.Subnetwork)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotSubnetwork)
+	}
+
+	existing, err := e.subnetworks.Get(ctx, s.Spec.Region, s.GetResourceName())
+	if googleapi.IsErrorNotFound(err) {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, errGetSubnetwork)
+	}
+
+	s.Status.Name = existing.Name
+	s.Status.SelfLink = existing.SelfLink
+	s.Status.GatewayAddress = existing.GatewayAddress
+	s.Status.SetConditions(runtimev1alpha1.Available())
+	resource.SetBindable(s)
+
+	return resource.ExternalObservation{ResourceExists: true}, nil
+}
+
+func (e *subnetworkExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	s, ok := mg.(*v1alpha1.Subnetwork)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotSubnetwork)
+	}
+
+	s.Status.SetConditions(runtimev1alpha1.Creating())
+
+	err := e.subnetworks.Insert(ctx, s.Spec.Region, gcpcompute.NewSubnetwork(s))
+	return resource.ExternalCreation{}, errors.Wrap(err, errInsertSubnetwork)
+}
+
+// Update expands the primary IP range of the subnetwork, toggles its private
+// Google access, and patches its secondary IP ranges. Each of these is a
+// distinct GCP API call.
+func (e *subnetworkExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	s, ok := mg.(*v1alpha1.Subnetwork)
+	if !ok {
+		return resource.ExternalUpdate{}, errors.New(errNotSubnetwork)
+	}
+
+	name := s.GetResourceName()
+	existing, err := e.subnetworks.Get(ctx, s.Spec.Region, name)
+	if err != nil {
+		return resource.ExternalUpdate{}, errors.Wrap(err, errGetSubnetwork)
+	}
+
+	if s.Spec.IPCIDRRange != existing.IpCidrRange {
+		if err := e.subnetworks.ExpandIPCIDRRange(ctx, s.Spec.Region, name, s.Spec.IPCIDRRange); err != nil {
+			return resource.ExternalUpdate{}, errors.Wrap(err, errExpandSubnetwork)
+		}
+	}
+
+	if s.Spec.PrivateIPGoogleAccess != existing.PrivateIpGoogleAccess {
+		if err := e.subnetworks.SetPrivateIPGoogleAccess(ctx, s.Spec.Region, name, s.Spec.PrivateIPGoogleAccess); err != nil {
+			return resource.ExternalUpdate{}, errors.Wrap(err, errSetPrivateGoogleAccess)
+		}
+	}
+
+	if !gcpcompute.SecondaryRangesNeedUpdate(s, existing) {
+		return resource.ExternalUpdate{}, nil
+	}
+
+	// Patches must include the fingerprint of the subnetwork they patch, and
+	// must explicitly send an empty list to remove all secondary ranges.
+	patch := &compute.Subnetwork{
+		Fingerprint:       existing.Fingerprint,
+		SecondaryIpRanges: gcpcompute.NewSecondaryRanges(s.Spec.SecondaryIPRanges),
+		ForceSendFields:   []string{secondaryIPRangesFieldName},
+	}
+	return resource.ExternalUpdate{}, errors.Wrap(e.subnetworks.Patch(ctx, s.Spec.Region, name, patch), errPatchSubnetwork)
+}
+
+func (e *subnetworkExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	s, ok := mg.(*v1alpha1.Subnetwork)
+	if !ok {
+		return errors.New(errNotSubnetwork)
+	}
+
+	s.Status.SetConditions(runtimev1alpha1.Deleting())
+
+	err := e.subnetworks.Delete(ctx, s.Spec.Region, s.GetResourceName())
+	return errors.Wrap(resource.Ignore(googleapi.IsErrorNotFound, err), errDeleteSubnetwork)
+}
@@ -0,0 +1,333 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	"github.com/crossplaneio/crossplane/gcp/apis/compute/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/gcp/compute/fake"
+)
+
+const (
+	subnetworkName     = "cool-subnetwork"
+	subnetworkUID      = "definitely-a-uuid"
+	subnetworkRegion   = "us-central1"
+	subnetworkCIDR     = "10.0.0.0/16"
+	subnetworkGateway  = "10.0.0.1"
+	subnetworkSelfLink = "https://www.googleapis.com/compute/v1/projects/cool-project/regions/us-central1/subnetworks/subnetwork-definitely-a-uuid"
+)
+
+var (
+	subnetworkResourceName = "subnetwork-" + subnetworkUID
+)
+
+var _ resource.ExternalConnecter = &subnetworkConnecter{}
+var _ resource.ExternalClient = &subnetworkExternal{}
+
+type subnetworkModifier func(*v1alpha1.Subnetwork)
+
+func withSubnetworkConditions(c ...runtimev1alpha1.Condition) subnetworkModifier {
+	return func(s *v1alpha1.Subnetwork) { s.Status.ConditionedStatus.Conditions = c }
+}
+
+func withSubnetworkBindingPhase(p runtimev1alpha1.BindingPhase) subnetworkModifier {
+	return func(s *v1alpha1.Subnetwork) { s.Status.SetBindingPhase(p) }
+}
+
+func withSubnetworkStatus(name, selfLink, gateway string) subnetworkModifier {
+	return func(s *v1alpha1.Subnetwork) {
+		s.Status.Name = name
+		s.Status.SelfLink = selfLink
+		s.Status.GatewayAddress = gateway
+	}
+}
+
+func withSecondaryRanges(r ...v1alpha1.SecondaryRange) subnetworkModifier {
+	return func(s *v1alpha1.Subnetwork) { s.Spec.SecondaryIPRanges = r }
+}
+
+func subnetwork(m ...subnetworkModifier) *v1alpha1.Subnetwork {
+	s := &v1alpha1.Subnetwork{
+		ObjectMeta: metav1.ObjectMeta{Namespace: networkNamespace, Name: subnetworkName, UID: subnetworkUID},
+		Spec: v1alpha1.SubnetworkSpec{
+			ResourceSpec: runtimev1alpha1.ResourceSpec{
+				ProviderReference: &corev1.ObjectReference{Namespace: networkNamespace, Name: networkProviderName},
+			},
+			SubnetworkParameters: v1alpha1.SubnetworkParameters{
+				Network:     "global/networks/" + networkResourceName,
+				Region:      subnetworkRegion,
+				IPCIDRRange: subnetworkCIDR,
+			},
+		},
+	}
+
+	for _, fn := range m {
+		fn(s)
+	}
+
+	return s
+}
+
+func TestSubnetworkObserve(t *testing.T) {
+	type want struct {
+		o   resource.ExternalObservation
+		s   *v1alpha1.Subnetwork
+		err error
+	}
+
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		s    *v1alpha1.Subnetwork
+		want want
+	}{
+		"SubnetworkExists": {
+			e: &subnetworkExternal{subnetworks: &fake.MockSubnetworkClient{
+				MockGet: func(_ context.Context, region, name string) (*compute.Subnetwork, error) {
+					if region != subnetworkRegion {
+						return nil, errors.Errorf("unexpected region %s", region)
+					}
+					return &compute.Subnetwork{Name: name, SelfLink: subnetworkSelfLink, GatewayAddress: subnetworkGateway}, nil
+				},
+			}},
+			s: subnetwork(),
+			want: want{
+				o: resource.ExternalObservation{ResourceExists: true},
+				s: subnetwork(
+					withSubnetworkStatus(subnetworkResourceName, subnetworkSelfLink, subnetworkGateway),
+					withSubnetworkConditions(runtimev1alpha1.Available()),
+					withSubnetworkBindingPhase(runtimev1alpha1.BindingPhaseUnbound),
+				),
+			},
+		},
+		"SubnetworkDoesNotExist": {
+			e: &subnetworkExternal{subnetworks: &fake.MockSubnetworkClient{
+				MockGet: func(_ context.Context, _, _ string) (*compute.Subnetwork, error) {
+					return nil, &googleapi.Error{Code: http.StatusNotFound}
+				},
+			}},
+			s:    subnetwork(),
+			want: want{o: resource.ExternalObservation{ResourceExists: false}, s: subnetwork()},
+		},
+		"GetSubnetworkFailed": {
+			e: &subnetworkExternal{subnetworks: &fake.MockSubnetworkClient{
+				MockGet: func(_ context.Context, _, _ string) (*compute.Subnetwork, error) { return nil, errNetworkBoom },
+			}},
+			s:    subnetwork(),
+			want: want{s: subnetwork(), err: errors.Wrap(errNetworkBoom, errGetSubnetwork)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.s)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("e.Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.s, tc.s, test.EquateConditions()); diff != "" {
+				t.Errorf("e.Observe(...): -want subnetwork, +got subnetwork:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSubnetworkCreate(t *testing.T) {
+	type want struct {
+		s   *v1alpha1.Subnetwork
+		err error
+	}
+
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		s    *v1alpha1.Subnetwork
+		want want
+	}{
+		"Successful": {
+			e: &subnetworkExternal{subnetworks: &fake.MockSubnetworkClient{
+				MockInsert: func(_ context.Context, region string, s *compute.Subnetwork) error {
+					if region != subnetworkRegion || s.Name != subnetworkResourceName || s.IpCidrRange != subnetworkCIDR {
+						return errors.Errorf("unexpected subnetwork %s in region %s", s.Name, region)
+					}
+					return nil
+				},
+			}},
+			s:    subnetwork(),
+			want: want{s: subnetwork(withSubnetworkConditions(runtimev1alpha1.Creating()))},
+		},
+		"InsertFailed": {
+			e: &subnetworkExternal{subnetworks: &fake.MockSubnetworkClient{
+				MockInsert: func(_ context.Context, _ string, _ *compute.Subnetwork) error { return errNetworkBoom },
+			}},
+			s: subnetwork(),
+			want: want{
+				s:   subnetwork(withSubnetworkConditions(runtimev1alpha1.Creating())),
+				err: errors.Wrap(errNetworkBoom, errInsertSubnetwork),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Create(context.Background(), tc.s)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Create(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.s, tc.s, test.EquateConditions()); diff != "" {
+				t.Errorf("e.Create(...): -want subnetwork, +got subnetwork:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSubnetworkUpdate(t *testing.T) {
+	unexpected := &fake.MockSubnetworkClient{
+		MockPatch: func(_ context.Context, _, _ string, _ *compute.Subnetwork) error {
+			return errors.New("unexpected patch")
+		},
+		MockExpandIPCIDRRange: func(_ context.Context, _, _, _ string) error {
+			return errors.New("unexpected expand")
+		},
+		MockSetPrivateIPGoogleAccess: func(_ context.Context, _, _ string, _ bool) error {
+			return errors.New("unexpected private Google access change")
+		},
+	}
+
+	cases := map[string]struct {
+		subnetworks *fake.MockSubnetworkClient
+		existing    *compute.Subnetwork
+		s           *v1alpha1.Subnetwork
+		want        error
+	}{
+		"UpToDate": {
+			subnetworks: unexpected,
+			existing:    &compute.Subnetwork{IpCidrRange: subnetworkCIDR},
+			s:           subnetwork(),
+		},
+		"ExpandIPCIDRRange": {
+			subnetworks: &fake.MockSubnetworkClient{
+				MockExpandIPCIDRRange: func(_ context.Context, _, _, cidr string) error {
+					if cidr != subnetworkCIDR {
+						return errors.Errorf("unexpected range %s", cidr)
+					}
+					return nil
+				},
+			},
+			existing: &compute.Subnetwork{IpCidrRange: "10.0.0.0/20"},
+			s:        subnetwork(),
+		},
+		"ExpandIPCIDRRangeFailed": {
+			subnetworks: &fake.MockSubnetworkClient{
+				MockExpandIPCIDRRange: func(_ context.Context, _, _, _ string) error { return errNetworkBoom },
+			},
+			existing: &compute.Subnetwork{IpCidrRange: "10.0.0.0/20"},
+			s:        subnetwork(),
+			want:     errors.Wrap(errNetworkBoom, errExpandSubnetwork),
+		},
+		"SetPrivateIPGoogleAccessFailed": {
+			subnetworks: &fake.MockSubnetworkClient{
+				MockSetPrivateIPGoogleAccess: func(_ context.Context, _, _ string, _ bool) error { return errNetworkBoom },
+			},
+			existing: &compute.Subnetwork{IpCidrRange: subnetworkCIDR, PrivateIpGoogleAccess: true},
+			s:        subnetwork(),
+			want:     errors.Wrap(errNetworkBoom, errSetPrivateGoogleAccess),
+		},
+		"PatchSecondaryRanges": {
+			subnetworks: &fake.MockSubnetworkClient{
+				MockPatch: func(_ context.Context, _, _ string, s *compute.Subnetwork) error {
+					if s.Fingerprint != "cool-fingerprint" || len(s.SecondaryIpRanges) != 1 {
+						return errors.New("unexpected patch")
+					}
+					return nil
+				},
+			},
+			existing: &compute.Subnetwork{IpCidrRange: subnetworkCIDR, Fingerprint: "cool-fingerprint"},
+			s:        subnetwork(withSecondaryRanges(v1alpha1.SecondaryRange{RangeName: "pods", IPCIDRRange: "10.1.0.0/16"})),
+		},
+		"PatchFailed": {
+			subnetworks: &fake.MockSubnetworkClient{
+				MockPatch: func(_ context.Context, _, _ string, _ *compute.Subnetwork) error { return errNetworkBoom },
+			},
+			existing: &compute.Subnetwork{IpCidrRange: subnetworkCIDR},
+			s:        subnetwork(withSecondaryRanges(v1alpha1.SecondaryRange{RangeName: "pods", IPCIDRRange: "10.1.0.0/16"})),
+			want:     errors.Wrap(errNetworkBoom, errPatchSubnetwork),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tc.subnetworks.MockGet = func(_ context.Context, _, _ string) (*compute.Subnetwork, error) { return tc.existing, nil }
+			e := &subnetworkExternal{subnetworks: tc.subnetworks}
+
+			_, err := e.Update(context.Background(), tc.s)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Update(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSubnetworkDelete(t *testing.T) {
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		s    *v1alpha1.Subnetwork
+		want error
+	}{
+		"Successful": {
+			e: &subnetworkExternal{subnetworks: &fake.MockSubnetworkClient{
+				MockDelete: func(_ context.Context, _, _ string) error { return nil },
+			}},
+			s: subnetwork(),
+		},
+		"SubnetworkNotFound": {
+			e: &subnetworkExternal{subnetworks: &fake.MockSubnetworkClient{
+				MockDelete: func(_ context.Context, _, _ string) error { return &googleapi.Error{Code: http.StatusNotFound} },
+			}},
+			s: subnetwork(),
+		},
+		"DeleteFailed": {
+			e: &subnetworkExternal{subnetworks: &fake.MockSubnetworkClient{
+				MockDelete: func(_ context.Context, _, _ string) error { return errNetworkBoom },
+			}},
+			s:    subnetwork(),
+			want: errors.Wrap(errNetworkBoom, errDeleteSubnetwork),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.e.Delete(context.Background(), tc.s)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Delete(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
@@ -27,6 +27,13 @@ import (
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
 	databasev1alpha1 "github.com/crossplaneio/crossplane/apis/database/v1alpha1"
 	"github.com/crossplaneio/crossplane/gcp/apis/database/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/gcp"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/secrets"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
 )
 
 // CloudsqlController is responsible for adding the Cloudsql
@@ -36,15 +43,19 @@ type CloudsqlController struct{}
 // SetupWithManager creates a Controller that reconciles CloudsqlInstance resources.
 func (c *CloudsqlController) SetupWithManager(mgr ctrl.Manager) error {
 	r := &Reconciler{
-		client:  mgr.GetClient(),
-		factory: &operationsFactory{mgr.GetClient()},
+		client: mgr.GetClient(),
+		factory: &operationsFactory{
+			Client: mgr.GetClient(),
+			pool:   pool.Default,
+			record: event.NewRecorder(mgr.GetEventRecorderFor(controllerName), event.WithErrorCoder(gcp.ErrorCode)),
+		},
 	}
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(controllerName).
 		For(&v1alpha1.CloudsqlInstance{}).
 		Owns(&core.Secret{}).
-		Complete(r)
+		Complete(tracing.NewTracer(controllerName).Reconciler(pause.NewReconciler(mgr.GetClient(), &v1alpha1.CloudsqlInstance{}, r)))
 }
 
 // PostgreSQLInstanceClaimController is responsible for adding the PostgreSQLInstance
@@ -53,25 +64,29 @@ type PostgreSQLInstanceClaimController struct{}
 
 // SetupWithManager adds a controller that reconciles PostgreSQLInstance instance claims.
 func (c *PostgreSQLInstanceClaimController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s", databasev1alpha1.PostgreSQLInstanceKind, controllerName))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(event.KubernetesErrorCode))
+	tracer := tracing.NewTracer(name)
+
 	r := resource.NewClaimReconciler(mgr,
 		resource.ClaimKind(databasev1alpha1.PostgreSQLInstanceGroupVersionKind),
 		resource.ClassKind(v1alpha1.CloudsqlInstanceClassGroupVersionKind),
 		resource.ManagedKind(v1alpha1.CloudsqlInstanceGroupVersionKind),
-		resource.WithManagedBinder(resource.NewAPIManagedStatusBinder(mgr.GetClient())),
+		resource.WithManagedBinder(event.NewBinder(recorder, tracer.Binder(resource.NewAPIManagedStatusBinder(mgr.GetClient())))),
 		resource.WithManagedFinalizer(resource.NewAPIManagedStatusUnbinder(mgr.GetClient())),
 		resource.WithManagedConfigurators(
 			resource.ManagedConfiguratorFn(ConfigurePostgreSQLCloudsqlInstance),
 			resource.NewObjectMetaConfigurator(mgr.GetScheme()),
-		))
-
-	name := strings.ToLower(fmt.Sprintf("%s.%s", databasev1alpha1.PostgreSQLInstanceKind, controllerName))
+			resource.ManagedConfiguratorFn(protection.ConfigureManaged),
+		),
+		resource.WithManagedConnectionPropagator(secrets.NewAPIConnectionPropagator(mgr.GetClient(), resource.ClaimKind(databasev1alpha1.PostgreSQLInstanceGroupVersionKind), databasev1alpha1.PostgreSQLInstanceSecretDefinition)))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		Watches(&source.Kind{Type: &v1alpha1.CloudsqlInstance{}}, &resource.EnqueueRequestForClaim{}).
 		For(&databasev1alpha1.PostgreSQLInstance{}).
 		WithEventFilter(resource.NewPredicates(resource.HasClassReferenceKind(resource.ClassKind(v1alpha1.CloudsqlInstanceClassGroupVersionKind)))).
-		Complete(r)
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &databasev1alpha1.PostgreSQLInstance{}, protection.NewReconciler(mgr.GetClient(), &databasev1alpha1.PostgreSQLInstance{}, r))))
 }
 
 // MySQLInstanceClaimController is responsible for adding the MySQLInstance
@@ -80,23 +95,93 @@ type MySQLInstanceClaimController struct{}
 
 // SetupWithManager adds a controller that reconciles MySQLInstance instance claims.
 func (c *MySQLInstanceClaimController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s", databasev1alpha1.MySQLInstanceKind, controllerName))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(event.KubernetesErrorCode))
+	tracer := tracing.NewTracer(name)
+
 	r := resource.NewClaimReconciler(mgr,
 		resource.ClaimKind(databasev1alpha1.MySQLInstanceGroupVersionKind),
 		resource.ClassKind(v1alpha1.CloudsqlInstanceClassGroupVersionKind),
 		resource.ManagedKind(v1alpha1.CloudsqlInstanceGroupVersionKind),
-		resource.WithManagedBinder(resource.NewAPIManagedStatusBinder(mgr.GetClient())),
+		resource.WithManagedBinder(event.NewBinder(recorder, tracer.Binder(resource.NewAPIManagedStatusBinder(mgr.GetClient())))),
 		resource.WithManagedFinalizer(resource.NewAPIManagedStatusUnbinder(mgr.GetClient())),
 		resource.WithManagedConfigurators(
 			resource.ManagedConfiguratorFn(ConfigureMyCloudsqlInstance),
 			resource.NewObjectMetaConfigurator(mgr.GetScheme()),
-		))
-
-	name := strings.ToLower(fmt.Sprintf("%s.%s", databasev1alpha1.MySQLInstanceKind, controllerName))
+			resource.ManagedConfiguratorFn(protection.ConfigureManaged),
+		),
+		resource.WithManagedConnectionPropagator(secrets.NewAPIConnectionPropagator(mgr.GetClient(), resource.ClaimKind(databasev1alpha1.MySQLInstanceGroupVersionKind), databasev1alpha1.MySQLInstanceSecretDefinition)))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		Watches(&source.Kind{Type: &v1alpha1.CloudsqlInstance{}}, &resource.EnqueueRequestForClaim{}).
 		For(&databasev1alpha1.MySQLInstance{}).
 		WithEventFilter(resource.NewPredicates(resource.HasClassReferenceKind(resource.ClassKind(v1alpha1.CloudsqlInstanceClassGroupVersionKind)))).
-		Complete(r)
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &databasev1alpha1.MySQLInstance{}, protection.NewReconciler(mgr.GetClient(), &databasev1alpha1.MySQLInstance{}, r))))
+}
+
+// PostgreSQLInstanceDatabaseClaimController is responsible for adding the PostgreSQLInstance claim
+// controller for claims that use a CloudsqlDatabaseClass, and its
+// corresponding reconciler to the manager with any runtime configuration.
+type PostgreSQLInstanceDatabaseClaimController struct{}
+
+// SetupWithManager adds a controller that binds PostgreSQLInstance instance claims to
+// CloudsqlDatabases.
+func (c *PostgreSQLInstanceDatabaseClaimController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s.%s", databasev1alpha1.PostgreSQLInstanceKind, v1alpha1.CloudsqlDatabaseKind, v1alpha1.Group))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(event.KubernetesErrorCode))
+	tracer := tracing.NewTracer(name)
+
+	r := resource.NewClaimReconciler(mgr,
+		resource.ClaimKind(databasev1alpha1.PostgreSQLInstanceGroupVersionKind),
+		resource.ClassKind(v1alpha1.CloudsqlDatabaseClassGroupVersionKind),
+		resource.ManagedKind(v1alpha1.CloudsqlDatabaseGroupVersionKind),
+		resource.WithManagedBinder(event.NewBinder(recorder, tracer.Binder(resource.NewAPIManagedStatusBinder(mgr.GetClient())))),
+		resource.WithManagedFinalizer(resource.NewAPIManagedStatusUnbinder(mgr.GetClient())),
+		resource.WithManagedConfigurators(
+			resource.ManagedConfiguratorFn(ConfigureCloudsqlDatabase),
+			resource.NewObjectMetaConfigurator(mgr.GetScheme()),
+			resource.ManagedConfiguratorFn(protection.ConfigureManaged),
+		),
+		resource.WithManagedConnectionPropagator(secrets.NewAPIConnectionPropagator(mgr.GetClient(), resource.ClaimKind(databasev1alpha1.PostgreSQLInstanceGroupVersionKind), databasev1alpha1.PostgreSQLInstanceSecretDefinition)))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		Watches(&source.Kind{Type: &v1alpha1.CloudsqlDatabase{}}, &resource.EnqueueRequestForClaim{}).
+		For(&databasev1alpha1.PostgreSQLInstance{}).
+		WithEventFilter(resource.NewPredicates(resource.HasClassReferenceKind(resource.ClassKind(v1alpha1.CloudsqlDatabaseClassGroupVersionKind)))).
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &databasev1alpha1.PostgreSQLInstance{}, protection.NewReconciler(mgr.GetClient(), &databasev1alpha1.PostgreSQLInstance{}, r))))
+}
+
+// MySQLInstanceDatabaseClaimController is responsible for adding the MySQLInstance claim
+// controller for claims that use a CloudsqlDatabaseClass, and its
+// corresponding reconciler to the manager with any runtime configuration.
+type MySQLInstanceDatabaseClaimController struct{}
+
+// SetupWithManager adds a controller that binds MySQLInstance instance claims to
+// CloudsqlDatabases.
+func (c *MySQLInstanceDatabaseClaimController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s.%s", databasev1alpha1.MySQLInstanceKind, v1alpha1.CloudsqlDatabaseKind, v1alpha1.Group))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(event.KubernetesErrorCode))
+	tracer := tracing.NewTracer(name)
+
+	r := resource.NewClaimReconciler(mgr,
+		resource.ClaimKind(databasev1alpha1.MySQLInstanceGroupVersionKind),
+		resource.ClassKind(v1alpha1.CloudsqlDatabaseClassGroupVersionKind),
+		resource.ManagedKind(v1alpha1.CloudsqlDatabaseGroupVersionKind),
+		resource.WithManagedBinder(event.NewBinder(recorder, tracer.Binder(resource.NewAPIManagedStatusBinder(mgr.GetClient())))),
+		resource.WithManagedFinalizer(resource.NewAPIManagedStatusUnbinder(mgr.GetClient())),
+		resource.WithManagedConfigurators(
+			resource.ManagedConfiguratorFn(ConfigureCloudsqlDatabase),
+			resource.NewObjectMetaConfigurator(mgr.GetScheme()),
+			resource.ManagedConfiguratorFn(protection.ConfigureManaged),
+		),
+		resource.WithManagedConnectionPropagator(secrets.NewAPIConnectionPropagator(mgr.GetClient(), resource.ClaimKind(databasev1alpha1.MySQLInstanceGroupVersionKind), databasev1alpha1.MySQLInstanceSecretDefinition)))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		Watches(&source.Kind{Type: &v1alpha1.CloudsqlDatabase{}}, &resource.EnqueueRequestForClaim{}).
+		For(&databasev1alpha1.MySQLInstance{}).
+		WithEventFilter(resource.NewPredicates(resource.HasClassReferenceKind(resource.ClassKind(v1alpha1.CloudsqlDatabaseClassGroupVersionKind)))).
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &databasev1alpha1.MySQLInstance{}, protection.NewReconciler(mgr.GetClient(), &databasev1alpha1.MySQLInstance{}, r))))
 }
@@ -0,0 +1,250 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2/google"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane/gcp/apis/database/v1alpha1"
+	gcpv1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/gcp"
+	"github.com/crossplaneio/crossplane/pkg/clients/gcp/cloudsql"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
+	"github.com/crossplaneio/crossplane/pkg/util/googleapi"
+)
+
+// Error strings.
+const (
+	errNewBackupClient   = "cannot create new CloudSQL backup run client"
+	errNotBackup         = "managed resource is not a CloudSQL backup"
+	errGetBackupInstance = "cannot get referenced CloudsqlInstance"
+	errBackupInstance    = "referenced CloudsqlInstance is not yet runnable"
+	errGetBackup         = "cannot get CloudSQL backup run"
+	errListBackups       = "cannot list CloudSQL backup runs"
+	errCreateBackup      = "cannot create CloudSQL backup run"
+	errDeleteBackup      = "cannot delete CloudSQL backup run"
+)
+
+// CloudsqlBackupController is responsible for adding the CloudsqlBackup
+// controller and its corresponding reconciler to the manager with any runtime configuration.
+type CloudsqlBackupController struct{}
+
+// SetupWithManager creates a new CloudsqlBackup Controller and adds it to the
+// Manager with default RBAC. The Manager will set fields on the Controller and
+// start it when the Manager is Started.
+func (c *CloudsqlBackupController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s", v1alpha1.CloudsqlBackupKind, v1alpha1.Group))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(gcp.ErrorCode))
+	tracer := tracing.NewTracer(name)
+
+	r := resource.NewManagedReconciler(mgr,
+		resource.ManagedKind(v1alpha1.CloudsqlBackupGroupVersionKind),
+		resource.WithExternalConnecter(event.NewConnecter(recorder, protection.NewConnecter(tracer.Connecter(&backupConnecter{client: mgr.GetClient(), pool: pool.Default})))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.CloudsqlBackup{}).
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &v1alpha1.CloudsqlBackup{}, r)))
+}
+
+type backupConnecter struct {
+	client      client.Client
+	pool        *pool.Pool
+	newClientFn func(ctx context.Context, creds *google.Credentials, hc *http.Client) (cloudsql.BackupRunService, error)
+}
+
+func (c *backupConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	b, ok := mg.(*v1alpha1.CloudsqlBackup)
+	if !ok {
+		return nil, errors.New(errNotBackup)
+	}
+
+	p := &gcpv1alpha1.Provider{}
+	n := meta.NamespacedNameOf(b.Spec.ProviderReference)
+	if err := c.client.Get(ctx, n, p); err != nil {
+		return nil, errors.Wrapf(err, "cannot get provider %s", n)
+	}
+
+	newClientFn := newBackupRunClient
+	if c.newClientFn != nil {
+		newClientFn = c.newClientFn
+	}
+	cl, err := gcp.PooledClient(ctx, c.pool, c.client, p, cloudsql.API, "backupruns", func(creds *google.Credentials, hc *http.Client) (interface{}, error) {
+		// Pooled clients outlive the supplied context.
+		cl, err := newClientFn(context.Background(), creds, hc)
+		return cl, errors.Wrap(err, errNewBackupClient)
+	}, cloudsql.DefaultScope)
+	if err != nil {
+		return nil, err
+	}
+	return &backupExternal{kube: c.client, runs: cl.(cloudsql.BackupRunService)}, nil
+}
+
+func newBackupRunClient(ctx context.Context, creds *google.Credentials, hc *http.Client) (cloudsql.BackupRunService, error) {
+	return cloudsql.NewBackupRunClient(ctx, creds, hc)
+}
+
+type backupExternal struct {
+	kube client.Client
+	runs cloudsql.BackupRunService
+}
+
+// instanceName returns the CloudSQL name of the instance the supplied backup
+// was or will be taken of.
+func (e *backupExternal) instanceName(ctx context.Context, b *v1alpha1.CloudsqlBackup) (string, error) {
+	if b.Status.Instance != "" {
+		return b.Status.Instance, nil
+	}
+
+	i := &v1alpha1.CloudsqlInstance{}
+	n := types.NamespacedName{Namespace: b.GetNamespace(), Name: b.Spec.InstanceRef.Name}
+	if err := e.kube.Get(ctx, n, i); err != nil {
+		return "", errors.Wrap(err, errGetBackupInstance)
+	}
+	if !i.IsRunnable() {
+		return "", errors.New(errBackupInstance)
+	}
+	return i.GetResourceName(), nil
+}
+
+// find returns the backup run of the supplied backup, or nil if it does not
+// exist. CloudSQL assigns backup run IDs, so until the ID is known the run is
+// found by its description.
+func (e *backupExternal) find(ctx context.Context, b *v1alpha1.CloudsqlBackup) (*sqladmin.BackupRun, error) {
+	instance, err := e.instanceName(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.Status.BackupRunID != 0 {
+		run, err := e.runs.Get(ctx, instance, b.Status.BackupRunID)
+		if googleapi.IsErrorNotFound(err) {
+			return nil, nil
+		}
+		return run, errors.Wrap(err, errGetBackup)
+	}
+
+	runs, err := e.runs.List(ctx, instance)
+	if err != nil {
+		return nil, errors.Wrap(err, errListBackups)
+	}
+	for _, run := range runs {
+		if run.Description == b.Description() {
+			return run, nil
+		}
+	}
+	return nil, nil
+}
+
+func (e *backupExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	b, ok := mg.(*v1alpha1.CloudsqlBackup)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotBackup)
+	}
+
+	run, err := e.find(ctx, b)
+	if err != nil {
+		return resource.ExternalObservation{}, err
+	}
+	if run == nil {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	b.Status.State = run.Status
+	b.Status.Instance = run.Instance
+	b.Status.BackupRunID = run.Id
+	b.Status.StartTime = run.StartTime
+	b.Status.EndTime = run.EndTime
+
+	switch b.Status.State {
+	case v1alpha1.BackupStateSuccessful:
+		b.Status.SetConditions(runtimev1alpha1.Available())
+	case v1alpha1.BackupStateFailed:
+		b.Status.SetConditions(runtimev1alpha1.Unavailable())
+	default:
+		b.Status.SetConditions(runtimev1alpha1.Creating())
+	}
+
+	return resource.ExternalObservation{ResourceExists: true}, nil
+}
+
+func (e *backupExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	b, ok := mg.(*v1alpha1.CloudsqlBackup)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotBackup)
+	}
+
+	b.Status.SetConditions(runtimev1alpha1.Creating())
+
+	instance, err := e.instanceName(ctx, b)
+	if err != nil {
+		return resource.ExternalCreation{}, err
+	}
+
+	// Record the instance so the backup run can still be found (and deleted)
+	// if the CloudsqlInstance resource goes away.
+	b.Status.Instance = instance
+	run := &sqladmin.BackupRun{Description: b.Description()}
+	return resource.ExternalCreation{}, errors.Wrap(e.runs.Insert(ctx, instance, run), errCreateBackup)
+}
+
+// Update is a no-op; backup runs are immutable.
+func (e *backupExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	return resource.ExternalUpdate{}, nil
+}
+
+func (e *backupExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	b, ok := mg.(*v1alpha1.CloudsqlBackup)
+	if !ok {
+		return errors.New(errNotBackup)
+	}
+
+	b.Status.SetConditions(runtimev1alpha1.Deleting())
+
+	if b.Status.Instance == "" {
+		// No backup run was ever requested.
+		return nil
+	}
+
+	run, err := e.find(ctx, b)
+	if err != nil {
+		return err
+	}
+	if run == nil {
+		return nil
+	}
+
+	err = e.runs.Delete(ctx, run.Instance, run.Id)
+	return errors.Wrap(resource.Ignore(googleapi.IsErrorNotFound, err), errDeleteBackup)
+}
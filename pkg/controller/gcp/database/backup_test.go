@@ -0,0 +1,332 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/api/googleapi"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	"github.com/crossplaneio/crossplane/gcp/apis/database/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/gcp/cloudsql/fake"
+)
+
+const (
+	backupName        = "cool-backup"
+	backupUID         = "also-a-uuid"
+	backupDescription = "crossplane-" + backupUID
+	backupRunID       = int64(42)
+	backupStartTime   = "2019-08-20T10:00:00.000Z"
+	backupEndTime     = "2019-08-20T10:05:00.000Z"
+)
+
+var errBackupBoom = errors.New("boom")
+
+type cloudsqlBackupModifier func(*v1alpha1.CloudsqlBackup)
+
+func withBackupConditions(c ...runtimev1alpha1.Condition) cloudsqlBackupModifier {
+	return func(b *v1alpha1.CloudsqlBackup) { b.Status.ConditionedStatus.Conditions = c }
+}
+
+func withBackupInstance(i string) cloudsqlBackupModifier {
+	return func(b *v1alpha1.CloudsqlBackup) { b.Status.Instance = i }
+}
+
+func withBackupRun(state string, id int64, start, end string) cloudsqlBackupModifier {
+	return func(b *v1alpha1.CloudsqlBackup) {
+		b.Status.State = state
+		b.Status.BackupRunID = id
+		b.Status.StartTime = start
+		b.Status.EndTime = end
+	}
+}
+
+func cloudsqlBackup(m ...cloudsqlBackupModifier) *v1alpha1.CloudsqlBackup {
+	b := &v1alpha1.CloudsqlBackup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: databaseNamespace, Name: backupName, UID: backupUID},
+		Spec: v1alpha1.CloudsqlBackupSpec{
+			CloudsqlBackupParameters: v1alpha1.CloudsqlBackupParameters{
+				InstanceRef: corev1.LocalObjectReference{Name: databaseInstanceName},
+			},
+		},
+	}
+
+	for _, fn := range m {
+		fn(b)
+	}
+
+	return b
+}
+
+var _ resource.ExternalConnecter = &backupConnecter{}
+var _ resource.ExternalClient = &backupExternal{}
+
+func TestBackupObserve(t *testing.T) {
+	type want struct {
+		o   resource.ExternalObservation
+		b   *v1alpha1.CloudsqlBackup
+		err error
+	}
+
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		b    *v1alpha1.CloudsqlBackup
+		want want
+	}{
+		"BackupFoundByDescription": {
+			e: &backupExternal{
+				kube: databaseKube(databaseInstance(v1alpha1.StateRunnable)),
+				runs: &fake.MockBackupRunClient{MockList: func(_ context.Context, instance string) ([]*sqladmin.BackupRun, error) {
+					return []*sqladmin.BackupRun{
+						{Id: 1, Instance: instance, Description: "someone-elses-backup"},
+						{Id: backupRunID, Instance: instance, Description: backupDescription, Status: "RUNNING", StartTime: backupStartTime},
+					}, nil
+				}},
+			},
+			b: cloudsqlBackup(),
+			want: want{
+				o: resource.ExternalObservation{ResourceExists: true},
+				b: cloudsqlBackup(
+					withBackupInstance(databaseInstanceResourceName),
+					withBackupRun("RUNNING", backupRunID, backupStartTime, ""),
+					withBackupConditions(runtimev1alpha1.Creating()),
+				),
+			},
+		},
+		"BackupFoundByID": {
+			e: &backupExternal{
+				runs: &fake.MockBackupRunClient{MockGet: func(_ context.Context, instance string, id int64) (*sqladmin.BackupRun, error) {
+					return &sqladmin.BackupRun{
+						Id:          id,
+						Instance:    instance,
+						Description: backupDescription,
+						Status:      v1alpha1.BackupStateSuccessful,
+						StartTime:   backupStartTime,
+						EndTime:     backupEndTime,
+					}, nil
+				}},
+			},
+			b: cloudsqlBackup(
+				withBackupInstance(databaseInstanceResourceName),
+				withBackupRun("RUNNING", backupRunID, backupStartTime, ""),
+			),
+			want: want{
+				o: resource.ExternalObservation{ResourceExists: true},
+				b: cloudsqlBackup(
+					withBackupInstance(databaseInstanceResourceName),
+					withBackupRun(v1alpha1.BackupStateSuccessful, backupRunID, backupStartTime, backupEndTime),
+					withBackupConditions(runtimev1alpha1.Available()),
+				),
+			},
+		},
+		"BackupFailed": {
+			e: &backupExternal{
+				runs: &fake.MockBackupRunClient{MockGet: func(_ context.Context, instance string, id int64) (*sqladmin.BackupRun, error) {
+					return &sqladmin.BackupRun{Id: id, Instance: instance, Status: v1alpha1.BackupStateFailed}, nil
+				}},
+			},
+			b: cloudsqlBackup(
+				withBackupInstance(databaseInstanceResourceName),
+				withBackupRun("RUNNING", backupRunID, "", ""),
+			),
+			want: want{
+				o: resource.ExternalObservation{ResourceExists: true},
+				b: cloudsqlBackup(
+					withBackupInstance(databaseInstanceResourceName),
+					withBackupRun(v1alpha1.BackupStateFailed, backupRunID, "", ""),
+					withBackupConditions(runtimev1alpha1.Unavailable()),
+				),
+			},
+		},
+		"BackupDoesNotExist": {
+			e: &backupExternal{
+				kube: databaseKube(databaseInstance(v1alpha1.StateRunnable)),
+				runs: &fake.MockBackupRunClient{MockList: func(_ context.Context, _ string) ([]*sqladmin.BackupRun, error) {
+					return nil, nil
+				}},
+			},
+			b:    cloudsqlBackup(),
+			want: want{o: resource.ExternalObservation{ResourceExists: false}, b: cloudsqlBackup()},
+		},
+		"InstanceNotRunnable": {
+			e:    &backupExternal{kube: databaseKube(databaseInstance("PENDING_CREATE"))},
+			b:    cloudsqlBackup(),
+			want: want{b: cloudsqlBackup(), err: errors.New(errBackupInstance)},
+		},
+		"ListBackupsFailed": {
+			e: &backupExternal{
+				kube: databaseKube(databaseInstance(v1alpha1.StateRunnable)),
+				runs: &fake.MockBackupRunClient{MockList: func(_ context.Context, _ string) ([]*sqladmin.BackupRun, error) {
+					return nil, errBackupBoom
+				}},
+			},
+			b:    cloudsqlBackup(),
+			want: want{b: cloudsqlBackup(), err: errors.Wrap(errBackupBoom, errListBackups)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.b)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("e.Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.b, tc.b, test.EquateConditions()); diff != "" {
+				t.Errorf("e.Observe(...): -want backup, +got backup:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBackupCreate(t *testing.T) {
+	type want struct {
+		b   *v1alpha1.CloudsqlBackup
+		err error
+	}
+
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		b    *v1alpha1.CloudsqlBackup
+		want want
+	}{
+		"Successful": {
+			e: &backupExternal{
+				kube: databaseKube(databaseInstance(v1alpha1.StateRunnable)),
+				runs: &fake.MockBackupRunClient{MockInsert: func(_ context.Context, instance string, r *sqladmin.BackupRun) error {
+					if instance != databaseInstanceResourceName || r.Description != backupDescription {
+						return errors.Errorf("unexpected backup run %s of instance %s", r.Description, instance)
+					}
+					return nil
+				}},
+			},
+			b: cloudsqlBackup(),
+			want: want{b: cloudsqlBackup(
+				withBackupInstance(databaseInstanceResourceName),
+				withBackupConditions(runtimev1alpha1.Creating()),
+			)},
+		},
+		"InstanceNotRunnable": {
+			e: &backupExternal{kube: databaseKube(databaseInstance("PENDING_CREATE"))},
+			b: cloudsqlBackup(),
+			want: want{
+				b:   cloudsqlBackup(withBackupConditions(runtimev1alpha1.Creating())),
+				err: errors.New(errBackupInstance),
+			},
+		},
+		"InsertFailed": {
+			e: &backupExternal{
+				kube: databaseKube(databaseInstance(v1alpha1.StateRunnable)),
+				runs: &fake.MockBackupRunClient{MockInsert: func(_ context.Context, _ string, _ *sqladmin.BackupRun) error {
+					return errBackupBoom
+				}},
+			},
+			b: cloudsqlBackup(),
+			want: want{
+				b: cloudsqlBackup(
+					withBackupInstance(databaseInstanceResourceName),
+					withBackupConditions(runtimev1alpha1.Creating()),
+				),
+				err: errors.Wrap(errBackupBoom, errCreateBackup),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Create(context.Background(), tc.b)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Create(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.b, tc.b, test.EquateConditions()); diff != "" {
+				t.Errorf("e.Create(...): -want backup, +got backup:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBackupDelete(t *testing.T) {
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		b    *v1alpha1.CloudsqlBackup
+		want error
+	}{
+		"Successful": {
+			e: &backupExternal{
+				runs: &fake.MockBackupRunClient{
+					MockGet: func(_ context.Context, instance string, id int64) (*sqladmin.BackupRun, error) {
+						return &sqladmin.BackupRun{Id: id, Instance: instance}, nil
+					},
+					MockDelete: func(_ context.Context, instance string, id int64) error {
+						if instance != databaseInstanceResourceName || id != backupRunID {
+							return errors.Errorf("unexpected backup run %d of instance %s", id, instance)
+						}
+						return nil
+					},
+				},
+			},
+			b: cloudsqlBackup(withBackupInstance(databaseInstanceResourceName), withBackupRun("", backupRunID, "", "")),
+		},
+		"NeverCreated": {
+			e: &backupExternal{},
+			b: cloudsqlBackup(),
+		},
+		"BackupNotFound": {
+			e: &backupExternal{
+				runs: &fake.MockBackupRunClient{MockGet: func(_ context.Context, _ string, _ int64) (*sqladmin.BackupRun, error) {
+					return nil, &googleapi.Error{Code: http.StatusNotFound}
+				}},
+			},
+			b: cloudsqlBackup(withBackupInstance(databaseInstanceResourceName), withBackupRun("", backupRunID, "", "")),
+		},
+		"DeleteFailed": {
+			e: &backupExternal{
+				runs: &fake.MockBackupRunClient{
+					MockGet: func(_ context.Context, instance string, id int64) (*sqladmin.BackupRun, error) {
+						return &sqladmin.BackupRun{Id: id, Instance: instance}, nil
+					},
+					MockDelete: func(_ context.Context, _ string, _ int64) error {
+						return errBackupBoom
+					},
+				},
+			},
+			b:    cloudsqlBackup(withBackupInstance(databaseInstanceResourceName), withBackupRun("", backupRunID, "", "")),
+			want: errors.Wrap(errBackupBoom, errDeleteBackup),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.e.Delete(context.Background(), tc.b)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Delete(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
@@ -26,6 +26,7 @@ import (
 
 	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	corev1alpha1 "github.com/crossplaneio/crossplane/apis/core/v1alpha1"
 	databasev1alpha1 "github.com/crossplaneio/crossplane/apis/database/v1alpha1"
 	"github.com/crossplaneio/crossplane/gcp/apis/database/v1alpha1"
 )
@@ -62,6 +63,10 @@ func ConfigurePostgreSQLCloudsqlInstance(_ context.Context, cm resource.Claim, c
 	}
 	spec.DatabaseVersion = v
 
+	if err := configureClaimParameters(spec, rs.SpecTemplate.ClaimParameters, pg.Spec.StorageGB, pg.Spec.InstanceSize); err != nil {
+		return err
+	}
+
 	// NOTE(hasheddan): consider moving defaulting to either CRD or managed reconciler level
 	checkEmptySpec(spec)
 
@@ -107,6 +112,10 @@ func ConfigureMyCloudsqlInstance(_ context.Context, cm resource.Claim, cs resour
 	}
 	spec.DatabaseVersion = v
 
+	if err := configureClaimParameters(spec, rs.SpecTemplate.ClaimParameters, my.Spec.StorageGB, my.Spec.InstanceSize); err != nil {
+		return err
+	}
+
 	// NOTE(hasheddan): consider moving defaulting to either CRD or managed reconciler level
 	checkEmptySpec(spec)
 
@@ -126,6 +135,25 @@ func translateVersion(version, versionPrefix string) string {
 	return fmt.Sprintf("%s_%s", versionPrefix, strings.Replace(version, ".", "_", -1))
 }
 
+// configureClaimParameters applies the storage and instance size requested by
+// an instance claim to the supplied spec, if the class's policy allows them.
+func configureClaimParameters(spec *v1alpha1.CloudsqlInstanceSpec, p *corev1alpha1.ClaimParameterPolicy, storageGB *int64, size string) error {
+	if storageGB != nil {
+		if err := p.AllowStorageGB(*storageGB); err != nil {
+			return err
+		}
+		spec.StorageGB = *storageGB
+	}
+	if size != "" {
+		t, err := p.InstanceType(size)
+		if err != nil {
+			return err
+		}
+		spec.Tier = t
+	}
+	return nil
+}
+
 func checkEmptySpec(spec *v1alpha1.CloudsqlInstanceSpec) {
 	if spec.Labels == nil {
 		spec.Labels = map[string]string{}
@@ -137,3 +165,41 @@ func checkEmptySpec(spec *v1alpha1.CloudsqlInstanceSpec) {
 		spec.StorageGB = v1alpha1.DefaultStorageGB
 	}
 }
+
+// ConfigureCloudsqlDatabase configures the supplied database (presumed to be a
+// CloudsqlDatabase) using the supplied instance claim (presumed to be a
+// MySQLInstance or PostgreSQLInstance) and database class. The claim's engine
+// version is not used; the database uses whatever version its instance runs.
+func ConfigureCloudsqlDatabase(_ context.Context, cm resource.Claim, cs resource.Class, mg resource.Managed) error {
+	switch cm.(type) {
+	case *databasev1alpha1.MySQLInstance, *databasev1alpha1.PostgreSQLInstance:
+	default:
+		return errors.Errorf("expected instance claim %s to be %s or %s", cm.GetName(),
+			databasev1alpha1.MySQLInstanceGroupVersionKind, databasev1alpha1.PostgreSQLInstanceGroupVersionKind)
+	}
+
+	rs, csok := cs.(*v1alpha1.CloudsqlDatabaseClass)
+	if !csok {
+		return errors.Errorf("expected resource class %s to be %s", cs.GetName(), v1alpha1.CloudsqlDatabaseClassGroupVersionKind)
+	}
+
+	d, mgok := mg.(*v1alpha1.CloudsqlDatabase)
+	if !mgok {
+		return errors.Errorf("expected managed resource %s to be %s", mg.GetName(), v1alpha1.CloudsqlDatabaseGroupVersionKind)
+	}
+
+	spec := &v1alpha1.CloudsqlDatabaseSpec{
+		ResourceSpec: runtimev1alpha1.ResourceSpec{
+			ReclaimPolicy: runtimev1alpha1.ReclaimRetain,
+		},
+		CloudsqlDatabaseParameters: rs.SpecTemplate.CloudsqlDatabaseParameters,
+	}
+
+	spec.WriteConnectionSecretToReference = corev1.LocalObjectReference{Name: string(cm.GetUID())}
+	spec.ProviderReference = rs.SpecTemplate.ProviderReference
+	spec.ReclaimPolicy = rs.SpecTemplate.ReclaimPolicy
+
+	d.Spec = *spec
+
+	return nil
+}
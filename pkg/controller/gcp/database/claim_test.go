@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -28,6 +29,7 @@ import (
 	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
 	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	corev1alpha1 "github.com/crossplaneio/crossplane/apis/core/v1alpha1"
 	databasev1alpha1 "github.com/crossplaneio/crossplane/apis/database/v1alpha1"
 	"github.com/crossplaneio/crossplane/gcp/apis/database/v1alpha1"
 )
@@ -35,6 +37,7 @@ import (
 var (
 	_ resource.ManagedConfigurator = resource.ManagedConfiguratorFn(ConfigurePostgreSQLCloudsqlInstance)
 	_ resource.ManagedConfigurator = resource.ManagedConfiguratorFn(ConfigureMyCloudsqlInstance)
+	_ resource.ManagedConfigurator = resource.ManagedConfiguratorFn(ConfigureCloudsqlDatabase)
 )
 
 func TestConfigurePostgreCloudsqlInstance(t *testing.T) {
@@ -122,6 +125,13 @@ func TestConfigureMyCloudsqlInstance(t *testing.T) {
 
 	claimUID := types.UID("definitely-a-uuid")
 	providerName := "coolprovider"
+	storageGB := int64(20)
+	tooMuchStorageGB := int64(200)
+	maxStorageGB := int64(100)
+	policy := &corev1alpha1.ClaimParameterPolicy{
+		StorageGB:     &corev1alpha1.Int64Range{Max: &maxStorageGB},
+		InstanceSizes: map[string]string{"small": "db-n1-standard-1"},
+	}
 
 	cases := map[string]struct {
 		args args
@@ -162,6 +172,77 @@ func TestConfigureMyCloudsqlInstance(t *testing.T) {
 				err: nil,
 			},
 		},
+		"ClaimParameters": {
+			args: args{
+				cm: &databasev1alpha1.MySQLInstance{
+					ObjectMeta: metav1.ObjectMeta{UID: claimUID},
+					Spec: databasev1alpha1.MySQLInstanceSpec{
+						EngineVersion: "5.6",
+						StorageGB:     &storageGB,
+						InstanceSize:  "small",
+					},
+				},
+				cs: &v1alpha1.CloudsqlInstanceClass{
+					SpecTemplate: v1alpha1.CloudsqlInstanceClassSpecTemplate{
+						ResourceClassSpecTemplate: runtimev1alpha1.ResourceClassSpecTemplate{
+							ProviderReference: &corev1.ObjectReference{Name: providerName},
+							ReclaimPolicy:     runtimev1alpha1.ReclaimDelete,
+						},
+						ClaimParameters: policy,
+					},
+				},
+				mg: &v1alpha1.CloudsqlInstance{},
+			},
+			want: want{
+				mg: &v1alpha1.CloudsqlInstance{
+					Spec: v1alpha1.CloudsqlInstanceSpec{
+						ResourceSpec: runtimev1alpha1.ResourceSpec{
+							ReclaimPolicy:                    runtimev1alpha1.ReclaimDelete,
+							WriteConnectionSecretToReference: corev1.LocalObjectReference{Name: string(claimUID)},
+							ProviderReference:                &corev1.ObjectReference{Name: providerName},
+						},
+						CloudsqlInstanceParameters: v1alpha1.CloudsqlInstanceParameters{
+							AuthorizedNetworks: []string{},
+							DatabaseVersion:    "MYSQL_5_6",
+							Labels:             map[string]string{},
+							StorageGB:          storageGB,
+							Tier:               "db-n1-standard-1",
+						},
+					},
+				},
+				err: nil,
+			},
+		},
+		"StorageGBOutOfRange": {
+			args: args{
+				cm: &databasev1alpha1.MySQLInstance{
+					ObjectMeta: metav1.ObjectMeta{UID: claimUID},
+					Spec:       databasev1alpha1.MySQLInstanceSpec{StorageGB: &tooMuchStorageGB},
+				},
+				cs: &v1alpha1.CloudsqlInstanceClass{
+					SpecTemplate: v1alpha1.CloudsqlInstanceClassSpecTemplate{ClaimParameters: policy},
+				},
+				mg: &v1alpha1.CloudsqlInstance{},
+			},
+			want: want{
+				mg:  &v1alpha1.CloudsqlInstance{},
+				err: errors.New("requested storageGB 200 is not allowed by the resource class, which requires at most 100"),
+			},
+		},
+		"InstanceSizeNotAllowed": {
+			args: args{
+				cm: &databasev1alpha1.MySQLInstance{
+					ObjectMeta: metav1.ObjectMeta{UID: claimUID},
+					Spec:       databasev1alpha1.MySQLInstanceSpec{InstanceSize: "small"},
+				},
+				cs: &v1alpha1.CloudsqlInstanceClass{},
+				mg: &v1alpha1.CloudsqlInstance{},
+			},
+			want: want{
+				mg:  &v1alpha1.CloudsqlInstance{},
+				err: errors.New("resource class does not allow claims to request instanceSize"),
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -176,3 +257,87 @@ func TestConfigureMyCloudsqlInstance(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigureCloudsqlDatabase(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		cm  resource.Claim
+		cs  resource.Class
+		mg  resource.Managed
+	}
+
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	claimUID := types.UID("definitely-a-uuid")
+	providerName := "coolprovider"
+	instanceName := "coolinstance"
+
+	class := &v1alpha1.CloudsqlDatabaseClass{
+		SpecTemplate: v1alpha1.CloudsqlDatabaseClassSpecTemplate{
+			ResourceClassSpecTemplate: runtimev1alpha1.ResourceClassSpecTemplate{
+				ProviderReference: &corev1.ObjectReference{Name: providerName},
+				ReclaimPolicy:     runtimev1alpha1.ReclaimDelete,
+			},
+			CloudsqlDatabaseParameters: v1alpha1.CloudsqlDatabaseParameters{
+				InstanceRef: corev1.LocalObjectReference{Name: instanceName},
+				Charset:     "utf8",
+			},
+		},
+	}
+
+	configured := &v1alpha1.CloudsqlDatabase{
+		Spec: v1alpha1.CloudsqlDatabaseSpec{
+			ResourceSpec: runtimev1alpha1.ResourceSpec{
+				ReclaimPolicy:                    runtimev1alpha1.ReclaimDelete,
+				WriteConnectionSecretToReference: corev1.LocalObjectReference{Name: string(claimUID)},
+				ProviderReference:                &corev1.ObjectReference{Name: providerName},
+			},
+			CloudsqlDatabaseParameters: v1alpha1.CloudsqlDatabaseParameters{
+				InstanceRef: corev1.LocalObjectReference{Name: instanceName},
+				Charset:     "utf8",
+			},
+		},
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"MySQLInstance": {
+			args: args{
+				cm: &databasev1alpha1.MySQLInstance{
+					ObjectMeta: metav1.ObjectMeta{UID: claimUID},
+					Spec:       databasev1alpha1.MySQLInstanceSpec{EngineVersion: "5.7"},
+				},
+				cs: class,
+				mg: &v1alpha1.CloudsqlDatabase{},
+			},
+			want: want{mg: configured},
+		},
+		"PostgreSQLInstance": {
+			args: args{
+				cm: &databasev1alpha1.PostgreSQLInstance{
+					ObjectMeta: metav1.ObjectMeta{UID: claimUID},
+				},
+				cs: class,
+				mg: &v1alpha1.CloudsqlDatabase{},
+			},
+			want: want{mg: configured},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ConfigureCloudsqlDatabase(tc.args.ctx, tc.args.cm, tc.args.cs, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ConfigureCloudsqlDatabase(...) Error  -want, +got: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("ConfigureCloudsqlDatabase(...) Managed -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2/google"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane-runtime/pkg/util"
+	databasev1alpha1 "github.com/crossplaneio/crossplane/apis/database/v1alpha1"
+	"github.com/crossplaneio/crossplane/gcp/apis/database/v1alpha1"
+	gcpv1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/gcp"
+	"github.com/crossplaneio/crossplane/pkg/clients/gcp/cloudsql"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
+	"github.com/crossplaneio/crossplane/pkg/clients/sql"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
+	"github.com/crossplaneio/crossplane/pkg/util/googleapi"
+)
+
+// Error strings.
+const (
+	errNewDatabaseClient     = "cannot create new CloudSQL database client"
+	errNotDatabase           = "managed resource is not a CloudSQL database"
+	errGetDatabaseInstance   = "cannot get referenced CloudsqlInstance"
+	errDatabaseInstanceState = "referenced CloudsqlInstance is not yet runnable"
+	errGetDatabase           = "cannot get CloudSQL database"
+	errCreateDatabase        = "cannot create CloudSQL database"
+	errDeleteDatabase        = "cannot delete CloudSQL database"
+	errDatabaseOwnerGranted  = "cannot determine whether CloudSQL database owner exists"
+	errGenerateOwnerPassword = "cannot generate CloudSQL database owner password"
+	errCreateDatabaseOwner   = "cannot create CloudSQL database owner"
+	errDeleteDatabaseOwner   = "cannot delete CloudSQL database owner"
+)
+
+// CloudsqlDatabaseController is responsible for adding the CloudsqlDatabase
+// controller and its corresponding reconciler to the manager with any runtime configuration.
+type CloudsqlDatabaseController struct{}
+
+// SetupWithManager creates a new CloudsqlDatabase Controller and adds it to
+// the Manager with default RBAC. The Manager will set fields on the Controller
+// and start it when the Manager is Started.
+func (c *CloudsqlDatabaseController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s", v1alpha1.CloudsqlDatabaseKind, v1alpha1.Group))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(gcp.ErrorCode))
+	tracer := tracing.NewTracer(name)
+
+	r := resource.NewManagedReconciler(mgr,
+		resource.ManagedKind(v1alpha1.CloudsqlDatabaseGroupVersionKind),
+		resource.WithExternalConnecter(event.NewConnecter(recorder, protection.NewConnecter(tracer.Connecter(&databaseConnecter{client: mgr.GetClient(), pool: pool.Default})))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.CloudsqlDatabase{}).
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &v1alpha1.CloudsqlDatabase{}, r)))
+}
+
+type databaseConnecter struct {
+	client         client.Client
+	pool           *pool.Pool
+	newClientFn    func(ctx context.Context, creds *google.Credentials, hc *http.Client) (cloudsql.DatabaseService, error)
+	newSQLClientFn func(o sql.Options) (sql.Client, error)
+}
+
+func (c *databaseConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	d, ok := mg.(*v1alpha1.CloudsqlDatabase)
+	if !ok {
+		return nil, errors.New(errNotDatabase)
+	}
+
+	p := &gcpv1alpha1.Provider{}
+	n := meta.NamespacedNameOf(d.Spec.ProviderReference)
+	if err := c.client.Get(ctx, n, p); err != nil {
+		return nil, errors.Wrapf(err, "cannot get provider %s", n)
+	}
+
+	newClientFn := newDatabaseClient
+	if c.newClientFn != nil {
+		newClientFn = c.newClientFn
+	}
+	cl, err := gcp.PooledClient(ctx, c.pool, c.client, p, cloudsql.API, "databases", func(creds *google.Credentials, hc *http.Client) (interface{}, error) {
+		// Pooled clients outlive the supplied context.
+		cl, err := newClientFn(context.Background(), creds, hc)
+		return cl, errors.Wrap(err, errNewDatabaseClient)
+	}, cloudsql.DefaultScope)
+	if err != nil {
+		return nil, err
+	}
+	return &databaseExternal{kube: c.client, databases: cl.(cloudsql.DatabaseService), newSQLClientFn: c.newSQLClientFn}, nil
+}
+
+func newDatabaseClient(ctx context.Context, creds *google.Credentials, hc *http.Client) (cloudsql.DatabaseService, error) {
+	return cloudsql.NewDatabaseClient(ctx, creds, hc)
+}
+
+// A databaseExternal manages a CloudsqlDatabase and the user that owns it.
+// Databases are managed via the CloudSQL API, but their owners are managed via
+// SQL, because users created via the API are granted broad privileges.
+type databaseExternal struct {
+	kube           client.Client
+	databases      cloudsql.DatabaseService
+	newSQLClientFn func(o sql.Options) (sql.Client, error)
+}
+
+// instance returns the CloudsqlInstance referenced by the supplied database.
+func (e *databaseExternal) instance(ctx context.Context, d *v1alpha1.CloudsqlDatabase) (*v1alpha1.CloudsqlInstance, error) {
+	i := &v1alpha1.CloudsqlInstance{}
+	n := types.NamespacedName{Namespace: d.GetNamespace(), Name: d.Spec.InstanceRef.Name}
+	return i, errors.Wrap(e.kube.Get(ctx, n, i), errGetDatabaseInstance)
+}
+
+func (e *databaseExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	d, ok := mg.(*v1alpha1.CloudsqlDatabase)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotDatabase)
+	}
+
+	i, err := e.instance(ctx, d)
+	if kerrors.IsNotFound(errors.Cause(err)) && meta.WasDeleted(d) {
+		// Databases cannot outlive the instance they were created in.
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, err
+	}
+	if !i.IsRunnable() {
+		return resource.ExternalObservation{}, errors.New(errDatabaseInstanceState)
+	}
+
+	if _, err := e.databases.Get(ctx, i.GetResourceName(), d.GetDatabaseName()); err != nil {
+		return resource.ExternalObservation{ResourceExists: false}, errors.Wrap(resource.Ignore(googleapi.IsErrorNotFound, err), errGetDatabase)
+	}
+
+	db, err := connectInstance(ctx, e.kube, e.newSQLClientFn, i, "")
+	if err != nil {
+		return resource.ExternalObservation{}, err
+	}
+
+	// A database is not usable until its owner exists and has been granted
+	// privileges on it. Creating the database creates its owner.
+	owner := sql.OwnerName(d.GetUID())
+	granted, err := sql.IsGranted(ctx, db, owner, d.GetDatabaseName())
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, errDatabaseOwnerGranted)
+	}
+	if !granted {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	d.Status.Instance = i.GetResourceName()
+	d.Status.SetConditions(runtimev1alpha1.Available())
+	resource.SetBindable(d)
+
+	// A database's connection details are those of its owner, never the
+	// master credentials of its instance.
+	o := resource.ExternalObservation{
+		ResourceExists: true,
+		ConnectionDetails: resource.ConnectionDetails{
+			runtimev1alpha1.ResourceCredentialsSecretUserKey:      []byte(owner),
+			runtimev1alpha1.ResourceCredentialsSecretEndpointKey:  []byte(i.Status.Endpoint),
+			databasev1alpha1.ResourceCredentialsSecretDatabaseKey: []byte(d.GetDatabaseName()),
+		},
+	}
+	return o, nil
+}
+
+func (e *databaseExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	d, ok := mg.(*v1alpha1.CloudsqlDatabase)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotDatabase)
+	}
+
+	d.Status.SetConditions(runtimev1alpha1.Creating())
+
+	i, err := e.instance(ctx, d)
+	if err != nil {
+		return resource.ExternalCreation{}, err
+	}
+
+	// The database may already exist if we previously failed to create its
+	// owner.
+	_, err = e.databases.Get(ctx, i.GetResourceName(), d.GetDatabaseName())
+	if resource.Ignore(googleapi.IsErrorNotFound, err) != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, errGetDatabase)
+	}
+	if err != nil {
+		db := &sqladmin.Database{Name: d.GetDatabaseName(), Charset: d.Spec.Charset, Collation: d.Spec.Collation}
+		if err := e.databases.Create(ctx, i.GetResourceName(), db); err != nil {
+			return resource.ExternalCreation{}, errors.Wrap(err, errCreateDatabase)
+		}
+	}
+
+	db, err := connectInstance(ctx, e.kube, e.newSQLClientFn, i, "")
+	if err != nil {
+		return resource.ExternalCreation{}, err
+	}
+
+	password, err := util.GeneratePassword(v1alpha1.PasswordLength)
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, errGenerateOwnerPassword)
+	}
+
+	owner := sql.OwnerName(d.GetUID())
+	if err := sql.CreateOwner(ctx, db, owner, password, d.GetDatabaseName()); err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, errCreateDatabaseOwner)
+	}
+
+	c := resource.ExternalCreation{
+		ConnectionDetails: resource.ConnectionDetails{
+			runtimev1alpha1.ResourceCredentialsSecretUserKey:     []byte(owner),
+			runtimev1alpha1.ResourceCredentialsSecretPasswordKey: []byte(password),
+		},
+	}
+	return c, nil
+}
+
+// Update is a no-op; a database's name is its identity, and its charset and
+// collation are only used when it is created.
+func (e *databaseExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	return resource.ExternalUpdate{}, nil
+}
+
+func (e *databaseExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	d, ok := mg.(*v1alpha1.CloudsqlDatabase)
+	if !ok {
+		return errors.New(errNotDatabase)
+	}
+
+	d.Status.SetConditions(runtimev1alpha1.Deleting())
+
+	i, err := e.instance(ctx, d)
+	if kerrors.IsNotFound(errors.Cause(err)) {
+		// Databases cannot outlive the instance they were created in.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	err = e.databases.Delete(ctx, i.GetResourceName(), d.GetDatabaseName())
+	if err := resource.Ignore(googleapi.IsErrorNotFound, err); err != nil {
+		return errors.Wrap(err, errDeleteDatabase)
+	}
+
+	db, err := connectInstance(ctx, e.kube, e.newSQLClientFn, i, "")
+	if err != nil {
+		return err
+	}
+
+	return errors.Wrap(db.DeleteUser(ctx, sql.OwnerName(d.GetUID())), errDeleteDatabaseOwner)
+}
@@ -0,0 +1,475 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/api/googleapi"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	databasev1alpha1 "github.com/crossplaneio/crossplane/apis/database/v1alpha1"
+	"github.com/crossplaneio/crossplane/gcp/apis/database/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/gcp/cloudsql/fake"
+	"github.com/crossplaneio/crossplane/pkg/clients/sql"
+	sqlfake "github.com/crossplaneio/crossplane/pkg/clients/sql/fake"
+)
+
+const (
+	databaseNamespace    = "cool-namespace"
+	databaseName         = "cool-database"
+	databaseInstanceName = "cool-instance"
+	databaseInstanceUID  = "definitely-a-uuid"
+	databaseUID          = types.UID("0b1c1a5e-7f43-11e9-8d3c-42010a800002")
+	databaseSecretName   = "cool-instance-secret"
+	databaseEndpoint     = "10.0.0.1"
+	databaseServerCA     = "cool-ca"
+	databaseMasterName   = "root"
+	databaseMasterPass   = "hunter2"
+)
+
+var (
+	errDatabaseBoom = errors.New("boom")
+
+	databaseInstanceResourceName = "cloudsqlinstance-" + databaseInstanceUID
+	databaseOwner                = sql.OwnerName(databaseUID)
+)
+
+type cloudsqlDatabaseModifier func(*v1alpha1.CloudsqlDatabase)
+
+func withDatabaseConditions(c ...runtimev1alpha1.Condition) cloudsqlDatabaseModifier {
+	return func(d *v1alpha1.CloudsqlDatabase) { d.Status.ConditionedStatus.Conditions = c }
+}
+
+func withDatabaseBindingPhase(p runtimev1alpha1.BindingPhase) cloudsqlDatabaseModifier {
+	return func(d *v1alpha1.CloudsqlDatabase) { d.Status.SetBindingPhase(p) }
+}
+
+func withDatabaseInstance(i string) cloudsqlDatabaseModifier {
+	return func(d *v1alpha1.CloudsqlDatabase) { d.Status.Instance = i }
+}
+
+func withDatabaseDeletionTimestamp(t metav1.Time) cloudsqlDatabaseModifier {
+	return func(d *v1alpha1.CloudsqlDatabase) { d.SetDeletionTimestamp(&t) }
+}
+
+func cloudsqlDatabase(m ...cloudsqlDatabaseModifier) *v1alpha1.CloudsqlDatabase {
+	d := &v1alpha1.CloudsqlDatabase{
+		ObjectMeta: metav1.ObjectMeta{Namespace: databaseNamespace, Name: databaseName, UID: databaseUID},
+		Spec: v1alpha1.CloudsqlDatabaseSpec{
+			CloudsqlDatabaseParameters: v1alpha1.CloudsqlDatabaseParameters{
+				InstanceRef: corev1.LocalObjectReference{Name: databaseInstanceName},
+			},
+		},
+	}
+
+	for _, fn := range m {
+		fn(d)
+	}
+
+	return d
+}
+
+type databaseInstanceModifier func(*v1alpha1.CloudsqlInstance)
+
+func withDatabaseInstanceServerCA(ca string) databaseInstanceModifier {
+	return func(i *v1alpha1.CloudsqlInstance) { i.Status.ServerCACertificate = ca }
+}
+
+func databaseInstance(state string, im ...databaseInstanceModifier) *v1alpha1.CloudsqlInstance {
+	i := &v1alpha1.CloudsqlInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: databaseNamespace, Name: databaseInstanceName, UID: databaseInstanceUID},
+		Spec: v1alpha1.CloudsqlInstanceSpec{
+			ResourceSpec: runtimev1alpha1.ResourceSpec{
+				WriteConnectionSecretToReference: corev1.LocalObjectReference{Name: databaseSecretName},
+			},
+		},
+		Status: v1alpha1.CloudsqlInstanceStatus{State: state, Endpoint: databaseEndpoint, ServerCACertificate: databaseServerCA},
+	}
+
+	for _, m := range im {
+		m(i)
+	}
+
+	return i
+}
+
+func databaseKube(i *v1alpha1.CloudsqlInstance) client.Client {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+			switch o := obj.(type) {
+			case *v1alpha1.CloudsqlInstance:
+				if i == nil {
+					return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+				}
+				*o = *i
+			case *corev1.Secret:
+				o.Data = map[string][]byte{
+					runtimev1alpha1.ResourceCredentialsSecretEndpointKey: []byte(databaseEndpoint),
+					runtimev1alpha1.ResourceCredentialsSecretUserKey:     []byte(databaseMasterName),
+					runtimev1alpha1.ResourceCredentialsSecretPasswordKey: []byte(databaseMasterPass),
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// databaseOwnerSQL returns a function that returns a mock SQL client for an
+// instance in which the supplied owners have been granted privileges on the
+// supplied databases. The client may create and delete owners.
+func databaseOwnerSQL(grants map[string][]string) func(sql.Options) (sql.Client, error) {
+	return func(o sql.Options) (sql.Client, error) {
+		if o.Username != databaseMasterName || o.Password != databaseMasterPass || o.Endpoint != databaseEndpoint {
+			return nil, errors.New("unexpected SQL options")
+		}
+		if !o.TLS || !o.VerifyCAOnly || string(o.CACertificates) != databaseServerCA {
+			return nil, errors.New("unverified SQL connection")
+		}
+		return &sqlfake.MockClient{
+			MockUserExists: func(_ context.Context, name string) (bool, error) {
+				_, ok := grants[name]
+				return ok, nil
+			},
+			MockGrants:     func(_ context.Context, user string) ([]string, error) { return grants[user], nil },
+			MockDeleteUser: func(_ context.Context, _ string) error { return nil },
+			MockCreateUser: func(_ context.Context, name, password string) error {
+				if name != databaseOwner || password == "" {
+					return errors.Errorf("unexpected user %s", name)
+				}
+				return nil
+			},
+			MockGrant: func(_ context.Context, user, database string) error {
+				if user != databaseOwner || database != databaseName {
+					return errors.Errorf("unexpected grant of %s to %s", database, user)
+				}
+				return nil
+			},
+		}, nil
+	}
+}
+
+var _ resource.ExternalConnecter = &databaseConnecter{}
+var _ resource.ExternalClient = &databaseExternal{}
+
+func TestDatabaseObserve(t *testing.T) {
+	deleted := metav1.Now()
+
+	type want struct {
+		o   resource.ExternalObservation
+		d   *v1alpha1.CloudsqlDatabase
+		err error
+	}
+
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		d    *v1alpha1.CloudsqlDatabase
+		want want
+	}{
+		"DatabaseExists": {
+			e: &databaseExternal{
+				kube: databaseKube(databaseInstance(v1alpha1.StateRunnable)),
+				databases: &fake.MockDatabaseClient{MockGet: func(_ context.Context, instance, name string) (*sqladmin.Database, error) {
+					return &sqladmin.Database{Instance: instance, Name: name}, nil
+				}},
+				newSQLClientFn: databaseOwnerSQL(map[string][]string{databaseOwner: {databaseName}}),
+			},
+			d: cloudsqlDatabase(),
+			want: want{
+				o: resource.ExternalObservation{
+					ResourceExists: true,
+					ConnectionDetails: resource.ConnectionDetails{
+						runtimev1alpha1.ResourceCredentialsSecretEndpointKey:  []byte(databaseEndpoint),
+						runtimev1alpha1.ResourceCredentialsSecretUserKey:      []byte(databaseOwner),
+						databasev1alpha1.ResourceCredentialsSecretDatabaseKey: []byte(databaseName),
+					},
+				},
+				d: cloudsqlDatabase(
+					withDatabaseInstance(databaseInstanceResourceName),
+					withDatabaseConditions(runtimev1alpha1.Available()),
+					withDatabaseBindingPhase(runtimev1alpha1.BindingPhaseUnbound),
+				),
+			},
+		},
+		"DatabaseDoesNotExist": {
+			e: &databaseExternal{
+				kube: databaseKube(databaseInstance(v1alpha1.StateRunnable)),
+				databases: &fake.MockDatabaseClient{MockGet: func(_ context.Context, _, _ string) (*sqladmin.Database, error) {
+					return nil, &googleapi.Error{Code: http.StatusNotFound}
+				}},
+			},
+			d:    cloudsqlDatabase(),
+			want: want{o: resource.ExternalObservation{ResourceExists: false}, d: cloudsqlDatabase()},
+		},
+		"OwnerDoesNotExist": {
+			e: &databaseExternal{
+				kube: databaseKube(databaseInstance(v1alpha1.StateRunnable)),
+				databases: &fake.MockDatabaseClient{MockGet: func(_ context.Context, instance, name string) (*sqladmin.Database, error) {
+					return &sqladmin.Database{Instance: instance, Name: name}, nil
+				}},
+				newSQLClientFn: databaseOwnerSQL(nil),
+			},
+			d:    cloudsqlDatabase(),
+			want: want{o: resource.ExternalObservation{ResourceExists: false}, d: cloudsqlDatabase()},
+		},
+		"NewSQLClientFailed": {
+			e: &databaseExternal{
+				kube: databaseKube(databaseInstance(v1alpha1.StateRunnable)),
+				databases: &fake.MockDatabaseClient{MockGet: func(_ context.Context, instance, name string) (*sqladmin.Database, error) {
+					return &sqladmin.Database{Instance: instance, Name: name}, nil
+				}},
+				newSQLClientFn: func(_ sql.Options) (sql.Client, error) { return nil, errDatabaseBoom },
+			},
+			d:    cloudsqlDatabase(),
+			want: want{d: cloudsqlDatabase(), err: errors.Wrap(errDatabaseBoom, errNewSQLClient)},
+		},
+		"InstanceServerCAUnknown": {
+			e: &databaseExternal{
+				kube: databaseKube(databaseInstance(v1alpha1.StateRunnable, withDatabaseInstanceServerCA(""))),
+				databases: &fake.MockDatabaseClient{MockGet: func(_ context.Context, instance, name string) (*sqladmin.Database, error) {
+					return &sqladmin.Database{Instance: instance, Name: name}, nil
+				}},
+				newSQLClientFn: databaseOwnerSQL(nil),
+			},
+			d:    cloudsqlDatabase(),
+			want: want{d: cloudsqlDatabase(), err: errors.New(errNoInstanceCA)},
+		},
+		"InstanceNotRunnable": {
+			e:    &databaseExternal{kube: databaseKube(databaseInstance("PENDING_CREATE"))},
+			d:    cloudsqlDatabase(),
+			want: want{d: cloudsqlDatabase(), err: errors.New(errDatabaseInstanceState)},
+		},
+		"InstanceNotFound": {
+			e:    &databaseExternal{kube: databaseKube(nil)},
+			d:    cloudsqlDatabase(),
+			want: want{d: cloudsqlDatabase(), err: errors.Wrap(kerrors.NewNotFound(schema.GroupResource{}, databaseInstanceName), errGetDatabaseInstance)},
+		},
+		"InstanceNotFoundWhileDeleting": {
+			e:    &databaseExternal{kube: databaseKube(nil)},
+			d:    cloudsqlDatabase(withDatabaseDeletionTimestamp(deleted)),
+			want: want{o: resource.ExternalObservation{ResourceExists: false}, d: cloudsqlDatabase(withDatabaseDeletionTimestamp(deleted))},
+		},
+		"GetDatabaseFailed": {
+			e: &databaseExternal{
+				kube: databaseKube(databaseInstance(v1alpha1.StateRunnable)),
+				databases: &fake.MockDatabaseClient{MockGet: func(_ context.Context, _, _ string) (*sqladmin.Database, error) {
+					return nil, errDatabaseBoom
+				}},
+			},
+			d:    cloudsqlDatabase(),
+			want: want{d: cloudsqlDatabase(), err: errors.Wrap(errDatabaseBoom, errGetDatabase)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.d)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("e.Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.d, tc.d, test.EquateConditions()); diff != "" {
+				t.Errorf("e.Observe(...): -want database, +got database:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDatabaseCreate(t *testing.T) {
+	type want struct {
+		password bool
+		d        *v1alpha1.CloudsqlDatabase
+		err      error
+	}
+
+	notFound := func(_ context.Context, _, _ string) (*sqladmin.Database, error) {
+		return nil, &googleapi.Error{Code: http.StatusNotFound}
+	}
+
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		d    *v1alpha1.CloudsqlDatabase
+		want want
+	}{
+		"Successful": {
+			e: &databaseExternal{
+				kube: databaseKube(databaseInstance(v1alpha1.StateRunnable)),
+				databases: &fake.MockDatabaseClient{
+					MockGet: notFound,
+					MockCreate: func(_ context.Context, instance string, d *sqladmin.Database) error {
+						if instance != databaseInstanceResourceName || d.Name != databaseName {
+							return errors.Errorf("unexpected database %s in instance %s", d.Name, instance)
+						}
+						return nil
+					},
+				},
+				newSQLClientFn: databaseOwnerSQL(nil),
+			},
+			d: cloudsqlDatabase(),
+			want: want{
+				password: true,
+				d:        cloudsqlDatabase(withDatabaseConditions(runtimev1alpha1.Creating())),
+			},
+		},
+		"DatabaseAlreadyExists": {
+			e: &databaseExternal{
+				kube: databaseKube(databaseInstance(v1alpha1.StateRunnable)),
+				databases: &fake.MockDatabaseClient{MockGet: func(_ context.Context, instance, name string) (*sqladmin.Database, error) {
+					return &sqladmin.Database{Instance: instance, Name: name}, nil
+				}},
+				newSQLClientFn: databaseOwnerSQL(nil),
+			},
+			d: cloudsqlDatabase(),
+			want: want{
+				password: true,
+				d:        cloudsqlDatabase(withDatabaseConditions(runtimev1alpha1.Creating())),
+			},
+		},
+		"CreateFailed": {
+			e: &databaseExternal{
+				kube: databaseKube(databaseInstance(v1alpha1.StateRunnable)),
+				databases: &fake.MockDatabaseClient{
+					MockGet:    notFound,
+					MockCreate: func(_ context.Context, _ string, _ *sqladmin.Database) error { return errDatabaseBoom },
+				},
+			},
+			d: cloudsqlDatabase(),
+			want: want{
+				d:   cloudsqlDatabase(withDatabaseConditions(runtimev1alpha1.Creating())),
+				err: errors.Wrap(errDatabaseBoom, errCreateDatabase),
+			},
+		},
+		"CreateOwnerFailed": {
+			e: &databaseExternal{
+				kube: databaseKube(databaseInstance(v1alpha1.StateRunnable)),
+				databases: &fake.MockDatabaseClient{
+					MockGet:    notFound,
+					MockCreate: func(_ context.Context, _ string, _ *sqladmin.Database) error { return nil },
+				},
+				newSQLClientFn: func(_ sql.Options) (sql.Client, error) {
+					return &sqlfake.MockClient{MockDeleteUser: func(_ context.Context, _ string) error { return errDatabaseBoom }}, nil
+				},
+			},
+			d: cloudsqlDatabase(),
+			want: want{
+				d:   cloudsqlDatabase(withDatabaseConditions(runtimev1alpha1.Creating())),
+				err: errors.Wrap(errDatabaseBoom, errCreateDatabaseOwner),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c, err := tc.e.Create(context.Background(), tc.d)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Create(...): -want error, +got error:\n%s", diff)
+			}
+			if got := len(c.ConnectionDetails[runtimev1alpha1.ResourceCredentialsSecretPasswordKey]) > 0; got != tc.want.password {
+				t.Errorf("e.Create(...): want password %t, got %t", tc.want.password, got)
+			}
+			for k, v := range c.ConnectionDetails {
+				if string(v) == databaseMasterPass {
+					t.Errorf("e.Create(...): connection detail %s must not contain the instance's master password", k)
+				}
+			}
+			if diff := cmp.Diff(tc.want.d, tc.d, test.EquateConditions()); diff != "" {
+				t.Errorf("e.Create(...): -want database, +got database:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDatabaseDelete(t *testing.T) {
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		d    *v1alpha1.CloudsqlDatabase
+		want error
+	}{
+		"Successful": {
+			e: &databaseExternal{
+				kube: databaseKube(databaseInstance(v1alpha1.StateRunnable)),
+				databases: &fake.MockDatabaseClient{MockDelete: func(_ context.Context, _, _ string) error {
+					return nil
+				}},
+				newSQLClientFn: databaseOwnerSQL(nil),
+			},
+			d: cloudsqlDatabase(),
+		},
+		"DatabaseNotFound": {
+			e: &databaseExternal{
+				kube: databaseKube(databaseInstance(v1alpha1.StateRunnable)),
+				databases: &fake.MockDatabaseClient{MockDelete: func(_ context.Context, _, _ string) error {
+					return &googleapi.Error{Code: http.StatusNotFound}
+				}},
+				newSQLClientFn: databaseOwnerSQL(nil),
+			},
+			d: cloudsqlDatabase(),
+		},
+		"DeleteOwnerFailed": {
+			e: &databaseExternal{
+				kube: databaseKube(databaseInstance(v1alpha1.StateRunnable)),
+				databases: &fake.MockDatabaseClient{MockDelete: func(_ context.Context, _, _ string) error {
+					return nil
+				}},
+				newSQLClientFn: func(_ sql.Options) (sql.Client, error) {
+					return &sqlfake.MockClient{MockDeleteUser: func(_ context.Context, _ string) error { return errDatabaseBoom }}, nil
+				},
+			},
+			d:    cloudsqlDatabase(),
+			want: errors.Wrap(errDatabaseBoom, errDeleteDatabaseOwner),
+		},
+		"InstanceNotFound": {
+			e: &databaseExternal{kube: databaseKube(nil)},
+			d: cloudsqlDatabase(),
+		},
+		"DeleteFailed": {
+			e: &databaseExternal{
+				kube: databaseKube(databaseInstance(v1alpha1.StateRunnable)),
+				databases: &fake.MockDatabaseClient{MockDelete: func(_ context.Context, _, _ string) error {
+					return errDatabaseBoom
+				}},
+			},
+			d:    cloudsqlDatabase(),
+			want: errors.Wrap(errDatabaseBoom, errDeleteDatabase),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.e.Delete(context.Background(), tc.d)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Delete(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
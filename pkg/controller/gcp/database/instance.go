@@ -18,23 +18,29 @@ package database
 
 import (
 	"context"
+	"net/http"
+	"reflect"
 	"time"
 
 	"github.com/pkg/errors"
 	"golang.org/x/oauth2/google"
 	sqladmin "google.golang.org/api/sqladmin/v1beta4"
-	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
 	"github.com/crossplaneio/crossplane-runtime/pkg/logging"
 	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
 	"github.com/crossplaneio/crossplane/gcp/apis/database/v1alpha1"
 	gcpv1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/gcp"
 	"github.com/crossplaneio/crossplane/pkg/clients/gcp/cloudsql"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/reference"
 	"github.com/crossplaneio/crossplane/pkg/util/googleapi"
 )
 
@@ -87,6 +93,25 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 		return requeueNever, handleNotFound(err)
 	}
 
+	// resolve references to other managed resources, e.g. a private network
+	if !meta.WasDeleted(i) && len(i.GetReferencers()) > 0 {
+		existing := i.DeepCopy()
+		if err := reference.Resolve(ctx, r.client, i); err != nil {
+			if reference.IsNotReady(err) {
+				i.Status.SetConditions(reference.WaitingForReferences(err))
+				return requeueWait, r.client.Status().Update(ctx, i)
+			}
+			i.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+			return requeueNow, r.client.Status().Update(ctx, i)
+		}
+		if !reflect.DeepEqual(existing.Spec, i.Spec) {
+			if err := r.client.Update(ctx, i); err != nil {
+				return requeueNow, errors.Wrap(err, "cannot update instance with resolved references")
+			}
+		}
+		i.Status.SetConditions(reference.ReferencesResolved())
+	}
+
 	// create local operations to handle Kubernetes (local) types operations
 	lops := r.factory.makeLocalOperations(i, r.client)
 
@@ -100,6 +125,9 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 	sd := r.factory.makeSyncDeleter(mops)
 
 	if meta.WasDeleted(i) {
+		if err := protection.Check(i); err != nil {
+			return requeueNow, lops.updateReconcileStatus(ctx, err)
+		}
 		return sd.delete(ctx)
 	}
 
@@ -114,12 +142,16 @@ type factory interface {
 
 type operationsFactory struct {
 	client.Client
+	pool   *pool.Pool
+	record *event.Recorder
 }
 
 var _ factory = &operationsFactory{}
 
 func (f *operationsFactory) makeLocalOperations(inst *v1alpha1.CloudsqlInstance, kube client.Client) localOperations {
-	return newLocalHandler(inst, kube)
+	h := newLocalHandler(inst, kube)
+	h.record = f.record
+	return h
 }
 
 func (f *operationsFactory) makeManagedOperations(ctx context.Context, inst *v1alpha1.CloudsqlInstance, ops localOperations) (managedOperations, error) {
@@ -129,18 +161,17 @@ func (f *operationsFactory) makeManagedOperations(ctx context.Context, inst *v1a
 		return nil, err
 	}
 
-	s := &corev1.Secret{}
-	n = types.NamespacedName{Namespace: p.GetNamespace(), Name: p.Spec.Secret.Name}
-	if err := f.Get(ctx, n, s); err != nil {
-		return nil, errors.Wrapf(err, "cannot get provider's secret %s", n)
-	}
-
-	creds, err := google.CredentialsFromJSON(ctx, s.Data[p.Spec.Secret.Key], cloudsql.DefaultScope)
+	c, err := gcp.PooledClient(ctx, f.pool, f.Client, p, cloudsql.API, "instances", func(creds *google.Credentials, hc *http.Client) (interface{}, error) {
+		// Pooled clients outlive the supplied context.
+		return newManagedClients(context.Background(), creds, hc)
+	}, cloudsql.DefaultScope)
 	if err != nil {
-		return nil, errors.Wrapf(err, "cannot retrieve creds from json")
+		return nil, err
 	}
 
-	return newManagedHandler(ctx, inst, ops, creds)
+	h := newManagedHandler(inst, ops, c.(*managedClients))
+	h.record = f.record
+	return h, nil
 }
 
 func (f *operationsFactory) makeSyncDeleter(ops managedOperations) syncdeleter {
@@ -162,6 +193,15 @@ type instanceSyncDeleter struct {
 
 func (sd *instanceSyncDeleter) delete(ctx context.Context) (reconcile.Result, error) {
 	if sd.isReclaimDelete() {
+		if sd.needsFinalExport() {
+			exported, err := sd.exportInstance(ctx)
+			if err != nil {
+				return requeueNow, sd.updateReconcileStatus(ctx, err)
+			}
+			if !exported {
+				return requeueWait, sd.updateReconcileStatus(ctx, nil)
+			}
+		}
 		if err := handleNotFound(sd.deleteInstance(ctx)); err != nil {
 			return requeueNow, sd.updateReconcileStatus(ctx, err)
 		}
@@ -221,6 +261,10 @@ func (ih *instanceCreateUpdater) update(ctx context.Context, inst *sqladmin.Data
 		return requeueWait, ih.updateReconcileStatus(ctx, nil)
 	}
 
+	if ih.needsRestore() {
+		return requeueWait, ih.updateReconcileStatus(ctx, ih.restoreInstance(ctx))
+	}
+
 	// NOTE: needsUpdate(...) always returns false, for details see needsUpdate function call
 	if ih.needsUpdate(inst) {
 		return requeueNow, ih.updateReconcileStatus(ctx, ih.updateInstance(ctx))
@@ -21,6 +21,7 @@ import (
 	"github.com/crossplaneio/crossplane-runtime/pkg/test"
 	"github.com/crossplaneio/crossplane/gcp/apis/database/v1alpha1"
 	gcpv1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/reference"
 )
 
 type mockCreateUpdater struct {
@@ -127,12 +128,34 @@ func Test_instanceCreateUpdater_update(t *testing.T) {
 				res: requeueWait,
 			},
 		},
+		"InstanceNeedsRestore": {
+			fields: fields{
+				operations: &mockManagedOperations{
+					localOperations: &mockLocalOperations{
+						mockUpdateInstanceStatus: func(ctx context.Context, di *sqladmin.DatabaseInstance) error { return nil },
+						mockIsInstanceReady:      func() bool { return true },
+						mockNeedsRestore:         func() bool { return true },
+						mockUpdateReconcileStatus: func(ctx context.Context, e error) error {
+							return assertUpdateReconcileStatusSuccess(t, e)
+						},
+					},
+					mockRestoreInstance: func(ctx context.Context) error { return nil },
+				},
+			},
+			args: args{
+				inst: &sqladmin.DatabaseInstance{},
+			},
+			want: want{
+				res: requeueWait,
+			},
+		},
 		"InstanceNeedsAnUpdate": {
 			fields: fields{
 				operations: &mockManagedOperations{
 					localOperations: &mockLocalOperations{
 						mockUpdateInstanceStatus: func(ctx context.Context, di *sqladmin.DatabaseInstance) error { return nil },
 						mockIsInstanceReady:      func() bool { return true },
+						mockNeedsRestore:         func() bool { return false },
 						mockNeedUpdate:           func(di *sqladmin.DatabaseInstance) bool { return true },
 						mockUpdateReconcileStatus: func(ctx context.Context, e error) error {
 							return assertUpdateReconcileStatusSuccess(t, e)
@@ -154,6 +177,7 @@ func Test_instanceCreateUpdater_update(t *testing.T) {
 					localOperations: &mockLocalOperations{
 						mockUpdateInstanceStatus: func(ctx context.Context, di *sqladmin.DatabaseInstance) error { return nil },
 						mockIsInstanceReady:      func() bool { return true },
+						mockNeedsRestore:         func() bool { return false },
 						mockNeedUpdate:           func(di *sqladmin.DatabaseInstance) bool { return false },
 						mockUpdateReconcileStatus: func(ctx context.Context, e error) error {
 							if e != nil {
@@ -365,6 +389,7 @@ func Test_instanceSyncDeleter_delete(t *testing.T) {
 					mockDeleteInstance: func(ctx context.Context) error { return errTest },
 					localOperations: &mockLocalOperations{
 						mockIsReclaimDelete: func() bool { return true },
+						mockNeedsExport:     func() bool { return false },
 						mockUpdateReconcileStatus: func(ctx context.Context, e error) error {
 							if diff := cmp.Diff(errTest, e, test.EquateErrors()); diff != "" {
 								t.Errorf("delete() error %s", diff)
@@ -389,6 +414,7 @@ func Test_instanceSyncDeleter_delete(t *testing.T) {
 					},
 					localOperations: &mockLocalOperations{
 						mockIsReclaimDelete: func() bool { return true },
+						mockNeedsExport:     func() bool { return false },
 						mockRemoveFinalizer: func(ctx context.Context) error { return nil },
 					},
 				},
@@ -404,6 +430,61 @@ func Test_instanceSyncDeleter_delete(t *testing.T) {
 					mockDeleteInstance: func(ctx context.Context) error { return nil },
 					localOperations: &mockLocalOperations{
 						mockIsReclaimDelete: func() bool { return true },
+						mockNeedsExport:     func() bool { return false },
+						mockRemoveFinalizer: func(ctx context.Context) error { return nil },
+					},
+				},
+				createupdater: nil,
+			},
+			want: want{
+				res: requeueNow,
+			},
+		},
+		"FinalExportError": {
+			fields: fields{
+				operations: &mockManagedOperations{
+					mockExportInstance: func(ctx context.Context) (bool, error) { return false, errTest },
+					localOperations: &mockLocalOperations{
+						mockIsReclaimDelete: func() bool { return true },
+						mockNeedsExport:     func() bool { return true },
+						mockUpdateReconcileStatus: func(ctx context.Context, e error) error {
+							if diff := cmp.Diff(errTest, e, test.EquateErrors()); diff != "" {
+								t.Errorf("delete() error %s", diff)
+							}
+							return nil
+						},
+					},
+				},
+				createupdater: nil,
+			},
+			want: want{
+				res: requeueNow,
+			},
+		},
+		"FinalExportRunning": {
+			fields: fields{
+				operations: &mockManagedOperations{
+					mockExportInstance: func(ctx context.Context) (bool, error) { return false, nil },
+					localOperations: &mockLocalOperations{
+						mockIsReclaimDelete:       func() bool { return true },
+						mockNeedsExport:           func() bool { return true },
+						mockUpdateReconcileStatus: func(ctx context.Context, e error) error { return assertUpdateReconcileStatusSuccess(t, e) },
+					},
+				},
+				createupdater: nil,
+			},
+			want: want{
+				res: requeueWait,
+			},
+		},
+		"FinalExported": {
+			fields: fields{
+				operations: &mockManagedOperations{
+					mockExportInstance: func(ctx context.Context) (bool, error) { return true, nil },
+					mockDeleteInstance: func(ctx context.Context) error { return nil },
+					localOperations: &mockLocalOperations{
+						mockIsReclaimDelete: func() bool { return true },
+						mockNeedsExport:     func() bool { return true },
 						mockRemoveFinalizer: func(ctx context.Context) error { return nil },
 					},
 				},
@@ -540,7 +621,7 @@ func Test_operationsFactory_makeManagedOperations(t *testing.T) {
 				inst: mockInstance,
 			},
 			want: want{
-				err: errors.Wrapf(errTest, "cannot get provider's secret default/test-provider-secret"),
+				err: errors.Wrapf(errTest, "cannot get provider secret default/test-provider-secret"),
 				ops: nil,
 			},
 		},
@@ -656,6 +737,33 @@ func TestReconciler_Reconcile(t *testing.T) {
 				res: requeueNow,
 			},
 		},
+		"WaitingForReferences": {
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+						inst, ok := obj.(*v1alpha1.CloudsqlInstance)
+						if !ok {
+							return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+						}
+						inst.Spec.PrivateNetworkRef = &v1alpha1.NetworkURIReferencerForCloudsqlInstance{}
+						inst.Spec.PrivateNetworkRef.Name = "cool-network"
+						return nil
+					},
+					MockStatusUpdate: func(ctx context.Context, obj runtime.Object, _ ...client.UpdateOption) error {
+						inst := assert(t, obj)
+						if got := inst.Status.GetCondition(reference.TypeReferencesResolved); got.Reason != reference.ReasonWaitingForReferences {
+							t.Errorf("Reconcile() unexpected ready condition: %v", got)
+						}
+						return nil
+					},
+				},
+			},
+			args: args{request: reconcile.Request{NamespacedName: testKey}},
+			want: want{
+				err: nil,
+				res: requeueWait,
+			},
+		},
 		"Deleting": {
 			fields: fields{
 				kube: &test.MockClient{
@@ -34,15 +34,22 @@ import (
 	"github.com/crossplaneio/crossplane-runtime/pkg/util"
 	"github.com/crossplaneio/crossplane/gcp/apis/database/v1alpha1"
 	"github.com/crossplaneio/crossplane/pkg/clients/gcp/cloudsql"
+	"github.com/crossplaneio/crossplane/pkg/event"
 )
 
+// operationStatusDone is the status of a finished Cloud SQL operation.
+const operationStatusDone = "DONE"
+
 type localOperations interface {
 	// Bucket object managedOperations
 	addFinalizer(context.Context) error
 	isReclaimDelete() bool
 	isInstanceReady() bool
 	needsUpdate(*sqladmin.DatabaseInstance) bool
+	needsRestore() bool
+	needsFinalExport() bool
 	removeFinalizer(context.Context) error
+	restoreContext(context.Context) (*sqladmin.RestoreBackupContext, error)
 
 	// Controller-runtime managedOperations
 	updateObject(ctx context.Context) error
@@ -54,6 +61,7 @@ type localOperations interface {
 type localHandler struct {
 	*v1alpha1.CloudsqlInstance
 	client client.Client
+	record *event.Recorder
 }
 
 var _ localOperations = &localHandler{}
@@ -95,12 +103,37 @@ func (h *localHandler) needsUpdate(actual *sqladmin.DatabaseInstance) bool {
 	//  consider using cmp.Equal to determine whether an update is required
 }
 
+func (h *localHandler) needsRestore() bool {
+	return h.Spec.RestoreFrom != nil && !h.Status.Restored
+}
+
+func (h *localHandler) needsFinalExport() bool {
+	return h.Spec.FinalExport != nil
+}
+
+// restoreContext returns the backup run this instance should be restored from.
+func (h *localHandler) restoreContext(ctx context.Context) (*sqladmin.RestoreBackupContext, error) {
+	b := &v1alpha1.CloudsqlBackup{}
+	n := types.NamespacedName{Namespace: h.GetNamespace(), Name: h.Spec.RestoreFrom.BackupRef.Name}
+	if err := h.client.Get(ctx, n, b); err != nil {
+		return nil, errors.Wrapf(err, "cannot get backup %s", n)
+	}
+	if b.Status.State != v1alpha1.BackupStateSuccessful {
+		return nil, errors.Errorf("backup %s is not yet successful", n)
+	}
+	return &sqladmin.RestoreBackupContext{BackupRunId: b.Status.BackupRunID, InstanceId: b.Status.Instance}, nil
+}
+
 func (h *localHandler) updateObject(ctx context.Context) error {
 	return h.client.Update(ctx, h.CloudsqlInstance)
 }
 
 func (h *localHandler) updateInstanceStatus(ctx context.Context, inst *sqladmin.DatabaseInstance) error {
+	wasReady := event.Ready(h.CloudsqlInstance)
 	h.SetStatus(inst)
+	if !wasReady && event.Ready(h.CloudsqlInstance) {
+		h.record.Normal(h.CloudsqlInstance, event.ReasonBecameReady, "External resource became ready")
+	}
 	return h.client.Status().Update(ctx, h.CloudsqlInstance)
 }
 
@@ -109,6 +142,7 @@ func (h *localHandler) updateReconcileStatus(ctx context.Context, err error) err
 		h.Status.SetConditions(runtimev1alpha1.ReconcileSuccess())
 	} else {
 		h.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+		h.record.Failed(h.CloudsqlInstance, err)
 	}
 	return h.client.Status().Update(ctx, h.CloudsqlInstance)
 }
@@ -156,6 +190,8 @@ type managedOperations interface {
 	createInstance(ctx context.Context) error
 	updateInstance(ctx context.Context) error
 	deleteInstance(ctx context.Context) error
+	restoreInstance(ctx context.Context) error
+	exportInstance(ctx context.Context) (bool, error)
 
 	// DatabaseUser managedOperations
 	updateUserCreds(ctx context.Context) error
@@ -166,25 +202,36 @@ type managedHandler struct {
 	localOperations
 	instance cloudsql.InstanceService
 	user     cloudsql.UserService
+	record   *event.Recorder
 }
 
 var _ managedOperations = &managedHandler{}
 
-func newManagedHandler(ctx context.Context, inst *v1alpha1.CloudsqlInstance, tops localOperations, creds *google.Credentials) (*managedHandler, error) {
-	instClient, err := cloudsql.NewInstanceClient(ctx, creds)
+// managedClients are the CloudSQL API clients used by a managedHandler.
+type managedClients struct {
+	instance cloudsql.InstanceService
+	user     cloudsql.UserService
+}
+
+func newManagedClients(ctx context.Context, creds *google.Credentials, hc *http.Client) (*managedClients, error) {
+	instClient, err := cloudsql.NewInstanceClient(ctx, creds, hc)
 	if err != nil {
 		return nil, err
 	}
-	userClient, err := cloudsql.NewUserClient(ctx, creds)
+	userClient, err := cloudsql.NewUserClient(ctx, creds, hc)
 	if err != nil {
 		return nil, err
 	}
+	return &managedClients{instance: instClient, user: userClient}, nil
+}
+
+func newManagedHandler(inst *v1alpha1.CloudsqlInstance, tops localOperations, c *managedClients) *managedHandler {
 	return &managedHandler{
 		CloudsqlInstance: inst,
 		localOperations:  tops,
-		instance:         instClient,
-		user:             userClient,
-	}, nil
+		instance:         c.instance,
+		user:             c.user,
+	}
 }
 
 func (h *managedHandler) getInstance(ctx context.Context) (*sqladmin.DatabaseInstance, error) {
@@ -197,16 +244,76 @@ func (h *managedHandler) getInstance(ctx context.Context) (*sqladmin.DatabaseIns
 
 func (h *managedHandler) createInstance(ctx context.Context) error {
 	h.Status.SetConditions(runtimev1alpha1.Creating())
-	return h.instance.Create(ctx, h.DatabaseInstance(h.GetResourceName()))
+	if err := h.instance.Create(ctx, h.DatabaseInstance(h.GetResourceName())); err != nil {
+		return err
+	}
+	h.record.Normal(h.CloudsqlInstance, event.ReasonCreateRequested, "Requested creation of external resource")
+	return nil
 }
 
 func (h *managedHandler) updateInstance(ctx context.Context) error {
 	name := h.GetResourceName()
-	return h.instance.Update(ctx, name, h.DatabaseInstance(name))
+	if err := h.instance.Update(ctx, name, h.DatabaseInstance(name)); err != nil {
+		return err
+	}
+	h.record.Normal(h.CloudsqlInstance, event.ReasonUpdateApplied, "Applied update to external resource")
+	return nil
 }
 
 func (h *managedHandler) deleteInstance(ctx context.Context) error {
-	return h.instance.Delete(ctx, h.GetResourceName())
+	if err := h.instance.Delete(ctx, h.GetResourceName()); err != nil {
+		return err
+	}
+	h.record.Normal(h.CloudsqlInstance, event.ReasonDeletionStarted, "Requested deletion of external resource")
+	return nil
+}
+
+// restoreInstance overwrites the data of this instance with that of the backup
+// it should be restored from. Restoring also overwrites the instance's users,
+// so the root password is reset by updateUserCreds afterwards.
+func (h *managedHandler) restoreInstance(ctx context.Context) error {
+	rc, err := h.restoreContext(ctx)
+	if err != nil {
+		return err
+	}
+	if err := h.instance.RestoreBackup(ctx, h.GetResourceName(), rc); err != nil {
+		return errors.Wrapf(err, "failed to restore backup")
+	}
+	h.Status.Restored = true
+	h.record.Normal(h.CloudsqlInstance, event.ReasonUpdateApplied, "Restored external resource from backup")
+	return nil
+}
+
+// exportInstance exports the data of this instance to its final export URI,
+// and returns true once the export has succeeded or there is no instance left
+// to export. A failed export is started again on the next call.
+func (h *managedHandler) exportInstance(ctx context.Context) (bool, error) {
+	if h.Status.FinalExportOperation == "" {
+		ec := &sqladmin.ExportContext{FileType: "SQL", Uri: h.Spec.FinalExport.URI, Databases: h.Spec.FinalExport.Databases}
+		op, err := h.instance.Export(ctx, h.GetResourceName(), ec)
+		if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == http.StatusNotFound {
+			return true, nil
+		}
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to export instance")
+		}
+		h.Status.FinalExportOperation = op
+		h.record.Normal(h.CloudsqlInstance, event.ReasonUpdateApplied, "Requested final export of external resource")
+		return false, nil
+	}
+
+	op, err := h.instance.GetOperation(ctx, h.Status.FinalExportOperation)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get export operation")
+	}
+	if op.Status != operationStatusDone {
+		return false, nil
+	}
+	if op.Error != nil && len(op.Error.Errors) > 0 {
+		h.Status.FinalExportOperation = ""
+		return false, errors.Errorf("failed to export instance: %s", op.Error.Errors[0].Message)
+	}
+	return true, nil
 }
 
 func (h *managedHandler) getUser(ctx context.Context) (*sqladmin.User, error) {
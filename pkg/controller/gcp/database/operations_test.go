@@ -75,7 +75,10 @@ type mockLocalOperations struct {
 	mockIsReclaimDelete func() bool
 	mockIsInstanceReady func() bool
 	mockNeedUpdate      func(*sqladmin.DatabaseInstance) bool
+	mockNeedsRestore    func() bool
+	mockNeedsExport     func() bool
 	mockRemoveFinalizer func(context.Context) error
+	mockRestoreContext  func(context.Context) (*sqladmin.RestoreBackupContext, error)
 
 	// Controller-runtime managedOperations
 	mockUpdateObject           func(context.Context) error
@@ -98,9 +101,18 @@ func (m *mockLocalOperations) isInstanceReady() bool {
 func (m *mockLocalOperations) needsUpdate(di *sqladmin.DatabaseInstance) bool {
 	return m.mockNeedUpdate(di)
 }
+func (m *mockLocalOperations) needsRestore() bool {
+	return m.mockNeedsRestore()
+}
+func (m *mockLocalOperations) needsFinalExport() bool {
+	return m.mockNeedsExport()
+}
 func (m *mockLocalOperations) removeFinalizer(ctx context.Context) error {
 	return m.mockRemoveFinalizer(ctx)
 }
+func (m *mockLocalOperations) restoreContext(ctx context.Context) (*sqladmin.RestoreBackupContext, error) {
+	return m.mockRestoreContext(ctx)
+}
 func (m *mockLocalOperations) updateObject(ctx context.Context) error {
 	return m.mockUpdateObject(ctx)
 }
@@ -118,10 +130,12 @@ type mockManagedOperations struct {
 	localOperations
 
 	// DatabaseInstance managedOperations
-	mockGetInstance    func(context.Context) (*sqladmin.DatabaseInstance, error)
-	mockCreateInstance func(context.Context) error
-	mockUpdateInstance func(context.Context) error
-	mockDeleteInstance func(context.Context) error
+	mockGetInstance     func(context.Context) (*sqladmin.DatabaseInstance, error)
+	mockCreateInstance  func(context.Context) error
+	mockUpdateInstance  func(context.Context) error
+	mockDeleteInstance  func(context.Context) error
+	mockRestoreInstance func(context.Context) error
+	mockExportInstance  func(context.Context) (bool, error)
 
 	// DatabaseUser managedOperations
 	mockUpdateUserCreds func(context.Context) error
@@ -141,6 +155,12 @@ func (m *mockManagedOperations) updateInstance(ctx context.Context) error {
 func (m *mockManagedOperations) deleteInstance(ctx context.Context) error {
 	return m.mockDeleteInstance(ctx)
 }
+func (m *mockManagedOperations) restoreInstance(ctx context.Context) error {
+	return m.mockRestoreInstance(ctx)
+}
+func (m *mockManagedOperations) exportInstance(ctx context.Context) (bool, error) {
+	return m.mockExportInstance(ctx)
+}
 func (m *mockManagedOperations) updateUserCreds(ctx context.Context) error {
 	return m.mockUpdateUserCreds(ctx)
 }
@@ -464,6 +484,107 @@ func Test_localHandler_needUpdate(t *testing.T) {
 	}
 }
 
+func Test_localHandler_needsRestore(t *testing.T) {
+	restoreFrom := &v1alpha1.CloudsqlInstanceRestoreSource{BackupRef: core.LocalObjectReference{Name: "cool-backup"}}
+	tests := map[string]struct {
+		inst *v1alpha1.CloudsqlInstance
+		want bool
+	}{
+		"Default": {
+			inst: &v1alpha1.CloudsqlInstance{},
+			want: false,
+		},
+		"NotYetRestored": {
+			inst: &v1alpha1.CloudsqlInstance{
+				Spec: v1alpha1.CloudsqlInstanceSpec{
+					CloudsqlInstanceParameters: v1alpha1.CloudsqlInstanceParameters{RestoreFrom: restoreFrom},
+				},
+			},
+			want: true,
+		},
+		"Restored": {
+			inst: &v1alpha1.CloudsqlInstance{
+				Spec: v1alpha1.CloudsqlInstanceSpec{
+					CloudsqlInstanceParameters: v1alpha1.CloudsqlInstanceParameters{RestoreFrom: restoreFrom},
+				},
+				Status: v1alpha1.CloudsqlInstanceStatus{Restored: true},
+			},
+			want: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ih := &localHandler{
+				CloudsqlInstance: tt.inst,
+			}
+			if got := ih.needsRestore(); got != tt.want {
+				t.Errorf("needsRestore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_localHandler_restoreContext(t *testing.T) {
+	inst := &v1alpha1.CloudsqlInstance{
+		ObjectMeta: testMeta,
+		Spec: v1alpha1.CloudsqlInstanceSpec{
+			CloudsqlInstanceParameters: v1alpha1.CloudsqlInstanceParameters{
+				RestoreFrom: &v1alpha1.CloudsqlInstanceRestoreSource{BackupRef: core.LocalObjectReference{Name: "cool-backup"}},
+			},
+		},
+	}
+	backupKube := func(state string) client.Client {
+		return &test.MockClient{
+			MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+				if key != (types.NamespacedName{Namespace: testNs, Name: "cool-backup"}) {
+					return errors.Errorf("unexpected key %s", key)
+				}
+				b := obj.(*v1alpha1.CloudsqlBackup)
+				b.Status.State = state
+				b.Status.Instance = "source-instance"
+				b.Status.BackupRunID = 42
+				return nil
+			},
+		}
+	}
+	type want struct {
+		rc  *sqladmin.RestoreBackupContext
+		err error
+	}
+	tests := map[string]struct {
+		kube client.Client
+		want want
+	}{
+		"Successful": {
+			kube: backupKube(v1alpha1.BackupStateSuccessful),
+			want: want{rc: &sqladmin.RestoreBackupContext{BackupRunId: 42, InstanceId: "source-instance"}},
+		},
+		"BackupNotSuccessful": {
+			kube: backupKube("RUNNING"),
+			want: want{err: errors.Errorf("backup %s is not yet successful", types.NamespacedName{Namespace: testNs, Name: "cool-backup"})},
+		},
+		"GetBackupFailed": {
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(errTest)},
+			want: want{err: errors.Wrapf(errTest, "cannot get backup %s", types.NamespacedName{Namespace: testNs, Name: "cool-backup"})},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ih := &localHandler{
+				CloudsqlInstance: inst,
+				client:           tt.kube,
+			}
+			rc, err := ih.restoreContext(context.Background())
+			if diff := cmp.Diff(tt.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("restoreContext() error -want, +got: %s", diff)
+			}
+			if diff := cmp.Diff(tt.want.rc, rc); diff != "" {
+				t.Errorf("restoreContext() -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
 func Test_localHandler_updateObject(t *testing.T) {
 	type fields struct {
 		obj  *v1alpha1.CloudsqlInstance
@@ -1130,6 +1251,172 @@ func Test_managedHandler_deleteInstance(t *testing.T) {
 	}
 }
 
+func Test_managedHandler_restoreInstance(t *testing.T) {
+	rc := &sqladmin.RestoreBackupContext{BackupRunId: 42, InstanceId: "source-instance"}
+	type want struct {
+		restored bool
+		err      error
+	}
+	tests := map[string]struct {
+		ops      localOperations
+		instance cloudsql.InstanceService
+		want     want
+	}{
+		"Successful": {
+			ops: &mockLocalOperations{
+				mockRestoreContext: func(_ context.Context) (*sqladmin.RestoreBackupContext, error) { return rc, nil },
+			},
+			instance: &fake.MockInstanceClient{
+				MockRestoreBackup: func(_ context.Context, name string, got *sqladmin.RestoreBackupContext) error {
+					if diff := cmp.Diff(getExpectedInstanceName(testUID), name); diff != "" {
+						t.Errorf("restoreInstance() name -want, +got: %s", diff)
+					}
+					if diff := cmp.Diff(rc, got); diff != "" {
+						t.Errorf("restoreInstance() context -want, +got: %s", diff)
+					}
+					return nil
+				},
+			},
+			want: want{restored: true},
+		},
+		"RestoreContextFailed": {
+			ops: &mockLocalOperations{
+				mockRestoreContext: func(_ context.Context) (*sqladmin.RestoreBackupContext, error) { return nil, errTest },
+			},
+			want: want{err: errTest},
+		},
+		"RestoreBackupFailed": {
+			ops: &mockLocalOperations{
+				mockRestoreContext: func(_ context.Context) (*sqladmin.RestoreBackupContext, error) { return rc, nil },
+			},
+			instance: &fake.MockInstanceClient{
+				MockRestoreBackup: func(_ context.Context, _ string, _ *sqladmin.RestoreBackupContext) error { return errTest },
+			},
+			want: want{err: errors.Wrapf(errTest, "failed to restore backup")},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ih := &managedHandler{
+				CloudsqlInstance: &v1alpha1.CloudsqlInstance{ObjectMeta: testMeta},
+				localOperations:  tt.ops,
+				instance:         tt.instance,
+			}
+			if diff := cmp.Diff(tt.want.err, ih.restoreInstance(context.Background()), test.EquateErrors()); diff != "" {
+				t.Errorf("restoreInstance() error -want, +got: %s", diff)
+			}
+			if ih.Status.Restored != tt.want.restored {
+				t.Errorf("restoreInstance() restored = %v, want %v", ih.Status.Restored, tt.want.restored)
+			}
+		})
+	}
+}
+
+func Test_managedHandler_exportInstance(t *testing.T) {
+	export := &v1alpha1.CloudsqlInstanceExport{URI: "gs://cool-bucket/cool.sql"}
+	type want struct {
+		exported  bool
+		operation string
+		err       error
+	}
+	tests := map[string]struct {
+		operation string
+		instance  cloudsql.InstanceService
+		want      want
+	}{
+		"Started": {
+			instance: &fake.MockInstanceClient{
+				MockExport: func(_ context.Context, name string, got *sqladmin.ExportContext) (string, error) {
+					if diff := cmp.Diff(getExpectedInstanceName(testUID), name); diff != "" {
+						t.Errorf("exportInstance() name -want, +got: %s", diff)
+					}
+					if diff := cmp.Diff(&sqladmin.ExportContext{FileType: "SQL", Uri: export.URI}, got); diff != "" {
+						t.Errorf("exportInstance() context -want, +got: %s", diff)
+					}
+					return "cool-operation", nil
+				},
+			},
+			want: want{operation: "cool-operation"},
+		},
+		"StartFailed": {
+			instance: &fake.MockInstanceClient{
+				MockExport: func(_ context.Context, _ string, _ *sqladmin.ExportContext) (string, error) { return "", errTest },
+			},
+			want: want{err: errors.Wrapf(errTest, "failed to export instance")},
+		},
+		"InstanceNotFound": {
+			instance: &fake.MockInstanceClient{
+				MockExport: func(_ context.Context, _ string, _ *sqladmin.ExportContext) (string, error) {
+					return "", &googleapi.Error{Code: http.StatusNotFound}
+				},
+			},
+			want: want{exported: true},
+		},
+		"Running": {
+			operation: "cool-operation",
+			instance: &fake.MockInstanceClient{
+				MockGetOperation: func(_ context.Context, name string) (*sqladmin.Operation, error) {
+					return &sqladmin.Operation{Name: name, Status: "RUNNING"}, nil
+				},
+			},
+			want: want{operation: "cool-operation"},
+		},
+		"Done": {
+			operation: "cool-operation",
+			instance: &fake.MockInstanceClient{
+				MockGetOperation: func(_ context.Context, name string) (*sqladmin.Operation, error) {
+					return &sqladmin.Operation{Name: name, Status: operationStatusDone}, nil
+				},
+			},
+			want: want{exported: true, operation: "cool-operation"},
+		},
+		"Failed": {
+			operation: "cool-operation",
+			instance: &fake.MockInstanceClient{
+				MockGetOperation: func(_ context.Context, name string) (*sqladmin.Operation, error) {
+					return &sqladmin.Operation{
+						Name:   name,
+						Status: operationStatusDone,
+						Error:  &sqladmin.OperationErrors{Errors: []*sqladmin.OperationError{{Message: "access denied"}}},
+					}, nil
+				},
+			},
+			want: want{err: errors.New("failed to export instance: access denied")},
+		},
+		"GetOperationFailed": {
+			operation: "cool-operation",
+			instance: &fake.MockInstanceClient{
+				MockGetOperation: func(_ context.Context, _ string) (*sqladmin.Operation, error) { return nil, errTest },
+			},
+			want: want{operation: "cool-operation", err: errors.Wrapf(errTest, "failed to get export operation")},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ih := &managedHandler{
+				CloudsqlInstance: &v1alpha1.CloudsqlInstance{
+					ObjectMeta: testMeta,
+					Spec: v1alpha1.CloudsqlInstanceSpec{
+						CloudsqlInstanceParameters: v1alpha1.CloudsqlInstanceParameters{FinalExport: export},
+					},
+					Status: v1alpha1.CloudsqlInstanceStatus{FinalExportOperation: tt.operation},
+				},
+				instance: tt.instance,
+			}
+			got, err := ih.exportInstance(context.Background())
+			if diff := cmp.Diff(tt.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("exportInstance() error -want, +got: %s", diff)
+			}
+			if got != tt.want.exported {
+				t.Errorf("exportInstance() = %v, want %v", got, tt.want.exported)
+			}
+			if diff := cmp.Diff(tt.want.operation, ih.Status.FinalExportOperation); diff != "" {
+				t.Errorf("exportInstance() operation -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
 func Test_managedHandler_getUser(t *testing.T) {
 	type fields struct {
 		obj  *v1alpha1.CloudsqlInstance
@@ -1304,12 +1591,11 @@ func Test_managedHandler_updateUserCreds(t *testing.T) {
 	}
 }
 
-func Test_newManagedHandler(t *testing.T) {
+func Test_newManagedClients(t *testing.T) {
 	type args struct {
-		ctx      context.Context
-		instance *v1alpha1.CloudsqlInstance
-		ops      localOperations
-		creds    *google.Credentials
+		ctx   context.Context
+		creds *google.Credentials
+		hc    *http.Client
 	}
 	type want struct {
 		err error
@@ -1326,9 +1612,9 @@ func Test_newManagedHandler(t *testing.T) {
 	}
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
-			_, err := newManagedHandler(tt.args.ctx, tt.args.instance, tt.args.ops, tt.args.creds)
+			_, err := newManagedClients(tt.args.ctx, tt.args.creds, tt.args.hc)
 			if diff := cmp.Diff(tt.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("newManagedHandler() error -want, +got: %s", diff)
+				t.Errorf("newManagedClients() error -want, +got: %s", diff)
 			}
 		})
 	}
@@ -0,0 +1,76 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane/gcp/apis/database/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/sql"
+)
+
+// Error strings.
+const (
+	errGetInstanceSecret = "cannot get referenced CloudsqlInstance connection secret"
+	errNoInstanceCA      = "referenced CloudsqlInstance has not yet reported its server certificate authority"
+	errNewSQLClient      = "cannot create new SQL client for referenced CloudsqlInstance"
+)
+
+// connectInstance returns a SQL client for the supplied instance, connected
+// using the credentials from its connection secret. The client manages MySQL
+// users with the supplied host. Connections are encrypted, and the instance's
+// certificate is verified using its server certificate authority. Cloud SQL
+// server certificates name the instance rather than the address at which it
+// is dialed, so only the certificate chain is verified.
+func connectInstance(ctx context.Context, kube client.Client, newClientFn func(sql.Options) (sql.Client, error), i *v1alpha1.CloudsqlInstance, userHost string) (sql.Client, error) {
+	if i.Status.ServerCACertificate == "" {
+		return nil, errors.New(errNoInstanceCA)
+	}
+
+	s := &corev1.Secret{}
+	n := types.NamespacedName{Namespace: i.GetNamespace(), Name: i.GetWriteConnectionSecretToReference().Name}
+	if err := kube.Get(ctx, n, s); err != nil {
+		return nil, errors.Wrap(err, errGetInstanceSecret)
+	}
+
+	engine := sql.MySQL
+	if strings.HasPrefix(i.Spec.DatabaseVersion, v1alpha1.PostgresqlDBVersionPrefix) {
+		engine = sql.PostgreSQL
+	}
+
+	if newClientFn == nil {
+		newClientFn = sql.NewClient
+	}
+	db, err := newClientFn(sql.Options{
+		Engine:         engine,
+		Endpoint:       i.Status.Endpoint,
+		Username:       string(s.Data[runtimev1alpha1.ResourceCredentialsSecretUserKey]),
+		Password:       string(s.Data[runtimev1alpha1.ResourceCredentialsSecretPasswordKey]),
+		TLS:            true,
+		CACertificates: []byte(i.Status.ServerCACertificate),
+		VerifyCAOnly:   true,
+		UserHost:       userHost,
+	})
+	return db, errors.Wrap(err, errNewSQLClient)
+}
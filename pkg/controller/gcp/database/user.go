@@ -0,0 +1,250 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane-runtime/pkg/util"
+	"github.com/crossplaneio/crossplane/gcp/apis/database/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/gcp"
+	"github.com/crossplaneio/crossplane/pkg/clients/sql"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
+)
+
+// Error strings.
+const (
+	errNotUser           = "managed resource is not a CloudSQL user"
+	errGetUserInstance   = "cannot get referenced CloudsqlInstance"
+	errUserInstanceState = "referenced CloudsqlInstance is not yet runnable"
+	errUserExists        = "cannot determine whether CloudSQL user exists"
+	errGeneratePassword  = "cannot generate CloudSQL user password"
+	errCreateUser        = "cannot create CloudSQL user"
+	errDeleteUser        = "cannot delete CloudSQL user"
+	errUpdateGrants      = "cannot update CloudSQL user grants"
+)
+
+// CloudsqlUserController is responsible for adding the CloudsqlUser
+// controller and its corresponding reconciler to the manager with any runtime configuration.
+type CloudsqlUserController struct{}
+
+// SetupWithManager creates a new CloudsqlUser Controller and adds it to the
+// Manager with default RBAC. The Manager will set fields on the Controller and
+// start it when the Manager is Started.
+func (c *CloudsqlUserController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s", v1alpha1.CloudsqlUserKind, v1alpha1.Group))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(gcp.ErrorCode))
+	tracer := tracing.NewTracer(name)
+
+	r := resource.NewManagedReconciler(mgr,
+		resource.ManagedKind(v1alpha1.CloudsqlUserGroupVersionKind),
+		resource.WithExternalConnecter(event.NewConnecter(recorder, protection.NewConnecter(tracer.Connecter(&userConnecter{client: mgr.GetClient()})))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.CloudsqlUser{}).
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &v1alpha1.CloudsqlUser{}, r)))
+}
+
+type userConnecter struct {
+	client         client.Client
+	newSQLClientFn func(o sql.Options) (sql.Client, error)
+}
+
+func (c *userConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.CloudsqlUser); !ok {
+		return nil, errors.New(errNotUser)
+	}
+	return &userExternal{kube: c.client, newSQLClientFn: c.newSQLClientFn}, nil
+}
+
+// A userExternal manages a CloudsqlUser via SQL, using the master credentials
+// of its instance. Users are not managed via the CloudSQL API, because users
+// created via the API are granted broad privileges that cannot be scoped by
+// their grants.
+type userExternal struct {
+	kube           client.Client
+	newSQLClientFn func(o sql.Options) (sql.Client, error)
+}
+
+// instance returns the CloudsqlInstance referenced by the supplied user.
+func (e *userExternal) instance(ctx context.Context, u *v1alpha1.CloudsqlUser) (*v1alpha1.CloudsqlInstance, error) {
+	i := &v1alpha1.CloudsqlInstance{}
+	n := types.NamespacedName{Namespace: u.GetNamespace(), Name: u.Spec.InstanceRef.Name}
+	return i, errors.Wrap(e.kube.Get(ctx, n, i), errGetUserInstance)
+}
+
+// userHost returns the host of the supplied user. MySQL users are identified
+// by their name and host, and default to allowing connections from any host.
+// PostgreSQL users have no host.
+func userHost(u *v1alpha1.CloudsqlUser, i *v1alpha1.CloudsqlInstance) string {
+	if u.Spec.Host == "" && !strings.HasPrefix(i.Spec.DatabaseVersion, v1alpha1.PostgresqlDBVersionPrefix) {
+		return v1alpha1.MysqlAnyHost
+	}
+	return u.Spec.Host
+}
+
+func (e *userExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	u, ok := mg.(*v1alpha1.CloudsqlUser)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotUser)
+	}
+
+	i, err := e.instance(ctx, u)
+	if kerrors.IsNotFound(errors.Cause(err)) && meta.WasDeleted(u) {
+		// Users cannot outlive the instance they were created in.
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, err
+	}
+	if !i.IsRunnable() {
+		return resource.ExternalObservation{}, errors.New(errUserInstanceState)
+	}
+
+	db, err := connectInstance(ctx, e.kube, e.newSQLClientFn, i, userHost(u, i))
+	if err != nil {
+		return resource.ExternalObservation{}, err
+	}
+
+	exists, err := db.UserExists(ctx, u.GetUsername())
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, errUserExists)
+	}
+	if !exists {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	u.Status.Instance = i.GetResourceName()
+	u.Status.SetConditions(runtimev1alpha1.Available())
+	resource.SetBindable(u)
+
+	o := resource.ExternalObservation{
+		ResourceExists: true,
+		ConnectionDetails: resource.ConnectionDetails{
+			runtimev1alpha1.ResourceCredentialsSecretUserKey:     []byte(u.GetUsername()),
+			runtimev1alpha1.ResourceCredentialsSecretEndpointKey: []byte(i.Status.Endpoint),
+		},
+	}
+	return o, nil
+}
+
+func (e *userExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	u, ok := mg.(*v1alpha1.CloudsqlUser)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotUser)
+	}
+
+	u.Status.SetConditions(runtimev1alpha1.Creating())
+
+	i, err := e.instance(ctx, u)
+	if err != nil {
+		return resource.ExternalCreation{}, err
+	}
+
+	db, err := connectInstance(ctx, e.kube, e.newSQLClientFn, i, userHost(u, i))
+	if err != nil {
+		return resource.ExternalCreation{}, err
+	}
+
+	password, err := util.GeneratePassword(v1alpha1.PasswordLength)
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, errGeneratePassword)
+	}
+
+	if err := db.CreateUser(ctx, u.GetUsername(), password); err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, errCreateUser)
+	}
+
+	c := resource.ExternalCreation{
+		ConnectionDetails: resource.ConnectionDetails{
+			runtimev1alpha1.ResourceCredentialsSecretUserKey:     []byte(u.GetUsername()),
+			runtimev1alpha1.ResourceCredentialsSecretPasswordKey: []byte(password),
+		},
+	}
+
+	return c, nil
+}
+
+// Update grants the user privileges on the databases named by its grants, and
+// revokes any others. A user's name and host are its identity, and its
+// password is generated once when it is created. Grants are not made by
+// Create, because a failure to grant would lose the password of the newly
+// created user.
+func (e *userExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	u, ok := mg.(*v1alpha1.CloudsqlUser)
+	if !ok {
+		return resource.ExternalUpdate{}, errors.New(errNotUser)
+	}
+
+	i, err := e.instance(ctx, u)
+	if err != nil {
+		return resource.ExternalUpdate{}, err
+	}
+
+	db, err := connectInstance(ctx, e.kube, e.newSQLClientFn, i, userHost(u, i))
+	if err != nil {
+		return resource.ExternalUpdate{}, err
+	}
+
+	databases := make([]string, 0, len(u.Spec.Grants))
+	for _, g := range u.Spec.Grants {
+		databases = append(databases, g.Database)
+	}
+
+	err = sql.ReconcileGrants(ctx, db, u.GetUsername(), databases)
+	return resource.ExternalUpdate{}, errors.Wrap(err, errUpdateGrants)
+}
+
+func (e *userExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	u, ok := mg.(*v1alpha1.CloudsqlUser)
+	if !ok {
+		return errors.New(errNotUser)
+	}
+
+	u.Status.SetConditions(runtimev1alpha1.Deleting())
+
+	i, err := e.instance(ctx, u)
+	if kerrors.IsNotFound(errors.Cause(err)) {
+		// Users cannot outlive the instance they were created in.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	db, err := connectInstance(ctx, e.kube, e.newSQLClientFn, i, userHost(u, i))
+	if err != nil {
+		return err
+	}
+
+	return errors.Wrap(db.DeleteUser(ctx, u.GetUsername()), errDeleteUser)
+}
@@ -0,0 +1,447 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	"github.com/crossplaneio/crossplane/gcp/apis/database/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/sql"
+	sqlfake "github.com/crossplaneio/crossplane/pkg/clients/sql/fake"
+)
+
+const (
+	userNamespace    = "cool-namespace"
+	userName         = "cool-user"
+	userInstanceName = "cool-instance"
+	userInstanceUID  = "definitely-a-uuid"
+	userEndpoint     = "10.0.0.1"
+	userServerCA     = "cool-ca"
+	userSecretName   = "cool-instance-secret"
+	userMasterName   = "root"
+	userMasterPass   = "hunter2"
+)
+
+var (
+	errUserBoom = errors.New("boom")
+
+	userInstanceResourceName = "cloudsqlinstance-" + userInstanceUID
+)
+
+type cloudsqlUserModifier func(*v1alpha1.CloudsqlUser)
+
+func withUserConditions(c ...runtimev1alpha1.Condition) cloudsqlUserModifier {
+	return func(u *v1alpha1.CloudsqlUser) { u.Status.ConditionedStatus.Conditions = c }
+}
+
+func withUserBindingPhase(p runtimev1alpha1.BindingPhase) cloudsqlUserModifier {
+	return func(u *v1alpha1.CloudsqlUser) { u.Status.SetBindingPhase(p) }
+}
+
+func withUserInstance(i string) cloudsqlUserModifier {
+	return func(u *v1alpha1.CloudsqlUser) { u.Status.Instance = i }
+}
+
+func withUserDeletionTimestamp(t metav1.Time) cloudsqlUserModifier {
+	return func(u *v1alpha1.CloudsqlUser) { u.SetDeletionTimestamp(&t) }
+}
+
+func withUserHost(h string) cloudsqlUserModifier {
+	return func(u *v1alpha1.CloudsqlUser) { u.Spec.Host = h }
+}
+
+func withUserGrants(databases ...string) cloudsqlUserModifier {
+	return func(u *v1alpha1.CloudsqlUser) {
+		for _, d := range databases {
+			u.Spec.Grants = append(u.Spec.Grants, v1alpha1.DatabaseGrant{Database: d})
+		}
+	}
+}
+
+func cloudsqlUser(m ...cloudsqlUserModifier) *v1alpha1.CloudsqlUser {
+	u := &v1alpha1.CloudsqlUser{
+		ObjectMeta: metav1.ObjectMeta{Namespace: userNamespace, Name: userName},
+		Spec: v1alpha1.CloudsqlUserSpec{
+			CloudsqlUserParameters: v1alpha1.CloudsqlUserParameters{
+				InstanceRef: corev1.LocalObjectReference{Name: userInstanceName},
+			},
+		},
+	}
+
+	for _, fn := range m {
+		fn(u)
+	}
+
+	return u
+}
+
+func userInstance(state string) *v1alpha1.CloudsqlInstance {
+	return &v1alpha1.CloudsqlInstance{
+		ObjectMeta: metav1.ObjectMeta{Namespace: userNamespace, Name: userInstanceName, UID: userInstanceUID},
+		Spec: v1alpha1.CloudsqlInstanceSpec{
+			ResourceSpec: runtimev1alpha1.ResourceSpec{
+				WriteConnectionSecretToReference: corev1.LocalObjectReference{Name: userSecretName},
+			},
+			CloudsqlInstanceParameters: v1alpha1.CloudsqlInstanceParameters{DatabaseVersion: "MYSQL_5_7"},
+		},
+		Status: v1alpha1.CloudsqlInstanceStatus{State: state, Endpoint: userEndpoint, ServerCACertificate: userServerCA},
+	}
+}
+
+func userKube(i *v1alpha1.CloudsqlInstance) client.Client {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+			switch o := obj.(type) {
+			case *v1alpha1.CloudsqlInstance:
+				if i == nil {
+					return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+				}
+				*o = *i
+			case *corev1.Secret:
+				o.Data = map[string][]byte{
+					runtimev1alpha1.ResourceCredentialsSecretEndpointKey: []byte(userEndpoint),
+					runtimev1alpha1.ResourceCredentialsSecretUserKey:     []byte(userMasterName),
+					runtimev1alpha1.ResourceCredentialsSecretPasswordKey: []byte(userMasterPass),
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// userSQL returns a function that returns the supplied mock SQL client if it
+// is asked to connect to the user's instance using the instance's master
+// credentials over a verified connection, and to manage users with the
+// supplied host.
+func userSQL(host string, db sql.Client) func(sql.Options) (sql.Client, error) {
+	return func(o sql.Options) (sql.Client, error) {
+		want := sql.Options{
+			Engine:         sql.MySQL,
+			Endpoint:       userEndpoint,
+			Username:       userMasterName,
+			Password:       userMasterPass,
+			TLS:            true,
+			CACertificates: []byte(userServerCA),
+			VerifyCAOnly:   true,
+			UserHost:       host,
+		}
+		if diff := cmp.Diff(want, o); diff != "" {
+			return nil, errors.Errorf("unexpected SQL options: -want, +got:\n%s", diff)
+		}
+		return db, nil
+	}
+}
+
+var _ resource.ExternalConnecter = &userConnecter{}
+var _ resource.ExternalClient = &userExternal{}
+
+func TestUserObserve(t *testing.T) {
+	deleted := metav1.Now()
+
+	type want struct {
+		o   resource.ExternalObservation
+		u   *v1alpha1.CloudsqlUser
+		err error
+	}
+
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		u    *v1alpha1.CloudsqlUser
+		want want
+	}{
+		"UserExists": {
+			e: &userExternal{
+				kube: userKube(userInstance(v1alpha1.StateRunnable)),
+				newSQLClientFn: userSQL(v1alpha1.MysqlAnyHost, &sqlfake.MockClient{MockUserExists: func(_ context.Context, name string) (bool, error) {
+					return name == userName, nil
+				}}),
+			},
+			u: cloudsqlUser(),
+			want: want{
+				o: resource.ExternalObservation{
+					ResourceExists: true,
+					ConnectionDetails: resource.ConnectionDetails{
+						runtimev1alpha1.ResourceCredentialsSecretUserKey:     []byte(userName),
+						runtimev1alpha1.ResourceCredentialsSecretEndpointKey: []byte(userEndpoint),
+					},
+				},
+				u: cloudsqlUser(
+					withUserInstance(userInstanceResourceName),
+					withUserConditions(runtimev1alpha1.Available()),
+					withUserBindingPhase(runtimev1alpha1.BindingPhaseUnbound),
+				),
+			},
+		},
+		"UserDoesNotExist": {
+			e: &userExternal{
+				kube: userKube(userInstance(v1alpha1.StateRunnable)),
+				newSQLClientFn: userSQL(v1alpha1.MysqlAnyHost, &sqlfake.MockClient{MockUserExists: func(_ context.Context, _ string) (bool, error) {
+					return false, nil
+				}}),
+			},
+			u:    cloudsqlUser(),
+			want: want{o: resource.ExternalObservation{ResourceExists: false}, u: cloudsqlUser()},
+		},
+		"InstanceNotRunnable": {
+			e:    &userExternal{kube: userKube(userInstance("PENDING_CREATE"))},
+			u:    cloudsqlUser(),
+			want: want{u: cloudsqlUser(), err: errors.New(errUserInstanceState)},
+		},
+		"InstanceNotFound": {
+			e:    &userExternal{kube: userKube(nil)},
+			u:    cloudsqlUser(),
+			want: want{u: cloudsqlUser(), err: errors.Wrap(kerrors.NewNotFound(schema.GroupResource{}, userInstanceName), errGetUserInstance)},
+		},
+		"InstanceNotFoundWhileDeleting": {
+			e:    &userExternal{kube: userKube(nil)},
+			u:    cloudsqlUser(withUserDeletionTimestamp(deleted)),
+			want: want{o: resource.ExternalObservation{ResourceExists: false}, u: cloudsqlUser(withUserDeletionTimestamp(deleted))},
+		},
+		"NewSQLClientFailed": {
+			e: &userExternal{
+				kube:           userKube(userInstance(v1alpha1.StateRunnable)),
+				newSQLClientFn: func(_ sql.Options) (sql.Client, error) { return nil, errUserBoom },
+			},
+			u:    cloudsqlUser(),
+			want: want{u: cloudsqlUser(), err: errors.Wrap(errUserBoom, errNewSQLClient)},
+		},
+		"UserExistsFailed": {
+			e: &userExternal{
+				kube: userKube(userInstance(v1alpha1.StateRunnable)),
+				newSQLClientFn: userSQL(v1alpha1.MysqlAnyHost, &sqlfake.MockClient{MockUserExists: func(_ context.Context, _ string) (bool, error) {
+					return false, errUserBoom
+				}}),
+			},
+			u:    cloudsqlUser(),
+			want: want{u: cloudsqlUser(), err: errors.Wrap(errUserBoom, errUserExists)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.u)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("e.Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.u, tc.u, test.EquateConditions()); diff != "" {
+				t.Errorf("e.Observe(...): -want user, +got user:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUserCreate(t *testing.T) {
+	type want struct {
+		password bool
+		u        *v1alpha1.CloudsqlUser
+		err      error
+	}
+
+	created := func(_ context.Context, name, password string) error {
+		if name != userName || password == "" {
+			return errors.Errorf("unexpected user %s", name)
+		}
+		return nil
+	}
+
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		u    *v1alpha1.CloudsqlUser
+		want want
+	}{
+		"Successful": {
+			e: &userExternal{
+				kube:           userKube(userInstance(v1alpha1.StateRunnable)),
+				newSQLClientFn: userSQL(v1alpha1.MysqlAnyHost, &sqlfake.MockClient{MockCreateUser: created}),
+			},
+			u: cloudsqlUser(),
+			want: want{
+				password: true,
+				u:        cloudsqlUser(withUserConditions(runtimev1alpha1.Creating())),
+			},
+		},
+		"SuccessfulWithHost": {
+			e: &userExternal{
+				kube:           userKube(userInstance(v1alpha1.StateRunnable)),
+				newSQLClientFn: userSQL("10.0.0.%", &sqlfake.MockClient{MockCreateUser: created}),
+			},
+			u: cloudsqlUser(withUserHost("10.0.0.%")),
+			want: want{
+				password: true,
+				u:        cloudsqlUser(withUserHost("10.0.0.%"), withUserConditions(runtimev1alpha1.Creating())),
+			},
+		},
+		"CreateFailed": {
+			e: &userExternal{
+				kube: userKube(userInstance(v1alpha1.StateRunnable)),
+				newSQLClientFn: userSQL(v1alpha1.MysqlAnyHost, &sqlfake.MockClient{MockCreateUser: func(_ context.Context, _, _ string) error {
+					return errUserBoom
+				}}),
+			},
+			u: cloudsqlUser(),
+			want: want{
+				u:   cloudsqlUser(withUserConditions(runtimev1alpha1.Creating())),
+				err: errors.Wrap(errUserBoom, errCreateUser),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c, err := tc.e.Create(context.Background(), tc.u)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Create(...): -want error, +got error:\n%s", diff)
+			}
+			if got := len(c.ConnectionDetails[runtimev1alpha1.ResourceCredentialsSecretPasswordKey]) > 0; got != tc.want.password {
+				t.Errorf("e.Create(...): want password %t, got %t", tc.want.password, got)
+			}
+			if diff := cmp.Diff(tc.want.u, tc.u, test.EquateConditions()); diff != "" {
+				t.Errorf("e.Create(...): -want user, +got user:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUserUpdate(t *testing.T) {
+	type want struct {
+		granted []string
+		revoked []string
+		err     error
+	}
+
+	cases := map[string]struct {
+		existing  []string
+		grantsErr error
+		u         *v1alpha1.CloudsqlUser
+		want      want
+	}{
+		"GrantsAndRevokes": {
+			existing: []string{"cool-db", "lame-db"},
+			u:        cloudsqlUser(withUserGrants("cool-db", "new-db")),
+			want: want{
+				granted: []string{"new-db"},
+				revoked: []string{"lame-db"},
+			},
+		},
+		"UpToDate": {
+			existing: []string{"cool-db"},
+			u:        cloudsqlUser(withUserGrants("cool-db")),
+			want:     want{},
+		},
+		"GrantsFailed": {
+			grantsErr: errUserBoom,
+			u:         cloudsqlUser(withUserGrants("cool-db")),
+			want: want{
+				err: errors.Wrap(errUserBoom, errUpdateGrants),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var granted, revoked []string
+			db := &sqlfake.MockClient{
+				MockGrants: func(_ context.Context, user string) ([]string, error) {
+					if user != userName {
+						return nil, errors.Errorf("unexpected user %s", user)
+					}
+					return tc.existing, tc.grantsErr
+				},
+				MockGrant: func(_ context.Context, _, database string) error {
+					granted = append(granted, database)
+					return nil
+				},
+				MockRevoke: func(_ context.Context, _, database string) error {
+					revoked = append(revoked, database)
+					return nil
+				},
+			}
+			e := &userExternal{kube: userKube(userInstance(v1alpha1.StateRunnable)), newSQLClientFn: userSQL(v1alpha1.MysqlAnyHost, db)}
+
+			_, err := e.Update(context.Background(), tc.u)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Update(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.granted, granted); diff != "" {
+				t.Errorf("e.Update(...): -want granted, +got granted:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.revoked, revoked); diff != "" {
+				t.Errorf("e.Update(...): -want revoked, +got revoked:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUserDelete(t *testing.T) {
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		u    *v1alpha1.CloudsqlUser
+		want error
+	}{
+		"Successful": {
+			e: &userExternal{
+				kube: userKube(userInstance(v1alpha1.StateRunnable)),
+				newSQLClientFn: userSQL(v1alpha1.MysqlAnyHost, &sqlfake.MockClient{MockDeleteUser: func(_ context.Context, name string) error {
+					if name != userName {
+						return errors.Errorf("unexpected user %s", name)
+					}
+					return nil
+				}}),
+			},
+			u: cloudsqlUser(),
+		},
+		"InstanceNotFound": {
+			e: &userExternal{kube: userKube(nil)},
+			u: cloudsqlUser(),
+		},
+		"DeleteFailed": {
+			e: &userExternal{
+				kube: userKube(userInstance(v1alpha1.StateRunnable)),
+				newSQLClientFn: userSQL(v1alpha1.MysqlAnyHost, &sqlfake.MockClient{MockDeleteUser: func(_ context.Context, _ string) error {
+					return errUserBoom
+				}}),
+			},
+			u:    cloudsqlUser(),
+			want: errors.Wrap(errUserBoom, errDeleteUser),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.e.Delete(context.Background(), tc.u)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Delete(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/crossplaneio/crossplane/pkg/controller/gcp/cache"
 	"github.com/crossplaneio/crossplane/pkg/controller/gcp/compute"
 	"github.com/crossplaneio/crossplane/pkg/controller/gcp/database"
+	"github.com/crossplaneio/crossplane/pkg/controller/gcp/servicenetworking"
 	"github.com/crossplaneio/crossplane/pkg/controller/gcp/storage"
 )
 
@@ -46,6 +47,22 @@ func (c *Controllers) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
+	if err := (&compute.NetworkController{}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&compute.SubnetworkController{}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&compute.GlobalAddressController{}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&servicenetworking.ConnectionController{}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
 	if err := (&database.PostgreSQLInstanceClaimController{}).SetupWithManager(mgr); err != nil {
 		return err
 	}
@@ -58,6 +75,26 @@ func (c *Controllers) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
+	if err := (&database.CloudsqlUserController{}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&database.PostgreSQLInstanceDatabaseClaimController{}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&database.MySQLInstanceDatabaseClaimController{}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&database.CloudsqlDatabaseController{}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&database.CloudsqlBackupController{}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
 	if err := (&storage.BucketClaimController{}).SetupWithManager(mgr); err != nil {
 		return err
 	}
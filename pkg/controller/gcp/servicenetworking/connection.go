@@ -0,0 +1,195 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package servicenetworking contains controllers for GCP private services
+// access connections.
+package servicenetworking
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2/google"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane/gcp/apis/servicenetworking/v1alpha1"
+	gcpv1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/gcp"
+	gcpsn "github.com/crossplaneio/crossplane/pkg/clients/gcp/servicenetworking"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/reference"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
+	"github.com/crossplaneio/crossplane/pkg/util/googleapi"
+)
+
+// Error strings.
+const (
+	errNewClient        = "cannot create new GCP service networking client"
+	errNotConnection    = "managed resource is not a GCP service networking connection"
+	errGetConnection    = "cannot get GCP service networking connection"
+	errCreateConnection = "cannot create GCP service networking connection"
+	errPatchConnection  = "cannot patch GCP service networking connection"
+	errRemovePeering    = "cannot remove GCP service networking connection peering"
+)
+
+// ConnectionController is responsible for adding the Connection controller
+// and its corresponding reconciler to the manager with any runtime
+// configuration.
+type ConnectionController struct{}
+
+// SetupWithManager creates a new Connection Controller and adds it to the
+// Manager with default RBAC. The Manager will set fields on the Controller and
+// start it when the Manager is Started.
+func (c *ConnectionController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s", v1alpha1.ConnectionKind, v1alpha1.Group))
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(name), event.WithErrorCoder(gcp.ErrorCode))
+	tracer := tracing.NewTracer(name)
+
+	r := resource.NewManagedReconciler(mgr,
+		resource.ManagedKind(v1alpha1.ConnectionGroupVersionKind),
+		resource.WithExternalConnecter(event.NewConnecter(recorder, protection.NewConnecter(tracer.Connecter(reference.NewConnecter(mgr.GetClient(), &connecter{client: mgr.GetClient(), pool: pool.Default}))))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.Connection{}).
+		Complete(tracer.Reconciler(pause.NewReconciler(mgr.GetClient(), &v1alpha1.Connection{}, r)))
+}
+
+type connecter struct {
+	client      client.Client
+	pool        *pool.Pool
+	newClientFn func(ctx context.Context, creds *google.Credentials, hc *http.Client) (gcpsn.ConnectionService, error)
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	cn, ok := mg.(*v1alpha1.Connection)
+	if !ok {
+		return nil, errors.New(errNotConnection)
+	}
+
+	p := &gcpv1alpha1.Provider{}
+	n := meta.NamespacedNameOf(cn.Spec.ProviderReference)
+	if err := c.client.Get(ctx, n, p); err != nil {
+		return nil, errors.Wrapf(err, "cannot get provider %s", n)
+	}
+
+	newClientFn := newConnectionClient
+	if c.newClientFn != nil {
+		newClientFn = c.newClientFn
+	}
+	cl, err := gcp.PooledClient(ctx, c.pool, c.client, p, gcpsn.API, "", func(creds *google.Credentials, hc *http.Client) (interface{}, error) {
+		// Pooled clients outlive the supplied context.
+		cl, err := newClientFn(context.Background(), creds, hc)
+		return cl, errors.Wrap(err, errNewClient)
+	}, gcpsn.DefaultScope)
+	if err != nil {
+		return nil, err
+	}
+	return &external{connections: cl.(gcpsn.ConnectionService)}, nil
+}
+
+func newConnectionClient(ctx context.Context, creds *google.Credentials, hc *http.Client) (gcpsn.ConnectionService, error) {
+	return gcpsn.NewConnectionClient(ctx, creds, hc)
+}
+
+type external struct {
+	connections gcpsn.ConnectionService
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	cn, ok := mg.(*v1alpha1.Connection)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotConnection)
+	}
+
+	existing, err := e.connections.Get(ctx, cn.GetParent(), cn.Spec.Network)
+	if googleapi.IsErrorNotFound(err) {
+		return resource.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, errGetConnection)
+	}
+
+	cn.Status.Peering = existing.Peering
+	cn.Status.Service = existing.Service
+
+	// Connections are only returned once their create operation is done.
+	cn.Status.SetConditions(runtimev1alpha1.Available())
+	resource.SetBindable(cn)
+
+	return resource.ExternalObservation{ResourceExists: true}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	cn, ok := mg.(*v1alpha1.Connection)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotConnection)
+	}
+
+	cn.Status.SetConditions(runtimev1alpha1.Creating())
+
+	err := e.connections.Create(ctx, cn.GetParent(), gcpsn.NewConnection(cn))
+	return resource.ExternalCreation{}, errors.Wrap(err, errCreateConnection)
+}
+
+// Update patches the reserved peering ranges of the connection. Its network
+// cannot be changed.
+func (e *external) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	cn, ok := mg.(*v1alpha1.Connection)
+	if !ok {
+		return resource.ExternalUpdate{}, errors.New(errNotConnection)
+	}
+
+	existing, err := e.connections.Get(ctx, cn.GetParent(), cn.Spec.Network)
+	if err != nil {
+		return resource.ExternalUpdate{}, errors.Wrap(err, errGetConnection)
+	}
+
+	if !gcpsn.ConnectionNeedsUpdate(cn, existing) {
+		return resource.ExternalUpdate{}, nil
+	}
+
+	err = e.connections.Patch(ctx, cn.GetParent(), gcpsn.NewConnection(cn))
+	return resource.ExternalUpdate{}, errors.Wrap(err, errPatchConnection)
+}
+
+// Delete removes the VPC network peering of the connection, which deletes the
+// connection.
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cn, ok := mg.(*v1alpha1.Connection)
+	if !ok {
+		return errors.New(errNotConnection)
+	}
+
+	cn.Status.SetConditions(runtimev1alpha1.Deleting())
+
+	if cn.Status.Peering == "" {
+		return nil
+	}
+
+	err := e.connections.RemovePeering(ctx, cn.Spec.Network, cn.Status.Peering)
+	return errors.Wrap(resource.Ignore(googleapi.IsErrorNotFound, err), errRemovePeering)
+}
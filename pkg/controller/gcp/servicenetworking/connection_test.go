@@ -0,0 +1,335 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicenetworking
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/api/googleapi"
+	servicenetworking "google.golang.org/api/servicenetworking/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	"github.com/crossplaneio/crossplane/gcp/apis/servicenetworking/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/gcp/servicenetworking/fake"
+)
+
+const (
+	namespace      = "cool-namespace"
+	connectionName = "cool-connection"
+	providerName   = "cool-gcp"
+	network        = "projects/cool-project/global/networks/cool-network"
+	peering        = "servicenetworking-googleapis-com"
+	service        = "services/servicenetworking.googleapis.com"
+	peeringRange   = "cool-range"
+)
+
+var errBoom = errors.New("boom")
+
+var _ resource.ExternalConnecter = &connecter{}
+var _ resource.ExternalClient = &external{}
+
+type connectionModifier func(*v1alpha1.Connection)
+
+func withConditions(c ...runtimev1alpha1.Condition) connectionModifier {
+	return func(cn *v1alpha1.Connection) { cn.Status.ConditionedStatus.Conditions = c }
+}
+
+func withBindingPhase(p runtimev1alpha1.BindingPhase) connectionModifier {
+	return func(cn *v1alpha1.Connection) { cn.Status.SetBindingPhase(p) }
+}
+
+func withPeering(p string) connectionModifier {
+	return func(cn *v1alpha1.Connection) { cn.Status.Peering = p }
+}
+
+func withService(s string) connectionModifier {
+	return func(cn *v1alpha1.Connection) { cn.Status.Service = s }
+}
+
+func connection(m ...connectionModifier) *v1alpha1.Connection {
+	cn := &v1alpha1.Connection{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: connectionName},
+		Spec: v1alpha1.ConnectionSpec{
+			ResourceSpec: runtimev1alpha1.ResourceSpec{
+				ProviderReference: &corev1.ObjectReference{Namespace: namespace, Name: providerName},
+			},
+			ConnectionParameters: v1alpha1.ConnectionParameters{
+				Network:               network,
+				ReservedPeeringRanges: []string{peeringRange},
+			},
+		},
+	}
+
+	for _, fn := range m {
+		fn(cn)
+	}
+
+	return cn
+}
+
+func TestConnect(t *testing.T) {
+	cases := map[string]struct {
+		conn    *connecter
+		cn      *v1alpha1.Connection
+		wantErr error
+	}{
+		"FailedToGetProvider": {
+			conn: &connecter{
+				client: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			},
+			cn:      connection(),
+			wantErr: errors.Wrapf(errBoom, "cannot get provider %s/%s", namespace, providerName),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.conn.Connect(context.Background(), tc.cn)
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.conn.Connect(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		o   resource.ExternalObservation
+		cn  *v1alpha1.Connection
+		err error
+	}
+
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		cn   *v1alpha1.Connection
+		want want
+	}{
+		"ConnectionExists": {
+			e: &external{connections: &fake.MockConnectionClient{
+				MockGet: func(_ context.Context, parent, n string) (*servicenetworking.Connection, error) {
+					if parent != v1alpha1.ServiceNetworkingParent || n != network {
+						return nil, errors.Errorf("unexpected connection of %s to %s", n, parent)
+					}
+					return &servicenetworking.Connection{Peering: peering, Service: service}, nil
+				},
+			}},
+			cn: connection(),
+			want: want{
+				o: resource.ExternalObservation{ResourceExists: true},
+				cn: connection(
+					withPeering(peering),
+					withService(service),
+					withConditions(runtimev1alpha1.Available()),
+					withBindingPhase(runtimev1alpha1.BindingPhaseUnbound),
+				),
+			},
+		},
+		"ConnectionDoesNotExist": {
+			e: &external{connections: &fake.MockConnectionClient{
+				MockGet: func(_ context.Context, _, _ string) (*servicenetworking.Connection, error) {
+					return nil, &googleapi.Error{Code: http.StatusNotFound}
+				},
+			}},
+			cn:   connection(),
+			want: want{o: resource.ExternalObservation{ResourceExists: false}, cn: connection()},
+		},
+		"GetConnectionFailed": {
+			e: &external{connections: &fake.MockConnectionClient{
+				MockGet: func(_ context.Context, _, _ string) (*servicenetworking.Connection, error) { return nil, errBoom },
+			}},
+			cn:   connection(),
+			want: want{cn: connection(), err: errors.Wrap(errBoom, errGetConnection)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.cn)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("e.Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cn, tc.cn, test.EquateConditions()); diff != "" {
+				t.Errorf("e.Observe(...): -want connection, +got connection:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		cn  *v1alpha1.Connection
+		err error
+	}
+
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		cn   *v1alpha1.Connection
+		want want
+	}{
+		"Successful": {
+			e: &external{connections: &fake.MockConnectionClient{
+				MockCreate: func(_ context.Context, _ string, cn *servicenetworking.Connection) error {
+					if cn.Network != network {
+						return errors.Errorf("unexpected network %s", cn.Network)
+					}
+					return nil
+				},
+			}},
+			cn:   connection(),
+			want: want{cn: connection(withConditions(runtimev1alpha1.Creating()))},
+		},
+		"CreateFailed": {
+			e: &external{connections: &fake.MockConnectionClient{
+				MockCreate: func(_ context.Context, _ string, _ *servicenetworking.Connection) error { return errBoom },
+			}},
+			cn: connection(),
+			want: want{
+				cn:  connection(withConditions(runtimev1alpha1.Creating())),
+				err: errors.Wrap(errBoom, errCreateConnection),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Create(context.Background(), tc.cn)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Create(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cn, tc.cn, test.EquateConditions()); diff != "" {
+				t.Errorf("e.Create(...): -want connection, +got connection:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		cn   *v1alpha1.Connection
+		want error
+	}{
+		"UpToDate": {
+			e: &external{connections: &fake.MockConnectionClient{
+				MockGet: func(_ context.Context, _, _ string) (*servicenetworking.Connection, error) {
+					return &servicenetworking.Connection{ReservedPeeringRanges: []string{peeringRange}}, nil
+				},
+				MockPatch: func(_ context.Context, _ string, _ *servicenetworking.Connection) error {
+					return errors.New("unexpected patch")
+				},
+			}},
+			cn: connection(),
+		},
+		"RangesChanged": {
+			e: &external{connections: &fake.MockConnectionClient{
+				MockGet: func(_ context.Context, _, _ string) (*servicenetworking.Connection, error) {
+					return &servicenetworking.Connection{}, nil
+				},
+				MockPatch: func(_ context.Context, _ string, cn *servicenetworking.Connection) error {
+					if len(cn.ReservedPeeringRanges) != 1 || cn.ReservedPeeringRanges[0] != peeringRange {
+						return errors.Errorf("unexpected ranges %v", cn.ReservedPeeringRanges)
+					}
+					return nil
+				},
+			}},
+			cn: connection(),
+		},
+		"GetConnectionFailed": {
+			e: &external{connections: &fake.MockConnectionClient{
+				MockGet: func(_ context.Context, _, _ string) (*servicenetworking.Connection, error) { return nil, errBoom },
+			}},
+			cn:   connection(),
+			want: errors.Wrap(errBoom, errGetConnection),
+		},
+		"PatchFailed": {
+			e: &external{connections: &fake.MockConnectionClient{
+				MockGet: func(_ context.Context, _, _ string) (*servicenetworking.Connection, error) {
+					return &servicenetworking.Connection{}, nil
+				},
+				MockPatch: func(_ context.Context, _ string, _ *servicenetworking.Connection) error { return errBoom },
+			}},
+			cn:   connection(),
+			want: errors.Wrap(errBoom, errPatchConnection),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Update(context.Background(), tc.cn)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Update(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		e    resource.ExternalClient
+		cn   *v1alpha1.Connection
+		want error
+	}{
+		"NoPeering": {
+			e:  &external{connections: &fake.MockConnectionClient{}},
+			cn: connection(),
+		},
+		"Successful": {
+			e: &external{connections: &fake.MockConnectionClient{
+				MockRemovePeering: func(_ context.Context, n, p string) error {
+					if n != network || p != peering {
+						return errors.Errorf("unexpected removal of peering %s from %s", p, n)
+					}
+					return nil
+				},
+			}},
+			cn: connection(withPeering(peering)),
+		},
+		"PeeringNotFound": {
+			e: &external{connections: &fake.MockConnectionClient{
+				MockRemovePeering: func(_ context.Context, _, _ string) error { return &googleapi.Error{Code: http.StatusNotFound} },
+			}},
+			cn: connection(withPeering(peering)),
+		},
+		"RemovePeeringFailed": {
+			e: &external{connections: &fake.MockConnectionClient{
+				MockRemovePeering: func(_ context.Context, _, _ string) error { return errBoom },
+			}},
+			cn:   connection(withPeering(peering)),
+			want: errors.Wrap(errBoom, errRemovePeering),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.e.Delete(context.Background(), tc.cn)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Delete(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ package storage
 
 import (
 	"context"
+	"net/http"
 	"reflect"
 	"time"
 
@@ -27,7 +28,6 @@ import (
 	"google.golang.org/api/option"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -37,7 +37,13 @@ import (
 	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
 	"github.com/crossplaneio/crossplane/gcp/apis/storage/v1alpha1"
 	gcpv1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/gcp"
 	gcpstorage "github.com/crossplaneio/crossplane/pkg/clients/gcp/storage"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/protection"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
 )
 
 const (
@@ -69,15 +75,19 @@ type BucketController struct{}
 // The Manager will set fields on the Controller and Start it when the Manager is Started.
 func (c *BucketController) SetupWithManager(mgr ctrl.Manager) error {
 	r := &Reconciler{
-		Client:  mgr.GetClient(),
-		factory: &bucketFactory{mgr.GetClient()},
+		Client: mgr.GetClient(),
+		factory: &bucketFactory{
+			Client: mgr.GetClient(),
+			pool:   pool.Default,
+			record: event.NewRecorder(mgr.GetEventRecorderFor(controllerName), event.WithErrorCoder(gcp.ErrorCode)),
+		},
 	}
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(controllerName).
 		For(&v1alpha1.Bucket{}).
 		Owns(&corev1.Secret{}).
-		Complete(r)
+		Complete(tracing.NewTracer(controllerName).Reconciler(pause.NewReconciler(mgr.GetClient(), &v1alpha1.Bucket{}, r)))
 }
 
 // Reconcile reads that state of the cluster for a Provider bucket and makes changes based on the state read
@@ -104,6 +114,10 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 
 	// Check for deletion
 	if b.DeletionTimestamp != nil {
+		if err := protection.Check(b); err != nil {
+			b.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
+			return resultRequeue, r.Status().Update(ctx, b)
+		}
 		return bh.delete(ctx)
 	}
 
@@ -116,6 +130,15 @@ type factory interface {
 
 type bucketFactory struct {
 	client.Client
+	pool   *pool.Pool
+	record *event.Recorder
+}
+
+// storageClient is a storage client and the project of the credentials it was
+// created with.
+type storageClient struct {
+	*storage.Client
+	projectID string
 }
 
 func (m *bucketFactory) newSyncDeleter(ctx context.Context, b *v1alpha1.Bucket) (syncdeleter, error) {
@@ -124,31 +147,29 @@ func (m *bucketFactory) newSyncDeleter(ctx context.Context, b *v1alpha1.Bucket)
 		return nil, err
 	}
 
-	s := &corev1.Secret{}
-	n := types.NamespacedName{Namespace: p.GetNamespace(), Name: p.Spec.Secret.Name}
-	if err := m.Get(ctx, n, s); err != nil {
-		return nil, errors.Wrapf(err, "cannot get provider's secret %s", n)
-	}
-
-	creds, err := google.CredentialsFromJSON(context.Background(), s.Data[p.Spec.Secret.Key], storage.ScopeFullControl)
-	if err != nil {
-		return nil, errors.Wrapf(err, "cannot retrieve creds from json")
-	}
-
-	sc, err := storage.NewClient(ctx, option.WithCredentials(creds))
+	c, err := gcp.PooledClient(ctx, m.pool, m.Client, p, gcpstorage.API, "", func(creds *google.Credentials, hc *http.Client) (interface{}, error) {
+		// Pooled clients outlive the supplied context.
+		sc, err := storage.NewClient(context.Background(), option.WithHTTPClient(hc))
+		if err != nil {
+			return nil, errors.Wrapf(err, "error creating storage client")
+		}
+		return &storageClient{Client: sc, projectID: creds.ProjectID}, nil
+	}, storage.ScopeFullControl)
 	if err != nil {
-		return nil, errors.Wrapf(err, "error creating storage client")
+		return nil, err
 	}
+	sc := c.(*storageClient)
 
 	ops := &bucketHandler{
 		Bucket: b,
 		gcp:    &gcpstorage.BucketClient{BucketHandle: sc.Bucket(b.GetBucketName())},
 		kube:   m.Client,
+		record: m.record,
 	}
 
 	return &bucketSyncDeleter{
 		operations:    ops,
-		createupdater: &bucketCreateUpdater{operations: ops, projectID: creds.ProjectID},
+		createupdater: &bucketCreateUpdater{operations: ops, projectID: sc.projectID},
 	}, nil
 
 }
@@ -31,6 +31,7 @@ import (
 	"github.com/crossplaneio/crossplane-runtime/pkg/util"
 	"github.com/crossplaneio/crossplane/gcp/apis/storage/v1alpha1"
 	gcpstorage "github.com/crossplaneio/crossplane/pkg/clients/gcp/storage"
+	"github.com/crossplaneio/crossplane/pkg/event"
 )
 
 type operations interface {
@@ -58,8 +59,9 @@ type operations interface {
 
 type bucketHandler struct {
 	*v1alpha1.Bucket
-	kube client.Client
-	gcp  gcpstorage.Client
+	kube   client.Client
+	gcp    gcpstorage.Client
+	record *event.Recorder
 }
 
 var _ operations = &bucketHandler{}
@@ -100,7 +102,11 @@ func (bh *bucketHandler) setStatusAttrs(attrs *storage.BucketAttrs) {
 }
 
 func (bh *bucketHandler) setStatusConditions(c ...runtimev1alpha1.Condition) {
+	wasReady := event.Ready(bh.Bucket)
 	bh.Status.SetConditions(c...)
+	if !wasReady && event.Ready(bh.Bucket) {
+		bh.record.Normal(bh.Bucket, event.ReasonBecameReady, "External resource became ready")
+	}
 }
 
 func (bh *bucketHandler) setBindable() {
@@ -148,15 +154,35 @@ func (bh *bucketHandler) updateSecret(ctx context.Context) error {
 // GCP Storage Bucket operations
 //
 func (bh *bucketHandler) createBucket(ctx context.Context, projectID string) error {
-	return bh.gcp.Create(ctx, projectID, v1alpha1.CopyBucketSpecAttrs(&bh.Spec.BucketSpecAttrs))
+	if err := bh.gcp.Create(ctx, projectID, v1alpha1.CopyBucketSpecAttrs(&bh.Spec.BucketSpecAttrs)); err != nil {
+		bh.record.Failed(bh.Bucket, err)
+		return err
+	}
+	bh.record.Normal(bh.Bucket, event.ReasonCreateRequested, "Requested creation of external resource")
+	return nil
 }
 
 func (bh *bucketHandler) deleteBucket(ctx context.Context) error {
-	return bh.gcp.Delete(ctx)
+	err := bh.gcp.Delete(ctx)
+	if err == storage.ErrBucketNotExist {
+		return err
+	}
+	if err != nil {
+		bh.record.Failed(bh.Bucket, err)
+		return err
+	}
+	bh.record.Normal(bh.Bucket, event.ReasonDeletionStarted, "Requested deletion of external resource")
+	return nil
 }
 
 func (bh *bucketHandler) updateBucket(ctx context.Context, labels map[string]string) (*storage.BucketAttrs, error) {
-	return bh.gcp.Update(ctx, v1alpha1.CopyToBucketUpdateAttrs(bh.Spec.BucketUpdatableAttrs, labels))
+	attrs, err := bh.gcp.Update(ctx, v1alpha1.CopyToBucketUpdateAttrs(bh.Spec.BucketUpdatableAttrs, labels))
+	if err != nil {
+		bh.record.Failed(bh.Bucket, err)
+		return nil, err
+	}
+	bh.record.Normal(bh.Bucket, event.ReasonUpdateApplied, "Applied update to external resource")
+	return attrs, nil
 }
 
 func (bh *bucketHandler) getAttributes(ctx context.Context) (*storage.BucketAttrs, error) {
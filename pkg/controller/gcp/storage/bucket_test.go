@@ -345,7 +345,7 @@ func Test_bucketFactory_newHandler(t *testing.T) {
 			bucket: newBucket(ns, bucketName).withProvider(ns, providerName).Bucket,
 			want: want{
 				err: errors.WithStack(
-					errors.Errorf("cannot get provider's secret %s/%s: secrets \"%s\" not found", ns, secretName, secretName)),
+					errors.Errorf("cannot get provider secret %s/%s: secrets \"%s\" not found", ns, secretName, secretName)),
 			},
 		},
 		{
@@ -0,0 +1,49 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	awsv1alpha1 "github.com/crossplaneio/crossplane/aws/apis/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/aws"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
+)
+
+// AWSController is responsible for adding the provider controller for AWS
+// providers and its corresponding reconciler to the manager with any runtime configuration.
+type AWSController struct{}
+
+// SetupWithManager adds a provider controller that validates the credentials
+// of AWS providers by calling STS GetCallerIdentity.
+func (c *AWSController) SetupWithManager(mgr ctrl.Manager) error {
+	v := ValidatorFn(func(ctx context.Context, p Provider) (string, error) {
+		return aws.ValidateProvider(ctx, mgr.GetClient(), p.(*awsv1alpha1.Provider))
+	})
+	r := NewReconciler(mgr, awsv1alpha1.ProviderGroupVersionKind, v, WithRejectedFn(aws.IsErrorUnauthorized))
+
+	name := strings.ToLower(fmt.Sprintf("%s.%s", awsv1alpha1.ProviderKind, awsv1alpha1.Group))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&awsv1alpha1.Provider{}).
+		Complete(tracing.NewTracer(name).Reconciler(r))
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	azurev1alpha1 "github.com/crossplaneio/crossplane/azure/apis/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/azure"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
+)
+
+// AzureController is responsible for adding the provider controller for Azure
+// providers and its corresponding reconciler to the manager with any runtime configuration.
+type AzureController struct{}
+
+// SetupWithManager adds a provider controller that validates the credentials
+// of Azure providers by acquiring an Azure Active Directory token.
+func (c *AzureController) SetupWithManager(mgr ctrl.Manager) error {
+	v := ValidatorFn(func(ctx context.Context, p Provider) (string, error) {
+		return azure.ValidateProvider(ctx, mgr.GetClient(), p.(*azurev1alpha1.Provider))
+	})
+	r := NewReconciler(mgr, azurev1alpha1.ProviderGroupVersionKind, v, WithRejectedFn(azure.IsUnauthorized))
+
+	name := strings.ToLower(fmt.Sprintf("%s.%s", azurev1alpha1.ProviderKind, azurev1alpha1.Group))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&azurev1alpha1.Provider{}).
+		Complete(tracing.NewTracer(name).Reconciler(r))
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	gcpv1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/gcp"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
+)
+
+// GCPController is responsible for adding the provider controller for GCP
+// providers and its corresponding reconciler to the manager with any runtime configuration.
+type GCPController struct{}
+
+// SetupWithManager adds a provider controller that validates the credentials
+// of GCP providers by testing that they are granted their required permissions.
+func (c *GCPController) SetupWithManager(mgr ctrl.Manager) error {
+	v := ValidatorFn(func(ctx context.Context, p Provider) (string, error) {
+		return gcp.ValidateProvider(ctx, mgr.GetClient(), p.(*gcpv1alpha1.Provider))
+	})
+	r := NewReconciler(mgr, gcpv1alpha1.ProviderGroupVersionKind, v, WithRejectedFn(gcp.IsErrorUnauthorized))
+
+	name := strings.ToLower(fmt.Sprintf("%s.%s", gcpv1alpha1.ProviderKind, gcpv1alpha1.Group))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&gcpv1alpha1.Provider{}).
+		Complete(tracing.NewTracer(name).Reconciler(r))
+}
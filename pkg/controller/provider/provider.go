@@ -0,0 +1,338 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package provider contains controllers that validate the credentials of
+// cloud providers and report whether they are ready for use.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/logging"
+	awsv1alpha1 "github.com/crossplaneio/crossplane/aws/apis/v1alpha1"
+	azurev1alpha1 "github.com/crossplaneio/crossplane/azure/apis/v1alpha1"
+	gcpv1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
+)
+
+const (
+	controllerBaseName = "provider.crossplane.io"
+
+	reconcileTimeout = 1 * time.Minute
+
+	// Valid credentials are revalidated periodically in case they expire
+	// or are revoked. Invalid credentials are revalidated more often, so
+	// that a fixed provider becomes ready promptly.
+	validationInterval = 5 * time.Minute
+	aShortWait         = 1 * time.Minute
+
+	// Errors that do not indicate credentials were rejected, for example
+	// timeouts, may be transient. A provider is only considered unready
+	// once its credentials fail to validate this many times in a row.
+	maxFailures = 5
+)
+
+var log = logging.Logger.WithName("controller." + controllerBaseName)
+
+// Error strings.
+const (
+	errGetProvider          = "cannot get provider"
+	errUpdateProviderStatus = "cannot update provider status"
+)
+
+// Reasons a provider's credentials are or are not valid.
+const (
+	ReasonCredentialsValid   runtimev1alpha1.ConditionReason = "Successfully validated provider credentials"
+	ReasonCredentialsInvalid runtimev1alpha1.ConditionReason = "Cannot validate provider credentials"
+)
+
+// Reasons for the events emitted when a provider's credentials become valid
+// or invalid.
+const (
+	EventReasonCredentialsValid   = "CredentialsValid"
+	EventReasonCredentialsInvalid = "CredentialsInvalid"
+	EventReasonValidationFailed   = "ValidationFailed"
+)
+
+// CredentialsValid returns a condition that indicates a provider's
+// credentials are valid, and authenticate as the supplied identity.
+func CredentialsValid(identity string) runtimev1alpha1.Condition {
+	c := runtimev1alpha1.Condition{
+		Type:               runtimev1alpha1.TypeReady,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonCredentialsValid,
+	}
+	if identity != "" {
+		c.Message = fmt.Sprintf("authenticated as %s", identity)
+	}
+	return c
+}
+
+// CredentialsInvalid returns a condition that indicates a provider's
+// credentials could not be validated.
+func CredentialsInvalid(err error) runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               runtimev1alpha1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonCredentialsInvalid,
+		Message:            err.Error(),
+	}
+}
+
+// A Provider of cloud credentials.
+type Provider interface {
+	runtime.Object
+	metav1.Object
+
+	SetConditions(c ...runtimev1alpha1.Condition)
+	GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition
+
+	SetIdentity(identity string)
+	GetIdentity() string
+}
+
+// A Validator validates the credentials of a provider.
+type Validator interface {
+	// Validate the credentials of the supplied provider, returning the
+	// identity as which they authenticate. The identity may be empty if it
+	// cannot be determined.
+	Validate(ctx context.Context, p Provider) (string, error)
+}
+
+// A ValidatorFn validates the credentials of a provider.
+type ValidatorFn func(ctx context.Context, p Provider) (string, error)
+
+// Validate the credentials of the supplied provider.
+func (fn ValidatorFn) Validate(ctx context.Context, p Provider) (string, error) {
+	return fn(ctx, p)
+}
+
+// A RejectedFn returns true if the supplied validation error indicates that
+// a provider's credentials were rejected, for example because they are
+// invalid or are not authorized to perform an operation.
+type RejectedFn func(err error) bool
+
+// A ReconcilerOption configures a Reconciler.
+type ReconcilerOption func(*Reconciler)
+
+// WithRejectedFn configures how a Reconciler determines whether a validation
+// error indicates a provider's credentials were rejected. Providers whose
+// credentials are rejected are immediately considered unready. By default no
+// errors are considered rejections.
+func WithRejectedFn(fn RejectedFn) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.rejected = fn
+	}
+}
+
+// WithPool configures the pool of cloud API clients from which a Reconciler
+// removes the clients of deleted providers. The default pool is used by
+// default.
+func WithPool(p *pool.Pool) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.pool = p
+	}
+}
+
+// A Reconciler periodically validates the credentials of providers of one
+// kind, and reports whether they are ready for use.
+type Reconciler struct {
+	client      client.Client
+	newProvider func() Provider
+	validator   Validator
+	rejected    RejectedFn
+	record      record.EventRecorder
+	pool        *pool.Pool
+
+	mu       sync.Mutex
+	failures map[types.NamespacedName]int
+	uids     map[types.NamespacedName]types.UID
+}
+
+// NewReconciler returns a Reconciler that validates the credentials of
+// providers of the supplied kind using the supplied validator.
+func NewReconciler(m ctrl.Manager, of schema.GroupVersionKind, v Validator, o ...ReconcilerOption) *Reconciler {
+	np := func() Provider {
+		o, err := m.GetScheme().New(of)
+		if err != nil {
+			// Controllers are only added for provider kinds that are
+			// registered with the scheme, so this should never happen.
+			panic(err)
+		}
+		return o.(Provider)
+	}
+
+	r := &Reconciler{
+		client:      m.GetClient(),
+		newProvider: np,
+		validator:   v,
+		record:      m.GetEventRecorderFor(controllerBaseName),
+		pool:        pool.Default,
+	}
+
+	for _, ro := range o {
+		ro(r)
+	}
+
+	return r
+}
+
+// Reconcile a provider by validating its credentials. A provider becomes
+// unready when its credentials are rejected, or when they fail to validate
+// for any other reason maxFailures times in a row. Other failures emit a
+// warning event but do not change the provider's readiness. Events are
+// otherwise emitted only when a provider becomes ready or unready. The cached
+// cloud API clients of deleted providers are removed from the pool.
+func (r *Reconciler) Reconcile(req reconcile.Request) (reconcile.Result, error) {
+	log.V(logging.Debug).Info("reconciling", "controller", controllerBaseName, "request", req)
+
+	ctx, cancel := context.WithTimeout(context.Background(), reconcileTimeout)
+	defer cancel()
+
+	p := r.newProvider()
+	if err := r.client.Get(ctx, req.NamespacedName, p); err != nil {
+		if kerrors.IsNotFound(err) {
+			r.forget(req.NamespacedName)
+			r.release(req.NamespacedName)
+			return reconcile.Result{Requeue: false}, nil
+		}
+		return reconcile.Result{Requeue: false}, errors.Wrap(err, errGetProvider)
+	}
+
+	r.track(req.NamespacedName, p.GetUID())
+
+	previous := p.GetCondition(runtimev1alpha1.TypeReady)
+
+	identity, err := r.validator.Validate(ctx, p)
+	if err != nil && !r.isRejected(err) && r.failed(req.NamespacedName) < maxFailures {
+		r.record.Event(p, corev1.EventTypeWarning, EventReasonValidationFailed, err.Error())
+		return reconcile.Result{RequeueAfter: aShortWait}, nil
+	}
+	if err != nil {
+		if previous.Status != corev1.ConditionFalse {
+			r.record.Event(p, corev1.EventTypeWarning, EventReasonCredentialsInvalid, err.Error())
+		}
+		p.SetIdentity("")
+		p.SetConditions(CredentialsInvalid(err))
+		return reconcile.Result{RequeueAfter: aShortWait}, errors.Wrap(r.client.Status().Update(ctx, p), errUpdateProviderStatus)
+	}
+
+	r.forget(req.NamespacedName)
+
+	c := CredentialsValid(identity)
+	if previous.Status != corev1.ConditionTrue {
+		r.record.Event(p, corev1.EventTypeNormal, EventReasonCredentialsValid, string(c.Reason))
+	}
+	p.SetIdentity(identity)
+	p.SetConditions(c)
+	return reconcile.Result{RequeueAfter: validationInterval}, errors.Wrap(r.client.Status().Update(ctx, p), errUpdateProviderStatus)
+}
+
+func (r *Reconciler) isRejected(err error) bool {
+	return r.rejected != nil && r.rejected(err)
+}
+
+// failed records that the credentials of the named provider failed to
+// validate, and returns how many times in a row they have done so.
+func (r *Reconciler) failed(n types.NamespacedName) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.failures == nil {
+		r.failures = map[types.NamespacedName]int{}
+	}
+	r.failures[n]++
+	return r.failures[n]
+}
+
+// forget discards any failures recorded for the named provider, for example
+// because its credentials were successfully validated.
+func (r *Reconciler) forget(n types.NamespacedName) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.failures, n)
+}
+
+// track records the UID of the named provider, so that its clients can be
+// removed from the pool once it is deleted. The clients of any provider
+// previously known by the same name are removed.
+func (r *Reconciler) track(n types.NamespacedName, uid types.UID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.uids == nil {
+		r.uids = map[types.NamespacedName]types.UID{}
+	}
+	if previous, ok := r.uids[n]; ok && previous != uid {
+		r.pool.Remove(previous)
+	}
+	r.uids[n] = uid
+}
+
+// release removes the clients of the named provider from the pool, because
+// it was deleted.
+func (r *Reconciler) release(n types.NamespacedName) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if uid, ok := r.uids[n]; ok {
+		r.pool.Remove(uid)
+		delete(r.uids, n)
+	}
+}
+
+// Controllers passes down config and adds individual controllers to the manager.
+type Controllers struct{}
+
+// SetupWithManager adds the provider controllers of each cloud provider whose
+// provider kind is registered with the manager's scheme. Cloud providers that
+// are not enabled are not registered, and their CRDs may not be installed.
+func (c *Controllers) SetupWithManager(mgr ctrl.Manager) error {
+	controllers := []struct {
+		kind       schema.GroupVersionKind
+		controller interface{ SetupWithManager(ctrl.Manager) error }
+	}{
+		{kind: awsv1alpha1.ProviderGroupVersionKind, controller: &AWSController{}},
+		{kind: azurev1alpha1.ProviderGroupVersionKind, controller: &AzureController{}},
+		{kind: gcpv1alpha1.ProviderGroupVersionKind, controller: &GCPController{}},
+	}
+
+	for _, pc := range controllers {
+		if !mgr.GetScheme().Recognizes(pc.kind) {
+			continue
+		}
+		if err := pc.controller.SetupWithManager(mgr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
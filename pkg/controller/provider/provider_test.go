@@ -0,0 +1,214 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	awsv1alpha1 "github.com/crossplaneio/crossplane/aws/apis/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/clients/pool"
+)
+
+const (
+	namespace    = "crossplane-system"
+	providerName = "cool-provider"
+	identity     = "arn:aws:iam::123456789012:user/cool-user"
+)
+
+var errBoom = errors.New("boom")
+
+func provider(c ...runtimev1alpha1.Condition) *awsv1alpha1.Provider {
+	p := &awsv1alpha1.Provider{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: providerName}}
+	p.SetConditions(c...)
+	return p
+}
+
+func TestReconcile(t *testing.T) {
+	type want struct {
+		result    reconcile.Result
+		err       error
+		identity  string
+		condition *runtimev1alpha1.Condition
+		events    int
+	}
+
+	valid := CredentialsValid(identity)
+	invalid := CredentialsInvalid(errBoom)
+	succeed := ValidatorFn(func(_ context.Context, _ Provider) (string, error) { return identity, nil })
+	fail := ValidatorFn(func(_ context.Context, _ Provider) (string, error) { return "", errBoom })
+	rejected := func(_ error) bool { return true }
+
+	cases := map[string]struct {
+		provider  *awsv1alpha1.Provider
+		getErr    error
+		validator Validator
+		rejected  RejectedFn
+		failures  int
+		want      want
+	}{
+		"NotFound": {
+			getErr: kerrors.NewNotFound(schema.GroupResource{}, providerName),
+		},
+		"GetFailed": {
+			getErr: errBoom,
+			want:   want{err: errors.Wrap(errBoom, errGetProvider)},
+		},
+		"BecameValid": {
+			provider:  provider(),
+			validator: succeed,
+			want:      want{result: reconcile.Result{RequeueAfter: validationInterval}, identity: identity, condition: &valid, events: 1},
+		},
+		"StillValid": {
+			provider:  provider(valid),
+			validator: succeed,
+			want:      want{result: reconcile.Result{RequeueAfter: validationInterval}, identity: identity, condition: &valid},
+		},
+		"BecameInvalid": {
+			provider:  provider(valid),
+			validator: fail,
+			rejected:  rejected,
+			want:      want{result: reconcile.Result{RequeueAfter: aShortWait}, condition: &invalid, events: 1},
+		},
+		"StillInvalid": {
+			provider:  provider(invalid),
+			validator: fail,
+			rejected:  rejected,
+			want:      want{result: reconcile.Result{RequeueAfter: aShortWait}, condition: &invalid},
+		},
+		"TransientFailure": {
+			provider:  provider(valid),
+			validator: fail,
+			want:      want{result: reconcile.Result{RequeueAfter: aShortWait}, events: 1},
+		},
+		"TooManyFailures": {
+			provider:  provider(valid),
+			validator: fail,
+			failures:  maxFailures - 1,
+			want:      want{result: reconcile.Result{RequeueAfter: aShortWait}, condition: &invalid, events: 1},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &awsv1alpha1.Provider{}
+			rec := record.NewFakeRecorder(10)
+
+			r := &Reconciler{
+				client: &test.MockClient{
+					MockGet: func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+						if tc.getErr != nil {
+							return tc.getErr
+						}
+						tc.provider.DeepCopyInto(obj.(*awsv1alpha1.Provider))
+						return nil
+					},
+					MockStatusUpdate: func(_ context.Context, obj runtime.Object, _ ...client.UpdateOption) error {
+						obj.(*awsv1alpha1.Provider).DeepCopyInto(got)
+						return nil
+					},
+				},
+				newProvider: func() Provider { return &awsv1alpha1.Provider{} },
+				validator:   tc.validator,
+				rejected:    tc.rejected,
+				record:      rec,
+				failures:    map[types.NamespacedName]int{{Namespace: namespace, Name: providerName}: tc.failures},
+			}
+
+			result, err := r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: providerName}})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r.Reconcile(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, result); diff != "" {
+				t.Errorf("r.Reconcile(...): -want result, +got result:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.identity, got.GetIdentity()); diff != "" {
+				t.Errorf("r.Reconcile(...): -want identity, +got identity:\n%s", diff)
+			}
+
+			var c *runtimev1alpha1.Condition
+			if len(got.Status.Conditions) > 0 {
+				c = &got.Status.Conditions[0]
+			}
+			if diff := cmp.Diff(tc.want.condition, c, test.EquateConditions()); diff != "" {
+				t.Errorf("r.Reconcile(...): -want condition, +got condition:\n%s", diff)
+			}
+			if len(rec.Events) != tc.want.events {
+				t.Errorf("r.Reconcile(...): want %d events, got %d", tc.want.events, len(rec.Events))
+			}
+		})
+	}
+}
+
+func TestReconcileRemovesDeletedProvider(t *testing.T) {
+	p := pool.New(pool.DefaultLimit, nil)
+	k := pool.Key{Provider: "cool-uid", API: "cool.example.org"}
+	if _, err := p.Get(k, "1", func() (interface{}, error) { return 42, nil }); err != nil {
+		t.Fatalf("p.Get(...): %s", err)
+	}
+
+	var deleted bool
+	r := &Reconciler{
+		client: &test.MockClient{
+			MockGet: func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+				if deleted {
+					return kerrors.NewNotFound(schema.GroupResource{}, providerName)
+				}
+				pr := provider()
+				pr.SetUID(k.Provider)
+				pr.DeepCopyInto(obj.(*awsv1alpha1.Provider))
+				return nil
+			},
+			MockStatusUpdate: test.NewMockStatusUpdateFn(nil),
+		},
+		newProvider: func() Provider { return &awsv1alpha1.Provider{} },
+		validator:   ValidatorFn(func(_ context.Context, _ Provider) (string, error) { return identity, nil }),
+		record:      record.NewFakeRecorder(10),
+		pool:        p,
+	}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: providerName}}
+
+	if _, err := r.Reconcile(req); err != nil {
+		t.Fatalf("r.Reconcile(...): %s", err)
+	}
+	got, _ := p.Get(k, "1", func() (interface{}, error) { return 43, nil })
+	if got != 42 {
+		t.Errorf("p.Get(...): want the client of an existing provider to be cached, got %v", got)
+	}
+
+	deleted = true
+	if _, err := r.Reconcile(req); err != nil {
+		t.Fatalf("r.Reconcile(...): %s", err)
+	}
+	got, _ = p.Get(k, "1", func() (interface{}, error) { return 43, nil })
+	if got != 43 {
+		t.Errorf("p.Get(...): want the client of a deleted provider to be removed, got %v", got)
+	}
+}
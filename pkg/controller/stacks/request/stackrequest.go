@@ -45,6 +45,10 @@ import (
 	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
 	"github.com/crossplaneio/crossplane-runtime/pkg/util"
 	"github.com/crossplaneio/crossplane/apis/stacks/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/metrics"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
 )
 
 const (
@@ -73,6 +77,7 @@ type Reconciler struct {
 	sync.Mutex
 	kube       client.Client
 	kubeclient kubernetes.Interface
+	record     *event.Recorder
 	factory
 	executorInfoDiscovery
 	executorInfo *executorInfo
@@ -85,17 +90,20 @@ type Controller struct{}
 // SetupWithManager creates a new Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
 func (c *Controller) SetupWithManager(mgr ctrl.Manager) error {
+	recorder := event.NewRecorder(mgr.GetEventRecorderFor(controllerName))
+
 	r := &Reconciler{
 		kube:                  mgr.GetClient(),
 		kubeclient:            kubernetes.NewForConfigOrDie(mgr.GetConfig()),
-		factory:               &handlerFactory{},
+		record:                recorder,
+		factory:               &handlerFactory{record: recorder},
 		executorInfoDiscovery: &executorInfoDiscoverer{kube: mgr.GetClient()},
 	}
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(controllerName).
 		For(&v1alpha1.StackRequest{}).
-		Complete(r)
+		Complete(tracing.NewTracer(controllerName).Reconciler(pause.NewReconciler(mgr.GetClient(), &v1alpha1.StackRequest{}, r)))
 }
 
 // Reconcile reads that state of the StackRequest for a Instance object and makes changes based on the state read
@@ -116,6 +124,7 @@ func (r *Reconciler) Reconcile(req reconcile.Request) (reconcile.Result, error)
 	}
 
 	if err := r.discoverExecutorInfo(ctx); err != nil {
+		r.record.Failed(i, err)
 		return fail(ctx, r.kube, i, err)
 	}
 
@@ -137,6 +146,7 @@ type stackRequestHandler struct {
 	jobCompleter jobCompleter
 	executorInfo executorInfo
 	ext          *v1alpha1.StackRequest
+	record       *event.Recorder
 }
 
 // jobCompleter is an interface for handling job completion
@@ -165,7 +175,9 @@ type factory interface {
 	newHandler(context.Context, *v1alpha1.StackRequest, client.Client, kubernetes.Interface, executorInfo) handler
 }
 
-type handlerFactory struct{}
+type handlerFactory struct {
+	record *event.Recorder
+}
 
 func (f *handlerFactory) newHandler(ctx context.Context, ext *v1alpha1.StackRequest,
 	kube client.Client, kubeclient kubernetes.Interface, ei executorInfo) handler {
@@ -174,6 +186,7 @@ func (f *handlerFactory) newHandler(ctx context.Context, ext *v1alpha1.StackRequ
 		ext:          ext,
 		kube:         kube,
 		executorInfo: ei,
+		record:       f.record,
 		jobCompleter: &stackRequestJobCompleter{
 			kube: kube,
 			podLogReader: &k8sPodLogReader{
@@ -204,8 +217,10 @@ func (h *stackRequestHandler) create(ctx context.Context) (reconcile.Result, err
 		// there is no install job created yet, create it now
 		job := createInstallJob(h.ext, h.executorInfo)
 		if err := h.kube.Create(ctx, job); err != nil {
+			h.record.Failed(h.ext, err)
 			return fail(ctx, h.kube, h.ext, err)
 		}
+		h.record.Normal(h.ext, event.ReasonCreateRequested, fmt.Sprintf("Created install job %s", job.Name))
 
 		jobRef = &corev1.ObjectReference{
 			Name:      job.Name,
@@ -223,6 +238,7 @@ func (h *stackRequestHandler) create(ctx context.Context) (reconcile.Result, err
 	// the install job already exists, let's check its status and completion
 	job := &batchv1.Job{}
 	if err := h.kube.Get(ctx, meta.NamespacedNameOf(jobRef), job); err != nil {
+		h.record.Failed(h.ext, err)
 		return fail(ctx, h.kube, h.ext, err)
 	}
 
@@ -237,15 +253,28 @@ func (h *stackRequestHandler) create(ctx context.Context) (reconcile.Result, err
 			case batchv1.JobComplete:
 				// the install job succeeded, process the output
 				if err := h.jobCompleter.handleJobCompletion(ctx, h.ext, job); err != nil {
+					h.record.Failed(h.ext, err)
 					return fail(ctx, h.kube, h.ext, err)
 				}
 
 				// the install job's completion was handled successfully, this stack request is ready
+				if !event.Ready(h.ext) {
+					h.record.Normal(h.ext, event.ReasonBecameReady, "Installed stack")
+				}
 				h.ext.Status.SetConditions(runtimev1alpha1.Available(), runtimev1alpha1.ReconcileSuccess())
-				return requeueOnSuccess, h.kube.Status().Update(ctx, h.ext)
+				if err := h.kube.Status().Update(ctx, h.ext); err != nil {
+					return requeueOnSuccess, err
+				}
+
+				// the install is only observed once its completion has been recorded, so that it is not
+				// observed again if the completion is handled again
+				metrics.StackInstallDuration.Observe(time.Since(h.ext.GetCreationTimestamp().Time).Seconds())
+				return requeueOnSuccess, nil
 			case batchv1.JobFailed:
 				// the install job failed, report the failure
-				return fail(ctx, h.kube, h.ext, errors.New(c.Message))
+				err := errors.New(c.Message)
+				h.record.Failed(h.ext, err)
+				return fail(ctx, h.kube, h.ext, err)
 			}
 		}
 	}
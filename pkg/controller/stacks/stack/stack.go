@@ -18,6 +18,7 @@ package stack
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	apps "k8s.io/api/apps/v1"
@@ -37,6 +38,9 @@ import (
 	"github.com/crossplaneio/crossplane-runtime/pkg/logging"
 	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
 	"github.com/crossplaneio/crossplane/apis/stacks/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
 )
 
 const (
@@ -67,13 +71,13 @@ type Controller struct{}
 func (c *Controller) SetupWithManager(mgr ctrl.Manager) error {
 	r := &Reconciler{
 		kube:    mgr.GetClient(),
-		factory: &stackHandlerFactory{},
+		factory: &stackHandlerFactory{record: event.NewRecorder(mgr.GetEventRecorderFor(controllerName))},
 	}
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(controllerName).
 		For(&v1alpha1.Stack{}).
-		Complete(r)
+		Complete(tracing.NewTracer(controllerName).Reconciler(pause.NewReconciler(mgr.GetClient(), &v1alpha1.Stack{}, r)))
 }
 
 // Reconcile reads that state of the Stack for a Instance object and makes changes based on the state read
@@ -105,20 +109,24 @@ type handler interface {
 }
 
 type stackHandler struct {
-	kube client.Client
-	ext  *v1alpha1.Stack
+	kube   client.Client
+	ext    *v1alpha1.Stack
+	record *event.Recorder
 }
 
 type factory interface {
 	newHandler(context.Context, *v1alpha1.Stack, client.Client) handler
 }
 
-type stackHandlerFactory struct{}
+type stackHandlerFactory struct {
+	record *event.Recorder
+}
 
 func (f *stackHandlerFactory) newHandler(ctx context.Context, ext *v1alpha1.Stack, kube client.Client) handler {
 	return &stackHandler{
-		kube: kube,
-		ext:  ext,
+		kube:   kube,
+		ext:    ext,
+		record: f.record,
 	}
 }
 
@@ -138,19 +146,26 @@ func (h *stackHandler) create(ctx context.Context) (reconcile.Result, error) {
 
 	// create RBAC permissions
 	if err := h.processRBAC(ctx); err != nil {
+		h.record.Failed(h.ext, err)
 		return fail(ctx, h.kube, h.ext, err)
 	}
 
 	// create controller deployment or job
 	if err := h.processDeployment(ctx); err != nil {
+		h.record.Failed(h.ext, err)
 		return fail(ctx, h.kube, h.ext, err)
 	}
 
 	if err := h.processJob(ctx); err != nil {
+		h.record.Failed(h.ext, err)
 		return fail(ctx, h.kube, h.ext, err)
 	}
 
 	// the stack has successfully been created, the stack is ready
+	if h.ext.Status.ControllerRef != nil {
+		h.record.Normal(h.ext, event.ReasonCreateRequested, fmt.Sprintf("Created controller %s", h.ext.Status.ControllerRef.Name))
+	}
+	h.record.Normal(h.ext, event.ReasonBecameReady, "Installed stack")
 	h.ext.Status.SetConditions(runtimev1alpha1.Available(), runtimev1alpha1.ReconcileSuccess())
 	return requeueOnSuccess, h.kube.Status().Update(ctx, h.ext)
 }
@@ -38,6 +38,9 @@ import (
 	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
 	"github.com/crossplaneio/crossplane-runtime/pkg/util"
 	"github.com/crossplaneio/crossplane/apis/workload/v1alpha1"
+	xpevent "github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
 )
 
 const (
@@ -80,11 +83,12 @@ func Add(mgr manager.Manager) error {
 	r := &Reconciler{
 		kube: kube,
 		local: &localCluster{
-			ar: &applicationResourceClient{kube: kube},
-			gc: &applicationResourceGarbageCollector{kube: kube},
+			ar:     &applicationResourceClient{kube: kube},
+			gc:     &applicationResourceGarbageCollector{kube: kube},
+			record: xpevent.NewRecorder(mgr.GetEventRecorderFor(controllerName)),
 		},
 	}
-	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: r})
+	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: tracing.NewTracer(controllerName).Reconciler(r)})
 	if err != nil {
 		return errors.Wrap(err, "cannot create Kubernetes controller")
 	}
@@ -115,8 +119,9 @@ func (c *Controller) SetupWithManager(mgr ctrl.Manager) error {
 	r := &Reconciler{
 		kube: kube,
 		local: &localCluster{
-			ar: &applicationResourceClient{kube: kube},
-			gc: &applicationResourceGarbageCollector{kube: kube},
+			ar:     &applicationResourceClient{kube: kube},
+			gc:     &applicationResourceGarbageCollector{kube: kube},
+			record: xpevent.NewRecorder(mgr.GetEventRecorderFor(controllerName)),
 		},
 	}
 
@@ -125,14 +130,15 @@ func (c *Controller) SetupWithManager(mgr ctrl.Manager) error {
 		For(&v1alpha1.KubernetesApplication{}).
 		Owns(&v1alpha1.KubernetesApplicationResource{}).
 		WithEventFilter(&predicate.Funcs{CreateFunc: CreatePredicate, UpdateFunc: UpdatePredicate}).
-		Complete(r)
+		Complete(tracing.NewTracer(controllerName).Reconciler(pause.NewReconciler(mgr.GetClient(), &v1alpha1.KubernetesApplication{}, r, pause.WithObjectUpdate())))
 }
 
 // localCluster is a syncDeleter that syncs and deletes resources from the same
 // cluster as their controlling application.
 type localCluster struct {
-	ar applicationResourceSyncer
-	gc garbageCollector
+	ar     applicationResourceSyncer
+	gc     garbageCollector
+	record *xpevent.Recorder
 }
 
 func (c *localCluster) sync(ctx context.Context, app *v1alpha1.KubernetesApplication) reconcile.Result {
@@ -141,6 +147,7 @@ func (c *localCluster) sync(ctx context.Context, app *v1alpha1.KubernetesApplica
 
 	// Garbage collect any resource we control but no longer have templates for.
 	if err := c.gc.process(ctx, app); err != nil {
+		c.record.Failed(app, err)
 		app.Status.State = v1alpha1.KubernetesApplicationStateFailed
 		app.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
 		return reconcile.Result{Requeue: true}
@@ -155,6 +162,7 @@ func (c *localCluster) sync(ctx context.Context, app *v1alpha1.KubernetesApplica
 		}
 
 		if err != nil {
+			c.record.Failed(app, err)
 			app.Status.State = v1alpha1.KubernetesApplicationStateFailed
 			app.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
 			return reconcile.Result{Requeue: true}
@@ -177,6 +185,9 @@ func (c *localCluster) sync(ctx context.Context, app *v1alpha1.KubernetesApplica
 		return reconcile.Result{RequeueAfter: requeueOnWait}
 	}
 
+	if app.Status.State != v1alpha1.KubernetesApplicationStateSubmitted {
+		c.record.Normal(app, xpevent.ReasonBecameReady, "Submitted all resources to the scheduled cluster")
+	}
 	app.Status.State = v1alpha1.KubernetesApplicationStateSubmitted
 	app.Status.SetConditions(runtimev1alpha1.ReconcileSuccess())
 	return reconcile.Result{Requeue: false}
@@ -43,6 +43,9 @@ import (
 	"github.com/crossplaneio/crossplane-runtime/pkg/util"
 	computev1alpha1 "github.com/crossplaneio/crossplane/apis/compute/v1alpha1"
 	"github.com/crossplaneio/crossplane/apis/workload/v1alpha1"
+	xpevent "github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
 )
 
 const (
@@ -91,16 +94,19 @@ type Controller struct{}
 // SetupWithManager creates a new Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
 func (c *Controller) SetupWithManager(mgr ctrl.Manager) error {
+	recorder := xpevent.NewRecorder(mgr.GetEventRecorderFor(controllerName))
+
 	r := &Reconciler{
-		connecter: &clusterConnecter{kube: mgr.GetClient()},
+		connecter: &clusterConnecter{kube: mgr.GetClient(), record: recorder},
 		kube:      mgr.GetClient(),
+		record:    recorder,
 	}
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(controllerName).
 		For(&v1alpha1.KubernetesApplicationResource{}).
 		WithEventFilter(&predicate.Funcs{CreateFunc: CreatePredicate, UpdateFunc: UpdatePredicate}).
-		Complete(r)
+		Complete(tracing.NewTracer(controllerName).Reconciler(pause.NewReconciler(mgr.GetClient(), &v1alpha1.KubernetesApplicationResource{}, r, pause.WithObjectUpdate())))
 }
 
 // A syncer can sync resources with a KubernetesCluster.
@@ -153,6 +159,7 @@ type secretSyncDeleter interface {
 type remoteCluster struct {
 	unstructured unstructuredSyncDeleter
 	secret       secretSyncDeleter
+	record       *xpevent.Recorder
 }
 
 func (c *remoteCluster) sync(ctx context.Context, ar *v1alpha1.KubernetesApplicationResource, secrets []corev1.Secret) reconcile.Result {
@@ -172,6 +179,7 @@ func (c *remoteCluster) sync(ctx context.Context, ar *v1alpha1.KubernetesApplica
 		setRemoteController(ar, template)
 
 		if err := c.secret.sync(ctx, template); err != nil {
+			c.record.Failed(ar, err)
 			ar.Status.State = v1alpha1.KubernetesApplicationResourceStateFailed
 			ar.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
 			return reconcile.Result{Requeue: true}
@@ -193,11 +201,15 @@ func (c *remoteCluster) sync(ctx context.Context, ar *v1alpha1.KubernetesApplica
 		ar.Status.Remote = status
 	}
 	if err != nil {
+		c.record.Failed(ar, err)
 		ar.Status.State = v1alpha1.KubernetesApplicationResourceStateFailed
 		ar.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
 		return reconcile.Result{Requeue: true}
 	}
 
+	if ar.Status.State != v1alpha1.KubernetesApplicationResourceStateSubmitted {
+		c.record.Normal(ar, xpevent.ReasonBecameReady, "Submitted resource to its scheduled cluster")
+	}
 	ar.Status.SetConditions(runtimev1alpha1.ReconcileSuccess())
 	ar.Status.State = v1alpha1.KubernetesApplicationResourceStateSubmitted
 	return reconcile.Result{Requeue: false}
@@ -219,6 +231,7 @@ func (c *remoteCluster) delete(ctx context.Context, ar *v1alpha1.KubernetesAppli
 	setRemoteController(ar, template)
 
 	if err := c.unstructured.delete(ctx, template); err != nil {
+		c.record.Failed(ar, err)
 		ar.Status.State = v1alpha1.KubernetesApplicationResourceStateFailed
 		ar.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
 		return reconcile.Result{Requeue: true}
@@ -231,11 +244,13 @@ func (c *remoteCluster) delete(ctx context.Context, ar *v1alpha1.KubernetesAppli
 		setRemoteController(ar, template)
 
 		if err := c.secret.delete(ctx, template); err != nil {
+			c.record.Failed(ar, err)
 			ar.Status.State = v1alpha1.KubernetesApplicationResourceStateFailed
 			ar.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
 			return reconcile.Result{Requeue: true}
 		}
 	}
+	c.record.Normal(ar, xpevent.ReasonDeletionFinished, "Deleted resource from its scheduled cluster")
 	meta.RemoveFinalizer(ar, finalizerName)
 	ar.Status.SetConditions(runtimev1alpha1.ReconcileSuccess())
 	return reconcile.Result{Requeue: false}
@@ -407,6 +422,7 @@ type connecter interface {
 type clusterConnecter struct {
 	kube    client.Client
 	options client.Options
+	record  *xpevent.Recorder
 }
 
 func (c *clusterConnecter) config(ctx context.Context, ar *v1alpha1.KubernetesApplicationResource) (*rest.Config, error) {
@@ -464,13 +480,14 @@ func (c *clusterConnecter) connect(ctx context.Context, ar *v1alpha1.KubernetesA
 		return nil, errors.Wrap(err, "cannot create Kubernetes client")
 	}
 
-	return &remoteCluster{unstructured: &unstructuredClient{kube: kc}, secret: &secretClient{kube: kc}}, nil
+	return &remoteCluster{unstructured: &unstructuredClient{kube: kc}, secret: &secretClient{kube: kc}, record: c.record}, nil
 }
 
 // Reconciler reconciles a Instance object
 type Reconciler struct {
 	connecter
-	kube client.Client
+	kube   client.Client
+	record *xpevent.Recorder
 }
 
 // Reconcile scheduled Kubernetes application resources by propagating them to
@@ -497,6 +514,7 @@ func (r *Reconciler) Reconcile(req reconcile.Request) (reconcile.Result, error)
 			meta.RemoveFinalizer(ar, finalizerName)
 			return reconcile.Result{Requeue: false}, errors.Wrapf(r.kube.Update(ctx, ar), "cannot update %s %s", v1alpha1.KubernetesApplicationResourceKind, req.NamespacedName)
 		}
+		r.record.Failed(ar, err)
 		ar.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
 		return reconcile.Result{Requeue: true}, errors.Wrapf(r.kube.Update(ctx, ar), "cannot update %s %s", v1alpha1.KubernetesApplicationResourceKind, req.NamespacedName)
 	}
@@ -18,6 +18,7 @@ package scheduler
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/pkg/errors"
@@ -33,6 +34,9 @@ import (
 	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
 	computev1alpha1 "github.com/crossplaneio/crossplane/apis/compute/v1alpha1"
 	workloadv1alpha1 "github.com/crossplaneio/crossplane/apis/workload/v1alpha1"
+	xpevent "github.com/crossplaneio/crossplane/pkg/event"
+	"github.com/crossplaneio/crossplane/pkg/pause"
+	"github.com/crossplaneio/crossplane/pkg/tracing"
 )
 
 const (
@@ -50,6 +54,7 @@ type scheduler interface {
 type roundRobinScheduler struct {
 	kube             client.Client
 	lastClusterIndex uint64
+	record           *xpevent.Recorder
 }
 
 func (s *roundRobinScheduler) schedule(ctx context.Context, app *workloadv1alpha1.KubernetesApplication) reconcile.Result {
@@ -57,6 +62,7 @@ func (s *roundRobinScheduler) schedule(ctx context.Context, app *workloadv1alpha
 
 	clusters := &computev1alpha1.KubernetesClusterList{}
 	if err := s.kube.List(ctx, clusters, client.MatchingLabels(app.Spec.ClusterSelector.MatchLabels)); err != nil {
+		s.record.Failed(app, err)
 		app.Status.SetConditions(runtimev1alpha1.ReconcileError(err))
 		return reconcile.Result{Requeue: true}
 	}
@@ -74,6 +80,7 @@ func (s *roundRobinScheduler) schedule(ctx context.Context, app *workloadv1alpha
 	app.Status.Cluster = meta.ReferenceTo(&cluster, computev1alpha1.KubernetesClusterGroupVersionKind)
 	app.Status.State = workloadv1alpha1.KubernetesApplicationStateScheduled
 	app.Status.SetConditions(runtimev1alpha1.ReconcileSuccess())
+	s.record.Normal(app, xpevent.ReasonScheduled, fmt.Sprintf("Scheduled to %s %s", computev1alpha1.KubernetesClusterKind, cluster.GetName()))
 
 	return reconcile.Result{Requeue: false}
 }
@@ -107,14 +114,14 @@ type Controller struct{}
 func (c *Controller) SetupWithManager(mgr ctrl.Manager) error {
 	r := &Reconciler{
 		kube:      mgr.GetClient(),
-		scheduler: &roundRobinScheduler{kube: mgr.GetClient()},
+		scheduler: &roundRobinScheduler{kube: mgr.GetClient(), record: xpevent.NewRecorder(mgr.GetEventRecorderFor(controllerName))},
 	}
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(controllerName).
 		For(&workloadv1alpha1.KubernetesApplication{}).
 		WithEventFilter(&predicate.Funcs{CreateFunc: CreatePredicate, UpdateFunc: UpdatePredicate}).
-		Complete(r)
+		Complete(tracing.NewTracer(controllerName).Reconciler(pause.NewReconciler(mgr.GetClient(), &workloadv1alpha1.KubernetesApplication{}, r, pause.WithObjectUpdate())))
 }
 
 // A Reconciler schedules KubernetesApplications to KubernetesClusters.
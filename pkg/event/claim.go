@@ -0,0 +1,54 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package event
+
+import (
+	"context"
+	"fmt"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+)
+
+// A Binder records an event when a resource claim is bound to a managed
+// resource.
+type Binder struct {
+	resource.ManagedBinder
+
+	record *Recorder
+}
+
+// NewBinder returns a Binder that records events using the supplied Recorder
+// when the supplied ManagedBinder binds a resource claim.
+func NewBinder(r *Recorder, b resource.ManagedBinder) *Binder {
+	return &Binder{ManagedBinder: b, record: r}
+}
+
+// Bind the supplied resource claim to the supplied managed resource.
+func (b *Binder) Bind(ctx context.Context, cm resource.Claim, mg resource.Managed) error {
+	wasBound := cm.GetBindingPhase() == runtimev1alpha1.BindingPhaseBound
+
+	if err := b.ManagedBinder.Bind(ctx, cm, mg); err != nil {
+		b.record.Warning(cm, ReasonReconcileError, err)
+		return err
+	}
+
+	if !wasBound {
+		b.record.Normal(cm, ReasonBound, fmt.Sprintf("Bound to managed resource %s", mg.GetName()))
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package event
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/client-go/tools/record"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+
+	databasev1alpha1 "github.com/crossplaneio/crossplane/apis/database/v1alpha1"
+)
+
+type mockBinder struct {
+	err error
+}
+
+func (b *mockBinder) Bind(_ context.Context, cm resource.Claim, _ resource.Managed) error {
+	if b.err != nil {
+		return b.err
+	}
+	cm.SetBindingPhase(runtimev1alpha1.BindingPhaseBound)
+	return nil
+}
+
+func claim(p runtimev1alpha1.BindingPhase) *databasev1alpha1.MySQLInstance {
+	cm := &databasev1alpha1.MySQLInstance{}
+	cm.SetUID("a")
+	cm.SetBindingPhase(p)
+	return cm
+}
+
+func TestBinder(t *testing.T) {
+	mg := network("b")
+	mg.SetName("cool-network")
+
+	type args struct {
+		b  resource.ManagedBinder
+		cm resource.Claim
+	}
+	type want struct {
+		err    error
+		events []string
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Bound": {
+			args: args{
+				b:  &mockBinder{},
+				cm: claim(runtimev1alpha1.BindingPhaseUnbound),
+			},
+			want: want{
+				events: []string{"Normal Bound Bound to managed resource cool-network"},
+			},
+		},
+		"AlreadyBound": {
+			args: args{
+				b:  &mockBinder{},
+				cm: claim(runtimev1alpha1.BindingPhaseBound),
+			},
+			want: want{},
+		},
+		"BindError": {
+			args: args{
+				b:  &mockBinder{err: errBoom},
+				cm: claim(runtimev1alpha1.BindingPhaseUnbound),
+			},
+			want: want{
+				err:    errBoom,
+				events: []string{"Warning ReconcileError boom"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fr := record.NewFakeRecorder(10)
+			b := NewBinder(NewRecorder(fr), tc.args.b)
+			err := b.Bind(context.Background(), tc.args.cm, mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("b.Bind(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.events, recorded(fr)); diff != "" {
+				t.Errorf("b.Bind(...): -want events, +got events:\n%s", diff)
+			}
+		})
+	}
+}
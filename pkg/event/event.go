@@ -0,0 +1,219 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package event records Kubernetes events when the resources Crossplane
+// manages change state, so that describing a resource tells its story.
+package event
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+)
+
+// Reasons for the events Crossplane's controllers record.
+const (
+	ReasonCreateRequested  = "CreateRequested"
+	ReasonImported         = "Imported"
+	ReasonBecameReady      = "BecameReady"
+	ReasonUpdateApplied    = "UpdateApplied"
+	ReasonDeletionStarted  = "DeletionStarted"
+	ReasonDeletionFinished = "DeletionFinished"
+	ReasonBound            = "Bound"
+	ReasonScheduled        = "Scheduled"
+	ReasonExternalError    = "ExternalError"
+	ReasonReconcileError   = "ReconcileError"
+)
+
+// DefaultDedupeInterval is the interval during which an event identical to
+// one already recorded for the same object is not recorded again.
+const DefaultDedupeInterval = 10 * time.Minute
+
+// An ErrorCoder returns the error code a cloud provider, SQL server, or the
+// Kubernetes API reported in the supplied error, if any.
+type ErrorCoder func(err error) string
+
+// KubernetesErrorCode returns the reason the Kubernetes API reported in the
+// supplied error, for example Conflict or NotFound, if any. It is the
+// ErrorCoder of controllers, such as resource claim controllers, that only
+// call the Kubernetes API.
+func KubernetesErrorCode(err error) string {
+	return string(kerrors.ReasonForError(errors.Cause(err)))
+}
+
+// A key identifies events that are considered identical for the purposes of
+// deduplication. Warnings are identified by their error code, or by their
+// message with any IDs, timestamps, and other numbers removed, so that errors
+// that embed a request ID are not recorded again on every reconcile.
+type key struct {
+	object    types.UID
+	eventtype string
+	reason    string
+	message   string
+}
+
+var numbers = regexp.MustCompile(`[0-9a-fA-F]{8}(-[0-9a-fA-F]{4}){3}-[0-9a-fA-F]{12}|[0-9]+`)
+
+// normalize the supplied message by replacing UUIDs and numbers with #.
+func normalize(message string) string {
+	return numbers.ReplaceAllString(message, "#")
+}
+
+// A Recorder records events. Identical events recorded for the same object
+// within the dedupe interval are dropped, so that a resource whose
+// reconciliation repeatedly fails does not flood the API server with events.
+// A nil Recorder records nothing.
+type Recorder struct {
+	record   record.EventRecorder
+	code     ErrorCoder
+	interval time.Duration
+
+	mu       sync.Mutex
+	recorded map[key]time.Time
+}
+
+// A RecorderOption configures a Recorder.
+type RecorderOption func(*Recorder)
+
+// WithErrorCoder configures the function a Recorder uses to determine the
+// cloud provider error code of the errors it records.
+func WithErrorCoder(c ErrorCoder) RecorderOption {
+	return func(r *Recorder) {
+		r.code = c
+	}
+}
+
+// WithDedupeInterval configures the interval during which a Recorder drops
+// events identical to one it already recorded for the same object.
+func WithDedupeInterval(d time.Duration) RecorderOption {
+	return func(r *Recorder) {
+		r.interval = d
+	}
+}
+
+// NewRecorder returns a Recorder that records events using the supplied
+// EventRecorder.
+func NewRecorder(er record.EventRecorder, o ...RecorderOption) *Recorder {
+	r := &Recorder{
+		record:   er,
+		code:     func(_ error) string { return "" },
+		interval: DefaultDedupeInterval,
+		recorded: make(map[key]time.Time),
+	}
+	for _, ro := range o {
+		ro(r)
+	}
+	return r
+}
+
+// Normal records an event of type Normal for the supplied object.
+func (r *Recorder) Normal(obj runtime.Object, reason, message string) {
+	r.event(obj, corev1.EventTypeNormal, reason, message, message)
+}
+
+// Warning records an event of type Warning for the supplied object. The
+// message of the event is the supplied error, prefixed by its cloud provider
+// error code if it has one.
+func (r *Recorder) Warning(obj runtime.Object, reason string, err error) {
+	if r == nil || err == nil {
+		return
+	}
+	message := err.Error()
+	dedupe := normalize(message)
+	if code := r.code(err); code != "" {
+		message = fmt.Sprintf("[%s] %s", code, message)
+		dedupe = code
+	}
+	r.event(obj, corev1.EventTypeWarning, reason, message, dedupe)
+}
+
+// Failed records an event of type Warning for the supplied object, which could
+// not be reconciled due to the supplied error. The reason of the event is
+// ExternalError if the error has a cloud provider error code, or
+// ReconcileError otherwise.
+func (r *Recorder) Failed(obj runtime.Object, err error) {
+	if r == nil || err == nil {
+		return
+	}
+	reason := ReasonReconcileError
+	if r.code(err) != "" {
+		reason = ReasonExternalError
+	}
+	r.Warning(obj, reason, err)
+}
+
+func (r *Recorder) event(obj runtime.Object, eventtype, reason, message, dedupe string) {
+	if r == nil {
+		return
+	}
+
+	k := key{eventtype: eventtype, reason: reason, message: dedupe}
+	if m, err := meta.Accessor(obj); err == nil {
+		k.object = m.GetUID()
+	}
+
+	now := time.Now()
+	r.mu.Lock()
+	if t, ok := r.recorded[k]; ok && now.Sub(t) < r.interval {
+		r.mu.Unlock()
+		return
+	}
+	r.recorded[k] = now
+	r.forget(now)
+	r.mu.Unlock()
+
+	r.record.Event(obj, eventtype, reason, message)
+}
+
+// forget events recorded longer ago than the dedupe interval, so that the
+// events a long running Recorder remembers don't grow without bound. It must
+// be called with the lock held.
+func (r *Recorder) forget(now time.Time) {
+	for k, t := range r.recorded {
+		if now.Sub(t) >= r.interval {
+			delete(r.recorded, k)
+		}
+	}
+}
+
+// Ready returns true if the supplied object has a Ready condition whose status
+// is True.
+func Ready(obj runtime.Object) bool {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return false
+	}
+	conditions, _, _ := unstructured.NestedSlice(u, "status", "conditions")
+	for _, o := range conditions {
+		c, ok := o.(map[string]interface{})
+		if ok && c["type"] == string(runtimev1alpha1.TypeReady) {
+			return c["status"] == string(corev1.ConditionTrue)
+		}
+	}
+	return false
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package event
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane/gcp/apis/compute/v1alpha1"
+)
+
+var errBoom = errors.New("boom")
+
+func coder(code string) ErrorCoder {
+	return func(_ error) string { return code }
+}
+
+func network(uid types.UID, c ...runtimev1alpha1.Condition) *v1alpha1.Network {
+	n := &v1alpha1.Network{}
+	n.SetUID(uid)
+	n.Status.SetConditions(c...)
+	return n
+}
+
+// recorded returns the events the supplied FakeRecorder has recorded so far.
+func recorded(fr *record.FakeRecorder) []string {
+	var e []string
+	for {
+		select {
+		case s := <-fr.Events:
+			e = append(e, s)
+		default:
+			return e
+		}
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	cases := map[string]struct {
+		o      []RecorderOption
+		record func(r *Recorder)
+		want   []string
+	}{
+		"Normal": {
+			record: func(r *Recorder) { r.Normal(network("a"), ReasonBound, "bound") },
+			want:   []string{"Normal Bound bound"},
+		},
+		"DuplicateDropped": {
+			record: func(r *Recorder) {
+				r.Normal(network("a"), ReasonBound, "bound")
+				r.Normal(network("a"), ReasonBound, "bound")
+			},
+			want: []string{"Normal Bound bound"},
+		},
+		"DuplicateForDifferentObjectRecorded": {
+			record: func(r *Recorder) {
+				r.Normal(network("a"), ReasonBound, "bound")
+				r.Normal(network("b"), ReasonBound, "bound")
+			},
+			want: []string{"Normal Bound bound", "Normal Bound bound"},
+		},
+		"DuplicateAfterIntervalRecorded": {
+			o: []RecorderOption{WithDedupeInterval(0)},
+			record: func(r *Recorder) {
+				r.Normal(network("a"), ReasonBound, "bound")
+				r.Normal(network("a"), ReasonBound, "bound")
+			},
+			want: []string{"Normal Bound bound", "Normal Bound bound"},
+		},
+		"Warning": {
+			record: func(r *Recorder) { r.Warning(network("a"), ReasonExternalError, errBoom) },
+			want:   []string{"Warning ExternalError boom"},
+		},
+		"WarningWithCode": {
+			o:      []RecorderOption{WithErrorCoder(coder("Throttled"))},
+			record: func(r *Recorder) { r.Warning(network("a"), ReasonExternalError, errBoom) },
+			want:   []string{"Warning ExternalError [Throttled] boom"},
+		},
+		"WarningWithSameCodeDropped": {
+			o: []RecorderOption{WithErrorCoder(coder("Throttled"))},
+			record: func(r *Recorder) {
+				r.Warning(network("a"), ReasonExternalError, errors.New("rate exceeded, request ID a"))
+				r.Warning(network("a"), ReasonExternalError, errors.New("rate exceeded, request ID b"))
+			},
+			want: []string{"Warning ExternalError [Throttled] rate exceeded, request ID a"},
+		},
+		"WarningWithDifferentCodeRecorded": {
+			o: []RecorderOption{WithErrorCoder(func(err error) string { return err.Error() })},
+			record: func(r *Recorder) {
+				r.Warning(network("a"), ReasonExternalError, errors.New("Throttled"))
+				r.Warning(network("a"), ReasonExternalError, errors.New("AccessDenied"))
+			},
+			want: []string{"Warning ExternalError [Throttled] Throttled", "Warning ExternalError [AccessDenied] AccessDenied"},
+		},
+		"WarningDifferingOnlyByIDDropped": {
+			record: func(r *Recorder) {
+				r.Warning(network("a"), ReasonReconcileError, errors.New("request 3f1c2a4e-9b7d-4c1e-8f2a-6d5b4c3a2e1f failed after 1200ms"))
+				r.Warning(network("a"), ReasonReconcileError, errors.New("request 7a6b5c4d-3e2f-4a1b-9c8d-7e6f5a4b3c2d failed after 980ms"))
+			},
+			want: []string{"Warning ReconcileError request 3f1c2a4e-9b7d-4c1e-8f2a-6d5b4c3a2e1f failed after 1200ms"},
+		},
+		"WarningWithoutError": {
+			record: func(r *Recorder) { r.Warning(network("a"), ReasonExternalError, nil) },
+		},
+		"FailedWithCode": {
+			o:      []RecorderOption{WithErrorCoder(coder("Throttled"))},
+			record: func(r *Recorder) { r.Failed(network("a"), errBoom) },
+			want:   []string{"Warning ExternalError [Throttled] boom"},
+		},
+		"FailedWithoutCode": {
+			record: func(r *Recorder) { r.Failed(network("a"), errBoom) },
+			want:   []string{"Warning ReconcileError boom"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fr := record.NewFakeRecorder(10)
+			tc.record(NewRecorder(fr, tc.o...))
+			if diff := cmp.Diff(tc.want, recorded(fr)); diff != "" {
+				t.Errorf("r.record(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestKubernetesErrorCode(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want string
+	}{
+		"Conflict": {
+			err:  errors.Wrap(kerrors.NewConflict(schema.GroupResource{}, "cool", errBoom), "cannot update claim"),
+			want: "Conflict",
+		},
+		"NotKubernetes": {
+			err:  errBoom,
+			want: "",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := KubernetesErrorCode(tc.err)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("KubernetesErrorCode(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNilRecorder(t *testing.T) {
+	var r *Recorder
+	r.Normal(network("a"), ReasonBound, "bound")
+	r.Warning(network("a"), ReasonExternalError, errBoom)
+	r.Failed(network("a"), errBoom)
+}
+
+func TestReady(t *testing.T) {
+	cases := map[string]struct {
+		obj  runtime.Object
+		want bool
+	}{
+		"Available": {
+			obj:  network("a", runtimev1alpha1.Available()),
+			want: true,
+		},
+		"Creating": {
+			obj:  network("a", runtimev1alpha1.Creating()),
+			want: false,
+		},
+		"NoConditions": {
+			obj:  network("a"),
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Ready(tc.obj)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Ready(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package event
+
+import (
+	"context"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+)
+
+// A Connecter records events when the external resources of managed
+// resources change state.
+type Connecter struct {
+	resource.ExternalConnecter
+
+	record *Recorder
+}
+
+// NewConnecter returns a Connecter that records events using the supplied
+// Recorder when the external clients returned by the supplied
+// ExternalConnecter observe, create, update or delete external resources.
+func NewConnecter(r *Recorder, ec resource.ExternalConnecter) *Connecter {
+	return &Connecter{ExternalConnecter: ec, record: r}
+}
+
+// Connect to the external system of the supplied managed resource.
+func (c *Connecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	ec, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		c.record.Warning(mg, ReasonExternalError, err)
+		return nil, err
+	}
+	return &External{ExternalClient: ec, record: c.record}, nil
+}
+
+// An External records events when it observes, creates, updates or deletes
+// an external resource.
+type External struct {
+	resource.ExternalClient
+
+	record *Recorder
+}
+
+// Observe the external resource of the supplied managed resource. An event is
+// recorded when the managed resource becomes ready, or when the external
+// resource of a managed resource that is being deleted no longer exists.
+func (e *External) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	wasReady := Ready(mg)
+
+	o, err := e.ExternalClient.Observe(ctx, mg)
+	if err != nil {
+		e.record.Warning(mg, ReasonExternalError, err)
+		return o, err
+	}
+
+	if mg.GetDeletionTimestamp() != nil && !o.ResourceExists {
+		e.record.Normal(mg, ReasonDeletionFinished, "External resource was deleted")
+	}
+	if !wasReady && Ready(mg) {
+		e.record.Normal(mg, ReasonBecameReady, "External resource became ready")
+	}
+	return o, nil
+}
+
+// Create the external resource of the supplied managed resource.
+func (e *External) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	c, err := e.ExternalClient.Create(ctx, mg)
+	if err != nil {
+		e.record.Warning(mg, ReasonExternalError, err)
+		return c, err
+	}
+	e.record.Normal(mg, ReasonCreateRequested, "Requested creation of external resource")
+	return c, nil
+}
+
+// Update the external resource of the supplied managed resource. The managed
+// resource reconciler only calls Update when the external resource is not up
+// to date, so an event is recorded every time an update succeeds.
+func (e *External) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	u, err := e.ExternalClient.Update(ctx, mg)
+	if err != nil {
+		e.record.Warning(mg, ReasonExternalError, err)
+		return u, err
+	}
+	e.record.Normal(mg, ReasonUpdateApplied, "Applied update to external resource")
+	return u, nil
+}
+
+// Delete the external resource of the supplied managed resource.
+func (e *External) Delete(ctx context.Context, mg resource.Managed) error {
+	if err := e.ExternalClient.Delete(ctx, mg); err != nil {
+		e.record.Warning(mg, ReasonExternalError, err)
+		return err
+	}
+	e.record.Normal(mg, ReasonDeletionStarted, "Requested deletion of external resource")
+	return nil
+}
@@ -0,0 +1,258 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package event
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+)
+
+type mockConnecter struct {
+	client resource.ExternalClient
+	err    error
+}
+
+func (c *mockConnecter) Connect(_ context.Context, _ resource.Managed) (resource.ExternalClient, error) {
+	return c.client, c.err
+}
+
+type mockExternal struct {
+	observation resource.ExternalObservation
+	conditions  []runtimev1alpha1.Condition
+	err         error
+}
+
+func (e *mockExternal) Observe(_ context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	mg.SetConditions(e.conditions...)
+	return e.observation, e.err
+}
+
+func (e *mockExternal) Create(_ context.Context, _ resource.Managed) (resource.ExternalCreation, error) {
+	return resource.ExternalCreation{}, e.err
+}
+
+func (e *mockExternal) Update(_ context.Context, _ resource.Managed) (resource.ExternalUpdate, error) {
+	return resource.ExternalUpdate{}, e.err
+}
+
+func (e *mockExternal) Delete(_ context.Context, _ resource.Managed) error {
+	return e.err
+}
+
+func TestConnecter(t *testing.T) {
+	type args struct {
+		ec resource.ExternalConnecter
+		mg resource.Managed
+	}
+	type want struct {
+		err    error
+		events []string
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ConnectSuccessful": {
+			args: args{
+				ec: &mockConnecter{client: &mockExternal{}},
+				mg: network("a"),
+			},
+			want: want{},
+		},
+		"ConnectError": {
+			args: args{
+				ec: &mockConnecter{err: errBoom},
+				mg: network("a"),
+			},
+			want: want{
+				err:    errBoom,
+				events: []string{"Warning ExternalError boom"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fr := record.NewFakeRecorder(10)
+			c := NewConnecter(NewRecorder(fr), tc.args.ec)
+			_, err := c.Connect(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("c.Connect(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.events, recorded(fr)); diff != "" {
+				t.Errorf("c.Connect(...): -want events, +got events:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExternal(t *testing.T) {
+	deleted := network("a")
+	deleted.SetDeletionTimestamp(&metav1.Time{})
+
+	type args struct {
+		client resource.ExternalClient
+		mg     resource.Managed
+		call   func(ctx context.Context, e *External, mg resource.Managed) error
+	}
+	type want struct {
+		err    error
+		events []string
+	}
+
+	observe := func(ctx context.Context, e *External, mg resource.Managed) error {
+		_, err := e.Observe(ctx, mg)
+		return err
+	}
+	create := func(ctx context.Context, e *External, mg resource.Managed) error {
+		_, err := e.Create(ctx, mg)
+		return err
+	}
+	update := func(ctx context.Context, e *External, mg resource.Managed) error {
+		_, err := e.Update(ctx, mg)
+		return err
+	}
+	del := func(ctx context.Context, e *External, mg resource.Managed) error {
+		return e.Delete(ctx, mg)
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ObserveBecameReady": {
+			args: args{
+				client: &mockExternal{
+					observation: resource.ExternalObservation{ResourceExists: true},
+					conditions:  []runtimev1alpha1.Condition{runtimev1alpha1.Available()},
+				},
+				mg:   network("a", runtimev1alpha1.Creating()),
+				call: observe,
+			},
+			want: want{
+				events: []string{"Normal BecameReady External resource became ready"},
+			},
+		},
+		"ObserveAlreadyReady": {
+			args: args{
+				client: &mockExternal{
+					observation: resource.ExternalObservation{ResourceExists: true},
+					conditions:  []runtimev1alpha1.Condition{runtimev1alpha1.Available()},
+				},
+				mg:   network("a", runtimev1alpha1.Available()),
+				call: observe,
+			},
+			want: want{},
+		},
+		"ObserveDeletionFinished": {
+			args: args{
+				client: &mockExternal{observation: resource.ExternalObservation{ResourceExists: false}},
+				mg:     deleted,
+				call:   observe,
+			},
+			want: want{
+				events: []string{"Normal DeletionFinished External resource was deleted"},
+			},
+		},
+		"ObserveError": {
+			args: args{
+				client: &mockExternal{err: errBoom},
+				mg:     network("a"),
+				call:   observe,
+			},
+			want: want{
+				err:    errBoom,
+				events: []string{"Warning ExternalError boom"},
+			},
+		},
+		"CreateSuccessful": {
+			args: args{
+				client: &mockExternal{},
+				mg:     network("a"),
+				call:   create,
+			},
+			want: want{
+				events: []string{"Normal CreateRequested Requested creation of external resource"},
+			},
+		},
+		"CreateError": {
+			args: args{
+				client: &mockExternal{err: errBoom},
+				mg:     network("a"),
+				call:   create,
+			},
+			want: want{
+				err:    errBoom,
+				events: []string{"Warning ExternalError boom"},
+			},
+		},
+		"UpdateSuccessful": {
+			args: args{
+				client: &mockExternal{},
+				mg:     network("a"),
+				call:   update,
+			},
+			want: want{
+				events: []string{"Normal UpdateApplied Applied update to external resource"},
+			},
+		},
+		"DeleteSuccessful": {
+			args: args{
+				client: &mockExternal{},
+				mg:     network("a"),
+				call:   del,
+			},
+			want: want{
+				events: []string{"Normal DeletionStarted Requested deletion of external resource"},
+			},
+		},
+		"DeleteError": {
+			args: args{
+				client: &mockExternal{err: errBoom},
+				mg:     network("a"),
+				call:   del,
+			},
+			want: want{
+				err:    errBoom,
+				events: []string{"Warning ExternalError boom"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fr := record.NewFakeRecorder(10)
+			e := &External{ExternalClient: tc.args.client, record: NewRecorder(fr)}
+			err := tc.args.call(context.Background(), e, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.%s(...): -want error, +got error:\n%s", name, diff)
+			}
+			if diff := cmp.Diff(tc.want.events, recorded(fr)); diff != "" {
+				t.Errorf("e.%s(...): -want events, +got events:\n%s", name, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package externalname allows managed resources to represent external
+// resources that already exist. A managed resource annotated with the name,
+// or ID, of an existing external resource takes over that resource rather
+// than creating a new one. This allows cloud resources that were created
+// outside of Crossplane to be imported.
+package externalname
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AnnotationKey is the key of the annotation whose value is the name of the
+// external resource a managed resource represents.
+const AnnotationKey = "crossplane.io/external-name"
+
+// Get returns the external name of the supplied managed resource, or an
+// empty string if it has none.
+func Get(o metav1.Object) string {
+	return o.GetAnnotations()[AnnotationKey]
+}
+
+// Set the external name of the supplied managed resource.
+func Set(o metav1.Object, name string) {
+	a := o.GetAnnotations()
+	if a == nil {
+		a = map[string]string{}
+	}
+	a[AnnotationKey] = name
+	o.SetAnnotations(a)
+}
+
+// Or returns the external name of the supplied managed resource, or the
+// supplied name if it has none.
+func Or(o metav1.Object, name string) string {
+	if n := Get(o); n != "" {
+		return n
+	}
+	return name
+}
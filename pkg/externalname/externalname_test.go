@@ -0,0 +1,65 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalname
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestOr(t *testing.T) {
+	cases := map[string]struct {
+		o    metav1.Object
+		name string
+		want string
+	}{
+		"NoAnnotations": {
+			o:    &metav1.ObjectMeta{},
+			name: "generated",
+			want: "generated",
+		},
+		"EmptyExternalName": {
+			o:    &metav1.ObjectMeta{Annotations: map[string]string{AnnotationKey: ""}},
+			name: "generated",
+			want: "generated",
+		},
+		"ExternalName": {
+			o:    &metav1.ObjectMeta{Annotations: map[string]string{AnnotationKey: "existing"}},
+			name: "generated",
+			want: "existing",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Or(tc.o, tc.name)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Or(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSet(t *testing.T) {
+	o := &metav1.ObjectMeta{}
+	Set(o, "existing")
+	if diff := cmp.Diff("existing", Get(o)); diff != "" {
+		t.Errorf("Set(...): -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,328 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inspect
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+)
+
+// Error strings.
+const (
+	errFmtGet = "cannot get %s %s"
+)
+
+// Explanations of why a resource claim is not bound.
+const (
+	whyNoClass              = "The claim has no class reference or class selector, and no default class was found for it"
+	whyNoMatchingClass      = "No resource class matches the claim's class selector"
+	whyClassNotFound        = "The claim's class does not exist"
+	whyNoManaged            = "No managed resource has been provisioned for the claim yet"
+	whyManagedNotFound      = "The claim's managed resource does not exist"
+	whyNoProvider           = "The managed resource has no provider reference"
+	whyProviderNotFound     = "The managed resource's provider does not exist"
+	whyCredentialsNotFound  = "The provider's credentials secret does not exist"
+	whyNotSynced            = "The last reconcile failed: %s"
+	whyManagedNotReady      = "The managed resource is not yet ready"
+	whyManagedNotReadyFmt   = "The managed resource is not yet ready: %s"
+	whyManagedBoundElsewise = "The managed resource is bound to a different claim"
+	whyAwaitingBind         = "The managed resource is ready, but the claim has not been bound to it yet"
+	whySecretNotFound       = "The connection secret has not been written yet"
+)
+
+var secretGVK = corev1.SchemeGroupVersion.WithKind("Secret")
+
+// Claim returns the resource graph of the resource claim of the supplied
+// kind and name. The graph includes the claim's resource class, its managed
+// resource, the provider the managed resource uses and the credentials secret
+// of the provider, and the connection secrets of the claim and managed
+// resource. If the claim is not bound the graph explains why.
+func Claim(ctx context.Context, c client.Reader, gvk schema.GroupVersionKind, nn types.NamespacedName) (*Node, error) {
+	cm, err := get(ctx, c, gvk, nn)
+	if err != nil {
+		return nil, err
+	}
+	n := node(RoleClaim, gvk, nn, cm)
+	if cm == nil {
+		return n, nil
+	}
+
+	classRef := reference(cm, "spec", "classRef")
+	resourceRef := reference(cm, "spec", "resourceRef")
+
+	if classRef != nil {
+		class, err := get(ctx, c, classRef.GroupVersionKind(), refName(classRef))
+		if err != nil {
+			return nil, err
+		}
+		cn := node(RoleClass, classRef.GroupVersionKind(), refName(classRef), class)
+		n.Children = append(n.Children, cn)
+		if class == nil {
+			explain(n, whyClassNotFound)
+		}
+	}
+	if classRef == nil && resourceRef == nil {
+		if _, found, _ := unstructured.NestedMap(cm.Object, "spec", "classSelector"); found {
+			explain(n, whyNoMatchingClass)
+		} else {
+			explain(n, whyNoClass)
+		}
+	}
+
+	if resourceRef == nil {
+		if classRef != nil {
+			explain(n, whyNoManaged)
+		}
+		explainSynced(n)
+	}
+
+	if resourceRef != nil {
+		mn, err := managed(ctx, c, resourceRef, cm)
+		if err != nil {
+			return nil, err
+		}
+		n.Children = append(n.Children, mn)
+		if n.BindingPhase != runtimev1alpha1.BindingPhaseBound {
+			explainManaged(n, mn)
+		}
+	}
+
+	if name, _, _ := unstructured.NestedString(cm.Object, "spec", "writeConnectionSecretToRef", "name"); name != "" {
+		sn, err := secret(ctx, c, types.NamespacedName{Namespace: nn.Namespace, Name: name})
+		if err != nil {
+			return nil, err
+		}
+		if !sn.Found && n.BindingPhase == runtimev1alpha1.BindingPhaseBound {
+			explain(sn, whySecretNotFound)
+		}
+		n.Children = append(n.Children, sn)
+	}
+
+	return n, nil
+}
+
+// managed returns the graph of the managed resource the supplied claim
+// references.
+func managed(ctx context.Context, c client.Reader, ref *corev1.ObjectReference, cm *unstructured.Unstructured) (*Node, error) {
+	mg, err := get(ctx, c, ref.GroupVersionKind(), refName(ref))
+	if err != nil {
+		return nil, err
+	}
+	n := node(RoleManaged, ref.GroupVersionKind(), refName(ref), mg)
+	if mg == nil {
+		return n, nil
+	}
+
+	if claimRef := reference(mg, "spec", "claimRef"); claimRef != nil && claimRef.UID != cm.GetUID() {
+		explain(n, whyManagedBoundElsewise)
+	}
+
+	if providerRef := reference(mg, "spec", "providerRef"); providerRef != nil {
+		pn, err := provider(ctx, c, providerKind(ref.GroupVersionKind(), providerRef), refName(providerRef))
+		if err != nil {
+			return nil, err
+		}
+		n.Children = append(n.Children, pn)
+	}
+
+	if name, _, _ := unstructured.NestedString(mg.Object, "spec", "writeConnectionSecretToRef", "name"); name != "" {
+		sn, err := secret(ctx, c, types.NamespacedName{Namespace: mg.GetNamespace(), Name: name})
+		if err != nil {
+			return nil, err
+		}
+		if !sn.Found && ready(n) {
+			explain(sn, whySecretNotFound)
+		}
+		n.Children = append(n.Children, sn)
+	}
+
+	return n, nil
+}
+
+// provider returns the graph of the provider of the supplied kind and name.
+func provider(ctx context.Context, c client.Reader, gvk schema.GroupVersionKind, nn types.NamespacedName) (*Node, error) {
+	p, err := get(ctx, c, gvk, nn)
+	if err != nil {
+		return nil, err
+	}
+	n := node(RoleProvider, gvk, nn, p)
+	if p == nil {
+		return n, nil
+	}
+
+	name, _, _ := unstructured.NestedString(p.Object, "spec", "credentialsSecretRef", "name")
+	if name == "" {
+		return n, nil
+	}
+	sn, err := get(ctx, c, secretGVK, types.NamespacedName{Namespace: p.GetNamespace(), Name: name})
+	if err != nil {
+		return nil, err
+	}
+	cn := node(RoleCredentials, secretGVK, types.NamespacedName{Namespace: p.GetNamespace(), Name: name}, sn)
+	if sn == nil {
+		explain(cn, whyCredentialsNotFound)
+	}
+	n.Children = append(n.Children, cn)
+	return n, nil
+}
+
+// providerKind returns the kind of provider the supplied reference, made by
+// a managed resource of the supplied kind, refers to. Provider references
+// omit their kind, which is implied by the managed resource; the providers of
+// the database.aws.crossplane.io managed resources are of kind
+// Provider.aws.crossplane.io.
+func providerKind(mg schema.GroupVersionKind, ref *corev1.ObjectReference) schema.GroupVersionKind {
+	if ref.Kind != "" {
+		return ref.GroupVersionKind()
+	}
+	group := mg.Group
+	if i := strings.Index(group, "."); i > 0 {
+		group = group[i+1:]
+	}
+	return schema.GroupVersionKind{Group: group, Version: mg.Version, Kind: "Provider"}
+}
+
+// secret returns the graph of the named connection secret.
+func secret(ctx context.Context, c client.Reader, nn types.NamespacedName) (*Node, error) {
+	s, err := get(ctx, c, secretGVK, nn)
+	if err != nil {
+		return nil, err
+	}
+	return node(RoleConnectionSecret, secretGVK, nn, s), nil
+}
+
+// explainManaged explains why the supplied claim is not bound to the supplied
+// managed resource.
+func explainManaged(cm, mg *Node) {
+	if !mg.Found {
+		explain(cm, whyManagedNotFound)
+		return
+	}
+	hasProvider := false
+	for _, c := range mg.Children {
+		if c.Role != RoleProvider {
+			continue
+		}
+		hasProvider = true
+		if !c.Found {
+			explain(cm, whyProviderNotFound)
+		}
+		for _, cc := range c.Children {
+			if cc.Role == RoleCredentials && !cc.Found {
+				explain(cm, whyCredentialsNotFound)
+			}
+		}
+	}
+	if !hasProvider {
+		explain(cm, whyNoProvider)
+	}
+	explainSynced(mg)
+	if !ready(mg) {
+		r, ok := condition(mg.Conditions, runtimev1alpha1.TypeReady)
+		switch {
+		case ok && r.Message != "":
+			explain(cm, fmt.Sprintf(whyManagedNotReadyFmt, r.Message))
+		case ok && r.Reason != "":
+			explain(cm, fmt.Sprintf(whyManagedNotReadyFmt, r.Reason))
+		default:
+			explain(cm, whyManagedNotReady)
+		}
+		return
+	}
+	explain(cm, whyAwaitingBind)
+	explainSynced(cm)
+}
+
+// explainSynced explains that the supplied node's last reconcile failed, if
+// it did.
+func explainSynced(n *Node) {
+	if s, ok := condition(n.Conditions, runtimev1alpha1.TypeSynced); ok && s.Status == corev1.ConditionFalse {
+		explain(n, fmt.Sprintf(whyNotSynced, s.Message))
+	}
+}
+
+func explain(n *Node, why string) {
+	for _, p := range n.Problems {
+		if p == why {
+			return
+		}
+	}
+	n.Problems = append(n.Problems, why)
+}
+
+func ready(n *Node) bool {
+	r, ok := condition(n.Conditions, runtimev1alpha1.TypeReady)
+	return ok && r.Status == corev1.ConditionTrue
+}
+
+// get returns the object of the supplied kind and name, or nil if it does not
+// exist.
+func get(ctx context.Context, c client.Reader, gvk schema.GroupVersionKind, nn types.NamespacedName) (*unstructured.Unstructured, error) {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	err := c.Get(ctx, nn, u)
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	return u, errors.Wrapf(err, errFmtGet, gvk.Kind, nn)
+}
+
+// node returns a node of the supplied role for the supplied object, which
+// may be nil if it does not exist.
+func node(role string, gvk schema.GroupVersionKind, nn types.NamespacedName, u *unstructured.Unstructured) *Node {
+	apiVersion, kind := gvk.ToAPIVersionAndKind()
+	n := &Node{Role: role, APIVersion: apiVersion, Kind: kind, Namespace: nn.Namespace, Name: nn.Name}
+	if u == nil {
+		return n
+	}
+	n.Found = true
+	n.Conditions = conditions(u)
+	if p, _, _ := unstructured.NestedString(u.Object, "status", "bindingPhase"); p != "" {
+		n.BindingPhase = runtimev1alpha1.BindingPhase(p)
+	}
+	return n
+}
+
+// reference returns the object reference at the supplied path of the
+// supplied object, or nil if there is none.
+func reference(u *unstructured.Unstructured, path ...string) *corev1.ObjectReference {
+	m, found, err := unstructured.NestedMap(u.Object, path...)
+	if err != nil || !found {
+		return nil
+	}
+	ref := &corev1.ObjectReference{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(m, ref); err != nil {
+		return nil
+	}
+	return ref
+}
+
+func refName(ref *corev1.ObjectReference) types.NamespacedName {
+	return types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}
+}
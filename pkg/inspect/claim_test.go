@@ -0,0 +1,278 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inspect
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+)
+
+var (
+	errBoom = errors.New("boom")
+
+	claimKind   = schema.GroupVersionKind{Group: "database.crossplane.io", Version: "v1alpha1", Kind: "MySQLInstance"}
+	classKind   = schema.GroupVersionKind{Group: "database.aws.crossplane.io", Version: "v1alpha1", Kind: "RDSInstanceClass"}
+	managedKind = schema.GroupVersionKind{Group: "database.aws.crossplane.io", Version: "v1alpha1", Kind: "RDSInstance"}
+	providerGVK = schema.GroupVersionKind{Group: "aws.crossplane.io", Version: "v1alpha1", Kind: "Provider"}
+)
+
+// objects is a fake API server, which serves the objects it contains.
+type objects []*unstructured.Unstructured
+
+func (o objects) Get(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+	u := obj.(*unstructured.Unstructured)
+	for _, want := range o {
+		if want.GroupVersionKind() == u.GroupVersionKind() && want.GetNamespace() == key.Namespace && want.GetName() == key.Name {
+			u.Object = want.DeepCopy().Object
+			return nil
+		}
+	}
+	return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+}
+
+func (o objects) List(_ context.Context, _ runtime.Object, _ ...client.ListOption) error {
+	return nil
+}
+
+func object(gvk schema.GroupVersionKind, namespace, name string, fields map[string]interface{}) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: fields}
+	if u.Object == nil {
+		u.Object = map[string]interface{}{}
+	}
+	u.SetGroupVersionKind(gvk)
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	u.SetUID(types.UID(name))
+	return u
+}
+
+func ref(gvk schema.GroupVersionKind, namespace, name string) map[string]interface{} {
+	apiVersion, kind := gvk.ToAPIVersionAndKind()
+	return map[string]interface{}{"apiVersion": apiVersion, "kind": kind, "namespace": namespace, "name": name, "uid": name}
+}
+
+func cond(t runtimev1alpha1.ConditionType, s corev1.ConditionStatus, reason string) map[string]interface{} {
+	return map[string]interface{}{"type": string(t), "status": string(s), "reason": reason}
+}
+
+func claimObj(phase string, spec map[string]interface{}) *unstructured.Unstructured {
+	spec["writeConnectionSecretToRef"] = map[string]interface{}{"name": "app-db"}
+	return object(claimKind, "default", "app-db", map[string]interface{}{
+		"spec":   spec,
+		"status": map[string]interface{}{"bindingPhase": phase},
+	})
+}
+
+func managedObj(ready corev1.ConditionStatus, reason string) *unstructured.Unstructured {
+	return object(managedKind, "crossplane-system", "mysql-1234", map[string]interface{}{
+		"spec": map[string]interface{}{
+			"claimRef":                   ref(claimKind, "default", "app-db"),
+			"providerRef":                map[string]interface{}{"namespace": "crossplane-system", "name": "aws"},
+			"writeConnectionSecretToRef": map[string]interface{}{"name": "mysql-1234"},
+		},
+		"status": map[string]interface{}{
+			"conditions": []interface{}{cond(runtimev1alpha1.TypeReady, ready, reason)},
+		},
+	})
+}
+
+func providerObj() *unstructured.Unstructured {
+	return object(providerGVK, "crossplane-system", "aws", map[string]interface{}{
+		"spec": map[string]interface{}{"credentialsSecretRef": map[string]interface{}{"name": "aws-creds", "key": "credentials"}},
+	})
+}
+
+func TestClaim(t *testing.T) {
+	nn := types.NamespacedName{Namespace: "default", Name: "app-db"}
+	classRef := ref(classKind, "crossplane-system", "mysql")
+	resourceRef := ref(managedKind, "crossplane-system", "mysql-1234")
+
+	claimNode := func(phase runtimev1alpha1.BindingPhase, problems []string, children ...*Node) *Node {
+		return &Node{Role: RoleClaim, APIVersion: "database.crossplane.io/v1alpha1", Kind: "MySQLInstance", Namespace: "default", Name: "app-db",
+			Found: true, BindingPhase: phase, Conditions: []runtimev1alpha1.Condition{}, Problems: problems, Children: children}
+	}
+	classNode := func(found bool) *Node {
+		n := &Node{Role: RoleClass, APIVersion: "database.aws.crossplane.io/v1alpha1", Kind: "RDSInstanceClass", Namespace: "crossplane-system", Name: "mysql", Found: found}
+		if found {
+			n.Conditions = []runtimev1alpha1.Condition{}
+		}
+		return n
+	}
+	secretNode := func(role, namespace, name string, found bool, problems ...string) *Node {
+		n := &Node{Role: role, APIVersion: "v1", Kind: "Secret", Namespace: namespace, Name: name, Found: found, Problems: problems}
+		if found {
+			n.Conditions = []runtimev1alpha1.Condition{}
+		}
+		return n
+	}
+	providerNode := func(credentials *Node) *Node {
+		return &Node{Role: RoleProvider, APIVersion: "aws.crossplane.io/v1alpha1", Kind: "Provider", Namespace: "crossplane-system", Name: "aws",
+			Found: true, Conditions: []runtimev1alpha1.Condition{}, Children: []*Node{credentials}}
+	}
+	managedNode := func(ready corev1.ConditionStatus, reason string, children ...*Node) *Node {
+		return &Node{Role: RoleManaged, APIVersion: "database.aws.crossplane.io/v1alpha1", Kind: "RDSInstance", Namespace: "crossplane-system", Name: "mysql-1234",
+			Found: true, Conditions: []runtimev1alpha1.Condition{{Type: runtimev1alpha1.TypeReady, Status: ready, Reason: runtimev1alpha1.ConditionReason(reason)}}, Children: children}
+	}
+
+	type want struct {
+		n   *Node
+		err error
+	}
+
+	cases := map[string]struct {
+		c    client.Reader
+		want want
+	}{
+		"ClaimNotFound": {
+			c: objects{},
+			want: want{
+				n: &Node{Role: RoleClaim, APIVersion: "database.crossplane.io/v1alpha1", Kind: "MySQLInstance", Namespace: "default", Name: "app-db"},
+			},
+		},
+		"GetError": {
+			c: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			want: want{
+				err: errors.Wrapf(errBoom, errFmtGet, "MySQLInstance", nn),
+			},
+		},
+		"NoClass": {
+			c: objects{claimObj("Unbound", map[string]interface{}{})},
+			want: want{
+				n: claimNode(runtimev1alpha1.BindingPhaseUnbound, []string{whyNoClass},
+					secretNode(RoleConnectionSecret, "default", "app-db", false)),
+			},
+		},
+		"NoMatchingClass": {
+			c: objects{claimObj("Unbound", map[string]interface{}{"classSelector": map[string]interface{}{}})},
+			want: want{
+				n: claimNode(runtimev1alpha1.BindingPhaseUnbound, []string{whyNoMatchingClass},
+					secretNode(RoleConnectionSecret, "default", "app-db", false)),
+			},
+		},
+		"ClassNotFound": {
+			c: objects{claimObj("Unbound", map[string]interface{}{"classRef": classRef})},
+			want: want{
+				n: claimNode(runtimev1alpha1.BindingPhaseUnbound, []string{whyClassNotFound, whyNoManaged},
+					classNode(false),
+					secretNode(RoleConnectionSecret, "default", "app-db", false)),
+			},
+		},
+		"ManagedNotReady": {
+			c: objects{
+				claimObj("Unbound", map[string]interface{}{"classRef": classRef, "resourceRef": resourceRef}),
+				object(classKind, "crossplane-system", "mysql", nil),
+				managedObj(corev1.ConditionFalse, "Creating"),
+				providerObj(),
+				object(secretGVK, "crossplane-system", "aws-creds", nil),
+			},
+			want: want{
+				n: claimNode(runtimev1alpha1.BindingPhaseUnbound, []string{"The managed resource is not yet ready: Creating"},
+					classNode(true),
+					managedNode(corev1.ConditionFalse, "Creating",
+						providerNode(secretNode(RoleCredentials, "crossplane-system", "aws-creds", true)),
+						secretNode(RoleConnectionSecret, "crossplane-system", "mysql-1234", false)),
+					secretNode(RoleConnectionSecret, "default", "app-db", false)),
+			},
+		},
+		"CredentialsNotFound": {
+			c: objects{
+				claimObj("Unbound", map[string]interface{}{"classRef": classRef, "resourceRef": resourceRef}),
+				object(classKind, "crossplane-system", "mysql", nil),
+				managedObj(corev1.ConditionFalse, "Creating"),
+				providerObj(),
+			},
+			want: want{
+				n: claimNode(runtimev1alpha1.BindingPhaseUnbound, []string{whyCredentialsNotFound, "The managed resource is not yet ready: Creating"},
+					classNode(true),
+					managedNode(corev1.ConditionFalse, "Creating",
+						providerNode(secretNode(RoleCredentials, "crossplane-system", "aws-creds", false, whyCredentialsNotFound)),
+						secretNode(RoleConnectionSecret, "crossplane-system", "mysql-1234", false)),
+					secretNode(RoleConnectionSecret, "default", "app-db", false)),
+			},
+		},
+		"Bound": {
+			c: objects{
+				claimObj("Bound", map[string]interface{}{"classRef": classRef, "resourceRef": resourceRef}),
+				object(classKind, "crossplane-system", "mysql", nil),
+				managedObj(corev1.ConditionTrue, "Available"),
+				providerObj(),
+				object(secretGVK, "crossplane-system", "aws-creds", nil),
+				object(secretGVK, "crossplane-system", "mysql-1234", nil),
+				object(secretGVK, "default", "app-db", nil),
+			},
+			want: want{
+				n: claimNode(runtimev1alpha1.BindingPhaseBound, nil,
+					classNode(true),
+					managedNode(corev1.ConditionTrue, "Available",
+						providerNode(secretNode(RoleCredentials, "crossplane-system", "aws-creds", true)),
+						secretNode(RoleConnectionSecret, "crossplane-system", "mysql-1234", true)),
+					secretNode(RoleConnectionSecret, "default", "app-db", true)),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Claim(context.Background(), tc.c, claimKind, nn)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Claim(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.n, got); diff != "" {
+				t.Errorf("Claim(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPrint(t *testing.T) {
+	n := &Node{Role: RoleClaim, APIVersion: "database.crossplane.io/v1alpha1", Kind: "MySQLInstance", Namespace: "default", Name: "app-db",
+		Found: true, BindingPhase: runtimev1alpha1.BindingPhaseUnbound, Problems: []string{whyClassNotFound},
+		Children: []*Node{
+			{Role: RoleClass, APIVersion: "database.aws.crossplane.io/v1alpha1", Kind: "RDSInstanceClass", Namespace: "crossplane-system", Name: "mysql"},
+			{Role: RoleConnectionSecret, APIVersion: "v1", Kind: "Secret", Namespace: "default", Name: "app-db", Found: true,
+				Conditions: []runtimev1alpha1.Condition{{Type: runtimev1alpha1.TypeReady, Status: corev1.ConditionFalse, Reason: "Creating", Message: "soon"}}},
+		},
+	}
+
+	want := `Claim: MySQLInstance.database.crossplane.io default/app-db
+│   Binding phase: Unbound
+│   ! The claim's class does not exist
+├── Class: RDSInstanceClass.database.aws.crossplane.io crossplane-system/mysql (not found)
+└── Connection secret: Secret default/app-db
+        Ready: False (Creating) soon
+`
+
+	b := &bytes.Buffer{}
+	Print(b, n)
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("Print(...): -want, +got:\n%s", diff)
+	}
+}
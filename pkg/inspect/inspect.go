@@ -0,0 +1,181 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package inspect describes the resources Crossplane manages and the
+// relationships between them, so that operators can follow a resource claim
+// to the cloud provider resource it is bound to without chaining kubectl
+// commands.
+package inspect
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+)
+
+// Error strings.
+const (
+	errFmtResolveKind = "cannot resolve the kind of %s"
+)
+
+// Roles of the nodes of a resource graph.
+const (
+	RoleClaim            = "Claim"
+	RoleClass            = "Class"
+	RoleManaged          = "Managed resource"
+	RoleProvider         = "Provider"
+	RoleCredentials      = "Credentials secret"
+	RoleConnectionSecret = "Connection secret"
+)
+
+// A Node of a resource graph. Each node is a Kubernetes resource that plays a
+// role in provisioning a resource claim, for example the claim's managed
+// resource or the provider the managed resource uses.
+type Node struct {
+	Role       string
+	APIVersion string
+	Kind       string
+	Namespace  string
+	Name       string
+
+	// Found is false if the resource does not exist.
+	Found bool
+
+	// BindingPhase is the binding phase of a resource claim or managed
+	// resource.
+	BindingPhase runtimev1alpha1.BindingPhase
+
+	// Conditions of the resource.
+	Conditions []runtimev1alpha1.Condition
+
+	// Problems explain why the resource, or the resource claim that this
+	// node is part of the graph of, is not yet usable.
+	Problems []string
+
+	Children []*Node
+}
+
+// KindFor returns the kind of the supplied resource, which may be a kind or
+// resource name qualified by its group and optionally its version, for
+// example mysqlinstance, mysqlinstances.database.crossplane.io or
+// MySQLInstance.v1alpha1.database.crossplane.io.
+func KindFor(m meta.RESTMapper, resource string) (schema.GroupVersionKind, error) {
+	gvr, gr := schema.ParseResourceArg(strings.ToLower(resource))
+	if gvr != nil {
+		if gvk, err := m.KindFor(*gvr); err == nil {
+			return gvk, nil
+		}
+	}
+	gvk, err := m.KindFor(gr.WithVersion(""))
+	return gvk, errors.Wrapf(err, errFmtResolveKind, resource)
+}
+
+// Print the supplied resource graph as an indented tree.
+func Print(w io.Writer, n *Node) {
+	printNode(w, n, "", "")
+}
+
+func printNode(w io.Writer, n *Node, first, rest string) {
+	fmt.Fprintf(w, "%s%s\n", first, describe(n))
+
+	details := []string{}
+	if n.BindingPhase != "" {
+		details = append(details, fmt.Sprintf("Binding phase: %s", n.BindingPhase))
+	}
+	for _, c := range n.Conditions {
+		d := fmt.Sprintf("%s: %s", c.Type, c.Status)
+		if c.Reason != "" {
+			d += fmt.Sprintf(" (%s)", c.Reason)
+		}
+		if c.Message != "" {
+			d += fmt.Sprintf(" %s", c.Message)
+		}
+		details = append(details, d)
+	}
+	for _, p := range n.Problems {
+		details = append(details, fmt.Sprintf("! %s", p))
+	}
+
+	indent := rest + "│   "
+	if len(n.Children) == 0 {
+		indent = rest + "    "
+	}
+	for _, d := range details {
+		fmt.Fprintf(w, "%s%s\n", indent, d)
+	}
+
+	for i, c := range n.Children {
+		if i == len(n.Children)-1 {
+			printNode(w, c, rest+"└── ", rest+"    ")
+			continue
+		}
+		printNode(w, c, rest+"├── ", rest+"│   ")
+	}
+}
+
+func describe(n *Node) string {
+	name := n.Name
+	if n.Namespace != "" {
+		name = n.Namespace + "/" + n.Name
+	}
+	kind := n.Kind
+	if gv, err := schema.ParseGroupVersion(n.APIVersion); err == nil && gv.Group != "" {
+		kind = fmt.Sprintf("%s.%s", n.Kind, gv.Group)
+	}
+
+	d := fmt.Sprintf("%s: %s %s", n.Role, kind, name)
+	if !n.Found {
+		d += " (not found)"
+	}
+	return d
+}
+
+// conditions returns the conditions of the supplied object.
+func conditions(u *unstructured.Unstructured) []runtimev1alpha1.Condition {
+	cs, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	out := make([]runtimev1alpha1.Condition, 0, len(cs))
+	for _, o := range cs {
+		m, ok := o.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		c := runtimev1alpha1.Condition{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(m, &c); err != nil {
+			continue
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// condition returns the condition of the supplied type, and whether it was
+// found.
+func condition(cs []runtimev1alpha1.Condition, ct runtimev1alpha1.ConditionType) (runtimev1alpha1.Condition, bool) {
+	for _, c := range cs {
+		if c.Type == ct {
+			return c, true
+		}
+	}
+	return runtimev1alpha1.Condition{}, false
+}
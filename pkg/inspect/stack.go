@@ -0,0 +1,209 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inspect
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+
+	"github.com/crossplaneio/crossplane/apis/stacks/v1alpha1"
+)
+
+// Error strings.
+const (
+	errListStacks         = "cannot list stacks"
+	errFmtGetController   = "cannot get controller %s of stack %s"
+	errFmtUnknownCtrlKind = "unknown controller kind %s"
+)
+
+// Controller health.
+const (
+	HealthHealthy   = "Healthy"
+	HealthUnhealthy = "Unhealthy"
+	HealthMissing   = "Missing"
+	HealthPending   = "Pending"
+	HealthNone      = "None"
+)
+
+// A Stack that is installed.
+type Stack struct {
+	Namespace string
+	Name      string
+	Version   string
+
+	// Ready is the status of the stack's Ready condition.
+	Ready corev1.ConditionStatus
+
+	// Owned CRDs, as kind.group.
+	Owned []string
+
+	// Controller is the kind and name of the stack's controller.
+	Controller string
+
+	// Health of the stack's controller, and a message explaining it.
+	Health        string
+	HealthMessage string
+}
+
+// Stacks returns the stacks installed in the supplied namespace, or in all
+// namespaces if the supplied namespace is empty.
+func Stacks(ctx context.Context, c client.Reader, namespace string) ([]Stack, error) {
+	l := &v1alpha1.StackList{}
+	if err := c.List(ctx, l, client.InNamespace(namespace)); err != nil {
+		return nil, errors.Wrap(err, errListStacks)
+	}
+
+	stacks := make([]Stack, 0, len(l.Items))
+	for i := range l.Items {
+		s, err := stack(ctx, c, &l.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		stacks = append(stacks, s)
+	}
+	return stacks, nil
+}
+
+func stack(ctx context.Context, c client.Reader, st *v1alpha1.Stack) (Stack, error) {
+	s := Stack{
+		Namespace: st.GetNamespace(),
+		Name:      st.GetName(),
+		Version:   st.Spec.Version,
+		Ready:     corev1.ConditionUnknown,
+		Owned:     make([]string, 0, len(st.Spec.CRDs.Owned)),
+		Health:    HealthNone,
+	}
+	if r, ok := condition(st.Status.Conditions, runtimev1alpha1.TypeReady); ok {
+		s.Ready = r.Status
+	}
+	for _, crd := range st.Spec.CRDs.Owned {
+		s.Owned = append(s.Owned, ownedName(crd.APIVersion, crd.Kind))
+	}
+
+	hasController := st.Spec.Controller.Deployment != nil || st.Spec.Controller.Job != nil
+	ref := st.Status.ControllerRef
+	switch {
+	case ref == nil && hasController:
+		s.Health = HealthPending
+		s.HealthMessage = "Controller has not been created yet"
+		return s, nil
+	case ref == nil:
+		return s, nil
+	}
+	s.Controller = fmt.Sprintf("%s/%s", strings.ToLower(ref.Kind), ref.Name)
+
+	nn := types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}
+	var err error
+	switch ref.Kind {
+	case "Deployment":
+		err = deploymentHealth(ctx, c, nn, &s)
+	case "Job":
+		err = jobHealth(ctx, c, nn, &s)
+	default:
+		err = errors.Errorf(errFmtUnknownCtrlKind, ref.Kind)
+	}
+	if kerrors.IsNotFound(err) {
+		s.Health = HealthMissing
+		s.HealthMessage = "Controller does not exist"
+		return s, nil
+	}
+	return s, errors.Wrapf(err, errFmtGetController, s.Controller, st.GetName())
+}
+
+func deploymentHealth(ctx context.Context, c client.Reader, nn types.NamespacedName, s *Stack) error {
+	d := &appsv1.Deployment{}
+	if err := c.Get(ctx, nn, d); err != nil {
+		return err
+	}
+	want := int32(1)
+	if d.Spec.Replicas != nil {
+		want = *d.Spec.Replicas
+	}
+	s.Health = HealthHealthy
+	if d.Status.AvailableReplicas < want {
+		s.Health = HealthUnhealthy
+	}
+	s.HealthMessage = fmt.Sprintf("%d/%d replicas available", d.Status.AvailableReplicas, want)
+	return nil
+}
+
+func jobHealth(ctx context.Context, c client.Reader, nn types.NamespacedName, s *Stack) error {
+	j := &batchv1.Job{}
+	if err := c.Get(ctx, nn, j); err != nil {
+		return err
+	}
+	for _, jc := range j.Status.Conditions {
+		if jc.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch jc.Type {
+		case batchv1.JobComplete:
+			s.Health = HealthHealthy
+			s.HealthMessage = "Job completed"
+			return nil
+		case batchv1.JobFailed:
+			s.Health = HealthUnhealthy
+			s.HealthMessage = fmt.Sprintf("Job failed: %s", jc.Message)
+			return nil
+		}
+	}
+	s.Health = HealthPending
+	s.HealthMessage = fmt.Sprintf("Job running, %d active", j.Status.Active)
+	return nil
+}
+
+// ownedName returns the name of a CRD of the supplied API version and kind,
+// as kind.group.
+func ownedName(apiVersion, kind string) string {
+	group := apiVersion
+	if i := strings.Index(apiVersion, "/"); i >= 0 {
+		group = apiVersion[:i]
+	}
+	return strings.ToLower(kind) + "." + group
+}
+
+// PrintStacks prints the supplied stacks as a table.
+func PrintStacks(w io.Writer, stacks []Stack) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAMESPACE\tNAME\tVERSION\tREADY\tCONTROLLER\tHEALTH\tOWNS")
+	for _, s := range stacks {
+		controller := s.Controller
+		if controller == "" {
+			controller = "-"
+		}
+		health := s.Health
+		if s.HealthMessage != "" {
+			health = fmt.Sprintf("%s (%s)", s.Health, s.HealthMessage)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			s.Namespace, s.Name, s.Version, s.Ready, controller, health, strings.Join(s.Owned, ","))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inspect
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+
+	"github.com/crossplaneio/crossplane/apis/stacks/v1alpha1"
+)
+
+func installed(controller *corev1.ObjectReference) v1alpha1.Stack {
+	s := v1alpha1.Stack{}
+	s.SetNamespace("default")
+	s.SetName("wordpress")
+	s.Spec.Version = "0.1.0"
+	s.Spec.CRDs.Owned = []metav1.TypeMeta{{APIVersion: "wordpress.samples.stacks.crossplane.io/v1alpha1", Kind: "WordpressInstance"}}
+	s.Spec.Controller.Deployment = &v1alpha1.ControllerDeployment{Name: "wordpress"}
+	s.Status.ControllerRef = controller
+	s.Status.SetConditions(runtimev1alpha1.Available())
+	return s
+}
+
+func TestStacks(t *testing.T) {
+	deployment := &corev1.ObjectReference{Kind: "Deployment", Namespace: "default", Name: "wordpress"}
+	job := &corev1.ObjectReference{Kind: "Job", Namespace: "default", Name: "wordpress"}
+
+	list := func(stacks ...v1alpha1.Stack) func(context.Context, runtime.Object, ...client.ListOption) error {
+		return func(_ context.Context, obj runtime.Object, _ ...client.ListOption) error {
+			obj.(*v1alpha1.StackList).Items = stacks
+			return nil
+		}
+	}
+	summary := func(controller, health, message string) []Stack {
+		return []Stack{{
+			Namespace:     "default",
+			Name:          "wordpress",
+			Version:       "0.1.0",
+			Ready:         corev1.ConditionTrue,
+			Owned:         []string{"wordpressinstance.wordpress.samples.stacks.crossplane.io"},
+			Controller:    controller,
+			Health:        health,
+			HealthMessage: message,
+		}}
+	}
+
+	type want struct {
+		stacks []Stack
+		err    error
+	}
+
+	cases := map[string]struct {
+		c    client.Reader
+		want want
+	}{
+		"ListError": {
+			c:    &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+			want: want{err: errors.Wrap(errBoom, errListStacks)},
+		},
+		"ControllerPending": {
+			c:    &test.MockClient{MockList: list(installed(nil))},
+			want: want{stacks: summary("", HealthPending, "Controller has not been created yet")},
+		},
+		"DeploymentHealthy": {
+			c: &test.MockClient{
+				MockList: list(installed(deployment)),
+				MockGet: func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+					obj.(*appsv1.Deployment).Status.AvailableReplicas = 1
+					return nil
+				},
+			},
+			want: want{stacks: summary("deployment/wordpress", HealthHealthy, "1/1 replicas available")},
+		},
+		"DeploymentUnhealthy": {
+			c: &test.MockClient{
+				MockList: list(installed(deployment)),
+				MockGet:  test.NewMockGetFn(nil),
+			},
+			want: want{stacks: summary("deployment/wordpress", HealthUnhealthy, "0/1 replicas available")},
+		},
+		"DeploymentMissing": {
+			c: &test.MockClient{
+				MockList: list(installed(deployment)),
+				MockGet:  test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "wordpress")),
+			},
+			want: want{stacks: summary("deployment/wordpress", HealthMissing, "Controller does not exist")},
+		},
+		"JobFailed": {
+			c: &test.MockClient{
+				MockList: list(installed(job)),
+				MockGet: func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+					obj.(*batchv1.Job).Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "boom"}}
+					return nil
+				},
+			},
+			want: want{stacks: summary("job/wordpress", HealthUnhealthy, "Job failed: boom")},
+		},
+		"GetControllerError": {
+			c: &test.MockClient{
+				MockList: list(installed(deployment)),
+				MockGet:  test.NewMockGetFn(errBoom),
+			},
+			want: want{err: errors.Wrapf(errBoom, errFmtGetController, "deployment/wordpress", "wordpress")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Stacks(context.Background(), tc.c, "default")
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Stacks(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.stacks, got); diff != "" {
+				t.Errorf("Stacks(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPrintStacks(t *testing.T) {
+	stacks := []Stack{{
+		Namespace:     "default",
+		Name:          "wordpress",
+		Version:       "0.1.0",
+		Ready:         corev1.ConditionTrue,
+		Owned:         []string{"wordpressinstance.wordpress.samples.stacks.crossplane.io"},
+		Controller:    "deployment/wordpress",
+		Health:        HealthHealthy,
+		HealthMessage: "1/1 replicas available",
+	}}
+
+	want := `NAMESPACE  NAME       VERSION  READY  CONTROLLER            HEALTH                            OWNS
+default    wordpress  0.1.0    True   deployment/wordpress  Healthy (1/1 replicas available)  wordpressinstance.wordpress.samples.stacks.crossplane.io
+`
+
+	b := &bytes.Buffer{}
+	if err := PrintStacks(b, stacks); err != nil {
+		t.Fatalf("PrintStacks(...): %s", err)
+	}
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("PrintStacks(...): -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics contains Prometheus metrics that describe the cloud
+// resources Crossplane manages and the cloud APIs it calls. The metrics are
+// registered with controller-runtime's registry, and are thus served by the
+// controller manager alongside its own metrics.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const namespace = "crossplane"
+
+// Metric labels.
+const (
+	LabelAPI          = "api"
+	LabelOperation    = "operation"
+	LabelCode         = "code"
+	LabelKind         = "kind"
+	LabelReady        = "ready"
+	LabelBindingPhase = "binding_phase"
+)
+
+// CodeError is the code label of requests that did not receive a response.
+const CodeError = "error"
+
+var (
+	// CloudAPIRequests counts requests made to cloud provider APIs.
+	CloudAPIRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cloud_api",
+		Name:      "requests_total",
+		Help:      "Total number of requests made to cloud provider APIs, by API, operation and response code. HTTP APIs report their HTTP status code, and gRPC APIs their gRPC status code.",
+	}, []string{LabelAPI, LabelOperation, LabelCode})
+
+	// CloudAPIRequestDuration observes the latency of requests made to cloud
+	// provider APIs.
+	CloudAPIRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "cloud_api",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of requests made to cloud provider APIs, by API and operation. Time spent waiting for the rate limiter is excluded.",
+		Buckets:   prometheus.ExponentialBuckets(0.05, 2, 10),
+	}, []string{LabelAPI, LabelOperation})
+
+	// ManagedResourceTimeToReady observes how long managed resources take to
+	// become ready after they are created.
+	ManagedResourceTimeToReady = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "managed_resource",
+		Name:      "time_to_ready_seconds",
+		Help:      "Time from the creation of managed resources until they became ready, by kind.",
+		Buckets:   prometheus.ExponentialBuckets(15, 2, 10),
+	}, []string{LabelKind})
+
+	// ManagedResources counts managed resources.
+	ManagedResources = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "managed_resources",
+		Help:      "Number of managed resources, by kind, readiness and binding phase.",
+	}, []string{LabelKind, LabelReady, LabelBindingPhase})
+
+	// ResourceClaims counts resource claims.
+	ResourceClaims = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "resource_claims",
+		Help:      "Number of resource claims, by kind and binding phase.",
+	}, []string{LabelKind, LabelBindingPhase})
+
+	// StackInstallDuration observes how long stacks take to install.
+	StackInstallDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "stack",
+		Name:      "install_duration_seconds",
+		Help:      "Time from the creation of stack requests until their stack was successfully installed.",
+		Buckets:   prometheus.ExponentialBuckets(5, 2, 8),
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		CloudAPIRequests,
+		CloudAPIRequestDuration,
+		ManagedResourceTimeToReady,
+		ManagedResources,
+		ResourceClaims,
+		StackInstallDuration,
+	)
+}
+
+// ObserveCloudAPIRequest records a request made to the named operation of a
+// cloud API, which took the supplied duration. The response is nil if the
+// request did not receive one.
+func ObserveCloudAPIRequest(api, operation string, rsp *http.Response, d time.Duration) {
+	code := CodeError
+	if rsp != nil {
+		code = strconv.Itoa(rsp.StatusCode)
+	}
+	ObserveCloudAPICall(api, operation, code, d)
+}
+
+// ObserveCloudAPICall records a call to the named operation of a cloud API,
+// which returned the supplied code and took the supplied duration.
+func ObserveCloudAPICall(api, operation, code string, d time.Duration) {
+	CloudAPIRequests.WithLabelValues(api, operation, code).Inc()
+	CloudAPIRequestDuration.WithLabelValues(api, operation).Observe(d.Seconds())
+}
+
+// UnaryClientInterceptor returns a gRPC interceptor that records calls made to
+// the supplied cloud API. Calls are labelled with the name of the gRPC method
+// they invoke, and the gRPC status code they returned.
+func UnaryClientInterceptor(api string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		ObserveCloudAPICall(api, path.Base(method), status.Code(err).String(), time.Since(start))
+		return err
+	}
+}
+
+type operationKey struct{}
+
+// WithOperation returns a copy of the supplied context that names the cloud
+// API operation made by requests using the context. Cloud SDKs that know the
+// name of each operation may use it to label metrics more precisely than can
+// be inferred from the HTTP request.
+func WithOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, operationKey{}, operation)
+}
+
+// OperationFrom returns the cloud API operation named by the supplied
+// context, if any.
+func OperationFrom(ctx context.Context) (string, bool) {
+	op, ok := ctx.Value(operationKey{}).(string)
+	return op, ok
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// write returns the current value of the supplied metric.
+func write(t *testing.T, m prometheus.Metric) *dto.Metric {
+	t.Helper()
+	d := &dto.Metric{}
+	if err := m.Write(d); err != nil {
+		t.Fatalf("m.Write(...): %s", err)
+	}
+	return d
+}
+
+func TestObserveCloudAPIRequest(t *testing.T) {
+	cases := map[string]struct {
+		api  string
+		rsp  *http.Response
+		code string
+	}{
+		"Response": {
+			api:  "response.example.org",
+			rsp:  &http.Response{StatusCode: http.StatusTooManyRequests},
+			code: "429",
+		},
+		"NoResponse": {
+			api:  "noresponse.example.org",
+			code: CodeError,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ObserveCloudAPIRequest(tc.api, "CoolOperation", tc.rsp, 2*time.Second)
+
+			requests := write(t, CloudAPIRequests.WithLabelValues(tc.api, "CoolOperation", tc.code)).GetCounter().GetValue()
+			if diff := cmp.Diff(1.0, requests); diff != "" {
+				t.Errorf("ObserveCloudAPIRequest(...): -want requests, +got requests:\n%s", diff)
+			}
+
+			h := CloudAPIRequestDuration.WithLabelValues(tc.api, "CoolOperation").(prometheus.Histogram)
+			duration := write(t, h).GetHistogram().GetSampleSum()
+			if diff := cmp.Diff(2.0, duration); diff != "" {
+				t.Errorf("ObserveCloudAPIRequest(...): -want duration, +got duration:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		code string
+	}{
+		"Succeeded": {code: "OK"},
+		"Failed":    {err: status.Error(codes.Unavailable, "boom"), code: "Unavailable"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			api := "grpc.example.org/" + name
+			invoker := func(_ context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+				return tc.err
+			}
+
+			err := UnaryClientInterceptor(api)(context.Background(), "/example.v1.Cool/CoolOperation", nil, nil, nil, invoker)
+			if err != tc.err {
+				t.Errorf("UnaryClientInterceptor(...): want error %v, got %v", tc.err, err)
+			}
+
+			requests := write(t, CloudAPIRequests.WithLabelValues(api, "CoolOperation", tc.code)).GetCounter().GetValue()
+			if diff := cmp.Diff(1.0, requests); diff != "" {
+				t.Errorf("UnaryClientInterceptor(...): -want requests, +got requests:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOperationFrom(t *testing.T) {
+	if _, ok := OperationFrom(context.Background()); ok {
+		t.Errorf("OperationFrom(...): want no operation from a context without one")
+	}
+
+	got, ok := OperationFrom(WithOperation(context.Background(), "CoolOperation"))
+	if !ok {
+		t.Errorf("OperationFrom(...): want an operation from a context with one")
+	}
+	if diff := cmp.Diff("CoolOperation", got); diff != "" {
+		t.Errorf("OperationFrom(...): -want, +got:\n%s", diff)
+	}
+}
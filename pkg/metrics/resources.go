@@ -0,0 +1,186 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/logging"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+)
+
+const listTimeout = 1 * time.Minute
+
+var log = logging.Logger.WithName("metrics")
+
+// A ResourceCollector periodically counts the managed resources and resource
+// claims of every kind registered with a manager's scheme, and observes how
+// long managed resources took to become ready.
+type ResourceCollector struct {
+	client   client.Reader
+	managed  []schema.GroupVersionKind
+	claims   []schema.GroupVersionKind
+	interval time.Duration
+
+	// since is when the previous collection started. Managed resources
+	// that became ready since then have not yet been observed.
+	since time.Time
+}
+
+// NewResourceCollector returns a ResourceCollector that collects metrics at
+// the supplied interval. Resources are read directly from the API server,
+// rather than from the manager's cache, so that kinds without a controller
+// are not cached.
+func NewResourceCollector(m manager.Manager, interval time.Duration) *ResourceCollector {
+	c := &ResourceCollector{client: m.GetAPIReader(), interval: interval}
+	for gvk, t := range m.GetScheme().AllKnownTypes() {
+		switch reflect.New(t).Interface().(type) {
+		case resource.Managed:
+			c.managed = append(c.managed, gvk)
+		case resource.Claim:
+			c.claims = append(c.claims, gvk)
+		}
+	}
+	sort.Slice(c.managed, func(i, j int) bool { return c.managed[i].String() < c.managed[j].String() })
+	sort.Slice(c.claims, func(i, j int) bool { return c.claims[i].String() < c.claims[j].String() })
+	return c
+}
+
+// Start collecting metrics until the supplied channel is closed.
+func (c *ResourceCollector) Start(stop <-chan struct{}) error {
+	// Times in resource status have a resolution of one second.
+	c.since = time.Now().Truncate(time.Second)
+
+	t := time.NewTicker(c.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			c.collect()
+		case <-stop:
+			return nil
+		}
+	}
+}
+
+func (c *ResourceCollector) collect() {
+	ctx, cancel := context.WithTimeout(context.Background(), listTimeout)
+	defer cancel()
+
+	now := time.Now().Truncate(time.Second)
+
+	managed := map[[3]string]float64{}
+	for _, k := range c.managed {
+		l, err := c.list(ctx, k)
+		if err != nil {
+			log.V(logging.Debug).Info("cannot list managed resources", "kind", k, "error", err)
+			continue
+		}
+		for i := range l.Items {
+			mg := &l.Items[i]
+			ready := readyCondition(mg)
+			managed[[3]string{kind(k), ready.status, bindingPhase(mg)}]++
+
+			// Each managed resource that became ready during this
+			// interval is observed exactly once.
+			if ready.status != string(corev1.ConditionTrue) {
+				continue
+			}
+			if ready.time.Before(c.since) || !ready.time.Before(now) {
+				continue
+			}
+			ManagedResourceTimeToReady.WithLabelValues(kind(k)).Observe(ready.time.Sub(mg.GetCreationTimestamp().Time).Seconds())
+		}
+	}
+
+	claims := map[[2]string]float64{}
+	for _, k := range c.claims {
+		l, err := c.list(ctx, k)
+		if err != nil {
+			log.V(logging.Debug).Info("cannot list resource claims", "kind", k, "error", err)
+			continue
+		}
+		for i := range l.Items {
+			claims[[2]string{kind(k), bindingPhase(&l.Items[i])}]++
+		}
+	}
+
+	c.since = now
+
+	ManagedResources.Reset()
+	for l, n := range managed {
+		ManagedResources.WithLabelValues(l[0], l[1], l[2]).Set(n)
+	}
+	ResourceClaims.Reset()
+	for l, n := range claims {
+		ResourceClaims.WithLabelValues(l[0], l[1]).Set(n)
+	}
+}
+
+func (c *ResourceCollector) list(ctx context.Context, k schema.GroupVersionKind) (*unstructured.UnstructuredList, error) {
+	l := &unstructured.UnstructuredList{}
+	l.SetGroupVersionKind(k.GroupVersion().WithKind(k.Kind + "List"))
+	return l, c.client.List(ctx, l)
+}
+
+// kind returns the label used for resources of the supplied kind, for example
+// RDSInstance.database.aws.crossplane.io.
+func kind(k schema.GroupVersionKind) string {
+	return k.GroupKind().String()
+}
+
+type condition struct {
+	status string
+	time   time.Time
+}
+
+// readyCondition returns the status of the supplied resource's Ready
+// condition, and when it last transitioned. The status is Unknown if the
+// resource has no Ready condition.
+func readyCondition(u *unstructured.Unstructured) condition {
+	conditions, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, o := range conditions {
+		c, ok := o.(map[string]interface{})
+		if !ok || c["type"] != string(runtimev1alpha1.TypeReady) {
+			continue
+		}
+		status, _ := c["status"].(string)
+		ts, _ := c["lastTransitionTime"].(string)
+		t, _ := time.Parse(time.RFC3339, ts)
+		return condition{status: status, time: t}
+	}
+	return condition{status: string(corev1.ConditionUnknown)}
+}
+
+// bindingPhase returns the binding phase of the supplied resource, if any.
+func bindingPhase(u *unstructured.Unstructured) string {
+	p, _, _ := unstructured.NestedString(u.Object, "status", "bindingPhase")
+	return p
+}
+
+// Ensure a ResourceCollector can be added to a manager.
+var _ manager.Runnable = &ResourceCollector{}
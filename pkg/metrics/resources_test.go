@@ -0,0 +1,124 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+)
+
+var (
+	managedKind = schema.GroupVersionKind{Group: "cool.crossplane.io", Version: "v1alpha1", Kind: "CoolManaged"}
+	claimKind   = schema.GroupVersionKind{Group: "cool.crossplane.io", Version: "v1alpha1", Kind: "CoolClaim"}
+	brokenKind  = schema.GroupVersionKind{Group: "broken.crossplane.io", Version: "v1alpha1", Kind: "BrokenManaged"}
+)
+
+func object(created time.Time, phase string, ready string, readyAt time.Time) unstructured.Unstructured {
+	status := map[string]interface{}{"bindingPhase": phase}
+	if ready != "" {
+		status["conditions"] = []interface{}{
+			map[string]interface{}{"type": "Ready", "status": ready, "lastTransitionTime": readyAt.UTC().Format(time.RFC3339)},
+		}
+	}
+	u := unstructured.Unstructured{Object: map[string]interface{}{"status": status}}
+	u.SetCreationTimestamp(metav1.NewTime(created))
+	return u
+}
+
+func TestCollect(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	since := now.Add(-1 * time.Minute)
+
+	managed := []unstructured.Unstructured{
+		// Became ready before the previous collection, so already observed.
+		object(now.Add(-10*time.Minute), "Bound", "True", now.Add(-5*time.Minute)),
+
+		// Became ready since the previous collection.
+		object(now.Add(-90*time.Second), "Unbound", "True", now.Add(-30*time.Second)),
+
+		object(now.Add(-20*time.Second), "Unbound", "False", now.Add(-10*time.Second)),
+		object(now.Add(-10*time.Second), "", "", time.Time{}),
+	}
+	claims := []unstructured.Unstructured{
+		object(now, "Bound", "", time.Time{}),
+		object(now, "Bound", "", time.Time{}),
+		object(now, "Unbound", "", time.Time{}),
+	}
+
+	c := &ResourceCollector{
+		client: &test.MockClient{
+			MockList: func(_ context.Context, obj runtime.Object, _ ...client.ListOption) error {
+				l := obj.(*unstructured.UnstructuredList)
+				switch l.GroupVersionKind() {
+				case managedKind.GroupVersion().WithKind(managedKind.Kind + "List"):
+					l.Items = managed
+				case claimKind.GroupVersion().WithKind(claimKind.Kind + "List"):
+					l.Items = claims
+				default:
+					return errors.New("boom")
+				}
+				return nil
+			},
+		},
+		managed: []schema.GroupVersionKind{brokenKind, managedKind},
+		claims:  []schema.GroupVersionKind{claimKind},
+		since:   since,
+	}
+	c.collect()
+
+	gauges := map[string]struct {
+		gauge prometheus.Gauge
+		want  float64
+	}{
+		"ReadyBound":       {gauge: ManagedResources.WithLabelValues("CoolManaged.cool.crossplane.io", "True", "Bound"), want: 1},
+		"ReadyUnbound":     {gauge: ManagedResources.WithLabelValues("CoolManaged.cool.crossplane.io", "True", "Unbound"), want: 1},
+		"NotReadyUnbound":  {gauge: ManagedResources.WithLabelValues("CoolManaged.cool.crossplane.io", "False", "Unbound"), want: 1},
+		"UnknownReadiness": {gauge: ManagedResources.WithLabelValues("CoolManaged.cool.crossplane.io", "Unknown", ""), want: 1},
+		"ClaimsBound":      {gauge: ResourceClaims.WithLabelValues("CoolClaim.cool.crossplane.io", "Bound"), want: 2},
+		"ClaimsUnbound":    {gauge: ResourceClaims.WithLabelValues("CoolClaim.cool.crossplane.io", "Unbound"), want: 1},
+	}
+	for name, g := range gauges {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(g.want, write(t, g.gauge).GetGauge().GetValue()); diff != "" {
+				t.Errorf("c.collect(): -want, +got:\n%s", diff)
+			}
+		})
+	}
+
+	h := write(t, ManagedResourceTimeToReady.WithLabelValues("CoolManaged.cool.crossplane.io").(prometheus.Histogram)).GetHistogram()
+	if diff := cmp.Diff(uint64(1), h.GetSampleCount()); diff != "" {
+		t.Errorf("c.collect(): -want time to ready observations, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff(60.0, h.GetSampleSum()); diff != "" {
+		t.Errorf("c.collect(): -want time to ready, +got:\n%s", diff)
+	}
+	if c.since.Before(now) {
+		t.Errorf("c.collect(): want next collection to observe resources that became ready after %s, got %s", now, c.since)
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pause suspends the reconciliation of individual resources, so that
+// an operator may change the external resource a managed resource represents
+// without Crossplane overwriting the change, or marking it as failed.
+package pause
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+)
+
+// AnnotationKey is the key of the annotation that pauses reconciliation of a
+// resource when its value is "true".
+const AnnotationKey = "crossplane.io/paused"
+
+// TypePaused resources are not reconciled.
+const TypePaused runtimev1alpha1.ConditionType = "Paused"
+
+// Reasons a resource is or is not paused.
+const (
+	ReasonPaused  runtimev1alpha1.ConditionReason = "Reconciliation is paused"
+	ReasonResumed runtimev1alpha1.ConditionReason = "Reconciliation has resumed"
+)
+
+// IsPaused returns true if reconciliation of the supplied object is paused.
+func IsPaused(o metav1.Object) bool {
+	return o.GetAnnotations()[AnnotationKey] == "true"
+}
+
+// Set whether reconciliation of the supplied object is paused.
+func Set(o metav1.Object, paused bool) {
+	a := o.GetAnnotations()
+	if !paused {
+		delete(a, AnnotationKey)
+		o.SetAnnotations(a)
+		return
+	}
+	if a == nil {
+		a = map[string]string{}
+	}
+	a[AnnotationKey] = "true"
+	o.SetAnnotations(a)
+}
+
+// Paused returns a condition that indicates reconciliation of a resource is
+// paused. Crossplane neither changes the resource nor calls the external
+// system it represents until it is resumed.
+func Paused() runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypePaused,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPaused,
+		Message:            "Remove the " + AnnotationKey + " annotation to resume reconciliation",
+	}
+}
+
+// Resumed returns a condition that indicates reconciliation of a resource
+// that was paused has resumed.
+func Resumed() runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypePaused,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonResumed,
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pause
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplaneio/crossplane/gcp/apis/compute/v1alpha1"
+)
+
+func TestSet(t *testing.T) {
+	n := &v1alpha1.Network{}
+
+	Set(n, true)
+	if !IsPaused(n) {
+		t.Errorf("Set(n, true): IsPaused(n) = false, want true")
+	}
+
+	Set(n, false)
+	if IsPaused(n) {
+		t.Errorf("Set(n, false): IsPaused(n) = true, want false")
+	}
+	if diff := cmp.Diff(map[string]string{}, n.GetAnnotations()); diff != "" {
+		t.Errorf("Set(n, false): -want annotations, +got annotations:\n%s", diff)
+	}
+}
+
+func TestIsPaused(t *testing.T) {
+	cases := map[string]struct {
+		annotations map[string]string
+		want        bool
+	}{
+		"NoAnnotations": {
+			want: false,
+		},
+		"False": {
+			annotations: map[string]string{AnnotationKey: "false"},
+			want:        false,
+		},
+		"True": {
+			annotations: map[string]string{AnnotationKey: "true"},
+			want:        true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			n := &v1alpha1.Network{}
+			n.SetAnnotations(tc.annotations)
+			if got := IsPaused(n); got != tc.want {
+				t.Errorf("IsPaused(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pause
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+)
+
+const timeout = 1 * time.Minute
+
+// Error strings.
+const (
+	errFmtUpdate = "cannot update paused condition of %s"
+)
+
+// An Object whose reconciliation may be paused.
+type Object interface {
+	metav1.Object
+	runtime.Object
+
+	SetConditions(c ...runtimev1alpha1.Condition)
+	GetCondition(runtimev1alpha1.ConditionType) runtimev1alpha1.Condition
+}
+
+// A ReconcilerOption configures a Reconciler.
+type ReconcilerOption func(*Reconciler)
+
+// WithObjectUpdate configures a Reconciler to update the whole object when it
+// sets the Paused condition, rather than its status subresource. It is
+// required for kinds whose status is not a subresource.
+func WithObjectUpdate() ReconcilerOption {
+	return func(r *Reconciler) {
+		r.update = func(ctx context.Context, obj runtime.Object) error {
+			return r.client.Update(ctx, obj)
+		}
+	}
+}
+
+// A Reconciler skips the reconciles of a reconcile.Reconciler while the
+// reconciled resource is paused.
+type Reconciler struct {
+	reconcile.Reconciler
+
+	client client.Client
+	of     Object
+	update func(context.Context, runtime.Object) error
+}
+
+// NewReconciler returns a Reconciler that skips the reconciles of the supplied
+// reconcile.Reconciler while the reconciled resource, of the same kind as the
+// supplied Object, is paused.
+func NewReconciler(c client.Client, of Object, r reconcile.Reconciler, o ...ReconcilerOption) *Reconciler {
+	pr := &Reconciler{Reconciler: r, client: c, of: of}
+	pr.update = func(ctx context.Context, obj runtime.Object) error {
+		return pr.client.Status().Update(ctx, obj)
+	}
+	for _, ro := range o {
+		ro(pr)
+	}
+	return pr
+}
+
+// Reconcile the supplied request, unless the requested resource is paused.
+// The Paused condition of the resource is set when it is paused, and cleared
+// before reconciliation resumes.
+func (r *Reconciler) Reconcile(req reconcile.Request) (reconcile.Result, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	obj := r.of.DeepCopyObject().(Object)
+	if err := r.client.Get(ctx, req.NamespacedName, obj); err != nil {
+		// The wrapped Reconciler handles resources it cannot get.
+		return r.Reconciler.Reconcile(req)
+	}
+
+	paused := IsPaused(obj)
+	wasPaused := obj.GetCondition(TypePaused).Status == corev1.ConditionTrue
+
+	switch {
+	case paused && wasPaused:
+		// Removing the annotation triggers a reconcile, so there is no need
+		// to requeue.
+		return reconcile.Result{}, nil
+	case paused:
+		obj.SetConditions(Paused())
+		return reconcile.Result{}, errors.Wrapf(r.update(ctx, obj), errFmtUpdate, req.NamespacedName)
+	case wasPaused:
+		// We requeue rather than reconcile immediately, so that the wrapped
+		// Reconciler reads the resource as of our update.
+		obj.SetConditions(Resumed())
+		return reconcile.Result{Requeue: true}, errors.Wrapf(r.update(ctx, obj), errFmtUpdate, req.NamespacedName)
+	}
+
+	return r.Reconciler.Reconcile(req)
+}
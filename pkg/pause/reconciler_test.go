@@ -0,0 +1,208 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pause
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+
+	awsdatabasev1alpha1 "github.com/crossplaneio/crossplane/aws/apis/database/v1alpha1"
+	"github.com/crossplaneio/crossplane/gcp/apis/compute/v1alpha1"
+)
+
+var (
+	errBoom = errors.New("boom")
+	req     = reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "cool"}}
+)
+
+type networkModifier func(*v1alpha1.Network)
+
+func withPaused(n *v1alpha1.Network) { Set(n, true) }
+
+func withCondition(c runtimev1alpha1.Condition) networkModifier {
+	return func(n *v1alpha1.Network) { n.SetConditions(c) }
+}
+
+func get(nm ...networkModifier) func(context.Context, client.ObjectKey, runtime.Object) error {
+	return func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+		n := obj.(*v1alpha1.Network)
+		for _, m := range nm {
+			m(n)
+		}
+		return nil
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	type want struct {
+		result     reconcile.Result
+		err        error
+		reconciled bool
+		updated    bool
+		condition  runtimev1alpha1.Condition
+	}
+
+	cases := map[string]struct {
+		get       func(context.Context, client.ObjectKey, runtime.Object) error
+		updateErr error
+		o         []ReconcilerOption
+		want      want
+	}{
+		"GetError": {
+			get:  test.NewMockGetFn(errBoom),
+			want: want{reconciled: true},
+		},
+		"NotPaused": {
+			get:  test.NewMockGetFn(nil),
+			want: want{reconciled: true},
+		},
+		"Paused": {
+			get:  get(withPaused),
+			want: want{updated: true, condition: Paused()},
+		},
+		"PausedUpdateError": {
+			get:       get(withPaused),
+			updateErr: errBoom,
+			want:      want{err: errors.Wrapf(errBoom, errFmtUpdate, req.NamespacedName), updated: true, condition: Paused()},
+		},
+		"PausedWithObjectUpdate": {
+			get:  get(withPaused),
+			o:    []ReconcilerOption{WithObjectUpdate()},
+			want: want{updated: true, condition: Paused()},
+		},
+		"StillPaused": {
+			get:  get(withPaused, withCondition(Paused())),
+			want: want{},
+		},
+		"Resumed": {
+			get:  get(withCondition(Paused())),
+			want: want{result: reconcile.Result{Requeue: true}, updated: true, condition: Resumed()},
+		},
+		"PreviouslyResumed": {
+			get:  get(withCondition(Resumed())),
+			want: want{reconciled: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			reconciled := false
+			wrapped := reconcile.Func(func(_ reconcile.Request) (reconcile.Result, error) {
+				reconciled = true
+				return reconcile.Result{}, nil
+			})
+
+			updated := false
+			var got runtimev1alpha1.Condition
+			update := func(_ context.Context, obj runtime.Object, _ ...client.UpdateOption) error {
+				updated = true
+				got = obj.(Object).GetCondition(TypePaused)
+				return tc.updateErr
+			}
+
+			// Objects are updated via their status subresource unless the
+			// Reconciler is configured otherwise.
+			c := &test.MockClient{MockGet: tc.get, MockStatusUpdate: update}
+			if len(tc.o) > 0 {
+				c = &test.MockClient{MockGet: tc.get, MockUpdate: update}
+			}
+
+			r := NewReconciler(c, &v1alpha1.Network{}, wrapped, tc.o...)
+			result, err := r.Reconcile(req)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r.Reconcile(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, result); diff != "" {
+				t.Errorf("r.Reconcile(...): -want, +got:\n%s", diff)
+			}
+			if reconciled != tc.want.reconciled {
+				t.Errorf("r.Reconcile(...): want wrapped reconciled %t, got %t", tc.want.reconciled, reconciled)
+			}
+			if updated != tc.want.updated {
+				t.Errorf("r.Reconcile(...): want updated %t, got %t", tc.want.updated, updated)
+			}
+			if !got.Equal(tc.want.condition) {
+				t.Errorf("r.Reconcile(...): want condition %+v, got %+v", tc.want.condition, got)
+			}
+		})
+	}
+}
+
+// TestReconcileWithoutStatusSubresource pauses an RDSInstance, whose status is
+// not a subresource. The API server returns NotFound when asked to update the
+// status subresource of such a kind.
+func TestReconcileWithoutStatusSubresource(t *testing.T) {
+	errNoStatus := kerrors.NewNotFound(schema.GroupResource{Group: awsdatabasev1alpha1.Group, Resource: "rdsinstances/status"}, req.Name)
+
+	cases := map[string]struct {
+		o    []ReconcilerOption
+		want error
+	}{
+		"StatusUpdate": {
+			want: errors.Wrapf(errNoStatus, errFmtUpdate, req.NamespacedName),
+		},
+		"ObjectUpdate": {
+			o: []ReconcilerOption{WithObjectUpdate()},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got *awsdatabasev1alpha1.RDSInstance
+			c := &test.MockClient{
+				MockGet: func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+					Set(obj.(*awsdatabasev1alpha1.RDSInstance), true)
+					return nil
+				},
+				MockStatusUpdate: func(_ context.Context, _ runtime.Object, _ ...client.UpdateOption) error {
+					return errNoStatus
+				},
+				MockUpdate: func(_ context.Context, obj runtime.Object, _ ...client.UpdateOption) error {
+					got = obj.(*awsdatabasev1alpha1.RDSInstance)
+					return nil
+				},
+			}
+
+			wrapped := reconcile.Func(func(_ reconcile.Request) (reconcile.Result, error) {
+				t.Errorf("r.Reconcile(...): paused RDSInstance was reconciled")
+				return reconcile.Result{}, nil
+			})
+
+			r := NewReconciler(c, &awsdatabasev1alpha1.RDSInstance{}, wrapped, tc.o...)
+			_, err := r.Reconcile(req)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r.Reconcile(...): -want error, +got error:\n%s", diff)
+			}
+			if tc.want == nil && (got == nil || !got.GetCondition(TypePaused).Equal(Paused())) {
+				t.Errorf("r.Reconcile(...): want RDSInstance updated with the Paused condition")
+			}
+		})
+	}
+}
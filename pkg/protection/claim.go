@@ -0,0 +1,92 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protection
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+)
+
+const timeout = 1 * time.Minute
+
+// Error strings.
+const (
+	errFmtUpdateClaim = "cannot update deletion protection condition of %s"
+)
+
+// ConfigureManaged protects the supplied managed resource from deletion if
+// the resource claim or resource class it is configured from is protected. It
+// is a resource.ManagedConfiguratorFn, and must be used by claim reconcilers
+// so that a protected claim's managed resource cannot be deleted in its stead.
+func ConfigureManaged(_ context.Context, cm resource.Claim, cs resource.Class, mg resource.Managed) error {
+	if Enabled(cm) || Enabled(cs) {
+		Set(mg, true)
+	}
+	return nil
+}
+
+// A Reconciler refuses to reconcile the deletion of a resource claim that is
+// protected from deletion. A claim reconciler deletes the managed resource of
+// a claim when its reclaim policy is Delete, and removes the claim's
+// finalizer, so a protected claim remains until its protection is removed.
+type Reconciler struct {
+	reconcile.Reconciler
+
+	client client.Client
+	of     resource.Claim
+}
+
+// NewReconciler returns a Reconciler that skips the reconciles of the supplied
+// claim reconciler while the reconciled claim, of the same kind as the
+// supplied Claim, is being deleted but is protected from deletion.
+func NewReconciler(c client.Client, of resource.Claim, r reconcile.Reconciler) *Reconciler {
+	return &Reconciler{Reconciler: r, client: c, of: of}
+}
+
+// Reconcile the supplied request, unless the requested claim is being deleted
+// but is protected. The claim explains why it was not deleted using its
+// Synced condition.
+func (r *Reconciler) Reconcile(req reconcile.Request) (reconcile.Result, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cm := r.of.DeepCopyObject().(resource.Claim)
+	if err := r.client.Get(ctx, req.NamespacedName, cm); err != nil {
+		// The wrapped Reconciler handles claims it cannot get.
+		return r.Reconciler.Reconcile(req)
+	}
+
+	err := Check(cm)
+	if err == nil {
+		return r.Reconciler.Reconcile(req)
+	}
+
+	// Removing the annotation triggers a reconcile, so there is no need to
+	// requeue, or to update a claim that already explains why it remains.
+	if cm.GetCondition(runtimev1alpha1.TypeSynced).Equal(runtimev1alpha1.ReconcileError(err)) {
+		return reconcile.Result{}, nil
+	}
+	cm.SetConditions(runtimev1alpha1.Deleting(), runtimev1alpha1.ReconcileError(err))
+	return reconcile.Result{}, errors.Wrapf(r.client.Status().Update(ctx, cm), errFmtUpdateClaim, req.NamespacedName)
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+
+	databasev1alpha1 "github.com/crossplaneio/crossplane/apis/database/v1alpha1"
+	awsdatabasev1alpha1 "github.com/crossplaneio/crossplane/aws/apis/database/v1alpha1"
+)
+
+var req = reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "cool"}}
+
+func TestConfigureManaged(t *testing.T) {
+	cases := map[string]struct {
+		cm   *databasev1alpha1.MySQLInstance
+		cs   *awsdatabasev1alpha1.RDSInstanceClass
+		want bool
+	}{
+		"Unprotected": {
+			cm:   &databasev1alpha1.MySQLInstance{},
+			cs:   &awsdatabasev1alpha1.RDSInstanceClass{},
+			want: false,
+		},
+		"ClaimProtected": {
+			cm:   &databasev1alpha1.MySQLInstance{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationKey: "true"}}},
+			cs:   &awsdatabasev1alpha1.RDSInstanceClass{},
+			want: true,
+		},
+		"ClassProtected": {
+			cm:   &databasev1alpha1.MySQLInstance{},
+			cs:   &awsdatabasev1alpha1.RDSInstanceClass{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationKey: "true"}}},
+			want: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &awsdatabasev1alpha1.RDSInstance{}
+			if err := ConfigureManaged(context.Background(), tc.cm, tc.cs, mg); err != nil {
+				t.Fatalf("ConfigureManaged(...): %s", err)
+			}
+			if got := Enabled(mg); got != tc.want {
+				t.Errorf("ConfigureManaged(...): want managed resource protected %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func claim(protected, deleted bool, c ...runtimev1alpha1.Condition) func(context.Context, client.ObjectKey, runtime.Object) error {
+	return func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+		cm := obj.(*databasev1alpha1.MySQLInstance)
+		cm.SetName("cool")
+		Set(cm, protected)
+		if deleted {
+			cm.SetDeletionTimestamp(&metav1.Time{})
+		}
+		cm.SetConditions(c...)
+		return nil
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	errProtected := errors.Errorf(errFmtProtected, "cool", AnnotationKey)
+
+	type want struct {
+		err        error
+		reconciled bool
+		updated    bool
+		condition  runtimev1alpha1.Condition
+	}
+
+	cases := map[string]struct {
+		get       func(context.Context, client.ObjectKey, runtime.Object) error
+		updateErr error
+		want      want
+	}{
+		"GetError": {
+			get:  test.NewMockGetFn(errBoom),
+			want: want{reconciled: true},
+		},
+		"Unprotected": {
+			get:  claim(false, true),
+			want: want{reconciled: true},
+		},
+		"ProtectedNotDeleted": {
+			get:  claim(true, false),
+			want: want{reconciled: true},
+		},
+		"ProtectedDeleted": {
+			get:  claim(true, true),
+			want: want{updated: true, condition: runtimev1alpha1.ReconcileError(errProtected)},
+		},
+		"ProtectedDeletedUpdateError": {
+			get:       claim(true, true),
+			updateErr: errBoom,
+			want:      want{err: errors.Wrapf(errBoom, errFmtUpdateClaim, req.NamespacedName), updated: true, condition: runtimev1alpha1.ReconcileError(errProtected)},
+		},
+		"StillProtectedDeleted": {
+			get:  claim(true, true, runtimev1alpha1.ReconcileError(errProtected)),
+			want: want{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			reconciled := false
+			wrapped := reconcile.Func(func(_ reconcile.Request) (reconcile.Result, error) {
+				reconciled = true
+				return reconcile.Result{}, nil
+			})
+
+			updated := false
+			var got runtimev1alpha1.Condition
+			c := &test.MockClient{
+				MockGet: tc.get,
+				MockStatusUpdate: func(_ context.Context, obj runtime.Object, _ ...client.UpdateOption) error {
+					updated = true
+					got = obj.(*databasev1alpha1.MySQLInstance).GetCondition(runtimev1alpha1.TypeSynced)
+					return tc.updateErr
+				},
+			}
+
+			r := NewReconciler(c, &databasev1alpha1.MySQLInstance{}, wrapped)
+			_, err := r.Reconcile(req)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r.Reconcile(...): -want error, +got error:\n%s", diff)
+			}
+			if reconciled != tc.want.reconciled {
+				t.Errorf("r.Reconcile(...): want wrapped reconciled %t, got %t", tc.want.reconciled, reconciled)
+			}
+			if updated != tc.want.updated {
+				t.Errorf("r.Reconcile(...): want updated %t, got %t", tc.want.updated, updated)
+			}
+			if !got.Equal(tc.want.condition) {
+				t.Errorf("r.Reconcile(...): want condition %+v, got %+v", tc.want.condition, got)
+			}
+		})
+	}
+}
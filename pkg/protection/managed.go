@@ -0,0 +1,71 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protection
+
+import (
+	"context"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+)
+
+// A Connecter returns external clients that refuse to observe or delete the
+// external resources of managed resources that are being deleted but are
+// protected from deletion.
+type Connecter struct {
+	resource.ExternalConnecter
+}
+
+// NewConnecter returns a Connecter that wraps the external clients returned
+// by the supplied ExternalConnecter.
+func NewConnecter(ec resource.ExternalConnecter) *Connecter {
+	return &Connecter{ExternalConnecter: ec}
+}
+
+// Connect to the external system of the supplied managed resource.
+func (c *Connecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	ec, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &External{ExternalClient: ec}, nil
+}
+
+// An External enforces deletion protection.
+type External struct {
+	resource.ExternalClient
+}
+
+// Observe the external resource of the supplied managed resource. Observe
+// returns an error if the managed resource is being deleted but is protected.
+// The managed resource reconciler neither deletes the external resource nor
+// removes its finalizer from the managed resource when Observe fails, so the
+// managed resource remains until its protection is removed.
+func (e *External) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	if err := Check(mg); err != nil {
+		return resource.ExternalObservation{}, err
+	}
+	return e.ExternalClient.Observe(ctx, mg)
+}
+
+// Delete the external resource of the supplied managed resource, unless it is
+// protected.
+func (e *External) Delete(ctx context.Context, mg resource.Managed) error {
+	if err := Check(mg); err != nil {
+		return err
+	}
+	return e.ExternalClient.Delete(ctx, mg)
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+)
+
+var errBoom = errors.New("boom")
+
+type mockConnecter struct {
+	client resource.ExternalClient
+	err    error
+}
+
+func (c *mockConnecter) Connect(_ context.Context, _ resource.Managed) (resource.ExternalClient, error) {
+	return c.client, c.err
+}
+
+type mockExternal struct {
+	observed bool
+	deleted  bool
+}
+
+func (e *mockExternal) Observe(_ context.Context, _ resource.Managed) (resource.ExternalObservation, error) {
+	e.observed = true
+	return resource.ExternalObservation{ResourceExists: true}, nil
+}
+
+func (e *mockExternal) Create(_ context.Context, _ resource.Managed) (resource.ExternalCreation, error) {
+	return resource.ExternalCreation{}, nil
+}
+
+func (e *mockExternal) Update(_ context.Context, _ resource.Managed) (resource.ExternalUpdate, error) {
+	return resource.ExternalUpdate{}, nil
+}
+
+func (e *mockExternal) Delete(_ context.Context, _ resource.Managed) error {
+	e.deleted = true
+	return nil
+}
+
+func TestConnecter(t *testing.T) {
+	c := NewConnecter(&mockConnecter{err: errBoom})
+	if _, err := c.Connect(context.Background(), network(false, false)); err != errBoom {
+		t.Errorf("c.Connect(...): want error %s, got %v", errBoom, err)
+	}
+}
+
+func TestExternal(t *testing.T) {
+	type want struct {
+		err      error
+		observed bool
+		deleted  bool
+	}
+
+	cases := map[string]struct {
+		mg   resource.Managed
+		want want
+	}{
+		"Unprotected": {
+			mg:   network(false, true),
+			want: want{observed: true, deleted: true},
+		},
+		"ProtectedNotDeleted": {
+			mg:   network(true, false),
+			want: want{observed: true, deleted: true},
+		},
+		"ProtectedDeleted": {
+			mg:   network(true, true),
+			want: want{err: errors.Errorf(errFmtProtected, "cool", AnnotationKey)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := &mockExternal{}
+			ec, err := NewConnecter(&mockConnecter{client: mock}).Connect(context.Background(), tc.mg)
+			if err != nil {
+				t.Fatalf("Connect(...): %s", err)
+			}
+
+			_, err = ec.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Observe(...): -want error, +got error:\n%s", diff)
+			}
+			err = ec.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("e.Delete(...): -want error, +got error:\n%s", diff)
+			}
+
+			if mock.observed != tc.want.observed {
+				t.Errorf("e.Observe(...): want observed %t, got %t", tc.want.observed, mock.observed)
+			}
+			if mock.deleted != tc.want.deleted {
+				t.Errorf("e.Delete(...): want deleted %t, got %t", tc.want.deleted, mock.deleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package protection prevents managed resources and resource claims, and the
+// external resources they represent, from being deleted by accident. A managed
+// resource or claim that is protected from deletion cannot finish being
+// deleted, and its external resource is not deleted, until the protection is
+// removed.
+package protection
+
+import (
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AnnotationKey is the key of the annotation that protects a managed resource,
+// resource claim, or resource class from deletion when its value is "true".
+const AnnotationKey = "crossplane.io/deletion-protection"
+
+// Error strings.
+const (
+	errFmtProtected = "%s is protected from deletion: remove its %s annotation to delete it"
+)
+
+// Enabled returns true if the supplied object is protected from deletion.
+func Enabled(o metav1.Object) bool {
+	return o.GetAnnotations()[AnnotationKey] == "true"
+}
+
+// Set whether the supplied object is protected from deletion.
+func Set(o metav1.Object, enabled bool) {
+	a := o.GetAnnotations()
+	if !enabled {
+		delete(a, AnnotationKey)
+		o.SetAnnotations(a)
+		return
+	}
+	if a == nil {
+		a = map[string]string{}
+	}
+	a[AnnotationKey] = "true"
+	o.SetAnnotations(a)
+}
+
+// Check returns an error if the supplied object is being deleted but is
+// protected from deletion. Controllers must not delete the external resource
+// of, or remove the finalizer from, an object for which Check returns an error.
+func Check(o metav1.Object) error {
+	if o.GetDeletionTimestamp() == nil || !Enabled(o) {
+		return nil
+	}
+	return errors.Errorf(errFmtProtected, o.GetName(), AnnotationKey)
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protection
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+
+	"github.com/crossplaneio/crossplane/gcp/apis/compute/v1alpha1"
+)
+
+func network(protected, deleted bool) *v1alpha1.Network {
+	n := &v1alpha1.Network{}
+	n.SetName("cool")
+	Set(n, protected)
+	if deleted {
+		n.SetDeletionTimestamp(&metav1.Time{})
+	}
+	return n
+}
+
+func TestSet(t *testing.T) {
+	n := &v1alpha1.Network{}
+
+	Set(n, true)
+	if !Enabled(n) {
+		t.Errorf("Set(n, true): Enabled(n) = false, want true")
+	}
+
+	Set(n, false)
+	if Enabled(n) {
+		t.Errorf("Set(n, false): Enabled(n) = true, want false")
+	}
+	if diff := cmp.Diff(map[string]string{}, n.GetAnnotations()); diff != "" {
+		t.Errorf("Set(n, false): -want annotations, +got annotations:\n%s", diff)
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	cases := map[string]struct {
+		annotations map[string]string
+		want        bool
+	}{
+		"NoAnnotations": {
+			want: false,
+		},
+		"False": {
+			annotations: map[string]string{AnnotationKey: "false"},
+			want:        false,
+		},
+		"True": {
+			annotations: map[string]string{AnnotationKey: "true"},
+			want:        true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			n := &v1alpha1.Network{}
+			n.SetAnnotations(tc.annotations)
+			if got := Enabled(n); got != tc.want {
+				t.Errorf("Enabled(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	cases := map[string]struct {
+		o    metav1.Object
+		want error
+	}{
+		"Unprotected": {
+			o:    network(false, true),
+			want: nil,
+		},
+		"ProtectedNotDeleted": {
+			o:    network(true, false),
+			want: nil,
+		},
+		"ProtectedDeleted": {
+			o:    network(true, true),
+			want: errors.Errorf(errFmtProtected, "cool", AnnotationKey),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Check(tc.o)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("Check(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
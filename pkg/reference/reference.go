@@ -0,0 +1,197 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package reference resolves the attributes of managed resources that are
+// specified by reference to other managed resources.
+package reference
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+)
+
+// TypeReferencesResolved managed resources have resolved all of their
+// references to other managed resources.
+const TypeReferencesResolved runtimev1alpha1.ConditionType = "ReferencesResolved"
+
+// Reasons a managed resource's references are or are not resolved.
+const (
+	ReasonReferencesResolved   runtimev1alpha1.ConditionReason = "Successfully resolved managed resource references"
+	ReasonWaitingForReferences runtimev1alpha1.ConditionReason = "Waiting for referenced managed resources to be ready"
+)
+
+// ReferencesResolved returns a condition that indicates a managed resource has
+// resolved all of its references.
+func ReferencesResolved() runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypeReferencesResolved,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonReferencesResolved,
+	}
+}
+
+// WaitingForReferences returns a condition that indicates a managed resource
+// is waiting for the resources it references to exist and become ready.
+func WaitingForReferences(err error) runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypeReferencesResolved,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonWaitingForReferences,
+		Message:            err.Error(),
+	}
+}
+
+// A CanReference managed resource has attributes that may be resolved from
+// other managed resources.
+type CanReference interface {
+	resource.Managed
+
+	// GetReferencers returns an AttributeReferencer for each of the
+	// resource's references. Unset references should be omitted.
+	GetReferencers() []AttributeReferencer
+}
+
+// An AttributeReferencer resolves an attribute of a referencing managed
+// resource from a referenced managed resource.
+type AttributeReferencer interface {
+	// Build returns the value of the referenced attribute. It returns an
+	// error for which IsNotReady returns true if the referenced resource does
+	// not exist or is not yet ready.
+	Build(ctx context.Context, res CanReference, r client.Reader) (string, error)
+
+	// Assign the supplied value to the referencing managed resource.
+	Assign(res CanReference, value string) error
+}
+
+type notReady interface {
+	NotReady() bool
+}
+
+type notReadyError struct {
+	error
+}
+
+func (e notReadyError) NotReady() bool {
+	return true
+}
+
+// NewNotReadyError returns an error indicating that a referenced resource does
+// not exist or is not yet ready.
+func NewNotReadyError(format string, args ...interface{}) error {
+	return notReadyError{errors.Errorf(format, args...)}
+}
+
+// IsNotReady returns true if the supplied error indicates that a referenced
+// resource does not exist or is not yet ready.
+func IsNotReady(err error) bool {
+	nr, ok := errors.Cause(err).(notReady)
+	return ok && nr.NotReady()
+}
+
+// Get the referenced object with the supplied name from the namespace of the
+// referencing resource. It returns an error for which IsNotReady returns true
+// if the object does not exist.
+func Get(ctx context.Context, r client.Reader, res metav1.Object, name string, obj runtime.Object) error {
+	err := r.Get(ctx, types.NamespacedName{Namespace: res.GetNamespace(), Name: name}, obj)
+	if kerrors.IsNotFound(err) {
+		return NewNotReadyError("referenced resource %s does not exist", name)
+	}
+	return errors.Wrapf(err, "cannot get referenced resource %s", name)
+}
+
+// Ready returns true if the supplied status is of a ready managed resource.
+func Ready(s runtimev1alpha1.ConditionedStatus) bool {
+	for _, c := range s.Conditions {
+		if c.Type == runtimev1alpha1.TypeReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// Resolve the references of the supplied managed resource, assigning the
+// value of each referenced attribute to the resource. It returns an error for
+// which IsNotReady returns true if any referenced resource does not exist or
+// is not yet ready.
+func Resolve(ctx context.Context, r client.Reader, res CanReference) error {
+	for _, ref := range res.GetReferencers() {
+		v, err := ref.Build(ctx, res, r)
+		if err != nil {
+			return err
+		}
+		if err := ref.Assign(res, v); err != nil {
+			return errors.Wrap(err, "cannot assign referenced value")
+		}
+	}
+	return nil
+}
+
+// A Connecter resolves the references of a managed resource before connecting
+// to its external system. Resolved values are persisted to the managed
+// resource's spec, so that they remain available if a referenced resource is
+// later deleted. References are not resolved for managed resources that are
+// being deleted, and managed resources without references are not given a
+// ReferencesResolved condition.
+type Connecter struct {
+	resource.ExternalConnecter
+
+	client client.Client
+}
+
+// NewConnecter returns a Connecter that resolves references before calling
+// the supplied ExternalConnecter.
+func NewConnecter(c client.Client, ec resource.ExternalConnecter) *Connecter {
+	return &Connecter{ExternalConnecter: ec, client: c}
+}
+
+// Connect resolves the references of the supplied managed resource, if it has
+// any, then connects to its external system.
+func (c *Connecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	res, ok := mg.(CanReference)
+	if !ok || len(res.GetReferencers()) == 0 || mg.GetDeletionTimestamp() != nil {
+		return c.ExternalConnecter.Connect(ctx, mg)
+	}
+
+	existing := res.DeepCopyObject()
+	if err := Resolve(ctx, c.client, res); err != nil {
+		if IsNotReady(err) {
+			res.SetConditions(WaitingForReferences(err))
+		}
+		return nil, err
+	}
+
+	if !reflect.DeepEqual(existing, res) {
+		if err := c.client.Update(ctx, res); err != nil {
+			return nil, errors.Wrap(err, "cannot update managed resource with resolved references")
+		}
+	}
+	res.SetConditions(ReferencesResolved())
+
+	return c.ExternalConnecter.Connect(ctx, mg)
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reference
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+)
+
+var errBoom = errors.New("boom")
+
+var _ CanReference = &mockReferencing{}
+
+type mockReferencing struct {
+	resource.Managed
+
+	value      string
+	conditions []runtimev1alpha1.Condition
+	referencer AttributeReferencer
+	deleted    *metav1.Time
+}
+
+func (m *mockReferencing) GetReferencers() []AttributeReferencer {
+	if m.referencer == nil {
+		return nil
+	}
+	return []AttributeReferencer{m.referencer}
+}
+
+func (m *mockReferencing) SetConditions(c ...runtimev1alpha1.Condition) { m.conditions = c }
+func (m *mockReferencing) GetDeletionTimestamp() *metav1.Time           { return m.deleted }
+func (m *mockReferencing) GetNamespace() string                         { return "cool-namespace" }
+
+func (m *mockReferencing) DeepCopyObject() runtime.Object {
+	c := *m
+	return &c
+}
+
+type mockReferencer struct {
+	value string
+	err   error
+}
+
+func (r mockReferencer) Build(_ context.Context, _ CanReference, _ client.Reader) (string, error) {
+	return r.value, r.err
+}
+
+func (r mockReferencer) Assign(res CanReference, value string) error {
+	res.(*mockReferencing).value = value
+	return nil
+}
+
+type mockConnecter struct {
+	err error
+}
+
+func (c mockConnecter) Connect(_ context.Context, _ resource.Managed) (resource.ExternalClient, error) {
+	return nil, c.err
+}
+
+func TestIsNotReady(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want bool
+	}{
+		"NotReady":        {err: NewNotReadyError("not ready"), want: true},
+		"WrappedNotReady": {err: errors.Wrap(NewNotReadyError("not ready"), "wrapped"), want: true},
+		"OtherError":      {err: errBoom, want: false},
+		"NilError":        {err: nil, want: false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := IsNotReady(tc.err); got != tc.want {
+				t.Errorf("IsNotReady(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestReady(t *testing.T) {
+	cases := map[string]struct {
+		s    runtimev1alpha1.ConditionedStatus
+		want bool
+	}{
+		"Available":   {s: runtimev1alpha1.ConditionedStatus{Conditions: []runtimev1alpha1.Condition{runtimev1alpha1.Available()}}, want: true},
+		"Creating":    {s: runtimev1alpha1.ConditionedStatus{Conditions: []runtimev1alpha1.Condition{runtimev1alpha1.Creating()}}, want: false},
+		"NoCondition": {s: runtimev1alpha1.ConditionedStatus{}, want: false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := Ready(tc.s); got != tc.want {
+				t.Errorf("Ready(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestConnect(t *testing.T) {
+	type want struct {
+		value     string
+		condition *runtimev1alpha1.Condition
+		err       error
+	}
+
+	resolved := ReferencesResolved()
+	waiting := WaitingForReferences(NewNotReadyError("not ready"))
+
+	cases := map[string]struct {
+		c    *Connecter
+		mg   *mockReferencing
+		want want
+	}{
+		"NoReferences": {
+			c:    NewConnecter(&test.MockClient{}, mockConnecter{}),
+			mg:   &mockReferencing{},
+			want: want{},
+		},
+		"Resolved": {
+			c: NewConnecter(&test.MockClient{MockUpdate: func(_ context.Context, _ runtime.Object, _ ...client.UpdateOption) error {
+				return nil
+			}}, mockConnecter{}),
+			mg:   &mockReferencing{referencer: mockReferencer{value: "cool"}},
+			want: want{value: "cool", condition: &resolved},
+		},
+		"AlreadyResolved": {
+			c: NewConnecter(&test.MockClient{MockUpdate: func(_ context.Context, _ runtime.Object, _ ...client.UpdateOption) error {
+				return errors.New("unexpected update")
+			}}, mockConnecter{}),
+			mg:   &mockReferencing{value: "cool", referencer: mockReferencer{value: "cool"}},
+			want: want{value: "cool", condition: &resolved},
+		},
+		"NotReady": {
+			c:    NewConnecter(&test.MockClient{}, mockConnecter{}),
+			mg:   &mockReferencing{referencer: mockReferencer{err: NewNotReadyError("not ready")}},
+			want: want{condition: &waiting, err: NewNotReadyError("not ready")},
+		},
+		"BuildFailed": {
+			c:    NewConnecter(&test.MockClient{}, mockConnecter{}),
+			mg:   &mockReferencing{referencer: mockReferencer{err: errBoom}},
+			want: want{err: errBoom},
+		},
+		"UpdateFailed": {
+			c: NewConnecter(&test.MockClient{MockUpdate: func(_ context.Context, _ runtime.Object, _ ...client.UpdateOption) error {
+				return errBoom
+			}}, mockConnecter{}),
+			mg:   &mockReferencing{referencer: mockReferencer{value: "cool"}},
+			want: want{value: "cool", err: errors.Wrap(errBoom, "cannot update managed resource with resolved references")},
+		},
+		"Deleted": {
+			c:    NewConnecter(&test.MockClient{}, mockConnecter{}),
+			mg:   &mockReferencing{referencer: mockReferencer{err: errBoom}, deleted: &metav1.Time{}},
+			want: want{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.c.Connect(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("c.Connect(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.value, tc.mg.value); diff != "" {
+				t.Errorf("c.Connect(...): -want value, +got value:\n%s", diff)
+			}
+			var got *runtimev1alpha1.Condition
+			if len(tc.mg.conditions) > 0 {
+				got = &tc.mg.conditions[0]
+			}
+			if diff := cmp.Diff(tc.want.condition, got, test.EquateConditions()); diff != "" {
+				t.Errorf("c.Connect(...): -want condition, +got condition:\n%s", diff)
+			}
+		})
+	}
+}
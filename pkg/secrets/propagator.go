@@ -0,0 +1,228 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package secrets propagates typed connection secrets from managed resources
+// to the resource claims they are bound to.
+package secrets
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	corev1alpha1 "github.com/crossplaneio/crossplane/apis/core/v1alpha1"
+	awsdbv1alpha1 "github.com/crossplaneio/crossplane/aws/apis/database/v1alpha1"
+	azuredbv1alpha1 "github.com/crossplaneio/crossplane/azure/apis/database/v1alpha1"
+	gcpdbv1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/database/v1alpha1"
+)
+
+// Labels with which a database user managed resource names the resource claim
+// that may use its credentials.
+const (
+	LabelKeyClaimNamespace = "crossplane.io/claim-namespace"
+	LabelKeyClaimName      = "crossplane.io/claim-name"
+)
+
+// Error strings.
+const (
+	errGetSecret          = "cannot get managed resource's connection secret"
+	errFmtGetDefinition   = "cannot get custom secret definition %s"
+	errFmtInvalidSecret   = "connection secret does not satisfy custom secret definition %s"
+	errGetClaimSecret     = "cannot get resource claim's connection secret"
+	errCreateClaimSecret  = "cannot create resource claim's connection secret"
+	errUpdateClaimSecret  = "cannot update resource claim's connection secret"
+	errDeleteClaimSecret  = "cannot delete resource claim's connection secret"
+	errClaimSecretControl = "resource claim's connection secret is controlled by another object"
+	errUserKind           = "resource claim's referenced user is not a database user"
+	errGetUser            = "cannot get resource claim's referenced user"
+	errUserClaim          = "resource claim's referenced user is not labelled for use by the resource claim"
+	errUserInstance       = "resource claim's referenced user does not belong to the bound managed resource"
+	errGetUserSecret      = "cannot get connection secret of resource claim's referenced user"
+	errUserSecretNotReady = "connection secret of resource claim's referenced user does not yet contain a password"
+)
+
+// userKinds are the kinds of database user managed resource that a resource
+// claim may reference.
+var userKinds = map[schema.GroupVersionKind]bool{
+	awsdbv1alpha1.RDSUserGroupVersionKind:         true,
+	azuredbv1alpha1.SQLServerUserGroupVersionKind: true,
+	gcpdbv1alpha1.CloudsqlUserGroupVersionKind:    true,
+}
+
+// A UserReferencer may reference a database user managed resource, for
+// example an RDSUser, whose credentials should be propagated to the resource
+// claim in place of those of the managed resource the claim is bound to.
+type UserReferencer interface {
+	GetUserReference() *corev1.ObjectReference
+}
+
+// An APIConnectionPropagator propagates the connection secret of a managed
+// resource to the resource claim it is bound to. The claim's connection secret
+// is typed and validated according to a CustomSecretDefinition.
+type APIConnectionPropagator struct {
+	client     client.Client
+	kind       schema.GroupVersionKind
+	definition string
+}
+
+// NewAPIConnectionPropagator returns an APIConnectionPropagator that
+// propagates connection secrets to resource claims of the supplied kind. The
+// propagated secrets must satisfy the named CustomSecretDefinition.
+func NewAPIConnectionPropagator(c client.Client, k resource.ClaimKind, definition string) *APIConnectionPropagator {
+	return &APIConnectionPropagator{client: c, kind: schema.GroupVersionKind(k), definition: definition}
+}
+
+// PropagateConnection details from the supplied managed resource to the
+// supplied resource claim. Connection details that do not satisfy the claim
+// kind's CustomSecretDefinition are not propagated; the returned error is
+// reflected in the claim's conditions.
+func (a *APIConnectionPropagator) PropagateConnection(ctx context.Context, cm resource.Claim, mg resource.Managed) error {
+	// Either this is a managed resource that doesn't write a connection
+	// secret, or it hasn't written one yet.
+	if mg.GetWriteConnectionSecretToReference().Name == "" {
+		return nil
+	}
+
+	mgcs := &corev1.Secret{}
+	n := types.NamespacedName{Namespace: mg.GetNamespace(), Name: mg.GetWriteConnectionSecretToReference().Name}
+	if err := a.client.Get(ctx, n, mgcs); err != nil {
+		return errors.Wrap(err, errGetSecret)
+	}
+
+	csd := &corev1alpha1.CustomSecretDefinition{}
+	if err := a.client.Get(ctx, types.NamespacedName{Name: a.definition}, csd); err != nil {
+		return errors.Wrapf(err, errFmtGetDefinition, a.definition)
+	}
+
+	data := mgcs.Data
+	if ur, ok := cm.(UserReferencer); ok && ur.GetUserReference() != nil {
+		d, err := a.withUser(ctx, ur.GetUserReference(), cm, mg, data)
+		if err != nil {
+			return err
+		}
+		data = d
+	}
+
+	want := resource.ConnectionSecretFor(cm, a.kind)
+	want.Type = csd.SecretType()
+	want.Data = data
+	if err := csd.Validate(want); err != nil {
+		return errors.Wrapf(err, errFmtInvalidSecret, a.definition)
+	}
+
+	got := &corev1.Secret{}
+	err := a.client.Get(ctx, types.NamespacedName{Namespace: want.GetNamespace(), Name: want.GetName()}, got)
+	if kerrors.IsNotFound(err) {
+		return errors.Wrap(a.client.Create(ctx, want), errCreateClaimSecret)
+	}
+	if err != nil {
+		return errors.Wrap(err, errGetClaimSecret)
+	}
+
+	if c := metav1.GetControllerOf(got); c == nil || c.UID != cm.GetUID() {
+		return errors.New(errClaimSecretControl)
+	}
+
+	// A secret's type cannot be changed once it is created, so we recreate
+	// secrets that were propagated before their claim kind was typed.
+	if got.Type != want.Type {
+		if err := a.client.Delete(ctx, got); resource.Ignore(kerrors.IsNotFound, err) != nil {
+			return errors.Wrap(err, errDeleteClaimSecret)
+		}
+		return errors.Wrap(a.client.Create(ctx, want), errCreateClaimSecret)
+	}
+
+	got.Data = want.Data
+	return errors.Wrap(a.client.Update(ctx, got), errUpdateClaimSecret)
+}
+
+// withUser returns a copy of the supplied connection data in which the
+// username and password are those of the referenced user. The user must be a
+// database user in the same namespace as the supplied managed resource, must
+// be labelled with the namespace and name of the supplied resource claim, and
+// must have been created in the instance or server that the managed resource
+// either is or references.
+func (a *APIConnectionPropagator) withUser(ctx context.Context, ref *corev1.ObjectReference, cm resource.Claim, mg resource.Managed, data map[string][]byte) (map[string][]byte, error) {
+	gvk := schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind)
+	if !userKinds[gvk] {
+		return nil, errors.New(errUserKind)
+	}
+
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	if err := a.client.Get(ctx, types.NamespacedName{Namespace: mg.GetNamespace(), Name: ref.Name}, u); err != nil {
+		return nil, errors.Wrap(err, errGetUser)
+	}
+
+	// Users live alongside the instance or server, typically in a namespace
+	// that claims of many applications may bind to. A claim may only use a
+	// user that has been explicitly set aside for it.
+	l := u.GetLabels()
+	if l[LabelKeyClaimNamespace] != cm.GetNamespace() || l[LabelKeyClaimName] != cm.GetName() {
+		return nil, errors.New(errUserClaim)
+	}
+
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errUserInstance)
+	}
+	instance := parentOf(m)
+	if instance == "" {
+		instance = mg.GetName()
+	}
+	if parentOf(u.Object) != instance {
+		return nil, errors.New(errUserInstance)
+	}
+
+	secret, _, _ := unstructured.NestedString(u.Object, "spec", "writeConnectionSecretToRef", "name")
+	us := &corev1.Secret{}
+	if err := a.client.Get(ctx, types.NamespacedName{Namespace: mg.GetNamespace(), Name: secret}, us); err != nil {
+		return nil, errors.Wrap(err, errGetUserSecret)
+	}
+	if len(us.Data[runtimev1alpha1.ResourceCredentialsSecretPasswordKey]) == 0 {
+		return nil, errors.New(errUserSecretNotReady)
+	}
+
+	out := make(map[string][]byte, len(data))
+	for k, v := range data {
+		out[k] = v
+	}
+	out[runtimev1alpha1.ResourceCredentialsSecretUserKey] = us.Data[runtimev1alpha1.ResourceCredentialsSecretUserKey]
+	out[runtimev1alpha1.ResourceCredentialsSecretPasswordKey] = us.Data[runtimev1alpha1.ResourceCredentialsSecretPasswordKey]
+	return out, nil
+}
+
+// parentOf returns the name of the instance or server referenced by the
+// supplied unstructured managed resource, if any. Database users and logical
+// databases reference the instance or server they are created in.
+func parentOf(o map[string]interface{}) string {
+	for _, ref := range []string{"instanceRef", "serverRef"} {
+		if n, _, _ := unstructured.NestedString(o, "spec", ref, "name"); n != "" {
+			return n
+		}
+	}
+	return ""
+}
@@ -0,0 +1,347 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	corev1alpha1 "github.com/crossplaneio/crossplane/apis/core/v1alpha1"
+	databasev1alpha1 "github.com/crossplaneio/crossplane/apis/database/v1alpha1"
+	awsdatabasev1alpha1 "github.com/crossplaneio/crossplane/aws/apis/database/v1alpha1"
+)
+
+const (
+	namespace     = "cool-namespace"
+	claimUID      = types.UID("cool-uid")
+	claimSecret   = "cool-claim-secret"
+	managedSecret = "cool-managed-secret"
+	userSecret    = "cool-user-secret"
+	definition    = "mysql.database.crossplane.io"
+	secretType    = corev1.SecretType("mysql.v1alpha1.database.crossplane.io")
+)
+
+var errBoom = errors.New("boom")
+
+func claim() *databasev1alpha1.MySQLInstance {
+	cm := &databasev1alpha1.MySQLInstance{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "cool-claim", UID: claimUID}}
+	cm.SetWriteConnectionSecretToReference(corev1.LocalObjectReference{Name: claimSecret})
+	return cm
+}
+
+func managed(secret string) *awsdatabasev1alpha1.RDSInstance {
+	mg := &awsdatabasev1alpha1.RDSInstance{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "cool-managed"}}
+	mg.SetWriteConnectionSecretToReference(corev1.LocalObjectReference{Name: secret})
+	return mg
+}
+
+func csd() *corev1alpha1.CustomSecretDefinition {
+	return &corev1alpha1.CustomSecretDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: definition},
+		Spec: corev1alpha1.CustomSecretDefinitionSpec{
+			Group:   "database.crossplane.io",
+			Names:   corev1alpha1.CustomSecretDefinitionNames{Kind: "mysql"},
+			Version: "v1alpha1",
+			Validation: corev1alpha1.CustomSecretDefinitionValidation{
+				OpenAPIV3Schema: corev1alpha1.SecretSchema{
+					Properties: map[string]corev1alpha1.SecretKeySchema{"username": {}, "password": {}},
+					Required:   []string{"username", "password"},
+				},
+			},
+		},
+	}
+}
+
+var connectionDetails = map[string][]byte{"username": []byte("cool-user"), "password": []byte("cool-password")}
+
+func claimWithUser() *databasev1alpha1.MySQLInstance {
+	cm := claim()
+	cm.Spec.UserReference = &corev1.ObjectReference{
+		APIVersion: awsdatabasev1alpha1.SchemeGroupVersion.String(),
+		Kind:       awsdatabasev1alpha1.RDSUserKind,
+		Name:       "cool-rdsuser",
+	}
+	return cm
+}
+
+func user(instance string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetLabels(map[string]string{LabelKeyClaimNamespace: namespace, LabelKeyClaimName: "cool-claim"})
+	_ = unstructured.SetNestedField(u.Object, instance, "spec", "instanceRef", "name")
+	_ = unstructured.SetNestedField(u.Object, userSecret, "spec", "writeConnectionSecretToRef", "name")
+	return u
+}
+
+// getWithUser returns a MockGetFn that behaves like get, but also returns the
+// supplied user and its connection secret.
+func getWithUser(mgcs, cmcs *corev1.Secret, u *unstructured.Unstructured, ucs *corev1.Secret) test.MockGetFn {
+	fn := get(mgcs, cmcs)
+	return func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+		switch o := obj.(type) {
+		case *unstructured.Unstructured:
+			if u == nil {
+				return errBoom
+			}
+			o.Object = u.Object
+			return nil
+		case *corev1.Secret:
+			if key.Name == userSecret {
+				*o = *ucs
+				return nil
+			}
+		}
+		return fn(ctx, key, obj)
+	}
+}
+
+// get returns a MockGetFn that returns the supplied managed resource connection
+// secret and claim connection secret. A nil claim secret is not found.
+func get(mgcs, cmcs *corev1.Secret) test.MockGetFn {
+	return func(_ context.Context, key client.ObjectKey, obj runtime.Object) error {
+		switch o := obj.(type) {
+		case *corev1alpha1.CustomSecretDefinition:
+			*o = *csd()
+		case *corev1.Secret:
+			switch key.Name {
+			case managedSecret:
+				*o = *mgcs
+			case claimSecret:
+				if cmcs == nil {
+					return kerrors.NewNotFound(schema.GroupResource{}, claimSecret)
+				}
+				*o = *cmcs
+			}
+		}
+		return nil
+	}
+}
+
+func TestPropagateConnection(t *testing.T) {
+	controlled := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       namespace,
+			Name:            claimSecret,
+			OwnerReferences: []metav1.OwnerReference{{UID: claimUID, Controller: func() *bool { c := true; return &c }()}},
+		},
+		Type: secretType,
+	}
+
+	userDetails := map[string][]byte{"username": []byte("app-user"), "password": []byte("app-password")}
+
+	type args struct {
+		kube client.Client
+		cm   resource.Claim
+		mg   resource.Managed
+	}
+
+	cases := map[string]struct {
+		args args
+		want error
+	}{
+		"NoConnectionSecret": {
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+				mg:   managed(""),
+			},
+		},
+		"GetManagedSecretError": {
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+				mg:   managed(managedSecret),
+			},
+			want: errors.Wrap(errBoom, errGetSecret),
+		},
+		"GetDefinitionError": {
+			args: args{
+				kube: &test.MockClient{MockGet: func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+					if _, ok := obj.(*corev1alpha1.CustomSecretDefinition); ok {
+						return errBoom
+					}
+					return nil
+				}},
+				mg: managed(managedSecret),
+			},
+			want: errors.Wrapf(errBoom, errFmtGetDefinition, definition),
+		},
+		"InvalidSecret": {
+			args: args{
+				kube: &test.MockClient{MockGet: get(&corev1.Secret{Data: map[string][]byte{"username": []byte("cool-user")}}, nil)},
+				mg:   managed(managedSecret),
+			},
+			want: errors.Wrapf(errors.New("connection secret is missing required keys: password"), errFmtInvalidSecret, definition),
+		},
+		"CreateSecret": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet: get(&corev1.Secret{Data: connectionDetails}, nil),
+					MockCreate: func(_ context.Context, obj runtime.Object, _ ...client.CreateOption) error {
+						if s := obj.(*corev1.Secret); s.Type != secretType {
+							t.Errorf("Create(...): want type %s, got %s", secretType, s.Type)
+						}
+						return nil
+					},
+				},
+				mg: managed(managedSecret),
+			},
+		},
+		"CreateSecretError": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet:    get(&corev1.Secret{Data: connectionDetails}, nil),
+					MockCreate: func(_ context.Context, _ runtime.Object, _ ...client.CreateOption) error { return errBoom },
+				},
+				mg: managed(managedSecret),
+			},
+			want: errors.Wrap(errBoom, errCreateClaimSecret),
+		},
+		"SecretControlledByAnother": {
+			args: args{
+				kube: &test.MockClient{MockGet: get(&corev1.Secret{Data: connectionDetails}, &corev1.Secret{})},
+				mg:   managed(managedSecret),
+			},
+			want: errors.New(errClaimSecretControl),
+		},
+		"RecreateUntypedSecret": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet: get(&corev1.Secret{Data: connectionDetails}, func() *corev1.Secret {
+						s := controlled.DeepCopy()
+						s.Type = corev1.SecretTypeOpaque
+						return s
+					}()),
+					MockDelete: test.NewMockDeleteFn(nil),
+					MockCreate: func(_ context.Context, _ runtime.Object, _ ...client.CreateOption) error { return nil },
+				},
+				mg: managed(managedSecret),
+			},
+		},
+		"UpdateSecret": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet: get(&corev1.Secret{Data: connectionDetails}, controlled),
+					MockUpdate: func(_ context.Context, obj runtime.Object, _ ...client.UpdateOption) error {
+						if diff := cmp.Diff(connectionDetails, obj.(*corev1.Secret).Data); diff != "" {
+							t.Errorf("Update(...): -want, +got:\n%s", diff)
+						}
+						return nil
+					},
+				},
+				mg: managed(managedSecret),
+			},
+		},
+		"UserOfUnsupportedKind": {
+			args: args{
+				kube: &test.MockClient{MockGet: getWithUser(&corev1.Secret{Data: connectionDetails}, controlled, user("cool-managed"), nil)},
+				cm: func() resource.Claim {
+					cm := claimWithUser()
+					cm.Spec.UserReference.APIVersion = "v1"
+					cm.Spec.UserReference.Kind = "Secret"
+					return cm
+				}(),
+				mg: managed(managedSecret),
+			},
+			want: errors.New(errUserKind),
+		},
+		"GetUserError": {
+			args: args{
+				kube: &test.MockClient{MockGet: getWithUser(&corev1.Secret{Data: connectionDetails}, controlled, nil, nil)},
+				cm:   claimWithUser(),
+				mg:   managed(managedSecret),
+			},
+			want: errors.Wrap(errBoom, errGetUser),
+		},
+		"UserOfAnotherClaim": {
+			args: args{
+				kube: &test.MockClient{MockGet: getWithUser(&corev1.Secret{Data: connectionDetails}, controlled, func() *unstructured.Unstructured {
+					u := user("cool-managed")
+					u.SetLabels(map[string]string{LabelKeyClaimNamespace: namespace, LabelKeyClaimName: "other-claim"})
+					return u
+				}(), nil)},
+				cm: claimWithUser(),
+				mg: managed(managedSecret),
+			},
+			want: errors.New(errUserClaim),
+		},
+		"UserOfAnotherInstance": {
+			args: args{
+				kube: &test.MockClient{MockGet: getWithUser(&corev1.Secret{Data: connectionDetails}, controlled, user("other-instance"), nil)},
+				cm:   claimWithUser(),
+				mg:   managed(managedSecret),
+			},
+			want: errors.New(errUserInstance),
+		},
+		"UserSecretNotReady": {
+			args: args{
+				kube: &test.MockClient{MockGet: getWithUser(&corev1.Secret{Data: connectionDetails}, controlled, user("cool-managed"), &corev1.Secret{})},
+				cm:   claimWithUser(),
+				mg:   managed(managedSecret),
+			},
+			want: errors.New(errUserSecretNotReady),
+		},
+		"UpdateSecretWithUser": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet: getWithUser(&corev1.Secret{Data: map[string][]byte{
+						"username": []byte("master"),
+						"password": []byte("master-password"),
+						"endpoint": []byte("cool-endpoint"),
+					}}, controlled, user("cool-managed"), &corev1.Secret{Data: userDetails}),
+					MockUpdate: func(_ context.Context, obj runtime.Object, _ ...client.UpdateOption) error {
+						want := map[string][]byte{
+							"username": []byte("app-user"),
+							"password": []byte("app-password"),
+							"endpoint": []byte("cool-endpoint"),
+						}
+						if diff := cmp.Diff(want, obj.(*corev1.Secret).Data); diff != "" {
+							t.Errorf("Update(...): -want, +got:\n%s", diff)
+						}
+						return nil
+					},
+				},
+				cm: claimWithUser(),
+				mg: managed(managedSecret),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cm := tc.args.cm
+			if cm == nil {
+				cm = claim()
+			}
+			p := NewAPIConnectionPropagator(tc.args.kube, resource.ClaimKind(databasev1alpha1.MySQLInstanceGroupVersionKind), definition)
+			err := p.PropagateConnection(context.Background(), cm, tc.args.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("p.PropagateConnection(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
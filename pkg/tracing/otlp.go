@@ -0,0 +1,359 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/logging"
+)
+
+var log = logging.Logger.WithName("tracing")
+
+// DefaultOTLPEndpoint is the URL at which an OpenTelemetry Collector receives
+// spans over OTLP/HTTP by default.
+const DefaultOTLPEndpoint = "http://localhost:4318/v1/traces"
+
+// OTLPExporter defaults.
+const (
+	defaultOTLPInterval  = 5 * time.Second
+	defaultOTLPBatchSize = 512
+	defaultOTLPTimeout   = 10 * time.Second
+
+	// Spans are dropped rather than queued without bound while the OTLP
+	// endpoint is unreachable.
+	maxOTLPQueuedBatches = 4
+)
+
+// OTLP span kinds and status codes.
+const (
+	otlpSpanKindInternal = 1
+	otlpSpanKindServer   = 2
+	otlpSpanKindClient   = 3
+
+	otlpStatusCodeError = 2
+)
+
+// An OTLPExporter exports spans to an OTLP/HTTP endpoint, such as an
+// OpenTelemetry Collector or a tracing backend that accepts OTLP. Spans are
+// exported in batches, each encoded as a JSON ExportTraceServiceRequest.
+type OTLPExporter struct {
+	endpoint  string
+	headers   map[string]string
+	service   string
+	client    *http.Client
+	interval  time.Duration
+	batchSize int
+
+	mu      sync.Mutex
+	spans   []otlpSpan
+	dropped int
+
+	full chan struct{}
+	stop chan struct{}
+	done chan struct{}
+}
+
+// An OTLPExporterOption configures an OTLPExporter.
+type OTLPExporterOption func(*OTLPExporter)
+
+// WithOTLPHeaders configures the HTTP headers sent with each export, for
+// example to authenticate to a tracing backend.
+func WithOTLPHeaders(h map[string]string) OTLPExporterOption {
+	return func(e *OTLPExporter) { e.headers = h }
+}
+
+// WithOTLPServiceName configures the name of the service that recorded the
+// exported spans.
+func WithOTLPServiceName(n string) OTLPExporterOption {
+	return func(e *OTLPExporter) { e.service = n }
+}
+
+// WithOTLPInterval configures the interval at which spans are exported.
+// Spans are exported sooner when a full batch is waiting.
+func WithOTLPInterval(d time.Duration) OTLPExporterOption {
+	return func(e *OTLPExporter) { e.interval = d }
+}
+
+// NewOTLPExporter returns an OTLPExporter that exports spans to the supplied
+// OTLP/HTTP traces endpoint, for example http://localhost:4318/v1/traces. The
+// exporter exports spans until it is stopped.
+func NewOTLPExporter(endpoint string, o ...OTLPExporterOption) (*OTLPExporter, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot parse OTLP endpoint %s", endpoint)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.Errorf("OTLP endpoint %s must be an http or https URL", endpoint)
+	}
+
+	e := &OTLPExporter{
+		endpoint:  endpoint,
+		client:    &http.Client{Timeout: defaultOTLPTimeout},
+		interval:  defaultOTLPInterval,
+		batchSize: defaultOTLPBatchSize,
+		full:      make(chan struct{}, 1),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	for _, fn := range o {
+		fn(e)
+	}
+
+	go e.run()
+	return e, nil
+}
+
+func (e *OTLPExporter) run() {
+	defer close(e.done)
+
+	t := time.NewTicker(e.interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-t.C:
+			e.Flush()
+		case <-e.full:
+			e.Flush()
+		case <-e.stop:
+			e.Flush()
+			return
+		}
+	}
+}
+
+// ExportSpan queues the supplied span for export.
+func (e *OTLPExporter) ExportSpan(sd *trace.SpanData) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if len(e.spans) >= e.batchSize*maxOTLPQueuedBatches {
+		e.dropped++
+		return
+	}
+	e.spans = append(e.spans, newOTLPSpan(sd))
+
+	if len(e.spans) >= e.batchSize {
+		select {
+		case e.full <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Flush exports all queued spans.
+func (e *OTLPExporter) Flush() {
+	e.mu.Lock()
+	spans, dropped := e.spans, e.dropped
+	e.spans, e.dropped = nil, 0
+	e.mu.Unlock()
+
+	if dropped > 0 {
+		log.V(logging.Debug).Info("dropped spans while OTLP endpoint was unreachable", "endpoint", e.endpoint, "spans", dropped)
+	}
+
+	for len(spans) > 0 {
+		n := e.batchSize
+		if n > len(spans) {
+			n = len(spans)
+		}
+		if err := e.export(spans[:n]); err != nil {
+			log.V(logging.Debug).Info("cannot export spans", "endpoint", e.endpoint, "spans", n, "error", err)
+		}
+		spans = spans[n:]
+	}
+}
+
+// Stop exports all queued spans, then stops the exporter. Spans must not be
+// exported after the exporter is stopped.
+func (e *OTLPExporter) Stop() {
+	close(e.stop)
+	<-e.done
+}
+
+func (e *OTLPExporter) export(spans []otlpSpan) error {
+	body, err := json.Marshal(otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource: otlpResource{Attributes: otlpAttributes(map[string]interface{}{"service.name": e.service})},
+		ScopeSpans: []otlpScopeSpans{{
+			Scope: otlpScope{Name: "github.com/crossplaneio/crossplane/pkg/tracing"},
+			Spans: spans,
+		}},
+	}}})
+	if err != nil {
+		return errors.Wrap(err, "cannot encode spans")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOTLPTimeout)
+	defer cancel()
+	req, err := http.NewRequest(http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "cannot create export request")
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+
+	rsp, err := e.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "cannot send export request")
+	}
+	defer rsp.Body.Close() // nolint:errcheck
+
+	// The response body is drained so that the connection may be reused.
+	msg, _ := ioutil.ReadAll(io.LimitReader(rsp.Body, 1024))
+	if rsp.StatusCode < 200 || rsp.StatusCode > 299 {
+		return errors.Errorf("export request failed with status %s: %s", rsp.Status, msg)
+	}
+	return nil
+}
+
+// The following types encode an OTLP ExportTraceServiceRequest using the JSON
+// encoding of OTLP/HTTP, in which trace and span IDs are hex encoded and 64
+// bit integers are encoded as strings.
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano uint64         `json:"startTimeUnixNano,string"`
+	EndTimeUnixNano   uint64         `json:"endTimeUnixNano,string"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Events            []otlpEvent    `json:"events,omitempty"`
+	Status            *otlpStatus    `json:"status,omitempty"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano uint64         `json:"timeUnixNano,string"`
+	Name         string         `json:"name"`
+	Attributes   []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *int64   `json:"intValue,string,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+func newOTLPSpan(sd *trace.SpanData) otlpSpan {
+	s := otlpSpan{
+		TraceID:           hex.EncodeToString(sd.TraceID[:]),
+		SpanID:            hex.EncodeToString(sd.SpanID[:]),
+		Name:              sd.Name,
+		Kind:              otlpSpanKindInternal,
+		StartTimeUnixNano: uint64(sd.StartTime.UnixNano()),
+		EndTimeUnixNano:   uint64(sd.EndTime.UnixNano()),
+		Attributes:        otlpAttributes(sd.Attributes),
+	}
+	if sd.ParentSpanID != (trace.SpanID{}) {
+		s.ParentSpanID = hex.EncodeToString(sd.ParentSpanID[:])
+	}
+	switch sd.SpanKind {
+	case trace.SpanKindServer:
+		s.Kind = otlpSpanKindServer
+	case trace.SpanKindClient:
+		s.Kind = otlpSpanKindClient
+	}
+	for _, a := range sd.Annotations {
+		s.Events = append(s.Events, otlpEvent{TimeUnixNano: uint64(a.Time.UnixNano()), Name: a.Message, Attributes: otlpAttributes(a.Attributes)})
+	}
+	// OpenCensus status codes are gRPC codes, of which only OK (0) is not an
+	// error. OTLP spans with an unset status are considered successful.
+	if sd.Code != 0 {
+		s.Status = &otlpStatus{Code: otlpStatusCodeError, Message: sd.Message}
+	}
+	return s
+}
+
+func otlpAttributes(attrs map[string]interface{}) []otlpKeyValue {
+	if len(attrs) == 0 {
+		return nil
+	}
+	kvs := make([]otlpKeyValue, 0, len(attrs))
+	for k, v := range attrs {
+		kvs = append(kvs, otlpKeyValue{Key: k, Value: otlpValue(v)})
+	}
+	return kvs
+}
+
+func otlpValue(v interface{}) otlpAnyValue {
+	switch t := v.(type) {
+	case string:
+		return otlpAnyValue{StringValue: &t}
+	case bool:
+		return otlpAnyValue{BoolValue: &t}
+	case int64:
+		return otlpAnyValue{IntValue: &t}
+	case int:
+		i := int64(t)
+		return otlpAnyValue{IntValue: &i}
+	case float64:
+		return otlpAnyValue{DoubleValue: &t}
+	}
+	s := fmt.Sprint(v)
+	return otlpAnyValue{StringValue: &s}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+)
+
+func TestNewOTLPExporter(t *testing.T) {
+	cases := map[string]struct {
+		endpoint string
+		wantErr  error
+	}{
+		"HTTP": {
+			endpoint: DefaultOTLPEndpoint,
+		},
+		"HTTPS": {
+			endpoint: "https://otlp.example.org/v1/traces",
+		},
+		"NotAURL": {
+			endpoint: "localhost:4318",
+			wantErr:  errors.New("OTLP endpoint localhost:4318 must be an http or https URL"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e, err := NewOTLPExporter(tc.endpoint)
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("NewOTLPExporter(...): -want error, +got error:\n%s", diff)
+			}
+			if e != nil {
+				e.Stop()
+			}
+		})
+	}
+}
+
+func TestOTLPExporter(t *testing.T) {
+	start := time.Date(2019, 8, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Second)
+	service, name, exists, throttled := "crossplane", "cool", true, int64(3)
+	startNano, endNano := uint64(start.UnixNano()), uint64(end.UnixNano())
+
+	sd := []*trace.SpanData{
+		{
+			SpanContext: trace.SpanContext{TraceID: trace.TraceID{1}, SpanID: trace.SpanID{2}},
+			SpanKind:    trace.SpanKindServer,
+			Name:        "cool/Reconcile",
+			StartTime:   start,
+			EndTime:     end,
+			Attributes:  map[string]interface{}{AttributeName: name},
+		},
+		{
+			SpanContext:  trace.SpanContext{TraceID: trace.TraceID{1}, SpanID: trace.SpanID{3}},
+			ParentSpanID: trace.SpanID{2},
+			Name:         "cool/Observe",
+			StartTime:    start,
+			EndTime:      end,
+			Attributes:   map[string]interface{}{AttributeResourceExists: exists},
+			Annotations: []trace.Annotation{{
+				Time:       start,
+				Message:    "Waited for rate limiter",
+				Attributes: map[string]interface{}{AttributeThrottled: throttled},
+			}},
+			Status: trace.Status{Code: 2, Message: "boom"},
+		},
+	}
+
+	want := otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource: otlpResource{Attributes: []otlpKeyValue{{Key: "service.name", Value: otlpAnyValue{StringValue: &service}}}},
+		ScopeSpans: []otlpScopeSpans{{
+			Scope: otlpScope{Name: "github.com/crossplaneio/crossplane/pkg/tracing"},
+			Spans: []otlpSpan{
+				{
+					TraceID:           "01000000000000000000000000000000",
+					SpanID:            "0200000000000000",
+					Name:              "cool/Reconcile",
+					Kind:              otlpSpanKindServer,
+					StartTimeUnixNano: startNano,
+					EndTimeUnixNano:   endNano,
+					Attributes:        []otlpKeyValue{{Key: AttributeName, Value: otlpAnyValue{StringValue: &name}}},
+				},
+				{
+					TraceID:           "01000000000000000000000000000000",
+					SpanID:            "0300000000000000",
+					ParentSpanID:      "0200000000000000",
+					Name:              "cool/Observe",
+					Kind:              otlpSpanKindInternal,
+					StartTimeUnixNano: startNano,
+					EndTimeUnixNano:   endNano,
+					Attributes:        []otlpKeyValue{{Key: AttributeResourceExists, Value: otlpAnyValue{BoolValue: &exists}}},
+					Events: []otlpEvent{{
+						TimeUnixNano: startNano,
+						Name:         "Waited for rate limiter",
+						Attributes:   []otlpKeyValue{{Key: AttributeThrottled, Value: otlpAnyValue{IntValue: &throttled}}},
+					}},
+					Status: &otlpStatus{Code: otlpStatusCodeError, Message: "boom"},
+				},
+			},
+		}},
+	}}}
+
+	requests := make(chan *http.Request, 1)
+	bodies := make(chan []byte, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		requests <- r
+		bodies <- b
+	}))
+	defer srv.Close()
+
+	// The interval is long enough that spans are only exported when the
+	// exporter is stopped.
+	e, err := NewOTLPExporter(srv.URL+"/v1/traces",
+		WithOTLPServiceName(service),
+		WithOTLPHeaders(map[string]string{"Authorization": "Bearer token"}),
+		WithOTLPInterval(time.Hour))
+	if err != nil {
+		t.Fatalf("NewOTLPExporter(...): %s", err)
+	}
+	for _, s := range sd {
+		e.ExportSpan(s)
+	}
+	e.Stop()
+
+	r := <-requests
+	if r.Method != http.MethodPost || r.URL.Path != "/v1/traces" {
+		t.Errorf("e.Stop(): want POST /v1/traces, got %s %s", r.Method, r.URL.Path)
+	}
+	if got := r.Header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("e.Stop(): want Content-Type application/json, got %s", got)
+	}
+	if got := r.Header.Get("Authorization"); got != "Bearer token" {
+		t.Errorf("e.Stop(): want Authorization header Bearer token, got %s", got)
+	}
+
+	got := otlpRequest{}
+	if err := json.Unmarshal(<-bodies, &got); err != nil {
+		t.Fatalf("json.Unmarshal(...): %s", err)
+	}
+	if diff := cmp.Diff(want, got, cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("e.Stop(): -want request, +got request:\n%s", diff)
+	}
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"sync"
+
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/codes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+)
+
+// A Tracer traces the reconciles of a controller, and the operations each
+// reconcile performs. The reconcilers of this version of controller-runtime
+// do not pass a context to the code they call, so the Tracer tracks the span
+// of each reconcile that is in progress, and starts the spans of operations
+// on the reconciled resource as its children.
+type Tracer struct {
+	controller string
+
+	mu          sync.RWMutex
+	reconciling map[types.NamespacedName]*trace.Span
+}
+
+var tracers = struct {
+	sync.Mutex
+	named map[string]*Tracer
+}{named: make(map[string]*Tracer)}
+
+// NewTracer returns the Tracer of the named controller. Calling NewTracer
+// with the same name returns the same Tracer, so code that is not passed the
+// Tracer of its controller may still start spans as children of its
+// reconciles.
+func NewTracer(controller string) *Tracer {
+	tracers.Lock()
+	defer tracers.Unlock()
+	if t, ok := tracers.named[controller]; ok {
+		return t
+	}
+	t := &Tracer{controller: controller, reconciling: make(map[types.NamespacedName]*trace.Span)}
+	tracers.named[controller] = t
+	return t
+}
+
+// StartSpan starts a span of the named operation on the supplied object. The
+// span is a child of the span in the supplied context if there is one, or
+// else of the span of the object's reconcile if one is in progress. A nil
+// Tracer starts spans named for no controller.
+func (t *Tracer) StartSpan(ctx context.Context, operation string, obj metav1.Object) (context.Context, *trace.Span) {
+	if t == nil {
+		t = &Tracer{}
+	}
+	uid := trace.StringAttribute(AttributeUID, string(obj.GetUID()))
+
+	parent := trace.FromContext(ctx)
+	if parent == nil {
+		t.mu.RLock()
+		parent = t.reconciling[types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}]
+		t.mu.RUnlock()
+		if parent != nil {
+			// A reconcile learns the UID of its resource from its first
+			// operation.
+			parent.AddAttributes(uid)
+		}
+	}
+
+	ctx, s := trace.StartSpan(trace.NewContext(ctx, parent), t.controller+"/"+operation)
+	s.AddAttributes(
+		trace.StringAttribute(AttributeController, t.controller),
+		trace.StringAttribute(AttributeNamespace, obj.GetNamespace()),
+		trace.StringAttribute(AttributeName, obj.GetName()),
+		uid,
+	)
+	return ctx, s
+}
+
+// Reconciler returns a Reconciler that traces each reconcile of the supplied
+// reconcile.Reconciler.
+func (t *Tracer) Reconciler(r reconcile.Reconciler) *Reconciler {
+	return &Reconciler{Reconciler: r, tracer: t}
+}
+
+// Connecter returns a Connecter that traces the operations of the external
+// clients returned by the supplied resource.ExternalConnecter.
+func (t *Tracer) Connecter(ec resource.ExternalConnecter) *Connecter {
+	return &Connecter{ExternalConnecter: ec, tracer: t}
+}
+
+// Binder returns a Binder that traces the binds of the supplied
+// resource.ManagedBinder.
+func (t *Tracer) Binder(b resource.ManagedBinder) *Binder {
+	return &Binder{ManagedBinder: b, tracer: t}
+}
+
+// A Reconciler traces each reconcile of a reconcile.Reconciler.
+type Reconciler struct {
+	reconcile.Reconciler
+
+	tracer *Tracer
+}
+
+// Reconcile the supplied request.
+func (r *Reconciler) Reconcile(req reconcile.Request) (reconcile.Result, error) {
+	_, s := trace.StartSpan(context.Background(), r.tracer.controller+"/Reconcile", trace.WithSpanKind(trace.SpanKindServer))
+	defer s.End()
+	s.AddAttributes(
+		trace.StringAttribute(AttributeController, r.tracer.controller),
+		trace.StringAttribute(AttributeNamespace, req.Namespace),
+		trace.StringAttribute(AttributeName, req.Name),
+	)
+
+	// controller-runtime never reconciles the same request concurrently.
+	r.tracer.mu.Lock()
+	r.tracer.reconciling[req.NamespacedName] = s
+	r.tracer.mu.Unlock()
+	defer func() {
+		r.tracer.mu.Lock()
+		delete(r.tracer.reconciling, req.NamespacedName)
+		r.tracer.mu.Unlock()
+	}()
+
+	result, err := r.Reconciler.Reconcile(req)
+	setStatus(s, err)
+	return result, err
+}
+
+// A Connecter traces the operations of the external clients it returns.
+type Connecter struct {
+	resource.ExternalConnecter
+
+	tracer *Tracer
+}
+
+// Connect to the external system of the supplied managed resource.
+func (c *Connecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	ctx, s := c.tracer.StartSpan(ctx, "Connect", mg)
+	defer s.End()
+	addClaimUID(s, mg)
+
+	ec, err := c.ExternalConnecter.Connect(ctx, mg)
+	setStatus(s, err)
+	if err != nil {
+		return nil, err
+	}
+	return &External{ExternalClient: ec, tracer: c.tracer}, nil
+}
+
+// An External traces the operations of an external client.
+type External struct {
+	resource.ExternalClient
+
+	tracer *Tracer
+}
+
+// Observe the external resource of the supplied managed resource.
+func (e *External) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	ctx, s := e.tracer.StartSpan(ctx, "Observe", mg)
+	defer s.End()
+	addClaimUID(s, mg)
+
+	o, err := e.ExternalClient.Observe(ctx, mg)
+	s.AddAttributes(trace.BoolAttribute(AttributeResourceExists, o.ResourceExists))
+	setStatus(s, err)
+	return o, err
+}
+
+// Create the external resource of the supplied managed resource.
+func (e *External) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	ctx, s := e.tracer.StartSpan(ctx, "Create", mg)
+	defer s.End()
+	addClaimUID(s, mg)
+
+	c, err := e.ExternalClient.Create(ctx, mg)
+	setStatus(s, err)
+	return c, err
+}
+
+// Update the external resource of the supplied managed resource.
+func (e *External) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	ctx, s := e.tracer.StartSpan(ctx, "Update", mg)
+	defer s.End()
+	addClaimUID(s, mg)
+
+	u, err := e.ExternalClient.Update(ctx, mg)
+	setStatus(s, err)
+	return u, err
+}
+
+// Delete the external resource of the supplied managed resource.
+func (e *External) Delete(ctx context.Context, mg resource.Managed) error {
+	ctx, s := e.tracer.StartSpan(ctx, "Delete", mg)
+	defer s.End()
+	addClaimUID(s, mg)
+
+	err := e.ExternalClient.Delete(ctx, mg)
+	setStatus(s, err)
+	return err
+}
+
+// A Binder traces the binds of a resource.ManagedBinder.
+type Binder struct {
+	resource.ManagedBinder
+
+	tracer *Tracer
+}
+
+// Bind the supplied resource claim to the supplied managed resource.
+func (b *Binder) Bind(ctx context.Context, cm resource.Claim, mg resource.Managed) error {
+	ctx, s := b.tracer.StartSpan(ctx, "Bind", cm)
+	defer s.End()
+	s.AddAttributes(trace.StringAttribute(AttributeManagedUID, string(mg.GetUID())))
+
+	err := b.ManagedBinder.Bind(ctx, cm, mg)
+	setStatus(s, err)
+	return err
+}
+
+func addClaimUID(s *trace.Span, mg resource.Managed) {
+	if ref := mg.GetClaimReference(); ref != nil {
+		s.AddAttributes(trace.StringAttribute(AttributeClaimUID, string(ref.UID)))
+	}
+}
+
+func setStatus(s *trace.Span, err error) {
+	if err != nil {
+		s.SetStatus(trace.Status{Code: int32(codes.Unknown), Message: err.Error()})
+	}
+}
@@ -0,0 +1,260 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+
+	databasev1alpha1 "github.com/crossplaneio/crossplane/apis/database/v1alpha1"
+	computev1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/compute/v1alpha1"
+)
+
+var errBoom = errors.New("boom")
+
+// A spanRecorder records the spans it exports.
+type spanRecorder struct {
+	mu    sync.Mutex
+	spans []*trace.SpanData
+}
+
+func (r *spanRecorder) ExportSpan(sd *trace.SpanData) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans = append(r.spans, sd)
+}
+
+// named returns the recorded span with the supplied name, if any.
+func (r *spanRecorder) named(name string) *trace.SpanData {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, sd := range r.spans {
+		if sd.Name == name {
+			return sd
+		}
+	}
+	return nil
+}
+
+func record(t *testing.T) *spanRecorder {
+	t.Helper()
+	r := &spanRecorder{}
+	trace.ApplyConfig(trace.Config{DefaultSampler: trace.AlwaysSample()})
+	trace.RegisterExporter(r)
+	return r
+}
+
+type mockReconciler struct {
+	reconcile func() error
+}
+
+func (r *mockReconciler) Reconcile(_ reconcile.Request) (reconcile.Result, error) {
+	return reconcile.Result{}, r.reconcile()
+}
+
+type mockConnecter struct {
+	err error
+}
+
+func (c *mockConnecter) Connect(_ context.Context, _ resource.Managed) (resource.ExternalClient, error) {
+	return &mockExternal{err: c.err}, c.err
+}
+
+type mockExternal struct {
+	err error
+}
+
+func (e *mockExternal) Observe(_ context.Context, _ resource.Managed) (resource.ExternalObservation, error) {
+	return resource.ExternalObservation{ResourceExists: e.err == nil}, e.err
+}
+
+func (e *mockExternal) Create(_ context.Context, _ resource.Managed) (resource.ExternalCreation, error) {
+	return resource.ExternalCreation{}, e.err
+}
+
+func (e *mockExternal) Update(_ context.Context, _ resource.Managed) (resource.ExternalUpdate, error) {
+	return resource.ExternalUpdate{}, e.err
+}
+
+func (e *mockExternal) Delete(_ context.Context, _ resource.Managed) error {
+	return e.err
+}
+
+type mockBinder struct {
+	err error
+}
+
+func (b *mockBinder) Bind(_ context.Context, _ resource.Claim, _ resource.Managed) error {
+	return b.err
+}
+
+func network() *computev1alpha1.Network {
+	n := &computev1alpha1.Network{}
+	n.SetName("cool-network")
+	n.SetUID("managed")
+	n.SetClaimReference(&corev1.ObjectReference{UID: "claim"})
+	return n
+}
+
+func TestNewTracer(t *testing.T) {
+	if NewTracer("cool") != NewTracer("cool") {
+		t.Errorf("NewTracer(...): want the same Tracer for the same name")
+	}
+	if NewTracer("cool") == NewTracer("uncool") {
+		t.Errorf("NewTracer(...): want different Tracers for different names")
+	}
+}
+
+func TestReconcilerConnecter(t *testing.T) {
+	type want struct {
+		err        error
+		operations []string
+		status     int32
+	}
+
+	cases := map[string]struct {
+		ec   resource.ExternalConnecter
+		want want
+	}{
+		"Successful": {
+			ec: &mockConnecter{},
+			want: want{
+				operations: []string{"Connect", "Observe"},
+			},
+		},
+		"ConnectError": {
+			ec: &mockConnecter{err: errBoom},
+			want: want{
+				err:        errBoom,
+				operations: []string{"Connect"},
+				status:     2,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			sr := record(t)
+			defer trace.UnregisterExporter(sr)
+
+			tr := NewTracer("connecter-" + name)
+			mg := network()
+			r := tr.Reconciler(&mockReconciler{reconcile: func() error {
+				ec, err := tr.Connecter(tc.ec).Connect(context.Background(), mg)
+				if err != nil {
+					return err
+				}
+				_, err = ec.Observe(context.Background(), mg)
+				return err
+			}})
+
+			_, err := r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Name: mg.GetName()}})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r.Reconcile(...): -want error, +got error:\n%s", diff)
+			}
+
+			rs := sr.named(tr.controller + "/Reconcile")
+			if rs == nil {
+				t.Fatalf("r.Reconcile(...): no reconcile span was recorded")
+			}
+			if diff := cmp.Diff(tc.want.status, rs.Code); diff != "" {
+				t.Errorf("r.Reconcile(...): -want status, +got status:\n%s", diff)
+			}
+			if diff := cmp.Diff("managed", rs.Attributes[AttributeUID]); diff != "" {
+				t.Errorf("r.Reconcile(...): -want uid, +got uid:\n%s", diff)
+			}
+
+			for _, op := range tc.want.operations {
+				s := sr.named(tr.controller + "/" + op)
+				if s == nil {
+					t.Errorf("r.Reconcile(...): no %s span was recorded", op)
+					continue
+				}
+				if diff := cmp.Diff(rs.SpanID, s.ParentSpanID); diff != "" {
+					t.Errorf("r.Reconcile(...): %s: -want parent, +got parent:\n%s", op, diff)
+				}
+				if diff := cmp.Diff("claim", s.Attributes[AttributeClaimUID]); diff != "" {
+					t.Errorf("r.Reconcile(...): %s: -want claim uid, +got claim uid:\n%s", op, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestBinder(t *testing.T) {
+	cases := map[string]struct {
+		b          resource.ManagedBinder
+		wantErr    error
+		wantStatus int32
+	}{
+		"Successful": {
+			b: &mockBinder{},
+		},
+		"BindError": {
+			b:          &mockBinder{err: errBoom},
+			wantErr:    errBoom,
+			wantStatus: 2,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			sr := record(t)
+			defer trace.UnregisterExporter(sr)
+
+			tr := NewTracer("binder-" + name)
+			cm := &databasev1alpha1.MySQLInstance{}
+			cm.SetUID("claim")
+
+			err := tr.Binder(tc.b).Bind(context.Background(), cm, network())
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("b.Bind(...): -want error, +got error:\n%s", diff)
+			}
+
+			s := sr.named(tr.controller + "/Bind")
+			if s == nil {
+				t.Fatalf("b.Bind(...): no bind span was recorded")
+			}
+			if diff := cmp.Diff(tc.wantStatus, s.Code); diff != "" {
+				t.Errorf("b.Bind(...): -want status, +got status:\n%s", diff)
+			}
+			if diff := cmp.Diff("claim", s.Attributes[AttributeUID]); diff != "" {
+				t.Errorf("b.Bind(...): -want uid, +got uid:\n%s", diff)
+			}
+			if diff := cmp.Diff("managed", s.Attributes[AttributeManagedUID]); diff != "" {
+				t.Errorf("b.Bind(...): -want managed uid, +got managed uid:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNilTracer(t *testing.T) {
+	var tr *Tracer
+	_, s := tr.StartSpan(context.Background(), "Observe", network())
+	s.End()
+}
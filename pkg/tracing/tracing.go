@@ -0,0 +1,228 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing traces the reconciles of Crossplane's controllers and the
+// cloud API calls they make. Spans are recorded using OpenCensus, and may be
+// exported over OTLP, to an OpenCensus agent, or written to a file.
+package tracing
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"contrib.go.opencensus.io/exporter/ocagent"
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+)
+
+// Span attributes that identify the resources and cloud API calls a span
+// concerns. Spans concerning the same resource share its UID, which may be
+// used to correlate the spans of a resource claim, the managed resource it is
+// bound to, and the calls made to the cloud API using its provider.
+const (
+	AttributeController     = "crossplane.io/controller"
+	AttributeNamespace      = "crossplane.io/namespace"
+	AttributeName           = "crossplane.io/name"
+	AttributeUID            = "crossplane.io/uid"
+	AttributeClaimUID       = "crossplane.io/claim-uid"
+	AttributeManagedUID     = "crossplane.io/managed-uid"
+	AttributeProviderUID    = "crossplane.io/provider-uid"
+	AttributeResourceExists = "crossplane.io/resource-exists"
+	AttributeAPI            = "crossplane.io/api"
+	AttributeOperation      = "crossplane.io/operation"
+	AttributeOperationDone  = "crossplane.io/operation-done"
+	AttributeThrottled      = "crossplane.io/throttled"
+)
+
+// Supported span exporters.
+const (
+	// ExporterNone does not export spans.
+	ExporterNone = "none"
+
+	// ExporterAgent exports spans to an OpenCensus agent, or to an
+	// OpenTelemetry Collector with an OpenCensus receiver.
+	ExporterAgent = "agent"
+
+	// ExporterOTLP exports spans over OTLP/HTTP, for example to an
+	// OpenTelemetry Collector or to a tracing backend that accepts OTLP.
+	ExporterOTLP = "otlp"
+
+	// ExporterStdout writes spans to stdout.
+	ExporterStdout = "stdout"
+
+	// ExporterFile writes spans to a file.
+	ExporterFile = "file"
+)
+
+// Exporters are the names of the supported span exporters.
+var Exporters = []string{ExporterNone, ExporterAgent, ExporterOTLP, ExporterStdout, ExporterFile}
+
+// DefaultAgentAddress is the address at which an OpenCensus agent, or the
+// OpenCensus receiver of an OpenTelemetry Collector, listens by default.
+const DefaultAgentAddress = "localhost:55678"
+
+// Options configures how spans are sampled and exported.
+type Options struct {
+	// ServiceName identifies the process that recorded the spans.
+	ServiceName string
+
+	// Exporter is the name of the exporter to use.
+	Exporter string
+
+	// AgentAddress is the address of the agent to which ExporterAgent
+	// exports spans.
+	AgentAddress string
+
+	// OTLPEndpoint is the URL of the OTLP/HTTP traces endpoint to which
+	// ExporterOTLP exports spans.
+	OTLPEndpoint string
+
+	// OTLPHeaders are HTTP headers ExporterOTLP sends with each export, for
+	// example to authenticate to a tracing backend.
+	OTLPHeaders map[string]string
+
+	// File is the path of the file to which ExporterFile writes spans.
+	File string
+
+	// SamplingProbability is the probability with which a trace is sampled.
+	SamplingProbability float64
+}
+
+// Setup registers the exporter configured by the supplied options with
+// OpenCensus. The returned function flushes and stops the exporter.
+func Setup(o Options) (stop func(), err error) {
+	switch o.Exporter {
+	case ExporterNone, "":
+		return func() {}, nil
+	case ExporterAgent:
+		addr := o.AgentAddress
+		if addr == "" {
+			addr = DefaultAgentAddress
+		}
+		e, err := ocagent.NewExporter(ocagent.WithInsecure(), ocagent.WithAddress(addr), ocagent.WithServiceName(o.ServiceName))
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot create exporter for agent %s", addr)
+		}
+		register(o)
+		trace.RegisterExporter(e)
+		return func() {
+			trace.UnregisterExporter(e)
+			e.Flush()
+			_ = e.Stop()
+		}, nil
+	case ExporterOTLP:
+		endpoint := o.OTLPEndpoint
+		if endpoint == "" {
+			endpoint = DefaultOTLPEndpoint
+		}
+		e, err := NewOTLPExporter(endpoint, WithOTLPHeaders(o.OTLPHeaders), WithOTLPServiceName(o.ServiceName))
+		if err != nil {
+			return nil, err
+		}
+		register(o)
+		trace.RegisterExporter(e)
+		return func() {
+			trace.UnregisterExporter(e)
+			e.Stop()
+		}, nil
+	case ExporterStdout:
+		e := NewWriterExporter(os.Stdout)
+		register(o)
+		trace.RegisterExporter(e)
+		return func() { trace.UnregisterExporter(e) }, nil
+	case ExporterFile:
+		f, err := os.OpenFile(o.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot open span file %s", o.File)
+		}
+		e := NewWriterExporter(f)
+		register(o)
+		trace.RegisterExporter(e)
+		return func() {
+			trace.UnregisterExporter(e)
+			_ = f.Close()
+		}, nil
+	}
+	return nil, errors.Errorf("unknown span exporter %s", o.Exporter)
+}
+
+func register(o Options) {
+	trace.ApplyConfig(trace.Config{DefaultSampler: trace.ProbabilitySampler(o.SamplingProbability)})
+}
+
+// A WriterExporter writes the spans it exports to a writer as JSON, one span
+// per line. It is intended for debugging and testing without an agent.
+type WriterExporter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewWriterExporter returns a WriterExporter that writes to the supplied
+// writer.
+func NewWriterExporter(w io.Writer) *WriterExporter {
+	return &WriterExporter{enc: json.NewEncoder(w)}
+}
+
+// A Span as written by a WriterExporter.
+type Span struct {
+	TraceID      string                 `json:"traceID"`
+	SpanID       string                 `json:"spanID"`
+	ParentSpanID string                 `json:"parentSpanID,omitempty"`
+	Name         string                 `json:"name"`
+	Start        time.Time              `json:"start"`
+	End          time.Time              `json:"end"`
+	Duration     string                 `json:"duration"`
+	Attributes   map[string]interface{} `json:"attributes,omitempty"`
+	Annotations  []Annotation           `json:"annotations,omitempty"`
+	Status       int32                  `json:"status,omitempty"`
+	Message      string                 `json:"message,omitempty"`
+}
+
+// An Annotation of a Span as written by a WriterExporter.
+type Annotation struct {
+	Time       time.Time              `json:"time"`
+	Message    string                 `json:"message"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// ExportSpan writes the supplied span.
+func (e *WriterExporter) ExportSpan(sd *trace.SpanData) {
+	s := Span{
+		TraceID:     sd.TraceID.String(),
+		SpanID:      sd.SpanID.String(),
+		Name:        sd.Name,
+		Start:       sd.StartTime,
+		End:         sd.EndTime,
+		Duration:    sd.EndTime.Sub(sd.StartTime).String(),
+		Attributes:  sd.Attributes,
+		Annotations: make([]Annotation, len(sd.Annotations)),
+		Status:      sd.Code,
+		Message:     sd.Message,
+	}
+	if sd.ParentSpanID != (trace.SpanID{}) {
+		s.ParentSpanID = sd.ParentSpanID.String()
+	}
+	for i, a := range sd.Annotations {
+		s.Annotations[i] = Annotation{Time: a.Time, Message: a.Message, Attributes: a.Attributes}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_ = e.enc.Encode(s)
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+)
+
+func TestSetup(t *testing.T) {
+	cases := map[string]struct {
+		o       Options
+		wantErr error
+	}{
+		"None": {
+			o: Options{Exporter: ExporterNone},
+		},
+		"Unset": {
+			o: Options{},
+		},
+		"Stdout": {
+			o: Options{Exporter: ExporterStdout, SamplingProbability: 1},
+		},
+		"OTLP": {
+			o: Options{Exporter: ExporterOTLP},
+		},
+		"OTLPInvalidEndpoint": {
+			o:       Options{Exporter: ExporterOTLP, OTLPEndpoint: "localhost:4318"},
+			wantErr: errors.New("OTLP endpoint localhost:4318 must be an http or https URL"),
+		},
+		"Unknown": {
+			o:       Options{Exporter: "zipkin"},
+			wantErr: errors.New("unknown span exporter zipkin"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			stop, err := Setup(tc.o)
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Setup(...): -want error, +got error:\n%s", diff)
+			}
+			if stop != nil {
+				stop()
+			}
+		})
+	}
+}
+
+func TestWriterExporter(t *testing.T) {
+	start := time.Date(2019, 8, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Second)
+
+	cases := map[string]struct {
+		sd   *trace.SpanData
+		want Span
+	}{
+		"RootSpan": {
+			sd: &trace.SpanData{
+				SpanContext: trace.SpanContext{TraceID: trace.TraceID{1}, SpanID: trace.SpanID{2}},
+				Name:        "cool/Reconcile",
+				StartTime:   start,
+				EndTime:     end,
+				Attributes:  map[string]interface{}{AttributeName: "cool"},
+			},
+			want: Span{
+				TraceID:    "01000000000000000000000000000000",
+				SpanID:     "0200000000000000",
+				Name:       "cool/Reconcile",
+				Start:      start,
+				End:        end,
+				Duration:   "2s",
+				Attributes: map[string]interface{}{AttributeName: "cool"},
+			},
+		},
+		"ChildSpanWithStatusAndAnnotation": {
+			sd: &trace.SpanData{
+				SpanContext:  trace.SpanContext{TraceID: trace.TraceID{1}, SpanID: trace.SpanID{3}},
+				ParentSpanID: trace.SpanID{2},
+				Name:         "cool/Observe",
+				StartTime:    start,
+				EndTime:      end,
+				Annotations:  []trace.Annotation{{Time: start, Message: "Waited for rate limiter"}},
+				Status:       trace.Status{Code: 2, Message: "boom"},
+			},
+			want: Span{
+				TraceID:      "01000000000000000000000000000000",
+				SpanID:       "0300000000000000",
+				ParentSpanID: "0200000000000000",
+				Name:         "cool/Observe",
+				Start:        start,
+				End:          end,
+				Duration:     "2s",
+				Annotations:  []Annotation{{Time: start, Message: "Waited for rate limiter"}},
+				Status:       2,
+				Message:      "boom",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			b := &bytes.Buffer{}
+			NewWriterExporter(b).ExportSpan(tc.sd)
+
+			got := Span{}
+			if err := json.Unmarshal(b.Bytes(), &got); err != nil {
+				t.Fatalf("json.Unmarshal(...): %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("e.ExportSpan(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	cachev1alpha1 "github.com/crossplaneio/crossplane/apis/cache/v1alpha1"
+	computev1alpha1 "github.com/crossplaneio/crossplane/apis/compute/v1alpha1"
+	databasev1alpha1 "github.com/crossplaneio/crossplane/apis/database/v1alpha1"
+	storagev1alpha1 "github.com/crossplaneio/crossplane/apis/storage/v1alpha1"
+	awscachev1alpha1 "github.com/crossplaneio/crossplane/aws/apis/cache/v1alpha1"
+	awscomputev1alpha1 "github.com/crossplaneio/crossplane/aws/apis/compute/v1alpha1"
+	awsdatabasev1alpha1 "github.com/crossplaneio/crossplane/aws/apis/database/v1alpha1"
+	awsnetworkv1alpha1 "github.com/crossplaneio/crossplane/aws/apis/network/v1alpha1"
+	awsstoragev1alpha1 "github.com/crossplaneio/crossplane/aws/apis/storage/v1alpha1"
+	awsv1alpha1 "github.com/crossplaneio/crossplane/aws/apis/v1alpha1"
+	awscache "github.com/crossplaneio/crossplane/pkg/controller/aws/cache"
+	awscompute "github.com/crossplaneio/crossplane/pkg/controller/aws/compute"
+	"github.com/crossplaneio/crossplane/pkg/controller/aws/rds"
+	"github.com/crossplaneio/crossplane/pkg/controller/aws/s3"
+)
+
+// registerAWS registers the validators and defaulters for AWS resource
+// classes and managed resources, and the validators for resource claims that
+// may be satisfied by AWS managed resources.
+func registerAWS(mgr ctrl.Manager, v *ValidatingHandler, d *DefaultingHandler) {
+	for _, k := range []schema.GroupVersionKind{
+		awscachev1alpha1.ReplicationGroupGroupVersionKind,
+		awscomputev1alpha1.EKSClusterGroupVersionKind,
+		awsdatabasev1alpha1.RDSInstanceGroupVersionKind,
+		awsdatabasev1alpha1.RDSUserGroupVersionKind,
+		awsdatabasev1alpha1.RDSDatabaseGroupVersionKind,
+		awsdatabasev1alpha1.RDSSnapshotGroupVersionKind,
+		awsnetworkv1alpha1.VPCGroupVersionKind,
+		awsnetworkv1alpha1.SubnetGroupVersionKind,
+		awsnetworkv1alpha1.SecurityGroupGroupVersionKind,
+		awsstoragev1alpha1.S3BucketGroupVersionKind,
+	} {
+		v.Register(k, NewProviderReferenceValidator(mgr.GetClient(), &awsv1alpha1.Provider{}, "spec.providerRef"), validateExternalName)
+		d.Register(k, defaultManagedReclaimPolicy)
+	}
+
+	for _, k := range []schema.GroupVersionKind{
+		awscachev1alpha1.ReplicationGroupClassGroupVersionKind,
+		awscomputev1alpha1.EKSClusterClassGroupVersionKind,
+		awsdatabasev1alpha1.RDSInstanceClassGroupVersionKind,
+		awsdatabasev1alpha1.RDSDatabaseClassGroupVersionKind,
+		awsstoragev1alpha1.S3BucketClassGroupVersionKind,
+	} {
+		v.Register(k, NewProviderReferenceValidator(mgr.GetClient(), &awsv1alpha1.Provider{}, "specTemplate.providerRef"))
+		d.Register(k, defaultClassReclaimPolicy)
+	}
+
+	v.Register(awscachev1alpha1.ReplicationGroupGroupVersionKind,
+		NewImmutableFieldsValidator("spec.atRestEncryptionEnabled", "spec.transitEncryptionEnabled"))
+	v.Register(awscomputev1alpha1.EKSClusterGroupVersionKind,
+		NewImmutableFieldsValidator("spec.region", "spec.roleARN", "spec.vpcId", "spec.subnetIds", "spec.securityGroupIds"))
+	v.Register(awsdatabasev1alpha1.RDSInstanceGroupVersionKind,
+		NewImmutableFieldsValidator("spec.engine", "spec.masterUsername"))
+	v.Register(awsnetworkv1alpha1.VPCGroupVersionKind,
+		NewImmutableFieldsValidator("spec.cidrBlock"))
+	v.Register(awsnetworkv1alpha1.SubnetGroupVersionKind,
+		NewImmutableFieldsValidator("spec.cidrBlock", "spec.vpcId", "spec.availabilityZone"))
+	v.Register(awsnetworkv1alpha1.SecurityGroupGroupVersionKind,
+		NewImmutableFieldsValidator("spec.vpcId", "spec.groupName", "spec.description"))
+	v.Register(awsstoragev1alpha1.S3BucketGroupVersionKind,
+		NewImmutableFieldsValidator("spec.nameFormat", "spec.region"),
+		NewNameFormatValidator("spec.nameFormat"))
+	v.Register(awsstoragev1alpha1.S3BucketClassGroupVersionKind,
+		NewNameFormatValidator("specTemplate.nameFormat"))
+
+	v.Register(cachev1alpha1.RedisClusterGroupVersionKind,
+		claimValidator(mgr, awscachev1alpha1.ReplicationGroupClassGroupVersionKind, awscachev1alpha1.ReplicationGroupGroupVersionKind, awscache.ConfigureReplicationGroup))
+	v.Register(computev1alpha1.KubernetesClusterGroupVersionKind,
+		claimValidator(mgr, awscomputev1alpha1.EKSClusterClassGroupVersionKind, awscomputev1alpha1.EKSClusterGroupVersionKind, awscompute.ConfigureEKSCluster))
+	v.Register(databasev1alpha1.PostgreSQLInstanceGroupVersionKind,
+		claimValidator(mgr, awsdatabasev1alpha1.RDSInstanceClassGroupVersionKind, awsdatabasev1alpha1.RDSInstanceGroupVersionKind, rds.ConfigurePostgreRDSInstance),
+		claimValidator(mgr, awsdatabasev1alpha1.RDSDatabaseClassGroupVersionKind, awsdatabasev1alpha1.RDSDatabaseGroupVersionKind, rds.ConfigureRDSDatabase))
+	v.Register(databasev1alpha1.MySQLInstanceGroupVersionKind,
+		claimValidator(mgr, awsdatabasev1alpha1.RDSInstanceClassGroupVersionKind, awsdatabasev1alpha1.RDSInstanceGroupVersionKind, rds.ConfigureMyRDSInstance),
+		claimValidator(mgr, awsdatabasev1alpha1.RDSDatabaseClassGroupVersionKind, awsdatabasev1alpha1.RDSDatabaseGroupVersionKind, rds.ConfigureRDSDatabase))
+	v.Register(storagev1alpha1.BucketGroupVersionKind,
+		claimValidator(mgr, awsstoragev1alpha1.S3BucketClassGroupVersionKind, awsstoragev1alpha1.S3BucketGroupVersionKind, s3.ConfigureS3Bucket))
+}
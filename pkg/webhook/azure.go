@@ -0,0 +1,117 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	cachev1alpha1 "github.com/crossplaneio/crossplane/apis/cache/v1alpha1"
+	computev1alpha1 "github.com/crossplaneio/crossplane/apis/compute/v1alpha1"
+	databasev1alpha1 "github.com/crossplaneio/crossplane/apis/database/v1alpha1"
+	storagev1alpha1 "github.com/crossplaneio/crossplane/apis/storage/v1alpha1"
+	azurecachev1alpha1 "github.com/crossplaneio/crossplane/azure/apis/cache/v1alpha1"
+	azurecomputev1alpha1 "github.com/crossplaneio/crossplane/azure/apis/compute/v1alpha1"
+	azuredatabasev1alpha1 "github.com/crossplaneio/crossplane/azure/apis/database/v1alpha1"
+	azurenetworkv1alpha1 "github.com/crossplaneio/crossplane/azure/apis/network/v1alpha1"
+	azurestoragev1alpha1 "github.com/crossplaneio/crossplane/azure/apis/storage/v1alpha1"
+	azurev1alpha1 "github.com/crossplaneio/crossplane/azure/apis/v1alpha1"
+	azurecache "github.com/crossplaneio/crossplane/pkg/controller/azure/cache"
+	azurecompute "github.com/crossplaneio/crossplane/pkg/controller/azure/compute"
+	azuredatabase "github.com/crossplaneio/crossplane/pkg/controller/azure/database"
+	"github.com/crossplaneio/crossplane/pkg/controller/azure/storage/account"
+	"github.com/crossplaneio/crossplane/pkg/controller/azure/storage/container"
+)
+
+// registerAzure registers the validators and defaulters for Azure resource
+// classes and managed resources, and the validators for resource claims that
+// may be satisfied by Azure managed resources.
+func registerAzure(mgr ctrl.Manager, v *ValidatingHandler, d *DefaultingHandler) {
+	for _, k := range []schema.GroupVersionKind{
+		azurev1alpha1.ResourceGroupGroupVersionKind,
+		azurecachev1alpha1.RedisGroupVersionKind,
+		azurecomputev1alpha1.AKSClusterGroupVersionKind,
+		azuredatabasev1alpha1.MysqlServerGroupVersionKind,
+		azuredatabasev1alpha1.PostgresqlServerGroupVersionKind,
+		azuredatabasev1alpha1.SQLServerUserGroupVersionKind,
+		azuredatabasev1alpha1.SQLServerDatabaseGroupVersionKind,
+		azurenetworkv1alpha1.VirtualNetworkGroupVersionKind,
+		azurenetworkv1alpha1.SubnetGroupVersionKind,
+		azurestoragev1alpha1.AccountGroupVersionKind,
+	} {
+		v.Register(k, NewProviderReferenceValidator(mgr.GetClient(), &azurev1alpha1.Provider{}, "spec.providerRef"), validateExternalName)
+		d.Register(k, defaultManagedReclaimPolicy)
+	}
+
+	// Containers read their credentials from their storage account rather
+	// than from a provider.
+	v.Register(azurestoragev1alpha1.ContainerGroupVersionKind, validateExternalName)
+	d.Register(azurestoragev1alpha1.ContainerGroupVersionKind, defaultManagedReclaimPolicy)
+
+	for _, k := range []schema.GroupVersionKind{
+		azurecachev1alpha1.RedisClassGroupVersionKind,
+		azurecomputev1alpha1.AKSClusterClassGroupVersionKind,
+		azuredatabasev1alpha1.SQLServerClassGroupVersionKind,
+		azuredatabasev1alpha1.SQLServerDatabaseClassGroupVersionKind,
+		azurestoragev1alpha1.AccountClassGroupVersionKind,
+	} {
+		v.Register(k, NewProviderReferenceValidator(mgr.GetClient(), &azurev1alpha1.Provider{}, "specTemplate.providerRef"))
+		d.Register(k, defaultClassReclaimPolicy)
+	}
+	d.Register(azurestoragev1alpha1.ContainerClassGroupVersionKind, defaultClassReclaimPolicy)
+
+	v.Register(azurev1alpha1.ResourceGroupGroupVersionKind,
+		NewImmutableFieldsValidator("spec.name", "spec.location"))
+	v.Register(azurecachev1alpha1.RedisGroupVersionKind,
+		NewImmutableFieldsValidator("spec.resourceGroupName", "spec.location"))
+	v.Register(azurecomputev1alpha1.AKSClusterGroupVersionKind,
+		NewImmutableFieldsValidator("spec.resourceGroupName", "spec.location", "spec.dnsNamePrefix"))
+	v.Register(azuredatabasev1alpha1.MysqlServerGroupVersionKind,
+		NewImmutableFieldsValidator("spec.resourceGroupName", "spec.location", "spec.adminLoginName", "spec.version"))
+	v.Register(azuredatabasev1alpha1.PostgresqlServerGroupVersionKind,
+		NewImmutableFieldsValidator("spec.resourceGroupName", "spec.location", "spec.adminLoginName", "spec.version"))
+	v.Register(azurenetworkv1alpha1.VirtualNetworkGroupVersionKind,
+		NewImmutableFieldsValidator("spec.nameFormat", "spec.resourceGroupName", "spec.location"),
+		NewNameFormatValidator("spec.nameFormat"))
+	v.Register(azurenetworkv1alpha1.SubnetGroupVersionKind,
+		NewImmutableFieldsValidator("spec.nameFormat", "spec.resourceGroupName", "spec.virtualNetworkName"),
+		NewNameFormatValidator("spec.nameFormat"))
+	v.Register(azurestoragev1alpha1.AccountGroupVersionKind,
+		NewImmutableFieldsValidator("spec.resourceGroupName", "spec.storageAccountName"))
+	v.Register(azurestoragev1alpha1.ContainerGroupVersionKind,
+		NewImmutableFieldsValidator("spec.nameFormat", "spec.accountReference"),
+		NewNameFormatValidator("spec.nameFormat"))
+	v.Register(azurestoragev1alpha1.ContainerClassGroupVersionKind,
+		NewNameFormatValidator("specTemplate.nameFormat"))
+
+	d.Register(azurecomputev1alpha1.AKSClusterGroupVersionKind,
+		NewFieldDefaulter("spec.nodeCount", int64(azurecomputev1alpha1.DefaultNodeCount)))
+
+	v.Register(cachev1alpha1.RedisClusterGroupVersionKind,
+		claimValidator(mgr, azurecachev1alpha1.RedisClassGroupVersionKind, azurecachev1alpha1.RedisGroupVersionKind, azurecache.ConfigureRedis))
+	v.Register(computev1alpha1.KubernetesClusterGroupVersionKind,
+		claimValidator(mgr, azurecomputev1alpha1.AKSClusterClassGroupVersionKind, azurecomputev1alpha1.AKSClusterGroupVersionKind, azurecompute.ConfigureAKSCluster))
+	v.Register(databasev1alpha1.PostgreSQLInstanceGroupVersionKind,
+		claimValidator(mgr, azuredatabasev1alpha1.SQLServerClassGroupVersionKind, azuredatabasev1alpha1.PostgresqlServerGroupVersionKind, azuredatabase.ConfigurePostgresqlServer),
+		claimValidator(mgr, azuredatabasev1alpha1.SQLServerDatabaseClassGroupVersionKind, azuredatabasev1alpha1.SQLServerDatabaseGroupVersionKind, azuredatabase.ConfigureSQLServerDatabase))
+	v.Register(databasev1alpha1.MySQLInstanceGroupVersionKind,
+		claimValidator(mgr, azuredatabasev1alpha1.SQLServerClassGroupVersionKind, azuredatabasev1alpha1.MysqlServerGroupVersionKind, azuredatabase.ConfigureMysqlServer),
+		claimValidator(mgr, azuredatabasev1alpha1.SQLServerDatabaseClassGroupVersionKind, azuredatabasev1alpha1.SQLServerDatabaseGroupVersionKind, azuredatabase.ConfigureSQLServerDatabase))
+	v.Register(storagev1alpha1.BucketGroupVersionKind,
+		claimValidator(mgr, azurestoragev1alpha1.AccountClassGroupVersionKind, azurestoragev1alpha1.AccountGroupVersionKind, account.ConfigureAccount),
+		claimValidator(mgr, azurestoragev1alpha1.ContainerClassGroupVersionKind, azurestoragev1alpha1.ContainerGroupVersionKind, container.ConfigureContainer))
+}
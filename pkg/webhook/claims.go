@@ -0,0 +1,65 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	cachev1alpha1 "github.com/crossplaneio/crossplane/apis/cache/v1alpha1"
+	computev1alpha1 "github.com/crossplaneio/crossplane/apis/compute/v1alpha1"
+	databasev1alpha1 "github.com/crossplaneio/crossplane/apis/database/v1alpha1"
+	storagev1alpha1 "github.com/crossplaneio/crossplane/apis/storage/v1alpha1"
+	"github.com/crossplaneio/crossplane/pkg/externalname"
+)
+
+// Defaulters common to all resource classes and managed resources. Managed
+// resources are retained unless their reclaim policy says otherwise.
+var (
+	defaultClassReclaimPolicy   = NewFieldDefaulter("specTemplate.reclaimPolicy", string(runtimev1alpha1.ReclaimRetain))
+	defaultManagedReclaimPolicy = NewFieldDefaulter("spec.reclaimPolicy", string(runtimev1alpha1.ReclaimRetain))
+)
+
+// validateExternalName is common to all managed resources. Changing the
+// external name of a managed resource would silently point it at a different
+// external resource, orphaning the one it represented.
+var validateExternalName = NewImmutableAnnotationValidator(externalname.AnnotationKey)
+
+// registerClaims registers the validators common to all resource claims. A
+// claim's class and managed resource references may be set once, either by
+// the claim's author or by Crossplane's controllers, but changing them
+// afterward would orphan the claim's managed resource.
+func registerClaims(_ ctrl.Manager, v *ValidatingHandler, _ *DefaultingHandler) {
+	for _, k := range []schema.GroupVersionKind{
+		cachev1alpha1.RedisClusterGroupVersionKind,
+		computev1alpha1.KubernetesClusterGroupVersionKind,
+		databasev1alpha1.MySQLInstanceGroupVersionKind,
+		databasev1alpha1.PostgreSQLInstanceGroupVersionKind,
+		storagev1alpha1.BucketGroupVersionKind,
+	} {
+		v.Register(k, NewImmutableFieldsValidator("spec.classRef", "spec.resourceRef"))
+	}
+}
+
+// claimValidator returns a Validator that rejects resource claims that the
+// supplied configurator could not use to configure a managed resource of the
+// supplied kind from a resource class of the supplied kind.
+func claimValidator(mgr ctrl.Manager, cs, mg schema.GroupVersionKind, fn resource.ManagedConfiguratorFn) Validator {
+	return NewClaimConfiguratorValidator(mgr.GetClient(), mgr.GetScheme(), resource.ClassKind(cs), resource.ManagedKind(mg), fn)
+}
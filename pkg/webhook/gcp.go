@@ -0,0 +1,112 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	cachev1alpha1 "github.com/crossplaneio/crossplane/apis/cache/v1alpha1"
+	computev1alpha1 "github.com/crossplaneio/crossplane/apis/compute/v1alpha1"
+	databasev1alpha1 "github.com/crossplaneio/crossplane/apis/database/v1alpha1"
+	storagev1alpha1 "github.com/crossplaneio/crossplane/apis/storage/v1alpha1"
+	gcpcachev1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/cache/v1alpha1"
+	gcpcomputev1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/compute/v1alpha1"
+	gcpdatabasev1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/database/v1alpha1"
+	gcpservicenetworkingv1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/servicenetworking/v1alpha1"
+	gcpstoragev1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/storage/v1alpha1"
+	gcpv1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/v1alpha1"
+	gcpcache "github.com/crossplaneio/crossplane/pkg/controller/gcp/cache"
+	gcpcompute "github.com/crossplaneio/crossplane/pkg/controller/gcp/compute"
+	gcpdatabase "github.com/crossplaneio/crossplane/pkg/controller/gcp/database"
+	gcpstorage "github.com/crossplaneio/crossplane/pkg/controller/gcp/storage"
+)
+
+// registerGCP registers the validators and defaulters for GCP resource
+// classes and managed resources, and the validators for resource claims that
+// may be satisfied by GCP managed resources.
+func registerGCP(mgr ctrl.Manager, v *ValidatingHandler, d *DefaultingHandler) {
+	for _, k := range []schema.GroupVersionKind{
+		gcpcachev1alpha1.CloudMemorystoreInstanceGroupVersionKind,
+		gcpcomputev1alpha1.GKEClusterGroupVersionKind,
+		gcpcomputev1alpha1.NetworkGroupVersionKind,
+		gcpcomputev1alpha1.SubnetworkGroupVersionKind,
+		gcpcomputev1alpha1.GlobalAddressGroupVersionKind,
+		gcpdatabasev1alpha1.CloudsqlInstanceGroupVersionKind,
+		gcpdatabasev1alpha1.CloudsqlUserGroupVersionKind,
+		gcpdatabasev1alpha1.CloudsqlDatabaseGroupVersionKind,
+		gcpdatabasev1alpha1.CloudsqlBackupGroupVersionKind,
+		gcpservicenetworkingv1alpha1.ConnectionGroupVersionKind,
+		gcpstoragev1alpha1.BucketGroupVersionKind,
+	} {
+		v.Register(k, NewProviderReferenceValidator(mgr.GetClient(), &gcpv1alpha1.Provider{}, "spec.providerRef"), validateExternalName)
+		d.Register(k, defaultManagedReclaimPolicy)
+	}
+
+	for _, k := range []schema.GroupVersionKind{
+		gcpcachev1alpha1.CloudMemorystoreInstanceClassGroupVersionKind,
+		gcpcomputev1alpha1.GKEClusterClassGroupVersionKind,
+		gcpdatabasev1alpha1.CloudsqlInstanceClassGroupVersionKind,
+		gcpdatabasev1alpha1.CloudsqlDatabaseClassGroupVersionKind,
+		gcpstoragev1alpha1.BucketClassGroupVersionKind,
+	} {
+		v.Register(k, NewProviderReferenceValidator(mgr.GetClient(), &gcpv1alpha1.Provider{}, "specTemplate.providerRef"))
+		d.Register(k, defaultClassReclaimPolicy)
+	}
+
+	v.Register(gcpcachev1alpha1.CloudMemorystoreInstanceGroupVersionKind,
+		NewImmutableFieldsValidator("spec.region", "spec.tier", "spec.locationId", "spec.reservedIpRange", "spec.authorizedNetwork"))
+	v.Register(gcpcomputev1alpha1.GKEClusterGroupVersionKind,
+		NewImmutableFieldsValidator("spec.zone", "spec.network", "spec.subnetwork", "spec.clusterIPV4CIDR"))
+	v.Register(gcpcomputev1alpha1.NetworkGroupVersionKind,
+		NewImmutableFieldsValidator("spec.nameFormat", "spec.description"),
+		NewNameFormatValidator("spec.nameFormat"))
+	v.Register(gcpcomputev1alpha1.SubnetworkGroupVersionKind,
+		NewImmutableFieldsValidator("spec.nameFormat", "spec.network", "spec.region", "spec.description"),
+		NewNameFormatValidator("spec.nameFormat"))
+	v.Register(gcpcomputev1alpha1.GlobalAddressGroupVersionKind,
+		NewImmutableFieldsValidator("spec.nameFormat", "spec.address", "spec.addressType", "spec.purpose", "spec.prefixLength", "spec.network", "spec.description"),
+		NewNameFormatValidator("spec.nameFormat"))
+	v.Register(gcpdatabasev1alpha1.CloudsqlInstanceGroupVersionKind,
+		NewImmutableFieldsValidator("spec.nameFormat", "spec.region", "spec.databaseVersion", "spec.privateNetwork"),
+		NewNameFormatValidator("spec.nameFormat"))
+	v.Register(gcpdatabasev1alpha1.CloudsqlInstanceClassGroupVersionKind,
+		NewNameFormatValidator("specTemplate.nameFormat"))
+	v.Register(gcpstoragev1alpha1.BucketGroupVersionKind,
+		NewImmutableFieldsValidator("spec.nameFormat", "spec.location"),
+		NewNameFormatValidator("spec.nameFormat"))
+	v.Register(gcpstoragev1alpha1.BucketClassGroupVersionKind,
+		NewNameFormatValidator("specTemplate.nameFormat"))
+
+	d.Register(gcpcomputev1alpha1.GKEClusterGroupVersionKind,
+		NewFieldDefaulter("spec.numNodes", gcpcomputev1alpha1.DefaultNumberOfNodes))
+	d.Register(gcpdatabasev1alpha1.CloudsqlInstanceGroupVersionKind,
+		NewFieldDefaulter("spec.storageGB", int64(gcpdatabasev1alpha1.DefaultStorageGB)))
+
+	v.Register(cachev1alpha1.RedisClusterGroupVersionKind,
+		claimValidator(mgr, gcpcachev1alpha1.CloudMemorystoreInstanceClassGroupVersionKind, gcpcachev1alpha1.CloudMemorystoreInstanceGroupVersionKind, gcpcache.ConfigureCloudMemorystoreInstance))
+	v.Register(computev1alpha1.KubernetesClusterGroupVersionKind,
+		claimValidator(mgr, gcpcomputev1alpha1.GKEClusterClassGroupVersionKind, gcpcomputev1alpha1.GKEClusterGroupVersionKind, gcpcompute.ConfigureGKECluster))
+	v.Register(databasev1alpha1.PostgreSQLInstanceGroupVersionKind,
+		claimValidator(mgr, gcpdatabasev1alpha1.CloudsqlInstanceClassGroupVersionKind, gcpdatabasev1alpha1.CloudsqlInstanceGroupVersionKind, gcpdatabase.ConfigurePostgreSQLCloudsqlInstance),
+		claimValidator(mgr, gcpdatabasev1alpha1.CloudsqlDatabaseClassGroupVersionKind, gcpdatabasev1alpha1.CloudsqlDatabaseGroupVersionKind, gcpdatabase.ConfigureCloudsqlDatabase))
+	v.Register(databasev1alpha1.MySQLInstanceGroupVersionKind,
+		claimValidator(mgr, gcpdatabasev1alpha1.CloudsqlInstanceClassGroupVersionKind, gcpdatabasev1alpha1.CloudsqlInstanceGroupVersionKind, gcpdatabase.ConfigureMyCloudsqlInstance),
+		claimValidator(mgr, gcpdatabasev1alpha1.CloudsqlDatabaseClassGroupVersionKind, gcpdatabasev1alpha1.CloudsqlDatabaseGroupVersionKind, gcpdatabase.ConfigureCloudsqlDatabase))
+	v.Register(storagev1alpha1.BucketGroupVersionKind,
+		claimValidator(mgr, gcpstoragev1alpha1.BucketClassGroupVersionKind, gcpstoragev1alpha1.BucketGroupVersionKind, gcpstorage.ConfigureBucket))
+}
@@ -0,0 +1,265 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+)
+
+// Error strings.
+const (
+	errFmtImmutable           = "%s cannot be changed once set"
+	errFmtImmutableAnnotation = "annotation %s cannot be changed once set"
+	errFmtProviderNotFound    = "%s refers to provider %s/%s, which does not exist"
+	errFmtGetProvider         = "cannot get provider %s/%s"
+	errFmtNameFormatVerb      = "%s may only contain the %%s verb, not %%%c"
+	errFmtNameFormatTrailing  = "%s must not end with a single %%"
+	errFmtNameFormatVerbCount = "%s may contain the %%s verb at most once"
+	errFmtIncompatibleClass   = "resource claim is incompatible with %s %s/%s"
+	errFmtNewObject           = "cannot create new %s"
+	errFmtGetClass            = "cannot get %s %s/%s"
+	errDecodeClassReference   = "cannot decode class reference"
+	errDecodeClaim            = "cannot decode resource claim"
+)
+
+// fields returns the fields of the supplied dot separated path, e.g.
+// spec.providerRef.
+func fields(path string) []string {
+	return strings.Split(path, ".")
+}
+
+// unchanged returns true if the supplied object is being updated, and the
+// field at the supplied path is unchanged by the update.
+func unchanged(obj, old *unstructured.Unstructured, path string) bool {
+	if old == nil {
+		return false
+	}
+	v, _, _ := unstructured.NestedFieldNoCopy(obj.Object, fields(path)...)
+	o, _, _ := unstructured.NestedFieldNoCopy(old.Object, fields(path)...)
+	return reflect.DeepEqual(v, o)
+}
+
+// empty returns true if the supplied JSON value is null, or the zero value
+// of its type.
+func empty(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	default:
+		return reflect.DeepEqual(v, reflect.Zero(rv.Type()).Interface())
+	}
+}
+
+// NewImmutableFieldsValidator returns a Validator that rejects updates to the
+// fields at the supplied paths, for example spec.region. A field that is
+// unset may be set, so that values resolved from references may be written
+// after a managed resource is created.
+func NewImmutableFieldsValidator(paths ...string) ValidatorFn {
+	return func(_ context.Context, obj, old *unstructured.Unstructured) error {
+		if old == nil {
+			return nil
+		}
+		for _, p := range paths {
+			if o, _, _ := unstructured.NestedFieldNoCopy(old.Object, fields(p)...); empty(o) {
+				continue
+			}
+			if !unchanged(obj, old, p) {
+				return errors.Errorf(errFmtImmutable, p)
+			}
+		}
+		return nil
+	}
+}
+
+// NewImmutableAnnotationValidator returns a Validator that rejects updates
+// that change or remove the annotation with the supplied key, for example
+// crossplane.io/external-name. An annotation that is unset may be set, so that
+// controllers may record the name of an external resource they create.
+func NewImmutableAnnotationValidator(key string) ValidatorFn {
+	return func(_ context.Context, obj, old *unstructured.Unstructured) error {
+		if old == nil {
+			return nil
+		}
+		o := old.GetAnnotations()[key]
+		if o == "" {
+			return nil
+		}
+		if obj.GetAnnotations()[key] != o {
+			return errors.Errorf(errFmtImmutableAnnotation, key)
+		}
+		return nil
+	}
+}
+
+// NewProviderReferenceValidator returns a Validator that rejects objects
+// whose provider reference, found at the supplied path, refers to a provider
+// that does not exist. The supplied provider is used as a template for the
+// kind of provider to get. References are only validated when they are set or
+// changed, so that an object may still be updated (for example to remove its
+// finalizers) after its provider is deleted.
+func NewProviderReferenceValidator(c client.Reader, provider runtime.Object, path string) ValidatorFn {
+	return func(ctx context.Context, obj, old *unstructured.Unstructured) error {
+		if unchanged(obj, old, path) {
+			return nil
+		}
+		m, found, err := unstructured.NestedMap(obj.Object, fields(path)...)
+		if err != nil || !found {
+			// Missing or malformed references are rejected by CRD validation.
+			return nil
+		}
+		ref := &corev1.ObjectReference{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(m, ref); err != nil {
+			return nil
+		}
+
+		p := provider.DeepCopyObject()
+		err = c.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, p)
+		if kerrors.IsNotFound(err) {
+			return errors.Errorf(errFmtProviderNotFound, path, ref.Namespace, ref.Name)
+		}
+		return errors.Wrapf(err, errFmtGetProvider, ref.Namespace, ref.Name)
+	}
+}
+
+// NewNameFormatValidator returns a Validator that rejects objects whose name
+// format, found at the supplied path, would not produce a valid external name.
+// A name format may contain the %s verb, which is replaced with the object's
+// UID, at most once.
+func NewNameFormatValidator(path string) ValidatorFn {
+	return func(_ context.Context, obj, old *unstructured.Unstructured) error {
+		if unchanged(obj, old, path) {
+			return nil
+		}
+		f, _, _ := unstructured.NestedString(obj.Object, fields(path)...)
+
+		verbs := 0
+		for i := 0; i < len(f); i++ {
+			if f[i] != '%' {
+				continue
+			}
+			if i+1 == len(f) {
+				return errors.Errorf(errFmtNameFormatTrailing, path)
+			}
+			i++
+			switch f[i] {
+			case '%':
+			case 's':
+				verbs++
+			default:
+				return errors.Errorf(errFmtNameFormatVerb, path, f[i])
+			}
+		}
+		if verbs > 1 {
+			return errors.Errorf(errFmtNameFormatVerbCount, path)
+		}
+		return nil
+	}
+}
+
+// NewClaimConfiguratorValidator returns a Validator that rejects resource
+// claims that reference a resource class of the supplied kind, but that the
+// supplied ManagedConfigurator cannot use to configure a managed resource of
+// the supplied kind, for example because the claim requests an engine version
+// that the class does not support. Claims are validated only when they are
+// created or their spec changes. Claims that reference a class that does not
+// yet exist are admitted.
+func NewClaimConfiguratorValidator(c client.Reader, s *runtime.Scheme, cs resource.ClassKind, mg resource.ManagedKind, mc resource.ManagedConfigurator) ValidatorFn {
+	return func(ctx context.Context, obj, old *unstructured.Unstructured) error {
+		if unchanged(obj, old, "spec") {
+			return nil
+		}
+		m, found, err := unstructured.NestedMap(obj.Object, "spec", "classRef")
+		if err != nil || !found {
+			return nil
+		}
+		ref := &corev1.ObjectReference{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(m, ref); err != nil {
+			return errors.Wrap(err, errDecodeClassReference)
+		}
+		if schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind) != schema.GroupVersionKind(cs) {
+			return nil
+		}
+
+		claim, err := newObject(s, obj.GroupVersionKind())
+		if err != nil {
+			return err
+		}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, claim); err != nil {
+			return errors.Wrap(err, errDecodeClaim)
+		}
+
+		class, err := newObject(s, schema.GroupVersionKind(cs))
+		if err != nil {
+			return err
+		}
+		err = c.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, class)
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, errFmtGetClass, ref.Kind, ref.Namespace, ref.Name)
+		}
+
+		managed, err := newObject(s, schema.GroupVersionKind(mg))
+		if err != nil {
+			return err
+		}
+
+		cm, cmok := claim.(resource.Claim)
+		cl, csok := class.(resource.Class)
+		mr, mgok := managed.(resource.Managed)
+		if !cmok || !csok || !mgok {
+			return nil
+		}
+
+		return errors.Wrapf(mc.Configure(ctx, cm, cl, mr), errFmtIncompatibleClass, ref.Kind, ref.Namespace, ref.Name)
+	}
+}
+
+func newObject(s *runtime.Scheme, k schema.GroupVersionKind) (runtime.Object, error) {
+	o, err := s.New(k)
+	return o, errors.Wrapf(err, errFmtNewObject, k.Kind)
+}
+
+// NewFieldDefaulter returns a Defaulter that sets the field at the supplied
+// path to the supplied value, unless it is already set. The value must be a
+// JSON compatible type, i.e. a string, bool, int64, or float64.
+func NewFieldDefaulter(path string, value interface{}) DefaulterFn {
+	return func(_ context.Context, obj *unstructured.Unstructured) error {
+		if v, found, _ := unstructured.NestedFieldNoCopy(obj.Object, fields(path)...); found && v != nil {
+			return nil
+		}
+		return unstructured.SetNestedField(obj.Object, value, fields(path)...)
+	}
+}
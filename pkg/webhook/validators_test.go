@@ -0,0 +1,284 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	awsv1alpha1 "github.com/crossplaneio/crossplane/aws/apis/v1alpha1"
+)
+
+var errBoom = errors.New("boom")
+
+func object(spec map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+}
+
+func TestImmutableFieldsValidator(t *testing.T) {
+	type args struct {
+		obj *unstructured.Unstructured
+		old *unstructured.Unstructured
+	}
+
+	cases := map[string]struct {
+		args args
+		want error
+	}{
+		"Create": {
+			args: args{
+				obj: object(map[string]interface{}{"region": "us-east-1"}),
+			},
+		},
+		"Unchanged": {
+			args: args{
+				obj: object(map[string]interface{}{"region": "us-east-1"}),
+				old: object(map[string]interface{}{"region": "us-east-1"}),
+			},
+		},
+		"PreviouslyUnset": {
+			args: args{
+				obj: object(map[string]interface{}{"region": "us-east-1"}),
+				old: object(map[string]interface{}{"region": ""}),
+			},
+		},
+		"Changed": {
+			args: args{
+				obj: object(map[string]interface{}{"region": "us-west-2"}),
+				old: object(map[string]interface{}{"region": "us-east-1"}),
+			},
+			want: errors.New("spec.region cannot be changed once set"),
+		},
+		"Removed": {
+			args: args{
+				obj: object(map[string]interface{}{}),
+				old: object(map[string]interface{}{"region": "us-east-1"}),
+			},
+			want: errors.New("spec.region cannot be changed once set"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			v := NewImmutableFieldsValidator("spec.region")
+			got := v.Validate(context.Background(), tc.args.obj, tc.args.old)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("v.Validate(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestImmutableAnnotationValidator(t *testing.T) {
+	annotated := func(a map[string]string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{Object: map[string]interface{}{}}
+		u.SetAnnotations(a)
+		return u
+	}
+
+	type args struct {
+		obj *unstructured.Unstructured
+		old *unstructured.Unstructured
+	}
+
+	cases := map[string]struct {
+		args args
+		want error
+	}{
+		"Create": {
+			args: args{
+				obj: annotated(map[string]string{"example.org/name": "cool"}),
+			},
+		},
+		"Unchanged": {
+			args: args{
+				obj: annotated(map[string]string{"example.org/name": "cool", "other": "changed"}),
+				old: annotated(map[string]string{"example.org/name": "cool"}),
+			},
+		},
+		"PreviouslyUnset": {
+			args: args{
+				obj: annotated(map[string]string{"example.org/name": "cool"}),
+				old: annotated(nil),
+			},
+		},
+		"Changed": {
+			args: args{
+				obj: annotated(map[string]string{"example.org/name": "cooler"}),
+				old: annotated(map[string]string{"example.org/name": "cool"}),
+			},
+			want: errors.New("annotation example.org/name cannot be changed once set"),
+		},
+		"Removed": {
+			args: args{
+				obj: annotated(nil),
+				old: annotated(map[string]string{"example.org/name": "cool"}),
+			},
+			want: errors.New("annotation example.org/name cannot be changed once set"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			v := NewImmutableAnnotationValidator("example.org/name")
+			got := v.Validate(context.Background(), tc.args.obj, tc.args.old)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("v.Validate(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProviderReferenceValidator(t *testing.T) {
+	ref := map[string]interface{}{"namespace": "ns", "name": "cool-provider"}
+
+	type args struct {
+		kube *test.MockClient
+		obj  *unstructured.Unstructured
+		old  *unstructured.Unstructured
+	}
+
+	cases := map[string]struct {
+		args args
+		want error
+	}{
+		"ProviderExists": {
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+				obj:  object(map[string]interface{}{"providerRef": ref}),
+			},
+		},
+		"ProviderNotFound": {
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "cool-provider"))},
+				obj:  object(map[string]interface{}{"providerRef": ref}),
+			},
+			want: errors.New("spec.providerRef refers to provider ns/cool-provider, which does not exist"),
+		},
+		"GetProviderError": {
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+				obj:  object(map[string]interface{}{"providerRef": ref}),
+			},
+			want: errors.Wrap(errBoom, "cannot get provider ns/cool-provider"),
+		},
+		"ReferenceUnchanged": {
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+				obj:  object(map[string]interface{}{"providerRef": ref}),
+				old:  object(map[string]interface{}{"providerRef": ref}),
+			},
+		},
+		"NoReference": {
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+				obj:  object(map[string]interface{}{}),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			v := NewProviderReferenceValidator(tc.args.kube, &awsv1alpha1.Provider{}, "spec.providerRef")
+			got := v.Validate(context.Background(), tc.args.obj, tc.args.old)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("v.Validate(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNameFormatValidator(t *testing.T) {
+	cases := map[string]struct {
+		format string
+		want   error
+	}{
+		"Empty": {
+			format: "",
+		},
+		"Literal": {
+			format: "cool-bucket",
+		},
+		"SingleVerb": {
+			format: "cool-%s",
+		},
+		"EscapedPercent": {
+			format: "cool-%%-%s",
+		},
+		"UnsupportedVerb": {
+			format: "cool-%d",
+			want:   errors.New("spec.nameFormat may only contain the %s verb, not %d"),
+		},
+		"TrailingPercent": {
+			format: "cool-%",
+			want:   errors.New("spec.nameFormat must not end with a single %"),
+		},
+		"MultipleVerbs": {
+			format: "%s-cool-%s",
+			want:   errors.New("spec.nameFormat may contain the %s verb at most once"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			v := NewNameFormatValidator("spec.nameFormat")
+			got := v.Validate(context.Background(), object(map[string]interface{}{"nameFormat": tc.format}), nil)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("v.Validate(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFieldDefaulter(t *testing.T) {
+	cases := map[string]struct {
+		obj  *unstructured.Unstructured
+		want *unstructured.Unstructured
+	}{
+		"Unset": {
+			obj:  object(map[string]interface{}{}),
+			want: object(map[string]interface{}{"reclaimPolicy": "Retain"}),
+		},
+		"Null": {
+			obj:  object(map[string]interface{}{"reclaimPolicy": nil}),
+			want: object(map[string]interface{}{"reclaimPolicy": "Retain"}),
+		},
+		"AlreadySet": {
+			obj:  object(map[string]interface{}{"reclaimPolicy": "Delete"}),
+			want: object(map[string]interface{}{"reclaimPolicy": "Delete"}),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d := NewFieldDefaulter("spec.reclaimPolicy", "Retain")
+			if err := d.Default(context.Background(), tc.obj); err != nil {
+				t.Fatalf("d.Default(...): %s", err)
+			}
+			if diff := cmp.Diff(tc.want, tc.obj); diff != "" {
+				t.Errorf("d.Default(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook serves admission webhooks that validate and default
+// resource claims, resource classes, and managed resources.
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/pkg/errors"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/logging"
+	awsv1alpha1 "github.com/crossplaneio/crossplane/aws/apis/v1alpha1"
+	azurev1alpha1 "github.com/crossplaneio/crossplane/azure/apis/v1alpha1"
+	gcpv1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/v1alpha1"
+)
+
+// Paths at which the webhook server serves admission requests.
+const (
+	ValidatePath = "/validate"
+	MutatePath   = "/mutate"
+)
+
+// Error strings.
+const (
+	errDecodeObject    = "cannot decode object"
+	errDecodeOldObject = "cannot decode old object"
+	errEncodeObject    = "cannot encode object"
+	errDefaultObject   = "cannot default object"
+)
+
+var log = logging.Logger.WithName("webhook")
+
+// A Validator validates an object submitted for admission. The old object is
+// nil unless the object is being updated.
+type Validator interface {
+	Validate(ctx context.Context, obj, old *unstructured.Unstructured) error
+}
+
+// A ValidatorFn is a function that satisfies the Validator interface.
+type ValidatorFn func(ctx context.Context, obj, old *unstructured.Unstructured) error
+
+// Validate the supplied object.
+func (fn ValidatorFn) Validate(ctx context.Context, obj, old *unstructured.Unstructured) error {
+	return fn(ctx, obj, old)
+}
+
+// A Defaulter sets the default values of an object submitted for admission.
+type Defaulter interface {
+	Default(ctx context.Context, obj *unstructured.Unstructured) error
+}
+
+// A DefaulterFn is a function that satisfies the Defaulter interface.
+type DefaulterFn func(ctx context.Context, obj *unstructured.Unstructured) error
+
+// Default the supplied object.
+func (fn DefaulterFn) Default(ctx context.Context, obj *unstructured.Unstructured) error {
+	return fn(ctx, obj)
+}
+
+// A ValidatingHandler admits an object only if all of the validators
+// registered for its kind accept it. Objects of kinds without registered
+// validators are always admitted.
+type ValidatingHandler struct {
+	validators map[schema.GroupVersionKind][]Validator
+}
+
+// NewValidatingHandler returns a ValidatingHandler with no validators.
+func NewValidatingHandler() *ValidatingHandler {
+	return &ValidatingHandler{validators: map[schema.GroupVersionKind][]Validator{}}
+}
+
+// Register the supplied validators for the supplied kind.
+func (h *ValidatingHandler) Register(k schema.GroupVersionKind, v ...Validator) {
+	h.validators[k] = append(h.validators[k], v...)
+}
+
+// Handle an admission request.
+func (h *ValidatingHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	vs := h.validators[gvk(req.Kind)]
+	if len(vs) == 0 || req.Operation == admissionv1beta1.Delete {
+		return admission.Allowed("")
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(req.Object.Raw); err != nil {
+		return admission.Errored(http.StatusBadRequest, errors.Wrap(err, errDecodeObject))
+	}
+
+	var old *unstructured.Unstructured
+	if req.Operation == admissionv1beta1.Update {
+		old = &unstructured.Unstructured{}
+		if err := old.UnmarshalJSON(req.OldObject.Raw); err != nil {
+			return admission.Errored(http.StatusBadRequest, errors.Wrap(err, errDecodeOldObject))
+		}
+	}
+
+	for _, v := range vs {
+		if err := v.Validate(ctx, obj, old); err != nil {
+			log.V(logging.Debug).Info("denied admission", "kind", req.Kind, "namespace", req.Namespace, "name", req.Name, "reason", err.Error())
+			return admission.Denied(err.Error())
+		}
+	}
+
+	return admission.Allowed("")
+}
+
+// A DefaultingHandler patches an object with the default values set by each
+// of the defaulters registered for its kind.
+type DefaultingHandler struct {
+	defaulters map[schema.GroupVersionKind][]Defaulter
+}
+
+// NewDefaultingHandler returns a DefaultingHandler with no defaulters.
+func NewDefaultingHandler() *DefaultingHandler {
+	return &DefaultingHandler{defaulters: map[schema.GroupVersionKind][]Defaulter{}}
+}
+
+// Register the supplied defaulters for the supplied kind.
+func (h *DefaultingHandler) Register(k schema.GroupVersionKind, d ...Defaulter) {
+	h.defaulters[k] = append(h.defaulters[k], d...)
+}
+
+// Handle an admission request.
+func (h *DefaultingHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	ds := h.defaulters[gvk(req.Kind)]
+	if len(ds) == 0 || req.Operation == admissionv1beta1.Delete {
+		return admission.Allowed("")
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(req.Object.Raw); err != nil {
+		return admission.Errored(http.StatusBadRequest, errors.Wrap(err, errDecodeObject))
+	}
+
+	for _, d := range ds {
+		if err := d.Default(ctx, obj); err != nil {
+			return admission.Errored(http.StatusInternalServerError, errors.Wrap(err, errDefaultObject))
+		}
+	}
+
+	defaulted, err := json.Marshal(obj.Object)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, errors.Wrap(err, errEncodeObject))
+	}
+
+	return admission.PatchResponseFromRaw(req.Object.Raw, defaulted)
+}
+
+// Webhooks is responsible for adding Crossplane's admission webhooks to the
+// manager's webhook server.
+type Webhooks struct{}
+
+// SetupWithManager registers the validating and defaulting webhooks for
+// resource claims and for the resource classes and managed resources of each
+// enabled cloud provider.
+func (w *Webhooks) SetupWithManager(mgr ctrl.Manager) error {
+	v := NewValidatingHandler()
+	d := NewDefaultingHandler()
+	register(mgr, v, d)
+
+	srv := mgr.GetWebhookServer()
+	srv.Register(ValidatePath, &webhook.Admission{Handler: v})
+	srv.Register(MutatePath, &webhook.Admission{Handler: d})
+
+	return nil
+}
+
+// register the validators and defaulters of resource claims, and of each cloud
+// provider whose provider kind is registered with the manager's scheme. Cloud
+// providers that are not enabled are not registered, and their CRDs may not be
+// installed.
+func register(mgr ctrl.Manager, v *ValidatingHandler, d *DefaultingHandler) {
+	registerClaims(mgr, v, d)
+
+	providers := []struct {
+		kind     schema.GroupVersionKind
+		register func(ctrl.Manager, *ValidatingHandler, *DefaultingHandler)
+	}{
+		{kind: awsv1alpha1.ProviderGroupVersionKind, register: registerAWS},
+		{kind: azurev1alpha1.ProviderGroupVersionKind, register: registerAzure},
+		{kind: gcpv1alpha1.ProviderGroupVersionKind, register: registerGCP},
+	}
+
+	for _, p := range providers {
+		if !mgr.GetScheme().Recognizes(p.kind) {
+			continue
+		}
+		p.register(mgr, v, d)
+	}
+}
+
+func gvk(k metav1.GroupVersionKind) schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: k.Group, Version: k.Version, Kind: k.Kind}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	databasev1alpha1 "github.com/crossplaneio/crossplane/apis/database/v1alpha1"
+	awsapis "github.com/crossplaneio/crossplane/aws/apis"
+	awsdatabasev1alpha1 "github.com/crossplaneio/crossplane/aws/apis/database/v1alpha1"
+	azuredatabasev1alpha1 "github.com/crossplaneio/crossplane/azure/apis/database/v1alpha1"
+	gcpdatabasev1alpha1 "github.com/crossplaneio/crossplane/gcp/apis/database/v1alpha1"
+)
+
+var kind = schema.GroupVersionKind{Group: "example.crossplane.io", Version: "v1alpha1", Kind: "Example"}
+
+func request(op admissionv1beta1.Operation, obj, old string) admission.Request {
+	return admission.Request{AdmissionRequest: admissionv1beta1.AdmissionRequest{
+		Kind:      metav1.GroupVersionKind{Group: kind.Group, Version: kind.Version, Kind: kind.Kind},
+		Operation: op,
+		Object:    runtime.RawExtension{Raw: []byte(obj)},
+		OldObject: runtime.RawExtension{Raw: []byte(old)},
+	}}
+}
+
+func TestValidatingHandler(t *testing.T) {
+	deny := ValidatorFn(func(_ context.Context, _, _ *unstructured.Unstructured) error { return errBoom })
+	allow := ValidatorFn(func(_ context.Context, _, _ *unstructured.Unstructured) error { return nil })
+	requireOld := ValidatorFn(func(_ context.Context, _, old *unstructured.Unstructured) error {
+		if old == nil {
+			return errBoom
+		}
+		return nil
+	})
+
+	cases := map[string]struct {
+		validators []Validator
+		req        admission.Request
+		want       bool
+	}{
+		"NoValidators": {
+			req:  request(admissionv1beta1.Create, `{}`, ``),
+			want: true,
+		},
+		"Allowed": {
+			validators: []Validator{allow},
+			req:        request(admissionv1beta1.Create, `{}`, ``),
+			want:       true,
+		},
+		"Denied": {
+			validators: []Validator{allow, deny},
+			req:        request(admissionv1beta1.Create, `{}`, ``),
+			want:       false,
+		},
+		"DeleteIsAlwaysAllowed": {
+			validators: []Validator{deny},
+			req:        request(admissionv1beta1.Delete, `{}`, ``),
+			want:       true,
+		},
+		"UpdatePassesOldObject": {
+			validators: []Validator{requireOld},
+			req:        request(admissionv1beta1.Update, `{}`, `{}`),
+			want:       true,
+		},
+		"MalformedObject": {
+			validators: []Validator{allow},
+			req:        request(admissionv1beta1.Create, `{`, ``),
+			want:       false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			h := NewValidatingHandler()
+			h.Register(kind, tc.validators...)
+			got := h.Handle(context.Background(), tc.req)
+			if diff := cmp.Diff(tc.want, got.Allowed); diff != "" {
+				t.Errorf("h.Handle(...): -want allowed, +got allowed:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDefaultingHandler(t *testing.T) {
+	cases := map[string]struct {
+		defaulters []Defaulter
+		req        admission.Request
+		wantPatch  int
+	}{
+		"NoDefaulters": {
+			req: request(admissionv1beta1.Create, `{"spec":{}}`, ``),
+		},
+		"AlreadyDefaulted": {
+			defaulters: []Defaulter{NewFieldDefaulter("spec.reclaimPolicy", "Retain")},
+			req:        request(admissionv1beta1.Create, `{"spec":{"reclaimPolicy":"Delete"}}`, ``),
+		},
+		"Defaulted": {
+			defaulters: []Defaulter{NewFieldDefaulter("spec.reclaimPolicy", "Retain")},
+			req:        request(admissionv1beta1.Create, `{"spec":{}}`, ``),
+			wantPatch:  1,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			h := NewDefaultingHandler()
+			h.Register(kind, tc.defaulters...)
+			got := h.Handle(context.Background(), tc.req)
+			if !got.Allowed {
+				t.Errorf("h.Handle(...): want allowed, got denied")
+			}
+			if diff := cmp.Diff(tc.wantPatch, len(got.Patches)); diff != "" {
+				t.Errorf("h.Handle(...): -want patches, +got patches:\n%s", diff)
+			}
+		})
+	}
+}
+
+// A manager that provides only a client and a scheme.
+type manager struct {
+	ctrl.Manager
+	scheme *runtime.Scheme
+}
+
+func (m *manager) GetClient() client.Client   { return &test.MockClient{} }
+func (m *manager) GetScheme() *runtime.Scheme { return m.scheme }
+
+func TestRegister(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := awsapis.AddToScheme(s); err != nil {
+		t.Fatalf("awsapis.AddToScheme(...): %s", err)
+	}
+
+	v := NewValidatingHandler()
+	d := NewDefaultingHandler()
+	register(&manager{scheme: s}, v, d)
+
+	cases := map[string]struct {
+		kind schema.GroupVersionKind
+		want bool
+	}{
+		"Claim":            {kind: databasev1alpha1.MySQLInstanceGroupVersionKind, want: true},
+		"EnabledAWS":       {kind: awsdatabasev1alpha1.RDSInstanceGroupVersionKind, want: true},
+		"DisabledAzure":    {kind: azuredatabasev1alpha1.MysqlServerGroupVersionKind, want: false},
+		"DisabledGCP":      {kind: gcpdatabasev1alpha1.CloudsqlInstanceGroupVersionKind, want: false},
+		"DisabledGCPClass": {kind: gcpdatabasev1alpha1.CloudsqlInstanceClassGroupVersionKind, want: false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := len(v.validators[tc.kind]) > 0; got != tc.want {
+				t.Errorf("register(...): want validators registered for %s: %t, got %t", tc.kind, tc.want, got)
+			}
+		})
+	}
+}